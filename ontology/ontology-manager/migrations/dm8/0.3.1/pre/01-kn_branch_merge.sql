@@ -0,0 +1,15 @@
+-- Copyright The kweaver.ai Authors.
+--
+-- Licensed under the Apache License, Version 2.0.
+-- See the LICENSE file in the project root for details.
+
+SET SCHEMA adp;
+
+CREATE TABLE IF NOT EXISTS t_kn_branch_merge (
+  f_kn_id VARCHAR(40 CHAR) NOT NULL DEFAULT '',
+  f_branch VARCHAR(40 CHAR) NOT NULL DEFAULT '',
+  f_target_branch VARCHAR(40 CHAR) NOT NULL DEFAULT '',
+  f_merge_time BIGINT NOT NULL DEFAULT 0,
+  f_base TEXT DEFAULT NULL,
+  CLUSTER PRIMARY KEY (f_kn_id,f_branch,f_target_branch)
+);
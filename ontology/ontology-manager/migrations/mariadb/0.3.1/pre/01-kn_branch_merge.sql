@@ -0,0 +1,16 @@
+-- Copyright The kweaver.ai Authors.
+--
+-- Licensed under the Apache License, Version 2.0.
+-- See the LICENSE file in the project root for details.
+
+USE adp;
+
+-- 分支合并基点，记录源分支最近一次合入目标分支的时间及当时源分支上的概念
+CREATE TABLE IF NOT EXISTS t_kn_branch_merge (
+  f_kn_id VARCHAR(40) NOT NULL DEFAULT '' COMMENT '业务知识网络id',
+  f_branch VARCHAR(40) NOT NULL DEFAULT '' COMMENT '源分支',
+  f_target_branch VARCHAR(40) NOT NULL DEFAULT '' COMMENT '目标分支',
+  f_merge_time BIGINT(20) NOT NULL DEFAULT 0 COMMENT '合并时间',
+  f_base MEDIUMTEXT DEFAULT NULL COMMENT '合并时源分支上的概念id和分组关系',
+  PRIMARY KEY (f_kn_id,f_branch,f_target_branch)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin COMMENT = '分支合并基点';
@@ -35,6 +35,7 @@ type ServerSetting struct {
 	DefaultSmallModelEnabled bool          `mapstructure:"defaultSmallModelEnabled"`
 	JobMaxRetryTimes         int           `mapstructure:"jobMaxRetryTimes"`
 	ReloadJobEnabled         bool          `mapstructure:"reloadJobEnabled"`
+	GraphAnalyticsMaxNodes   int           `mapstructure:"graphAnalyticsMaxNodes"` // 单次图分析加载的对象实例上限，default 500000
 	// Schedule worker settings
 	SchedulePollInterval int `mapstructure:"schedulePollInterval"` // in seconds, default 10
 	ScheduleLockTimeout  int `mapstructure:"scheduleLockTimeout"`  // in seconds, default 300 (5 min)
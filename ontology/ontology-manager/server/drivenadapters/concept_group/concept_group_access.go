@@ -982,7 +982,12 @@ func (cga *conceptGroupAccess) ListConceptGroupRelations(ctx context.Context, tx
 	// 记录处理的 sql 字符串
 	o11y.Info(ctx, fmt.Sprintf("查询概念分组信息的 sql 语句: %s.", sqlStr))
 
-	rows, err := tx.Query(sqlStr, vals...)
+	var rows *sql.Rows
+	if tx != nil {
+		rows, err = tx.Query(sqlStr, vals...)
+	} else {
+		rows, err = cga.db.Query(sqlStr, vals...)
+	}
 	if err != nil {
 		logger.Errorf("list data error: %v\n", err)
 		o11y.Error(ctx, fmt.Sprintf("List data error: %v", err))
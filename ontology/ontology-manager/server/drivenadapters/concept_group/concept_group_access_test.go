@@ -863,6 +863,18 @@ func Test_conceptGroupAccess_ListConceptGroupRelations(t *testing.T) {
 			}
 		})
 
+		Convey("ListConceptGroupRelations without tx Success \n", func() {
+			smock.ExpectQuery(sqlStr).WithArgs().WillReturnRows(rows)
+
+			relations, err := cga.ListConceptGroupRelations(testCtx, nil, query)
+			So(err, ShouldBeNil)
+			So(len(relations), ShouldEqual, 2)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
 		Convey("ListConceptGroupRelations Failed \n", func() {
 			smock.ExpectBegin()
 			expectedErr := errors.New("some error")
@@ -29,7 +29,8 @@ import (
 )
 
 const (
-	KN_TABLE_NAME = "t_knowledge_network"
+	KN_TABLE_NAME              = "t_knowledge_network"
+	KN_BRANCH_MERGE_TABLE_NAME = "t_kn_branch_merge"
 )
 
 var (
@@ -445,6 +446,7 @@ func (kna *knowledgeNetworkAccess) UpdateKN(ctx context.Context, tx *sql.Tx, kn
 	sqlStr, vals, err := sq.Update(KN_TABLE_NAME).
 		SetMap(data).
 		Where(sq.Eq{"f_id": kn.KNID}).
+		Where(sq.Eq{"f_branch": kn.Branch}).
 		ToSql()
 	if err != nil {
 		logger.Errorf("Failed to build the sql of update knowledge network by knowledge network_id, error: %s", err.Error())
@@ -1002,6 +1004,216 @@ func (kna *knowledgeNetworkAccess) GetAllKNs(ctx context.Context) (map[string]*i
 	return KNs, nil
 }
 
+// 获取业务知识网络的所有分支(包含主线)，按创建时间升序
+func (kna *knowledgeNetworkAccess) ListKNBranches(ctx context.Context, knID string) ([]*interfaces.KN, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Select knowledge network branches", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(
+		attr.Key("db_url").String(libdb.GetDBUrl()),
+		attr.Key("db_type").String(libdb.GetDBType()))
+
+	sqlStr, vals, err := sq.Select(
+		"f_id",
+		"f_name",
+		"f_branch",
+		"f_creator",
+		"f_creator_type",
+		"f_create_time",
+		"f_updater",
+		"f_updater_type",
+		"f_update_time").
+		From(KN_TABLE_NAME).
+		Where(sq.Eq{"f_id": knID}).
+		OrderBy("f_create_time asc").
+		ToSql()
+	if err != nil {
+		logger.Errorf("Failed to build the sql of select knowledge network branches, error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Failed to build the sql of select knowledge network branches, error: %s", err.Error()))
+		span.SetStatus(codes.Error, "Build sql failed ")
+		return []*interfaces.KN{}, err
+	}
+
+	// 记录处理的 sql 字符串
+	o11y.Info(ctx, fmt.Sprintf("查询业务知识网络分支列表的 sql 语句: %s; knID: %s", sqlStr, knID))
+
+	rows, err := kna.db.Query(sqlStr, vals...)
+	if err != nil {
+		logger.Errorf("list data error: %v\n", err)
+		o11y.Error(ctx, fmt.Sprintf("List data error: %v", err))
+		span.SetStatus(codes.Error, "List data error")
+		return []*interfaces.KN{}, err
+	}
+	defer rows.Close()
+
+	kns := make([]*interfaces.KN, 0)
+	for rows.Next() {
+		kn := interfaces.KN{
+			ModuleType: interfaces.MODULE_TYPE_KN,
+		}
+		err := rows.Scan(
+			&kn.KNID,
+			&kn.KNName,
+			&kn.Branch,
+			&kn.Creator.ID,
+			&kn.Creator.Type,
+			&kn.CreateTime,
+			&kn.Updater.ID,
+			&kn.Updater.Type,
+			&kn.UpdateTime,
+		)
+		if err != nil {
+			logger.Errorf("row scan failed, err: %v \n", err)
+			o11y.Error(ctx, fmt.Sprintf("Row scan error: %v", err))
+			span.SetStatus(codes.Error, "Row scan error")
+			return []*interfaces.KN{}, err
+		}
+
+		kns = append(kns, &kn)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return kns, nil
+}
+
+// 获取源分支合入目标分支的合并基点，未合并过时返回 nil
+func (kna *knowledgeNetworkAccess) GetKNBranchMergeBase(ctx context.Context, knID string,
+	branch string, targetBranch string) (*interfaces.KNBranchMergeBase, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Select knowledge network branch merge base", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(
+		attr.Key("db_url").String(libdb.GetDBUrl()),
+		attr.Key("db_type").String(libdb.GetDBType()))
+
+	sqlStr, vals, err := sq.Select(
+		"f_merge_time",
+		"f_base").
+		From(KN_BRANCH_MERGE_TABLE_NAME).
+		Where(sq.Eq{"f_kn_id": knID}).
+		Where(sq.Eq{"f_branch": branch}).
+		Where(sq.Eq{"f_target_branch": targetBranch}).
+		ToSql()
+	if err != nil {
+		logger.Errorf("Failed to build the sql of select knowledge network branch merge base, error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Failed to build the sql of select knowledge network branch merge base, error: %s", err.Error()))
+		span.SetStatus(codes.Error, "Build sql failed ")
+		return nil, err
+	}
+
+	// 记录处理的 sql 字符串
+	o11y.Info(ctx, fmt.Sprintf("查询分支合并基点的 sql 语句: %s; knID: %s", sqlStr, knID))
+
+	base := &interfaces.KNBranchMergeBase{
+		KNID:         knID,
+		Branch:       branch,
+		TargetBranch: targetBranch,
+	}
+	baseBytes := []byte{}
+	err = kna.db.QueryRow(sqlStr, vals...).Scan(
+		&base.MergeTime,
+		&baseBytes,
+	)
+	if err == sql.ErrNoRows {
+		span.SetAttributes(attr.Key("no_rows").Bool(true))
+		span.SetStatus(codes.Ok, "")
+		return nil, nil
+	} else if err != nil {
+		logger.Errorf("Get knowledge network branch merge base error: %v\n", err)
+		o11y.Error(ctx, fmt.Sprintf("Get knowledge network branch merge base error: %v", err))
+		span.SetStatus(codes.Error, "Get knowledge network branch merge base error")
+		return nil, err
+	}
+
+	err = sonic.Unmarshal(baseBytes, base)
+	if err != nil {
+		logger.Errorf("Failed to unmarshal branch merge base after getting, err: %v", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Failed to unmarshal branch merge base after getting, err: %v", err.Error()))
+		span.SetStatus(codes.Error, "Unmarshal branch merge base error")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return base, nil
+}
+
+// 记录源分支合入目标分支的合并基点，覆盖上一次的记录
+func (kna *knowledgeNetworkAccess) UpdateKNBranchMergeBase(ctx context.Context, tx *sql.Tx,
+	base *interfaces.KNBranchMergeBase) error {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Update knowledge network branch merge base", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(
+		attr.Key("db_url").String(libdb.GetDBUrl()),
+		attr.Key("db_type").String(libdb.GetDBType()))
+
+	baseBytes, err := sonic.Marshal(base)
+	if err != nil {
+		logger.Errorf("Failed to marshal branch merge base, err: %v", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Failed to marshal branch merge base, err: %v", err.Error()))
+		span.SetStatus(codes.Error, "Marshal branch merge base error")
+		return err
+	}
+
+	delStr, delVals, err := sq.Delete(KN_BRANCH_MERGE_TABLE_NAME).
+		Where(sq.Eq{"f_kn_id": base.KNID}).
+		Where(sq.Eq{"f_branch": base.Branch}).
+		Where(sq.Eq{"f_target_branch": base.TargetBranch}).
+		ToSql()
+	if err != nil {
+		logger.Errorf("Failed to build the sql of delete knowledge network branch merge base, error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Failed to build the sql of delete knowledge network branch merge base, error: %s", err.Error()))
+		span.SetStatus(codes.Error, "Build sql failed ")
+		return err
+	}
+
+	insStr, insVals, err := sq.Insert(KN_BRANCH_MERGE_TABLE_NAME).
+		Columns(
+			"f_kn_id",
+			"f_branch",
+			"f_target_branch",
+			"f_merge_time",
+			"f_base",
+		).
+		Values(
+			base.KNID,
+			base.Branch,
+			base.TargetBranch,
+			base.MergeTime,
+			baseBytes).
+		ToSql()
+	if err != nil {
+		logger.Errorf("Failed to build the sql of insert knowledge network branch merge base, error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Failed to build the sql of insert knowledge network branch merge base, error: %s", err.Error()))
+		span.SetStatus(codes.Error, "Build sql failed ")
+		return err
+	}
+
+	// 记录处理的 sql 字符串
+	o11y.Info(ctx, fmt.Sprintf("更新分支合并基点的 sql 语句: %s; %s", delStr, insStr))
+
+	_, err = tx.Exec(delStr, delVals...)
+	if err != nil {
+		logger.Errorf("delete data error: %v\n", err)
+		o11y.Error(ctx, fmt.Sprintf("Delete data error: %v ", err))
+		span.SetStatus(codes.Error, "Delete data error")
+		return err
+	}
+
+	_, err = tx.Exec(insStr, insVals...)
+	if err != nil {
+		logger.Errorf("insert data error: %v\n", err)
+		o11y.Error(ctx, fmt.Sprintf("Insert data error: %v ", err))
+		span.SetStatus(codes.Error, "Insert data error")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
 func (kna *knowledgeNetworkAccess) ListKnSrcs(ctx context.Context,
 	query interfaces.KNsQueryParams) ([]interfaces.Resource, error) {
 	ctx, span := ar_trace.Tracer.Start(ctx, "Select knowledge networks", trace.WithSpanKind(trace.SpanKindClient))
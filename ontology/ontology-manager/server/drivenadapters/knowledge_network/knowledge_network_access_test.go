@@ -472,11 +472,12 @@ func Test_knowledgeNetworkAccess_UpdateKN(t *testing.T) {
 		kna, smock := MockNewKNAccess(appSetting)
 
 		sqlStr := fmt.Sprintf("UPDATE %s SET f_color = ?, f_comment = ?, f_icon = ?, f_name = ?, "+
-			"f_tags = ?, f_update_time = ?, f_updater = ?, f_updater_type = ? WHERE f_id = ?", KN_TABLE_NAME)
+			"f_tags = ?, f_update_time = ?, f_updater = ?, f_updater_type = ? WHERE f_id = ? AND f_branch = ?", KN_TABLE_NAME)
 
 		kn := &interfaces.KN{
 			KNID:    "kn1",
 			KNName:  "Updated Knowledge Network",
+			Branch:  interfaces.MAIN_BRANCH,
 			Tags:    testTags,
 			Comment: "updated comment",
 			Icon:    "icon1",
@@ -745,6 +746,207 @@ func Test_knowledgeNetworkAccess_GetAllKNs(t *testing.T) {
 	})
 }
 
+func Test_knowledgeNetworkAccess_ListKNBranches(t *testing.T) {
+	Convey("test ListKNBranches\n", t, func() {
+		appSetting := &common.AppSetting{}
+		kna, smock := MockNewKNAccess(appSetting)
+
+		sqlStr := fmt.Sprintf("SELECT f_id, f_name, f_branch, f_creator, f_creator_type, f_create_time, "+
+			"f_updater, f_updater_type, f_update_time FROM %s WHERE f_id = ? ORDER BY f_create_time asc", KN_TABLE_NAME)
+
+		Convey("ListKNBranches Success \n", func() {
+			rows := sqlmock.NewRows([]string{
+				"f_id", "f_name", "f_branch", "f_creator", "f_creator_type", "f_create_time",
+				"f_updater", "f_updater_type", "f_update_time",
+			}).AddRow(
+				"kn1", "Knowledge Network 1", "main", "admin", "admin", testUpdateTime,
+				"admin", "admin", testUpdateTime,
+			).AddRow(
+				"kn1", "Knowledge Network 1", "dev", "admin", "admin", testUpdateTime+1,
+				"admin", "admin", testUpdateTime+1,
+			)
+			smock.ExpectQuery(sqlStr).WithArgs("kn1").WillReturnRows(rows)
+
+			kns, err := kna.ListKNBranches(testCtx, "kn1")
+			So(err, ShouldBeNil)
+			So(len(kns), ShouldEqual, 2)
+			So(kns[0].Branch, ShouldEqual, interfaces.MAIN_BRANCH)
+			So(kns[1].Branch, ShouldEqual, "dev")
+			So(kns[1].CreateTime, ShouldEqual, testUpdateTime+1)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("ListKNBranches Failed \n", func() {
+			expectedErr := errors.New("some error")
+			smock.ExpectQuery(sqlStr).WithArgs("kn1").WillReturnError(expectedErr)
+
+			kns, err := kna.ListKNBranches(testCtx, "kn1")
+			So(kns, ShouldResemble, []*interfaces.KN{})
+			So(err, ShouldResemble, expectedErr)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("ListKNBranches Scan error \n", func() {
+			rows := sqlmock.NewRows([]string{
+				"f_id", "f_name", "f_branch", "f_creator", "f_creator_type", "f_create_time",
+				"f_updater", "f_updater_type", "f_update_time",
+			}).AddRow(
+				"kn1", "Knowledge Network 1", "main", "admin", "admin", "testUpdateTime",
+				"admin", "admin", testUpdateTime,
+			)
+			smock.ExpectQuery(sqlStr).WithArgs("kn1").WillReturnRows(rows)
+
+			kns, err := kna.ListKNBranches(testCtx, "kn1")
+			So(kns, ShouldResemble, []*interfaces.KN{})
+			So(err, ShouldNotBeNil)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	})
+}
+
+func Test_knowledgeNetworkAccess_GetKNBranchMergeBase(t *testing.T) {
+	Convey("test GetKNBranchMergeBase\n", t, func() {
+		appSetting := &common.AppSetting{}
+		kna, smock := MockNewKNAccess(appSetting)
+
+		sqlStr := fmt.Sprintf("SELECT f_merge_time, f_base FROM %s "+
+			"WHERE f_kn_id = ? AND f_branch = ? AND f_target_branch = ?", KN_BRANCH_MERGE_TABLE_NAME)
+
+		Convey("GetKNBranchMergeBase Success \n", func() {
+			rows := sqlmock.NewRows([]string{"f_merge_time", "f_base"}).AddRow(
+				testUpdateTime, `{"concepts":{"object_type":["ot1"]},"relations":["cg1/object_type/ot1"]}`)
+			smock.ExpectQuery(sqlStr).WithArgs("kn1", "dev", interfaces.MAIN_BRANCH).WillReturnRows(rows)
+
+			base, err := kna.GetKNBranchMergeBase(testCtx, "kn1", "dev", interfaces.MAIN_BRANCH)
+			So(err, ShouldBeNil)
+			So(base, ShouldResemble, &interfaces.KNBranchMergeBase{
+				KNID:         "kn1",
+				Branch:       "dev",
+				TargetBranch: interfaces.MAIN_BRANCH,
+				MergeTime:    testUpdateTime,
+				Concepts:     map[string][]string{interfaces.MODULE_TYPE_OBJECT_TYPE: {"ot1"}},
+				Relations:    []string{"cg1/object_type/ot1"},
+			})
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("GetKNBranchMergeBase not merged yet \n", func() {
+			smock.ExpectQuery(sqlStr).WithArgs("kn1", "dev", interfaces.MAIN_BRANCH).WillReturnError(sql.ErrNoRows)
+
+			base, err := kna.GetKNBranchMergeBase(testCtx, "kn1", "dev", interfaces.MAIN_BRANCH)
+			So(err, ShouldBeNil)
+			So(base, ShouldBeNil)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("GetKNBranchMergeBase Failed \n", func() {
+			expectedErr := errors.New("some error")
+			smock.ExpectQuery(sqlStr).WithArgs("kn1", "dev", interfaces.MAIN_BRANCH).WillReturnError(expectedErr)
+
+			base, err := kna.GetKNBranchMergeBase(testCtx, "kn1", "dev", interfaces.MAIN_BRANCH)
+			So(base, ShouldBeNil)
+			So(err, ShouldResemble, expectedErr)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("GetKNBranchMergeBase Unmarshal error \n", func() {
+			rows := sqlmock.NewRows([]string{"f_merge_time", "f_base"}).AddRow(testUpdateTime, "{")
+			smock.ExpectQuery(sqlStr).WithArgs("kn1", "dev", interfaces.MAIN_BRANCH).WillReturnRows(rows)
+
+			base, err := kna.GetKNBranchMergeBase(testCtx, "kn1", "dev", interfaces.MAIN_BRANCH)
+			So(base, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	})
+}
+
+func Test_knowledgeNetworkAccess_UpdateKNBranchMergeBase(t *testing.T) {
+	Convey("Test UpdateKNBranchMergeBase\n", t, func() {
+		appSetting := &common.AppSetting{}
+		kna, smock := MockNewKNAccess(appSetting)
+
+		delStr := fmt.Sprintf("DELETE FROM %s WHERE f_kn_id = ? AND f_branch = ? AND f_target_branch = ?",
+			KN_BRANCH_MERGE_TABLE_NAME)
+		insStr := fmt.Sprintf("INSERT INTO %s (f_kn_id,f_branch,f_target_branch,f_merge_time,f_base) "+
+			"VALUES (?,?,?,?,?)", KN_BRANCH_MERGE_TABLE_NAME)
+
+		base := &interfaces.KNBranchMergeBase{
+			KNID:         "kn1",
+			Branch:       "dev",
+			TargetBranch: interfaces.MAIN_BRANCH,
+			MergeTime:    testUpdateTime,
+			Concepts:     map[string][]string{interfaces.MODULE_TYPE_OBJECT_TYPE: {"ot1"}},
+			Relations:    []string{},
+		}
+
+		Convey("UpdateKNBranchMergeBase Success \n", func() {
+			smock.ExpectBegin()
+			smock.ExpectExec(delStr).WithArgs("kn1", "dev", interfaces.MAIN_BRANCH).WillReturnResult(sqlmock.NewResult(0, 1))
+			smock.ExpectExec(insStr).WithArgs("kn1", "dev", interfaces.MAIN_BRANCH, testUpdateTime,
+				[]byte(`{"concepts":{"object_type":["ot1"]},"relations":[]}`)).WillReturnResult(sqlmock.NewResult(1, 1))
+
+			tx, _ := kna.db.Begin()
+			err := kna.UpdateKNBranchMergeBase(testCtx, tx, base)
+			So(err, ShouldBeNil)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("UpdateKNBranchMergeBase delete failed \n", func() {
+			smock.ExpectBegin()
+			expectedErr := errors.New("delete error")
+			smock.ExpectExec(delStr).WillReturnError(expectedErr)
+
+			tx, _ := kna.db.Begin()
+			err := kna.UpdateKNBranchMergeBase(testCtx, tx, base)
+			So(err, ShouldResemble, expectedErr)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("UpdateKNBranchMergeBase insert failed \n", func() {
+			smock.ExpectBegin()
+			expectedErr := errors.New("insert error")
+			smock.ExpectExec(delStr).WillReturnResult(sqlmock.NewResult(0, 0))
+			smock.ExpectExec(insStr).WillReturnError(expectedErr)
+
+			tx, _ := kna.db.Begin()
+			err := kna.UpdateKNBranchMergeBase(testCtx, tx, base)
+			So(err, ShouldResemble, expectedErr)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	})
+}
+
 func Test_knowledgeNetworkAccess_ListKnSrcs(t *testing.T) {
 	Convey("test ListKnSrcs\n", t, func() {
 		appSetting := &common.AppSetting{}
@@ -122,6 +122,37 @@ func (o *openSearchAccess) CreateIndex(ctx context.Context, indexName string, bo
 	return nil
 }
 
+// PutMapping 向已存在的索引追加字段映射
+// 已存在字段的类型不能修改，只能新增字段
+func (o *openSearchAccess) PutMapping(ctx context.Context, indexName string, body any) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "PutMapping", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(attr.Key("index_name").String(indexName))
+
+	bodyBytes, err := sonic.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mapping body: %w", err)
+	}
+
+	req := opensearchapi.IndicesPutMappingRequest{
+		Index: []string{indexName},
+		Body:  bytes.NewBuffer(bodyBytes),
+	}
+
+	res, err := req.Do(ctx, o.client)
+	if err != nil {
+		return fmt.Errorf("failed to put mapping of index %s: %w", indexName, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("put mapping of index %s failed: %s, %s", indexName, res.Status(), res.String())
+	}
+
+	return nil
+}
+
 // IndexExists 检查指定索引是否存在
 // 通过发送索引存在性检查请求来确定指定的索引是否已存在于OpenSearch中
 // 参数：
@@ -337,6 +368,80 @@ func (o *openSearchAccess) BulkInsertData(ctx context.Context, indexName string,
 	return nil
 }
 
+// BulkUpdateData 按照文档ID批量局部更新数据，文档ID取自数据中的 __id
+func (o *openSearchAccess) BulkUpdateData(ctx context.Context, indexName string, dataList []any) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "BulkUpdateData", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(attr.Key("index_name").String(indexName))
+
+	if len(dataList) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	for _, data := range dataList {
+		meta := map[string]any{
+			"update": map[string]any{
+				"_index": indexName,
+				"_id":    data.(map[string]any)[interfaces.OBJECT_ID],
+			},
+		}
+
+		metaJSON, err := sonic.Marshal(meta)
+		if err != nil {
+			return fmt.Errorf("failed to marshal bulk metadata: %w", err)
+		}
+		buf.Write(metaJSON)
+		buf.WriteByte('\n')
+
+		// 只更新给出的字段，其余字段保持不变
+		dataJSON, err := sonic.Marshal(map[string]any{"doc": data})
+		if err != nil {
+			return fmt.Errorf("failed to marshal bulk data: %w", err)
+		}
+		buf.Write(dataJSON)
+		buf.WriteByte('\n')
+	}
+
+	req := opensearchapi.BulkRequest{
+		Body:    &buf,
+		Refresh: "true",
+	}
+
+	res, err := req.Do(ctx, o.client)
+	if err != nil {
+		return fmt.Errorf("failed to bulk update data: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("bulk update data failed: %s, %s", res.Status(), res.String())
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var resp struct {
+		Took   int             `json:"took"`
+		Errors bool            `json:"errors"`
+		Items  json.RawMessage `json:"items"`
+	}
+
+	if err := sonic.Unmarshal(resBody, &resp); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if resp.Errors {
+		return fmt.Errorf("bulk update data failed: %s", resp.Items)
+	}
+
+	return nil
+}
+
 // SearchData 搜索指定索引中的数据
 // 根据提供的查询条件在指定索引中执行搜索操作
 // 支持复杂的查询DSL，包括全文搜索、过滤、聚合等
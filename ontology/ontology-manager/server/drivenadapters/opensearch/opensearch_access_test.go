@@ -185,6 +185,63 @@ func Test_openSearchAccess_CreateIndex(t *testing.T) {
 	})
 }
 
+func Test_openSearchAccess_PutMapping(t *testing.T) {
+	Convey("test PutMapping\n", t, func() {
+		appSetting := &common.AppSetting{}
+		osa, _ := MockNewOpenSearchAccess(appSetting, &mockTransport{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				So(req.Method, ShouldEqual, "PUT")
+				So(req.URL.Path, ShouldEqual, "/test-index/_mapping")
+
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader(`{"acknowledged": true}`)),
+				}, nil
+			},
+		})
+
+		mappingBody := map[string]any{
+			"properties": interfaces.KN_INDEX_GRAPH_ANALYTICS_MAPPING,
+		}
+
+		Convey("PutMapping Success \n", func() {
+			err := osa.PutMapping(testCtx, "test-index", mappingBody)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("PutMapping Failed - marshal error\n", func() {
+			invalidBody := make(chan int)
+			err := osa.PutMapping(testCtx, "test-index", invalidBody)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("PutMapping Failed - HTTP error\n", func() {
+			osa2, _ := MockNewOpenSearchAccess(appSetting, &mockTransport{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("network error")
+				},
+			})
+
+			err := osa2.PutMapping(testCtx, "test-index", mappingBody)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("PutMapping Failed - response error\n", func() {
+			osa3, _ := MockNewOpenSearchAccess(appSetting, &mockTransport{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       io.NopCloser(strings.NewReader(`{"error": "illegal_argument_exception"}`)),
+					}, nil
+				},
+			})
+
+			err := osa3.PutMapping(testCtx, "test-index", mappingBody)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
 func Test_openSearchAccess_IndexExists(t *testing.T) {
 	Convey("test IndexExists\n", t, func() {
 		appSetting := &common.AppSetting{}
@@ -448,6 +505,108 @@ func Test_openSearchAccess_BulkInsertData(t *testing.T) {
 	})
 }
 
+func Test_openSearchAccess_BulkUpdateData(t *testing.T) {
+	Convey("test BulkUpdateData\n", t, func() {
+		appSetting := &common.AppSetting{}
+		osa, _ := MockNewOpenSearchAccess(appSetting, &mockTransport{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				So(req.Method, ShouldEqual, "POST")
+				So(req.URL.Path, ShouldEqual, "/_bulk")
+
+				body, _ := io.ReadAll(req.Body)
+				lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+				So(len(lines), ShouldEqual, 4)
+				var meta, doc map[string]any
+				So(json.Unmarshal([]byte(lines[0]), &meta), ShouldBeNil)
+				So(meta, ShouldResemble, map[string]any{
+					"update": map[string]any{"_id": "doc1", "_index": "test-index"},
+				})
+				So(json.Unmarshal([]byte(lines[1]), &doc), ShouldBeNil)
+				So(doc["doc"], ShouldNotBeNil)
+
+				bulkResponse := map[string]any{
+					"took":   10,
+					"errors": false,
+					"items":  []any{},
+				}
+				respBytes, _ := json.Marshal(bulkResponse)
+
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewReader(respBytes)),
+				}, nil
+			},
+		})
+
+		dataList := []any{
+			map[string]any{
+				interfaces.OBJECT_ID:            "doc1",
+				interfaces.GRAPH_PAGERANK_FIELD: 0.5,
+			},
+			map[string]any{
+				interfaces.OBJECT_ID:            "doc2",
+				interfaces.GRAPH_PAGERANK_FIELD: 0.5,
+			},
+		}
+
+		Convey("BulkUpdateData Success \n", func() {
+			err := osa.BulkUpdateData(testCtx, "test-index", dataList)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("BulkUpdateData Success - empty list\n", func() {
+			err := osa.BulkUpdateData(testCtx, "test-index", []any{})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("BulkUpdateData Failed - HTTP error\n", func() {
+			osa2, _ := MockNewOpenSearchAccess(appSetting, &mockTransport{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("network error")
+				},
+			})
+
+			err := osa2.BulkUpdateData(testCtx, "test-index", dataList)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("BulkUpdateData Failed - response error\n", func() {
+			osa3, _ := MockNewOpenSearchAccess(appSetting, &mockTransport{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       io.NopCloser(strings.NewReader(`{"error": "bad request"}`)),
+					}, nil
+				},
+			})
+
+			err := osa3.BulkUpdateData(testCtx, "test-index", dataList)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("BulkUpdateData Failed - errors in response\n", func() {
+			osa4, _ := MockNewOpenSearchAccess(appSetting, &mockTransport{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					bulkResponse := map[string]any{
+						"took":   10,
+						"errors": true,
+						"items":  []any{map[string]any{"error": "document_missing_exception"}},
+					}
+					respBytes, _ := json.Marshal(bulkResponse)
+
+					return &http.Response{
+						StatusCode: 200,
+						Body:       io.NopCloser(bytes.NewReader(respBytes)),
+					}, nil
+				},
+			})
+
+			err := osa4.BulkUpdateData(testCtx, "test-index", dataList)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
 func Test_openSearchAccess_SearchData(t *testing.T) {
 	Convey("test SearchData\n", t, func() {
 		appSetting := &common.AppSetting{}
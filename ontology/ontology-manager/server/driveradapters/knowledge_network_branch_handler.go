@@ -0,0 +1,435 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package driveradapters
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/audit"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	attr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	oerrors "ontology-manager/errors"
+	"ontology-manager/interfaces"
+)
+
+// 创建业务知识网络分支(内部)
+func (r *restHandler) CreateKNBranchByIn(c *gin.Context) {
+	logger.Debug("Handler CreateKNBranchByIn Start")
+	// 内部接口 user_id从header中取
+	visitor := GenerateVisitor(c)
+	r.CreateKNBranch(c, visitor)
+}
+
+// 创建业务知识网络分支（外部）
+func (r *restHandler) CreateKNBranchByEx(c *gin.Context) {
+	logger.Debug("Handler CreateKNBranchByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"创建业务知识网络分支", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+	r.CreateKNBranch(c, visitor)
+}
+
+// 从主线创建业务知识网络分支
+func (r *restHandler) CreateKNBranch(c *gin.Context, visitor rest.Visitor) {
+	logger.Debug("Handler CreateKNBranch Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"创建业务知识网络分支", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	// 设置 trace 的相关 api 的属性
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	knID := c.Param("kn_id")
+	span.SetAttributes(attr.Key("kn_id").String(knID))
+
+	//接收绑定参数
+	branch := interfaces.KNBranch{}
+	err := c.ShouldBindJSON(&branch)
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter).
+			WithErrorDetails("Binding Paramter Failed:" + err.Error())
+
+		// 记录异常日志
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description, httpErr.BaseError.ErrorDetails))
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+	span.SetAttributes(attr.Key("branch").String(branch.Name))
+
+	// 记录接口调用参数： c.Request.RequestURI, body
+	o11y.Info(ctx, fmt.Sprintf("创建业务知识网络分支请求参数: [%s, %v]", c.Request.RequestURI, branch))
+
+	err = ValidateKNBranch(ctx, branch.Name)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	err = r.kns.CreateKNBranch(ctx, knID, branch.Name)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	audit.NewInfoLog(audit.OPERATION, audit.CREATE, audit.TransforOperator(visitor),
+		interfaces.GenerateKNAuditObject(knID, branch.Name), "")
+
+	logger.Debug("Handler CreateKNBranch Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusCreated)
+	rest.ReplyOK(c, http.StatusCreated, map[string]any{"name": branch.Name})
+}
+
+// 获取业务知识网络分支列表(内部)
+func (r *restHandler) ListKNBranchesByIn(c *gin.Context) {
+	logger.Debug("Handler ListKNBranchesByIn Start")
+	// 内部接口 user_id从header中取，跳过用户有效认证，后面在权限校验时就会校验这个用户是否有权限，无效用户无权限
+	visitor := GenerateVisitor(c)
+	r.ListKNBranches(c, visitor)
+}
+
+// 获取业务知识网络分支列表（外部）
+func (r *restHandler) ListKNBranchesByEx(c *gin.Context) {
+	logger.Debug("Handler ListKNBranchesByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"获取业务知识网络分支列表", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+	r.ListKNBranches(c, visitor)
+}
+
+// 获取业务知识网络分支列表，包括主线
+func (r *restHandler) ListKNBranches(c *gin.Context, visitor rest.Visitor) {
+	logger.Debug("Handler ListKNBranches Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"获取业务知识网络分支列表", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	// 设置 trace 的相关 api 的属性
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	knID := c.Param("kn_id")
+	span.SetAttributes(attr.Key("kn_id").String(knID))
+
+	branches, err := r.kns.ListKNBranches(ctx, knID)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	result := map[string]any{
+		"entries":     branches,
+		"total_count": len(branches),
+	}
+
+	logger.Debug("Handler ListKNBranches Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, result)
+}
+
+// 删除业务知识网络分支(内部)
+func (r *restHandler) DeleteKNBranchByIn(c *gin.Context) {
+	logger.Debug("Handler DeleteKNBranchByIn Start")
+	// 内部接口 user_id从header中取
+	visitor := GenerateVisitor(c)
+	r.DeleteKNBranch(c, visitor)
+}
+
+// 删除业务知识网络分支（外部）
+func (r *restHandler) DeleteKNBranchByEx(c *gin.Context) {
+	logger.Debug("Handler DeleteKNBranchByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"删除业务知识网络分支", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+	r.DeleteKNBranch(c, visitor)
+}
+
+// 删除业务知识网络分支，主线不能通过此接口删除
+func (r *restHandler) DeleteKNBranch(c *gin.Context, visitor rest.Visitor) {
+	logger.Debug("Handler DeleteKNBranch Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"删除业务知识网络分支", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	// 设置 trace 的相关 api 的属性
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	// 记录接口调用参数： c.Request.RequestURI, body
+	o11y.Info(ctx, fmt.Sprintf("删除业务知识网络分支请求参数: [%s]", c.Request.RequestURI))
+
+	knID := c.Param("kn_id")
+	branch := c.Param("branch")
+	span.SetAttributes(
+		attr.Key("kn_id").String(knID),
+		attr.Key("branch").String(branch),
+	)
+
+	err := ValidateKNBranch(ctx, branch)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	kn, err := r.kns.GetKNByID(ctx, knID, branch, "")
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+	if kn == nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusNotFound,
+			oerrors.OntologyManager_KnowledgeNetwork_BranchNotFound)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	err = r.kns.DeleteKN(ctx, kn)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	// 记录审计日志
+	audit.NewWarnLog(audit.OPERATION, audit.DELETE, audit.TransforOperator(visitor),
+		interfaces.GenerateKNAuditObject(knID, branch), audit.SUCCESS, "")
+
+	logger.Debug("Handler DeleteKNBranch Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusNoContent, nil)
+}
+
+// 比较业务知识网络分支(内部)
+func (r *restHandler) DiffKNBranchByIn(c *gin.Context) {
+	logger.Debug("Handler DiffKNBranchByIn Start")
+	// 内部接口 user_id从header中取，跳过用户有效认证，后面在权限校验时就会校验这个用户是否有权限，无效用户无权限
+	visitor := GenerateVisitor(c)
+	r.DiffKNBranch(c, visitor)
+}
+
+// 比较业务知识网络分支（外部）
+func (r *restHandler) DiffKNBranchByEx(c *gin.Context) {
+	logger.Debug("Handler DiffKNBranchByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"比较业务知识网络分支", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+	r.DiffKNBranch(c, visitor)
+}
+
+// 比较分支与目标分支（默认主线）的概念差异
+func (r *restHandler) DiffKNBranch(c *gin.Context, visitor rest.Visitor) {
+	logger.Debug("Handler DiffKNBranch Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"比较业务知识网络分支", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	// 设置 trace 的相关 api 的属性
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	knID := c.Param("kn_id")
+	branch := c.Param("branch")
+	targetBranch := c.DefaultQuery("target_branch", interfaces.MAIN_BRANCH)
+	span.SetAttributes(
+		attr.Key("kn_id").String(knID),
+		attr.Key("branch").String(branch),
+		attr.Key("target_branch").String(targetBranch),
+	)
+
+	diff, err := r.kns.DiffKNBranch(ctx, knID, branch, targetBranch)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	logger.Debug("Handler DiffKNBranch Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, diff)
+}
+
+// 合并业务知识网络分支(内部)
+func (r *restHandler) MergeKNBranchByIn(c *gin.Context) {
+	logger.Debug("Handler MergeKNBranchByIn Start")
+	// 内部接口 user_id从header中取
+	visitor := GenerateVisitor(c)
+	r.MergeKNBranch(c, visitor)
+}
+
+// 合并业务知识网络分支（外部）
+func (r *restHandler) MergeKNBranchByEx(c *gin.Context) {
+	logger.Debug("Handler MergeKNBranchByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"合并业务知识网络分支", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+	r.MergeKNBranch(c, visitor)
+}
+
+// 将分支合并到目标分支（默认主线），存在冲突时按冲突处理策略处理
+func (r *restHandler) MergeKNBranch(c *gin.Context, visitor rest.Visitor) {
+	logger.Debug("Handler MergeKNBranch Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"合并业务知识网络分支", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	// 设置 trace 的相关 api 的属性
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	knID := c.Param("kn_id")
+	branch := c.Param("branch")
+	span.SetAttributes(
+		attr.Key("kn_id").String(knID),
+		attr.Key("branch").String(branch),
+	)
+
+	//接收绑定参数
+	merge := interfaces.KNBranchMerge{}
+	err := c.ShouldBindJSON(&merge)
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter).
+			WithErrorDetails("Binding Paramter Failed:" + err.Error())
+
+		// 记录异常日志
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description, httpErr.BaseError.ErrorDetails))
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	// 记录接口调用参数： c.Request.RequestURI, body
+	o11y.Info(ctx, fmt.Sprintf("合并业务知识网络分支请求参数: [%s, %v]", c.Request.RequestURI, merge))
+
+	err = ValidateKNBranchMerge(ctx, branch, &merge)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+	span.SetAttributes(attr.Key("target_branch").String(merge.TargetBranch))
+
+	result, err := r.kns.MergeKNBranch(ctx, knID, branch, merge)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	audit.NewInfoLog(audit.OPERATION, audit.UPDATE, audit.TransforOperator(visitor),
+		interfaces.GenerateKNAuditObject(knID, merge.TargetBranch), "")
+
+	logger.Debug("Handler MergeKNBranch Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, result)
+}
@@ -0,0 +1,348 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package driveradapters
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"ontology-manager/common"
+	oerrors "ontology-manager/errors"
+	"ontology-manager/interfaces"
+	dmock "ontology-manager/interfaces/mock"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	rmock "github.com/kweaver-ai/kweaver-go-lib/rest/mock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_KnowledgeNetworkRestHandler_CreateKNBranch(t *testing.T) {
+	Convey("Test KnowledgeNetworkHandler CreateKNBranch\n", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydra := rmock.NewMockHydra(mockCtrl)
+		kns := dmock.NewMockKNService(mockCtrl)
+
+		handler := MockNewKnowledgeNetworkRestHandler(appSetting, hydra, kns)
+		handler.RegisterPublic(engine)
+
+		hydra.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/ontology-manager/v1/knowledge-networks/kn1/branches"
+
+		Convey("Success CreateKNBranch\n", func() {
+			kns.EXPECT().CreateKNBranch(gomock.Any(), "kn1", "dev").Return(nil)
+
+			reqParamByte, _ := sonic.Marshal(map[string]any{"name": "dev"})
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("Failed CreateKNBranch ShouldBind Error\n", func() {
+			reqParamByte, _ := sonic.Marshal([]string{"dev"})
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Failed CreateKNBranch with main branch\n", func() {
+			reqParamByte, _ := sonic.Marshal(map[string]any{"name": interfaces.MAIN_BRANCH})
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("CreateKNBranch failed\n", func() {
+			err := &rest.HTTPError{
+				HTTPCode: http.StatusBadRequest,
+				Language: rest.DefaultLanguage,
+				BaseError: rest.BaseError{
+					ErrorCode: oerrors.OntologyManager_KnowledgeNetwork_BranchExisted,
+				},
+			}
+			kns.EXPECT().CreateKNBranch(gomock.Any(), "kn1", "dev").Return(err)
+
+			reqParamByte, _ := sonic.Marshal(map[string]any{"name": "dev"})
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+func Test_KnowledgeNetworkRestHandler_ListKNBranches(t *testing.T) {
+	Convey("Test KnowledgeNetworkHandler ListKNBranches\n", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydra := rmock.NewMockHydra(mockCtrl)
+		kns := dmock.NewMockKNService(mockCtrl)
+
+		handler := MockNewKnowledgeNetworkRestHandler(appSetting, hydra, kns)
+		handler.RegisterPublic(engine)
+
+		hydra.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/ontology-manager/v1/knowledge-networks/kn1/branches"
+
+		Convey("Success ListKNBranches\n", func() {
+			kns.EXPECT().ListKNBranches(gomock.Any(), "kn1").Return([]*interfaces.KNBranch{
+				{Name: interfaces.MAIN_BRANCH},
+				{Name: "dev"},
+			}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			result := map[string]any{}
+			_ = sonic.Unmarshal(w.Body.Bytes(), &result)
+			So(result["total_count"], ShouldEqual, 2)
+		})
+
+		Convey("ListKNBranches failed\n", func() {
+			err := &rest.HTTPError{
+				HTTPCode: http.StatusNotFound,
+				Language: rest.DefaultLanguage,
+				BaseError: rest.BaseError{
+					ErrorCode: oerrors.OntologyManager_KnowledgeNetwork_NotFound,
+				},
+			}
+			kns.EXPECT().ListKNBranches(gomock.Any(), "kn1").Return(nil, err)
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func Test_KnowledgeNetworkRestHandler_DeleteKNBranch(t *testing.T) {
+	Convey("Test KnowledgeNetworkHandler DeleteKNBranch\n", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydra := rmock.NewMockHydra(mockCtrl)
+		kns := dmock.NewMockKNService(mockCtrl)
+
+		handler := MockNewKnowledgeNetworkRestHandler(appSetting, hydra, kns)
+		handler.RegisterPublic(engine)
+
+		hydra.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/ontology-manager/v1/knowledge-networks/kn1/branches/dev"
+
+		Convey("Success DeleteKNBranch\n", func() {
+			kn := &interfaces.KN{KNID: "kn1", Branch: "dev"}
+			kns.EXPECT().GetKNByID(gomock.Any(), "kn1", "dev", "").Return(kn, nil)
+			kns.EXPECT().DeleteKN(gomock.Any(), kn).Return(nil)
+
+			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusNoContent)
+		})
+
+		Convey("Failed DeleteKNBranch with main branch\n", func() {
+			req := httptest.NewRequest(http.MethodDelete, "/api/ontology-manager/v1/knowledge-networks/kn1/branches/main", nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Failed DeleteKNBranch when branch not found\n", func() {
+			kns.EXPECT().GetKNByID(gomock.Any(), "kn1", "dev", "").Return(nil, nil)
+
+			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func Test_KnowledgeNetworkRestHandler_DiffKNBranch(t *testing.T) {
+	Convey("Test KnowledgeNetworkHandler DiffKNBranch\n", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydra := rmock.NewMockHydra(mockCtrl)
+		kns := dmock.NewMockKNService(mockCtrl)
+
+		handler := MockNewKnowledgeNetworkRestHandler(appSetting, hydra, kns)
+		handler.RegisterPublic(engine)
+
+		hydra.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/ontology-manager/v1/knowledge-networks/kn1/branches/dev/diff"
+
+		Convey("Success DiffKNBranch with default target\n", func() {
+			kns.EXPECT().DiffKNBranch(gomock.Any(), "kn1", "dev", interfaces.MAIN_BRANCH).Return(&interfaces.KNBranchDiff{}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("Success DiffKNBranch with target branch\n", func() {
+			kns.EXPECT().DiffKNBranch(gomock.Any(), "kn1", "dev", "test").Return(&interfaces.KNBranchDiff{}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, url+"?target_branch=test", nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("DiffKNBranch failed\n", func() {
+			err := &rest.HTTPError{
+				HTTPCode: http.StatusNotFound,
+				Language: rest.DefaultLanguage,
+				BaseError: rest.BaseError{
+					ErrorCode: oerrors.OntologyManager_KnowledgeNetwork_BranchNotFound,
+				},
+			}
+			kns.EXPECT().DiffKNBranch(gomock.Any(), "kn1", "dev", interfaces.MAIN_BRANCH).Return(nil, err)
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func Test_KnowledgeNetworkRestHandler_MergeKNBranch(t *testing.T) {
+	Convey("Test KnowledgeNetworkHandler MergeKNBranch\n", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydra := rmock.NewMockHydra(mockCtrl)
+		kns := dmock.NewMockKNService(mockCtrl)
+
+		handler := MockNewKnowledgeNetworkRestHandler(appSetting, hydra, kns)
+		handler.RegisterPublic(engine)
+
+		hydra.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/ontology-manager/v1/knowledge-networks/kn1/branches/dev/merge"
+
+		Convey("Success MergeKNBranch with defaults\n", func() {
+			kns.EXPECT().MergeKNBranch(gomock.Any(), "kn1", "dev", interfaces.KNBranchMerge{
+				TargetBranch:     interfaces.MAIN_BRANCH,
+				ConflictStrategy: interfaces.CONFLICT_STRATEGY_ABORT,
+			}).Return(&interfaces.KNBranchMergeResult{}, nil)
+
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader([]byte("{}")))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("Failed MergeKNBranch with invalid strategy\n", func() {
+			reqParamByte, _ := sonic.Marshal(interfaces.KNBranchMerge{ConflictStrategy: "ours"})
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Failed MergeKNBranch into itself\n", func() {
+			reqParamByte, _ := sonic.Marshal(interfaces.KNBranchMerge{TargetBranch: "dev"})
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("MergeKNBranch conflict\n", func() {
+			err := &rest.HTTPError{
+				HTTPCode: http.StatusConflict,
+				Language: rest.DefaultLanguage,
+				BaseError: rest.BaseError{
+					ErrorCode: oerrors.OntologyManager_KnowledgeNetwork_Conflict_BranchMerge,
+				},
+			}
+			kns.EXPECT().MergeKNBranch(gomock.Any(), "kn1", "dev", gomock.Any()).Return(nil, err)
+
+			reqParamByte, _ := sonic.Marshal(interfaces.KNBranchMerge{ConflictStrategy: interfaces.CONFLICT_STRATEGY_ABORT})
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusConflict)
+		})
+	})
+}
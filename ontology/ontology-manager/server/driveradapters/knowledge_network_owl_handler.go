@@ -0,0 +1,245 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package driveradapters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/audit"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	attr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	oerrors "ontology-manager/errors"
+	"ontology-manager/interfaces"
+)
+
+// 导出业务知识网络为OWL(内部)
+func (r *restHandler) ExportKNToOWLByIn(c *gin.Context) {
+	logger.Debug("Handler ExportKNToOWLByIn Start")
+	// 内部接口 user_id从header中取，跳过用户有效认证，后面在权限校验时就会校验这个用户是否有权限，无效用户无权限
+	visitor := GenerateVisitor(c)
+	r.ExportKNToOWL(c, visitor)
+}
+
+// 导出业务知识网络为OWL（外部）
+func (r *restHandler) ExportKNToOWLByEx(c *gin.Context) {
+	logger.Debug("Handler ExportKNToOWLByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"导出业务知识网络为OWL", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+	r.ExportKNToOWL(c, visitor)
+}
+
+// 导出业务知识网络的 schema 为 Turtle 或 JSON-LD 格式的 OWL 本体
+func (r *restHandler) ExportKNToOWL(c *gin.Context, visitor rest.Visitor) {
+	logger.Debug("Handler ExportKNToOWL Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"导出业务知识网络为OWL", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	// 设置 trace 的相关 api 的属性
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	knID := c.Param("kn_id")
+	branch := c.DefaultQuery("branch", interfaces.MAIN_BRANCH)
+	format := c.DefaultQuery(interfaces.QueryParam_Format, interfaces.OWL_FORMAT_TURTLE)
+	span.SetAttributes(
+		attr.Key("kn_id").String(knID),
+		attr.Key("branch").String(branch),
+		attr.Key(interfaces.QueryParam_Format).String(format),
+	)
+
+	err := ValidateOWLFormat(ctx, format)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	data, err := r.kns.ExportKNToOWL(ctx, knID, branch, format)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	logger.Debug("Handler ExportKNToOWL Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	c.Data(http.StatusOK, interfaces.OWL_FORMAT_CONTENT_TYPE[format], data)
+}
+
+// 从OWL导入业务知识网络(内部)
+func (r *restHandler) ImportKNFromOWLByIn(c *gin.Context) {
+	logger.Debug("Handler ImportKNFromOWLByIn Start")
+	// 内部接口 user_id从header中取
+	visitor := GenerateVisitor(c)
+	r.ImportKNFromOWL(c, visitor)
+}
+
+// 从OWL导入业务知识网络（外部）
+func (r *restHandler) ImportKNFromOWLByEx(c *gin.Context) {
+	logger.Debug("Handler ImportKNFromOWLByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"从OWL导入业务知识网络", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+	r.ImportKNFromOWL(c, visitor)
+}
+
+// 从 Turtle 或 JSON-LD 格式的 OWL 本体创建业务知识网络，返回无法映射的本体结构
+func (r *restHandler) ImportKNFromOWL(c *gin.Context, visitor rest.Visitor) {
+	logger.Debug("Handler ImportKNFromOWL Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"从OWL导入业务知识网络", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	// 设置 trace 的相关 api 的属性
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	format := c.DefaultQuery(interfaces.QueryParam_Format, interfaces.OWL_FORMAT_TURTLE)
+	span.SetAttributes(attr.Key(interfaces.QueryParam_Format).String(format))
+	err := ValidateOWLFormat(ctx, format)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	// 导入模式，与 json 导入一致
+	mode := c.DefaultQuery(interfaces.QueryParam_ImportMode, interfaces.ImportMode_Normal)
+	httpErr := validateImportMode(ctx, mode)
+	if httpErr != nil {
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	// 是否校验依赖，默认true
+	validateDependencyStr := c.DefaultQuery(interfaces.QueryParam_ValidateDependency, "true")
+	validateDependency, err := strconv.ParseBool(validateDependencyStr)
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter).
+			WithErrorDetails(fmt.Sprintf("Invalid validate_dependency parameter: %s", validateDependencyStr))
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	// 从header中获取业务域
+	businessDomain := c.GetHeader(interfaces.HTTP_HEADER_BUSINESS_DOMAIN)
+	if businessDomain == "" {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_BusinessDomain).
+			WithErrorDetails("Business Domain is empty")
+
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	// 请求体即 OWL 文档
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_OWLDocument).
+			WithErrorDetails("Read Request Body Failed:" + err.Error())
+
+		// 记录异常日志
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description, httpErr.BaseError.ErrorDetails))
+
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	kn, unmapped, err := r.kns.ConvertOWLToKN(ctx, data, format)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 记录异常日志
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description, httpErr.BaseError.ErrorDetails))
+
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+	kn.BusinessDomain = businessDomain
+	span.SetAttributes(attr.Key("kn_id").String(kn.KNID))
+
+	// 转换后的业务知识网络与 json 导入走同样的校验
+	err = ValidateKN(ctx, kn)
+	if err == nil {
+		err = ValidateKNConcepts(ctx, kn)
+	}
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 记录异常日志
+		o11y.Error(ctx, fmt.Sprintf("Validate knowledge network[%s] converted from owl failed: %s. %v", kn.KNName,
+			httpErr.BaseError.Description, httpErr.BaseError.ErrorDetails))
+
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	knID, err := r.kns.CreateKN(ctx, kn, mode, validateDependency)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	// 成功创建记录审计日志
+	audit.NewInfoLog(audit.OPERATION, audit.CREATE, audit.TransforOperator(visitor),
+		interfaces.GenerateKNAuditObject(knID, kn.KNName), "")
+
+	logger.Debug("Handler ImportKNFromOWL Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusCreated)
+	rest.ReplyOK(c, http.StatusCreated, map[string]any{"id": knID, "unmapped": unmapped})
+}
@@ -0,0 +1,240 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package driveradapters
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"ontology-manager/common"
+	oerrors "ontology-manager/errors"
+	"ontology-manager/interfaces"
+	dmock "ontology-manager/interfaces/mock"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	rmock "github.com/kweaver-ai/kweaver-go-lib/rest/mock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_KnowledgeNetworkRestHandler_ExportKNToOWL(t *testing.T) {
+	Convey("Test KnowledgeNetworkHandler ExportKNToOWL\n", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydra := rmock.NewMockHydra(mockCtrl)
+		kns := dmock.NewMockKNService(mockCtrl)
+
+		handler := MockNewKnowledgeNetworkRestHandler(appSetting, hydra, kns)
+		handler.RegisterPublic(engine)
+
+		hydra.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/ontology-manager/v1/knowledge-networks/kn1/owl"
+
+		Convey("Success ExportKNToOWL with default format\n", func() {
+			kns.EXPECT().ExportKNToOWL(gomock.Any(), "kn1", interfaces.MAIN_BRANCH, interfaces.OWL_FORMAT_TURTLE).
+				Return([]byte("<urn:kweaver:kn:kn1> a owl:Ontology ."), nil)
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(interfaces.CONTENT_TYPE_NAME), ShouldEqual, "text/turtle; charset=utf-8")
+			So(w.Body.String(), ShouldEqual, "<urn:kweaver:kn:kn1> a owl:Ontology .")
+		})
+
+		Convey("Success ExportKNToOWL with jsonld on branch\n", func() {
+			kns.EXPECT().ExportKNToOWL(gomock.Any(), "kn1", "dev", interfaces.OWL_FORMAT_JSONLD).
+				Return([]byte(`{"@graph": []}`), nil)
+
+			req := httptest.NewRequest(http.MethodGet, url+"?format=jsonld&branch=dev", nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(interfaces.CONTENT_TYPE_NAME), ShouldEqual, "application/ld+json; charset=utf-8")
+		})
+
+		Convey("Failed ExportKNToOWL with invalid format\n", func() {
+			req := httptest.NewRequest(http.MethodGet, url+"?format=rdfxml", nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("ExportKNToOWL failed\n", func() {
+			err := &rest.HTTPError{
+				HTTPCode: http.StatusNotFound,
+				Language: rest.DefaultLanguage,
+				BaseError: rest.BaseError{
+					ErrorCode: oerrors.OntologyManager_KnowledgeNetwork_NotFound,
+				},
+			}
+			kns.EXPECT().ExportKNToOWL(gomock.Any(), "kn1", interfaces.MAIN_BRANCH, interfaces.OWL_FORMAT_TURTLE).
+				Return(nil, err)
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func Test_KnowledgeNetworkRestHandler_ImportKNFromOWL(t *testing.T) {
+	Convey("Test KnowledgeNetworkHandler ImportKNFromOWL\n", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydra := rmock.NewMockHydra(mockCtrl)
+		kns := dmock.NewMockKNService(mockCtrl)
+
+		handler := MockNewKnowledgeNetworkRestHandler(appSetting, hydra, kns)
+		handler.RegisterPublic(engine)
+
+		hydra.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/ontology-manager/v1/knowledge-networks/owl"
+		doc := []byte("<http://example.org/shop> a <http://www.w3.org/2002/07/owl#Ontology> .")
+
+		newKN := func() *interfaces.KN {
+			return &interfaces.KN{
+				KNID:   "shop",
+				KNName: "shop",
+				Branch: interfaces.MAIN_BRANCH,
+				ObjectTypes: []*interfaces.ObjectType{
+					{
+						ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+							OTID:           "order",
+							OTName:         "order",
+							DataProperties: []*interfaces.DataProperty{{Name: "orderNo", DisplayName: "orderNo", Type: "string"}},
+							PrimaryKeys:    []string{"orderNo"},
+							DisplayKey:     "orderNo",
+						},
+					},
+				},
+			}
+		}
+		unmapped := []*interfaces.OWLUnmapped{{Subject: ":margherita", Reason: "not mapped"}}
+
+		Convey("Success ImportKNFromOWL\n", func() {
+			kns.EXPECT().ConvertOWLToKN(gomock.Any(), doc, interfaces.OWL_FORMAT_TURTLE).Return(newKN(), unmapped, nil)
+			kns.EXPECT().CreateKN(gomock.Any(), gomock.Any(), interfaces.ImportMode_Overwrite, true).Return("shop", nil)
+
+			req := httptest.NewRequest(http.MethodPost, url+"?import_mode=overwrite", bytes.NewReader(doc))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, "text/turtle")
+			req.Header.Set(interfaces.HTTP_HEADER_BUSINESS_DOMAIN, "bd_public")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusCreated)
+			result := struct {
+				ID       string                    `json:"id"`
+				Unmapped []*interfaces.OWLUnmapped `json:"unmapped"`
+			}{}
+			_ = sonic.Unmarshal(w.Body.Bytes(), &result)
+			So(result.ID, ShouldEqual, "shop")
+			So(result.Unmapped, ShouldResemble, unmapped)
+		})
+
+		Convey("Failed ImportKNFromOWL with invalid format\n", func() {
+			req := httptest.NewRequest(http.MethodPost, url+"?format=rdfxml", bytes.NewReader(doc))
+			req.Header.Set(interfaces.HTTP_HEADER_BUSINESS_DOMAIN, "bd_public")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Failed ImportKNFromOWL with invalid import mode\n", func() {
+			req := httptest.NewRequest(http.MethodPost, url+"?import_mode=xxx", bytes.NewReader(doc))
+			req.Header.Set(interfaces.HTTP_HEADER_BUSINESS_DOMAIN, "bd_public")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Failed ImportKNFromOWL without business domain\n", func() {
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(doc))
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Failed ImportKNFromOWL with invalid document\n", func() {
+			err := &rest.HTTPError{
+				HTTPCode: http.StatusBadRequest,
+				Language: rest.DefaultLanguage,
+				BaseError: rest.BaseError{
+					ErrorCode: oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_OWLDocument,
+				},
+			}
+			kns.EXPECT().ConvertOWLToKN(gomock.Any(), doc, interfaces.OWL_FORMAT_JSONLD).Return(nil, nil, err)
+
+			req := httptest.NewRequest(http.MethodPost, url+"?format=jsonld", bytes.NewReader(doc))
+			req.Header.Set(interfaces.HTTP_HEADER_BUSINESS_DOMAIN, "bd_public")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Failed ImportKNFromOWL when converted kn is invalid\n", func() {
+			kn := newKN()
+			kn.ObjectTypes[0].PrimaryKeys = []string{}
+			kns.EXPECT().ConvertOWLToKN(gomock.Any(), doc, interfaces.OWL_FORMAT_TURTLE).Return(kn, unmapped, nil)
+
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(doc))
+			req.Header.Set(interfaces.HTTP_HEADER_BUSINESS_DOMAIN, "bd_public")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("ImportKNFromOWL CreateKN failed\n", func() {
+			err := &rest.HTTPError{
+				HTTPCode: http.StatusBadRequest,
+				Language: rest.DefaultLanguage,
+				BaseError: rest.BaseError{
+					ErrorCode: oerrors.OntologyManager_KnowledgeNetwork_KNIDExisted,
+				},
+			}
+			kns.EXPECT().ConvertOWLToKN(gomock.Any(), doc, interfaces.OWL_FORMAT_TURTLE).Return(newKN(), unmapped, nil)
+			kns.EXPECT().CreateKN(gomock.Any(), gomock.Any(), interfaces.ImportMode_Normal, true).Return("", err)
+
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(doc))
+			req.Header.Set(interfaces.HTTP_HEADER_BUSINESS_DOMAIN, "bd_public")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
@@ -77,6 +77,13 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		apiV1.GET("/knowledge-networks", r.ListKNsByEx)
 		apiV1.GET("/knowledge-networks/:kn_id", r.GetKNByEx)
 		apiV1.POST("/knowledge-networks/:kn_id/relation-type-paths", r.GetRelationTypePathsByEx)
+		apiV1.POST("/knowledge-networks/:kn_id/branches", r.verifyJsonContentTypeMiddleWare(), r.CreateKNBranchByEx)
+		apiV1.GET("/knowledge-networks/:kn_id/branches", r.ListKNBranchesByEx)
+		apiV1.DELETE("/knowledge-networks/:kn_id/branches/:branch", r.DeleteKNBranchByEx)
+		apiV1.GET("/knowledge-networks/:kn_id/branches/:branch/diff", r.DiffKNBranchByEx)
+		apiV1.POST("/knowledge-networks/:kn_id/branches/:branch/merge", r.verifyJsonContentTypeMiddleWare(), r.MergeKNBranchByEx)
+		apiV1.POST("/knowledge-networks/owl", r.ImportKNFromOWLByEx)
+		apiV1.GET("/knowledge-networks/:kn_id/owl", r.ExportKNToOWLByEx)
 
 		// 概念分组
 		apiV1.POST("/knowledge-networks/:kn_id/concept-groups", r.verifyJsonContentTypeMiddleWare(), r.CreateConceptGroupByEx)
@@ -135,6 +142,13 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		apiInV1.GET("/knowledge-networks", r.ListKNsByIn)
 		apiInV1.GET("/knowledge-networks/:kn_id", r.GetKNByIn)
 		apiInV1.POST("/knowledge-networks/:kn_id/relation-type-paths", r.GetRelationTypePathsByIn)
+		apiInV1.POST("/knowledge-networks/:kn_id/branches", r.verifyJsonContentTypeMiddleWare(), r.CreateKNBranchByIn)
+		apiInV1.GET("/knowledge-networks/:kn_id/branches", r.ListKNBranchesByIn)
+		apiInV1.DELETE("/knowledge-networks/:kn_id/branches/:branch", r.DeleteKNBranchByIn)
+		apiInV1.GET("/knowledge-networks/:kn_id/branches/:branch/diff", r.DiffKNBranchByIn)
+		apiInV1.POST("/knowledge-networks/:kn_id/branches/:branch/merge", r.verifyJsonContentTypeMiddleWare(), r.MergeKNBranchByIn)
+		apiInV1.POST("/knowledge-networks/owl", r.ImportKNFromOWLByIn)
+		apiInV1.GET("/knowledge-networks/:kn_id/owl", r.ExportKNToOWLByIn)
 
 		// 概念分组
 		apiInV1.POST("/knowledge-networks/:kn_id/concept-groups", r.verifyJsonContentTypeMiddleWare(), r.CreateConceptGroupByIn)
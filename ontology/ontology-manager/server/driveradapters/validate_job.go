@@ -53,10 +53,11 @@ func ValidateJobType(ctx context.Context, jobType interfaces.JobType) error {
 	switch jobType {
 	case interfaces.JobTypeFull:
 	case interfaces.JobTypeIncremental:
+	case interfaces.JobTypeGraphAnalytics:
 	default:
 		return rest.NewHTTPError(ctx, http.StatusBadRequest,
 			oerrors.OntologyManager_Job_InvalidParameter_JobType).
-			WithErrorDetails(fmt.Sprintf("The job_type value can only be 'full', 'incremental', but got: %s", jobType))
+			WithErrorDetails(fmt.Sprintf("The job_type value can only be 'full', 'incremental', 'graph_analytics', but got: %s", jobType))
 	}
 
 	return nil
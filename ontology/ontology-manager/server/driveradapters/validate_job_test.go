@@ -93,6 +93,11 @@ func Test_ValidateJobType(t *testing.T) {
 			So(err, ShouldBeNil)
 		})
 
+		Convey("Success with graph_analytics type\n", func() {
+			err := ValidateJobType(ctx, interfaces.JobTypeGraphAnalytics)
+			So(err, ShouldBeNil)
+		})
+
 		Convey("Failed with invalid type\n", func() {
 			err := ValidateJobType(ctx, interfaces.JobType("invalid"))
 			So(err, ShouldNotBeNil)
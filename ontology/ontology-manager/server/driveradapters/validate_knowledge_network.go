@@ -14,6 +14,7 @@ import (
 	oerrors "ontology-manager/errors"
 	"ontology-manager/interfaces"
 
+	"github.com/dlclark/regexp2"
 	libCommon "github.com/kweaver-ai/kweaver-go-lib/common"
 	"github.com/kweaver-ai/kweaver-go-lib/rest"
 )
@@ -58,6 +59,35 @@ func ValidateKN(ctx context.Context, kn *interfaces.KN) error {
 	return nil
 }
 
+// 若kn的对象类，关系类，行动类, 概念分组不为空，则应循环调用对象类、关系类、行动类, 概念分组的校验函数
+func ValidateKNConcepts(ctx context.Context, kn *interfaces.KN) error {
+	if len(kn.ObjectTypes) > 0 {
+		err := ValidateObjectTypes(ctx, kn.KNID, kn.ObjectTypes)
+		if err != nil {
+			return err
+		}
+	}
+	if len(kn.RelationTypes) > 0 {
+		err := ValidateRelationTypes(ctx, kn.KNID, kn.RelationTypes)
+		if err != nil {
+			return err
+		}
+	}
+	if len(kn.ActionTypes) > 0 {
+		err := ValidateActionTypes(ctx, kn.KNID, kn.ActionTypes)
+		if err != nil {
+			return err
+		}
+	}
+	for _, conceptGroup := range kn.ConceptGroups {
+		err := ValidateConceptGroup(ctx, conceptGroup)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // 路径查询的参数校验
 func ValidateRelationTypePathsQuery(ctx context.Context, query *interfaces.RelationTypePathsBaseOnSource) error {
 	// 起点对象类非空
@@ -84,3 +114,57 @@ func ValidateRelationTypePathsQuery(ctx context.Context, query *interfaces.Relat
 
 	return nil
 }
+
+// 分支名称校验，命名规则与id一致，主线名称保留
+func ValidateKNBranch(ctx context.Context, branch string) error {
+	if branch == "" {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_NullParameter_Branch).
+			WithErrorDetails("branch must be set")
+	}
+
+	re := regexp2.MustCompile(interfaces.RegexPattern_NonBuiltin_ID, regexp2.RE2)
+	match, err := re.MatchString(branch)
+	if err != nil || !match {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_Branch).
+			WithErrorDetails(`The branch can contain only lowercase letters, digits and underscores(_),
+			it cannot start with underscores and cannot exceed 40 characters`)
+	}
+
+	if branch == interfaces.MAIN_BRANCH {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_Branch).
+			WithErrorDetails(fmt.Sprintf("The branch name %s is reserved", interfaces.MAIN_BRANCH))
+	}
+
+	return nil
+}
+
+// 分支合并请求的参数校验，未指定时合并到主线、冲突时中止
+func ValidateKNBranchMerge(ctx context.Context, branch string, merge *interfaces.KNBranchMerge) error {
+	if merge.TargetBranch == "" {
+		merge.TargetBranch = interfaces.MAIN_BRANCH
+	}
+	if merge.TargetBranch == branch {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_Branch).
+			WithErrorDetails("The target branch cannot be the same as the source branch")
+	}
+
+	if merge.ConflictStrategy == "" {
+		merge.ConflictStrategy = interfaces.CONFLICT_STRATEGY_ABORT
+	}
+	if !interfaces.CONFLICT_STRATEGY_MAP[merge.ConflictStrategy] {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_ConflictStrategy).
+			WithErrorDetails(fmt.Sprintf("当前支持的冲突处理策略有: abort, source, target. 请求的策略为: %s", merge.ConflictStrategy))
+	}
+
+	return nil
+}
+
+// OWL 序列化格式的校验
+func ValidateOWLFormat(ctx context.Context, format string) error {
+	if _, ok := interfaces.OWL_FORMAT_CONTENT_TYPE[format]; !ok {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_OWLFormat).
+			WithErrorDetails(fmt.Sprintf("The format:%s is invalid, supported formats are %s and %s",
+				format, interfaces.OWL_FORMAT_TURTLE, interfaces.OWL_FORMAT_JSONLD))
+	}
+	return nil
+}
@@ -133,3 +133,65 @@ func Test_ValidateRelationTypePathsQuery(t *testing.T) {
 		})
 	})
 }
+
+func Test_ValidateKNBranch(t *testing.T) {
+	Convey("Test ValidateKNBranch\n", t, func() {
+		ctx := context.Background()
+
+		Convey("Success with valid branch\n", func() {
+			err := ValidateKNBranch(ctx, "dev-1")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Failed with empty branch\n", func() {
+			err := ValidateKNBranch(ctx, "")
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_NullParameter_Branch)
+		})
+
+		Convey("Failed with invalid branch\n", func() {
+			err := ValidateKNBranch(ctx, "_Dev")
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_Branch)
+		})
+
+		Convey("Failed with reserved main branch\n", func() {
+			err := ValidateKNBranch(ctx, interfaces.MAIN_BRANCH)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_Branch)
+		})
+	})
+}
+
+func Test_ValidateKNBranchMerge(t *testing.T) {
+	Convey("Test ValidateKNBranchMerge\n", t, func() {
+		ctx := context.Background()
+
+		Convey("Success with defaults\n", func() {
+			merge := &interfaces.KNBranchMerge{}
+			err := ValidateKNBranchMerge(ctx, "dev", merge)
+			So(err, ShouldBeNil)
+			So(merge.TargetBranch, ShouldEqual, interfaces.MAIN_BRANCH)
+			So(merge.ConflictStrategy, ShouldEqual, interfaces.CONFLICT_STRATEGY_ABORT)
+		})
+
+		Convey("Failed when target is the source branch\n", func() {
+			merge := &interfaces.KNBranchMerge{TargetBranch: "dev"}
+			err := ValidateKNBranchMerge(ctx, "dev", merge)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_Branch)
+		})
+
+		Convey("Failed with invalid conflict strategy\n", func() {
+			merge := &interfaces.KNBranchMerge{ConflictStrategy: "ours"}
+			err := ValidateKNBranchMerge(ctx, "dev", merge)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_ConflictStrategy)
+		})
+	})
+}
@@ -8,13 +8,18 @@ package errors
 // 业务知识网络错误码
 const (
 	// 400
+	OntologyManager_KnowledgeNetwork_BranchExisted                      = "OntologyManager.KnowledgeNetwork.BranchExisted"
 	OntologyManager_KnowledgeNetwork_Duplicated_Name                    = "OntologyManager.KnowledgeNetwork.Duplicated.Name"
 	OntologyManager_KnowledgeNetwork_InvalidParameter                   = "OntologyManager.KnowledgeNetwork.InvalidParameter"
+	OntologyManager_KnowledgeNetwork_InvalidParameter_Branch            = "OntologyManager.KnowledgeNetwork.InvalidParameter.Branch"
 	OntologyManager_KnowledgeNetwork_InvalidParameter_BusinessDomain    = "OntologyManager.KnowledgeNetwork.InvalidParameter.BusinessDomain"
 	OntologyManager_KnowledgeNetwork_InvalidParameter_ConceptCondition  = "OntologyManager.KnowledgeNetwork.InvalidParameter.ConceptCondition"
+	OntologyManager_KnowledgeNetwork_InvalidParameter_ConflictStrategy  = "OntologyManager.KnowledgeNetwork.InvalidParameter.ConflictStrategy"
 	OntologyManager_KnowledgeNetwork_InvalidParameter_Direction         = "OntologyManager.KnowledgeNetwork.InvalidParameter.Direction"
 	OntologyManager_KnowledgeNetwork_InvalidParameter_IncludeStatistics = "OntologyManager.KnowledgeNetwork.InvalidParameter.IncludeStatistics"
 	OntologyManager_KnowledgeNetwork_InvalidParameter_IncludeTypeInfo   = "OntologyManager.KnowledgeNetwork.InvalidParameter.IncludeTypeInfo"
+	OntologyManager_KnowledgeNetwork_InvalidParameter_OWLDocument       = "OntologyManager.KnowledgeNetwork.InvalidParameter.OWLDocument"
+	OntologyManager_KnowledgeNetwork_InvalidParameter_OWLFormat         = "OntologyManager.KnowledgeNetwork.InvalidParameter.OWLFormat"
 	OntologyManager_KnowledgeNetwork_InvalidParameter_PathLength        = "OntologyManager.KnowledgeNetwork.InvalidParameter.PathLength"
 	OntologyManager_KnowledgeNetwork_KNIDExisted                        = "OntologyManager.KnowledgeNetwork.KNIDExisted"
 	OntologyManager_KnowledgeNetwork_KNNameExisted                      = "OntologyManager.KnowledgeNetwork.KNNameExisted"
@@ -28,7 +33,11 @@ const (
 	OntologyManager_KnowledgeNetwork_Forbidden_HasRunningJob = "OntologyManager.KnowledgeNetwork.Forbidden.HasRunningJob"
 
 	// 404
-	OntologyManager_KnowledgeNetwork_NotFound = "OntologyManager.KnowledgeNetwork.NotFound"
+	OntologyManager_KnowledgeNetwork_BranchNotFound = "OntologyManager.KnowledgeNetwork.BranchNotFound"
+	OntologyManager_KnowledgeNetwork_NotFound       = "OntologyManager.KnowledgeNetwork.NotFound"
+
+	// 409
+	OntologyManager_KnowledgeNetwork_Conflict_BranchMerge = "OntologyManager.KnowledgeNetwork.Conflict.BranchMerge"
 
 	// 500
 	OntologyManager_KnowledgeNetwork_InternalError                             = "OntologyManager.KnowledgeNetwork.InternalError"
@@ -51,18 +60,27 @@ const (
 	OntologyManager_KnowledgeNetwork_InternalError_DeleteObjectTypesFailed     = "OntologyManager.KnowledgeNetwork.InternalError.DeleteObjectTypesFailed"
 	OntologyManager_KnowledgeNetwork_InternalError_DeleteRelationTypesFailed   = "OntologyManager.KnowledgeNetwork.InternalError.DeleteRelationTypesFailed"
 	OntologyManager_KnowledgeNetwork_InternalError_DeleteActionTypesFailed     = "OntologyManager.KnowledgeNetwork.InternalError.DeleteActionTypesFailed"
+	OntologyManager_KnowledgeNetwork_InternalError_ForkBranchFailed            = "OntologyManager.KnowledgeNetwork.InternalError.ForkBranchFailed"
+	OntologyManager_KnowledgeNetwork_InternalError_MergeBranchFailed           = "OntologyManager.KnowledgeNetwork.InternalError.MergeBranchFailed"
+	OntologyManager_KnowledgeNetwork_InternalError_GetBranchConceptsFailed     = "OntologyManager.KnowledgeNetwork.InternalError.GetBranchConceptsFailed"
+	OntologyManager_KnowledgeNetwork_InternalError_ExportOWLFailed             = "OntologyManager.KnowledgeNetwork.InternalError.ExportOWLFailed"
 )
 
 var (
 	KNErrCodeList = []string{
 		// 400
+		OntologyManager_KnowledgeNetwork_BranchExisted,
 		OntologyManager_KnowledgeNetwork_Duplicated_Name,
 		OntologyManager_KnowledgeNetwork_InvalidParameter,
+		OntologyManager_KnowledgeNetwork_InvalidParameter_Branch,
 		OntologyManager_KnowledgeNetwork_InvalidParameter_BusinessDomain,
 		OntologyManager_KnowledgeNetwork_InvalidParameter_ConceptCondition,
+		OntologyManager_KnowledgeNetwork_InvalidParameter_ConflictStrategy,
 		OntologyManager_KnowledgeNetwork_InvalidParameter_Direction,
 		OntologyManager_KnowledgeNetwork_InvalidParameter_IncludeStatistics,
 		OntologyManager_KnowledgeNetwork_InvalidParameter_IncludeTypeInfo,
+		OntologyManager_KnowledgeNetwork_InvalidParameter_OWLDocument,
+		OntologyManager_KnowledgeNetwork_InvalidParameter_OWLFormat,
 		OntologyManager_KnowledgeNetwork_InvalidParameter_PathLength,
 		OntologyManager_KnowledgeNetwork_KNIDExisted,
 		OntologyManager_KnowledgeNetwork_KNNameExisted,
@@ -76,8 +94,12 @@ var (
 		OntologyManager_KnowledgeNetwork_Forbidden_HasRunningJob,
 
 		// 404
+		OntologyManager_KnowledgeNetwork_BranchNotFound,
 		OntologyManager_KnowledgeNetwork_NotFound,
 
+		// 409
+		OntologyManager_KnowledgeNetwork_Conflict_BranchMerge,
+
 		// 500
 		OntologyManager_KnowledgeNetwork_InternalError,
 		OntologyManager_KnowledgeNetwork_InternalError_CheckKNIfExistFailed,
@@ -99,5 +121,9 @@ var (
 		OntologyManager_KnowledgeNetwork_InternalError_DeleteObjectTypesFailed,
 		OntologyManager_KnowledgeNetwork_InternalError_DeleteRelationTypesFailed,
 		OntologyManager_KnowledgeNetwork_InternalError_DeleteActionTypesFailed,
+		OntologyManager_KnowledgeNetwork_InternalError_ForkBranchFailed,
+		OntologyManager_KnowledgeNetwork_InternalError_MergeBranchFailed,
+		OntologyManager_KnowledgeNetwork_InternalError_GetBranchConceptsFailed,
+		OntologyManager_KnowledgeNetwork_InternalError_ExportOWLFailed,
 	}
 )
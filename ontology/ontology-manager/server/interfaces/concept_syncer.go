@@ -0,0 +1,16 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package interfaces
+
+import "context"
+
+// ConceptSyncer 定义概念同步接口
+//
+//go:generate mockgen -source ../interfaces/concept_syncer.go -destination ../interfaces/mock/mock_concept_syncer.go
+type ConceptSyncer interface {
+	SyncKN(ctx context.Context, knID string, branch string) error
+	Start()
+}
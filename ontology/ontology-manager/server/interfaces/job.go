@@ -10,12 +10,32 @@ type JobState string
 type TaskState string
 
 const (
-	JobTypeFull        JobType = "full"
-	JobTypeIncremental JobType = "incremental"
+	JobTypeFull           JobType = "full"
+	JobTypeIncremental    JobType = "incremental"
+	JobTypeGraphAnalytics JobType = "graph_analytics"
 
 	MAX_STATE_DETAIL_SIZE int = 50000
 )
 
+// 图分析任务写回对象类索引的字段
+const (
+	GRAPH_PAGERANK_FIELD               = "_graph_pagerank"
+	GRAPH_DEGREE_CENTRALITY_FIELD      = "_graph_degree_centrality"
+	GRAPH_BETWEENNESS_CENTRALITY_FIELD = "_graph_betweenness_centrality"
+	GRAPH_COMPONENT_FIELD              = "_graph_component"
+	GRAPH_COMMUNITY_FIELD              = "_graph_community"
+)
+
+var (
+	KN_INDEX_GRAPH_ANALYTICS_MAPPING = map[string]any{
+		GRAPH_PAGERANK_FIELD:               map[string]any{"type": "double"},
+		GRAPH_DEGREE_CENTRALITY_FIELD:      map[string]any{"type": "double"},
+		GRAPH_BETWEENNESS_CENTRALITY_FIELD: map[string]any{"type": "double"},
+		GRAPH_COMPONENT_FIELD:              map[string]any{"type": "long"},
+		GRAPH_COMMUNITY_FIELD:              map[string]any{"type": "long"},
+	}
+)
+
 const (
 	JobStatePending   JobState = "pending"
 	JobStateRunning   JobState = "running"
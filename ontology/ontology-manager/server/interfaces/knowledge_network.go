@@ -18,6 +18,23 @@ const (
 	DIRECTION_FORWARD       = "forward"
 	DIRECTION_BACKWARD      = "backward"
 	DIRECTION_BIDIRECTIONAL = "bidirectional"
+
+	// 分支合并的冲突处理策略
+	CONFLICT_STRATEGY_ABORT  = "abort"  // 存在冲突时不合并
+	CONFLICT_STRATEGY_SOURCE = "source" // 冲突时以源分支为准
+	CONFLICT_STRATEGY_TARGET = "target" // 冲突时以目标分支为准
+
+	// 分支合并的冲突类型
+	CONFLICT_TYPE_BOTH_MODIFIED        = "both_modified"        // 两个分支都修改了同一个概念
+	CONFLICT_TYPE_MODIFIED_AND_REMOVED = "modified_and_removed" // 源分支修改，目标分支删除
+	CONFLICT_TYPE_REMOVED_AND_MODIFIED = "removed_and_modified" // 源分支删除，目标分支修改
+	CONFLICT_TYPE_NAME_DUPLICATED      = "name_duplicated"      // 合并后同类概念重名，不能通过策略解决
+
+	// OWL 本体的序列化格式
+	OWL_FORMAT_TURTLE = "turtle"
+	OWL_FORMAT_JSONLD = "jsonld"
+
+	QueryParam_Format = "format"
 )
 
 var (
@@ -92,6 +109,17 @@ var (
 		},
 	}
 
+	CONFLICT_STRATEGY_MAP = map[string]bool{
+		CONFLICT_STRATEGY_ABORT:  true,
+		CONFLICT_STRATEGY_SOURCE: true,
+		CONFLICT_STRATEGY_TARGET: true,
+	}
+
+	OWL_FORMAT_CONTENT_TYPE = map[string]string{
+		OWL_FORMAT_TURTLE: "text/turtle; charset=utf-8",
+		OWL_FORMAT_JSONLD: "application/ld+json; charset=utf-8",
+	}
+
 	DIRECTION_MAP = map[string]bool{
 		DIRECTION_FORWARD:       true,
 		DIRECTION_BACKWARD:      true,
@@ -208,3 +236,72 @@ type TypeEdge struct {
 type CommonQueryParameters struct {
 	IncludeStatistics bool
 }
+
+// 业务知识网络分支，分支与主线共用知识网络id，分支的创建时间即从主线分叉的时间
+type KNBranch struct {
+	Name       string      `json:"name"`
+	Creator    AccountInfo `json:"creator"`
+	CreateTime int64       `json:"create_time"`
+	Updater    AccountInfo `json:"updater"`
+	UpdateTime int64       `json:"update_time"`
+}
+
+// 分支合并请求
+type KNBranchMerge struct {
+	TargetBranch     string `json:"target_branch"`
+	ConflictStrategy string `json:"conflict_strategy"`
+}
+
+// 分支间的结构化差异，以源分支相对目标分支描述
+type KNBranchDiff struct {
+	Branch        string      `json:"branch"`
+	TargetBranch  string      `json:"target_branch"`
+	ObjectTypes   ConceptDiff `json:"object_types"`
+	RelationTypes ConceptDiff `json:"relation_types"`
+	ActionTypes   ConceptDiff `json:"action_types"`
+	ConceptGroups ConceptDiff `json:"concept_groups"`
+}
+
+type ConceptDiff struct {
+	Added    []ConceptChange `json:"added"`
+	Removed  []ConceptChange `json:"removed"`
+	Modified []ConceptChange `json:"modified"`
+}
+
+type ConceptChange struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Fields []string `json:"fields,omitempty"` // 修改时发生变化的字段
+}
+
+// 分支合并冲突
+type KNBranchConflict struct {
+	ModuleType   string `json:"module_type"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ConflictType string `json:"conflict_type"`
+}
+
+// 分支合并结果，差异部分为实际合入目标分支的变更
+type KNBranchMergeResult struct {
+	KNBranchDiff
+	Conflicts []KNBranchConflict `json:"conflicts"`
+}
+
+// 分支合并基点。记录源分支最近一次合入目标分支的时间及当时源分支上的概念，作为下次合并的共同祖先
+type KNBranchMergeBase struct {
+	KNID         string              `json:"-"`
+	Branch       string              `json:"-"`
+	TargetBranch string              `json:"-"`
+	MergeTime    int64               `json:"-"`
+	Concepts     map[string][]string `json:"concepts"`  // 以概念类型为key的概念id
+	Relations    []string            `json:"relations"` // 分组关系，以 分组id/概念类型/概念id 表示
+}
+
+// OWL 导入时未能映射为业务知识网络概念的 RDF 结构，以前缀名形式给出所在的三元组
+type OWLUnmapped struct {
+	Subject   string `json:"subject"`
+	Predicate string `json:"predicate,omitempty"`
+	Object    string `json:"object,omitempty"`
+	Reason    string `json:"reason"`
+}
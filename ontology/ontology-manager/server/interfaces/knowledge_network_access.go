@@ -24,6 +24,9 @@ type KNAccess interface {
 	DeleteKN(ctx context.Context, tx *sql.Tx, knID string, branch string) (int64, error)
 
 	GetAllKNs(ctx context.Context) (map[string]*KN, error)
+	ListKNBranches(ctx context.Context, knID string) ([]*KN, error)
+	GetKNBranchMergeBase(ctx context.Context, knID string, branch string, targetBranch string) (*KNBranchMergeBase, error)
+	UpdateKNBranchMergeBase(ctx context.Context, tx *sql.Tx, base *KNBranchMergeBase) error
 	GetNeighborPathsBatch(ctx context.Context, otIDs []string, query RelationTypePathsBaseOnSource) (map[string][]RelationTypePath, error)
 
 	ListKnSrcs(ctx context.Context, query KNsQueryParams) ([]Resource, error)
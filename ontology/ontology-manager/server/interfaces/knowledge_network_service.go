@@ -25,4 +25,12 @@ type KNService interface {
 	GetRelationTypePaths(ctx context.Context, query RelationTypePathsBaseOnSource) ([]RelationTypePath, error)
 
 	ListKnSrcs(ctx context.Context, query KNsQueryParams) ([]Resource, int, error)
+
+	CreateKNBranch(ctx context.Context, knID string, branch string) error
+	ListKNBranches(ctx context.Context, knID string) ([]*KNBranch, error)
+	DiffKNBranch(ctx context.Context, knID string, branch string, targetBranch string) (*KNBranchDiff, error)
+	MergeKNBranch(ctx context.Context, knID string, branch string, merge KNBranchMerge) (*KNBranchMergeResult, error)
+
+	ExportKNToOWL(ctx context.Context, knID string, branch string, format string) ([]byte, error)
+	ConvertOWLToKN(ctx context.Context, data []byte, format string) (*KN, []*OWLUnmapped, error)
 }
@@ -117,6 +117,21 @@ func (mr *MockKNAccessMockRecorder) GetAllKNs(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllKNs", reflect.TypeOf((*MockKNAccess)(nil).GetAllKNs), ctx)
 }
 
+// GetKNBranchMergeBase mocks base method.
+func (m *MockKNAccess) GetKNBranchMergeBase(ctx context.Context, knID, branch, targetBranch string) (*interfaces.KNBranchMergeBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKNBranchMergeBase", ctx, knID, branch, targetBranch)
+	ret0, _ := ret[0].(*interfaces.KNBranchMergeBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKNBranchMergeBase indicates an expected call of GetKNBranchMergeBase.
+func (mr *MockKNAccessMockRecorder) GetKNBranchMergeBase(ctx, knID, branch, targetBranch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKNBranchMergeBase", reflect.TypeOf((*MockKNAccess)(nil).GetKNBranchMergeBase), ctx, knID, branch, targetBranch)
+}
+
 // GetKNByID mocks base method.
 func (m *MockKNAccess) GetKNByID(ctx context.Context, knID, branch string) (*interfaces.KN, error) {
 	m.ctrl.T.Helper()
@@ -162,6 +177,21 @@ func (mr *MockKNAccessMockRecorder) GetNeighborPathsBatch(ctx, otIDs, query inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNeighborPathsBatch", reflect.TypeOf((*MockKNAccess)(nil).GetNeighborPathsBatch), ctx, otIDs, query)
 }
 
+// ListKNBranches mocks base method.
+func (m *MockKNAccess) ListKNBranches(ctx context.Context, knID string) ([]*interfaces.KN, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKNBranches", ctx, knID)
+	ret0, _ := ret[0].([]*interfaces.KN)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKNBranches indicates an expected call of ListKNBranches.
+func (mr *MockKNAccessMockRecorder) ListKNBranches(ctx, knID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKNBranches", reflect.TypeOf((*MockKNAccess)(nil).ListKNBranches), ctx, knID)
+}
+
 // ListKNs mocks base method.
 func (m *MockKNAccess) ListKNs(ctx context.Context, query interfaces.KNsQueryParams) ([]*interfaces.KN, error) {
 	m.ctrl.T.Helper()
@@ -206,6 +236,20 @@ func (mr *MockKNAccessMockRecorder) UpdateKN(ctx, tx, kn interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKN", reflect.TypeOf((*MockKNAccess)(nil).UpdateKN), ctx, tx, kn)
 }
 
+// UpdateKNBranchMergeBase mocks base method.
+func (m *MockKNAccess) UpdateKNBranchMergeBase(ctx context.Context, tx *sql.Tx, base *interfaces.KNBranchMergeBase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKNBranchMergeBase", ctx, tx, base)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateKNBranchMergeBase indicates an expected call of UpdateKNBranchMergeBase.
+func (mr *MockKNAccessMockRecorder) UpdateKNBranchMergeBase(ctx, tx, base interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKNBranchMergeBase", reflect.TypeOf((*MockKNAccess)(nil).UpdateKNBranchMergeBase), ctx, tx, base)
+}
+
 // UpdateKNDetail mocks base method.
 func (m *MockKNAccess) UpdateKNDetail(ctx context.Context, knID, branch, detail string) error {
 	m.ctrl.T.Helper()
@@ -73,6 +73,22 @@ func (mr *MockKNServiceMockRecorder) CheckKNExistByName(ctx, knName, branch inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckKNExistByName", reflect.TypeOf((*MockKNService)(nil).CheckKNExistByName), ctx, knName, branch)
 }
 
+// ConvertOWLToKN mocks base method.
+func (m *MockKNService) ConvertOWLToKN(ctx context.Context, data []byte, format string) (*interfaces.KN, []*interfaces.OWLUnmapped, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertOWLToKN", ctx, data, format)
+	ret0, _ := ret[0].(*interfaces.KN)
+	ret1, _ := ret[1].([]*interfaces.OWLUnmapped)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ConvertOWLToKN indicates an expected call of ConvertOWLToKN.
+func (mr *MockKNServiceMockRecorder) ConvertOWLToKN(ctx, data, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertOWLToKN", reflect.TypeOf((*MockKNService)(nil).ConvertOWLToKN), ctx, data, format)
+}
+
 // CreateKN mocks base method.
 func (m *MockKNService) CreateKN(ctx context.Context, kn *interfaces.KN, mode string, validateDependency bool) (string, error) {
 	m.ctrl.T.Helper()
@@ -88,6 +104,20 @@ func (mr *MockKNServiceMockRecorder) CreateKN(ctx, kn, mode, validateDependency
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKN", reflect.TypeOf((*MockKNService)(nil).CreateKN), ctx, kn, mode, validateDependency)
 }
 
+// CreateKNBranch mocks base method.
+func (m *MockKNService) CreateKNBranch(ctx context.Context, knID, branch string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKNBranch", ctx, knID, branch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateKNBranch indicates an expected call of CreateKNBranch.
+func (mr *MockKNServiceMockRecorder) CreateKNBranch(ctx, knID, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKNBranch", reflect.TypeOf((*MockKNService)(nil).CreateKNBranch), ctx, knID, branch)
+}
+
 // DeleteKN mocks base method.
 func (m *MockKNService) DeleteKN(ctx context.Context, kn *interfaces.KN) error {
 	m.ctrl.T.Helper()
@@ -102,6 +132,36 @@ func (mr *MockKNServiceMockRecorder) DeleteKN(ctx, kn interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKN", reflect.TypeOf((*MockKNService)(nil).DeleteKN), ctx, kn)
 }
 
+// DiffKNBranch mocks base method.
+func (m *MockKNService) DiffKNBranch(ctx context.Context, knID, branch, targetBranch string) (*interfaces.KNBranchDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffKNBranch", ctx, knID, branch, targetBranch)
+	ret0, _ := ret[0].(*interfaces.KNBranchDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffKNBranch indicates an expected call of DiffKNBranch.
+func (mr *MockKNServiceMockRecorder) DiffKNBranch(ctx, knID, branch, targetBranch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffKNBranch", reflect.TypeOf((*MockKNService)(nil).DiffKNBranch), ctx, knID, branch, targetBranch)
+}
+
+// ExportKNToOWL mocks base method.
+func (m *MockKNService) ExportKNToOWL(ctx context.Context, knID, branch, format string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportKNToOWL", ctx, knID, branch, format)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportKNToOWL indicates an expected call of ExportKNToOWL.
+func (mr *MockKNServiceMockRecorder) ExportKNToOWL(ctx, knID, branch, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportKNToOWL", reflect.TypeOf((*MockKNService)(nil).ExportKNToOWL), ctx, knID, branch, format)
+}
+
 // GetKNByID mocks base method.
 func (m *MockKNService) GetKNByID(ctx context.Context, knID, branch, mode string) (*interfaces.KN, error) {
 	m.ctrl.T.Helper()
@@ -147,6 +207,21 @@ func (mr *MockKNServiceMockRecorder) GetStatByKN(ctx, kn interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatByKN", reflect.TypeOf((*MockKNService)(nil).GetStatByKN), ctx, kn)
 }
 
+// ListKNBranches mocks base method.
+func (m *MockKNService) ListKNBranches(ctx context.Context, knID string) ([]*interfaces.KNBranch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKNBranches", ctx, knID)
+	ret0, _ := ret[0].([]*interfaces.KNBranch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKNBranches indicates an expected call of ListKNBranches.
+func (mr *MockKNServiceMockRecorder) ListKNBranches(ctx, knID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKNBranches", reflect.TypeOf((*MockKNService)(nil).ListKNBranches), ctx, knID)
+}
+
 // ListKNs mocks base method.
 func (m *MockKNService) ListKNs(ctx context.Context, query interfaces.KNsQueryParams) ([]*interfaces.KN, int, error) {
 	m.ctrl.T.Helper()
@@ -179,6 +254,21 @@ func (mr *MockKNServiceMockRecorder) ListKnSrcs(ctx, query interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKnSrcs", reflect.TypeOf((*MockKNService)(nil).ListKnSrcs), ctx, query)
 }
 
+// MergeKNBranch mocks base method.
+func (m *MockKNService) MergeKNBranch(ctx context.Context, knID, branch string, merge interfaces.KNBranchMerge) (*interfaces.KNBranchMergeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeKNBranch", ctx, knID, branch, merge)
+	ret0, _ := ret[0].(*interfaces.KNBranchMergeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeKNBranch indicates an expected call of MergeKNBranch.
+func (mr *MockKNServiceMockRecorder) MergeKNBranch(ctx, knID, branch, merge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeKNBranch", reflect.TypeOf((*MockKNService)(nil).MergeKNBranch), ctx, knID, branch, merge)
+}
+
 // UpdateKN mocks base method.
 func (m *MockKNService) UpdateKN(ctx context.Context, tx *sql.Tx, kn *interfaces.KN) error {
 	m.ctrl.T.Helper()
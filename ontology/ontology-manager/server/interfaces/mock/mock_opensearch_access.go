@@ -68,6 +68,20 @@ func (mr *MockOpenSearchAccessMockRecorder) BulkInsertData(ctx, indexName, dataL
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkInsertData", reflect.TypeOf((*MockOpenSearchAccess)(nil).BulkInsertData), ctx, indexName, dataList)
 }
 
+// BulkUpdateData mocks base method.
+func (m *MockOpenSearchAccess) BulkUpdateData(ctx context.Context, indexName string, dataList []any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateData", ctx, indexName, dataList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkUpdateData indicates an expected call of BulkUpdateData.
+func (mr *MockOpenSearchAccessMockRecorder) BulkUpdateData(ctx, indexName, dataList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateData", reflect.TypeOf((*MockOpenSearchAccess)(nil).BulkUpdateData), ctx, indexName, dataList)
+}
+
 // Count mocks base method.
 func (m *MockOpenSearchAccess) Count(ctx context.Context, indexName string, query any) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -197,6 +211,20 @@ func (mr *MockOpenSearchAccessMockRecorder) PutIndexTemplate(ctx, indexTemplateN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutIndexTemplate", reflect.TypeOf((*MockOpenSearchAccess)(nil).PutIndexTemplate), ctx, indexTemplateName, body)
 }
 
+// PutMapping mocks base method.
+func (m *MockOpenSearchAccess) PutMapping(ctx context.Context, indexName string, body any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMapping", ctx, indexName, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutMapping indicates an expected call of PutMapping.
+func (mr *MockOpenSearchAccessMockRecorder) PutMapping(ctx, indexName, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMapping", reflect.TypeOf((*MockOpenSearchAccess)(nil).PutMapping), ctx, indexName, body)
+}
+
 // Refresh mocks base method.
 func (m *MockOpenSearchAccess) Refresh(ctx context.Context, indexName string) error {
 	m.ctrl.T.Helper()
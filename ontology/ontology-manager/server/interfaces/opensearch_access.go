@@ -28,6 +28,9 @@ type OpenSearchAccess interface {
 
 	CreateIndex(ctx context.Context, indexName string, body any) error
 
+	// PutMapping 向已存在的索引追加字段映射
+	PutMapping(ctx context.Context, indexName string, body any) error
+
 	// IndexExists 检查指定索引是否存在
 	IndexExists(ctx context.Context, indexName string) (bool, error)
 
@@ -46,6 +49,9 @@ type OpenSearchAccess interface {
 	// BulkInsertData 批量写入数据到指定索引
 	BulkInsertData(ctx context.Context, indexName string, dataList []any) error
 
+	// BulkUpdateData 按照文档ID批量局部更新数据
+	BulkUpdateData(ctx context.Context, indexName string, dataList []any) error
+
 	// SearchData 搜索指定索引中的数据
 	SearchData(ctx context.Context, indexName string, query any) ([]Hit, error)
 
@@ -119,7 +119,22 @@ func (js *jobService) CreateJob(ctx context.Context, jobInfo *interfaces.JobInfo
 	}
 
 	taskInfos := map[string]*interfaces.TaskInfo{}
-	if len(jobInfo.JobConceptConfig) == 0 {
+	if jobInfo.JobType == interfaces.JobTypeGraphAnalytics {
+		// 图分析在整个业务知识网络的实例图上计算，只生成一个子任务，
+		// 参与分析的对象类和关系类由 JobConceptConfig 指定，未指定时取全部
+		task_id := xid.New().String()
+
+		taskInfos[task_id] = &interfaces.TaskInfo{
+			ID:          task_id,
+			Name:        string(interfaces.JobTypeGraphAnalytics),
+			JobID:       jobInfo.ID,
+			ConceptType: interfaces.MODULE_TYPE_KN,
+			ConceptID:   jobInfo.KNID,
+			TaskStateInfo: interfaces.TaskStateInfo{
+				State: interfaces.TaskStatePending,
+			},
+		}
+	} else if len(jobInfo.JobConceptConfig) == 0 {
 		objectTypes, err := js.ots.GetAllObjectTypesByKnID(ctx, jobInfo.KNID, jobInfo.Branch)
 		if err != nil {
 			return "", err
@@ -116,6 +116,35 @@ func Test_jobService_CreateJob(t *testing.T) {
 			So(jobID, ShouldEqual, "job1")
 		})
 
+		Convey("Success creating graph analytics job with a single knowledge network task\n", func() {
+			jobInfo := &interfaces.JobInfo{
+				ID:      "job1",
+				KNID:    "kn1",
+				Branch:  interfaces.MAIN_BRANCH,
+				JobType: interfaces.JobTypeGraphAnalytics,
+				JobConceptConfig: []interfaces.ConceptConfig{
+					{ConceptType: interfaces.MODULE_TYPE_OBJECT_TYPE, ConceptID: "ot1"},
+				},
+			}
+
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ja.EXPECT().ListJobs(gomock.Any(), gomock.Any()).Return([]*interfaces.JobInfo{}, nil)
+			smock.ExpectBegin()
+			ja.EXPECT().CreateJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ja.EXPECT().CreateTasks(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			smock.ExpectCommit()
+			je.EXPECT().AddJob(gomock.Any(), gomock.Any()).Return(nil)
+
+			jobID, err := service.CreateJob(ctx, jobInfo)
+			So(err, ShouldBeNil)
+			So(jobID, ShouldEqual, "job1")
+			So(len(jobInfo.TaskInfos), ShouldEqual, 1)
+			for _, taskInfo := range jobInfo.TaskInfos {
+				So(taskInfo.ConceptType, ShouldEqual, interfaces.MODULE_TYPE_KN)
+				So(taskInfo.ConceptID, ShouldEqual, "kn1")
+			}
+		})
+
 		Convey("Success creating job with auto-generated ID\n", func() {
 			jobInfo := &interfaces.JobInfo{
 				ID:     "",
@@ -0,0 +1,1110 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package knowledge_network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"github.com/rs/xid"
+	"go.opentelemetry.io/otel/codes"
+
+	oerrors "ontology-manager/errors"
+	"ontology-manager/interfaces"
+)
+
+// 分组成员在差异中对应的字段名
+const GROUP_MEMBERS_FIELD = "object_types"
+
+// 分支上某一时刻的全部概念
+type branchSnapshot struct {
+	kn            *interfaces.KN
+	objectTypes   map[string]*interfaces.ObjectType
+	relationTypes map[string]*interfaces.RelationType
+	actionTypes   map[string]*interfaces.ActionType
+	conceptGroups map[string]*interfaces.ConceptGroup
+	relations     map[string]interfaces.ConceptGroupRelation // 以 分组id/概念类型/概念id 为key
+}
+
+// 用于比较的概念，content 只保留概念自身的定义，不含详情、时间、分支等字段
+type branchConcept struct {
+	id         string
+	name       string
+	content    map[string]any
+	createTime int64
+	updateTime int64
+}
+
+// 单类概念的合并计划
+type conceptMergePlan struct {
+	upserts   []string // 以源分支为准写入目标分支的概念
+	removes   []string // 从目标分支删除的概念
+	diff      interfaces.ConceptDiff
+	conflicts []interfaces.KNBranchConflict
+}
+
+// 合并的共同祖先。concepts 为 nil 表示两个分支自分叉后未合并过，以分叉时间和概念的创建时间判断概念是否在共同祖先中
+type branchMergeBase struct {
+	time      int64
+	concepts  map[string]map[string]bool // 以概念类型为key的概念id
+	relations map[string]bool
+}
+
+// 整个分支的合并计划
+type branchMergePlan struct {
+	objectTypes      *conceptMergePlan
+	relationTypes    *conceptMergePlan
+	actionTypes      *conceptMergePlan
+	conceptGroups    *conceptMergePlan
+	relations        map[string]interfaces.ConceptGroupRelation // 合并后目标分支的分组关系
+	relationsChanged bool
+}
+
+// 从主线创建分支，复制主线上的全部概念
+func (kns *knowledgeNetworkService) CreateKNBranch(ctx context.Context, knID string, branch string) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Create knowledge network branch")
+	defer span.End()
+
+	err := kns.ps.CheckPermission(ctx, interfaces.Resource{
+		Type: interfaces.RESOURCE_TYPE_KN,
+		ID:   knID,
+	}, []string{interfaces.OPERATION_TYPE_MODIFY})
+	if err != nil {
+		return err
+	}
+
+	_, exist, err := kns.CheckKNExistByID(ctx, knID, branch)
+	if err != nil {
+		return err
+	}
+	if exist {
+		span.SetStatus(codes.Error, "分支已存在")
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyManager_KnowledgeNetwork_BranchExisted).
+			WithErrorDetails(fmt.Sprintf("Branch[%s] of knowledge network[%s] already exists", branch, knID))
+	}
+
+	snapshot, err := kns.getBranchSnapshot(ctx, knID, interfaces.MAIN_BRANCH)
+	if err != nil {
+		span.SetStatus(codes.Error, "获取主线概念失败")
+		return err
+	}
+
+	accountInfo := interfaces.AccountInfo{}
+	if ctx.Value(interfaces.ACCOUNT_INFO_KEY) != nil {
+		accountInfo = ctx.Value(interfaces.ACCOUNT_INFO_KEY).(interfaces.AccountInfo)
+	}
+	currentTime := time.Now().UnixMilli()
+
+	// 分支的创建时间即分叉点，合并时作为共同祖先的时间
+	branchKN := *snapshot.kn
+	branchKN.Branch = branch
+	branchKN.Creator = accountInfo
+	branchKN.CreateTime = currentTime
+	branchKN.Updater = accountInfo
+	branchKN.UpdateTime = currentTime
+
+	err = kns.forkBranch(ctx, snapshot, &branchKN)
+	if err != nil {
+		logger.Errorf("Fork branch[%s] of knowledge network[%s] error: %s", branch, knID, err.Error())
+		span.SetStatus(codes.Error, "创建业务知识网络分支失败")
+
+		return rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyManager_KnowledgeNetwork_InternalError_ForkBranchFailed).WithErrorDetails(err.Error())
+	}
+
+	// 分支上的概念索引和知识网络详情立即同步，失败时由定时同步补齐
+	err = kns.csr.SyncKN(ctx, knID, branch)
+	if err != nil {
+		logger.Warnf("Sync branch[%s] of knowledge network[%s] error: %s", branch, knID, err.Error())
+		o11y.Warn(ctx, fmt.Sprintf("Sync branch[%s] of knowledge network[%s] error: %s", branch, knID, err.Error()))
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// 在一个事务中写入分支的知识网络及其全部概念，概念保留原有的创建、更新时间
+func (kns *knowledgeNetworkService) forkBranch(ctx context.Context, snapshot *branchSnapshot, branchKN *interfaces.KN) (err error) {
+	tx, err := kns.db.Begin()
+	if err != nil {
+		logger.Errorf("Begin transaction error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Begin transaction error: %s", err.Error()))
+		return err
+	}
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit()
+			if err != nil {
+				logger.Errorf("CreateKNBranch Transaction Commit Failed:%v", err)
+				o11y.Error(ctx, fmt.Sprintf("CreateKNBranch Transaction Commit Failed: %s", err.Error()))
+				return
+			}
+			logger.Infof("CreateKNBranch Transaction Commit Success: %s %s", branchKN.KNID, branchKN.Branch)
+			o11y.Debug(ctx, fmt.Sprintf("CreateKNBranch Transaction Commit Success: %s %s", branchKN.KNID, branchKN.Branch))
+		default:
+			rollbackErr := tx.Rollback()
+			if rollbackErr != nil {
+				logger.Errorf("CreateKNBranch Transaction Rollback Error:%v", rollbackErr)
+				o11y.Error(ctx, fmt.Sprintf("CreateKNBranch Transaction Rollback Error: %s", rollbackErr.Error()))
+			}
+		}
+	}()
+
+	err = kns.kna.CreateKN(ctx, tx, branchKN)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range sortedKeys(snapshot.objectTypes) {
+		ot := *snapshot.objectTypes[id]
+		ot.Branch = branchKN.Branch
+		err = kns.ota.CreateObjectType(ctx, tx, &ot)
+		if err != nil {
+			return err
+		}
+		err = kns.ota.CreateObjectTypeStatus(ctx, tx, &ot)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, id := range sortedKeys(snapshot.relationTypes) {
+		rt := *snapshot.relationTypes[id]
+		rt.Branch = branchKN.Branch
+		err = kns.rta.CreateRelationType(ctx, tx, &rt)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, id := range sortedKeys(snapshot.actionTypes) {
+		at := *snapshot.actionTypes[id]
+		at.Branch = branchKN.Branch
+		err = kns.ata.CreateActionType(ctx, tx, &at)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, id := range sortedKeys(snapshot.conceptGroups) {
+		cg := *snapshot.conceptGroups[id]
+		cg.Branch = branchKN.Branch
+		err = kns.cga.CreateConceptGroup(ctx, tx, &cg)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 分组关系的创建时间保留，用于合并时判断关系是否在分叉后新增
+	for _, key := range sortedKeys(snapshot.relations) {
+		relation := snapshot.relations[key]
+		relation.ID = xid.New().String()
+		relation.Branch = branchKN.Branch
+		err = kns.cga.CreateConceptGroupRelation(ctx, tx, &relation)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 列出业务知识网络的全部分支，包括主线
+func (kns *knowledgeNetworkService) ListKNBranches(ctx context.Context, knID string) ([]*interfaces.KNBranch, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "List knowledge network branches")
+	defer span.End()
+
+	err := kns.ps.CheckPermission(ctx, interfaces.Resource{
+		Type: interfaces.RESOURCE_TYPE_KN,
+		ID:   knID,
+	}, []string{interfaces.OPERATION_TYPE_VIEW_DETAIL})
+	if err != nil {
+		return nil, err
+	}
+
+	knList, err := kns.kna.ListKNBranches(ctx, knID)
+	if err != nil {
+		logger.Errorf("ListKNBranches error: %s", err.Error())
+		span.SetStatus(codes.Error, "获取业务知识网络分支失败")
+
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyManager_KnowledgeNetwork_InternalError).WithErrorDetails(err.Error())
+	}
+	if len(knList) == 0 {
+		errStr := fmt.Sprintf("Knowledge network[%s] not found", knID)
+		span.SetStatus(codes.Error, errStr)
+
+		return nil, rest.NewHTTPError(ctx, http.StatusNotFound,
+			oerrors.OntologyManager_KnowledgeNetwork_NotFound).WithErrorDetails(errStr)
+	}
+
+	branches := make([]*interfaces.KNBranch, 0, len(knList))
+	accountInfos := make([]*interfaces.AccountInfo, 0, len(knList)*2)
+	for _, kn := range knList {
+		branch := &interfaces.KNBranch{
+			Name:       kn.Branch,
+			Creator:    kn.Creator,
+			CreateTime: kn.CreateTime,
+			Updater:    kn.Updater,
+			UpdateTime: kn.UpdateTime,
+		}
+		branches = append(branches, branch)
+		accountInfos = append(accountInfos, &branch.Creator, &branch.Updater)
+	}
+
+	err = kns.uma.GetAccountNames(ctx, accountInfos)
+	if err != nil {
+		span.SetStatus(codes.Error, "GetAccountNames error")
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyManager_KnowledgeNetwork_InternalError).WithErrorDetails(err.Error())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return branches, nil
+}
+
+// 以目标分支为基准，给出源分支上概念的新增、删除和修改
+func (kns *knowledgeNetworkService) DiffKNBranch(ctx context.Context, knID string,
+	branch string, targetBranch string) (*interfaces.KNBranchDiff, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Diff knowledge network branch")
+	defer span.End()
+
+	err := kns.ps.CheckPermission(ctx, interfaces.Resource{
+		Type: interfaces.RESOURCE_TYPE_KN,
+		ID:   knID,
+	}, []string{interfaces.OPERATION_TYPE_VIEW_DETAIL})
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := kns.getBranchSnapshot(ctx, knID, branch)
+	if err != nil {
+		span.SetStatus(codes.Error, "获取源分支概念失败")
+		return nil, err
+	}
+	target, err := kns.getBranchSnapshot(ctx, knID, targetBranch)
+	if err != nil {
+		span.SetStatus(codes.Error, "获取目标分支概念失败")
+		return nil, err
+	}
+
+	diff, err := diffBranchSnapshots(source, target)
+	if err != nil {
+		logger.Errorf("Diff branch[%s] and branch[%s] error: %s", branch, targetBranch, err.Error())
+		span.SetStatus(codes.Error, "比较分支概念失败")
+
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyManager_KnowledgeNetwork_InternalError_GetBranchConceptsFailed).WithErrorDetails(err.Error())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return diff, nil
+}
+
+// 将源分支合并到目标分支。以上一次合并时的源分支作为共同祖先，未合并过时以两个分支中较晚的创建时间作为共同祖先，
+// 按概念的创建、更新时间做三方合并
+func (kns *knowledgeNetworkService) MergeKNBranch(ctx context.Context, knID string,
+	branch string, merge interfaces.KNBranchMerge) (*interfaces.KNBranchMergeResult, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Merge knowledge network branch")
+	defer span.End()
+
+	err := kns.ps.CheckPermission(ctx, interfaces.Resource{
+		Type: interfaces.RESOURCE_TYPE_KN,
+		ID:   knID,
+	}, []string{interfaces.OPERATION_TYPE_MODIFY})
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := kns.getBranchSnapshot(ctx, knID, branch)
+	if err != nil {
+		span.SetStatus(codes.Error, "获取源分支概念失败")
+		return nil, err
+	}
+	target, err := kns.getBranchSnapshot(ctx, knID, merge.TargetBranch)
+	if err != nil {
+		span.SetStatus(codes.Error, "获取目标分支概念失败")
+		return nil, err
+	}
+
+	record, err := kns.kna.GetKNBranchMergeBase(ctx, knID, branch, merge.TargetBranch)
+	if err != nil {
+		logger.Errorf("Get merge base of branch[%s] into branch[%s] error: %s", branch, merge.TargetBranch, err.Error())
+		span.SetStatus(codes.Error, "获取分支合并基点失败")
+
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyManager_KnowledgeNetwork_InternalError_MergeBranchFailed).WithErrorDetails(err.Error())
+	}
+
+	plan, err := planBranchMerge(source, target, newBranchMergeBase(source, target, record), merge.ConflictStrategy)
+	if err != nil {
+		logger.Errorf("Plan merge of branch[%s] into branch[%s] error: %s", branch, merge.TargetBranch, err.Error())
+		span.SetStatus(codes.Error, "计算分支合并失败")
+
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyManager_KnowledgeNetwork_InternalError_MergeBranchFailed).WithErrorDetails(err.Error())
+	}
+
+	result := &interfaces.KNBranchMergeResult{
+		KNBranchDiff: interfaces.KNBranchDiff{
+			Branch:        branch,
+			TargetBranch:  merge.TargetBranch,
+			ObjectTypes:   plan.objectTypes.diff,
+			RelationTypes: plan.relationTypes.diff,
+			ActionTypes:   plan.actionTypes.diff,
+			ConceptGroups: plan.conceptGroups.diff,
+		},
+		Conflicts: []interfaces.KNBranchConflict{},
+	}
+	nameDuplicated := false
+	for _, p := range []*conceptMergePlan{plan.objectTypes, plan.relationTypes, plan.actionTypes, plan.conceptGroups} {
+		for _, conflict := range p.conflicts {
+			if conflict.ConflictType == interfaces.CONFLICT_TYPE_NAME_DUPLICATED {
+				nameDuplicated = true
+			}
+			result.Conflicts = append(result.Conflicts, conflict)
+		}
+	}
+
+	// 重名无法通过策略解决，其余冲突仅在 abort 策略下中止合并
+	if nameDuplicated || (len(result.Conflicts) > 0 &&
+		merge.ConflictStrategy != interfaces.CONFLICT_STRATEGY_SOURCE &&
+		merge.ConflictStrategy != interfaces.CONFLICT_STRATEGY_TARGET) {
+
+		span.SetStatus(codes.Error, "分支合并存在冲突")
+		return nil, rest.NewHTTPError(ctx, http.StatusConflict,
+			oerrors.OntologyManager_KnowledgeNetwork_Conflict_BranchMerge).WithErrorDetails(result.Conflicts)
+	}
+
+	if plan.isEmpty() {
+		span.SetStatus(codes.Ok, "")
+		return result, nil
+	}
+
+	err = kns.applyBranchMerge(ctx, source, target, plan)
+	if err != nil {
+		logger.Errorf("Merge branch[%s] into branch[%s] error: %s", branch, merge.TargetBranch, err.Error())
+		span.SetStatus(codes.Error, "合并业务知识网络分支失败")
+
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyManager_KnowledgeNetwork_InternalError_MergeBranchFailed).WithErrorDetails(err.Error())
+	}
+
+	// 删除目标分支上已移除概念的索引，新增和修改的概念由同步写入
+	removed := map[string][]string{
+		interfaces.MODULE_TYPE_OBJECT_TYPE:   plan.objectTypes.removes,
+		interfaces.MODULE_TYPE_RELATION_TYPE: plan.relationTypes.removes,
+		interfaces.MODULE_TYPE_ACTION_TYPE:   plan.actionTypes.removes,
+		interfaces.MODULE_TYPE_CONCEPT_GROUP: plan.conceptGroups.removes,
+	}
+	for moduleType, ids := range removed {
+		for _, id := range ids {
+			docid := interfaces.GenerateConceptDocuemtnID(knID, moduleType, id, merge.TargetBranch)
+			err = kns.osa.DeleteData(ctx, interfaces.KN_CONCEPT_INDEX_NAME, docid)
+			if err != nil {
+				logger.Warnf("Delete concept document[%s] error: %s", docid, err.Error())
+			}
+		}
+	}
+
+	// 合并后重新同步目标分支，失败时由定时同步补齐
+	err = kns.csr.SyncKN(ctx, knID, merge.TargetBranch)
+	if err != nil {
+		logger.Warnf("Sync branch[%s] of knowledge network[%s] error: %s", merge.TargetBranch, knID, err.Error())
+		o11y.Warn(ctx, fmt.Sprintf("Sync branch[%s] of knowledge network[%s] error: %s", merge.TargetBranch, knID, err.Error()))
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// 在一个事务中把合并计划写入目标分支：先删除被移除和被修改的概念，再写入源分支的版本，最后把合并基点推进到本次合并
+func (kns *knowledgeNetworkService) applyBranchMerge(ctx context.Context, source *branchSnapshot,
+	target *branchSnapshot, plan *branchMergePlan) (err error) {
+
+	knID := target.kn.KNID
+	targetBranch := target.kn.Branch
+
+	accountInfo := interfaces.AccountInfo{}
+	if ctx.Value(interfaces.ACCOUNT_INFO_KEY) != nil {
+		accountInfo = ctx.Value(interfaces.ACCOUNT_INFO_KEY).(interfaces.AccountInfo)
+	}
+	// 合入的概念以合并时间为更新时间，后续合并时视为目标分支上的修改
+	currentTime := time.Now().UnixMilli()
+
+	tx, err := kns.db.Begin()
+	if err != nil {
+		logger.Errorf("Begin transaction error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Begin transaction error: %s", err.Error()))
+		return err
+	}
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit()
+			if err != nil {
+				logger.Errorf("MergeKNBranch Transaction Commit Failed:%v", err)
+				o11y.Error(ctx, fmt.Sprintf("MergeKNBranch Transaction Commit Failed: %s", err.Error()))
+				return
+			}
+			logger.Infof("MergeKNBranch Transaction Commit Success: %s %s", knID, targetBranch)
+			o11y.Debug(ctx, fmt.Sprintf("MergeKNBranch Transaction Commit Success: %s %s", knID, targetBranch))
+		default:
+			rollbackErr := tx.Rollback()
+			if rollbackErr != nil {
+				logger.Errorf("MergeKNBranch Transaction Rollback Error:%v", rollbackErr)
+				o11y.Error(ctx, fmt.Sprintf("MergeKNBranch Transaction Rollback Error: %s", rollbackErr.Error()))
+			}
+		}
+	}()
+
+	// 1. 删除
+	otIDs := idsToDelete(plan.objectTypes, target.objectTypes)
+	if len(otIDs) > 0 {
+		_, err = kns.ota.DeleteObjectTypesByIDs(ctx, tx, knID, targetBranch, otIDs)
+		if err != nil {
+			return err
+		}
+	}
+	// 被修改的对象类保留原有的索引状态
+	if len(plan.objectTypes.removes) > 0 {
+		_, err = kns.ota.DeleteObjectTypeStatusByIDs(ctx, tx, knID, targetBranch, plan.objectTypes.removes)
+		if err != nil {
+			return err
+		}
+	}
+	rtIDs := idsToDelete(plan.relationTypes, target.relationTypes)
+	if len(rtIDs) > 0 {
+		_, err = kns.rta.DeleteRelationTypesByIDs(ctx, tx, knID, targetBranch, rtIDs)
+		if err != nil {
+			return err
+		}
+	}
+	atIDs := idsToDelete(plan.actionTypes, target.actionTypes)
+	if len(atIDs) > 0 {
+		_, err = kns.ata.DeleteActionTypesByIDs(ctx, tx, knID, targetBranch, atIDs)
+		if err != nil {
+			return err
+		}
+	}
+	for _, cgID := range idsToDelete(plan.conceptGroups, target.conceptGroups) {
+		_, err = kns.cga.DeleteConceptGroupByID(ctx, tx, knID, targetBranch, cgID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 2. 写入源分支的版本
+	for _, id := range plan.objectTypes.upserts {
+		ot := *source.objectTypes[id]
+		ot.Branch = targetBranch
+		ot.Updater = accountInfo
+		ot.UpdateTime = currentTime
+		err = kns.ota.CreateObjectType(ctx, tx, &ot)
+		if err != nil {
+			return err
+		}
+		if _, exist := target.objectTypes[id]; !exist {
+			err = kns.ota.CreateObjectTypeStatus(ctx, tx, &ot)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range plan.relationTypes.upserts {
+		rt := *source.relationTypes[id]
+		rt.Branch = targetBranch
+		rt.Updater = accountInfo
+		rt.UpdateTime = currentTime
+		err = kns.rta.CreateRelationType(ctx, tx, &rt)
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range plan.actionTypes.upserts {
+		at := *source.actionTypes[id]
+		at.Branch = targetBranch
+		at.Updater = accountInfo
+		at.UpdateTime = currentTime
+		err = kns.ata.CreateActionType(ctx, tx, &at)
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range plan.conceptGroups.upserts {
+		cg := *source.conceptGroups[id]
+		cg.Branch = targetBranch
+		cg.Updater = accountInfo
+		cg.UpdateTime = currentTime
+		err = kns.cga.CreateConceptGroup(ctx, tx, &cg)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 3. 分组关系整体重写
+	if plan.relationsChanged {
+		_, err = kns.cga.DeleteConceptGroupRelationsByKnID(ctx, tx, knID, targetBranch)
+		if err != nil {
+			return err
+		}
+		for _, key := range sortedKeys(plan.relations) {
+			relation := plan.relations[key]
+			if _, exist := target.relations[key]; !exist {
+				relation.ID = xid.New().String()
+				relation.Branch = targetBranch
+			}
+			err = kns.cga.CreateConceptGroupRelation(ctx, tx, &relation)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	kn := *target.kn
+	kn.Updater = accountInfo
+	kn.UpdateTime = currentTime
+	err = kns.kna.UpdateKN(ctx, tx, &kn)
+	if err != nil {
+		return err
+	}
+
+	// 4. 记录本次合并时源分支上的概念，下次合并以此为共同祖先
+	err = kns.kna.UpdateKNBranchMergeBase(ctx, tx, newMergeBaseRecord(source, targetBranch, currentTime))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// 读取分支上的知识网络、概念和分组关系
+func (kns *knowledgeNetworkService) getBranchSnapshot(ctx context.Context, knID string, branch string) (*branchSnapshot, error) {
+	kn, err := kns.kna.GetKNByID(ctx, knID, branch)
+	if err != nil {
+		logger.Errorf("GetKNByID error: %s", err.Error())
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyManager_KnowledgeNetwork_InternalError_GetKNByIDFailed).WithErrorDetails(err.Error())
+	}
+	if kn == nil {
+		if branch == interfaces.MAIN_BRANCH {
+			return nil, rest.NewHTTPError(ctx, http.StatusNotFound, oerrors.OntologyManager_KnowledgeNetwork_NotFound).
+				WithErrorDetails(fmt.Sprintf("Knowledge network[%s] not found", knID))
+		}
+		return nil, rest.NewHTTPError(ctx, http.StatusNotFound, oerrors.OntologyManager_KnowledgeNetwork_BranchNotFound).
+			WithErrorDetails(fmt.Sprintf("Branch[%s] of knowledge network[%s] not found", branch, knID))
+	}
+
+	snapshot := &branchSnapshot{
+		kn:        kn,
+		relations: map[string]interfaces.ConceptGroupRelation{},
+	}
+
+	snapshot.objectTypes, err = kns.ota.GetAllObjectTypesByKnID(ctx, knID, branch)
+	if err == nil {
+		snapshot.relationTypes, err = kns.rta.GetAllRelationTypesByKnID(ctx, knID, branch)
+	}
+	if err == nil {
+		snapshot.actionTypes, err = kns.ata.GetAllActionTypesByKnID(ctx, knID, branch)
+	}
+	if err == nil {
+		snapshot.conceptGroups, err = kns.cga.GetAllConceptGroupsByKnID(ctx, knID, branch)
+	}
+	var relations []interfaces.ConceptGroupRelation
+	if err == nil {
+		relations, err = kns.cga.ListConceptGroupRelations(ctx, nil, interfaces.ConceptGroupRelationsQueryParams{
+			KNID:   knID,
+			Branch: branch,
+		})
+	}
+	if err != nil {
+		logger.Errorf("Get concepts of branch[%s] of knowledge network[%s] error: %s", branch, knID, err.Error())
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyManager_KnowledgeNetwork_InternalError_GetBranchConceptsFailed).WithErrorDetails(err.Error())
+	}
+
+	for _, relation := range relations {
+		snapshot.relations[relationKey(relation)] = relation
+	}
+
+	return snapshot, nil
+}
+
+func (s *branchSnapshot) concepts() (ots, rts, ats, cgs map[string]*branchConcept, err error) {
+	ots = make(map[string]*branchConcept, len(s.objectTypes))
+	for id, ot := range s.objectTypes {
+		ots[id], err = newBranchConcept(id, ot.OTName, ot.ObjectTypeWithKeyField, ot.CommonInfo, ot.CreateTime, ot.UpdateTime)
+		if err != nil {
+			return
+		}
+	}
+	rts = make(map[string]*branchConcept, len(s.relationTypes))
+	for id, rt := range s.relationTypes {
+		rts[id], err = newBranchConcept(id, rt.RTName, rt.RelationTypeWithKeyField, rt.CommonInfo, rt.CreateTime, rt.UpdateTime)
+		if err != nil {
+			return
+		}
+	}
+	ats = make(map[string]*branchConcept, len(s.actionTypes))
+	for id, at := range s.actionTypes {
+		ats[id], err = newBranchConcept(id, at.ATName, at.ActionTypeWithKeyField, at.CommonInfo, at.CreateTime, at.UpdateTime, "object_type")
+		if err != nil {
+			return
+		}
+	}
+	cgs = make(map[string]*branchConcept, len(s.conceptGroups))
+	for id, cg := range s.conceptGroups {
+		cgs[id], err = newBranchConcept(id, cg.CGName, map[string]any{"name": cg.CGName}, cg.CommonInfo, cg.CreateTime, cg.UpdateTime)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// 把概念的关键字段和通用信息转成可比较的map，去掉 id、detail 以及额外指定的展示字段
+func newBranchConcept(id string, name string, keyField any, commonInfo interfaces.CommonInfo,
+	createTime int64, updateTime int64, ignoreFields ...string) (*branchConcept, error) {
+
+	content := map[string]any{}
+	for _, part := range []any{keyField, commonInfo} {
+		bytes, err := sonic.Marshal(part)
+		if err != nil {
+			return nil, err
+		}
+		err = sonic.Unmarshal(bytes, &content)
+		if err != nil {
+			return nil, err
+		}
+	}
+	delete(content, "id")
+	delete(content, "detail")
+	for _, field := range ignoreFields {
+		delete(content, field)
+	}
+
+	return &branchConcept{
+		id:         id,
+		name:       name,
+		content:    content,
+		createTime: createTime,
+		updateTime: updateTime,
+	}, nil
+}
+
+// 发生变化的顶层字段
+func changedFields(a, b *branchConcept) []string {
+	fields := []string{}
+	for key, value := range a.content {
+		if !reflect.DeepEqual(value, b.content[key]) {
+			fields = append(fields, key)
+		}
+	}
+	for key := range b.content {
+		if _, exist := a.content[key]; !exist {
+			fields = append(fields, key)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func diffBranchSnapshots(source, target *branchSnapshot) (*interfaces.KNBranchDiff, error) {
+	srcOTs, srcRTs, srcATs, srcCGs, err := source.concepts()
+	if err != nil {
+		return nil, err
+	}
+	tgtOTs, tgtRTs, tgtATs, tgtCGs, err := target.concepts()
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &interfaces.KNBranchDiff{
+		Branch:        source.kn.Branch,
+		TargetBranch:  target.kn.Branch,
+		ObjectTypes:   diffConcepts(srcOTs, tgtOTs),
+		RelationTypes: diffConcepts(srcRTs, tgtRTs),
+		ActionTypes:   diffConcepts(srcATs, tgtATs),
+		ConceptGroups: diffConcepts(srcCGs, tgtCGs),
+	}
+	diffGroupMembers(&diff.ConceptGroups, srcCGs, tgtCGs, target.relations, source.relations)
+
+	return diff, nil
+}
+
+func diffConcepts(source, target map[string]*branchConcept) interfaces.ConceptDiff {
+	diff := newConceptDiff()
+	for _, id := range sortedKeys(source) {
+		src := source[id]
+		tgt, exist := target[id]
+		if !exist {
+			diff.Added = append(diff.Added, interfaces.ConceptChange{ID: id, Name: src.name})
+			continue
+		}
+		if fields := changedFields(src, tgt); len(fields) > 0 {
+			diff.Modified = append(diff.Modified, interfaces.ConceptChange{ID: id, Name: src.name, Fields: fields})
+		}
+	}
+	for _, id := range sortedKeys(target) {
+		if _, exist := source[id]; !exist {
+			diff.Removed = append(diff.Removed, interfaces.ConceptChange{ID: id, Name: target[id].name})
+		}
+	}
+	return diff
+}
+
+// 对两侧都存在的分组比较成员，成员变化的分组记为修改
+func diffGroupMembers(diff *interfaces.ConceptDiff, groups map[string]*branchConcept, otherGroups map[string]*branchConcept,
+	before map[string]interfaces.ConceptGroupRelation, after map[string]interfaces.ConceptGroupRelation) {
+
+	beforeMembers := groupMembers(before)
+	afterMembers := groupMembers(after)
+	for _, id := range sortedKeys(groups) {
+		if _, exist := otherGroups[id]; !exist {
+			continue
+		}
+		if reflect.DeepEqual(beforeMembers[id], afterMembers[id]) {
+			continue
+		}
+
+		found := false
+		for i := range diff.Modified {
+			if diff.Modified[i].ID == id {
+				diff.Modified[i].Fields = append(diff.Modified[i].Fields, GROUP_MEMBERS_FIELD)
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff.Modified = append(diff.Modified, interfaces.ConceptChange{
+				ID:     id,
+				Name:   groups[id].name,
+				Fields: []string{GROUP_MEMBERS_FIELD},
+			})
+		}
+	}
+}
+
+// 计算合并计划。晚于共同祖先时间的更新视为共同祖先之后在该分支上发生的变更
+func planBranchMerge(source, target *branchSnapshot, base *branchMergeBase, strategy string) (*branchMergePlan, error) {
+	srcOTs, srcRTs, srcATs, srcCGs, err := source.concepts()
+	if err != nil {
+		return nil, err
+	}
+	tgtOTs, tgtRTs, tgtATs, tgtCGs, err := target.concepts()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &branchMergePlan{
+		objectTypes:   mergeConcepts(interfaces.MODULE_TYPE_OBJECT_TYPE, srcOTs, tgtOTs, base, strategy),
+		relationTypes: mergeConcepts(interfaces.MODULE_TYPE_RELATION_TYPE, srcRTs, tgtRTs, base, strategy),
+		actionTypes:   mergeConcepts(interfaces.MODULE_TYPE_ACTION_TYPE, srcATs, tgtATs, base, strategy),
+		conceptGroups: mergeConcepts(interfaces.MODULE_TYPE_CONCEPT_GROUP, srcCGs, tgtCGs, base, strategy),
+	}
+
+	finalOTs := mergedConcepts(plan.objectTypes, srcOTs, tgtOTs)
+	finalRTs := mergedConcepts(plan.relationTypes, srcRTs, tgtRTs)
+	finalATs := mergedConcepts(plan.actionTypes, srcATs, tgtATs)
+	finalCGs := mergedConcepts(plan.conceptGroups, srcCGs, tgtCGs)
+
+	plan.objectTypes.conflicts = append(plan.objectTypes.conflicts,
+		duplicatedNames(interfaces.MODULE_TYPE_OBJECT_TYPE, finalOTs)...)
+	plan.relationTypes.conflicts = append(plan.relationTypes.conflicts,
+		duplicatedNames(interfaces.MODULE_TYPE_RELATION_TYPE, finalRTs)...)
+	plan.actionTypes.conflicts = append(plan.actionTypes.conflicts,
+		duplicatedNames(interfaces.MODULE_TYPE_ACTION_TYPE, finalATs)...)
+	plan.conceptGroups.conflicts = append(plan.conceptGroups.conflicts,
+		duplicatedNames(interfaces.MODULE_TYPE_CONCEPT_GROUP, finalCGs)...)
+
+	// 分组关系按集合合并：源分支在共同祖先之后新增的关系加入，共同祖先中有而源分支已删除的关系移除
+	relations := make(map[string]interfaces.ConceptGroupRelation, len(target.relations))
+	for key, relation := range target.relations {
+		if _, exist := source.relations[key]; exist || !base.hasRelation(key, relation) {
+			relations[key] = relation
+		}
+	}
+	for key, relation := range source.relations {
+		if _, exist := target.relations[key]; !exist && !base.hasRelation(key, relation) {
+			relations[key] = relation
+		}
+	}
+	// 去掉分组或概念已不存在的关系
+	finalConcepts := map[string]map[string]*branchConcept{
+		interfaces.MODULE_TYPE_OBJECT_TYPE:   finalOTs,
+		interfaces.MODULE_TYPE_RELATION_TYPE: finalRTs,
+		interfaces.MODULE_TYPE_ACTION_TYPE:   finalATs,
+	}
+	for key, relation := range relations {
+		if _, exist := finalCGs[relation.CGID]; !exist {
+			delete(relations, key)
+			continue
+		}
+		if concepts, ok := finalConcepts[relation.ConceptType]; ok {
+			if _, exist := concepts[relation.ConceptID]; !exist {
+				delete(relations, key)
+			}
+		}
+	}
+	plan.relations = relations
+	plan.relationsChanged = !sameKeys(relations, target.relations)
+
+	// 合入结果中分组成员的变化记为分组的修改
+	diffGroupMembers(&plan.conceptGroups.diff, tgtCGs, finalCGs, target.relations, relations)
+
+	return plan, nil
+}
+
+// 单类概念的三方合并
+func mergeConcepts(moduleType string, source, target map[string]*branchConcept, base *branchMergeBase, strategy string) *conceptMergePlan {
+	plan := &conceptMergePlan{
+		diff:      newConceptDiff(),
+		conflicts: []interfaces.KNBranchConflict{},
+	}
+	addConflict := func(c *branchConcept, conflictType string) {
+		plan.conflicts = append(plan.conflicts, interfaces.KNBranchConflict{
+			ModuleType:   moduleType,
+			ID:           c.id,
+			Name:         c.name,
+			ConflictType: conflictType,
+		})
+	}
+	upsert := func(src *branchConcept, tgt *branchConcept) {
+		plan.upserts = append(plan.upserts, src.id)
+		if tgt == nil {
+			plan.diff.Added = append(plan.diff.Added, interfaces.ConceptChange{ID: src.id, Name: src.name})
+		} else {
+			plan.diff.Modified = append(plan.diff.Modified, interfaces.ConceptChange{
+				ID:     src.id,
+				Name:   src.name,
+				Fields: changedFields(src, tgt),
+			})
+		}
+	}
+	remove := func(tgt *branchConcept) {
+		plan.removes = append(plan.removes, tgt.id)
+		plan.diff.Removed = append(plan.diff.Removed, interfaces.ConceptChange{ID: tgt.id, Name: tgt.name})
+	}
+
+	for _, id := range sortedKeys(source) {
+		src := source[id]
+		tgt, exist := target[id]
+		switch {
+		case exist:
+			if len(changedFields(src, tgt)) == 0 {
+				continue
+			}
+			switch {
+			case src.updateTime > base.time && tgt.updateTime > base.time:
+				addConflict(src, interfaces.CONFLICT_TYPE_BOTH_MODIFIED)
+				if strategy == interfaces.CONFLICT_STRATEGY_SOURCE {
+					upsert(src, tgt)
+				}
+			case src.updateTime > base.time:
+				upsert(src, tgt)
+			default:
+				// 只有目标分支修改过，或差异已在上一次合并时处理，保留目标分支
+			}
+		case !base.hasConcept(moduleType, src):
+			upsert(src, nil)
+		case src.updateTime > base.time:
+			addConflict(src, interfaces.CONFLICT_TYPE_MODIFIED_AND_REMOVED)
+			if strategy == interfaces.CONFLICT_STRATEGY_SOURCE {
+				upsert(src, nil)
+			}
+		}
+		// 其余情况为目标分支删除、源分支未改动，保持删除
+	}
+
+	for _, id := range sortedKeys(target) {
+		tgt := target[id]
+		if _, exist := source[id]; exist {
+			continue
+		}
+		switch {
+		case !base.hasConcept(moduleType, tgt):
+			// 目标分支上新增，保留
+		case tgt.updateTime > base.time:
+			addConflict(tgt, interfaces.CONFLICT_TYPE_REMOVED_AND_MODIFIED)
+			if strategy == interfaces.CONFLICT_STRATEGY_SOURCE {
+				remove(tgt)
+			}
+		default:
+			remove(tgt)
+		}
+	}
+
+	return plan
+}
+
+// 得到合并的共同祖先。合并记录早于分叉时间时说明分支删除后重建过，记录不再有效
+func newBranchMergeBase(source, target *branchSnapshot, record *interfaces.KNBranchMergeBase) *branchMergeBase {
+	forkTime := max(source.kn.CreateTime, target.kn.CreateTime)
+	if record == nil || record.MergeTime <= forkTime {
+		return &branchMergeBase{time: forkTime}
+	}
+
+	base := &branchMergeBase{
+		time:      record.MergeTime,
+		concepts:  map[string]map[string]bool{},
+		relations: make(map[string]bool, len(record.Relations)),
+	}
+	for moduleType, ids := range record.Concepts {
+		base.concepts[moduleType] = make(map[string]bool, len(ids))
+		for _, id := range ids {
+			base.concepts[moduleType][id] = true
+		}
+	}
+	for _, key := range record.Relations {
+		base.relations[key] = true
+	}
+	return base
+}
+
+// 概念是否在共同祖先中
+func (b *branchMergeBase) hasConcept(moduleType string, c *branchConcept) bool {
+	if b.concepts == nil {
+		return c.createTime <= b.time
+	}
+	return b.concepts[moduleType][c.id]
+}
+
+// 分组关系是否在共同祖先中
+func (b *branchMergeBase) hasRelation(key string, relation interfaces.ConceptGroupRelation) bool {
+	if b.concepts == nil {
+		return relation.CreateTime <= b.time
+	}
+	return b.relations[key]
+}
+
+// 以合并时源分支上的概念和分组关系生成合并记录
+func newMergeBaseRecord(source *branchSnapshot, targetBranch string, mergeTime int64) *interfaces.KNBranchMergeBase {
+	return &interfaces.KNBranchMergeBase{
+		KNID:         source.kn.KNID,
+		Branch:       source.kn.Branch,
+		TargetBranch: targetBranch,
+		MergeTime:    mergeTime,
+		Concepts: map[string][]string{
+			interfaces.MODULE_TYPE_OBJECT_TYPE:   sortedKeys(source.objectTypes),
+			interfaces.MODULE_TYPE_RELATION_TYPE: sortedKeys(source.relationTypes),
+			interfaces.MODULE_TYPE_ACTION_TYPE:   sortedKeys(source.actionTypes),
+			interfaces.MODULE_TYPE_CONCEPT_GROUP: sortedKeys(source.conceptGroups),
+		},
+		Relations: sortedKeys(source.relations),
+	}
+}
+
+// 按合并计划得到目标分支合并后的概念
+func mergedConcepts(plan *conceptMergePlan, source, target map[string]*branchConcept) map[string]*branchConcept {
+	merged := make(map[string]*branchConcept, len(target))
+	for id, c := range target {
+		merged[id] = c
+	}
+	for _, id := range plan.removes {
+		delete(merged, id)
+	}
+	for _, id := range plan.upserts {
+		merged[id] = source[id]
+	}
+	return merged
+}
+
+func duplicatedNames(moduleType string, concepts map[string]*branchConcept) []interfaces.KNBranchConflict {
+	nameCount := map[string]int{}
+	for _, c := range concepts {
+		nameCount[c.name]++
+	}
+
+	conflicts := []interfaces.KNBranchConflict{}
+	for _, id := range sortedKeys(concepts) {
+		c := concepts[id]
+		if nameCount[c.name] > 1 {
+			conflicts = append(conflicts, interfaces.KNBranchConflict{
+				ModuleType:   moduleType,
+				ID:           c.id,
+				Name:         c.name,
+				ConflictType: interfaces.CONFLICT_TYPE_NAME_DUPLICATED,
+			})
+		}
+	}
+	return conflicts
+}
+
+// 目标分支上需要先删除的概念：被移除的，以及将被源分支版本覆盖的
+func idsToDelete[T any](plan *conceptMergePlan, target map[string]T) []string {
+	ids := append([]string{}, plan.removes...)
+	for _, id := range plan.upserts {
+		if _, exist := target[id]; exist {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (p *branchMergePlan) isEmpty() bool {
+	for _, plan := range []*conceptMergePlan{p.objectTypes, p.relationTypes, p.actionTypes, p.conceptGroups} {
+		if len(plan.upserts) > 0 || len(plan.removes) > 0 {
+			return false
+		}
+	}
+	return !p.relationsChanged
+}
+
+func newConceptDiff() interfaces.ConceptDiff {
+	return interfaces.ConceptDiff{
+		Added:    []interfaces.ConceptChange{},
+		Removed:  []interfaces.ConceptChange{},
+		Modified: []interfaces.ConceptChange{},
+	}
+}
+
+func relationKey(relation interfaces.ConceptGroupRelation) string {
+	return fmt.Sprintf("%s/%s/%s", relation.CGID, relation.ConceptType, relation.ConceptID)
+}
+
+// 以分组id为key的成员列表，成员为 概念类型/概念id
+func groupMembers(relations map[string]interfaces.ConceptGroupRelation) map[string][]string {
+	members := map[string][]string{}
+	for _, key := range sortedKeys(relations) {
+		relation := relations[key]
+		members[relation.CGID] = append(members[relation.CGID],
+			fmt.Sprintf("%s/%s", relation.ConceptType, relation.ConceptID))
+	}
+	return members
+}
+
+func sameKeys[T any](a, b map[string]T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key := range a {
+		if _, exist := b[key]; !exist {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
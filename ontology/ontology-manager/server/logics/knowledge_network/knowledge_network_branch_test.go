@@ -0,0 +1,722 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package knowledge_network
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"ontology-manager/common"
+	oerrors "ontology-manager/errors"
+	"ontology-manager/interfaces"
+	dmock "ontology-manager/interfaces/mock"
+)
+
+func newBranchTestOT(id string, name string, createTime int64, updateTime int64) *interfaces.ObjectType {
+	return &interfaces.ObjectType{
+		ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+			OTID:        id,
+			OTName:      name,
+			PrimaryKeys: []string{"id"},
+			DisplayKey:  "name",
+		},
+		KNID:       "kn1",
+		CreateTime: createTime,
+		UpdateTime: updateTime,
+	}
+}
+
+func newBranchTestRT(id string, name string, createTime int64, updateTime int64) *interfaces.RelationType {
+	return &interfaces.RelationType{
+		RelationTypeWithKeyField: interfaces.RelationTypeWithKeyField{
+			RTID:               id,
+			RTName:             name,
+			SourceObjectTypeID: "ot1",
+			TargetObjectTypeID: "ot1",
+			Type:               "direct",
+		},
+		KNID:       "kn1",
+		CreateTime: createTime,
+		UpdateTime: updateTime,
+	}
+}
+
+func newBranchTestAT(id string, name string, createTime int64, updateTime int64) *interfaces.ActionType {
+	return &interfaces.ActionType{
+		ActionTypeWithKeyField: interfaces.ActionTypeWithKeyField{
+			ATID:         id,
+			ATName:       name,
+			ObjectTypeID: "ot1",
+		},
+		KNID:       "kn1",
+		CreateTime: createTime,
+		UpdateTime: updateTime,
+	}
+}
+
+func Test_knowledgeNetworkService_CreateKNBranch(t *testing.T) {
+	Convey("Test CreateKNBranch\n", t, func() {
+		ctx := context.Background()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		ps := dmock.NewMockPermissionService(mockCtrl)
+		kna := dmock.NewMockKNAccess(mockCtrl)
+		ota := dmock.NewMockObjectTypeAccess(mockCtrl)
+		rta := dmock.NewMockRelationTypeAccess(mockCtrl)
+		ata := dmock.NewMockActionTypeAccess(mockCtrl)
+		cga := dmock.NewMockConceptGroupAccess(mockCtrl)
+		csr := dmock.NewMockConceptSyncer(mockCtrl)
+
+		db, smock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+
+		service := &knowledgeNetworkService{
+			appSetting: &common.AppSetting{},
+			db:         db,
+			ps:         ps,
+			kna:        kna,
+			ota:        ota,
+			rta:        rta,
+			ata:        ata,
+			cga:        cga,
+			csr:        csr,
+		}
+
+		mainKN := &interfaces.KN{KNID: "kn1", KNName: "kn1", Branch: interfaces.MAIN_BRANCH, CreateTime: 100}
+
+		expectMainSnapshot := func() {
+			kna.EXPECT().GetKNByID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(mainKN, nil)
+			ota.EXPECT().GetAllObjectTypesByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(
+				map[string]*interfaces.ObjectType{"ot1": newBranchTestOT("ot1", "ot1", 100, 100)}, nil)
+			rta.EXPECT().GetAllRelationTypesByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(
+				map[string]*interfaces.RelationType{"rt1": newBranchTestRT("rt1", "rt1", 100, 100)}, nil)
+			ata.EXPECT().GetAllActionTypesByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(
+				map[string]*interfaces.ActionType{"at1": newBranchTestAT("at1", "at1", 100, 100)}, nil)
+			cga.EXPECT().GetAllConceptGroupsByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(
+				map[string]*interfaces.ConceptGroup{"cg1": {CGID: "cg1", CGName: "cg1", KNID: "kn1"}}, nil)
+			cga.EXPECT().ListConceptGroupRelations(gomock.Any(), nil, gomock.Any()).Return(
+				[]interfaces.ConceptGroupRelation{{ID: "r1", KNID: "kn1", CGID: "cg1",
+					ConceptType: interfaces.MODULE_TYPE_OBJECT_TYPE, ConceptID: "ot1", CreateTime: 100}}, nil)
+		}
+
+		Convey("Success creating branch\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().CheckKNExistByID(gomock.Any(), "kn1", "dev").Return("", false, nil)
+			expectMainSnapshot()
+
+			smock.ExpectBegin()
+			kna.EXPECT().CreateKN(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, tx any, kn *interfaces.KN) error {
+					So(kn.Branch, ShouldEqual, "dev")
+					So(kn.CreateTime, ShouldBeGreaterThan, mainKN.CreateTime)
+					return nil
+				})
+			ota.EXPECT().CreateObjectType(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, tx any, ot *interfaces.ObjectType) error {
+					So(ot.Branch, ShouldEqual, "dev")
+					So(ot.UpdateTime, ShouldEqual, 100)
+					return nil
+				})
+			ota.EXPECT().CreateObjectTypeStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			rta.EXPECT().CreateRelationType(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ata.EXPECT().CreateActionType(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			cga.EXPECT().CreateConceptGroup(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			cga.EXPECT().CreateConceptGroupRelation(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, tx any, relation *interfaces.ConceptGroupRelation) error {
+					So(relation.ID, ShouldNotEqual, "r1")
+					So(relation.Branch, ShouldEqual, "dev")
+					So(relation.CreateTime, ShouldEqual, 100)
+					return nil
+				})
+			smock.ExpectCommit()
+			csr.EXPECT().SyncKN(gomock.Any(), "kn1", "dev").Return(nil)
+
+			err := service.CreateKNBranch(ctx, "kn1", "dev")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Success even if sync fails\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().CheckKNExistByID(gomock.Any(), "kn1", "dev").Return("", false, nil)
+			expectMainSnapshot()
+
+			smock.ExpectBegin()
+			kna.EXPECT().CreateKN(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ota.EXPECT().CreateObjectType(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ota.EXPECT().CreateObjectTypeStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			rta.EXPECT().CreateRelationType(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ata.EXPECT().CreateActionType(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			cga.EXPECT().CreateConceptGroup(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			cga.EXPECT().CreateConceptGroupRelation(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			smock.ExpectCommit()
+			csr.EXPECT().SyncKN(gomock.Any(), "kn1", "dev").Return(errors.New("sync error"))
+
+			err := service.CreateKNBranch(ctx, "kn1", "dev")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Failed when permission check fails\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				rest.NewHTTPError(ctx, http.StatusForbidden, rest.PublicError_Forbidden))
+
+			err := service.CreateKNBranch(ctx, "kn1", "dev")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Failed when branch exists\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().CheckKNExistByID(gomock.Any(), "kn1", "dev").Return("kn1", true, nil)
+
+			err := service.CreateKNBranch(ctx, "kn1", "dev")
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_BranchExisted)
+		})
+
+		Convey("Failed when knowledge network not found\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().CheckKNExistByID(gomock.Any(), "kn1", "dev").Return("", false, nil)
+			kna.EXPECT().GetKNByID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(nil, nil)
+
+			err := service.CreateKNBranch(ctx, "kn1", "dev")
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_NotFound)
+		})
+
+		Convey("Failed and rollback when create concept fails\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().CheckKNExistByID(gomock.Any(), "kn1", "dev").Return("", false, nil)
+			expectMainSnapshot()
+
+			smock.ExpectBegin()
+			kna.EXPECT().CreateKN(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ota.EXPECT().CreateObjectType(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			smock.ExpectRollback()
+
+			err := service.CreateKNBranch(ctx, "kn1", "dev")
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_InternalError_ForkBranchFailed)
+		})
+	})
+}
+
+func Test_knowledgeNetworkService_ListKNBranches(t *testing.T) {
+	Convey("Test ListKNBranches\n", t, func() {
+		ctx := context.Background()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		ps := dmock.NewMockPermissionService(mockCtrl)
+		kna := dmock.NewMockKNAccess(mockCtrl)
+		uma := dmock.NewMockUserMgmtAccess(mockCtrl)
+
+		service := &knowledgeNetworkService{
+			appSetting: &common.AppSetting{},
+			ps:         ps,
+			kna:        kna,
+			uma:        uma,
+		}
+
+		Convey("Success listing branches\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().ListKNBranches(gomock.Any(), "kn1").Return([]*interfaces.KN{
+				{KNID: "kn1", Branch: interfaces.MAIN_BRANCH, CreateTime: 100},
+				{KNID: "kn1", Branch: "dev", CreateTime: 200},
+			}, nil)
+			uma.EXPECT().GetAccountNames(gomock.Any(), gomock.Any()).Return(nil)
+
+			branches, err := service.ListKNBranches(ctx, "kn1")
+			So(err, ShouldBeNil)
+			So(len(branches), ShouldEqual, 2)
+			So(branches[1].Name, ShouldEqual, "dev")
+			So(branches[1].CreateTime, ShouldEqual, 200)
+		})
+
+		Convey("Failed when knowledge network not found\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().ListKNBranches(gomock.Any(), "kn1").Return([]*interfaces.KN{}, nil)
+
+			_, err := service.ListKNBranches(ctx, "kn1")
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_NotFound)
+		})
+
+		Convey("Failed when access layer returns error\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().ListKNBranches(gomock.Any(), "kn1").Return([]*interfaces.KN{}, errors.New("db error"))
+
+			_, err := service.ListKNBranches(ctx, "kn1")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_knowledgeNetworkService_DiffKNBranch(t *testing.T) {
+	Convey("Test DiffKNBranch\n", t, func() {
+		ctx := context.Background()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		ps := dmock.NewMockPermissionService(mockCtrl)
+		kna := dmock.NewMockKNAccess(mockCtrl)
+		ota := dmock.NewMockObjectTypeAccess(mockCtrl)
+		rta := dmock.NewMockRelationTypeAccess(mockCtrl)
+		ata := dmock.NewMockActionTypeAccess(mockCtrl)
+		cga := dmock.NewMockConceptGroupAccess(mockCtrl)
+
+		service := &knowledgeNetworkService{
+			appSetting: &common.AppSetting{},
+			ps:         ps,
+			kna:        kna,
+			ota:        ota,
+			rta:        rta,
+			ata:        ata,
+			cga:        cga,
+		}
+
+		Convey("Success diffing branches\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			modifiedOT := newBranchTestOT("ot2", "ot2", 100, 300)
+			modifiedOT.DisplayKey = "title"
+			modifiedOT.Detail = "detail is not compared"
+
+			kna.EXPECT().GetKNByID(gomock.Any(), "kn1", "dev").Return(&interfaces.KN{KNID: "kn1", Branch: "dev", CreateTime: 200}, nil)
+			ota.EXPECT().GetAllObjectTypesByKnID(gomock.Any(), "kn1", "dev").Return(map[string]*interfaces.ObjectType{
+				"ot1": newBranchTestOT("ot1", "ot1", 100, 100),
+				"ot2": modifiedOT,
+				"ot3": newBranchTestOT("ot3", "ot3", 300, 300),
+			}, nil)
+			rta.EXPECT().GetAllRelationTypesByKnID(gomock.Any(), "kn1", "dev").Return(map[string]*interfaces.RelationType{}, nil)
+			ata.EXPECT().GetAllActionTypesByKnID(gomock.Any(), "kn1", "dev").Return(map[string]*interfaces.ActionType{}, nil)
+			cga.EXPECT().GetAllConceptGroupsByKnID(gomock.Any(), "kn1", "dev").Return(map[string]*interfaces.ConceptGroup{
+				"cg1": {CGID: "cg1", CGName: "cg1"},
+			}, nil)
+			cga.EXPECT().ListConceptGroupRelations(gomock.Any(), nil, gomock.Any()).Return([]interfaces.ConceptGroupRelation{
+				{CGID: "cg1", ConceptType: interfaces.MODULE_TYPE_OBJECT_TYPE, ConceptID: "ot3", CreateTime: 300},
+			}, nil)
+
+			kna.EXPECT().GetKNByID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(&interfaces.KN{KNID: "kn1", Branch: interfaces.MAIN_BRANCH, CreateTime: 50}, nil)
+			ota.EXPECT().GetAllObjectTypesByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(map[string]*interfaces.ObjectType{
+				"ot1": newBranchTestOT("ot1", "ot1", 100, 100),
+				"ot2": newBranchTestOT("ot2", "ot2", 100, 100),
+				"ot4": newBranchTestOT("ot4", "ot4", 100, 100),
+			}, nil)
+			rta.EXPECT().GetAllRelationTypesByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(map[string]*interfaces.RelationType{}, nil)
+			ata.EXPECT().GetAllActionTypesByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(map[string]*interfaces.ActionType{}, nil)
+			cga.EXPECT().GetAllConceptGroupsByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(map[string]*interfaces.ConceptGroup{
+				"cg1": {CGID: "cg1", CGName: "cg1"},
+			}, nil)
+			cga.EXPECT().ListConceptGroupRelations(gomock.Any(), nil, gomock.Any()).Return([]interfaces.ConceptGroupRelation{}, nil)
+
+			diff, err := service.DiffKNBranch(ctx, "kn1", "dev", interfaces.MAIN_BRANCH)
+			So(err, ShouldBeNil)
+			So(diff.ObjectTypes.Added, ShouldResemble, []interfaces.ConceptChange{{ID: "ot3", Name: "ot3"}})
+			So(diff.ObjectTypes.Removed, ShouldResemble, []interfaces.ConceptChange{{ID: "ot4", Name: "ot4"}})
+			So(diff.ObjectTypes.Modified, ShouldResemble, []interfaces.ConceptChange{{ID: "ot2", Name: "ot2", Fields: []string{"display_key"}}})
+			So(diff.ConceptGroups.Modified, ShouldResemble, []interfaces.ConceptChange{{ID: "cg1", Name: "cg1", Fields: []string{GROUP_MEMBERS_FIELD}}})
+		})
+
+		Convey("Failed when branch not found\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().GetKNByID(gomock.Any(), "kn1", "dev").Return(nil, nil)
+
+			_, err := service.DiffKNBranch(ctx, "kn1", "dev", interfaces.MAIN_BRANCH)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_BranchNotFound)
+		})
+
+		Convey("Failed when get concepts fails\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().GetKNByID(gomock.Any(), "kn1", "dev").Return(&interfaces.KN{KNID: "kn1", Branch: "dev"}, nil)
+			ota.EXPECT().GetAllObjectTypesByKnID(gomock.Any(), "kn1", "dev").Return(nil, errors.New("db error"))
+
+			_, err := service.DiffKNBranch(ctx, "kn1", "dev", interfaces.MAIN_BRANCH)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_InternalError_GetBranchConceptsFailed)
+		})
+	})
+}
+
+func Test_knowledgeNetworkService_MergeKNBranch(t *testing.T) {
+	Convey("Test MergeKNBranch\n", t, func() {
+		ctx := context.Background()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		ps := dmock.NewMockPermissionService(mockCtrl)
+		kna := dmock.NewMockKNAccess(mockCtrl)
+		ota := dmock.NewMockObjectTypeAccess(mockCtrl)
+		rta := dmock.NewMockRelationTypeAccess(mockCtrl)
+		ata := dmock.NewMockActionTypeAccess(mockCtrl)
+		cga := dmock.NewMockConceptGroupAccess(mockCtrl)
+		osa := dmock.NewMockOpenSearchAccess(mockCtrl)
+		csr := dmock.NewMockConceptSyncer(mockCtrl)
+
+		db, smock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+
+		service := &knowledgeNetworkService{
+			appSetting: &common.AppSetting{},
+			db:         db,
+			ps:         ps,
+			kna:        kna,
+			ota:        ota,
+			rta:        rta,
+			ata:        ata,
+			cga:        cga,
+			osa:        osa,
+			csr:        csr,
+		}
+
+		// 分叉时间为 200：
+		// ot1 两侧均未修改；ot2 两侧都修改，冲突；rt1 源分支新增；at1 源分支删除
+		bothModifiedSrc := newBranchTestOT("ot2", "ot2", 100, 300)
+		bothModifiedSrc.DisplayKey = "src"
+		bothModifiedTgt := newBranchTestOT("ot2", "ot2", 100, 400)
+		bothModifiedTgt.DisplayKey = "tgt"
+
+		expectSnapshots := func() {
+			kna.EXPECT().GetKNByID(gomock.Any(), "kn1", "dev").Return(&interfaces.KN{KNID: "kn1", Branch: "dev", CreateTime: 200}, nil)
+			ota.EXPECT().GetAllObjectTypesByKnID(gomock.Any(), "kn1", "dev").Return(map[string]*interfaces.ObjectType{
+				"ot1": newBranchTestOT("ot1", "ot1", 100, 100),
+				"ot2": bothModifiedSrc,
+			}, nil)
+			rta.EXPECT().GetAllRelationTypesByKnID(gomock.Any(), "kn1", "dev").Return(map[string]*interfaces.RelationType{
+				"rt1": newBranchTestRT("rt1", "rt1", 300, 300),
+			}, nil)
+			ata.EXPECT().GetAllActionTypesByKnID(gomock.Any(), "kn1", "dev").Return(map[string]*interfaces.ActionType{}, nil)
+			cga.EXPECT().GetAllConceptGroupsByKnID(gomock.Any(), "kn1", "dev").Return(map[string]*interfaces.ConceptGroup{}, nil)
+			cga.EXPECT().ListConceptGroupRelations(gomock.Any(), nil, gomock.Any()).Return([]interfaces.ConceptGroupRelation{}, nil)
+
+			kna.EXPECT().GetKNByID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(&interfaces.KN{KNID: "kn1", Branch: interfaces.MAIN_BRANCH, CreateTime: 50}, nil)
+			ota.EXPECT().GetAllObjectTypesByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(map[string]*interfaces.ObjectType{
+				"ot1": newBranchTestOT("ot1", "ot1", 100, 100),
+				"ot2": bothModifiedTgt,
+			}, nil)
+			rta.EXPECT().GetAllRelationTypesByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(map[string]*interfaces.RelationType{}, nil)
+			ata.EXPECT().GetAllActionTypesByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(map[string]*interfaces.ActionType{
+				"at1": newBranchTestAT("at1", "at1", 100, 100),
+			}, nil)
+			cga.EXPECT().GetAllConceptGroupsByKnID(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(map[string]*interfaces.ConceptGroup{}, nil)
+			cga.EXPECT().ListConceptGroupRelations(gomock.Any(), nil, gomock.Any()).Return([]interfaces.ConceptGroupRelation{}, nil)
+		}
+
+		Convey("Abort when conflicts exist\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			expectSnapshots()
+			kna.EXPECT().GetKNBranchMergeBase(gomock.Any(), "kn1", "dev", interfaces.MAIN_BRANCH).Return(nil, nil)
+
+			_, err := service.MergeKNBranch(ctx, "kn1", "dev", interfaces.KNBranchMerge{
+				TargetBranch:     interfaces.MAIN_BRANCH,
+				ConflictStrategy: interfaces.CONFLICT_STRATEGY_ABORT,
+			})
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.HTTPCode, ShouldEqual, http.StatusConflict)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_Conflict_BranchMerge)
+		})
+
+		Convey("Success merging with source strategy\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			expectSnapshots()
+			kna.EXPECT().GetKNBranchMergeBase(gomock.Any(), "kn1", "dev", interfaces.MAIN_BRANCH).Return(nil, nil)
+
+			smock.ExpectBegin()
+			ota.EXPECT().DeleteObjectTypesByIDs(gomock.Any(), gomock.Any(), "kn1", interfaces.MAIN_BRANCH, []string{"ot2"}).Return(int64(1), nil)
+			ata.EXPECT().DeleteActionTypesByIDs(gomock.Any(), gomock.Any(), "kn1", interfaces.MAIN_BRANCH, []string{"at1"}).Return(int64(1), nil)
+			ota.EXPECT().CreateObjectType(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, tx any, ot *interfaces.ObjectType) error {
+					So(ot.Branch, ShouldEqual, interfaces.MAIN_BRANCH)
+					So(ot.DisplayKey, ShouldEqual, "src")
+					return nil
+				})
+			rta.EXPECT().CreateRelationType(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().UpdateKN(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().UpdateKNBranchMergeBase(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, tx any, base *interfaces.KNBranchMergeBase) error {
+					So(base.Branch, ShouldEqual, "dev")
+					So(base.TargetBranch, ShouldEqual, interfaces.MAIN_BRANCH)
+					So(base.Concepts[interfaces.MODULE_TYPE_OBJECT_TYPE], ShouldResemble, []string{"ot1", "ot2"})
+					So(base.Concepts[interfaces.MODULE_TYPE_RELATION_TYPE], ShouldResemble, []string{"rt1"})
+					return nil
+				})
+			smock.ExpectCommit()
+			osa.EXPECT().DeleteData(gomock.Any(), interfaces.KN_CONCEPT_INDEX_NAME,
+				interfaces.GenerateConceptDocuemtnID("kn1", interfaces.MODULE_TYPE_ACTION_TYPE, "at1", interfaces.MAIN_BRANCH)).Return(nil)
+			csr.EXPECT().SyncKN(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(nil)
+
+			result, err := service.MergeKNBranch(ctx, "kn1", "dev", interfaces.KNBranchMerge{
+				TargetBranch:     interfaces.MAIN_BRANCH,
+				ConflictStrategy: interfaces.CONFLICT_STRATEGY_SOURCE,
+			})
+			So(err, ShouldBeNil)
+			So(result.Conflicts, ShouldResemble, []interfaces.KNBranchConflict{{
+				ModuleType:   interfaces.MODULE_TYPE_OBJECT_TYPE,
+				ID:           "ot2",
+				Name:         "ot2",
+				ConflictType: interfaces.CONFLICT_TYPE_BOTH_MODIFIED,
+			}})
+			So(result.ObjectTypes.Modified, ShouldResemble, []interfaces.ConceptChange{{ID: "ot2", Name: "ot2", Fields: []string{"display_key"}}})
+			So(result.RelationTypes.Added, ShouldResemble, []interfaces.ConceptChange{{ID: "rt1", Name: "rt1"}})
+			So(result.ActionTypes.Removed, ShouldResemble, []interfaces.ConceptChange{{ID: "at1", Name: "at1"}})
+		})
+
+		Convey("Failed and rollback when apply fails\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			expectSnapshots()
+			kna.EXPECT().GetKNBranchMergeBase(gomock.Any(), "kn1", "dev", interfaces.MAIN_BRANCH).Return(nil, nil)
+
+			smock.ExpectBegin()
+			ota.EXPECT().DeleteObjectTypesByIDs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db error"))
+			smock.ExpectRollback()
+
+			_, err := service.MergeKNBranch(ctx, "kn1", "dev", interfaces.KNBranchMerge{
+				TargetBranch:     interfaces.MAIN_BRANCH,
+				ConflictStrategy: interfaces.CONFLICT_STRATEGY_SOURCE,
+			})
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_InternalError_MergeBranchFailed)
+		})
+
+		Convey("Merge the same branch twice from the last merge base\n", func() {
+			// 分叉时间为 200：ot2 在源分支新增，ot3 在目标分支新增。
+			// 第一次合并后目标分支删除了合入的 ot2，第二次合并时源分支没有新的变更，不应重新合入 ot2，也不应删除 ot3
+			expectSnapshot := func(branch string, createTime int64, ots map[string]*interfaces.ObjectType) {
+				kna.EXPECT().GetKNByID(gomock.Any(), "kn1", branch).Return(&interfaces.KN{KNID: "kn1", Branch: branch, CreateTime: createTime}, nil)
+				ota.EXPECT().GetAllObjectTypesByKnID(gomock.Any(), "kn1", branch).Return(ots, nil)
+				rta.EXPECT().GetAllRelationTypesByKnID(gomock.Any(), "kn1", branch).Return(map[string]*interfaces.RelationType{}, nil)
+				ata.EXPECT().GetAllActionTypesByKnID(gomock.Any(), "kn1", branch).Return(map[string]*interfaces.ActionType{}, nil)
+				cga.EXPECT().GetAllConceptGroupsByKnID(gomock.Any(), "kn1", branch).Return(map[string]*interfaces.ConceptGroup{}, nil)
+				cga.EXPECT().ListConceptGroupRelations(gomock.Any(), nil, gomock.Any()).Return([]interfaces.ConceptGroupRelation{}, nil)
+			}
+			devOTs := func() map[string]*interfaces.ObjectType {
+				return map[string]*interfaces.ObjectType{
+					"ot1": newBranchTestOT("ot1", "ot1", 100, 100),
+					"ot2": newBranchTestOT("ot2", "ot2", 300, 300),
+				}
+			}
+
+			// 第一次合并
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			expectSnapshot("dev", 200, devOTs())
+			expectSnapshot(interfaces.MAIN_BRANCH, 50, map[string]*interfaces.ObjectType{
+				"ot1": newBranchTestOT("ot1", "ot1", 100, 100),
+				"ot3": newBranchTestOT("ot3", "ot3", 250, 250),
+			})
+			kna.EXPECT().GetKNBranchMergeBase(gomock.Any(), "kn1", "dev", interfaces.MAIN_BRANCH).Return(nil, nil)
+
+			var mergeBase *interfaces.KNBranchMergeBase
+			smock.ExpectBegin()
+			ota.EXPECT().CreateObjectType(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ota.EXPECT().CreateObjectTypeStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().UpdateKN(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			kna.EXPECT().UpdateKNBranchMergeBase(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, tx any, base *interfaces.KNBranchMergeBase) error {
+					mergeBase = base
+					return nil
+				})
+			smock.ExpectCommit()
+			csr.EXPECT().SyncKN(gomock.Any(), "kn1", interfaces.MAIN_BRANCH).Return(nil)
+
+			result, err := service.MergeKNBranch(ctx, "kn1", "dev", interfaces.KNBranchMerge{
+				TargetBranch:     interfaces.MAIN_BRANCH,
+				ConflictStrategy: interfaces.CONFLICT_STRATEGY_ABORT,
+			})
+			So(err, ShouldBeNil)
+			So(result.ObjectTypes.Added, ShouldResemble, []interfaces.ConceptChange{{ID: "ot2", Name: "ot2"}})
+			So(result.ObjectTypes.Removed, ShouldBeEmpty)
+			So(mergeBase, ShouldNotBeNil)
+			So(mergeBase.MergeTime, ShouldBeGreaterThan, 300)
+
+			// 第二次合并
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			expectSnapshot("dev", 200, devOTs())
+			expectSnapshot(interfaces.MAIN_BRANCH, 50, map[string]*interfaces.ObjectType{
+				"ot1": newBranchTestOT("ot1", "ot1", 100, 100),
+				"ot3": newBranchTestOT("ot3", "ot3", 250, 250),
+			})
+			kna.EXPECT().GetKNBranchMergeBase(gomock.Any(), "kn1", "dev", interfaces.MAIN_BRANCH).Return(mergeBase, nil)
+
+			result, err = service.MergeKNBranch(ctx, "kn1", "dev", interfaces.KNBranchMerge{
+				TargetBranch:     interfaces.MAIN_BRANCH,
+				ConflictStrategy: interfaces.CONFLICT_STRATEGY_ABORT,
+			})
+			So(err, ShouldBeNil)
+			So(result.Conflicts, ShouldBeEmpty)
+			So(result.ObjectTypes.Added, ShouldBeEmpty)
+			So(result.ObjectTypes.Removed, ShouldBeEmpty)
+			So(result.ObjectTypes.Modified, ShouldBeEmpty)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("Failed when get merge base fails\n", func() {
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			expectSnapshots()
+			kna.EXPECT().GetKNBranchMergeBase(gomock.Any(), "kn1", "dev", interfaces.MAIN_BRANCH).Return(nil, errors.New("db error"))
+
+			_, err := service.MergeKNBranch(ctx, "kn1", "dev", interfaces.KNBranchMerge{
+				TargetBranch:     interfaces.MAIN_BRANCH,
+				ConflictStrategy: interfaces.CONFLICT_STRATEGY_SOURCE,
+			})
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_InternalError_MergeBranchFailed)
+		})
+	})
+}
+
+func Test_mergeConcepts(t *testing.T) {
+	Convey("Test mergeConcepts\n", t, func() {
+		concept := func(id string, name string, value string, createTime int64, updateTime int64) *branchConcept {
+			return &branchConcept{
+				id:         id,
+				name:       name,
+				content:    map[string]any{"name": name, "value": value},
+				createTime: createTime,
+				updateTime: updateTime,
+			}
+		}
+		base := &branchMergeBase{time: 200}
+
+		Convey("Apply source when only source modified\n", func() {
+			plan := mergeConcepts(interfaces.MODULE_TYPE_OBJECT_TYPE,
+				map[string]*branchConcept{"a": concept("a", "a", "new", 100, 300)},
+				map[string]*branchConcept{"a": concept("a", "a", "old", 100, 100)},
+				base, interfaces.CONFLICT_STRATEGY_ABORT)
+			So(plan.upserts, ShouldResemble, []string{"a"})
+			So(plan.conflicts, ShouldBeEmpty)
+		})
+
+		Convey("Keep target when only target modified\n", func() {
+			plan := mergeConcepts(interfaces.MODULE_TYPE_OBJECT_TYPE,
+				map[string]*branchConcept{"a": concept("a", "a", "old", 100, 100)},
+				map[string]*branchConcept{"a": concept("a", "a", "new", 100, 300)},
+				base, interfaces.CONFLICT_STRATEGY_ABORT)
+			So(plan.upserts, ShouldBeEmpty)
+			So(plan.conflicts, ShouldBeEmpty)
+		})
+
+		Convey("Keep target removal when source not modified\n", func() {
+			plan := mergeConcepts(interfaces.MODULE_TYPE_OBJECT_TYPE,
+				map[string]*branchConcept{"a": concept("a", "a", "old", 100, 100)},
+				map[string]*branchConcept{},
+				base, interfaces.CONFLICT_STRATEGY_ABORT)
+			So(plan.upserts, ShouldBeEmpty)
+			So(plan.conflicts, ShouldBeEmpty)
+		})
+
+		Convey("Conflict when source modified and target removed\n", func() {
+			plan := mergeConcepts(interfaces.MODULE_TYPE_OBJECT_TYPE,
+				map[string]*branchConcept{"a": concept("a", "a", "new", 100, 300)},
+				map[string]*branchConcept{},
+				base, interfaces.CONFLICT_STRATEGY_TARGET)
+			So(plan.upserts, ShouldBeEmpty)
+			So(len(plan.conflicts), ShouldEqual, 1)
+			So(plan.conflicts[0].ConflictType, ShouldEqual, interfaces.CONFLICT_TYPE_MODIFIED_AND_REMOVED)
+		})
+
+		Convey("Keep concepts added on target\n", func() {
+			plan := mergeConcepts(interfaces.MODULE_TYPE_OBJECT_TYPE,
+				map[string]*branchConcept{},
+				map[string]*branchConcept{"a": concept("a", "a", "new", 300, 300)},
+				base, interfaces.CONFLICT_STRATEGY_ABORT)
+			So(plan.removes, ShouldBeEmpty)
+			So(plan.conflicts, ShouldBeEmpty)
+		})
+
+		Convey("Conflict when source removed and target modified\n", func() {
+			plan := mergeConcepts(interfaces.MODULE_TYPE_OBJECT_TYPE,
+				map[string]*branchConcept{},
+				map[string]*branchConcept{"a": concept("a", "a", "new", 100, 300)},
+				base, interfaces.CONFLICT_STRATEGY_SOURCE)
+			So(plan.removes, ShouldResemble, []string{"a"})
+			So(len(plan.conflicts), ShouldEqual, 1)
+			So(plan.conflicts[0].ConflictType, ShouldEqual, interfaces.CONFLICT_TYPE_REMOVED_AND_MODIFIED)
+		})
+
+		Convey("Use concepts recorded at the last merge as base\n", func() {
+			merged := &branchMergeBase{
+				time:     500,
+				concepts: map[string]map[string]bool{interfaces.MODULE_TYPE_OBJECT_TYPE: {"a": true}},
+			}
+			// a 上次合并后被目标分支删除，b 为目标分支上新增但早于上次合并
+			plan := mergeConcepts(interfaces.MODULE_TYPE_OBJECT_TYPE,
+				map[string]*branchConcept{"a": concept("a", "a", "old", 300, 300)},
+				map[string]*branchConcept{"b": concept("b", "b", "new", 400, 400)},
+				merged, interfaces.CONFLICT_STRATEGY_ABORT)
+			So(plan.upserts, ShouldBeEmpty)
+			So(plan.removes, ShouldBeEmpty)
+			So(plan.conflicts, ShouldBeEmpty)
+		})
+	})
+}
+
+func Test_planBranchMerge(t *testing.T) {
+	Convey("Test planBranchMerge\n", t, func() {
+		newSnapshot := func(branch string, createTime int64) *branchSnapshot {
+			return &branchSnapshot{
+				kn:            &interfaces.KN{KNID: "kn1", Branch: branch, CreateTime: createTime},
+				objectTypes:   map[string]*interfaces.ObjectType{},
+				relationTypes: map[string]*interfaces.RelationType{},
+				actionTypes:   map[string]*interfaces.ActionType{},
+				conceptGroups: map[string]*interfaces.ConceptGroup{},
+				relations:     map[string]interfaces.ConceptGroupRelation{},
+			}
+		}
+
+		Convey("Name duplicated after merge\n", func() {
+			source := newSnapshot("dev", 200)
+			source.objectTypes["ot1"] = newBranchTestOT("ot1", "same", 300, 300)
+			target := newSnapshot(interfaces.MAIN_BRANCH, 50)
+			target.objectTypes["ot2"] = newBranchTestOT("ot2", "same", 400, 400)
+
+			plan, err := planBranchMerge(source, target, newBranchMergeBase(source, target, nil), interfaces.CONFLICT_STRATEGY_SOURCE)
+			So(err, ShouldBeNil)
+			So(len(plan.objectTypes.conflicts), ShouldEqual, 2)
+			So(plan.objectTypes.conflicts[0].ConflictType, ShouldEqual, interfaces.CONFLICT_TYPE_NAME_DUPLICATED)
+		})
+
+		Convey("Merge group relations as sets\n", func() {
+			source := newSnapshot("dev", 200)
+			target := newSnapshot(interfaces.MAIN_BRANCH, 50)
+			for _, s := range []*branchSnapshot{source, target} {
+				s.objectTypes["ot1"] = newBranchTestOT("ot1", "ot1", 100, 100)
+				s.objectTypes["ot2"] = newBranchTestOT("ot2", "ot2", 100, 100)
+				s.conceptGroups["cg1"] = &interfaces.ConceptGroup{CGID: "cg1", CGName: "cg1", CreateTime: 100, UpdateTime: 100}
+			}
+			removedInSource := interfaces.ConceptGroupRelation{ID: "r1", CGID: "cg1",
+				ConceptType: interfaces.MODULE_TYPE_OBJECT_TYPE, ConceptID: "ot1", CreateTime: 100}
+			addedInSource := interfaces.ConceptGroupRelation{ID: "r2", CGID: "cg1",
+				ConceptType: interfaces.MODULE_TYPE_OBJECT_TYPE, ConceptID: "ot2", CreateTime: 300}
+			target.relations[relationKey(removedInSource)] = removedInSource
+			source.relations[relationKey(addedInSource)] = addedInSource
+
+			plan, err := planBranchMerge(source, target, newBranchMergeBase(source, target, nil), interfaces.CONFLICT_STRATEGY_ABORT)
+			So(err, ShouldBeNil)
+			So(plan.relationsChanged, ShouldBeTrue)
+			So(len(plan.relations), ShouldEqual, 1)
+			So(plan.relations[relationKey(addedInSource)].ConceptID, ShouldEqual, "ot2")
+			So(plan.conceptGroups.diff.Modified, ShouldResemble, []interfaces.ConceptChange{{ID: "cg1", Name: "cg1", Fields: []string{GROUP_MEMBERS_FIELD}}})
+			So(plan.isEmpty(), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package knowledge_network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"go.opentelemetry.io/otel/codes"
+
+	oerrors "ontology-manager/errors"
+	"ontology-manager/interfaces"
+	"ontology-manager/logics/knowledge_network/owl"
+)
+
+// 把业务知识网络的 schema 导出为 OWL 文档
+func (kns *knowledgeNetworkService) ExportKNToOWL(ctx context.Context, knID string, branch string, format string) ([]byte, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, fmt.Sprintf("导出业务知识网络[%s]为OWL", knID))
+	defer span.End()
+
+	// 导出模式下会带出对象类、关系类及其分组，权限校验也在其中
+	kn, err := kns.GetKNByID(ctx, knID, branch, interfaces.Mode_Export)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := owl.Marshal(owl.FromKN(kn), format)
+	if err != nil {
+		logger.Errorf("Export knowledge network[%s] as owl error: %s", knID, err.Error())
+		span.SetStatus(codes.Error, "导出OWL失败")
+
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyManager_KnowledgeNetwork_InternalError_ExportOWLFailed).WithErrorDetails(err.Error())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return data, nil
+}
+
+// 把 OWL 文档转换为业务知识网络，同时返回无法映射的本体结构。只做转换，不落库
+func (kns *knowledgeNetworkService) ConvertOWLToKN(ctx context.Context, data []byte,
+	format string) (*interfaces.KN, []*interfaces.OWLUnmapped, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "转换OWL为业务知识网络")
+	defer span.End()
+
+	g, err := owl.Unmarshal(data, format)
+	if err != nil {
+		span.SetStatus(codes.Error, "解析OWL文档失败")
+		return nil, nil, rest.NewHTTPError(ctx, http.StatusBadRequest,
+			oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_OWLDocument).WithErrorDetails(err.Error())
+	}
+
+	kn, unmapped, err := owl.ToKN(g)
+	if err != nil {
+		span.SetStatus(codes.Error, "转换OWL文档失败")
+		return nil, nil, rest.NewHTTPError(ctx, http.StatusBadRequest,
+			oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_OWLDocument).WithErrorDetails(err.Error())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return kn, unmapped, nil
+}
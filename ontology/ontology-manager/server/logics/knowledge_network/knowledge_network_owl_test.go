@@ -0,0 +1,156 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package knowledge_network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"ontology-manager/common"
+	oerrors "ontology-manager/errors"
+	"ontology-manager/interfaces"
+	dmock "ontology-manager/interfaces/mock"
+)
+
+func Test_knowledgeNetworkService_ExportKNToOWL(t *testing.T) {
+	Convey("Test ExportKNToOWL\n", t, func() {
+		ctx := context.Background()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		kna := dmock.NewMockKNAccess(mockCtrl)
+		ps := dmock.NewMockPermissionService(mockCtrl)
+		uma := dmock.NewMockUserMgmtAccess(mockCtrl)
+		cgs := dmock.NewMockConceptGroupService(mockCtrl)
+		ots := dmock.NewMockObjectTypeService(mockCtrl)
+		rts := dmock.NewMockRelationTypeService(mockCtrl)
+		ats := dmock.NewMockActionTypeService(mockCtrl)
+
+		service := &knowledgeNetworkService{
+			appSetting: &common.AppSetting{},
+			kna:        kna,
+			ps:         ps,
+			uma:        uma,
+			cgs:        cgs,
+			ots:        ots,
+			rts:        rts,
+			ats:        ats,
+		}
+
+		kn := &interfaces.KN{
+			KNID:   "kn1",
+			KNName: "kn1",
+			Branch: interfaces.MAIN_BRANCH,
+		}
+		objectTypes := []*interfaces.ObjectType{
+			{
+				ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+					OTID:           "ot1",
+					OTName:         "ot1",
+					DataProperties: []*interfaces.DataProperty{{Name: "id", DisplayName: "id", Type: "string"}},
+					PrimaryKeys:    []string{"id"},
+					DisplayKey:     "id",
+				},
+			},
+		}
+
+		expectGetKN := func() {
+			kna.EXPECT().GetKNByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(kn, nil)
+			ps.EXPECT().FilterResources(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(map[string]interfaces.ResourceOps{
+					"kn1": {
+						Operations: []string{interfaces.OPERATION_TYPE_VIEW_DETAIL},
+					},
+				}, nil)
+			uma.EXPECT().GetAccountNames(gomock.Any(), gomock.Any()).Return(nil)
+			cgs.EXPECT().ListConceptGroups(gomock.Any(), gomock.Any()).Return([]*interfaces.ConceptGroup{}, 0, nil)
+			ots.EXPECT().ListObjectTypes(gomock.Any(), gomock.Any(), gomock.Any()).Return(objectTypes, 1, nil)
+			rts.EXPECT().ListRelationTypes(gomock.Any(), gomock.Any()).Return([]*interfaces.RelationType{}, 0, nil)
+			ats.EXPECT().ListActionTypes(gomock.Any(), gomock.Any()).Return([]*interfaces.ActionType{}, 0, nil)
+		}
+
+		Convey("Success with turtle\n", func() {
+			expectGetKN()
+
+			data, err := service.ExportKNToOWL(ctx, "kn1", interfaces.MAIN_BRANCH, interfaces.OWL_FORMAT_TURTLE)
+			So(err, ShouldBeNil)
+			So(string(data), ShouldContainSubstring, "<urn:kweaver:kn:kn1> a owl:Ontology")
+			So(string(data), ShouldContainSubstring, "ot:ot1 a owl:Class")
+		})
+
+		Convey("Success with jsonld\n", func() {
+			expectGetKN()
+
+			data, err := service.ExportKNToOWL(ctx, "kn1", interfaces.MAIN_BRANCH, interfaces.OWL_FORMAT_JSONLD)
+			So(err, ShouldBeNil)
+			So(string(data), ShouldContainSubstring, `"@graph"`)
+		})
+
+		Convey("Failed with unsupported format\n", func() {
+			expectGetKN()
+
+			_, err := service.ExportKNToOWL(ctx, "kn1", interfaces.MAIN_BRANCH, "rdfxml")
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_InternalError_ExportOWLFailed)
+		})
+
+		Convey("Failed when KN not found\n", func() {
+			kna.EXPECT().GetKNByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+			_, err := service.ExportKNToOWL(ctx, "kn1", interfaces.MAIN_BRANCH, interfaces.OWL_FORMAT_TURTLE)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_NotFound)
+		})
+	})
+}
+
+func Test_knowledgeNetworkService_ConvertOWLToKN(t *testing.T) {
+	Convey("Test ConvertOWLToKN\n", t, func() {
+		ctx := context.Background()
+		service := &knowledgeNetworkService{
+			appSetting: &common.AppSetting{},
+		}
+
+		Convey("Success with turtle\n", func() {
+			doc := `
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.org/shop> a owl:Ontology .
+<http://example.org/Order> a owl:Class ; owl:hasKey ( <http://example.org/orderNo> ) .
+<http://example.org/orderNo> a owl:DatatypeProperty ; rdfs:domain <http://example.org/Order> ; rdfs:range xsd:string .
+`
+			kn, unmapped, err := service.ConvertOWLToKN(ctx, []byte(doc), interfaces.OWL_FORMAT_TURTLE)
+			So(err, ShouldBeNil)
+			So(unmapped, ShouldBeEmpty)
+			So(kn.KNID, ShouldEqual, "shop")
+			So(len(kn.ObjectTypes), ShouldEqual, 1)
+			So(kn.ObjectTypes[0].OTID, ShouldEqual, "order")
+			So(kn.ObjectTypes[0].PrimaryKeys, ShouldResemble, []string{"orderNo"})
+		})
+
+		Convey("Failed with invalid document\n", func() {
+			_, _, err := service.ConvertOWLToKN(ctx, []byte(`{"@graph": [`), interfaces.OWL_FORMAT_JSONLD)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_OWLDocument)
+		})
+
+		Convey("Failed without ontology declaration\n", func() {
+			doc := `<http://example.org/Order> a <http://www.w3.org/2002/07/owl#Class> .`
+			_, _, err := service.ConvertOWLToKN(ctx, []byte(doc), interfaces.OWL_FORMAT_TURTLE)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyManager_KnowledgeNetwork_InvalidParameter_OWLDocument)
+		})
+	})
+}
@@ -31,6 +31,7 @@ import (
 	"ontology-manager/logics/object_type"
 	"ontology-manager/logics/permission"
 	"ontology-manager/logics/relation_type"
+	"ontology-manager/worker"
 )
 
 var (
@@ -46,6 +47,7 @@ type knowledgeNetworkService struct {
 	bsa        interfaces.BusinessSystemAccess
 	cga        interfaces.ConceptGroupAccess
 	cgs        interfaces.ConceptGroupService
+	csr        interfaces.ConceptSyncer
 	js         interfaces.JobService
 	kna        interfaces.KNAccess
 	mfa        interfaces.ModelFactoryAccess
@@ -67,6 +69,7 @@ func NewKNService(appSetting *common.AppSetting) interfaces.KNService {
 			bsa:        logics.BSA,
 			cga:        logics.CGA,
 			cgs:        concept_group.NewConceptGroupService(appSetting),
+			csr:        worker.NewConceptSyncer(appSetting),
 			db:         logics.DB,
 			js:         job.NewJobService(appSetting),
 			kna:        logics.KNA,
@@ -875,6 +878,12 @@ func (kns *knowledgeNetworkService) DeleteKN(ctx context.Context, kn *interfaces
 			WithErrorDetails(err.Error())
 	}
 
+	// 资源策略和业务域绑定归属于主线，删除分支时保留
+	if kn.Branch != interfaces.MAIN_BRANCH {
+		span.SetStatus(codes.Ok, "")
+		return nil
+	}
+
 	//  清除资源策略
 	err = kns.ps.DeleteResources(ctx, interfaces.RESOURCE_TYPE_KN, []string{kn.KNID})
 	if err != nil {
@@ -1026,6 +1026,31 @@ func Test_knowledgeNetworkService_DeleteKN(t *testing.T) {
 			So(err, ShouldBeNil)
 		})
 
+		Convey("Success deleting branch without touching resources\n", func() {
+			kn := &interfaces.KN{
+				KNID:           "kn1",
+				KNName:         "kn1",
+				Branch:         "dev",
+				BusinessDomain: "bd1",
+			}
+
+			smock.ExpectBegin()
+			ps.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			js.EXPECT().ListJobs(gomock.Any(), gomock.Any()).Return([]*interfaces.JobInfo{}, int64(0), nil)
+			kna.EXPECT().DeleteKN(gomock.Any(), gomock.Any(), "kn1", "dev").Return(int64(1), nil)
+			ots.EXPECT().DeleteObjectTypesByKnID(gomock.Any(), gomock.Any(), "kn1", "dev").Return(nil)
+			rts.EXPECT().DeleteRelationTypesByKnID(gomock.Any(), gomock.Any(), "kn1", "dev").Return(nil)
+			ats.EXPECT().DeleteActionTypesByKnID(gomock.Any(), gomock.Any(), "kn1", "dev").Return(nil)
+			cgs.EXPECT().DeleteConceptGroupsByKnID(gomock.Any(), gomock.Any(), "kn1", "dev").Return(nil)
+			js.EXPECT().DeleteJobsByKnID(gomock.Any(), gomock.Any(), "kn1", "dev").Return(nil)
+			osa.EXPECT().DeleteData(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			osa.EXPECT().DeleteByQuery(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			smock.ExpectCommit()
+
+			err := service.DeleteKN(ctx, kn)
+			So(err, ShouldBeNil)
+		})
+
 		Convey("Failed when permission check fails\n", func() {
 			kn := &interfaces.KN{
 				KNID:   "kn1",
@@ -0,0 +1,560 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package owl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// WriteJSONLD 把图序列化为带 @context 和 @graph 的压缩形式 JSON-LD，前缀作为 @context 中的项。
+func WriteJSONLD(g *Graph) ([]byte, error) {
+	w := &jsonldWriter{
+		g:         g,
+		refs:      g.blankReferences(),
+		bySubject: g.indexBySubject(),
+		written:   map[Term]bool{},
+	}
+
+	context := map[string]any{}
+	for _, p := range g.Prefixes {
+		context[p.Name] = p.IRI
+	}
+
+	subjects := []Term{}
+	seen := map[Term]bool{}
+	for _, t := range g.Triples {
+		if !seen[t.Subject] {
+			seen[t.Subject] = true
+			subjects = append(subjects, t.Subject)
+		}
+	}
+
+	nodes := []any{}
+	for _, subject := range subjects {
+		if subject.IsBlank() && w.refs[subject.Value] == 1 {
+			continue
+		}
+		nodes = append(nodes, w.node(subject, true))
+	}
+	for _, subject := range subjects {
+		if !w.written[subject] {
+			nodes = append(nodes, w.node(subject, true))
+		}
+	}
+
+	return json.MarshalIndent(map[string]any{
+		"@context": context,
+		"@graph":   nodes,
+	}, "", "  ")
+}
+
+type jsonldWriter struct {
+	g         *Graph
+	refs      map[string]int
+	bySubject map[Term][]int
+	written   map[Term]bool
+}
+
+func (w *jsonldWriter) node(subject Term, withID bool) map[string]any {
+	w.written[subject] = true
+	obj := map[string]any{}
+	if withID {
+		obj["@id"] = w.id(subject)
+	}
+
+	types := []any{}
+	for _, idx := range w.bySubject[subject] {
+		t := w.g.Triples[idx]
+		if t.Predicate.Value == RDF_TYPE && !t.Object.IsLiteral() {
+			types = append(types, w.id(t.Object))
+			continue
+		}
+		key := w.id(t.Predicate)
+		values, _ := obj[key].([]any)
+		obj[key] = append(values, w.value(t.Object))
+	}
+	if len(types) > 0 {
+		obj["@type"] = types
+	}
+	return obj
+}
+
+func (w *jsonldWriter) id(t Term) string {
+	if t.IsBlank() {
+		return "_:" + t.Value
+	}
+	if name, ok := w.g.compact(t.Value); ok {
+		return name
+	}
+	return t.Value
+}
+
+func (w *jsonldWriter) value(object Term) any {
+	switch object.Type {
+	case TermLiteral:
+		value := map[string]any{"@value": object.Value}
+		if object.Lang != "" {
+			value["@language"] = object.Lang
+		} else if object.Datatype != "" && object.Datatype != XSD_STRING {
+			value["@type"] = w.id(IRI(object.Datatype))
+		}
+		return value
+	case TermIRI:
+		if object.Value == RDF_NIL {
+			return map[string]any{"@list": []any{}}
+		}
+		return map[string]any{"@id": w.id(object)}
+	}
+
+	if w.refs[object.Value] != 1 || w.written[object] {
+		return map[string]any{"@id": w.id(object)}
+	}
+	if items, used, ok := w.g.list(object, w.bySubject); ok {
+		for _, idx := range used {
+			w.written[w.g.Triples[idx].Subject] = true
+		}
+		values := make([]any, 0, len(items))
+		for _, item := range items {
+			values = append(values, w.value(item))
+		}
+		return map[string]any{"@list": values}
+	}
+	return w.node(object, false)
+}
+
+// ParseJSONLD 解析 JSON-LD 文档。
+// 支持内嵌 @context(前缀、词项、@vocab、@base、@language、类型强制、@list 容器、关键字别名)、
+// @graph、@id、@type、值对象、@list 以及嵌套节点，不支持远程 @context。
+func ParseJSONLD(data []byte) (*Graph, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc any
+	err := decoder.Decode(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid json-ld document: %s", err.Error())
+	}
+
+	p := &jsonldParser{
+		g:      NewGraph(),
+		blanks: map[string]Term{},
+	}
+	ctx := &jsonldContext{terms: map[string]*jsonldTerm{}}
+
+	switch v := doc.(type) {
+	case []any:
+		for _, item := range v {
+			err = p.parseTop(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		err = p.parseTop(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p.g, nil
+}
+
+type jsonldTerm struct {
+	iri       string
+	typ       string
+	container string
+	lang      *string
+}
+
+type jsonldContext struct {
+	terms map[string]*jsonldTerm
+	vocab string
+	base  string
+	lang  string
+}
+
+type jsonldParser struct {
+	g      *Graph
+	blanks map[string]Term
+}
+
+func (p *jsonldParser) parseTop(ctx *jsonldContext, item any) error {
+	obj, ok := item.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid json-ld document: top level item must be an object")
+	}
+
+	ctx, err := p.mergeContext(ctx, obj["@context"])
+	if err != nil {
+		return err
+	}
+
+	graph, ok := obj["@graph"]
+	if !ok {
+		_, err = p.parseNode(ctx, obj)
+		return err
+	}
+	for _, node := range asArray(graph) {
+		nodeObj, ok := node.(map[string]any)
+		if !ok {
+			return fmt.Errorf("invalid json-ld document: @graph item must be an object")
+		}
+		_, err = p.parseNode(ctx, nodeObj)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 合并 @context，返回新的上下文，不修改外层上下文
+func (p *jsonldParser) mergeContext(ctx *jsonldContext, raw any) (*jsonldContext, error) {
+	if raw == nil {
+		return ctx, nil
+	}
+
+	merged := &jsonldContext{
+		terms: make(map[string]*jsonldTerm, len(ctx.terms)),
+		vocab: ctx.vocab,
+		base:  ctx.base,
+		lang:  ctx.lang,
+	}
+	for k, v := range ctx.terms {
+		merged.terms[k] = v
+	}
+
+	for _, item := range asArray(raw) {
+		switch def := item.(type) {
+		case string:
+			return nil, fmt.Errorf("remote json-ld context '%s' is not supported", def)
+		case map[string]any:
+			for key, value := range def {
+				switch key {
+				case "@vocab":
+					merged.vocab, _ = value.(string)
+				case "@base":
+					merged.base, _ = value.(string)
+				case "@language":
+					merged.lang, _ = value.(string)
+				case "@version", "@protected", "@propagate", "@import":
+				default:
+					switch v := value.(type) {
+					case nil:
+						delete(merged.terms, key)
+					case string:
+						merged.terms[key] = &jsonldTerm{iri: v}
+					case map[string]any:
+						term := &jsonldTerm{}
+						term.iri, _ = v["@id"].(string)
+						term.typ, _ = v["@type"].(string)
+						term.container, _ = v["@container"].(string)
+						if lang, ok := v["@language"]; ok {
+							s, _ := lang.(string)
+							term.lang = &s
+						}
+						merged.terms[key] = term
+					default:
+						return nil, fmt.Errorf("invalid json-ld term definition for '%s'", key)
+					}
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid json-ld @context")
+		}
+	}
+	return merged, nil
+}
+
+// 关键字别名，例如 "id": "@id"
+func (ctx *jsonldContext) keyword(key string) string {
+	if strings.HasPrefix(key, "@") {
+		return key
+	}
+	if term, ok := ctx.terms[key]; ok && strings.HasPrefix(term.iri, "@") {
+		return term.iri
+	}
+	return key
+}
+
+// 展开 IRI。vocab 为 true 时按词项和 @vocab 展开(属性名、类型)，否则按 @base 解析(@id 的值)。
+// 无法展开为绝对 IRI 的属性名返回空串，按 JSON-LD 的规则丢弃。
+func (ctx *jsonldContext) expand(value string, vocab bool) string {
+	return ctx.expandDepth(value, vocab, 0)
+}
+
+func (ctx *jsonldContext) expandDepth(value string, vocab bool, depth int) string {
+	if depth > 8 {
+		return ""
+	}
+	if vocab {
+		if term, ok := ctx.terms[value]; ok {
+			if term.iri == "" {
+				if ctx.vocab != "" {
+					return ctx.vocab + value
+				}
+				return ""
+			}
+			if term.iri == value {
+				return value
+			}
+			return ctx.expandDepth(term.iri, vocab, depth+1)
+		}
+	}
+
+	if idx := strings.Index(value, ":"); idx > 0 {
+		prefix, suffix := value[:idx], value[idx+1:]
+		if prefix == "_" || strings.HasPrefix(suffix, "//") {
+			return value
+		}
+		if term, ok := ctx.terms[prefix]; ok && term.iri != "" {
+			ns := ctx.expandDepth(term.iri, true, depth+1)
+			if ns != "" {
+				return ns + suffix
+			}
+		}
+		return value
+	}
+
+	if vocab {
+		if ctx.vocab != "" {
+			return ctx.vocab + value
+		}
+		return ""
+	}
+	if ctx.base != "" {
+		base, err := url.Parse(ctx.base)
+		ref, refErr := url.Parse(value)
+		if err == nil && refErr == nil {
+			return base.ResolveReference(ref).String()
+		}
+	}
+	return value
+}
+
+func (p *jsonldParser) resource(ctx *jsonldContext, value string, vocab bool) Term {
+	if strings.HasPrefix(value, "_:") {
+		return p.blank(value[2:])
+	}
+	return IRI(ctx.expand(value, vocab))
+}
+
+func (p *jsonldParser) blank(label string) Term {
+	node, ok := p.blanks[label]
+	if !ok {
+		node = p.g.NewBlank()
+		p.blanks[label] = node
+	}
+	return node
+}
+
+func (p *jsonldParser) parseNode(ctx *jsonldContext, obj map[string]any) (Term, error) {
+	ctx, err := p.mergeContext(ctx, obj["@context"])
+	if err != nil {
+		return Term{}, err
+	}
+
+	keys := make([]string, 0, len(obj))
+	normalized := map[string]any{}
+	for key, value := range obj {
+		keyword := ctx.keyword(key)
+		if _, ok := normalized[keyword]; !ok {
+			keys = append(keys, key)
+		}
+		normalized[keyword] = value
+	}
+	sort.Strings(keys)
+
+	var subject Term
+	if id, ok := normalized["@id"].(string); ok {
+		subject = p.resource(ctx, id, false)
+	} else {
+		subject = p.g.NewBlank()
+	}
+
+	for _, typ := range asArray(normalized["@type"]) {
+		s, ok := typ.(string)
+		if !ok {
+			return Term{}, fmt.Errorf("invalid json-ld @type value")
+		}
+		p.g.Add(subject, IRI(RDF_TYPE), p.resource(ctx, s, true))
+	}
+
+	for _, key := range keys {
+		if strings.HasPrefix(ctx.keyword(key), "@") {
+			continue
+		}
+		predicate := ctx.expand(key, true)
+		if predicate == "" || strings.HasPrefix(predicate, "_:") {
+			continue
+		}
+		term := ctx.terms[key]
+		if term == nil {
+			term = &jsonldTerm{}
+		}
+
+		value := obj[key]
+		if items, ok := value.([]any); ok && term.container == "@list" {
+			list, err := p.parseList(ctx, term, items)
+			if err != nil {
+				return Term{}, err
+			}
+			p.g.Add(subject, IRI(predicate), list)
+			continue
+		}
+		for _, item := range flattenSets(ctx, asArray(value)) {
+			object, ok, err := p.parseValue(ctx, term, item)
+			if err != nil {
+				return Term{}, err
+			}
+			if ok {
+				p.g.Add(subject, IRI(predicate), object)
+			}
+		}
+	}
+	return subject, nil
+}
+
+func (p *jsonldParser) parseList(ctx *jsonldContext, term *jsonldTerm, items []any) (Term, error) {
+	values := []Term{}
+	for _, item := range items {
+		value, ok, err := p.parseValue(ctx, term, item)
+		if err != nil {
+			return Term{}, err
+		}
+		if ok {
+			values = append(values, value)
+		}
+	}
+	return p.g.AddList(values), nil
+}
+
+// 解析属性值，null 返回 false
+func (p *jsonldParser) parseValue(ctx *jsonldContext, term *jsonldTerm, value any) (Term, bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return Term{}, false, nil
+	case string:
+		switch {
+		case term.typ == "@id":
+			return p.resource(ctx, v, false), true, nil
+		case term.typ == "@vocab":
+			return p.resource(ctx, v, true), true, nil
+		case term.typ != "":
+			return TypedLiteral(v, ctx.expand(term.typ, true)), true, nil
+		case term.lang != nil:
+			if *term.lang == "" {
+				return Literal(v), true, nil
+			}
+			return LangLiteral(v, *term.lang), true, nil
+		case ctx.lang != "":
+			return LangLiteral(v, ctx.lang), true, nil
+		default:
+			return Literal(v), true, nil
+		}
+	case json.Number:
+		s := v.String()
+		if term.typ != "" && term.typ != "@id" && term.typ != "@vocab" {
+			return TypedLiteral(s, ctx.expand(term.typ, true)), true, nil
+		}
+		if strings.ContainsAny(s, ".eE") {
+			return TypedLiteral(s, XSD_DOUBLE), true, nil
+		}
+		return TypedLiteral(s, XSD_INTEGER), true, nil
+	case bool:
+		return TypedLiteral(fmt.Sprintf("%t", v), XSD_BOOLEAN), true, nil
+	case []any:
+		// 数组的数组，按列表处理
+		list, err := p.parseList(ctx, term, v)
+		return list, err == nil, err
+	case map[string]any:
+		return p.parseObjectValue(ctx, term, v)
+	default:
+		return Term{}, false, fmt.Errorf("invalid json-ld value")
+	}
+}
+
+func (p *jsonldParser) parseObjectValue(ctx *jsonldContext, term *jsonldTerm, v map[string]any) (Term, bool, error) {
+	normalized := map[string]any{}
+	for key, value := range v {
+		normalized[ctx.keyword(key)] = value
+	}
+
+	if raw, ok := normalized["@value"]; ok {
+		var s string
+		switch value := raw.(type) {
+		case nil:
+			return Term{}, false, nil
+		case string:
+			s = value
+		case json.Number:
+			s = value.String()
+		case bool:
+			s = fmt.Sprintf("%t", value)
+		default:
+			return Term{}, false, fmt.Errorf("invalid json-ld @value")
+		}
+		if lang, ok := normalized["@language"].(string); ok && lang != "" {
+			return LangLiteral(s, lang), true, nil
+		}
+		if typ, ok := normalized["@type"].(string); ok && typ != "" {
+			return TypedLiteral(s, ctx.expand(typ, true)), true, nil
+		}
+		switch raw.(type) {
+		case json.Number:
+			if strings.ContainsAny(s, ".eE") {
+				return TypedLiteral(s, XSD_DOUBLE), true, nil
+			}
+			return TypedLiteral(s, XSD_INTEGER), true, nil
+		case bool:
+			return TypedLiteral(s, XSD_BOOLEAN), true, nil
+		}
+		return Literal(s), true, nil
+	}
+
+	if items, ok := normalized["@list"]; ok {
+		list, err := p.parseList(ctx, term, asArray(items))
+		return list, err == nil, err
+	}
+
+	node, err := p.parseNode(ctx, v)
+	return node, err == nil, err
+}
+
+// 展开值数组中的 {"@set": [...]}
+func flattenSets(ctx *jsonldContext, values []any) []any {
+	flattened := make([]any, 0, len(values))
+	for _, value := range values {
+		if obj, ok := value.(map[string]any); ok {
+			found := false
+			for key, items := range obj {
+				if ctx.keyword(key) == "@set" {
+					flattened = append(flattened, asArray(items)...)
+					found = true
+				}
+			}
+			if found {
+				continue
+			}
+		}
+		flattened = append(flattened, value)
+	}
+	return flattened
+}
+
+func asArray(value any) []any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		return v
+	default:
+		return []any{v}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package owl
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_JSONLD_ParseJSONLD(t *testing.T) {
+	Convey("Test ParseJSONLD", t, func() {
+		Convey("Success with context, graph and value objects\n", func() {
+			doc := `{
+  "@context": {
+    "ex": "http://example.org/",
+    "owl": "http://www.w3.org/2002/07/owl#",
+    "rdfs": "http://www.w3.org/2000/01/rdf-schema#",
+    "xsd": "http://www.w3.org/2001/XMLSchema#",
+    "id": "@id",
+    "label": "rdfs:label",
+    "domain": {"@id": "rdfs:domain", "@type": "@id"},
+    "keys": {"@id": "owl:hasKey", "@container": "@list", "@type": "@id"},
+    "since": {"@id": "ex:since", "@type": "xsd:date"}
+  },
+  "@graph": [
+    {
+      "id": "ex:Person",
+      "@type": "owl:Class",
+      "label": [{"@value": "Person", "@language": "en"}, "人员"],
+      "keys": ["ex:id"],
+      "since": "2020-01-01",
+      "ex:count": 3,
+      "ex:flag": false,
+      "ex:addr": {"ex:city": "Hefei"},
+      "unknown": "dropped"
+    },
+    {"@id": "ex:name", "@type": ["owl:DatatypeProperty"], "domain": "ex:Person", "rdfs:range": {"@id": "xsd:string"}}
+  ]
+}`
+			g, err := ParseJSONLD([]byte(doc))
+			So(err, ShouldBeNil)
+
+			person := IRI("http://example.org/Person")
+			So(hasTriple(g, person, RDF_TYPE, IRI(OWL_CLASS)), ShouldBeTrue)
+			So(hasTriple(g, person, RDFS_LABEL, LangLiteral("Person", "en")), ShouldBeTrue)
+			So(hasTriple(g, person, RDFS_LABEL, Literal("人员")), ShouldBeTrue)
+			So(hasTriple(g, person, "http://example.org/since", TypedLiteral("2020-01-01", XSD_DATE)), ShouldBeTrue)
+			So(hasTriple(g, person, "http://example.org/count", TypedLiteral("3", XSD_INTEGER)), ShouldBeTrue)
+			So(hasTriple(g, person, "http://example.org/flag", TypedLiteral("false", XSD_BOOLEAN)), ShouldBeTrue)
+
+			keys := objectsOf(g, person, OWL_HAS_KEY)
+			So(len(keys), ShouldEqual, 1)
+			items, _, ok := g.list(keys[0], g.indexBySubject())
+			So(ok, ShouldBeTrue)
+			So(items, ShouldResemble, []Term{IRI("http://example.org/id")})
+
+			addr := objectsOf(g, person, "http://example.org/addr")
+			So(len(addr), ShouldEqual, 1)
+			So(hasTriple(g, addr[0], "http://example.org/city", Literal("Hefei")), ShouldBeTrue)
+
+			name := IRI("http://example.org/name")
+			So(hasTriple(g, name, RDFS_DOMAIN, person), ShouldBeTrue)
+			So(hasTriple(g, name, RDFS_RANGE, IRI(XSD_STRING)), ShouldBeTrue)
+			So(len(objectsOf(g, person, "unknown")), ShouldEqual, 0)
+		})
+
+		Convey("Success with vocab and top level array\n", func() {
+			doc := `[{"@context": {"@vocab": "http://example.org/"}, "@id": "http://example.org/a", "p": "v"}]`
+			g, err := ParseJSONLD([]byte(doc))
+			So(err, ShouldBeNil)
+			So(hasTriple(g, IRI("http://example.org/a"), "http://example.org/p", Literal("v")), ShouldBeTrue)
+		})
+
+		Convey("Failed with remote context\n", func() {
+			_, err := ParseJSONLD([]byte(`{"@context": "https://schema.org/", "@id": "a"}`))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "remote json-ld context")
+		})
+
+		Convey("Failed with invalid json\n", func() {
+			_, err := ParseJSONLD([]byte(`{"@graph": [`))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_JSONLD_WriteJSONLD(t *testing.T) {
+	Convey("Test WriteJSONLD", t, func() {
+		Convey("Success round trip\n", func() {
+			g := NewGraph()
+			g.SetPrefix("ex", "http://example.org/")
+			g.SetPrefix("owl", NS_OWL)
+			g.SetPrefix("xsd", NS_XSD)
+			person := IRI("http://example.org/Person")
+			g.Add(person, IRI(RDF_TYPE), IRI(OWL_CLASS))
+			g.Add(person, IRI(RDFS_LABEL), Literal("人员"))
+			g.Add(person, IRI(RDFS_LABEL), LangLiteral("Person", "en"))
+			g.Add(person, IRI(OWL_HAS_KEY), g.AddList([]Term{IRI("http://example.org/id"), IRI("http://example.org/name")}))
+			g.Add(person, IRI("http://example.org/json"), TypedLiteral(`[{"a":1}]`, RDF_JSON))
+
+			data, err := WriteJSONLD(g)
+			So(err, ShouldBeNil)
+			So(string(data), ShouldContainSubstring, `"@id": "ex:Person"`)
+			So(string(data), ShouldContainSubstring, `"@list"`)
+
+			parsed, err := ParseJSONLD(data)
+			So(err, ShouldBeNil)
+			So(len(parsed.Triples), ShouldEqual, len(g.Triples))
+			So(hasTriple(parsed, person, RDF_TYPE, IRI(OWL_CLASS)), ShouldBeTrue)
+			So(hasTriple(parsed, person, RDFS_LABEL, Literal("人员")), ShouldBeTrue)
+			So(hasTriple(parsed, person, RDFS_LABEL, LangLiteral("Person", "en")), ShouldBeTrue)
+			So(hasTriple(parsed, person, "http://example.org/json", TypedLiteral(`[{"a":1}]`, RDF_JSON)), ShouldBeTrue)
+			keys := objectsOf(parsed, person, OWL_HAS_KEY)
+			items, _, ok := parsed.list(keys[0], parsed.indexBySubject())
+			So(ok, ShouldBeTrue)
+			So(items, ShouldResemble, []Term{IRI("http://example.org/id"), IRI("http://example.org/name")})
+		})
+	})
+}
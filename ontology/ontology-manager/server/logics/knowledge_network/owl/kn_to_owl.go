@@ -0,0 +1,216 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package owl
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"ontology-manager/interfaces"
+	"ontology-manager/interfaces/data_type"
+)
+
+// 数据属性类型到 XSD 数据类型，没有直接对应的类型同时用 kw:dataType 记录原始类型
+var dataTypeToXSD = map[string]string{
+	data_type.DATATYPE_KEYWORD:          XSD_STRING,
+	data_type.DATATYPE_TEXT:             XSD_STRING,
+	data_type.DATATYPE_STRING:           XSD_STRING,
+	data_type.DATATYPE_IP:               XSD_STRING,
+	data_type.DATATYPE_VECTOR:           XSD_STRING,
+	data_type.DATATYPE_POINT:            XSD_STRING,
+	data_type.DATATYPE_SHAPE:            XSD_STRING,
+	data_type.DATATYPE_BINARY:           NS_XSD + "base64Binary",
+	data_type.DATATYPE_JSON:             RDF_JSON,
+	data_type.DATATYPE_INTEGER:          XSD_INTEGER,
+	data_type.DATATYPE_UNSIGNED_INTEGER: NS_XSD + "nonNegativeInteger",
+	data_type.DATATYPE_FLOAT:            XSD_DOUBLE,
+	data_type.DATATYPE_DECIMAL:          XSD_DECIMAL,
+	data_type.DATATYPE_DATE:             XSD_DATE,
+	data_type.DATATYPE_DATETIME:         XSD_DATE_TIME,
+	data_type.DATATYPE_TIMESTAMP:        XSD_TIMESTAMP,
+	data_type.DATATYPE_TIME:             XSD_TIME,
+	data_type.DATATYPE_BOOLEAN:          XSD_BOOLEAN,
+}
+
+// 导出时声明的注解属性
+var kwAnnotationProperties = []string{
+	KW_ID, KW_NAME, KW_DATA_TYPE, KW_DISPLAY_KEY, KW_INCREMENTAL_KEY,
+	KW_RELATION_TYPE, KW_MAPPING_RULES, KW_TAG, KW_ICON, KW_COLOR, KW_DETAIL,
+}
+
+// 业务知识网络的本体 IRI
+func OntologyIRI(knID string) string {
+	return KN_IRI_PREFIX + knID
+}
+
+// FromKN 把业务知识网络的 schema 转换为 OWL 本体:
+// 对象类为 owl:Class，数据属性为以对象类为 domain 的 owl:DatatypeProperty，主键为 owl:hasKey，
+// 关系类为以起点、终点对象类为 domain、range 的 owl:ObjectProperty，
+// 概念分组为 kw:ConceptGroup 类型的 owl:Class，分组内的对象类是它的子类。
+// 数据来源、逻辑属性、行动类不属于本体结构，不导出。
+func FromKN(kn *interfaces.KN) *Graph {
+	g := NewGraph()
+	for _, p := range StandardPrefixes {
+		g.SetPrefix(p.Name, p.IRI)
+	}
+
+	ontologyIRI := OntologyIRI(kn.KNID)
+	otNS := ontologyIRI + "/object-types/"
+	rtNS := ontologyIRI + "/relation-types/"
+	cgNS := ontologyIRI + "/concept-groups/"
+	g.SetPrefix("ot", otNS)
+	g.SetPrefix("rt", rtNS)
+	g.SetPrefix("cg", cgNS)
+
+	ontology := IRI(ontologyIRI)
+	g.Add(ontology, IRI(RDF_TYPE), IRI(OWL_ONTOLOGY))
+	addLabel(g, ontology, kn.KNName)
+	g.Add(ontology, IRI(KW_ID), Literal(kn.KNID))
+	addCommonInfo(g, ontology, interfaces.CommonInfo{
+		Tags:    kn.Tags,
+		Comment: kn.Comment,
+		Icon:    kn.Icon,
+		Color:   kn.Color,
+		Detail:  kn.Detail,
+	})
+
+	for _, iri := range kwAnnotationProperties {
+		g.Add(IRI(iri), IRI(RDF_TYPE), IRI(OWL_ANNOTATION_PROPERTY))
+	}
+	g.Add(IRI(KW_CONCEPT_GROUP), IRI(RDF_TYPE), IRI(OWL_CLASS))
+
+	for _, cg := range kn.ConceptGroups {
+		class := IRI(cgNS + cg.CGID)
+		g.Add(class, IRI(RDF_TYPE), IRI(OWL_CLASS))
+		g.Add(class, IRI(RDF_TYPE), IRI(KW_CONCEPT_GROUP))
+		addLabel(g, class, cg.CGName)
+		g.Add(class, IRI(KW_ID), Literal(cg.CGID))
+		addCommonInfo(g, class, cg.CommonInfo)
+	}
+
+	for _, ot := range kn.ObjectTypes {
+		class := IRI(otNS + ot.OTID)
+		g.Add(class, IRI(RDF_TYPE), IRI(OWL_CLASS))
+		addLabel(g, class, ot.OTName)
+		g.Add(class, IRI(KW_ID), Literal(ot.OTID))
+		addCommonInfo(g, class, ot.CommonInfo)
+		for _, cg := range ot.ConceptGroups {
+			g.Add(class, IRI(RDFS_SUBCLASS_OF), IRI(cgNS+cg.CGID))
+		}
+
+		propIRIs := map[string]Term{}
+		for _, prop := range ot.DataProperties {
+			propIRIs[prop.Name] = IRI(otNS + ot.OTID + "." + prop.Name)
+		}
+		keys := []Term{}
+		for _, pk := range ot.PrimaryKeys {
+			if iri, ok := propIRIs[pk]; ok {
+				keys = append(keys, iri)
+			}
+		}
+		if len(keys) > 0 {
+			g.Add(class, IRI(OWL_HAS_KEY), g.AddList(keys))
+		}
+		if ot.DisplayKey != "" {
+			g.Add(class, IRI(KW_DISPLAY_KEY), Literal(ot.DisplayKey))
+		}
+		if ot.IncrementalKey != "" {
+			g.Add(class, IRI(KW_INCREMENTAL_KEY), Literal(ot.IncrementalKey))
+		}
+
+		for _, prop := range ot.DataProperties {
+			property := propIRIs[prop.Name]
+			g.Add(property, IRI(RDF_TYPE), IRI(OWL_DATATYPE_PROPERTY))
+			displayName := prop.DisplayName
+			if displayName == "" {
+				displayName = prop.Name
+			}
+			addLabel(g, property, displayName)
+			g.Add(property, IRI(KW_NAME), Literal(prop.Name))
+			if prop.Comment != "" {
+				g.Add(property, IRI(RDFS_COMMENT), Literal(prop.Comment))
+			}
+			g.Add(property, IRI(RDFS_DOMAIN), class)
+			if xsd, ok := dataTypeToXSD[prop.Type]; ok {
+				g.Add(property, IRI(RDFS_RANGE), IRI(xsd))
+			}
+			if prop.Type != "" {
+				g.Add(property, IRI(KW_DATA_TYPE), Literal(prop.Type))
+			}
+		}
+	}
+
+	for _, rt := range kn.RelationTypes {
+		property := IRI(rtNS + rt.RTID)
+		g.Add(property, IRI(RDF_TYPE), IRI(OWL_OBJECT_PROPERTY))
+		addLabel(g, property, rt.RTName)
+		g.Add(property, IRI(KW_ID), Literal(rt.RTID))
+		addCommonInfo(g, property, rt.CommonInfo)
+		g.Add(property, IRI(RDFS_DOMAIN), IRI(otNS+rt.SourceObjectTypeID))
+		g.Add(property, IRI(RDFS_RANGE), IRI(otNS+rt.TargetObjectTypeID))
+
+		// 映射规则是对象类属性之间的关联方式，导入时原样还原
+		if rt.Type != "" {
+			g.Add(property, IRI(KW_RELATION_TYPE), Literal(rt.Type))
+		}
+		if rt.MappingRules != nil {
+			rules, err := json.Marshal(rt.MappingRules)
+			if err == nil {
+				g.Add(property, IRI(KW_MAPPING_RULES), TypedLiteral(string(rules), RDF_JSON))
+			}
+		}
+	}
+
+	return g
+}
+
+// 按格式序列化
+func Marshal(g *Graph, format string) ([]byte, error) {
+	switch format {
+	case interfaces.OWL_FORMAT_TURTLE:
+		return WriteTurtle(g), nil
+	case interfaces.OWL_FORMAT_JSONLD:
+		return WriteJSONLD(g)
+	default:
+		return nil, fmt.Errorf("unsupported owl format '%s'", format)
+	}
+}
+
+// 按格式解析
+func Unmarshal(data []byte, format string) (*Graph, error) {
+	switch format {
+	case interfaces.OWL_FORMAT_TURTLE:
+		return ParseTurtle(data)
+	case interfaces.OWL_FORMAT_JSONLD:
+		return ParseJSONLD(data)
+	default:
+		return nil, fmt.Errorf("unsupported owl format '%s'", format)
+	}
+}
+
+func addLabel(g *Graph, subject Term, label string) {
+	if label != "" {
+		g.Add(subject, IRI(RDFS_LABEL), Literal(label))
+	}
+}
+
+func addCommonInfo(g *Graph, subject Term, info interfaces.CommonInfo) {
+	if info.Comment != "" {
+		g.Add(subject, IRI(RDFS_COMMENT), Literal(info.Comment))
+	}
+	for _, tag := range info.Tags {
+		g.Add(subject, IRI(KW_TAG), Literal(tag))
+	}
+	if info.Icon != "" {
+		g.Add(subject, IRI(KW_ICON), Literal(info.Icon))
+	}
+	if info.Color != "" {
+		g.Add(subject, IRI(KW_COLOR), Literal(info.Color))
+	}
+	if info.Detail != "" {
+		g.Add(subject, IRI(KW_DETAIL), Literal(info.Detail))
+	}
+}
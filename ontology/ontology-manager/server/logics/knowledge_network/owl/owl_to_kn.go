@@ -0,0 +1,739 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package owl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rs/xid"
+
+	"ontology-manager/interfaces"
+	"ontology-manager/interfaces/data_type"
+)
+
+const (
+	// 概念 id、属性名的最大长度，与 RegexPattern_NonBuiltin_ID、RegexPattern_Property_Name 一致
+	ID_MAX_LENGTH = 40
+
+	// 没有声明 owl:hasKey 的类补充的主键属性
+	GENERATED_PRIMARY_KEY = "id"
+)
+
+// XSD 数据类型到数据属性类型
+var xsdToDataType = map[string]string{
+	XSD_STRING:                    data_type.DATATYPE_STRING,
+	NS_XSD + "normalizedString":   data_type.DATATYPE_STRING,
+	NS_XSD + "token":              data_type.DATATYPE_STRING,
+	NS_XSD + "language":           data_type.DATATYPE_STRING,
+	NS_XSD + "Name":               data_type.DATATYPE_STRING,
+	NS_XSD + "NCName":             data_type.DATATYPE_STRING,
+	NS_XSD + "NMTOKEN":            data_type.DATATYPE_STRING,
+	NS_XSD + "anyURI":             data_type.DATATYPE_STRING,
+	RDF_LANG_STRING:               data_type.DATATYPE_TEXT,
+	NS_RDF + "PlainLiteral":       data_type.DATATYPE_TEXT,
+	XSD_INTEGER:                   data_type.DATATYPE_INTEGER,
+	NS_XSD + "int":                data_type.DATATYPE_INTEGER,
+	NS_XSD + "long":               data_type.DATATYPE_INTEGER,
+	NS_XSD + "short":              data_type.DATATYPE_INTEGER,
+	NS_XSD + "byte":               data_type.DATATYPE_INTEGER,
+	NS_XSD + "negativeInteger":    data_type.DATATYPE_INTEGER,
+	NS_XSD + "nonPositiveInteger": data_type.DATATYPE_INTEGER,
+	NS_XSD + "nonNegativeInteger": data_type.DATATYPE_UNSIGNED_INTEGER,
+	NS_XSD + "positiveInteger":    data_type.DATATYPE_UNSIGNED_INTEGER,
+	NS_XSD + "unsignedLong":       data_type.DATATYPE_UNSIGNED_INTEGER,
+	NS_XSD + "unsignedInt":        data_type.DATATYPE_UNSIGNED_INTEGER,
+	NS_XSD + "unsignedShort":      data_type.DATATYPE_UNSIGNED_INTEGER,
+	NS_XSD + "unsignedByte":       data_type.DATATYPE_UNSIGNED_INTEGER,
+	XSD_FLOAT:                     data_type.DATATYPE_FLOAT,
+	XSD_DOUBLE:                    data_type.DATATYPE_FLOAT,
+	XSD_DECIMAL:                   data_type.DATATYPE_DECIMAL,
+	XSD_BOOLEAN:                   data_type.DATATYPE_BOOLEAN,
+	XSD_DATE:                      data_type.DATATYPE_DATE,
+	XSD_DATE_TIME:                 data_type.DATATYPE_DATETIME,
+	XSD_TIMESTAMP:                 data_type.DATATYPE_TIMESTAMP,
+	XSD_TIME:                      data_type.DATATYPE_TIME,
+	NS_XSD + "base64Binary":       data_type.DATATYPE_BINARY,
+	NS_XSD + "hexBinary":          data_type.DATATYPE_BINARY,
+	RDF_JSON:                      data_type.DATATYPE_JSON,
+}
+
+// ToKN 把 OWL 本体转换为业务知识网络，是 FromKN 的逆过程，同时兼容通用语义网工具产出的本体:
+//   - 第一个 owl:Ontology 为业务知识网络；
+//   - 有数据属性或没有子类的 owl:Class 为对象类，只作为其他类的父类的 owl:Class 为概念分组，
+//     多层的类层次展开为对象类所属的全部分组；
+//   - domain 为对象类(或对象类的 owl:unionOf)的 owl:DatatypeProperty 为数据属性，
+//     owl:hasKey 为主键，没有声明时补充 id 属性作为主键；
+//   - domain、range 各为一个对象类且带有映射规则的 owl:ObjectProperty 为关系类。
+//
+// 返回的业务知识网络未经校验，其余不能映射的结构(限制、属性特征、个体等)逐条列在报告中。
+func ToKN(g *Graph) (*interfaces.KN, []*interfaces.OWLUnmapped, error) {
+	m := &owlImporter{
+		g:         g,
+		bySubject: g.indexBySubject(),
+		consumed:  make([]bool, len(g.Triples)),
+		types:     map[Term]map[string]bool{},
+		unmapped:  []*interfaces.OWLUnmapped{},
+		otClasses: map[Term]*interfaces.ObjectType{},
+		cgClasses: map[Term]*interfaces.ConceptGroup{},
+		propNames: map[Term]map[*interfaces.ObjectType]string{},
+	}
+	for _, p := range StandardPrefixes {
+		if !m.hasPrefix(p.Name) {
+			g.Prefixes = append(g.Prefixes, p)
+		}
+	}
+
+	for _, t := range g.Triples {
+		if t.Predicate.Value == RDF_TYPE && t.Object.IsIRI() {
+			if m.types[t.Subject] == nil {
+				m.types[t.Subject] = map[string]bool{}
+			}
+			m.types[t.Subject][t.Object.Value] = true
+		}
+	}
+
+	kn, err := m.mapOntology()
+	if err != nil {
+		return nil, nil, err
+	}
+	m.mapClasses(kn)
+	m.mapDataProperties()
+	m.mapKeys()
+	m.mapObjectProperties(kn)
+	m.reportRemaining()
+
+	return kn, m.unmapped, nil
+}
+
+type owlImporter struct {
+	g         *Graph
+	bySubject map[Term][]int
+	consumed  []bool
+	types     map[Term]map[string]bool
+	unmapped  []*interfaces.OWLUnmapped
+
+	otClasses map[Term]*interfaces.ObjectType
+	cgClasses map[Term]*interfaces.ConceptGroup
+	otOrder   []Term
+	// 数据属性 IRI 在各个对象类中对应的属性名
+	propNames map[Term]map[*interfaces.ObjectType]string
+}
+
+func (m *owlImporter) hasPrefix(name string) bool {
+	for _, p := range m.g.Prefixes {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// 按声明顺序返回指定类型的命名资源
+func (m *owlImporter) subjectsOfType(typeIRIs ...string) []Term {
+	subjects := []Term{}
+	seen := map[Term]bool{}
+	for _, t := range m.g.Triples {
+		if t.Predicate.Value != RDF_TYPE || !t.Subject.IsIRI() || seen[t.Subject] {
+			continue
+		}
+		for _, typeIRI := range typeIRIs {
+			if t.Object.Value == typeIRI {
+				seen[t.Subject] = true
+				subjects = append(subjects, t.Subject)
+				break
+			}
+		}
+	}
+	return subjects
+}
+
+// 取出主语的某个谓词的全部宾语，并标记为已映射
+func (m *owlImporter) take(subject Term, predicate string) []Term {
+	objects := []Term{}
+	for _, idx := range m.bySubject[subject] {
+		t := m.g.Triples[idx]
+		if t.Predicate.Value == predicate && !m.consumed[idx] {
+			m.consumed[idx] = true
+			objects = append(objects, t.Object)
+		}
+	}
+	return objects
+}
+
+// 取出字面量值，多个值时优先不带语言标签的
+func (m *owlImporter) takeString(subject Term, predicate string) string {
+	value := ""
+	plain := false
+	for _, object := range m.take(subject, predicate) {
+		if !object.IsLiteral() || plain {
+			continue
+		}
+		if value == "" || object.Lang == "" {
+			value = object.Value
+			plain = object.Lang == ""
+		}
+	}
+	return value
+}
+
+func (m *owlImporter) takeStrings(subject Term, predicate string) []string {
+	values := []string{}
+	for _, object := range m.take(subject, predicate) {
+		if object.IsLiteral() {
+			values = append(values, object.Value)
+		}
+	}
+	return values
+}
+
+func (m *owlImporter) takeType(subject Term, typeIRI string) {
+	for _, idx := range m.bySubject[subject] {
+		t := m.g.Triples[idx]
+		if t.Predicate.Value == RDF_TYPE && t.Object.Value == typeIRI {
+			m.consumed[idx] = true
+		}
+	}
+}
+
+func (m *owlImporter) commonInfo(subject Term) interfaces.CommonInfo {
+	return interfaces.CommonInfo{
+		Tags:    m.takeStrings(subject, KW_TAG),
+		Comment: m.takeString(subject, RDFS_COMMENT),
+		Icon:    m.takeString(subject, KW_ICON),
+		Color:   m.takeString(subject, KW_COLOR),
+		Detail:  m.takeString(subject, KW_DETAIL),
+	}
+}
+
+func (m *owlImporter) label(subject Term) string {
+	label := m.takeString(subject, RDFS_LABEL)
+	if label == "" && subject.IsIRI() {
+		label = localName(subject.Value)
+	}
+	return label
+}
+
+func (m *owlImporter) report(subject Term, predicate Term, object Term, reason string) {
+	entry := &interfaces.OWLUnmapped{
+		Subject: m.g.Display(subject),
+		Reason:  reason,
+	}
+	if predicate.Value != "" {
+		entry.Predicate = m.g.Display(predicate)
+	}
+	if object.Value != "" || object.IsLiteral() {
+		entry.Object = m.g.Display(object)
+	}
+	m.unmapped = append(m.unmapped, entry)
+}
+
+// 整个资源不能映射时只报告一条，资源的三元组及其引用的空白节点一并标记
+func (m *owlImporter) reportResource(subject Term, reason string) {
+	m.report(subject, Term{}, Term{}, reason)
+	m.consumeResource(subject)
+}
+
+func (m *owlImporter) consumeResource(subject Term) {
+	for _, idx := range m.bySubject[subject] {
+		if m.consumed[idx] {
+			continue
+		}
+		m.consumed[idx] = true
+		if object := m.g.Triples[idx].Object; object.IsBlank() {
+			m.consumeResource(object)
+		}
+	}
+}
+
+// 报告单个三元组，宾语是空白节点时其结构一并标记
+func (m *owlImporter) reportObject(subject Term, predicate string, object Term, reason string) {
+	m.report(subject, IRI(predicate), object, reason)
+	if object.IsBlank() {
+		m.consumeResource(object)
+	}
+}
+
+func (m *owlImporter) mapOntology() (*interfaces.KN, error) {
+	ontologies := []Term{}
+	seen := map[Term]bool{}
+	for _, t := range m.g.Triples {
+		if t.Predicate.Value == RDF_TYPE && t.Object.Value == OWL_ONTOLOGY && !seen[t.Subject] {
+			seen[t.Subject] = true
+			ontologies = append(ontologies, t.Subject)
+		}
+	}
+	if len(ontologies) == 0 {
+		return nil, fmt.Errorf("no owl:Ontology declared in the document")
+	}
+
+	ontology := ontologies[0]
+	m.takeType(ontology, OWL_ONTOLOGY)
+	kn := &interfaces.KN{
+		KNID:   m.takeString(ontology, KW_ID),
+		KNName: m.label(ontology),
+		Branch: interfaces.MAIN_BRANCH,
+	}
+	if kn.KNID == "" && ontology.IsIRI() {
+		name := strings.TrimPrefix(ontology.Value, KN_IRI_PREFIX)
+		kn.KNID = sanitizeID(localName(strings.TrimSuffix(strings.TrimSuffix(name, "#"), "/")))
+	}
+	if kn.KNID == "" {
+		kn.KNID = xid.New().String()
+	}
+	if kn.KNName == "" {
+		kn.KNName = kn.KNID
+	}
+	info := m.commonInfo(ontology)
+	kn.Tags = info.Tags
+	kn.Comment = info.Comment
+	kn.Icon = info.Icon
+	kn.Color = info.Color
+	kn.Detail = info.Detail
+
+	for _, other := range ontologies[1:] {
+		m.reportResource(other, "only the first owl:Ontology in the document is imported")
+	}
+
+	// 本体管理自身的注解属性声明
+	for subject := range m.bySubject {
+		if subject.IsIRI() && strings.HasPrefix(subject.Value, NS_KW) {
+			m.consumeResource(subject)
+		}
+	}
+	return kn, nil
+}
+
+func (m *owlImporter) isOWLClass(subject Term) bool {
+	return m.types[subject][OWL_CLASS] || m.types[subject][RDFS_CLASS]
+}
+
+func (m *owlImporter) mapClasses(kn *interfaces.KN) {
+	classes := []Term{}
+	for _, class := range m.subjectsOfType(OWL_CLASS, RDFS_CLASS) {
+		if !strings.HasPrefix(class.Value, NS_KW) && class.Value != OWL_THING {
+			classes = append(classes, class)
+		}
+	}
+
+	// 作为数据属性 domain 的类
+	hasProps := map[Term]bool{}
+	for _, property := range m.subjectsOfType(OWL_DATATYPE_PROPERTY) {
+		for _, idx := range m.bySubject[property] {
+			t := m.g.Triples[idx]
+			if t.Predicate.Value == RDFS_DOMAIN {
+				for _, domain := range m.classUnion(t.Object) {
+					hasProps[domain] = true
+				}
+			}
+		}
+	}
+	// 有命名子类的类
+	hasSubclass := map[Term]bool{}
+	for _, class := range classes {
+		for _, idx := range m.bySubject[class] {
+			t := m.g.Triples[idx]
+			if t.Predicate.Value == RDFS_SUBCLASS_OF && t.Object.IsIRI() {
+				hasSubclass[t.Object] = true
+			}
+		}
+	}
+
+	otIDs := map[string]bool{}
+	cgIDs := map[string]bool{}
+	for _, class := range classes {
+		isGroup := m.types[class][KW_CONCEPT_GROUP] || (hasSubclass[class] && !hasProps[class])
+		m.takeType(class, OWL_CLASS)
+		m.takeType(class, RDFS_CLASS)
+		m.takeType(class, KW_CONCEPT_GROUP)
+
+		id := m.takeString(class, KW_ID)
+		if id == "" {
+			id = sanitizeID(localName(class.Value))
+		}
+		name := m.label(class)
+		info := m.commonInfo(class)
+
+		if isGroup {
+			cg := &interfaces.ConceptGroup{
+				CGID:       uniqueName(id, cgIDs),
+				CGName:     name,
+				CommonInfo: info,
+				Branch:     kn.Branch,
+			}
+			m.cgClasses[class] = cg
+			kn.ConceptGroups = append(kn.ConceptGroups, cg)
+			continue
+		}
+
+		ot := &interfaces.ObjectType{
+			ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+				OTID:   uniqueName(id, otIDs),
+				OTName: name,
+			},
+			CommonInfo: info,
+			Branch:     kn.Branch,
+		}
+		m.otClasses[class] = ot
+		m.otOrder = append(m.otOrder, class)
+		kn.ObjectTypes = append(kn.ObjectTypes, ot)
+	}
+
+	// 类层次: 对象类归入父类对应的分组，分组之间的层次展开
+	parents := map[Term][]Term{}
+	for _, class := range classes {
+		for _, super := range m.take(class, RDFS_SUBCLASS_OF) {
+			switch {
+			case super.IsIRI() && super.Value == OWL_THING:
+			case m.cgClasses[super] != nil:
+				parents[class] = append(parents[class], super)
+				if m.cgClasses[class] != nil {
+					m.report(class, IRI(RDFS_SUBCLASS_OF), super,
+						"concept groups cannot be nested, object types of the subgroup are added to the parent group")
+				}
+			case m.otClasses[super] != nil:
+				m.report(class, IRI(RDFS_SUBCLASS_OF), super, "inheritance between object types is not supported")
+			case super.IsBlank():
+				m.reportObject(class, RDFS_SUBCLASS_OF, super, "anonymous class expressions are not supported")
+			default:
+				m.report(class, IRI(RDFS_SUBCLASS_OF), super, "superclass is not declared as an owl:Class")
+			}
+		}
+	}
+	for _, class := range m.otOrder {
+		ot := m.otClasses[class]
+		visited := map[Term]bool{}
+		queue := append([]Term{}, parents[class]...)
+		for len(queue) > 0 {
+			group := queue[0]
+			queue = queue[1:]
+			if visited[group] {
+				continue
+			}
+			visited[group] = true
+			ot.ConceptGroups = append(ot.ConceptGroups, &interfaces.ConceptGroup{CGID: m.cgClasses[group].CGID})
+			queue = append(queue, parents[group]...)
+		}
+	}
+}
+
+// 类表达式展开为命名类: 命名类本身，或 owl:unionOf 的成员，其他表达式返回 nil
+func (m *owlImporter) classUnion(expr Term) []Term {
+	if expr.IsIRI() {
+		return []Term{expr}
+	}
+	if !expr.IsBlank() {
+		return nil
+	}
+	var members []Term
+	for _, idx := range m.bySubject[expr] {
+		t := m.g.Triples[idx]
+		switch {
+		case t.Predicate.Value == RDF_TYPE && t.Object.Value == OWL_CLASS:
+		case t.Predicate.Value == OWL_UNION_OF && members == nil:
+			items, _, ok := m.g.list(t.Object, m.bySubject)
+			if !ok {
+				return nil
+			}
+			for _, item := range items {
+				if !item.IsIRI() {
+					return nil
+				}
+			}
+			members = items
+		default:
+			return nil
+		}
+	}
+	return members
+}
+
+func (m *owlImporter) mapDataProperties() {
+	for _, property := range m.subjectsOfType(OWL_DATATYPE_PROPERTY) {
+		domains := m.take(property, RDFS_DOMAIN)
+		var ots []*interfaces.ObjectType
+		if len(domains) == 1 {
+			for _, class := range m.classUnion(domains[0]) {
+				ot := m.otClasses[class]
+				if ot == nil {
+					ots = nil
+					break
+				}
+				ots = append(ots, ot)
+			}
+		}
+		if len(ots) == 0 {
+			m.reportResource(property, "datatype property must have exactly one object type (or a union of object types) as rdfs:domain")
+			continue
+		}
+		if domains[0].IsBlank() {
+			m.consumeResource(domains[0])
+		}
+
+		m.takeType(property, OWL_DATATYPE_PROPERTY)
+		// 数据属性本身就是单值的
+		m.takeType(property, OWL_FUNCTIONAL_PROPERTY)
+
+		name := m.takeString(property, KW_NAME)
+		if name == "" {
+			name = sanitizePropertyName(localName(property.Value))
+		}
+		displayName := m.takeString(property, RDFS_LABEL)
+		if displayName == "" {
+			displayName = name
+		}
+		comment := m.takeString(property, RDFS_COMMENT)
+		propType := m.dataType(property)
+
+		m.propNames[property] = map[*interfaces.ObjectType]string{}
+		for _, ot := range ots {
+			names := map[string]bool{}
+			for _, prop := range ot.DataProperties {
+				names[prop.Name] = true
+			}
+			propName := uniqueName(name, names)
+			ot.DataProperties = append(ot.DataProperties, &interfaces.DataProperty{
+				Name:        propName,
+				DisplayName: displayName,
+				Type:        propType,
+				Comment:     comment,
+			})
+			m.propNames[property][ot] = propName
+		}
+	}
+}
+
+// 数据属性类型，优先使用 kw:dataType 记录的原始类型
+func (m *owlImporter) dataType(property Term) string {
+	kwType := m.takeString(property, KW_DATA_TYPE)
+	ranges := m.take(property, RDFS_RANGE)
+	if interfaces.ValidDataPropertyTypes[kwType] {
+		return kwType
+	}
+
+	if len(ranges) == 0 {
+		m.report(property, Term{}, Term{}, "datatype property has no rdfs:range, imported as string")
+		return data_type.DATATYPE_STRING
+	}
+	if len(ranges) == 1 && ranges[0].IsIRI() {
+		if propType, ok := xsdToDataType[ranges[0].Value]; ok {
+			return propType
+		}
+	}
+	for _, rng := range ranges {
+		m.reportObject(property, RDFS_RANGE, rng, "unsupported datatype, imported as string")
+	}
+	return data_type.DATATYPE_STRING
+}
+
+func (m *owlImporter) mapKeys() {
+	for _, class := range m.otOrder {
+		ot := m.otClasses[class]
+		props := map[string]*interfaces.DataProperty{}
+		for _, prop := range ot.DataProperties {
+			props[prop.Name] = prop
+		}
+
+		for _, keys := range m.take(class, OWL_HAS_KEY) {
+			items, _, ok := m.g.list(keys, m.bySubject)
+			if !ok {
+				m.reportObject(class, OWL_HAS_KEY, keys, "owl:hasKey must be a list of datatype properties")
+				continue
+			}
+			m.consumeResource(keys)
+			for _, item := range items {
+				name, ok := m.propNames[item][ot]
+				switch {
+				case !ok:
+					m.report(class, IRI(OWL_HAS_KEY), item, "key is not a datatype property of the class")
+				case !interfaces.ValidPrimaryKeyTypes[props[name].Type]:
+					m.report(class, IRI(OWL_HAS_KEY), item,
+						fmt.Sprintf("key type %s can not be a primary key", props[name].Type))
+				default:
+					ot.PrimaryKeys = append(ot.PrimaryKeys, name)
+				}
+			}
+		}
+
+		if len(ot.PrimaryKeys) == 0 {
+			prop, ok := props[GENERATED_PRIMARY_KEY]
+			if !ok || !interfaces.ValidPrimaryKeyTypes[prop.Type] {
+				names := map[string]bool{}
+				for name := range props {
+					names[name] = true
+				}
+				prop = &interfaces.DataProperty{
+					Name:        uniqueName(GENERATED_PRIMARY_KEY, names),
+					DisplayName: GENERATED_PRIMARY_KEY,
+					Type:        data_type.DATATYPE_STRING,
+				}
+				ot.DataProperties = append(ot.DataProperties, prop)
+				props[prop.Name] = prop
+			}
+			ot.PrimaryKeys = []string{prop.Name}
+			m.report(class, Term{}, Term{}, fmt.Sprintf("no owl:hasKey declared, property '%s' is used as the primary key", prop.Name))
+		}
+
+		displayKey := m.takeString(class, KW_DISPLAY_KEY)
+		if prop, ok := props[displayKey]; ok && interfaces.ValidDisplayKeyTypes[prop.Type] {
+			ot.DisplayKey = displayKey
+		} else {
+			ot.DisplayKey = ot.PrimaryKeys[0]
+			for _, prop := range ot.DataProperties {
+				if prop.Type == data_type.DATATYPE_STRING || prop.Type == data_type.DATATYPE_TEXT {
+					ot.DisplayKey = prop.Name
+					break
+				}
+			}
+		}
+
+		incrementalKey := m.takeString(class, KW_INCREMENTAL_KEY)
+		if _, ok := props[incrementalKey]; ok {
+			ot.IncrementalKey = incrementalKey
+		}
+	}
+}
+
+func (m *owlImporter) mapObjectProperties(kn *interfaces.KN) {
+	rtIDs := map[string]bool{}
+	for _, property := range m.subjectsOfType(OWL_OBJECT_PROPERTY) {
+		domains := m.take(property, RDFS_DOMAIN)
+		ranges := m.take(property, RDFS_RANGE)
+		if len(domains) != 1 || len(ranges) != 1 || m.otClasses[domains[0]] == nil || m.otClasses[ranges[0]] == nil {
+			m.reportResource(property, "object property must have exactly one object type as rdfs:domain and one as rdfs:range")
+			continue
+		}
+
+		// 关系类必须有映射规则，通用本体中没有对应的结构
+		relationType := m.takeString(property, KW_RELATION_TYPE)
+		rules := m.takeString(property, KW_MAPPING_RULES)
+		var mappingRules any
+		if rules != "" {
+			if err := json.Unmarshal([]byte(rules), &mappingRules); err != nil {
+				mappingRules = nil
+			}
+		}
+		if relationType == "" || mappingRules == nil {
+			m.reportResource(property, "relation type requires kw:relationType and kw:mappingRules, create it after import")
+			continue
+		}
+
+		m.takeType(property, OWL_OBJECT_PROPERTY)
+		id := m.takeString(property, KW_ID)
+		if id == "" {
+			id = sanitizeID(localName(property.Value))
+		}
+		rt := &interfaces.RelationType{
+			RelationTypeWithKeyField: interfaces.RelationTypeWithKeyField{
+				RTID:               uniqueName(id, rtIDs),
+				RTName:             m.label(property),
+				SourceObjectTypeID: m.otClasses[domains[0]].OTID,
+				TargetObjectTypeID: m.otClasses[ranges[0]].OTID,
+				Type:               relationType,
+				MappingRules:       mappingRules,
+			},
+			CommonInfo: m.commonInfo(property),
+			Branch:     kn.Branch,
+		}
+		kn.RelationTypes = append(kn.RelationTypes, rt)
+	}
+}
+
+// 其余未映射的三元组。完全没有被映射的资源只报告一条，空白节点的结构归入引用它的三元组
+func (m *owlImporter) reportRemaining() {
+	referenced := map[Term]bool{}
+	for _, t := range m.g.Triples {
+		if t.Object.IsBlank() {
+			referenced[t.Object] = true
+		}
+	}
+
+	partial := map[Term]bool{}
+	for i, t := range m.g.Triples {
+		if m.consumed[i] {
+			partial[t.Subject] = true
+		}
+	}
+
+	reported := map[Term]bool{}
+	for i, t := range m.g.Triples {
+		if m.consumed[i] || (t.Subject.IsBlank() && referenced[t.Subject]) {
+			continue
+		}
+		if partial[t.Subject] {
+			m.report(t.Subject, t.Predicate, t.Object, "not mapped to any knowledge network concept")
+			continue
+		}
+		if reported[t.Subject] {
+			continue
+		}
+		reported[t.Subject] = true
+		reason := "resource is not mapped to any knowledge network concept"
+		for _, idx := range m.bySubject[t.Subject] {
+			if typ := m.g.Triples[idx]; typ.Predicate.Value == RDF_TYPE {
+				reason = fmt.Sprintf("resource of type %s is not mapped to any knowledge network concept", m.g.Display(typ.Object))
+				break
+			}
+		}
+		m.report(t.Subject, Term{}, Term{}, reason)
+	}
+}
+
+// 转换为合法的概念 id: 小写字母、数字、_、-，不以 _、- 开头
+func sanitizeID(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	return truncate(strings.TrimLeft(sb.String(), "_-"), ID_MAX_LENGTH)
+}
+
+// 转换为合法的属性名，与 id 的区别是允许大写字母
+func sanitizePropertyName(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case isLetterOrDigit(r) || r == '_' || r == '-':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	name := truncate(strings.TrimLeft(sb.String(), "_-"), ID_MAX_LENGTH)
+	if name == "" {
+		name = "property"
+	}
+	return name
+}
+
+// 在 used 中不重复的名称，重复时加数字后缀。空名称生成新的 id
+func uniqueName(name string, used map[string]bool) string {
+	if name == "" {
+		name = xid.New().String()
+	}
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		suffix := "_" + strconv.Itoa(i)
+		candidate = truncate(name, ID_MAX_LENGTH-len(suffix)) + suffix
+	}
+	used[candidate] = true
+	return candidate
+}
+
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
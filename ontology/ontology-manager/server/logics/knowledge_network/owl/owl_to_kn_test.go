@@ -0,0 +1,236 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package owl
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"ontology-manager/interfaces"
+)
+
+func testKN() *interfaces.KN {
+	return &interfaces.KN{
+		KNID:    "kn1",
+		KNName:  "供应链",
+		Tags:    []string{"a"},
+		Comment: "supply chain",
+		Branch:  interfaces.MAIN_BRANCH,
+		ConceptGroups: []*interfaces.ConceptGroup{
+			{CGID: "cg1", CGName: "组织", CommonInfo: interfaces.CommonInfo{Color: "#fff"}},
+		},
+		ObjectTypes: []*interfaces.ObjectType{
+			{
+				ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+					OTID:   "company",
+					OTName: "公司",
+					DataProperties: []*interfaces.DataProperty{
+						{Name: "code", DisplayName: "编码", Type: "string"},
+						{Name: "name", DisplayName: "名称", Type: "text", Comment: "全称"},
+						{Name: "geo", DisplayName: "位置", Type: "point"},
+						{Name: "updated", DisplayName: "更新时间", Type: "timestamp"},
+					},
+					PrimaryKeys:    []string{"code"},
+					DisplayKey:     "name",
+					IncrementalKey: "updated",
+				},
+				CommonInfo:    interfaces.CommonInfo{Icon: "icon-company"},
+				ConceptGroups: []*interfaces.ConceptGroup{{CGID: "cg1"}},
+			},
+			{
+				ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+					OTID:           "product",
+					OTName:         "产品",
+					DataProperties: []*interfaces.DataProperty{{Name: "sku", DisplayName: "SKU", Type: "unsigned integer"}},
+					PrimaryKeys:    []string{"sku"},
+					DisplayKey:     "sku",
+				},
+			},
+		},
+		RelationTypes: []*interfaces.RelationType{
+			{
+				RelationTypeWithKeyField: interfaces.RelationTypeWithKeyField{
+					RTID:               "produces",
+					RTName:             "生产",
+					SourceObjectTypeID: "company",
+					TargetObjectTypeID: "product",
+					Type:               interfaces.RELATION_TYPE_DIRECT,
+					MappingRules: []any{map[string]any{
+						"source_property": map[string]any{"name": "code"},
+						"target_property": map[string]any{"name": "sku"},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func Test_OWL_RoundTrip(t *testing.T) {
+	Convey("Test FromKN and ToKN round trip", t, func() {
+		for _, format := range []string{interfaces.OWL_FORMAT_TURTLE, interfaces.OWL_FORMAT_JSONLD} {
+			Convey("Success with format "+format+"\n", func() {
+				data, err := Marshal(FromKN(testKN()), format)
+				So(err, ShouldBeNil)
+
+				g, err := Unmarshal(data, format)
+				So(err, ShouldBeNil)
+				kn, unmapped, err := ToKN(g)
+				So(err, ShouldBeNil)
+				So(unmapped, ShouldBeEmpty)
+
+				So(kn.KNID, ShouldEqual, "kn1")
+				So(kn.KNName, ShouldEqual, "供应链")
+				So(kn.Tags, ShouldResemble, []string{"a"})
+				So(kn.Comment, ShouldEqual, "supply chain")
+				So(kn.Branch, ShouldEqual, interfaces.MAIN_BRANCH)
+
+				So(len(kn.ConceptGroups), ShouldEqual, 1)
+				So(kn.ConceptGroups[0].CGID, ShouldEqual, "cg1")
+				So(kn.ConceptGroups[0].CGName, ShouldEqual, "组织")
+				So(kn.ConceptGroups[0].Color, ShouldEqual, "#fff")
+
+				So(len(kn.ObjectTypes), ShouldEqual, 2)
+				company := kn.ObjectTypes[0]
+				So(company.OTID, ShouldEqual, "company")
+				So(company.OTName, ShouldEqual, "公司")
+				So(company.Icon, ShouldEqual, "icon-company")
+				So(company.DataProperties, ShouldResemble, testKN().ObjectTypes[0].DataProperties)
+				So(company.PrimaryKeys, ShouldResemble, []string{"code"})
+				So(company.DisplayKey, ShouldEqual, "name")
+				So(company.IncrementalKey, ShouldEqual, "updated")
+				So(len(company.ConceptGroups), ShouldEqual, 1)
+				So(company.ConceptGroups[0].CGID, ShouldEqual, "cg1")
+				So(kn.ObjectTypes[1].DataProperties[0].Type, ShouldEqual, "unsigned integer")
+
+				So(len(kn.RelationTypes), ShouldEqual, 1)
+				rt := kn.RelationTypes[0]
+				So(rt.RTID, ShouldEqual, "produces")
+				So(rt.RTName, ShouldEqual, "生产")
+				So(rt.SourceObjectTypeID, ShouldEqual, "company")
+				So(rt.TargetObjectTypeID, ShouldEqual, "product")
+				So(rt.Type, ShouldEqual, interfaces.RELATION_TYPE_DIRECT)
+				So(rt.MappingRules, ShouldResemble, testKN().RelationTypes[0].MappingRules)
+			})
+		}
+	})
+}
+
+func Test_OWL_ToKN(t *testing.T) {
+	Convey("Test ToKN with ontology from other tools", t, func() {
+		Convey("Success with report of unmapped constructs\n", func() {
+			doc := `
+@prefix : <http://example.org/pizza#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+@prefix dc: <http://purl.org/dc/elements/1.1/> .
+
+<http://example.org/pizza> a owl:Ontology ;
+    rdfs:label "Pizza Ontology"@en ;
+    dc:creator "someone" .
+
+:Food a owl:Class .
+:Pizza a owl:Class ; rdfs:subClassOf :Food ;
+    rdfs:label "Pizza"@en ;
+    owl:hasKey ( :pizzaCode ) ;
+    rdfs:subClassOf [ a owl:Restriction ; owl:onProperty :hasTopping ; owl:someValuesFrom :Topping ] .
+:Topping a owl:Class ; rdfs:subClassOf :Food .
+:CheeseTopping a owl:Class ; rdfs:subClassOf :Topping .
+
+:pizzaCode a owl:DatatypeProperty, owl:FunctionalProperty ;
+    rdfs:domain :Pizza ; rdfs:range xsd:int .
+:calories a owl:DatatypeProperty ;
+    rdfs:domain [ a owl:Class ; owl:unionOf ( :Pizza :CheeseTopping ) ] ;
+    rdfs:range xsd:nonNegativeInteger .
+:spiciness a owl:DatatypeProperty ; rdfs:domain :CheeseTopping ; rdfs:range xsd:gYear .
+:orphan a owl:DatatypeProperty .
+
+:hasTopping a owl:ObjectProperty, owl:TransitiveProperty ; rdfs:domain :Pizza ; rdfs:range :CheeseTopping .
+
+:margherita a owl:NamedIndividual, :Pizza .
+`
+			g, err := ParseTurtle([]byte(doc))
+			So(err, ShouldBeNil)
+			kn, unmapped, err := ToKN(g)
+			So(err, ShouldBeNil)
+
+			So(kn.KNID, ShouldEqual, "pizza")
+			So(kn.KNName, ShouldEqual, "Pizza Ontology")
+
+			// Food、Topping 只有子类，作为分组，多层层次展开
+			So(len(kn.ConceptGroups), ShouldEqual, 2)
+			So(kn.ConceptGroups[0].CGID, ShouldEqual, "food")
+			So(kn.ConceptGroups[1].CGID, ShouldEqual, "topping")
+
+			So(len(kn.ObjectTypes), ShouldEqual, 2)
+			pizza, cheese := kn.ObjectTypes[0], kn.ObjectTypes[1]
+			So(pizza.OTID, ShouldEqual, "pizza")
+			So(pizza.OTName, ShouldEqual, "Pizza")
+			So(len(pizza.ConceptGroups), ShouldEqual, 1)
+			So(pizza.ConceptGroups[0].CGID, ShouldEqual, "food")
+			So(pizza.DataProperties, ShouldResemble, []*interfaces.DataProperty{
+				{Name: "pizzaCode", DisplayName: "pizzaCode", Type: "integer"},
+				{Name: "calories", DisplayName: "calories", Type: "unsigned integer"},
+			})
+			So(pizza.PrimaryKeys, ShouldResemble, []string{"pizzaCode"})
+			So(pizza.DisplayKey, ShouldEqual, "pizzaCode")
+
+			So(cheese.OTID, ShouldEqual, "cheesetopping")
+			So(len(cheese.ConceptGroups), ShouldEqual, 2)
+			So(cheese.ConceptGroups[0].CGID, ShouldEqual, "topping")
+			So(cheese.ConceptGroups[1].CGID, ShouldEqual, "food")
+			So(len(cheese.DataProperties), ShouldEqual, 3)
+			So(cheese.DataProperties[1].Name, ShouldEqual, "spiciness")
+			So(cheese.DataProperties[1].Type, ShouldEqual, "string")
+			So(cheese.DataProperties[2].Name, ShouldEqual, "id")
+			So(cheese.PrimaryKeys, ShouldResemble, []string{"id"})
+			So(cheese.DisplayKey, ShouldEqual, "spiciness")
+
+			So(kn.RelationTypes, ShouldBeEmpty)
+
+			reasons := map[string]string{}
+			for _, item := range unmapped {
+				object := item.Object
+				if strings.HasPrefix(object, "_:") {
+					object = "_:"
+				}
+				reasons[item.Subject+" "+item.Predicate+" "+object] = item.Reason
+			}
+			So(reasons, ShouldContainKey, "<http://example.org/pizza> dc:creator \"someone\"")
+			So(reasons, ShouldContainKey, ":Topping rdfs:subClassOf :Food")
+			So(reasons[":Pizza rdfs:subClassOf _:"], ShouldContainSubstring, "anonymous class expressions")
+			So(reasons, ShouldContainKey, ":spiciness rdfs:range xsd:gYear")
+			So(reasons[":orphan  "], ShouldContainSubstring, "rdfs:domain")
+			So(reasons[":CheeseTopping  "], ShouldContainSubstring, "no owl:hasKey")
+			So(reasons[":hasTopping  "], ShouldContainSubstring, "kw:mappingRules")
+			So(reasons[":margherita  "], ShouldContainSubstring, "owl:NamedIndividual")
+			So(len(unmapped), ShouldEqual, 8)
+		})
+
+		Convey("Failed without ontology declaration\n", func() {
+			g, err := ParseTurtle([]byte(`<http://a> a <http://www.w3.org/2002/07/owl#Class> .`))
+			So(err, ShouldBeNil)
+			_, _, err = ToKN(g)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_OWL_SanitizeID(t *testing.T) {
+	Convey("Test sanitizeID and uniqueName", t, func() {
+		So(sanitizeID("_My Class.v2"), ShouldEqual, "my_class_v2")
+		So(sanitizeID("人员"), ShouldEqual, "")
+		So(sanitizePropertyName("has-Name"), ShouldEqual, "has-Name")
+		So(sanitizePropertyName("名称"), ShouldEqual, "property")
+
+		used := map[string]bool{}
+		So(uniqueName("a", used), ShouldEqual, "a")
+		So(uniqueName("a", used), ShouldEqual, "a_2")
+		So(len(uniqueName("", used)), ShouldEqual, 20)
+	})
+}
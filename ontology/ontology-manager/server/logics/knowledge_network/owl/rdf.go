@@ -0,0 +1,308 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package owl 实现业务知识网络与 OWL 本体(Turtle、JSON-LD 两种 RDF 序列化格式)之间的互相转换。
+// 只依赖标准库，RDF 的解析和序列化只覆盖本体交换用得到的语法子集。
+package owl
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	NS_RDF  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	NS_RDFS = "http://www.w3.org/2000/01/rdf-schema#"
+	NS_OWL  = "http://www.w3.org/2002/07/owl#"
+	NS_XSD  = "http://www.w3.org/2001/XMLSchema#"
+	// 本体管理自定义的注解属性，承载 OWL 无法直接表达的业务知识网络信息
+	NS_KW = "urn:kweaver:ontology#"
+
+	// 业务知识网络的本体 IRI 前缀，完整 IRI 为 urn:kweaver:kn:<kn_id>
+	KN_IRI_PREFIX = "urn:kweaver:kn:"
+
+	RDF_TYPE        = NS_RDF + "type"
+	RDF_FIRST       = NS_RDF + "first"
+	RDF_REST        = NS_RDF + "rest"
+	RDF_NIL         = NS_RDF + "nil"
+	RDF_LANG_STRING = NS_RDF + "langString"
+	RDF_JSON        = NS_RDF + "JSON"
+
+	RDFS_CLASS       = NS_RDFS + "Class"
+	RDFS_LABEL       = NS_RDFS + "label"
+	RDFS_COMMENT     = NS_RDFS + "comment"
+	RDFS_DOMAIN      = NS_RDFS + "domain"
+	RDFS_RANGE       = NS_RDFS + "range"
+	RDFS_SUBCLASS_OF = NS_RDFS + "subClassOf"
+
+	OWL_ONTOLOGY            = NS_OWL + "Ontology"
+	OWL_CLASS               = NS_OWL + "Class"
+	OWL_THING               = NS_OWL + "Thing"
+	OWL_DATATYPE_PROPERTY   = NS_OWL + "DatatypeProperty"
+	OWL_OBJECT_PROPERTY     = NS_OWL + "ObjectProperty"
+	OWL_ANNOTATION_PROPERTY = NS_OWL + "AnnotationProperty"
+	OWL_HAS_KEY             = NS_OWL + "hasKey"
+	OWL_UNION_OF            = NS_OWL + "unionOf"
+	OWL_FUNCTIONAL_PROPERTY = NS_OWL + "FunctionalProperty"
+
+	XSD_STRING    = NS_XSD + "string"
+	XSD_BOOLEAN   = NS_XSD + "boolean"
+	XSD_INTEGER   = NS_XSD + "integer"
+	XSD_DECIMAL   = NS_XSD + "decimal"
+	XSD_DOUBLE    = NS_XSD + "double"
+	XSD_FLOAT     = NS_XSD + "float"
+	XSD_DATE      = NS_XSD + "date"
+	XSD_DATE_TIME = NS_XSD + "dateTime"
+	XSD_TIMESTAMP = NS_XSD + "dateTimeStamp"
+	XSD_TIME      = NS_XSD + "time"
+
+	KW_CONCEPT_GROUP   = NS_KW + "ConceptGroup"
+	KW_ID              = NS_KW + "id"
+	KW_NAME            = NS_KW + "name"
+	KW_DATA_TYPE       = NS_KW + "dataType"
+	KW_DISPLAY_KEY     = NS_KW + "displayKey"
+	KW_INCREMENTAL_KEY = NS_KW + "incrementalKey"
+	KW_RELATION_TYPE   = NS_KW + "relationType"
+	KW_MAPPING_RULES   = NS_KW + "mappingRules"
+	KW_TAG             = NS_KW + "tag"
+	KW_ICON            = NS_KW + "icon"
+	KW_COLOR           = NS_KW + "color"
+	KW_DETAIL          = NS_KW + "detail"
+)
+
+// 导出时使用的固定前缀，解析结果中的前缀也会叠加在这之上，用于生成可读的映射报告
+var StandardPrefixes = []Prefix{
+	{Name: "rdf", IRI: NS_RDF},
+	{Name: "rdfs", IRI: NS_RDFS},
+	{Name: "owl", IRI: NS_OWL},
+	{Name: "xsd", IRI: NS_XSD},
+	{Name: "kw", IRI: NS_KW},
+}
+
+type TermType int
+
+const (
+	TermIRI TermType = iota
+	TermBlank
+	TermLiteral
+)
+
+// RDF 项，Datatype 和 Lang 只对字面量有意义
+type Term struct {
+	Type     TermType
+	Value    string
+	Datatype string
+	Lang     string
+}
+
+type Triple struct {
+	Subject   Term
+	Predicate Term
+	Object    Term
+}
+
+type Prefix struct {
+	Name string
+	IRI  string
+}
+
+// 按加入顺序保存的三元组集合
+type Graph struct {
+	Triples  []Triple
+	Prefixes []Prefix
+
+	blankSeq int
+}
+
+func IRI(value string) Term {
+	return Term{Type: TermIRI, Value: value}
+}
+
+func Blank(label string) Term {
+	return Term{Type: TermBlank, Value: label}
+}
+
+// 不带类型的字面量即 xsd:string
+func Literal(value string) Term {
+	return Term{Type: TermLiteral, Value: value, Datatype: XSD_STRING}
+}
+
+func TypedLiteral(value string, datatype string) Term {
+	if datatype == "" {
+		datatype = XSD_STRING
+	}
+	return Term{Type: TermLiteral, Value: value, Datatype: datatype}
+}
+
+func LangLiteral(value string, lang string) Term {
+	return Term{Type: TermLiteral, Value: value, Datatype: RDF_LANG_STRING, Lang: strings.ToLower(lang)}
+}
+
+func (t Term) IsIRI() bool {
+	return t.Type == TermIRI
+}
+
+func (t Term) IsBlank() bool {
+	return t.Type == TermBlank
+}
+
+func (t Term) IsLiteral() bool {
+	return t.Type == TermLiteral
+}
+
+func NewGraph() *Graph {
+	return &Graph{}
+}
+
+func (g *Graph) Add(subject Term, predicate Term, object Term) {
+	g.Triples = append(g.Triples, Triple{Subject: subject, Predicate: predicate, Object: object})
+}
+
+// 生成图内唯一的空白节点
+func (g *Graph) NewBlank() Term {
+	g.blankSeq++
+	return Blank("b" + strconv.Itoa(g.blankSeq))
+}
+
+// 以 RDF 列表的形式加入 items，返回列表头，空列表返回 rdf:nil
+func (g *Graph) AddList(items []Term) Term {
+	if len(items) == 0 {
+		return IRI(RDF_NIL)
+	}
+	head := g.NewBlank()
+	node := head
+	for i, item := range items {
+		g.Add(node, IRI(RDF_FIRST), item)
+		if i == len(items)-1 {
+			g.Add(node, IRI(RDF_REST), IRI(RDF_NIL))
+		} else {
+			next := g.NewBlank()
+			g.Add(node, IRI(RDF_REST), next)
+			node = next
+		}
+	}
+	return head
+}
+
+// 设置前缀，同名前缀以后设置的为准
+func (g *Graph) SetPrefix(name string, iri string) {
+	for i := range g.Prefixes {
+		if g.Prefixes[i].Name == name {
+			g.Prefixes[i].IRI = iri
+			return
+		}
+	}
+	g.Prefixes = append(g.Prefixes, Prefix{Name: name, IRI: iri})
+}
+
+// 用图中的前缀把 IRI 压缩成 prefix:local 形式，压缩不了时返回 false
+func (g *Graph) compact(iri string) (string, bool) {
+	best := -1
+	for i, p := range g.Prefixes {
+		if p.IRI != "" && strings.HasPrefix(iri, p.IRI) && isPNLocal(iri[len(p.IRI):]) {
+			if best < 0 || len(p.IRI) > len(g.Prefixes[best].IRI) {
+				best = i
+			}
+		}
+	}
+	if best < 0 {
+		return "", false
+	}
+	return g.Prefixes[best].Name + ":" + iri[len(g.Prefixes[best].IRI):], true
+}
+
+// 可读形式，用于映射报告
+func (g *Graph) Display(t Term) string {
+	switch t.Type {
+	case TermIRI:
+		if name, ok := g.compact(t.Value); ok {
+			return name
+		}
+		return "<" + t.Value + ">"
+	case TermBlank:
+		return "_:" + t.Value
+	default:
+		return formatTurtleLiteral(g, t)
+	}
+}
+
+// 图中用作某个三元组宾语的次数，按空白节点统计
+func (g *Graph) blankReferences() map[string]int {
+	refs := map[string]int{}
+	for _, t := range g.Triples {
+		if t.Object.IsBlank() {
+			refs[t.Object.Value]++
+		}
+	}
+	return refs
+}
+
+// 解析以 node 开头的 RDF 列表，不是规范的列表时返回 false
+func (g *Graph) list(node Term, bySubject map[Term][]int) ([]Term, []int, bool) {
+	items := []Term{}
+	used := []int{}
+	visited := map[Term]bool{}
+	for !(node.IsIRI() && node.Value == RDF_NIL) {
+		if !node.IsBlank() || visited[node] {
+			return nil, nil, false
+		}
+		visited[node] = true
+
+		var first, rest *Term
+		for _, idx := range bySubject[node] {
+			t := g.Triples[idx]
+			switch {
+			case t.Predicate.Value == RDF_FIRST && first == nil:
+				first = &g.Triples[idx].Object
+			case t.Predicate.Value == RDF_REST && rest == nil:
+				rest = &g.Triples[idx].Object
+			default:
+				return nil, nil, false
+			}
+			used = append(used, idx)
+		}
+		if first == nil || rest == nil {
+			return nil, nil, false
+		}
+		items = append(items, *first)
+		node = *rest
+	}
+	return items, used, true
+}
+
+func (g *Graph) indexBySubject() map[Term][]int {
+	bySubject := map[Term][]int{}
+	for i, t := range g.Triples {
+		bySubject[t.Subject] = append(bySubject[t.Subject], i)
+	}
+	return bySubject
+}
+
+// IRI 的本地名，取最后一个 # / : 之后的部分
+func localName(iri string) string {
+	idx := strings.LastIndexAny(iri, "#/:")
+	if idx >= 0 && idx < len(iri)-1 {
+		return iri[idx+1:]
+	}
+	return iri
+}
+
+// Turtle 前缀名的本地部分，只接受不需要转义的常见字符
+func isPNLocal(local string) bool {
+	if local == "" {
+		return true
+	}
+	for i, r := range local {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+		case r == '-' && i > 0:
+		case r == '.' && i > 0 && i < len(local)-1:
+		case r > 0x7f && r != 0xfeff:
+		default:
+			return false
+		}
+	}
+	return true
+}
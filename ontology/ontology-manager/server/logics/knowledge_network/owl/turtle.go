@@ -0,0 +1,868 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package owl
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// WriteTurtle 把图序列化为 Turtle。
+// 主语按首次出现的顺序输出，只被引用一次的空白节点内联为 [ ... ] 或 ( ... )。
+func WriteTurtle(g *Graph) []byte {
+	w := &turtleWriter{
+		g:         g,
+		refs:      g.blankReferences(),
+		bySubject: g.indexBySubject(),
+		written:   map[Term]bool{},
+	}
+
+	var sb strings.Builder
+	for _, p := range g.Prefixes {
+		fmt.Fprintf(&sb, "@prefix %s: <%s> .\n", p.Name, escapeIRI(p.IRI))
+	}
+
+	subjects := []Term{}
+	seen := map[Term]bool{}
+	for _, t := range g.Triples {
+		if !seen[t.Subject] {
+			seen[t.Subject] = true
+			subjects = append(subjects, t.Subject)
+		}
+	}
+
+	// 先输出命名节点和多次引用的空白节点，内联的空白节点在引用处输出
+	for _, subject := range subjects {
+		if subject.IsBlank() && w.refs[subject.Value] == 1 {
+			continue
+		}
+		w.writeSubject(&sb, subject)
+	}
+	// 成环的空白节点无法内联，补充输出
+	for _, subject := range subjects {
+		if !w.written[subject] {
+			w.writeSubject(&sb, subject)
+		}
+	}
+
+	return []byte(sb.String())
+}
+
+type turtleWriter struct {
+	g         *Graph
+	refs      map[string]int
+	bySubject map[Term][]int
+	written   map[Term]bool
+}
+
+func (w *turtleWriter) writeSubject(sb *strings.Builder, subject Term) {
+	w.written[subject] = true
+	sb.WriteString("\n")
+	sb.WriteString(w.formatNode(subject))
+	w.writePredicates(sb, subject, "\n    ")
+	sb.WriteString(" .\n")
+}
+
+// 按谓词分组输出，rdf:type 排在最前面
+func (w *turtleWriter) writePredicates(sb *strings.Builder, subject Term, indent string) {
+	predicates := []Term{}
+	objects := map[Term][]Term{}
+	for _, idx := range w.bySubject[subject] {
+		t := w.g.Triples[idx]
+		if _, ok := objects[t.Predicate]; !ok {
+			if t.Predicate.Value == RDF_TYPE {
+				predicates = append([]Term{t.Predicate}, predicates...)
+			} else {
+				predicates = append(predicates, t.Predicate)
+			}
+		}
+		objects[t.Predicate] = append(objects[t.Predicate], t.Object)
+	}
+
+	for i, predicate := range predicates {
+		if i == 0 {
+			sb.WriteString(" ")
+		} else {
+			sb.WriteString(" ;")
+			sb.WriteString(indent)
+		}
+		if predicate.Value == RDF_TYPE {
+			sb.WriteString("a")
+		} else {
+			sb.WriteString(w.formatNode(predicate))
+		}
+		for j, object := range objects[predicate] {
+			if j == 0 {
+				sb.WriteString(" ")
+			} else {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(w.formatObject(object))
+		}
+	}
+}
+
+func (w *turtleWriter) formatObject(object Term) string {
+	if !object.IsBlank() || w.refs[object.Value] != 1 || w.written[object] {
+		return w.formatNode(object)
+	}
+
+	if items, _, ok := w.g.list(object, w.bySubject); ok {
+		w.markList(object)
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			parts = append(parts, w.formatObject(item))
+		}
+		return "( " + strings.Join(parts, " ") + " )"
+	}
+
+	w.written[object] = true
+	if len(w.bySubject[object]) == 0 {
+		return "[]"
+	}
+	var sb strings.Builder
+	sb.WriteString("[")
+	w.writePredicates(&sb, object, " ")
+	sb.WriteString(" ]")
+	return sb.String()
+}
+
+func (w *turtleWriter) markList(node Term) {
+	for node.IsBlank() && !w.written[node] {
+		w.written[node] = true
+		next := Term{}
+		for _, idx := range w.bySubject[node] {
+			if w.g.Triples[idx].Predicate.Value == RDF_REST {
+				next = w.g.Triples[idx].Object
+			}
+		}
+		node = next
+	}
+}
+
+func (w *turtleWriter) formatNode(t Term) string {
+	switch t.Type {
+	case TermIRI:
+		if t.Value == RDF_NIL {
+			return "()"
+		}
+		if name, ok := w.g.compact(t.Value); ok {
+			return name
+		}
+		return "<" + escapeIRI(t.Value) + ">"
+	case TermBlank:
+		return "_:" + t.Value
+	default:
+		return formatTurtleLiteral(w.g, t)
+	}
+}
+
+func formatTurtleLiteral(g *Graph, t Term) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range t.Value {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+
+	switch {
+	case t.Lang != "":
+		sb.WriteString("@" + t.Lang)
+	case t.Datatype != "" && t.Datatype != XSD_STRING:
+		if name, ok := g.compact(t.Datatype); ok {
+			sb.WriteString("^^" + name)
+		} else {
+			sb.WriteString("^^<" + escapeIRI(t.Datatype) + ">")
+		}
+	}
+	return sb.String()
+}
+
+func escapeIRI(iri string) string {
+	var sb strings.Builder
+	for _, r := range iri {
+		if r <= 0x20 || strings.ContainsRune("<>\"{}|^`\\", r) {
+			fmt.Fprintf(&sb, "\\u%04X", r)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// ParseTurtle 解析 Turtle 文档，支持 @prefix/@base、SPARQL 风格的 PREFIX/BASE、
+// 前缀名、相对 IRI、a、; 和 , 缩写、空白节点、[ ... ]、( ... ) 列表以及各种字面量写法。
+func ParseTurtle(data []byte) (*Graph, error) {
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("turtle document is not valid UTF-8")
+	}
+
+	p := &turtleParser{
+		src:      []rune(string(data)),
+		g:        NewGraph(),
+		prefixes: map[string]string{},
+		blanks:   map[string]Term{},
+	}
+	err := p.parseDocument()
+	if err != nil {
+		return nil, err
+	}
+	return p.g, nil
+}
+
+type turtleParser struct {
+	src      []rune
+	pos      int
+	g        *Graph
+	base     string
+	prefixes map[string]string
+	blanks   map[string]Term
+}
+
+func (p *turtleParser) errorf(format string, args ...any) error {
+	line := 1
+	for i := 0; i < p.pos && i < len(p.src); i++ {
+		if p.src[i] == '\n' {
+			line++
+		}
+	}
+	return fmt.Errorf("turtle syntax error at line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *turtleParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *turtleParser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *turtleParser) peekAt(offset int) rune {
+	if p.pos+offset >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos+offset]
+}
+
+// 跳过空白和注释
+func (p *turtleParser) skipSpace() {
+	for !p.eof() {
+		switch r := p.peek(); {
+		case r == ' ' || r == '\t' || r == '\r' || r == '\n' || r == 0xfeff:
+			p.pos++
+		case r == '#':
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *turtleParser) expect(r rune) error {
+	p.skipSpace()
+	if p.peek() != r {
+		if p.eof() {
+			return p.errorf("expected '%c' but reached end of document", r)
+		}
+		return p.errorf("expected '%c' but found '%c'", r, p.peek())
+	}
+	p.pos++
+	return nil
+}
+
+// 读取关键字，不区分大小写，后面必须是空白
+func (p *turtleParser) matchKeyword(keyword string) bool {
+	n := len(keyword)
+	if p.pos+n > len(p.src) {
+		return false
+	}
+	if !strings.EqualFold(string(p.src[p.pos:p.pos+n]), keyword) {
+		return false
+	}
+	next := p.peekAt(n)
+	if next != 0 && next != ' ' && next != '\t' && next != '\r' && next != '\n' && next != '<' {
+		return false
+	}
+	p.pos += n
+	return true
+}
+
+func (p *turtleParser) parseDocument() error {
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return nil
+		}
+
+		switch {
+		case p.peek() == '@':
+			err := p.parseAtDirective()
+			if err != nil {
+				return err
+			}
+		case p.matchKeyword("PREFIX"):
+			err := p.parsePrefixBody()
+			if err != nil {
+				return err
+			}
+		case p.matchKeyword("BASE"):
+			err := p.parseBaseBody()
+			if err != nil {
+				return err
+			}
+		default:
+			err := p.parseTriples()
+			if err != nil {
+				return err
+			}
+			err = p.expect('.')
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *turtleParser) parseAtDirective() error {
+	p.pos++
+	switch {
+	case p.matchKeyword("prefix"):
+		err := p.parsePrefixBody()
+		if err != nil {
+			return err
+		}
+	case p.matchKeyword("base"):
+		err := p.parseBaseBody()
+		if err != nil {
+			return err
+		}
+	default:
+		return p.errorf("unknown directive")
+	}
+	return p.expect('.')
+}
+
+func (p *turtleParser) parsePrefixBody() error {
+	p.skipSpace()
+	start := p.pos
+	for !p.eof() && p.peek() != ':' {
+		if !isPNChar(p.peek()) && p.peek() != '.' {
+			return p.errorf("invalid prefix name")
+		}
+		p.pos++
+	}
+	name := string(p.src[start:p.pos])
+	err := p.expect(':')
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	iri, err := p.parseIRIRef()
+	if err != nil {
+		return err
+	}
+	p.prefixes[name] = iri
+	p.g.SetPrefix(name, iri)
+	return nil
+}
+
+func (p *turtleParser) parseBaseBody() error {
+	p.skipSpace()
+	iri, err := p.parseIRIRef()
+	if err != nil {
+		return err
+	}
+	p.base = iri
+	return nil
+}
+
+// triples ::= subject predicateObjectList | blankNodePropertyList predicateObjectList?
+func (p *turtleParser) parseTriples() error {
+	p.skipSpace()
+	if p.peek() == '[' {
+		subject, err := p.parseBlankNodePropertyList()
+		if err != nil {
+			return err
+		}
+		p.skipSpace()
+		if p.peek() == '.' {
+			return nil
+		}
+		return p.parsePredicateObjectList(subject)
+	}
+
+	subject, err := p.parseSubject()
+	if err != nil {
+		return err
+	}
+	return p.parsePredicateObjectList(subject)
+}
+
+func (p *turtleParser) parseSubject() (Term, error) {
+	p.skipSpace()
+	switch {
+	case p.peek() == '(':
+		return p.parseCollection()
+	case p.peek() == '_' && p.peekAt(1) == ':':
+		return p.parseBlankNodeLabel()
+	default:
+		return p.parseIRI()
+	}
+}
+
+func (p *turtleParser) parsePredicateObjectList(subject Term) error {
+	for {
+		p.skipSpace()
+		predicate, err := p.parseVerb()
+		if err != nil {
+			return err
+		}
+
+		for {
+			object, err := p.parseObject()
+			if err != nil {
+				return err
+			}
+			p.g.Add(subject, predicate, object)
+
+			p.skipSpace()
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+
+		// 多个连续的 ; 以及 ; 后直接结束都是合法的
+		p.skipSpace()
+		if p.peek() != ';' {
+			return nil
+		}
+		for p.peek() == ';' {
+			p.pos++
+			p.skipSpace()
+		}
+		if p.peek() == '.' || p.peek() == ']' || p.eof() {
+			return nil
+		}
+	}
+}
+
+func (p *turtleParser) parseVerb() (Term, error) {
+	if p.peek() == 'a' {
+		next := p.peekAt(1)
+		if next == ' ' || next == '\t' || next == '\r' || next == '\n' || next == '<' || next == '[' || next == '(' || next == '_' || next == '"' {
+			p.pos++
+			return IRI(RDF_TYPE), nil
+		}
+	}
+	return p.parseIRI()
+}
+
+func (p *turtleParser) parseObject() (Term, error) {
+	p.skipSpace()
+	r := p.peek()
+	switch {
+	case r == '[':
+		return p.parseBlankNodePropertyList()
+	case r == '(':
+		return p.parseCollection()
+	case r == '_' && p.peekAt(1) == ':':
+		return p.parseBlankNodeLabel()
+	case r == '"' || r == '\'':
+		return p.parseLiteral()
+	case r == '+' || r == '-' || r == '.' || (r >= '0' && r <= '9'):
+		return p.parseNumber()
+	case p.matchBoolean("true"):
+		return TypedLiteral("true", XSD_BOOLEAN), nil
+	case p.matchBoolean("false"):
+		return TypedLiteral("false", XSD_BOOLEAN), nil
+	default:
+		return p.parseIRI()
+	}
+}
+
+func (p *turtleParser) matchBoolean(word string) bool {
+	n := len(word)
+	if p.pos+n > len(p.src) || string(p.src[p.pos:p.pos+n]) != word {
+		return false
+	}
+	next := p.peekAt(n)
+	if isPNChar(next) || next == ':' {
+		return false
+	}
+	p.pos += n
+	return true
+}
+
+func (p *turtleParser) parseBlankNodePropertyList() (Term, error) {
+	p.pos++
+	node := p.g.NewBlank()
+	p.skipSpace()
+	if p.peek() == ']' {
+		p.pos++
+		return node, nil
+	}
+	err := p.parsePredicateObjectList(node)
+	if err != nil {
+		return Term{}, err
+	}
+	err = p.expect(']')
+	if err != nil {
+		return Term{}, err
+	}
+	return node, nil
+}
+
+func (p *turtleParser) parseCollection() (Term, error) {
+	p.pos++
+	items := []Term{}
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return Term{}, p.errorf("unterminated collection")
+		}
+		if p.peek() == ')' {
+			p.pos++
+			break
+		}
+		item, err := p.parseObject()
+		if err != nil {
+			return Term{}, err
+		}
+		items = append(items, item)
+	}
+	return p.g.AddList(items), nil
+}
+
+// 文档中的空白节点标签统一映射为图内生成的标签，避免和 [] 生成的节点冲突
+func (p *turtleParser) parseBlankNodeLabel() (Term, error) {
+	p.pos += 2
+	start := p.pos
+	for !p.eof() && (isPNChar(p.peek()) || p.peek() == '.') {
+		p.pos++
+	}
+	for p.pos > start && p.src[p.pos-1] == '.' {
+		p.pos--
+	}
+	if p.pos == start {
+		return Term{}, p.errorf("empty blank node label")
+	}
+	label := string(p.src[start:p.pos])
+	node, ok := p.blanks[label]
+	if !ok {
+		node = p.g.NewBlank()
+		p.blanks[label] = node
+	}
+	return node, nil
+}
+
+func (p *turtleParser) parseIRI() (Term, error) {
+	p.skipSpace()
+	if p.peek() == '<' {
+		iri, err := p.parseIRIRef()
+		if err != nil {
+			return Term{}, err
+		}
+		return IRI(iri), nil
+	}
+	return p.parsePrefixedName()
+}
+
+// IRIREF，相对 IRI 按 base 解析
+func (p *turtleParser) parseIRIRef() (string, error) {
+	if p.peek() != '<' {
+		return "", p.errorf("expected IRI")
+	}
+	p.pos++
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated IRI")
+		}
+		r := p.peek()
+		p.pos++
+		switch {
+		case r == '>':
+			return p.resolve(sb.String()), nil
+		case r == '\\':
+			decoded, err := p.parseUnicodeEscape()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteRune(decoded)
+		case r <= 0x20 || r == '<' || r == '"' || r == '{' || r == '}' || r == '|' || r == '^' || r == '`':
+			return "", p.errorf("invalid character in IRI")
+		default:
+			sb.WriteRune(r)
+		}
+	}
+}
+
+func (p *turtleParser) resolve(iri string) string {
+	if p.base == "" {
+		return iri
+	}
+	ref, err := url.Parse(iri)
+	if err != nil || ref.IsAbs() {
+		return iri
+	}
+	base, err := url.Parse(p.base)
+	if err != nil {
+		return iri
+	}
+	return base.ResolveReference(ref).String()
+}
+
+func (p *turtleParser) parsePrefixedName() (Term, error) {
+	start := p.pos
+	for !p.eof() && p.peek() != ':' {
+		if !isPNChar(p.peek()) && p.peek() != '.' {
+			break
+		}
+		p.pos++
+	}
+	if p.peek() != ':' {
+		p.pos = start
+		if p.eof() {
+			return Term{}, p.errorf("unexpected end of document")
+		}
+		return Term{}, p.errorf("unexpected character '%c'", p.peek())
+	}
+	prefix := string(p.src[start:p.pos])
+	ns, ok := p.prefixes[prefix]
+	if !ok {
+		return Term{}, p.errorf("undefined prefix '%s'", prefix)
+	}
+	p.pos++
+
+	var sb strings.Builder
+	for !p.eof() {
+		r := p.peek()
+		switch {
+		case isPNChar(r) || r == ':':
+			sb.WriteRune(r)
+			p.pos++
+		case r == '.':
+			// 本地名不能以 . 结尾，. 后面不是名称字符时是语句结束符
+			next := p.peekAt(1)
+			if !isPNChar(next) && next != ':' && next != '.' && next != '%' && next != '\\' {
+				return IRI(ns + sb.String()), nil
+			}
+			sb.WriteRune(r)
+			p.pos++
+		case r == '%':
+			if p.pos+2 >= len(p.src) || !isHex(p.peekAt(1)) || !isHex(p.peekAt(2)) {
+				return Term{}, p.errorf("invalid percent encoding in prefixed name")
+			}
+			sb.WriteString(string(p.src[p.pos : p.pos+3]))
+			p.pos += 3
+		case r == '\\':
+			next := p.peekAt(1)
+			if !strings.ContainsRune("_~.-!$&'()*+,;=/?#@%", next) {
+				return Term{}, p.errorf("invalid escape in prefixed name")
+			}
+			sb.WriteRune(next)
+			p.pos += 2
+		default:
+			return IRI(ns + sb.String()), nil
+		}
+	}
+	return IRI(ns + sb.String()), nil
+}
+
+func (p *turtleParser) parseLiteral() (Term, error) {
+	quote := p.peek()
+	long := p.peekAt(1) == quote && p.peekAt(2) == quote
+	if long {
+		p.pos += 3
+	} else {
+		p.pos++
+	}
+
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return Term{}, p.errorf("unterminated string literal")
+		}
+		r := p.peek()
+		if r == quote {
+			if !long {
+				p.pos++
+				break
+			}
+			if p.peekAt(1) == quote && p.peekAt(2) == quote {
+				p.pos += 3
+				// """a"""" 这种写法，结尾多出的引号属于字符串内容
+				for p.peek() == quote {
+					sb.WriteRune(quote)
+					p.pos++
+				}
+				break
+			}
+		}
+		if !long && (r == '\n' || r == '\r') {
+			return Term{}, p.errorf("line break in short string literal")
+		}
+		p.pos++
+		if r != '\\' {
+			sb.WriteRune(r)
+			continue
+		}
+
+		esc := p.peek()
+		switch esc {
+		case 't':
+			sb.WriteRune('\t')
+		case 'b':
+			sb.WriteRune('\b')
+		case 'n':
+			sb.WriteRune('\n')
+		case 'r':
+			sb.WriteRune('\r')
+		case 'f':
+			sb.WriteRune('\f')
+		case '"', '\'', '\\':
+			sb.WriteRune(esc)
+		case 'u', 'U':
+			decoded, err := p.parseUnicodeEscape()
+			if err != nil {
+				return Term{}, err
+			}
+			sb.WriteRune(decoded)
+			continue
+		default:
+			return Term{}, p.errorf("invalid escape '\\%c' in string literal", esc)
+		}
+		p.pos++
+	}
+	value := sb.String()
+
+	switch {
+	case p.peek() == '@':
+		p.pos++
+		start := p.pos
+		for !p.eof() && (isLetterOrDigit(p.peek()) || p.peek() == '-') {
+			p.pos++
+		}
+		if p.pos == start {
+			return Term{}, p.errorf("empty language tag")
+		}
+		return LangLiteral(value, string(p.src[start:p.pos])), nil
+	case p.peek() == '^' && p.peekAt(1) == '^':
+		p.pos += 2
+		datatype, err := p.parseIRI()
+		if err != nil {
+			return Term{}, err
+		}
+		return TypedLiteral(value, datatype.Value), nil
+	default:
+		return Literal(value), nil
+	}
+}
+
+// 解析 \uXXXX 或 \UXXXXXXXX，调用时 pos 指向 u/U
+func (p *turtleParser) parseUnicodeEscape() (rune, error) {
+	n := 0
+	switch p.peek() {
+	case 'u':
+		n = 4
+	case 'U':
+		n = 8
+	default:
+		return 0, p.errorf("invalid unicode escape")
+	}
+	if p.pos+1+n > len(p.src) {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	code, err := strconv.ParseUint(string(p.src[p.pos+1:p.pos+1+n]), 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	p.pos += 1 + n
+	return rune(code), nil
+}
+
+// 数值字面量: integer、decimal、double
+func (p *turtleParser) parseNumber() (Term, error) {
+	start := p.pos
+	if p.peek() == '+' || p.peek() == '-' {
+		p.pos++
+	}
+	digits := p.skipDigits()
+	datatype := XSD_INTEGER
+	if p.peek() == '.' && p.peekAt(1) >= '0' && p.peekAt(1) <= '9' {
+		p.pos++
+		digits += p.skipDigits()
+		datatype = XSD_DECIMAL
+	}
+	if digits == 0 {
+		p.pos = start
+		return Term{}, p.errorf("invalid numeric literal")
+	}
+	if p.peek() == 'e' || p.peek() == 'E' {
+		p.pos++
+		if p.peek() == '+' || p.peek() == '-' {
+			p.pos++
+		}
+		if p.skipDigits() == 0 {
+			return Term{}, p.errorf("invalid exponent in numeric literal")
+		}
+		datatype = XSD_DOUBLE
+	}
+	return TypedLiteral(string(p.src[start:p.pos]), datatype), nil
+}
+
+func (p *turtleParser) skipDigits() int {
+	n := 0
+	for p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+		n++
+	}
+	return n
+}
+
+func isLetterOrDigit(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func isHex(r rune) bool {
+	return (r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+}
+
+// 前缀名中除 . : % \ 以外的合法字符，非 ASCII 字符按 PN_CHARS 的范围粗略判断
+func isPNChar(r rune) bool {
+	switch {
+	case isLetterOrDigit(r) || r == '_' || r == '-' || r == 0xb7:
+		return true
+	case r < 0xc0 || r == 0xd7 || r == 0xf7 || r == 0x37e || r == 0x3000 || r == 0xfeff:
+		return false
+	case r >= 0x2000 && r <= 0x206f:
+		return r == 0x200c || r == 0x200d || r == 0x203f || r == 0x2040
+	default:
+		return true
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package owl
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func hasTriple(g *Graph, subject Term, predicate string, object Term) bool {
+	for _, t := range g.Triples {
+		if t.Subject == subject && t.Predicate.Value == predicate && t.Object == object {
+			return true
+		}
+	}
+	return false
+}
+
+func objectsOf(g *Graph, subject Term, predicate string) []Term {
+	objects := []Term{}
+	for _, t := range g.Triples {
+		if t.Subject == subject && t.Predicate.Value == predicate {
+			objects = append(objects, t.Object)
+		}
+	}
+	return objects
+}
+
+func Test_Turtle_ParseTurtle(t *testing.T) {
+	Convey("Test ParseTurtle", t, func() {
+		Convey("Success with prefixes, abbreviations and literals\n", func() {
+			doc := `
+# 注释
+@prefix ex: <http://example.org/> .
+PREFIX owl: <http://www.w3.org/2002/07/owl#>
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+@base <http://example.org/base/> .
+
+ex:Person a owl:Class ;
+    rdfs:label "Person"@en, "人员" ;
+    rdfs:comment """multi
+line "quoted" comment""" ;
+    ex:count 3 ; ex:ratio 1.5 ; ex:big 1e3 ; ex:flag true ;
+    ex:escaped "tab\tquote\" 人" ;
+    ex:typed "2020-01-01"^^xsd:date ;
+    ex:rel <relative> .
+ex:name.first a owl:DatatypeProperty.
+`
+			g, err := ParseTurtle([]byte(doc))
+			So(err, ShouldBeNil)
+
+			person := IRI("http://example.org/Person")
+			So(hasTriple(g, person, RDF_TYPE, IRI(OWL_CLASS)), ShouldBeTrue)
+			So(hasTriple(g, person, RDFS_LABEL, LangLiteral("Person", "en")), ShouldBeTrue)
+			So(hasTriple(g, person, RDFS_LABEL, Literal("人员")), ShouldBeTrue)
+			So(hasTriple(g, person, RDFS_COMMENT, Literal("multi\nline \"quoted\" comment")), ShouldBeTrue)
+			So(hasTriple(g, person, "http://example.org/count", TypedLiteral("3", XSD_INTEGER)), ShouldBeTrue)
+			So(hasTriple(g, person, "http://example.org/ratio", TypedLiteral("1.5", XSD_DECIMAL)), ShouldBeTrue)
+			So(hasTriple(g, person, "http://example.org/big", TypedLiteral("1e3", XSD_DOUBLE)), ShouldBeTrue)
+			So(hasTriple(g, person, "http://example.org/flag", TypedLiteral("true", XSD_BOOLEAN)), ShouldBeTrue)
+			So(hasTriple(g, person, "http://example.org/escaped", Literal("tab\tquote\" 人")), ShouldBeTrue)
+			So(hasTriple(g, person, "http://example.org/typed", TypedLiteral("2020-01-01", XSD_DATE)), ShouldBeTrue)
+			So(hasTriple(g, person, "http://example.org/rel", IRI("http://example.org/base/relative")), ShouldBeTrue)
+			So(hasTriple(g, IRI("http://example.org/name.first"), RDF_TYPE, IRI(OWL_DATATYPE_PROPERTY)), ShouldBeTrue)
+			So(len(g.Prefixes), ShouldEqual, 4)
+		})
+
+		Convey("Success with blank nodes and collections\n", func() {
+			doc := `
+@prefix ex: <http://example.org/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+ex:Person owl:hasKey ( ex:id ex:name ) ;
+    ex:addr [ ex:city "Hefei" ; ex:zip _:z ] .
+_:z ex:code "230000" .
+[ ex:anon 1 ] .
+`
+			g, err := ParseTurtle([]byte(doc))
+			So(err, ShouldBeNil)
+
+			person := IRI("http://example.org/Person")
+			keys := objectsOf(g, person, OWL_HAS_KEY)
+			So(len(keys), ShouldEqual, 1)
+			items, _, ok := g.list(keys[0], g.indexBySubject())
+			So(ok, ShouldBeTrue)
+			So(items, ShouldResemble, []Term{IRI("http://example.org/id"), IRI("http://example.org/name")})
+
+			addr := objectsOf(g, person, "http://example.org/addr")
+			So(len(addr), ShouldEqual, 1)
+			So(addr[0].IsBlank(), ShouldBeTrue)
+			So(hasTriple(g, addr[0], "http://example.org/city", Literal("Hefei")), ShouldBeTrue)
+			zip := objectsOf(g, addr[0], "http://example.org/zip")
+			So(hasTriple(g, zip[0], "http://example.org/code", Literal("230000")), ShouldBeTrue)
+		})
+
+		Convey("Failed with undefined prefix\n", func() {
+			_, err := ParseTurtle([]byte("ex:a ex:b ex:c ."))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "undefined prefix 'ex'")
+		})
+
+		Convey("Failed with missing dot\n", func() {
+			_, err := ParseTurtle([]byte("@prefix ex: <http://example.org/> .\nex:a ex:b ex:c\nex:d ex:e ex:f ."))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "line 3")
+		})
+
+		Convey("Failed with unterminated string\n", func() {
+			_, err := ParseTurtle([]byte(`<http://a> <http://b> "abc .`))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_Turtle_WriteTurtle(t *testing.T) {
+	Convey("Test WriteTurtle", t, func() {
+		Convey("Success round trip\n", func() {
+			g := NewGraph()
+			g.SetPrefix("ex", "http://example.org/")
+			g.SetPrefix("owl", NS_OWL)
+			g.SetPrefix("xsd", NS_XSD)
+			person := IRI("http://example.org/Person")
+			g.Add(person, IRI(RDF_TYPE), IRI(OWL_CLASS))
+			g.Add(person, IRI(RDFS_LABEL), Literal("a \"b\"\nc"))
+			g.Add(person, IRI(RDFS_LABEL), LangLiteral("人员", "zh"))
+			g.Add(person, IRI(OWL_HAS_KEY), g.AddList([]Term{IRI("http://example.org/id")}))
+			shared := g.NewBlank()
+			g.Add(person, IRI("http://example.org/a"), shared)
+			g.Add(person, IRI("http://example.org/b"), shared)
+			g.Add(shared, IRI("http://example.org/v"), TypedLiteral("1", XSD_INTEGER))
+			g.Add(IRI("http://other.org/x y"), IRI("http://example.org/p"), IRI(RDF_NIL))
+
+			data := WriteTurtle(g)
+			So(string(data), ShouldContainSubstring, "@prefix ex: <http://example.org/> .")
+			So(string(data), ShouldContainSubstring, "ex:Person a owl:Class ;")
+			So(string(data), ShouldContainSubstring, "owl:hasKey ( ex:id )")
+			So(string(data), ShouldContainSubstring, `"1"^^xsd:integer`)
+			So(string(data), ShouldContainSubstring, `<http://other.org/x\u0020y>`)
+
+			parsed, err := ParseTurtle(data)
+			So(err, ShouldBeNil)
+			So(len(parsed.Triples), ShouldEqual, len(g.Triples))
+			So(hasTriple(parsed, person, RDFS_LABEL, Literal("a \"b\"\nc")), ShouldBeTrue)
+			So(hasTriple(parsed, person, RDFS_LABEL, LangLiteral("人员", "zh")), ShouldBeTrue)
+			a := objectsOf(parsed, person, "http://example.org/a")
+			b := objectsOf(parsed, person, "http://example.org/b")
+			So(a, ShouldResemble, b)
+			So(hasTriple(parsed, a[0], "http://example.org/v", TypedLiteral("1", XSD_INTEGER)), ShouldBeTrue)
+			So(hasTriple(parsed, IRI("http://other.org/x y"), "http://example.org/p", IRI(RDF_NIL)), ShouldBeTrue)
+		})
+	})
+}
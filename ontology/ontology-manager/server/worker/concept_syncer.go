@@ -135,6 +135,20 @@ func (cs *ConceptSyncer) handleKNs() error {
 	return nil
 }
 
+// SyncKN 立即同步指定分支的业务知识网络详情和概念索引，用于分支创建、合并等批量变更之后
+func (cs *ConceptSyncer) SyncKN(ctx context.Context, knID string, branch string) error {
+	kn, err := cs.kna.GetKNByID(ctx, knID, branch)
+	if err != nil {
+		logger.Errorf("Failed to get knowledge network %s %s: %v", knID, branch, err)
+		return err
+	}
+	if kn == nil {
+		return fmt.Errorf("knowledge network %s %s not found", knID, branch)
+	}
+
+	return cs.handleKnowledgeNetwork(ctx, kn, true)
+}
+
 // handleKnowledgeNetwork 处理单个知识网络
 func (cs *ConceptSyncer) handleKnowledgeNetwork(ctx context.Context, kn *interfaces.KN, need_update bool) error {
 	logger.Debugf("Handle knowledge network: %s (%s %s), %s", kn.KNName, kn.KNID, kn.Branch)
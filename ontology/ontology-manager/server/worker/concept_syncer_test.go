@@ -121,6 +121,77 @@ func TestConceptSyncer_handleKNs(t *testing.T) {
 	})
 }
 
+func TestConceptSyncer_SyncKN(t *testing.T) {
+	Convey("Test SyncKN", t, func() {
+		ctx := context.Background()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{
+			ServerSetting: common.ServerSetting{
+				DefaultSmallModelEnabled: false,
+			},
+		}
+
+		kna := dmock.NewMockKNAccess(mockCtrl)
+		osa := dmock.NewMockOpenSearchAccess(mockCtrl)
+		ota := dmock.NewMockObjectTypeAccess(mockCtrl)
+		rta := dmock.NewMockRelationTypeAccess(mockCtrl)
+		ata := dmock.NewMockActionTypeAccess(mockCtrl)
+		cga := dmock.NewMockConceptGroupAccess(mockCtrl)
+
+		cs := &ConceptSyncer{
+			appSetting: appSetting,
+			kna:        kna,
+			osa:        osa,
+			ota:        ota,
+			rta:        rta,
+			ata:        ata,
+			cga:        cga,
+		}
+
+		knID := "kn1"
+		branch := "dev"
+
+		Convey("Success syncing branch", func() {
+			kn := &interfaces.KN{
+				KNID:       knID,
+				KNName:     "test_kn",
+				Branch:     branch,
+				UpdateTime: time.Now().UnixMilli(),
+			}
+			kna.EXPECT().GetKNByID(ctx, knID, branch).Return(kn, nil)
+
+			ota.EXPECT().GetAllObjectTypesByKnID(ctx, knID, branch).Return(map[string]*interfaces.ObjectType{}, nil)
+			osa.EXPECT().SearchData(gomock.Any(), interfaces.KN_CONCEPT_INDEX_NAME, gomock.Any()).Return([]interfaces.Hit{}, nil).Times(4)
+			rta.EXPECT().GetAllRelationTypesByKnID(ctx, knID, branch).Return(map[string]*interfaces.RelationType{}, nil)
+			ata.EXPECT().GetAllActionTypesByKnID(ctx, knID, branch).Return(map[string]*interfaces.ActionType{}, nil)
+			cga.EXPECT().GetAllConceptGroupsByKnID(ctx, knID, branch).Return(map[string]*interfaces.ConceptGroup{}, nil)
+
+			// 即使概念没有变化，也要更新知识网络详情
+			kna.EXPECT().UpdateKNDetail(ctx, knID, branch, gomock.Any()).Return(nil)
+			osa.EXPECT().InsertData(ctx, interfaces.KN_CONCEPT_INDEX_NAME, gomock.Any(), gomock.Any()).Return(nil)
+
+			err := cs.SyncKN(ctx, knID, branch)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Knowledge network not found", func() {
+			kna.EXPECT().GetKNByID(ctx, knID, branch).Return(nil, nil)
+
+			err := cs.SyncKN(ctx, knID, branch)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Failed to get knowledge network", func() {
+			kna.EXPECT().GetKNByID(ctx, knID, branch).Return(nil, errors.New("db error"))
+
+			err := cs.SyncKN(ctx, knID, branch)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
 func TestConceptSyncer_handleKnowledgeNetwork(t *testing.T) {
 	Convey("Test handleKnowledgeNetwork", t, func() {
 		ctx := context.Background()
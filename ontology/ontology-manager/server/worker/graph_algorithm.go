@@ -0,0 +1,388 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package worker
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	PAGERANK_DAMPING_FACTOR = 0.85
+	PAGERANK_MAX_ITERATIONS = 100
+	PAGERANK_TOLERANCE      = 1e-6
+
+	// 节点数超过该值时，介数中心性按均匀抽样的源点近似计算
+	BETWEENNESS_MAX_SOURCES = 1000
+
+	LOUVAIN_MAX_LEVELS = 32
+	LOUVAIN_MAX_PASSES = 100
+)
+
+// instanceGraph 对象实例图，节点为对象实例，边为关系实例（已去重、去自环）
+type instanceGraph struct {
+	out   [][]int
+	in    [][]int
+	adj   [][]int // 无向邻接，out 与 in 的并集
+	edges map[[2]int]struct{}
+}
+
+func newInstanceGraph(n int) *instanceGraph {
+	return &instanceGraph{
+		out:   make([][]int, n),
+		in:    make([][]int, n),
+		adj:   make([][]int, n),
+		edges: make(map[[2]int]struct{}),
+	}
+}
+
+func (g *instanceGraph) nodeCount() int {
+	return len(g.out)
+}
+
+func (g *instanceGraph) edgeCount() int {
+	return len(g.edges)
+}
+
+func (g *instanceGraph) addEdge(from, to int) {
+	if from == to {
+		return
+	}
+	if _, ok := g.edges[[2]int{from, to}]; ok {
+		return
+	}
+	g.edges[[2]int{from, to}] = struct{}{}
+	g.out[from] = append(g.out[from], to)
+	g.in[to] = append(g.in[to], from)
+
+	// 反向边已存在时，无向邻接已经记录过
+	if _, ok := g.edges[[2]int{to, from}]; ok {
+		return
+	}
+	g.adj[from] = append(g.adj[from], to)
+	g.adj[to] = append(g.adj[to], from)
+}
+
+// pageRank 有向图的 PageRank，出度为0的节点将其得分均分给所有节点
+func pageRank(g *instanceGraph) []float64 {
+	n := g.nodeCount()
+	if n == 0 {
+		return []float64{}
+	}
+
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+
+	next := make([]float64, n)
+	for iter := 0; iter < PAGERANK_MAX_ITERATIONS; iter++ {
+		dangling := 0.0
+		for i := 0; i < n; i++ {
+			if len(g.out[i]) == 0 {
+				dangling += rank[i]
+			}
+		}
+
+		base := (1-PAGERANK_DAMPING_FACTOR)/float64(n) + PAGERANK_DAMPING_FACTOR*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for i := 0; i < n; i++ {
+			if len(g.out[i]) == 0 {
+				continue
+			}
+			share := PAGERANK_DAMPING_FACTOR * rank[i] / float64(len(g.out[i]))
+			for _, j := range g.out[i] {
+				next[j] += share
+			}
+		}
+
+		diff := 0.0
+		for i := 0; i < n; i++ {
+			diff += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		if diff < PAGERANK_TOLERANCE {
+			break
+		}
+	}
+
+	return rank
+}
+
+// degreeCentrality 无向度中心性，按 n-1 归一化
+func degreeCentrality(g *instanceGraph) []float64 {
+	n := g.nodeCount()
+	centrality := make([]float64, n)
+	if n <= 1 {
+		return centrality
+	}
+
+	for i := 0; i < n; i++ {
+		centrality[i] = float64(len(g.adj[i])) / float64(n-1)
+	}
+	return centrality
+}
+
+// betweennessCentrality 无向图的介数中心性(Brandes)，按 (n-1)(n-2)/2 归一化。
+// 节点数超过 maxSources 时，均匀抽取 maxSources 个源点计算后按比例放大
+func betweennessCentrality(g *instanceGraph, maxSources int) []float64 {
+	n := g.nodeCount()
+	centrality := make([]float64, n)
+	if n <= 2 {
+		return centrality
+	}
+
+	sources := make([]int, 0, n)
+	if maxSources <= 0 || n <= maxSources {
+		for i := 0; i < n; i++ {
+			sources = append(sources, i)
+		}
+	} else {
+		step := float64(n) / float64(maxSources)
+		for k := 0; k < maxSources; k++ {
+			sources = append(sources, int(float64(k)*step))
+		}
+	}
+
+	sigma := make([]float64, n)
+	dist := make([]int, n)
+	delta := make([]float64, n)
+	preds := make([][]int, n)
+	stack := make([]int, 0, n)
+	queue := make([]int, 0, n)
+
+	for _, s := range sources {
+		stack = stack[:0]
+		queue = queue[:0]
+		for i := 0; i < n; i++ {
+			sigma[i] = 0
+			dist[i] = -1
+			delta[i] = 0
+			preds[i] = preds[i][:0]
+		}
+		sigma[s] = 1
+		dist[s] = 0
+		queue = append(queue, s)
+
+		for head := 0; head < len(queue); head++ {
+			v := queue[head]
+			stack = append(stack, v)
+			for _, w := range g.adj[v] {
+				if dist[w] < 0 {
+					dist[w] = dist[v] + 1
+					queue = append(queue, w)
+				}
+				if dist[w] == dist[v]+1 {
+					sigma[w] += sigma[v]
+					preds[w] = append(preds[w], v)
+				}
+			}
+		}
+
+		for k := len(stack) - 1; k >= 0; k-- {
+			w := stack[k]
+			for _, v := range preds[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				centrality[w] += delta[w]
+			}
+		}
+	}
+
+	// 无向图每条最短路径被两端各统计一次
+	scale := float64(n) / float64(len(sources)) / 2
+	norm := float64(n-1) * float64(n-2) / 2
+	for i := range centrality {
+		centrality[i] = centrality[i] * scale / norm
+	}
+	return centrality
+}
+
+// connectedComponents 无向连通分量，分量编号按节点顺序从0开始
+func connectedComponents(g *instanceGraph) []int {
+	n := g.nodeCount()
+	component := make([]int, n)
+	for i := range component {
+		component[i] = -1
+	}
+
+	next := 0
+	queue := make([]int, 0)
+	for i := 0; i < n; i++ {
+		if component[i] >= 0 {
+			continue
+		}
+		component[i] = next
+		queue = append(queue[:0], i)
+		for head := 0; head < len(queue); head++ {
+			v := queue[head]
+			for _, w := range g.adj[v] {
+				if component[w] < 0 {
+					component[w] = next
+					queue = append(queue, w)
+				}
+			}
+		}
+		next++
+	}
+	return component
+}
+
+type louvainLink struct {
+	to     int
+	weight float64
+}
+
+// louvainCommunities 无向图的 Louvain 社区发现，社区编号按节点顺序从0开始
+func louvainCommunities(g *instanceGraph) []int {
+	n := g.nodeCount()
+	links := make([][]louvainLink, n)
+	for i := 0; i < n; i++ {
+		for _, j := range g.adj[i] {
+			links[i] = append(links[i], louvainLink{to: j, weight: 1})
+		}
+	}
+
+	community := make([]int, n)
+	for i := range community {
+		community[i] = i
+	}
+
+	for level := 0; level < LOUVAIN_MAX_LEVELS; level++ {
+		partition, moved := louvainOneLevel(links)
+		if !moved {
+			break
+		}
+		for i := range community {
+			community[i] = partition[community[i]]
+		}
+		links = louvainAggregate(links, partition)
+	}
+
+	return renumberCommunities(community)
+}
+
+// louvainOneLevel 局部移动阶段：依次把每个节点移入模块度增益最大的相邻社区，直到没有节点移动
+func louvainOneLevel(links [][]louvainLink) ([]int, bool) {
+	n := len(links)
+	degree := make([]float64, n)
+	total := 0.0
+	for i := 0; i < n; i++ {
+		for _, l := range links[i] {
+			degree[i] += l.weight
+		}
+		total += degree[i]
+	}
+
+	community := make([]int, n)
+	tot := make([]float64, n)
+	for i := 0; i < n; i++ {
+		community[i] = i
+		tot[i] = degree[i]
+	}
+	if total == 0 {
+		return community, false
+	}
+
+	neighWeight := make([]float64, n)
+	neighComms := make([]int, 0)
+	moved := false
+	for pass := 0; pass < LOUVAIN_MAX_PASSES; pass++ {
+		passMoved := false
+		for i := 0; i < n; i++ {
+			ci := community[i]
+
+			neighComms = neighComms[:0]
+			for _, l := range links[i] {
+				if l.to == i {
+					continue
+				}
+				c := community[l.to]
+				if neighWeight[c] == 0 {
+					neighComms = append(neighComms, c)
+				}
+				neighWeight[c] += l.weight
+			}
+
+			tot[ci] -= degree[i]
+			best := ci
+			bestGain := neighWeight[ci] - tot[ci]*degree[i]/total
+			for _, c := range neighComms {
+				gain := neighWeight[c] - tot[c]*degree[i]/total
+				if gain > bestGain+1e-12 {
+					best = c
+					bestGain = gain
+				}
+			}
+			tot[best] += degree[i]
+			community[i] = best
+
+			for _, c := range neighComms {
+				neighWeight[c] = 0
+			}
+
+			if best != ci {
+				passMoved = true
+				moved = true
+			}
+		}
+		if !passMoved {
+			break
+		}
+	}
+
+	return renumberCommunities(community), moved
+}
+
+// louvainAggregate 聚合阶段：把每个社区收缩为一个节点，社区内部的边变为自环
+func louvainAggregate(links [][]louvainLink, partition []int) [][]louvainLink {
+	size := 0
+	for _, c := range partition {
+		if c+1 > size {
+			size = c + 1
+		}
+	}
+
+	weights := make([]map[int]float64, size)
+	for i := range weights {
+		weights[i] = map[int]float64{}
+	}
+	for i, ls := range links {
+		for _, l := range ls {
+			weights[partition[i]][partition[l.to]] += l.weight
+		}
+	}
+
+	aggregated := make([][]louvainLink, size)
+	for c, ws := range weights {
+		targets := make([]int, 0, len(ws))
+		for to := range ws {
+			targets = append(targets, to)
+		}
+		sort.Ints(targets)
+		for _, to := range targets {
+			aggregated[c] = append(aggregated[c], louvainLink{to: to, weight: ws[to]})
+		}
+	}
+	return aggregated
+}
+
+func renumberCommunities(community []int) []int {
+	ids := map[int]int{}
+	result := make([]int, len(community))
+	for i, c := range community {
+		id, ok := ids[c]
+		if !ok {
+			id = len(ids)
+			ids[c] = id
+		}
+		result[i] = id
+	}
+	return result
+}
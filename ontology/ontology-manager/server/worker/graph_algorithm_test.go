@@ -0,0 +1,152 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package worker
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func buildTestGraph(n int, edges [][2]int) *instanceGraph {
+	g := newInstanceGraph(n)
+	for _, e := range edges {
+		g.addEdge(e[0], e[1])
+	}
+	return g
+}
+
+func Test_instanceGraph_addEdge(t *testing.T) {
+	Convey("Test instanceGraph addEdge", t, func() {
+		g := buildTestGraph(3, [][2]int{{0, 1}, {0, 1}, {1, 0}, {1, 1}, {1, 2}})
+
+		Convey("Should skip duplicated edges and self loops", func() {
+			So(g.edgeCount(), ShouldEqual, 3)
+			So(g.out[0], ShouldResemble, []int{1})
+			So(g.out[1], ShouldResemble, []int{0, 2})
+			So(g.adj[0], ShouldResemble, []int{1})
+			So(g.adj[1], ShouldResemble, []int{0, 2})
+		})
+	})
+}
+
+func Test_pageRank(t *testing.T) {
+	Convey("Test pageRank", t, func() {
+		Convey("Empty graph", func() {
+			So(pageRank(newInstanceGraph(0)), ShouldBeEmpty)
+		})
+
+		Convey("Ranks sum to one and the hub ranks first", func() {
+			// 1、2、3 都指向 0，0 指向 1
+			g := buildTestGraph(4, [][2]int{{1, 0}, {2, 0}, {3, 0}, {0, 1}})
+			rank := pageRank(g)
+
+			sum := 0.0
+			for _, r := range rank {
+				sum += r
+			}
+			So(sum, ShouldAlmostEqual, 1.0, 1e-6)
+			So(rank[0], ShouldBeGreaterThan, rank[1])
+			So(rank[1], ShouldBeGreaterThan, rank[2])
+			So(rank[2], ShouldAlmostEqual, rank[3], 1e-9)
+		})
+
+		Convey("Dangling nodes keep the ranks uniform on isolated nodes", func() {
+			rank := pageRank(newInstanceGraph(4))
+			for _, r := range rank {
+				So(r, ShouldAlmostEqual, 0.25, 1e-9)
+			}
+		})
+	})
+}
+
+func Test_degreeCentrality(t *testing.T) {
+	Convey("Test degreeCentrality", t, func() {
+		Convey("Star graph", func() {
+			g := buildTestGraph(4, [][2]int{{0, 1}, {0, 2}, {3, 0}})
+			c := degreeCentrality(g)
+			So(c, ShouldResemble, []float64{1, 1.0 / 3, 1.0 / 3, 1.0 / 3})
+		})
+
+		Convey("Single node", func() {
+			So(degreeCentrality(newInstanceGraph(1)), ShouldResemble, []float64{0})
+		})
+	})
+}
+
+func Test_betweennessCentrality(t *testing.T) {
+	Convey("Test betweennessCentrality", t, func() {
+		Convey("Path graph", func() {
+			g := buildTestGraph(3, [][2]int{{0, 1}, {1, 2}})
+			c := betweennessCentrality(g, 0)
+			So(c[0], ShouldAlmostEqual, 0, 1e-9)
+			So(c[1], ShouldAlmostEqual, 1, 1e-9)
+			So(c[2], ShouldAlmostEqual, 0, 1e-9)
+		})
+
+		Convey("Star graph", func() {
+			g := buildTestGraph(5, [][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}})
+			c := betweennessCentrality(g, 0)
+			So(c[0], ShouldAlmostEqual, 1, 1e-9)
+			So(c[1], ShouldAlmostEqual, 0, 1e-9)
+		})
+
+		Convey("Sampled sources on a cycle keep the total score", func() {
+			edges := [][2]int{}
+			for i := 0; i < 10; i++ {
+				edges = append(edges, [2]int{i, (i + 1) % 10})
+			}
+			g := buildTestGraph(10, edges)
+			exact := betweennessCentrality(g, 0)
+			sampled := betweennessCentrality(g, 5)
+			exactSum, sampledSum := 0.0, 0.0
+			for i := range exact {
+				exactSum += exact[i]
+				sampledSum += sampled[i]
+			}
+			So(sampledSum, ShouldAlmostEqual, exactSum, 1e-9)
+		})
+	})
+}
+
+func Test_connectedComponents(t *testing.T) {
+	Convey("Test connectedComponents", t, func() {
+		g := buildTestGraph(6, [][2]int{{0, 1}, {2, 1}, {3, 4}})
+		So(connectedComponents(g), ShouldResemble, []int{0, 0, 0, 1, 1, 2})
+	})
+}
+
+func Test_louvainCommunities(t *testing.T) {
+	Convey("Test louvainCommunities", t, func() {
+		Convey("Two triangles joined by one edge", func() {
+			g := buildTestGraph(6, [][2]int{
+				{0, 1}, {1, 2}, {2, 0},
+				{3, 4}, {4, 5}, {5, 3},
+				{2, 3},
+			})
+			community := louvainCommunities(g)
+			So(community, ShouldResemble, []int{0, 0, 0, 1, 1, 1})
+		})
+
+		Convey("Two cliques joined by a bridge node", func() {
+			edges := [][2]int{}
+			for i := 0; i < 5; i++ {
+				for j := i + 1; j < 5; j++ {
+					edges = append(edges, [2]int{i, j}, [2]int{i + 5, j + 5})
+				}
+			}
+			edges = append(edges, [2]int{4, 10}, [2]int{10, 5})
+			community := louvainCommunities(buildTestGraph(11, edges))
+			So(community[0], ShouldEqual, community[4])
+			So(community[5], ShouldEqual, community[9])
+			So(community[0], ShouldNotEqual, community[5])
+		})
+
+		Convey("Graph without edges keeps every node alone", func() {
+			So(louvainCommunities(newInstanceGraph(3)), ShouldResemble, []int{0, 1, 2})
+		})
+	})
+}
@@ -0,0 +1,430 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+
+	"ontology-manager/common"
+	"ontology-manager/interfaces"
+	"ontology-manager/logics"
+)
+
+const (
+	// 单次图分析允许加载的对象实例上限的默认值，避免内存失控，可通过 graphAnalyticsMaxNodes 配置
+	GRAPH_ANALYTICS_MAX_NODES = 500000
+	// 写回分析结果时每批更新的文档数
+	GRAPH_ANALYTICS_WRITE_BATCH_SIZE = 1000
+)
+
+type graphNode struct {
+	otID   string
+	docID  string
+	values map[string]any // 关系映射用到的属性值
+}
+
+type GraphAnalyticsTask struct {
+	appSetting *common.AppSetting
+	dva        interfaces.DataViewAccess
+	ja         interfaces.JobAccess
+	osa        interfaces.OpenSearchAccess
+
+	ViewDataLimit int
+	MaxNodes      int
+	taskInfo      *interfaces.TaskInfo
+	objectTypes   []*interfaces.ObjectType
+	relationTypes []*interfaces.RelationType
+
+	nodes   []*graphNode
+	otNodes map[string][]int // 对象类ID -> 节点下标
+	graph   *instanceGraph
+}
+
+func NewGraphAnalyticsTask(appSetting *common.AppSetting, taskInfo *interfaces.TaskInfo,
+	objectTypes []*interfaces.ObjectType, relationTypes []*interfaces.RelationType) *GraphAnalyticsTask {
+
+	return &GraphAnalyticsTask{
+		appSetting: appSetting,
+		dva:        logics.DVA,
+		ja:         logics.JA,
+		osa:        logics.OSA,
+
+		ViewDataLimit: appSetting.ServerSetting.ViewDataLimit,
+		MaxNodes:      appSetting.ServerSetting.GraphAnalyticsMaxNodes,
+		taskInfo:      taskInfo,
+		objectTypes:   objectTypes,
+		relationTypes: relationTypes,
+
+		nodes:   make([]*graphNode, 0),
+		otNodes: make(map[string][]int),
+	}
+}
+
+func (gat *GraphAnalyticsTask) GetTaskInfo() *interfaces.TaskInfo {
+	return gat.taskInfo
+}
+
+// 图分析结果写回对象类索引的 _graph_* 字段。全量构建会重建索引并丢失这些字段，
+// 全量构建完成后 jobExecutor 会按最近一次图分析的配置自动重新发起图分析
+func (gat *GraphAnalyticsTask) HandleGraphAnalyticsTask(ctx context.Context, jobInfo *interfaces.JobInfo,
+	taskInfo *interfaces.TaskInfo) error {
+
+	startTime := time.Now()
+	logger.Infof("开始图分析 kn %s, branch %s", jobInfo.KNID, jobInfo.Branch)
+
+	if gat.ViewDataLimit <= 0 {
+		gat.ViewDataLimit = GRAPH_ANALYTICS_WRITE_BATCH_SIZE
+	}
+	if gat.MaxNodes <= 0 {
+		gat.MaxNodes = GRAPH_ANALYTICS_MAX_NODES
+	}
+
+	// 只分析已经构建好索引的对象类
+	objectTypes := map[string]*interfaces.ObjectType{}
+	for _, objectType := range gat.objectTypes {
+		if objectType.Status == nil || !objectType.Status.IndexAvailable || objectType.Status.Index == "" {
+			logger.Warnf("object type %s has no available index, skip it in graph analytics", objectType.OTID)
+			continue
+		}
+		objectTypes[objectType.OTID] = objectType
+	}
+	if len(objectTypes) == 0 {
+		return fmt.Errorf("none of the selected object types has an available index, please run a full job first")
+	}
+
+	relationTypes := make([]*interfaces.RelationType, 0, len(gat.relationTypes))
+	for _, relationType := range gat.relationTypes {
+		_, sourceOk := objectTypes[relationType.SourceObjectTypeID]
+		_, targetOk := objectTypes[relationType.TargetObjectTypeID]
+		if !sourceOk || !targetOk {
+			logger.Warnf("relation type %s links object types without available index, skip it in graph analytics",
+				relationType.RTID)
+			continue
+		}
+		relationTypes = append(relationTypes, relationType)
+	}
+
+	// 1. 加载对象实例作为节点
+	joinProps := collectJoinProperties(relationTypes)
+	for _, objectType := range gat.objectTypes {
+		if _, ok := objectTypes[objectType.OTID]; !ok {
+			continue
+		}
+		err := gat.loadNodes(ctx, objectType, joinProps[objectType.OTID])
+		if err != nil {
+			logger.Errorf("加载对象类 %s 的实例失败: %s", objectType.OTID, err.Error())
+			return err
+		}
+	}
+
+	err := gat.ja.UpdateTaskState(ctx, taskInfo.ID, interfaces.TaskStateInfo{
+		DocCount: int64(len(gat.nodes)),
+	})
+	if err != nil {
+		logger.Errorf("更新 task %s 状态失败: %s", taskInfo.ID, err.Error())
+		return err
+	}
+
+	// 2. 根据关系类的映射规则生成边
+	gat.graph = newInstanceGraph(len(gat.nodes))
+	for _, relationType := range relationTypes {
+		err = gat.loadEdges(ctx, relationType)
+		if err != nil {
+			logger.Errorf("生成关系类 %s 的边失败: %s", relationType.RTID, err.Error())
+			return err
+		}
+	}
+	logger.Infof("图分析加载完成, 节点数：%d, 边数：%d, 耗时：%dms",
+		gat.graph.nodeCount(), gat.graph.edgeCount(), time.Since(startTime).Milliseconds())
+
+	// 3. 计算并写回
+	scores := gat.computeScores()
+	for _, objectType := range gat.objectTypes {
+		if _, ok := objectTypes[objectType.OTID]; !ok {
+			continue
+		}
+		err = gat.writeScores(ctx, objectType, scores)
+		if err != nil {
+			logger.Errorf("写回对象类 %s 的图分析结果失败: %s", objectType.OTID, err.Error())
+			return err
+		}
+	}
+
+	logger.Infof("图分析 kn %s 完成, 节点数：%d, 边数：%d, 耗时：%dms", jobInfo.KNID,
+		gat.graph.nodeCount(), gat.graph.edgeCount(), time.Since(startTime).Milliseconds())
+	return nil
+}
+
+// 收集每个对象类在关系映射中用到的属性
+func collectJoinProperties(relationTypes []*interfaces.RelationType) map[string][]string {
+	propSets := map[string]map[string]struct{}{}
+	add := func(otID string, prop string) {
+		if _, ok := propSets[otID]; !ok {
+			propSets[otID] = map[string]struct{}{}
+		}
+		propSets[otID][prop] = struct{}{}
+	}
+
+	for _, relationType := range relationTypes {
+		switch rules := relationType.MappingRules.(type) {
+		case []interfaces.Mapping:
+			for _, m := range rules {
+				add(relationType.SourceObjectTypeID, m.SourceProp.Name)
+				add(relationType.TargetObjectTypeID, m.TargetProp.Name)
+			}
+		case interfaces.InDirectMapping:
+			for _, m := range rules.SourceMappingRules {
+				add(relationType.SourceObjectTypeID, m.SourceProp.Name)
+			}
+			for _, m := range rules.TargetMappingRules {
+				add(relationType.TargetObjectTypeID, m.TargetProp.Name)
+			}
+		}
+	}
+
+	joinProps := map[string][]string{}
+	for otID, props := range propSets {
+		for prop := range props {
+			joinProps[otID] = append(joinProps[otID], prop)
+		}
+		sort.Strings(joinProps[otID])
+	}
+	return joinProps
+}
+
+// 按 __id 顺序分批读取对象类索引中的实例
+func (gat *GraphAnalyticsTask) loadNodes(ctx context.Context, objectType *interfaces.ObjectType, props []string) error {
+	source := append([]string{interfaces.OBJECT_ID}, props...)
+	var searchAfter []any
+	for {
+		query := map[string]any{
+			"size":    gat.ViewDataLimit,
+			"_source": source,
+			"sort": []any{
+				map[string]any{interfaces.OBJECT_ID + ".keyword": "asc"},
+			},
+		}
+		if len(searchAfter) > 0 {
+			query["search_after"] = searchAfter
+		}
+
+		hits, err := gat.osa.SearchData(ctx, objectType.Status.Index, query)
+		if err != nil {
+			return err
+		}
+
+		for _, hit := range hits {
+			docID, ok := hit.Source[interfaces.OBJECT_ID].(string)
+			if !ok || docID == "" {
+				continue
+			}
+			values := make(map[string]any, len(props))
+			for _, prop := range props {
+				if v, exist := hit.Source[prop]; exist && v != nil {
+					values[prop] = v
+				}
+			}
+
+			gat.otNodes[objectType.OTID] = append(gat.otNodes[objectType.OTID], len(gat.nodes))
+			gat.nodes = append(gat.nodes, &graphNode{
+				otID:   objectType.OTID,
+				docID:  docID,
+				values: values,
+			})
+		}
+		if len(gat.nodes) > gat.MaxNodes {
+			return fmt.Errorf("the number of object instances exceeds the limit %d of graph analytics",
+				gat.MaxNodes)
+		}
+
+		if len(hits) < gat.ViewDataLimit {
+			break
+		}
+		searchAfter = hits[len(hits)-1].Sort
+	}
+
+	logger.Infof("加载对象类 %s 的实例完成, 实例数：%d", objectType.OTID, len(gat.otNodes[objectType.OTID]))
+	return nil
+}
+
+func (gat *GraphAnalyticsTask) loadEdges(ctx context.Context, relationType *interfaces.RelationType) error {
+	switch rules := relationType.MappingRules.(type) {
+	case []interfaces.Mapping:
+		if len(rules) == 0 {
+			return nil
+		}
+		sourceProps := make([]string, 0, len(rules))
+		targetProps := make([]string, 0, len(rules))
+		for _, m := range rules {
+			sourceProps = append(sourceProps, m.SourceProp.Name)
+			targetProps = append(targetProps, m.TargetProp.Name)
+		}
+
+		targets := gat.buildNodeLookup(relationType.TargetObjectTypeID, targetProps)
+		for _, from := range gat.otNodes[relationType.SourceObjectTypeID] {
+			key, ok := graphJoinKey(gat.nodes[from].values, sourceProps)
+			if !ok {
+				continue
+			}
+			for _, to := range targets[key] {
+				gat.graph.addEdge(from, to)
+			}
+		}
+
+	case interfaces.InDirectMapping:
+		if rules.BackingDataSource == nil || len(rules.SourceMappingRules) == 0 || len(rules.TargetMappingRules) == 0 {
+			return nil
+		}
+		// 对象属性与视图字段的对应
+		sourceProps, sourceFields := make([]string, 0), make([]string, 0)
+		for _, m := range rules.SourceMappingRules {
+			sourceProps = append(sourceProps, m.SourceProp.Name)
+			sourceFields = append(sourceFields, m.TargetProp.Name)
+		}
+		targetProps, targetFields := make([]string, 0), make([]string, 0)
+		for _, m := range rules.TargetMappingRules {
+			targetFields = append(targetFields, m.SourceProp.Name)
+			targetProps = append(targetProps, m.TargetProp.Name)
+		}
+
+		sources := gat.buildNodeLookup(relationType.SourceObjectTypeID, sourceProps)
+		targets := gat.buildNodeLookup(relationType.TargetObjectTypeID, targetProps)
+
+		handleEntries := func(entries []map[string]any) {
+			for _, entry := range entries {
+				sourceKey, ok := graphJoinKey(entry, sourceFields)
+				if !ok {
+					continue
+				}
+				targetKey, ok := graphJoinKey(entry, targetFields)
+				if !ok {
+					continue
+				}
+				for _, from := range sources[sourceKey] {
+					for _, to := range targets[targetKey] {
+						gat.graph.addEdge(from, to)
+					}
+				}
+			}
+		}
+
+		viewQueryResult, err := gat.dva.GetDataStart(ctx, rules.BackingDataSource.ID, "", nil, gat.ViewDataLimit)
+		if err != nil {
+			return err
+		}
+		handleEntries(viewQueryResult.Entries)
+		for len(viewQueryResult.SearchAfter) > 0 {
+			viewQueryResult, err = gat.dva.GetDataNext(ctx, rules.BackingDataSource.ID,
+				viewQueryResult.SearchAfter, gat.ViewDataLimit)
+			if err != nil {
+				return err
+			}
+			handleEntries(viewQueryResult.Entries)
+		}
+	}
+
+	return nil
+}
+
+// 按给定属性的取值为对象类的节点建立索引
+func (gat *GraphAnalyticsTask) buildNodeLookup(otID string, props []string) map[string][]int {
+	lookup := map[string][]int{}
+	for _, idx := range gat.otNodes[otID] {
+		key, ok := graphJoinKey(gat.nodes[idx].values, props)
+		if !ok {
+			continue
+		}
+		lookup[key] = append(lookup[key], idx)
+	}
+	return lookup
+}
+
+// graphJoinKey 拼接多个属性值作为关联键，任一属性为空时不参与关联
+func graphJoinKey(values map[string]any, props []string) (string, bool) {
+	parts := make([]string, 0, len(props))
+	for _, prop := range props {
+		v, ok := values[prop]
+		if !ok || v == nil {
+			return "", false
+		}
+		parts = append(parts, graphKeyValue(v))
+	}
+	return strings.Join(parts, "\x00"), true
+}
+
+// 索引与视图返回的数值类型可能不同(float64/int64)，统一成相同的字符串表示
+func graphKeyValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+func (gat *GraphAnalyticsTask) computeScores() []map[string]any {
+	startTime := time.Now()
+
+	pageRanks := pageRank(gat.graph)
+	degrees := degreeCentrality(gat.graph)
+	betweenness := betweennessCentrality(gat.graph, BETWEENNESS_MAX_SOURCES)
+	components := connectedComponents(gat.graph)
+	communities := louvainCommunities(gat.graph)
+
+	scores := make([]map[string]any, len(gat.nodes))
+	for i, node := range gat.nodes {
+		scores[i] = map[string]any{
+			interfaces.OBJECT_ID:                          node.docID,
+			interfaces.GRAPH_PAGERANK_FIELD:               pageRanks[i],
+			interfaces.GRAPH_DEGREE_CENTRALITY_FIELD:      degrees[i],
+			interfaces.GRAPH_BETWEENNESS_CENTRALITY_FIELD: betweenness[i],
+			interfaces.GRAPH_COMPONENT_FIELD:              components[i],
+			interfaces.GRAPH_COMMUNITY_FIELD:              communities[i],
+		}
+	}
+
+	logger.Infof("图算法计算完成, 耗时：%dms", time.Since(startTime).Milliseconds())
+	return scores
+}
+
+func (gat *GraphAnalyticsTask) writeScores(ctx context.Context, objectType *interfaces.ObjectType, scores []map[string]any) error {
+	index := objectType.Status.Index
+	err := gat.osa.PutMapping(ctx, index, map[string]any{
+		"properties": interfaces.KN_INDEX_GRAPH_ANALYTICS_MAPPING,
+	})
+	if err != nil {
+		return err
+	}
+
+	nodeIdxs := gat.otNodes[objectType.OTID]
+	for start := 0; start < len(nodeIdxs); start += GRAPH_ANALYTICS_WRITE_BATCH_SIZE {
+		end := start + GRAPH_ANALYTICS_WRITE_BATCH_SIZE
+		if end > len(nodeIdxs) {
+			end = len(nodeIdxs)
+		}
+
+		dataList := make([]any, 0, end-start)
+		for _, idx := range nodeIdxs[start:end] {
+			dataList = append(dataList, scores[idx])
+		}
+		err = gat.osa.BulkUpdateData(ctx, index, dataList)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
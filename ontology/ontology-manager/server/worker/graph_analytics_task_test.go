@@ -0,0 +1,266 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"ontology-manager/common"
+	"ontology-manager/interfaces"
+	dmock "ontology-manager/interfaces/mock"
+)
+
+func TestNewGraphAnalyticsTask(t *testing.T) {
+	Convey("Test NewGraphAnalyticsTask", t, func() {
+		appSetting := &common.AppSetting{
+			ServerSetting: common.ServerSetting{
+				ViewDataLimit: 1000,
+			},
+		}
+		taskInfo := &interfaces.TaskInfo{
+			ID:          "task1",
+			ConceptID:   "kn1",
+			ConceptType: interfaces.MODULE_TYPE_KN,
+		}
+
+		task := NewGraphAnalyticsTask(appSetting, taskInfo, nil, nil)
+
+		Convey("Should create task with correct settings", func() {
+			So(task, ShouldNotBeNil)
+			So(task.ViewDataLimit, ShouldEqual, 1000)
+			So(task.GetTaskInfo(), ShouldEqual, taskInfo)
+		})
+	})
+}
+
+func TestGraphAnalyticsTask_HandleGraphAnalyticsTask(t *testing.T) {
+	Convey("Test HandleGraphAnalyticsTask", t, func() {
+		ctx := context.Background()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		dva := dmock.NewMockDataViewAccess(mockCtrl)
+		ja := dmock.NewMockJobAccess(mockCtrl)
+		osa := dmock.NewMockOpenSearchAccess(mockCtrl)
+
+		jobInfo := &interfaces.JobInfo{
+			ID:      "job1",
+			KNID:    "kn1",
+			Branch:  "main",
+			JobType: interfaces.JobTypeGraphAnalytics,
+		}
+		taskInfo := &interfaces.TaskInfo{
+			ID:          "task1",
+			JobID:       "job1",
+			ConceptID:   "kn1",
+			ConceptType: interfaces.MODULE_TYPE_KN,
+		}
+
+		person := &interfaces.ObjectType{
+			ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+				OTID: "person",
+			},
+			Status: &interfaces.ObjectTypeStatus{
+				Index:          "person_index",
+				IndexAvailable: true,
+			},
+		}
+		company := &interfaces.ObjectType{
+			ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+				OTID: "company",
+			},
+			Status: &interfaces.ObjectTypeStatus{
+				Index:          "company_index",
+				IndexAvailable: true,
+			},
+		}
+
+		// 直接映射：person.company_id = company.id
+		worksAt := &interfaces.RelationType{
+			RelationTypeWithKeyField: interfaces.RelationTypeWithKeyField{
+				RTID:               "works_at",
+				SourceObjectTypeID: "person",
+				TargetObjectTypeID: "company",
+				Type:               interfaces.RELATION_TYPE_DIRECT,
+				MappingRules: []interfaces.Mapping{
+					{
+						SourceProp: interfaces.SimpleProperty{Name: "company_id"},
+						TargetProp: interfaces.SimpleProperty{Name: "id"},
+					},
+				},
+			},
+		}
+		// 视图映射：person.id -> view.from_id, view.to_id -> person.id
+		knows := &interfaces.RelationType{
+			RelationTypeWithKeyField: interfaces.RelationTypeWithKeyField{
+				RTID:               "knows",
+				SourceObjectTypeID: "person",
+				TargetObjectTypeID: "person",
+				Type:               interfaces.RELATION_TYPE_DATA_VIEW,
+				MappingRules: interfaces.InDirectMapping{
+					BackingDataSource: &interfaces.ResourceInfo{ID: "dv_knows"},
+					SourceMappingRules: []interfaces.Mapping{
+						{
+							SourceProp: interfaces.SimpleProperty{Name: "id"},
+							TargetProp: interfaces.SimpleProperty{Name: "from_id"},
+						},
+					},
+					TargetMappingRules: []interfaces.Mapping{
+						{
+							SourceProp: interfaces.SimpleProperty{Name: "to_id"},
+							TargetProp: interfaces.SimpleProperty{Name: "id"},
+						},
+					},
+				},
+			},
+		}
+
+		personHits := []interfaces.Hit{
+			{Source: map[string]any{interfaces.OBJECT_ID: "p1", "id": float64(1), "company_id": "c1"}, Sort: []any{"p1"}},
+			{Source: map[string]any{interfaces.OBJECT_ID: "p2", "id": float64(2), "company_id": "c1"}, Sort: []any{"p2"}},
+			{Source: map[string]any{interfaces.OBJECT_ID: "p3", "id": float64(3)}, Sort: []any{"p3"}},
+		}
+		companyHits := []interfaces.Hit{
+			{Source: map[string]any{interfaces.OBJECT_ID: "c1", "id": "c1"}, Sort: []any{"c1"}},
+			{Source: map[string]any{interfaces.OBJECT_ID: "c2", "id": "c2"}, Sort: []any{"c2"}},
+		}
+
+		newTask := func(objectTypes []*interfaces.ObjectType, relationTypes []*interfaces.RelationType) *GraphAnalyticsTask {
+			task := NewGraphAnalyticsTask(&common.AppSetting{
+				ServerSetting: common.ServerSetting{ViewDataLimit: 10},
+			}, taskInfo, objectTypes, relationTypes)
+			task.dva = dva
+			task.ja = ja
+			task.osa = osa
+			return task
+		}
+
+		Convey("Success computing and writing back scores", func() {
+			task := newTask([]*interfaces.ObjectType{person, company}, []*interfaces.RelationType{worksAt, knows})
+
+			osa.EXPECT().SearchData(gomock.Any(), "person_index", gomock.Any()).Return(personHits, nil)
+			osa.EXPECT().SearchData(gomock.Any(), "company_index", gomock.Any()).Return(companyHits, nil)
+			ja.EXPECT().UpdateTaskState(gomock.Any(), "task1", interfaces.TaskStateInfo{DocCount: 5}).Return(nil)
+			dva.EXPECT().GetDataStart(gomock.Any(), "dv_knows", "", nil, 10).Return(&interfaces.ViewQueryResult{
+				Entries:     []map[string]any{{"from_id": int64(3), "to_id": int64(1)}},
+				SearchAfter: []any{1},
+			}, nil)
+			dva.EXPECT().GetDataNext(gomock.Any(), "dv_knows", []any{1}, 10).Return(&interfaces.ViewQueryResult{
+				Entries: []map[string]any{{"from_id": int64(1), "to_id": nil}},
+			}, nil)
+
+			osa.EXPECT().PutMapping(gomock.Any(), "person_index", gomock.Any()).Return(nil)
+			osa.EXPECT().PutMapping(gomock.Any(), "company_index", gomock.Any()).Return(nil)
+
+			var personDocs, companyDocs []any
+			osa.EXPECT().BulkUpdateData(gomock.Any(), "person_index", gomock.Any()).DoAndReturn(
+				func(ctx context.Context, index string, dataList []any) error {
+					personDocs = dataList
+					return nil
+				})
+			osa.EXPECT().BulkUpdateData(gomock.Any(), "company_index", gomock.Any()).DoAndReturn(
+				func(ctx context.Context, index string, dataList []any) error {
+					companyDocs = dataList
+					return nil
+				})
+
+			err := task.HandleGraphAnalyticsTask(ctx, jobInfo, taskInfo)
+			So(err, ShouldBeNil)
+			So(task.graph.nodeCount(), ShouldEqual, 5)
+			// p1->c1, p2->c1, p3->p1
+			So(task.graph.edgeCount(), ShouldEqual, 3)
+
+			So(len(personDocs), ShouldEqual, 3)
+			So(len(companyDocs), ShouldEqual, 2)
+
+			c1 := companyDocs[0].(map[string]any)
+			c2 := companyDocs[1].(map[string]any)
+			p1 := personDocs[0].(map[string]any)
+			So(c1[interfaces.OBJECT_ID], ShouldEqual, "c1")
+			So(c1[interfaces.GRAPH_PAGERANK_FIELD].(float64), ShouldBeGreaterThan, c2[interfaces.GRAPH_PAGERANK_FIELD].(float64))
+			So(c1[interfaces.GRAPH_COMPONENT_FIELD], ShouldEqual, p1[interfaces.GRAPH_COMPONENT_FIELD])
+			So(c2[interfaces.GRAPH_COMPONENT_FIELD], ShouldNotEqual, p1[interfaces.GRAPH_COMPONENT_FIELD])
+			So(c2[interfaces.GRAPH_DEGREE_CENTRALITY_FIELD], ShouldEqual, 0)
+			So(p1[interfaces.GRAPH_BETWEENNESS_CENTRALITY_FIELD].(float64), ShouldBeGreaterThan, 0)
+		})
+
+		Convey("Success skipping object types without available index", func() {
+			company.Status.IndexAvailable = false
+			task := newTask([]*interfaces.ObjectType{person, company}, []*interfaces.RelationType{worksAt})
+
+			osa.EXPECT().SearchData(gomock.Any(), "person_index", gomock.Any()).Return(personHits, nil)
+			ja.EXPECT().UpdateTaskState(gomock.Any(), "task1", gomock.Any()).Return(nil)
+			osa.EXPECT().PutMapping(gomock.Any(), "person_index", gomock.Any()).Return(nil)
+			osa.EXPECT().BulkUpdateData(gomock.Any(), "person_index", gomock.Any()).Return(nil)
+
+			err := task.HandleGraphAnalyticsTask(ctx, jobInfo, taskInfo)
+			So(err, ShouldBeNil)
+			So(task.graph.edgeCount(), ShouldEqual, 0)
+		})
+
+		Convey("Failed when no object type has available index", func() {
+			person.Status.IndexAvailable = false
+			company.Status.IndexAvailable = false
+			task := newTask([]*interfaces.ObjectType{person, company}, nil)
+
+			err := task.HandleGraphAnalyticsTask(ctx, jobInfo, taskInfo)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Failed when SearchData returns error", func() {
+			task := newTask([]*interfaces.ObjectType{person}, nil)
+
+			osa.EXPECT().SearchData(gomock.Any(), "person_index", gomock.Any()).Return(nil, errors.New("search error"))
+
+			err := task.HandleGraphAnalyticsTask(ctx, jobInfo, taskInfo)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Failed when reading backing data view returns error", func() {
+			task := newTask([]*interfaces.ObjectType{person}, []*interfaces.RelationType{knows})
+
+			osa.EXPECT().SearchData(gomock.Any(), "person_index", gomock.Any()).Return(personHits, nil)
+			ja.EXPECT().UpdateTaskState(gomock.Any(), "task1", gomock.Any()).Return(nil)
+			dva.EXPECT().GetDataStart(gomock.Any(), "dv_knows", "", nil, 10).Return(nil, errors.New("view error"))
+
+			err := task.HandleGraphAnalyticsTask(ctx, jobInfo, taskInfo)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Failed when PutMapping returns error", func() {
+			task := newTask([]*interfaces.ObjectType{person}, nil)
+
+			osa.EXPECT().SearchData(gomock.Any(), "person_index", gomock.Any()).Return(personHits, nil)
+			ja.EXPECT().UpdateTaskState(gomock.Any(), "task1", gomock.Any()).Return(nil)
+			osa.EXPECT().PutMapping(gomock.Any(), "person_index", gomock.Any()).Return(errors.New("mapping error"))
+
+			err := task.HandleGraphAnalyticsTask(ctx, jobInfo, taskInfo)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_graphJoinKey(t *testing.T) {
+	Convey("Test graphJoinKey", t, func() {
+		Convey("Numbers from index and view produce the same key", func() {
+			k1, ok1 := graphJoinKey(map[string]any{"a": float64(1000000), "b": "x"}, []string{"a", "b"})
+			k2, ok2 := graphJoinKey(map[string]any{"a": int64(1000000), "b": "x"}, []string{"a", "b"})
+			So(ok1, ShouldBeTrue)
+			So(ok2, ShouldBeTrue)
+			So(k1, ShouldEqual, k2)
+		})
+
+		Convey("Missing value is not joined", func() {
+			_, ok := graphJoinKey(map[string]any{"a": nil}, []string{"a"})
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
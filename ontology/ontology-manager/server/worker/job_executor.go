@@ -15,6 +15,7 @@ import (
 
 	llq "github.com/emirpasic/gods/queues/linkedlistqueue"
 	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"github.com/rs/xid"
 
 	"ontology-manager/common"
 	"ontology-manager/interfaces"
@@ -168,6 +169,12 @@ func (je *jobExecutor) AddJob(ctx context.Context, jobInfo *interfaces.JobInfo)
 
 			ott := NewObjectTypeTask(je.appSetting, taskInfo, ot)
 			job.mTasks[taskInfo.ID] = ott
+		case interfaces.MODULE_TYPE_KN:
+			gat, err := je.newGraphAnalyticsTask(ctx, jobInfo, taskInfo)
+			if err != nil {
+				return err
+			}
+			job.mTasks[taskInfo.ID] = gat
 		}
 	}
 
@@ -190,6 +197,53 @@ func (je *jobExecutor) AddJob(ctx context.Context, jobInfo *interfaces.JobInfo)
 	return nil
 }
 
+// 图分析任务的对象类和关系类取自 job 的概念配置，未配置时取业务知识网络下的全部
+func (je *jobExecutor) newGraphAnalyticsTask(ctx context.Context, jobInfo *interfaces.JobInfo,
+	taskInfo *interfaces.TaskInfo) (*GraphAnalyticsTask, error) {
+
+	otIDs := []string{}
+	rtIDs := []string{}
+	for _, conceptConfig := range jobInfo.JobConceptConfig {
+		switch conceptConfig.ConceptType {
+		case interfaces.MODULE_TYPE_OBJECT_TYPE:
+			otIDs = append(otIDs, conceptConfig.ConceptID)
+		case interfaces.MODULE_TYPE_RELATION_TYPE:
+			rtIDs = append(rtIDs, conceptConfig.ConceptID)
+		}
+	}
+
+	var err error
+	if len(otIDs) == 0 {
+		otIDs, err = je.ota.GetObjectTypeIDsByKnID(ctx, jobInfo.KNID, jobInfo.Branch)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(rtIDs) == 0 {
+		rtIDs, err = je.rta.GetRelationTypeIDsByKnID(ctx, jobInfo.KNID, jobInfo.Branch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	objectTypes := []*interfaces.ObjectType{}
+	if len(otIDs) > 0 {
+		objectTypes, err = je.ota.GetObjectTypesByIDs(ctx, nil, jobInfo.KNID, jobInfo.Branch, otIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	relationTypes := []*interfaces.RelationType{}
+	if len(rtIDs) > 0 {
+		relationTypes, err = je.rta.GetRelationTypesByIDs(ctx, jobInfo.KNID, jobInfo.Branch, rtIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewGraphAnalyticsTask(je.appSetting, taskInfo, objectTypes, relationTypes), nil
+}
+
 func (je *jobExecutor) StartTaskCallbackWorker() {
 	logger.Info("jobExecutor StartTaskCallbackWorker")
 	for {
@@ -283,6 +337,105 @@ func (je *jobExecutor) HandleTaskCallback(task Task) {
 	}
 
 	delete(je.mJobs, job.mJobInfo.ID)
+
+	// 全量构建重建了对象类索引，图分析写回的字段随之丢失，需重新做图分析。
+	// AddJob 需要持有 mJobLock，异步发起
+	if job.mJobInfo.JobType == interfaces.JobTypeFull {
+		go je.rerunGraphAnalytics(ctx, job.mJobInfo)
+	}
+}
+
+// 业务知识网络做过图分析时，按最近一次成功的图分析任务的概念配置重新发起图分析
+func (je *jobExecutor) rerunGraphAnalytics(ctx context.Context, fullJob *interfaces.JobInfo) {
+	defer func() {
+		if rerr := recover(); rerr != nil {
+			logger.Errorf("[rerunGraphAnalytics] Failed: %v", rerr)
+			debug.PrintStack()
+		}
+	}()
+
+	jobList, err := je.ja.ListJobs(ctx, interfaces.JobsQueryParams{
+		PaginationQueryParameters: interfaces.PaginationQueryParameters{
+			Sort:      "f_create_time",
+			Direction: interfaces.DESC_DIRECTION,
+		},
+		KNID:    fullJob.KNID,
+		Branch:  fullJob.Branch,
+		JobType: interfaces.JobTypeGraphAnalytics,
+		State:   []interfaces.JobState{interfaces.JobStateCompleted},
+	})
+	if err != nil {
+		logger.Errorf("List graph analytics jobs of kn %s failed: %v", fullJob.KNID, err)
+		return
+	}
+	// ListJobs 不按分支过滤，取当前分支最近的一次
+	var lastJob *interfaces.JobInfo
+	for _, j := range jobList {
+		if j.Branch == fullJob.Branch {
+			lastJob = j
+			break
+		}
+	}
+	if lastJob == nil {
+		return
+	}
+
+	jobInfo := &interfaces.JobInfo{
+		ID:               xid.New().String(),
+		Name:             lastJob.Name,
+		KNID:             fullJob.KNID,
+		Branch:           fullJob.Branch,
+		JobType:          interfaces.JobTypeGraphAnalytics,
+		JobConceptConfig: lastJob.JobConceptConfig,
+		Creator:          fullJob.Creator,
+		CreateTime:       time.Now().UnixMilli(),
+		JobStateInfo: interfaces.JobStateInfo{
+			State: interfaces.JobStatePending,
+		},
+	}
+	if jobInfo.JobConceptConfig == nil {
+		jobInfo.JobConceptConfig = []interfaces.ConceptConfig{}
+	}
+	taskID := xid.New().String()
+	jobInfo.TaskInfos = map[string]*interfaces.TaskInfo{
+		taskID: {
+			ID:          taskID,
+			Name:        string(interfaces.JobTypeGraphAnalytics),
+			JobID:       jobInfo.ID,
+			ConceptType: interfaces.MODULE_TYPE_KN,
+			ConceptID:   jobInfo.KNID,
+			TaskStateInfo: interfaces.TaskStateInfo{
+				State: interfaces.TaskStatePending,
+			},
+		},
+	}
+
+	tx, err := je.db.Begin()
+	if err != nil {
+		logger.Errorf("Failed to begin transaction: %v", err)
+		return
+	}
+	err = je.ja.CreateJob(ctx, tx, jobInfo)
+	if err == nil {
+		err = je.ja.CreateTasks(ctx, tx, jobInfo.TaskInfos)
+	}
+	if err != nil {
+		logger.Errorf("Create graph analytics job after full job %s failed: %v", fullJob.ID, err)
+		_ = tx.Rollback()
+		return
+	}
+	err = tx.Commit()
+	if err != nil {
+		logger.Errorf("Failed to commit transaction: %v", err)
+		return
+	}
+
+	logger.Infof("full job %s rebuilt the indexes of kn %s, rerun graph analytics with job %s",
+		fullJob.ID, fullJob.KNID, jobInfo.ID)
+	err = je.AddJob(ctx, jobInfo)
+	if err != nil {
+		logger.Errorf("Add graph analytics job %s failed: %v", jobInfo.ID, err)
+	}
 }
 
 func (je *jobExecutor) StartTaskWorker() {
@@ -406,6 +559,16 @@ func (je *jobExecutor) HandleTask(ctx context.Context, task Task) (err error) {
 			return err
 		}
 
+		je.UpdateTaskStateCompleted(ctx, taskInfo)
+		je.mTaskCallbackChan <- task
+	case *GraphAnalyticsTask:
+		gat := t
+		err = gat.HandleGraphAnalyticsTask(ctx, job.mJobInfo, taskInfo)
+		if err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+
 		je.UpdateTaskStateCompleted(ctx, taskInfo)
 		je.mTaskCallbackChan <- task
 	}
@@ -7,6 +7,7 @@ package worker
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -185,6 +186,96 @@ func TestJobExecutor_AddJob(t *testing.T) {
 	})
 }
 
+func TestJobExecutor_AddJob_GraphAnalytics(t *testing.T) {
+	Convey("Test AddJob with graph analytics task", t, func() {
+		ctx := context.Background()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{
+			ServerSetting: common.ServerSetting{
+				ViewDataLimit: 100,
+			},
+		}
+
+		ja := dmock.NewMockJobAccess(mockCtrl)
+		ota := dmock.NewMockObjectTypeAccess(mockCtrl)
+		rta := dmock.NewMockRelationTypeAccess(mockCtrl)
+
+		je := &jobExecutor{
+			appSetting: appSetting,
+			ja:         ja,
+			ota:        ota,
+			rta:        rta,
+			mJobs:      make(map[string]*Job),
+			mTaskQueue: llq.New(),
+		}
+
+		newJobInfo := func(conceptConfig []interfaces.ConceptConfig) *interfaces.JobInfo {
+			return &interfaces.JobInfo{
+				ID:               "job1",
+				KNID:             "kn1",
+				Branch:           "main",
+				JobType:          interfaces.JobTypeGraphAnalytics,
+				JobConceptConfig: conceptConfig,
+				TaskInfos: map[string]*interfaces.TaskInfo{
+					"task1": {
+						ID:          "task1",
+						JobID:       "job1",
+						ConceptID:   "kn1",
+						ConceptType: interfaces.MODULE_TYPE_KN,
+					},
+				},
+			}
+		}
+
+		objectTypes := []*interfaces.ObjectType{
+			{ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{OTID: "ot1"}},
+		}
+		relationTypes := []*interfaces.RelationType{
+			{RelationTypeWithKeyField: interfaces.RelationTypeWithKeyField{RTID: "rt1"}},
+		}
+
+		Convey("Success with all concepts of the knowledge network", func() {
+			ota.EXPECT().GetObjectTypeIDsByKnID(ctx, "kn1", "main").Return([]string{"ot1"}, nil)
+			rta.EXPECT().GetRelationTypeIDsByKnID(ctx, "kn1", "main").Return([]string{"rt1"}, nil)
+			ota.EXPECT().GetObjectTypesByIDs(ctx, gomock.Any(), "kn1", "main", []string{"ot1"}).Return(objectTypes, nil)
+			rta.EXPECT().GetRelationTypesByIDs(ctx, "kn1", "main", []string{"rt1"}).Return(relationTypes, nil)
+			ja.EXPECT().UpdateJobState(ctx, nil, "job1", gomock.Any()).Return(nil)
+
+			err := je.AddJob(ctx, newJobInfo(nil))
+			So(err, ShouldBeNil)
+			task, ok := je.mJobs["job1"].mTasks["task1"].(*GraphAnalyticsTask)
+			So(ok, ShouldBeTrue)
+			So(task.objectTypes, ShouldResemble, objectTypes)
+			So(task.relationTypes, ShouldResemble, relationTypes)
+		})
+
+		Convey("Success with selected concepts", func() {
+			ota.EXPECT().GetObjectTypesByIDs(ctx, gomock.Any(), "kn1", "main", []string{"ot1"}).Return(objectTypes, nil)
+			rta.EXPECT().GetRelationTypesByIDs(ctx, "kn1", "main", []string{"rt1"}).Return(relationTypes, nil)
+			ja.EXPECT().UpdateJobState(ctx, nil, "job1", gomock.Any()).Return(nil)
+
+			err := je.AddJob(ctx, newJobInfo([]interfaces.ConceptConfig{
+				{ConceptType: interfaces.MODULE_TYPE_OBJECT_TYPE, ConceptID: "ot1"},
+				{ConceptType: interfaces.MODULE_TYPE_RELATION_TYPE, ConceptID: "rt1"},
+			}))
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Failed to get relation types", func() {
+			ota.EXPECT().GetObjectTypesByIDs(ctx, gomock.Any(), "kn1", "main", []string{"ot1"}).Return(objectTypes, nil)
+			rta.EXPECT().GetRelationTypesByIDs(ctx, "kn1", "main", []string{"rt1"}).Return(nil, errors.New("db error"))
+
+			err := je.AddJob(ctx, newJobInfo([]interfaces.ConceptConfig{
+				{ConceptType: interfaces.MODULE_TYPE_OBJECT_TYPE, ConceptID: "ot1"},
+				{ConceptType: interfaces.MODULE_TYPE_RELATION_TYPE, ConceptID: "rt1"},
+			}))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
 func TestJobExecutor_HandleTaskCallback(t *testing.T) {
 	Convey("Test HandleTaskCallback", t, func() {
 		mockCtrl := gomock.NewController(t)
@@ -295,6 +386,76 @@ func TestJobExecutor_HandleTaskCallback(t *testing.T) {
 	})
 }
 
+func TestJobExecutor_rerunGraphAnalytics(t *testing.T) {
+	Convey("Test rerunGraphAnalytics after full job", t, func() {
+		ctx := context.Background()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		ja := dmock.NewMockJobAccess(mockCtrl)
+		ota := dmock.NewMockObjectTypeAccess(mockCtrl)
+		rta := dmock.NewMockRelationTypeAccess(mockCtrl)
+		db, smock, _ := sqlmock.New()
+
+		je := &jobExecutor{
+			appSetting: &common.AppSetting{},
+			ja:         ja,
+			ota:        ota,
+			rta:        rta,
+			db:         db,
+			mJobs:      make(map[string]*Job),
+			mTaskQueue: llq.New(),
+		}
+
+		fullJob := &interfaces.JobInfo{
+			ID:      "full1",
+			KNID:    "kn1",
+			Branch:  "main",
+			JobType: interfaces.JobTypeFull,
+		}
+
+		Convey("Rerun with the config of the last graph analytics job", func() {
+			conceptConfig := []interfaces.ConceptConfig{
+				{ConceptType: interfaces.MODULE_TYPE_OBJECT_TYPE, ConceptID: "ot1"},
+				{ConceptType: interfaces.MODULE_TYPE_RELATION_TYPE, ConceptID: "rt1"},
+			}
+			ja.EXPECT().ListJobs(ctx, gomock.Any()).Return([]*interfaces.JobInfo{
+				{ID: "ga0", Name: "ga", KNID: "kn1", Branch: "dev", JobType: interfaces.JobTypeGraphAnalytics},
+				{ID: "ga1", Name: "ga", KNID: "kn1", Branch: "main", JobType: interfaces.JobTypeGraphAnalytics,
+					JobConceptConfig: conceptConfig},
+			}, nil)
+			smock.ExpectBegin()
+			var created *interfaces.JobInfo
+			ja.EXPECT().CreateJob(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, tx *sql.Tx, jobInfo *interfaces.JobInfo) error {
+					created = jobInfo
+					return nil
+				})
+			ja.EXPECT().CreateTasks(ctx, gomock.Any(), gomock.Any()).Return(nil)
+			smock.ExpectCommit()
+			ota.EXPECT().GetObjectTypesByIDs(ctx, gomock.Any(), "kn1", "main", []string{"ot1"}).Return(
+				[]*interfaces.ObjectType{}, nil)
+			rta.EXPECT().GetRelationTypesByIDs(ctx, "kn1", "main", []string{"rt1"}).Return(
+				[]*interfaces.RelationType{}, nil)
+			ja.EXPECT().UpdateJobState(ctx, nil, gomock.Any(), gomock.Any()).Return(nil)
+
+			je.rerunGraphAnalytics(ctx, fullJob)
+			So(created, ShouldNotBeNil)
+			So(created.JobType, ShouldEqual, interfaces.JobTypeGraphAnalytics)
+			So(created.JobConceptConfig, ShouldResemble, conceptConfig)
+			So(len(created.TaskInfos), ShouldEqual, 1)
+			So(je.mJobs[created.ID], ShouldNotBeNil)
+		})
+
+		Convey("No graph analytics job before", func() {
+			ja.EXPECT().ListJobs(ctx, gomock.Any()).Return([]*interfaces.JobInfo{}, nil)
+
+			je.rerunGraphAnalytics(ctx, fullJob)
+			So(len(je.mJobs), ShouldEqual, 0)
+		})
+	})
+}
+
 func TestJobExecutor_UpdateTaskStateFailed(t *testing.T) {
 	Convey("Test UpdateTaskStateFailed", t, func() {
 		ctx := context.Background()
@@ -432,3 +432,253 @@ func (r *restHandler) GetObjectsSubgraphByObjects(c *gin.Context, visitor rest.V
 	result.OverallMs = time.Now().UnixMilli() - startTime.UnixMilli()
 	rest.ReplyOK(c, http.StatusOK, result)
 }
+
+// 查询两个对象实例之间的路径（外部）
+func (r *restHandler) GetObjectsPathsByEx(c *gin.Context) {
+	logger.Debug("Handler GetObjectsPathsByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c), "查询两个对象实例之间的路径API",
+		trace.WithSpanKind(trace.SpanKindServer))
+
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+
+	r.GetObjectsPaths(c, visitor)
+}
+
+// 查询两个对象实例之间的路径（内部）
+func (r *restHandler) GetObjectsPathsByIn(c *gin.Context) {
+	logger.Debug("Handler GetObjectsPathsByIn Start")
+	visitor := GenerateVisitor(c)
+	r.GetObjectsPaths(c, visitor)
+}
+
+// 查询两个对象实例之间的最短路径或前k条路径（通用处理函数）
+func (r *restHandler) GetObjectsPaths(c *gin.Context, visitor rest.Visitor) {
+	logger.Debug("Handler GetObjectsPaths Start")
+	startTime := time.Now()
+
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c), "查询两个对象实例之间的路径API", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	// 设置 trace 的相关 api 的属性
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	// 1. 接受 kn_id 参数
+	knID := c.Param("kn_id")
+	span.SetAttributes(attr.Key("kn_id").String(knID))
+
+	// 接受 branch 参数
+	branch := c.DefaultQuery("branch", interfaces.MAIN_BRANCH)
+	span.SetAttributes(attr.Key("branch").String(branch))
+
+	// 是否包含逻辑属性计算参数
+	includeLogicParams := c.DefaultQuery("include_logic_params", interfaces.DEFAULT_INCLUDE_LOGIC_PARAMS)
+	// 是否忽略持久化数据,走虚拟化查询,默认是false,不忽略
+	ignoringStoreCache := c.DefaultQuery("ignoring_store_cache", interfaces.DEFAULT_IGNORING_STORE_CACHE)
+	// 排除系统字段列表
+	excludeSystemProperties := c.QueryArray("exclude_system_properties")
+	// 校验查询参数
+	queryParams, err := validateSugraphQueryParameters(ctx, includeLogicParams, ignoringStoreCache, excludeSystemProperties)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		// 记录异常日志
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description,
+			httpErr.BaseError.ErrorDetails))
+
+		rest.ReplyError(c, httpErr)
+
+		return
+	}
+
+	//接收绑定参数
+	query := interfaces.PathQueryBetweenObjects{}
+	err = c.ShouldBindJSON(&query)
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter).
+			WithErrorDetails(fmt.Sprintf("Binding Paramter Failed:%s", err.Error()))
+
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description,
+			httpErr.BaseError.ErrorDetails))
+
+		rest.ReplyError(c, httpErr)
+
+		return
+	}
+
+	query.KNID = knID
+	query.Branch = branch
+	query.CommonQueryParameters = queryParams
+
+	err = validatePathQueryBetweenObjectsRequest(ctx, &query)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description,
+			httpErr.BaseError.ErrorDetails))
+
+		rest.ReplyError(c, httpErr)
+
+		return
+	}
+
+	// 执行查询
+	result, err := r.kns.SearchPathsBetweenObjects(ctx, &query)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description,
+			httpErr.BaseError.ErrorDetails))
+
+		rest.ReplyError(c, httpErr)
+
+		return
+	}
+
+	// 设置 trace 的成功信息的 attributes
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+
+	result.OverallMs = time.Now().UnixMilli() - startTime.UnixMilli()
+	rest.ReplyOK(c, http.StatusOK, result)
+}
+
+// 执行图查询语句（外部）
+func (r *restHandler) SearchByGraphQueryByEx(c *gin.Context) {
+	logger.Debug("Handler SearchByGraphQueryByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c), "执行图查询语句API",
+		trace.WithSpanKind(trace.SpanKindServer))
+
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+
+	r.SearchByGraphQuery(c, visitor)
+}
+
+// 执行图查询语句（内部）
+func (r *restHandler) SearchByGraphQueryByIn(c *gin.Context) {
+	logger.Debug("Handler SearchByGraphQueryByIn Start")
+	visitor := GenerateVisitor(c)
+	r.SearchByGraphQuery(c, visitor)
+}
+
+// 执行类 Cypher 的图查询语句，语句以 EXPLAIN 开头时只返回执行计划（通用处理函数）
+func (r *restHandler) SearchByGraphQuery(c *gin.Context, visitor rest.Visitor) {
+	logger.Debug("Handler SearchByGraphQuery Start")
+	startTime := time.Now()
+
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c), "执行图查询语句API", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	// 设置 trace 的相关 api 的属性
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	// 1. 接受 kn_id 参数
+	knID := c.Param("kn_id")
+	span.SetAttributes(attr.Key("kn_id").String(knID))
+
+	// 接受 branch 参数
+	branch := c.DefaultQuery("branch", interfaces.MAIN_BRANCH)
+	span.SetAttributes(attr.Key("branch").String(branch))
+
+	// 是否包含逻辑属性计算参数
+	includeLogicParams := c.DefaultQuery("include_logic_params", interfaces.DEFAULT_INCLUDE_LOGIC_PARAMS)
+	// 是否忽略持久化数据,走虚拟化查询,默认是false,不忽略
+	ignoringStoreCache := c.DefaultQuery("ignoring_store_cache", interfaces.DEFAULT_IGNORING_STORE_CACHE)
+	// 排除系统字段列表
+	excludeSystemProperties := c.QueryArray("exclude_system_properties")
+	// 校验查询参数
+	queryParams, err := validateSugraphQueryParameters(ctx, includeLogicParams, ignoringStoreCache, excludeSystemProperties)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		// 记录异常日志
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description,
+			httpErr.BaseError.ErrorDetails))
+
+		rest.ReplyError(c, httpErr)
+
+		return
+	}
+
+	//接收绑定参数
+	query := interfaces.GraphQuery{}
+	err = c.ShouldBindJSON(&query)
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter).
+			WithErrorDetails(fmt.Sprintf("Binding Paramter Failed:%s", err.Error()))
+
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description,
+			httpErr.BaseError.ErrorDetails))
+
+		rest.ReplyError(c, httpErr)
+
+		return
+	}
+
+	query.KNID = knID
+	query.Branch = branch
+	query.CommonQueryParameters = queryParams
+
+	err = validateGraphQueryRequest(ctx, &query)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description,
+			httpErr.BaseError.ErrorDetails))
+
+		rest.ReplyError(c, httpErr)
+
+		return
+	}
+
+	// 执行查询
+	result, err := r.kns.SearchByGraphQuery(ctx, &query)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description,
+			httpErr.BaseError.ErrorDetails))
+
+		rest.ReplyError(c, httpErr)
+
+		return
+	}
+
+	// 设置 trace 的成功信息的 attributes
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+
+	result.OverallMs = time.Now().UnixMilli() - startTime.UnixMilli()
+	rest.ReplyOK(c, http.StatusOK, result)
+}
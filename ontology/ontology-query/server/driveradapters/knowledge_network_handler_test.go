@@ -442,3 +442,209 @@ func Test_RestHandler_GetObjectsSubgraphByTypePath(t *testing.T) {
 		})
 	})
 }
+
+func Test_RestHandler_GetObjectsPaths(t *testing.T) {
+	Convey("Test RestHandler GetObjectsPaths", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydra := rmock.NewMockHydra(mockCtrl)
+		ats := dmock.NewMockActionTypeService(mockCtrl)
+		kns := dmock.NewMockKnowledgeNetworkService(mockCtrl)
+		ots := dmock.NewMockObjectTypeService(mockCtrl)
+
+		handler := MockNewRestHandler(appSetting, hydra, ats, kns, ots)
+		handler.RegisterPublic(engine)
+
+		knID := "kn1"
+		url := "/api/ontology-query/v1/knowledge-networks/" + knID + "/subgraph/paths"
+		inUrl := "/api/ontology-query/in/v1/knowledge-networks/" + knID + "/subgraph/paths"
+
+		pathQuery := interfaces.PathQueryBetweenObjects{
+			Source: interfaces.InputObjectInstance{
+				ObjectTypeID:     "ot1",
+				InstanceIdentity: map[string]any{"id": "1"},
+			},
+			Target: interfaces.InputObjectInstance{
+				ObjectTypeID:     "ot2",
+				InstanceIdentity: map[string]any{"id": "2"},
+			},
+			K: 3,
+		}
+
+		Convey("成功 - 查询两个对象实例之间的路径", func() {
+			visitor := rest.Visitor{
+				ID:   "user1",
+				Type: rest.VisitorType_User,
+			}
+			hydra.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).Return(visitor, nil)
+			kns.EXPECT().SearchPathsBetweenObjects(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, query *interfaces.PathQueryBetweenObjects) (interfaces.ObjectSubGraph, error) {
+					So(query.KNID, ShouldEqual, knID)
+					So(query.Direction, ShouldEqual, interfaces.DIRECTION_BIDIRECTIONAL)
+					So(query.MaxLength, ShouldEqual, interfaces.DEFAULT_PATH_MAX_LENGTH)
+					So(query.K, ShouldEqual, 3)
+					return interfaces.ObjectSubGraph{
+						Objects:       map[string]interfaces.ObjectInfoInSubgraph{},
+						RelationPaths: []interfaces.RelationPath{},
+					}, nil
+				})
+
+			reqParamByte, _ := sonic.Marshal(pathQuery)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("成功 - 内部接口查询路径", func() {
+			kns.EXPECT().SearchPathsBetweenObjects(gomock.Any(), gomock.Any()).Return(interfaces.ObjectSubGraph{}, nil)
+
+			reqParamByte, _ := sonic.Marshal(pathQuery)
+			req := httptest.NewRequest(http.MethodPost, inUrl, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_ID, "user1")
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_TYPE, "user")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("失败 - 起点和终点相同", func() {
+			query := pathQuery
+			query.Target = query.Source
+
+			reqParamByte, _ := sonic.Marshal(query)
+			req := httptest.NewRequest(http.MethodPost, inUrl, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_ID, "user1")
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_TYPE, "user")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("失败 - 对象实例不存在", func() {
+			kns.EXPECT().SearchPathsBetweenObjects(gomock.Any(), gomock.Any()).Return(interfaces.ObjectSubGraph{},
+				rest.NewHTTPError(context.TODO(), http.StatusNotFound, oerrors.OntologyQuery_KnowledgeNetwork_ObjectNotFound))
+
+			reqParamByte, _ := sonic.Marshal(pathQuery)
+			req := httptest.NewRequest(http.MethodPost, inUrl, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_ID, "user1")
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_TYPE, "user")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func Test_RestHandler_SearchByGraphQuery(t *testing.T) {
+	Convey("Test RestHandler SearchByGraphQuery", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydra := rmock.NewMockHydra(mockCtrl)
+		ats := dmock.NewMockActionTypeService(mockCtrl)
+		kns := dmock.NewMockKnowledgeNetworkService(mockCtrl)
+		ots := dmock.NewMockObjectTypeService(mockCtrl)
+
+		handler := MockNewRestHandler(appSetting, hydra, ats, kns, ots)
+		handler.RegisterPublic(engine)
+
+		knID := "kn1"
+		url := "/api/ontology-query/v1/knowledge-networks/" + knID + "/graph-query"
+		inUrl := "/api/ontology-query/in/v1/knowledge-networks/" + knID + "/graph-query"
+
+		graphQuery := interfaces.GraphQuery{
+			Query: " MATCH (a:customer)-[:owns]->(b:account) RETURN a, b LIMIT 10 ",
+		}
+
+		Convey("成功 - 执行图查询语句", func() {
+			visitor := rest.Visitor{
+				ID:   "user1",
+				Type: rest.VisitorType_User,
+			}
+			hydra.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).Return(visitor, nil)
+			kns.EXPECT().SearchByGraphQuery(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, query *interfaces.GraphQuery) (interfaces.GraphQueryResult, error) {
+					So(query.KNID, ShouldEqual, knID)
+					So(query.Branch, ShouldEqual, interfaces.MAIN_BRANCH)
+					So(query.Query, ShouldEqual, "MATCH (a:customer)-[:owns]->(b:account) RETURN a, b LIMIT 10")
+					return interfaces.GraphQueryResult{
+						Columns: []string{"a", "b"},
+						Datas:   []map[string]any{},
+					}, nil
+				})
+
+			reqParamByte, _ := sonic.Marshal(graphQuery)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("成功 - 内部接口执行图查询语句", func() {
+			kns.EXPECT().SearchByGraphQuery(gomock.Any(), gomock.Any()).Return(interfaces.GraphQueryResult{}, nil)
+
+			reqParamByte, _ := sonic.Marshal(graphQuery)
+			req := httptest.NewRequest(http.MethodPost, inUrl, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_ID, "user1")
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_TYPE, "user")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("失败 - 查询语句为空", func() {
+			reqParamByte, _ := sonic.Marshal(interfaces.GraphQuery{Query: "  "})
+			req := httptest.NewRequest(http.MethodPost, inUrl, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_ID, "user1")
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_TYPE, "user")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("失败 - 查询语句语法错误", func() {
+			kns.EXPECT().SearchByGraphQuery(gomock.Any(), gomock.Any()).Return(interfaces.GraphQueryResult{},
+				rest.NewHTTPError(context.TODO(), http.StatusBadRequest, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter_GraphQuery))
+
+			reqParamByte, _ := sonic.Marshal(interfaces.GraphQuery{Query: "MATCH (a RETURN a"})
+			req := httptest.NewRequest(http.MethodPost, inUrl, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_ID, "user1")
+			req.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_TYPE, "user")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
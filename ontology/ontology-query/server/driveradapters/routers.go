@@ -68,6 +68,8 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		// 基于起点、方向和路径长度获取对象子图
 		apiV1.POST("/knowledge-networks/:kn_id/subgraph", r.verifyJsonContentTypeMiddleWare(), r.GetObjectsSubgraphByEx)
 		apiV1.POST("/knowledge-networks/:kn_id/subgraph/objects", r.verifyJsonContentTypeMiddleWare(), r.GetObjectsSubgraphByObjectsByEx)
+		apiV1.POST("/knowledge-networks/:kn_id/subgraph/paths", r.verifyJsonContentTypeMiddleWare(), r.GetObjectsPathsByEx)
+		apiV1.POST("/knowledge-networks/:kn_id/graph-query", r.verifyJsonContentTypeMiddleWare(), r.SearchByGraphQueryByEx)
 		apiV1.POST("/knowledge-networks/:kn_id/action-types/:at_id", r.verifyJsonContentTypeMiddleWare(), r.GetActionsInActionTypeByEx)
 
 		// 行动执行相关 API
@@ -86,6 +88,8 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		// 基于起点、方向和路径长度获取对象子图
 		apiInV1.POST("/knowledge-networks/:kn_id/subgraph", r.verifyJsonContentTypeMiddleWare(), r.GetObjectsSubgraphByIn)
 		apiInV1.POST("/knowledge-networks/:kn_id/subgraph/objects", r.verifyJsonContentTypeMiddleWare(), r.GetObjectsSubgraphByObjectsByIn)
+		apiInV1.POST("/knowledge-networks/:kn_id/subgraph/paths", r.verifyJsonContentTypeMiddleWare(), r.GetObjectsPathsByIn)
+		apiInV1.POST("/knowledge-networks/:kn_id/graph-query", r.verifyJsonContentTypeMiddleWare(), r.SearchByGraphQueryByIn)
 		apiInV1.POST("/knowledge-networks/:kn_id/action-types/:at_id", r.verifyJsonContentTypeMiddleWare(), r.GetActionsInActionTypeByIn)
 
 		// 行动执行相关 API (内部)
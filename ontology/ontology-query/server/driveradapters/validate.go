@@ -9,7 +9,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/kweaver-ai/kweaver-go-lib/rest"
 	"github.com/mitchellh/mapstructure"
@@ -395,3 +397,72 @@ func validateSubgraphQueryByObjectsRequest(ctx context.Context, query *interface
 
 	return nil
 }
+
+// 两个对象实例间路径查询的参数校验
+func validatePathQueryBetweenObjectsRequest(ctx context.Context, query *interfaces.PathQueryBetweenObjects) error {
+
+	// 起点和终点对象实例非空
+	for i, entry := range []interfaces.InputObjectInstance{query.Source, query.Target} {
+		name := []string{"source", "target"}[i]
+		if entry.ObjectTypeID == "" {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter).
+				WithErrorDetails(fmt.Sprintf("%s对象的对象类型ID不能为空", name))
+		}
+		if len(entry.InstanceIdentity) == 0 {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter).
+				WithErrorDetails(fmt.Sprintf("%s对象的唯一标识不能为空", name))
+		}
+	}
+
+	// 起点和终点不能是同一个对象实例
+	if query.Source.ObjectTypeID == query.Target.ObjectTypeID &&
+		reflect.DeepEqual(query.Source.InstanceIdentity, query.Target.InstanceIdentity) {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter).
+			WithErrorDetails("起点和终点不能是同一个对象实例")
+	}
+
+	// 方向默认双向
+	if query.Direction == "" {
+		query.Direction = interfaces.DIRECTION_BIDIRECTIONAL
+	}
+	if !interfaces.DIRECTION_MAP[query.Direction] {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter_Direction).
+			WithErrorDetails(fmt.Sprintf("当前支持的方向有: forward, backward, bidirectional. 请求的方向为: %s", query.Direction))
+	}
+
+	// max_length 可选值 1-6, 默认值为 3
+	if query.MaxLength == 0 {
+		query.MaxLength = interfaces.DEFAULT_PATH_MAX_LENGTH
+	}
+	if query.MaxLength < 1 || query.MaxLength > interfaces.MAX_PATH_MAX_LENGTH {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter_PathLength).
+			WithErrorDetails(fmt.Sprintf("max_length可选值 1-%d, 当前max_length为 %d", interfaces.MAX_PATH_MAX_LENGTH, query.MaxLength))
+	}
+
+	// k 可选值 1-100, 默认值为 1，即只返回最短路径
+	if query.K == 0 {
+		query.K = interfaces.DEFAULT_PATH_K
+	}
+	if query.K < 1 || query.K > interfaces.MAX_PATH_K {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter).
+			WithErrorDetails(fmt.Sprintf("k可选值 1-%d, 当前k为 %d", interfaces.MAX_PATH_K, query.K))
+	}
+
+	// 搜索过程中扩展的对象数量受全局路径数量限制
+	query.PathQuotaManager = &interfaces.PathQuotaManager{
+		TotalLimit:         interfaces.MAX_PATHS,
+		RequestPathTypeNum: 1,
+	}
+
+	return nil
+}
+
+// 图查询语句的参数校验，语句的语法在解析时校验
+func validateGraphQueryRequest(ctx context.Context, query *interfaces.GraphQuery) error {
+	query.Query = strings.TrimSpace(query.Query)
+	if query.Query == "" {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyQuery_KnowledgeNetwork_NullParameter_GraphQuery)
+	}
+
+	return nil
+}
@@ -893,3 +893,106 @@ func Test_validateObjectPropertyValueQuery(t *testing.T) {
 		})
 	})
 }
+
+func Test_validatePathQueryBetweenObjectsRequest(t *testing.T) {
+	Convey("Test validatePathQueryBetweenObjectsRequest", t, func() {
+		ctx := context.Background()
+
+		newQuery := func() *interfaces.PathQueryBetweenObjects {
+			return &interfaces.PathQueryBetweenObjects{
+				Source: interfaces.InputObjectInstance{
+					ObjectTypeID:     "ot1",
+					InstanceIdentity: map[string]any{"id": "1"},
+				},
+				Target: interfaces.InputObjectInstance{
+					ObjectTypeID:     "ot2",
+					InstanceIdentity: map[string]any{"id": "2"},
+				},
+			}
+		}
+
+		Convey("成功 - 使用默认值", func() {
+			query := newQuery()
+			err := validatePathQueryBetweenObjectsRequest(ctx, query)
+			So(err, ShouldBeNil)
+			So(query.Direction, ShouldEqual, interfaces.DIRECTION_BIDIRECTIONAL)
+			So(query.MaxLength, ShouldEqual, interfaces.DEFAULT_PATH_MAX_LENGTH)
+			So(query.K, ShouldEqual, interfaces.DEFAULT_PATH_K)
+			So(query.PathQuotaManager.TotalLimit, ShouldEqual, interfaces.MAX_PATHS)
+		})
+
+		Convey("失败 - 起点对象类为空", func() {
+			query := newQuery()
+			query.Source.ObjectTypeID = ""
+			err := validatePathQueryBetweenObjectsRequest(ctx, query)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter)
+		})
+
+		Convey("失败 - 终点唯一标识为空", func() {
+			query := newQuery()
+			query.Target.InstanceIdentity = nil
+			err := validatePathQueryBetweenObjectsRequest(ctx, query)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter)
+		})
+
+		Convey("失败 - 起点和终点相同", func() {
+			query := newQuery()
+			query.Target = query.Source
+			err := validatePathQueryBetweenObjectsRequest(ctx, query)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter)
+		})
+
+		Convey("失败 - Direction无效", func() {
+			query := newQuery()
+			query.Direction = "invalid"
+			err := validatePathQueryBetweenObjectsRequest(ctx, query)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter_Direction)
+		})
+
+		Convey("失败 - MaxLength超过上限", func() {
+			query := newQuery()
+			query.MaxLength = interfaces.MAX_PATH_MAX_LENGTH + 1
+			err := validatePathQueryBetweenObjectsRequest(ctx, query)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter_PathLength)
+		})
+
+		Convey("失败 - K无效", func() {
+			query := newQuery()
+			query.K = -1
+			err := validatePathQueryBetweenObjectsRequest(ctx, query)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter)
+		})
+	})
+}
+
+func Test_validateGraphQueryRequest(t *testing.T) {
+	Convey("Test validateGraphQueryRequest", t, func() {
+		ctx := context.Background()
+
+		Convey("成功 - 去除首尾空白", func() {
+			query := &interfaces.GraphQuery{Query: "\n MATCH (a:ot1) RETURN a \t"}
+			err := validateGraphQueryRequest(ctx, query)
+			So(err, ShouldBeNil)
+			So(query.Query, ShouldEqual, "MATCH (a:ot1) RETURN a")
+		})
+
+		Convey("失败 - 查询语句为空", func() {
+			err := validateGraphQueryRequest(ctx, &interfaces.GraphQuery{Query: " "})
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_KnowledgeNetwork_NullParameter_GraphQuery)
+		})
+	})
+}
@@ -12,16 +12,19 @@ const (
 	OntologyQuery_KnowledgeNetwork_NullParameter_SourceObjectTypeId    = "OntologyQuery.KnowledgeNetwork.NullParameter.SourceObjectTypeId"
 	OntologyQuery_KnowledgeNetwork_NullParameter_TypePathObjectTypes   = "OntologyQuery.KnowledgeNetwork.NullParameter.TypePathObjectTypes"
 	OntologyQuery_KnowledgeNetwork_NullParameter_TypePathRelationTypes = "OntologyQuery.KnowledgeNetwork.NullParameter.TypePathRelationTypes"
+	OntologyQuery_KnowledgeNetwork_NullParameter_GraphQuery            = "OntologyQuery.KnowledgeNetwork.NullParameter.GraphQuery"
 	OntologyQuery_KnowledgeNetwork_InvalidParameter                    = "OntologyQuery.KnowledgeNetwork.InvalidParameter"
 	OntologyQuery_KnowledgeNetwork_InvalidParameter_Direction          = "OntologyQuery.KnowledgeNetwork.InvalidParameter.Direction"
 	OntologyQuery_KnowledgeNetwork_InvalidParameter_IncludeTypeInfo    = "OntologyQuery.KnowledgeNetwork.InvalidParameter.IncludeTypeInfo"
 	OntologyQuery_KnowledgeNetwork_InvalidParameter_PathLength         = "OntologyQuery.KnowledgeNetwork.InvalidParameter.PathLength"
 	OntologyQuery_KnowledgeNetwork_InvalidParameter_TypePath           = "OntologyQuery.KnowledgeNetwork.InvalidParameter.TypePath"
+	OntologyQuery_KnowledgeNetwork_InvalidParameter_GraphQuery         = "OntologyQuery.KnowledgeNetwork.InvalidParameter.GraphQuery"
 	// OntologyQuery_KnowledgeNetwork_UnsupportLogicPropertyType       = "OntologyQuery.KnowledgeNetwork.UnsupportLogicPropertyType"
 
 	//404
 	OntologyQuery_KnowledgeNetwork_KnowledgeNetworkNotFound = "OntologyQuery.KnowledgeNetwork.KnowledgeNetworkNotFound"
 	OntologyQuery_KnowledgeNetwork_RelationTypeNotFound     = "OntologyQuery.KnowledgeNetwork.RelationTypeNotFound"
+	OntologyQuery_KnowledgeNetwork_ObjectNotFound           = "OntologyQuery.KnowledgeNetwork.ObjectNotFound"
 
 	// 500
 	OntologyQuery_KnowledgeNetwork_InternalError_GetViewDataByIDFailed          = "OntologyQuery.KnowledgeNetwork.InternalError.GetViewDataByIDFailed"
@@ -36,15 +39,18 @@ var (
 		OntologyQuery_KnowledgeNetwork_NullParameter_SourceObjectTypeId,
 		OntologyQuery_KnowledgeNetwork_NullParameter_TypePathObjectTypes,
 		OntologyQuery_KnowledgeNetwork_NullParameter_TypePathRelationTypes,
+		OntologyQuery_KnowledgeNetwork_NullParameter_GraphQuery,
 		OntologyQuery_KnowledgeNetwork_InvalidParameter,
 		OntologyQuery_KnowledgeNetwork_InvalidParameter_Direction,
 		OntologyQuery_KnowledgeNetwork_InvalidParameter_IncludeTypeInfo,
 		OntologyQuery_KnowledgeNetwork_InvalidParameter_PathLength,
 		OntologyQuery_KnowledgeNetwork_InvalidParameter_TypePath,
+		OntologyQuery_KnowledgeNetwork_InvalidParameter_GraphQuery,
 
 		// 404
 		OntologyQuery_KnowledgeNetwork_KnowledgeNetworkNotFound,
 		OntologyQuery_KnowledgeNetwork_RelationTypeNotFound,
+		OntologyQuery_KnowledgeNetwork_ObjectNotFound,
 
 		// 500
 		OntologyQuery_KnowledgeNetwork_InternalError_GetViewDataByIDFailed,
@@ -49,6 +49,18 @@ const (
 	// 默认的路径查询数量 - 基于路径查询时
 	DEFAULT_PATHS = 2000
 
+	// 两个对象实例间路径查询的默认和最大路径长度
+	DEFAULT_PATH_MAX_LENGTH = 3
+	MAX_PATH_MAX_LENGTH     = 6
+
+	// 两个对象实例间路径查询返回的路径数量，默认只返回最短路径
+	DEFAULT_PATH_K = 1
+	MAX_PATH_K     = 100
+
+	// 图查询语句中路径的最大长度，以及模式展开后的概念路径数量上限
+	MAX_GRAPH_QUERY_PATH_LENGTH = 6
+	MAX_GRAPH_QUERY_TYPE_PATHS  = 100
+
 	// 路径子图查询
 	QUERY_TYPE_RELATION_TYPE_PATH = "relation_path"
 
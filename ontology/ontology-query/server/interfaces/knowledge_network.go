@@ -52,6 +52,30 @@ type SubGraphQueryBaseOnObjects struct {
 	CommonQueryParameters
 }
 
+// 查询两个对象实例之间路径的请求体
+type PathQueryBetweenObjects struct {
+	ConceptGroups []string            `json:"concept_groups,omitempty"`
+	Source        InputObjectInstance `json:"source"`
+	Target        InputObjectInstance `json:"target"`
+	Direction     string              `json:"direction"`
+	MaxLength     int                 `json:"max_length"` // 路径的最大长度
+	K             int                 `json:"k"`          // 返回的路径数量，为1时只返回最短路径
+
+	KNID   string `json:"-"`
+	Branch string `json:"-"`
+	CommonQueryParameters
+	*PathQuotaManager
+}
+
+// 图查询语句的请求体
+type GraphQuery struct {
+	Query string `json:"query"` // 类 Cypher 的查询语句，以 EXPLAIN 开头时只返回执行计划
+
+	KNID   string `json:"-"`
+	Branch string `json:"-"`
+	CommonQueryParameters
+}
+
 // 输入的对象实例
 type InputObjectInstance struct {
 	ObjectTypeID     string         `json:"object_type_id"`
@@ -162,3 +186,47 @@ type LevelObjectWithPath struct {
 	LevelObject
 	Paths []RelationPath // 从起点到当前对象的所有路径
 }
+
+// 图查询语句的返回体，每行数据的key为返回列的列名
+type GraphQueryResult struct {
+	Columns   []string         `json:"columns"`
+	Datas     []map[string]any `json:"datas"`
+	Plan      *GraphQueryPlan  `json:"plan,omitempty"`
+	Truncated bool             `json:"truncated"` // 检索的对象路径达到上限，去重和排序只作用于已检索到的路径
+	OverallMs int64            `json:"overall_ms"`
+}
+
+// 图查询语句的执行计划
+type GraphQueryPlan struct {
+	TypePaths  []GraphQueryTypePath `json:"relation_type_paths"`
+	Distinct   bool                 `json:"distinct"`
+	Sort       []*SortParams        `json:"sort,omitempty"`
+	SortPushed bool                 `json:"sort_pushed"` // 排序是否下推到起点对象的查询
+	Skip       int                  `json:"skip"`
+	Limit      int                  `json:"limit"`
+	PathLimit  int                  `json:"path_limit"` // 检索的对象路径数量上限
+}
+
+// 模式展开后的概念路径
+type GraphQueryTypePath struct {
+	ObjectTypes []GraphQueryObjectType   `json:"object_types"`
+	Edges       []GraphQueryRelationType `json:"relation_types"`
+	Length      int                      `json:"length"`
+}
+
+// 概念路径中的对象类及下推到该对象类的过滤条件
+type GraphQueryObjectType struct {
+	Variable     string        `json:"variable,omitempty"`
+	ObjectTypeID string        `json:"object_type_id"`
+	Condition    *cond.CondCfg `json:"condition,omitempty"`
+}
+
+// 概念路径中的关系类
+type GraphQueryRelationType struct {
+	Variable           string `json:"variable,omitempty"`
+	RelationTypeID     string `json:"relation_type_id"`
+	RelationTypeName   string `json:"relation_type_name"`
+	SourceObjectTypeID string `json:"source_object_type_id"`
+	TargetObjectTypeID string `json:"target_object_type_id"`
+	Direction          string `json:"direction"`
+}
@@ -12,4 +12,6 @@ type KnowledgeNetworkService interface {
 	SearchSubgraph(ctx context.Context, query *SubGraphQueryBaseOnSource) (ObjectSubGraph, error)
 	SearchSubgraphByTypePath(ctx context.Context, query *SubGraphQueryBaseOnTypePath) (PathsEntries, error)
 	SearchSubgraphByObjects(ctx context.Context, query *SubGraphQueryBaseOnObjects) (ObjectSubGraph, error)
+	SearchPathsBetweenObjects(ctx context.Context, query *PathQueryBetweenObjects) (ObjectSubGraph, error)
+	SearchByGraphQuery(ctx context.Context, query *GraphQuery) (GraphQueryResult, error)
 }
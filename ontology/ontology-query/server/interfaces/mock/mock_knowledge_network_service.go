@@ -40,6 +40,36 @@ func (m *MockKnowledgeNetworkService) EXPECT() *MockKnowledgeNetworkServiceMockR
 	return m.recorder
 }
 
+// SearchByGraphQuery mocks base method.
+func (m *MockKnowledgeNetworkService) SearchByGraphQuery(ctx context.Context, query *interfaces.GraphQuery) (interfaces.GraphQueryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByGraphQuery", ctx, query)
+	ret0, _ := ret[0].(interfaces.GraphQueryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchByGraphQuery indicates an expected call of SearchByGraphQuery.
+func (mr *MockKnowledgeNetworkServiceMockRecorder) SearchByGraphQuery(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByGraphQuery", reflect.TypeOf((*MockKnowledgeNetworkService)(nil).SearchByGraphQuery), ctx, query)
+}
+
+// SearchPathsBetweenObjects mocks base method.
+func (m *MockKnowledgeNetworkService) SearchPathsBetweenObjects(ctx context.Context, query *interfaces.PathQueryBetweenObjects) (interfaces.ObjectSubGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPathsBetweenObjects", ctx, query)
+	ret0, _ := ret[0].(interfaces.ObjectSubGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPathsBetweenObjects indicates an expected call of SearchPathsBetweenObjects.
+func (mr *MockKnowledgeNetworkServiceMockRecorder) SearchPathsBetweenObjects(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPathsBetweenObjects", reflect.TypeOf((*MockKnowledgeNetworkService)(nil).SearchPathsBetweenObjects), ctx, query)
+}
+
 // SearchSubgraph mocks base method.
 func (m *MockKnowledgeNetworkService) SearchSubgraph(ctx context.Context, query *interfaces.SubGraphQueryBaseOnSource) (interfaces.ObjectSubGraph, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package interfaces
 
 import (
 	cond "ontology-query/common/condition"
+	dtype "ontology-query/interfaces/data_type"
 )
 
 const (
@@ -27,8 +28,24 @@ const (
 	SYSTEM_PROPERTY_INSTANCE_ID       = "_instance_id"
 	SYSTEM_PROPERTY_INSTANCE_IDENTITY = "_instance_identity"
 	SYSTEM_PROPERTY_DISPLAY           = "_display"
+
+	// 图分析任务写入对象索引的系统字段，与 ontology-manager 的图分析任务保持一致
+	SYSTEM_PROPERTY_GRAPH_PAGERANK               = "_graph_pagerank"
+	SYSTEM_PROPERTY_GRAPH_DEGREE_CENTRALITY      = "_graph_degree_centrality"
+	SYSTEM_PROPERTY_GRAPH_BETWEENNESS_CENTRALITY = "_graph_betweenness_centrality"
+	SYSTEM_PROPERTY_GRAPH_COMPONENT              = "_graph_component"
+	SYSTEM_PROPERTY_GRAPH_COMMUNITY              = "_graph_community"
 )
 
+// 图分析系统字段及其类型，只存在于对象索引中，查询对象索引时可用于过滤、排序和返回
+var GRAPH_SYSTEM_PROPERTIES = map[string]string{
+	SYSTEM_PROPERTY_GRAPH_PAGERANK:               dtype.DATATYPE_DOUBLE,
+	SYSTEM_PROPERTY_GRAPH_DEGREE_CENTRALITY:      dtype.DATATYPE_DOUBLE,
+	SYSTEM_PROPERTY_GRAPH_BETWEENNESS_CENTRALITY: dtype.DATATYPE_DOUBLE,
+	SYSTEM_PROPERTY_GRAPH_COMPONENT:              dtype.DATATYPE_LONG,
+	SYSTEM_PROPERTY_GRAPH_COMMUNITY:              dtype.DATATYPE_LONG,
+}
+
 // 对象检索请求体
 type ObjectQueryBaseOnObjectType struct {
 	Condition  map[string]any `json:"condition,omitempty"`
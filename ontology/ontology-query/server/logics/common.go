@@ -545,6 +545,23 @@ func TransferPropsToPropMap(props []cond.DataProperty) map[string]*cond.DataProp
 	return propMap
 }
 
+// 对象索引的属性映射，在数据属性之外包含图分析写入的系统字段
+func TransferIndexPropsToPropMap(props []cond.DataProperty) map[string]*cond.DataProperty {
+	propMap := TransferPropsToPropMap(props)
+	for name, typ := range interfaces.GRAPH_SYSTEM_PROPERTIES {
+		if _, exists := propMap[name]; exists {
+			continue
+		}
+		propMap[name] = &cond.DataProperty{
+			Name:        name,
+			DisplayName: name,
+			Type:        typ,
+			MappedField: cond.Field{Name: name, Type: typ},
+		}
+	}
+	return propMap
+}
+
 // 构建dsl的query
 func BuildDslQuery(ctx context.Context, queryStr string, query *interfaces.ObjectQueryBaseOnObjectType) (map[string]any, error) {
 
@@ -0,0 +1,911 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package knowledge_network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	cond "ontology-query/common/condition"
+	oerrors "ontology-query/errors"
+	"ontology-query/interfaces"
+	"ontology-query/logics"
+)
+
+// 取反后的比较操作，正则匹配没有对应的取反操作
+var graphQueryNegatedOperations = map[string]string{
+	cond.OperationEq:       cond.OperationNotEq,
+	cond.OperationNotEq:    cond.OperationEq,
+	cond.OperationGt:       cond.OperationLte,
+	cond.OperationGte:      cond.OperationLt,
+	cond.OperationLt:       cond.OperationGte,
+	cond.OperationLte:      cond.OperationGt,
+	cond.OperationIn:       cond.OperationNotIn,
+	cond.OperationNotIn:    cond.OperationIn,
+	cond.OperationLike:     cond.OperationNotLike,
+	cond.OperationNotLike:  cond.OperationLike,
+	cond.OperationExist:    cond.OperationNotExist,
+	cond.OperationNotExist: cond.OperationExist,
+}
+
+// 模式展开后的一条概念路径
+type graphQueryTypePath struct {
+	typePath      interfaces.RelationTypePath
+	nodePositions []int    // 模式中各节点在概念路径对象类中的下标
+	relationSpans [][2]int // 模式中各关系在概念路径边中的下标范围
+}
+
+// 一条对象路径上模式变量的取值。节点变量为 ObjectInfoInSubgraph，
+// 定长关系变量为 Relation，可变长度关系变量为 []Relation
+type graphQueryBinding map[string]any
+
+// 把模式展开为概念路径时用到的关系类，同一次查询内缓存
+type graphQueryPlanner struct {
+	kns           *knowledgeNetworkService
+	query         *interfaces.GraphQuery
+	relationTypes map[string]interfaces.RelationType
+	sourceOf      map[string][]interfaces.RelationType // 对象类id -> 以该对象类为起点的关系类
+	targetOf      map[string][]interfaces.RelationType // 对象类id -> 以该对象类为终点的关系类
+}
+
+// 执行图查询语句
+func (kns *knowledgeNetworkService) SearchByGraphQuery(ctx context.Context,
+	query *interfaces.GraphQuery) (interfaces.GraphQueryResult, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "执行图查询语句")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Key("kn_id").String(query.KNID),
+		attribute.Key("branch").String(query.Branch),
+	)
+
+	result := interfaces.GraphQueryResult{
+		Columns: []string{},
+		Datas:   []map[string]any{},
+	}
+
+	// 1. 解析语句，过滤条件按节点下推
+	stmt, err := parseGraphQuery(query.Query)
+	if err != nil {
+		span.SetStatus(codes.Error, "Parse graph query error")
+		return result, rest.NewHTTPError(ctx, http.StatusBadRequest,
+			oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter_GraphQuery).WithErrorDetails(err.Error())
+	}
+	for _, item := range stmt.returns {
+		result.Columns = append(result.Columns, item.column())
+	}
+	nodeConds, err := buildGraphQueryNodeConditions(stmt)
+	if err != nil {
+		span.SetStatus(codes.Error, "Build graph query condition error")
+		return result, rest.NewHTTPError(ctx, http.StatusBadRequest,
+			oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter_GraphQuery).WithErrorDetails(err.Error())
+	}
+	stmt.reverseIfNeeded()
+
+	// 2. 把模式展开为概念路径
+	planner := &graphQueryPlanner{
+		kns:           kns,
+		query:         query,
+		relationTypes: map[string]interfaces.RelationType{},
+		sourceOf:      map[string][]interfaces.RelationType{},
+		targetOf:      map[string][]interfaces.RelationType{},
+	}
+	typePaths, err := planner.plan(ctx, stmt, nodeConds)
+	if err != nil {
+		span.SetStatus(codes.Error, "Plan graph query error")
+		return result, err
+	}
+
+	// 有去重或排序时，需要先取到足够多的路径再截取。排序能下推到起点对象的查询时，只需取到分页所需的对象
+	startSort := buildGraphQueryStartSort(stmt, typePaths)
+	pathLimit := stmt.skip + stmt.limit
+	if stmt.distinct || (len(stmt.orderBy) > 0 && startSort == nil) {
+		pathLimit = interfaces.MAX_PATHS
+	}
+
+	if stmt.explain {
+		result.Plan = buildGraphQueryPlan(stmt, typePaths, pathLimit, startSort != nil)
+		span.SetStatus(codes.Ok, "")
+		return result, nil
+	}
+
+	// 3. 沿概念路径批量检索对象路径
+	bindings, err := kns.matchGraphQueryPaths(ctx, query, stmt, typePaths, pathLimit, startSort)
+	if err != nil {
+		span.SetStatus(codes.Error, "Match graph query paths error")
+		return result, err
+	}
+	// 为去重或排序取到的路径达到上限时，结果只基于部分路径
+	if pathLimit == interfaces.MAX_PATHS && len(bindings) >= pathLimit {
+		result.Truncated = true
+		logger.Warnf("Graph query matched paths reached the limit %d, distinct and order by only apply to the matched paths", pathLimit)
+	}
+
+	// 4. 投影、去重、排序和分页
+	result.Datas = projectGraphQueryBindings(stmt, bindings)
+
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// 汇总各节点上的过滤条件。WHERE 按 AND 拆分后，每部分只能引用一个节点变量
+func buildGraphQueryNodeConditions(stmt *graphQueryStatement) (map[*graphQueryNode]*cond.CondCfg, error) {
+	nodes := map[string]*graphQueryNode{}
+	conds := map[*graphQueryNode][]*cond.CondCfg{}
+	for _, node := range stmt.nodes {
+		if node.variable != "" {
+			nodes[node.variable] = node
+		}
+		for _, expr := range node.properties {
+			cfg, err := buildGraphQueryCondition(expr, false)
+			if err != nil {
+				return nil, err
+			}
+			conds[node] = append(conds[node], cfg)
+		}
+	}
+
+	for _, expr := range splitGraphQueryConjuncts(stmt.where) {
+		variables := map[string]bool{}
+		collectGraphQueryVariables(expr, variables)
+		if len(variables) != 1 {
+			names := make([]string, 0, len(variables))
+			for name := range variables {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("过滤条件中 OR 或 NOT 连接的部分引用了多个变量%v，只能在 AND 连接的各部分中分别引用单个节点变量", names)
+		}
+
+		cfg, err := buildGraphQueryCondition(expr, false)
+		if err != nil {
+			return nil, err
+		}
+		for variable := range variables {
+			conds[nodes[variable]] = append(conds[nodes[variable]], cfg)
+		}
+	}
+
+	result := map[*graphQueryNode]*cond.CondCfg{}
+	for node, cfgs := range conds {
+		if len(cfgs) == 1 {
+			result[node] = cfgs[0]
+		} else {
+			result[node] = &cond.CondCfg{
+				Operation: cond.OperationAnd,
+				SubConds:  cfgs,
+			}
+		}
+	}
+	return result, nil
+}
+
+// 按顶层的 AND 拆分过滤表达式
+func splitGraphQueryConjuncts(expr *graphQueryExpr) []*graphQueryExpr {
+	if expr == nil {
+		return nil
+	}
+	if expr.operation != cond.OperationAnd {
+		return []*graphQueryExpr{expr}
+	}
+	conjuncts := []*graphQueryExpr{}
+	for _, sub := range expr.subExprs {
+		conjuncts = append(conjuncts, splitGraphQueryConjuncts(sub)...)
+	}
+	return conjuncts
+}
+
+func collectGraphQueryVariables(expr *graphQueryExpr, variables map[string]bool) {
+	if len(expr.subExprs) == 0 {
+		variables[expr.variable] = true
+		return
+	}
+	for _, sub := range expr.subExprs {
+		collectGraphQueryVariables(sub, variables)
+	}
+}
+
+// 把过滤表达式转为过滤条件，NOT 按德摩根定律下推到各个比较操作
+func buildGraphQueryCondition(expr *graphQueryExpr, negate bool) (*cond.CondCfg, error) {
+	switch expr.operation {
+	case graphQueryOperationNot:
+		return buildGraphQueryCondition(expr.subExprs[0], !negate)
+
+	case cond.OperationAnd, cond.OperationOr:
+		operation := expr.operation
+		if negate {
+			operation = map[string]string{
+				cond.OperationAnd: cond.OperationOr,
+				cond.OperationOr:  cond.OperationAnd,
+			}[operation]
+		}
+		cfg := &cond.CondCfg{Operation: operation}
+		for _, sub := range expr.subExprs {
+			subCfg, err := buildGraphQueryCondition(sub, negate)
+			if err != nil {
+				return nil, err
+			}
+			cfg.SubConds = append(cfg.SubConds, subCfg)
+		}
+		return cfg, nil
+	}
+
+	operation := expr.operation
+	if negate {
+		negated, ok := graphQueryNegatedOperations[operation]
+		if !ok {
+			return nil, fmt.Errorf("NOT 不能作用于%s.%s的正则匹配", expr.variable, expr.property)
+		}
+		operation = negated
+	}
+
+	cfg := &cond.CondCfg{
+		Name:      expr.property,
+		Operation: operation,
+	}
+	if _, ok := cond.NotRequiredValueOperationMap[operation]; !ok {
+		cfg.ValueOptCfg = cond.ValueOptCfg{
+			ValueFrom: cond.ValueFrom_Const,
+			Value:     expr.value,
+		}
+	}
+	return cfg, nil
+}
+
+// 把模式展开为所有可能的概念路径。可变长度关系按每个跳数分别展开
+func (planner *graphQueryPlanner) plan(ctx context.Context, stmt *graphQueryStatement,
+	nodeConds map[*graphQueryNode]*cond.CondCfg) ([]graphQueryTypePath, error) {
+
+	for _, node := range stmt.nodes {
+		if node.label == "" {
+			continue
+		}
+		if err := planner.checkObjectType(ctx, node.label); err != nil {
+			return nil, err
+		}
+	}
+
+	startObjectTypeIDs, err := planner.startObjectTypeIDs(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	typePaths := []graphQueryTypePath{}
+	objectTypes := []interfaces.ObjectTypeWithKeyField{}
+	edges := []interfaces.TypeEdge{}
+	nodePositions := make([]int, len(stmt.nodes))
+	relationSpans := make([][2]int, len(stmt.relations))
+
+	var visitNode func(nodeIndex int, otID string) error
+	var visitRelation func(relationIndex int, hops int, otID string) error
+
+	// 到达模式中的节点，节点指定了对象类时需要一致
+	visitNode = func(nodeIndex int, otID string) error {
+		node := stmt.nodes[nodeIndex]
+		if node.label != "" && node.label != otID {
+			return nil
+		}
+
+		nodePositions[nodeIndex] = len(objectTypes)
+		objectTypes = append(objectTypes, interfaces.ObjectTypeWithKeyField{
+			OTID:            otID,
+			ActualCondition: nodeConds[node],
+		})
+		defer func() {
+			objectTypes = objectTypes[:len(objectTypes)-1]
+		}()
+
+		if nodeIndex == len(stmt.relations) {
+			if len(typePaths) >= interfaces.MAX_GRAPH_QUERY_TYPE_PATHS {
+				return rest.NewHTTPError(ctx, http.StatusBadRequest,
+					oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter_GraphQuery).
+					WithErrorDetails(fmt.Sprintf("模式展开后的概念路径数量超过%d，请为节点或关系指定类型，或缩小关系的跳数范围",
+						interfaces.MAX_GRAPH_QUERY_TYPE_PATHS))
+			}
+			typePath := graphQueryTypePath{
+				typePath: interfaces.RelationTypePath{
+					ObjectTypes: append([]interfaces.ObjectTypeWithKeyField{}, objectTypes...),
+					TypeEdges:   append([]interfaces.TypeEdge{}, edges...),
+					Length:      len(edges),
+					ID:          len(typePaths),
+				},
+				nodePositions: append([]int{}, nodePositions...),
+				relationSpans: append([][2]int{}, relationSpans...),
+			}
+			typePaths = append(typePaths, typePath)
+			return nil
+		}
+
+		relationSpans[nodeIndex][0] = len(edges)
+		return visitRelation(nodeIndex, 0, otID)
+	}
+
+	// 沿关系扩展一跳，跳数达到最小跳数后即可到达下一个节点
+	visitRelation = func(relationIndex int, hops int, otID string) error {
+		relation := stmt.relations[relationIndex]
+		if hops >= relation.minHops {
+			relationSpans[relationIndex][1] = len(edges)
+			if err := visitNode(relationIndex+1, otID); err != nil {
+				return err
+			}
+		}
+		if hops == relation.maxHops || len(edges) >= interfaces.MAX_GRAPH_QUERY_PATH_LENGTH {
+			return nil
+		}
+
+		candidates, err := planner.candidateEdges(ctx, otID, relation)
+		if err != nil {
+			return err
+		}
+
+		// 可变长度关系经过的中间节点没有过滤条件
+		if hops > 0 {
+			objectTypes = append(objectTypes, interfaces.ObjectTypeWithKeyField{OTID: otID})
+			defer func() {
+				objectTypes = objectTypes[:len(objectTypes)-1]
+			}()
+		}
+		for _, edge := range candidates {
+			edges = append(edges, edge)
+			err := visitRelation(relationIndex, hops+1, edge.TargetObjectTypeId)
+			edges = edges[:len(edges)-1]
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, otID := range startObjectTypeIDs {
+		if err := visitNode(0, otID); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Debugf("图查询语句展开为%d条概念路径", len(typePaths))
+	return typePaths, nil
+}
+
+// 起点的对象类。首个节点未指定时，由首个关系的关系类推断
+func (planner *graphQueryPlanner) startObjectTypeIDs(ctx context.Context, stmt *graphQueryStatement) ([]string, error) {
+	if stmt.nodes[0].label != "" {
+		return []string{stmt.nodes[0].label}, nil
+	}
+	if len(stmt.relations) == 0 || len(stmt.relations[0].labels) == 0 {
+		return nil, rest.NewHTTPError(ctx, http.StatusBadRequest,
+			oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter_GraphQuery).
+			WithErrorDetails("无法确定起点的对象类，请为首个或末尾节点指定对象类，或为首个关系指定关系类")
+	}
+
+	relation := stmt.relations[0]
+	otIDs := []string{}
+	seen := map[string]bool{}
+	for _, label := range relation.labels {
+		relationType, err := planner.getRelationType(ctx, label)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := []string{}
+		if relation.direction != interfaces.DIRECTION_BACKWARD {
+			candidates = append(candidates, relationType.SourceObjectTypeID)
+		}
+		if relation.direction != interfaces.DIRECTION_FORWARD {
+			candidates = append(candidates, relationType.TargetObjectTypeID)
+		}
+		for _, otID := range candidates {
+			if !seen[otID] {
+				seen[otID] = true
+				otIDs = append(otIDs, otID)
+			}
+		}
+	}
+	return otIDs, nil
+}
+
+// 从对象类出发，满足关系的类型和方向的概念边
+func (planner *graphQueryPlanner) candidateEdges(ctx context.Context, otID string,
+	relation *graphQueryRelation) ([]interfaces.TypeEdge, error) {
+
+	var forwardTypes, backwardTypes []interfaces.RelationType
+	if len(relation.labels) > 0 {
+		for _, label := range relation.labels {
+			relationType, err := planner.getRelationType(ctx, label)
+			if err != nil {
+				return nil, err
+			}
+			forwardTypes = append(forwardTypes, relationType)
+		}
+		backwardTypes = forwardTypes
+	} else {
+		var err error
+		if relation.direction != interfaces.DIRECTION_BACKWARD {
+			if forwardTypes, err = planner.listRelationTypes(ctx, otID, true); err != nil {
+				return nil, err
+			}
+		}
+		if relation.direction != interfaces.DIRECTION_FORWARD {
+			if backwardTypes, err = planner.listRelationTypes(ctx, otID, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	edges := []interfaces.TypeEdge{}
+	if relation.direction != interfaces.DIRECTION_BACKWARD {
+		for _, relationType := range forwardTypes {
+			if relationType.SourceObjectTypeID == otID {
+				edges = append(edges, interfaces.TypeEdge{
+					RelationTypeId:     relationType.RTID,
+					RelationType:       relationType,
+					SourceObjectTypeId: otID,
+					TargetObjectTypeId: relationType.TargetObjectTypeID,
+					Direction:          interfaces.DIRECTION_FORWARD,
+				})
+			}
+		}
+	}
+	if relation.direction != interfaces.DIRECTION_FORWARD {
+		for _, relationType := range backwardTypes {
+			if relationType.TargetObjectTypeID == otID {
+				edges = append(edges, interfaces.TypeEdge{
+					RelationTypeId:     relationType.RTID,
+					RelationType:       relationType,
+					SourceObjectTypeId: otID,
+					TargetObjectTypeId: relationType.SourceObjectTypeID,
+					Direction:          interfaces.DIRECTION_BACKWARD,
+				})
+			}
+		}
+	}
+	return edges, nil
+}
+
+func (planner *graphQueryPlanner) checkObjectType(ctx context.Context, otID string) error {
+	_, exists, err := planner.kns.omAccess.GetObjectType(ctx, planner.query.KNID, planner.query.Branch, otID)
+	if err != nil {
+		logger.Errorf("Get object type error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Get object type error: %v", err))
+		return rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyQuery_ObjectType_InternalError_GetObjectTypesByIDFailed).WithErrorDetails(err.Error())
+	}
+	if !exists {
+		return rest.NewHTTPError(ctx, http.StatusNotFound,
+			oerrors.OntologyQuery_ObjectType_ObjectTypeNotFound).WithErrorDetails(fmt.Sprintf("对象类型[%s]不存在", otID))
+	}
+	return nil
+}
+
+func (planner *graphQueryPlanner) getRelationType(ctx context.Context, rtID string) (interfaces.RelationType, error) {
+	if relationType, exist := planner.relationTypes[rtID]; exist {
+		return relationType, nil
+	}
+
+	relationType, exists, err := planner.kns.omAccess.GetRelationType(ctx, planner.query.KNID, planner.query.Branch, rtID)
+	if err != nil {
+		logger.Errorf("Get relation type error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Get relation type error: %v", err))
+		return relationType, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyQuery_KnowledgeNetwork_InternalError_GetRelationTypeFailed).WithErrorDetails(err.Error())
+	}
+	if !exists {
+		return relationType, rest.NewHTTPError(ctx, http.StatusNotFound,
+			oerrors.OntologyQuery_KnowledgeNetwork_RelationTypeNotFound).WithErrorDetails(fmt.Sprintf("关系类[%s]不存在", rtID))
+	}
+
+	planner.relationTypes[rtID] = relationType
+	return relationType, nil
+}
+
+// 以对象类为起点或终点的关系类
+func (planner *graphQueryPlanner) listRelationTypes(ctx context.Context, otID string, asSource bool) ([]interfaces.RelationType, error) {
+	cache := planner.targetOf
+	rtQuery := interfaces.RelationTypesQuery{TargetObjectTypeID: otID}
+	if asSource {
+		cache = planner.sourceOf
+		rtQuery = interfaces.RelationTypesQuery{SourceObjectTypeID: otID}
+	}
+	if relationTypes, exist := cache[otID]; exist {
+		return relationTypes, nil
+	}
+
+	relationTypes, err := planner.kns.omAccess.ListRelationTypes(ctx, planner.query.KNID, planner.query.Branch, rtQuery)
+	if err != nil {
+		logger.Errorf("List relation types error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("List relation types error: %v", err))
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyQuery_KnowledgeNetwork_InternalError_GetRelationTypeFailed).WithErrorDetails(err.Error())
+	}
+
+	cache[otID] = relationTypes
+	return relationTypes, nil
+}
+
+// 只有一个节点且只匹配一个对象类时，按该节点属性的排序下推到起点对象的查询，否则返回 nil
+func buildGraphQueryStartSort(stmt *graphQueryStatement, typePaths []graphQueryTypePath) []*interfaces.SortParams {
+	if len(stmt.relations) > 0 || len(typePaths) != 1 || len(stmt.orderBy) == 0 || stmt.distinct {
+		return nil
+	}
+
+	sorts := []*interfaces.SortParams{}
+	for _, item := range stmt.orderBy {
+		if item.property == "" || item.variable != stmt.nodes[0].variable {
+			return nil
+		}
+		direction := interfaces.ASC_DIRECTION
+		if item.desc {
+			direction = interfaces.DESC_DIRECTION
+		}
+		sorts = append(sorts, &interfaces.SortParams{
+			Field:     item.property,
+			Direction: direction,
+		})
+	}
+	return sorts
+}
+
+// 沿各条概念路径检索对象路径，所有概念路径共用一个路径配额
+func (kns *knowledgeNetworkService) matchGraphQueryPaths(ctx context.Context,
+	query *interfaces.GraphQuery,
+	stmt *graphQueryStatement,
+	typePaths []graphQueryTypePath,
+	pathLimit int,
+	startSort []*interfaces.SortParams) ([]graphQueryBinding, error) {
+
+	quotaManager := &interfaces.PathQuotaManager{
+		TotalLimit:         int64(pathLimit),
+		RequestPathTypeNum: len(typePaths),
+	}
+	typePathObjectCtx := &typePathObjectsContext{
+		ctx:           ctx,
+		relationPaths: []interfaces.RelationPath{},
+		objectsMap:    map[string]interfaces.ObjectInfoInSubgraph{},
+		errCh:         make(chan error, 1),
+		wg:            &sync.WaitGroup{},
+	}
+
+	// 只有一个节点时，对象实例即为结果
+	startLimit := interfaces.DEFAULT_LIMIT
+	if len(stmt.relations) == 0 {
+		startLimit = pathLimit
+	}
+
+	bindings := []graphQueryBinding{}
+	startObjectsMap := map[string]interfaces.Objects{}
+	for _, typePath := range typePaths {
+		if !logics.CanGenerate(quotaManager, typePath.typePath.ID) {
+			break
+		}
+
+		start := typePath.typePath.ObjectTypes[0]
+		startObjects, exist := startObjectsMap[start.OTID]
+		if !exist {
+			var err error
+			startObjects, err = kns.ots.GetObjectsByObjectTypeID(ctx, &interfaces.ObjectQueryBaseOnObjectType{
+				ActualCondition: start.ActualCondition,
+				PageQuery: interfaces.PageQuery{
+					Limit: startLimit,
+					Sort:  startSort,
+				},
+				KNID:         query.KNID,
+				Branch:       query.Branch,
+				ObjectTypeID: start.OTID,
+				CommonQueryParameters: interfaces.CommonQueryParameters{
+					IncludeTypeInfo:    true,
+					IncludeLogicParams: query.IncludeLogicParams,
+					IgnoringStore:      query.IgnoringStore,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			startObjectsMap[start.OTID] = startObjects
+		}
+
+		if len(typePath.typePath.TypeEdges) == 0 {
+			for _, objData := range startObjects.Datas {
+				objectID, uk := logics.GetObjectID(objData, startObjects.ObjectType)
+				if objectID == "" {
+					continue
+				}
+				binding := graphQueryBinding{}
+				if variable := stmt.nodes[0].variable; variable != "" {
+					binding[variable] = buildObjectInfoInSubgraph(interfaces.LevelObject{
+						ObjectID:   objectID,
+						ObjectUK:   uk,
+						ObjectData: objData,
+						ObjectType: startObjects.ObjectType,
+					}, query.ExcludeSystemProperties)
+				}
+				bindings = append(bindings, binding)
+			}
+			continue
+		}
+
+		subGraphQuery := &interfaces.SubGraphQueryBaseOnSource{
+			KNID:              query.KNID,
+			Branch:            query.Branch,
+			SourceObjecTypeId: start.OTID,
+			ActualCondition:   start.ActualCondition,
+			PageQuery: interfaces.PageQuery{
+				Limit: interfaces.DEFAULT_LIMIT,
+			},
+			CommonQueryParameters: query.CommonQueryParameters,
+			PathQuotaManager:      quotaManager,
+			BatchQueryState: interfaces.BatchQueryState{
+				Visited:   map[string]bool{},
+				BatchSize: 50,
+			},
+		}
+
+		// 顺序执行，新增的对象路径都属于当前概念路径
+		begin := len(typePathObjectCtx.relationPaths)
+		kns.buildSingleTypePathObjects(typePathObjectCtx, typePath.typePath, subGraphQuery, startObjects)
+		if len(typePathObjectCtx.errCh) > 0 {
+			return nil, <-typePathObjectCtx.errCh
+		}
+		for _, path := range typePathObjectCtx.relationPaths[begin:] {
+			bindings = append(bindings, bindGraphQueryPath(stmt, typePath, path, typePathObjectCtx.objectsMap))
+		}
+	}
+
+	return bindings, nil
+}
+
+// 把对象路径对应到模式中的变量
+func bindGraphQueryPath(stmt *graphQueryStatement, typePath graphQueryTypePath,
+	path interfaces.RelationPath, objectsMap map[string]interfaces.ObjectInfoInSubgraph) graphQueryBinding {
+
+	objectIDs := make([]string, 0, len(path.Relations)+1)
+	objectIDs = append(objectIDs, path.Relations[0].SourceObjectId)
+	for _, relation := range path.Relations {
+		objectIDs = append(objectIDs, relation.TargetObjectId)
+	}
+
+	binding := graphQueryBinding{}
+	for i, node := range stmt.nodes {
+		if node.variable != "" {
+			binding[node.variable] = objectsMap[objectIDs[typePath.nodePositions[i]]]
+		}
+	}
+
+	for i, relation := range stmt.relations {
+		if relation.variable == "" {
+			continue
+		}
+		span := typePath.relationSpans[i]
+		relations := make([]interfaces.Relation, 0, span[1]-span[0])
+		for k := span[0]; k < span[1]; k++ {
+			r := path.Relations[k]
+			// 对象路径中的关系按路径方向记录，返回时还原为关系类的起点到终点
+			if typePath.typePath.TypeEdges[k].Direction == interfaces.DIRECTION_BACKWARD {
+				r.SourceObjectId, r.TargetObjectId = r.TargetObjectId, r.SourceObjectId
+			}
+			relations = append(relations, r)
+		}
+		// 模式反转过时，关系列表按语句中书写的顺序返回
+		if stmt.reversed {
+			for l, r := 0, len(relations)-1; l < r; l, r = l+1, r-1 {
+				relations[l], relations[r] = relations[r], relations[l]
+			}
+		}
+
+		if relation.variableLength {
+			binding[relation.variable] = relations
+		} else {
+			binding[relation.variable] = relations[0]
+		}
+	}
+	return binding
+}
+
+// 取变量或变量的属性值
+func graphQueryValue(binding graphQueryBinding, variable string, property string) any {
+	value := binding[variable]
+	if property == "" {
+		return value
+	}
+	if object, ok := value.(interfaces.ObjectInfoInSubgraph); ok {
+		return object.Properties[property]
+	}
+	return nil
+}
+
+// 按返回项生成结果行，再去重、排序和分页
+func projectGraphQueryBindings(stmt *graphQueryStatement, bindings []graphQueryBinding) []map[string]any {
+	type graphQueryRow struct {
+		data     map[string]any
+		sortKeys []any
+	}
+
+	rows := make([]graphQueryRow, 0, len(bindings))
+	seen := map[string]bool{}
+	for _, binding := range bindings {
+		row := graphQueryRow{data: map[string]any{}}
+		for _, item := range stmt.returns {
+			row.data[item.column()] = graphQueryValue(binding, item.variable, item.property)
+		}
+
+		if stmt.distinct {
+			key, _ := json.Marshal(row.data)
+			if seen[string(key)] {
+				continue
+			}
+			seen[string(key)] = true
+		}
+
+		for _, item := range stmt.orderBy {
+			if item.property != "" {
+				row.sortKeys = append(row.sortKeys, graphQueryValue(binding, item.variable, item.property))
+			} else {
+				row.sortKeys = append(row.sortKeys, row.data[item.variable])
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if len(stmt.orderBy) > 0 {
+		sort.SliceStable(rows, func(i, j int) bool {
+			for k, item := range stmt.orderBy {
+				c := compareGraphQueryValues(rows[i].sortKeys[k], rows[j].sortKeys[k])
+				if c == 0 {
+					continue
+				}
+				if item.desc {
+					return c > 0
+				}
+				return c < 0
+			}
+			return false
+		})
+	}
+
+	datas := []map[string]any{}
+	for i := stmt.skip; i < len(rows) && len(datas) < stmt.limit; i++ {
+		datas = append(datas, rows[i].data)
+	}
+	return datas
+}
+
+// 比较两个值的大小，空值排在最后
+func compareGraphQueryValues(a any, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		default:
+			return -1
+		}
+	}
+
+	af, aok := graphQueryNumber(a)
+	bf, bok := graphQueryNumber(b)
+	if aok && bok {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	ab, aok := a.(bool)
+	bb, bok := b.(bool)
+	if aok && bok {
+		switch {
+		case ab == bb:
+			return 0
+		case !ab:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func graphQueryNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// 生成执行计划
+func buildGraphQueryPlan(stmt *graphQueryStatement, typePaths []graphQueryTypePath, pathLimit int,
+	sortPushed bool) *interfaces.GraphQueryPlan {
+
+	plan := &interfaces.GraphQueryPlan{
+		TypePaths:  []interfaces.GraphQueryTypePath{},
+		Distinct:   stmt.distinct,
+		SortPushed: sortPushed,
+		Skip:       stmt.skip,
+		Limit:      stmt.limit,
+		PathLimit:  pathLimit,
+	}
+	for _, item := range stmt.orderBy {
+		direction := interfaces.ASC_DIRECTION
+		if item.desc {
+			direction = interfaces.DESC_DIRECTION
+		}
+		plan.Sort = append(plan.Sort, &interfaces.SortParams{
+			Field:     item.field(),
+			Direction: direction,
+		})
+	}
+
+	for _, typePath := range typePaths {
+		planPath := interfaces.GraphQueryTypePath{
+			ObjectTypes: []interfaces.GraphQueryObjectType{},
+			Edges:       []interfaces.GraphQueryRelationType{},
+			Length:      typePath.typePath.Length,
+		}
+		for _, objectType := range typePath.typePath.ObjectTypes {
+			planPath.ObjectTypes = append(planPath.ObjectTypes, interfaces.GraphQueryObjectType{
+				ObjectTypeID: objectType.OTID,
+				Condition:    objectType.ActualCondition,
+			})
+		}
+		for i, node := range stmt.nodes {
+			planPath.ObjectTypes[typePath.nodePositions[i]].Variable = node.variable
+		}
+
+		for _, edge := range typePath.typePath.TypeEdges {
+			planPath.Edges = append(planPath.Edges, interfaces.GraphQueryRelationType{
+				RelationTypeID:     edge.RelationTypeId,
+				RelationTypeName:   edge.RelationType.RTName,
+				SourceObjectTypeID: edge.RelationType.SourceObjectTypeID,
+				TargetObjectTypeID: edge.RelationType.TargetObjectTypeID,
+				Direction:          edge.Direction,
+			})
+		}
+		for i, relation := range stmt.relations {
+			span := typePath.relationSpans[i]
+			for k := span[0]; k < span[1]; k++ {
+				planPath.Edges[k].Variable = relation.variable
+			}
+		}
+
+		plan.TypePaths = append(plan.TypePaths, planPath)
+	}
+	return plan
+}
@@ -0,0 +1,962 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package knowledge_network
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	cond "ontology-query/common/condition"
+	"ontology-query/interfaces"
+)
+
+// 图查询语句支持的语法（类 Cypher 的子集）：
+//
+//	[EXPLAIN] MATCH (a:ot1 {prop: 1})-[r:rt1|rt2*1..3]->(b:ot2)<-[:rt3]-(c)
+//	[WHERE a.prop > 1 AND (b.prop IN [1, 2] OR NOT b.prop STARTS WITH 'x')]
+//	RETURN [DISTINCT] a, b.prop AS name, r | *
+//	[ORDER BY name DESC, a.prop] [SKIP 10] [LIMIT 20]
+//
+// 节点的标签为对象类id，关系的标签为关系类id。WHERE 中每个 AND 分支只能引用一个节点变量，
+// 这样过滤条件可以下推到各个对象类的对象实例查询中。
+
+type graphQueryTokenKind int
+
+const (
+	graphQueryTokenEOF graphQueryTokenKind = iota
+	graphQueryTokenIdent
+	graphQueryTokenQuotedIdent
+	graphQueryTokenString
+	graphQueryTokenNumber
+	graphQueryTokenSymbol
+)
+
+type graphQueryToken struct {
+	kind graphQueryTokenKind
+	text string
+	pos  int
+}
+
+// 过滤表达式中的取反操作，转换为过滤条件时下推到各个比较操作
+const graphQueryOperationNot = "not"
+
+// 多字符的符号，需要优先于单字符符号匹配
+var graphQueryMultiCharSymbols = []string{"<>", "!=", "<=", ">=", "=~", ".."}
+
+// 将查询语句切分为词法单元
+func tokenizeGraphQuery(query string) ([]graphQueryToken, error) {
+	runes := []rune(query)
+	tokens := []graphQueryToken{}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, graphQueryToken{kind: graphQueryTokenIdent, text: string(runes[start:i]), pos: start})
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			// 小数点后必须是数字，避免把 *1..3 中的 .. 当作小数点
+			if i+1 < len(runes) && runes[i] == '.' && unicode.IsDigit(runes[i+1]) {
+				i++
+				for i < len(runes) && unicode.IsDigit(runes[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, graphQueryToken{kind: graphQueryTokenNumber, text: string(runes[start:i]), pos: start})
+
+		case r == '\'' || r == '"' || r == '`':
+			start := i
+			var sb strings.Builder
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\\' && r != '`' && i+1 < len(runes) {
+					i++
+					switch runes[i] {
+					case 'n':
+						sb.WriteRune('\n')
+					case 't':
+						sb.WriteRune('\t')
+					default:
+						sb.WriteRune(runes[i])
+					}
+					continue
+				}
+				if runes[i] == r {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+			}
+			if !closed {
+				return nil, fmt.Errorf("位置%d: 引号未闭合", start)
+			}
+			kind := graphQueryTokenString
+			if r == '`' {
+				kind = graphQueryTokenQuotedIdent
+			}
+			tokens = append(tokens, graphQueryToken{kind: kind, text: sb.String(), pos: start})
+
+		default:
+			matched := false
+			for _, symbol := range graphQueryMultiCharSymbols {
+				if i+1 < len(runes) && string(runes[i:i+2]) == symbol {
+					tokens = append(tokens, graphQueryToken{kind: graphQueryTokenSymbol, text: symbol, pos: i})
+					i += 2
+					matched = true
+					break
+				}
+			}
+			if matched {
+				continue
+			}
+			if !strings.ContainsRune("()[]{}:,.|*-<>=", r) {
+				return nil, fmt.Errorf("位置%d: 不支持的字符'%c'", i, r)
+			}
+			tokens = append(tokens, graphQueryToken{kind: graphQueryTokenSymbol, text: string(r), pos: i})
+			i++
+		}
+	}
+
+	tokens = append(tokens, graphQueryToken{kind: graphQueryTokenEOF, pos: len(runes)})
+	return tokens, nil
+}
+
+// 查询语句
+type graphQueryStatement struct {
+	explain   bool
+	nodes     []*graphQueryNode
+	relations []*graphQueryRelation // relations[i] 连接 nodes[i] 和 nodes[i+1]
+	where     *graphQueryExpr
+	distinct  bool
+	returnAll bool
+	returns   []*graphQueryReturnItem
+	orderBy   []*graphQueryOrderItem
+	skip      int
+	limit     int
+	reversed  bool // 是否已从末尾节点开始匹配
+}
+
+// 模式中的节点
+type graphQueryNode struct {
+	variable   string
+	label      string
+	properties []*graphQueryExpr // 节点上内联的属性等值条件
+}
+
+// 模式中的关系
+type graphQueryRelation struct {
+	variable       string
+	labels         []string
+	direction      string
+	minHops        int
+	maxHops        int // 为0时表示不限，由路径的最大长度决定
+	variableLength bool
+}
+
+// 过滤表达式。operation 为 and、or、not 或 condition 中的比较操作符
+type graphQueryExpr struct {
+	operation string
+	subExprs  []*graphQueryExpr
+	variable  string
+	property  string
+	value     any
+}
+
+// 返回项，property 为空时返回整个节点或关系
+type graphQueryReturnItem struct {
+	variable string
+	property string
+	alias    string
+}
+
+// 排序项，property 为空时 variable 为返回项的列名
+type graphQueryOrderItem struct {
+	variable string
+	property string
+	desc     bool
+}
+
+// 返回项的列名
+func (item *graphQueryReturnItem) column() string {
+	if item.alias != "" {
+		return item.alias
+	}
+	if item.property != "" {
+		return item.variable + "." + item.property
+	}
+	return item.variable
+}
+
+// 排序项对应的字段名
+func (item *graphQueryOrderItem) field() string {
+	if item.property != "" {
+		return item.variable + "." + item.property
+	}
+	return item.variable
+}
+
+type graphQueryParser struct {
+	tokens []graphQueryToken
+	pos    int
+}
+
+// 解析查询语句并校验变量的引用
+func parseGraphQuery(query string) (*graphQueryStatement, error) {
+	tokens, err := tokenizeGraphQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &graphQueryParser{tokens: tokens}
+	stmt, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+	if err = stmt.validate(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+func (p *graphQueryParser) peek() graphQueryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *graphQueryParser) next() graphQueryToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != graphQueryTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// 当前词是否为指定关键字，关键字不区分大小写
+func (p *graphQueryParser) isKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == graphQueryTokenIdent && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *graphQueryParser) acceptKeyword(keyword string) bool {
+	if p.isKeyword(keyword) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *graphQueryParser) expectKeyword(keyword string) error {
+	if !p.acceptKeyword(keyword) {
+		return p.errorf("期望关键字 %s", keyword)
+	}
+	return nil
+}
+
+func (p *graphQueryParser) isSymbol(symbol string) bool {
+	tok := p.peek()
+	return tok.kind == graphQueryTokenSymbol && tok.text == symbol
+}
+
+func (p *graphQueryParser) acceptSymbol(symbol string) bool {
+	if p.isSymbol(symbol) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *graphQueryParser) expectSymbol(symbol string) error {
+	if !p.acceptSymbol(symbol) {
+		return p.errorf("期望 '%s'", symbol)
+	}
+	return nil
+}
+
+// 标识符，反引号括起的标识符可以包含任意字符
+func (p *graphQueryParser) expectIdent(what string) (string, error) {
+	tok := p.peek()
+	if tok.kind != graphQueryTokenIdent && tok.kind != graphQueryTokenQuotedIdent {
+		return "", p.errorf("期望%s", what)
+	}
+	p.pos++
+	return tok.text, nil
+}
+
+func (p *graphQueryParser) expectInt(what string) (int, error) {
+	tok := p.peek()
+	if tok.kind != graphQueryTokenNumber {
+		return 0, p.errorf("期望%s", what)
+	}
+	n, err := strconv.Atoi(tok.text)
+	if err != nil {
+		return 0, p.errorf("%s必须是非负整数", what)
+	}
+	p.pos++
+	return n, nil
+}
+
+func (p *graphQueryParser) errorf(format string, args ...any) error {
+	tok := p.peek()
+	actual := tok.text
+	if tok.kind == graphQueryTokenEOF {
+		actual = "语句结尾"
+	}
+	return fmt.Errorf("位置%d: %s, 实际为 %s", tok.pos, fmt.Sprintf(format, args...), actual)
+}
+
+func (p *graphQueryParser) parseStatement() (*graphQueryStatement, error) {
+	stmt := &graphQueryStatement{}
+	stmt.explain = p.acceptKeyword("EXPLAIN")
+
+	if err := p.expectKeyword("MATCH"); err != nil {
+		return nil, err
+	}
+	if err := p.parsePattern(stmt); err != nil {
+		return nil, err
+	}
+
+	if p.acceptKeyword("WHERE") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		stmt.where = expr
+	}
+
+	if err := p.expectKeyword("RETURN"); err != nil {
+		return nil, err
+	}
+	if err := p.parseReturn(stmt); err != nil {
+		return nil, err
+	}
+
+	if p.acceptKeyword("ORDER") {
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		if err := p.parseOrderBy(stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptKeyword("SKIP") {
+		n, err := p.expectInt("SKIP 的数量")
+		if err != nil {
+			return nil, err
+		}
+		stmt.skip = n
+	}
+	if p.acceptKeyword("LIMIT") {
+		n, err := p.expectInt("LIMIT 的数量")
+		if err != nil {
+			return nil, err
+		}
+		stmt.limit = n
+	}
+
+	if p.peek().kind != graphQueryTokenEOF {
+		return nil, p.errorf("期望语句结束")
+	}
+	return stmt, nil
+}
+
+// 模式：节点与关系交替出现的一条链
+func (p *graphQueryParser) parsePattern(stmt *graphQueryStatement) error {
+	node, err := p.parseNode()
+	if err != nil {
+		return err
+	}
+	stmt.nodes = append(stmt.nodes, node)
+
+	for p.isSymbol("-") || p.isSymbol("<") {
+		relation, err := p.parseRelation()
+		if err != nil {
+			return err
+		}
+		node, err := p.parseNode()
+		if err != nil {
+			return err
+		}
+		stmt.relations = append(stmt.relations, relation)
+		stmt.nodes = append(stmt.nodes, node)
+	}
+
+	if p.isSymbol(",") {
+		return p.errorf("只支持单条链式模式")
+	}
+	return nil
+}
+
+// 节点：(var:label {prop: value, ...})
+func (p *graphQueryParser) parseNode() (*graphQueryNode, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+
+	node := &graphQueryNode{}
+	if tok := p.peek(); tok.kind == graphQueryTokenIdent || tok.kind == graphQueryTokenQuotedIdent {
+		node.variable = p.next().text
+	}
+	if p.acceptSymbol(":") {
+		label, err := p.expectIdent("对象类id")
+		if err != nil {
+			return nil, err
+		}
+		node.label = label
+	}
+
+	if p.acceptSymbol("{") {
+		for !p.acceptSymbol("}") {
+			if len(node.properties) > 0 {
+				if err := p.expectSymbol(","); err != nil {
+					return nil, err
+				}
+			}
+			property, err := p.expectIdent("属性名")
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expectSymbol(":"); err != nil {
+				return nil, err
+			}
+			value, err := p.parseLiteral()
+			if err != nil {
+				return nil, err
+			}
+			node.properties = append(node.properties, &graphQueryExpr{
+				operation: cond.OperationEq,
+				variable:  node.variable,
+				property:  property,
+				value:     value,
+			})
+		}
+	}
+
+	if err := p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// 关系：-[var:rt1|rt2*min..max]->、<-[...]-、-[...]-，以及省略方括号的 -->、<--、--
+func (p *graphQueryParser) parseRelation() (*graphQueryRelation, error) {
+	relation := &graphQueryRelation{minHops: 1, maxHops: 1}
+	start := p.peek().pos
+
+	leftArrow := p.acceptSymbol("<")
+	if err := p.expectSymbol("-"); err != nil {
+		return nil, err
+	}
+
+	if p.acceptSymbol("[") {
+		if tok := p.peek(); tok.kind == graphQueryTokenIdent || tok.kind == graphQueryTokenQuotedIdent {
+			relation.variable = p.next().text
+		}
+		if p.acceptSymbol(":") {
+			for {
+				label, err := p.expectIdent("关系类id")
+				if err != nil {
+					return nil, err
+				}
+				relation.labels = append(relation.labels, label)
+				if !p.acceptSymbol("|") {
+					break
+				}
+				// 兼容 :rt1|:rt2 的写法
+				p.acceptSymbol(":")
+			}
+		}
+		if p.acceptSymbol("*") {
+			if err := p.parseHops(relation); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expectSymbol("]"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.expectSymbol("-"); err != nil {
+		return nil, err
+	}
+	rightArrow := p.acceptSymbol(">")
+
+	switch {
+	case leftArrow && rightArrow:
+		return nil, fmt.Errorf("位置%d: 关系不能同时指向两端", start)
+	case rightArrow:
+		relation.direction = interfaces.DIRECTION_FORWARD
+	case leftArrow:
+		relation.direction = interfaces.DIRECTION_BACKWARD
+	default:
+		relation.direction = interfaces.DIRECTION_BIDIRECTIONAL
+	}
+	return relation, nil
+}
+
+// 可变长度关系的跳数：*、*n、*n..、*..m、*n..m
+func (p *graphQueryParser) parseHops(relation *graphQueryRelation) error {
+	relation.variableLength = true
+	relation.minHops, relation.maxHops = 1, 0
+	start := p.peek().pos
+
+	if p.peek().kind == graphQueryTokenNumber {
+		n, err := p.expectInt("跳数")
+		if err != nil {
+			return err
+		}
+		relation.minHops, relation.maxHops = n, n
+	}
+	if p.acceptSymbol("..") {
+		relation.maxHops = 0
+		if p.peek().kind == graphQueryTokenNumber {
+			n, err := p.expectInt("跳数")
+			if err != nil {
+				return err
+			}
+			relation.maxHops = n
+		}
+	}
+
+	if relation.minHops < 1 {
+		return fmt.Errorf("位置%d: 关系的最小跳数不能小于1", start)
+	}
+	if relation.maxHops != 0 && relation.maxHops < relation.minHops {
+		return fmt.Errorf("位置%d: 关系的最大跳数不能小于最小跳数", start)
+	}
+	return nil
+}
+
+func (p *graphQueryParser) parseOr() (*graphQueryExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	subExprs := []*graphQueryExpr{left}
+	for p.acceptKeyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		subExprs = append(subExprs, right)
+	}
+	if len(subExprs) == 1 {
+		return left, nil
+	}
+	return &graphQueryExpr{operation: cond.OperationOr, subExprs: subExprs}, nil
+}
+
+func (p *graphQueryParser) parseAnd() (*graphQueryExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	subExprs := []*graphQueryExpr{left}
+	for p.acceptKeyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		subExprs = append(subExprs, right)
+	}
+	if len(subExprs) == 1 {
+		return left, nil
+	}
+	return &graphQueryExpr{operation: cond.OperationAnd, subExprs: subExprs}, nil
+}
+
+func (p *graphQueryParser) parseNot() (*graphQueryExpr, error) {
+	if p.acceptKeyword("NOT") {
+		sub, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &graphQueryExpr{operation: graphQueryOperationNot, subExprs: []*graphQueryExpr{sub}}, nil
+	}
+	if p.acceptSymbol("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+// 比较表达式，左侧必须是 var.prop
+func (p *graphQueryParser) parseComparison() (*graphQueryExpr, error) {
+	variable, err := p.expectIdent("变量名")
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectSymbol("."); err != nil {
+		return nil, err
+	}
+	property, err := p.expectIdent("属性名")
+	if err != nil {
+		return nil, err
+	}
+	expr := &graphQueryExpr{variable: variable, property: property}
+
+	switch {
+	case p.acceptKeyword("IS"):
+		expr.operation = cond.OperationNotExist
+		if p.acceptKeyword("NOT") {
+			expr.operation = cond.OperationExist
+		}
+		if err = p.expectKeyword("NULL"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+
+	case p.acceptKeyword("IN"):
+		expr.operation = cond.OperationIn
+		expr.value, err = p.parseList()
+		return expr, err
+
+	case p.acceptKeyword("CONTAINS"):
+		return p.parseLikeValue(expr, "%", "%")
+
+	case p.acceptKeyword("STARTS"):
+		if err = p.expectKeyword("WITH"); err != nil {
+			return nil, err
+		}
+		return p.parseLikeValue(expr, "", "%")
+
+	case p.acceptKeyword("ENDS"):
+		if err = p.expectKeyword("WITH"); err != nil {
+			return nil, err
+		}
+		return p.parseLikeValue(expr, "%", "")
+
+	case p.acceptSymbol("=~"):
+		if p.peek().kind != graphQueryTokenString {
+			return nil, p.errorf("=~ 右侧必须是字符串")
+		}
+		expr.operation = cond.OperationRegex
+		expr.value = p.next().text
+		return expr, nil
+	}
+
+	operations := map[string]string{
+		"=":  cond.OperationEq,
+		"<>": cond.OperationNotEq,
+		"!=": cond.OperationNotEq,
+		">":  cond.OperationGt,
+		">=": cond.OperationGte,
+		"<":  cond.OperationLt,
+		"<=": cond.OperationLte,
+	}
+	tok := p.peek()
+	operation, ok := operations[tok.text]
+	if tok.kind != graphQueryTokenSymbol || !ok {
+		return nil, p.errorf("期望比较操作符")
+	}
+	p.pos++
+	expr.operation = operation
+
+	if p.isKeyword("NULL") {
+		return nil, p.errorf("与 NULL 的比较请使用 IS NULL 或 IS NOT NULL")
+	}
+	expr.value, err = p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+// CONTAINS、STARTS WITH、ENDS WITH 转为 like，值中的通配符需要转义
+func (p *graphQueryParser) parseLikeValue(expr *graphQueryExpr, prefix string, suffix string) (*graphQueryExpr, error) {
+	if p.peek().kind != graphQueryTokenString {
+		return nil, p.errorf("字符串匹配的右侧必须是字符串")
+	}
+	str := p.next().text
+	escaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+	expr.operation = cond.OperationLike
+	expr.value = prefix + escaper.Replace(str) + suffix
+	return expr, nil
+}
+
+// 列表：[value, ...]
+func (p *graphQueryParser) parseList() ([]any, error) {
+	if err := p.expectSymbol("["); err != nil {
+		return nil, err
+	}
+	if p.isSymbol("]") {
+		return nil, p.errorf("IN 的列表不能为空")
+	}
+	values := []any{}
+	for !p.acceptSymbol("]") {
+		if len(values) > 0 {
+			if err := p.expectSymbol(","); err != nil {
+				return nil, err
+			}
+		}
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// 字面量：字符串、数字、true、false
+func (p *graphQueryParser) parseLiteral() (any, error) {
+	negative := p.acceptSymbol("-")
+
+	tok := p.peek()
+	switch {
+	case tok.kind == graphQueryTokenNumber:
+		p.pos++
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("位置%d: 不合法的数字 %s", tok.pos, tok.text)
+		}
+		if negative {
+			value = -value
+		}
+		return value, nil
+
+	case negative:
+		return nil, p.errorf("期望数字")
+
+	case tok.kind == graphQueryTokenString:
+		p.pos++
+		return tok.text, nil
+
+	case p.acceptKeyword("TRUE"):
+		return true, nil
+
+	case p.acceptKeyword("FALSE"):
+		return false, nil
+	}
+
+	return nil, p.errorf("期望字符串、数字或布尔值")
+}
+
+// 返回项：*，或 var、var.prop，可用 AS 指定列名
+func (p *graphQueryParser) parseReturn(stmt *graphQueryStatement) error {
+	stmt.distinct = p.acceptKeyword("DISTINCT")
+	if p.acceptSymbol("*") {
+		stmt.returnAll = true
+		return nil
+	}
+
+	for {
+		item := &graphQueryReturnItem{}
+		variable, err := p.expectIdent("返回的变量名")
+		if err != nil {
+			return err
+		}
+		item.variable = variable
+		if p.acceptSymbol(".") {
+			if item.property, err = p.expectIdent("属性名"); err != nil {
+				return err
+			}
+		}
+		if p.acceptKeyword("AS") {
+			if item.alias, err = p.expectIdent("列名"); err != nil {
+				return err
+			}
+		}
+		stmt.returns = append(stmt.returns, item)
+
+		if !p.acceptSymbol(",") {
+			return nil
+		}
+	}
+}
+
+// 排序项：var.prop 或返回项的列名，默认升序
+func (p *graphQueryParser) parseOrderBy(stmt *graphQueryStatement) error {
+	for {
+		item := &graphQueryOrderItem{}
+		variable, err := p.expectIdent("排序字段")
+		if err != nil {
+			return err
+		}
+		item.variable = variable
+		if p.acceptSymbol(".") {
+			if item.property, err = p.expectIdent("属性名"); err != nil {
+				return err
+			}
+		}
+		if p.acceptKeyword("DESC") || p.acceptKeyword("DESCENDING") {
+			item.desc = true
+		} else if !p.acceptKeyword("ASC") {
+			p.acceptKeyword("ASCENDING")
+		}
+		stmt.orderBy = append(stmt.orderBy, item)
+
+		if !p.acceptSymbol(",") {
+			return nil
+		}
+	}
+}
+
+// 校验变量的定义和引用，并补全可变长度关系的最大跳数
+func (stmt *graphQueryStatement) validate() error {
+	nodeVars := map[string]bool{}
+	relationVars := map[string]bool{}
+	for _, node := range stmt.nodes {
+		if node.variable == "" {
+			continue
+		}
+		if nodeVars[node.variable] {
+			return fmt.Errorf("变量[%s]重复定义，暂不支持环状模式", node.variable)
+		}
+		nodeVars[node.variable] = true
+	}
+	for _, relation := range stmt.relations {
+		if relation.variable == "" {
+			continue
+		}
+		if nodeVars[relation.variable] || relationVars[relation.variable] {
+			return fmt.Errorf("变量[%s]重复定义", relation.variable)
+		}
+		relationVars[relation.variable] = true
+	}
+
+	// 路径总长度不能超过上限，未指定最大跳数的关系用剩余的长度补全
+	minLength, fixedLength := 0, 0
+	unbounded := []*graphQueryRelation{}
+	for _, relation := range stmt.relations {
+		minLength += relation.minHops
+		if relation.maxHops == 0 {
+			unbounded = append(unbounded, relation)
+		} else {
+			fixedLength += relation.maxHops
+		}
+	}
+	if minLength > interfaces.MAX_GRAPH_QUERY_PATH_LENGTH {
+		return fmt.Errorf("路径的最小长度为%d, 超过了最大长度%d", minLength, interfaces.MAX_GRAPH_QUERY_PATH_LENGTH)
+	}
+	for _, relation := range unbounded {
+		fixedLength += relation.minHops
+	}
+	if fixedLength > interfaces.MAX_GRAPH_QUERY_PATH_LENGTH {
+		return fmt.Errorf("路径的最大长度为%d, 超过了最大长度%d", fixedLength, interfaces.MAX_GRAPH_QUERY_PATH_LENGTH)
+	}
+	for _, relation := range unbounded {
+		relation.maxHops = relation.minHops + interfaces.MAX_GRAPH_QUERY_PATH_LENGTH - fixedLength
+	}
+
+	if err := validateGraphQueryExprVariables(stmt.where, nodeVars, relationVars); err != nil {
+		return err
+	}
+
+	// RETURN * 按变量在模式中出现的顺序返回
+	if stmt.returnAll {
+		for i, node := range stmt.nodes {
+			if node.variable != "" {
+				stmt.returns = append(stmt.returns, &graphQueryReturnItem{variable: node.variable})
+			}
+			if i < len(stmt.relations) && stmt.relations[i].variable != "" {
+				stmt.returns = append(stmt.returns, &graphQueryReturnItem{variable: stmt.relations[i].variable})
+			}
+		}
+		if len(stmt.returns) == 0 {
+			return fmt.Errorf("RETURN * 要求模式中至少定义一个变量")
+		}
+	}
+
+	columns := map[string]bool{}
+	for _, item := range stmt.returns {
+		if !nodeVars[item.variable] && !relationVars[item.variable] {
+			return fmt.Errorf("返回项中的变量[%s]未定义", item.variable)
+		}
+		if item.property != "" && relationVars[item.variable] {
+			return fmt.Errorf("关系变量[%s]没有属性", item.variable)
+		}
+		if columns[item.column()] {
+			return fmt.Errorf("返回的列名[%s]重复", item.column())
+		}
+		columns[item.column()] = true
+	}
+
+	for _, item := range stmt.orderBy {
+		if item.property != "" {
+			if !nodeVars[item.variable] {
+				return fmt.Errorf("排序字段中的变量[%s]不是节点变量", item.variable)
+			}
+			continue
+		}
+		if !columns[item.variable] {
+			return fmt.Errorf("排序字段[%s]必须是节点属性或返回的列名", item.variable)
+		}
+	}
+
+	if stmt.limit == 0 {
+		stmt.limit = interfaces.MAX_PATHS
+	}
+	if stmt.skip+stmt.limit > interfaces.MAX_PATHS {
+		return fmt.Errorf("SKIP 与 LIMIT 之和不能超过%d", interfaces.MAX_PATHS)
+	}
+	return nil
+}
+
+// 过滤表达式只能引用节点变量
+func validateGraphQueryExprVariables(expr *graphQueryExpr, nodeVars map[string]bool, relationVars map[string]bool) error {
+	if expr == nil {
+		return nil
+	}
+	if len(expr.subExprs) > 0 {
+		for _, sub := range expr.subExprs {
+			if err := validateGraphQueryExprVariables(sub, nodeVars, relationVars); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if relationVars[expr.variable] {
+		return fmt.Errorf("暂不支持对关系变量[%s]的过滤", expr.variable)
+	}
+	if !nodeVars[expr.variable] {
+		return fmt.Errorf("过滤条件中的变量[%s]未定义", expr.variable)
+	}
+	return nil
+}
+
+// 首个节点未指定对象类而末尾节点指定了时，把模式反转为从末尾节点开始匹配
+func (stmt *graphQueryStatement) reverseIfNeeded() {
+	if stmt.nodes[0].label != "" || stmt.nodes[len(stmt.nodes)-1].label == "" {
+		return
+	}
+
+	for i, j := 0, len(stmt.nodes)-1; i < j; i, j = i+1, j-1 {
+		stmt.nodes[i], stmt.nodes[j] = stmt.nodes[j], stmt.nodes[i]
+	}
+	for i, j := 0, len(stmt.relations)-1; i < j; i, j = i+1, j-1 {
+		stmt.relations[i], stmt.relations[j] = stmt.relations[j], stmt.relations[i]
+	}
+	for _, relation := range stmt.relations {
+		switch relation.direction {
+		case interfaces.DIRECTION_FORWARD:
+			relation.direction = interfaces.DIRECTION_BACKWARD
+		case interfaces.DIRECTION_BACKWARD:
+			relation.direction = interfaces.DIRECTION_FORWARD
+		}
+	}
+	stmt.reversed = true
+}
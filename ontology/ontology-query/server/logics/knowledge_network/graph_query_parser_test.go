@@ -0,0 +1,216 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package knowledge_network
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	cond "ontology-query/common/condition"
+	"ontology-query/interfaces"
+)
+
+func Test_tokenizeGraphQuery(t *testing.T) {
+	Convey("Test tokenizeGraphQuery", t, func() {
+		Convey("成功 - 区分跳数范围与小数", func() {
+			tokens, err := tokenizeGraphQuery(`-[*1..3]-> 1.5 'a\'b' <> =~ ` + "`x-y`")
+			So(err, ShouldBeNil)
+
+			texts := []string{}
+			for _, tok := range tokens {
+				texts = append(texts, tok.text)
+			}
+			So(texts, ShouldResemble, []string{"-", "[", "*", "1", "..", "3", "]", "-", ">", "1.5", "a'b", "<>", "=~", "x-y", ""})
+			So(tokens[10].kind, ShouldEqual, graphQueryTokenString)
+			So(tokens[13].kind, ShouldEqual, graphQueryTokenQuotedIdent)
+			So(tokens[14].kind, ShouldEqual, graphQueryTokenEOF)
+		})
+
+		Convey("失败 - 引号未闭合", func() {
+			_, err := tokenizeGraphQuery(`a.name = 'abc`)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("失败 - 不支持的字符", func() {
+			_, err := tokenizeGraphQuery(`a.age + 1`)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_parseGraphQuery(t *testing.T) {
+	Convey("Test parseGraphQuery", t, func() {
+		Convey("成功 - 解析完整语句", func() {
+			stmt, err := parseGraphQuery(`explain MATCH (a:customer {level: 'vip'})-[r:owns|:holds]->(b:account)<-[*2..3]-(c)
+				WHERE a.age >= 18 AND (b.balance IN [1, 2.5] OR NOT b.name STARTS WITH 'x_')
+				RETURN DISTINCT a, b.balance AS balance, r
+				ORDER BY balance DESC, a.age SKIP 5 LIMIT 10`)
+			So(err, ShouldBeNil)
+			So(stmt.explain, ShouldBeTrue)
+			So(stmt.distinct, ShouldBeTrue)
+			So(stmt.skip, ShouldEqual, 5)
+			So(stmt.limit, ShouldEqual, 10)
+
+			So(len(stmt.nodes), ShouldEqual, 3)
+			So(stmt.nodes[0].variable, ShouldEqual, "a")
+			So(stmt.nodes[0].label, ShouldEqual, "customer")
+			So(stmt.nodes[0].properties, ShouldResemble, []*graphQueryExpr{
+				{operation: cond.OperationEq, variable: "a", property: "level", value: "vip"},
+			})
+			So(stmt.nodes[2].variable, ShouldEqual, "c")
+			So(stmt.nodes[2].label, ShouldEqual, "")
+
+			So(*stmt.relations[0], ShouldResemble, graphQueryRelation{
+				variable:  "r",
+				labels:    []string{"owns", "holds"},
+				direction: interfaces.DIRECTION_FORWARD,
+				minHops:   1,
+				maxHops:   1,
+			})
+			So(*stmt.relations[1], ShouldResemble, graphQueryRelation{
+				direction:      interfaces.DIRECTION_BACKWARD,
+				minHops:        2,
+				maxHops:        3,
+				variableLength: true,
+			})
+
+			So(stmt.where, ShouldResemble, &graphQueryExpr{
+				operation: cond.OperationAnd,
+				subExprs: []*graphQueryExpr{
+					{operation: cond.OperationGte, variable: "a", property: "age", value: float64(18)},
+					{
+						operation: cond.OperationOr,
+						subExprs: []*graphQueryExpr{
+							{operation: cond.OperationIn, variable: "b", property: "balance", value: []any{float64(1), 2.5}},
+							{
+								operation: graphQueryOperationNot,
+								subExprs: []*graphQueryExpr{
+									{operation: cond.OperationLike, variable: "b", property: "name", value: `x\_%`},
+								},
+							},
+						},
+					},
+				},
+			})
+
+			columns := []string{}
+			for _, item := range stmt.returns {
+				columns = append(columns, item.column())
+			}
+			So(columns, ShouldResemble, []string{"a", "balance", "r"})
+			So(stmt.orderBy, ShouldResemble, []*graphQueryOrderItem{
+				{variable: "balance", desc: true},
+				{variable: "a", property: "age"},
+			})
+		})
+
+		Convey("成功 - 省略方括号的关系和 RETURN *", func() {
+			stmt, err := parseGraphQuery(`MATCH (a:ot1)--(b)-[r]->(:ot2) RETURN *`)
+			So(err, ShouldBeNil)
+			So(stmt.relations[0].direction, ShouldEqual, interfaces.DIRECTION_BIDIRECTIONAL)
+			So(stmt.relations[1].direction, ShouldEqual, interfaces.DIRECTION_FORWARD)
+			So(stmt.limit, ShouldEqual, interfaces.MAX_PATHS)
+
+			columns := []string{}
+			for _, item := range stmt.returns {
+				columns = append(columns, item.column())
+			}
+			So(columns, ShouldResemble, []string{"a", "b", "r"})
+		})
+
+		Convey("成功 - 比较操作和字符串匹配", func() {
+			stmt, err := parseGraphQuery(`MATCH (a:ot1) WHERE a.x <> -1 AND a.y IS NULL AND a.z IS NOT NULL
+				AND a.s CONTAINS '50%' AND a.t ENDS WITH 'end' AND a.u =~ '^a.*' AND a.v = true RETURN a`)
+			So(err, ShouldBeNil)
+
+			operations := []string{}
+			values := []any{}
+			for _, sub := range stmt.where.subExprs {
+				operations = append(operations, sub.operation)
+				values = append(values, sub.value)
+			}
+			So(operations, ShouldResemble, []string{
+				cond.OperationNotEq, cond.OperationNotExist, cond.OperationExist,
+				cond.OperationLike, cond.OperationLike, cond.OperationRegex, cond.OperationEq,
+			})
+			So(values, ShouldResemble, []any{float64(-1), nil, nil, `%50\%%`, "%end", "^a.*", true})
+		})
+
+		Convey("成功 - 补全未指定最大跳数的关系", func() {
+			stmt, err := parseGraphQuery(`MATCH (a:ot1)-[:rt1*2..]->(b)-[:rt2]->(c) RETURN a`)
+			So(err, ShouldBeNil)
+			So(stmt.relations[0].minHops, ShouldEqual, 2)
+			So(stmt.relations[0].maxHops, ShouldEqual, interfaces.MAX_GRAPH_QUERY_PATH_LENGTH-1)
+
+			stmt, err = parseGraphQuery(`MATCH (a:ot1)-[*]->(b) RETURN a`)
+			So(err, ShouldBeNil)
+			So(stmt.relations[0].minHops, ShouldEqual, 1)
+			So(stmt.relations[0].maxHops, ShouldEqual, interfaces.MAX_GRAPH_QUERY_PATH_LENGTH)
+
+			stmt, err = parseGraphQuery(`MATCH (a:ot1)-[*..2]->(b) RETURN a`)
+			So(err, ShouldBeNil)
+			So(stmt.relations[0].minHops, ShouldEqual, 1)
+			So(stmt.relations[0].maxHops, ShouldEqual, 2)
+		})
+
+		Convey("失败 - 语法或语义错误", func() {
+			queries := []string{
+				`RETURN a`,
+				`MATCH (a:ot1 RETURN a`,
+				`MATCH (a:ot1) RETURN a LIMIT x`,
+				`MATCH (a:ot1) RETURN a extra`,
+				`MATCH (a:ot1), (b:ot2) RETURN a`,
+				`MATCH (a:ot1)<-[:rt1]->(b) RETURN a`,
+				`MATCH (a:ot1)-[*0..2]->(b) RETURN a`,
+				`MATCH (a:ot1)-[*3..2]->(b) RETURN a`,
+				`MATCH (a:ot1)-[*7]->(b) RETURN a`,
+				`MATCH (a:ot1)-[*4]->(b)-[*3]->(c) RETURN a`,
+				`MATCH (a:ot1)-->(a) RETURN a`,
+				`MATCH (a:ot1) WHERE b.x = 1 RETURN a`,
+				`MATCH (a:ot1)-[r]->(b) WHERE r.x = 1 RETURN a`,
+				`MATCH (a:ot1) WHERE a.x = NULL RETURN a`,
+				`MATCH (a:ot1) WHERE a.x IN [] RETURN a`,
+				`MATCH (a:ot1) WHERE a.x CONTAINS 1 RETURN a`,
+				`MATCH (a:ot1) RETURN b`,
+				`MATCH (a:ot1)-[r]->(b) RETURN r.x`,
+				`MATCH (a:ot1) RETURN a.x, a.y AS a.x`,
+				`MATCH (a:ot1) RETURN a.x AS x, a.y AS x`,
+				`MATCH (a:ot1) RETURN a ORDER BY x`,
+				`MATCH (:ot1) RETURN *`,
+				`MATCH (a:ot1) RETURN a SKIP 1 LIMIT 2000`,
+			}
+			for _, query := range queries {
+				_, err := parseGraphQuery(query)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func Test_graphQueryStatement_reverseIfNeeded(t *testing.T) {
+	Convey("Test graphQueryStatement reverseIfNeeded", t, func() {
+		Convey("首个节点未指定对象类时从末尾节点开始", func() {
+			stmt, err := parseGraphQuery(`MATCH (a)-[r1]->(b)<-[r2]-(c:ot1) RETURN a`)
+			So(err, ShouldBeNil)
+			stmt.reverseIfNeeded()
+			So(stmt.reversed, ShouldBeTrue)
+			So(stmt.nodes[0].variable, ShouldEqual, "c")
+			So(stmt.nodes[2].variable, ShouldEqual, "a")
+			So(stmt.relations[0].variable, ShouldEqual, "r2")
+			So(stmt.relations[0].direction, ShouldEqual, interfaces.DIRECTION_FORWARD)
+			So(stmt.relations[1].direction, ShouldEqual, interfaces.DIRECTION_BACKWARD)
+		})
+
+		Convey("首个节点指定了对象类时不反转", func() {
+			stmt, err := parseGraphQuery(`MATCH (a:ot1)-[r1]->(b:ot2) RETURN a`)
+			So(err, ShouldBeNil)
+			stmt.reverseIfNeeded()
+			So(stmt.reversed, ShouldBeFalse)
+			So(stmt.nodes[0].variable, ShouldEqual, "a")
+		})
+	})
+}
@@ -0,0 +1,408 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package knowledge_network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"ontology-query/common"
+	cond "ontology-query/common/condition"
+	oerrors "ontology-query/errors"
+	"ontology-query/interfaces"
+	dmock "ontology-query/interfaces/mock"
+)
+
+// 按过滤条件筛选测试数据，只支持测试中用到的操作
+func matchGraphQueryTestCondition(data map[string]any, cfg *cond.CondCfg) bool {
+	if cfg == nil {
+		return true
+	}
+	switch cfg.Operation {
+	case cond.OperationAnd, cond.OperationOr:
+		for _, sub := range cfg.SubConds {
+			matched := matchGraphQueryTestCondition(data, sub)
+			if cfg.Operation == cond.OperationOr && matched {
+				return true
+			}
+			if cfg.Operation == cond.OperationAnd && !matched {
+				return false
+			}
+		}
+		return cfg.Operation == cond.OperationAnd
+	}
+
+	value, exist := data[cfg.Name]
+	switch cfg.Operation {
+	case cond.OperationExist:
+		return exist
+	case cond.OperationNotExist:
+		return !exist
+	case cond.OperationIn, cond.OperationNotIn:
+		in := false
+		for _, v := range cfg.Value.([]any) {
+			if exist && compareGraphQueryValues(value, v) == 0 {
+				in = true
+			}
+		}
+		return in == (cfg.Operation == cond.OperationIn)
+	}
+	if !exist {
+		return false
+	}
+
+	c := compareGraphQueryValues(value, cfg.Value)
+	switch cfg.Operation {
+	case cond.OperationEq:
+		return c == 0
+	case cond.OperationNotEq:
+		return c != 0
+	case cond.OperationGt:
+		return c > 0
+	case cond.OperationGte:
+		return c >= 0
+	case cond.OperationLt:
+		return c < 0
+	case cond.OperationLte:
+		return c <= 0
+	}
+	panic(fmt.Sprintf("unsupported operation %s", cfg.Operation))
+}
+
+func Test_knowledgeNetworkService_SearchByGraphQuery(t *testing.T) {
+	Convey("Test knowledgeNetworkService SearchByGraphQuery", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		omAccess := dmock.NewMockOntologyManagerAccess(mockCtrl)
+		ots := dmock.NewMockObjectTypeService(mockCtrl)
+
+		service := &knowledgeNetworkService{
+			appSetting: appSetting,
+			omAccess:   omAccess,
+			ots:        ots,
+		}
+
+		ctx := context.Background()
+
+		// 客户认识(knows)客户：c1->c2->c3；客户拥有(owns)账户：c1->a1, c1->a2, c2->a3
+		objectTypes := map[string]*interfaces.ObjectType{
+			"customer": {
+				ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+					OTID:        "customer",
+					OTName:      "客户",
+					PrimaryKeys: []string{"id"},
+					DisplayKey:  "name",
+				},
+			},
+			"account": {
+				ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+					OTID:        "account",
+					OTName:      "账户",
+					PrimaryKeys: []string{"id"},
+					DisplayKey:  "id",
+				},
+			},
+		}
+		datas := map[string][]map[string]any{
+			"customer": {
+				{"id": "c1", "name": "Alice", "age": float64(30), "friend": "c2"},
+				{"id": "c2", "name": "Bob", "age": float64(20), "friend": "c3"},
+				{"id": "c3", "name": "Carol", "age": float64(40)},
+			},
+			"account": {
+				{"id": "a1", "owner": "c1", "balance": float64(100)},
+				{"id": "a2", "owner": "c1", "balance": float64(50)},
+				{"id": "a3", "owner": "c2", "balance": float64(10)},
+			},
+		}
+		relationTypes := map[string]interfaces.RelationType{
+			"owns": {
+				RTID:               "owns",
+				RTName:             "拥有",
+				SourceObjectTypeID: "customer",
+				TargetObjectTypeID: "account",
+				MappingRules: []interfaces.Mapping{
+					{
+						SourceProp: interfaces.SimpleProperty{Name: "id"},
+						TargetProp: interfaces.SimpleProperty{Name: "owner"},
+					},
+				},
+			},
+			"knows": {
+				RTID:               "knows",
+				RTName:             "认识",
+				SourceObjectTypeID: "customer",
+				TargetObjectTypeID: "customer",
+				MappingRules: []interfaces.Mapping{
+					{
+						SourceProp: interfaces.SimpleProperty{Name: "friend"},
+						TargetProp: interfaces.SimpleProperty{Name: "id"},
+					},
+				},
+			},
+		}
+
+		omAccess.EXPECT().GetObjectType(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(ctx context.Context, knID string, branch string, otID string) (interfaces.ObjectType, bool, error) {
+				objectType, exist := objectTypes[otID]
+				if !exist {
+					return interfaces.ObjectType{}, false, nil
+				}
+				return *objectType, true, nil
+			})
+		omAccess.EXPECT().GetRelationType(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(ctx context.Context, knID string, branch string, rtID string) (interfaces.RelationType, bool, error) {
+				relationType, exist := relationTypes[rtID]
+				return relationType, exist, nil
+			})
+		// 起点对象的查询按排序和数量截取
+		startQueries := []*interfaces.ObjectQueryBaseOnObjectType{}
+		ots.EXPECT().GetObjectsByObjectTypeID(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(ctx context.Context, query *interfaces.ObjectQueryBaseOnObjectType) (interfaces.Objects, error) {
+				startQueries = append(startQueries, query)
+				objects := interfaces.Objects{
+					Datas:      []map[string]any{},
+					ObjectType: objectTypes[query.ObjectTypeID],
+				}
+				for _, data := range datas[query.ObjectTypeID] {
+					if matchGraphQueryTestCondition(data, query.ActualCondition) {
+						objects.Datas = append(objects.Datas, data)
+					}
+				}
+				sort.SliceStable(objects.Datas, func(i, j int) bool {
+					for _, sp := range query.Sort {
+						c := compareGraphQueryValues(objects.Datas[i][sp.Field], objects.Datas[j][sp.Field])
+						if c != 0 {
+							return (c < 0) == (sp.Direction == interfaces.ASC_DIRECTION)
+						}
+					}
+					return false
+				})
+				if query.Limit > 0 && len(objects.Datas) > query.Limit {
+					objects.Datas = objects.Datas[:query.Limit]
+				}
+				return objects, nil
+			})
+
+		newQuery := func(statement string) *interfaces.GraphQuery {
+			return &interfaces.GraphQuery{
+				Query:  statement,
+				KNID:   "kn1",
+				Branch: "main",
+			}
+		}
+
+		Convey("成功 - 按条件匹配并排序", func() {
+			result, err := service.SearchByGraphQuery(ctx, newQuery(`MATCH (c:customer)-[:owns]->(a:account)
+				WHERE c.age >= 20 AND a.balance > 20
+				RETURN c.name AS name, a.balance AS balance ORDER BY balance DESC`))
+			So(err, ShouldBeNil)
+			So(result.Columns, ShouldResemble, []string{"name", "balance"})
+			So(result.Datas, ShouldResemble, []map[string]any{
+				{"name": "Alice", "balance": float64(100)},
+				{"name": "Alice", "balance": float64(50)},
+			})
+			So(result.Plan, ShouldBeNil)
+		})
+
+		Convey("成功 - 反向关系返回关系类的起点和终点", func() {
+			result, err := service.SearchByGraphQuery(ctx, newQuery(
+				`MATCH (a:account {id: 'a3'})<-[r:owns]-(c) RETURN c.name, r`))
+			So(err, ShouldBeNil)
+			So(result.Columns, ShouldResemble, []string{"c.name", "r"})
+			So(result.Datas, ShouldResemble, []map[string]any{
+				{
+					"c.name": "Bob",
+					"r": interfaces.Relation{
+						RelationTypeId:   "owns",
+						RelationTypeName: "拥有",
+						SourceObjectId:   "customer-c2",
+						TargetObjectId:   "account-a3",
+					},
+				},
+			})
+		})
+
+		Convey("成功 - 可变长度关系", func() {
+			result, err := service.SearchByGraphQuery(ctx, newQuery(
+				`MATCH (a:customer {name: 'Alice'})-[r:knows*1..2]->(b) RETURN b.name, r ORDER BY b.name`))
+			So(err, ShouldBeNil)
+			So(len(result.Datas), ShouldEqual, 2)
+			So(result.Datas[0]["b.name"], ShouldEqual, "Bob")
+			So(result.Datas[0]["r"], ShouldHaveLength, 1)
+			So(result.Datas[1]["b.name"], ShouldEqual, "Carol")
+			So(result.Datas[1]["r"], ShouldResemble, []interfaces.Relation{
+				{RelationTypeId: "knows", RelationTypeName: "认识", SourceObjectId: "customer-c1", TargetObjectId: "customer-c2"},
+				{RelationTypeId: "knows", RelationTypeName: "认识", SourceObjectId: "customer-c2", TargetObjectId: "customer-c3"},
+			})
+		})
+
+		Convey("成功 - 末尾节点指定对象类时从末尾开始匹配", func() {
+			result, err := service.SearchByGraphQuery(ctx, newQuery(
+				`MATCH (c)-[:owns]->(a:account) WHERE a.balance < 60 RETURN DISTINCT c.name ORDER BY c.name`))
+			So(err, ShouldBeNil)
+			So(result.Datas, ShouldResemble, []map[string]any{
+				{"c.name": "Alice"},
+				{"c.name": "Bob"},
+			})
+		})
+
+		Convey("成功 - 单个节点分页，排序下推到起点对象的查询", func() {
+			result, err := service.SearchByGraphQuery(ctx, newQuery(
+				`MATCH (c:customer) RETURN c.name ORDER BY c.age DESC SKIP 1 LIMIT 1`))
+			So(err, ShouldBeNil)
+			So(result.Datas, ShouldResemble, []map[string]any{
+				{"c.name": "Alice"},
+			})
+			So(result.Truncated, ShouldBeFalse)
+			So(len(startQueries), ShouldEqual, 1)
+			So(startQueries[0].Limit, ShouldEqual, 2)
+			So(startQueries[0].Sort, ShouldResemble, []*interfaces.SortParams{
+				{Field: "age", Direction: interfaces.DESC_DIRECTION},
+			})
+		})
+
+		Convey("成功 - 单个节点按返回列排序时不下推", func() {
+			result, err := service.SearchByGraphQuery(ctx, newQuery(
+				`MATCH (c:customer) RETURN c.name AS name ORDER BY name LIMIT 1`))
+			So(err, ShouldBeNil)
+			So(result.Datas, ShouldResemble, []map[string]any{
+				{"name": "Alice"},
+			})
+			So(startQueries[0].Limit, ShouldEqual, interfaces.MAX_PATHS)
+			So(startQueries[0].Sort, ShouldBeNil)
+		})
+
+		Convey("成功 - 路径达到上限时标记结果被截断", func() {
+			for i := 0; i < interfaces.MAX_PATHS; i++ {
+				datas["account"] = append(datas["account"], map[string]any{
+					"id": fmt.Sprintf("b%d", i), "owner": "c3", "balance": float64(i),
+				})
+			}
+
+			result, err := service.SearchByGraphQuery(ctx, newQuery(
+				`MATCH (a:account) RETURN DISTINCT a.owner ORDER BY a.owner`))
+			So(err, ShouldBeNil)
+			So(result.Truncated, ShouldBeTrue)
+			So(startQueries[0].Limit, ShouldEqual, interfaces.MAX_PATHS)
+			// 只基于检索到的前 MAX_PATHS 个账户去重
+			So(len(result.Datas), ShouldEqual, 3)
+		})
+
+		Convey("成功 - EXPLAIN 只返回执行计划", func() {
+			omAccess.EXPECT().ListRelationTypes(gomock.Any(), "kn1", "main",
+				interfaces.RelationTypesQuery{SourceObjectTypeID: "customer"}).
+				Return([]interfaces.RelationType{relationTypes["owns"], relationTypes["knows"]}, nil)
+
+			result, err := service.SearchByGraphQuery(ctx, newQuery(
+				`EXPLAIN MATCH (c:customer)-[r]->(b) WHERE NOT (c.age > 30 OR c.name = 'Bob') RETURN c, b LIMIT 5`))
+			So(err, ShouldBeNil)
+			So(result.Datas, ShouldBeEmpty)
+			So(result.Plan, ShouldNotBeNil)
+			So(result.Plan.PathLimit, ShouldEqual, 5)
+			So(result.Plan.SortPushed, ShouldBeFalse)
+			So(len(result.Plan.TypePaths), ShouldEqual, 2)
+
+			typePath := result.Plan.TypePaths[0]
+			So(typePath.ObjectTypes, ShouldResemble, []interfaces.GraphQueryObjectType{
+				{
+					Variable:     "c",
+					ObjectTypeID: "customer",
+					Condition: &cond.CondCfg{
+						Operation: cond.OperationAnd,
+						SubConds: []*cond.CondCfg{
+							{
+								Name:        "age",
+								Operation:   cond.OperationLte,
+								ValueOptCfg: cond.ValueOptCfg{ValueFrom: cond.ValueFrom_Const, Value: float64(30)},
+							},
+							{
+								Name:        "name",
+								Operation:   cond.OperationNotEq,
+								ValueOptCfg: cond.ValueOptCfg{ValueFrom: cond.ValueFrom_Const, Value: "Bob"},
+							},
+						},
+					},
+				},
+				{Variable: "b", ObjectTypeID: "account"},
+			})
+			So(typePath.Edges, ShouldResemble, []interfaces.GraphQueryRelationType{
+				{
+					Variable:           "r",
+					RelationTypeID:     "owns",
+					RelationTypeName:   "拥有",
+					SourceObjectTypeID: "customer",
+					TargetObjectTypeID: "account",
+					Direction:          interfaces.DIRECTION_FORWARD,
+				},
+			})
+			So(result.Plan.TypePaths[1].Edges[0].RelationTypeID, ShouldEqual, "knows")
+		})
+
+		Convey("失败 - 语法错误", func() {
+			_, err := service.SearchByGraphQuery(ctx, newQuery(`MATCH (c:customer RETURN c`))
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).HTTPCode, ShouldEqual, http.StatusBadRequest)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter_GraphQuery)
+		})
+
+		Convey("失败 - OR 连接了多个变量", func() {
+			_, err := service.SearchByGraphQuery(ctx, newQuery(
+				`MATCH (c:customer)-[:owns]->(a:account) WHERE c.age > 1 OR a.balance > 1 RETURN c`))
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).HTTPCode, ShouldEqual, http.StatusBadRequest)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_KnowledgeNetwork_InvalidParameter_GraphQuery)
+		})
+
+		Convey("失败 - NOT 作用于正则匹配", func() {
+			_, err := service.SearchByGraphQuery(ctx, newQuery(
+				`MATCH (c:customer) WHERE NOT c.name =~ '^A' RETURN c`))
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).HTTPCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("失败 - 对象类不存在", func() {
+			_, err := service.SearchByGraphQuery(ctx, newQuery(`MATCH (c:supplier) RETURN c`))
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).HTTPCode, ShouldEqual, http.StatusNotFound)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_ObjectType_ObjectTypeNotFound)
+		})
+
+		Convey("失败 - 关系类不存在", func() {
+			_, err := service.SearchByGraphQuery(ctx, newQuery(`MATCH (c:customer)-[:holds]->(a) RETURN c`))
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).HTTPCode, ShouldEqual, http.StatusNotFound)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_KnowledgeNetwork_RelationTypeNotFound)
+		})
+
+		Convey("失败 - 无法确定起点的对象类", func() {
+			_, err := service.SearchByGraphQuery(ctx, newQuery(`MATCH (c)-->(a) RETURN c`))
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).HTTPCode, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+func Test_compareGraphQueryValues(t *testing.T) {
+	Convey("Test compareGraphQueryValues", t, func() {
+		So(compareGraphQueryValues(1, 2.5), ShouldEqual, -1)
+		So(compareGraphQueryValues(int64(3), float64(3)), ShouldEqual, 0)
+		So(compareGraphQueryValues(true, false), ShouldEqual, 1)
+		So(compareGraphQueryValues("b", "a"), ShouldEqual, 1)
+		So(compareGraphQueryValues(nil, "a"), ShouldEqual, 1)
+		So(compareGraphQueryValues("a", nil), ShouldEqual, -1)
+		So(compareGraphQueryValues(nil, nil), ShouldEqual, 0)
+	})
+}
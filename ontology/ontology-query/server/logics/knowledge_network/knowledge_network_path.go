@@ -0,0 +1,494 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package knowledge_network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	oerrors "ontology-query/errors"
+	"ontology-query/interfaces"
+	"ontology-query/logics"
+)
+
+// 路径搜索中扩展的对象只用一个配额id
+const pathQuotaID = 0
+
+// 双向广度优先搜索中一侧的搜索状态
+type pathSearchSide struct {
+	edges    map[string][]interfaces.TypeEdge // 对象类id -> 从该对象类出发可扩展的概念边
+	depth    map[string]int                   // 已访问的对象id -> 距离本侧起点的层数
+	frontier []interfaces.LevelObject         // 下一次要扩展的对象
+	level    int                              // 已扩展的层数
+	forward  bool                             // 是否从起点一侧出发
+}
+
+// 路径搜索过程中的对象和关系
+type pathSearchGraph struct {
+	objects   map[string]interfaces.LevelObject
+	links     map[string][]interfaces.Relation // 起点方向的对象id -> 指向终点方向的关系
+	linkExist map[string]bool
+	quota     *interfaces.PathQuotaManager // 每扩展出一个新对象消耗一个配额
+	exhausted bool                         // 配额已用完，停止扩展
+}
+
+// 查询两个对象实例之间的最短路径或前k条简单路径
+func (kns *knowledgeNetworkService) SearchPathsBetweenObjects(ctx context.Context,
+	query *interfaces.PathQueryBetweenObjects) (interfaces.ObjectSubGraph, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "查询两个对象实例之间的路径")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Key("kn_id").String(query.KNID),
+		attribute.Key("branch").String(query.Branch),
+	)
+
+	result := interfaces.ObjectSubGraph{
+		Objects:         map[string]interfaces.ObjectInfoInSubgraph{},
+		IsolatedObjects: map[string]interfaces.ObjectInfoInSubgraph{},
+		RelationPaths:   []interfaces.RelationPath{},
+	}
+
+	// 1. 查询起点和终点对象实例
+	source, err := kns.getPathEndpoint(ctx, query, query.Source)
+	if err != nil {
+		span.SetStatus(codes.Error, "Get source object error")
+		return result, err
+	}
+	target, err := kns.getPathEndpoint(ctx, query, query.Target)
+	if err != nil {
+		span.SetStatus(codes.Error, "Get target object error")
+		return result, err
+	}
+
+	// 2. 从起点对象类出发获取概念路径，概念分组的范围由本体引擎过滤
+	typePaths, err := kns.omAccess.GetRelationTypePathsBaseOnSource(ctx, query.KNID, query.Branch,
+		interfaces.PathsQueryBaseOnSource{
+			ConceptGroups:     query.ConceptGroups,
+			SourceObjecTypeId: source.ObjectType.OTID,
+			Direction:         query.Direction,
+			PathLength:        query.MaxLength,
+		})
+	if err != nil {
+		logger.Errorf("GetRelationTypePathsBaseOnSource error: %s", err.Error())
+		span.SetStatus(codes.Error, "Get RelationTypePathsBaseOnSource error")
+		o11y.Error(ctx, fmt.Sprintf("Get RelationTypePathsBaseOnSource error: %v", err))
+
+		return result, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyQuery_ObjectType_InternalError_GetObjectTypesByIDFailed).WithErrorDetails(err.Error())
+	}
+	forwardEdges, backwardEdges := buildPathSearchEdges(typePaths)
+
+	// 3. 实例层面的双向广度优先搜索
+	graph := &pathSearchGraph{
+		objects: map[string]interfaces.LevelObject{
+			source.ObjectID: source,
+			target.ObjectID: target,
+		},
+		links:     map[string][]interfaces.Relation{},
+		linkExist: map[string]bool{},
+		quota:     query.PathQuotaManager,
+	}
+	paths, err := kns.bidirectionalSearch(ctx, query, graph, source, target, forwardEdges, backwardEdges)
+	if err != nil {
+		span.SetStatus(codes.Error, "Search paths error")
+		return result, err
+	}
+
+	// 4. 组装结果，路径数量已由 k 限制
+	for _, path := range paths {
+		result.RelationPaths = append(result.RelationPaths, path)
+
+		for _, relation := range path.Relations {
+			for _, objectID := range []string{relation.SourceObjectId, relation.TargetObjectId} {
+				if _, exist := result.Objects[objectID]; !exist {
+					result.Objects[objectID] = buildObjectInfoInSubgraph(graph.objects[objectID], query.ExcludeSystemProperties)
+				}
+			}
+		}
+	}
+	// 没有连通的起点和终点作为孤立对象返回
+	for _, endpoint := range []interfaces.LevelObject{source, target} {
+		if _, exist := result.Objects[endpoint.ObjectID]; !exist {
+			result.IsolatedObjects[endpoint.ObjectID] = buildObjectInfoInSubgraph(endpoint, query.ExcludeSystemProperties)
+		}
+	}
+	result.CuurentPathNumber = len(result.RelationPaths)
+
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// 根据唯一标识查询路径的端点对象
+func (kns *knowledgeNetworkService) getPathEndpoint(ctx context.Context,
+	query *interfaces.PathQueryBetweenObjects, input interfaces.InputObjectInstance) (interfaces.LevelObject, error) {
+
+	objectType, exists, err := kns.omAccess.GetObjectType(ctx, query.KNID, query.Branch, input.ObjectTypeID)
+	if err != nil {
+		return interfaces.LevelObject{}, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.OntologyQuery_ObjectType_InternalError_GetObjectTypesByIDFailed).WithErrorDetails(err.Error())
+	}
+	if !exists {
+		return interfaces.LevelObject{}, rest.NewHTTPError(ctx, http.StatusNotFound,
+			oerrors.OntologyQuery_ObjectType_ObjectTypeNotFound).WithErrorDetails(fmt.Sprintf("对象类型[%s]不存在", input.ObjectTypeID))
+	}
+
+	objects, err := kns.ots.GetObjectsByObjectTypeID(ctx, &interfaces.ObjectQueryBaseOnObjectType{
+		ActualCondition: logics.BuildInstanceIdentitiesCondition([]map[string]any{input.InstanceIdentity}),
+		PageQuery: interfaces.PageQuery{
+			Limit: 1,
+		},
+		KNID:         query.KNID,
+		Branch:       query.Branch,
+		ObjectTypeID: objectType.OTID,
+		CommonQueryParameters: interfaces.CommonQueryParameters{
+			IncludeTypeInfo:    true,
+			IncludeLogicParams: query.IncludeLogicParams,
+			IgnoringStore:      query.IgnoringStore,
+		},
+	})
+	if err != nil {
+		return interfaces.LevelObject{}, err
+	}
+
+	for _, objData := range objects.Datas {
+		objectID, uk := logics.GetObjectID(objData, objects.ObjectType)
+		if objectID == "" {
+			continue
+		}
+		return interfaces.LevelObject{
+			ObjectID:   objectID,
+			ObjectUK:   uk,
+			ObjectData: objData,
+			ObjectType: objects.ObjectType,
+		}, nil
+	}
+
+	return interfaces.LevelObject{}, rest.NewHTTPError(ctx, http.StatusNotFound,
+		oerrors.OntologyQuery_KnowledgeNetwork_ObjectNotFound).
+		WithErrorDetails(fmt.Sprintf("对象类型[%s]下唯一标识为%v的对象实例不存在", input.ObjectTypeID, input.InstanceIdentity))
+}
+
+// 从概念路径中收集两侧搜索可用的概念边。正向按边的起点对象类分组，反向把边的方向翻转后按终点对象类分组
+func buildPathSearchEdges(typePaths []interfaces.RelationTypePath) (map[string][]interfaces.TypeEdge, map[string][]interfaces.TypeEdge) {
+	forwardEdges := map[string][]interfaces.TypeEdge{}
+	backwardEdges := map[string][]interfaces.TypeEdge{}
+
+	seen := map[string]bool{}
+	for _, typePath := range typePaths {
+		for _, edge := range typePath.TypeEdges {
+			key := edge.RelationTypeId + ":" + edge.Direction
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			from, to := edge.RelationType.SourceObjectTypeID, edge.RelationType.TargetObjectTypeID
+			reversed := edge
+			reversed.Direction = interfaces.DIRECTION_FORWARD
+			if edge.Direction == interfaces.DIRECTION_FORWARD {
+				reversed.Direction = interfaces.DIRECTION_BACKWARD
+			} else {
+				from, to = to, from
+			}
+
+			forwardEdges[from] = append(forwardEdges[from], edge)
+			backwardEdges[to] = append(backwardEdges[to], reversed)
+		}
+	}
+
+	return forwardEdges, backwardEdges
+}
+
+// 双向广度优先搜索，每次扩展对象数量较少的一侧。两侧相遇后，所有长度不超过两侧层数之和的路径都已在搜索图中
+func (kns *knowledgeNetworkService) bidirectionalSearch(ctx context.Context,
+	query *interfaces.PathQueryBetweenObjects,
+	graph *pathSearchGraph,
+	source interfaces.LevelObject,
+	target interfaces.LevelObject,
+	forwardEdges map[string][]interfaces.TypeEdge,
+	backwardEdges map[string][]interfaces.TypeEdge) ([]interfaces.RelationPath, error) {
+
+	forward := &pathSearchSide{
+		edges:    forwardEdges,
+		depth:    map[string]int{source.ObjectID: 0},
+		frontier: []interfaces.LevelObject{source},
+		forward:  true,
+	}
+	backward := &pathSearchSide{
+		edges:    backwardEdges,
+		depth:    map[string]int{target.ObjectID: 0},
+		frontier: []interfaces.LevelObject{target},
+	}
+
+	// 下一层对象的查询沿用子图查询的批量扩展
+	expandQuery := &interfaces.SubGraphQueryBaseOnSource{
+		KNID:   query.KNID,
+		Branch: query.Branch,
+		PageQuery: interfaces.PageQuery{
+			Limit: interfaces.MAX_PATHS,
+		},
+		CommonQueryParameters: query.CommonQueryParameters,
+	}
+
+	met := false
+	for forward.level+backward.level < query.MaxLength {
+		if len(forward.frontier) == 0 || len(backward.frontier) == 0 {
+			// 有一侧已无可扩展的对象，搜索图中已包含所有可达的关系
+			return findSimplePaths(graph, source.ObjectID, target.ObjectID, query.MaxLength, query.K), nil
+		}
+
+		side, other := forward, backward
+		if len(backward.frontier) < len(forward.frontier) {
+			side, other = backward, forward
+		}
+
+		newMet, err := kns.expandPathSearchSide(ctx, expandQuery, graph, side, other)
+		if err != nil {
+			return nil, err
+		}
+		met = met || newMet
+
+		if graph.exhausted {
+			// 配额用完，只返回已扩展的搜索图中的路径
+			logger.Debugf("路径搜索配额已用完 - 正向层数: %d, 反向层数: %d, 对象数: %d",
+				forward.level, backward.level, len(graph.objects))
+			return findSimplePaths(graph, source.ObjectID, target.ObjectID, query.MaxLength, query.K), nil
+		}
+
+		if met {
+			paths := findSimplePaths(graph, source.ObjectID, target.ObjectID, forward.level+backward.level, query.K)
+			if len(paths) >= query.K {
+				return paths, nil
+			}
+		}
+	}
+
+	if !met {
+		return []interfaces.RelationPath{}, nil
+	}
+	return findSimplePaths(graph, source.ObjectID, target.ObjectID, query.MaxLength, query.K), nil
+}
+
+// 扩展一侧的一层对象，返回两侧是否相遇。配额用完时停止扩展，本层只保留已扩展出的对象
+func (kns *knowledgeNetworkService) expandPathSearchSide(ctx context.Context,
+	expandQuery *interfaces.SubGraphQueryBaseOnSource,
+	graph *pathSearchGraph,
+	side *pathSearchSide,
+	other *pathSearchSide) (bool, error) {
+
+	// 按对象类分组，同一对象类的对象沿同样的概念边扩展
+	typeGroups := map[string][]interfaces.LevelObject{}
+	typeOrder := []string{}
+	for _, obj := range side.frontier {
+		otID := obj.ObjectType.OTID
+		if _, exist := typeGroups[otID]; !exist {
+			typeOrder = append(typeOrder, otID)
+		}
+		typeGroups[otID] = append(typeGroups[otID], obj)
+	}
+
+	batchSize := 50
+	met := false
+	var nextFrontier []interfaces.LevelObject
+expand:
+	for _, otID := range typeOrder {
+		objects := typeGroups[otID]
+		for _, edge := range side.edges[otID] {
+			nextObjectTypeID := edge.RelationType.TargetObjectTypeID
+			if edge.Direction != interfaces.DIRECTION_FORWARD {
+				nextObjectTypeID = edge.RelationType.SourceObjectTypeID
+			}
+
+			for i := 0; i < len(objects); i += batchSize {
+				end := i + batchSize
+				if end > len(objects) {
+					end = len(objects)
+				}
+
+				nextObjectsMap, err := kns.getNextObjectsBatchByRelation(ctx, expandQuery, objects[i:end], &edge,
+					interfaces.ObjectTypeWithKeyField{OTID: nextObjectTypeID})
+				if err != nil {
+					return false, err
+				}
+
+				for _, current := range objects[i:end] {
+					nextObjects, exist := nextObjectsMap[current.ObjectID]
+					if !exist {
+						continue
+					}
+					for _, nextData := range nextObjects.Datas {
+						nextID, uk := logics.GetObjectID(nextData, nextObjects.ObjectType)
+						if nextID == "" || nextID == current.ObjectID {
+							continue
+						}
+
+						if _, exist := graph.objects[nextID]; !exist {
+							if !logics.CanGenerate(graph.quota, pathQuotaID) {
+								graph.exhausted = true
+								break expand
+							}
+							logics.RecordGenerated(graph.quota, pathQuotaID, 1)
+							graph.objects[nextID] = interfaces.LevelObject{
+								ObjectID:   nextID,
+								ObjectUK:   uk,
+								ObjectData: nextData,
+								ObjectType: nextObjects.ObjectType,
+							}
+						}
+
+						// 关系统一按起点到终点的方向记录
+						if side.forward {
+							graph.addLink(current.ObjectID, nextID, edge)
+						} else {
+							graph.addLink(nextID, current.ObjectID, edge)
+						}
+
+						if _, visited := side.depth[nextID]; visited {
+							continue
+						}
+						side.depth[nextID] = side.level + 1
+						nextFrontier = append(nextFrontier, graph.objects[nextID])
+						if _, exist := other.depth[nextID]; exist {
+							met = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	side.frontier = nextFrontier
+	side.level++
+	logger.Debugf("路径搜索扩展完成 - 正向: %v, 层数: %d, 新增对象: %d", side.forward, side.level, len(nextFrontier))
+	return met, nil
+}
+
+// 记录两个对象间的关系，同一关系类只记录一次
+func (g *pathSearchGraph) addLink(fromID string, toID string, edge interfaces.TypeEdge) {
+	key := fmt.Sprintf("%s:%s->%s", edge.RelationTypeId, fromID, toID)
+	if g.linkExist[key] {
+		return
+	}
+	g.linkExist[key] = true
+
+	g.links[fromID] = append(g.links[fromID], interfaces.Relation{
+		RelationTypeId:   edge.RelationTypeId,
+		RelationTypeName: edge.RelationType.RTName,
+		SourceObjectId:   fromID,
+		TargetObjectId:   toID,
+	})
+}
+
+// 在搜索图中按长度从短到长枚举起点到终点的简单路径，最多返回k条
+func findSimplePaths(graph *pathSearchGraph, sourceID string, targetID string, maxLength int, k int) []interfaces.RelationPath {
+	// 反向求各对象到终点的最短距离，用于剪枝
+	distance := map[string]int{targetID: 0}
+	reverseLinks := map[string][]string{}
+	for fromID, relations := range graph.links {
+		for _, relation := range relations {
+			reverseLinks[relation.TargetObjectId] = append(reverseLinks[relation.TargetObjectId], fromID)
+		}
+	}
+	queue := []string{targetID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, prev := range reverseLinks[current] {
+			if _, exist := distance[prev]; !exist {
+				distance[prev] = distance[current] + 1
+				queue = append(queue, prev)
+			}
+		}
+	}
+
+	shortest, reachable := distance[sourceID]
+	if !reachable || shortest > maxLength {
+		return []interfaces.RelationPath{}
+	}
+
+	// 同一对象的关系排序，保证相同长度路径的顺序稳定
+	for _, relations := range graph.links {
+		sort.Slice(relations, func(i, j int) bool {
+			if relations[i].TargetObjectId != relations[j].TargetObjectId {
+				return relations[i].TargetObjectId < relations[j].TargetObjectId
+			}
+			return relations[i].RelationTypeId < relations[j].RelationTypeId
+		})
+	}
+
+	paths := []interfaces.RelationPath{}
+	visited := map[string]bool{sourceID: true}
+	var current []interfaces.Relation
+
+	var dfs func(objectID string, remaining int)
+	dfs = func(objectID string, remaining int) {
+		if len(paths) >= k {
+			return
+		}
+		if objectID == targetID {
+			if remaining == 0 {
+				relations := make([]interfaces.Relation, len(current))
+				copy(relations, current)
+				paths = append(paths, interfaces.RelationPath{
+					Relations: relations,
+					Length:    len(relations),
+				})
+			}
+			return
+		}
+
+		for _, relation := range graph.links[objectID] {
+			nextID := relation.TargetObjectId
+			dist, exist := distance[nextID]
+			if visited[nextID] || !exist || dist > remaining-1 {
+				continue
+			}
+
+			visited[nextID] = true
+			current = append(current, relation)
+			dfs(nextID, remaining-1)
+			current = current[:len(current)-1]
+			visited[nextID] = false
+		}
+	}
+
+	// 逐个长度枚举，先找到的路径一定不长于后找到的路径
+	for length := shortest; length <= maxLength && len(paths) < k; length++ {
+		dfs(sourceID, length)
+	}
+
+	return paths
+}
+
+// 构建子图中的对象信息
+func buildObjectInfoInSubgraph(obj interfaces.LevelObject, excludeSystemProperties []string) interfaces.ObjectInfoInSubgraph {
+	objInfo := interfaces.ObjectInfoInSubgraph{
+		ObjectTypeId:   obj.ObjectType.OTID,
+		ObjectTypeName: obj.ObjectType.OTName,
+		Properties:     obj.ObjectData,
+	}
+	if !logics.ShouldExcludeSystemProperty(interfaces.SYSTEM_PROPERTY_INSTANCE_ID, excludeSystemProperties) {
+		objInfo.InstanceID = obj.ObjectID
+	}
+	if !logics.ShouldExcludeSystemProperty(interfaces.SYSTEM_PROPERTY_INSTANCE_IDENTITY, excludeSystemProperties) {
+		objInfo.InstanceIdentity = obj.ObjectUK
+	}
+	if !logics.ShouldExcludeSystemProperty(interfaces.SYSTEM_PROPERTY_DISPLAY, excludeSystemProperties) {
+		objInfo.Display = obj.ObjectData[obj.ObjectType.DisplayKey]
+	}
+	return objInfo
+}
@@ -0,0 +1,307 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package knowledge_network
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"ontology-query/common"
+	oerrors "ontology-query/errors"
+	"ontology-query/interfaces"
+	dmock "ontology-query/interfaces/mock"
+)
+
+func Test_knowledgeNetworkService_SearchPathsBetweenObjects(t *testing.T) {
+	Convey("Test knowledgeNetworkService SearchPathsBetweenObjects", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		omAccess := dmock.NewMockOntologyManagerAccess(mockCtrl)
+		ots := dmock.NewMockObjectTypeService(mockCtrl)
+
+		service := &knowledgeNetworkService{
+			appSetting: appSetting,
+			omAccess:   omAccess,
+			ots:        ots,
+		}
+
+		ctx := context.Background()
+
+		// 人员的上级(manages)和任职公司(works_at)：a->b->c1, a->b->f->c1, a->c2
+		objectTypes := map[string]*interfaces.ObjectType{
+			"person": {
+				ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+					OTID:        "person",
+					OTName:      "人员",
+					PrimaryKeys: []string{"id"},
+					DisplayKey:  "id",
+				},
+			},
+			"company": {
+				ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+					OTID:        "company",
+					OTName:      "公司",
+					PrimaryKeys: []string{"id"},
+					DisplayKey:  "id",
+				},
+			},
+		}
+		datas := map[string][]map[string]any{
+			"person": {
+				{"id": "a", "manager": "b", "company": "c2"},
+				{"id": "b", "manager": "f", "company": "c1"},
+				{"id": "f", "company": "c1"},
+			},
+			"company": {
+				{"id": "c1"},
+				{"id": "c2"},
+				{"id": "c3"},
+			},
+		}
+
+		manages := interfaces.TypeEdge{
+			RelationTypeId: "manages",
+			Direction:      interfaces.DIRECTION_FORWARD,
+			RelationType: interfaces.RelationType{
+				RTID:               "manages",
+				RTName:             "上级",
+				SourceObjectTypeID: "person",
+				TargetObjectTypeID: "person",
+				MappingRules: []interfaces.Mapping{
+					{
+						SourceProp: interfaces.SimpleProperty{Name: "manager"},
+						TargetProp: interfaces.SimpleProperty{Name: "id"},
+					},
+				},
+			},
+		}
+		worksAt := interfaces.TypeEdge{
+			RelationTypeId: "works_at",
+			Direction:      interfaces.DIRECTION_FORWARD,
+			RelationType: interfaces.RelationType{
+				RTID:               "works_at",
+				RTName:             "任职",
+				SourceObjectTypeID: "person",
+				TargetObjectTypeID: "company",
+				MappingRules: []interfaces.Mapping{
+					{
+						SourceProp: interfaces.SimpleProperty{Name: "company"},
+						TargetProp: interfaces.SimpleProperty{Name: "id"},
+					},
+				},
+			},
+		}
+		typePaths := []interfaces.RelationTypePath{
+			{
+				ObjectTypes: []interfaces.ObjectTypeWithKeyField{{OTID: "person"}, {OTID: "person"}, {OTID: "company"}},
+				TypeEdges:   []interfaces.TypeEdge{manages, worksAt},
+				Length:      2,
+			},
+			{
+				ObjectTypes: []interfaces.ObjectTypeWithKeyField{{OTID: "person"}, {OTID: "company"}},
+				TypeEdges:   []interfaces.TypeEdge{worksAt},
+				Length:      1,
+			},
+		}
+
+		omAccess.EXPECT().GetObjectType(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(ctx context.Context, knID string, branch string, otID string) (interfaces.ObjectType, bool, error) {
+				objectType, exist := objectTypes[otID]
+				if !exist {
+					return interfaces.ObjectType{}, false, nil
+				}
+				return *objectType, true, nil
+			})
+		// 端点按唯一标识过滤，下一层对象由关系映射规则过滤，这里返回对象类的全部数据
+		ots.EXPECT().GetObjectsByObjectTypeID(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(ctx context.Context, query *interfaces.ObjectQueryBaseOnObjectType) (interfaces.Objects, error) {
+				objects := interfaces.Objects{
+					Datas:      []map[string]any{},
+					ObjectType: objectTypes[query.ObjectTypeID],
+				}
+				for _, data := range datas[query.ObjectTypeID] {
+					matched := true
+					if query.Limit == 1 {
+						for _, sub := range query.ActualCondition.SubConds {
+							if data[sub.Name] != sub.Value {
+								matched = false
+							}
+						}
+					}
+					if matched {
+						objects.Datas = append(objects.Datas, data)
+					}
+				}
+				return objects, nil
+			})
+
+		newQuery := func(targetID string, k int) *interfaces.PathQueryBetweenObjects {
+			return &interfaces.PathQueryBetweenObjects{
+				KNID:   "kn1",
+				Branch: "main",
+				Source: interfaces.InputObjectInstance{
+					ObjectTypeID:     "person",
+					InstanceIdentity: map[string]any{"id": "a"},
+				},
+				Target: interfaces.InputObjectInstance{
+					ObjectTypeID:     "company",
+					InstanceIdentity: map[string]any{"id": targetID},
+				},
+				Direction: interfaces.DIRECTION_FORWARD,
+				MaxLength: 3,
+				K:         k,
+				PathQuotaManager: &interfaces.PathQuotaManager{
+					TotalLimit:         interfaces.MAX_PATHS,
+					RequestPathTypeNum: 1,
+				},
+			}
+		}
+
+		Convey("成功 - 查询最短路径", func() {
+			omAccess.EXPECT().GetRelationTypePathsBaseOnSource(gomock.Any(), "kn1", "main",
+				interfaces.PathsQueryBaseOnSource{
+					SourceObjecTypeId: "person",
+					Direction:         interfaces.DIRECTION_FORWARD,
+					PathLength:        3,
+				}).Return(typePaths, nil)
+
+			result, err := service.SearchPathsBetweenObjects(ctx, newQuery("c1", 1))
+			So(err, ShouldBeNil)
+			So(result.RelationPaths, ShouldResemble, []interfaces.RelationPath{
+				{
+					Relations: []interfaces.Relation{
+						{RelationTypeId: "manages", RelationTypeName: "上级", SourceObjectId: "person-a", TargetObjectId: "person-b"},
+						{RelationTypeId: "works_at", RelationTypeName: "任职", SourceObjectId: "person-b", TargetObjectId: "company-c1"},
+					},
+					Length: 2,
+				},
+			})
+			So(len(result.Objects), ShouldEqual, 3)
+			So(result.Objects["person-b"].Display, ShouldEqual, "b")
+			So(result.IsolatedObjects, ShouldBeEmpty)
+			So(result.CuurentPathNumber, ShouldEqual, 1)
+		})
+
+		Convey("成功 - 查询前k条路径", func() {
+			omAccess.EXPECT().GetRelationTypePathsBaseOnSource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(typePaths, nil)
+
+			result, err := service.SearchPathsBetweenObjects(ctx, newQuery("c1", 5))
+			So(err, ShouldBeNil)
+			So(len(result.RelationPaths), ShouldEqual, 2)
+			So(result.RelationPaths[0].Length, ShouldEqual, 2)
+			So(result.RelationPaths[1].Length, ShouldEqual, 3)
+			So(result.RelationPaths[1].Relations[2], ShouldResemble, interfaces.Relation{
+				RelationTypeId: "works_at", RelationTypeName: "任职", SourceObjectId: "person-f", TargetObjectId: "company-c1",
+			})
+			So(len(result.Objects), ShouldEqual, 4)
+		})
+
+		Convey("成功 - 配额用完时停止扩展，只返回已搜索到的路径", func() {
+			omAccess.EXPECT().GetRelationTypePathsBaseOnSource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(typePaths, nil)
+
+			// 正向扩展出 b、c2 后配额用完，反向扩展时 b 已在搜索图中，f 无法再扩展
+			query := newQuery("c1", 5)
+			query.PathQuotaManager.TotalLimit = 2
+			result, err := service.SearchPathsBetweenObjects(ctx, query)
+			So(err, ShouldBeNil)
+			So(len(result.RelationPaths), ShouldEqual, 1)
+			So(result.RelationPaths[0].Length, ShouldEqual, 2)
+			So(result.Objects, ShouldNotContainKey, "person-f")
+			So(query.PathQuotaManager.GlobalCount, ShouldEqual, 2)
+		})
+
+		Convey("成功 - 配额在两侧相遇前用完时无路径", func() {
+			omAccess.EXPECT().GetRelationTypePathsBaseOnSource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(typePaths, nil)
+
+			query := newQuery("c1", 5)
+			query.PathQuotaManager.TotalLimit = 1
+			result, err := service.SearchPathsBetweenObjects(ctx, query)
+			So(err, ShouldBeNil)
+			So(result.RelationPaths, ShouldBeEmpty)
+			So(len(result.IsolatedObjects), ShouldEqual, 2)
+			So(query.PathQuotaManager.GlobalCount, ShouldEqual, 1)
+		})
+
+		Convey("成功 - 超过最大长度时无路径", func() {
+			omAccess.EXPECT().GetRelationTypePathsBaseOnSource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(typePaths, nil)
+
+			query := newQuery("c1", 1)
+			query.MaxLength = 1
+			result, err := service.SearchPathsBetweenObjects(ctx, query)
+			So(err, ShouldBeNil)
+			So(result.RelationPaths, ShouldBeEmpty)
+			So(len(result.IsolatedObjects), ShouldEqual, 2)
+		})
+
+		Convey("成功 - 对象之间不连通", func() {
+			omAccess.EXPECT().GetRelationTypePathsBaseOnSource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(typePaths, nil)
+
+			result, err := service.SearchPathsBetweenObjects(ctx, newQuery("c3", 1))
+			So(err, ShouldBeNil)
+			So(result.RelationPaths, ShouldBeEmpty)
+			So(result.Objects, ShouldBeEmpty)
+			So(result.IsolatedObjects, ShouldContainKey, "person-a")
+			So(result.IsolatedObjects, ShouldContainKey, "company-c3")
+		})
+
+		Convey("失败 - 对象实例不存在", func() {
+			_, err := service.SearchPathsBetweenObjects(ctx, newQuery("c9", 1))
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.HTTPCode, ShouldEqual, http.StatusNotFound)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_KnowledgeNetwork_ObjectNotFound)
+		})
+
+		Convey("失败 - 对象类不存在", func() {
+			query := newQuery("c1", 1)
+			query.Source.ObjectTypeID = "unknown"
+			_, err := service.SearchPathsBetweenObjects(ctx, query)
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.BaseError.ErrorCode, ShouldEqual, oerrors.OntologyQuery_ObjectType_ObjectTypeNotFound)
+		})
+
+		Convey("失败 - 获取概念路径错误", func() {
+			omAccess.EXPECT().GetRelationTypePathsBaseOnSource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.OntologyQuery_InternalError))
+
+			_, err := service.SearchPathsBetweenObjects(ctx, newQuery("c1", 1))
+			So(err, ShouldNotBeNil)
+			httpErr := err.(*rest.HTTPError)
+			So(httpErr.HTTPCode, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
+
+func Test_buildPathSearchEdges(t *testing.T) {
+	Convey("Test buildPathSearchEdges", t, func() {
+		edge := interfaces.TypeEdge{
+			RelationTypeId: "rt1",
+			Direction:      interfaces.DIRECTION_BACKWARD,
+			RelationType: interfaces.RelationType{
+				SourceObjectTypeID: "ot1",
+				TargetObjectTypeID: "ot2",
+			},
+		}
+		typePaths := []interfaces.RelationTypePath{
+			{TypeEdges: []interfaces.TypeEdge{edge}},
+			{TypeEdges: []interfaces.TypeEdge{edge}},
+		}
+
+		forwardEdges, backwardEdges := buildPathSearchEdges(typePaths)
+		So(len(forwardEdges["ot2"]), ShouldEqual, 1)
+		So(forwardEdges["ot2"][0].Direction, ShouldEqual, interfaces.DIRECTION_BACKWARD)
+		So(len(backwardEdges["ot1"]), ShouldEqual, 1)
+		So(backwardEdges["ot1"][0].Direction, ShouldEqual, interfaces.DIRECTION_FORWARD)
+	})
+}
@@ -123,9 +123,14 @@ func (ots *objectTypeService) GetObjectsByObjectTypeID(ctx context.Context,
 		}
 	}
 
-	// 排序字段非空时，排序字段必须是对象类的数据属性, _score
+	searchFromIndex := !query.IgnoringStore && objectType.Status != nil && objectType.Status.IndexAvailable
+
+	// 排序字段非空时，排序字段必须是对象类的数据属性, _score，查询对象索引时还可以是图分析的系统字段
 	if len(query.Sort) > 0 {
 		for _, sp := range query.Sort {
+			if _, isGraphProp := interfaces.GRAPH_SYSTEM_PROPERTIES[sp.Field]; isGraphProp && searchFromIndex {
+				continue
+			}
 			if _, exists := indexPropMap[sp.Field]; !exists {
 				return resps, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.OntologyQuery_ObjectType_InvalidParameter).
 					WithErrorDetails(fmt.Sprintf("排序字段[%s]不是对象类的数据属性", sp.Field))
@@ -166,7 +171,7 @@ func (ots *objectTypeService) GetObjectsByObjectTypeID(ctx context.Context,
 		}
 	}
 
-	if !query.IgnoringStore && objectType.Status != nil && objectType.Status.IndexAvailable {
+	if searchFromIndex {
 		// 2. 构造排序字段
 		if query.Sort == nil {
 			// 给默认值, 默认按 _score desc，主键 asc
@@ -415,7 +420,7 @@ func (ots *objectTypeService) getObjectsFromObjectIndex(ctx context.Context, que
 	// 构造 DSL 过滤条件
 	conditionDslStr := "{}"
 	if query.ActualCondition != nil {
-		condtion, err := cond.NewCondition(ctx, query.ActualCondition, 1, logics.TransferPropsToPropMap(objectType.DataProperties))
+		condtion, err := cond.NewCondition(ctx, query.ActualCondition, 1, logics.TransferIndexPropsToPropMap(objectType.DataProperties))
 		if err != nil {
 			return rest.NewHTTPError(ctx, http.StatusBadRequest,
 				oerrors.OntologyQuery_InvalidParameter_Condition).
@@ -470,6 +475,12 @@ func (ots *objectTypeService) getObjectsFromObjectIndex(ctx context.Context, que
 		}
 		// 添加_score字段
 		object[interfaces.SORT_FIELD_SCORE] = hit.Score
+		// 添加图分析写入的系统字段
+		for name := range interfaces.GRAPH_SYSTEM_PROPERTIES {
+			if v, exists := hit.Source[name]; exists && !logics.ShouldExcludeSystemProperty(name, query.ExcludeSystemProperties) {
+				object[name] = v
+			}
+		}
 
 		// 为对象添加 _instance_id, _instance_identity, _display 字段
 		instanceID, instanceIdentity := logics.GetObjectID(object, &objectType)
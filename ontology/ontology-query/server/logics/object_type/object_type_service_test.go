@@ -202,6 +202,99 @@ func Test_objectTypeService_GetObjectsByObjectTypeID(t *testing.T) {
 			So(len(result.Datas), ShouldEqual, 1)
 		})
 
+		Convey("成功 - 从索引查询时按图分析系统字段过滤和排序", func() {
+			objectType := interfaces.ObjectType{
+				ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+					OTID: objectTypeID,
+					DataProperties: []cond.DataProperty{
+						{Name: "prop1"},
+					},
+					PrimaryKeys: []string{"id"},
+				},
+				Status: &interfaces.ObjectTypeStatus{
+					IndexAvailable: true,
+					Index:          "index1",
+				},
+			}
+
+			query := &interfaces.ObjectQueryBaseOnObjectType{
+				KNID:         knID,
+				Branch:       branch,
+				ObjectTypeID: objectTypeID,
+				ActualCondition: &cond.CondCfg{
+					Name:        interfaces.SYSTEM_PROPERTY_GRAPH_PAGERANK,
+					Operation:   cond.OperationGt,
+					ValueOptCfg: cond.ValueOptCfg{ValueFrom: cond.ValueFrom_Const, Value: 0.5},
+				},
+				PageQuery: interfaces.PageQuery{
+					Limit: 10,
+					Sort: []*interfaces.SortParams{
+						{Field: interfaces.SYSTEM_PROPERTY_GRAPH_PAGERANK, Direction: interfaces.DESC_DIRECTION},
+					},
+				},
+			}
+
+			var dsl map[string]any
+			omAccess.EXPECT().GetObjectType(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(objectType, true, nil)
+			osa.EXPECT().SearchData(gomock.Any(), "index1", gomock.Any()).DoAndReturn(
+				func(ctx context.Context, index string, query any) ([]interfaces.Hit, error) {
+					dsl = query.(map[string]any)
+					return []interfaces.Hit{
+						{
+							Source: map[string]interface{}{
+								"prop1": "value1",
+								interfaces.SYSTEM_PROPERTY_GRAPH_PAGERANK: 0.8,
+							},
+							Score: 1.0,
+						},
+					}, nil
+				})
+
+			result, err := service.GetObjectsByObjectTypeID(ctx, query)
+			So(err, ShouldBeNil)
+			So(result.SearchFromIndex, ShouldBeTrue)
+			So(dsl["sort"], ShouldResemble, []map[string]any{
+				{interfaces.SYSTEM_PROPERTY_GRAPH_PAGERANK: interfaces.DESC_DIRECTION},
+			})
+			So(dsl["query"], ShouldResemble, map[string]any{
+				"range": map[string]any{
+					interfaces.SYSTEM_PROPERTY_GRAPH_PAGERANK: map[string]any{"gt": 0.5},
+				},
+			})
+			So(len(result.Datas), ShouldEqual, 1)
+			So(result.Datas[0][interfaces.SYSTEM_PROPERTY_GRAPH_PAGERANK], ShouldEqual, 0.8)
+		})
+
+		Convey("失败 - 从视图查询时按图分析系统字段排序", func() {
+			objectType := interfaces.ObjectType{
+				ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
+					OTID: objectTypeID,
+					DataProperties: []cond.DataProperty{
+						{Name: "prop1"},
+					},
+				},
+			}
+
+			query := &interfaces.ObjectQueryBaseOnObjectType{
+				KNID:         knID,
+				Branch:       branch,
+				ObjectTypeID: objectTypeID,
+				PageQuery: interfaces.PageQuery{
+					Sort: []*interfaces.SortParams{
+						{Field: interfaces.SYSTEM_PROPERTY_GRAPH_PAGERANK, Direction: interfaces.DESC_DIRECTION},
+					},
+				},
+			}
+
+			omAccess.EXPECT().GetObjectType(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(objectType, true, nil)
+
+			_, err := service.GetObjectsByObjectTypeID(ctx, query)
+			So(err, ShouldNotBeNil)
+			httpErr, ok := err.(*rest.HTTPError)
+			So(ok, ShouldBeTrue)
+			So(httpErr.HTTPCode, ShouldEqual, http.StatusBadRequest)
+		})
+
 		Convey("失败 - 从索引查询时GetTotal失败", func() {
 			objectType := interfaces.ObjectType{
 				ObjectTypeWithKeyField: interfaces.ObjectTypeWithKeyField{
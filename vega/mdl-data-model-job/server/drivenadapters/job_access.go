@@ -29,6 +29,7 @@ const (
 	OBJECTIVE_MODEL_TABLE_NAME = "t_objective_model"
 	EVENT_MODEL_TABLE_NAME     = "t_event_models"
 	EVENT_TASK_TABLE_NAME      = "t_event_model_task"
+	METRIC_TASK_BACKFILL_TABLE = "t_metric_model_task_backfill"
 )
 
 var (
@@ -487,3 +488,205 @@ func (ja *jobAccess) ListEventJobs() ([]interfaces.JobInfo, error) {
 	}
 	return jobs, nil
 }
+
+// 查询未完成（待执行、执行中）的指标任务回溯，联表带上任务和模型的信息
+func (ja *jobAccess) ListMetricTaskBackfills() ([]interfaces.JobInfo, error) {
+	jobs := make([]interfaces.JobInfo, 0)
+	sqlStr, args, err := sq.Select(
+		"b.f_backfill_id",
+		"b.f_start",
+		"b.f_end",
+		"b.f_steps",
+		"b.f_concurrency",
+		"b.f_status",
+		"b.f_checkpoint",
+		"b.f_creator",
+		"b.f_creator_type",
+		"task.f_task_id",
+		"task.f_task_name",
+		"task.f_module_type",
+		"task.f_model_id",
+		"model.f_measure_name",
+		"task.f_time_windows",
+		"task.f_steps",
+		"task.f_index_base",
+	).
+		From(fmt.Sprintf("%s as b", METRIC_TASK_BACKFILL_TABLE)).
+		Join(fmt.Sprintf("%s as task on b.f_task_id = task.f_task_id", METRIC_TASK_TABLE_NAME)).
+		Join(fmt.Sprintf("%s as model on task.f_model_id = model.f_model_id", METRIC_MODEL_TABLE_NAME)).
+		Where(sq.Eq{"b.f_status": []string{interfaces.BACKFILL_STATUS_PENDING, interfaces.BACKFILL_STATUS_RUNNING}}).
+		ToSql()
+
+	if err != nil {
+		errDetails := fmt.Sprintf("Generate 'list metric task backfills' sql stmt failed, %v", err)
+		logger.Error(errDetails)
+		return nil, err
+	}
+
+	rows, err := ja.db.Query(sqlStr, args...)
+	if err != nil {
+		errDetails := fmt.Sprintf("List metric task backfills failed, %v", err)
+		logger.Error(errDetails)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			backfillStepsBytes []byte
+			windowsBytes       []byte
+			stepsBytes         []byte
+		)
+		backfill := interfaces.MetricTaskBackfill{}
+		task := interfaces.MetricTask{}
+		err := rows.Scan(
+			&backfill.BackfillID,
+			&backfill.Start,
+			&backfill.End,
+			&backfillStepsBytes,
+			&backfill.Concurrency,
+			&backfill.Status,
+			&backfill.Checkpoint,
+			&task.Creator.ID,
+			&task.Creator.Type,
+			&task.TaskID,
+			&task.TaskName,
+			&task.ModuleType,
+			&task.ModelID,
+			&task.MeasureName,
+			&windowsBytes,
+			&stepsBytes,
+			&task.IndexBase,
+		)
+		if err != nil {
+			errDetails := fmt.Sprintf("Row scan failed, err: %v", err)
+			logger.Error(errDetails)
+			return nil, err
+		}
+		backfill.TaskID = task.TaskID
+
+		err = json.Unmarshal(backfillStepsBytes, &backfill.Steps)
+		if err != nil {
+			logger.Errorf("Failed to unmarshal backfill steps after getting metric task backfill, err: %v", err.Error())
+			return jobs, err
+		}
+		err = json.Unmarshal(windowsBytes, &task.TimeWindows)
+		if err != nil {
+			logger.Errorf("Failed to unmarshal time windows after getting metric task backfill, err: %v", err.Error())
+			return jobs, err
+		}
+		err = json.Unmarshal(stepsBytes, &task.Steps)
+		if err != nil {
+			logger.Errorf("Failed to unmarshal steps after getting metric task backfill, err: %v", err.Error())
+			return jobs, err
+		}
+
+		job := interfaces.JobInfo{
+			JobId:      backfill.BackfillID,
+			JobType:    interfaces.JOB_TYPE_BACKFILL,
+			ModuleType: interfaces.MODULE_TYPE_METRIC_MODEL,
+			MetricTask: &task,
+			Backfill:   &backfill,
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// 抢占待执行或租约已过期的回溯，置为执行中。多个实例同时抢占时只有一个能更新成功
+func (ja *jobAccess) ClaimMetricTaskBackfill(backfillID string, updateTime int64, expiredBefore int64) (bool, error) {
+	sqlStr, args, err := sq.Update(METRIC_TASK_BACKFILL_TABLE).
+		Set("f_status", interfaces.BACKFILL_STATUS_RUNNING).
+		Set("f_update_time", updateTime).
+		Where(sq.Eq{"f_backfill_id": backfillID}).
+		Where(sq.Or{
+			sq.Eq{"f_status": interfaces.BACKFILL_STATUS_PENDING},
+			sq.And{
+				sq.Eq{"f_status": interfaces.BACKFILL_STATUS_RUNNING},
+				sq.Lt{"f_update_time": expiredBefore},
+			},
+		}).ToSql()
+	if err != nil {
+		errDetails := fmt.Sprintf("Generate 'claim metric task backfill' sql stmt failed, %v", err)
+		logger.Error(errDetails)
+		return false, err
+	}
+
+	result, err := ja.db.Exec(sqlStr, args...)
+	if err != nil {
+		errDetails := fmt.Sprintf("Execute sql stmt for 'claim metric task backfill' failed, %v", err)
+		logger.Error(errDetails)
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		errDetails := fmt.Sprintf("Get rows affected for 'claim metric task backfill' failed, %v", err)
+		logger.Error(errDetails)
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// 更新执行中的指标任务回溯的状态和进度，回溯已被删除或取消时不更新并返回 false
+func (ja *jobAccess) UpdateMetricTaskBackfill(backfill interfaces.MetricTaskBackfill) (bool, error) {
+	updateMap := map[string]any{
+		"f_status":        backfill.Status,
+		"f_checkpoint":    backfill.Checkpoint,
+		"f_error_details": backfill.ErrorDetails,
+		"f_update_time":   backfill.UpdateTime,
+	}
+
+	sqlStr, args, err := sq.Update(METRIC_TASK_BACKFILL_TABLE).SetMap(updateMap).
+		Where(sq.Eq{
+			"f_backfill_id": backfill.BackfillID,
+			"f_status":      interfaces.BACKFILL_STATUS_RUNNING,
+		}).ToSql()
+	if err != nil {
+		errDetails := fmt.Sprintf("Generate 'update metric task backfill' sql stmt failed, %v", err)
+		logger.Error(errDetails)
+		return false, err
+	}
+
+	result, err := ja.db.Exec(sqlStr, args...)
+	if err != nil {
+		errDetails := fmt.Sprintf("Execute sql stmt for 'update metric task backfill' failed, %v", err)
+		logger.Error(errDetails)
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		errDetails := fmt.Sprintf("Get rows affected for 'update metric task backfill' failed, %v", err)
+		logger.Error(errDetails)
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// 取消指标任务下未完成的回溯
+func (ja *jobAccess) CancelMetricTaskBackfills(taskID string, updateTime int64) error {
+	sqlStr, args, err := sq.Update(METRIC_TASK_BACKFILL_TABLE).
+		Set("f_status", interfaces.BACKFILL_STATUS_CANCELED).
+		Set("f_update_time", updateTime).
+		Where(sq.Eq{
+			"f_task_id": taskID,
+			"f_status":  []string{interfaces.BACKFILL_STATUS_PENDING, interfaces.BACKFILL_STATUS_RUNNING},
+		}).ToSql()
+	if err != nil {
+		errDetails := fmt.Sprintf("Generate 'cancel metric task backfills' sql stmt failed, %v", err)
+		logger.Error(errDetails)
+		return err
+	}
+
+	_, err = ja.db.Exec(sqlStr, args...)
+	if err != nil {
+		errDetails := fmt.Sprintf("Execute sql stmt for 'cancel metric task backfills' failed, %v", err)
+		logger.Error(errDetails)
+		return err
+	}
+
+	return nil
+}
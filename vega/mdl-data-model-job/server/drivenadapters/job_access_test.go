@@ -639,3 +639,243 @@ func Test_JobAccess_ListEventJobs(t *testing.T) {
 		})
 	})
 }
+
+func Test_JobAccess_ListMetricTaskBackfills(t *testing.T) {
+	Convey("test ListMetricTaskBackfills\n", t, func() {
+		ja, smock := MockNewJobAccess()
+
+		task1 := interfaces.MetricTask{
+			TaskID:      "1",
+			TaskName:    "task1",
+			ModuleType:  interfaces.MODULE_TYPE_METRIC_MODEL,
+			ModelID:     "1",
+			MeasureName: "__m.ddd",
+			TimeWindows: []string{"5m", "1h"},
+			Steps:       []string{"5m", "1h"},
+			IndexBase:   "base1",
+			Creator:     interfaces.AccountInfo{ID: "u1", Type: "user"},
+		}
+		backfill1 := interfaces.MetricTaskBackfill{
+			BackfillID:  "b1",
+			TaskID:      "1",
+			Start:       1699336800000,
+			End:         1699423200000,
+			Steps:       []string{"1h"},
+			Concurrency: 2,
+			Status:      interfaces.BACKFILL_STATUS_RUNNING,
+			Checkpoint:  1699340400000,
+		}
+
+		columns := []string{
+			"b.f_backfill_id", "b.f_start", "b.f_end", "b.f_steps", "b.f_concurrency", "b.f_status",
+			"b.f_checkpoint", "b.f_creator", "b.f_creator_type", "task.f_task_id", "task.f_task_name",
+			"task.f_module_type", "task.f_model_id", "model.f_measure_name", "task.f_time_windows",
+			"task.f_steps", "task.f_index_base"}
+		rows := sqlmock.NewRows(columns).AddRow(
+			backfill1.BackfillID, backfill1.Start, backfill1.End, `["1h"]`, backfill1.Concurrency, backfill1.Status,
+			backfill1.Checkpoint, task1.Creator.ID, task1.Creator.Type, task1.TaskID, task1.TaskName,
+			task1.ModuleType, task1.ModelID, task1.MeasureName, `["5m","1h"]`, `["5m","1h"]`, task1.IndexBase)
+
+		sqlStr := fmt.Sprintf("SELECT b.f_backfill_id, b.f_start, b.f_end, b.f_steps, b.f_concurrency, "+
+			"b.f_status, b.f_checkpoint, b.f_creator, b.f_creator_type, task.f_task_id, task.f_task_name, "+
+			"task.f_module_type, task.f_model_id, model.f_measure_name, task.f_time_windows, task.f_steps, "+
+			"task.f_index_base FROM %s as b JOIN %s as task on b.f_task_id = task.f_task_id "+
+			"JOIN %s as model on task.f_model_id = model.f_model_id WHERE b.f_status IN (?,?)",
+			METRIC_TASK_BACKFILL_TABLE, METRIC_TASK_TABLE_NAME, METRIC_MODEL_TABLE_NAME)
+
+		Convey("ListMetricTaskBackfills Success \n", func() {
+			expect := []interfaces.JobInfo{
+				{
+					JobId:      backfill1.BackfillID,
+					JobType:    interfaces.JOB_TYPE_BACKFILL,
+					ModuleType: interfaces.MODULE_TYPE_METRIC_MODEL,
+					MetricTask: &task1,
+					Backfill:   &backfill1,
+				},
+			}
+
+			smock.ExpectQuery(sqlStr).WithArgs(interfaces.BACKFILL_STATUS_PENDING, interfaces.BACKFILL_STATUS_RUNNING).
+				WillReturnRows(rows)
+
+			jobs, err := ja.ListMetricTaskBackfills()
+			So(err, ShouldBeNil)
+			So(jobs, ShouldResemble, expect)
+		})
+
+		Convey("Get failed, caused by the error from squirrel func ToSql", func() {
+			expectedErr := errors.New("some error")
+			patch := ApplyMethodReturn(sq.SelectBuilder{}, "ToSql", "", []interface{}{}, expectedErr)
+			defer patch.Reset()
+
+			jobs, err := ja.ListMetricTaskBackfills()
+			So(jobs, ShouldBeNil)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Get failed, caused by query error", func() {
+			expectedErr := errors.New("some error")
+			smock.ExpectQuery(sqlStr).WithArgs().WillReturnError(expectedErr)
+
+			jobs, err := ja.ListMetricTaskBackfills()
+			So(jobs, ShouldBeNil)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Check failed, caused by the scan error", func() {
+			rowsErr := sqlmock.NewRows([]string{"b.f_backfill_id"}).AddRow("b1")
+			smock.ExpectQuery(sqlStr).WithArgs().WillReturnRows(rowsErr)
+
+			jobs, err := ja.ListMetricTaskBackfills()
+			So(jobs, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Get failed, caused by steps unmarshal error", func() {
+			expectedErr := errors.New("some error")
+			patch := ApplyFuncReturn(json.Unmarshal, expectedErr)
+			defer patch.Reset()
+
+			smock.ExpectQuery(sqlStr).WithArgs().WillReturnRows(rows)
+
+			jobs, err := ja.ListMetricTaskBackfills()
+			So(jobs, ShouldResemble, []interfaces.JobInfo{})
+			So(err, ShouldResemble, expectedErr)
+		})
+	})
+}
+
+func Test_JobAccess_ClaimMetricTaskBackfill(t *testing.T) {
+	Convey("Test ClaimMetricTaskBackfill", t, func() {
+		ja, smock := MockNewJobAccess()
+
+		sqlStr := fmt.Sprintf("UPDATE %s SET f_status = ?, f_update_time = ? "+
+			"WHERE f_backfill_id = ? AND (f_status = ? OR (f_status = ? AND f_update_time < ?))", METRIC_TASK_BACKFILL_TABLE)
+
+		Convey("Claim failed, caused by the error from squirrel func ToSql", func() {
+			expectedErr := errors.New("some error")
+			patch := ApplyMethodReturn(sq.UpdateBuilder{}, "ToSql", "", []interface{}{}, expectedErr)
+			defer patch.Reset()
+
+			claimed, err := ja.ClaimMetricTaskBackfill("b1", 1699340400001, 1699340100001)
+			So(claimed, ShouldBeFalse)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Claim failed, caused by exec sql error", func() {
+			expectedErr := errors.New("some error")
+			smock.ExpectExec(sqlStr).WithArgs().WillReturnError(expectedErr)
+
+			claimed, err := ja.ClaimMetricTaskBackfill("b1", 1699340400001, 1699340100001)
+			So(claimed, ShouldBeFalse)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Claimed by another instance", func() {
+			smock.ExpectExec(sqlStr).WithArgs(interfaces.BACKFILL_STATUS_RUNNING, 1699340400001, "b1",
+				interfaces.BACKFILL_STATUS_PENDING, interfaces.BACKFILL_STATUS_RUNNING, 1699340100001).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			claimed, err := ja.ClaimMetricTaskBackfill("b1", 1699340400001, 1699340100001)
+			So(claimed, ShouldBeFalse)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Claim succeed", func() {
+			smock.ExpectExec(sqlStr).WithArgs(interfaces.BACKFILL_STATUS_RUNNING, 1699340400001, "b1",
+				interfaces.BACKFILL_STATUS_PENDING, interfaces.BACKFILL_STATUS_RUNNING, 1699340100001).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			claimed, err := ja.ClaimMetricTaskBackfill("b1", 1699340400001, 1699340100001)
+			So(claimed, ShouldBeTrue)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func Test_JobAccess_UpdateMetricTaskBackfill(t *testing.T) {
+	Convey("Test UpdateMetricTaskBackfill", t, func() {
+		ja, smock := MockNewJobAccess()
+
+		sqlStr := fmt.Sprintf("UPDATE %s SET f_checkpoint = ?, f_error_details = ?, f_status = ?, f_update_time = ? "+
+			"WHERE f_backfill_id = ? AND f_status = ?", METRIC_TASK_BACKFILL_TABLE)
+
+		backfill := interfaces.MetricTaskBackfill{
+			BackfillID: "b1",
+			Status:     interfaces.BACKFILL_STATUS_RUNNING,
+			Checkpoint: 1699340400000,
+			UpdateTime: 1699340400001,
+		}
+
+		Convey("Update failed, caused by the error from squirrel func ToSql", func() {
+			expectedErr := errors.New("some error")
+			patch := ApplyMethodReturn(sq.UpdateBuilder{}, "ToSql", "", []interface{}{}, expectedErr)
+			defer patch.Reset()
+
+			_, err := ja.UpdateMetricTaskBackfill(backfill)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Update failed, caused by exec sql error", func() {
+			expectedErr := errors.New("some error")
+			smock.ExpectExec(sqlStr).WithArgs().WillReturnError(expectedErr)
+
+			_, err := ja.UpdateMetricTaskBackfill(backfill)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Backfill is no longer running", func() {
+			smock.ExpectExec(sqlStr).WithArgs(backfill.Checkpoint, "", backfill.Status, backfill.UpdateTime,
+				backfill.BackfillID, interfaces.BACKFILL_STATUS_RUNNING).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			running, err := ja.UpdateMetricTaskBackfill(backfill)
+			So(running, ShouldBeFalse)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Update succeed", func() {
+			smock.ExpectExec(sqlStr).WithArgs(backfill.Checkpoint, "", backfill.Status, backfill.UpdateTime,
+				backfill.BackfillID, interfaces.BACKFILL_STATUS_RUNNING).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			running, err := ja.UpdateMetricTaskBackfill(backfill)
+			So(running, ShouldBeTrue)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func Test_JobAccess_CancelMetricTaskBackfills(t *testing.T) {
+	Convey("Test CancelMetricTaskBackfills", t, func() {
+		ja, smock := MockNewJobAccess()
+
+		sqlStr := fmt.Sprintf("UPDATE %s SET f_status = ?, f_update_time = ? "+
+			"WHERE f_status IN (?,?) AND f_task_id = ?", METRIC_TASK_BACKFILL_TABLE)
+
+		Convey("Cancel failed, caused by the error from squirrel func ToSql", func() {
+			expectedErr := errors.New("some error")
+			patch := ApplyMethodReturn(sq.UpdateBuilder{}, "ToSql", "", []interface{}{}, expectedErr)
+			defer patch.Reset()
+
+			err := ja.CancelMetricTaskBackfills("t1", 1699340400001)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Cancel failed, caused by exec sql error", func() {
+			expectedErr := errors.New("some error")
+			smock.ExpectExec(sqlStr).WithArgs().WillReturnError(expectedErr)
+
+			err := ja.CancelMetricTaskBackfills("t1", 1699340400001)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Cancel succeed", func() {
+			smock.ExpectExec(sqlStr).WithArgs(interfaces.BACKFILL_STATUS_CANCELED, 1699340400001,
+				interfaces.BACKFILL_STATUS_PENDING, interfaces.BACKFILL_STATUS_RUNNING, "t1").
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			err := ja.CancelMetricTaskBackfills("t1", 1699340400001)
+			So(err, ShouldBeNil)
+		})
+	})
+}
@@ -33,6 +33,7 @@ const (
 	// 提交的时候提交job_type字段，扫描的时候是metric单独扫描，在扫描metric的时候，构造的jobInfo的job_type赋值为 metric_mdoel
 	JOB_TYPE_STREAM   = "stream"   // 流式订阅的任务类型为 stream
 	JOB_TYPE_SCHEDULE = "schedule" // 定时任务的类型为 schedule
+	JOB_TYPE_BACKFILL = "backfill" // 指标任务历史数据回溯的类型为 backfill
 
 	// 调度类型
 	SCHEDULE_TYPE_FIXED = "FIX_RATE"
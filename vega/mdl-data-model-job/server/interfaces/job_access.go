@@ -27,6 +27,7 @@ type JobInfo struct {
 	MetricTask *MetricTask `josn:"metric_task,omitempty"` // 指标模型、目标模型的持久化任务信息
 	EventTask  *EventTask  `josn:"event_task,omitempty"`  // 事件模型的持久化任务信息
 	Schedule   `json:"schedule,omitempty"`
+	Backfill   *MetricTaskBackfill `json:"backfill,omitempty"` // 指标任务的历史数据回溯信息
 
 	Ticker   *time.Ticker  // 固定频率的计时器
 	StopChan chan struct{} `json:"-"` // 用于停止固定频率任务
@@ -47,4 +48,9 @@ type JobAccess interface {
 	ListMetricJobs() ([]JobInfo, error)
 	ListObjectiveJobs() ([]JobInfo, error)
 	ListEventJobs() ([]JobInfo, error)
+
+	ListMetricTaskBackfills() ([]JobInfo, error)
+	ClaimMetricTaskBackfill(backfillID string, updateTime int64, expiredBefore int64) (bool, error)
+	UpdateMetricTaskBackfill(backfill MetricTaskBackfill) (bool, error)
+	CancelMetricTaskBackfills(taskID string, updateTime int64) error
 }
@@ -5,7 +5,10 @@
 
 package interfaces
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 const (
 	APP_NAME        = "metric-persist-jobs"
@@ -28,6 +31,20 @@ const (
 	MIN_STEP = 300000
 
 	DEFAULT_TIME_ZONE = "Asia/Shanghai"
+
+	// 回溯任务状态
+	BACKFILL_STATUS_PENDING  = "pending"
+	BACKFILL_STATUS_RUNNING  = "running"
+	BACKFILL_STATUS_SUCCESS  = "success"
+	BACKFILL_STATUS_FAILED   = "failed"
+	BACKFILL_STATUS_CANCELED = "canceled"
+
+	// 回溯任务按天切分时间段，每个时间段完成后推进一次 checkpoint
+	BACKFILL_CHUNK_SIZE = 24 * 60 * 60 * 1000
+
+	// 执行中的回溯按心跳间隔刷新更新时间，超过租约时长未刷新的回溯可被其他实例接管
+	BACKFILL_HEARTBEAT_INTERVAL = time.Minute
+	BACKFILL_LEASE_TIMEOUT      = 5 * time.Minute
 )
 
 type MetricTask struct {
@@ -50,6 +67,20 @@ type MetricTask struct {
 	Creator            AccountInfo `json:"creator"`
 }
 
+// 指标任务的历史数据回溯，[start, end) 范围内 checkpoint 之前的时间点均已处理完成
+type MetricTaskBackfill struct {
+	BackfillID   string   `json:"id"`
+	TaskID       string   `json:"task_id"`
+	Start        int64    `json:"start"`
+	End          int64    `json:"end"`
+	Steps        []string `json:"steps"`
+	Concurrency  int      `json:"concurrency"`
+	Status       string   `json:"status"`
+	Checkpoint   int64    `json:"checkpoint"`
+	ErrorDetails string   `json:"error_details"`
+	UpdateTime   int64    `json:"update_time"`
+}
+
 type Schedule struct {
 	Type       string `json:"type"`
 	Expression string `json:"expression"`
@@ -65,4 +96,6 @@ type Filter struct {
 type MetricTaskService interface {
 	// 参数为jobconfig，其内是任务信息
 	MetricTaskExecutor(cxt context.Context, metricTask MetricTask) (msg string)
+	// 按任务的步长重新计算[start, end)内的时间点并发送到kafka，不更新任务的计划时间，返回发送的消息数
+	BackfillMetricTask(ctx context.Context, metricTask MetricTask, start int64, end int64) (int, error)
 }
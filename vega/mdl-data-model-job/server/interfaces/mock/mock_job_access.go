@@ -39,6 +39,35 @@ func (m *MockJobAccess) EXPECT() *MockJobAccessMockRecorder {
 	return m.recorder
 }
 
+// CancelMetricTaskBackfills mocks base method.
+func (m *MockJobAccess) CancelMetricTaskBackfills(taskID string, updateTime int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelMetricTaskBackfills", taskID, updateTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelMetricTaskBackfills indicates an expected call of CancelMetricTaskBackfills.
+func (mr *MockJobAccessMockRecorder) CancelMetricTaskBackfills(taskID, updateTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelMetricTaskBackfills", reflect.TypeOf((*MockJobAccess)(nil).CancelMetricTaskBackfills), taskID, updateTime)
+}
+
+// ClaimMetricTaskBackfill mocks base method.
+func (m *MockJobAccess) ClaimMetricTaskBackfill(backfillID string, updateTime, expiredBefore int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimMetricTaskBackfill", backfillID, updateTime, expiredBefore)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimMetricTaskBackfill indicates an expected call of ClaimMetricTaskBackfill.
+func (mr *MockJobAccessMockRecorder) ClaimMetricTaskBackfill(backfillID, updateTime, expiredBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimMetricTaskBackfill", reflect.TypeOf((*MockJobAccess)(nil).ClaimMetricTaskBackfill), backfillID, updateTime, expiredBefore)
+}
+
 // ListEventJobs mocks base method.
 func (m *MockJobAccess) ListEventJobs() ([]interfaces.JobInfo, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +98,21 @@ func (mr *MockJobAccessMockRecorder) ListMetricJobs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricJobs", reflect.TypeOf((*MockJobAccess)(nil).ListMetricJobs))
 }
 
+// ListMetricTaskBackfills mocks base method.
+func (m *MockJobAccess) ListMetricTaskBackfills() ([]interfaces.JobInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMetricTaskBackfills")
+	ret0, _ := ret[0].([]interfaces.JobInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMetricTaskBackfills indicates an expected call of ListMetricTaskBackfills.
+func (mr *MockJobAccessMockRecorder) ListMetricTaskBackfills() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricTaskBackfills", reflect.TypeOf((*MockJobAccess)(nil).ListMetricTaskBackfills))
+}
+
 // ListObjectiveJobs mocks base method.
 func (m *MockJobAccess) ListObjectiveJobs() ([]interfaces.JobInfo, error) {
 	m.ctrl.T.Helper()
@@ -112,3 +156,18 @@ func (mr *MockJobAccessMockRecorder) UpdateJobStatus(job interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobStatus", reflect.TypeOf((*MockJobAccess)(nil).UpdateJobStatus), job)
 }
+
+// UpdateMetricTaskBackfill mocks base method.
+func (m *MockJobAccess) UpdateMetricTaskBackfill(backfill interfaces.MetricTaskBackfill) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetricTaskBackfill", backfill)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMetricTaskBackfill indicates an expected call of UpdateMetricTaskBackfill.
+func (mr *MockJobAccessMockRecorder) UpdateMetricTaskBackfill(backfill interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricTaskBackfill", reflect.TypeOf((*MockJobAccess)(nil).UpdateMetricTaskBackfill), backfill)
+}
@@ -40,6 +40,21 @@ func (m *MockMetricTaskService) EXPECT() *MockMetricTaskServiceMockRecorder {
 	return m.recorder
 }
 
+// BackfillMetricTask mocks base method.
+func (m *MockMetricTaskService) BackfillMetricTask(ctx context.Context, metricTask interfaces.MetricTask, start, end int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillMetricTask", ctx, metricTask, start, end)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillMetricTask indicates an expected call of BackfillMetricTask.
+func (mr *MockMetricTaskServiceMockRecorder) BackfillMetricTask(ctx, metricTask, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillMetricTask", reflect.TypeOf((*MockMetricTaskService)(nil).BackfillMetricTask), ctx, metricTask, start, end)
+}
+
 // MetricTaskExecutor mocks base method.
 func (m *MockMetricTaskService) MetricTaskExecutor(cxt context.Context, metricTask interfaces.MetricTask) string {
 	m.ctrl.T.Helper()
@@ -0,0 +1,264 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package logics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+
+	"data-model-job/interfaces"
+)
+
+// 心跳间隔，测试中可调小
+var backfillHeartbeatInterval = interfaces.BACKFILL_HEARTBEAT_INTERVAL
+
+// 内存中运行的指标任务回溯
+type backfillJob struct {
+	*interfaces.JobInfo
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// 停止回溯，可重复调用
+func (bfJob *backfillJob) stop() {
+	bfJob.stopOnce.Do(func() {
+		close(bfJob.stopChan)
+	})
+}
+
+// 回溯的一个时间段 [start, end)
+type backfillChunk struct {
+	start int64
+	end   int64
+}
+
+// 加载数据库中未完成的回溯并启动，服务重启后从 checkpoint 处继续
+func (jService *jobService) recoverBackfillJobs() {
+	jobs, err := jService.jAccess.ListMetricTaskBackfills()
+	if err != nil {
+		logger.Errorf("Backfill Recover: list metric task backfills failed, %s", err.Error())
+		return
+	}
+	logger.Debugf("Backfill Recover: there are %d unfinished backfills in db", len(jobs))
+
+	for i := range jobs {
+		bfJob := &backfillJob{
+			JobInfo:  &jobs[i],
+			stopChan: make(chan struct{}),
+		}
+		if _, loaded := jService.backfillMap.LoadOrStore(bfJob.JobId, bfJob); loaded {
+			continue
+		}
+
+		logger.Infof("Backfill Recover: start backfill %s of metric task %s", bfJob.JobId, bfJob.Backfill.TaskID)
+		go jService.runBackfill(bfJob)
+	}
+}
+
+// 停止指标任务下运行中的回溯，并把数据库中未完成的回溯置为已取消。
+// 在其他实例上运行的回溯会在下一次更新进度或心跳时发现已取消而停止
+func (jService *jobService) stopBackfillJobs(taskID string) {
+	if err := jService.jAccess.CancelMetricTaskBackfills(taskID, time.Now().UnixMilli()); err != nil {
+		logger.Errorf("Cancel backfills of metric task %s failed, %s", taskID, err.Error())
+	}
+
+	jService.backfillMap.Range(func(key, value any) bool {
+		bfJob := value.(*backfillJob)
+		if bfJob.Backfill.TaskID == taskID {
+			bfJob.stop()
+			jService.backfillMap.Delete(key)
+			logger.Infof("Stopped backfill %s of metric task %s", bfJob.JobId, taskID)
+		}
+		return true
+	})
+}
+
+// 按天切分回溯范围，以 concurrency 个时间段并发执行。只有当前面的时间段都完成后才推进 checkpoint，
+// 因此 checkpoint 之前的数据一定已写入。任一时间段失败则不再派发新的时间段，回溯置为失败。
+// 多实例部署时先在数据库中抢占回溯，执行期间按心跳刷新更新时间，同一回溯只会在一个实例上执行。
+func (jService *jobService) runBackfill(bfJob *backfillJob) {
+	defer jService.backfillMap.CompareAndDelete(bfJob.JobId, bfJob)
+
+	backfill := *bfJob.Backfill
+	task := *bfJob.MetricTask
+	if len(backfill.Steps) > 0 {
+		task.Steps = backfill.Steps
+	}
+
+	ctx := context.WithValue(context.Background(), rest.XLangKey, rest.DefaultLanguage)
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, interfaces.AccountInfo{
+		ID:   task.Creator.ID,
+		Type: task.Creator.Type,
+	})
+
+	chunks := splitBackfillRange(max(backfill.Checkpoint, backfill.Start), backfill.End, interfaces.BACKFILL_CHUNK_SIZE)
+
+	now := time.Now()
+	claimed, err := jService.jAccess.ClaimMetricTaskBackfill(backfill.BackfillID, now.UnixMilli(),
+		now.Add(-interfaces.BACKFILL_LEASE_TIMEOUT).UnixMilli())
+	if err != nil {
+		// 下一轮恢复时重试
+		logger.Errorf("Claim backfill %s of metric task %s failed, %s", backfill.BackfillID, task.TaskID, err.Error())
+		return
+	}
+	if !claimed {
+		logger.Debugf("Backfill %s of metric task %s is running on another instance", backfill.BackfillID, task.TaskID)
+		return
+	}
+	backfill.Status = interfaces.BACKFILL_STATUS_RUNNING
+	backfill.UpdateTime = now.UnixMilli()
+
+	concurrency := backfill.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		runErr   error
+		stopped  bool
+		done     = make([]bool, len(chunks))
+		finished = 0
+		sem      = make(chan struct{}, concurrency)
+	)
+
+	heartbeat := time.NewTicker(backfillHeartbeatInterval)
+	heartbeatDone := make(chan struct{})
+	defer func() {
+		close(heartbeatDone)
+		heartbeat.Stop()
+	}()
+	go jService.heartbeatBackfill(bfJob, &backfill, &mu, heartbeat.C, heartbeatDone)
+
+	for i, chunk := range chunks {
+		// 优先响应停止，再等待空闲的并发槽位
+		select {
+		case <-bfJob.stopChan:
+			stopped = true
+		default:
+			select {
+			case <-bfJob.stopChan:
+				stopped = true
+			case sem <- struct{}{}:
+			}
+		}
+		if stopped {
+			break
+		}
+
+		// 拿到槽位后再检查，避免已有时间段失败后继续派发
+		mu.Lock()
+		failed := runErr != nil
+		mu.Unlock()
+		if failed {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, chunk backfillChunk) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			msgTotal, err := jService.mtService.BackfillMetricTask(ctx, task, chunk.start, chunk.end)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				logger.Errorf("Backfill %s of metric task %s failed in [%d, %d), %s",
+					backfill.BackfillID, task.TaskID, chunk.start, chunk.end, err.Error())
+				if runErr == nil {
+					runErr = err
+				}
+				return
+			}
+			logger.Debugf("Backfill %s of metric task %s finished [%d, %d), %d messages sent",
+				backfill.BackfillID, task.TaskID, chunk.start, chunk.end, msgTotal)
+
+			done[i] = true
+			advanced := false
+			for finished < len(chunks) && done[finished] {
+				finished++
+				advanced = true
+			}
+			if advanced {
+				backfill.Checkpoint = chunks[finished-1].end
+				jService.updateBackfill(bfJob, &backfill)
+			}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	select {
+	case <-bfJob.stopChan:
+		stopped = true
+	default:
+	}
+	if stopped {
+		logger.Infof("Backfill %s of metric task %s stopped at checkpoint %d", backfill.BackfillID, task.TaskID, backfill.Checkpoint)
+		return
+	}
+
+	if runErr != nil {
+		backfill.Status = interfaces.BACKFILL_STATUS_FAILED
+		backfill.ErrorDetails = runErr.Error()
+	} else {
+		backfill.Status = interfaces.BACKFILL_STATUS_SUCCESS
+		backfill.Checkpoint = backfill.End
+		backfill.ErrorDetails = ""
+	}
+	mu.Lock()
+	jService.updateBackfill(bfJob, &backfill)
+	mu.Unlock()
+}
+
+// 按心跳间隔刷新回溯的更新时间，避免执行耗时较长的时间段时租约过期被其他实例接管
+func (jService *jobService) heartbeatBackfill(bfJob *backfillJob, backfill *interfaces.MetricTaskBackfill,
+	mu *sync.Mutex, tick <-chan time.Time, done chan struct{}) {
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-bfJob.stopChan:
+			return
+		case <-tick:
+			mu.Lock()
+			jService.updateBackfill(bfJob, backfill)
+			mu.Unlock()
+		}
+	}
+}
+
+// 更新数据库中回溯的状态和 checkpoint。回溯已被删除或取消时停止回溯
+func (jService *jobService) updateBackfill(bfJob *backfillJob, backfill *interfaces.MetricTaskBackfill) {
+	backfill.UpdateTime = time.Now().UnixMilli()
+	running, err := jService.jAccess.UpdateMetricTaskBackfill(*backfill)
+	if err != nil {
+		logger.Errorf("Update backfill %s to status %s, checkpoint %d failed, %s",
+			backfill.BackfillID, backfill.Status, backfill.Checkpoint, err.Error())
+		return
+	}
+	if !running {
+		logger.Infof("Backfill %s is no longer running in db, it may be canceled or deleted", backfill.BackfillID)
+		bfJob.stop()
+	}
+}
+
+// 把 [start, end) 切分为不超过 size 的连续时间段
+func splitBackfillRange(start, end, size int64) []backfillChunk {
+	chunks := make([]backfillChunk, 0)
+	for s := start; s < end; s += size {
+		chunks = append(chunks, backfillChunk{start: s, end: min(s+size, end)})
+	}
+	return chunks
+}
@@ -0,0 +1,313 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package logics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"data-model-job/interfaces"
+	dmock "data-model-job/interfaces/mock"
+)
+
+func mockBackfillJob(concurrency int) *backfillJob {
+	return &backfillJob{
+		JobInfo: &interfaces.JobInfo{
+			JobId:      "b1",
+			JobType:    interfaces.JOB_TYPE_BACKFILL,
+			ModuleType: interfaces.MODULE_TYPE_METRIC_MODEL,
+			MetricTask: &interfaces.MetricTask{
+				TaskID: "t1",
+				Steps:  []string{"5m", "1h"},
+			},
+			Backfill: &interfaces.MetricTaskBackfill{
+				BackfillID:  "b1",
+				TaskID:      "t1",
+				Start:       0,
+				End:         3 * interfaces.BACKFILL_CHUNK_SIZE,
+				Steps:       []string{"1h"},
+				Concurrency: concurrency,
+				Status:      interfaces.BACKFILL_STATUS_PENDING,
+			},
+		},
+		stopChan: make(chan struct{}),
+	}
+}
+
+func Test_Backfill_splitBackfillRange(t *testing.T) {
+	Convey("Test splitBackfillRange", t, func() {
+		Convey("split into chunks, the last one is truncated", func() {
+			chunks := splitBackfillRange(0, 25, 10)
+			So(chunks, ShouldResemble, []backfillChunk{{0, 10}, {10, 20}, {20, 25}})
+		})
+
+		Convey("empty range", func() {
+			So(len(splitBackfillRange(10, 10, 10)), ShouldEqual, 0)
+			So(len(splitBackfillRange(20, 10, 10)), ShouldEqual, 0)
+		})
+	})
+}
+
+func Test_Backfill_runBackfill(t *testing.T) {
+	Convey("Test runBackfill", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		jaMock := dmock.NewMockJobAccess(mockCtrl)
+		mtsMock := dmock.NewMockMetricTaskService(mockCtrl)
+		jsMock := MockNewJobService(jaMock, nil, nil)
+		jsMock.mtService = mtsMock
+
+		var (
+			mu      sync.Mutex
+			updates []interfaces.MetricTaskBackfill
+		)
+		jaMock.EXPECT().ClaimMetricTaskBackfill("b1", gomock.Any(), gomock.Any()).AnyTimes().Return(true, nil)
+		jaMock.EXPECT().UpdateMetricTaskBackfill(gomock.Any()).AnyTimes().
+			DoAndReturn(func(backfill interfaces.MetricTaskBackfill) (bool, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				updates = append(updates, backfill)
+				return true, nil
+			})
+
+		Convey("success, checkpoint advances chunk by chunk", func() {
+			bfJob := mockBackfillJob(2)
+			jsMock.backfillMap.Store(bfJob.JobId, bfJob)
+
+			var steps []string
+			mtsMock.EXPECT().BackfillMetricTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3).
+				DoAndReturn(func(ctx context.Context, task interfaces.MetricTask, start, end int64) (int, error) {
+					mu.Lock()
+					defer mu.Unlock()
+					steps = task.Steps
+					return 1, nil
+				})
+
+			jsMock.runBackfill(bfJob)
+
+			// 使用回溯指定的步长
+			So(steps, ShouldResemble, []string{"1h"})
+
+			So(updates[0].Status, ShouldEqual, interfaces.BACKFILL_STATUS_RUNNING)
+			last := updates[len(updates)-1]
+			So(last.Status, ShouldEqual, interfaces.BACKFILL_STATUS_SUCCESS)
+			So(last.Checkpoint, ShouldEqual, int64(3*interfaces.BACKFILL_CHUNK_SIZE))
+
+			// checkpoint 单调递增
+			for i := 1; i < len(updates); i++ {
+				So(updates[i].Checkpoint, ShouldBeGreaterThanOrEqualTo, updates[i-1].Checkpoint)
+			}
+
+			_, ok := jsMock.backfillMap.Load(bfJob.JobId)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("resume from checkpoint", func() {
+			bfJob := mockBackfillJob(1)
+			bfJob.Backfill.Checkpoint = 2 * interfaces.BACKFILL_CHUNK_SIZE
+			bfJob.Backfill.Status = interfaces.BACKFILL_STATUS_RUNNING
+
+			mtsMock.EXPECT().BackfillMetricTask(gomock.Any(), gomock.Any(),
+				int64(2*interfaces.BACKFILL_CHUNK_SIZE), int64(3*interfaces.BACKFILL_CHUNK_SIZE)).Return(1, nil)
+
+			jsMock.runBackfill(bfJob)
+
+			last := updates[len(updates)-1]
+			So(last.Status, ShouldEqual, interfaces.BACKFILL_STATUS_SUCCESS)
+		})
+
+		Convey("failed, checkpoint stays before the failed chunk", func() {
+			bfJob := mockBackfillJob(1)
+
+			gomock.InOrder(
+				mtsMock.EXPECT().BackfillMetricTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil),
+				mtsMock.EXPECT().BackfillMetricTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(0, errors.New("uniquery error")),
+			)
+
+			jsMock.runBackfill(bfJob)
+
+			last := updates[len(updates)-1]
+			So(last.Status, ShouldEqual, interfaces.BACKFILL_STATUS_FAILED)
+			So(last.ErrorDetails, ShouldEqual, "uniquery error")
+			So(last.Checkpoint, ShouldEqual, int64(interfaces.BACKFILL_CHUNK_SIZE))
+		})
+
+		Convey("stopped before running", func() {
+			bfJob := mockBackfillJob(1)
+			close(bfJob.stopChan)
+
+			jsMock.runBackfill(bfJob)
+
+			// 停止后不再更新状态，由停止方取消数据库中的回溯
+			So(len(updates), ShouldEqual, 0)
+		})
+	})
+}
+
+func Test_Backfill_runBackfill_Claim(t *testing.T) {
+	Convey("Test runBackfill claims the backfill before running", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		jaMock := dmock.NewMockJobAccess(mockCtrl)
+		mtsMock := dmock.NewMockMetricTaskService(mockCtrl)
+		jsMock := MockNewJobService(jaMock, nil, nil)
+		jsMock.mtService = mtsMock
+
+		Convey("claim failed", func() {
+			jaMock.EXPECT().ClaimMetricTaskBackfill("b1", gomock.Any(), gomock.Any()).Return(false, errors.New("db error"))
+
+			jsMock.runBackfill(mockBackfillJob(1))
+		})
+
+		Convey("running on another instance", func() {
+			jaMock.EXPECT().ClaimMetricTaskBackfill("b1", gomock.Any(), gomock.Any()).Return(false, nil)
+
+			bfJob := mockBackfillJob(1)
+			jsMock.backfillMap.Store(bfJob.JobId, bfJob)
+
+			jsMock.runBackfill(bfJob)
+
+			_, ok := jsMock.backfillMap.Load(bfJob.JobId)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("lease expired before now", func() {
+			var expiredBefore int64
+			jaMock.EXPECT().ClaimMetricTaskBackfill("b1", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(backfillID string, updateTime, before int64) (bool, error) {
+					expiredBefore = before
+					So(updateTime-before, ShouldEqual, interfaces.BACKFILL_LEASE_TIMEOUT.Milliseconds())
+					return false, nil
+				})
+
+			jsMock.runBackfill(mockBackfillJob(1))
+			So(expiredBefore, ShouldBeGreaterThan, 0)
+		})
+	})
+}
+
+func Test_Backfill_runBackfill_Canceled(t *testing.T) {
+	Convey("Test runBackfill stops when the backfill is canceled in db", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		jaMock := dmock.NewMockJobAccess(mockCtrl)
+		mtsMock := dmock.NewMockMetricTaskService(mockCtrl)
+		jsMock := MockNewJobService(jaMock, nil, nil)
+		jsMock.mtService = mtsMock
+
+		jaMock.EXPECT().ClaimMetricTaskBackfill("b1", gomock.Any(), gomock.Any()).Return(true, nil)
+
+		Convey("checkpoint update finds the backfill canceled", func() {
+			// 第一个时间段完成后发现已取消，不再派发后续时间段
+			mtsMock.EXPECT().BackfillMetricTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
+			jaMock.EXPECT().UpdateMetricTaskBackfill(gomock.Any()).Return(false, nil)
+
+			bfJob := mockBackfillJob(1)
+			jsMock.runBackfill(bfJob)
+
+			_, open := <-bfJob.stopChan
+			So(open, ShouldBeFalse)
+		})
+
+		Convey("heartbeat finds the backfill canceled", func() {
+			interval := backfillHeartbeatInterval
+			backfillHeartbeatInterval = 10 * time.Millisecond
+			defer func() { backfillHeartbeatInterval = interval }()
+
+			bfJob := mockBackfillJob(1)
+			release := make(chan struct{})
+			mtsMock.EXPECT().BackfillMetricTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, task interfaces.MetricTask, start, end int64) (int, error) {
+					<-bfJob.stopChan
+					close(release)
+					return 1, nil
+				})
+			jaMock.EXPECT().UpdateMetricTaskBackfill(gomock.Any()).AnyTimes().Return(false, nil)
+
+			jsMock.runBackfill(bfJob)
+
+			_, open := <-release
+			So(open, ShouldBeFalse)
+		})
+	})
+}
+
+func Test_Backfill_recoverAndStopBackfillJobs(t *testing.T) {
+	Convey("Test recoverBackfillJobs and stopBackfillJobs", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		jaMock := dmock.NewMockJobAccess(mockCtrl)
+		jsMock := MockNewJobService(jaMock, nil, nil)
+		jaMock.EXPECT().CancelMetricTaskBackfills(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+		Convey("list backfills failed", func() {
+			jaMock.EXPECT().ListMetricTaskBackfills().Return(nil, errors.New("error"))
+
+			jsMock.recoverBackfillJobs()
+
+			count := 0
+			jsMock.backfillMap.Range(func(key, value any) bool {
+				count++
+				return true
+			})
+			So(count, ShouldEqual, 0)
+		})
+
+		Convey("backfills already running are skipped, then stopped by task id", func() {
+			running := mockBackfillJob(1)
+			jsMock.backfillMap.Store(running.JobId, running)
+
+			jaMock.EXPECT().ListMetricTaskBackfills().Return([]interfaces.JobInfo{*running.JobInfo}, nil)
+
+			jsMock.recoverBackfillJobs()
+
+			val, ok := jsMock.backfillMap.Load(running.JobId)
+			So(ok, ShouldBeTrue)
+			So(val, ShouldEqual, running)
+
+			jsMock.stopBackfillJobs("t2")
+			_, ok = jsMock.backfillMap.Load(running.JobId)
+			So(ok, ShouldBeTrue)
+
+			jsMock.stopBackfillJobs("t1")
+			_, ok = jsMock.backfillMap.Load(running.JobId)
+			So(ok, ShouldBeFalse)
+
+			_, open := <-running.stopChan
+			So(open, ShouldBeFalse)
+
+			// 重复停止不会 panic
+			So(func() { running.stop() }, ShouldNotPanic)
+		})
+
+		Convey("cancel backfills in db failed, running backfills are still stopped", func() {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			jaMock := dmock.NewMockJobAccess(mockCtrl)
+			jsMock := MockNewJobService(jaMock, nil, nil)
+			jaMock.EXPECT().CancelMetricTaskBackfills("t1", gomock.Any()).Return(errors.New("db error"))
+
+			running := mockBackfillJob(1)
+			jsMock.backfillMap.Store(running.JobId, running)
+
+			jsMock.stopBackfillJobs("t1")
+			_, ok := jsMock.backfillMap.Load(running.JobId)
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
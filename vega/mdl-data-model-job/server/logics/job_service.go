@@ -53,6 +53,8 @@ type jobService struct {
 	mtService  interfaces.MetricTaskService
 	jobMap     sync.Map
 	scheduler  *Scheduler
+	// 运行中的指标任务回溯，key 为 backfill id
+	backfillMap sync.Map
 	// job发生错误后将err写入errChan
 	errChan chan jobError
 }
@@ -124,6 +126,12 @@ func (jService *jobService) StartJob(ctx context.Context, jobInfo *interfaces.Jo
 		return jService.addScheduleJob(jobInfo)
 	}
 
+	if jobInfo.JobType == interfaces.JOB_TYPE_BACKFILL {
+		// 指标任务的回溯，data-model 只提交回溯id，任务详情以数据库为准
+		jService.recoverBackfillJobs()
+		return nil
+	}
+
 	if _, ok := jService.jobMap.Load(jobInfo.JobId); ok {
 		logger.Errorf("Job %s already exists in memory!", jobInfo.JobId)
 		return fmt.Errorf("job already exists in memory")
@@ -207,6 +215,9 @@ func (jService *jobService) UpdateJob(ctx context.Context, jobInfo *interfaces.J
 
 // 停止job
 func (jService *jobService) StopJob(ctx context.Context, jobId string) error {
+	// 指标任务删除时，停止该任务下运行中的回溯
+	jService.stopBackfillJobs(jobId)
+
 	// 指标模型提交的jobInfo中包含了持久化task表中的内容和信息，所以直接把JobConfig反序列化为MetricTask.
 	if jService.scheduler != nil {
 		task, exists := jService.scheduler.jobs[jobId]
@@ -263,6 +274,7 @@ func (jService *jobService) WatchJob(interval time.Duration) {
 
 		// 恢复或同步指标类（指标、目标）的任务
 		jService.recoverMetricJobs()
+		jService.recoverBackfillJobs()
 
 		// 恢复或同步事件的定时任务
 		jService.recoverEventJobs()
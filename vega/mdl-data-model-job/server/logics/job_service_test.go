@@ -215,6 +215,7 @@ func Test_JobService_StopJob(t *testing.T) {
 		kaMock := dmock.NewMockKafkaAccess(mockCtl)
 		dvsMock := dmock.NewMockDataViewService(mockCtl)
 		jsMock := MockNewJobService(jaMock, kaMock, dvsMock)
+		jaMock.EXPECT().CancelMetricTaskBackfills(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
 
 		jobId := "1a"
 		jobInfo := &interfaces.JobInfo{
@@ -677,6 +678,7 @@ func Test_JobService_recoverJobs(t *testing.T) {
 		kaMock := dmock.NewMockKafkaAccess(mockCtl)
 		dvsMock := dmock.NewMockDataViewService(mockCtl)
 		jsMock := MockNewJobService(jaMock, kaMock, dvsMock)
+		jaMock.EXPECT().CancelMetricTaskBackfills(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
 
 		jobsToCreate := []*interfaces.JobInfo{
 			{
@@ -120,78 +120,25 @@ func (mtService *metricTaskService) executTask(ctx context.Context, task interfa
 		return "", fmt.Errorf("指标模型任务的计划时间【%v】不合法, 期望为计划时间长度为1", taskPlanTime)
 	}
 
-	steps := make([]int64, 0)
-	for _, stepStr := range task.Steps {
-		// 解析步长为具体毫秒
-		step, err := common.ParseDuration(stepStr, common.DurationDayHourMinuteRE, true)
-		if err != nil {
-			logger.Errorf("Failed to parse schedule duration, err: %s", err.Error())
-			return "", fmt.Errorf("failed to parse schedule duration, err: %s", err.Error())
-		}
-		steps = append(steps, int64(step/(time.Millisecond/time.Nanosecond)))
+	steps, err := parseTaskSteps(task.Steps)
+	if err != nil {
+		return "", err
 	}
 
 	ts := time.Now().UnixNano() / int64(time.Millisecond/time.Nanosecond)
 	// 所以对plan_time按任务的steps的5m修正，对now按步长修正
 	fixedPlanTime := int64(math.Floor(float64(taskPlanTime)/float64(interfaces.MIN_STEP))) * interfaces.MIN_STEP
-	timeZone := os.Getenv("TZ")
-	if timeZone == "" {
-		timeZone = interfaces.DEFAULT_TIME_ZONE
-	}
-	location, err := time.LoadLocation(timeZone)
+	offset, err := timeZoneOffset()
 	if err != nil {
-		// 记录异常日志
-		logger.Errorf("LoadLocation error: %s", err.Error())
-		return "", fmt.Errorf("LoadLocation error: %s", err.Error())
+		return "", err
 	}
-	_, offset := time.Now().In(location).Zone()
 
 	msgTotal := 0
 	var planTime int64
 	for planTime = fixedPlanTime; planTime <= ts; {
-		messages := make([]*kafka.Message, 0)
-		for i, stepStr := range task.Steps {
-			// 判断当前步长点是否能被step整除。 用plan_time偏移到utc时间的除。
-			p := planTime + int64(offset*1000)
-			if p%steps[i] != 0 {
-				// 跳过，遍历下一个step
-				logger.Debugf("跳过：指标模型任务[%s]当前计算时间点plantime:%d. 当前时间now:%d, 执行步长为%s", task.TaskID, planTime, ts, stepStr)
-				continue
-			}
-
-			// 确定 look_back_delta。因为在管理端，语言是 promql 时，校验了时间窗口应为空
-			lookBackDeltas := make([]string, 0)
-			if len(task.TimeWindows) == 0 {
-				// promql 时，用任务的 step 作为即时查询的 look_back_delta
-				lookBackDeltas = append(lookBackDeltas, stepStr)
-			} else {
-				// dsl 时，用时间窗口 timw_window 作为即时查询的 look_back_delta
-				lookBackDeltas = append(lookBackDeltas, task.TimeWindows...)
-			}
-
-			// 遍历 look_back_delta，查询数据并组装kafka message
-			for _, lookBackDelta := range lookBackDeltas {
-				// 请求uniquery，即时查询，
-
-				metricData, err := mtService.uAccess.GetMetricModelData(ctx, task.ModelID, interfaces.MetricModelQuery{
-					IsInstantQuery: true,
-					Time:           planTime,
-					LookBackDelta:  lookBackDelta,
-				})
-				logger.Debugf("指标模型任务[%s], 当前step【%s】查询的步长点为【%d】", task.TaskID, stepStr, planTime)
-				if err != nil {
-					return "", fmt.Errorf("指标模型任务[%s], 查询参数: time=%d,look_back_delta=%s, 获取指标数据失败[%s]. ", task.TaskID, planTime, lookBackDelta, err.Error())
-				}
-
-				//  组装kafka messages
-				if len(metricData.Datas) > 0 {
-					// 3.2.数据转换为 metric 数据格式. category, 任务名称，时间窗口
-					err = mtService.metricDataTransferToMessage(planTime-steps[i], metricData, lookBackDelta, task, stepStr, indexBases[0], &messages)
-					if err != nil {
-						return "", fmt.Errorf("指标模型任务[%s], 时间窗口[%s]把数据转换为 kafka massage 失败[%s]. ", task.TaskID, lookBackDelta, err.Error())
-					}
-				}
-			}
+		messages, err := mtService.collectMetricMessages(ctx, task, steps, offset, planTime, ts, indexBases[0])
+		if err != nil {
+			return "", err
 		}
 		planTime += interfaces.MIN_STEP
 
@@ -219,6 +166,128 @@ func (mtService *metricTaskService) executTask(ctx context.Context, task interfa
 	return fmt.Sprintf("service: 指标模型任务[%s]执行完成. 共发送[%d]条数据到kafka。", task.TaskID, msgTotal), nil
 }
 
+// 计算单个时间点上各步长的指标数据，并组装成 kafka messages
+func (mtService *metricTaskService) collectMetricMessages(ctx context.Context, task interfaces.MetricTask, steps []int64,
+	offset int, planTime int64, ts int64, indexBase interfaces.IndexBase) ([]*kafka.Message, error) {
+
+	messages := make([]*kafka.Message, 0)
+	for i, stepStr := range task.Steps {
+		// 判断当前步长点是否能被step整除。 用plan_time偏移到utc时间的除。
+		p := planTime + int64(offset*1000)
+		if p%steps[i] != 0 {
+			// 跳过，遍历下一个step
+			logger.Debugf("跳过：指标模型任务[%s]当前计算时间点plantime:%d. 当前时间now:%d, 执行步长为%s", task.TaskID, planTime, ts, stepStr)
+			continue
+		}
+
+		// 确定 look_back_delta。因为在管理端，语言是 promql 时，校验了时间窗口应为空
+		lookBackDeltas := make([]string, 0)
+		if len(task.TimeWindows) == 0 {
+			// promql 时，用任务的 step 作为即时查询的 look_back_delta
+			lookBackDeltas = append(lookBackDeltas, stepStr)
+		} else {
+			// dsl 时，用时间窗口 timw_window 作为即时查询的 look_back_delta
+			lookBackDeltas = append(lookBackDeltas, task.TimeWindows...)
+		}
+
+		// 遍历 look_back_delta，查询数据并组装kafka message
+		for _, lookBackDelta := range lookBackDeltas {
+			// 请求uniquery，即时查询，
+
+			metricData, err := mtService.uAccess.GetMetricModelData(ctx, task.ModelID, interfaces.MetricModelQuery{
+				IsInstantQuery: true,
+				Time:           planTime,
+				LookBackDelta:  lookBackDelta,
+			})
+			logger.Debugf("指标模型任务[%s], 当前step【%s】查询的步长点为【%d】", task.TaskID, stepStr, planTime)
+			if err != nil {
+				return nil, fmt.Errorf("指标模型任务[%s], 查询参数: time=%d,look_back_delta=%s, 获取指标数据失败[%s]. ", task.TaskID, planTime, lookBackDelta, err.Error())
+			}
+
+			//  组装kafka messages
+			if len(metricData.Datas) > 0 {
+				// 3.2.数据转换为 metric 数据格式. category, 任务名称，时间窗口
+				err = mtService.metricDataTransferToMessage(planTime-steps[i], metricData, lookBackDelta, task, stepStr, indexBase, &messages)
+				if err != nil {
+					return nil, fmt.Errorf("指标模型任务[%s], 时间窗口[%s]把数据转换为 kafka massage 失败[%s]. ", task.TaskID, lookBackDelta, err.Error())
+				}
+			}
+		}
+	}
+	return messages, nil
+}
+
+// 按任务的步长重新计算[start, end)内的时间点，每个时间点提交一次kafka。不更新任务的计划时间，
+// 写入的文档 id 由时间和序列决定，重复计算同一时间点是幂等的
+func (mtService *metricTaskService) BackfillMetricTask(ctx context.Context, task interfaces.MetricTask, start int64, end int64) (int, error) {
+	indexBases, err := mtService.iBAccess.GetIndexBasesByTypes(ctx, []string{task.IndexBase})
+	if err != nil {
+		return 0, err
+	}
+	if len(indexBases) != 1 {
+		return 0, fmt.Errorf("指标模型任务的索引库类型[%s]对应的索引库数量不等于1,为[%d]", task.IndexBase, len(indexBases))
+	}
+
+	steps, err := parseTaskSteps(task.Steps)
+	if err != nil {
+		return 0, err
+	}
+
+	offset, err := timeZoneOffset()
+	if err != nil {
+		return 0, err
+	}
+
+	msgTotal := 0
+	fixedStart := int64(math.Ceil(float64(start)/float64(interfaces.MIN_STEP))) * interfaces.MIN_STEP
+	for planTime := fixedStart; planTime < end; planTime += interfaces.MIN_STEP {
+		messages, err := mtService.collectMetricMessages(ctx, task, steps, offset, planTime, end, indexBases[0])
+		if err != nil {
+			return msgTotal, err
+		}
+
+		if len(messages) > 0 {
+			err = mtService.flushToKafka(task, messages)
+			if err != nil {
+				return msgTotal, fmt.Errorf("指标模型任务[%s], 把回溯数据发送到 kafka 失败[%s]. ", task.TaskID, err.Error())
+			}
+			msgTotal += len(messages)
+		}
+	}
+
+	logger.Debugf("service: 指标模型任务[%s]回溯[%d, %d)完成. 共发送[%d]条数据到kafka。", task.TaskID, start, end, msgTotal)
+	return msgTotal, nil
+}
+
+// 解析任务的步长为毫秒
+func parseTaskSteps(stepStrs []string) ([]int64, error) {
+	steps := make([]int64, 0, len(stepStrs))
+	for _, stepStr := range stepStrs {
+		step, err := common.ParseDuration(stepStr, common.DurationDayHourMinuteRE, true)
+		if err != nil {
+			logger.Errorf("Failed to parse schedule duration, err: %s", err.Error())
+			return nil, fmt.Errorf("failed to parse schedule duration, err: %s", err.Error())
+		}
+		steps = append(steps, int64(step/(time.Millisecond/time.Nanosecond)))
+	}
+	return steps, nil
+}
+
+// 获取 TZ 时区相对 utc 的偏移秒数，步长点按该时区对齐
+func timeZoneOffset() (int, error) {
+	timeZone := os.Getenv("TZ")
+	if timeZone == "" {
+		timeZone = interfaces.DEFAULT_TIME_ZONE
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		logger.Errorf("LoadLocation error: %s", err.Error())
+		return 0, fmt.Errorf("LoadLocation error: %s", err.Error())
+	}
+	_, offset := time.Now().In(location).Zone()
+	return offset, nil
+}
+
 func (mtService *metricTaskService) executObjectiveTask(ctx context.Context, task interfaces.MetricTask) (string, error) {
 	// 2. 请求 index mgnt 获取索引库的data type
 	indexBases, err := mtService.iBAccess.GetIndexBasesByTypes(ctx, []string{task.IndexBase})
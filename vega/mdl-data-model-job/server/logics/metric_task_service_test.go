@@ -380,6 +380,84 @@ func Test_MetricTaskService_MetricTaskExecutor(t *testing.T) {
 	})
 }
 
+func Test_MetricTaskService_BackfillMetricTask(t *testing.T) {
+	Convey("Test BackfillMetricTask", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		mmaMock := dmock.NewMockMetricModelAccess(mockCtrl)
+		uaMock := dmock.NewMockUniqueryAccess(mockCtrl)
+		ibaMock := dmock.NewMockIndexBaseAccess(mockCtrl)
+		kaMock := dmock.NewMockKafkaAccess(mockCtrl)
+		mtsMock := MockNewMetricTaskService(appSetting, mmaMock, uaMock, kaMock, ibaMock)
+
+		producer := &kafka.Producer{}
+		patchF2 := ApplyMethod(reflect.TypeOf(producer), "Close",
+			func(*kafka.Producer) {
+				// nothing to do
+			},
+		)
+		defer patchF2.Reset()
+
+		indexBases := []interfaces.IndexBase{{SimpleIndexBase: interfaces.SimpleIndexBase{BaseType: "1"}, DataType: "1"}}
+		// 一小时内共 12 个 5m 步长点
+		start := int64(1699336800000)
+		end := start + 3600000
+
+		Convey("failed, cause by GetIndexBasesByTypes error", func() {
+			ibaMock.EXPECT().GetIndexBasesByTypes(gomock.Any(), gomock.Any()).
+				Return([]interfaces.IndexBase{}, fmt.Errorf("error"))
+
+			total, err := mtsMock.BackfillMetricTask(testCtx, task, start, end)
+			So(total, ShouldEqual, 0)
+			So(err, ShouldResemble, fmt.Errorf("error"))
+		})
+
+		Convey("failed, cause by invalid step", func() {
+			taskTmp := task
+			taskTmp.Steps = []string{"5x"}
+			ibaMock.EXPECT().GetIndexBasesByTypes(gomock.Any(), gomock.Any()).Return(indexBases, nil)
+
+			_, err := mtsMock.BackfillMetricTask(testCtx, taskTmp, start, end)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("failed, cause by DoProduceMsgToKafka error", func() {
+			ibaMock.EXPECT().GetIndexBasesByTypes(gomock.Any(), gomock.Any()).Return(indexBases, nil)
+			uaMock.EXPECT().GetMetricModelData(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+				Return(uniqueryData, nil)
+			kaMock.EXPECT().NewTrxProducer(gomock.Any()).Return(producer, nil)
+			kaMock.EXPECT().DoProduceMsgToKafka(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error1"))
+
+			total, err := mtsMock.BackfillMetricTask(testCtx, task, start, end)
+			So(total, ShouldEqual, 0)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("success, plan time is not updated", func() {
+			ibaMock.EXPECT().GetIndexBasesByTypes(gomock.Any(), gomock.Any()).Return(indexBases, nil)
+			// 两个时间窗口，每个步长点查询两次
+			uaMock.EXPECT().GetMetricModelData(gomock.Any(), gomock.Any(), gomock.Any()).Times(24).
+				Return(uniqueryData, nil)
+			kaMock.EXPECT().NewTrxProducer(gomock.Any()).Times(12).Return(producer, nil)
+			kaMock.EXPECT().DoProduceMsgToKafka(gomock.Any(), gomock.Any()).Times(12).Return(nil)
+
+			total, err := mtsMock.BackfillMetricTask(testCtx, task, start, end)
+			So(err, ShouldBeNil)
+			So(total, ShouldBeGreaterThan, 0)
+		})
+
+		Convey("success with empty range", func() {
+			ibaMock.EXPECT().GetIndexBasesByTypes(gomock.Any(), gomock.Any()).Return(indexBases, nil)
+
+			total, err := mtsMock.BackfillMetricTask(testCtx, task, end, end)
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 0)
+		})
+	})
+}
+
 func Test_MetricTaskService_ExecutObjectiveTask(t *testing.T) {
 	Convey("Test ExecutObjectiveTask", t, func() {
 		mockCtrl := gomock.NewController(t)
@@ -0,0 +1,25 @@
+-- Copyright The kweaver.ai Authors.
+--
+-- Licensed under the Apache License, Version 2.0.
+-- See the LICENSE file in the project root for details.
+
+SET SCHEMA adp;
+
+CREATE TABLE IF NOT EXISTS t_metric_model_task_backfill (
+  f_backfill_id VARCHAR(40 CHAR) NOT NULL DEFAULT '',
+  f_task_id VARCHAR(40 CHAR) NOT NULL,
+  f_start BIGINT NOT NULL,
+  f_end BIGINT NOT NULL,
+  f_steps VARCHAR(255 CHAR) NOT NULL DEFAULT '[]',
+  f_concurrency INT NOT NULL DEFAULT 1,
+  f_status VARCHAR(20 CHAR) NOT NULL DEFAULT '',
+  f_checkpoint BIGINT NOT NULL DEFAULT 0,
+  f_error_details TEXT DEFAULT NULL,
+  f_create_time BIGINT NOT NULL DEFAULT 0,
+  f_update_time BIGINT NOT NULL DEFAULT 0,
+  f_creator VARCHAR(40 CHAR) NOT NULL DEFAULT '',
+  f_creator_type VARCHAR(20 CHAR) NOT NULL DEFAULT '',
+  CLUSTER PRIMARY KEY (f_backfill_id)
+);
+
+CREATE INDEX IF NOT EXISTS t_metric_model_task_backfill_idx_f_task_id ON t_metric_model_task_backfill(f_task_id);
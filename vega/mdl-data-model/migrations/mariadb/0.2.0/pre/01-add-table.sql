@@ -0,0 +1,25 @@
+-- Copyright The kweaver.ai Authors.
+--
+-- Licensed under the Apache License, Version 2.0.
+-- See the LICENSE file in the project root for details.
+
+USE adp;
+
+-- 指标持久化任务的历史数据回溯
+CREATE TABLE IF NOT EXISTS t_metric_model_task_backfill (
+  f_backfill_id varchar(40) NOT NULL DEFAULT '' COMMENT '回溯 id',
+  f_task_id varchar(40) NOT NULL COMMENT '指标持久化任务 id',
+  f_start bigint(20) NOT NULL COMMENT '回溯开始时间',
+  f_end bigint(20) NOT NULL COMMENT '回溯结束时间',
+  f_steps varchar(255) NOT NULL DEFAULT '[]' COMMENT '回溯步长',
+  f_concurrency int(11) NOT NULL DEFAULT 1 COMMENT '并发数',
+  f_status varchar(20) NOT NULL DEFAULT '' COMMENT '回溯状态',
+  f_checkpoint bigint(20) NOT NULL DEFAULT 0 COMMENT '已完成的时间点',
+  f_error_details text DEFAULT NULL COMMENT '失败原因',
+  f_create_time bigint(20) NOT NULL DEFAULT 0 COMMENT '创建时间',
+  f_update_time bigint(20) NOT NULL DEFAULT 0 COMMENT '更新时间',
+  f_creator varchar(40) NOT NULL DEFAULT '' COMMENT '创建者id',
+  f_creator_type varchar(20) NOT NULL DEFAULT '' COMMENT '创建者类型',
+  PRIMARY KEY (f_backfill_id),
+  KEY idx_f_task_id (f_task_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin COMMENT = '指标持久化任务的历史数据回溯';
@@ -27,7 +27,8 @@ import (
 )
 
 const (
-	METRIC_MODEL_TASK_TABLE_NAME = "t_metric_model_task"
+	METRIC_MODEL_TASK_TABLE_NAME          = "t_metric_model_task"
+	METRIC_MODEL_TASK_BACKFILL_TABLE_NAME = "t_metric_model_task_backfill"
 )
 
 var (
@@ -725,3 +726,189 @@ func (mmta *metricModelTaskAccess) CheckMetricModelTaskExistByName(ctx context.C
 	span.SetStatus(codes.Ok, "")
 	return true, nil
 }
+
+// 新建指标任务的历史数据回溯记录
+func (mmta *metricModelTaskAccess) CreateMetricTaskBackfill(ctx context.Context, backfill interfaces.MetricTaskBackfill) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Insert into metric model task backfill", trace.WithSpanKind(trace.SpanKindClient))
+	span.SetAttributes(
+		attr.Key("db_url").String(libdb.GetDBUrl()),
+		attr.Key("db_type").String(libdb.GetDBType()))
+	defer span.End()
+
+	stepsBytes, err := sonic.Marshal(backfill.Steps)
+	if err != nil {
+		logger.Errorf("Failed to marshal steps, err: %v", err.Error())
+		span.SetStatus(codes.Error, "Marshal steps failed")
+		return err
+	}
+
+	sqlStr, vals, err := sq.Insert(METRIC_MODEL_TASK_BACKFILL_TABLE_NAME).
+		Columns(
+			"f_backfill_id",
+			"f_task_id",
+			"f_start",
+			"f_end",
+			"f_steps",
+			"f_concurrency",
+			"f_status",
+			"f_checkpoint",
+			"f_error_details",
+			"f_create_time",
+			"f_update_time",
+			"f_creator",
+			"f_creator_type",
+		).
+		Values(
+			backfill.BackfillID,
+			backfill.TaskID,
+			backfill.Start,
+			backfill.End,
+			stepsBytes,
+			backfill.Concurrency,
+			backfill.Status,
+			backfill.Checkpoint,
+			backfill.ErrorDetails,
+			backfill.CreateTime,
+			backfill.UpdateTime,
+			backfill.Creator.ID,
+			backfill.Creator.Type,
+		).ToSql()
+	if err != nil {
+		logger.Errorf("Failed to build the sql of insert metric task backfill, error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Failed to build the sql of insert metric task backfill, error: %s", err.Error()))
+		span.SetStatus(codes.Error, "Build sql failed ")
+		return err
+	}
+	// 记录处理的 sql 字符串
+	o11y.Info(ctx, fmt.Sprintf("创建指标任务历史数据回溯的 sql 语句: %s", sqlStr))
+
+	_, err = mmta.db.Exec(sqlStr, vals...)
+	if err != nil {
+		logger.Errorf("insert data error: %v\n", err)
+		span.SetStatus(codes.Error, "Insert data error")
+		o11y.Error(ctx, fmt.Sprintf("Insert data error: %v ", err))
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// 按任务id获取历史数据回溯记录，按创建时间倒序
+func (mmta *metricModelTaskAccess) GetMetricTaskBackfillsByTaskID(ctx context.Context, taskID string) ([]interfaces.MetricTaskBackfill, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Select metric model task backfills", trace.WithSpanKind(trace.SpanKindClient))
+	span.SetAttributes(
+		attr.Key("db_url").String(libdb.GetDBUrl()),
+		attr.Key("db_type").String(libdb.GetDBType()))
+	defer span.End()
+
+	backfills := make([]interfaces.MetricTaskBackfill, 0)
+
+	sqlStr, vals, err := sq.Select(
+		"f_backfill_id",
+		"f_task_id",
+		"f_start",
+		"f_end",
+		"f_steps",
+		"f_concurrency",
+		"f_status",
+		"f_checkpoint",
+		"f_error_details",
+		"f_create_time",
+		"f_update_time",
+		"f_creator",
+		"f_creator_type").
+		From(METRIC_MODEL_TASK_BACKFILL_TABLE_NAME).
+		Where(sq.Eq{"f_task_id": taskID}).
+		OrderBy("f_create_time desc").
+		ToSql()
+	if err != nil {
+		logger.Errorf("Failed to build the sql of select task backfills, error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Failed to build the sql of select task backfills, error: %s", err.Error()))
+		span.SetStatus(codes.Error, "Build sql failed ")
+		return backfills, err
+	}
+
+	// 记录处理的 sql 字符串
+	o11y.Info(ctx, fmt.Sprintf("查询指标任务历史数据回溯列表的 sql 语句: %s; taskID: %s", sqlStr, taskID))
+	rows, err := mmta.db.Query(sqlStr, vals...)
+	if err != nil {
+		logger.Errorf("list data error: %v\n", err)
+		span.SetStatus(codes.Error, "List data error")
+		o11y.Error(ctx, fmt.Sprintf("List data error: %v", err))
+		return backfills, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		backfill := interfaces.MetricTaskBackfill{}
+		var stepsBytes []byte
+		err := rows.Scan(
+			&backfill.BackfillID,
+			&backfill.TaskID,
+			&backfill.Start,
+			&backfill.End,
+			&stepsBytes,
+			&backfill.Concurrency,
+			&backfill.Status,
+			&backfill.Checkpoint,
+			&backfill.ErrorDetails,
+			&backfill.CreateTime,
+			&backfill.UpdateTime,
+			&backfill.Creator.ID,
+			&backfill.Creator.Type,
+		)
+		if err != nil {
+			logger.Errorf("row scan failed, err: %v \n", err)
+			span.SetStatus(codes.Error, "Row scan error")
+			o11y.Error(ctx, fmt.Sprintf("Row scan error: %v", err))
+			return backfills, err
+		}
+
+		err = sonic.Unmarshal(stepsBytes, &backfill.Steps)
+		if err != nil {
+			logger.Errorf("Failed to unmarshal steps after getting metric task backfill, err: %v", err.Error())
+			span.SetStatus(codes.Error, "Unmarshal steps failed")
+			return backfills, err
+		}
+
+		backfills = append(backfills, backfill)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return backfills, nil
+}
+
+// 按任务id物理删除历史数据回溯记录
+func (mmta *metricModelTaskAccess) DeleteMetricTaskBackfillsByTaskIDs(ctx context.Context, tx *sql.Tx, taskIDs []string) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Delete metric model task backfills from db", trace.WithSpanKind(trace.SpanKindClient))
+	span.SetAttributes(
+		attr.Key("db_url").String(libdb.GetDBUrl()),
+		attr.Key("db_type").String(libdb.GetDBType()),
+		attr.Key("task_ids").String(fmt.Sprintf("%v", taskIDs)))
+	defer span.End()
+
+	sqlStr, vals, err := sq.Delete(METRIC_MODEL_TASK_BACKFILL_TABLE_NAME).
+		Where(sq.Eq{"f_task_id": taskIDs}).
+		ToSql()
+	if err != nil {
+		logger.Errorf("Failed to build the sql of delete task backfills by f_task_id, error: %s", err.Error())
+		o11y.Error(ctx, fmt.Sprintf("Failed to build the sql of delete task backfills by f_task_id, error: %s", err.Error()))
+		span.SetStatus(codes.Error, "Build sql failed ")
+		return err
+	}
+
+	// 记录处理的 sql 字符串
+	o11y.Info(ctx, fmt.Sprintf("删除指标任务历史数据回溯的 sql 语句: %s; 删除的任务id: %v", sqlStr, taskIDs))
+
+	_, err = tx.Exec(sqlStr, vals...)
+	if err != nil {
+		logger.Errorf("delete data error: %v\n", err)
+		span.SetStatus(codes.Error, "Delete data error")
+		o11y.Error(ctx, fmt.Sprintf("Delete data error: %v ", err))
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
@@ -707,3 +707,148 @@ func Test_MetricModelTaskAccess_DeleteMetricTaskByIDs(t *testing.T) {
 		})
 	})
 }
+
+func Test_MetricModelTaskAccess_CreateMetricTaskBackfill(t *testing.T) {
+	Convey("Test CreateMetricTaskBackfill", t, func() {
+		appSetting := &common.AppSetting{}
+		mmta, smock := MockNewMetricModelTaskAccess(appSetting)
+
+		sqlStr := fmt.Sprintf("INSERT INTO %s (f_backfill_id,f_task_id,f_start,f_end,f_steps,f_concurrency,"+
+			"f_status,f_checkpoint,f_error_details,f_create_time,f_update_time,f_creator,f_creator_type) "+
+			"VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)", METRIC_MODEL_TASK_BACKFILL_TABLE_NAME)
+
+		backfill := interfaces.MetricTaskBackfill{
+			BackfillID:  "b1",
+			TaskID:      "1",
+			Start:       1699336800000,
+			End:         1699423200000,
+			Steps:       []string{"5m"},
+			Concurrency: 2,
+			Status:      interfaces.BACKFILL_STATUS_PENDING,
+			Checkpoint:  1699336800000,
+		}
+
+		Convey("Create succeed", func() {
+			smock.ExpectExec(sqlStr).WithArgs().WillReturnResult(sqlmock.NewResult(1, 1))
+
+			err := mmta.CreateMetricTaskBackfill(testCtx, backfill)
+			So(err, ShouldBeNil)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("Create failed, caused by the error from squirrel func ToSql", func() {
+			expectedErr := errors.New("some error")
+			patch := ApplyMethodReturn(sq.InsertBuilder{}, "ToSql", "", []interface{}{}, expectedErr)
+			defer patch.Reset()
+
+			err := mmta.CreateMetricTaskBackfill(testCtx, backfill)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Create failed, caused by exec sql error", func() {
+			expectedErr := errors.New("some error")
+			smock.ExpectExec(sqlStr).WithArgs().WillReturnError(expectedErr)
+
+			err := mmta.CreateMetricTaskBackfill(testCtx, backfill)
+			So(err, ShouldResemble, expectedErr)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	})
+}
+
+func Test_MetricModelTaskAccess_GetMetricTaskBackfillsByTaskID(t *testing.T) {
+	Convey("Test GetMetricTaskBackfillsByTaskID", t, func() {
+		appSetting := &common.AppSetting{}
+		mmta, smock := MockNewMetricModelTaskAccess(appSetting)
+
+		sqlStr := fmt.Sprintf("SELECT f_backfill_id, f_task_id, f_start, f_end, f_steps, f_concurrency, "+
+			"f_status, f_checkpoint, f_error_details, f_create_time, f_update_time, f_creator, f_creator_type "+
+			"FROM %s WHERE f_task_id = ? ORDER BY f_create_time desc", METRIC_MODEL_TASK_BACKFILL_TABLE_NAME)
+
+		columns := []string{"f_backfill_id", "f_task_id", "f_start", "f_end", "f_steps", "f_concurrency",
+			"f_status", "f_checkpoint", "f_error_details", "f_create_time", "f_update_time", "f_creator", "f_creator_type"}
+
+		Convey("Get succeed", func() {
+			rows := sqlmock.NewRows(columns).AddRow("b1", "1", 100, 200, `["5m"]`, 2,
+				interfaces.BACKFILL_STATUS_RUNNING, 150, "", 1, 2, "u1", "user")
+			smock.ExpectQuery(sqlStr).WithArgs("1").WillReturnRows(rows)
+
+			backfills, err := mmta.GetMetricTaskBackfillsByTaskID(testCtx, "1")
+			So(err, ShouldBeNil)
+			So(len(backfills), ShouldEqual, 1)
+			So(backfills[0].Steps, ShouldResemble, []string{"5m"})
+			So(backfills[0].Checkpoint, ShouldEqual, int64(150))
+			So(backfills[0].Creator.ID, ShouldEqual, "u1")
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("Get failed, caused by query error", func() {
+			expectedErr := errors.New("some error")
+			smock.ExpectQuery(sqlStr).WithArgs("1").WillReturnError(expectedErr)
+
+			backfills, err := mmta.GetMetricTaskBackfillsByTaskID(testCtx, "1")
+			So(backfills, ShouldResemble, []interfaces.MetricTaskBackfill{})
+			So(err, ShouldResemble, expectedErr)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("Get failed, caused by unmarshal steps error", func() {
+			rows := sqlmock.NewRows(columns).AddRow("b1", "1", 100, 200, `[`, 2,
+				interfaces.BACKFILL_STATUS_RUNNING, 150, "", 1, 2, "u1", "user")
+			smock.ExpectQuery(sqlStr).WithArgs("1").WillReturnRows(rows)
+
+			_, err := mmta.GetMetricTaskBackfillsByTaskID(testCtx, "1")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_MetricModelTaskAccess_DeleteMetricTaskBackfillsByTaskIDs(t *testing.T) {
+	Convey("Test DeleteMetricTaskBackfillsByTaskIDs", t, func() {
+		appSetting := &common.AppSetting{}
+		mmta, smock := MockNewMetricModelTaskAccess(appSetting)
+
+		sqlStr := fmt.Sprintf("DELETE FROM %s WHERE f_task_id IN (?)", METRIC_MODEL_TASK_BACKFILL_TABLE_NAME)
+
+		Convey("Delete failed, caused by exec sql error", func() {
+			smock.ExpectBegin()
+			expectedErr := errors.New("some error")
+			smock.ExpectExec(sqlStr).WithArgs().WillReturnError(expectedErr)
+
+			tx, _ := mmta.db.Begin()
+
+			err := mmta.DeleteMetricTaskBackfillsByTaskIDs(testCtx, tx, []string{"1"})
+			So(err, ShouldResemble, expectedErr)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+
+		Convey("Delete succeed", func() {
+			smock.ExpectBegin()
+			smock.ExpectExec(sqlStr).WithArgs().WillReturnResult(sqlmock.NewResult(1, 2))
+
+			tx, _ := mmta.db.Begin()
+
+			err := mmta.DeleteMetricTaskBackfillsByTaskIDs(testCtx, tx, []string{"1"})
+			So(err, ShouldBeNil)
+
+			if err := smock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	})
+}
@@ -745,6 +745,16 @@ func (tma *traceModelAccess) processAfterGet(ctx context.Context, model interfac
 			return model, err
 		}
 		model.SpanConfig = spanConf
+	} else if model.SpanSourceType == interfaces.SOURCE_TYPE_OTEL {
+		spanConf := interfaces.SpanConfigWithOTel{}
+		err := sonic.Unmarshal(model.SpanConfigBytes, &spanConf)
+		if err != nil {
+			errDetails := fmt.Sprintf("Failed to unmarshal spanConfigBytes after getting trace models, err: %v", err.Error())
+			logger.Error(errDetails)
+			o11y.Error(ctx, errDetails)
+			return model, err
+		}
+		model.SpanConfig = spanConf
 	} else {
 		spanConf := interfaces.SpanConfigWithDataConnection{}
 		err := sonic.Unmarshal(model.SpanConfigBytes, &spanConf)
@@ -1019,10 +1019,187 @@ func (r *restHandler) GetMetricTask(c *gin.Context, visitor rest.Visitor) {
 		return
 	}
 
+	// 附带任务的历史数据回溯进度
+	backfills, err := r.mmts.GetMetricTaskBackfillsByTaskID(ctx, taskID)
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			derrors.DataModel_MetricModel_InternalError_GetMetricTaskByIDFailed).
+			WithErrorDetails("Get metric task backfills Failed:" + err.Error())
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+	tasks[0].Backfills = backfills
+
 	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
 	rest.ReplyOK(c, http.StatusOK, tasks[0])
 }
 
+// 创建指标模型持久化任务的历史数据回溯(内部)
+func (r *restHandler) CreateMetricTaskBackfillByIn(c *gin.Context) {
+	logger.Debug("Handler CreateMetricTaskBackfillByIn Start")
+	// 内部接口 user_id从header中取，跳过用户有效认证，后面在权限校验时就会校验这个用户是否有权限，无效用户无权限
+	// 自行构建一个visitor
+	visitor := GenerateVisitor(c)
+	r.CreateMetricTaskBackfill(c, visitor)
+}
+
+// 创建指标模型持久化任务的历史数据回溯（外部）
+func (r *restHandler) CreateMetricTaskBackfillByEx(c *gin.Context) {
+	logger.Debug("Handler CreateMetricTaskBackfillByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"创建指标模型持久化任务的历史数据回溯", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+	r.CreateMetricTaskBackfill(c, visitor)
+}
+
+// 创建指标模型持久化任务的历史数据回溯，按所选步长重新计算[start, end)内的历史数据
+func (r *restHandler) CreateMetricTaskBackfill(c *gin.Context, visitor rest.Visitor) {
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"创建指标模型持久化任务的历史数据回溯", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	// 设置 trace 的相关 api 的属性
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	taskID := c.Param("task_id")
+	span.SetAttributes(attr.Key("task_id").String(taskID))
+
+	//接收绑定参数
+	backfill := interfaces.MetricTaskBackfill{}
+	err := c.ShouldBindJSON(&backfill)
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_MetricModel_InvalidParameter).
+			WithErrorDetails("Binding Paramter Failed:" + err.Error())
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	err = validateMetricTaskBackfill(ctx, &backfill)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	tasks, err := r.mmts.GetMetricTasksByTaskIDs(ctx, []string{taskID})
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			derrors.DataModel_MetricModel_InternalError_GetMetricTaskByIDFailed).
+			WithErrorDetails("Get metric task Failed:" + err.Error())
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	if len(tasks) <= 0 {
+		httpErr := rest.NewHTTPError(ctx, http.StatusNotFound, derrors.DataModel_MetricModel_MetricTaskNotFound).
+			WithErrorDetails(fmt.Sprintf("Metric task[%s] not found", taskID))
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	err = r.mmts.CreateMetricTaskBackfill(ctx, tasks[0], &backfill)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	result := map[string]any{"id": backfill.BackfillID}
+	c.Writer.Header().Set("Location", fmt.Sprintf("/api/mdl-data-model/v1/metric-tasks/%s/backfills", taskID))
+	o11y.AddHttpAttrs4Ok(span, http.StatusCreated)
+	rest.ReplyOK(c, http.StatusCreated, result)
+}
+
+// 获取指标模型持久化任务的历史数据回溯列表(内部)
+func (r *restHandler) ListMetricTaskBackfillsByIn(c *gin.Context) {
+	logger.Debug("Handler ListMetricTaskBackfillsByIn Start")
+	// 内部接口 user_id从header中取，跳过用户有效认证，后面在权限校验时就会校验这个用户是否有权限，无效用户无权限
+	// 自行构建一个visitor
+	visitor := GenerateVisitor(c)
+	r.ListMetricTaskBackfills(c, visitor)
+}
+
+// 获取指标模型持久化任务的历史数据回溯列表（外部）
+func (r *restHandler) ListMetricTaskBackfillsByEx(c *gin.Context) {
+	logger.Debug("Handler ListMetricTaskBackfillsByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"获取指标模型持久化任务的历史数据回溯列表", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+	r.ListMetricTaskBackfills(c, visitor)
+}
+
+// 获取指标模型持久化任务的历史数据回溯列表及进度
+func (r *restHandler) ListMetricTaskBackfills(c *gin.Context, visitor rest.Visitor) {
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"获取指标模型持久化任务的历史数据回溯列表", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	// 设置 trace 的相关 api 的属性
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	taskID := c.Param("task_id")
+	span.SetAttributes(attr.Key("task_id").String(taskID))
+
+	backfills, err := r.mmts.GetMetricTaskBackfillsByTaskID(ctx, taskID)
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			derrors.DataModel_MetricModel_InternalError_GetMetricTaskByIDFailed).
+			WithErrorDetails("Get metric task backfills Failed:" + err.Error())
+
+		// 设置 trace 的错误信息的 attributes
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, backfills)
+}
+
 // 更新任务的计划时间和执行状态(内部)
 func (r *restHandler) UpdateMetricTaskPlanTimeByIn(c *gin.Context) {
 	logger.Debug("Handler UpdateMetricTaskPlanTimeByIn Start")
@@ -7,9 +7,11 @@ package driveradapters
 
 import (
 	"bytes"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/bytedance/sonic"
 	"github.com/gin-gonic/gin"
@@ -1447,12 +1449,28 @@ func Test_MetricModelRestHandler_GetMetricTask(t *testing.T) {
 		Convey("Success GetMetricTask \n", func() {
 			mmts.EXPECT().GetMetricTasksByTaskIDs(gomock.Any(), gomock.Any()).AnyTimes().
 				Return([]interfaces.MetricTask{testTask}, nil)
+			mmts.EXPECT().GetMetricTaskBackfillsByTaskID(gomock.Any(), gomock.Any()).
+				Return([]interfaces.MetricTaskBackfill{{BackfillID: "b1", TaskID: "1"}}, nil)
 
 			req := httptest.NewRequest(http.MethodGet, url, bytes.NewReader(nil))
 			w := httptest.NewRecorder()
 			engine.ServeHTTP(w, req)
 
 			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			So(w.Body.String(), ShouldContainSubstring, `"backfills":[{"id":"b1"`)
+		})
+
+		Convey("GetMetricTaskBackfillsByTaskID Failed \n", func() {
+			mmts.EXPECT().GetMetricTasksByTaskIDs(gomock.Any(), gomock.Any()).AnyTimes().
+				Return([]interfaces.MetricTask{testTask}, nil)
+			mmts.EXPECT().GetMetricTaskBackfillsByTaskID(gomock.Any(), gomock.Any()).
+				Return(nil, errors.New("db error"))
+
+			req := httptest.NewRequest(http.MethodGet, url, bytes.NewReader(nil))
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusInternalServerError)
 		})
 
 		Convey("GetMetricTasksByIDs Failed \n", func() {
@@ -1487,6 +1505,138 @@ func Test_MetricModelRestHandler_GetMetricTask(t *testing.T) {
 	})
 }
 
+func Test_MetricModelRestHandler_CreateMetricTaskBackfill(t *testing.T) {
+	Convey("Test MetricModelHandler CreateMetricTaskBackfill\n", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydra := rmock.NewMockHydra(mockCtrl)
+		mms := dmock.NewMockMetricModelService(mockCtrl)
+		dvs := dmock.NewMockDataViewService(mockCtrl)
+		mmgs := dmock.NewMockMetricModelGroupService(mockCtrl)
+		mmts := dmock.NewMockMetricModelTaskService(mockCtrl)
+
+		handler := MockNewMetricModelRestHandler(appSetting, hydra, mms, dvs, mmgs, mmts)
+		handler.RegisterPublic(engine)
+
+		hydra.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/mdl-data-model/v1/metric-tasks/1/backfills"
+		end := time.Now().Add(-time.Hour).UnixMilli()
+		backfill := interfaces.MetricTaskBackfill{
+			Start: end - 24*time.Hour.Milliseconds(),
+			End:   end,
+		}
+
+		Convey("Success CreateMetricTaskBackfill \n", func() {
+			mmts.EXPECT().GetMetricTasksByTaskIDs(gomock.Any(), gomock.Any()).Return([]interfaces.MetricTask{testTask}, nil)
+			mmts.EXPECT().CreateMetricTaskBackfill(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			reqParamByte, _ := sonic.Marshal(backfill)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("Invalid range \n", func() {
+			invalid := backfill
+			invalid.Start = invalid.End
+
+			reqParamByte, _ := sonic.Marshal(invalid)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("task not found \n", func() {
+			mmts.EXPECT().GetMetricTasksByTaskIDs(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+			reqParamByte, _ := sonic.Marshal(backfill)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("CreateMetricTaskBackfill Failed \n", func() {
+			mmts.EXPECT().GetMetricTasksByTaskIDs(gomock.Any(), gomock.Any()).Return([]interfaces.MetricTask{testTask}, nil)
+			mmts.EXPECT().CreateMetricTaskBackfill(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(rest.NewHTTPError(testCtx, http.StatusBadRequest, derrors.DataModel_MetricModel_InvalidParameter_Step))
+
+			reqParamByte, _ := sonic.Marshal(backfill)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+func Test_MetricModelRestHandler_ListMetricTaskBackfills(t *testing.T) {
+	Convey("Test MetricModelHandler ListMetricTaskBackfills\n", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydra := rmock.NewMockHydra(mockCtrl)
+		mms := dmock.NewMockMetricModelService(mockCtrl)
+		dvs := dmock.NewMockDataViewService(mockCtrl)
+		mmgs := dmock.NewMockMetricModelGroupService(mockCtrl)
+		mmts := dmock.NewMockMetricModelTaskService(mockCtrl)
+
+		handler := MockNewMetricModelRestHandler(appSetting, hydra, mms, dvs, mmgs, mmts)
+		handler.RegisterPublic(engine)
+
+		hydra.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/mdl-data-model/v1/metric-tasks/1/backfills"
+
+		Convey("Success ListMetricTaskBackfills \n", func() {
+			mmts.EXPECT().GetMetricTaskBackfillsByTaskID(gomock.Any(), "1").
+				Return([]interfaces.MetricTaskBackfill{{BackfillID: "b1", TaskID: "1"}}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, url, bytes.NewReader(nil))
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("GetMetricTaskBackfillsByTaskID Failed \n", func() {
+			mmts.EXPECT().GetMetricTaskBackfillsByTaskID(gomock.Any(), "1").Return(nil, errors.New("db error"))
+
+			req := httptest.NewRequest(http.MethodGet, url, bytes.NewReader(nil))
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
+
 func Test_MetricModelRestHandler_UpdateMetricTaskAttributes(t *testing.T) {
 	Convey("Test MetricModelHandler UpdateMetricTaskAttributes\n", t, func() {
 		test := setGinMode()
@@ -102,6 +102,8 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		// 指标模型持久化任务
 		apiV1.GET("/metric-tasks/:task_id", r.GetMetricTaskByEx)
 		apiV1.PUT("/metric-tasks/:task_id/attr", r.UpdateMetricTaskPlanTimeByEx)
+		apiV1.POST("/metric-tasks/:task_id/backfills", r.verifyJsonContentTypeMiddleWare(), r.CreateMetricTaskBackfillByEx)
+		apiV1.GET("/metric-tasks/:task_id/backfills", r.ListMetricTaskBackfillsByEx)
 
 		// event model
 		apiV1.POST("/event-models", r.verifyJsonContentTypeMiddleWare(), r.CreateEventModelByEx)                //新增事件模型
@@ -192,6 +194,8 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		// 指标模型持久化任务
 		apiInV1.GET("/metric-tasks/:task_id", r.GetMetricTaskByIn)
 		apiInV1.PUT("/metric-tasks/:task_id/attr", r.UpdateMetricTaskPlanTimeByIn)
+		apiInV1.POST("/metric-tasks/:task_id/backfills", r.verifyJsonContentTypeMiddleWare(), r.CreateMetricTaskBackfillByIn)
+		apiInV1.GET("/metric-tasks/:task_id/backfills", r.ListMetricTaskBackfillsByIn)
 
 		// 数据视图
 		apiInV1.POST("/data-views", r.verifyJsonContentTypeMiddleWare(), r.HandleDataViewPostOverrideByIn)
@@ -1087,9 +1087,19 @@ func convertSpanConfig(ctx context.Context, reqModels []interfaces.TraceModel) e
 					derrors.DataModel_InternalError_UnmarshalDataFailed).WithErrorDetails(errDetails)
 			}
 			reqModels[i].SpanConfig = conf
+		case interfaces.SOURCE_TYPE_OTEL:
+			conf := interfaces.SpanConfigWithOTel{}
+			err = sonic.Unmarshal(b, &conf)
+			if err != nil {
+				errDetails := fmt.Sprintf("Field span_config cannot be unmarshaled to SpanConfigWithOTel, err: %v", err.Error())
+				o11y.Error(ctx, errDetails)
+				return rest.NewHTTPError(ctx, http.StatusInternalServerError,
+					derrors.DataModel_InternalError_UnmarshalDataFailed).WithErrorDetails(errDetails)
+			}
+			reqModels[i].SpanConfig = conf
 		default:
 			errDetails := "span_source_type is invalid, valid span_source_type is " + interfaces.SOURCE_TYPE_DATA_VIEW +
-				" or " + interfaces.SOURCE_TYPE_DATA_CONNECTION
+				", " + interfaces.SOURCE_TYPE_DATA_CONNECTION + " or " + interfaces.SOURCE_TYPE_OTEL
 			o11y.Error(ctx, errDetails)
 			return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_TraceModel_InvalidParameter_SpanSourceType).
 				WithErrorDetails(errDetails)
@@ -832,7 +832,7 @@ func Test_TraceModelRestHandler_ListTraceModels(t *testing.T) {
 
 			expectedHttpErr := rest.NewHTTPError(testCtx, http.StatusBadRequest,
 				derrors.DataModel_TraceModel_InvalidParameter_SpanSourceType).
-				WithErrorDetails("span_source_type is invalid, valid span_source_type is data_view or data_connection")
+				WithErrorDetails("span_source_type is invalid, valid span_source_type is data_view, data_connection or otel")
 			patch2 := ApplyFuncReturn(validateSpanSourceType, expectedHttpErr)
 			defer patch2.Reset()
 
@@ -1071,7 +1071,7 @@ func Test_TraceModelRestHandler_ConvertSpanConfig(t *testing.T) {
 			}
 			expectedHttpErr := rest.NewHTTPError(testCtx, http.StatusBadRequest, derrors.DataModel_TraceModel_InvalidParameter_SpanSourceType).
 				WithErrorDetails("span_source_type is invalid, valid span_source_type is " + interfaces.SOURCE_TYPE_DATA_VIEW +
-					" or " + interfaces.SOURCE_TYPE_DATA_CONNECTION)
+					", " + interfaces.SOURCE_TYPE_DATA_CONNECTION + " or " + interfaces.SOURCE_TYPE_OTEL)
 
 			patch := ApplyFuncReturn(sonic.Marshal, []byte(nil), nil)
 			defer patch.Reset()
@@ -521,6 +521,46 @@ func validateTask(ctx context.Context, queryType string, task interfaces.MetricT
 	return nil
 }
 
+// 校验历史数据回溯的参数。步长是否属于任务的步长在 service 层校验
+func validateMetricTaskBackfill(ctx context.Context, backfill *interfaces.MetricTaskBackfill) error {
+	// 1. 时间范围，end 不能晚于当前时间，回溯不能覆盖到调度任务之后的时间点
+	if backfill.Start <= 0 || backfill.End <= backfill.Start {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_MetricModel_InvalidParameter_BackfillRange).
+			WithErrorDetails(fmt.Sprintf("Backfill start must be positive and less than end, actual start is %d, end is %d",
+				backfill.Start, backfill.End))
+	}
+	if backfill.End > time.Now().UnixMilli() {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_MetricModel_InvalidParameter_BackfillRange).
+			WithErrorDetails(fmt.Sprintf("Backfill end can not be later than now, actual end is %d", backfill.End))
+	}
+
+	// 2. 并发数
+	if backfill.Concurrency == 0 {
+		backfill.Concurrency = interfaces.DEFAULT_BACKFILL_CONCURRENCY
+	}
+	if backfill.Concurrency < 0 || backfill.Concurrency > interfaces.MAX_BACKFILL_CONCURRENCY {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_MetricModel_InvalidParameter_BackfillConcurrency).
+			WithErrorDetails(fmt.Sprintf("Backfill concurrency must be in [1, %d], actual is %d",
+				interfaces.MAX_BACKFILL_CONCURRENCY, backfill.Concurrency))
+	}
+
+	// 3. 步长，可为空，为空时回溯任务的全部步长
+	stepsMap := make(map[string]struct{})
+	for _, step := range backfill.Steps {
+		if _, exists := common.PersistStepsMap[step]; !exists {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_MetricModel_InvalidParameter_Step).
+				WithErrorDetails(fmt.Sprintf("expect persist steps is one of %v, actaul is %s",
+					common.PersistSteps, step))
+		}
+		if _, ok := stepsMap[step]; ok {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_MetricModel_Duplicated_TaskStep)
+		}
+		stepsMap[step] = struct{}{}
+	}
+
+	return nil
+}
+
 // 校验时间区间
 func validateDuration(ctx context.Context, durationStr string, reg *regexp.Regexp, errCode string, objectName string, containMinute bool) error {
 	durationV, err := common.ParseDuration(durationStr, reg, containMinute)
@@ -8,11 +8,13 @@ package driveradapters
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/kweaver-ai/kweaver-go-lib/rest"
 	"github.com/robfig/cron/v3"
 	. "github.com/smartystreets/goconvey/convey"
 
+	"data-model/common"
 	derrors "data-model/errors"
 	"data-model/interfaces"
 )
@@ -1537,6 +1539,49 @@ func Test_ValidateMetricModel_ValidateDSL(t *testing.T) {
 	})
 }
 
+func Test_ValidateMetricModel_ValidateMetricTaskBackfill(t *testing.T) {
+	Convey("Test validateMetricTaskBackfill", t, func() {
+		common.PersistStepsMap = StepsMap
+		end := time.Now().Add(-time.Hour).UnixMilli()
+
+		Convey("Validate failed, because start >= end", func() {
+			err := validateMetricTaskBackfill(testCtx, &interfaces.MetricTaskBackfill{Start: end, End: end})
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_MetricModel_InvalidParameter_BackfillRange)
+		})
+
+		Convey("Validate failed, because end is later than now", func() {
+			err := validateMetricTaskBackfill(testCtx, &interfaces.MetricTaskBackfill{
+				Start: end, End: time.Now().Add(time.Hour).UnixMilli()})
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_MetricModel_InvalidParameter_BackfillRange)
+		})
+
+		Convey("Validate failed, because concurrency exceeds max", func() {
+			err := validateMetricTaskBackfill(testCtx, &interfaces.MetricTaskBackfill{
+				Start: end - 1, End: end, Concurrency: interfaces.MAX_BACKFILL_CONCURRENCY + 1})
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_MetricModel_InvalidParameter_BackfillConcurrency)
+		})
+
+		Convey("Validate failed, because step is invalid", func() {
+			err := validateMetricTaskBackfill(testCtx, &interfaces.MetricTaskBackfill{
+				Start: end - 1, End: end, Steps: []string{"7m"}})
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_MetricModel_InvalidParameter_Step)
+		})
+
+		Convey("Validate failed, because step is duplicated", func() {
+			err := validateMetricTaskBackfill(testCtx, &interfaces.MetricTaskBackfill{
+				Start: end - 1, End: end, Steps: []string{"5m", "5m"}})
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_MetricModel_Duplicated_TaskStep)
+		})
+
+		Convey("Validate success with default concurrency", func() {
+			backfill := &interfaces.MetricTaskBackfill{Start: end - 1, End: end, Steps: []string{"5m"}}
+			err := validateMetricTaskBackfill(testCtx, backfill)
+			So(err, ShouldBeNil)
+			So(backfill.Concurrency, ShouldEqual, interfaces.DEFAULT_BACKFILL_CONCURRENCY)
+		})
+	})
+}
+
 func Test_ValidateMetricModel_Cron2(t *testing.T) {
 	// "* * * 1W * ?", "* * * L * ?","* * 1-2 1W * ?","* * 1-2 L * ?","* * 0/1 1W * ?","* * 0/1 L * ?","1-2 * * * * 1L" 不支持
 	exprs := []string{
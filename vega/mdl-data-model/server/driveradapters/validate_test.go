@@ -896,7 +896,7 @@ func Test_Validate_ValidateSpanSourceType(t *testing.T) {
 		Convey("Invalid spanSourceType, field value is xxx", func() {
 			err := validateSpanSourceType(testCtx, "xxx")
 			So(err, ShouldResemble, rest.NewHTTPError(testCtx, http.StatusBadRequest, derrors.DataModel_TraceModel_InvalidParameter_SpanSourceType).
-				WithErrorDetails("span_source_type is invalid, valid span_source_type is data_view or data_connection"))
+				WithErrorDetails("span_source_type is invalid, valid span_source_type is data_view, data_connection or otel"))
 		})
 	})
 }
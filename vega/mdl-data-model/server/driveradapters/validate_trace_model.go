@@ -226,6 +226,22 @@ func validateSpanConfig(ctx context.Context, spanConf any, spanSourceType string
 		if err != nil {
 			return err
 		}
+	} else if spanSourceType == interfaces.SOURCE_TYPE_OTEL {
+		conf := spanConf.(interfaces.SpanConfigWithOTel)
+		// 未指定数据视图时, 需指定OTel span索引所在的数据源
+		if conf.DataView.ID == "" && conf.DataView.Name == "" {
+			if conf.DataSourceID == "" {
+				return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_TraceModel_InvalidOTelSpanConfig).
+					WithErrorDetails("Either data_view or data_source_id must be specified in the OTel span config")
+			}
+			return nil
+		}
+
+		// 校验OTel span所在数据视图名称, 字段按OTel的固定结构解析, 无需配置基础属性
+		err := validateObjectName(ctx, conf.DataView.Name, interfaces.MODULE_TYPE_DATA_VIEW)
+		if err != nil {
+			return err
+		}
 	} else {
 		conf := spanConf.(interfaces.SpanConfigWithDataConnection)
 		// 校验数据连接名称
@@ -487,10 +503,10 @@ func isValidValueFrom(valueFrom string) bool {
 // 链路模型校验函数(12): span数据来源校验
 func validateSpanSourceType(ctx context.Context, spanSourceType string) error {
 	switch spanSourceType {
-	case "data_view", "data_connection":
+	case "data_view", "data_connection", "otel":
 		return nil
 	default:
 		return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_TraceModel_InvalidParameter_SpanSourceType).
-			WithErrorDetails("span_source_type is invalid, valid span_source_type is data_view or data_connection")
+			WithErrorDetails("span_source_type is invalid, valid span_source_type is data_view, data_connection or otel")
 	}
 }
@@ -345,6 +345,18 @@ func Test_ValidateTraceModel_ValidateSpanConfig(t *testing.T) {
 			err := validateSpanConfig(testCtx, span, interfaces.SOURCE_TYPE_DATA_VIEW)
 			So(err, ShouldBeNil)
 		})
+
+		Convey("Invalid span_config with span_source_type is otel, because neither data view nor data source is specified", func() {
+			span := interfaces.SpanConfigWithOTel{}
+			err := validateSpanConfig(testCtx, span, interfaces.SOURCE_TYPE_OTEL)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_TraceModel_InvalidOTelSpanConfig)
+		})
+
+		Convey("Valid span_config with span_source_type is otel, which only specifies the data source", func() {
+			span := interfaces.SpanConfigWithOTel{DataSourceID: "ds1"}
+			err := validateSpanConfig(testCtx, span, interfaces.SOURCE_TYPE_OTEL)
+			So(err, ShouldBeNil)
+		})
 	})
 }
 
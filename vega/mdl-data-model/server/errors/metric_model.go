@@ -26,6 +26,8 @@ const (
 	DataModel_MetricModel_GroupByFieldNotExisted                 = "DataModel.MetricModel.GroupByFieldNotExisted"
 	DataModel_MetricModel_IDExisted                              = "DataModel.MetricModel.IDExisted"
 	DataModel_MetricModel_InvalidParameter                       = "DataModel.MetricModel.InvalidParameter"
+	DataModel_MetricModel_InvalidParameter_BackfillConcurrency   = "DataModel.MetricModel.InvalidParameter.BackfillConcurrency"
+	DataModel_MetricModel_InvalidParameter_BackfillRange         = "DataModel.MetricModel.InvalidParameter.BackfillRange"
 	DataModel_MetricModel_InvalidParameter_DateField             = "DataModel.MetricModel.InvalidParameter.DateField"
 	DataModel_MetricModel_InvalidParameter_DateFormat            = "DataModel.MetricModel.InvalidParameter.DateFormat"
 	DataModel_MetricModel_InvalidParameter_DataSourceType        = "DataModel.MetricModel.InvalidParameter.DataSourceType"
@@ -95,6 +97,7 @@ const (
 	DataModel_MetricModel_InternalError_CheckFormulaFailed               = "DataModel.MetricModel.InternalError.CheckFormulaFailed"
 	DataModel_MetricModel_InternalError_CheckMetricModelTaskExistByName  = "DataModel.MetricModel.InternalError.CheckMetricModelTaskExistByName"
 	DataModel_MetricModel_InternalError_CheckModelIfExistFailed          = "DataModel.MetricModel.InternalError.CheckModelIfExistFailed"
+	DataModel_MetricModel_InternalError_CreateMetricTaskBackfillFailed   = "DataModel.MetricModel.InternalError.CreateMetricTaskBackfillFailed"
 	DataModel_MetricModel_InternalError_GenerateIDFailed                 = "DataModel.MetricModel.InternalError.GenerateIDFailed"
 	DataModel_MetricModel_InternalError_GetDataViewByIDFailed            = "DataModel.MetricModel.InternalError.GetDataViewByIDFailed"
 	DataModel_MetricModel_InternalError_GetDataViewByNameFailed          = "DataModel.MetricModel.InternalError.GetDataViewByNameFailed"
@@ -157,6 +160,8 @@ var (
 		DataModel_MetricModel_DateFieldNotExisted,
 		DataModel_MetricModel_GroupByFieldNotExisted,
 		DataModel_MetricModel_InvalidParameter,
+		DataModel_MetricModel_InvalidParameter_BackfillConcurrency,
+		DataModel_MetricModel_InvalidParameter_BackfillRange,
 		DataModel_MetricModel_InvalidParameter_DateField,
 		DataModel_MetricModel_InvalidParameter_DateFormat,
 		DataModel_MetricModel_InvalidParameter_DataSourceType,
@@ -226,6 +231,7 @@ var (
 		DataModel_MetricModel_InternalError_CheckFormulaFailed,
 		DataModel_MetricModel_InternalError_CheckMetricModelTaskExistByName,
 		DataModel_MetricModel_InternalError_CheckModelIfExistFailed,
+		DataModel_MetricModel_InternalError_CreateMetricTaskBackfillFailed,
 		DataModel_MetricModel_InternalError_GenerateIDFailed,
 		DataModel_MetricModel_InternalError_GetDataViewByIDFailed,
 		DataModel_MetricModel_InternalError_GetDataViewByNameFailed,
@@ -22,6 +22,7 @@ const (
 	DataModel_TraceModel_InvalidBasicAttributeConfig_StartTime    = "DataModel.TraceModel.InvalidBasicAttributeConfig.StartTime"
 	DataModel_TraceModel_InvalidBasicAttributeConfig_Status       = "DataModel.TraceModel.InvalidBasicAttributeConfig.Status"
 	DataModel_TraceModel_InvalidBasicAttributeConfig_TraceID      = "DataModel.TraceModel.InvalidBasicAttributeConfig.TraceID"
+	DataModel_TraceModel_InvalidOTelSpanConfig                    = "DataModel.TraceModel.InvalidOTelSpanConfig"
 	DataModel_TraceModel_InvalidParameter_EnabledRelatedLog       = "DataModel.TraceModel.InvalidParameter.EnabledRelatedLog"
 	DataModel_TraceModel_InvalidParameter_ModelIDs                = "DataModel.TraceModel.InvalidParameter.ModelIDs"
 	DataModel_TraceModel_InvalidParameter_RelatedLogSourceType    = "DataModel.TraceModel.InvalidParameter.RelatedLogSourceType"
@@ -66,6 +67,7 @@ var (
 		DataModel_TraceModel_InvalidBasicAttributeConfig_StartTime,
 		DataModel_TraceModel_InvalidBasicAttributeConfig_Status,
 		DataModel_TraceModel_InvalidBasicAttributeConfig_TraceID,
+		DataModel_TraceModel_InvalidOTelSpanConfig,
 		DataModel_TraceModel_InvalidParameter_EnabledRelatedLog,
 		DataModel_TraceModel_InvalidParameter_ModelIDs,
 		DataModel_TraceModel_InvalidParameter_RelatedLogSourceType,
@@ -64,6 +64,7 @@ const (
 	// 提交的时候提交job_type字段，扫描的时候是metric单独扫描，在扫描metric的时候，构造的jobInfo的job_type赋值为 metric_mdoel
 	JOB_TYPE_STREAM   = "stream"   // 流式订阅的任务类型为 stream
 	JOB_TYPE_SCHEDULE = "schedule" // 定时任务的类型为 schedule
+	JOB_TYPE_BACKFILL = "backfill" // 指标任务的历史数据回溯的类型为 backfill
 
 	// 未分组中英文
 	UNGROUPED_ZH_CN = "未分组"
@@ -107,6 +108,8 @@ type DataModelJobCfg struct {
 	MetricTask *MetricTask `josn:"metric_task,omitempty"` // 指标模型、目标模型的持久化任务信息
 	EventTask  *EventTask  `josn:"event_task,omitempty"`  // 事件模型的持久化任务信息
 	Schedule   `json:"schedule,omitempty"`
+
+	Backfill *MetricTaskBackfill `json:"backfill,omitempty"` // 指标任务的历史数据回溯信息
 }
 
 // type Field struct {
@@ -115,6 +115,17 @@ const (
 	SCHEDULE_SYNC_STATUS_SUCCESS = 4 // 执行成功
 	SCHEDULE_SYNC_STATUS_FAILED  = 5 // 执行失败
 
+	// 历史数据回溯的状态
+	BACKFILL_STATUS_PENDING  = "pending"  // 等待执行
+	BACKFILL_STATUS_RUNNING  = "running"  // 执行中
+	BACKFILL_STATUS_SUCCESS  = "success"  // 执行成功
+	BACKFILL_STATUS_FAILED   = "failed"   // 执行失败
+	BACKFILL_STATUS_CANCELED = "canceled" // 任务停止或删除后取消
+
+	// 历史数据回溯的默认并发数和最大并发数
+	DEFAULT_BACKFILL_CONCURRENCY = 1
+	MAX_BACKFILL_CONCURRENCY     = 8
+
 	// 任务类型 calendar 和 fixed
 	// TASK_TYPE_CALENDAR             = "calendar"
 	// TASK_TYPE_FIXED                = "fixed"
@@ -484,6 +495,26 @@ type MetricTask struct {
 	UpdateTime         int64       `json:"update_time"`
 	PlanTime           int64       `json:"plan_time"`
 	Creator            AccountInfo `json:"creator"`
+
+	Backfills []MetricTaskBackfill `json:"backfills,omitempty"`
+}
+
+// 指标任务的历史数据回溯（补数）。在[start, end)范围内按所选步长重新计算并写入索引库，
+// checkpoint 之前的时间点均已处理完成，服务重启后从 checkpoint 处继续。
+type MetricTaskBackfill struct {
+	BackfillID   string      `json:"id"`
+	TaskID       string      `json:"task_id"`
+	Start        int64       `json:"start"`
+	End          int64       `json:"end"`
+	Steps        []string    `json:"steps"`
+	Concurrency  int         `json:"concurrency"`
+	Status       string      `json:"status"`
+	Checkpoint   int64       `json:"checkpoint"`
+	Progress     float64     `json:"progress"`
+	ErrorDetails string      `json:"error_details"`
+	CreateTime   int64       `json:"create_time"`
+	UpdateTime   int64       `json:"update_time"`
+	Creator      AccountInfo `json:"creator"`
 }
 
 type Schedule struct {
@@ -25,4 +25,8 @@ type MetricModelTaskAccess interface {
 	DeleteMetricTaskByTaskIDs(ctx context.Context, tx *sql.Tx, taskIDs []string) error
 
 	CheckMetricModelTaskExistByName(ctx context.Context, task MetricTask, deleteTaskIDs []string) (bool, error)
+
+	CreateMetricTaskBackfill(ctx context.Context, backfill MetricTaskBackfill) error
+	GetMetricTaskBackfillsByTaskID(ctx context.Context, taskID string) ([]MetricTaskBackfill, error)
+	DeleteMetricTaskBackfillsByTaskIDs(ctx context.Context, tx *sql.Tx, taskIDs []string) error
 }
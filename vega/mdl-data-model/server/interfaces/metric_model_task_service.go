@@ -25,4 +25,7 @@ type MetricModelTaskService interface {
 	DeleteMetricTaskByTaskIDs(ctx context.Context, tx *sql.Tx, taskIDs []string) error
 
 	CheckMetricModelTaskExistByName(ctx context.Context, task MetricTask, deleteTaskIDs []string) (bool, error)
+
+	CreateMetricTaskBackfill(ctx context.Context, task MetricTask, backfill *MetricTaskBackfill) error
+	GetMetricTaskBackfillsByTaskID(ctx context.Context, taskID string) ([]MetricTaskBackfill, error)
 }
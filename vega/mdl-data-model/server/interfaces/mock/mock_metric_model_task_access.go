@@ -70,6 +70,34 @@ func (mr *MockMetricModelTaskAccessMockRecorder) CreateMetricTask(ctx, tx, metri
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMetricTask", reflect.TypeOf((*MockMetricModelTaskAccess)(nil).CreateMetricTask), ctx, tx, metricTasks)
 }
 
+// CreateMetricTaskBackfill mocks base method.
+func (m *MockMetricModelTaskAccess) CreateMetricTaskBackfill(ctx context.Context, backfill interfaces.MetricTaskBackfill) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMetricTaskBackfill", ctx, backfill)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMetricTaskBackfill indicates an expected call of CreateMetricTaskBackfill.
+func (mr *MockMetricModelTaskAccessMockRecorder) CreateMetricTaskBackfill(ctx, backfill interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMetricTaskBackfill", reflect.TypeOf((*MockMetricModelTaskAccess)(nil).CreateMetricTaskBackfill), ctx, backfill)
+}
+
+// DeleteMetricTaskBackfillsByTaskIDs mocks base method.
+func (m *MockMetricModelTaskAccess) DeleteMetricTaskBackfillsByTaskIDs(ctx context.Context, tx *sql.Tx, taskIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMetricTaskBackfillsByTaskIDs", ctx, tx, taskIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMetricTaskBackfillsByTaskIDs indicates an expected call of DeleteMetricTaskBackfillsByTaskIDs.
+func (mr *MockMetricModelTaskAccessMockRecorder) DeleteMetricTaskBackfillsByTaskIDs(ctx, tx, taskIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMetricTaskBackfillsByTaskIDs", reflect.TypeOf((*MockMetricModelTaskAccess)(nil).DeleteMetricTaskBackfillsByTaskIDs), ctx, tx, taskIDs)
+}
+
 // DeleteMetricTaskByTaskIDs mocks base method.
 func (m *MockMetricModelTaskAccess) DeleteMetricTaskByTaskIDs(ctx context.Context, tx *sql.Tx, taskIDs []string) error {
 	m.ctrl.T.Helper()
@@ -84,6 +112,21 @@ func (mr *MockMetricModelTaskAccessMockRecorder) DeleteMetricTaskByTaskIDs(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMetricTaskByTaskIDs", reflect.TypeOf((*MockMetricModelTaskAccess)(nil).DeleteMetricTaskByTaskIDs), ctx, tx, taskIDs)
 }
 
+// GetMetricTaskBackfillsByTaskID mocks base method.
+func (m *MockMetricModelTaskAccess) GetMetricTaskBackfillsByTaskID(ctx context.Context, taskID string) ([]interfaces.MetricTaskBackfill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricTaskBackfillsByTaskID", ctx, taskID)
+	ret0, _ := ret[0].([]interfaces.MetricTaskBackfill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricTaskBackfillsByTaskID indicates an expected call of GetMetricTaskBackfillsByTaskID.
+func (mr *MockMetricModelTaskAccessMockRecorder) GetMetricTaskBackfillsByTaskID(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricTaskBackfillsByTaskID", reflect.TypeOf((*MockMetricModelTaskAccess)(nil).GetMetricTaskBackfillsByTaskID), ctx, taskID)
+}
+
 // GetMetricTaskIDsByModelIDs mocks base method.
 func (m *MockMetricModelTaskAccess) GetMetricTaskIDsByModelIDs(ctx context.Context, modelIDs []string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +70,20 @@ func (mr *MockMetricModelTaskServiceMockRecorder) CreateMetricTask(ctx, tx, task
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMetricTask", reflect.TypeOf((*MockMetricModelTaskService)(nil).CreateMetricTask), ctx, tx, tasks)
 }
 
+// CreateMetricTaskBackfill mocks base method.
+func (m *MockMetricModelTaskService) CreateMetricTaskBackfill(ctx context.Context, task interfaces.MetricTask, backfill *interfaces.MetricTaskBackfill) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMetricTaskBackfill", ctx, task, backfill)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMetricTaskBackfill indicates an expected call of CreateMetricTaskBackfill.
+func (mr *MockMetricModelTaskServiceMockRecorder) CreateMetricTaskBackfill(ctx, task, backfill interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMetricTaskBackfill", reflect.TypeOf((*MockMetricModelTaskService)(nil).CreateMetricTaskBackfill), ctx, task, backfill)
+}
+
 // DeleteMetricTaskByTaskIDs mocks base method.
 func (m *MockMetricModelTaskService) DeleteMetricTaskByTaskIDs(ctx context.Context, tx *sql.Tx, taskIDs []string) error {
 	m.ctrl.T.Helper()
@@ -84,6 +98,21 @@ func (mr *MockMetricModelTaskServiceMockRecorder) DeleteMetricTaskByTaskIDs(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMetricTaskByTaskIDs", reflect.TypeOf((*MockMetricModelTaskService)(nil).DeleteMetricTaskByTaskIDs), ctx, tx, taskIDs)
 }
 
+// GetMetricTaskBackfillsByTaskID mocks base method.
+func (m *MockMetricModelTaskService) GetMetricTaskBackfillsByTaskID(ctx context.Context, taskID string) ([]interfaces.MetricTaskBackfill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricTaskBackfillsByTaskID", ctx, taskID)
+	ret0, _ := ret[0].([]interfaces.MetricTaskBackfill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricTaskBackfillsByTaskID indicates an expected call of GetMetricTaskBackfillsByTaskID.
+func (mr *MockMetricModelTaskServiceMockRecorder) GetMetricTaskBackfillsByTaskID(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricTaskBackfillsByTaskID", reflect.TypeOf((*MockMetricModelTaskService)(nil).GetMetricTaskBackfillsByTaskID), ctx, taskID)
+}
+
 // GetMetricTaskIDsByModelIDs mocks base method.
 func (m *MockMetricModelTaskService) GetMetricTaskIDsByModelIDs(ctx context.Context, modelIDs []string) ([]string, error) {
 	m.ctrl.T.Helper()
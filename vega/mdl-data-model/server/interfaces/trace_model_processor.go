@@ -54,6 +54,29 @@ var (
 			Type: dtype.DataType_Text,
 		},
 	}
+	// OTel span额外提供的元数据
+	OTEL_SPAN_METADATA = []TraceModelField{
+		{
+			Name: "__attributes",
+			Type: dtype.DataType_Json,
+		},
+		{
+			Name: "__events",
+			Type: dtype.DataType_Json,
+		},
+		{
+			Name: "__links",
+			Type: dtype.DataType_Json,
+		},
+		{
+			Name: "__resource_attributes",
+			Type: dtype.DataType_Json,
+		},
+		{
+			Name: "__status_message",
+			Type: dtype.DataType_Text,
+		},
+	}
 	RELATED_LOG_METADATA = []TraceModelField{
 		{
 			Name: "__span_id",
@@ -25,8 +25,12 @@ const (
 	RELATED_LOG_CLOSE           uint8  = 0
 	SOURCE_TYPE_DATA_VIEW       string = "data_view"
 	SOURCE_TYPE_DATA_CONNECTION string = "data_connection"
+	SOURCE_TYPE_OTEL            string = "otel"
 	QUERY_CATEGORY_SPAN         string = "span"
 	QUERY_CATEGORY_RELATED_LOG  string = "related_log"
+
+	// 未指定数据视图时OTel span所在索引的默认模式
+	OTEL_SPAN_INDEX_PATTERN string = "otel-v1-apm-span-*"
 )
 
 var (
@@ -49,6 +53,35 @@ var (
 	VALID_FIELD_TYPES_FOR_STATUS         = []string{dtype.DataType_Text, dtype.DataType_String}
 	VALID_FIELD_TYPES_FOR_SERVICE_NAME   = []string{dtype.DataType_Text, dtype.DataType_String}
 	VALID_PRECONDITION_VALUE_FROM        = []string{dcond.ValueFrom_Const, dcond.ValueFrom_Field}
+
+	// OTel span索引(Data Prepper otel-v1-apm-span)中链路模型必需的字段及其合法类型
+	OTEL_SPAN_REQUIRED_FIELDS = map[string][]string{
+		"traceId":      {dtype.DataType_Text, dtype.DataType_String},
+		"spanId":       {dtype.DataType_Text, dtype.DataType_String},
+		"parentSpanId": {dtype.DataType_Text, dtype.DataType_String},
+		"name":         {dtype.DataType_Text, dtype.DataType_String},
+		"startTime":    {dtype.DataType_Datetime, dtype.DataType_Timestamp},
+		"endTime":      {dtype.DataType_Datetime, dtype.DataType_Timestamp},
+	}
+
+	// 自动创建的OTel span视图的字段
+	OTEL_SPAN_VIEW_FIELDS = []TraceModelField{
+		{Name: "traceId", Type: dtype.DataType_String},
+		{Name: "spanId", Type: dtype.DataType_String},
+		{Name: "parentSpanId", Type: dtype.DataType_String},
+		{Name: "name", Type: dtype.DataType_String},
+		{Name: "kind", Type: dtype.DataType_String},
+		{Name: "startTime", Type: dtype.DataType_Datetime},
+		{Name: "endTime", Type: dtype.DataType_Datetime},
+		{Name: "durationInNanos", Type: dtype.DataType_Integer},
+		{Name: "serviceName", Type: dtype.DataType_String},
+		{Name: "traceGroup", Type: dtype.DataType_String},
+		{Name: "status.code", Type: dtype.DataType_Integer},
+		{Name: "status.message", Type: dtype.DataType_String},
+		{Name: "events", Type: dtype.DataType_Json},
+		{Name: "links", Type: dtype.DataType_Json},
+		{Name: "resource.attributes.service@name", Type: dtype.DataType_String},
+	}
 )
 
 // 链路模型列表项
@@ -112,6 +145,14 @@ type SpanConfigWithDataConnection struct {
 	DataConnection DataConnectionConfig `json:"data_connection"`
 }
 
+// OTel span配置, 数据视图需基于OTel/Jaeger的span索引, 无需配置字段映射.
+// 未指定数据视图时, 在data_source_id对应的OpenSearch数据源上按index_pattern查找或创建视图
+type SpanConfigWithOTel struct {
+	DataView     DataViewConfig `json:"data_view"`
+	DataSourceID string         `json:"data_source_id,omitempty"`
+	IndexPattern string         `json:"index_pattern,omitempty"`
+}
+
 // span关联日志配置
 type RelatedLogConfigWithDataView struct {
 	DataView DataViewConfig `json:"data_view"`
@@ -8,9 +8,17 @@ package metric_model
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"net/http"
 	"sync"
+	"time"
+
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"github.com/rs/xid"
 
 	"data-model/common"
+	derrors "data-model/errors"
 	"data-model/interfaces"
 	"data-model/logics"
 )
@@ -22,6 +30,7 @@ var (
 
 type metricModelTaskService struct {
 	appSetting *common.AppSetting
+	dmja       interfaces.DataModelJobAccess
 	mmta       interfaces.MetricModelTaskAccess
 }
 
@@ -29,6 +38,7 @@ func NewMetricModelTaskService(appSetting *common.AppSetting) interfaces.MetricM
 	mmtServiceOnce.Do(func() {
 		mmtService = &metricModelTaskService{
 			appSetting: appSetting,
+			dmja:       logics.DMJA,
 			mmta:       logics.MMTA,
 		}
 	})
@@ -84,12 +94,110 @@ func (mmts *metricModelTaskService) UpdateMetricTaskAttributes(ctx context.Conte
 	return mmts.mmta.UpdateMetricTaskAttributes(ctx, task)
 }
 
-// 根据任务id，物理删除任务
+// 根据任务id，物理删除任务及其历史数据回溯记录
 func (mmts *metricModelTaskService) DeleteMetricTaskByTaskIDs(ctx context.Context, tx *sql.Tx, taskIDs []string) error {
-	return mmts.mmta.DeleteMetricTaskByTaskIDs(ctx, tx, taskIDs)
+	err := mmts.mmta.DeleteMetricTaskByTaskIDs(ctx, tx, taskIDs)
+	if err != nil {
+		return err
+	}
+	return mmts.mmta.DeleteMetricTaskBackfillsByTaskIDs(ctx, tx, taskIDs)
 }
 
 // 校验模型下的任务名称是否已存在
 func (mmts *metricModelTaskService) CheckMetricModelTaskExistByName(ctx context.Context, task interfaces.MetricTask, deleteTaskIDs []string) (bool, error) {
 	return mmts.mmta.CheckMetricModelTaskExistByName(ctx, task, deleteTaskIDs)
 }
+
+// 创建历史数据回溯，记录落库后请求 data-model-job 开始执行。
+// 请求失败时不报错，data-model-job 会在下一轮巡检时从数据库中恢复未完成的回溯。
+func (mmts *metricModelTaskService) CreateMetricTaskBackfill(ctx context.Context, task interfaces.MetricTask,
+	backfill *interfaces.MetricTaskBackfill) error {
+
+	if task.ModuleType != interfaces.MODULE_TYPE_METRIC_MODEL {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_MetricModel_InvalidParameter).
+			WithErrorDetails(fmt.Sprintf("Backfill is only supported by metric model task, actual module type is %s", task.ModuleType))
+	}
+
+	// 回溯的步长需是任务步长的子集，未指定时回溯任务的全部步长
+	if len(backfill.Steps) == 0 {
+		backfill.Steps = task.Steps
+	} else {
+		taskSteps := make(map[string]struct{}, len(task.Steps))
+		for _, step := range task.Steps {
+			taskSteps[step] = struct{}{}
+		}
+		for _, step := range backfill.Steps {
+			if _, ok := taskSteps[step]; !ok {
+				return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_MetricModel_InvalidParameter_Step).
+					WithErrorDetails(fmt.Sprintf("Backfill step must be one of task steps %v, actual is %s", task.Steps, step))
+			}
+		}
+	}
+
+	accountInfo := interfaces.AccountInfo{}
+	if ctx.Value(interfaces.ACCOUNT_INFO_KEY) != nil {
+		accountInfo = ctx.Value(interfaces.ACCOUNT_INFO_KEY).(interfaces.AccountInfo)
+	}
+
+	now := time.Now().UnixMilli()
+	backfill.BackfillID = xid.New().String()
+	backfill.TaskID = task.TaskID
+	backfill.Status = interfaces.BACKFILL_STATUS_PENDING
+	backfill.Checkpoint = backfill.Start
+	backfill.ErrorDetails = ""
+	backfill.CreateTime = now
+	backfill.UpdateTime = now
+	backfill.Creator = accountInfo
+
+	err := mmts.mmta.CreateMetricTaskBackfill(ctx, *backfill)
+	if err != nil {
+		logger.Errorf("CreateMetricTaskBackfill error: %s", err.Error())
+		return rest.NewHTTPError(ctx, http.StatusInternalServerError, derrors.DataModel_MetricModel_InternalError_CreateMetricTaskBackfillFailed).
+			WithErrorDetails(err.Error())
+	}
+
+	jobCfg := &interfaces.DataModelJobCfg{
+		JobID:      backfill.BackfillID,
+		JobType:    interfaces.JOB_TYPE_BACKFILL,
+		ModuleType: interfaces.MODULE_TYPE_METRIC_MODEL,
+		MetricTask: &task,
+		Backfill:   backfill,
+	}
+	logger.Infof("metric task %s request data-model-job start backfill %s", task.TaskID, backfill.BackfillID)
+	uncancelableCtx := context.WithoutCancel(ctx)
+	go func() {
+		err := mmts.dmja.StartJob(uncancelableCtx, jobCfg)
+		if err != nil {
+			logger.Errorf("Start metric task backfill[%s] failed: %s", jobCfg.JobID, err.Error())
+		}
+	}()
+
+	return nil
+}
+
+// 按任务id获取历史数据回溯记录，并根据 checkpoint 计算进度
+func (mmts *metricModelTaskService) GetMetricTaskBackfillsByTaskID(ctx context.Context, taskID string) ([]interfaces.MetricTaskBackfill, error) {
+	backfills, err := mmts.mmta.GetMetricTaskBackfillsByTaskID(ctx, taskID)
+	if err != nil {
+		return backfills, err
+	}
+
+	for i := range backfills {
+		backfills[i].Progress = calcBackfillProgress(backfills[i])
+	}
+	return backfills, nil
+}
+
+// 回溯进度，取值 [0, 1]
+func calcBackfillProgress(backfill interfaces.MetricTaskBackfill) float64 {
+	if backfill.Status == interfaces.BACKFILL_STATUS_SUCCESS {
+		return 1
+	}
+	if backfill.End <= backfill.Start || backfill.Checkpoint <= backfill.Start {
+		return 0
+	}
+	if backfill.Checkpoint >= backfill.End {
+		return 1
+	}
+	return float64(backfill.Checkpoint-backfill.Start) / float64(backfill.End-backfill.Start)
+}
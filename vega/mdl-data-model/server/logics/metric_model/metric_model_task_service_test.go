@@ -6,13 +6,17 @@
 package metric_model
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
 	. "github.com/smartystreets/goconvey/convey"
 
 	"data-model/common"
+	derrors "data-model/errors"
 	"data-model/interfaces"
 	dmock "data-model/interfaces/mock"
 )
@@ -175,10 +179,110 @@ func Test_MetricTaskService_DeleteMetricTaskByTaskID(t *testing.T) {
 		Convey("DeleteMetricTaskByTaskID success", func() {
 			smock.ExpectBegin()
 			mmta.EXPECT().DeleteMetricTaskByTaskIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mmta.EXPECT().DeleteMetricTaskBackfillsByTaskIDs(gomock.Any(), gomock.Any(), []string{"1"}).Return(nil)
 
 			tx, _ := db.Begin()
 			httpErr := mmts.DeleteMetricTaskByTaskIDs(testCtx, tx, []string{"1"})
 			So(httpErr, ShouldBeNil)
 		})
+
+		Convey("DeleteMetricTaskByTaskID failed, cause by delete task error", func() {
+			smock.ExpectBegin()
+			mmta.EXPECT().DeleteMetricTaskByTaskIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error"))
+
+			tx, _ := db.Begin()
+			httpErr := mmts.DeleteMetricTaskByTaskIDs(testCtx, tx, []string{"1"})
+			So(httpErr, ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_MetricTaskService_CreateMetricTaskBackfill(t *testing.T) {
+	Convey("Test CreateMetricTaskBackfill", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		mmta := dmock.NewMockMetricModelTaskAccess(mockCtrl)
+		dmja := dmock.NewMockDataModelJobAccess(mockCtrl)
+		mmts := &metricModelTaskService{
+			appSetting: appSetting,
+			dmja:       dmja,
+			mmta:       mmta,
+		}
+
+		Convey("failed, cause by objective model task", func() {
+			objectiveTask := task
+			objectiveTask.ModuleType = interfaces.MODULE_TYPE_OBJECTIVE_MODEL
+
+			err := mmts.CreateMetricTaskBackfill(testCtx, objectiveTask, &interfaces.MetricTaskBackfill{Start: 1, End: 2})
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_MetricModel_InvalidParameter)
+		})
+
+		Convey("failed, cause by step not in task steps", func() {
+			err := mmts.CreateMetricTaskBackfill(testCtx, task, &interfaces.MetricTaskBackfill{Start: 1, End: 2, Steps: []string{"1d"}})
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_MetricModel_InvalidParameter_Step)
+		})
+
+		Convey("failed, cause by CreateMetricTaskBackfill error", func() {
+			mmta.EXPECT().CreateMetricTaskBackfill(gomock.Any(), gomock.Any()).Return(errors.New("error"))
+
+			err := mmts.CreateMetricTaskBackfill(testCtx, task, &interfaces.MetricTaskBackfill{Start: 1, End: 2})
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_MetricModel_InternalError_CreateMetricTaskBackfillFailed)
+		})
+
+		Convey("success, default to all task steps", func() {
+			started := make(chan *interfaces.DataModelJobCfg, 1)
+			mmta.EXPECT().CreateMetricTaskBackfill(gomock.Any(), gomock.Any()).Return(nil)
+			dmja.EXPECT().StartJob(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, job *interfaces.DataModelJobCfg) error {
+					started <- job
+					return nil
+				})
+
+			backfill := &interfaces.MetricTaskBackfill{Start: 1, End: 2, Concurrency: 2}
+			err := mmts.CreateMetricTaskBackfill(testCtx, task, backfill)
+			So(err, ShouldBeNil)
+			So(backfill.BackfillID, ShouldNotBeEmpty)
+			So(backfill.Steps, ShouldResemble, task.Steps)
+			So(backfill.Status, ShouldEqual, interfaces.BACKFILL_STATUS_PENDING)
+			So(backfill.Checkpoint, ShouldEqual, int64(1))
+
+			job := <-started
+			So(job.JobID, ShouldEqual, backfill.BackfillID)
+			So(job.JobType, ShouldEqual, interfaces.JOB_TYPE_BACKFILL)
+		})
+	})
+}
+
+func Test_MetricTaskService_GetMetricTaskBackfillsByTaskID(t *testing.T) {
+	Convey("Test GetMetricTaskBackfillsByTaskID", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		mmta := dmock.NewMockMetricModelTaskAccess(mockCtrl)
+		mmts := MockNewMetricModelTaskService(appSetting, mmta)
+
+		Convey("success, calculate progress by checkpoint", func() {
+			mmta.EXPECT().GetMetricTaskBackfillsByTaskID(gomock.Any(), "1").Return([]interfaces.MetricTaskBackfill{
+				{BackfillID: "a", Start: 0, End: 100, Checkpoint: 25, Status: interfaces.BACKFILL_STATUS_RUNNING},
+				{BackfillID: "b", Start: 0, End: 100, Checkpoint: 0, Status: interfaces.BACKFILL_STATUS_PENDING},
+				{BackfillID: "c", Start: 0, End: 100, Checkpoint: 90, Status: interfaces.BACKFILL_STATUS_SUCCESS},
+			}, nil)
+
+			backfills, err := mmts.GetMetricTaskBackfillsByTaskID(testCtx, "1")
+			So(err, ShouldBeNil)
+			So(backfills[0].Progress, ShouldEqual, 0.25)
+			So(backfills[1].Progress, ShouldEqual, 0)
+			So(backfills[2].Progress, ShouldEqual, 1)
+		})
+
+		Convey("failed", func() {
+			mmta.EXPECT().GetMetricTaskBackfillsByTaskID(gomock.Any(), "1").Return(nil, errors.New("error"))
+
+			_, err := mmts.GetMetricTaskBackfillsByTaskID(testCtx, "1")
+			So(err, ShouldNotBeNil)
+		})
 	})
 }
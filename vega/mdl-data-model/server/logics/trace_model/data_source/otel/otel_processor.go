@@ -0,0 +1,94 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package otel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"go.opentelemetry.io/otel/codes"
+
+	"data-model/common"
+	derrors "data-model/errors"
+	"data-model/interfaces"
+	"data-model/logics/data_view"
+)
+
+var (
+	otelProcessorOnce sync.Once
+	otelProcessor     interfaces.TraceModelProcessor
+)
+
+type otelTraceProcessor struct {
+	appSetting *common.AppSetting
+	dvService  interfaces.DataViewService
+}
+
+func NewOTelTraceProcessor(appSetting *common.AppSetting) interfaces.TraceModelProcessor {
+	otelProcessorOnce.Do(func() {
+		otelProcessor = &otelTraceProcessor{
+			appSetting: appSetting,
+			dvService:  data_view.NewDataViewService(appSetting),
+		}
+	})
+	return otelProcessor
+}
+
+func (otelp *otelTraceProcessor) GetSpanFieldInfo(ctx context.Context, model interfaces.TraceModel) (fieldInfos []interfaces.TraceModelField, err error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic层: 查询Span字段信息")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		span.End()
+	}()
+
+	spanConf, _ := model.SpanConfig.(interfaces.SpanConfigWithOTel)
+	views, err := otelp.dvService.GetDataViews(ctx, []string{spanConf.DataView.ID}, false)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		if httpErr.HTTPCode == http.StatusNotFound {
+			errDetails := fmt.Sprintf("The data view whose id equal to %v was not found", spanConf.DataView.ID)
+			logger.Errorf(errDetails)
+			o11y.Error(ctx, errDetails)
+			return fieldInfos, rest.NewHTTPError(ctx, http.StatusInternalServerError, derrors.DataModel_TraceModel_DependentDataViewNotFound).
+				WithErrorDetails(errDetails)
+		}
+		return fieldInfos, err
+	}
+
+	// 除通用的span元数据外, 还有OTel的属性、事件、链接等元数据
+	fieldInfos = make([]interfaces.TraceModelField, 0, len(interfaces.SPAN_METADATA)+len(interfaces.OTEL_SPAN_METADATA)+len(views[0].Fields))
+	fieldInfos = append(fieldInfos, interfaces.SPAN_METADATA...)
+	fieldInfos = append(fieldInfos, interfaces.OTEL_SPAN_METADATA...)
+	for _, field := range views[0].Fields {
+		fieldInfos = append(fieldInfos, interfaces.TraceModelField{
+			Name: field.Name,
+			Type: field.Type,
+		})
+	}
+
+	return fieldInfos, nil
+}
+
+// 关联日志的字段信息由related_log_source_type对应的processor获取
+func (otelp *otelTraceProcessor) GetRelatedLogFieldInfo(ctx context.Context, model interfaces.TraceModel) (fieldInfos []interfaces.TraceModelField, err error) {
+	_, span := ar_trace.Tracer.Start(ctx, "logic层: 查询Span关联日志字段信息")
+	defer func() {
+		span.SetStatus(codes.Ok, "")
+		span.End()
+	}()
+
+	return []interfaces.TraceModelField(nil), nil
+}
@@ -0,0 +1,95 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package otel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"data-model/common"
+	derrors "data-model/errors"
+	"data-model/interfaces"
+	dmock "data-model/interfaces/mock"
+)
+
+var (
+	testCtx = context.WithValue(context.Background(), rest.XLangKey, rest.DefaultLanguage)
+)
+
+func MockNewOTelTraceProcessor(appSetting *common.AppSetting,
+	dvService interfaces.DataViewService) *otelTraceProcessor {
+	return &otelTraceProcessor{
+		appSetting: appSetting,
+		dvService:  dvService,
+	}
+}
+
+func Test_OTelProcessor_GetSpanFieldInfo(t *testing.T) {
+	Convey("Test GetSpanFieldInfo", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		dvs := dmock.NewMockDataViewService(mockCtrl)
+		otelp := MockNewOTelTraceProcessor(appSetting, dvs)
+
+		model := interfaces.TraceModel{
+			SpanSourceType: interfaces.SOURCE_TYPE_OTEL,
+			SpanConfig: interfaces.SpanConfigWithOTel{
+				DataView: interfaces.DataViewConfig{
+					Name: "1",
+					ID:   "1",
+				},
+			},
+		}
+
+		Convey("Get failed, caused by the data view not found", func() {
+			expectedErr := rest.NewHTTPError(testCtx, http.StatusNotFound, derrors.DataModel_DataView_InternalError_GetDataViewsFailed)
+			dvs.EXPECT().GetDataViews(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*interfaces.DataView{}, expectedErr)
+
+			_, err := otelp.GetSpanFieldInfo(testCtx, model)
+			So(err, ShouldResemble, rest.NewHTTPError(testCtx, http.StatusInternalServerError, derrors.DataModel_TraceModel_DependentDataViewNotFound).
+				WithErrorDetails(fmt.Sprintf("The data view whose id equal to %v was not found", "1")))
+		})
+
+		Convey("Get failed, caused by the other error from method `GetDataViews`", func() {
+			expectedErr := rest.NewHTTPError(testCtx, http.StatusInternalServerError, derrors.DataModel_DataView_InternalError_GetDataViewsFailed)
+			dvs.EXPECT().GetDataViews(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*interfaces.DataView{}, expectedErr)
+
+			_, err := otelp.GetSpanFieldInfo(testCtx, model)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Get succeed", func() {
+			dvs.EXPECT().GetDataViews(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*interfaces.DataView{{
+				Fields: []*interfaces.ViewField{
+					{Name: "traceId", Type: "keyword"},
+				},
+			}}, nil)
+
+			fieldInfos, err := otelp.GetSpanFieldInfo(testCtx, model)
+			So(err, ShouldBeNil)
+			So(len(fieldInfos), ShouldEqual, len(interfaces.SPAN_METADATA)+len(interfaces.OTEL_SPAN_METADATA)+1)
+			So(fieldInfos[len(fieldInfos)-1], ShouldResemble, interfaces.TraceModelField{Name: "traceId", Type: "keyword"})
+		})
+	})
+}
+
+func Test_OTelProcessor_GetRelatedLogFieldInfo(t *testing.T) {
+	Convey("Test GetRelatedLogFieldInfo", t, func() {
+		otelp := MockNewOTelTraceProcessor(&common.AppSetting{}, nil)
+
+		fieldInfos, err := otelp.GetRelatedLogFieldInfo(testCtx, interfaces.TraceModel{})
+		So(err, ShouldBeNil)
+		So(fieldInfos, ShouldBeNil)
+	})
+}
@@ -16,6 +16,7 @@ import (
 	derrors "data-model/errors"
 	"data-model/interfaces"
 	"data-model/logics/trace_model/data_source/data_view"
+	"data-model/logics/trace_model/data_source/otel"
 	"data-model/logics/trace_model/data_source/tingyun"
 )
 
@@ -23,6 +24,8 @@ func NewTraceModelProcessor(ctx context.Context, appSetting *common.AppSetting,
 	switch dataSourceType {
 	case interfaces.SOURCE_TYPE_DATA_VIEW:
 		return data_view.NewDataViewTraceProcessor(appSetting), nil
+	case interfaces.SOURCE_TYPE_OTEL:
+		return otel.NewOTelTraceProcessor(appSetting), nil
 	case interfaces.SOURCE_TYPE_TINGYUN:
 		return tingyun.NewTingYunTraceProcessor(appSetting), nil
 	default:
@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -41,6 +42,7 @@ type traceModelService struct {
 	dvs        interfaces.DataViewService
 	ps         interfaces.PermissionService
 	dcs        interfaces.DataConnectionService
+	dsa        interfaces.DataSourceAccess
 	tma        interfaces.TraceModelAccess
 }
 
@@ -51,6 +53,7 @@ func NewTraceModelService(appSetting *common.AppSetting) interfaces.TraceModelSe
 			dvs:        data_view.NewDataViewService(appSetting),
 			ps:         permission.NewPermissionService(appSetting),
 			dcs:        data_connection.NewDataConnectionService(appSetting),
+			dsa:        logics.DSA,
 			tma:        logics.TMA,
 		}
 	})
@@ -78,6 +81,12 @@ func (tms *traceModelService) CreateTraceModels(ctx context.Context, reqModels [
 		return nil, err
 	}
 
+	// 未指定数据视图的OTel链路模型, 使用OTel span索引上的视图
+	err = tms.provisionOTelSpanViews(ctx, reqModels, true)
+	if err != nil {
+		return nil, err
+	}
+
 	// 1. 根据数据视图 ID 获取对应的viewMap
 	viewIDs := tms.getDependentViewIDs(reqModels)
 	viewMap, err := tms.getDetailedViewMapByIDs(ctx, viewIDs)
@@ -155,8 +164,14 @@ func (tms *traceModelService) SimulateCreateTraceModel(ctx context.Context, reqM
 		return interfaces.TraceModel{}, err
 	}
 
-	// 1. 根据数据视图 ID 获取对应的viewMap
 	reqModels := []interfaces.TraceModel{reqModel}
+	// 未指定数据视图的OTel链路模型, 使用OTel span索引上的视图
+	err = tms.provisionOTelSpanViews(ctx, reqModels, false)
+	if err != nil {
+		return reqModels[0], err
+	}
+
+	// 1. 根据数据视图 ID 获取对应的viewMap
 	viewIDs := tms.getDependentViewIDs(reqModels)
 	viewMap, err := tms.getDetailedViewMapByIDs(ctx, viewIDs)
 	if err != nil {
@@ -244,8 +259,14 @@ func (tms *traceModelService) UpdateTraceModel(ctx context.Context, reqModel int
 		return err
 	}
 
-	// 1. 根据数据视图 ID 获取对应的viewMap
 	reqModels := []interfaces.TraceModel{reqModel}
+	// 未指定数据视图的OTel链路模型, 使用OTel span索引上的视图
+	err = tms.provisionOTelSpanViews(ctx, reqModels, true)
+	if err != nil {
+		return err
+	}
+
+	// 1. 根据数据视图 ID 获取对应的viewMap
 	viewIDs := tms.getDependentViewIDs(reqModels)
 	viewMap, err := tms.getDetailedViewMapByIDs(ctx, viewIDs)
 	if err != nil {
@@ -312,8 +333,14 @@ func (tms *traceModelService) SimulateUpdateTraceModel(ctx context.Context, reqM
 		return interfaces.TraceModel{}, err
 	}
 
-	// 1. 根据数据视图 ID 获取对应的viewMap
 	reqModels := []interfaces.TraceModel{reqModel}
+	// 未指定数据视图的OTel链路模型, 使用OTel span索引上的视图
+	err = tms.provisionOTelSpanViews(ctx, reqModels, false)
+	if err != nil {
+		return reqModels[0], err
+	}
+
+	// 1. 根据数据视图 ID 获取对应的viewMap
 	viewIDs := tms.getDependentViewIDs(reqModels)
 	viewMap, err := tms.getDetailedViewMapByIDs(ctx, viewIDs)
 	if err != nil {
@@ -843,6 +870,17 @@ func (tms *traceModelService) validateReqTraceModels(ctx context.Context, reqMod
 				o11y.Error(ctx, err.Error())
 				return err
 			}
+		} else if model.SpanSourceType == interfaces.SOURCE_TYPE_OTEL {
+			// 校验数据视图是否包含OTel span的必需字段
+			// 待自动创建的视图字段固定, 无需校验
+			spanConf := model.SpanConfig.(interfaces.SpanConfigWithOTel)
+			if view, ok := viewMap[spanConf.DataView.ID]; ok {
+				err := tms.validateOTelSpanFields(ctx, view.FieldTypeMap)
+				if err != nil {
+					o11y.Error(ctx, err.Error())
+					return err
+				}
+			}
 		}
 
 		if model.EnabledRelatedLog == interfaces.RELATED_LOG_OPEN {
@@ -1022,6 +1060,117 @@ func (tms *traceModelService) validateSpanBasicAttrs(ctx context.Context, attrs
 	return nil
 }
 
+// 未指定数据视图的OTel链路模型, 在指定的OpenSearch数据源上按索引模式查找视图, create为true时不存在则创建
+func (tms *traceModelService) provisionOTelSpanViews(ctx context.Context, reqModels []interfaces.TraceModel, create bool) (err error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic层: 查找或创建OTel span视图")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		span.End()
+	}()
+
+	for i := range reqModels {
+		if reqModels[i].SpanSourceType != interfaces.SOURCE_TYPE_OTEL {
+			continue
+		}
+		spanConf := reqModels[i].SpanConfig.(interfaces.SpanConfigWithOTel)
+		if spanConf.DataView.ID != "" {
+			continue
+		}
+		if spanConf.IndexPattern == "" {
+			spanConf.IndexPattern = interfaces.OTEL_SPAN_INDEX_PATTERN
+		}
+
+		dataSource, err := tms.dsa.GetDataSourceByID(ctx, spanConf.DataSourceID)
+		if err != nil || dataSource == nil {
+			errDetails := fmt.Sprintf("The data source %v of the OTel span index was not found, err: %v", spanConf.DataSourceID, err)
+			logger.Errorf(errDetails)
+			o11y.Error(ctx, errDetails)
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_TraceModel_InvalidOTelSpanConfig).
+				WithErrorDetails(errDetails)
+		}
+		if dataSource.Type != interfaces.DataSourceType_OpenSearch {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_TraceModel_InvalidOTelSpanConfig).
+				WithErrorDetails(fmt.Sprintf("The type of data source %v is %v, valid type is %v",
+					spanConf.DataSourceID, dataSource.Type, interfaces.DataSourceType_OpenSearch))
+		}
+
+		// 原子视图以数据源名称为分组, 以索引模式为视图名称
+		viewID, exist, err := tms.dvs.CheckDataViewExistByName(ctx, nil, spanConf.IndexPattern, dataSource.Name)
+		if err != nil {
+			return err
+		}
+		if !exist && create {
+			viewIDs, err := tms.dvs.CreateDataViews(ctx, []*interfaces.DataView{newOTelSpanView(spanConf)},
+				interfaces.ImportMode_Normal, false)
+			if err != nil {
+				return err
+			}
+			viewID = viewIDs[0]
+		}
+
+		spanConf.DataView = interfaces.DataViewConfig{
+			ID:   viewID,
+			Name: spanConf.IndexPattern,
+		}
+		reqModels[i].SpanConfig = spanConf
+	}
+
+	return nil
+}
+
+// 生成OTel span索引上的原子视图, 分组、查询类型等由数据视图服务按数据源补全
+func newOTelSpanView(spanConf interfaces.SpanConfigWithOTel) *interfaces.DataView {
+	fields := make([]*interfaces.ViewField, 0, len(interfaces.OTEL_SPAN_VIEW_FIELDS))
+	for _, field := range interfaces.OTEL_SPAN_VIEW_FIELDS {
+		fields = append(fields, &interfaces.ViewField{
+			Name:         field.Name,
+			Type:         field.Type,
+			DisplayName:  field.Name,
+			OriginalName: field.Name,
+		})
+	}
+
+	return &interfaces.DataView{
+		SimpleDataView: interfaces.SimpleDataView{
+			ViewName:      spanConf.IndexPattern,
+			TechnicalName: spanConf.IndexPattern,
+			Type:          interfaces.ViewType_Atomic,
+			DataSourceID:  spanConf.DataSourceID,
+			Comment:       "OTel span",
+		},
+		Fields: fields,
+	}
+}
+
+// 校验OTel span所在数据视图的字段
+func (tms *traceModelService) validateOTelSpanFields(ctx context.Context, fieldMap map[string]string) error {
+	fieldNames := make([]string, 0, len(interfaces.OTEL_SPAN_REQUIRED_FIELDS))
+	for fieldName := range interfaces.OTEL_SPAN_REQUIRED_FIELDS {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		validTypes := interfaces.OTEL_SPAN_REQUIRED_FIELDS[fieldName]
+		fieldType, ok := fieldMap[fieldName]
+		if !ok {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_TraceModel_InvalidOTelSpanConfig).
+				WithErrorDetails(fmt.Sprintf("Field %v does not exist in the selected data view", fieldName))
+		}
+
+		if !tms.isValidFieldType(fieldType, validTypes) {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, derrors.DataModel_TraceModel_InvalidOTelSpanConfig).
+				WithErrorDetails(fmt.Sprintf("The type of field %v is invalid, valid type is in %v", fieldName, validTypes))
+		}
+	}
+
+	return nil
+}
+
 // 校验是否为合法的字段类型
 func (tms *traceModelService) isValidFieldType(fieldType string, validDataSet []string) bool {
 	for _, validType := range validDataSet {
@@ -1123,6 +1272,9 @@ func (tms *traceModelService) modifyReqModels(ctx context.Context, isUpdate bool
 		if reqModels[i].SpanSourceType == interfaces.SOURCE_TYPE_DATA_VIEW {
 			spanConf := reqModels[i].SpanConfig.(interfaces.SpanConfigWithDataView)
 			reqModels[i].SpanConfig = spanConf
+		} else if reqModels[i].SpanSourceType == interfaces.SOURCE_TYPE_OTEL {
+			spanConf := reqModels[i].SpanConfig.(interfaces.SpanConfigWithOTel)
+			reqModels[i].SpanConfig = spanConf
 		} else {
 			// 1. 更新Span所在数据连接的ID
 			spanConf := reqModels[i].SpanConfig.(interfaces.SpanConfigWithDataConnection)
@@ -1160,6 +1312,12 @@ func (tms *traceModelService) getDependentViewIDs(resModels []interfaces.TraceMo
 			spanConf := resModel.SpanConfig.(interfaces.SpanConfigWithDataView)
 			// span所在数据视图
 			m[spanConf.DataView.ID] = struct{}{}
+		} else if resModel.SpanSourceType == interfaces.SOURCE_TYPE_OTEL {
+			spanConf := resModel.SpanConfig.(interfaces.SpanConfigWithOTel)
+			// 模拟创建时自动创建的视图还不存在
+			if spanConf.DataView.ID != "" {
+				m[spanConf.DataView.ID] = struct{}{}
+			}
 		}
 
 		if resModel.EnabledRelatedLog == interfaces.RELATED_LOG_OPEN {
@@ -1278,6 +1436,12 @@ func (tms *traceModelService) modifyResModels(ctx context.Context, viewMap map[s
 				spanConf.DataView.Name = view.ViewName
 				resModels[i].SpanConfig = spanConf
 			}
+		} else if resModels[i].SpanSourceType == interfaces.SOURCE_TYPE_OTEL {
+			spanConf := resModels[i].SpanConfig.(interfaces.SpanConfigWithOTel)
+			if view, ok := viewMap[spanConf.DataView.ID]; ok {
+				spanConf.DataView.Name = view.ViewName
+				resModels[i].SpanConfig = spanConf
+			}
 		} else {
 			spanConf := resModels[i].SpanConfig.(interfaces.SpanConfigWithDataConnection)
 			connID := spanConf.DataConnection.ID
@@ -1313,6 +1477,8 @@ func (tms *traceModelService) getUnderlyingDataSourceType(ctx context.Context, q
 
 	if queryCategory == interfaces.QUERY_CATEGORY_SPAN && model.SpanSourceType == interfaces.SOURCE_TYPE_DATA_VIEW {
 		return interfaces.SOURCE_TYPE_DATA_VIEW, nil
+	} else if queryCategory == interfaces.QUERY_CATEGORY_SPAN && model.SpanSourceType == interfaces.SOURCE_TYPE_OTEL {
+		return interfaces.SOURCE_TYPE_OTEL, nil
 	} else if queryCategory == interfaces.QUERY_CATEGORY_RELATED_LOG && model.RelatedLogSourceType == interfaces.SOURCE_TYPE_DATA_VIEW {
 		return interfaces.SOURCE_TYPE_DATA_VIEW, nil
 	} else { // queryCategory == interfaces.QUERY_CATEGORY_SPAN && model.SpanSourceType == interfaces.SOURCE_TYPE_DATA_CONNECTION
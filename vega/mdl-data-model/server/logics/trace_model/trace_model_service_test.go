@@ -22,6 +22,7 @@ import (
 	derrors "data-model/errors"
 	"data-model/interfaces"
 	dcond "data-model/interfaces/condition"
+	dtype "data-model/interfaces/data_type"
 	dmock "data-model/interfaces/mock"
 	"data-model/logics/trace_model/data_source"
 )
@@ -2924,6 +2925,162 @@ func Test_TraceModelService_ValidateSpanBasicAttrs(t *testing.T) {
 	})
 }
 
+func Test_TraceModelService_ValidateOTelSpanFields(t *testing.T) {
+	Convey("Test ValidateOTelSpanFields", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		tma := dmock.NewMockTraceModelAccess(mockCtrl)
+		dvs := dmock.NewMockDataViewService(mockCtrl)
+		dcs := dmock.NewMockDataConnectionService(mockCtrl)
+		ps := dmock.NewMockPermissionService(mockCtrl)
+		tms := MockNewTraceModelService(appSetting, tma, dvs, dcs, ps)
+
+		fieldMap := map[string]string{
+			"traceId":      dtype.DataType_String,
+			"spanId":       dtype.DataType_String,
+			"parentSpanId": dtype.DataType_String,
+			"name":         dtype.DataType_Text,
+			"startTime":    dtype.DataType_Datetime,
+			"endTime":      dtype.DataType_Datetime,
+		}
+
+		Convey("Validate failed, because the field does not exist", func() {
+			delete(fieldMap, "endTime")
+			err := tms.validateOTelSpanFields(testCtx, fieldMap)
+			So(err, ShouldResemble, rest.NewHTTPError(testCtx, http.StatusBadRequest, derrors.DataModel_TraceModel_InvalidOTelSpanConfig).
+				WithErrorDetails("Field endTime does not exist in the selected data view"))
+		})
+
+		Convey("Validate failed, because the field type is invalid", func() {
+			fieldMap["startTime"] = dtype.DataType_String
+			err := tms.validateOTelSpanFields(testCtx, fieldMap)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_TraceModel_InvalidOTelSpanConfig)
+		})
+
+		Convey("Validate succeed", func() {
+			err := tms.validateOTelSpanFields(testCtx, fieldMap)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func Test_TraceModelService_ProvisionOTelSpanViews(t *testing.T) {
+	Convey("Test ProvisionOTelSpanViews", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		tma := dmock.NewMockTraceModelAccess(mockCtrl)
+		dvs := dmock.NewMockDataViewService(mockCtrl)
+		dcs := dmock.NewMockDataConnectionService(mockCtrl)
+		ps := dmock.NewMockPermissionService(mockCtrl)
+		dsa := dmock.NewMockDataSourceAccess(mockCtrl)
+		tms := MockNewTraceModelService(appSetting, tma, dvs, dcs, ps)
+		tms.dsa = dsa
+
+		dataSource := &interfaces.DataSource{ID: "ds1", Name: "opensearch", Type: interfaces.DataSourceType_OpenSearch}
+		newReqModels := func() []interfaces.TraceModel {
+			return []interfaces.TraceModel{
+				{
+					SpanSourceType: interfaces.SOURCE_TYPE_OTEL,
+					SpanConfig:     interfaces.SpanConfigWithOTel{DataSourceID: "ds1"},
+				},
+			}
+		}
+
+		Convey("Skip models which specify the data view", func() {
+			reqModels := []interfaces.TraceModel{
+				{
+					SpanSourceType: interfaces.SOURCE_TYPE_OTEL,
+					SpanConfig:     interfaces.SpanConfigWithOTel{DataView: interfaces.DataViewConfig{ID: "v1", Name: "spans"}},
+				},
+				{
+					SpanSourceType: interfaces.SOURCE_TYPE_DATA_VIEW,
+					SpanConfig:     interfaces.SpanConfigWithDataView{},
+				},
+			}
+			err := tms.provisionOTelSpanViews(testCtx, reqModels, true)
+			So(err, ShouldBeNil)
+			So(reqModels[0].SpanConfig.(interfaces.SpanConfigWithOTel).DataView.ID, ShouldEqual, "v1")
+		})
+
+		Convey("Provision failed, because the data source is not opensearch", func() {
+			dsa.EXPECT().GetDataSourceByID(gomock.Any(), "ds1").
+				Return(&interfaces.DataSource{ID: "ds1", Type: "mysql"}, nil)
+
+			err := tms.provisionOTelSpanViews(testCtx, newReqModels(), true)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_TraceModel_InvalidOTelSpanConfig)
+		})
+
+		Convey("Provision failed, because the data source does not exist", func() {
+			dsa.EXPECT().GetDataSourceByID(gomock.Any(), "ds1").Return(nil, errors.New("not found"))
+
+			err := tms.provisionOTelSpanViews(testCtx, newReqModels(), true)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, derrors.DataModel_TraceModel_InvalidOTelSpanConfig)
+		})
+
+		Convey("Use the existing view on the default index pattern", func() {
+			dsa.EXPECT().GetDataSourceByID(gomock.Any(), "ds1").Return(dataSource, nil)
+			dvs.EXPECT().CheckDataViewExistByName(gomock.Any(), gomock.Any(), interfaces.OTEL_SPAN_INDEX_PATTERN, "opensearch").
+				Return("v1", true, nil)
+
+			reqModels := newReqModels()
+			err := tms.provisionOTelSpanViews(testCtx, reqModels, true)
+			So(err, ShouldBeNil)
+			spanConf := reqModels[0].SpanConfig.(interfaces.SpanConfigWithOTel)
+			So(spanConf.DataView, ShouldResemble, interfaces.DataViewConfig{ID: "v1", Name: interfaces.OTEL_SPAN_INDEX_PATTERN})
+			So(spanConf.IndexPattern, ShouldEqual, interfaces.OTEL_SPAN_INDEX_PATTERN)
+		})
+
+		Convey("Create the view when it does not exist", func() {
+			dsa.EXPECT().GetDataSourceByID(gomock.Any(), "ds1").Return(dataSource, nil)
+			dvs.EXPECT().CheckDataViewExistByName(gomock.Any(), gomock.Any(), "spans-*", "opensearch").
+				Return("", false, nil)
+			dvs.EXPECT().CreateDataViews(gomock.Any(), gomock.Any(), interfaces.ImportMode_Normal, false).
+				DoAndReturn(func(_ context.Context, views []*interfaces.DataView, _ string, _ bool) ([]string, error) {
+					So(views[0].TechnicalName, ShouldEqual, "spans-*")
+					So(views[0].Type, ShouldEqual, interfaces.ViewType_Atomic)
+					So(views[0].DataSourceID, ShouldEqual, "ds1")
+					So(views[0].Fields, ShouldHaveLength, len(interfaces.OTEL_SPAN_VIEW_FIELDS))
+					return []string{"v2"}, nil
+				})
+
+			reqModels := []interfaces.TraceModel{
+				{
+					SpanSourceType: interfaces.SOURCE_TYPE_OTEL,
+					SpanConfig:     interfaces.SpanConfigWithOTel{DataSourceID: "ds1", IndexPattern: "spans-*"},
+				},
+			}
+			err := tms.provisionOTelSpanViews(testCtx, reqModels, true)
+			So(err, ShouldBeNil)
+			So(reqModels[0].SpanConfig.(interfaces.SpanConfigWithOTel).DataView.ID, ShouldEqual, "v2")
+		})
+
+		Convey("Do not create the view when simulating", func() {
+			dsa.EXPECT().GetDataSourceByID(gomock.Any(), "ds1").Return(dataSource, nil)
+			dvs.EXPECT().CheckDataViewExistByName(gomock.Any(), gomock.Any(), interfaces.OTEL_SPAN_INDEX_PATTERN, "opensearch").
+				Return("", false, nil)
+
+			reqModels := newReqModels()
+			err := tms.provisionOTelSpanViews(testCtx, reqModels, false)
+			So(err, ShouldBeNil)
+			So(reqModels[0].SpanConfig.(interfaces.SpanConfigWithOTel).DataView.ID, ShouldEqual, "")
+			So(tms.getDependentViewIDs(reqModels), ShouldBeEmpty)
+		})
+
+		Convey("Validate failed, caused by the error from the view fields", func() {
+			// 自动创建的视图字段满足OTel span的必需字段
+			fieldMap := map[string]string{}
+			for _, field := range interfaces.OTEL_SPAN_VIEW_FIELDS {
+				fieldMap[field.Name] = field.Type
+			}
+			So(tms.validateOTelSpanFields(testCtx, fieldMap), ShouldBeNil)
+		})
+	})
+}
+
 func Test_TraceModelService_IsValidFieldType(t *testing.T) {
 	Convey("Test IsValidFieldType", t, func() {
 		mockCtrl := gomock.NewController(t)
@@ -3462,6 +3619,15 @@ func Test_TraceModelService_GetUnderlyingDataSouceType(t *testing.T) {
 		ps := dmock.NewMockPermissionService(mockCtrl)
 		tms := MockNewTraceModelService(appSetting, tma, dvs, dcs, ps)
 
+		Convey("span category and span source type is otel", func() {
+			sourceType, err := tms.getUnderlyingDataSourceType(testCtx, interfaces.QUERY_CATEGORY_SPAN, interfaces.TraceModel{
+				SpanSourceType: interfaces.SOURCE_TYPE_OTEL,
+				SpanConfig:     interfaces.SpanConfigWithOTel{},
+			})
+			So(err, ShouldBeNil)
+			So(sourceType, ShouldEqual, interfaces.SOURCE_TYPE_OTEL)
+		})
+
 		Convey("other condition, and method 'GetDataConnectionTypeByName' return error", func() {
 			expectedErr := errors.New("some errors")
 			dcs.EXPECT().GetDataConnectionSourceType(gomock.Any(), gomock.Any()).Return("", false, expectedErr)
@@ -386,3 +386,88 @@ func (mma *metricModelAccess) GetMetricModels(ctx context.Context, modelIds []st
 
 	return metricModels, nil
 }
+
+// 获取指标模型列表，limit 为 -1 时不分页
+func (mma *metricModelAccess) ListMetricModels(ctx context.Context, limit int) ([]interfaces.MetricModel, error) {
+	httpUrl := fmt.Sprintf("%s/metric-models?offset=0&limit=%d", mma.metricModelUrl, limit)
+	ctx, span := ar_trace.Tracer.Start(ctx, "请求 data-model 获取指标模型列表", trace.WithSpanKind(trace.SpanKindClient))
+	o11y.AddAttrs4InternalHttp(span, o11y.TraceAttrs{
+		HttpUrl:         httpUrl,
+		HttpMethod:      http.MethodGet,
+		HttpContentType: rest.ContentTypeJson,
+	})
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{}
+	if ctx.Value(interfaces.ACCOUNT_INFO_KEY) != nil {
+		accountInfo = ctx.Value(interfaces.ACCOUNT_INFO_KEY).(interfaces.AccountInfo)
+	}
+	metricModelHeaders := map[string]string{
+		interfaces.CONTENT_TYPE_NAME:        interfaces.CONTENT_TYPE_JSON,
+		interfaces.HTTP_HEADER_ACCOUNT_ID:   accountInfo.ID,
+		interfaces.HTTP_HEADER_ACCOUNT_TYPE: accountInfo.Type,
+	}
+	// httpClient 的请求新增参数支持上下文的处理请求的函数
+	respCode, result, err := mma.httpClient.GetNoUnmarshal(ctx, httpUrl, nil, metricModelHeaders)
+	if err != nil {
+		logger.Errorf("get request method failed: %v", err)
+		// 添加异常时的 trace 属性
+		o11y.AddHttpAttrs4Error(span, respCode, "InternalError", "Http Get Failed")
+		// 记录异常日志
+		o11y.Error(ctx, fmt.Sprintf("List metric models request failed: %v", err))
+
+		return nil, fmt.Errorf("get request method failed: %v", err)
+	}
+
+	if respCode != http.StatusOK {
+		logger.Errorf("list metric models failed: %v", result)
+
+		var baseError rest.BaseError
+		if err := sonic.Unmarshal(result, &baseError); err != nil {
+			logger.Errorf("unmalshal BaesError failed: %v\n", err)
+
+			// 添加异常时的 trace 属性
+			o11y.AddHttpAttrs4Error(span, respCode, "InternalError", "Unmalshal BaesError failed")
+			// 记录异常日志
+			o11y.Error(ctx, fmt.Sprintf("Unmalshal BaesError failed: %v", err))
+
+			return nil, err
+		}
+
+		// 添加异常时的 trace 属性
+		o11y.AddHttpAttrs4Error(span, respCode, "InternalError", "Http status is not 200")
+		// 记录异常日志
+		o11y.Error(ctx, fmt.Sprintf("List metric models failed: %v", baseError.ErrorDetails))
+
+		return nil, fmt.Errorf("list metric models failed: %v", baseError.ErrorDetails)
+	}
+
+	if result == nil {
+		// 添加异常时的 trace 属性
+		o11y.AddHttpAttrs4Ok(span, respCode)
+		// 记录模型不存在的日志
+		o11y.Warn(ctx, "Http response body is null")
+
+		return nil, nil
+	}
+
+	// 处理返回结果 result, 列表接口返回 {"entries": [], "total_count": 0}
+	list := struct {
+		Entries []interfaces.MetricModel `json:"entries"`
+	}{}
+	if err := sonic.Unmarshal(result, &list); err != nil {
+		logger.Errorf("unmalshal metric model list failed: %v\n", err)
+
+		// 添加异常时的 trace 属性
+		o11y.AddHttpAttrs4Error(span, respCode, "InternalError", "Unmalshal metric model list failed")
+		// 记录异常日志
+		o11y.Error(ctx, fmt.Sprintf("Unmalshal metric model list failed: %v", err))
+
+		return nil, err
+	}
+
+	// 添加成功时的 trace 属性
+	o11y.AddHttpAttrs4Ok(span, respCode)
+
+	return list.Entries, nil
+}
@@ -364,3 +364,59 @@ func TestGetMetricModels(t *testing.T) {
 		})
 	})
 }
+
+func TestListMetricModels(t *testing.T) {
+	Convey("Test ListMetricModels", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockHttpClient := rmock.NewMockHTTPClient(mockCtrl)
+		mmAccess := &metricModelAccess{httpClient: mockHttpClient}
+
+		Convey("get request method failed", func() {
+			mockHttpClient.EXPECT().GetNoUnmarshal(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(
+				http.StatusOK, nil, fmt.Errorf("method failed"))
+
+			metricModels, err := mmAccess.ListMetricModels(testCtx, -1)
+
+			So(metricModels, ShouldBeEmpty)
+			So(err, ShouldResemble, fmt.Errorf("get request method failed: method failed"))
+		})
+
+		Convey("response status is not 200", func() {
+			bytes, _ := sonic.Marshal(rest.BaseError{ErrorDetails: "forbidden"})
+			mockHttpClient.EXPECT().GetNoUnmarshal(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(
+				http.StatusForbidden, bytes, nil)
+
+			metricModels, err := mmAccess.ListMetricModels(testCtx, -1)
+
+			So(metricModels, ShouldBeEmpty)
+			So(err, ShouldResemble, fmt.Errorf("list metric models failed: forbidden"))
+		})
+
+		Convey("success", func() {
+			metricModelE := interfaces.MetricModel{
+				ModelID:    "123",
+				ModelName:  "model1",
+				MetricType: "atomic",
+				QueryType:  "promql",
+				Formula:    "rate(node_network_receive_bytes_total[5m])",
+				Unit:       "ms",
+				Comment:    "network receive rate",
+			}
+
+			bytes, _ := sonic.Marshal(map[string]any{
+				"entries":     []interfaces.MetricModel{metricModelE},
+				"total_count": 1,
+			})
+			mockHttpClient.EXPECT().GetNoUnmarshal(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(
+				http.StatusOK, bytes, nil)
+
+			metricModels, err := mmAccess.ListMetricModels(testCtx, -1)
+
+			So(err, ShouldBeNil)
+			So(len(metricModels), ShouldEqual, 1)
+			So(metricModels[0], ShouldResemble, metricModelE)
+		})
+	})
+}
@@ -294,6 +294,16 @@ func (tma *traceModelAccess) commonProcessTraceModel(ctx context.Context, model
 			return model, err
 		}
 		model.SpanConfig = spanConf
+	case interfaces.SOURCE_TYPE_OTEL:
+		spanConf := interfaces.SpanConfigWithOTel{}
+		err = sonic.Unmarshal(b, &spanConf)
+		if err != nil {
+			errDetails := fmt.Sprintf("Field span_config cannot be unmarshaled to SpanConfigWithOTel, err: %v", err.Error())
+			logger.Error(errDetails)
+			o11y.Error(ctx, errDetails)
+			return model, err
+		}
+		model.SpanConfig = spanConf
 	default:
 		errDetails := fmt.Sprintf("Invalid span_source_type: %s", model.SpanSourceType)
 		logger.Error(errDetails)
@@ -350,6 +350,109 @@ func (r *restHandler) GetViewData(c *gin.Context, visitor rest.Visitor) {
 	}
 }
 
+// 视图数据导出（外部）
+func (r *restHandler) ExportViewDataByEx(c *gin.Context) {
+	logger.Debug("Handler ExportViewDataByEx Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c), "driver layer: Export view data",
+		trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	// 校验token
+	visitor, err := r.verifyOAuth(ctx, c)
+	if err != nil {
+		return
+	}
+	r.ExportViewData(ctx, c, visitor)
+}
+
+// 视图数据导出（内部）
+func (r *restHandler) ExportViewDataByIn(c *gin.Context) {
+	logger.Debug("Handler ExportViewDataByIn Start")
+
+	visitor := GenerateVisitor(c)
+	r.ExportViewData(rest.GetLanguageCtx(c), c, visitor)
+}
+
+// 视图数据导出，以流的方式返回视图的全量数据，客户端断开连接时停止导出
+func (r *restHandler) ExportViewData(ctx context.Context, c *gin.Context, visitor rest.Visitor) {
+	logger.Debug("Handler ExportViewData Start")
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "driver layer: Export view data", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := interfaces.AccountInfo{
+		ID:   visitor.ID,
+		Type: string(visitor.Type),
+	}
+	// accountID 存入 context 中
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	viewID := c.Param("view_ids")
+	if viewID == "" || len(convert.StringToStringSlice(viewID)) != 1 {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_ViewIDs).
+			WithErrorDetails("Export only supports one view")
+
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	query := interfaces.DataViewQueryV2{}
+	err := c.ShouldBindJSON(&query)
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_InvalidParameter_RequestBody).
+			WithErrorDetails("Binding Parameter Failed:" + err.Error())
+
+		o11y.Error(ctx, fmt.Sprintf("%s. %v", httpErr.BaseError.Description, httpErr.BaseError.ErrorDetails))
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	format := c.DefaultQuery(interfaces.QueryParam_ExportFormat, interfaces.ExportFormat_CSV)
+	query.UseSearchAfter = true
+	setDefaultValues(&query.ViewQueryCommonParams)
+
+	err = ValidateDataViewExport(ctx, &query, format)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	formatInfo := interfaces.ExportFormatMap[format]
+	c.Header(interfaces.CONTENT_TYPE_NAME, formatInfo.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, viewID, formatInfo.FileExt))
+
+	err = r.dvService.ExportViewData(ctx, viewID, &query, format, c.Writer)
+	if err != nil {
+		httpErr, ok := err.(*rest.HTTPError)
+		if !ok {
+			httpErr = rest.NewHTTPError(ctx, http.StatusInternalServerError, uerrors.Uniquery_DataView_InternalError_ExportFailed).
+				WithErrorDetails(err.Error())
+		}
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+
+		// 已经开始输出数据时无法再修改响应码，只记录错误
+		if c.Writer.Written() {
+			o11y.Error(ctx, fmt.Sprintf("Export view %s interrupted, %s", viewID, httpErr.Error()))
+			c.Abort()
+			return
+		}
+
+		// 错误信息以 json 返回，去掉导出文件的响应头
+		c.Writer.Header().Del("Content-Disposition")
+		c.Writer.Header().Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+}
+
 // 批量删除 pits（外部）
 func (r *restHandler) DeleteDataViewPitsByEx(c *gin.Context) {
 	logger.Debug("Handler DeleteDataViewPitsByEx Start")
@@ -7,6 +7,9 @@ package driveradapters
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -604,6 +607,100 @@ func TestGetViewData(t *testing.T) {
 	})
 }
 
+func TestExportViewData(t *testing.T) {
+	Convey("Test handler ExportViewData", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydraMock := rmock.NewMockHydra(mockCtrl)
+		dvService := umock.NewMockDataViewService(mockCtrl)
+		handler := mockNewDataViewRestHandler(appSetting, hydraMock, dvService)
+		handler.RegisterPublic(engine)
+
+		hydraMock.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/mdl-uniquery/v1/data-views/1a/export"
+		reqParamByte, _ := sonic.Marshal(interfaces.DataViewQueryV2{})
+
+		Convey("Export Success \n", func() {
+			dvService.EXPECT().ExportViewData(gomock.Any(), "1a", gomock.Any(), interfaces.ExportFormat_NDJSON, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, viewID string, query *interfaces.DataViewQueryV2, format string, w io.Writer) error {
+					_, err := w.Write([]byte("{\"a\":1}\n"))
+					return err
+				})
+
+			req := httptest.NewRequest(http.MethodPost, url+"?format=ndjson", bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(interfaces.CONTENT_TYPE_NAME), ShouldEqual, "application/x-ndjson")
+			So(w.Header().Get("Content-Disposition"), ShouldEqual, `attachment; filename="1a.ndjson"`)
+			So(w.Body.String(), ShouldEqual, "{\"a\":1}\n")
+		})
+
+		Convey("Export Failed, invalid format \n", func() {
+			req := httptest.NewRequest(http.MethodPost, url+"?format=xlsx", bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Export Failed, multiple views \n", func() {
+			req := httptest.NewRequest(http.MethodPost, "/api/mdl-uniquery/v1/data-views/1a,2a/export", bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Export Failed, ExportViewData error before writing \n", func() {
+			expectedErr := &rest.HTTPError{
+				HTTPCode: http.StatusForbidden,
+				BaseError: rest.BaseError{
+					ErrorCode: rest.PublicError_Forbidden,
+				},
+			}
+			dvService.EXPECT().ExportViewData(gomock.Any(), "1a", gomock.Any(), interfaces.ExportFormat_CSV, gomock.Any()).
+				Return(expectedErr)
+
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusForbidden)
+			So(w.Header().Get("Content-Disposition"), ShouldEqual, "")
+			So(w.Header().Get(interfaces.CONTENT_TYPE_NAME), ShouldStartWith, interfaces.CONTENT_TYPE_JSON)
+		})
+
+		Convey("Export Failed, ExportViewData returns a non HTTP error \n", func() {
+			dvService.EXPECT().ExportViewData(gomock.Any(), "1a", gomock.Any(), interfaces.ExportFormat_CSV, gomock.Any()).
+				Return(errors.New("stream closed"))
+
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusInternalServerError)
+			So(w.Header().Get(interfaces.CONTENT_TYPE_NAME), ShouldStartWith, interfaces.CONTENT_TYPE_JSON)
+			So(w.Body.String(), ShouldContainSubstring, uerrors.Uniquery_DataView_InternalError_ExportFailed)
+		})
+	})
+}
+
 func TestDeleteDataViewPits(t *testing.T) {
 	Convey("Test handler DeleteDataViewPits", t, func() {
 		test := setGinMode()
@@ -6,11 +6,15 @@
 package driveradapters
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"time"
 
+	"github.com/bytedance/sonic"
 	"github.com/gin-gonic/gin"
 	"github.com/kweaver-ai/kweaver-go-lib/rest"
 
@@ -18,6 +22,7 @@ import (
 	"uniquery/common/convert"
 	uerrors "uniquery/errors"
 	"uniquery/interfaces"
+	"uniquery/logics/promql/parser"
 	"uniquery/logics/promql/static"
 )
 
@@ -25,13 +30,13 @@ import (
 func (r *restHandler) PromqlQueryRange(c *gin.Context) {
 	ctx := rest.GetLanguageCtx(c)
 	// 参数校验
-	start, err := convert.ParseTime(c.PostForm("start"))
+	start, err := convert.ParseTime(formValue(c, "start"))
 	if err != nil {
 		common.ReplyError(c, http.StatusBadRequest, uerrors.InvalidParamError(err, "start"))
 		return
 	}
 
-	end, err := convert.ParseTime(c.PostForm("end"))
+	end, err := convert.ParseTime(formValue(c, "end"))
 	if err != nil {
 		common.ReplyError(c, http.StatusBadRequest, uerrors.InvalidParamError(err, "end"))
 		return
@@ -44,7 +49,7 @@ func (r *restHandler) PromqlQueryRange(c *gin.Context) {
 		return
 	}
 
-	step, err := convert.ParseDuration(c.PostForm("step"))
+	step, err := convert.ParseDuration(formValue(c, "step"))
 	if err != nil {
 		common.ReplyError(c, http.StatusBadRequest, uerrors.InvalidParamError(err, "step"))
 		return
@@ -77,7 +82,7 @@ func (r *restHandler) PromqlQueryRange(c *gin.Context) {
 	// defer func() { <-sem }() // 释放信号
 
 	var timeoutMS int64
-	if to := c.PostForm("timeout"); to != "" {
+	if to := formValue(c, "timeout"); to != "" {
 		timeout, err := convert.ParseDuration(to)
 		if err != nil {
 			common.ReplyError(c, http.StatusBadRequest, uerrors.InvalidParamError(err, "timeout"))
@@ -89,11 +94,11 @@ func (r *restHandler) PromqlQueryRange(c *gin.Context) {
 	ts := time.Now().UnixNano()
 	// 解析query语句并执行
 	_, res, status, err := r.promqlService.Exec(ctx, interfaces.Query{
-		QueryStr:   c.PostForm("query"),
+		QueryStr:   formValue(c, "query"),
 		Start:      start.UnixMilli(),
 		End:        end.UnixMilli(),
 		Interval:   step.Milliseconds(),
-		LogGroupId: c.PostForm("ar_dataview"),
+		LogGroupId: formValue(c, "ar_dataview"),
 		Limit:      -1,
 	})
 	// todo: 超时的暂时方案, 下个迭代解决此问题
@@ -105,7 +110,7 @@ func (r *restHandler) PromqlQueryRange(c *gin.Context) {
 		return
 	}
 	if err != nil {
-		common.ReplyError(c, status, err)
+		replyPromQLError(c, status, err)
 		return
 	}
 	common.ReplyOK(c, status, res)
@@ -118,7 +123,7 @@ func (r *restHandler) PromqlQueryRange(c *gin.Context) {
 func (r *restHandler) PromqlQuery(c *gin.Context) {
 	ctx := rest.GetLanguageCtx(c)
 	// 参数校验
-	tsSecond, err := convert.ParseTimeParam(c.PostForm("time"), "time", time.Now())
+	tsSecond, err := convert.ParseTimeParam(formValue(c, "time"), "time", time.Now())
 	if err != nil {
 		common.ReplyError(c, http.StatusBadRequest, uerrors.InvalidParamError(err, "time"))
 		return
@@ -129,7 +134,7 @@ func (r *restHandler) PromqlQuery(c *gin.Context) {
 	// defer func() { <-sem }() // 释放信号
 
 	var timeoutMS int64
-	if to := c.PostForm("timeout"); to != "" {
+	if to := formValue(c, "timeout"); to != "" {
 		timeout, err := convert.ParseDuration(to)
 		if err != nil {
 			common.ReplyError(c, http.StatusBadRequest, uerrors.InvalidParamError(err, "timeout"))
@@ -141,12 +146,12 @@ func (r *restHandler) PromqlQuery(c *gin.Context) {
 	ts := time.Now().UnixNano()
 	// 解析query语句并执行
 	_, res, status, err := r.promqlService.Exec(ctx, interfaces.Query{
-		QueryStr:       c.PostForm("query"),
+		QueryStr:       formValue(c, "query"),
 		Start:          tsSecond.UnixMilli(),
 		End:            tsSecond.UnixMilli(),
 		Interval:       1,
 		IsInstantQuery: true,
-		LogGroupId:     c.PostForm("ar_dataview"),
+		LogGroupId:     formValue(c, "ar_dataview"),
 		Limit:          -1,
 	})
 	// todo: 超时的暂时方案, 下个迭代解决此问题
@@ -158,7 +163,7 @@ func (r *restHandler) PromqlQuery(c *gin.Context) {
 		return
 	}
 	if err != nil {
-		common.ReplyError(c, status, err)
+		replyPromQLError(c, status, err)
 		return
 	}
 	common.ReplyOK(c, status, res)
@@ -167,7 +172,7 @@ func (r *restHandler) PromqlQuery(c *gin.Context) {
 // promql series 查询
 func (r *restHandler) PromqlSeries(c *gin.Context) {
 	// 参数校验
-	if len(c.PostFormArray("match[]")) == 0 {
+	if len(formValues(c, "match[]")) == 0 {
 		common.ReplyError(c, http.StatusBadRequest, uerrors.PromQLError{
 			Typ: uerrors.ErrorBadData,
 			Err: fmt.Errorf("no match[] parameter provided"),
@@ -175,14 +180,14 @@ func (r *restHandler) PromqlSeries(c *gin.Context) {
 		return
 	}
 
-	start, err := convert.ParseTimeParam(c.PostForm("start"), "start", convert.MinTime)
+	start, err := convert.ParseTimeParam(formValue(c, "start"), "start", convert.MinTime)
 	if err != nil {
 		common.ReplyError(c, http.StatusBadRequest, uerrors.InvalidParamError(err, "start"))
 		return
 	}
 
 	// end 默认是 now
-	end, err := convert.ParseTimeParam(c.PostForm("end"), "end", time.Now())
+	end, err := convert.ParseTimeParam(formValue(c, "end"), "end", time.Now())
 	if err != nil {
 		common.ReplyError(c, http.StatusBadRequest, uerrors.InvalidParamError(err, "end"))
 		return
@@ -196,7 +201,7 @@ func (r *restHandler) PromqlSeries(c *gin.Context) {
 	}
 
 	// match[] 解析
-	matcherSets, err := static.ParseMatchersParam(c.PostFormArray("match[]"))
+	matcherSets, err := static.ParseMatchersParam(formValues(c, "match[]"))
 	if err != nil {
 		common.ReplyError(c, http.StatusBadRequest, uerrors.InvalidParamError(err, "match[]"))
 		return
@@ -207,16 +212,237 @@ func (r *restHandler) PromqlSeries(c *gin.Context) {
 		MatcherSet: matcherSets,
 		Start:      convert.FromTime(start),
 		End:        convert.FromTime(end),
-		LogGroupId: c.PostForm("ar_dataview"),
+		LogGroupId: formValue(c, "ar_dataview"),
 	})
 
 	if err != nil {
-		common.ReplyError(c, status, err)
+		replyPromQLError(c, status, err)
 		return
 	}
 	common.ReplyOK(c, status, res)
 }
 
+// promql labels 查询，返回 match[] 选中的序列的维度字段
+func (r *restHandler) PromqlLabels(c *gin.Context) {
+	// 访问者信息由路由组的 oauth 中间件存入 context
+	ctx := rest.GetLanguageCtx(c)
+
+	query, matches, err := r.parseLabelsParams(ctx, c)
+	if err != nil {
+		replyPromQLError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	names := make(map[string]bool)
+	for _, match := range matches {
+		query.QueryStr = match
+		labels, status, err := r.promqlService.GetLabels(ctx, query)
+		if err != nil {
+			replyPromQLError(c, status, err)
+			return
+		}
+		for name := range labels {
+			names[name] = true
+		}
+	}
+
+	replyPromQLData(c, sortedKeys(names))
+}
+
+// promql label values 查询，返回 match[] 选中的序列在维度字段上的取值
+func (r *restHandler) PromqlLabelValues(c *gin.Context) {
+	// 访问者信息由路由组的 oauth 中间件存入 context
+	ctx := rest.GetLanguageCtx(c)
+
+	name := c.Param("name")
+	if name == "" {
+		common.ReplyError(c, http.StatusBadRequest, uerrors.InvalidParamError(errors.New("label name is empty"), "name"))
+		return
+	}
+
+	query, matches, err := r.parseLabelsParams(ctx, c)
+	if err != nil {
+		replyPromQLError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	values := make(map[string]bool)
+	for _, match := range matches {
+		query.QueryStr = match
+		fieldValues, status, err := r.promqlService.GetFieldValues(ctx, query, name)
+		if err != nil {
+			replyPromQLError(c, status, err)
+			return
+		}
+		for value := range fieldValues {
+			values[value] = true
+		}
+	}
+
+	replyPromQLData(c, sortedKeys(values))
+}
+
+// promql metadata 查询，指标的元数据来自指标模型，指标名为指标模型id
+func (r *restHandler) PromqlMetadata(c *gin.Context) {
+	// 访问者信息由路由组的 oauth 中间件存入 context
+	ctx := rest.GetLanguageCtx(c)
+
+	// limit 为空或者小于等于0时不限制个数
+	limit := 0
+	if s := formValue(c, "limit"); s != "" {
+		l, err := strconv.Atoi(s)
+		if err != nil {
+			common.ReplyError(c, http.StatusBadRequest, uerrors.InvalidParamError(errors.New("limit must be a number"), "limit"))
+			return
+		}
+		limit = l
+	}
+
+	metadata, err := r.mmService.GetMetricModelsMetadata(ctx, formValue(c, "metric"), limit)
+	if err != nil {
+		replyPromQLError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	replyPromQLData(c, metadata)
+}
+
+// prometheus 的接口参数既可以在 url 中，也可以在 form 表单中，form 表单中的参数优先
+func formValue(c *gin.Context, key string) string {
+	if value, ok := c.GetPostForm(key); ok {
+		return value
+	}
+	return c.Query(key)
+}
+
+func formValues(c *gin.Context, key string) []string {
+	if values, ok := c.GetPostFormArray(key); ok {
+		return values
+	}
+	return c.QueryArray(key)
+}
+
+// 解析 labels 和 label values 接口的公共参数。match[] 既可以是序列选择器，也可以是 metric_model("<model_id>")。
+// match[] 为空时，指定了 ar_dataview 则匹配视图下的全部序列，否则匹配有权限的全部指标模型
+func (r *restHandler) parseLabelsParams(ctx context.Context, c *gin.Context) (interfaces.Query, []string, error) {
+	start, err := convert.ParseTimeParam(formValue(c, "start"), "start", convert.MinTime)
+	if err != nil {
+		return interfaces.Query{}, nil, err
+	}
+	// end 默认是 now
+	end, err := convert.ParseTimeParam(formValue(c, "end"), "end", time.Now())
+	if err != nil {
+		return interfaces.Query{}, nil, err
+	}
+	if end.Before(start) {
+		return interfaces.Query{}, nil, errors.New("end timestamp must not be before start time")
+	}
+
+	query := interfaces.Query{
+		Start:      convert.FromTime(start),
+		End:        convert.FromTime(end),
+		LogGroupId: formValue(c, "ar_dataview"),
+		Limit:      -1,
+	}
+
+	matches := formValues(c, "match[]")
+	for _, match := range matches {
+		// 提前校验 match[]，非法的选择器按 bad_data 返回 400
+		expr, err := parser.ParseExpr(ctx, match)
+		if err != nil {
+			return interfaces.Query{}, nil, err
+		}
+		if expr.Type() != parser.ValueTypeVector {
+			return interfaces.Query{}, nil, fmt.Errorf("match[] %s must be an instant vector selector", match)
+		}
+	}
+	if len(matches) > 0 {
+		return query, matches, nil
+	}
+
+	if query.LogGroupId != "" {
+		return query, []string{`{__name__=~".+"}`}, nil
+	}
+
+	metadata, err := r.mmService.GetMetricModelsMetadata(ctx, "", 0)
+	if err != nil {
+		return interfaces.Query{}, nil, err
+	}
+	matches = make([]string, 0, len(metadata))
+	for modelID := range metadata {
+		matches = append(matches, fmt.Sprintf("metric_model(%q)", modelID))
+	}
+	sort.Strings(matches)
+	return query, matches, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// 按 prometheus 的响应格式返回数据 {"status":"success","data":...}
+func replyPromQLData(c *gin.Context, data any) {
+	bytes, err := sonic.Marshal(&interfaces.PromQLResponse{
+		Status: "success",
+		Data:   data,
+	})
+	if err != nil {
+		replyPromQLError(c, http.StatusInternalServerError, err)
+		return
+	}
+	common.ReplyOK(c, http.StatusOK, bytes)
+}
+
+// 按 prometheus 的错误格式返回 {"status":"error","errorType":"...","error":"..."}，
+// 非 PromQLError 的错误根据状态码映射成对应的错误类型
+func replyPromQLError(c *gin.Context, status int, err error) {
+	switch e := err.(type) {
+	case uerrors.PromQLError:
+	case *uerrors.OpenSearchError:
+		status = e.StatusCode
+		err = newPromQLError(status, e)
+	case *rest.HTTPError:
+		status = e.HTTPCode
+		msg := e.BaseError.Description
+		if e.BaseError.ErrorDetails != nil && e.BaseError.ErrorDetails != "" {
+			msg = fmt.Sprintf("%s: %v", msg, e.BaseError.ErrorDetails)
+		}
+		err = newPromQLError(status, errors.New(msg))
+	default:
+		err = newPromQLError(status, err)
+	}
+
+	if status < http.StatusBadRequest {
+		status = http.StatusInternalServerError
+	}
+	common.ReplyError(c, status, err)
+}
+
+// 按 http 状态码确定 prometheus 的错误类型
+func newPromQLError(status int, err error) uerrors.PromQLError {
+	promqlErr := uerrors.PromQLError{Typ: uerrors.ErrorInternal, Err: err}
+	switch {
+	case status == http.StatusNotFound:
+		promqlErr.Typ = uerrors.ErrorNotFound
+	case status == http.StatusNotAcceptable:
+		promqlErr.Typ = uerrors.ErrorStatusNotAcceptable
+	case status == http.StatusUnprocessableEntity:
+		promqlErr.Typ = uerrors.ErrorExec
+	case status == http.StatusRequestTimeout, status == http.StatusGatewayTimeout:
+		promqlErr.Typ = uerrors.ErrorTimeout
+	case status == http.StatusServiceUnavailable:
+		promqlErr.Typ = uerrors.ErrorUnavailable
+	case status >= http.StatusBadRequest && status < http.StatusInternalServerError:
+		promqlErr.Typ = uerrors.ErrorBadData
+	}
+	return promqlErr
+}
+
 func validateTimeParams(start time.Time, end time.Time) (time.Time, error) {
 	// start 是未来时间就抛异常,附带当前时间
 	currentTime := time.Now()
@@ -8,6 +8,7 @@ package driveradapters
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -554,6 +555,41 @@ func TestPromqlQuery(t *testing.T) {
 			compareJsonString(w.Body.String(), expected)
 		})
 
+		Convey("Success PromqlQuery with GET method \n", func() {
+			expected, _ := sonic.Marshal(mat)
+			promqlService.EXPECT().Exec(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, q interfaces.Query) (interfaces.PromQLResponse, []byte, int, error) {
+					So(q.QueryStr, ShouldEqual, `metric_model("1")`)
+					So(q.End, ShouldEqual, 123000)
+					return interfaces.PromQLResponse{}, expected, http.StatusOK, nil
+				})
+
+			req := httptest.NewRequest(http.MethodGet, `/api/v1/query?query=metric_model(%221%22)&time=123`, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			So(w.Body.String(), ShouldEqual, string(expected))
+		})
+
+		Convey("Exec failed with not PromQLError \n", func() {
+			promqlService.EXPECT().Exec(gomock.Any(), gomock.Any()).Return(interfaces.PromQLResponse{}, nil,
+				http.StatusInternalServerError, fmt.Errorf("metric model not found"))
+
+			expected := `{"status":"error",` +
+				`"errorType":"internal",` +
+				`"error":"metric model not found"}`
+
+			body := []byte(`query=metric_model("1")`)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_FORM)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusInternalServerError)
+			compareJsonString(w.Body.String(), expected)
+		})
+
 		Convey("Success PromqlQuery with macthers value contain \\. \n", func() {
 			expected, _ := sonic.Marshal(mat)
 			promqlService.EXPECT().Exec(gomock.Any(), gomock.Any()).Return(interfaces.PromQLResponse{}, expected, http.StatusOK, nil)
@@ -942,3 +978,319 @@ func TestPromqlSeries(t *testing.T) {
 		})
 	})
 }
+
+func TestPromqlLabels(t *testing.T) {
+	Convey("Test handler PromqlLabels", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydraMock := rmock.NewMockHydra(mockCtrl)
+		promqlService := umock.NewMockPromQLService(mockCtrl)
+		mmService := umock.NewMockMetricModelService(mockCtrl)
+		handler := mockNewPromqlRestHandler(appSetting, hydraMock, promqlService)
+		handler.mmService = mmService
+		handler.RegisterPublic(engine)
+
+		hydraMock.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/v1/labels"
+
+		Convey("Success without match[] and ar_dataview \n", func() {
+			mmService.EXPECT().GetMetricModelsMetadata(gomock.Any(), "", 0).Return(
+				map[string][]interfaces.MetricMetadata{"1": {}}, nil)
+			promqlService.EXPECT().GetLabels(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, query interfaces.Query) (map[string]bool, int, error) {
+					So(query.QueryStr, ShouldEqual, `metric_model("1")`)
+					return map[string]bool{"labels.job": true}, http.StatusOK, nil
+				})
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			compareJsonString(w.Body.String(), `{"status":"success","data":["labels.job"]}`)
+		})
+
+		Convey("Success without match[] with ar_dataview \n", func() {
+			promqlService.EXPECT().GetLabels(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, query interfaces.Query) (map[string]bool, int, error) {
+					So(query.QueryStr, ShouldEqual, `{__name__=~".+"}`)
+					So(query.LogGroupId, ShouldEqual, "a")
+					return map[string]bool{"instance": true, "job": true}, http.StatusOK, nil
+				})
+
+			req := httptest.NewRequest(http.MethodGet, url+"?ar_dataview=a", nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			compareJsonString(w.Body.String(), `{"status":"success","data":["instance","job"]}`)
+		})
+
+		Convey("Success with GET method \n", func() {
+			promqlService.EXPECT().GetLabels(gomock.Any(), gomock.Any()).Return(
+				map[string]bool{"labels.job": true, "labels.instance": true}, http.StatusOK, nil)
+			promqlService.EXPECT().GetLabels(gomock.Any(), gomock.Any()).Return(
+				map[string]bool{"labels.job": true, "labels.cpu": true}, http.StatusOK, nil)
+
+			req := httptest.NewRequest(http.MethodGet,
+				url+`?match[]=metric_model(%221%22)&match[]=sum(metric_model(%222%22))`, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			compareJsonString(w.Body.String(),
+				`{"status":"success","data":["labels.cpu","labels.instance","labels.job"]}`)
+		})
+
+		Convey("Success with POST method and series selector \n", func() {
+			promqlService.EXPECT().GetLabels(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, query interfaces.Query) (map[string]bool, int, error) {
+					So(query.QueryStr, ShouldEqual, `{__name__="up"}`)
+					return map[string]bool{"job": true}, http.StatusOK, nil
+				})
+
+			body := []byte(`match[]={__name__="up"}&ar_dataview=a`)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_FORM)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			compareJsonString(w.Body.String(), `{"status":"success","data":["job"]}`)
+		})
+
+		Convey("match[] parse error \n", func() {
+			req := httptest.NewRequest(http.MethodGet, url+`?match[]=%7Bjob%3D`, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, `"errorType":"bad_data"`)
+		})
+
+		Convey("match[] is not an instant vector \n", func() {
+			req := httptest.NewRequest(http.MethodGet, url+`?match[]=up%5B5m%5D`, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, `"errorType":"bad_data"`)
+		})
+
+		Convey("GetLabels failed \n", func() {
+			promqlService.EXPECT().GetLabels(gomock.Any(), gomock.Any()).Return(nil, http.StatusNotFound, &rest.HTTPError{
+				HTTPCode: http.StatusNotFound,
+				BaseError: rest.BaseError{
+					Description: "Metric Model Not Found",
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodGet, url+`?match[]=metric_model(%221%22)`, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusNotFound)
+			compareJsonString(w.Body.String(),
+				`{"status":"error","errorType":"not_found","error":"Metric Model Not Found"}`)
+		})
+	})
+}
+
+func TestPromqlLabelValues(t *testing.T) {
+	Convey("Test handler PromqlLabelValues", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydraMock := rmock.NewMockHydra(mockCtrl)
+		promqlService := umock.NewMockPromQLService(mockCtrl)
+		mmService := umock.NewMockMetricModelService(mockCtrl)
+		handler := mockNewPromqlRestHandler(appSetting, hydraMock, promqlService)
+		handler.mmService = mmService
+		handler.RegisterPublic(engine)
+
+		hydraMock.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/v1/label/labels.job/values"
+
+		Convey("Success \n", func() {
+			promqlService.EXPECT().GetFieldValues(gomock.Any(), gomock.Any(), "labels.job").Return(
+				map[string]bool{"node": true, "prometheus": true}, http.StatusOK, nil)
+			promqlService.EXPECT().GetFieldValues(gomock.Any(), gomock.Any(), "labels.job").Return(
+				map[string]bool{"mysql": true, "node": true}, http.StatusOK, nil)
+
+			req := httptest.NewRequest(http.MethodGet,
+				url+`?match[]=metric_model(%221%22)&match[]=%7B__name__%3D%22up%22%7D`, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			compareJsonString(w.Body.String(), `{"status":"success","data":["mysql","node","prometheus"]}`)
+		})
+
+		Convey("match[] parse error \n", func() {
+			req := httptest.NewRequest(http.MethodGet, url+`?match[]=metric_model(`, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, `"errorType":"bad_data"`)
+		})
+
+		Convey("GetFieldValues failed \n", func() {
+			promqlService.EXPECT().GetFieldValues(gomock.Any(), gomock.Any(), "labels.job").Return(nil, http.StatusBadRequest,
+				&rest.HTTPError{
+					HTTPCode: http.StatusBadRequest,
+					BaseError: rest.BaseError{
+						Description:  "Invalid Field Name",
+						ErrorDetails: "field not exists",
+					},
+				})
+
+			req := httptest.NewRequest(http.MethodGet, url+`?match[]=metric_model(%221%22)`, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+			compareJsonString(w.Body.String(),
+				`{"status":"error","errorType":"bad_data","error":"Invalid Field Name: field not exists"}`)
+		})
+	})
+}
+
+func TestPromqlMetadata(t *testing.T) {
+	Convey("Test handler PromqlMetadata", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydraMock := rmock.NewMockHydra(mockCtrl)
+		promqlService := umock.NewMockPromQLService(mockCtrl)
+		mmService := umock.NewMockMetricModelService(mockCtrl)
+		handler := mockNewPromqlRestHandler(appSetting, hydraMock, promqlService)
+		handler.mmService = mmService
+		handler.RegisterPublic(engine)
+
+		hydraMock.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/v1/metadata"
+
+		Convey("Success \n", func() {
+			mmService.EXPECT().GetMetricModelsMetadata(gomock.Any(), "1", 10).Return(
+				map[string][]interfaces.MetricMetadata{
+					"1": {{Type: interfaces.METRIC_METADATA_TYPE_GAUGE, Help: "cpu usage", Unit: "%"}},
+				}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, url+"?metric=1&limit=10", nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+			compareJsonString(w.Body.String(),
+				`{"status":"success","data":{"1":[{"type":"gauge","help":"cpu usage","unit":"%"}]}}`)
+		})
+
+		Convey("limit invalid \n", func() {
+			req := httptest.NewRequest(http.MethodGet, url+"?limit=a", nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, `"errorType":"bad_data"`)
+		})
+
+		Convey("GetMetricModelsMetadata failed \n", func() {
+			mmService.EXPECT().GetMetricModelsMetadata(gomock.Any(), "", 0).Return(nil, &rest.HTTPError{
+				HTTPCode: http.StatusInternalServerError,
+				BaseError: rest.BaseError{
+					Description: "List Metric Models Failed",
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusInternalServerError)
+			compareJsonString(w.Body.String(),
+				`{"status":"error","errorType":"internal","error":"List Metric Models Failed"}`)
+		})
+	})
+}
+
+func TestPromqlOAuth(t *testing.T) {
+	Convey("Test oauth of prometheus compatible api", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		hydraMock := rmock.NewMockHydra(mockCtrl)
+		promqlService := umock.NewMockPromQLService(mockCtrl)
+		mmService := umock.NewMockMetricModelService(mockCtrl)
+		handler := mockNewPromqlRestHandler(appSetting, hydraMock, promqlService)
+		handler.mmService = mmService
+		handler.RegisterPublic(engine)
+
+		Convey("Unauthorized for every api in the group \n", func() {
+			hydraMock.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, errors.New("invalid token"))
+
+			for _, url := range []string{
+				"/api/v1/query?query=up",
+				"/api/v1/query_range?query=up&start=1&end=2&step=1",
+				"/api/v1/series?match[]=up",
+				"/api/v1/labels",
+				"/api/v1/label/job/values",
+				"/api/v1/metadata",
+			} {
+				req := httptest.NewRequest(http.MethodGet, url, nil)
+				w := httptest.NewRecorder()
+				engine.ServeHTTP(w, req)
+
+				So(w.Result().StatusCode, ShouldEqual, http.StatusUnauthorized)
+			}
+		})
+
+		Convey("Account info is passed to the query \n", func() {
+			hydraMock.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).Return(rest.Visitor{ID: "u1", Type: rest.VisitorType_User}, nil)
+			promqlService.EXPECT().Exec(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, query interfaces.Query) (interfaces.PromQLResponse, []byte, int, error) {
+					So(ctx.Value(interfaces.ACCOUNT_INFO_KEY), ShouldResemble,
+						interfaces.AccountInfo{ID: "u1", Type: string(rest.VisitorType_User)})
+					return interfaces.PromQLResponse{}, []byte(`{"status":"success"}`), http.StatusOK, nil
+				})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/query?query=up&time=123", nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+		})
+	})
+}
@@ -171,6 +171,7 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		apiV1.POST("/data-views", r.verifyJsonContentTypeMiddleWare(), r.ViewSimulateByEx)
 		// apiV1.POST("/data-views/:view_ids", r.verifyJsonContentTypeMiddleWare(), r.GetViewDataV1)
 		apiV1.POST("/data-views/:view_ids", r.verifyJsonContentTypeMiddleWare(), r.GetViewDataByEx)
+		apiV1.POST("/data-views/:view_ids/export", r.verifyJsonContentTypeMiddleWare(), r.ExportViewDataByEx)
 		apiV1.POST("/data-view-pits", r.verifyJsonContentTypeMiddleWare(), r.DeleteDataViewPitsByEx)
 
 		// 链路查询接口
@@ -190,6 +191,10 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		apiV1.POST("/simulate-traces/:trace_id/spans/:span_id/related-logs", r.verifyJsonContentTypeMiddleWare(), r.PreviewSpanRelatedLogListByEx)
 		// (8) 查询span的关联日志列表
 		apiV1.POST("/trace-models/:trace_model_id/traces/:trace_id/spans/:span_id/related-logs", r.GetSpanRelatedLogListByEx)
+		// (9) 查询服务依赖图
+		apiV1.POST("/trace-models/:trace_model_id/service-dependencies", r.verifyJsonContentTypeMiddleWare(), r.GetServiceDependenciesByEx)
+		// (10) 查询trace关键路径
+		apiV1.GET("/trace-models/:trace_model_id/traces/:trace_id/critical-path", r.GetCriticalPathByEx)
 
 		// 目标模型的指标查询接口
 		apiV1.POST("/objective-models", r.verifyJsonContentTypeMiddleWare(), r.ObjectiveSimulateByEx)
@@ -209,6 +214,7 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		// 视图查询接口
 		apiInV1.POST("/data-views", r.verifyJsonContentTypeMiddleWare(), r.ViewSimulateByIn)
 		apiInV1.POST("/data-views/:view_ids", r.verifyJsonContentTypeMiddleWare(), r.GetViewDataByIn)
+		apiInV1.POST("/data-views/:view_ids/export", r.verifyJsonContentTypeMiddleWare(), r.ExportViewDataByIn)
 		apiInV1.POST("/data-view-pits", r.verifyJsonContentTypeMiddleWare(), r.DeleteDataViewPitsByIn)
 
 		// 目标模型的指标查询接口
@@ -236,14 +242,25 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		apiInV1.POST("/simulate-traces/:trace_id/spans/:span_id/related-logs", r.verifyJsonContentTypeMiddleWare(), r.PreviewSpanRelatedLogListByIn)
 		// (8) 查询span的关联日志列表
 		apiInV1.POST("/trace-models/:trace_model_id/traces/:trace_id/spans/:span_id/related-logs", r.GetSpanRelatedLogListByIn)
+		// (9) 查询服务依赖图
+		apiInV1.POST("/trace-models/:trace_model_id/service-dependencies", r.verifyJsonContentTypeMiddleWare(), r.GetServiceDependenciesByIn)
+		// (10) 查询trace关键路径
+		apiInV1.GET("/trace-models/:trace_model_id/traces/:trace_id/critical-path", r.GetCriticalPathByIn)
 	}
 
-	// promql api 遵循prometheus api规则开放对应api给grafana
-	apiV1_promql := c.Group("/api/v1")
+	// promql api 遵循prometheus api规则开放对应api给grafana，整组统一校验oauth
+	apiV1_promql := c.Group("/api/v1", r.verifyOAuthMiddleWare())
 	{
+		apiV1_promql.GET("/query_range", r.PromqlQueryRange)
 		apiV1_promql.POST("/query_range", r.promqlMiddleWare(), r.PromqlQueryRange)
+		apiV1_promql.GET("/query", r.PromqlQuery)
 		apiV1_promql.POST("/query", r.promqlMiddleWare(), r.PromqlQuery)
+		apiV1_promql.GET("/series", r.PromqlSeries)
 		apiV1_promql.POST("/series", r.promqlMiddleWare(), r.PromqlSeries)
+		apiV1_promql.GET("/labels", r.PromqlLabels)
+		apiV1_promql.POST("/labels", r.promqlMiddleWare(), r.PromqlLabels)
+		apiV1_promql.GET("/label/:name/values", r.PromqlLabelValues)
+		apiV1_promql.GET("/metadata", r.PromqlMetadata)
 	}
 
 	apiV1_loggroup := c.Group("/api/mdl-uniquery/v1/loggroup")
@@ -330,23 +347,26 @@ func (r *restHandler) promqlMiddleWare() gin.HandlerFunc {
 	}
 }
 
-// gin中间件 校验oauth
-// func (r *restHandler) verifyOAuthMiddleWare() gin.HandlerFunc {
-// 	return func(c *gin.Context) {
-// 		ctx := rest.GetLanguageCtx(c)
-// 		_, err := r.hydra.VerifyToken(ctx, c)
-// 		if err != nil {
-// 			httpError := rest.NewHTTPError(ctx, http.StatusUnauthorized, rest.PublicError_Unauthorized).
-// 				WithErrorDetails(err.Error())
-// 			rest.ReplyError(c, httpError)
-// 			c.Abort()
-// 			return
-// 		}
-
-// 		//执行后续操作
-// 		c.Next()
-// 	}
-// }
+// gin中间件 校验oauth，访问者信息存入请求的context中
+func (r *restHandler) verifyOAuthMiddleWare() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := rest.GetLanguageCtx(c)
+		visitor, err := r.verifyOAuth(ctx, c)
+		if err != nil {
+			c.Abort()
+			return
+		}
+		// accountID 存入 context 中
+		ctx = context.WithValue(c.Request.Context(), interfaces.ACCOUNT_INFO_KEY, interfaces.AccountInfo{
+			ID:   visitor.ID,
+			Type: string(visitor.Type),
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		//执行后续操作
+		c.Next()
+	}
+}
 
 // 校验oauth
 func (r *restHandler) verifyOAuth(ctx context.Context, c *gin.Context) (rest.Visitor, error) {
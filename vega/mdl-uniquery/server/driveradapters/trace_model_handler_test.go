@@ -1284,3 +1284,163 @@ func TestGetSpanRelatedLogList(t *testing.T) {
 		})
 	})
 }
+
+func TestGetServiceDependencies(t *testing.T) {
+	Convey("Test handler GetServiceDependencies", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		hydraMock := rmock.NewMockHydra(mockCtrl)
+		tmServiceMock := umock.NewMockTraceModelService(mockCtrl)
+		handler := mockNewTraceModelRestHandler(hydraMock, tmServiceMock)
+		handler.RegisterPublic(engine)
+
+		hydraMock.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/mdl-uniquery/v1/trace-models/1/service-dependencies"
+		queryParams := interfaces.ServiceDependencyQueryParams{
+			Start: 1718700000000,
+			End:   1718703600000,
+		}
+
+		Convey("Get failed, caused by invalid X_HTTP_METHOD_OVERRIDE", func() {
+			req := httptest.NewRequest(http.MethodPost, url, nil)
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+			res, _ := convert.JsonToMap(w.Body.String())
+			So(res["error_code"].(string), ShouldEqual, uerrors.Uniquery_InvalidParameter_OverrideMethod)
+		})
+
+		Convey("Get failed, caused by the invalid time range", func() {
+			reqParamByte, _ := json.Marshal(interfaces.ServiceDependencyQueryParams{
+				Start: queryParams.End,
+				End:   queryParams.Start,
+			})
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.HTTP_HEADER_METHOD_OVERRIDE, http.MethodGet)
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+			res, _ := convert.JsonToMap(w.Body.String())
+			So(res["error_code"].(string), ShouldEqual, uerrors.Uniquery_InvalidParameter_Start)
+		})
+
+		Convey("Get failed, caused by the error from method GetTraceModelByID", func() {
+			expectedErr := rest.NewHTTPError(testCtx, http.StatusNotFound, uerrors.Uniquery_TraceModel_TraceModelNotFound)
+			tmServiceMock.EXPECT().GetTraceModelByID(gomock.Any(), gomock.Any()).Return(interfaces.TraceModel{}, expectedErr)
+
+			reqParamByte, _ := json.Marshal(queryParams)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.HTTP_HEADER_METHOD_OVERRIDE, http.MethodGet)
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusNotFound)
+			res, _ := convert.JsonToMap(w.Body.String())
+			So(res["error_code"].(string), ShouldEqual, uerrors.Uniquery_TraceModel_TraceModelNotFound)
+		})
+
+		Convey("Get failed, caused by the error from method GetServiceDependencies", func() {
+			expectedErr := rest.NewHTTPError(testCtx, http.StatusBadRequest, uerrors.Uniquery_TraceModel_UnsupportedSourceType)
+			tmServiceMock.EXPECT().GetTraceModelByID(gomock.Any(), gomock.Any()).Return(interfaces.TraceModel{}, nil)
+			tmServiceMock.EXPECT().GetServiceDependencies(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(interfaces.ServiceDependencyGraph{}, expectedErr)
+
+			reqParamByte, _ := json.Marshal(queryParams)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.HTTP_HEADER_METHOD_OVERRIDE, http.MethodGet)
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusBadRequest)
+			res, _ := convert.JsonToMap(w.Body.String())
+			So(res["error_code"].(string), ShouldEqual, uerrors.Uniquery_TraceModel_UnsupportedSourceType)
+		})
+
+		Convey("Get succeed", func() {
+			tmServiceMock.EXPECT().GetTraceModelByID(gomock.Any(), gomock.Any()).Return(interfaces.TraceModel{}, nil)
+			tmServiceMock.EXPECT().GetServiceDependencies(gomock.Any(), gomock.Any(), queryParams).
+				Return(interfaces.ServiceDependencyGraph{}, nil)
+
+			reqParamByte, _ := json.Marshal(queryParams)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(reqParamByte))
+			req.Header.Set(interfaces.HTTP_HEADER_METHOD_OVERRIDE, http.MethodGet)
+			req.Header.Set(interfaces.CONTENT_TYPE_NAME, interfaces.CONTENT_TYPE_JSON)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+		})
+	})
+}
+
+func TestGetCriticalPath(t *testing.T) {
+	Convey("Test handler GetCriticalPath", t, func() {
+		test := setGinMode()
+		defer test()
+
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		hydraMock := rmock.NewMockHydra(mockCtrl)
+		tmServiceMock := umock.NewMockTraceModelService(mockCtrl)
+		handler := mockNewTraceModelRestHandler(hydraMock, tmServiceMock)
+		handler.RegisterPublic(engine)
+
+		hydraMock.EXPECT().VerifyToken(gomock.Any(), gomock.Any()).AnyTimes().Return(rest.Visitor{}, nil)
+
+		url := "/api/mdl-uniquery/v1/trace-models/1/traces/1/critical-path"
+
+		Convey("Get failed, caused by the error from method GetTraceModelByID", func() {
+			expectedErr := rest.NewHTTPError(testCtx, http.StatusNotFound, uerrors.Uniquery_TraceModel_TraceModelNotFound)
+			tmServiceMock.EXPECT().GetTraceModelByID(gomock.Any(), gomock.Any()).Return(interfaces.TraceModel{}, expectedErr)
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			So(w.Result().StatusCode, ShouldEqual, http.StatusNotFound)
+			res, _ := convert.JsonToMap(w.Body.String())
+			So(res["error_code"].(string), ShouldEqual, uerrors.Uniquery_TraceModel_TraceModelNotFound)
+		})
+
+		Convey("Get failed, caused by the error from method GetCriticalPath", func() {
+			expectedErr := rest.NewHTTPError(testCtx, http.StatusNotFound, uerrors.Uniquery_TraceModel_TraceNotFound)
+			tmServiceMock.EXPECT().GetTraceModelByID(gomock.Any(), gomock.Any()).Return(interfaces.TraceModel{}, nil)
+			tmServiceMock.EXPECT().GetCriticalPath(gomock.Any(), gomock.Any(), gomock.Any()).Return(interfaces.CriticalPath{}, expectedErr)
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			So(w.Result().StatusCode, ShouldEqual, http.StatusNotFound)
+			res, _ := convert.JsonToMap(w.Body.String())
+			So(res["error_code"].(string), ShouldEqual, uerrors.Uniquery_TraceModel_TraceNotFound)
+		})
+
+		Convey("Get succeed", func() {
+			tmServiceMock.EXPECT().GetTraceModelByID(gomock.Any(), gomock.Any()).Return(interfaces.TraceModel{}, nil)
+			tmServiceMock.EXPECT().GetCriticalPath(gomock.Any(), gomock.Any(), interfaces.TraceQueryParams{TraceID: "1"}).
+				Return(interfaces.CriticalPath{TraceID: "1"}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			So(w.Result().StatusCode, ShouldEqual, http.StatusOK)
+		})
+	})
+}
@@ -467,6 +467,12 @@ func ValidateMetricModelSimulate(ctx context.Context, query *interfaces.MetricMo
 		return err
 	}
 
+	// 校验指标分析参数
+	err = validateMetricAnalysis(ctx, query)
+	if err != nil {
+		return err
+	}
+
 	// 校验排序字段的非空
 	for _, orderBy := range query.OrderByFields {
 		if orderBy.Name == "" {
@@ -536,6 +542,12 @@ func validateMetricModelData(ctx context.Context, query *interfaces.MetricModelQ
 		return err
 	}
 
+	// 校验指标分析参数
+	err = validateMetricAnalysis(ctx, query)
+	if err != nil {
+		return err
+	}
+
 	// 校验排序字段的非空
 	for _, orderBy := range query.OrderByFields {
 		if orderBy.Name == "" {
@@ -817,6 +829,75 @@ func validateRequestMetrics(ctx context.Context, query *interfaces.MetricModelQu
 	return nil
 }
 
+// 校验指标分析(基线、异常检测、预测)的参数
+func validateMetricAnalysis(ctx context.Context, query *interfaces.MetricModelQuery) error {
+	analysis := query.Analysis
+	if analysis == nil {
+		return nil
+	}
+
+	// 基线和预测依赖时间序列，只支持趋势查询
+	if query.IsInstantQuery {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis).
+			WithErrorDetails("Metric analysis is only supported for range query")
+	}
+
+	if analysis.Baseline == "" {
+		analysis.Baseline = interfaces.ANALYSIS_BASELINE_STL
+	}
+	if !interfaces.IsValidAnalysisBaseline(analysis.Baseline) {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis).
+			WithErrorDetails(fmt.Sprintf("Unsupported baseline %s, expected %s or %s", analysis.Baseline,
+				interfaces.ANALYSIS_BASELINE_STL, interfaces.ANALYSIS_BASELINE_HOLT_WINTERS))
+	}
+
+	if analysis.Detector == "" {
+		analysis.Detector = interfaces.ANALYSIS_DETECTOR_MAD
+	}
+	if !interfaces.IsValidAnalysisDetector(analysis.Detector) {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis).
+			WithErrorDetails(fmt.Sprintf("Unsupported detector %s, expected %s or %s", analysis.Detector,
+				interfaces.ANALYSIS_DETECTOR_ZSCORE, interfaces.ANALYSIS_DETECTOR_MAD))
+	}
+
+	if analysis.Sensitivity != nil && *analysis.Sensitivity <= 0 {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis).
+			WithErrorDetails("The sensitivity of analysis must be greater than 0")
+	}
+
+	if analysis.Season != "" {
+		seasonT, err := convert.ParseDuration(analysis.Season)
+		if err != nil || seasonT <= 0 {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis).
+				WithErrorDetails(fmt.Sprintf("Invalid season %s of analysis", analysis.Season))
+		}
+		analysis.SeasonMs = seasonT.Milliseconds()
+	}
+
+	if analysis.Forecast != "" {
+		forecastT, err := convert.ParseDuration(analysis.Forecast)
+		if err != nil || forecastT <= 0 {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis).
+				WithErrorDetails(fmt.Sprintf("Invalid forecast %s of analysis", analysis.Forecast))
+		}
+		analysis.ForecastMs = forecastT.Milliseconds()
+	}
+
+	if analysis.ForecastMethod != "" {
+		if !interfaces.IsValidAnalysisForecastMethod(analysis.ForecastMethod) {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis).
+				WithErrorDetails(fmt.Sprintf("Unsupported forecast method %s, expected %s or %s", analysis.ForecastMethod,
+					interfaces.ANALYSIS_FORECAST_LINEAR, interfaces.ANALYSIS_FORECAST_SEASONAL))
+		}
+		if analysis.ForecastMethod == interfaces.ANALYSIS_FORECAST_SEASONAL && analysis.SeasonMs == 0 {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis).
+				WithErrorDetails("Seasonal forecast requires the season of analysis")
+		}
+	}
+
+	return nil
+}
+
 // 校验 dsl 是否满足规则
 func validateDSL(ctx context.Context, query *interfaces.MetricModelQuery) error {
 	// 解析 dsl 的 aggregations 部分，做 dsl 相关的校验
@@ -1170,6 +1251,12 @@ func ValidateDataViewQueryV2(ctx context.Context, query *interfaces.DataViewQuer
 		return err
 	}
 
+	// 校验聚合查询参数
+	err = validateViewAggregation(ctx, query)
+	if err != nil {
+		return err
+	}
+
 	// 过滤条件用map接，然后再decode到condCfg中
 	var actualCond *cond.CondCfg
 	err = mapstructure.Decode(query.GlobalFilters, &actualCond)
@@ -1188,6 +1275,28 @@ func ValidateDataViewQueryV2(ctx context.Context, query *interfaces.DataViewQuer
 	return nil
 }
 
+// 视图数据导出参数校验，翻页参数由导出逻辑内部维护
+func ValidateDataViewExport(ctx context.Context, query *interfaces.DataViewQueryV2, format string) error {
+	if _, ok := interfaces.ExportFormatMap[format]; !ok {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_ExportFormat).
+			WithErrorDetails(fmt.Sprintf("The value of param '%s' should be one of [%s, %s, %s, %s], but got '%s'",
+				interfaces.QueryParam_ExportFormat, interfaces.ExportFormat_CSV, interfaces.ExportFormat_NDJSON,
+				interfaces.ExportFormat_Parquet, interfaces.ExportFormat_Arrow, format))
+	}
+
+	if query.Aggregation != nil {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Export).
+			WithErrorDetails("Export does not support 'aggregation'")
+	}
+
+	if query.Offset != 0 || len(query.SearchAfter) > 0 || query.PitID != "" {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Export).
+			WithErrorDetails("Export exports all the data of view, 'offset', 'search_after' and 'pit_id' are not allowed")
+	}
+
+	return ValidateDataViewQueryV2(ctx, query)
+}
+
 // 视图数据查询参数校验
 func ValidateDataViewQueryV1(ctx context.Context, query *interfaces.DataViewQueryV1) error {
 	// 校验format是否为 original 或者 flat
@@ -1326,6 +1435,111 @@ func validateSortParamsV2(ctx context.Context, sort []*interfaces.SortParamsV2)
 	return nil
 }
 
+// 校验视图聚合查询参数，字段是否在视图中在查询时校验
+func validateViewAggregation(ctx context.Context, query *interfaces.DataViewQueryV2) error {
+	agg := query.Aggregation
+	if agg == nil {
+		return nil
+	}
+
+	if query.UseSearchAfter || len(query.SearchAfter) > 0 || query.PitID != "" || query.PitKeepAlive != "" {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+			WithErrorDetails("Aggregation query does not support search_after or pit")
+	}
+
+	if len(agg.Metrics) == 0 {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+			WithErrorDetails("The aggregation metrics cannot be empty")
+	}
+
+	groupFields := make(map[string]struct{}, len(agg.GroupBy))
+	for _, group := range agg.GroupBy {
+		if group == nil || group.Field == "" {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails("The group_by field cannot be empty")
+		}
+		if _, ok := groupFields[group.Field]; ok {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails(fmt.Sprintf("The group_by field '%s' is duplicated", group.Field))
+		}
+		groupFields[group.Field] = struct{}{}
+
+		if group.CalendarInterval != "" {
+			if _, ok := interfaces.CALENDAR_INTERVALS[group.CalendarInterval]; !ok {
+				return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+					WithErrorDetails(fmt.Sprintf("The calendar_interval of group_by field '%s' should be one of minute, hour, day, week, month, quarter, year, but got '%s'",
+						group.Field, group.CalendarInterval))
+			}
+		}
+	}
+
+	metrics := make(map[string]*interfaces.ViewAggMetric, len(agg.Metrics))
+	for _, metric := range agg.Metrics {
+		if metric == nil || !interfaces.ViewAggMetricNameRegex.MatchString(metric.Name) {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails("The metric name should consist of letters, digits and underscores and cannot start with a digit")
+		}
+		if _, ok := metrics[metric.Name]; ok {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails(fmt.Sprintf("The metric name '%s' is duplicated", metric.Name))
+		}
+		if _, ok := groupFields[metric.Name]; ok {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails(fmt.Sprintf("The metric name '%s' conflicts with the group_by field", metric.Name))
+		}
+		metrics[metric.Name] = metric
+
+		if _, ok := interfaces.ViewAggrTypeMap[metric.Aggr]; !ok {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails(fmt.Sprintf("The aggr of metric '%s' is not supported, got '%s'", metric.Name, metric.Aggr))
+		}
+		if metric.Aggr != interfaces.AGGR_TYPE_DOC_COUNT && metric.Field == "" {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails(fmt.Sprintf("The field of metric '%s' cannot be empty when aggr is '%s'", metric.Name, metric.Aggr))
+		}
+		if metric.Aggr == interfaces.AGGR_TYPE_PERCENTILES {
+			if len(metric.Percents) == 0 {
+				return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+					WithErrorDetails(fmt.Sprintf("The percents of metric '%s' cannot be empty", metric.Name))
+			}
+			for _, p := range metric.Percents {
+				if p <= 0 || p > 100 {
+					return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+						WithErrorDetails(fmt.Sprintf("The percents of metric '%s' should be in (0, 100], but got %v", metric.Name, p))
+				}
+			}
+		}
+	}
+
+	if len(agg.Having) > 0 && len(agg.GroupBy) == 0 {
+		return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+			WithErrorDetails("The having condition requires at least one group_by field")
+	}
+	for _, having := range agg.Having {
+		if having == nil {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails("The having condition cannot be null")
+		}
+		metric, ok := metrics[having.Metric]
+		if !ok {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails(fmt.Sprintf("The having metric '%s' is not in the aggregation metrics", having.Metric))
+		}
+		if metric.Aggr == interfaces.AGGR_TYPE_PERCENTILES {
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails(fmt.Sprintf("The having metric '%s' is percentiles, which is not supported", having.Metric))
+		}
+		switch having.Operation {
+		case cond.OperationEq, cond.OperationNotEq, cond.OperationGt, cond.OperationGte, cond.OperationLt, cond.OperationLte:
+		default:
+			return rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails(fmt.Sprintf("The having operation should be one of ==, !=, >, >=, <, <=, but got '%s'", having.Operation))
+		}
+	}
+
+	return nil
+}
+
 // 校验视图数据源
 func validateViewDataSource(ctx context.Context, dataSource map[string]any, fieldScope string) error {
 	var dataSourceType string
@@ -1539,6 +1753,7 @@ func validateCond(ctx context.Context, cfg *cond.CondCfg) error {
 基于链路模型预览/查询的校验函数. 包括:
 	(1) Span列表查询参数的校验
 	(2) Span关联日志列表预览参数的校验
+	(3) 服务依赖图查询参数的校验
 */
 
 // 基于链路模型查询的校验函数(1): : Span列表查询参数的校验
@@ -1579,6 +1794,33 @@ func validateParamsWhenGetRelatedLogList(ctx context.Context, params interfaces.
 	return params, nil
 }
 
+// 基于链路模型查询的校验函数(3): 服务依赖图查询参数的校验
+func validateParamsWhenGetServiceDependencies(ctx context.Context, params interfaces.ServiceDependencyQueryParams) (interfaces.ServiceDependencyQueryParams, error) {
+	// 1. 校验时间范围
+	if params.Start <= 0 {
+		return params, rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_InvalidParameter_Start).
+			WithErrorDetails("start must be a positive millisecond timestamp")
+	}
+
+	if params.End <= 0 {
+		return params, rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_InvalidParameter_End).
+			WithErrorDetails("end must be a positive millisecond timestamp")
+	}
+
+	_, err := validateTimes(ctx, time.UnixMilli(params.Start), time.UnixMilli(params.End))
+	if err != nil {
+		return params, err
+	}
+
+	// 2. 校验condition
+	err = validateCond(ctx, params.Condition)
+	if err != nil {
+		return params, err
+	}
+
+	return params, nil
+}
+
 // 目标模型数据预览参数校验
 func ValidateObjectiveModelSimulate(ctx context.Context, query *interfaces.ObjectiveModelQuery) error {
 	// 校验指标类型非空
@@ -2673,6 +2673,41 @@ func TestValidateParamsWhenGetRelatedLogList(t *testing.T) {
 	})
 }
 
+func TestValidateParamsWhenGetServiceDependencies(t *testing.T) {
+	Convey("Test validateParamsWhenGetServiceDependencies", t, func() {
+
+		Convey("Validate failed, because start is not set", func() {
+			_, err := validateParamsWhenGetServiceDependencies(testCtx, interfaces.ServiceDependencyQueryParams{End: 1})
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_InvalidParameter_Start)
+		})
+
+		Convey("Validate failed, because end is not set", func() {
+			_, err := validateParamsWhenGetServiceDependencies(testCtx, interfaces.ServiceDependencyQueryParams{Start: 1})
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_InvalidParameter_End)
+		})
+
+		Convey("Validate failed, because end is before start", func() {
+			_, err := validateParamsWhenGetServiceDependencies(testCtx, interfaces.ServiceDependencyQueryParams{Start: 2, End: 1})
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_InvalidParameter_Start)
+		})
+
+		Convey("Validate failed, caused by the error from func 'validateCond'", func() {
+			expectedErr := rest.NewHTTPError(testCtx, http.StatusBadRequest, uerrors.Uniquery_InvalidParameter_FilterValue)
+
+			patch := ApplyFuncReturn(validateCond, expectedErr)
+			defer patch.Reset()
+
+			_, err := validateParamsWhenGetServiceDependencies(testCtx, interfaces.ServiceDependencyQueryParams{Start: 1, End: 2})
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Validate succeed", func() {
+			_, err := validateParamsWhenGetServiceDependencies(testCtx, interfaces.ServiceDependencyQueryParams{Start: 1, End: 2})
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
 func TestValidateSearchAfterAndPit(t *testing.T) {
 	Convey("Test validateSearchAfterAndPit", t, func() {
 
@@ -2702,6 +2737,130 @@ func TestValidateSearchAfterAndPit(t *testing.T) {
 	})
 }
 
+func TestValidateViewAggregation(t *testing.T) {
+	Convey("Test validateViewAggregation", t, func() {
+		newQuery := func() *interfaces.DataViewQueryV2 {
+			return &interfaces.DataViewQueryV2{
+				Aggregation: &interfaces.ViewAggregation{
+					GroupBy: []*interfaces.ViewAggGroupBy{
+						{Field: "host"},
+						{Field: "@timestamp", CalendarInterval: interfaces.CALENDAR_STEP_DAY},
+					},
+					Metrics: []*interfaces.ViewAggMetric{
+						{Name: "cnt", Aggr: interfaces.AGGR_TYPE_DOC_COUNT},
+						{Name: "p", Aggr: interfaces.AGGR_TYPE_PERCENTILES, Field: "cost", Percents: []float64{50, 99}},
+					},
+					Having: []*interfaces.ViewAggHaving{
+						{Metric: "cnt", Operation: cond.OperationGte, Value: 2},
+					},
+				},
+			}
+		}
+
+		Convey("Validate succeed", func() {
+			So(validateViewAggregation(testCtx, newQuery()), ShouldBeNil)
+			So(validateViewAggregation(testCtx, &interfaces.DataViewQueryV2{}), ShouldBeNil)
+		})
+
+		Convey("Validate failed, because use search_after", func() {
+			query := newQuery()
+			query.UseSearchAfter = true
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+		})
+
+		Convey("Validate failed, because metrics is empty", func() {
+			query := newQuery()
+			query.Aggregation.Metrics = nil
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+		})
+
+		Convey("Validate failed, because calendar_interval is invalid", func() {
+			query := newQuery()
+			query.Aggregation.GroupBy[1].CalendarInterval = "2h"
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+		})
+
+		Convey("Validate failed, because metric name is invalid or duplicated", func() {
+			query := newQuery()
+			query.Aggregation.Metrics[0].Name = "a.b"
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+
+			query = newQuery()
+			query.Aggregation.Metrics[1].Name = "cnt"
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+
+			query = newQuery()
+			query.Aggregation.Metrics[0].Name = "host"
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+		})
+
+		Convey("Validate failed, because metric aggr or field is invalid", func() {
+			query := newQuery()
+			query.Aggregation.Metrics[0].Aggr = "top_hits"
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+
+			query = newQuery()
+			query.Aggregation.Metrics[1].Field = ""
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+
+			query = newQuery()
+			query.Aggregation.Metrics[1].Percents = []float64{0}
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+		})
+
+		Convey("Validate failed, because having is invalid", func() {
+			query := newQuery()
+			query.Aggregation.GroupBy = nil
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+
+			query = newQuery()
+			query.Aggregation.Having[0].Metric = "p"
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+
+			query = newQuery()
+			query.Aggregation.Having[0].Operation = cond.OperationIn
+			So(validateViewAggregation(testCtx, query), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestValidateDataViewExport(t *testing.T) {
+	Convey("Test ValidateDataViewExport", t, func() {
+		newQuery := func() *interfaces.DataViewQueryV2 {
+			return &interfaces.DataViewQueryV2{
+				ViewQueryCommonParams: interfaces.ViewQueryCommonParams{
+					Limit:          interfaces.SearchAfter_Limit,
+					Format:         interfaces.Format_Flat,
+					UseSearchAfter: true,
+				},
+			}
+		}
+
+		Convey("Validate succeed", func() {
+			So(ValidateDataViewExport(testCtx, newQuery(), interfaces.ExportFormat_Parquet), ShouldBeNil)
+		})
+
+		Convey("Validate failed, because format is invalid", func() {
+			err := ValidateDataViewExport(testCtx, newQuery(), "xlsx")
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_DataView_InvalidParameter_ExportFormat)
+		})
+
+		Convey("Validate failed, because aggregation is set", func() {
+			query := newQuery()
+			query.Aggregation = &interfaces.ViewAggregation{}
+			err := ValidateDataViewExport(testCtx, query, interfaces.ExportFormat_CSV)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_DataView_InvalidParameter_Export)
+		})
+
+		Convey("Validate failed, because pit_id is set", func() {
+			query := newQuery()
+			query.PitID = "pit"
+			err := ValidateDataViewExport(testCtx, query, interfaces.ExportFormat_CSV)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_DataView_InvalidParameter_Export)
+		})
+	})
+}
+
 func TestValidateObjectiveModelimulate(t *testing.T) {
 	Convey("Test ValidateObjectiveModelimulate", t, func() {
 		c := context.Background()
@@ -3281,3 +3440,104 @@ func TestValidateRequestMetrics(t *testing.T) {
 		})
 	})
 }
+
+func TestValidateMetricAnalysis(t *testing.T) {
+	Convey("Test validateMetricAnalysis", t, func() {
+		ctx := context.Background()
+
+		Convey("Analysis is nil", func() {
+			query := &interfaces.MetricModelQuery{}
+			err := validateMetricAnalysis(ctx, query)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Analysis with instant query", func() {
+			query := &interfaces.MetricModelQuery{
+				QueryTimeParams: interfaces.QueryTimeParams{IsInstantQuery: true},
+				Analysis:        &interfaces.MetricAnalysis{},
+			}
+			err := validateMetricAnalysis(ctx, query)
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis)
+		})
+
+		Convey("Default baseline and detector", func() {
+			query := &interfaces.MetricModelQuery{
+				Analysis: &interfaces.MetricAnalysis{
+					Season:   "1d",
+					Forecast: "1h",
+				},
+			}
+			err := validateMetricAnalysis(ctx, query)
+			So(err, ShouldBeNil)
+			So(query.Analysis.Baseline, ShouldEqual, interfaces.ANALYSIS_BASELINE_STL)
+			So(query.Analysis.Detector, ShouldEqual, interfaces.ANALYSIS_DETECTOR_MAD)
+			So(query.Analysis.SeasonMs, ShouldEqual, int64(24*60*60*1000))
+			So(query.Analysis.ForecastMs, ShouldEqual, int64(60*60*1000))
+		})
+
+		Convey("Invalid baseline", func() {
+			query := &interfaces.MetricModelQuery{
+				Analysis: &interfaces.MetricAnalysis{Baseline: "arima"},
+			}
+			err := validateMetricAnalysis(ctx, query)
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis)
+		})
+
+		Convey("Invalid detector", func() {
+			query := &interfaces.MetricModelQuery{
+				Analysis: &interfaces.MetricAnalysis{Detector: "iqr"},
+			}
+			err := validateMetricAnalysis(ctx, query)
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis)
+		})
+
+		Convey("Sensitivity is not positive", func() {
+			sensitivity := 0.0
+			query := &interfaces.MetricModelQuery{
+				Analysis: &interfaces.MetricAnalysis{Sensitivity: &sensitivity},
+			}
+			err := validateMetricAnalysis(ctx, query)
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis)
+		})
+
+		Convey("Invalid season", func() {
+			query := &interfaces.MetricModelQuery{
+				Analysis: &interfaces.MetricAnalysis{Season: "abc"},
+			}
+			err := validateMetricAnalysis(ctx, query)
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis)
+		})
+
+		Convey("Invalid forecast", func() {
+			query := &interfaces.MetricModelQuery{
+				Analysis: &interfaces.MetricAnalysis{Forecast: "abc"},
+			}
+			err := validateMetricAnalysis(ctx, query)
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis)
+		})
+
+		Convey("Invalid forecast method", func() {
+			query := &interfaces.MetricModelQuery{
+				Analysis: &interfaces.MetricAnalysis{Forecast: "1h", ForecastMethod: "arima"},
+			}
+			err := validateMetricAnalysis(ctx, query)
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis)
+		})
+
+		Convey("Seasonal forecast without season", func() {
+			query := &interfaces.MetricModelQuery{
+				Analysis: &interfaces.MetricAnalysis{Forecast: "1h", ForecastMethod: interfaces.ANALYSIS_FORECAST_SEASONAL},
+			}
+			err := validateMetricAnalysis(ctx, query)
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_MetricModel_InvalidParameter_Analysis)
+		})
+	})
+}
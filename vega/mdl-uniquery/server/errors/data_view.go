@@ -12,10 +12,13 @@ const (
 	Uniquery_DataView_CountExceeded_Filters               = "Uniquery.DataView.CountExceeded.Filters"
 	Uniquery_DataView_FieldTypeConflict                   = "Uniquery.DataView.FieldTypeConflict"
 	Uniquery_DataView_InvalidFilterField_FieldNotInView   = "Uniquery.DataView.InvalidFilterField.FieldNotInView"
+	Uniquery_DataView_InvalidParameter_Aggregation        = "Uniquery.DataView.InvalidParameter.Aggregation"
 	Uniquery_DataView_InvalidParameter_AllowNonExistField = "Uniquery.DataView.InvalidParameter.AllowNonExistField"
 	Uniquery_DataView_InvalidParameter_DataScope          = "Uniquery.DataView.InvalidParameter.DataScope"
 	Uniquery_DataView_InvalidParameter_DataSource         = "Uniquery.DataView.InvalidParameter.DataSource"
 	Uniquery_DataView_InvalidParameter_Direction          = "Uniquery.DataView.InvalidParameter.Direction"
+	Uniquery_DataView_InvalidParameter_Export             = "Uniquery.DataView.InvalidParameter.Export"
+	Uniquery_DataView_InvalidParameter_ExportFormat       = "Uniquery.DataView.InvalidParameter.ExportFormat"
 	Uniquery_DataView_InvalidParameter_FieldScope         = "Uniquery.DataView.InvalidParameter.FieldScope"
 	Uniquery_DataView_InvalidParameter_Filters            = "Uniquery.DataView.InvalidParameter.Filters"
 	Uniquery_DataView_InvalidParameter_Format             = "Uniquery.DataView.InvalidParameter.Format"
@@ -45,6 +48,7 @@ const (
 	Uniquery_DataView_InternalError_ConvertToViewUniResponseFailed = "Uniquery.DataView.InternalError.ConvertToViewUniResponseFailed"
 	Uniquery_DataView_InternalError_CreatePointInTimeFailed        = "Uniquery.DataView.InternalError.CreatePointInTimeFailed"
 	Uniquery_DataView_InternalError_DeletePointInTimeFailed        = "Uniquery.DataView.InternalError.DeletePointInTimeFailed"
+	Uniquery_DataView_InternalError_ExportFailed                   = "Uniquery.DataView.InternalError.ExportFailed"
 	Uniquery_DataView_InternalError_FetchDataFromVegaFailed        = "Uniquery.DataView.InternalError.FetchDataFromVegaFailed"
 	Uniquery_DataView_InternalError_GetDataViewByIDFailed          = "Uniquery.DataView.InternalError.GetDataViewByIDFailed"
 	Uniquery_DataView_InternalError_GetDocumentsFailed             = "Uniquery.DataView.InternalError.GetDocumentsFailed"
@@ -71,10 +75,13 @@ var (
 		Uniquery_DataView_CountExceeded_Filters,
 		Uniquery_DataView_FieldTypeConflict,
 		Uniquery_DataView_InvalidFilterField_FieldNotInView,
+		Uniquery_DataView_InvalidParameter_Aggregation,
 		Uniquery_DataView_InvalidParameter_AllowNonExistField,
 		Uniquery_DataView_InvalidParameter_DataScope,
 		Uniquery_DataView_InvalidParameter_DataSource,
 		Uniquery_DataView_InvalidParameter_Direction,
+		Uniquery_DataView_InvalidParameter_Export,
+		Uniquery_DataView_InvalidParameter_ExportFormat,
 		Uniquery_DataView_InvalidParameter_FieldScope,
 		Uniquery_DataView_InvalidParameter_Filters,
 		Uniquery_DataView_InvalidParameter_Format,
@@ -103,6 +110,7 @@ var (
 		Uniquery_DataView_InternalError_ConvertToViewUniResponseFailed,
 		Uniquery_DataView_InternalError_CreatePointInTimeFailed,
 		Uniquery_DataView_InternalError_DeletePointInTimeFailed,
+		Uniquery_DataView_InternalError_ExportFailed,
 		Uniquery_DataView_InternalError_FetchDataFromVegaFailed,
 		Uniquery_DataView_InternalError_GetDataViewByIDFailed,
 		Uniquery_DataView_InternalError_GetDocumentsFailed,
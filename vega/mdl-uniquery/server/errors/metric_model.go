@@ -13,6 +13,7 @@ const (
 	Uniquery_MetricModel_DateFieldNotExisted                        = "Uniquery.MetricModel.DateFieldNotExisted"
 	Uniquery_MetricModel_GroupByFieldNotExisted                     = "Uniquery.MetricModel.GroupByFieldNotExisted"
 	Uniquery_MetricModel_InvalidParameter                           = "Uniquery.MetricModel.InvalidParameter"
+	Uniquery_MetricModel_InvalidParameter_Analysis                  = "Uniquery.MetricModel.InvalidParameter.Analysis"
 	Uniquery_MetricModel_InvalidParameter_DataSourceType            = "Uniquery.MetricModel.InvalidParameter.DataSourceType"
 	Uniquery_MetricModel_InvalidParameter_DerivedCondition          = "Uniquery.MetricModel.InvalidParameter.DerivedCondition"
 	Uniquery_MetricModel_InvalidParameter_FieldName                 = "Uniquery.MetricModel.InvalidParameter.FieldName"
@@ -78,6 +79,7 @@ const (
 	Uniquery_MetricModel_InternalError_GetModelByIdFailed            = "Uniquery.MetricModel.InternalError.GetMetricModelByIdFailed"
 	Uniquery_MetricModel_InternalError_GetModelIdByNameFailed        = "Uniquery.MetricModel.InternalError.GetMetricModelIdByNameFailed"
 	Uniquery_MetricModel_InternalError_GetVegaViewFieldsByIDFailed   = "Uniquery.MetricModel.InternalError.GetVegaViewFieldsByIDFailed"
+	Uniquery_MetricModel_InternalError_ListMetricModelsFailed        = "Uniquery.MetricModel.InternalError.ListMetricModelsFailed"
 	Uniquery_MetricModel_InternalError_MarshalFailed                 = "Uniquery.MetricModel.InternalError.MarshalFailed"
 	Uniquery_MetricModel_InternalError_ParseResultFailed             = "Uniquery.MetricModel.InternalError.ParseResultFailed"
 	Uniquery_MetricModel_InternalError_ParseUpdateTimeFailed         = "Uniquery.MetricModel.InternalError.ParseUpdateTimeFailed"
@@ -94,6 +96,7 @@ var (
 		Uniquery_MetricModel_DateFieldNotExisted,
 		Uniquery_MetricModel_GroupByFieldNotExisted,
 		Uniquery_MetricModel_InvalidParameter,
+		Uniquery_MetricModel_InvalidParameter_Analysis,
 		Uniquery_MetricModel_InvalidParameter_DataSourceType,
 		Uniquery_MetricModel_InvalidParameter_DerivedCondition,
 		Uniquery_MetricModel_InvalidParameter_FieldName,
@@ -158,6 +161,7 @@ var (
 		Uniquery_MetricModel_InternalError_GetDataViewQueryFiltersFailed,
 		Uniquery_MetricModel_InternalError_GetModelByIdFailed,
 		Uniquery_MetricModel_InternalError_GetModelIdByNameFailed,
+		Uniquery_MetricModel_InternalError_ListMetricModelsFailed,
 		Uniquery_MetricModel_InternalError_GetVegaViewFieldsByIDFailed,
 		Uniquery_MetricModel_InternalError_MarshalFailed,
 		Uniquery_MetricModel_InternalError_ParseResultFailed,
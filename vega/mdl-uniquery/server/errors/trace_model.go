@@ -10,6 +10,7 @@ package errors
 const (
 	// 400
 	Uniquery_TraceModel_InvalidParameter_ModelID = "Uniquery.TraceModel.InvalidParameter.ModelID"
+	Uniquery_TraceModel_UnsupportedSourceType    = "Uniquery.TraceModel.UnsupportedSourceType"
 
 	// 404
 	Uniquery_TraceModel_TraceModelNotFound = "Uniquery.TraceModel.TraceModelNotFound"
@@ -32,6 +33,7 @@ var (
 	traceModelErrCodeList = []string{
 		// 400
 		Uniquery_TraceModel_InvalidParameter_ModelID,
+		Uniquery_TraceModel_UnsupportedSourceType,
 
 		// 404
 		Uniquery_TraceModel_SpanNotFound,
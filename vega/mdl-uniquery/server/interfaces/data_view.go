@@ -21,6 +21,7 @@ type ViewQueryInterface interface {
 	GetSearchAfterParams() *SearchAfterParams
 	GetVegaDuration() int64
 	GetRowColumnRules() []*DataViewRowColumnRule
+	GetAggregation() *ViewAggregation
 
 	SetFormat(format string)
 	SetNeedTotal(needTotal bool)
@@ -221,6 +222,18 @@ func (dvqV2 *DataViewQueryV2) GetSearchAfterParams() *SearchAfterParams {
 	return &dvqV2.SearchAfterParams
 }
 
+func (dvsq *DataViewSimulateQuery) GetAggregation() *ViewAggregation {
+	return nil
+}
+
+func (dvq *DataViewQueryV1) GetAggregation() *ViewAggregation {
+	return nil
+}
+
+func (dvqV2 *DataViewQueryV2) GetAggregation() *ViewAggregation {
+	return dvqV2.Aggregation
+}
+
 type SortParamsV1 struct {
 	Sort      string `json:"sort"`
 	Direction string `json:"direction"`
@@ -285,11 +298,40 @@ type DataViewQueryV2 struct {
 	Timeout       time.Duration   `json:"-"` // 超时时间，查询参数
 	ViewQueryCommonParams
 	SearchAfterParams
+	Aggregation *ViewAggregation `json:"aggregation"` // 聚合查询参数，不为空时按分组聚合返回数据
 
 	ActualCondition *cond.CondCfg `json:"-"`
 	VegaDurationMs  int64         `json:"-"`
 }
 
+// 视图聚合查询参数。分组个数受 limit 限制，聚合查询不支持 search_after 翻页
+type ViewAggregation struct {
+	GroupBy []*ViewAggGroupBy `json:"group_by"`
+	Metrics []*ViewAggMetric  `json:"metrics"`
+	Having  []*ViewAggHaving  `json:"having"` // 多个 having 条件之间是 and 的关系
+}
+
+// 分组字段，calendar_interval 不为空时按日期直方图分组，取值 minute hour day week month quarter year
+type ViewAggGroupBy struct {
+	Field            string `json:"field"`
+	CalendarInterval string `json:"calendar_interval"`
+}
+
+// 聚合指标，name 为返回数据中的列名。aggr 为 doc_count 时不需要 field
+type ViewAggMetric struct {
+	Name     string    `json:"name"`
+	Aggr     string    `json:"aggr"`
+	Field    string    `json:"field"`
+	Percents []float64 `json:"percents"` // aggr 为 percentiles 时的百分位，取值 (0, 100]
+}
+
+// 分组的过滤条件，metric 为聚合指标的 name
+type ViewAggHaving struct {
+	Metric    string  `json:"metric"`
+	Operation string  `json:"operation"`
+	Value     float64 `json:"value"`
+}
+
 type SearchAfterParams struct {
 	SearchAfter  []any  `json:"search_after"`
 	PitID        string `json:"pit_id"`
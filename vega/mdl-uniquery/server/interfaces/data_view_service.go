@@ -8,6 +8,8 @@ package interfaces
 import (
 	"bytes"
 	"context"
+	"io"
+	"regexp"
 	"time"
 
 	"github.com/bytedance/sonic"
@@ -57,6 +59,10 @@ const (
 	Format_Original = "original"
 	Format_Flat     = "flat"
 
+	// total_count 的含义，与 opensearch hits.total.relation 一致：eq 为精确值，gte 为下限
+	TotalCountRelation_Eq  = "eq"
+	TotalCountRelation_Gte = "gte"
+
 	Version_V1 = "v1"
 	Version_V2 = "v2"
 
@@ -76,6 +82,19 @@ const (
 	SearchError_SearchContextMissingException = "search_context_missing_exception"
 )
 
+// 视图数据导出的文件格式
+const (
+	ExportFormat_CSV     = "csv"
+	ExportFormat_NDJSON  = "ndjson"
+	ExportFormat_Parquet = "parquet"
+	ExportFormat_Arrow   = "arrow"
+
+	QueryParam_ExportFormat = "format"
+
+	// 导出时 opensearch pit 的保活时间，每次翻页都会续期
+	Export_PitKeepAlive = "5m"
+)
+
 var (
 	REQUIRED_META_FIELDS = []string{
 		"@timestamp",
@@ -101,8 +120,36 @@ var (
 		UnionType_All:      {},
 		UnionType_Distinct: {},
 	}
+
+	// 视图聚合查询支持的聚合类型，取值与指标模型的聚合类型保持一致
+	ViewAggrTypeMap = map[string]struct{}{
+		AGGR_TYPE_DOC_COUNT:   {},
+		AGGR_TYPE_VALUE_COUNT: {},
+		AGGR_TYPE_CARDINALITY: {},
+		AGGR_TYPE_SUM:         {},
+		AGGR_TYPE_AVG:         {},
+		AGGR_TYPE_MAX:         {},
+		AGGR_TYPE_MIN:         {},
+		AGGR_TYPE_PERCENTILES: {},
+	}
+
+	// 导出格式对应的 Content-Type 和文件扩展名
+	ExportFormatMap = map[string]ExportFormatInfo{
+		ExportFormat_CSV:     {ContentType: "text/csv; charset=utf-8", FileExt: "csv"},
+		ExportFormat_NDJSON:  {ContentType: "application/x-ndjson", FileExt: "ndjson"},
+		ExportFormat_Parquet: {ContentType: "application/vnd.apache.parquet", FileExt: "parquet"},
+		ExportFormat_Arrow:   {ContentType: "application/vnd.apache.arrow.stream", FileExt: "arrows"},
+	}
+
+	// 聚合指标名会作为 opensearch 的聚合名和 sql 的列别名，只允许字母、数字和下划线
+	ViewAggMetricNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
 )
 
+type ExportFormatInfo struct {
+	ContentType string
+	FileExt     string
+}
+
 // 视图查询外部接口统一返回结构
 type ViewUniResponseV1 struct {
 	ScrollId string     `json:"scroll_id,omitempty"`
@@ -123,6 +170,7 @@ type ViewUniResponseV2 struct {
 	View           *DataView        `json:"view,omitempty"`
 	Entries        []map[string]any `json:"entries"`
 	TotalCount     *int64           `json:"total_count,omitempty"`
+	TotalRelation  string           `json:"total_count_relation,omitempty"`
 	VegaDurationMs int64            `json:"vega_duration_ms,omitempty"`
 	OverallMs      int64            `json:"overall_ms,omitempty"`
 
@@ -171,6 +219,7 @@ type DSLCfg struct {
 		ID        string `json:"id,omitempty"`
 		KeepAlive string `json:"keep_alive,omitempty"`
 	} `json:"pit,omitempty"`
+	Aggs map[string]any `json:"aggs,omitempty"`
 }
 
 func (dsl DSLCfg) String() string {
@@ -193,6 +242,7 @@ type DataViewService interface {
 	Simulate(ctx context.Context, query *DataViewSimulateQuery) (*ViewUniResponseV2, error)
 	GetSingleViewData(ctx context.Context, viewID string, query ViewQueryInterface) (*ViewUniResponseV2, error)
 	DeleteDataViewPits(ctx context.Context, pits *DeletePits) (*DeletePitsResp, error)
+	ExportViewData(ctx context.Context, viewID string, query *DataViewQueryV2, format string, w io.Writer) error
 
 	// 服务内部调用，视图提供给内部模块的方法返回的error是httpErr
 	// GetDataViewIDByName(ctx context.Context, viewName string) (string, error)
@@ -0,0 +1,90 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package interfaces
+
+import "strconv"
+
+const (
+	// 基线算法
+	ANALYSIS_BASELINE_STL          string = "stl"
+	ANALYSIS_BASELINE_HOLT_WINTERS string = "holt_winters"
+
+	// 异常判定方法
+	ANALYSIS_DETECTOR_ZSCORE string = "zscore"
+	ANALYSIS_DETECTOR_MAD    string = "mad"
+
+	// 预测方法
+	ANALYSIS_FORECAST_LINEAR   string = "linear"
+	ANALYSIS_FORECAST_SEASONAL string = "seasonal"
+
+	// 异常带宽的默认倍数
+	DEFAULT_ANALYSIS_SENSITIVITY float64 = 3
+	// 单条序列最多预测的点数
+	MAX_ANALYSIS_FORECAST_POINTS int = 1000
+
+	// 事件模型 detect_rule.analysis_algo 中指标分析的配置项
+	ANALYSIS_ALGO_BASELINE    = "baseline"
+	ANALYSIS_ALGO_SEASON      = "season"
+	ANALYSIS_ALGO_DETECTOR    = "detector"
+	ANALYSIS_ALGO_SENSITIVITY = "sensitivity"
+
+	// 事件模型使用指标分析结果时，源记录中的字段
+	ANALYSIS_RECORD_BASELINE      = "baseline"
+	ANALYSIS_RECORD_UPPER_BOUND   = "upper_bound"
+	ANALYSIS_RECORD_LOWER_BOUND   = "lower_bound"
+	ANALYSIS_RECORD_ANOMALY       = "anomaly"
+	ANALYSIS_RECORD_ANOMALY_SCORE = "anomaly_score"
+)
+
+// 指标数据的分析配置：按基线算法拟合每条序列的基线，对残差按 zscore/mad 计算上下界和异常标记，并可向后预测
+type MetricAnalysis struct {
+	Baseline       string   `json:"baseline"`        // 基线算法，stl 或 holt_winters，默认 stl
+	Season         string   `json:"season"`          // 季节周期，如 1h、1d、1w，为空时不做季节性拟合
+	Detector       string   `json:"detector"`        // 异常判定方法，zscore 或 mad，默认 mad
+	Sensitivity    *float64 `json:"sensitivity"`     // 上下界为基线偏离 sensitivity 倍的离散度，默认 3
+	Forecast       string   `json:"forecast"`        // 预测时长，如 1h，为空时不预测
+	ForecastMethod string   `json:"forecast_method"` // 预测方法，linear 或 seasonal，默认有季节周期时 seasonal，否则 linear
+
+	SeasonMs   int64 `json:"-"` // 季节周期的毫秒数
+	ForecastMs int64 `json:"-"` // 预测时长的毫秒数
+}
+
+// 指标序列的预测结果
+type MetricForecast struct {
+	Times       []any `json:"times"`
+	Values      []any `json:"values"`
+	UpperBounds []any `json:"upper_bounds"`
+	LowerBounds []any `json:"lower_bounds"`
+}
+
+func IsValidAnalysisBaseline(m string) bool {
+	return m == ANALYSIS_BASELINE_STL || m == ANALYSIS_BASELINE_HOLT_WINTERS
+}
+
+func IsValidAnalysisDetector(m string) bool {
+	return m == ANALYSIS_DETECTOR_ZSCORE || m == ANALYSIS_DETECTOR_MAD
+}
+
+func IsValidAnalysisForecastMethod(m string) bool {
+	return m == ANALYSIS_FORECAST_LINEAR || m == ANALYSIS_FORECAST_SEASONAL
+}
+
+// 从事件模型检测规则的 analysis_algo 中解析指标分析配置，未配置基线和异常判定方法时返回 nil
+func NewMetricAnalysisFromAlgo(algo map[string]string) *MetricAnalysis {
+	if algo[ANALYSIS_ALGO_BASELINE] == "" && algo[ANALYSIS_ALGO_DETECTOR] == "" {
+		return nil
+	}
+
+	analysis := &MetricAnalysis{
+		Baseline: algo[ANALYSIS_ALGO_BASELINE],
+		Season:   algo[ANALYSIS_ALGO_SEASON],
+		Detector: algo[ANALYSIS_ALGO_DETECTOR],
+	}
+	if s, err := strconv.ParseFloat(algo[ANALYSIS_ALGO_SENSITIVITY], 64); err == nil && s > 0 {
+		analysis.Sensitivity = &s
+	}
+	return analysis
+}
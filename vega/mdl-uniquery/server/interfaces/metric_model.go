@@ -186,6 +186,7 @@ var (
 type MetricModelQuery struct {
 	QueryTimeParams
 	RequestMetrics  *RequestMetrics    `json:"metrics,omitempty"`
+	Analysis        *MetricAnalysis    `json:"analysis,omitempty"` // 基线、异常检测和预测
 	MetricType      string             `json:"metric_type"`
 	DataViewID      string             `json:"data_view_id"`
 	DataSource      *MetricDataSource  `json:"data_source"`
@@ -302,6 +303,14 @@ type MetricModelData struct {
 	GrowthValues []any             `json:"growth_values,omitempty"`
 	GrowthRates  []any             `json:"growth_rates,omitempty"`
 	Proportions  []any             `json:"proportions,omitempty"`
+
+	// 指标分析的结果
+	Baselines     []any           `json:"baselines,omitempty"`
+	UpperBounds   []any           `json:"upper_bounds,omitempty"`
+	LowerBounds   []any           `json:"lower_bounds,omitempty"`
+	Anomalies     []any           `json:"anomalies,omitempty"`
+	AnomalyScores []any           `json:"anomaly_scores,omitempty"`
+	Forecast      *MetricForecast `json:"forecast,omitempty"`
 }
 
 type MetricModel struct {
@@ -345,6 +354,16 @@ type FieldValues struct {
 	Values    []string `json:"values"`
 }
 
+// 指标模型的计算结果都是按步长采样的瞬时值，在 prometheus 的元数据中统一作为 gauge
+const METRIC_METADATA_TYPE_GAUGE = "gauge"
+
+// 指标模型的元数据，对应 prometheus /api/v1/metadata 接口中的指标元数据
+type MetricMetadata struct {
+	Type string `json:"type"`
+	Help string `json:"help"`
+	Unit string `json:"unit"`
+}
+
 type MetricDataSource struct {
 	Type string `json:"type"`
 	ID   string `json:"id"`
@@ -13,4 +13,5 @@ type MetricModelAccess interface {
 	GetMetricModelIDByName(ctx context.Context, groupName, modelName string) (string, bool, error)
 
 	GetMetricModels(ctx context.Context, modelId []string) ([]MetricModel, error)
+	ListMetricModels(ctx context.Context, limit int) ([]MetricModel, error)
 }
@@ -19,4 +19,5 @@ type MetricModelService interface {
 	GetMetricModelFields(ctx context.Context, modelID string) ([]Field, error)
 	GetMetricModelFieldValues(ctx context.Context, modelID, fieldName string) (FieldValues, error)
 	GetMetricModelLabels(ctx context.Context, modelID string) ([]*cond.ViewField, error)
+	GetMetricModelsMetadata(ctx context.Context, modelID string, limit int) (map[string][]MetricMetadata, error)
 }
@@ -12,6 +12,7 @@ package mock_interfaces
 import (
 	bytes "bytes"
 	context "context"
+	io "io"
 	reflect "reflect"
 	time "time"
 	interfaces "uniquery/interfaces"
@@ -87,6 +88,20 @@ func (mr *MockDataViewServiceMockRecorder) DeleteDataViewPits(ctx, pits interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDataViewPits", reflect.TypeOf((*MockDataViewService)(nil).DeleteDataViewPits), ctx, pits)
 }
 
+// ExportViewData mocks base method.
+func (m *MockDataViewService) ExportViewData(ctx context.Context, viewID string, query *interfaces.DataViewQueryV2, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportViewData", ctx, viewID, query, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportViewData indicates an expected call of ExportViewData.
+func (mr *MockDataViewServiceMockRecorder) ExportViewData(ctx, viewID, query, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportViewData", reflect.TypeOf((*MockDataViewService)(nil).ExportViewData), ctx, viewID, query, format, w)
+}
+
 // GetDataFromOpenSearch mocks base method.
 func (m *MockDataViewService) GetDataFromOpenSearch(ctx context.Context, query map[string]any, indices []string, scroll time.Duration, preference string, trackTotalHits bool) ([]byte, int, error) {
 	m.ctrl.T.Helper()
@@ -86,3 +86,18 @@ func (mr *MockMetricModelAccessMockRecorder) GetMetricModels(ctx, modelId interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricModels", reflect.TypeOf((*MockMetricModelAccess)(nil).GetMetricModels), ctx, modelId)
 }
+
+// ListMetricModels mocks base method.
+func (m *MockMetricModelAccess) ListMetricModels(ctx context.Context, limit int) ([]interfaces.MetricModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMetricModels", ctx, limit)
+	ret0, _ := ret[0].([]interfaces.MetricModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMetricModels indicates an expected call of ListMetricModels.
+func (mr *MockMetricModelAccessMockRecorder) ListMetricModels(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricModels", reflect.TypeOf((*MockMetricModelAccess)(nil).ListMetricModels), ctx, limit)
+}
@@ -130,6 +130,21 @@ func (mr *MockMetricModelServiceMockRecorder) GetMetricModelLabels(ctx, modelID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricModelLabels", reflect.TypeOf((*MockMetricModelService)(nil).GetMetricModelLabels), ctx, modelID)
 }
 
+// GetMetricModelsMetadata mocks base method.
+func (m *MockMetricModelService) GetMetricModelsMetadata(ctx context.Context, modelID string, limit int) (map[string][]interfaces.MetricMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricModelsMetadata", ctx, modelID, limit)
+	ret0, _ := ret[0].(map[string][]interfaces.MetricMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricModelsMetadata indicates an expected call of GetMetricModelsMetadata.
+func (mr *MockMetricModelServiceMockRecorder) GetMetricModelsMetadata(ctx, modelID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricModelsMetadata", reflect.TypeOf((*MockMetricModelService)(nil).GetMetricModelsMetadata), ctx, modelID, limit)
+}
+
 // Simulate mocks base method.
 func (m *MockMetricModelService) Simulate(ctx context.Context, query interfaces.MetricModelQuery) (interfaces.MetricModelUniResponse, error) {
 	m.ctrl.T.Helper()
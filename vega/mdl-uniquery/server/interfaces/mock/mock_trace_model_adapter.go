@@ -117,3 +117,19 @@ func (mr *MockTraceModelAdapterMockRecorder) GetSpanRelatedLogList(ctx, model, p
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpanRelatedLogList", reflect.TypeOf((*MockTraceModelAdapter)(nil).GetSpanRelatedLogList), ctx, model, params)
 }
+
+// GetSpansByTimeRange mocks base method.
+func (m *MockTraceModelAdapter) GetSpansByTimeRange(ctx context.Context, model interfaces.TraceModel, params interfaces.ServiceDependencyQueryParams) ([]interfaces.AbstractSpan, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpansByTimeRange", ctx, model, params)
+	ret0, _ := ret[0].([]interfaces.AbstractSpan)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSpansByTimeRange indicates an expected call of GetSpansByTimeRange.
+func (mr *MockTraceModelAdapterMockRecorder) GetSpansByTimeRange(ctx, model, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpansByTimeRange", reflect.TypeOf((*MockTraceModelAdapter)(nil).GetSpansByTimeRange), ctx, model, params)
+}
@@ -40,6 +40,36 @@ func (m *MockTraceModelService) EXPECT() *MockTraceModelServiceMockRecorder {
 	return m.recorder
 }
 
+// GetCriticalPath mocks base method.
+func (m *MockTraceModelService) GetCriticalPath(ctx context.Context, model interfaces.TraceModel, params interfaces.TraceQueryParams) (interfaces.CriticalPath, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCriticalPath", ctx, model, params)
+	ret0, _ := ret[0].(interfaces.CriticalPath)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCriticalPath indicates an expected call of GetCriticalPath.
+func (mr *MockTraceModelServiceMockRecorder) GetCriticalPath(ctx, model, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCriticalPath", reflect.TypeOf((*MockTraceModelService)(nil).GetCriticalPath), ctx, model, params)
+}
+
+// GetServiceDependencies mocks base method.
+func (m *MockTraceModelService) GetServiceDependencies(ctx context.Context, model interfaces.TraceModel, params interfaces.ServiceDependencyQueryParams) (interfaces.ServiceDependencyGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceDependencies", ctx, model, params)
+	ret0, _ := ret[0].(interfaces.ServiceDependencyGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceDependencies indicates an expected call of GetServiceDependencies.
+func (mr *MockTraceModelServiceMockRecorder) GetServiceDependencies(ctx, model, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceDependencies", reflect.TypeOf((*MockTraceModelService)(nil).GetServiceDependencies), ctx, model, params)
+}
+
 // GetSpan mocks base method.
 func (m *MockTraceModelService) GetSpan(ctx context.Context, model interfaces.TraceModel, params interfaces.SpanQueryParams) (interfaces.SpanDetail, error) {
 	m.ctrl.T.Helper()
@@ -27,6 +27,19 @@ const (
 	MIN_OVER_TIME                = "min_over_time"
 	COUNT_OVER_TIME              = "count_over_time"
 	DELTA_AGG                    = "delta"
+	IDELTA_AGG                   = "idelta"
+	RESETS_AGG                   = "resets"
+	DERIV_AGG                    = "deriv"
+	PREDICT_LINEAR_AGG           = "predict_linear"
+	HOLT_WINTERS_AGG             = "holt_winters"
+	STDDEV_OVER_TIME             = "stddev_over_time"
+	STDVAR_OVER_TIME             = "stdvar_over_time"
+	LAST_OVER_TIME               = "last_over_time"
+	QUANTILE_OVER_TIME           = "quantile_over_time"
+	LINEAR_REGRESSION_AGG        = "linear_regression"
+	RAW_SAMPLES_AGG              = "raw_samples"
+	MAX_RAW_SAMPLES_PER_BUCKET   = 100
+	RAW_SAMPLES_PAGE_SIZE        = 1000
 	CUMULATIVE_SUM               = "cumulative_sum"
 	HISTOGRAM_QUANTILE           = "histogram_quantile"
 	K_MINUTE_DOWNTIME            = "continuous_k_minute_downtime"
@@ -47,6 +60,7 @@ const (
 	DEFAULT_LOOK_BACK_DELTA_STR  = "5m"
 	DEFAULT_STEP_DIVISOR         = 5 * time.Minute
 	KMINUTE_DOWNTTIME_STEP       = 60000
+	DEFAULT_SUBQUERY_STEP        = 60000 // 子查询未指定步长，且无法沿用请求的步长时使用的默认步长
 )
 
 type Query struct {
@@ -15,6 +15,7 @@ const (
 	RELATED_LOG_CLOSE               uint8  = 0
 	SOURCE_TYPE_DATA_VIEW           string = "data_view"
 	SOURCE_TYPE_DATA_CONNECTION     string = "data_connection"
+	SOURCE_TYPE_OTEL                string = "otel"
 	QUERY_CATEGORY_SPAN             string = "span"
 	QUERY_CATEGORY_RELATED_LOG      string = "related_log"
 	QUERY_CATEGORY_SPAN_LIST        string = "span_list"
@@ -55,6 +56,11 @@ type SpanConfigWithDataConnection struct {
 	DataConnection DataConnectionConfig `json:"data_connection"`
 }
 
+// OTel span配置, 数据视图需基于OTel/Jaeger的span索引, 字段按OTel span的固定结构解析
+type SpanConfigWithOTel struct {
+	DataView DataViewConfig `json:"data_view"`
+}
+
 // span关联日志配置
 type RelatedLogConfigWithDataView struct {
 	DataView DataViewConfig `json:"data_view"`
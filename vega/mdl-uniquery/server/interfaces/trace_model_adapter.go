@@ -20,4 +20,5 @@ type TraceModelAdapter interface {
 	GetSpanMap(ctx context.Context, model TraceModel, params TraceQueryParams) (map[string]*BriefSpan_, map[string]SpanDetail, error)
 	GetRelatedLogCountMap(ctx context.Context, model TraceModel, params TraceQueryParams) (map[string]int64, error)
 	GetSpanRelatedLogList(ctx context.Context, model TraceModel, params RelatedLogListQueryParams) ([]RelatedLogListEntry, int64, error)
+	GetSpansByTimeRange(ctx context.Context, model TraceModel, params ServiceDependencyQueryParams) ([]AbstractSpan, bool, error)
 }
@@ -43,6 +43,9 @@ const (
 	SPAN_STATUS_ERROR = "error"
 	SPAN_STATUS_UNSET = "unset"
 	DEFAULT_SEPARATOR = "$_$"
+
+	// 服务依赖分析时单次最多拉取的span数, 超出部分会被截断
+	MAX_ANALYTICS_SPAN_SIZE = 100000
 )
 
 var (
@@ -205,12 +208,96 @@ type RelatedLogListEntry map[string]any
 // 	RawData            map[string]interface{} `json:"raw_data"`
 // }
 
+// 服务依赖图查询参数, start与end为毫秒时间戳
+type ServiceDependencyQueryParams struct {
+	Start     int64         `json:"start"`
+	End       int64         `json:"end"`
+	Condition *cond.CondCfg `json:"condition"`
+}
+
+// 服务依赖图
+type ServiceDependencyGraph struct {
+	Start      int64              `json:"start"`
+	End        int64              `json:"end"`
+	TraceCount int64              `json:"trace_count"`
+	SpanCount  int64              `json:"span_count"`
+	Truncated  bool               `json:"truncated"`
+	Nodes      []ServiceNodeStats `json:"nodes"`
+	Edges      []ServiceEdgeStats `json:"edges"`
+}
+
+// 服务节点的统计信息, 延迟基于服务的入口span(父span不存在或属于其他服务)计算
+type ServiceNodeStats struct {
+	ServiceName string       `json:"service_name"`
+	SpanCount   int64        `json:"span_count"`
+	ErrorCount  int64        `json:"error_count"`
+	ErrorRate   float64      `json:"error_rate"`
+	Latency     LatencyStats `json:"latency"`
+}
+
+// 服务间调用的统计信息, 延迟基于被调用方的span计算
+type ServiceEdgeStats struct {
+	Source     string       `json:"source"`
+	Target     string       `json:"target"`
+	CallCount  int64        `json:"call_count"`
+	ErrorCount int64        `json:"error_count"`
+	ErrorRate  float64      `json:"error_rate"`
+	Latency    LatencyStats `json:"latency"`
+}
+
+// 延迟统计, 单位与span的__duration一致(微秒)
+type LatencyStats struct {
+	Min int64 `json:"min"`
+	Max int64 `json:"max"`
+	Avg int64 `json:"avg"`
+	P50 int64 `json:"p50"`
+	P90 int64 `json:"p90"`
+	P95 int64 `json:"p95"`
+	P99 int64 `json:"p99"`
+}
+
+// trace的关键路径
+type CriticalPath struct {
+	TraceID   string                `json:"trace_id"`
+	StartTime int64                 `json:"start_time"`
+	EndTime   int64                 `json:"end_time"`
+	Duration  int64                 `json:"duration"`
+	Segments  []CriticalPathSegment `json:"segments"`
+	Spans     []CriticalPathSpan    `json:"spans"`
+}
+
+// 关键路径上的一段, 表示该时间段内trace的耗时由此span自身造成
+type CriticalPathSegment struct {
+	SpanID      string `json:"__span_id"`
+	Name        string `json:"__name"`
+	ServiceName string `json:"__service_name"`
+	StartTime   int64  `json:"start_time"`
+	EndTime     int64  `json:"end_time"`
+	Duration    int64  `json:"duration"`
+}
+
+// 关键路径上的span, self_time为span耗时中未被子span覆盖的部分
+type CriticalPathSpan struct {
+	SpanID       string `json:"__span_id"`
+	Name         string `json:"__name"`
+	ServiceName  string `json:"__service_name"`
+	Kind         string `json:"__kind"`
+	Status       string `json:"__status"`
+	StartTime    int64  `json:"__start_time"`
+	EndTime      int64  `json:"__end_time"`
+	Duration     int64  `json:"__duration"`
+	SelfTime     int64  `json:"self_time"`
+	CriticalTime int64  `json:"critical_time"`
+}
+
 //go:generate mockgen -source ../interfaces/trace_model_service.go -destination ../interfaces/mock/mock_trace_model_service.go
 type TraceModelService interface {
 	GetSpanList(ctx context.Context, model TraceModel, params SpanListQueryParams) ([]SpanListEntry, int64, error)
 	GetTrace(ctx context.Context, model TraceModel, params TraceQueryParams) (TraceDetail_, error)
 	GetSpan(ctx context.Context, model TraceModel, params SpanQueryParams) (SpanDetail, error)
 	GetSpanRelatedLogList(ctx context.Context, model TraceModel, params RelatedLogListQueryParams) ([]RelatedLogListEntry, int64, error)
+	GetServiceDependencies(ctx context.Context, model TraceModel, params ServiceDependencyQueryParams) (ServiceDependencyGraph, error)
+	GetCriticalPath(ctx context.Context, model TraceModel, params TraceQueryParams) (CriticalPath, error)
 
 	GetTraceModelByID(ctx context.Context, modelID string) (TraceModel, error)
 	SimulateCreateTraceModel(ctx context.Context, model TraceModel) (TraceModel, error)
@@ -0,0 +1,744 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package data_view
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/otel/codes"
+
+	"uniquery/common"
+	cond "uniquery/common/condition"
+	uerrors "uniquery/errors"
+	"uniquery/interfaces"
+	dtype "uniquery/interfaces/data_type"
+)
+
+const (
+	// 分组聚合的名称前缀，第 i 层分组的聚合名为 group_i
+	aggGroupNamePrefix = "group_"
+	// having 条件对应的 bucket_selector 聚合名
+	aggHavingName = "__having"
+	// 每层 terms 分组返回的最大桶数
+	aggMaxGroupBucketSize = 10000
+	// 一次聚合的桶总数上限，与 opensearch search.max_buckets 的默认值一致
+	aggMaxBuckets = 65535
+	// sql 聚合中分组总数的列名
+	aggTotalColumn = "__total"
+)
+
+// 聚合查询：IndexBase、DSL 视图转成 opensearch 的聚合，SQL 视图转成 group by 的 sql。
+// 过滤条件与行列规则复用视图数据查询的逻辑
+func (dvs *dataViewService) queryAggregation(ctx context.Context, query interfaces.ViewQueryInterface,
+	view *interfaces.DataView) (resBytes []byte, total int64, err error) {
+
+	switch view.QueryType {
+	case interfaces.QueryType_IndexBase:
+		return dvs.aggregateByIndexBase(ctx, query, view)
+	case interfaces.QueryType_DSL:
+		return dvs.aggregateByDSL(ctx, query, view)
+	case interfaces.QueryType_SQL:
+		return dvs.aggregateBySQL(ctx, query, view)
+	default:
+		return nil, 0, rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_QueryType).
+			WithErrorDetails("query type must be DSL or SQL or IndexBase")
+	}
+}
+
+func (dvs *dataViewService) aggregateByIndexBase(ctx context.Context, query interfaces.ViewQueryInterface,
+	view *interfaces.DataView) ([]byte, int64, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic layer: Aggregate view data by index base")
+	defer span.End()
+
+	commonParams := query.GetCommonParams()
+
+	// 获取视图的索引库列表
+	baseTypes, baseTypeViewMap, err := GetBaseTypes(view)
+	if err != nil {
+		span.SetStatus(codes.Error, "Get base types failed")
+		return []byte{}, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			rest.PublicError_InternalServerError).WithErrorDetails(err.Error())
+	}
+
+	// 使用索引优化接口获取索引
+	_, indices, _, err := dvs.GetIndices(ctx, baseTypes, commonParams.Start, commonParams.End)
+	if err != nil {
+		span.SetStatus(codes.Error, "Get indices failed")
+		return []byte{}, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			uerrors.Uniquery_DataView_InternalError_GetIndicesFailed).WithErrorDetails(err.Error())
+	}
+
+	// 如果索引列表为空，则返回空数据
+	if len(indices) == 0 {
+		span.SetStatus(codes.Ok, "No indices found")
+		return []byte{}, 0, nil
+	}
+
+	viewIndicesMap, err := getViewIndicesMap(indices, baseTypeViewMap)
+	if err != nil {
+		span.SetStatus(codes.Error, "Get view indices map failed")
+		return []byte{}, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			rest.PublicError_InternalServerError).WithErrorDetails(err.Error())
+	}
+
+	dsl, err := buildAggregationDSL(ctx, query, view, viewIndicesMap)
+	if err != nil {
+		o11y.Error(ctx, err.Error())
+		span.SetStatus(codes.Error, "Convert to aggregation DSL failed")
+		return []byte{}, 0, err
+	}
+
+	dslQueryBuffer, err := marshalDSL(dsl)
+	if err != nil {
+		span.SetStatus(codes.Error, "Marshal DSL failed")
+		return []byte{}, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			rest.PublicError_InternalServerError).WithErrorDetails(err.Error())
+	}
+	logger.Infof("view '%s' aggregation DSL string is %s", view.ViewName, dslQueryBuffer.String())
+
+	resBytes, _, err := dvs.osAccess.SearchSubmitWithBuffer(ctx, dslQueryBuffer, indices, 0, interfaces.DEFAULT_PREFERENCE)
+	if err != nil {
+		span.SetStatus(codes.Error, "Search submit failed")
+		return []byte{}, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			uerrors.Uniquery_InternalError_SearchSubmitFailed).WithErrorDetails(err.Error())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return resBytes, 0, nil
+}
+
+func (dvs *dataViewService) aggregateByDSL(ctx context.Context, query interfaces.ViewQueryInterface,
+	view *interfaces.DataView) ([]byte, int64, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic layer: Aggregate view data by DSL")
+	defer span.End()
+
+	// 获取索引列表, 视图 ID 到索引列表的映射
+	catalogName, indices, viewIndicesMap, err := dvs.getIndicesByView(view)
+	if err != nil {
+		span.SetStatus(codes.Error, "Get indices failed")
+		return []byte{}, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			uerrors.Uniquery_DataView_InternalError_GetIndicesFailed).WithErrorDetails(err.Error())
+	}
+
+	if len(indices) == 0 {
+		span.SetStatus(codes.Ok, "No indices found")
+		return []byte{}, 0, nil
+	}
+
+	dsl, err := buildAggregationDSL(ctx, query, view, viewIndicesMap)
+	if err != nil {
+		o11y.Error(ctx, err.Error())
+		span.SetStatus(codes.Error, "Convert to aggregation DSL failed")
+		return []byte{}, 0, err
+	}
+
+	// 记录查询vega耗时
+	startTime := time.Now()
+	defer func() {
+		elapsed := time.Since(startTime).Milliseconds()
+		logger.Infof("query vega data cost time is %dms", elapsed)
+		query.SetVegaDuration(elapsed)
+	}()
+
+	dataBatch, err := dvs.vgAccess.FetchDataNoUnmarshal(ctx, &interfaces.FetchVegaDataParams{
+		IsSingleDataSource: isSingleDataSource(view),
+		QueryType:          interfaces.QueryType_DSL,
+		DataSourceID:       getQueryDataSourceID(view),
+		CatalogName:        catalogName,
+		TableNames:         indices,
+		Dsl:                dsl,
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "Fetch data from vega failed")
+		return nil, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError, uerrors.Uniquery_DataView_InternalError_FetchDataFromVegaFailed).
+			WithErrorDetails(err.Error())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dataBatch, 0, nil
+}
+
+func (dvs *dataViewService) aggregateBySQL(ctx context.Context, query interfaces.ViewQueryInterface,
+	view *interfaces.DataView) ([]byte, int64, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic layer: Aggregate view data by SQL")
+	defer span.End()
+
+	commonParams := query.GetCommonParams()
+
+	sqlStr, newFields, newFieldsMap, err := buildFilteredViewSql(ctx, query, view)
+	if err != nil {
+		span.SetStatus(codes.Error, "Build view sql failed")
+		return nil, 0, err
+	}
+	// 更新视图字段为行列规则定义的字段
+	defer func() {
+		view.Fields = newFields
+		view.FieldsMap = newFieldsMap
+	}()
+
+	aggSql, err := buildAggregationSql(ctx, query.GetAggregation(), newFieldsMap, sqlStr, commonParams.Limit)
+	if err != nil {
+		span.SetStatus(codes.Error, "Build aggregation sql failed")
+		return nil, 0, err
+	}
+	logger.Infof("aggregation sqlStr is [%s]", aggSql)
+
+	startTime := time.Now()
+	defer func() {
+		elapsed := time.Since(startTime).Milliseconds()
+		logger.Infof("query vega data cost time is %dms", elapsed)
+		query.SetVegaDuration(elapsed)
+	}()
+
+	timeout := query.GetQueryParams()[interfaces.QueryParam_Timeout].(time.Duration)
+	dataBatch, err := dvs.vgAccess.FetchDataNoUnmarshal(ctx, &interfaces.FetchVegaDataParams{
+		IsSingleDataSource: isSingleDataSource(view),
+		QueryType:          interfaces.QueryType_SQL,
+		DataSourceID:       getQueryDataSourceID(view),
+		SqlStr:             aggSql,
+		Limit:              commonParams.Limit,
+		Timeout:            int64(timeout.Seconds()),
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "Fetch data from vega failed")
+		return nil, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError, uerrors.Uniquery_DataView_InternalError_FetchDataFromVegaFailed).
+			WithErrorDetails(err.Error())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dataBatch, 0, nil
+}
+
+// 在视图查询的 DSL 上追加聚合。查询条件、全局过滤和行列规则沿用 buildDSL, 不需要返回文档
+func buildAggregationDSL(ctx context.Context, query interfaces.ViewQueryInterface, view *interfaces.DataView,
+	viewIndicesMap map[string][]string) (interfaces.DSLCfg, error) {
+
+	dsl, err := buildDSL(ctx, query, view, viewIndicesMap)
+	if err != nil {
+		return dsl, err
+	}
+
+	dsl.From = 0
+	dsl.Size = 0
+	dsl.Sort = nil
+	dsl.SearchAfter = nil
+	dsl.Pit = nil
+
+	agg := query.GetAggregation()
+	// 不分组时 doc_count 取命中的总数
+	dsl.TrackTotalHits = len(agg.GroupBy) == 0
+
+	// buildDSL 之后视图字段已是行列规则限制后的字段
+	commonParams := query.GetCommonParams()
+	size := aggGroupBucketSize(len(agg.GroupBy), commonParams.Limit, commonParams.NeedTotal || len(agg.Having) > 0)
+	aggs, err := buildAggregationAggs(ctx, agg, view.FieldsMap, size)
+	if err != nil {
+		return dsl, err
+	}
+	dsl.Aggs = aggs
+
+	return dsl, nil
+}
+
+// 每层 terms 分组的桶数。各层桶数相乘后的桶总数不能超过 aggMaxBuckets。
+// 不需要全部分组时(不返回总数且没有 having)，每层取前 limit 个桶就能得到展开后的前 limit 行
+func aggGroupBucketSize(levels int, limit int, needAllGroups bool) int {
+	bucketsOf := func(size int) float64 {
+		total := 0.0
+		for i := 1; i <= levels; i++ {
+			total += math.Pow(float64(size), float64(i))
+		}
+		return total
+	}
+
+	size := aggMaxGroupBucketSize
+	if levels > 1 {
+		size = int(math.Pow(aggMaxBuckets, 1/float64(levels)))
+		for size > 1 && bucketsOf(size) > aggMaxBuckets {
+			size--
+		}
+	}
+	if !needAllGroups && limit > 0 && limit < size {
+		size = limit
+	}
+	return size
+}
+
+// 按分组由内向外嵌套 terms 或 date_histogram 聚合，指标和 having 放在最内层的分组下
+func buildAggregationAggs(ctx context.Context, agg *interfaces.ViewAggregation, fieldsMap map[string]*cond.ViewField,
+	size int) (map[string]any, error) {
+
+	aggs := map[string]any{}
+	for _, metric := range agg.Metrics {
+		// doc_count 直接使用桶的文档数
+		if metric.Aggr == interfaces.AGGR_TYPE_DOC_COUNT {
+			continue
+		}
+
+		field, err := getAggregationField(ctx, metric.Field, metric.Aggr, fieldsMap)
+		if err != nil {
+			return nil, err
+		}
+		fieldName, err := getAggregationDSLFieldName(ctx, field)
+		if err != nil {
+			return nil, err
+		}
+
+		if metric.Aggr == interfaces.AGGR_TYPE_PERCENTILES {
+			aggs[metric.Name] = map[string]any{
+				"percentiles": map[string]any{
+					"field":    fieldName,
+					"percents": metric.Percents,
+					"keyed":    false,
+				},
+			}
+		} else {
+			aggs[metric.Name] = map[string]any{
+				metric.Aggr: map[string]any{
+					"field": fieldName,
+				},
+			}
+		}
+	}
+
+	if len(agg.Having) > 0 {
+		aggs[aggHavingName] = buildHavingBucketSelector(agg)
+	}
+
+	for i := len(agg.GroupBy) - 1; i >= 0; i-- {
+		group := agg.GroupBy[i]
+		field, err := getAggregationField(ctx, group.Field, "", fieldsMap)
+		if err != nil {
+			return nil, err
+		}
+		fieldName, err := getAggregationDSLFieldName(ctx, field)
+		if err != nil {
+			return nil, err
+		}
+
+		var bucketAgg map[string]any
+		if group.CalendarInterval != "" {
+			if !dtype.DataType_IsDate(field.Type) {
+				return nil, rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+					WithErrorDetails(fmt.Sprintf("The group_by field '%s' with calendar_interval should be date type, but got '%s'",
+						group.Field, field.Type))
+			}
+			bucketAgg = map[string]any{
+				"date_histogram": map[string]any{
+					"field":             fieldName,
+					"calendar_interval": interfaces.CALENDAR_INTERVALS[group.CalendarInterval],
+					"time_zone":         interfaces.DEFAULT_QUERY_TIME_ZONE.String(),
+					"min_doc_count":     1,
+				},
+			}
+		} else {
+			// 与 sql 的 order by 保持一致，按分组的值升序
+			bucketAgg = map[string]any{
+				"terms": map[string]any{
+					"field": fieldName,
+					"size":  size,
+					"order": map[string]any{"_key": interfaces.ASC_DIRECTION},
+				},
+			}
+		}
+		if len(aggs) > 0 {
+			bucketAgg["aggs"] = aggs
+		}
+		aggs = map[string]any{fmt.Sprintf("%s%d", aggGroupNamePrefix, i): bucketAgg}
+	}
+
+	return aggs, nil
+}
+
+// having 条件转成 bucket_selector，buckets_path 的变量名不能使用指标名中的字符，按序号命名
+func buildHavingBucketSelector(agg *interfaces.ViewAggregation) map[string]any {
+	metricAggrs := make(map[string]string, len(agg.Metrics))
+	for _, metric := range agg.Metrics {
+		metricAggrs[metric.Name] = metric.Aggr
+	}
+
+	bucketsPath := map[string]any{}
+	scripts := make([]string, 0, len(agg.Having))
+	for i, having := range agg.Having {
+		variable := fmt.Sprintf("h%d", i)
+		if metricAggrs[having.Metric] == interfaces.AGGR_TYPE_DOC_COUNT {
+			bucketsPath[variable] = "_count"
+		} else {
+			bucketsPath[variable] = having.Metric
+		}
+		scripts = append(scripts, fmt.Sprintf("params.%s %s %v", variable, having.Operation, having.Value))
+	}
+
+	return map[string]any{
+		"bucket_selector": map[string]any{
+			"buckets_path": bucketsPath,
+			"script":       strings.Join(scripts, " && "),
+		},
+	}
+}
+
+// 获取聚合使用的视图字段，aggr 为空表示分组字段
+func getAggregationField(ctx context.Context, name string, aggr string,
+	fieldsMap map[string]*cond.ViewField) (*cond.ViewField, error) {
+
+	field, ok := fieldsMap[name]
+	if !ok {
+		return nil, rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+			WithErrorDetails(fmt.Sprintf("The aggregation field '%s' is not in the view fields list", name))
+	}
+
+	if field.Type == dtype.DataType_Binary {
+		return nil, rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+			WithErrorDetails(fmt.Sprintf("The aggregation field '%s' is binary type, do not support aggregation", name))
+	}
+
+	switch aggr {
+	case interfaces.AGGR_TYPE_SUM, interfaces.AGGR_TYPE_AVG, interfaces.AGGR_TYPE_PERCENTILES:
+		if !dtype.DataType_IsNumber(field.Type) {
+			return nil, rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails(fmt.Sprintf("The field '%s' of %s should be number type, but got '%s'", name, aggr, field.Type))
+		}
+	case interfaces.AGGR_TYPE_MAX, interfaces.AGGR_TYPE_MIN:
+		if !dtype.DataType_IsNumber(field.Type) && !dtype.DataType_IsDate(field.Type) {
+			return nil, rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails(fmt.Sprintf("The field '%s' of %s should be number or date type, but got '%s'", name, aggr, field.Type))
+		}
+	}
+
+	return field, nil
+}
+
+// text 类型的字段需要使用其下的 keyword 索引做聚合
+func getAggregationDSLFieldName(ctx context.Context, field *cond.ViewField) (string, error) {
+	if cond.IsTextType(field) {
+		if !cond.HasFeature(field, cond.FieldFeatureType_Keyword) {
+			return "", rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+				WithErrorDetails(fmt.Sprintf("The field '%s' is text type without keyword index, do not support aggregation", field.Name))
+		}
+		return field.OriginalName + "." + dtype.KEYWORD_SUFFIX, nil
+	}
+
+	return field.OriginalName, nil
+}
+
+// 把视图过滤后的 sql 作为子查询，拼接 group by、having、order by 和 limit。
+// 有分组时用窗口函数带出 limit 之前的分组总数
+func buildAggregationSql(ctx context.Context, agg *interfaces.ViewAggregation, fieldsMap map[string]*cond.ViewField,
+	fromSql string, limit int) (string, error) {
+
+	selects := []string{}
+	groups := []string{}
+	for _, group := range agg.GroupBy {
+		field, err := getAggregationField(ctx, group.Field, "", fieldsMap)
+		if err != nil {
+			return "", err
+		}
+
+		expr := common.QuotationMark(field.OriginalName)
+		if group.CalendarInterval != "" {
+			if !dtype.DataType_IsDate(field.Type) {
+				return "", rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_DataView_InvalidParameter_Aggregation).
+					WithErrorDetails(fmt.Sprintf("The group_by field '%s' with calendar_interval should be date type, but got '%s'",
+						group.Field, field.Type))
+			}
+			// 与 date_histogram 的 key 保持一致，返回桶开始时间的毫秒时间戳
+			expr = fmt.Sprintf(`CAST(to_unixtime(date_trunc('%s', %s)) * 1000 AS BIGINT)`,
+				interfaces.CALENDAR_INTERVALS[group.CalendarInterval], expr)
+		}
+		selects = append(selects, fmt.Sprintf("%s AS %s", expr, common.QuotationMark(group.Field)))
+		groups = append(groups, expr)
+	}
+
+	metricExprs := make(map[string]string, len(agg.Metrics))
+	for _, metric := range agg.Metrics {
+		if metric.Aggr == interfaces.AGGR_TYPE_DOC_COUNT {
+			metricExprs[metric.Name] = "count(*)"
+			selects = append(selects, fmt.Sprintf("count(*) AS %s", common.QuotationMark(metric.Name)))
+			continue
+		}
+
+		field, err := getAggregationField(ctx, metric.Field, metric.Aggr, fieldsMap)
+		if err != nil {
+			return "", err
+		}
+		column := common.QuotationMark(field.OriginalName)
+
+		var expr string
+		switch metric.Aggr {
+		case interfaces.AGGR_TYPE_VALUE_COUNT:
+			expr = fmt.Sprintf("count(%s)", column)
+		case interfaces.AGGR_TYPE_CARDINALITY:
+			expr = fmt.Sprintf("count(DISTINCT %s)", column)
+		case interfaces.AGGR_TYPE_PERCENTILES:
+			// 每个百分位一列，列名为 指标名.序号
+			for i, p := range metric.Percents {
+				selects = append(selects, fmt.Sprintf("approx_percentile(%s, %v) AS %s", column, p/100,
+					common.QuotationMark(percentileColumn(metric.Name, i))))
+			}
+			continue
+		default:
+			expr = fmt.Sprintf("%s(%s)", metric.Aggr, column)
+		}
+		metricExprs[metric.Name] = expr
+		selects = append(selects, fmt.Sprintf("%s AS %s", expr, common.QuotationMark(metric.Name)))
+	}
+
+	if len(groups) > 0 {
+		selects = append(selects, fmt.Sprintf("count(*) OVER () AS %s", common.QuotationMark(aggTotalColumn)))
+	}
+
+	sqlStr := fmt.Sprintf("SELECT %s FROM (%s)t", strings.Join(selects, ", "), strings.TrimSuffix(fromSql, ";"))
+	if len(groups) > 0 {
+		sqlStr = fmt.Sprintf("%s GROUP BY %s", sqlStr, strings.Join(groups, ", "))
+	}
+
+	havings := make([]string, 0, len(agg.Having))
+	for _, having := range agg.Having {
+		operation := having.Operation
+		if operation == cond.OperationEq {
+			operation = "="
+		}
+		havings = append(havings, fmt.Sprintf("%s %s %v", metricExprs[having.Metric], operation, having.Value))
+	}
+	if len(havings) > 0 {
+		sqlStr = fmt.Sprintf("%s HAVING %s", sqlStr, strings.Join(havings, " AND "))
+	}
+
+	if len(groups) > 0 {
+		sqlStr = fmt.Sprintf("%s ORDER BY %s", sqlStr, strings.Join(groups, ", "))
+	}
+	if limit > 0 {
+		sqlStr = fmt.Sprintf("%s LIMIT %d", sqlStr, limit)
+	}
+
+	return sqlStr, nil
+}
+
+func percentileColumn(metricName string, i int) string {
+	return metricName + "." + strconv.Itoa(i)
+}
+
+func percentileKey(percent float64) string {
+	return strconv.FormatFloat(percent, 'f', -1, 64)
+}
+
+// 聚合结果转成视图统一结构，每个分组一行
+func convertAggregationToViewUniResponse(ctx context.Context, query interfaces.ViewQueryInterface,
+	view *interfaces.DataView, content []byte, total int64) (*interfaces.ViewUniResponseV2, error) {
+
+	agg := query.GetAggregation()
+	limit := query.GetCommonParams().Limit
+
+	var entries []map[string]any
+	// opensearch 的分组有桶数上限，被截掉的分组不计入 total，此时 total 为下限
+	relation := interfaces.TotalCountRelation_Eq
+	switch view.QueryType {
+	case interfaces.QueryType_IndexBase, interfaces.QueryType_DSL:
+		root := gjson.ParseBytes(content)
+		if view.QueryType == interfaces.QueryType_DSL {
+			// vega 返回的 data 中是 opensearch 的查询结果
+			root = root.Get("data.0")
+		}
+		var complete bool
+		entries, complete = convertAggregationBuckets(agg, root)
+		total = int64(len(entries))
+		if !complete {
+			relation = interfaces.TotalCountRelation_Gte
+		}
+	case interfaces.QueryType_SQL:
+		var err error
+		entries, total, err = convertAggregationRows(agg, content, isSingleDataSource(view))
+		if err != nil {
+			return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+				uerrors.Uniquery_DataView_InternalError_GetDocumentsFailed).WithErrorDetails(err.Error())
+		}
+	default:
+		return nil, rest.NewHTTPError(ctx, http.StatusBadRequest, rest.PublicError_BadRequest).
+			WithErrorDetails("view query type must be DSL or SQL or IndexBase")
+	}
+
+	// limit 作用在展开后的行上
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	includeView := query.GetQueryParams()[interfaces.QueryParam_IncludeView].(bool)
+	if !includeView {
+		view = nil
+	}
+
+	// 聚合查询的 total 为 limit 截断前的分组数
+	var totalCount *int64
+	totalRelation := ""
+	if query.GetCommonParams().NeedTotal {
+		totalCount = &total
+		totalRelation = relation
+	}
+
+	return &interfaces.ViewUniResponseV2{
+		View:           view,
+		Entries:        entries,
+		TotalCount:     totalCount,
+		TotalRelation:  totalRelation,
+		VegaDurationMs: query.GetVegaDuration(),
+	}, nil
+}
+
+// 展开 opensearch 的嵌套分组桶，每个最内层的桶一行。
+// 任一 terms 分组的 sum_other_doc_count 大于 0 说明有分组超出桶数上限，此时返回 complete 为 false
+func convertAggregationBuckets(agg *interfaces.ViewAggregation, root gjson.Result) (entries []map[string]any, complete bool) {
+	entries = []map[string]any{}
+	complete = true
+	aggregations := root.Get("aggregations")
+
+	if len(agg.GroupBy) == 0 {
+		entry := map[string]any{}
+		setAggregationMetrics(agg, aggregations, root.Get("hits.total.value").Int(), entry)
+		return append(entries, entry), complete
+	}
+
+	var walk func(node gjson.Result, level int, row map[string]any)
+	walk = func(node gjson.Result, level int, row map[string]any) {
+		group := agg.GroupBy[level]
+		groupAgg := node.Get(fmt.Sprintf("%s%d", aggGroupNamePrefix, level))
+		if groupAgg.Get("sum_other_doc_count").Int() > 0 {
+			complete = false
+		}
+		for _, bucket := range groupAgg.Get("buckets").Array() {
+			if group.CalendarInterval != "" {
+				row[group.Field] = bucket.Get("key").Int()
+			} else {
+				row[group.Field] = bucket.Get("key").Value()
+			}
+
+			if level < len(agg.GroupBy)-1 {
+				walk(bucket, level+1, row)
+				continue
+			}
+
+			entry := make(map[string]any, len(row)+len(agg.Metrics))
+			for k, v := range row {
+				entry[k] = v
+			}
+			setAggregationMetrics(agg, bucket, bucket.Get("doc_count").Int(), entry)
+			entries = append(entries, entry)
+		}
+	}
+	walk(aggregations, 0, map[string]any{})
+
+	return entries, complete
+}
+
+func setAggregationMetrics(agg *interfaces.ViewAggregation, bucket gjson.Result, docCount int64, entry map[string]any) {
+	for _, metric := range agg.Metrics {
+		switch metric.Aggr {
+		case interfaces.AGGR_TYPE_DOC_COUNT:
+			entry[metric.Name] = docCount
+		case interfaces.AGGR_TYPE_VALUE_COUNT, interfaces.AGGR_TYPE_CARDINALITY:
+			entry[metric.Name] = bucket.Get(metric.Name + ".value").Int()
+		case interfaces.AGGR_TYPE_PERCENTILES:
+			values := map[string]any{}
+			for _, v := range bucket.Get(metric.Name + ".values").Array() {
+				values[percentileKey(v.Get("key").Float())] = v.Get("value").Value()
+			}
+			entry[metric.Name] = values
+		default:
+			entry[metric.Name] = bucket.Get(metric.Name + ".value").Value()
+		}
+	}
+}
+
+// 按列名把 sql 的结果行转成对象，百分位的多列合并成一个对象。同时返回 limit 之前的分组总数
+func convertAggregationRows(agg *interfaces.ViewAggregation, content []byte,
+	isSingleDataSource bool) ([]map[string]any, int64, error) {
+
+	result := gjson.ParseBytes(content)
+
+	columns := result.Get("columns").Array()
+	if len(columns) == 0 {
+		return nil, 0, fmt.Errorf("SQL columns not exists in response")
+	}
+	columnIndex := make(map[string]int, len(columns))
+	for i, column := range columns {
+		columnIndex[column.Get("name").String()] = i
+	}
+
+	rowsPath := "data"
+	if isSingleDataSource {
+		rowsPath = "entries"
+	}
+
+	entries := []map[string]any{}
+	total := int64(0)
+	for _, row := range result.Get(rowsPath).Array() {
+		values := row.Array()
+		valueOf := func(name string) (gjson.Result, bool) {
+			i, ok := columnIndex[name]
+			if !ok || i >= len(values) {
+				return gjson.Result{}, false
+			}
+			return values[i], true
+		}
+
+		entry := make(map[string]any, len(agg.GroupBy)+len(agg.Metrics))
+		for _, group := range agg.GroupBy {
+			v, ok := valueOf(group.Field)
+			if !ok {
+				return nil, 0, fmt.Errorf("SQL column '%s' not exists in response", group.Field)
+			}
+			if group.CalendarInterval != "" {
+				entry[group.Field] = v.Int()
+			} else {
+				entry[group.Field] = v.Value()
+			}
+		}
+
+		for _, metric := range agg.Metrics {
+			if metric.Aggr == interfaces.AGGR_TYPE_PERCENTILES {
+				percentiles := make(map[string]any, len(metric.Percents))
+				for i, p := range metric.Percents {
+					if v, ok := valueOf(percentileColumn(metric.Name, i)); ok {
+						percentiles[percentileKey(p)] = v.Value()
+					}
+				}
+				entry[metric.Name] = percentiles
+				continue
+			}
+
+			v, ok := valueOf(metric.Name)
+			if !ok {
+				return nil, 0, fmt.Errorf("SQL column '%s' not exists in response", metric.Name)
+			}
+			switch metric.Aggr {
+			case interfaces.AGGR_TYPE_DOC_COUNT, interfaces.AGGR_TYPE_VALUE_COUNT, interfaces.AGGR_TYPE_CARDINALITY:
+				entry[metric.Name] = v.Int()
+			default:
+				entry[metric.Name] = v.Value()
+			}
+		}
+		entries = append(entries, entry)
+
+		if v, ok := valueOf(aggTotalColumn); ok {
+			total = v.Int()
+		}
+	}
+	if total < int64(len(entries)) {
+		total = int64(len(entries))
+	}
+
+	return entries, total, nil
+}
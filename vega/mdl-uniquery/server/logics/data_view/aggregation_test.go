@@ -0,0 +1,320 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package data_view
+
+import (
+	"testing"
+
+	"github.com/bytedance/sonic"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/tidwall/gjson"
+
+	cond "uniquery/common/condition"
+	"uniquery/interfaces"
+	dtype "uniquery/interfaces/data_type"
+)
+
+var aggTestFieldsMap = map[string]*cond.ViewField{
+	"@timestamp": {Name: "@timestamp", OriginalName: "@timestamp", Type: dtype.DataType_Datetime},
+	"host": {Name: "host", OriginalName: "host_name", Type: dtype.DataType_Text,
+		Features: []cond.FieldFeature{{FeatureType: cond.FieldFeatureType_Keyword}}},
+	"message": {Name: "message", OriginalName: "message", Type: dtype.DataType_Text},
+	"cost":    {Name: "cost", OriginalName: "cost", Type: dtype.DataType_Float},
+}
+
+func TestBuildAggregationAggs(t *testing.T) {
+	Convey("Test buildAggregationAggs", t, func() {
+
+		Convey("nested groups with metrics and having", func() {
+			agg := &interfaces.ViewAggregation{
+				GroupBy: []*interfaces.ViewAggGroupBy{
+					{Field: "host"},
+					{Field: "@timestamp", CalendarInterval: interfaces.CALENDAR_STEP_HOUR},
+				},
+				Metrics: []*interfaces.ViewAggMetric{
+					{Name: "cnt", Aggr: interfaces.AGGR_TYPE_DOC_COUNT},
+					{Name: "avg_cost", Aggr: interfaces.AGGR_TYPE_AVG, Field: "cost"},
+					{Name: "p", Aggr: interfaces.AGGR_TYPE_PERCENTILES, Field: "cost", Percents: []float64{50, 99}},
+				},
+				Having: []*interfaces.ViewAggHaving{
+					{Metric: "cnt", Operation: cond.OperationGt, Value: 10},
+					{Metric: "avg_cost", Operation: cond.OperationLte, Value: 2.5},
+				},
+			}
+
+			aggs, err := buildAggregationAggs(testCtx, agg, aggTestFieldsMap, 10)
+			So(err, ShouldBeNil)
+
+			bytes, _ := sonic.Marshal(aggs)
+			res := gjson.ParseBytes(bytes)
+			So(res.Get("group_0.terms.field").String(), ShouldEqual, "host_name.keyword")
+			So(res.Get("group_0.terms.size").Int(), ShouldEqual, 10)
+			So(res.Get("group_0.aggs.group_1.date_histogram.calendar_interval").String(), ShouldEqual, "hour")
+
+			inner := res.Get("group_0.aggs.group_1.aggs")
+			So(inner.Get("cnt").Exists(), ShouldBeFalse)
+			So(inner.Get("avg_cost.avg.field").String(), ShouldEqual, "cost")
+			So(inner.Get("p.percentiles.keyed").Bool(), ShouldBeFalse)
+			So(inner.Get("__having.bucket_selector.buckets_path.h0").String(), ShouldEqual, "_count")
+			So(inner.Get("__having.bucket_selector.buckets_path.h1").String(), ShouldEqual, "avg_cost")
+			So(inner.Get("__having.bucket_selector.script").String(), ShouldEqual, "params.h0 > 10 && params.h1 <= 2.5")
+		})
+
+		Convey("field not in view", func() {
+			agg := &interfaces.ViewAggregation{
+				GroupBy: []*interfaces.ViewAggGroupBy{{Field: "unknown"}},
+				Metrics: []*interfaces.ViewAggMetric{{Name: "cnt", Aggr: interfaces.AGGR_TYPE_DOC_COUNT}},
+			}
+			_, err := buildAggregationAggs(testCtx, agg, aggTestFieldsMap, 10)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("text field without keyword", func() {
+			agg := &interfaces.ViewAggregation{
+				GroupBy: []*interfaces.ViewAggGroupBy{{Field: "message"}},
+				Metrics: []*interfaces.ViewAggMetric{{Name: "cnt", Aggr: interfaces.AGGR_TYPE_DOC_COUNT}},
+			}
+			_, err := buildAggregationAggs(testCtx, agg, aggTestFieldsMap, 10)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("sum of non number field", func() {
+			agg := &interfaces.ViewAggregation{
+				Metrics: []*interfaces.ViewAggMetric{{Name: "s", Aggr: interfaces.AGGR_TYPE_SUM, Field: "host"}},
+			}
+			_, err := buildAggregationAggs(testCtx, agg, aggTestFieldsMap, 10)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("calendar interval on non date field", func() {
+			agg := &interfaces.ViewAggregation{
+				GroupBy: []*interfaces.ViewAggGroupBy{{Field: "cost", CalendarInterval: interfaces.CALENDAR_STEP_DAY}},
+				Metrics: []*interfaces.ViewAggMetric{{Name: "cnt", Aggr: interfaces.AGGR_TYPE_DOC_COUNT}},
+			}
+			_, err := buildAggregationAggs(testCtx, agg, aggTestFieldsMap, 10)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestAggGroupBucketSize(t *testing.T) {
+	Convey("Test aggGroupBucketSize", t, func() {
+
+		Convey("single level", func() {
+			So(aggGroupBucketSize(1, 10, true), ShouldEqual, aggMaxGroupBucketSize)
+			So(aggGroupBucketSize(1, 10, false), ShouldEqual, 10)
+		})
+
+		Convey("nested levels stay within max buckets", func() {
+			for levels := 2; levels <= 4; levels++ {
+				size := aggGroupBucketSize(levels, 0, true)
+				buckets, next := 0, 0
+				for i, n := 1, 1; i <= levels; i++ {
+					n *= size
+					buckets += n
+				}
+				for i, n := 1, 1; i <= levels; i++ {
+					n *= size + 1
+					next += n
+				}
+				So(buckets, ShouldBeLessThanOrEqualTo, aggMaxBuckets)
+				So(next, ShouldBeGreaterThan, aggMaxBuckets)
+			}
+			So(aggGroupBucketSize(2, 0, true), ShouldEqual, 255)
+		})
+
+		Convey("limit larger than the derived size", func() {
+			So(aggGroupBucketSize(3, 1000, false), ShouldEqual, aggGroupBucketSize(3, 0, true))
+		})
+	})
+}
+
+func TestBuildAggregationSql(t *testing.T) {
+	Convey("Test buildAggregationSql", t, func() {
+
+		Convey("group by with having", func() {
+			agg := &interfaces.ViewAggregation{
+				GroupBy: []*interfaces.ViewAggGroupBy{
+					{Field: "host"},
+					{Field: "@timestamp", CalendarInterval: interfaces.CALENDAR_STEP_DAY},
+				},
+				Metrics: []*interfaces.ViewAggMetric{
+					{Name: "cnt", Aggr: interfaces.AGGR_TYPE_DOC_COUNT},
+					{Name: "uv", Aggr: interfaces.AGGR_TYPE_CARDINALITY, Field: "host"},
+					{Name: "p", Aggr: interfaces.AGGR_TYPE_PERCENTILES, Field: "cost", Percents: []float64{90}},
+				},
+				Having: []*interfaces.ViewAggHaving{
+					{Metric: "cnt", Operation: cond.OperationEq, Value: 3},
+				},
+			}
+
+			sqlStr, err := buildAggregationSql(testCtx, agg, aggTestFieldsMap, "SELECT * FROM t1 WHERE a = 1;", 100)
+			So(err, ShouldBeNil)
+
+			day := `CAST(to_unixtime(date_trunc('day', "@timestamp")) * 1000 AS BIGINT)`
+			expected := `SELECT "host_name" AS "host", ` + day + ` AS "@timestamp", count(*) AS "cnt", ` +
+				`count(DISTINCT "host_name") AS "uv", approx_percentile("cost", 0.9) AS "p.0", count(*) OVER () AS "__total" ` +
+				`FROM (SELECT * FROM t1 WHERE a = 1)t GROUP BY "host_name", ` + day +
+				` HAVING count(*) = 3 ORDER BY "host_name", ` + day + ` LIMIT 100`
+			So(sqlStr, ShouldEqual, expected)
+		})
+
+		Convey("without group by", func() {
+			agg := &interfaces.ViewAggregation{
+				Metrics: []*interfaces.ViewAggMetric{{Name: "m", Aggr: interfaces.AGGR_TYPE_MAX, Field: "cost"}},
+			}
+
+			sqlStr, err := buildAggregationSql(testCtx, agg, aggTestFieldsMap, "SELECT * FROM t1", 10)
+			So(err, ShouldBeNil)
+			So(sqlStr, ShouldEqual, `SELECT max("cost") AS "m" FROM (SELECT * FROM t1)t LIMIT 10`)
+		})
+
+		Convey("field not in view", func() {
+			agg := &interfaces.ViewAggregation{
+				Metrics: []*interfaces.ViewAggMetric{{Name: "m", Aggr: interfaces.AGGR_TYPE_MAX, Field: "unknown"}},
+			}
+
+			_, err := buildAggregationSql(testCtx, agg, aggTestFieldsMap, "SELECT * FROM t1", 10)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestConvertAggregationBuckets(t *testing.T) {
+	Convey("Test convertAggregationBuckets", t, func() {
+		agg := &interfaces.ViewAggregation{
+			GroupBy: []*interfaces.ViewAggGroupBy{
+				{Field: "host"},
+				{Field: "@timestamp", CalendarInterval: interfaces.CALENDAR_STEP_HOUR},
+			},
+			Metrics: []*interfaces.ViewAggMetric{
+				{Name: "cnt", Aggr: interfaces.AGGR_TYPE_DOC_COUNT},
+				{Name: "avg_cost", Aggr: interfaces.AGGR_TYPE_AVG, Field: "cost"},
+				{Name: "p", Aggr: interfaces.AGGR_TYPE_PERCENTILES, Field: "cost", Percents: []float64{50}},
+			},
+		}
+		content := `{"aggregations":{"group_0":{"buckets":[
+			{"key":"a","doc_count":3,"group_1":{"buckets":[
+				{"key":1000,"doc_count":2,"avg_cost":{"value":1.5},"p":{"values":[{"key":50.0,"value":1}]}},
+				{"key":2000,"doc_count":1,"avg_cost":{"value":3},"p":{"values":[{"key":50.0,"value":3}]}}]}},
+			{"key":"b","doc_count":1,"group_1":{"buckets":[
+				{"key":1000,"doc_count":1,"avg_cost":{"value":2},"p":{"values":[{"key":50.0,"value":2}]}}]}}]}}}`
+
+		Convey("all buckets", func() {
+			entries, complete := convertAggregationBuckets(agg, gjson.Parse(content))
+			So(complete, ShouldBeTrue)
+			So(len(entries), ShouldEqual, 3)
+			So(entries[0], ShouldResemble, map[string]any{
+				"host":       "a",
+				"@timestamp": int64(1000),
+				"cnt":        int64(2),
+				"avg_cost":   1.5,
+				"p":          map[string]any{"50": float64(1)},
+			})
+			So(entries[2]["host"], ShouldEqual, "b")
+		})
+
+		Convey("groups beyond the bucket size", func() {
+			content := `{"aggregations":{"group_0":{"sum_other_doc_count":0,"buckets":[
+				{"key":"a","doc_count":3,"group_1":{"sum_other_doc_count":1,"buckets":[
+					{"key":1000,"doc_count":2,"avg_cost":{"value":1.5},"p":{"values":[]}}]}}]}}}`
+			entries, complete := convertAggregationBuckets(agg, gjson.Parse(content))
+			So(complete, ShouldBeFalse)
+			So(len(entries), ShouldEqual, 1)
+		})
+
+		Convey("without group by", func() {
+			agg := &interfaces.ViewAggregation{
+				Metrics: []*interfaces.ViewAggMetric{
+					{Name: "cnt", Aggr: interfaces.AGGR_TYPE_DOC_COUNT},
+					{Name: "uv", Aggr: interfaces.AGGR_TYPE_CARDINALITY, Field: "host"},
+				},
+			}
+			entries, _ := convertAggregationBuckets(agg, gjson.Parse(`{"hits":{"total":{"value":42}},"aggregations":{"uv":{"value":5}}}`))
+			So(entries, ShouldResemble, []map[string]any{{"cnt": int64(42), "uv": int64(5)}})
+		})
+	})
+}
+
+func TestConvertAggregationToViewUniResponse(t *testing.T) {
+	Convey("Test convertAggregationToViewUniResponse", t, func() {
+		query := &interfaces.DataViewQueryV2{
+			ViewQueryCommonParams: interfaces.ViewQueryCommonParams{Limit: 2, NeedTotal: true},
+			Aggregation: &interfaces.ViewAggregation{
+				GroupBy: []*interfaces.ViewAggGroupBy{{Field: "host"}, {Field: "level"}},
+				Metrics: []*interfaces.ViewAggMetric{{Name: "cnt", Aggr: interfaces.AGGR_TYPE_DOC_COUNT}},
+			},
+		}
+		view := &interfaces.DataView{QueryType: interfaces.QueryType_IndexBase}
+
+		Convey("limit applies to flattened rows and total counts all groups", func() {
+			content := `{"aggregations":{"group_0":{"buckets":[
+				{"key":"a","group_1":{"buckets":[{"key":"info","doc_count":2},{"key":"warn","doc_count":1}]}},
+				{"key":"b","group_1":{"buckets":[{"key":"info","doc_count":4}]}}]}}}`
+			res, err := convertAggregationToViewUniResponse(testCtx, query, view, []byte(content), 0)
+			So(err, ShouldBeNil)
+			So(len(res.Entries), ShouldEqual, 2)
+			So(res.Entries[1], ShouldResemble, map[string]any{"host": "a", "level": "warn", "cnt": int64(1)})
+			So(*res.TotalCount, ShouldEqual, 3)
+			So(res.TotalRelation, ShouldEqual, interfaces.TotalCountRelation_Eq)
+		})
+
+		Convey("total is a lower bound when groups exceed the bucket size", func() {
+			content := `{"aggregations":{"group_0":{"sum_other_doc_count":5,"buckets":[
+				{"key":"a","group_1":{"buckets":[{"key":"info","doc_count":2}]}}]}}}`
+			res, err := convertAggregationToViewUniResponse(testCtx, query, view, []byte(content), 0)
+			So(err, ShouldBeNil)
+			So(*res.TotalCount, ShouldEqual, 1)
+			So(res.TotalRelation, ShouldEqual, interfaces.TotalCountRelation_Gte)
+		})
+	})
+}
+
+func TestConvertAggregationRows(t *testing.T) {
+	Convey("Test convertAggregationRows", t, func() {
+		agg := &interfaces.ViewAggregation{
+			GroupBy: []*interfaces.ViewAggGroupBy{{Field: "host"}},
+			Metrics: []*interfaces.ViewAggMetric{
+				{Name: "cnt", Aggr: interfaces.AGGR_TYPE_DOC_COUNT},
+				{Name: "p", Aggr: interfaces.AGGR_TYPE_PERCENTILES, Field: "cost", Percents: []float64{50, 99.9}},
+			},
+		}
+
+		Convey("single data source", func() {
+			content := `{"columns":[{"name":"host"},{"name":"cnt"},{"name":"p.0"},{"name":"p.1"}],
+				"entries":[["a",3,1.5,9],["b",1,2,2]]}`
+			entries, total, err := convertAggregationRows(agg, []byte(content), true)
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 2)
+			So(entries, ShouldResemble, []map[string]any{
+				{"host": "a", "cnt": int64(3), "p": map[string]any{"50": 1.5, "99.9": float64(9)}},
+				{"host": "b", "cnt": int64(1), "p": map[string]any{"50": float64(2), "99.9": float64(2)}},
+			})
+		})
+
+		Convey("multiple data sources", func() {
+			content := `{"columns":[{"name":"host"},{"name":"cnt"},{"name":"p.0"},{"name":"p.1"}],"data":[["a",3,1,2]]}`
+			entries, _, err := convertAggregationRows(agg, []byte(content), false)
+			So(err, ShouldBeNil)
+			So(len(entries), ShouldEqual, 1)
+		})
+
+		Convey("total before limit", func() {
+			content := `{"columns":[{"name":"host"},{"name":"cnt"},{"name":"p.0"},{"name":"p.1"},{"name":"__total"}],
+				"entries":[["a",3,1,2,25]]}`
+			entries, total, err := convertAggregationRows(agg, []byte(content), true)
+			So(err, ShouldBeNil)
+			So(len(entries), ShouldEqual, 1)
+			So(total, ShouldEqual, 25)
+		})
+
+		Convey("missing column", func() {
+			content := `{"columns":[{"name":"host"}],"entries":[["a"]]}`
+			_, _, err := convertAggregationRows(agg, []byte(content), true)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -690,6 +690,11 @@ func (dvs *dataViewService) querySingleViewData(ctx context.Context, query inter
 		query.SetFormat(interfaces.Format_Flat)
 	}
 
+	// 带聚合参数的查询按分组返回聚合结果
+	if query.GetAggregation() != nil {
+		return dvs.queryAggregation(ctx, query, view)
+	}
+
 	switch view.QueryType {
 	case interfaces.QueryType_IndexBase:
 		return dvs.queryByIndexBase(ctx, query, view)
@@ -930,36 +935,9 @@ func (dvs *dataViewService) queryBySQL(ctx context.Context, query interfaces.Vie
 	view *interfaces.DataView) (resBytes []byte, total int64, err error) {
 	commonParams := query.GetCommonParams()
 
-	// 优先使用查询接口指定的 sql
-	selectSql := commonParams.SqlStr
-	if selectSql == "" {
-		if view.SQLStr != "" {
-			// 原子视图还存着sql_str
-			selectSql = view.SQLStr
-		} else {
-			// 实时生成sql
-			selectSql, err = buildViewSql(ctx, view)
-			if err != nil {
-				return nil, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
-					WithErrorDetails(err.Error())
-			}
-		}
-	}
-
-	// 添加时间过滤
-	timeFilterSql := buildTimeFilterSql(commonParams.DateField, commonParams.Start, commonParams.End)
-	// 全局过滤条件, 全局过滤条件选择的字段要在视图列表里
-	globalFilterSql, err := buildSQLCondition(ctx, query.GetGlobalFilters(), view.Type, view.FieldsMap)
+	sqlStr, newFields, newFieldsMap, err := buildFilteredViewSql(ctx, query, view)
 	if err != nil {
-		return nil, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
-			WithErrorDetails(err.Error())
-	}
-	// 视图的行列规则不会应用在给指标模型生成的sql上，所以在这里添加行列规则过滤
-	rowColumnRules := query.GetRowColumnRules()
-	rowColumnRulesSQL, newFields, newFieldsMap, err := buildRowColumnRulesSQL(ctx, rowColumnRules, view)
-	if err != nil {
-		return nil, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
-			WithErrorDetails(err.Error())
+		return nil, 0, err
 	}
 	// 更新视图字段为行列规则定义的字段
 	defer func() {
@@ -967,24 +945,6 @@ func (dvs *dataViewService) queryBySQL(ctx context.Context, query interfaces.Vie
 		view.FieldsMap = newFieldsMap
 	}()
 
-	// 将全局过滤条件、时间过滤和视图sql一起拼sql，全局过滤条件、时间过滤均为可选项
-	var sqlStr string
-	var whereClauses []string
-	// 收集非空的过滤条件
-	if timeFilterSql != "" {
-		whereClauses = append(whereClauses, timeFilterSql)
-	}
-	if globalFilterSql != "" {
-		whereClauses = append(whereClauses, globalFilterSql)
-	}
-	if rowColumnRulesSQL != "" {
-		whereClauses = append(whereClauses, rowColumnRulesSQL)
-	}
-
-	builder := NewSQLBuilder(selectSql)
-	builder.AddWheres(whereClauses)
-	sqlStr = builder.Build()
-
 	// 记录查询vega耗时
 	startTime := time.Now()
 	defer func() {
@@ -1072,6 +1032,62 @@ func (dvs *dataViewService) queryBySQL(ctx context.Context, query interfaces.Vie
 	return dataBatch, total, nil
 }
 
+// 拼接视图的 sql 与时间过滤、全局过滤条件和行列规则，返回行列规则限制后的视图字段
+func buildFilteredViewSql(ctx context.Context, query interfaces.ViewQueryInterface,
+	view *interfaces.DataView) (string, []*cond.ViewField, map[string]*cond.ViewField, error) {
+	commonParams := query.GetCommonParams()
+
+	// 优先使用查询接口指定的 sql
+	selectSql := commonParams.SqlStr
+	if selectSql == "" {
+		if view.SQLStr != "" {
+			// 原子视图还存着sql_str
+			selectSql = view.SQLStr
+		} else {
+			// 实时生成sql
+			var err error
+			selectSql, err = buildViewSql(ctx, view)
+			if err != nil {
+				return "", nil, nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
+					WithErrorDetails(err.Error())
+			}
+		}
+	}
+
+	// 添加时间过滤
+	timeFilterSql := buildTimeFilterSql(commonParams.DateField, commonParams.Start, commonParams.End)
+	// 全局过滤条件, 全局过滤条件选择的字段要在视图列表里
+	globalFilterSql, err := buildSQLCondition(ctx, query.GetGlobalFilters(), view.Type, view.FieldsMap)
+	if err != nil {
+		return "", nil, nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
+			WithErrorDetails(err.Error())
+	}
+	// 视图的行列规则不会应用在给指标模型生成的sql上，所以在这里添加行列规则过滤
+	rowColumnRules := query.GetRowColumnRules()
+	rowColumnRulesSQL, newFields, newFieldsMap, err := buildRowColumnRulesSQL(ctx, rowColumnRules, view)
+	if err != nil {
+		return "", nil, nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
+			WithErrorDetails(err.Error())
+	}
+
+	// 将全局过滤条件、时间过滤和视图sql一起拼sql，全局过滤条件、时间过滤均为可选项
+	var whereClauses []string
+	// 收集非空的过滤条件
+	if timeFilterSql != "" {
+		whereClauses = append(whereClauses, timeFilterSql)
+	}
+	if globalFilterSql != "" {
+		whereClauses = append(whereClauses, globalFilterSql)
+	}
+	if rowColumnRulesSQL != "" {
+		whereClauses = append(whereClauses, rowColumnRulesSQL)
+	}
+
+	builder := NewSQLBuilder(selectSql)
+	builder.AddWheres(whereClauses)
+	return builder.Build(), newFields, newFieldsMap, nil
+}
+
 func (dvs *dataViewService) GetTotalByIndexBase(ctx context.Context, dslBuffer bytes.Buffer, indices []string) (total int64, err error) {
 	ctx, span := ar_trace.Tracer.Start(ctx, "logic layer: Query single view data")
 	defer span.End()
@@ -1355,6 +1371,10 @@ func convertToViewUniResponse(ctx context.Context, query interfaces.ViewQueryInt
 		}, nil
 	}
 
+	if query.GetAggregation() != nil {
+		return convertAggregationToViewUniResponse(ctx, query, view, content, total)
+	}
+
 	switch view.QueryType {
 	case interfaces.QueryType_DSL:
 		return convertToViewUniResponseByDSL(ctx, query, view, content, total)
@@ -0,0 +1,145 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package data_view
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	attr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	cond "uniquery/common/condition"
+	uerrors "uniquery/errors"
+	"uniquery/interfaces"
+)
+
+// 导出视图的全量数据。按 search_after 翻页查询，IndexBase 视图使用 pit 保证翻页期间数据一致，
+// SQL 视图按 vega 返回的 next_uri 分批查询。每批数据查询后立即写入 w，ctx 取消时停止导出
+func (dvs *dataViewService) ExportViewData(ctx context.Context, viewID string, query *interfaces.DataViewQueryV2,
+	format string, w io.Writer) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic layer: Export view data")
+	defer span.End()
+
+	span.SetAttributes(attr.Key("view_id").String(viewID), attr.Key("format").String(format))
+
+	query.UseSearchAfter = true
+	query.NeedTotal = false
+	query.IncludeView = true
+	query.Format = interfaces.Format_Flat
+	query.Offset = 0
+	query.SearchAfter = nil
+	query.PitID = ""
+	query.PitKeepAlive = interfaces.Export_PitKeepAlive
+	if query.Limit == 0 {
+		query.Limit = interfaces.SearchAfter_Limit
+	}
+
+	// 导出结束后删除翻页时创建的 pit
+	defer func() {
+		if query.PitID == "" {
+			return
+		}
+		_, delErr := dvs.DeleteDataViewPits(context.WithoutCancel(ctx), &interfaces.DeletePits{PitIDs: []string{query.PitID}})
+		if delErr != nil {
+			logger.Errorf("Delete pit of export view %s failed, %v", viewID, delErr)
+		}
+	}()
+
+	var writer viewDataWriter
+	var exported int64
+	for {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			span.SetStatus(codes.Error, "Export canceled")
+			return rest.NewHTTPError(ctx, http.StatusInternalServerError, uerrors.Uniquery_DataView_InternalError_ExportFailed).
+				WithErrorDetails(fmt.Sprintf("export canceled after %d rows, %s", exported, ctxErr.Error()))
+		}
+
+		// 每批都重新鉴权并获取视图，行列规则会在查询时改写视图字段
+		res, err := dvs.GetSingleViewData(ctx, viewID, query)
+		if err != nil {
+			span.SetStatus(codes.Error, "Get view data failed")
+			return err
+		}
+
+		// 第一批数据返回后才能确定行列规则限制后的导出列
+		if writer == nil {
+			writer, err = newViewDataWriter(format, w, getExportFields(res.View, query.OutputFields))
+			if err != nil {
+				span.SetStatus(codes.Error, "Create export writer failed")
+				return rest.NewHTTPError(ctx, http.StatusInternalServerError, uerrors.Uniquery_DataView_InternalError_ExportFailed).
+					WithErrorDetails(err.Error())
+			}
+		}
+
+		if len(res.Entries) > 0 {
+			if err = writer.Write(res.Entries); err != nil {
+				span.SetStatus(codes.Error, "Write export data failed")
+				return rest.NewHTTPError(ctx, http.StatusInternalServerError, uerrors.Uniquery_DataView_InternalError_ExportFailed).
+					WithErrorDetails(fmt.Sprintf("write export data failed after %d rows, %s", exported, err.Error()))
+			}
+			exported += int64(len(res.Entries))
+		}
+
+		if !hasNextExportBatch(res, query.Limit) {
+			break
+		}
+
+		query.SearchAfter = res.SearchAfter
+		if res.PitID != "" {
+			query.PitID = res.PitID
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		span.SetStatus(codes.Error, "Close export writer failed")
+		return rest.NewHTTPError(ctx, http.StatusInternalServerError, uerrors.Uniquery_DataView_InternalError_ExportFailed).
+			WithErrorDetails(err.Error())
+	}
+
+	logger.Infof("export view %s finished, format is %s, %d rows exported", viewID, format, exported)
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// 指定了输出字段时按输出字段的顺序导出，否则导出视图的全部字段。
+// 行列规则限制后的视图字段里没有的输出字段不导出，__index 和 _score 除外，按字符串导出
+func getExportFields(view *interfaces.DataView, outputFields []string) []*cond.ViewField {
+	if view == nil {
+		return []*cond.ViewField{}
+	}
+
+	if len(outputFields) == 0 {
+		return view.Fields
+	}
+
+	fields := make([]*cond.ViewField, 0, len(outputFields))
+	for _, name := range outputFields {
+		if field, ok := view.FieldsMap[name]; ok {
+			fields = append(fields, field)
+		} else if name == "__index" || name == "_score" {
+			fields = append(fields, &cond.ViewField{Name: name})
+		}
+	}
+	return fields
+}
+
+// SQL 视图以 vega 是否返回 next_uri 判断是否还有数据，opensearch 返回的数据不足一批时说明已经查完
+func hasNextExportBatch(res *interfaces.ViewUniResponseV2, limit int) bool {
+	if len(res.Entries) == 0 || len(res.SearchAfter) == 0 {
+		return false
+	}
+
+	if res.View != nil && res.View.QueryType != interfaces.QueryType_SQL {
+		return len(res.Entries) >= limit
+	}
+	return true
+}
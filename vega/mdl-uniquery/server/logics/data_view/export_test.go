@@ -0,0 +1,139 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package data_view
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	. "github.com/agiledragon/gomonkey/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"uniquery/common"
+	cond "uniquery/common/condition"
+	uerrors "uniquery/errors"
+	"uniquery/interfaces"
+	dtype "uniquery/interfaces/data_type"
+	mock "uniquery/interfaces/mock"
+)
+
+func TestExportViewData(t *testing.T) {
+	Convey("Test ExportViewData", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		osaMock := mock.NewMockOpenSearchAccess(mockCtrl)
+		dvs := MockNewDataViewService(&common.AppSetting{}, nil, nil, osaMock, nil)
+
+		view := &interfaces.DataView{
+			QueryType: interfaces.QueryType_IndexBase,
+			Fields: []*cond.ViewField{
+				{Name: "name", Type: dtype.DataType_String},
+				{Name: "count", Type: dtype.DataType_Integer},
+			},
+		}
+		view.FieldsMap = map[string]*cond.ViewField{"name": view.Fields[0], "count": view.Fields[1]}
+
+		Convey("export all pages and delete pit", func() {
+			pages := []*interfaces.ViewUniResponseV2{
+				{
+					PitID:       "pit-1",
+					SearchAfter: []any{2},
+					View:        view,
+					Entries:     []map[string]any{{"name": "a", "count": 1}, {"name": "b", "count": 2}},
+				},
+				{
+					PitID:       "pit-1",
+					SearchAfter: []any{3},
+					View:        view,
+					Entries:     []map[string]any{{"name": "c", "count": 3}},
+				},
+			}
+			queries := []interfaces.SearchAfterParams{}
+			patches := ApplyMethodFunc(reflect.TypeOf(dvs), "GetSingleViewData",
+				func(ctx context.Context, viewID string, query interfaces.ViewQueryInterface) (*interfaces.ViewUniResponseV2, error) {
+					queries = append(queries, *query.GetSearchAfterParams())
+					res := pages[len(queries)-1]
+					query.GetSearchAfterParams().PitID = res.PitID
+					return res, nil
+				})
+			defer patches.Reset()
+
+			osaMock.EXPECT().DeletePointInTime(gomock.Any(), []string{"pit-1"}).Return(nil, http.StatusOK, nil)
+
+			query := &interfaces.DataViewQueryV2{
+				ViewQueryCommonParams: interfaces.ViewQueryCommonParams{Limit: 2},
+			}
+			buf := &bytes.Buffer{}
+			err := dvs.ExportViewData(testCtx, "1", query, interfaces.ExportFormat_CSV, buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldEqual, "name,count\na,1\nb,2\nc,3\n")
+			So(len(queries), ShouldEqual, 2)
+			So(queries[0].PitKeepAlive, ShouldEqual, interfaces.Export_PitKeepAlive)
+			So(queries[1].SearchAfter, ShouldResemble, []any{2})
+			So(queries[1].PitID, ShouldEqual, "pit-1")
+		})
+
+		Convey("export with output fields", func() {
+			patches := ApplyMethodReturn(dvs, "GetSingleViewData", &interfaces.ViewUniResponseV2{
+				View:    view,
+				Entries: []map[string]any{{"count": 1}},
+			}, nil)
+			defer patches.Reset()
+
+			query := &interfaces.DataViewQueryV2{
+				ViewQueryCommonParams: interfaces.ViewQueryCommonParams{Limit: 2, OutputFields: []string{"count", "hidden"}},
+			}
+			buf := &bytes.Buffer{}
+			err := dvs.ExportViewData(testCtx, "1", query, interfaces.ExportFormat_CSV, buf)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldEqual, "count\n1\n")
+		})
+
+		Convey("get view data failed", func() {
+			expectedErr := rest.NewHTTPError(testCtx, http.StatusForbidden, rest.PublicError_Forbidden)
+			patches := ApplyMethodReturn(dvs, "GetSingleViewData", nil, expectedErr)
+			defer patches.Reset()
+
+			buf := &bytes.Buffer{}
+			err := dvs.ExportViewData(testCtx, "1", &interfaces.DataViewQueryV2{}, interfaces.ExportFormat_CSV, buf)
+			So(err, ShouldEqual, expectedErr)
+			So(buf.Len(), ShouldEqual, 0)
+		})
+
+		Convey("export canceled", func() {
+			ctx, cancel := context.WithCancel(testCtx)
+			cancel()
+
+			buf := &bytes.Buffer{}
+			err := dvs.ExportViewData(ctx, "1", &interfaces.DataViewQueryV2{}, interfaces.ExportFormat_CSV, buf)
+			So(err, ShouldNotBeNil)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_DataView_InternalError_ExportFailed)
+		})
+	})
+}
+
+func TestHasNextExportBatch(t *testing.T) {
+	Convey("Test hasNextExportBatch", t, func() {
+		entries := []map[string]any{{"a": 1}}
+
+		So(hasNextExportBatch(&interfaces.ViewUniResponseV2{Entries: entries}, 1), ShouldBeFalse)
+		So(hasNextExportBatch(&interfaces.ViewUniResponseV2{
+			Entries: entries, SearchAfter: []any{1}, View: &interfaces.DataView{QueryType: interfaces.QueryType_IndexBase},
+		}, 1), ShouldBeTrue)
+		So(hasNextExportBatch(&interfaces.ViewUniResponseV2{
+			Entries: entries, SearchAfter: []any{1}, View: &interfaces.DataView{QueryType: interfaces.QueryType_DSL},
+		}, 2), ShouldBeFalse)
+		So(hasNextExportBatch(&interfaces.ViewUniResponseV2{
+			Entries: entries, SearchAfter: []any{"a", "b", "c"}, View: &interfaces.DataView{QueryType: interfaces.QueryType_SQL},
+		}, 2), ShouldBeTrue)
+	})
+}
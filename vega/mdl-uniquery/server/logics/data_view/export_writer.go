@@ -0,0 +1,351 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package data_view
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/bytedance/sonic"
+
+	cond "uniquery/common/condition"
+	"uniquery/interfaces"
+	dtype "uniquery/interfaces/data_type"
+)
+
+// 视图数据导出的写入器，按批写入扁平格式的视图数据，Close 时写入文件尾
+type viewDataWriter interface {
+	Write(entries []map[string]any) error
+	Close() error
+}
+
+// 按导出格式创建写入器，fields 决定输出的列及列顺序
+func newViewDataWriter(format string, w io.Writer, fields []*cond.ViewField) (viewDataWriter, error) {
+	switch format {
+	case interfaces.ExportFormat_CSV:
+		return newCSVWriter(w, fields)
+	case interfaces.ExportFormat_NDJSON:
+		return newNDJSONWriter(w, fields), nil
+	case interfaces.ExportFormat_Parquet:
+		return newParquetWriter(w, fields)
+	case interfaces.ExportFormat_Arrow:
+		return newArrowWriter(w, fields), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format '%s'", format)
+	}
+}
+
+// 每批数据写完后把缓冲的数据推给客户端
+func flushWriter(w io.Writer) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+type csvWriter struct {
+	w      io.Writer
+	cw     *csv.Writer
+	fields []*cond.ViewField
+}
+
+func newCSVWriter(w io.Writer, fields []*cond.ViewField) (*csvWriter, error) {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = field.Name
+	}
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{w: w, cw: cw, fields: fields}, nil
+}
+
+func (cw *csvWriter) Write(entries []map[string]any) error {
+	record := make([]string, len(cw.fields))
+	for _, entry := range entries {
+		for i, field := range cw.fields {
+			value, err := formatExportValue(entry[field.Name])
+			if err != nil {
+				return err
+			}
+			record[i] = value
+		}
+		if err := cw.cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.cw.Flush()
+	if err := cw.cw.Error(); err != nil {
+		return err
+	}
+	flushWriter(cw.w)
+	return nil
+}
+
+func (cw *csvWriter) Close() error {
+	cw.cw.Flush()
+	return cw.cw.Error()
+}
+
+type ndjsonWriter struct {
+	w      io.Writer
+	bw     *bufio.Writer
+	fields []*cond.ViewField
+}
+
+func newNDJSONWriter(w io.Writer, fields []*cond.ViewField) *ndjsonWriter {
+	return &ndjsonWriter{w: w, bw: bufio.NewWriter(w), fields: fields}
+}
+
+func (nw *ndjsonWriter) Write(entries []map[string]any) error {
+	for _, entry := range entries {
+		// 只输出导出列，与其他格式保持一致
+		line := make(map[string]any, len(nw.fields))
+		for _, field := range nw.fields {
+			if value, ok := entry[field.Name]; ok {
+				line[field.Name] = value
+			}
+		}
+
+		lineBytes, err := sonic.Marshal(line)
+		if err != nil {
+			return err
+		}
+		if _, err = nw.bw.Write(lineBytes); err != nil {
+			return err
+		}
+		if err = nw.bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+
+	if err := nw.bw.Flush(); err != nil {
+		return err
+	}
+	flushWriter(nw.w)
+	return nil
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nw.bw.Flush()
+}
+
+type parquetWriter struct {
+	w  io.Writer
+	fw *pqarrow.FileWriter
+	rb *array.RecordBuilder
+}
+
+func newParquetWriter(w io.Writer, fields []*cond.ViewField) (*parquetWriter, error) {
+	schema := buildArrowSchema(fields)
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	fw, err := pqarrow.NewFileWriter(schema, w, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, err
+	}
+
+	return &parquetWriter{
+		w:  w,
+		fw: fw,
+		rb: array.NewRecordBuilder(memory.DefaultAllocator, schema),
+	}, nil
+}
+
+// 每批数据写成一个 row group
+func (pw *parquetWriter) Write(entries []map[string]any) error {
+	rec := buildArrowRecord(pw.rb, entries)
+	defer rec.Release()
+
+	if err := pw.fw.Write(rec); err != nil {
+		return err
+	}
+	flushWriter(pw.w)
+	return nil
+}
+
+func (pw *parquetWriter) Close() error {
+	pw.rb.Release()
+	return pw.fw.Close()
+}
+
+type arrowWriter struct {
+	w  io.Writer
+	iw *ipc.Writer
+	rb *array.RecordBuilder
+}
+
+// arrow IPC 使用流格式，客户端无需等到文件尾即可逐批读取
+func newArrowWriter(w io.Writer, fields []*cond.ViewField) *arrowWriter {
+	schema := buildArrowSchema(fields)
+	return &arrowWriter{
+		w:  w,
+		iw: ipc.NewWriter(w, ipc.WithSchema(schema), ipc.WithAllocator(memory.DefaultAllocator)),
+		rb: array.NewRecordBuilder(memory.DefaultAllocator, schema),
+	}
+}
+
+func (aw *arrowWriter) Write(entries []map[string]any) error {
+	rec := buildArrowRecord(aw.rb, entries)
+	defer rec.Release()
+
+	if err := aw.iw.Write(rec); err != nil {
+		return err
+	}
+	flushWriter(aw.w)
+	return nil
+}
+
+func (aw *arrowWriter) Close() error {
+	aw.rb.Release()
+	return aw.iw.Close()
+}
+
+// 视图字段类型映射成 arrow 类型，日期、ip、json 等类型统一按字符串输出
+func buildArrowSchema(fields []*cond.ViewField) *arrow.Schema {
+	arrowFields := make([]arrow.Field, len(fields))
+	for i, field := range fields {
+		var dataType arrow.DataType
+		switch field.Type {
+		case dtype.DataType_Integer:
+			dataType = arrow.PrimitiveTypes.Int64
+		case dtype.DataType_UnsignedInteger:
+			dataType = arrow.PrimitiveTypes.Uint64
+		case dtype.DataType_Float, dtype.DataType_Decimal:
+			dataType = arrow.PrimitiveTypes.Float64
+		case dtype.DataType_Boolean:
+			dataType = arrow.FixedWidthTypes.Boolean
+		default:
+			dataType = arrow.BinaryTypes.String
+		}
+		arrowFields[i] = arrow.Field{Name: field.Name, Type: dataType, Nullable: true}
+	}
+
+	return arrow.NewSchema(arrowFields, nil)
+}
+
+func buildArrowRecord(rb *array.RecordBuilder, entries []map[string]any) arrow.Record {
+	schema := rb.Schema()
+	for _, entry := range entries {
+		for i, field := range schema.Fields() {
+			appendArrowValue(rb.Field(i), entry[field.Name])
+		}
+	}
+
+	return rb.NewRecord()
+}
+
+// 值无法转换成列的类型时写入 null
+func appendArrowValue(builder array.Builder, value any) {
+	if value == nil {
+		builder.AppendNull()
+		return
+	}
+
+	switch b := builder.(type) {
+	case *array.Int64Builder:
+		// 字符串和 json.Number 先按整数解析，避免大整数经过 float64 丢失精度
+		if v, err := strconv.ParseInt(fmt.Sprintf("%v", value), 10, 64); err == nil {
+			b.Append(v)
+			return
+		}
+		if v, ok := toFloat64(value); ok && v == math.Trunc(v) {
+			b.Append(int64(v))
+			return
+		}
+	case *array.Uint64Builder:
+		if v, err := strconv.ParseUint(fmt.Sprintf("%v", value), 10, 64); err == nil {
+			b.Append(v)
+			return
+		}
+		if v, ok := toFloat64(value); ok && v >= 0 && v == math.Trunc(v) {
+			b.Append(uint64(v))
+			return
+		}
+	case *array.Float64Builder:
+		if v, ok := toFloat64(value); ok {
+			b.Append(v)
+			return
+		}
+	case *array.BooleanBuilder:
+		switch v := value.(type) {
+		case bool:
+			b.Append(v)
+			return
+		case string:
+			if bv, err := strconv.ParseBool(v); err == nil {
+				b.Append(bv)
+				return
+			}
+		}
+	case *array.StringBuilder:
+		if v, err := formatExportValue(value); err == nil {
+			b.Append(v)
+			return
+		}
+	}
+
+	builder.AppendNull()
+}
+
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// 单个值转成文本，数组和对象序列化成 json
+func formatExportValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case int, int32, int64, uint64, json.Number:
+		return fmt.Sprintf("%v", v), nil
+	default:
+		return sonic.MarshalString(v)
+	}
+}
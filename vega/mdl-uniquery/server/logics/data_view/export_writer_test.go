@@ -0,0 +1,130 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package data_view
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	. "github.com/smartystreets/goconvey/convey"
+
+	cond "uniquery/common/condition"
+	"uniquery/interfaces"
+	dtype "uniquery/interfaces/data_type"
+)
+
+var exportTestFields = []*cond.ViewField{
+	{Name: "name", Type: dtype.DataType_String},
+	{Name: "count", Type: dtype.DataType_Integer},
+	{Name: "ratio", Type: dtype.DataType_Float},
+	{Name: "ok", Type: dtype.DataType_Boolean},
+	{Name: "tags", Type: dtype.DataType_Json},
+}
+
+var exportTestBatches = [][]map[string]any{
+	{
+		{"name": "a", "count": float64(1), "ratio": 0.5, "ok": true, "tags": []any{"x", "y"}},
+		{"name": "b,c", "count": "9007199254740993", "ratio": nil, "ok": false},
+	},
+	{
+		{"name": "d", "count": "not a number", "ratio": float64(2), "ok": "true", "extra": "ignored"},
+	},
+}
+
+func writeExportTestData(format string) (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+	writer, err := newViewDataWriter(format, buf, exportTestFields)
+	if err != nil {
+		return nil, err
+	}
+	for _, batch := range exportTestBatches {
+		if err = writer.Write(batch); err != nil {
+			return nil, err
+		}
+	}
+	return buf, writer.Close()
+}
+
+func TestNewViewDataWriter(t *testing.T) {
+	Convey("Test newViewDataWriter", t, func() {
+
+		Convey("unsupported format", func() {
+			_, err := newViewDataWriter("xlsx", &bytes.Buffer{}, exportTestFields)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("csv", func() {
+			buf, err := writeExportTestData(interfaces.ExportFormat_CSV)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldEqual, "name,count,ratio,ok,tags\n"+
+				"a,1,0.5,true,\"[\"\"x\"\",\"\"y\"\"]\"\n"+
+				"\"b,c\",9007199254740993,,false,\n"+
+				"d,not a number,2,true,\n")
+		})
+
+		Convey("ndjson", func() {
+			buf, err := writeExportTestData(interfaces.ExportFormat_NDJSON)
+			So(err, ShouldBeNil)
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			So(len(lines), ShouldEqual, 3)
+			So(lines[2], ShouldNotContainSubstring, "extra")
+		})
+
+		Convey("arrow", func() {
+			buf, err := writeExportTestData(interfaces.ExportFormat_Arrow)
+			So(err, ShouldBeNil)
+
+			reader, err := ipc.NewReader(buf)
+			So(err, ShouldBeNil)
+			defer reader.Release()
+			So(reader.Schema().NumFields(), ShouldEqual, len(exportTestFields))
+
+			rows := 0
+			for reader.Next() {
+				rec := reader.Record()
+				if rows == 0 {
+					counts := rec.Column(1).(*array.Int64)
+					So(counts.Value(0), ShouldEqual, 1)
+					So(counts.Value(1), ShouldEqual, int64(9007199254740993))
+					So(rec.Column(2).IsNull(1), ShouldBeTrue)
+					So(rec.Column(4).(*array.String).Value(0), ShouldEqual, `["x","y"]`)
+				} else {
+					So(rec.Column(1).IsNull(0), ShouldBeTrue)
+					So(rec.Column(3).(*array.Boolean).Value(0), ShouldBeTrue)
+				}
+				rows += int(rec.NumRows())
+			}
+			So(reader.Err(), ShouldBeNil)
+			So(rows, ShouldEqual, 3)
+		})
+
+		Convey("parquet", func() {
+			buf, err := writeExportTestData(interfaces.ExportFormat_Parquet)
+			So(err, ShouldBeNil)
+
+			pf, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+			So(err, ShouldBeNil)
+			defer pf.Close()
+			So(pf.NumRows(), ShouldEqual, 3)
+			So(pf.NumRowGroups(), ShouldEqual, 2)
+
+			fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+			So(err, ShouldBeNil)
+			table, err := fr.ReadTable(context.Background())
+			So(err, ShouldBeNil)
+			defer table.Release()
+			So(table.Schema().Field(0).Name, ShouldEqual, "name")
+			So(table.Column(0).Data().Chunk(0).(*array.String).Value(1), ShouldEqual, "b,c")
+		})
+	})
+}
@@ -29,7 +29,9 @@ type MetricDataQuery struct {
 	End            int64
 	Step           string
 	Filters        []interfaces.Filter
-	mmService      interfaces.MetricModelService
+	// 检测规则中配置的动态基线分析，为空时按原始值检测
+	Analysis  *interfaces.MetricAnalysis
+	mmService interfaces.MetricModelService
 }
 
 func NewMetricDataQuery(appSetting *common.AppSetting, dataSource []string, dataSourceType string, t interfaces.TimeInterval, query interfaces.EventQuery) interfaces.DataModelQuery {
@@ -76,6 +78,19 @@ func (md *MetricDataQuery) FetchSourceRecordsFrom(ctx context.Context, format st
 			},
 			MetricModelID: md.DataSource[0],
 		}
+		// 动态基线依赖时间序列，只在范围查询时生效
+		if md.Analysis != nil {
+			analysis := *md.Analysis
+			if analysis.Season != "" {
+				seasonT, err := convert.ParseDuration(analysis.Season)
+				if err != nil {
+					logger.Errorf("invalid season %s of metric analysis, season will be ignored, error info is: %v", analysis.Season, err)
+				} else {
+					analysis.SeasonMs = seasonT.Milliseconds()
+				}
+			}
+			query.Analysis = &analysis
+		}
 	}
 	// 事件模型查询指标模型数据，不分页
 	query.Limit = interfaces.DEFAULT_SERIES_LIMIT_INT
@@ -101,6 +116,14 @@ func (md *MetricDataQuery) FetchSourceRecordsFrom(ctx context.Context, format st
 			}
 
 			new_record := map[string]interface{}{"value": rs.Values[index], "@timestamp": st}
+			// 附带基线分析结果，检测规则可直接对 anomaly、upper_bound 等字段判断
+			if len(rs.Anomalies) == len(rs.Values) {
+				new_record[interfaces.ANALYSIS_RECORD_BASELINE] = rs.Baselines[index]
+				new_record[interfaces.ANALYSIS_RECORD_UPPER_BOUND] = rs.UpperBounds[index]
+				new_record[interfaces.ANALYSIS_RECORD_LOWER_BOUND] = rs.LowerBounds[index]
+				new_record[interfaces.ANALYSIS_RECORD_ANOMALY] = rs.Anomalies[index]
+				new_record[interfaces.ANALYSIS_RECORD_ANOMALY_SCORE] = rs.AnomalyScores[index]
+			}
 			for key, value := range rs.Labels {
 				new_record["labels."+key] = value
 			}
@@ -329,7 +352,9 @@ func (md *EventDataQuery) FetchSourceRecordsFrom(ctx context.Context, format str
 // NOTE: 这里有空可以改用工厂模式，摈弃这种简单工厂模式
 func GenerateDataModelQuery(appSetting *common.AppSetting, em interfaces.EventModel, query interfaces.EventQuery) (_query interfaces.DataModelQuery) {
 	if em.DataSourceType == "metric_model" {
-		return NewMetricDataQuery(appSetting, em.DataSource, em.DataSourceType, em.DefaultTimeWindow, query)
+		mdq := NewMetricDataQuery(appSetting, em.DataSource, em.DataSourceType, em.DefaultTimeWindow, query).(*MetricDataQuery)
+		mdq.Analysis = interfaces.NewMetricAnalysisFromAlgo(em.DetectRule.AnalysisAlgo)
+		return mdq
 	} else if em.DataSourceType == "data_view" {
 		return NewLogDataQuery(appSetting, em.DataSource, em.DataSourceType, em.DefaultTimeWindow, query)
 	} else {
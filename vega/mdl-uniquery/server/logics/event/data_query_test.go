@@ -6,6 +6,7 @@
 package event
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -114,6 +115,38 @@ func TestMetricFetchSourceRecordsFrom(t *testing.T) {
 			So(err, ShouldBeNil)
 		})
 
+		Convey("success with analysis", func() {
+			mdqMock.Analysis = interfaces.NewMetricAnalysisFromAlgo(map[string]string{
+				interfaces.ANALYSIS_ALGO_BASELINE: interfaces.ANALYSIS_BASELINE_STL,
+				interfaces.ANALYSIS_ALGO_SEASON:   "1d",
+			})
+			expectRes := interfaces.MetricModelUniResponse{
+				Datas: []interfaces.MetricModelData{
+					{
+						Labels:        map[string]string{"cpu": "1"},
+						Times:         []interface{}{int64(1695003480000), int64(1695003840000)},
+						Values:        []interface{}{1.1, nil},
+						Baselines:     []interface{}{1.0, 1.0},
+						UpperBounds:   []interface{}{1.2, 1.2},
+						LowerBounds:   []interface{}{0.8, 0.8},
+						Anomalies:     []interface{}{false, nil},
+						AnomalyScores: []interface{}{0.5, nil},
+					},
+				},
+			}
+			var query *interfaces.MetricModelQuery
+			mmsMock.EXPECT().Exec(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, q *interfaces.MetricModelQuery) (interfaces.MetricModelUniResponse, int, int, error) {
+					query = q
+					return expectRes, 0, 0, nil
+				})
+			sr, _, err := mdqMock.FetchSourceRecordsFrom(testCtx, "flat")
+			So(err, ShouldBeNil)
+			So(query.Analysis.SeasonMs, ShouldEqual, int64(24*60*60*1000))
+			So(len(sr.Records), ShouldEqual, 1)
+			So(sr.Records[0][interfaces.ANALYSIS_RECORD_ANOMALY], ShouldEqual, false)
+			So(sr.Records[0][interfaces.ANALYSIS_RECORD_UPPER_BOUND], ShouldEqual, 1.2)
+		})
 	})
 }
 
@@ -0,0 +1,418 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package metric_model
+
+import (
+	"math"
+	"sort"
+
+	"uniquery/interfaces"
+)
+
+const (
+	// holt-winters 的平滑系数
+	hwAlpha = 0.3
+	hwBeta  = 0.05
+	hwGamma = 0.1
+
+	// mad 换算为正态分布标准差的系数
+	madScale = 1.4826
+
+	// 无季节周期时，stl 基线使用的滑动窗口
+	defaultTrendWindow = 5
+)
+
+// 对每条序列做基线拟合、异常检测和预测，结果写回序列。
+// nextTime 用于按查询步长生成预测点的时间。
+func analyzeMetricDatas(datas []interfaces.MetricModelData, analysis *interfaces.MetricAnalysis,
+	nextTime func(int64) int64) []interfaces.MetricModelData {
+
+	for i := range datas {
+		analyzeSeries(&datas[i], analysis, nextTime)
+	}
+	return datas
+}
+
+func analyzeSeries(data *interfaces.MetricModelData, analysis *interfaces.MetricAnalysis, nextTime func(int64) int64) {
+	n := len(data.Values)
+	data.Baselines = make([]any, n)
+	data.UpperBounds = make([]any, n)
+	data.LowerBounds = make([]any, n)
+	data.Anomalies = make([]any, n)
+	data.AnomalyScores = make([]any, n)
+
+	values, valid := seriesValues(data.Values)
+	if countValid(valid) < 2 {
+		// 点数不足，无法拟合
+		return
+	}
+	filled := fillMissing(values, valid)
+
+	period := seasonPoints(data.Times, analysis.SeasonMs)
+
+	var baseline []float64
+	if analysis.Baseline == interfaces.ANALYSIS_BASELINE_HOLT_WINTERS {
+		baseline, _ = holtWinters(filled, period, 0)
+	} else {
+		baseline = stlBaseline(filled, period)
+	}
+
+	sensitivity := interfaces.DEFAULT_ANALYSIS_SENSITIVITY
+	if analysis.Sensitivity != nil {
+		sensitivity = *analysis.Sensitivity
+	}
+
+	residuals := make([]float64, 0, n)
+	for i := range values {
+		if valid[i] {
+			residuals = append(residuals, values[i]-baseline[i])
+		}
+	}
+	center, spread := residualStats(residuals, analysis.Detector)
+
+	for i := range values {
+		b := baseline[i] + center
+		data.Baselines[i] = b
+		data.UpperBounds[i] = b + sensitivity*spread
+		data.LowerBounds[i] = b - sensitivity*spread
+		if !valid[i] {
+			continue
+		}
+
+		score := 0.0
+		if spread > 0 {
+			score = (values[i] - b) / spread
+		}
+		data.AnomalyScores[i] = score
+		data.Anomalies[i] = math.Abs(score) > sensitivity
+	}
+
+	if analysis.ForecastMs > 0 && len(data.Times) == n && n > 0 {
+		data.Forecast = forecastSeries(data, filled, valid, period, analysis, sensitivity, nextTime)
+	}
+}
+
+// 向后预测 forecast 时长内的点
+func forecastSeries(data *interfaces.MetricModelData, filled []float64, valid []bool, period int,
+	analysis *interfaces.MetricAnalysis, sensitivity float64, nextTime func(int64) int64) *interfaces.MetricForecast {
+
+	lastTime, ok := toMillis(data.Times[len(data.Times)-1])
+	if !ok {
+		return nil
+	}
+	end := lastTime + analysis.ForecastMs
+	times := make([]int64, 0)
+	for t := nextTime(lastTime); t <= end && len(times) < interfaces.MAX_ANALYSIS_FORECAST_POINTS; t = nextTime(t) {
+		times = append(times, t)
+	}
+	h := len(times)
+
+	method := analysis.ForecastMethod
+	if method == "" {
+		method = interfaces.ANALYSIS_FORECAST_LINEAR
+		if period >= 2 {
+			method = interfaces.ANALYSIS_FORECAST_SEASONAL
+		}
+	}
+
+	var (
+		predicted []float64
+		residuals []float64
+	)
+	if method == interfaces.ANALYSIS_FORECAST_SEASONAL && period >= 2 {
+		var fitted []float64
+		fitted, predicted = holtWinters(filled, period, h)
+		for i := range filled {
+			if valid[i] {
+				residuals = append(residuals, filled[i]-fitted[i])
+			}
+		}
+	} else {
+		slope, intercept := linearFit(filled, valid)
+		n := len(filled)
+		predicted = make([]float64, h)
+		for j := 0; j < h; j++ {
+			predicted[j] = intercept + slope*float64(n+j)
+		}
+		for i := range filled {
+			if valid[i] {
+				residuals = append(residuals, filled[i]-(intercept+slope*float64(i)))
+			}
+		}
+	}
+	_, spread := residualStats(residuals, analysis.Detector)
+
+	forecast := &interfaces.MetricForecast{
+		Times:       make([]any, h),
+		Values:      make([]any, h),
+		UpperBounds: make([]any, h),
+		LowerBounds: make([]any, h),
+	}
+	for j := 0; j < h; j++ {
+		forecast.Times[j] = times[j]
+		forecast.Values[j] = predicted[j]
+		forecast.UpperBounds[j] = predicted[j] + sensitivity*spread
+		forecast.LowerBounds[j] = predicted[j] - sensitivity*spread
+	}
+	return forecast
+}
+
+// 季节分解：滑动中位数得到趋势，去趋势后按相位取中位数得到季节项，基线为趋势+季节项。
+// 趋势和季节项都用中位数，单个突刺不会拉偏相邻点的基线。
+func stlBaseline(values []float64, period int) []float64 {
+	n := len(values)
+	if period < 2 || n < 2*period {
+		return movingMedian(values, min(defaultTrendWindow, n))
+	}
+
+	trend := movingMedian(values, period)
+	phases := make([][]float64, period)
+	for i := range values {
+		phases[i%period] = append(phases[i%period], values[i]-trend[i])
+	}
+
+	seasonal := make([]float64, period)
+	seasonalMean := 0.0
+	for p := range phases {
+		seasonal[p] = median(phases[p])
+		seasonalMean += seasonal[p]
+	}
+	seasonalMean /= float64(period)
+
+	baseline := make([]float64, n)
+	for i := range values {
+		baseline[i] = trend[i] + seasonal[i%period] - seasonalMean
+	}
+	return baseline
+}
+
+// 加法 holt-winters。返回每个点的一步预测值作为基线，以及之后 h 个点的预测值。
+// 无季节周期或点数不足两个周期时退化为 holt 线性趋势。
+func holtWinters(values []float64, period int, horizon int) ([]float64, []float64) {
+	n := len(values)
+	fitted := make([]float64, n)
+	predicted := make([]float64, horizon)
+
+	if period < 2 || n < 2*period {
+		level, trend := values[0], 0.0
+		if n > 1 {
+			trend = values[1] - values[0]
+		}
+		fitted[0] = values[0]
+		for i := 1; i < n; i++ {
+			fitted[i] = level + trend
+			prevLevel := level
+			level = hwAlpha*values[i] + (1-hwAlpha)*(level+trend)
+			trend = hwBeta*(level-prevLevel) + (1-hwBeta)*trend
+		}
+		for j := 0; j < horizon; j++ {
+			predicted[j] = level + float64(j+1)*trend
+		}
+		return fitted, predicted
+	}
+
+	// 用前两个周期初始化水平、趋势和季节项
+	first, second := mean(values[:period]), mean(values[period:2*period])
+	level := first
+	trend := (second - first) / float64(period)
+	season := make([]float64, period)
+	for i := 0; i < period; i++ {
+		season[i] = values[i] - first
+		fitted[i] = first + season[i]
+	}
+
+	for i := period; i < n; i++ {
+		s := season[i%period]
+		fitted[i] = level + trend + s
+		prevLevel := level
+		level = hwAlpha*(values[i]-s) + (1-hwAlpha)*(level+trend)
+		trend = hwBeta*(level-prevLevel) + (1-hwBeta)*trend
+		season[i%period] = hwGamma*(values[i]-level) + (1-hwGamma)*s
+	}
+	for j := 0; j < horizon; j++ {
+		predicted[j] = level + float64(j+1)*trend + season[(n+j)%period]
+	}
+	return fitted, predicted
+}
+
+// 残差的中心和离散度：zscore 用均值和标准差，mad 用中位数和换算后的中位数绝对偏差
+func residualStats(residuals []float64, detector string) (float64, float64) {
+	if len(residuals) == 0 {
+		return 0, 0
+	}
+	if detector == interfaces.ANALYSIS_DETECTOR_ZSCORE {
+		m := mean(residuals)
+		variance := 0.0
+		for _, r := range residuals {
+			variance += (r - m) * (r - m)
+		}
+		return m, math.Sqrt(variance / float64(len(residuals)))
+	}
+
+	med := median(residuals)
+	deviations := make([]float64, len(residuals))
+	for i, r := range residuals {
+		deviations[i] = math.Abs(r - med)
+	}
+	return med, madScale * median(deviations)
+}
+
+// 最小二乘拟合 value = intercept + slope*index
+func linearFit(values []float64, valid []bool) (float64, float64) {
+	var sx, sy, sxx, sxy, cnt float64
+	for i, v := range values {
+		if !valid[i] {
+			continue
+		}
+		x := float64(i)
+		sx += x
+		sy += v
+		sxx += x * x
+		sxy += x * v
+		cnt++
+	}
+	denominator := cnt*sxx - sx*sx
+	if cnt == 0 || denominator == 0 {
+		return 0, sy / math.Max(cnt, 1)
+	}
+	slope := (cnt*sxy - sx*sy) / denominator
+	return slope, (sy - slope*sx) / cnt
+}
+
+// 居中滑动中位数。两端不截断窗口而是平移到序列内，保证每个点的窗口都覆盖完整周期
+func movingMedian(values []float64, window int) []float64 {
+	n := len(values)
+	res := make([]float64, n)
+	window = max(1, min(window, n))
+	half := window / 2
+	for i := range values {
+		lo := min(max(0, i-half), n-window)
+		res[i] = median(values[lo : lo+window])
+	}
+	return res
+}
+
+// 根据序列的时间间隔中位数估算季节周期内的点数
+func seasonPoints(times []any, seasonMs int64) int {
+	if seasonMs <= 0 || len(times) < 2 {
+		return 0
+	}
+	intervals := make([]float64, 0, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		prev, ok1 := toMillis(times[i-1])
+		curr, ok2 := toMillis(times[i])
+		if ok1 && ok2 && curr > prev {
+			intervals = append(intervals, float64(curr-prev))
+		}
+	}
+	if len(intervals) == 0 {
+		return 0
+	}
+	return int(math.Round(float64(seasonMs) / median(intervals)))
+}
+
+// 取出序列中的有效数值，nil 和 +Inf 等字符串值视为缺失
+func seriesValues(raw []any) ([]float64, []bool) {
+	values := make([]float64, len(raw))
+	valid := make([]bool, len(raw))
+	for i, v := range raw {
+		var f float64
+		switch val := v.(type) {
+		case float64:
+			f = val
+		case float32:
+			f = float64(val)
+		case int:
+			f = float64(val)
+		case int64:
+			f = float64(val)
+		default:
+			continue
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			continue
+		}
+		values[i] = f
+		valid[i] = true
+	}
+	return values, valid
+}
+
+// 线性插值填补缺失点，两端用最近的有效值
+func fillMissing(values []float64, valid []bool) []float64 {
+	filled := make([]float64, len(values))
+	copy(filled, values)
+
+	prev := -1
+	for i := range values {
+		if !valid[i] {
+			continue
+		}
+		if prev == -1 {
+			for j := 0; j < i; j++ {
+				filled[j] = values[i]
+			}
+		} else if i-prev > 1 {
+			step := (values[i] - values[prev]) / float64(i-prev)
+			for j := prev + 1; j < i; j++ {
+				filled[j] = values[prev] + step*float64(j-prev)
+			}
+		}
+		prev = i
+	}
+	for j := prev + 1; j < len(values); j++ {
+		filled[j] = values[prev]
+	}
+	return filled
+}
+
+func countValid(valid []bool) int {
+	cnt := 0
+	for _, v := range valid {
+		if v {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+func toMillis(t any) (int64, bool) {
+	switch val := t.(type) {
+	case int64:
+		return val, true
+	case int:
+		return int64(val), true
+	case float64:
+		return int64(val), true
+	default:
+		return 0, false
+	}
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
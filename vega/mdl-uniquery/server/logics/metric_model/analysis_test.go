@@ -0,0 +1,162 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package metric_model
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"uniquery/interfaces"
+)
+
+const analysisStep = int64(60 * 1000)
+
+// 周期为 period 个点的正弦序列，spikeAt 处注入突刺
+func mockSeasonalSeries(n, period, spikeAt int) interfaces.MetricModelData {
+	times := make([]any, n)
+	values := make([]any, n)
+	for i := 0; i < n; i++ {
+		times[i] = int64(i) * analysisStep
+		// 叠加确定性的小幅噪声
+		values[i] = 100 + 10*math.Sin(2*math.Pi*float64(i)/float64(period)) + 0.5*math.Sin(float64(i*i))
+	}
+	if spikeAt >= 0 {
+		values[spikeAt] = values[spikeAt].(float64) + 80
+	}
+	return interfaces.MetricModelData{Times: times, Values: values}
+}
+
+func nextAnalysisTime(t int64) int64 {
+	return t + analysisStep
+}
+
+func Test_analyzeMetricDatas(t *testing.T) {
+	Convey("Test analyzeMetricDatas", t, func() {
+		period := 12
+
+		Convey("stl baseline with mad detector flags the spike", func() {
+			datas := []interfaces.MetricModelData{mockSeasonalSeries(6*period, period, 40)}
+			analysis := &interfaces.MetricAnalysis{
+				Baseline: interfaces.ANALYSIS_BASELINE_STL,
+				Detector: interfaces.ANALYSIS_DETECTOR_MAD,
+				SeasonMs: int64(period) * analysisStep,
+			}
+
+			res := analyzeMetricDatas(datas, analysis, nextAnalysisTime)
+			So(len(res[0].Anomalies), ShouldEqual, 6*period)
+			for i, a := range res[0].Anomalies {
+				So(a, ShouldEqual, i == 40)
+			}
+			So(res[0].AnomalyScores[40].(float64), ShouldBeGreaterThan, interfaces.DEFAULT_ANALYSIS_SENSITIVITY)
+			So(res[0].UpperBounds[0].(float64), ShouldBeGreaterThan, res[0].LowerBounds[0].(float64))
+			So(res[0].Forecast, ShouldBeNil)
+		})
+
+		Convey("holt-winters baseline with zscore detector flags the spike", func() {
+			datas := []interfaces.MetricModelData{mockSeasonalSeries(6*period, period, 50)}
+			sensitivity := 4.0
+			analysis := &interfaces.MetricAnalysis{
+				Baseline:    interfaces.ANALYSIS_BASELINE_HOLT_WINTERS,
+				Detector:    interfaces.ANALYSIS_DETECTOR_ZSCORE,
+				Sensitivity: &sensitivity,
+				SeasonMs:    int64(period) * analysisStep,
+			}
+
+			res := analyzeMetricDatas(datas, analysis, nextAnalysisTime)
+			So(res[0].Anomalies[50], ShouldBeTrue)
+			So(res[0].Anomalies[10], ShouldBeFalse)
+		})
+
+		Convey("missing values keep nil flags", func() {
+			data := mockSeasonalSeries(3*period, period, -1)
+			data.Values[5] = nil
+			data.Values[6] = "+Inf"
+			analysis := &interfaces.MetricAnalysis{SeasonMs: int64(period) * analysisStep}
+
+			res := analyzeMetricDatas([]interfaces.MetricModelData{data}, analysis, nextAnalysisTime)
+			So(res[0].Anomalies[5], ShouldBeNil)
+			So(res[0].AnomalyScores[6], ShouldBeNil)
+			So(res[0].Baselines[5], ShouldNotBeNil)
+		})
+
+		Convey("too few points", func() {
+			data := interfaces.MetricModelData{
+				Times:  []any{int64(0), analysisStep},
+				Values: []any{1.0, nil},
+			}
+			res := analyzeMetricDatas([]interfaces.MetricModelData{data}, &interfaces.MetricAnalysis{}, nextAnalysisTime)
+			So(res[0].Baselines, ShouldResemble, []any{nil, nil})
+			So(res[0].Anomalies, ShouldResemble, []any{nil, nil})
+		})
+
+		Convey("linear forecast follows the trend", func() {
+			n := 20
+			data := interfaces.MetricModelData{Times: make([]any, n), Values: make([]any, n)}
+			for i := 0; i < n; i++ {
+				data.Times[i] = int64(i) * analysisStep
+				data.Values[i] = float64(2*i + 1)
+			}
+			analysis := &interfaces.MetricAnalysis{
+				ForecastMs:     5 * analysisStep,
+				ForecastMethod: interfaces.ANALYSIS_FORECAST_LINEAR,
+			}
+
+			res := analyzeMetricDatas([]interfaces.MetricModelData{data}, analysis, nextAnalysisTime)
+			forecast := res[0].Forecast
+			So(forecast, ShouldNotBeNil)
+			So(len(forecast.Times), ShouldEqual, 5)
+			So(forecast.Times[0], ShouldEqual, int64(n)*analysisStep)
+			So(forecast.Times[4], ShouldEqual, int64(n+4)*analysisStep)
+			So(forecast.Values[0].(float64), ShouldAlmostEqual, float64(2*n+1), 1e-6)
+			So(forecast.Values[4].(float64), ShouldAlmostEqual, float64(2*(n+4)+1), 1e-6)
+		})
+
+		Convey("seasonal forecast repeats the season", func() {
+			data := mockSeasonalSeries(8*period, period, -1)
+			analysis := &interfaces.MetricAnalysis{
+				SeasonMs:   int64(period) * analysisStep,
+				ForecastMs: int64(period) * analysisStep,
+			}
+
+			res := analyzeMetricDatas([]interfaces.MetricModelData{data}, analysis, nextAnalysisTime)
+			forecast := res[0].Forecast
+			So(len(forecast.Values), ShouldEqual, period)
+			for j := 0; j < period; j++ {
+				expected := 100 + 10*math.Sin(2*math.Pi*float64(8*period+j)/float64(period))
+				So(forecast.Values[j].(float64), ShouldAlmostEqual, expected, 2)
+				So(forecast.UpperBounds[j].(float64), ShouldBeGreaterThanOrEqualTo, forecast.Values[j].(float64))
+			}
+		})
+	})
+}
+
+func Test_analysisHelpers(t *testing.T) {
+	Convey("Test analysis helpers", t, func() {
+		Convey("fillMissing interpolates and pads", func() {
+			values := []float64{0, 1, 0, 3, 0}
+			valid := []bool{false, true, false, true, false}
+			So(fillMissing(values, valid), ShouldResemble, []float64{1, 1, 2, 3, 3})
+		})
+
+		Convey("seasonPoints uses median interval", func() {
+			times := []any{int64(0), int64(1000), int64(2000), int64(5000)}
+			So(seasonPoints(times, 10000), ShouldEqual, 10)
+			So(seasonPoints(times, 0), ShouldEqual, 0)
+		})
+
+		Convey("residualStats", func() {
+			center, spread := residualStats([]float64{1, 2, 3, 4, 100}, interfaces.ANALYSIS_DETECTOR_MAD)
+			So(center, ShouldEqual, 3)
+			So(spread, ShouldAlmostEqual, madScale, 1e-9)
+
+			center, spread = residualStats([]float64{1, 3}, interfaces.ANALYSIS_DETECTOR_ZSCORE)
+			So(center, ShouldEqual, 2)
+			So(spread, ShouldEqual, 1)
+		})
+	})
+}
@@ -189,6 +189,12 @@ func (mms *metricModelService) Simulate(ctx context.Context,
 		return resp, err
 	}
 
+	if query.Analysis != nil && !query.IsInstantQuery {
+		resp.Datas = analyzeMetricDatas(resp.Datas, query.Analysis, func(t int64) int64 {
+			return getNextPointTime(query, t)
+		})
+	}
+
 	if query.IncludeModel {
 		resp.Model = interfaces.MetricModel{
 			MetricType:      query.MetricType,
@@ -341,6 +347,13 @@ func (mms *metricModelService) Exec(ctx context.Context, query *interfaces.Metri
 		return resps, seriesTotal, pointTotal, err
 	}
 
+	// 基线、异常检测和预测
+	if query.Analysis != nil && !query.IsInstantQuery {
+		respi.Datas = analyzeMetricDatas(respi.Datas, query.Analysis, func(t int64) int64 {
+			return getNextPointTime(*query, t)
+		})
+	}
+
 	if query.IncludeModel {
 		respi.Model = metricModel
 	}
@@ -586,6 +599,7 @@ func (mms *metricModelService) eval(ctx context.Context, query interfaces.Metric
 		derivedConfig := query.FormulaConfig.(interfaces.DerivedConfig)
 		newQuery := query
 		newQuery.MetricModelID = derivedConfig.DependMetricModel.ID // 查依赖指标
+		newQuery.Analysis = nil                                     // 指标分析在衍生指标的结果上做，不往原子指标传
 		// 衍生指标的过滤条件传递: 把衍生指标的过滤条件，code和配置化两种模式，不在这里把过滤条件转code。未来dsl promql支持衍生指标时，不好处理
 		// 用 and 合并date_condition 和business_condition
 		condition := derivedConfig.DateCondition
@@ -2374,6 +2388,77 @@ func (mms *metricModelService) GetMetricModelLabels(ctx context.Context, modelID
 	return fields, nil
 }
 
+// 获取指标模型的元数据，key 为指标模型id。modelID 为空时获取有权限的全部指标模型，limit 小于等于0时不限制个数
+func (mms *metricModelService) GetMetricModelsMetadata(ctx context.Context, modelID string,
+	limit int) (map[string][]interfaces.MetricMetadata, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "查询指标模型的元数据")
+	defer span.End()
+
+	var models []interfaces.MetricModel
+	if modelID != "" {
+		// 决策当前模型id的数据查询权限
+		err := mms.ps.CheckPermission(ctx, interfaces.Resource{
+			ID:   modelID,
+			Type: interfaces.RESOURCE_TYPE_METRIC_MODEL,
+		}, []string{interfaces.OPERATION_TYPE_DATA_QUERY})
+		if err != nil {
+			return nil, err
+		}
+
+		metricModels, _, err := mms.mmAccess.GetMetricModel(ctx, modelID)
+		if err != nil {
+			logger.Errorf("Get Metric Model error: %s", err.Error())
+
+			// 添加异常时的 trace 属性
+			span.SetAttributes(attribute.Key("model_id").String(modelID))
+			span.SetStatus(codes.Error, "Get Metric Model error")
+			// 记录异常日志
+			o11y.Error(ctx, fmt.Sprintf("Get Metric Model error: %v", err))
+
+			return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+				uerrors.Uniquery_MetricModel_InternalError_GetModelByIdFailed).WithErrorDetails(err.Error())
+		}
+		// 模型不存在时返回空的元数据
+		models = metricModels
+	} else {
+		// 列表接口按请求的账户过滤有权限的指标模型
+		metricModels, err := mms.mmAccess.ListMetricModels(ctx, -1)
+		if err != nil {
+			logger.Errorf("List Metric Models error: %s", err.Error())
+
+			span.SetStatus(codes.Error, "List Metric Models error")
+			// 记录异常日志
+			o11y.Error(ctx, fmt.Sprintf("List Metric Models error: %v", err))
+
+			return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+				uerrors.Uniquery_MetricModel_InternalError_ListMetricModelsFailed).WithErrorDetails(err.Error())
+		}
+		models = metricModels
+	}
+
+	metadata := make(map[string][]interfaces.MetricMetadata, len(models))
+	for _, model := range models {
+		if limit > 0 && len(metadata) >= limit {
+			break
+		}
+
+		// 没有备注时用模型名称作为帮助信息
+		help := model.Comment
+		if help == "" {
+			help = model.ModelName
+		}
+		metadata[model.ModelID] = []interfaces.MetricMetadata{{
+			Type: interfaces.METRIC_METADATA_TYPE_GAUGE,
+			Help: help,
+			Unit: model.Unit,
+		}}
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return metadata, nil
+}
+
 func (mms *metricModelService) getDSLModelLabels(ctx context.Context, model interfaces.MetricModel,
 	dataView interfaces.DataView) ([]*cond.ViewField, error) {
 
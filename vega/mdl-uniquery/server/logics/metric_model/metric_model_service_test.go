@@ -3279,6 +3279,83 @@ func TestGetMetricModelLabels(t *testing.T) {
 	})
 }
 
+func TestGetMetricModelsMetadata(t *testing.T) {
+	Convey("Test GetMetricModelsMetadata", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		mmaMock := mock.NewMockMetricModelAccess(mockCtrl)
+		ibaMock := mock.NewMockIndexBaseAccess(mockCtrl)
+		promQLMock := mock.NewMockPromQLService(mockCtrl)
+		dvsMock := mock.NewMockDataViewService(mockCtrl)
+		vvsMock := mock.NewMockVegaService(mockCtrl)
+		psMock := mock.NewMockPermissionService(mockCtrl)
+		ds := MockNewMetricModelService(appSetting, mmaMock, ibaMock, promQLMock, dvsMock, vvsMock, psMock)
+
+		models := []interfaces.MetricModel{
+			{ModelID: "1", ModelName: "cpu_usage", Unit: "%", Comment: "cpu usage of node"},
+			{ModelID: "2", ModelName: "mem_usage"},
+		}
+
+		Convey("failed with ListMetricModels error", func() {
+			mmaMock.EXPECT().ListMetricModels(gomock.Any(), -1).Return(nil, fmt.Errorf("list failed"))
+
+			metadata, err := ds.GetMetricModelsMetadata(testCtx, "", 0)
+			So(metadata, ShouldBeNil)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual,
+				uerrors.Uniquery_MetricModel_InternalError_ListMetricModelsFailed)
+		})
+
+		Convey("success with all metric models", func() {
+			mmaMock.EXPECT().ListMetricModels(gomock.Any(), -1).Return(models, nil)
+
+			metadata, err := ds.GetMetricModelsMetadata(testCtx, "", 0)
+			So(err, ShouldBeNil)
+			So(metadata, ShouldResemble, map[string][]interfaces.MetricMetadata{
+				"1": {{Type: interfaces.METRIC_METADATA_TYPE_GAUGE, Help: "cpu usage of node", Unit: "%"}},
+				"2": {{Type: interfaces.METRIC_METADATA_TYPE_GAUGE, Help: "mem_usage"}},
+			})
+		})
+
+		Convey("success with limit", func() {
+			mmaMock.EXPECT().ListMetricModels(gomock.Any(), -1).Return(models, nil)
+
+			metadata, err := ds.GetMetricModelsMetadata(testCtx, "", 1)
+			So(err, ShouldBeNil)
+			So(len(metadata), ShouldEqual, 1)
+		})
+
+		Convey("failed with permission error", func() {
+			psMock.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("forbidden"))
+
+			metadata, err := ds.GetMetricModelsMetadata(testCtx, "1", 0)
+			So(metadata, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("success with metric model id", func() {
+			psMock.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mmaMock.EXPECT().GetMetricModel(gomock.Any(), "1").Return(models[:1], true, nil)
+
+			metadata, err := ds.GetMetricModelsMetadata(testCtx, "1", 0)
+			So(err, ShouldBeNil)
+			So(metadata, ShouldResemble, map[string][]interfaces.MetricMetadata{
+				"1": {{Type: interfaces.METRIC_METADATA_TYPE_GAUGE, Help: "cpu usage of node", Unit: "%"}},
+			})
+		})
+
+		Convey("metric model not found", func() {
+			psMock.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mmaMock.EXPECT().GetMetricModel(gomock.Any(), "3").Return([]interfaces.MetricModel{}, false, nil)
+
+			metadata, err := ds.GetMetricModelsMetadata(testCtx, "3", 0)
+			So(err, ShouldBeNil)
+			So(metadata, ShouldBeEmpty)
+		})
+	})
+}
+
 func TestRewritePromQLFilters(t *testing.T) {
 	Convey("Test rewritePromQLFilters", t, func() {
 
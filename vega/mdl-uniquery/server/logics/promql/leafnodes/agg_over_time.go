@@ -143,10 +143,8 @@ func aggOverTimeMerge4InstantQuery(keys []string, mapResult MapResult, query *in
 				if finalPoint != nil {
 					mergeTwoPointWithSameTimeKey(aggName, currentPoint, finalPoint)
 				} else {
-					finalPoint = &static.AGGPoint{
-						Count: currentPoint.Count,
-						Value: currentPoint.Value,
-					}
+					point := *currentPoint
+					finalPoint = &point
 				}
 			}
 		}
@@ -231,10 +229,8 @@ func aggOverTimeMergeTaskFuncWrapper(chs chan<- map[string]static.Series, k stri
 				if existPoint, ok := tempPoints[ts]; ok {
 					mergeTwoPointWithSameTimeKey(aggName, currentPoint, existPoint)
 				} else {
-					tempPoints[ts] = &static.AGGPoint{
-						Count: currentPoint.Count,
-						Value: currentPoint.Value,
-					}
+					point := *currentPoint
+					tempPoints[ts] = &point
 				}
 			}
 
@@ -277,18 +273,38 @@ func mergeTwoPointWithSameTimeKey(aggName string, cuurentPoint *static.AGGPoint,
 	case interfaces.AVG_OVER_TIME, interfaces.SUM_OVER_TIME, interfaces.COUNT_OVER_TIME:
 		// 求和
 		existPoint.Value += cuurentPoint.Value
+	case interfaces.STDDEV_OVER_TIME, interfaces.STDVAR_OVER_TIME:
+		// 总和与平方和都需相加
+		existPoint.Value += cuurentPoint.Value
+		existPoint.SumOfSquares += cuurentPoint.SumOfSquares
+	case interfaces.LAST_OVER_TIME:
+		// 取时间戳较大者，时间戳相同时取值较大者
+		if cuurentPoint.Timestamp > existPoint.Timestamp ||
+			(cuurentPoint.Timestamp == existPoint.Timestamp && cuurentPoint.Value > existPoint.Value) {
+			existPoint.Timestamp = cuurentPoint.Timestamp
+			existPoint.Value = cuurentPoint.Value
+		}
 	}
 }
 
-// 基于合并完之后的数据计算相应的聚合值。avg_over_time = sum(sum) / sum(count)，其余的聚合函数直接取值。
+// 基于合并完之后的数据计算相应的聚合值。avg_over_time = sum(sum) / sum(count)，stddev_over_time 和 stdvar_over_time
+// 基于总和、平方和与计数计算，其余的聚合函数直接取值。
 func aggOverTimeFunction(aggName string, tempPoint *static.AGGPoint) float64 {
 	var value float64
 	switch aggName {
 	case interfaces.AVG_OVER_TIME:
 		// avg = 总和/总计数
 		value = float64(tempPoint.Value / float64(tempPoint.Count))
-	case interfaces.SUM_OVER_TIME, interfaces.MAX_OVER_TIME, interfaces.MIN_OVER_TIME, interfaces.COUNT_OVER_TIME:
+	case interfaces.SUM_OVER_TIME, interfaces.MAX_OVER_TIME, interfaces.MIN_OVER_TIME, interfaces.COUNT_OVER_TIME,
+		interfaces.LAST_OVER_TIME:
 		value = tempPoint.Value
+	case interfaces.STDDEV_OVER_TIME, interfaces.STDVAR_OVER_TIME:
+		// 方差 = 平方和/总计数 - 均值的平方，浮点误差可能导致结果略小于0，修正为0
+		mean := tempPoint.Value / float64(tempPoint.Count)
+		value = math.Max(tempPoint.SumOfSquares/float64(tempPoint.Count)-mean*mean, 0)
+		if aggName == interfaces.STDDEV_OVER_TIME {
+			value = math.Sqrt(value)
+		}
 	}
 	return value
 }
@@ -299,21 +315,25 @@ func aggOverTimeMergePointWithSameKey(aggName string, pointMap map[int64]*static
 	for _, tsArri := range tsArr {
 		for _, pointij := range tsArri {
 			currentT := pointij.Get("key").Int()
-			currentCount := pointij.Get("doc_count").Int()
-			currentValue := pointij.Get("value.value").Float()
+			currentPoint := &static.AGGPoint{
+				Count: pointij.Get("doc_count").Int(),
+				Value: pointij.Get("value.value").Float(),
+			}
+			switch aggName {
+			case interfaces.STDDEV_OVER_TIME, interfaces.STDVAR_OVER_TIME:
+				// extended_stats 的结果
+				currentPoint.Value = pointij.Get("value.sum").Float()
+				currentPoint.SumOfSquares = pointij.Get("value.sum_of_squares").Float()
+			case interfaces.LAST_OVER_TIME:
+				// sampling 的结果
+				currentPoint.Timestamp = pointij.Get("value.timestamp").Int()
+			}
 
 			// 得到当前点的值，需把时间桶key相同的合并在一起
 			if existPoint, ok := pointMap[currentT]; ok {
-				mergeTwoPointWithSameTimeKey(aggName,
-					&static.AGGPoint{
-						Count: currentCount,
-						Value: currentValue,
-					}, existPoint)
+				mergeTwoPointWithSameTimeKey(aggName, currentPoint, existPoint)
 			} else {
-				pointMap[currentT] = &static.AGGPoint{
-					Count: currentCount,
-					Value: currentValue,
-				}
+				pointMap[currentT] = currentPoint
 			}
 		}
 	}
@@ -1002,3 +1002,31 @@ func TestAggOverTimeMergePointWithSameKey(t *testing.T) {
 
 	})
 }
+
+func TestAggOverTimeStatFunctions(t *testing.T) {
+	Convey("test stddev_over_time, stdvar_over_time and last_over_time", t, func() {
+
+		Convey("stddev and stdvar merge sum and sum of squares", func() {
+			// 样本值 2,4,4,4 与 5,5,7,9 两个分片
+			tsArr := [][]gjson.Result{
+				{{Type: gjson.JSON, Raw: `{"key":1000,"doc_count":4,"value":{"count":4,"sum":14,"sum_of_squares":52}}`}},
+				{{Type: gjson.JSON, Raw: `{"key":1000,"doc_count":4,"value":{"count":4,"sum":26,"sum_of_squares":180}}`}},
+			}
+			pointMap := make(map[int64]*static.AGGPoint)
+			aggOverTimeMergePointWithSameKey(interfaces.STDVAR_OVER_TIME, pointMap, tsArr)
+			So(aggOverTimeFunction(interfaces.STDVAR_OVER_TIME, pointMap[1000]), ShouldAlmostEqual, 4)
+			So(aggOverTimeFunction(interfaces.STDDEV_OVER_TIME, pointMap[1000]), ShouldAlmostEqual, 2)
+		})
+
+		Convey("last_over_time takes the latest sample", func() {
+			tsArr := [][]gjson.Result{
+				{{Type: gjson.JSON, Raw: `{"key":1000,"doc_count":3,"value":{"timestamp":1800,"value":3}}`}},
+				{{Type: gjson.JSON, Raw: `{"key":1000,"doc_count":2,"value":{"timestamp":1900,"value":1}}`}},
+			}
+			pointMap := make(map[int64]*static.AGGPoint)
+			aggOverTimeMergePointWithSameKey(interfaces.LAST_OVER_TIME, pointMap, tsArr)
+			So(aggOverTimeFunction(interfaces.LAST_OVER_TIME, pointMap[1000]), ShouldEqual, 1)
+			So(pointMap[1000].Timestamp, ShouldEqual, 1900)
+		})
+	})
+}
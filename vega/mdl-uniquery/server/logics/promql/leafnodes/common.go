@@ -905,6 +905,18 @@ func (leafNodes *LeafNodes) searchSubmitTaskFuncWapper(ctx context.Context, shar
 		iteratorTermsAgg(aggregations, "", make([]*labels.Label, 0), 0,
 			groupBy, mapResult)
 
+		// 原始样本点的子时间桶被 top_hits 截断时，分页补齐
+		if err := leafNodes.pageRawSamples(ctx, dsl.String(), index, shardId, groupBy, mapResult); err != nil {
+			span.SetStatus(codes.Error, "Page raw samples error")
+			mapResultChs <- &MapResult{
+				Err: uerrors.PromQLError{
+					Typ: uerrors.ErrorInternal,
+					Err: errors.New(err.Error()),
+				},
+			}
+			return
+		}
+
 		span.SetStatus(codes.Ok, "")
 
 		mapResultChs <- mapResult
@@ -1150,7 +1150,7 @@ func TestMakeAggregation(t *testing.T) {
 		Convey("groupby is empty", func() {
 			var resAgg bytes.Buffer
 			status, err := makeAggregation([]string{}, samplingAgg,
-				"elasticsearch_os_cpu_percent", 30000, maxSearchSeriesSize, &resAgg)
+				"elasticsearch_os_cpu_percent", 30000, 0, maxSearchSeriesSize, &resAgg)
 			So(resAgg, ShouldNotBeEmpty)
 			So(status, ShouldEqual, http.StatusOK)
 			So(err, ShouldBeNil)
@@ -1158,7 +1158,7 @@ func TestMakeAggregation(t *testing.T) {
 		Convey("groupby is not __labels_str", func() {
 			var resAgg bytes.Buffer
 			status, err := makeAggregation([]string{"cluster"}, samplingAgg,
-				"elasticsearch_os_cpu_percent", 30000, maxSearchSeriesSize, &resAgg)
+				"elasticsearch_os_cpu_percent", 30000, 0, maxSearchSeriesSize, &resAgg)
 			So(resAgg, ShouldNotBeEmpty)
 			So(status, ShouldEqual, http.StatusOK)
 			So(err, ShouldBeNil)
@@ -1167,7 +1167,7 @@ func TestMakeAggregation(t *testing.T) {
 		Convey("aggregationType is empty", func() {
 			var resAgg bytes.Buffer
 			status, err := makeAggregation(groupby, "", "elasticsearch_os_cpu_percent",
-				30000, maxSearchSeriesSize, &resAgg)
+				30000, 0, maxSearchSeriesSize, &resAgg)
 			// So(resAgg, ShouldBeEmpty)
 			So(status, ShouldEqual, http.StatusUnprocessableEntity)
 			So(err, ShouldNotBeNil)
@@ -1216,7 +1216,7 @@ func TestMakeAggregation(t *testing.T) {
 			expectStr = replace(expectStr)
 
 			var resAgg bytes.Buffer
-			status, err := makeAggregation(groupby, samplingAgg, "elasticsearch_os_cpu_percent", 30000, maxSearchSeriesSize, &resAgg)
+			status, err := makeAggregation(groupby, samplingAgg, "elasticsearch_os_cpu_percent", 30000, 0, maxSearchSeriesSize, &resAgg)
 
 			So(replace(resAgg.String()), ShouldEqual, expectStr)
 			So(status, ShouldEqual, http.StatusOK)
@@ -99,7 +99,7 @@ func (leafNodes *LeafNodes) IrateEval(ctx context.Context, expr *parser.MatrixSe
 	// 合并结果
 	mergeCtx, mergeSpan := ar_trace.Tracer.Start(ctx, "Irate merge")
 	defer mergeSpan.End()
-	mat, err := irateMerge(result.(MapResult), &newQuery, expr.Range)
+	mat, err := irateMerge(result.(MapResult), &newQuery, expr.Range, aggregationType)
 	if err != nil {
 		// 记录异常的日志
 		o11y.Error(mergeCtx, fmt.Sprintf("Irate merge Error: %v", err))
@@ -140,9 +140,10 @@ func getCommonDivisor(a, b int64) int64 {
 //	@param mapResult
 //	@param query
 //	@param selRange
+//	@param aggregationType: irate 或 idelta，两者共用采样聚合，仅最终计算不同
 //	@return static.IrateMatrix
 //	@return error
-func irateMerge(mapResult MapResult, query *interfaces.Query, selRange time.Duration) (static.Matrix, error) {
+func irateMerge(mapResult MapResult, query *interfaces.Query, selRange time.Duration, aggregationType string) (static.Matrix, error) {
 	// 对 map 的 key 排序，使得同一个请求输出的结果不会乱序
 	keys := []string{}
 	for key := range mapResult.LabelsMap {
@@ -152,12 +153,12 @@ func irateMerge(mapResult MapResult, query *interfaces.Query, selRange time.Dura
 	sort.Strings(keys)
 
 	if query.IsInstantQuery {
-		return mergeInstant(mapResult, query, keys)
+		return mergeInstant(mapResult, query, keys, aggregationType)
 	}
-	return mergeRange(mapResult, query, selRange, keys)
+	return mergeRange(mapResult, query, selRange, keys, aggregationType)
 }
 
-func mergeInstant(mapResult MapResult, query *interfaces.Query, keys []string) (static.Matrix, error) {
+func mergeInstant(mapResult MapResult, query *interfaces.Query, keys []string, aggregationType string) (static.Matrix, error) {
 	mat := make(static.Matrix, 0)
 	for _, k := range keys {
 		tsArr := mapResult.TsValueMap[k]
@@ -196,7 +197,7 @@ func mergeInstant(mapResult MapResult, query *interfaces.Query, keys []string) (
 				LastV:     pointMap[end].LastV,
 			},
 		}
-		irateFunction(&tempPoints, query.End, &finalPoints)
+		instantVectorFunction(aggregationType)(&tempPoints, query.End, &finalPoints)
 		if len(finalPoints) == 0 {
 			continue
 		}
@@ -208,7 +209,7 @@ func mergeInstant(mapResult MapResult, query *interfaces.Query, keys []string) (
 	return mat, nil
 }
 
-func mergeRange(mapResult MapResult, query *interfaces.Query, selRange time.Duration, keys []string) (static.Matrix, error) {
+func mergeRange(mapResult MapResult, query *interfaces.Query, selRange time.Duration, keys []string, aggregationType string) (static.Matrix, error) {
 	chs := make(chan map[string]static.Series, len(mapResult.TsValueMap))
 	defer close(chs)
 
@@ -218,7 +219,7 @@ func mergeRange(mapResult MapResult, query *interfaces.Query, selRange time.Dura
 	for _, k := range keys {
 		tsArr := mapResult.TsValueMap[k]
 		// 用协程池提交执行的操作
-		err := util.MegerPool.Submit(irateMergeTaskFuncWapper(chs, k, tsArr, mapResult.LabelsMap, query, &wg, selRange))
+		err := util.MegerPool.Submit(irateMergeTaskFuncWapper(chs, k, tsArr, mapResult.LabelsMap, query, &wg, selRange, aggregationType))
 		if err != nil {
 			return nil, err
 		}
@@ -256,10 +257,11 @@ func mergeRange(mapResult MapResult, query *interfaces.Query, selRange time.Dura
 // @param query
 // @param wg
 // @param selRange
+// @param aggregationType
 // @return taskFunc
 func irateMergeTaskFuncWapper(chs chan<- map[string]static.Series, k string, tsArr [][]gjson.Result,
 	labelsMap map[string][]*labels.Label,
-	query *interfaces.Query, wg *sync.WaitGroup, selRange time.Duration) taskFunc {
+	query *interfaces.Query, wg *sync.WaitGroup, selRange time.Duration, aggregationType string) taskFunc {
 	return func() {
 		defer wg.Done()
 		// 1. 从k个数组中选取时间最小的作为开始时间, 选取时间最大的作为结束时间
@@ -279,6 +281,7 @@ func irateMergeTaskFuncWapper(chs chan<- map[string]static.Series, k string, tsA
 		seriesMap := make(map[string]static.Series)
 		selRangeTime := selRange.Milliseconds()
 		step := selRangeTime / query.SubIntervalWith30min
+		vectorFunction := instantVectorFunction(aggregationType)
 		// for ts := start; ts <= end; ts += query.Interval {
 		for ts := start; ts <= end; ts = static.GetNextPointTime(*query, ts) {
 			//根据step，对每个时间点做range处理
@@ -296,7 +299,7 @@ func irateMergeTaskFuncWapper(chs chan<- map[string]static.Series, k string, tsA
 					break
 				}
 			}
-			irateFunction(&tempPoints, ts, &finalPoints)
+			vectorFunction(&tempPoints, ts, &finalPoints)
 			delete(tempPoints, ts)
 		}
 
@@ -340,6 +343,33 @@ func irateFunction(tempPoints *map[int64]static.IratePoint, ts int64, finalPoint
 	return finalPoints
 }
 
+// instantVectorFunction
+// @Description: 根据聚合类型选择基于最后两个样本点的计算函数
+// @param aggregationType: irate 或 idelta
+// @return func
+func instantVectorFunction(aggregationType string) func(*map[int64]static.IratePoint, int64, *[]static.Point) *[]static.Point {
+	if aggregationType == interfaces.IDELTA_AGG {
+		return ideltaFunction
+	}
+	return irateFunction
+}
+
+// ideltaFunction
+// @Description: idelta计算, 即最后两个样本点的差值, 不处理计数器重置
+// @param tempPoints 计算的样本点
+// @param ts 当前时间戳
+// @param finalPoints 存储idelta值
+// @return *[]static.Point
+func ideltaFunction(tempPoints *map[int64]static.IratePoint, ts int64, finalPoints *[]static.Point) *[]static.Point {
+	value := (*tempPoints)[ts]
+	if value.PreviousT == 0 {
+		return finalPoints
+	}
+
+	*finalPoints = append(*finalPoints, static.Point{T: ts, V: value.LastV - value.PreviousV})
+	return finalPoints
+}
+
 // iratePoint
 // @Description: 合并分片的数据
 // @param pointMap: 合并存储的样本map
@@ -424,7 +424,7 @@ func TestLeafNodes_IrateMerge(t *testing.T) {
 				BatchSubmitPoolSize: 10,
 			})
 
-			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, &interfaces.Query{Interval: 0}, selRange)
+			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, &interfaces.Query{Interval: 0}, selRange, interfaces.IRATE_AGG)
 			So(mat, ShouldBeNil)
 			So(err.Error(), ShouldResemble, `this pool has been closed`)
 		})
@@ -518,7 +518,7 @@ func TestLeafNodes_IrateMerge(t *testing.T) {
 			expectMat := make(static.Matrix, 0)
 			expectMat = append(expectMat, expectSeries...)
 
-			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, irateQuery2, selRange)
+			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, irateQuery2, selRange, interfaces.IRATE_AGG)
 			So(mat, ShouldResemble, expectMat)
 			So(err, ShouldBeNil)
 		})
@@ -623,7 +623,7 @@ func TestLeafNodes_IrateMerge(t *testing.T) {
 			expectMat := make(static.Matrix, 0)
 			expectMat = append(expectMat, expectSeries...)
 
-			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, irateQueryFix, selRange)
+			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, irateQueryFix, selRange, interfaces.IRATE_AGG)
 			So(mat, ShouldResemble, expectMat)
 			So(err, ShouldBeNil)
 		})
@@ -700,7 +700,7 @@ func TestLeafNodes_IrateMerge(t *testing.T) {
 
 			expectMat := make(static.Matrix, 0)
 			expectMat = append(expectMat, expectSeries...)
-			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, query3, selRange)
+			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, query3, selRange, interfaces.IRATE_AGG)
 			So(mat, ShouldResemble, expectMat)
 			So(err, ShouldBeNil)
 		})
@@ -812,7 +812,7 @@ func TestLeafNodes_IrateMerge(t *testing.T) {
 			expectMat := make(static.Matrix, 0)
 			expectMat = append(expectMat, expectSeries...)
 
-			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, &interfaces.Query{Interval: 60000, Start: 165336900000, End: 1653370000, IsInstantQuery: false, SubIntervalWith30min: subInterval, FixedStart: fixedStart, FixedEnd: fixedEnd}, selRange)
+			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, &interfaces.Query{Interval: 60000, Start: 165336900000, End: 1653370000, IsInstantQuery: false, SubIntervalWith30min: subInterval, FixedStart: fixedStart, FixedEnd: fixedEnd}, selRange, interfaces.IRATE_AGG)
 			So(mat, ShouldResemble, expectMat)
 			So(err, ShouldBeNil)
 		})
@@ -908,7 +908,7 @@ func TestLeafNodes_IrateMerge(t *testing.T) {
 			subInterval := getCommonDivisor(irateRange, query.Interval)
 			fixedStart := int64(math.Floor(float64(irateQueryFix.Start*1000)/float64(irateQueryFix.Interval))) * irateQueryFix.Interval
 			fixedEnd := int64(math.Floor(float64(irateQueryFix.End*1000)/float64(irateQueryFix.Interval))) * irateQueryFix.Interval
-			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, &interfaces.Query{Interval: 300000, Start: 165336900000, End: 1653370000, IsInstantQuery: false, SubIntervalWith30min: subInterval, FixedStart: fixedStart, FixedEnd: fixedEnd}, selRange)
+			mat, err := irateMerge(MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}, &interfaces.Query{Interval: 300000, Start: 165336900000, End: 1653370000, IsInstantQuery: false, SubIntervalWith30min: subInterval, FixedStart: fixedStart, FixedEnd: fixedEnd}, selRange, interfaces.IRATE_AGG)
 			So(mat, ShouldResemble, expectMat)
 			So(err, ShouldBeNil)
 		})
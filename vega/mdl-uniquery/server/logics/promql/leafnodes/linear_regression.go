@@ -0,0 +1,272 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package leafnodes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	uerrors "uniquery/errors"
+	"uniquery/interfaces"
+	"uniquery/logics/promql/labels"
+	"uniquery/logics/promql/parser"
+	"uniquery/logics/promql/static"
+	"uniquery/logics/promql/util"
+)
+
+// deriv、predict_linear 算子：在子时间桶上下推计算线性回归的统计量(n, Σt, Σv, Σt², Σtv)，指定分片查询，合并结果。
+// duration 是 predict_linear 预测的时间（秒），deriv 不使用。
+func (ln *LeafNodes) LinearRegression(ctx context.Context, expr *parser.MatrixSelector, query *interfaces.Query,
+	funcName string, duration float64) (parser.Value, int, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Eval 叶子节点 LinearRegression")
+	defer span.End()
+
+	vs, newQuery, status, err := processParam(expr, query)
+	if err != nil {
+		return nil, status, err
+	}
+
+	span.SetAttributes(attribute.Key("function").String(funcName),
+		attribute.Key("start").Int64(newQuery.Start),
+		attribute.Key("subInterval30min").Int64(newQuery.SubIntervalWith30min),
+		attribute.Key("subInterval2h").Int64(newQuery.SubIntervalWith2h),
+	)
+
+	// 通用处理： 获取日志分组的索引信息 -> 构造 dsl -> 获取索引库下的所有索引以及对应的分片数 -> 执行 dsl
+	result, status, err := ln.commonProcess(ctx, vs, newQuery, interfaces.LINEAR_REGRESSION_AGG)
+	if err != nil {
+		// 记录异常的日志
+		o11y.Error(ctx, fmt.Sprintf("Common Process Error: %v", err))
+
+		return nil, status, err
+	}
+	matrixResult, ok := result.(static.Matrix)
+	if ok {
+		return matrixResult, status, err
+	}
+
+	// 合并结果
+	mergeCtx, mergeSpan := ar_trace.Tracer.Start(ctx, "LinearRegression merge")
+	defer mergeSpan.End()
+	mat, err := linearRegressionMerge(result.(MapResult), expr, newQuery, funcName, duration)
+	if err != nil {
+		// 记录异常的日志
+		o11y.Error(mergeCtx, fmt.Sprintf("LinearRegression merge Error: %v", err))
+		span.SetStatus(codes.Error, "LinearRegression merge Error")
+
+		return nil, http.StatusUnprocessableEntity, uerrors.PromQLError{
+			Typ: uerrors.ErrorExec,
+			Err: errors.New(err.Error()),
+		}
+	}
+	mergeSpan.SetStatus(codes.Ok, "")
+
+	if query.IfNeedAllSeries {
+		return mat, http.StatusOK, nil
+	}
+	span.SetStatus(codes.Ok, "")
+	return static.PageMatrix{Matrix: mat, TotalSeries: result.(MapResult).TotalSeries}, http.StatusOK, nil
+}
+
+// instant query 串行合并, range query 起协程同时合并
+func linearRegressionMerge(mapResult MapResult, expr *parser.MatrixSelector, query *interfaces.Query,
+	funcName string, duration float64) (static.Matrix, error) {
+
+	keys := make([]string, 0, len(mapResult.LabelsMap))
+	for key := range mapResult.LabelsMap {
+		keys = append(keys, key)
+	}
+	// 对 keys排序，从小到大
+	sort.Strings(keys)
+
+	if query.IsInstantQuery {
+		return linearRegressionMerge4InstantQuery(keys, mapResult, query, funcName, duration)
+	}
+	return linearRegressionMerge4RangeQuery(keys, mapResult, query, expr, funcName, duration)
+}
+
+// instant query 串行合并
+func linearRegressionMerge4InstantQuery(keys []string, mapResult MapResult, query *interfaces.Query,
+	funcName string, duration float64) (static.Matrix, error) {
+
+	mat := make(static.Matrix, 0, len(keys))
+	for _, k := range keys {
+		pointMap := make(map[int64]*static.RegressionPoint)
+		regressionMergePointWithSameKey(pointMap, mapResult.TsValueMap[k])
+
+		finalPoint := &static.RegressionPoint{}
+		for _, point := range pointMap {
+			mergeRegressionPoint(point, finalPoint)
+		}
+
+		v, ok := linearRegressionFunction(funcName, finalPoint, query.Start, query.End, duration)
+		if !ok {
+			continue
+		}
+		mat = append(mat, static.Series{
+			Metric: parseLabelsStr(k, mapResult.LabelsMap),
+			Points: []static.Point{{T: query.End, V: v}},
+		})
+	}
+
+	return mat, nil
+}
+
+// 区间查询, 每个__labels_str下的聚合结果各自用一个goroutine去合并
+func linearRegressionMerge4RangeQuery(keys []string, mapResult MapResult, query *interfaces.Query,
+	expr *parser.MatrixSelector, funcName string, duration float64) (static.Matrix, error) {
+
+	mat := make(static.Matrix, 0)
+	chs := make(chan map[string]static.Series, len(mapResult.TsValueMap))
+	defer close(chs)
+	var wg sync.WaitGroup
+	wg.Add(len(mapResult.TsValueMap))
+	for _, k := range keys {
+		tsArr := mapResult.TsValueMap[k]
+		err := util.MegerPool.Submit(linearRegressionMergeTaskFuncWrapper(chs, k, tsArr, mapResult.LabelsMap, query,
+			&wg, expr, funcName, duration))
+		if err != nil {
+			return nil, err
+		}
+	}
+	wg.Wait()
+
+	seriesMap := make(map[string]static.Series)
+	for j := 0; j < len(mapResult.TsValueMap); j++ {
+		series := <-chs
+
+		for k, v := range series {
+			if len(v.Points) == 0 {
+				continue
+			}
+			seriesMap[k] = v
+		}
+	}
+
+	for _, k := range keys {
+		if _, exist := seriesMap[k]; exist {
+			mat = append(mat, seriesMap[k])
+		}
+	}
+
+	return mat, nil
+}
+
+// 各个labels下的合并逻辑
+func linearRegressionMergeTaskFuncWrapper(chs chan<- map[string]static.Series, k string, tsArr [][]gjson.Result,
+	labelsMap map[string][]*labels.Label, query *interfaces.Query, wg *sync.WaitGroup, expr *parser.MatrixSelector,
+	funcName string, duration float64) taskFunc {
+
+	return func() {
+		defer wg.Done()
+
+		pointMap := make(map[int64]*static.RegressionPoint)
+		regressionMergePointWithSameKey(pointMap, tsArr)
+
+		bucketKeys := make([]int64, 0, len(pointMap))
+		for ts := range pointMap {
+			bucketKeys = append(bucketKeys, ts)
+		}
+		sort.Slice(bucketKeys, func(i, j int) bool { return bucketKeys[i] < bucketKeys[j] })
+
+		finalPoints := make([]static.Point, 0)
+		selRangeTime := expr.Range.Milliseconds()
+		seriesMap := make(map[string]static.Series)
+
+		for ts := query.FixedStart; ts <= query.FixedEnd; ts = static.GetNextPointTime(*query, ts) {
+			// 对每个时间点做range处理，把 [ts, ts+range) 内的子时间桶的统计量相加
+			windowPoint := &static.RegressionPoint{}
+			for _, bucketKey := range bucketKeysInWindow(bucketKeys, ts, ts+selRangeTime) {
+				mergeRegressionPoint(pointMap[bucketKey], windowPoint)
+			}
+
+			// 预测的时间点是当前窗口的结束时间
+			v, ok := linearRegressionFunction(funcName, windowPoint, query.Start, ts+selRangeTime, duration)
+			if !ok {
+				continue
+			}
+			finalPoints = append(finalPoints, static.Point{T: ts, V: v})
+		}
+
+		seriesMap[k] = static.Series{
+			Metric: parseLabelsStr(k, labelsMap),
+			Points: finalPoints,
+		}
+
+		chs <- seriesMap
+	}
+}
+
+// 按时间窗合并k个数组（即k个分片）中的统计量
+func regressionMergePointWithSameKey(pointMap map[int64]*static.RegressionPoint, tsArr [][]gjson.Result) {
+	for _, tsArri := range tsArr {
+		for _, pointij := range tsArri {
+			currentT := pointij.Get("key").Int()
+			currentPoint := &static.RegressionPoint{
+				Count: pointij.Get("value.doc_count").Int(),
+				SumT:  pointij.Get("value.sum_t.value").Float(),
+				SumV:  pointij.Get("value.sum_v.value").Float(),
+				SumTT: pointij.Get("value.sum_tt.value").Float(),
+				SumTV: pointij.Get("value.sum_tv.value").Float(),
+			}
+
+			if existPoint, ok := pointMap[currentT]; ok {
+				mergeRegressionPoint(currentPoint, existPoint)
+			} else {
+				pointMap[currentT] = currentPoint
+			}
+		}
+	}
+}
+
+// 线性回归的统计量都是可加的，不同分片、不同子时间桶的统计量直接相加
+func mergeRegressionPoint(currentPoint *static.RegressionPoint, existPoint *static.RegressionPoint) {
+	existPoint.Count += currentPoint.Count
+	existPoint.SumT += currentPoint.SumT
+	existPoint.SumV += currentPoint.SumV
+	existPoint.SumTT += currentPoint.SumTT
+	existPoint.SumTV += currentPoint.SumTV
+}
+
+// 基于最小二乘法计算斜率和截距。统计量中的时间是相对 baseTime 的秒数。
+// deriv 返回斜率，predict_linear 返回 evalTime 之后 duration 秒的预测值。样本点少于2个时无结果。
+func linearRegressionFunction(funcName string, point *static.RegressionPoint, baseTime int64, evalTime int64,
+	duration float64) (float64, bool) {
+
+	if point.Count < 2 {
+		return 0, false
+	}
+
+	n := float64(point.Count)
+	covXY := point.SumTV - point.SumT*point.SumV/n
+	varX := point.SumTT - point.SumT*point.SumT/n
+	if varX == 0 {
+		// 所有样本点的时间相同，无法计算斜率
+		return 0, false
+	}
+	slope := covXY / varX
+	intercept := point.SumV/n - slope*point.SumT/n
+
+	switch funcName {
+	case interfaces.DERIV_AGG:
+		return slope, true
+	case interfaces.PREDICT_LINEAR_AGG:
+		evalT := float64(evalTime-baseTime) / 1000
+		return intercept + slope*(evalT+duration), true
+	}
+	return 0, false
+}
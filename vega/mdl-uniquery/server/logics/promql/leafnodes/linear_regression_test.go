@@ -0,0 +1,126 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package leafnodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/tidwall/gjson"
+
+	"uniquery/common"
+	"uniquery/interfaces"
+	"uniquery/logics/promql/labels"
+	"uniquery/logics/promql/parser"
+	"uniquery/logics/promql/static"
+	"uniquery/logics/promql/util"
+)
+
+// 构造样本点 (t, v) 的回归统计量，t 为相对基准时间的秒数
+func regressionBucket(key int64, samples [][2]float64) gjson.Result {
+	var n, st, sv, stt, stv float64
+	for _, s := range samples {
+		n++
+		st += s[0]
+		sv += s[1]
+		stt += s[0] * s[0]
+		stv += s[0] * s[1]
+	}
+	raw, _ := sonic.MarshalString(map[string]any{
+		"key": key,
+		"value": map[string]any{
+			"doc_count": n,
+			"sum_t":     map[string]any{"value": st},
+			"sum_v":     map[string]any{"value": sv},
+			"sum_tt":    map[string]any{"value": stt},
+			"sum_tv":    map[string]any{"value": stv},
+		},
+	})
+	return gjson.Result{Type: gjson.JSON, Raw: raw}
+}
+
+func TestLinearRegressionFunction(t *testing.T) {
+	Convey("test linearRegressionFunction", t, func() {
+
+		Convey("less than 2 samples", func() {
+			_, ok := linearRegressionFunction(interfaces.DERIV_AGG, &static.RegressionPoint{Count: 1, SumT: 1, SumV: 1}, 0, 0, 0)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("samples with same timestamp", func() {
+			_, ok := linearRegressionFunction(interfaces.DERIV_AGG,
+				&static.RegressionPoint{Count: 2, SumT: 2, SumV: 3, SumTT: 2, SumTV: 3}, 0, 0, 0)
+			So(ok, ShouldBeFalse)
+		})
+
+		// v = 2t + 1, t = 0, 10, 20
+		point := &static.RegressionPoint{Count: 3, SumT: 30, SumV: 63, SumTT: 500, SumTV: 1030}
+
+		Convey("deriv", func() {
+			v, ok := linearRegressionFunction(interfaces.DERIV_AGG, point, 0, 0, 0)
+			So(ok, ShouldBeTrue)
+			So(v, ShouldAlmostEqual, 2)
+		})
+
+		Convey("predict_linear", func() {
+			v, ok := linearRegressionFunction(interfaces.PREDICT_LINEAR_AGG, point, 1000, 31000, 60)
+			So(ok, ShouldBeTrue)
+			So(v, ShouldAlmostEqual, 181)
+		})
+	})
+}
+
+func TestLinearRegressionMerge(t *testing.T) {
+	Convey("test linearRegressionMerge", t, func() {
+		util.InitAntsPool(common.PoolSetting{
+			MegerPoolSize:       10,
+			ExecutePoolSize:     10,
+			BatchSubmitPoolSize: 10,
+		})
+
+		key := "cluster=\"txy\",name=\"node-1\""
+		labelsMap := map[string][]*labels.Label{
+			key: {{Name: interfaces.LABELS_STR, Value: key}},
+		}
+		// 两个分片，样本点满足 v = 2t + 1
+		tsValueMap := map[string][][]gjson.Result{
+			key: {
+				{
+					regressionBucket(0, [][2]float64{{0, 1}, {10, 21}}),
+					regressionBucket(30000, [][2]float64{{30, 61}}),
+				},
+				{
+					regressionBucket(0, [][2]float64{{20, 41}}),
+					regressionBucket(30000, [][2]float64{{40, 81}, {50, 101}}),
+				},
+			},
+		}
+		mapResult := MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}
+		expr := &parser.MatrixSelector{VectorSelector: &parser.VectorSelector{}, Range: time.Minute}
+
+		Convey("instant query", func() {
+			query := &interfaces.Query{IsInstantQuery: true, Start: 0, End: 60000, SubIntervalWith30min: 30000}
+			mat, err := linearRegressionMerge(mapResult, expr, query, interfaces.DERIV_AGG, 0)
+			So(err, ShouldBeNil)
+			So(len(mat), ShouldEqual, 1)
+			So(mat[0].Points[0].T, ShouldEqual, 60000)
+			So(mat[0].Points[0].V, ShouldAlmostEqual, 2)
+		})
+
+		Convey("range query", func() {
+			query := &interfaces.Query{Start: 0, Interval: 30000, SubIntervalWith30min: 30000, FixedStart: 0, FixedEnd: 30000}
+			mat, err := linearRegressionMerge(mapResult, expr, query, interfaces.PREDICT_LINEAR_AGG, 0)
+			So(err, ShouldBeNil)
+			So(len(mat), ShouldEqual, 1)
+			So(len(mat[0].Points), ShouldEqual, 2)
+			// 窗口 [0, 60s) 的结束时间预测值为 121, 窗口 [30s, 90s) 的结束时间预测值为 181
+			So(mat[0].Points[0].V, ShouldAlmostEqual, 121)
+			So(mat[0].Points[1].V, ShouldAlmostEqual, 181)
+		})
+	})
+}
@@ -0,0 +1,472 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package leafnodes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bytedance/sonic"
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	uerrors "uniquery/errors"
+	"uniquery/interfaces"
+	"uniquery/logics/promql/labels"
+	"uniquery/logics/promql/parser"
+	"uniquery/logics/promql/static"
+	"uniquery/logics/promql/util"
+)
+
+// quantile_over_time、holt_winters、resets 算子的计算结果依赖 range 内的全部样本点，无法下推到 opensearch 的聚合中。
+// 按子步长分桶，每个桶用 top_hits 取出原始样本点，被截断的桶再分页补齐，合并后在内存中计算。
+// params 是函数的标量参数：quantile_over_time 为 [q]，holt_winters 为 [sf, tf]，resets 为空。
+func (ln *LeafNodes) RawSamplesEval(ctx context.Context, expr *parser.MatrixSelector, query *interfaces.Query,
+	funcName string, params []float64) (parser.Value, int, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Eval 叶子节点 RawSamples")
+	defer span.End()
+
+	if err := checkRawSamplesParams(funcName, params); err != nil {
+		span.SetStatus(codes.Error, "Invalid function params")
+		return nil, http.StatusBadRequest, uerrors.PromQLError{
+			Typ: uerrors.ErrorBadData,
+			Err: err,
+		}
+	}
+
+	vs, newQuery, status, err := processParam(expr, query)
+	if err != nil {
+		return nil, status, err
+	}
+
+	span.SetAttributes(attribute.Key("function").String(funcName),
+		attribute.Key("start").Int64(newQuery.Start),
+		attribute.Key("subInterval30min").Int64(newQuery.SubIntervalWith30min),
+		attribute.Key("subInterval2h").Int64(newQuery.SubIntervalWith2h),
+	)
+
+	// 通用处理： 获取日志分组的索引信息 -> 构造 dsl -> 获取索引库下的所有索引以及对应的分片数 -> 执行 dsl
+	result, status, err := ln.commonProcess(ctx, vs, newQuery, interfaces.RAW_SAMPLES_AGG)
+	if err != nil {
+		// 记录异常的日志
+		o11y.Error(ctx, fmt.Sprintf("Common Process Error: %v", err))
+
+		return nil, status, err
+	}
+	matrixResult, ok := result.(static.Matrix)
+	if ok {
+		return matrixResult, status, err
+	}
+
+	// 合并结果
+	mergeCtx, mergeSpan := ar_trace.Tracer.Start(ctx, "RawSamples merge")
+	defer mergeSpan.End()
+	mat, err := rawSamplesMerge(result.(MapResult), expr, newQuery, funcName, params)
+	if err != nil {
+		// 记录异常的日志
+		o11y.Error(mergeCtx, fmt.Sprintf("RawSamples merge Error: %v", err))
+		span.SetStatus(codes.Error, "RawSamples merge Error")
+
+		return nil, http.StatusUnprocessableEntity, uerrors.PromQLError{
+			Typ: uerrors.ErrorExec,
+			Err: errors.New(err.Error()),
+		}
+	}
+	mergeSpan.SetStatus(codes.Ok, "")
+
+	if query.IfNeedAllSeries {
+		return mat, http.StatusOK, nil
+	}
+	span.SetStatus(codes.Ok, "")
+	return static.PageMatrix{Matrix: mat, TotalSeries: result.(MapResult).TotalSeries}, http.StatusOK, nil
+}
+
+// 校验函数的标量参数
+func checkRawSamplesParams(funcName string, params []float64) error {
+	switch funcName {
+	case interfaces.QUANTILE_OVER_TIME:
+		if len(params) != 1 {
+			return fmt.Errorf("quantile_over_time expects 1 scalar parameter, got %d", len(params))
+		}
+	case interfaces.HOLT_WINTERS_AGG:
+		if len(params) != 2 {
+			return fmt.Errorf("holt_winters expects 2 scalar parameters, got %d", len(params))
+		}
+		// 平滑因子和趋势因子的取值范围都是(0, 1)
+		if params[0] <= 0 || params[0] >= 1 {
+			return fmt.Errorf("invalid smoothing factor. Expected: 0 < sf < 1, got: %v", params[0])
+		}
+		if params[1] <= 0 || params[1] >= 1 {
+			return fmt.Errorf("invalid trend factor. Expected: 0 < tf < 1, got: %v", params[1])
+		}
+	case interfaces.RESETS_AGG:
+	default:
+		return fmt.Errorf("unsupported raw samples function %s", funcName)
+	}
+	return nil
+}
+
+// instant query 串行合并, range query 起协程同时合并
+func rawSamplesMerge(mapResult MapResult, expr *parser.MatrixSelector, query *interfaces.Query,
+	funcName string, params []float64) (static.Matrix, error) {
+
+	keys := make([]string, 0, len(mapResult.LabelsMap))
+	for key := range mapResult.LabelsMap {
+		keys = append(keys, key)
+	}
+	// 对 keys排序，从小到大
+	sort.Strings(keys)
+
+	if query.IsInstantQuery {
+		return rawSamplesMerge4InstantQuery(keys, mapResult, query, funcName, params)
+	}
+	return rawSamplesMerge4RangeQuery(keys, mapResult, query, expr, funcName, params)
+}
+
+// instant query 串行合并
+func rawSamplesMerge4InstantQuery(keys []string, mapResult MapResult, query *interfaces.Query,
+	funcName string, params []float64) (static.Matrix, error) {
+
+	mat := make(static.Matrix, 0, len(keys))
+	for _, k := range keys {
+		pointMap, err := rawSamplesMergePointWithSameKey(mapResult.TsValueMap[k])
+		if err != nil {
+			return nil, err
+		}
+
+		bucketKeys := make([]int64, 0, len(pointMap))
+		for ts := range pointMap {
+			bucketKeys = append(bucketKeys, ts)
+		}
+		sort.Slice(bucketKeys, func(i, j int) bool { return bucketKeys[i] < bucketKeys[j] })
+
+		samples := make([]static.Point, 0)
+		for _, ts := range bucketKeys {
+			samples = append(samples, pointMap[ts]...)
+		}
+
+		v, ok := rawSamplesFunction(funcName, samples, params)
+		if !ok {
+			continue
+		}
+		mat = append(mat, static.Series{
+			Metric: parseLabelsStr(k, mapResult.LabelsMap),
+			Points: []static.Point{{T: query.End, V: v}},
+		})
+	}
+
+	return mat, nil
+}
+
+// 区间查询, 每个__labels_str下的聚合结果各自用一个goroutine去合并
+func rawSamplesMerge4RangeQuery(keys []string, mapResult MapResult, query *interfaces.Query,
+	expr *parser.MatrixSelector, funcName string, params []float64) (static.Matrix, error) {
+
+	// 先校验样本点是否被截断，截断时计算结果不准确，直接报错
+	pointMaps := make(map[string]map[int64][]static.Point, len(keys))
+	for _, k := range keys {
+		pointMap, err := rawSamplesMergePointWithSameKey(mapResult.TsValueMap[k])
+		if err != nil {
+			return nil, err
+		}
+		pointMaps[k] = pointMap
+	}
+
+	mat := make(static.Matrix, 0)
+	chs := make(chan map[string]static.Series, len(keys))
+	defer close(chs)
+	var wg sync.WaitGroup
+	wg.Add(len(keys))
+	for _, k := range keys {
+		err := util.MegerPool.Submit(rawSamplesMergeTaskFuncWrapper(chs, k, pointMaps[k], mapResult.LabelsMap, query,
+			&wg, expr, funcName, params))
+		if err != nil {
+			return nil, err
+		}
+	}
+	wg.Wait()
+
+	seriesMap := make(map[string]static.Series)
+	for j := 0; j < len(keys); j++ {
+		series := <-chs
+
+		for k, v := range series {
+			if len(v.Points) == 0 {
+				continue
+			}
+			seriesMap[k] = v
+		}
+	}
+
+	for _, k := range keys {
+		if _, exist := seriesMap[k]; exist {
+			mat = append(mat, seriesMap[k])
+		}
+	}
+
+	return mat, nil
+}
+
+// 各个labels下的合并逻辑
+func rawSamplesMergeTaskFuncWrapper(chs chan<- map[string]static.Series, k string, pointMap map[int64][]static.Point,
+	labelsMap map[string][]*labels.Label, query *interfaces.Query, wg *sync.WaitGroup, expr *parser.MatrixSelector,
+	funcName string, params []float64) taskFunc {
+
+	return func() {
+		defer wg.Done()
+
+		bucketKeys := make([]int64, 0, len(pointMap))
+		for ts := range pointMap {
+			bucketKeys = append(bucketKeys, ts)
+		}
+		sort.Slice(bucketKeys, func(i, j int) bool { return bucketKeys[i] < bucketKeys[j] })
+
+		finalPoints := make([]static.Point, 0)
+		selRangeTime := expr.Range.Milliseconds()
+		seriesMap := make(map[string]static.Series)
+
+		for ts := query.FixedStart; ts <= query.FixedEnd; ts = static.GetNextPointTime(*query, ts) {
+			// 对每个时间点做range处理，按时间顺序拼接 [ts, ts+range) 内的子时间桶的样本点
+			samples := make([]static.Point, 0)
+			for _, bucketKey := range bucketKeysInWindow(bucketKeys, ts, ts+selRangeTime) {
+				samples = append(samples, pointMap[bucketKey]...)
+			}
+
+			v, ok := rawSamplesFunction(funcName, samples, params)
+			if !ok {
+				continue
+			}
+			finalPoints = append(finalPoints, static.Point{T: ts, V: v})
+		}
+
+		seriesMap[k] = static.Series{
+			Metric: parseLabelsStr(k, labelsMap),
+			Points: finalPoints,
+		}
+
+		chs <- seriesMap
+	}
+}
+
+// top_hits 在单个子时间桶内最多返回 MAX_RAW_SAMPLES_PER_BUCKET 个样本点(opensearch 的 index.max_inner_result_window)。
+// 对被截断的子时间桶，在同一分片上按 @timestamp 用 search_after 分页取回桶内的全部样本点，替换聚合结果中的桶。
+// 不是原始样本点的聚合时直接返回。
+func (leafNodes *LeafNodes) pageRawSamples(ctx context.Context, dsl string, index []string, shardId int,
+	groupBy []string, mapResult *MapResult) error {
+
+	aggPath := "aggs." + strings.Join(groupBy, ".aggs.") + ".aggs.time"
+	metricField := gjson.Get(dsl, aggPath+".aggs.value.top_hits.docvalue_fields.0").String()
+	if metricField == "" {
+		return nil
+	}
+	interval, err := strconv.ParseInt(strings.TrimSuffix(
+		gjson.Get(dsl, aggPath+".date_histogram.fixed_interval").String(), "ms"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid raw samples interval: %v", err)
+	}
+	query := gjson.Get(dsl, "query").Value()
+
+	for key, tsArr := range mapResult.TsValueMap {
+		for i := range tsArr {
+			for j, bucket := range tsArr[i] {
+				docCount := bucket.Get("doc_count").Int()
+				if docCount <= int64(len(bucket.Get("value.hits.hits").Array())) {
+					continue
+				}
+
+				// 用分组字段的值和子时间桶的时间范围限定到一个序列的一个桶
+				filters := []any{query}
+				for _, lb := range mapResult.LabelsMap[key] {
+					filters = append(filters, map[string]any{
+						"term": map[string]any{wrapKeyWordFieldName(lb.Name): lb.Value},
+					})
+				}
+				bucketKey := bucket.Get("key").Int()
+				filters = append(filters, map[string]any{
+					"range": map[string]any{
+						"@timestamp": map[string]any{"gte": bucketKey, "lt": bucketKey + interval},
+					},
+				})
+
+				hits, err := leafNodes.searchRawSamples(ctx, filters, metricField, index, shardId)
+				if err != nil {
+					return err
+				}
+				tsArr[i][j] = gjson.Parse(fmt.Sprintf(`{"key":%d,"doc_count":%d,"value":{"hits":{"hits":[%s]}}}`,
+					bucketKey, len(hits), strings.Join(hits, ",")))
+			}
+		}
+	}
+	return nil
+}
+
+// 在指定分片上按 @timestamp 升序分页查询原始样本点，返回各个 hit 的原始 json
+func (leafNodes *LeafNodes) searchRawSamples(ctx context.Context, filters []any, metricField string,
+	index []string, shardId int) ([]string, error) {
+
+	hits := make([]string, 0, interfaces.MAX_RAW_SAMPLES_PER_BUCKET)
+	var searchAfter any
+	for {
+		body := map[string]any{
+			"size":            interfaces.RAW_SAMPLES_PAGE_SIZE,
+			"query":           map[string]any{"bool": map[string]any{"filter": filters}},
+			"sort":            []any{map[string]any{"@timestamp": "asc"}, map[string]any{"_doc": "asc"}},
+			"_source":         false,
+			"docvalue_fields": []string{metricField},
+		}
+		if searchAfter != nil {
+			body["search_after"] = searchAfter
+		}
+		dsl, err := sonic.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		res, _, err := leafNodes.dvService.GetDataFromOpenSearchWithBuffer(ctx, *bytes.NewBuffer(dsl), index,
+			0, "_shards:"+strconv.Itoa(shardId))
+		if err != nil {
+			return nil, err
+		}
+
+		page := gjson.GetBytes(res, "hits.hits").Array()
+		for _, hit := range page {
+			hits = append(hits, hit.Raw)
+		}
+		if len(page) < interfaces.RAW_SAMPLES_PAGE_SIZE {
+			return hits, nil
+		}
+		searchAfter = page[len(page)-1].Get("sort").Value()
+	}
+}
+
+// 按时间窗合并k个数组（即k个分片）中的原始样本点，每个子时间桶内的样本点按时间升序、去重。
+// 若子时间桶内的样本点不完整(分页补齐失败)，返回错误。
+func rawSamplesMergePointWithSameKey(tsArr [][]gjson.Result) (map[int64][]static.Point, error) {
+	pointMap := make(map[int64][]static.Point)
+	for _, tsArri := range tsArr {
+		for _, pointij := range tsArri {
+			currentT := pointij.Get("key").Int()
+			hits := pointij.Get("value.hits.hits").Array()
+			if pointij.Get("doc_count").Int() > int64(len(hits)) {
+				return nil, fmt.Errorf("incomplete samples in sub interval %d, expected %d, got %d",
+					currentT, pointij.Get("doc_count").Int(), len(hits))
+			}
+
+			for _, hit := range hits {
+				sampleT := hit.Get("sort.0").Int()
+				var value float64
+				// docvalue_fields 只请求了指标字段，fields 中仅有一个 key
+				hit.Get("fields").ForEach(func(_, v gjson.Result) bool {
+					value = v.Get("0").Float()
+					return false
+				})
+				pointMap[currentT] = append(pointMap[currentT], static.Point{T: sampleT, V: value})
+			}
+		}
+	}
+
+	for ts, points := range pointMap {
+		sort.Slice(points, func(i, j int) bool {
+			if points[i].T == points[j].T {
+				return points[i].V < points[j].V
+			}
+			return points[i].T < points[j].T
+		})
+		// 不同分片上时间戳相同的样本点，取值较大者
+		deduped := points[:0]
+		for _, p := range points {
+			if len(deduped) > 0 && deduped[len(deduped)-1].T == p.T {
+				deduped[len(deduped)-1] = p
+				continue
+			}
+			deduped = append(deduped, p)
+		}
+		pointMap[ts] = deduped
+	}
+
+	return pointMap, nil
+}
+
+// 基于按时间升序的样本点计算函数值，没有结果时返回 false
+func rawSamplesFunction(funcName string, samples []static.Point, params []float64) (float64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	switch funcName {
+	case interfaces.QUANTILE_OVER_TIME:
+		return quantileOverTime(params[0], samples), true
+	case interfaces.HOLT_WINTERS_AGG:
+		return holtWinters(samples, params[0], params[1])
+	case interfaces.RESETS_AGG:
+		resets := 0
+		for i := 1; i < len(samples); i++ {
+			if samples[i].V < samples[i-1].V {
+				resets++
+			}
+		}
+		return float64(resets), true
+	}
+	return 0, false
+}
+
+// 计算样本值的 φ-分位数，与 prometheus 的 quantile_over_time 一致：在相邻的两个样本值之间线性插值。
+func quantileOverTime(q float64, samples []static.Point) float64 {
+	if q < 0 {
+		return math.Inf(-1)
+	}
+	if q > 1 {
+		return math.Inf(+1)
+	}
+
+	values := make([]float64, 0, len(samples))
+	for _, p := range samples {
+		values = append(values, p.V)
+	}
+	sort.Float64s(values)
+
+	n := float64(len(values))
+	rank := q * (n - 1)
+	lowerIndex := math.Max(0, math.Floor(rank))
+	upperIndex := math.Min(n-1, lowerIndex+1)
+	weight := rank - math.Floor(rank)
+	return values[int(lowerIndex)]*(1-weight) + values[int(upperIndex)]*weight
+}
+
+// 双指数平滑，与 prometheus 的 holt_winters 一致。sf 为平滑因子，tf 为趋势因子。样本点少于2个时无结果。
+func holtWinters(samples []static.Point, sf, tf float64) (float64, bool) {
+	l := len(samples)
+	if l < 2 {
+		return 0, false
+	}
+
+	var s0, s1, b float64
+	// 用第一个样本值作为初始的平滑值，前两个样本的差值作为初始的趋势
+	s1 = samples[0].V
+	b = samples[1].V - samples[0].V
+
+	for i := 1; i < l; i++ {
+		// 基于上一次的平滑值和趋势计算本次的趋势
+		if i > 1 {
+			b = tf*(s1-s0) + (1-tf)*b
+		}
+		s0, s1 = s1, sf*samples[i].V+(1-sf)*(s1+b)
+	}
+
+	return s1, true
+}
@@ -0,0 +1,273 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package leafnodes
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/tidwall/gjson"
+
+	"uniquery/common"
+	"uniquery/interfaces"
+	umock "uniquery/interfaces/mock"
+	"uniquery/logics/promql/labels"
+	"uniquery/logics/promql/parser"
+	"uniquery/logics/promql/static"
+	"uniquery/logics/promql/util"
+)
+
+func rawSamplesBucket(raw string) gjson.Result {
+	return gjson.Result{Type: gjson.JSON, Raw: raw}
+}
+
+func TestCheckRawSamplesParams(t *testing.T) {
+	Convey("test checkRawSamplesParams", t, func() {
+		So(checkRawSamplesParams(interfaces.QUANTILE_OVER_TIME, []float64{0.9}), ShouldBeNil)
+		So(checkRawSamplesParams(interfaces.QUANTILE_OVER_TIME, []float64{}), ShouldNotBeNil)
+		So(checkRawSamplesParams(interfaces.HOLT_WINTERS_AGG, []float64{0.5, 0.5}), ShouldBeNil)
+		So(checkRawSamplesParams(interfaces.HOLT_WINTERS_AGG, []float64{1, 0.5}), ShouldNotBeNil)
+		So(checkRawSamplesParams(interfaces.HOLT_WINTERS_AGG, []float64{0.5, 0}), ShouldNotBeNil)
+		So(checkRawSamplesParams(interfaces.RESETS_AGG, nil), ShouldBeNil)
+		So(checkRawSamplesParams("unknown", nil), ShouldNotBeNil)
+	})
+}
+
+func TestRawSamplesMergePointWithSameKey(t *testing.T) {
+	Convey("test rawSamplesMergePointWithSameKey", t, func() {
+
+		Convey("merge samples of different shards", func() {
+			tsArr := [][]gjson.Result{
+				{
+					rawSamplesBucket(`{"key":1000,"doc_count":2,"value":{"hits":{"hits":[
+						{"sort":[1000],"fields":{"metrics.a":[1]}},
+						{"sort":[3000],"fields":{"metrics.a":[3]}}]}}}`),
+				},
+				{
+					rawSamplesBucket(`{"key":1000,"doc_count":2,"value":{"hits":{"hits":[
+						{"sort":[2000],"fields":{"metrics.a":[2]}},
+						{"sort":[3000],"fields":{"metrics.a":[4]}}]}}}`),
+				},
+			}
+			pointMap, err := rawSamplesMergePointWithSameKey(tsArr)
+			So(err, ShouldBeNil)
+			So(pointMap[1000], ShouldResemble, []static.Point{{T: 1000, V: 1}, {T: 2000, V: 2}, {T: 3000, V: 4}})
+		})
+
+		Convey("samples are truncated", func() {
+			tsArr := [][]gjson.Result{
+				{
+					rawSamplesBucket(`{"key":1000,"doc_count":200,"value":{"hits":{"hits":[
+						{"sort":[1000],"fields":{"metrics.a":[1]}}]}}}`),
+				},
+			}
+			pointMap, err := rawSamplesMergePointWithSameKey(tsArr)
+			So(pointMap, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestRawSamplesFunction(t *testing.T) {
+	Convey("test rawSamplesFunction", t, func() {
+		samples := []static.Point{{T: 1, V: 1}, {T: 2, V: 4}, {T: 3, V: 2}, {T: 4, V: 3}, {T: 5, V: 0}}
+
+		Convey("empty samples", func() {
+			_, ok := rawSamplesFunction(interfaces.RESETS_AGG, []static.Point{}, nil)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("quantile_over_time", func() {
+			v, ok := rawSamplesFunction(interfaces.QUANTILE_OVER_TIME, samples, []float64{0.5})
+			So(ok, ShouldBeTrue)
+			So(v, ShouldEqual, 2)
+
+			v, _ = rawSamplesFunction(interfaces.QUANTILE_OVER_TIME, samples, []float64{0.9})
+			So(v, ShouldAlmostEqual, 3.6)
+
+			v, _ = rawSamplesFunction(interfaces.QUANTILE_OVER_TIME, samples, []float64{1.5})
+			So(math.IsInf(v, 1), ShouldBeTrue)
+		})
+
+		Convey("resets", func() {
+			v, ok := rawSamplesFunction(interfaces.RESETS_AGG, samples, nil)
+			So(ok, ShouldBeTrue)
+			So(v, ShouldEqual, 2)
+		})
+
+		Convey("holt_winters", func() {
+			_, ok := rawSamplesFunction(interfaces.HOLT_WINTERS_AGG, samples[:1], []float64{0.5, 0.5})
+			So(ok, ShouldBeFalse)
+
+			// 线性增长的序列，平滑后的值即最后一个样本值
+			linear := []static.Point{{T: 1, V: 1}, {T: 2, V: 2}, {T: 3, V: 3}, {T: 4, V: 4}}
+			v, ok := rawSamplesFunction(interfaces.HOLT_WINTERS_AGG, linear, []float64{0.5, 0.5})
+			So(ok, ShouldBeTrue)
+			So(v, ShouldAlmostEqual, 4)
+		})
+	})
+}
+
+func TestRawSamplesMerge(t *testing.T) {
+	Convey("test rawSamplesMerge", t, func() {
+		util.InitAntsPool(common.PoolSetting{
+			MegerPoolSize:       10,
+			ExecutePoolSize:     10,
+			BatchSubmitPoolSize: 10,
+		})
+
+		key := "cluster=\"txy\",name=\"node-1\""
+		labelsMap := map[string][]*labels.Label{
+			key: {{Name: interfaces.LABELS_STR, Value: key}},
+		}
+		tsValueMap := map[string][][]gjson.Result{
+			key: {
+				{
+					rawSamplesBucket(`{"key":0,"doc_count":2,"value":{"hits":{"hits":[
+						{"sort":[1000],"fields":{"metrics.a":[5]}},
+						{"sort":[20000],"fields":{"metrics.a":[1]}}]}}}`),
+					rawSamplesBucket(`{"key":30000,"doc_count":2,"value":{"hits":{"hits":[
+						{"sort":[31000],"fields":{"metrics.a":[2]}},
+						{"sort":[50000],"fields":{"metrics.a":[0]}}]}}}`),
+				},
+			},
+		}
+		mapResult := MapResult{LabelsMap: labelsMap, TsValueMap: tsValueMap}
+		expr := &parser.MatrixSelector{VectorSelector: &parser.VectorSelector{}, Range: time.Minute}
+
+		Convey("instant query", func() {
+			query := &interfaces.Query{IsInstantQuery: true, End: 60000, SubIntervalWith30min: 30000}
+			mat, err := rawSamplesMerge(mapResult, expr, query, interfaces.RESETS_AGG, nil)
+			So(err, ShouldBeNil)
+			So(len(mat), ShouldEqual, 1)
+			So(mat[0].Points, ShouldResemble, []static.Point{{T: 60000, V: 2}})
+		})
+
+		Convey("range query", func() {
+			query := &interfaces.Query{Interval: 30000, SubIntervalWith30min: 30000, FixedStart: 0, FixedEnd: 30000}
+			mat, err := rawSamplesMerge(mapResult, expr, query, interfaces.RESETS_AGG, nil)
+			So(err, ShouldBeNil)
+			So(len(mat), ShouldEqual, 1)
+			So(mat[0].Points, ShouldResemble, []static.Point{{T: 0, V: 2}, {T: 30000, V: 1}})
+		})
+	})
+}
+
+// 生成 n 个间隔 15s 的样本点的 hits，样本值依次递增
+func rawSamplesHits(start int64, from, to int) string {
+	hits := make([]string, 0, to-from)
+	for i := from; i < to; i++ {
+		hits = append(hits, fmt.Sprintf(`{"sort":[%d,%d],"fields":{"metrics.a":[%d]}}`, start+int64(i)*15000, i, i))
+	}
+	return strings.Join(hits, ",")
+}
+
+func TestPageRawSamples(t *testing.T) {
+	Convey("test pageRawSamples", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		osaMock := umock.NewMockOpenSearchAccess(mockCtrl)
+		lgaMock := umock.NewMockLogGroupAccess(mockCtrl)
+		dvsMock := umock.NewMockDataViewService(mockCtrl)
+		lnMock := mockLeafNodes(osaMock, lgaMock, dvsMock)
+
+		expr := parser.VectorSelector{Name: "a", LabelMatchers: []*labels.Matcher{
+			{Type: labels.MatchEqual, Name: "__name__", Value: "a"},
+		}}
+		query := &interfaces.Query{
+			Start:                0,
+			End:                  (30 * time.Minute).Milliseconds(),
+			Interval:             (30 * time.Minute).Milliseconds(),
+			SubIntervalWith30min: (30 * time.Minute).Milliseconds(),
+			SubIntervalWith2h:    (30 * time.Minute).Milliseconds(),
+			IsInstantQuery:       true,
+			MaxSearchSeriesSize:  10,
+		}
+		groupBy := []string{interfaces.LABELS_STR}
+		dsl, _, err := makeDSL(expr, query, groupBy, interfaces.RAW_SAMPLES_AGG, []any{}, false)
+		So(err, ShouldBeNil)
+
+		key := "job=\"node\""
+		newMapResult := func(docCount int) *MapResult {
+			return &MapResult{
+				LabelsMap: map[string][]*labels.Label{key: {{Name: interfaces.LABELS_STR, Value: key}}},
+				TsValueMap: map[string][][]gjson.Result{key: {{
+					gjson.Parse(fmt.Sprintf(`{"key":0,"doc_count":%d,"value":{"hits":{"hits":[%s]}}}`,
+						docCount, rawSamplesHits(0, 0, interfaces.MAX_RAW_SAMPLES_PER_BUCKET))),
+				}}},
+			}
+		}
+
+		Convey("not raw samples dsl", func() {
+			dsl, _, err := makeDSL(expr, query, groupBy, interfaces.AVG_OVER_TIME, []any{}, false)
+			So(err, ShouldBeNil)
+			mapResult := newMapResult(200)
+			So(lnMock.pageRawSamples(testCtx, dsl.String(), []string{"a"}, 0, groupBy, mapResult), ShouldBeNil)
+		})
+
+		Convey("instant query [30m] with 120 samples at 15s", func() {
+			dvsMock.EXPECT().GetDataFromOpenSearchWithBuffer(gomock.Any(), gomock.Any(), []string{"a"}, gomock.Any(), "_shards:1").
+				DoAndReturn(func(_ any, body bytes.Buffer, _ []string, _ any, _ string) ([]byte, int, error) {
+					So(gjson.Get(body.String(), "search_after").Exists(), ShouldBeFalse)
+					So(body.String(), ShouldContainSubstring, `"lt":1800000`)
+					So(body.String(), ShouldContainSubstring, `"__labels_str.keyword":"job=\"node\""`)
+					return []byte(fmt.Sprintf(`{"hits":{"hits":[%s]}}`, rawSamplesHits(0, 0, 120))), 200, nil
+				})
+
+			mapResult := newMapResult(120)
+			So(lnMock.pageRawSamples(testCtx, dsl.String(), []string{"a"}, 1, groupBy, mapResult), ShouldBeNil)
+
+			pointMap, err := rawSamplesMergePointWithSameKey(mapResult.TsValueMap[key])
+			So(err, ShouldBeNil)
+			So(len(pointMap[0]), ShouldEqual, 120)
+
+			v, ok := rawSamplesFunction(interfaces.QUANTILE_OVER_TIME, pointMap[0], []float64{1})
+			So(ok, ShouldBeTrue)
+			So(v, ShouldEqual, 119)
+		})
+
+		Convey("samples are paged with search_after", func() {
+			total := interfaces.RAW_SAMPLES_PAGE_SIZE + 500
+			gomock.InOrder(
+				dvsMock.EXPECT().GetDataFromOpenSearchWithBuffer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]byte(fmt.Sprintf(`{"hits":{"hits":[%s]}}`,
+						rawSamplesHits(0, 0, interfaces.RAW_SAMPLES_PAGE_SIZE))), 200, nil),
+				dvsMock.EXPECT().GetDataFromOpenSearchWithBuffer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, body bytes.Buffer, _ []string, _ any, _ string) ([]byte, int, error) {
+						last := interfaces.RAW_SAMPLES_PAGE_SIZE - 1
+						So(gjson.Get(body.String(), "search_after").Raw, ShouldEqual, fmt.Sprintf("[%d,%d]", int64(last)*15000, last))
+						return []byte(fmt.Sprintf(`{"hits":{"hits":[%s]}}`,
+							rawSamplesHits(0, interfaces.RAW_SAMPLES_PAGE_SIZE, total))), 200, nil
+					}),
+			)
+
+			mapResult := newMapResult(total)
+			So(lnMock.pageRawSamples(testCtx, dsl.String(), []string{"a"}, 0, groupBy, mapResult), ShouldBeNil)
+
+			pointMap, err := rawSamplesMergePointWithSameKey(mapResult.TsValueMap[key])
+			So(err, ShouldBeNil)
+			So(len(pointMap[0]), ShouldEqual, total)
+
+			v, _ := rawSamplesFunction(interfaces.RESETS_AGG, pointMap[0], nil)
+			So(v, ShouldEqual, 0)
+		})
+
+		Convey("search failed", func() {
+			dvsMock.EXPECT().GetDataFromOpenSearchWithBuffer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, 500, fmt.Errorf("search failed"))
+
+			mapResult := newMapResult(120)
+			So(lnMock.pageRawSamples(testCtx, dsl.String(), []string{"a"}, 0, groupBy, mapResult), ShouldNotBeNil)
+		})
+	})
+}
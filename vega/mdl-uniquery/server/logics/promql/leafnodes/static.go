@@ -13,6 +13,7 @@ import (
 	"math"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -194,14 +195,16 @@ func makeBatchTsidDSL(expr parser.VectorSelector, query *interfaces.Query, group
 	var interval int64
 	switch aggregationType {
 	case interfaces.IRATE_AGG, interfaces.RATE_AGG, interfaces.CHANGES_AGG, interfaces.AVG_OVER_TIME, interfaces.SUM_OVER_TIME,
-		interfaces.MAX_OVER_TIME, interfaces.MIN_OVER_TIME, interfaces.COUNT_OVER_TIME:
+		interfaces.MAX_OVER_TIME, interfaces.MIN_OVER_TIME, interfaces.COUNT_OVER_TIME, interfaces.IDELTA_AGG,
+		interfaces.STDDEV_OVER_TIME, interfaces.STDVAR_OVER_TIME, interfaces.LAST_OVER_TIME,
+		interfaces.LINEAR_REGRESSION_AGG, interfaces.RAW_SAMPLES_AGG:
 		interval = query.SubIntervalWith2h // tsid按2h路由的子步长来查询
 	default:
 		interval = query.Interval
 	}
 
 	// 构造aggregation
-	status, err := makeAggregation(groupBy, aggregationType, expr.Name, interval, query.MaxSearchSeriesSize,
+	status, err := makeAggregation(groupBy, aggregationType, expr.Name, interval, query.Start, query.MaxSearchSeriesSize,
 		&queryBuffer)
 	if err != nil {
 		return nil, status, err
@@ -288,7 +291,9 @@ func makeDSL(expr parser.VectorSelector, query *interfaces.Query,
 	var interval int64
 	switch aggregationType {
 	case interfaces.IRATE_AGG, interfaces.RATE_AGG, interfaces.CHANGES_AGG, interfaces.AVG_OVER_TIME, interfaces.SUM_OVER_TIME,
-		interfaces.MAX_OVER_TIME, interfaces.MIN_OVER_TIME, interfaces.COUNT_OVER_TIME:
+		interfaces.MAX_OVER_TIME, interfaces.MIN_OVER_TIME, interfaces.COUNT_OVER_TIME, interfaces.IDELTA_AGG,
+		interfaces.STDDEV_OVER_TIME, interfaces.STDVAR_OVER_TIME, interfaces.LAST_OVER_TIME,
+		interfaces.LINEAR_REGRESSION_AGG, interfaces.RAW_SAMPLES_AGG:
 		interval = query.SubIntervalWith30min
 		if isTsid {
 			interval = query.SubIntervalWith2h
@@ -298,7 +303,7 @@ func makeDSL(expr parser.VectorSelector, query *interfaces.Query,
 	}
 
 	// 构造aggregation
-	status, err = makeAggregation(groupBy, aggregationType, metricName, interval,
+	status, err = makeAggregation(groupBy, aggregationType, metricName, interval, query.Start,
 		query.MaxSearchSeriesSize, &queryBuffer)
 	if err != nil {
 		return nil, status, err
@@ -381,9 +386,10 @@ func makeDSLQuery(expr parser.VectorSelector) (string, string) {
 	return queryStr, metricName
 }
 
-// 构造 dsl 的 aggs 部分，返回聚合查询的字符串
+// 构造 dsl 的 aggs 部分，返回聚合查询的字符串。
+// baseTime 是线性回归计算时间偏移量的基准时间，避免时间戳平方后丢失精度，其他聚合不使用。
 func makeAggregation(groupFields []string, aggregationType string, metricName string,
-	interval int64, maxSearchSeriesSize int, queryStr *bytes.Buffer) (int, error) {
+	interval int64, baseTime int64, maxSearchSeriesSize int, queryStr *bytes.Buffer) (int, error) {
 
 	var braceStr string
 	// 先写 terms aggregation 部分
@@ -408,7 +414,7 @@ func makeAggregation(groupFields []string, aggregationType string, metricName st
 	var valueAgg string
 	metricFiled := wrapMetricsFieldName(metricName)
 	switch aggregationType {
-	case interfaces.SAMPLING_AGG:
+	case interfaces.SAMPLING_AGG, interfaces.LAST_OVER_TIME:
 		valueAgg = fmt.Sprintf(`
 							"sampling": {
 								"value": {
@@ -418,7 +424,7 @@ func makeAggregation(groupFields []string, aggregationType string, metricName st
 									"field": "@timestamp"
 								}
 							}`, metricFiled)
-	case interfaces.IRATE_AGG:
+	case interfaces.IRATE_AGG, interfaces.IDELTA_AGG:
 		valueAgg = fmt.Sprintf(`
 						"irate_sampling": {
 								"value": {
@@ -480,6 +486,64 @@ func makeAggregation(groupFields []string, aggregationType string, metricName st
 									"field": "@timestamp"
 								}
 							}`, metricFiled)
+	case interfaces.STDDEV_OVER_TIME, interfaces.STDVAR_OVER_TIME:
+		valueAgg = fmt.Sprintf(`
+							"extended_stats": {
+								"field": "%s"
+							}`, metricFiled)
+	case interfaces.LINEAR_REGRESSION_AGG:
+		// 时间换算成相对 baseTime 的秒数，各个子时间桶的统计量可以直接相加
+		timeScript := "(doc['@timestamp'].value.toInstant().toEpochMilli() - params.base) / 1000.0"
+		valueScript := fmt.Sprintf("doc['%s'].value", metricFiled)
+		valueAgg = fmt.Sprintf(`
+							"filter": {
+								"exists": {
+									"field": "%s"
+								}
+							},
+							"aggs": {
+								"sum_t": {
+									"sum": {
+										"script": {
+											"source": "%s",
+											"params": { "base": %d }
+										}
+									}
+								},
+								"sum_v": {
+									"sum": {
+										"field": "%s"
+									}
+								},
+								"sum_tt": {
+									"sum": {
+										"script": {
+											"source": "double t = %s; return t * t;",
+											"params": { "base": %d }
+										}
+									}
+								},
+								"sum_tv": {
+									"sum": {
+										"script": {
+											"source": "double t = %s; return t * %s;",
+											"params": { "base": %d }
+										}
+									}
+								}
+							}`, metricFiled, timeScript, baseTime, metricFiled, timeScript, baseTime,
+			timeScript, valueScript, baseTime)
+	case interfaces.RAW_SAMPLES_AGG:
+		// 取出子时间桶内的原始样本点，用于无法下推到 opensearch 计算的函数
+		valueAgg = fmt.Sprintf(`
+							"top_hits": {
+								"size": %d,
+								"sort": [
+									{ "@timestamp": { "order": "asc" } }
+								],
+								"_source": false,
+								"docvalue_fields": ["%s"]
+							}`, interfaces.MAX_RAW_SAMPLES_PER_BUCKET, metricFiled)
 	default:
 		return http.StatusUnprocessableEntity, uerrors.PromQLError{
 			Typ: uerrors.ErrorExec,
@@ -727,6 +791,14 @@ func processParam(expr *parser.MatrixSelector, query *interfaces.Query) (
 	return vs, &newQuery, http.StatusOK, nil
 }
 
+// 返回升序的子时间桶 key 中落在 [start, end) 内的部分。
+// 30分钟路由和2h路由的子步长可能不同，按时间窗取桶可以同时兼容两种子步长的桶。
+func bucketKeysInWindow(keys []int64, start, end int64) []int64 {
+	i := sort.Search(len(keys), func(i int) bool { return keys[i] >= start })
+	j := sort.Search(len(keys), func(j int) bool { return keys[j] >= end })
+	return keys[i:j]
+}
+
 // 根据仪表盘提交的全局过滤器的过滤条件拼接到 dsl 请求的 query 部分
 func AppendFilters(query interfaces.Query) (string, int, error) {
 	if !query.IsMetricModel {
@@ -0,0 +1,204 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package leafnodes
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"uniquery/interfaces"
+	"uniquery/logics/promql/parser"
+	"uniquery/logics/promql/static"
+)
+
+// 子查询的外层 range 函数：内层表达式按子步长求值得到的矩阵无法再下推到 opensearch，在内存中按窗口计算。
+// 窗口与叶子节点下推计算时保持一致，range query 在 ts 上的窗口是 [ts, ts+range)，instant query 的窗口是 (end-range, end]。
+func SubqueryMerge(mat static.Matrix, query *interfaces.Query, selRange int64, funcName string, call FunctionCall,
+	params []float64) (static.Matrix, error) {
+
+	if err := checkSubqueryParams(funcName, params); err != nil {
+		return nil, err
+	}
+
+	res := make(static.Matrix, 0, len(mat))
+	for _, series := range mat {
+		points := series.Points
+		finalPoints := make([]static.Point, 0)
+
+		if query.IsInstantQuery {
+			rangeStart := query.End - selRange
+			window := windowSamples(points, rangeStart+1, query.End+1)
+			if v, ok := subqueryFunction(funcName, window, rangeStart, query.End, call, params); ok {
+				finalPoints = append(finalPoints, static.Point{T: query.End, V: v})
+			}
+		} else {
+			for ts := query.FixedStart; ts <= query.FixedEnd; ts = static.GetNextPointTime(*query, ts) {
+				window := windowSamples(points, ts, ts+selRange)
+				if v, ok := subqueryFunction(funcName, window, ts, ts+selRange, call, params); ok {
+					finalPoints = append(finalPoints, static.Point{T: ts, V: v})
+				}
+			}
+		}
+
+		if len(finalPoints) == 0 {
+			continue
+		}
+		res = append(res, static.Series{
+			Metric: series.Metric,
+			Points: finalPoints,
+		})
+	}
+
+	return res, nil
+}
+
+// 校验子查询外层函数的标量参数
+func checkSubqueryParams(funcName string, params []float64) error {
+	switch funcName {
+	case interfaces.RATE_AGG, interfaces.INCREASE_AGG, interfaces.DELTA_AGG, interfaces.IRATE_AGG, interfaces.IDELTA_AGG,
+		interfaces.CHANGES_AGG, interfaces.DERIV_AGG, interfaces.AVG_OVER_TIME, interfaces.SUM_OVER_TIME,
+		interfaces.MAX_OVER_TIME, interfaces.MIN_OVER_TIME, interfaces.COUNT_OVER_TIME, interfaces.STDDEV_OVER_TIME,
+		interfaces.STDVAR_OVER_TIME, interfaces.LAST_OVER_TIME:
+		return nil
+	case interfaces.PREDICT_LINEAR_AGG:
+		if len(params) != 1 {
+			return fmt.Errorf("predict_linear expects 1 scalar parameter, got %d", len(params))
+		}
+		return nil
+	default:
+		return checkRawSamplesParams(funcName, params)
+	}
+}
+
+// 从按时间升序的样本点中取出 [start, end) 内的样本点
+func windowSamples(points []static.Point, start, end int64) []static.Point {
+	from := sort.Search(len(points), func(i int) bool { return points[i].T >= start })
+	to := sort.Search(len(points), func(i int) bool { return points[i].T >= end })
+	return points[from:to]
+}
+
+// 基于窗口内的样本点计算 range 函数的值，复用叶子节点合并后的计算逻辑。没有结果时返回 false
+func subqueryFunction(funcName string, samples []static.Point, rangeStart, rangeEnd int64, call FunctionCall,
+	params []float64) (float64, bool) {
+
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	switch funcName {
+	case interfaces.AVG_OVER_TIME, interfaces.SUM_OVER_TIME, interfaces.MAX_OVER_TIME, interfaces.MIN_OVER_TIME,
+		interfaces.COUNT_OVER_TIME, interfaces.STDDEV_OVER_TIME, interfaces.STDVAR_OVER_TIME, interfaces.LAST_OVER_TIME:
+		windowPoint := sampleToAGGPoint(funcName, samples[0])
+		for _, sample := range samples[1:] {
+			currentPoint := sampleToAGGPoint(funcName, sample)
+			mergeTwoPointWithSameTimeKey(funcName, &currentPoint, &windowPoint)
+		}
+		return aggOverTimeFunction(funcName, &windowPoint), true
+
+	case interfaces.RATE_AGG, interfaces.INCREASE_AGG:
+		var counterCorrection float64
+		for i := 1; i < len(samples); i++ {
+			if samples[i].V < samples[i-1].V {
+				counterCorrection += samples[i-1].V
+			}
+		}
+		first, last := samples[0], samples[len(samples)-1]
+		return callRangeFunction(call, static.RatePoint{
+			FirstTimestamp:    first.T,
+			FirstValue:        first.V,
+			LastTimestamp:     last.T,
+			LastValue:         last.V,
+			CounterCorrection: counterCorrection,
+			PointsCount:       int64(len(samples)),
+		}, rangeStart, rangeEnd)
+
+	case interfaces.DELTA_AGG:
+		first, last := samples[0], samples[len(samples)-1]
+		return callRangeFunction(call, static.DeltaPoint{
+			FirstTimestamp: first.T,
+			FirstValue:     first.V,
+			LastTimestamp:  last.T,
+			LastValue:      last.V,
+			PointsCount:    int64(len(samples)),
+		}, rangeStart, rangeEnd)
+
+	case interfaces.IRATE_AGG, interfaces.IDELTA_AGG:
+		if len(samples) < 2 {
+			return 0, false
+		}
+		previous, last := samples[len(samples)-2], samples[len(samples)-1]
+		tempPoints := map[int64]static.IratePoint{
+			rangeStart: {PreviousT: previous.T, PreviousV: previous.V, LastT: last.T, LastV: last.V},
+		}
+		finalPoints := make([]static.Point, 0, 1)
+		instantVectorFunction(funcName)(&tempPoints, rangeStart, &finalPoints)
+		if len(finalPoints) == 0 {
+			return 0, false
+		}
+		return finalPoints[0].V, true
+
+	case interfaces.CHANGES_AGG:
+		changes := 0
+		for i := 1; i < len(samples); i++ {
+			current, prev := samples[i].V, samples[i-1].V
+			if current != prev && !(math.IsNaN(current) && math.IsNaN(prev)) {
+				changes++
+			}
+		}
+		return float64(changes), true
+
+	case interfaces.DERIV_AGG, interfaces.PREDICT_LINEAR_AGG:
+		// 回归统计量中的时间是相对窗口开始时间的秒数
+		point := &static.RegressionPoint{}
+		for _, sample := range samples {
+			t := float64(sample.T-rangeStart) / 1000
+			point.Count++
+			point.SumT += t
+			point.SumV += sample.V
+			point.SumTT += t * t
+			point.SumTV += t * sample.V
+		}
+		var duration float64
+		if funcName == interfaces.PREDICT_LINEAR_AGG {
+			duration = params[0]
+		}
+		return linearRegressionFunction(funcName, point, rangeStart, rangeEnd, duration)
+	}
+
+	return rawSamplesFunction(funcName, samples, params)
+}
+
+// 单个样本点转换成 *_over_time 合并时使用的中间结果
+func sampleToAGGPoint(funcName string, sample static.Point) static.AGGPoint {
+	point := static.AGGPoint{
+		Value:        sample.V,
+		Count:        1,
+		SumOfSquares: sample.V * sample.V,
+		Timestamp:    sample.T,
+	}
+	if funcName == interfaces.COUNT_OVER_TIME {
+		point.Value = 1
+	}
+	return point
+}
+
+// 调用 rate、increase、delta 的 Call 方法计算外推后的值，窗口为 [rangeStart, rangeEnd]
+func callRangeFunction(call FunctionCall, point parser.Value, rangeStart, rangeEnd int64) (float64, bool) {
+	enh := &static.EvalNodeHelper{
+		Ts:  rangeStart,
+		Out: make(static.Vector, 0, 1),
+	}
+	args := parser.Expressions{&parser.MatrixSelector{
+		Range: time.Duration(rangeEnd-rangeStart) * time.Millisecond,
+	}}
+	vec := call.Call([]parser.Value{point}, args, enh)
+	if len(vec) == 0 {
+		return 0, false
+	}
+	return vec[0].V, true
+}
@@ -0,0 +1,110 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package leafnodes
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"uniquery/interfaces"
+	"uniquery/logics/promql/labels"
+	"uniquery/logics/promql/static"
+)
+
+func TestSubqueryMerge(t *testing.T) {
+	Convey("test SubqueryMerge", t, func() {
+		metric := labels.Labels{&labels.Label{Name: "name", Value: "node-1"}}
+		// 子步长 10s 的样本点
+		mat := static.Matrix{
+			{
+				Metric: metric,
+				Points: []static.Point{
+					{T: 0, V: 1}, {T: 10000, V: 3}, {T: 20000, V: 2},
+					{T: 30000, V: 5}, {T: 40000, V: 4}, {T: 50000, V: 6},
+				},
+			},
+		}
+
+		Convey("unsupported function", func() {
+			query := &interfaces.Query{IsInstantQuery: true, End: 60000}
+			res, err := SubqueryMerge(mat, query, 30000, "unknown", nil, nil)
+			So(res, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("max_over_time on range query", func() {
+			query := &interfaces.Query{Interval: 30000, FixedStart: 0, FixedEnd: 30000}
+			res, err := SubqueryMerge(mat, query, 30000, interfaces.MAX_OVER_TIME, nil, nil)
+			So(err, ShouldBeNil)
+			So(len(res), ShouldEqual, 1)
+			So(res[0].Metric, ShouldResemble, metric)
+			// 窗口 [0, 30s) 和 [30s, 60s)
+			So(res[0].Points, ShouldResemble, []static.Point{{T: 0, V: 3}, {T: 30000, V: 6}})
+		})
+
+		Convey("avg_over_time and count_over_time on instant query", func() {
+			query := &interfaces.Query{IsInstantQuery: true, End: 50000}
+			// 窗口 (20s, 50s]
+			res, err := SubqueryMerge(mat, query, 30000, interfaces.AVG_OVER_TIME, nil, nil)
+			So(err, ShouldBeNil)
+			So(res[0].Points, ShouldResemble, []static.Point{{T: 50000, V: 5}})
+
+			res, err = SubqueryMerge(mat, query, 30000, interfaces.COUNT_OVER_TIME, nil, nil)
+			So(err, ShouldBeNil)
+			So(res[0].Points, ShouldResemble, []static.Point{{T: 50000, V: 3}})
+		})
+
+		Convey("resets and changes", func() {
+			query := &interfaces.Query{IsInstantQuery: true, End: 60000}
+			res, err := SubqueryMerge(mat, query, 60000, interfaces.RESETS_AGG, nil, nil)
+			So(err, ShouldBeNil)
+			So(res[0].Points, ShouldResemble, []static.Point{{T: 60000, V: 2}})
+
+			res, err = SubqueryMerge(mat, query, 60000, interfaces.CHANGES_AGG, nil, nil)
+			So(err, ShouldBeNil)
+			So(res[0].Points, ShouldResemble, []static.Point{{T: 60000, V: 4}})
+		})
+
+		Convey("idelta", func() {
+			query := &interfaces.Query{IsInstantQuery: true, End: 60000}
+			res, err := SubqueryMerge(mat, query, 60000, interfaces.IDELTA_AGG, nil, nil)
+			So(err, ShouldBeNil)
+			So(res[0].Points, ShouldResemble, []static.Point{{T: 60000, V: 2}})
+		})
+
+		Convey("increase", func() {
+			counter := static.Matrix{
+				{
+					Metric: metric,
+					Points: []static.Point{{T: 0, V: 0}, {T: 10000, V: 10}, {T: 20000, V: 20}, {T: 30000, V: 30}},
+				},
+			}
+			query := &interfaces.Query{Interval: 40000, FixedStart: 0, FixedEnd: 0}
+			res, err := SubqueryMerge(counter, query, 40000, interfaces.INCREASE_AGG,
+				static.FunctionCalls[interfaces.INCREASE_AGG], nil)
+			So(err, ShouldBeNil)
+			So(res[0].Points[0].V, ShouldAlmostEqual, 40)
+		})
+
+		Convey("predict_linear", func() {
+			linear := static.Matrix{
+				{
+					Metric: metric,
+					Points: []static.Point{{T: 0, V: 1}, {T: 10000, V: 21}, {T: 20000, V: 41}},
+				},
+			}
+			query := &interfaces.Query{IsInstantQuery: true, End: 30000}
+			_, err := SubqueryMerge(linear, query, 40000, interfaces.PREDICT_LINEAR_AGG, nil, nil)
+			So(err, ShouldNotBeNil)
+
+			res, err := SubqueryMerge(linear, query, 40000, interfaces.PREDICT_LINEAR_AGG, nil, []float64{10})
+			So(err, ShouldBeNil)
+			// v = 2t + 1，预测 30s 之后 10s 的值
+			So(res[0].Points[0].V, ShouldAlmostEqual, 81)
+		})
+	})
+}
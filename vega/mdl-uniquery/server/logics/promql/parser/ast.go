@@ -101,6 +101,11 @@ type Call struct {
 	Func *Function   // The function that was called.
 	Args Expressions // Arguments used in the call.
 
+	// metric_model(...) 的结果与选择器一样按时间窗查询，支持 offset 和 @ 修饰符，其他函数不支持。
+	OriginalOffset time.Duration
+	Timestamp      *int64
+	StartOrEnd     ItemType // Set when @ is used with start() or end()
+
 	PosRange PositionRange
 }
 
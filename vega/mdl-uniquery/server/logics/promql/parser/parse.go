@@ -724,6 +724,13 @@ func (p *parser) addOffset(e Node, offset time.Duration) {
 	case *SubqueryExpr:
 		orgoffsetp = &s.OriginalOffset
 		endPosp = &s.EndPos
+	case *Call:
+		if !isMetricModelCall(s) {
+			p.addParseErrf(e.PositionRange(), "offset modifier must be preceded by an instant vector selector or range vector selector or a subquery")
+			return
+		}
+		orgoffsetp = &s.OriginalOffset
+		endPosp = &s.PosRange.End
 	default:
 		p.addParseErrf(e.PositionRange(), "offset modifier must be preceded by an instant vector selector or range vector selector or a subquery")
 		return
@@ -775,6 +782,11 @@ func (p *parser) setAtModifierPreprocessor(e Node, op Item) {
 	*endPosp = p.lastClosing
 }
 
+// isMetricModelCall 判断是否是 metric_model(...) 函数调用，只有它可以使用 offset 和 @ 修饰符
+func isMetricModelCall(call *Call) bool {
+	return call.Func != nil && call.Func.Name == "metric_model"
+}
+
 func (p *parser) getAtModifierVars(e Node) (**int64, *ItemType, *Pos, bool) {
 	var (
 		timestampp **int64
@@ -799,6 +811,14 @@ func (p *parser) getAtModifierVars(e Node) (**int64, *ItemType, *Pos, bool) {
 		preprocp = &s.StartOrEnd
 		timestampp = &s.Timestamp
 		endPosp = &s.EndPos
+	case *Call:
+		if !isMetricModelCall(s) {
+			p.addParseErrf(e.PositionRange(), "@ modifier must be preceded by an instant vector selector or range vector selector or a subquery")
+			return nil, nil, nil, false
+		}
+		preprocp = &s.StartOrEnd
+		timestampp = &s.Timestamp
+		endPosp = &s.PosRange.End
 	default:
 		p.addParseErrf(e.PositionRange(), "@ modifier must be preceded by an instant vector selector or range vector selector or a subquery")
 		return nil, nil, nil, false
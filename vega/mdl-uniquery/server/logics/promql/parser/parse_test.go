@@ -2314,6 +2314,43 @@ var testExpr = []struct {
 		fail:   true,
 		errMsg: "1:1: parse error: @ modifier must be preceded by an instant vector selector or range vector selector or a subquery",
 	},
+	{
+		input: `metric_model("m") offset 5m`,
+		expected: &Call{
+			Func: MustGetFunction("metric_model"),
+			Args: Expressions{
+				&StringLiteral{
+					Val:      "m",
+					PosRange: PositionRange{Start: 13, End: 16},
+				},
+			},
+			OriginalOffset: 5 * time.Minute,
+			PosRange: PositionRange{
+				Start: 0,
+				End:   27,
+			},
+		},
+	}, {
+		input: `metric_model("m") @ 1603774568`,
+		expected: &Call{
+			Func: MustGetFunction("metric_model"),
+			Args: Expressions{
+				&StringLiteral{
+					Val:      "m",
+					PosRange: PositionRange{Start: 13, End: 16},
+				},
+			},
+			Timestamp: makeInt64Pointer(1603774568000),
+			PosRange: PositionRange{
+				Start: 0,
+				End:   30,
+			},
+		},
+	}, {
+		input:  `abs(metric_model("m")) offset 5m`,
+		fail:   true,
+		errMsg: "1:1: parse error: offset modifier must be preceded by an instant vector selector or range vector selector or a subquery",
+	},
 	{
 		input: "time()",
 		expected: &Call{
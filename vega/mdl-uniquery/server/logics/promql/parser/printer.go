@@ -99,7 +99,21 @@ func (node *BinaryExpr) String() string {
 }
 
 func (node *Call) String() string {
-	return fmt.Sprintf("%s(%s)", node.Func.Name, node.Args)
+	offset := ""
+	if node.OriginalOffset > time.Duration(0) {
+		offset = fmt.Sprintf(" offset %s", Duration(node.OriginalOffset))
+	} else if node.OriginalOffset < time.Duration(0) {
+		offset = fmt.Sprintf(" offset -%s", Duration(-node.OriginalOffset))
+	}
+	at := ""
+	if node.Timestamp != nil {
+		at = fmt.Sprintf(" @ %.3f", float64(*node.Timestamp)/1000.0)
+	} else if node.StartOrEnd == START {
+		at = " @ start()"
+	} else if node.StartOrEnd == END {
+		at = " @ end()"
+	}
+	return fmt.Sprintf("%s(%s)%s%s", node.Func.Name, node.Args, at, offset)
 }
 
 func (node *MatrixSelector) String() string {
@@ -183,6 +183,9 @@ func (ps *promQLService) Exec(ctx context.Context, query interfaces.Query) (resp
 
 		case parser.ValueTypeScalar:
 			return marshalParseValue(query, static.Scalar{V: mat[0].Points[0].V, T: query.Start}, 0, status)
+		case parser.ValueTypeMatrix:
+			// 子查询作为查询结果时返回 range vector
+			return marshalParseValue(query, mat, seriesTotal, status)
 		default:
 			return res, nil, http.StatusUnprocessableEntity, fmt.Errorf("unexpected expression type %q", expr.Type())
 		}
@@ -313,6 +316,12 @@ func (ps *promQLService) eval(ctx context.Context, expr parser.Expr, query *inte
 				matrixArg = true
 				break
 			}
+			// 子查询的结果同样是 range vector
+			if _, ok := a.(*parser.SubqueryExpr); ok {
+				matrixArgIndex = i
+				matrixArg = true
+				break
+			}
 		}
 
 		if !matrixArg {
@@ -333,7 +342,11 @@ func (ps *promQLService) eval(ctx context.Context, expr parser.Expr, query *inte
 				return ps.evalKMinuteDowntime(ctx, e, query)
 
 			case interfaces.FUNC_METRIC_MODEL:
-				return ps.evalMetricModel(ctx, e, query)
+				// offset 和 @ 平移指标模型的查询时间窗，与选择器一致
+				return ps.evalWithModifiers(ctx, query, e.OriginalOffset, e.Timestamp,
+					func(q *interfaces.Query) (parser.Value, int, error) {
+						return ps.evalMetricModel(ctx, e, q)
+					})
 			}
 			return ps.rangeEval(ctx, query, nil, func(v []parser.Value, _ [][]static.EvalSeriesHelper, enh *static.EvalNodeHelper) (static.Vector, int, error) {
 				return call.Call(v, e.Args, enh), status, err
@@ -343,34 +356,26 @@ func (ps *promQLService) eval(ctx context.Context, expr parser.Expr, query *inte
 		static.UnwrapParenExpr(&e.Args[matrixArgIndex])
 		arg := static.UnwrapStepInvariantExpr(e.Args[matrixArgIndex])
 		static.UnwrapParenExpr(&arg)
-		sel := arg.(*parser.MatrixSelector)
-
-		switch e.Func.Name {
-		case interfaces.IRATE_AGG:
-			span.SetStatus(codes.Ok, "")
-			return ps.leafNodes.IrateEval(ctx, sel, []string{interfaces.LABELS_STR}, interfaces.IRATE_AGG, query)
-		case interfaces.RATE_AGG, interfaces.INCREASE_AGG:
-			span.SetStatus(codes.Ok, "")
-			return ps.leafNodes.RateAggs(ctx, sel, query, call)
-		case interfaces.CHANGES_AGG:
-			span.SetStatus(codes.Ok, "")
-			return ps.leafNodes.ChangesAggs(ctx, sel, query)
-		case interfaces.DELTA_AGG:
-			span.SetStatus(codes.Ok, "")
-			return ps.leafNodes.DeltaAggs(ctx, sel, query, call)
-		case interfaces.AVG_OVER_TIME, interfaces.SUM_OVER_TIME, interfaces.MAX_OVER_TIME, interfaces.MIN_OVER_TIME, interfaces.COUNT_OVER_TIME:
+		if sq, ok := arg.(*parser.SubqueryExpr); ok {
 			span.SetStatus(codes.Ok, "")
-			return ps.leafNodes.AggOverTime(ctx, sel, query, e.Func.Name)
-		default:
-			span.SetStatus(codes.Error, fmt.Sprintf("unhandled expression of type: %T", e.Func.Name))
-			// 记录异常日志
-			o11y.Error(ctx, fmt.Sprintf("unhandled expression of type: %T", e.Func.Name))
+			return ps.evalSubqueryCall(ctx, e, sq, matrixArgIndex, call, query)
+		}
+		sel := arg.(*parser.MatrixSelector)
 
+		vs, ok := sel.VectorSelector.(*parser.VectorSelector)
+		if !ok {
+			span.SetStatus(codes.Error, fmt.Sprintf("invalid expression type %q", sel.VectorSelector.Type()))
 			return nil, http.StatusUnprocessableEntity, uerrors.PromQLError{
 				Typ: uerrors.ErrorExec,
-				Err: fmt.Errorf("unhandled expression of type: %T", e.Func.Name),
+				Err: fmt.Errorf("invalid expression type %q", sel.VectorSelector.Type()),
 			}
 		}
+		span.SetStatus(codes.Ok, "")
+		// offset 和 @ 修饰的是 range vector 的时间窗，叶子节点在平移后的时间窗上计算
+		return ps.evalWithModifiers(ctx, query, vs.OriginalOffset, vs.Timestamp,
+			func(q *interfaces.Query) (parser.Value, int, error) {
+				return ps.evalRangeFunction(ctx, e, sel, matrixArgIndex, call, q)
+			})
 
 	case *parser.ParenExpr:
 		span.SetStatus(codes.Ok, "")
@@ -398,7 +403,14 @@ func (ps *promQLService) eval(ctx context.Context, expr parser.Expr, query *inte
 		// 对应采样逻辑：termsAgg 用 __labels_str, valueAgg 用 sampling
 		// 根据叶子节点 VectorSelector 的属性构建 dsl 查询请求.
 		span.SetStatus(codes.Ok, "")
-		return ps.leafNodes.EvalVectorSelector(ctx, e, []string{interfaces.LABELS_STR}, interfaces.SAMPLING_AGG, query)
+		return ps.evalWithModifiers(ctx, query, e.OriginalOffset, e.Timestamp,
+			func(q *interfaces.Query) (parser.Value, int, error) {
+				return ps.leafNodes.EvalVectorSelector(ctx, e, []string{interfaces.LABELS_STR}, interfaces.SAMPLING_AGG, q)
+			})
+
+	case *parser.SubqueryExpr:
+		span.SetStatus(codes.Ok, "")
+		return ps.evalSubqueryExpr(ctx, e, query)
 
 	case *parser.StepInvariantExpr:
 		span.SetStatus(codes.Ok, "")
@@ -414,6 +426,305 @@ func (ps *promQLService) eval(ctx context.Context, expr parser.Expr, query *inte
 	}
 }
 
+// 获取函数的标量参数，叶子节点下推计算时只支持数值常量
+func scalarLiteralArg(arg parser.Expr) (float64, error) {
+	static.UnwrapParenExpr(&arg)
+	a := static.UnwrapStepInvariantExpr(arg)
+	static.UnwrapParenExpr(&a)
+
+	switch e := a.(type) {
+	case *parser.NumberLiteral:
+		return e.Val, nil
+	case *parser.UnaryExpr:
+		if n, ok := e.Expr.(*parser.NumberLiteral); ok {
+			if e.Op == parser.SUB {
+				return -n.Val, nil
+			}
+			return n.Val, nil
+		}
+	}
+	return 0, fmt.Errorf("expected a number literal, got %s", a.String())
+}
+
+// 获取 range 函数中除 range vector 以外的标量参数
+func scalarLiteralArgs(e *parser.Call, matrixArgIndex int) ([]float64, error) {
+	params := make([]float64, 0, len(e.Args)-1)
+	for i, arg := range e.Args {
+		if i == matrixArgIndex {
+			continue
+		}
+		param, err := scalarLiteralArg(arg)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// eval 参数为 range vector 的函数，由叶子节点下推到 opensearch 计算
+func (ps *promQLService) evalRangeFunction(ctx context.Context, e *parser.Call, sel *parser.MatrixSelector,
+	matrixArgIndex int, call static.FunctionCall, query *interfaces.Query) (parser.Value, int, error) {
+
+	switch e.Func.Name {
+	case interfaces.IRATE_AGG:
+		return ps.leafNodes.IrateEval(ctx, sel, []string{interfaces.LABELS_STR}, interfaces.IRATE_AGG, query)
+	case interfaces.RATE_AGG, interfaces.INCREASE_AGG:
+		return ps.leafNodes.RateAggs(ctx, sel, query, call)
+	case interfaces.CHANGES_AGG:
+		return ps.leafNodes.ChangesAggs(ctx, sel, query)
+	case interfaces.DELTA_AGG:
+		return ps.leafNodes.DeltaAggs(ctx, sel, query, call)
+	case interfaces.AVG_OVER_TIME, interfaces.SUM_OVER_TIME, interfaces.MAX_OVER_TIME, interfaces.MIN_OVER_TIME, interfaces.COUNT_OVER_TIME,
+		interfaces.STDDEV_OVER_TIME, interfaces.STDVAR_OVER_TIME, interfaces.LAST_OVER_TIME:
+		return ps.leafNodes.AggOverTime(ctx, sel, query, e.Func.Name)
+	case interfaces.IDELTA_AGG:
+		return ps.leafNodes.IrateEval(ctx, sel, []string{interfaces.LABELS_STR}, interfaces.IDELTA_AGG, query)
+	case interfaces.DERIV_AGG, interfaces.PREDICT_LINEAR_AGG, interfaces.QUANTILE_OVER_TIME, interfaces.HOLT_WINTERS_AGG,
+		interfaces.RESETS_AGG:
+		// 除了 range vector 以外的参数都是标量，需是数值常量
+		params, err := scalarLiteralArgs(e, matrixArgIndex)
+		if err != nil {
+			o11y.Error(ctx, fmt.Sprintf("invalid parameter of '%s': %v", e.Func.Name, err))
+			return nil, http.StatusBadRequest, uerrors.PromQLError{
+				Typ: uerrors.ErrorBadData,
+				Err: fmt.Errorf("invalid parameter of '%s': %v", e.Func.Name, err),
+			}
+		}
+
+		switch e.Func.Name {
+		case interfaces.DERIV_AGG:
+			return ps.leafNodes.LinearRegression(ctx, sel, query, e.Func.Name, 0)
+		case interfaces.PREDICT_LINEAR_AGG:
+			return ps.leafNodes.LinearRegression(ctx, sel, query, e.Func.Name, params[0])
+		default:
+			return ps.leafNodes.RawSamplesEval(ctx, sel, query, e.Func.Name, params)
+		}
+	default:
+		// 记录异常日志
+		o11y.Error(ctx, fmt.Sprintf("unhandled expression of type: %T", e.Func.Name))
+
+		return nil, http.StatusUnprocessableEntity, uerrors.PromQLError{
+			Typ: uerrors.ErrorExec,
+			Err: fmt.Errorf("unhandled expression of type: %T", e.Func.Name),
+		}
+	}
+}
+
+// eval 参数为子查询的 range 函数：先按子步长 eval 子查询得到矩阵，再在内存中按窗口计算外层函数
+func (ps *promQLService) evalSubqueryCall(ctx context.Context, e *parser.Call, sq *parser.SubqueryExpr,
+	matrixArgIndex int, call static.FunctionCall, query *interfaces.Query) (parser.Value, int, error) {
+
+	params, err := scalarLiteralArgs(e, matrixArgIndex)
+	if err != nil {
+		o11y.Error(ctx, fmt.Sprintf("invalid parameter of '%s': %v", e.Func.Name, err))
+		return nil, http.StatusBadRequest, uerrors.PromQLError{
+			Typ: uerrors.ErrorBadData,
+			Err: fmt.Errorf("invalid parameter of '%s': %v", e.Func.Name, err),
+		}
+	}
+
+	return ps.evalWithModifiers(ctx, query, sq.OriginalOffset, sq.Timestamp,
+		func(q *interfaces.Query) (parser.Value, int, error) {
+			mat, status, err := ps.evalSubquery(ctx, sq, q)
+			if err != nil {
+				return nil, status, err
+			}
+
+			res, err := leafnodes.SubqueryMerge(mat, q, sq.Range.Milliseconds(), e.Func.Name, call, params)
+			if err != nil {
+				o11y.Error(ctx, fmt.Sprintf("'%s' on subquery error: %v", e.Func.Name, err))
+				return nil, http.StatusBadRequest, uerrors.PromQLError{
+					Typ: uerrors.ErrorBadData,
+					Err: fmt.Errorf("'%s' on subquery error: %v", e.Func.Name, err),
+				}
+			}
+			return res, http.StatusOK, nil
+		})
+}
+
+// eval 作为查询结果的子查询，只能用于 instant query，返回子查询时间窗内的样本点
+func (ps *promQLService) evalSubqueryExpr(ctx context.Context, sq *parser.SubqueryExpr, query *interfaces.Query) (parser.Value, int, error) {
+	if !query.IsInstantQuery && query.Start != query.End {
+		return nil, http.StatusBadRequest, uerrors.PromQLError{
+			Typ: uerrors.ErrorBadData,
+			Err: fmt.Errorf("invalid expression type %q for range query, must be Scalar or instant Vector",
+				parser.DocumentedType(sq.Type())),
+		}
+	}
+
+	// 以 @ 指定的时间或者请求的结束时间为准，再往前平移 offset
+	newQuery := *query
+	if sq.Timestamp != nil {
+		newQuery.End = *sq.Timestamp
+	}
+	newQuery.End -= sq.OriginalOffset.Milliseconds()
+	newQuery.Start = newQuery.End - (query.End - query.Start)
+	newQuery.FixedStart = newQuery.Start
+	newQuery.FixedEnd = newQuery.End
+	newQuery.IsInstantQuery = true
+
+	mat, status, err := ps.evalSubquery(ctx, sq, &newQuery)
+	if err != nil {
+		return nil, status, err
+	}
+
+	// 只保留 (end-range, end] 内的样本点，并把时间平移回请求的时间轴
+	shift := query.End - newQuery.End
+	rangeStart := newQuery.End - sq.Range.Milliseconds()
+	res := make(static.Matrix, 0, len(mat))
+	for _, series := range mat {
+		points := make([]static.Point, 0, len(series.Points))
+		for _, p := range series.Points {
+			if p.T > rangeStart && p.T <= newQuery.End {
+				points = append(points, static.Point{T: p.T + shift, V: p.V})
+			}
+		}
+		if len(points) == 0 {
+			continue
+		}
+		res = append(res, static.Series{Metric: series.Metric, Points: points})
+	}
+	return res, http.StatusOK, nil
+}
+
+// 子查询的内层表达式以子步长做范围查询，覆盖外层函数在各个步长点上所需的时间窗
+func (ps *promQLService) evalSubquery(ctx context.Context, sq *parser.SubqueryExpr, query *interfaces.Query) (static.Matrix, int, error) {
+	selRange := sq.Range.Milliseconds()
+
+	// 子查询未指定步长时，range query 沿用请求的步长，instant query 和日历步长使用默认步长
+	step := sq.Step
+	if step == 0 {
+		if !query.IsInstantQuery && !query.IsCalendar && query.Interval > 0 {
+			step = time.Duration(query.Interval) * time.Millisecond
+		} else {
+			step = interfaces.DEFAULT_SUBQUERY_STEP * time.Millisecond
+		}
+	}
+
+	newQuery := *query
+	newQuery.Interval = step.Milliseconds()
+	newQuery.IntervalStr = parser.Duration(step).String()
+	newQuery.IsInstantQuery = false
+	newQuery.IsCalendar = false
+	// 外层函数在内存中计算，需要内层表达式的全部序列
+	newQuery.IfNeedAllSeries = true
+	if query.IsInstantQuery {
+		newQuery.Start = query.End - selRange
+		newQuery.End = query.End
+	} else {
+		newQuery.Start = query.FixedStart
+		newQuery.End = query.FixedEnd + selRange
+	}
+
+	// 一个序列能查的最大桶数是1w，若是超过，报错。
+	if (newQuery.End-newQuery.Start)/newQuery.Interval > interfaces.DEFAULT_MAX_QUERY_POINTS {
+		return nil, http.StatusUnprocessableEntity, uerrors.PromQLError{
+			Typ: uerrors.ErrorExec,
+			Err: fmt.Errorf("subquery %s exceeds the maximum of %d points per series, please use a larger step",
+				sq.String(), interfaces.DEFAULT_MAX_QUERY_POINTS),
+		}
+	}
+
+	// newQuery的fixedstart和fixedEnd需重新计算，因为start和interval变化了。
+	fixedStart, fixedEnd := static.CorrectingTime(newQuery, common.APP_LOCATION)
+	newQuery.FixedStart = fixedStart
+	newQuery.FixedEnd = fixedEnd
+
+	val, status, err := ps.eval(ctx, sq.Expr, &newQuery)
+	if err != nil {
+		return nil, status, err
+	}
+	// 记录vega耗时
+	query.VegaDurationMs = newQuery.VegaDurationMs
+
+	switch result := val.(type) {
+	case static.Matrix:
+		return result, http.StatusOK, nil
+	case static.PageMatrix:
+		return result.Matrix, http.StatusOK, nil
+	default:
+		return nil, http.StatusUnprocessableEntity, uerrors.PromQLError{
+			Typ: uerrors.ErrorExec,
+			Err: fmt.Errorf("unexpected result in subquery evaluation: %T", val),
+		}
+	}
+}
+
+// 处理 offset 和 @ 修饰符：在平移后的时间窗上 eval，再把结果映射回请求的时间轴。
+// 有 @ 时只在指定的时间点上 eval 一次，各个步长点都取该值；只有 offset 时按 offset 整体平移请求的时间范围。
+func (ps *promQLService) evalWithModifiers(ctx context.Context, query *interfaces.Query, offset time.Duration, timestamp *int64,
+	evalFunc func(*interfaces.Query) (parser.Value, int, error)) (parser.Value, int, error) {
+
+	if offset == 0 && timestamp == nil {
+		return evalFunc(query)
+	}
+
+	newQuery := *query
+	if timestamp != nil {
+		// 以 @ 指定的时间点做即时查询，时间窗的长度与请求中每个步长点的时间窗一致
+		window := query.Interval
+		if query.IsInstantQuery {
+			window = query.End - query.Start
+		}
+		newQuery.End = *timestamp - offset.Milliseconds()
+		newQuery.Start = newQuery.End - window
+		newQuery.FixedStart = newQuery.Start
+		newQuery.FixedEnd = newQuery.End
+		newQuery.IsInstantQuery = true
+		newQuery.IsCalendar = false
+	} else {
+		newQuery.Start = query.Start - offset.Milliseconds()
+		newQuery.End = query.End - offset.Milliseconds()
+		if query.IsInstantQuery {
+			newQuery.FixedStart = query.FixedStart - offset.Milliseconds()
+			newQuery.FixedEnd = query.FixedEnd - offset.Milliseconds()
+		} else {
+			// 平移后的时间需按步长重新修正
+			fixedStart, fixedEnd := static.CorrectingTime(newQuery, common.APP_LOCATION)
+			newQuery.FixedStart = fixedStart
+			newQuery.FixedEnd = fixedEnd
+		}
+	}
+
+	val, status, err := evalFunc(&newQuery)
+	if err != nil {
+		return nil, status, err
+	}
+	// 记录vega耗时
+	query.VegaDurationMs = newQuery.VegaDurationMs
+
+	var (
+		mat         static.Matrix
+		isPage      bool
+		totalSeries int
+	)
+	switch result := val.(type) {
+	case static.Matrix:
+		mat = result
+	case static.PageMatrix:
+		mat = result.Matrix
+		isPage = true
+		totalSeries = result.TotalSeries
+	default:
+		return nil, http.StatusUnprocessableEntity, uerrors.PromQLError{
+			Typ: uerrors.ErrorExec,
+			Err: fmt.Errorf("unexpected result in offset or @ modifier evaluation: %T", val),
+		}
+	}
+
+	if timestamp != nil {
+		mat = static.ReplicateMatrixPoints(mat, *query)
+	} else {
+		mat = static.ShiftMatrixTime(mat, newQuery, *query)
+	}
+
+	if isPage {
+		return static.PageMatrix{Matrix: mat, TotalSeries: totalSeries}, status, nil
+	}
+	return mat, status, nil
+}
+
 // eval 聚合操作
 func (ps *promQLService) evalAggregateExpr(ctx context.Context, expr *parser.AggregateExpr, query *interfaces.Query) (parser.Value, int, error) {
 	// Grouping labels must be sorted (expected both by generateGroupingKey() and aggregation()).
@@ -461,22 +772,17 @@ func (ps *promQLService) evalStepInvariantExpr(ctx context.Context, expr *parser
 		return ps.eval(ctx, ce, query)
 	}
 
-	newQuery := interfaces.Query{
-		QueryStr:             query.QueryStr,
-		Start:                query.Start,
-		End:                  query.End,
-		FixedStart:           query.FixedStart,
-		FixedEnd:             query.FixedStart,
-		Interval:             query.Interval,
-		IntervalStr:          query.IntervalStr,
-		IsInstantQuery:       query.IsInstantQuery,
-		SubIntervalWith30min: query.SubIntervalWith30min,
-		LogGroupId:           query.LogGroupId,
-	}
+	// 只在第一个步长点上 eval 一次。沿用请求的视图、过滤条件等信息，基于指标模型的查询同样适用
+	newQuery := *query
+	newQuery.FixedEnd = query.FixedStart
+	newQuery.IfNeedAllSeries = true
 	res, status, err := ps.eval(ctx, expr.Expr, &newQuery)
 	if err != nil {
 		return nil, status, err
 	}
+	if page, ok := res.(static.PageMatrix); ok {
+		res = page.Matrix
+	}
 
 	// For every evaluation while the value remains same, the timestamp for that
 	// value would change for different eval times. Hence we duplicate the result
@@ -1107,6 +1413,11 @@ func (ps *promQLService) evalFieldsInfo(ctx context.Context, expr parser.Expr,
 			}
 		}
 
+		if e.Func.Name == interfaces.FUNC_METRIC_MODEL {
+			span.SetStatus(codes.Ok, "")
+			return ps.evalMetricModelFields(ctx, e, fieldName)
+		}
+
 		var (
 			matrixArgIndex int
 			matrixArg      bool
@@ -1171,6 +1482,10 @@ func (ps *promQLService) evalFieldsInfo(ctx context.Context, expr parser.Expr,
 	case *parser.StepInvariantExpr:
 		span.SetStatus(codes.Ok, "")
 		return map[string]bool{}, http.StatusOK, nil
+
+	case *parser.SubqueryExpr:
+		span.SetStatus(codes.Ok, "")
+		return ps.evalFieldsInfo(ctx, e.Expr, query, fieldName)
 	}
 
 	span.SetStatus(codes.Error, fmt.Sprintf("unhandled expression of type: %T", expr))
@@ -1182,6 +1497,32 @@ func (ps *promQLService) evalFieldsInfo(ctx context.Context, expr parser.Expr,
 	}
 }
 
+// 获取 metric_model 引用的指标模型的字段集或者指定字段的值集
+func (ps *promQLService) evalMetricModelFields(ctx context.Context, expr *parser.Call, fieldName string) (map[string]bool, int, error) {
+	modelID := expr.Args[0].(*parser.StringLiteral).Val
+	if fieldName != "" {
+		fieldValues, err := ps.mmService.GetMetricModelFieldValues(ctx, modelID, fieldName)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		values := make(map[string]bool, len(fieldValues.Values))
+		for _, value := range fieldValues.Values {
+			values[value] = true
+		}
+		return values, http.StatusOK, nil
+	}
+
+	fields, err := ps.mmService.GetMetricModelFields(ctx, modelID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	fieldMap := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		fieldMap[field.Name] = true
+	}
+	return fieldMap, http.StatusOK, nil
+}
+
 // 获取函数中各参数的字段集或者指定字段的值集
 func (ps *promQLService) evalFuncFields(ctx context.Context, query *interfaces.Query, fieldName string, exprs ...parser.Expr) (map[string]bool, int, error) {
 	// eval函数的参数，对于dict_labels、dict_valeus，其字段暂时先不作为模型字段列表的一部分。
@@ -1346,6 +1687,18 @@ func (ps *promQLService) evalLabelsInfo(ctx context.Context, expr parser.Expr, q
 		// label_join：, label_replace：,continuous_k_minute_downtime：参数中多个表达式的并集
 
 		switch e.Func.Name {
+		case interfaces.FUNC_METRIC_MODEL:
+			// metric_model 的维度字段即指标模型的维度字段
+			modelID := e.Args[0].(*parser.StringLiteral).Val
+			fields, err := ps.mmService.GetMetricModelLabels(ctx, modelID)
+			if err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+			labelsMap := make(map[string]bool, len(fields))
+			for _, field := range fields {
+				labelsMap[field.Name] = true
+			}
+			return labelsMap, http.StatusOK, nil
 		case interfaces.DICT_LABELS:
 			// dict_labels函数中把扩展的维度字段写在了expendLables中了
 			// 先把eval第一个参数vector，然后再append expendLables即可
@@ -1541,6 +1894,9 @@ func (ps *promQLService) evalLabelsInfo(ctx context.Context, expr parser.Expr, q
 	case *parser.MatrixSelector:
 		span.SetStatus(codes.Ok, "")
 		return ps.evalLabelsInfo(ctx, e.VectorSelector, query)
+	case *parser.SubqueryExpr:
+		span.SetStatus(codes.Ok, "")
+		return ps.evalLabelsInfo(ctx, e.Expr, query)
 	}
 
 	span.SetStatus(codes.Error, fmt.Sprintf("unhandled expression of type: %T", expr))
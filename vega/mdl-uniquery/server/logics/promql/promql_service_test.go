@@ -7,6 +7,7 @@ package promql
 
 import (
 	"context"
+	"errors"
 	"math"
 	"net/http"
 	"os"
@@ -20,6 +21,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 
 	"uniquery/common"
+	cond "uniquery/common/condition"
 	"uniquery/common/convert"
 	uerrors "uniquery/errors"
 	"uniquery/interfaces"
@@ -5415,6 +5417,125 @@ func TestSortAndSortDescFunctionInQueryRange(t *testing.T) {
 	})
 }
 
+func TestEvalWithModifiers(t *testing.T) {
+	Convey("test promql_service evalWithModifiers ", t, func() {
+		loc, _ := time.LoadLocation(os.Getenv("TZ"))
+		common.APP_LOCATION = loc
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		osaMock := umock.NewMockOpenSearchAccess(mockCtrl)
+		lgaMock := umock.NewMockLogGroupAccess(mockCtrl)
+		dvsMock := umock.NewMockDataViewService(mockCtrl)
+		mmsMock := umock.NewMockMetricModelService(mockCtrl)
+		psMock := mockNewPromqlService(osaMock, lgaMock, dvsMock, mmsMock)
+
+		metric := labels.Labels{&labels.Label{Name: "name", Value: "node-1"}}
+		query := &interfaces.Query{Start: 1652320539000, End: 1652320554000, FixedStart: 1652320539000,
+			FixedEnd: 1652320554000, Interval: 3000}
+
+		Convey("offset shifts the query and the result back", func() {
+			var evalQuery interfaces.Query
+			res, status, err := psMock.evalWithModifiers(testCtx, query, 6*time.Second, nil,
+				func(q *interfaces.Query) (parser.Value, int, error) {
+					evalQuery = *q
+					return static.PageMatrix{
+						Matrix: static.Matrix{{Metric: metric, Points: []static.Point{
+							{T: q.FixedStart, V: 1}, {T: q.FixedEnd, V: 2}}}},
+						TotalSeries: 1,
+					}, http.StatusOK, nil
+				})
+
+			So(err, ShouldBeNil)
+			So(status, ShouldEqual, http.StatusOK)
+			So(evalQuery.Start, ShouldEqual, 1652320533000)
+			So(evalQuery.End, ShouldEqual, 1652320548000)
+			So(res, ShouldResemble, static.PageMatrix{
+				Matrix: static.Matrix{{Metric: metric, Points: []static.Point{
+					{T: 1652320539000, V: 1}, {T: 1652320554000, V: 2}}}},
+				TotalSeries: 1,
+			})
+		})
+
+		Convey("@ evaluates once and replicates the value", func() {
+			ts := int64(1652320000000)
+			var evalQuery interfaces.Query
+			res, status, err := psMock.evalWithModifiers(testCtx, query, 0, &ts,
+				func(q *interfaces.Query) (parser.Value, int, error) {
+					evalQuery = *q
+					return static.Matrix{{Metric: metric, Points: []static.Point{{T: q.End, V: 5}}}}, http.StatusOK, nil
+				})
+
+			So(err, ShouldBeNil)
+			So(status, ShouldEqual, http.StatusOK)
+			So(evalQuery.IsInstantQuery, ShouldBeTrue)
+			So(evalQuery.End, ShouldEqual, ts)
+			mat := res.(static.Matrix)
+			So(len(mat[0].Points), ShouldEqual, 6)
+			So(mat[0].Points[5], ShouldResemble, static.Point{T: 1652320554000, V: 5})
+		})
+
+		Convey("metric_model offset shifts the model query window", func() {
+			var mmQuery *interfaces.MetricModelQuery
+			mmsMock.EXPECT().Exec(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, q *interfaces.MetricModelQuery) (interfaces.MetricModelUniResponse, int, int, error) {
+					mmQuery = q
+					return interfaces.MetricModelUniResponse{Datas: []interfaces.MetricModelData{{
+						Labels: map[string]string{"name": "node-1"},
+						Times:  []any{*q.Start, *q.End},
+						Values: []any{1.0, 2.0},
+					}}}, 0, 0, nil
+				})
+
+			expr, err := parser.ParseExpr(testCtx, `metric_model("m") offset 6s`)
+			So(err, ShouldBeNil)
+			res, status, err := psMock.eval(testCtx, expr, query)
+
+			So(err, ShouldBeNil)
+			So(status, ShouldEqual, http.StatusOK)
+			So(*mmQuery.Start, ShouldEqual, 1652320533000)
+			So(*mmQuery.End, ShouldEqual, 1652320548000)
+			mat := res.(static.Matrix)
+			So(mat[0].Points, ShouldResemble, []static.Point{{T: 1652320539000, V: 1}, {T: 1652320554000, V: 2}})
+		})
+
+		Convey("metric_model @ queries the model at the given time", func() {
+			var mmQuery *interfaces.MetricModelQuery
+			mmsMock.EXPECT().Exec(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, q *interfaces.MetricModelQuery) (interfaces.MetricModelUniResponse, int, int, error) {
+					mmQuery = q
+					return interfaces.MetricModelUniResponse{Datas: []interfaces.MetricModelData{{
+						Labels: map[string]string{"name": "node-1"},
+						Times:  []any{*q.End},
+						Values: []any{5.0},
+					}}}, 0, 0, nil
+				})
+
+			expr, err := parser.ParseExpr(testCtx, `metric_model("m") @ 1652320000`)
+			So(err, ShouldBeNil)
+			res, status, err := psMock.eval(testCtx, expr, query)
+
+			So(err, ShouldBeNil)
+			So(status, ShouldEqual, http.StatusOK)
+			So(mmQuery.IsInstantQuery, ShouldBeTrue)
+			So(*mmQuery.End, ShouldEqual, 1652320000000)
+			mat := res.(static.Matrix)
+			So(len(mat[0].Points), ShouldEqual, 6)
+			So(mat[0].Points[5], ShouldResemble, static.Point{T: 1652320554000, V: 5})
+		})
+
+		Convey("subquery as result of range query", func() {
+			expr, _ := parser.ParseExpr(testCtx, `foo[1h:1m]`)
+			res, status, err := psMock.eval(testCtx, expr, query)
+
+			So(res, ShouldBeNil)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
 func TestTestAggOverTime(t *testing.T) {
 	Convey("test promql_service eval about agg_over_time ", t, func() {
 
@@ -6210,6 +6331,21 @@ func TestGetFieldValues(t *testing.T) {
 			So(err, ShouldBeNil)
 		})
 
+		Convey("when expression references a metric model", func() {
+			mmsMock.EXPECT().GetMetricModelFieldValues(gomock.Any(), "1", "labels.job").Return(interfaces.FieldValues{
+				Values: []string{"node", "mysql"},
+			}, nil)
+			query := interfaces.Query{
+				QueryStr: `sum by (labels.job) (metric_model("1"))`,
+			}
+
+			values, status, err := psMock.GetFieldValues(testCtx, query, "labels.job")
+
+			So(values, ShouldResemble, map[string]bool{"node": true, "mysql": true})
+			So(status, ShouldEqual, http.StatusOK)
+			So(err, ShouldBeNil)
+		})
+
 	})
 }
 
@@ -6277,6 +6413,33 @@ func TestGetLabels(t *testing.T) {
 			So(status, ShouldEqual, http.StatusOK)
 			So(err, ShouldBeNil)
 		})
+
+		Convey("when expression references a metric model", func() {
+			mmsMock.EXPECT().GetMetricModelLabels(gomock.Any(), "1").Return([]*cond.ViewField{
+				{Name: "labels.job"}, {Name: "labels.instance"},
+			}, nil)
+			query := interfaces.Query{
+				QueryStr: `metric_model("1")`,
+			}
+
+			labels, status, err := psMock.GetLabels(testCtx, query)
+
+			So(labels, ShouldResemble, map[string]bool{"labels.job": true, "labels.instance": true})
+			So(status, ShouldEqual, http.StatusOK)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("when getting the metric model labels fails", func() {
+			mmsMock.EXPECT().GetMetricModelLabels(gomock.Any(), "1").Return(nil, errors.New("not found"))
+			query := interfaces.Query{
+				QueryStr: `metric_model("1")`,
+			}
+
+			labels, _, err := psMock.GetLabels(testCtx, query)
+
+			So(labels, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+		})
 	})
 }
 
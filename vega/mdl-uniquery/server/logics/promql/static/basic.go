@@ -522,7 +522,8 @@ func PreprocessExpr(expr parser.Expr, start, end int64) (parser.Expr, error) {
 	if err != nil {
 		return nil, err
 	}
-	if isStepInvariant {
+	// 子查询作为查询结果时是 range vector，不做 step 不变的封装
+	if isStepInvariant && expr.Type() != parser.ValueTypeMatrix {
 		return newStepInvariantExpr(expr), nil
 	}
 	return expr, nil
@@ -602,7 +603,39 @@ func preprocessExprHelper(expr parser.Expr, start, end int64) (bool, error) {
 		//		n.Args[i] = newStepInvariantExpr(n.Args[i])
 		//	}
 		//}
-		return false, nil
+
+		// 函数本身不做 step 不变的封装，但参数中的 @ start() 和 @ end() 需解析成具体的时间
+		for i := range n.Args {
+			if _, err := preprocessExprHelper(n.Args[i], start, end); err != nil {
+				return false, err
+			}
+		}
+		// metric_model(...) 与选择器一样，有 @ 时是 step 不变的
+		if n.StartOrEnd == parser.START {
+			n.Timestamp = makeInt64Pointer(start)
+		} else if n.StartOrEnd == parser.END {
+			n.Timestamp = makeInt64Pointer(end)
+		}
+		return n.Timestamp != nil, nil
+
+	case *parser.MatrixSelector:
+		return preprocessExprHelper(n.VectorSelector, start, end)
+
+	case *parser.SubqueryExpr:
+		// 子查询内部 step 不变的表达式同样封装成 StepInvariantExpr
+		isInvariant, err := preprocessExprHelper(n.Expr, start, end)
+		if err != nil {
+			return false, err
+		}
+		if isInvariant {
+			n.Expr = newStepInvariantExpr(n.Expr)
+		}
+		if n.StartOrEnd == parser.START {
+			n.Timestamp = makeInt64Pointer(start)
+		} else if n.StartOrEnd == parser.END {
+			n.Timestamp = makeInt64Pointer(end)
+		}
+		return n.Timestamp != nil, nil
 
 	default:
 		return false, uerrors.PromQLError{
@@ -1460,3 +1493,61 @@ func GetNextPointTime(query interfaces.Query, currentTime int64) int64 {
 
 	return 0
 }
+
+// 带 @ 修饰的表达式只在指定的时间点上计算一次，每个序列取最后一个样本点的值，复制到请求的各个步长点上
+func ReplicateMatrixPoints(mat Matrix, query interfaces.Query) Matrix {
+	res := make(Matrix, 0, len(mat))
+	for _, series := range mat {
+		if len(series.Points) == 0 {
+			continue
+		}
+		v := series.Points[len(series.Points)-1].V
+
+		points := make([]Point, 0)
+		if query.IsInstantQuery || query.Start == query.End {
+			points = append(points, Point{T: query.End, V: v})
+		} else {
+			for ts := query.FixedStart; ts <= query.FixedEnd; ts = GetNextPointTime(query, ts) {
+				points = append(points, Point{T: ts, V: v})
+			}
+		}
+		res = append(res, Series{Metric: series.Metric, Points: points})
+	}
+	return res
+}
+
+// 把按 offset 平移后的 query 计算得到的结果映射回请求的时间轴。
+// 范围查询时平移前后的第 i 个步长点一一对应，offset 不是步长的整数倍时同样适用；即时查询直接平移时间。
+func ShiftMatrixTime(mat Matrix, shiftedQuery interfaces.Query, query interfaces.Query) Matrix {
+	if query.IsInstantQuery || query.Start == query.End {
+		shift := query.End - shiftedQuery.End
+		for i := range mat {
+			for j := range mat[i].Points {
+				mat[i].Points[j].T += shift
+			}
+		}
+		return mat
+	}
+
+	timeMap := make(map[int64]int64)
+	ts := query.FixedStart
+	for shiftedTs := shiftedQuery.FixedStart; shiftedTs <= shiftedQuery.FixedEnd && ts <= query.FixedEnd; shiftedTs = GetNextPointTime(shiftedQuery, shiftedTs) {
+		timeMap[shiftedTs] = ts
+		ts = GetNextPointTime(query, ts)
+	}
+
+	res := make(Matrix, 0, len(mat))
+	for _, series := range mat {
+		points := make([]Point, 0, len(series.Points))
+		for _, p := range series.Points {
+			if t, ok := timeMap[p.T]; ok {
+				points = append(points, Point{T: t, V: p.V})
+			}
+		}
+		if len(points) == 0 {
+			continue
+		}
+		res = append(res, Series{Metric: series.Metric, Points: points})
+	}
+	return res
+}
@@ -4023,3 +4023,101 @@ func TestCalculateUnavailableTime(t *testing.T) {
 		})
 	})
 }
+
+func TestPreprocessExprModifiers(t *testing.T) {
+	Convey("Test PreprocessExpr resolves @ start() and @ end()", t, func() {
+		start, end := int64(1000000), int64(2000000)
+
+		Convey("matrix selector in function args", func() {
+			expr, err := parser.ParseExpr(testCtx, "rate(foo[5m] @ end() offset 1m)")
+			So(err, ShouldBeNil)
+			expr, err = PreprocessExpr(expr, start, end)
+			So(err, ShouldBeNil)
+
+			call, ok := expr.(*parser.Call)
+			So(ok, ShouldBeTrue)
+			vs := call.Args[0].(*parser.MatrixSelector).VectorSelector.(*parser.VectorSelector)
+			So(*vs.Timestamp, ShouldEqual, end)
+			So(vs.OriginalOffset, ShouldEqual, time.Minute)
+		})
+
+		Convey("subquery in function args", func() {
+			expr, err := parser.ParseExpr(testCtx, "max_over_time(rate(foo[5m])[1h:1m] @ start())")
+			So(err, ShouldBeNil)
+			expr, err = PreprocessExpr(expr, start, end)
+			So(err, ShouldBeNil)
+
+			sq, ok := expr.(*parser.Call).Args[0].(*parser.SubqueryExpr)
+			So(ok, ShouldBeTrue)
+			So(*sq.Timestamp, ShouldEqual, start)
+		})
+
+		Convey("subquery as result is not wrapped", func() {
+			expr, err := parser.ParseExpr(testCtx, "foo[1h:1m] @ end()")
+			So(err, ShouldBeNil)
+			expr, err = PreprocessExpr(expr, start, end)
+			So(err, ShouldBeNil)
+
+			_, ok := expr.(*parser.SubqueryExpr)
+			So(ok, ShouldBeTrue)
+		})
+	})
+}
+
+func TestReplicateMatrixPoints(t *testing.T) {
+	Convey("Test ReplicateMatrixPoints", t, func() {
+		mat := Matrix{
+			{
+				Metric: labels.Labels{&labels.Label{Name: "a", Value: "1"}},
+				Points: []Point{{T: 100, V: 1}, {T: 200, V: 2}},
+			},
+			{
+				Metric: labels.Labels{&labels.Label{Name: "a", Value: "2"}},
+				Points: []Point{},
+			},
+		}
+
+		Convey("range query", func() {
+			query := interfaces.Query{Start: 0, End: 6000, FixedStart: 0, FixedEnd: 6000, Interval: 3000}
+			res := ReplicateMatrixPoints(mat, query)
+			So(len(res), ShouldEqual, 1)
+			So(res[0].Points, ShouldResemble, []Point{{T: 0, V: 2}, {T: 3000, V: 2}, {T: 6000, V: 2}})
+		})
+
+		Convey("instant query", func() {
+			query := interfaces.Query{Start: 6000, End: 6000, IsInstantQuery: true}
+			res := ReplicateMatrixPoints(mat, query)
+			So(len(res), ShouldEqual, 1)
+			So(res[0].Points, ShouldResemble, []Point{{T: 6000, V: 2}})
+		})
+	})
+}
+
+func TestShiftMatrixTime(t *testing.T) {
+	Convey("Test ShiftMatrixTime", t, func() {
+		Convey("range query", func() {
+			query := interfaces.Query{Start: 10000, End: 19000, FixedStart: 9000, FixedEnd: 18000, Interval: 3000}
+			// offset 4s，修正后的时间从 6000 开始
+			shiftedQuery := interfaces.Query{Start: 6000, End: 15000, FixedStart: 6000, FixedEnd: 15000, Interval: 3000}
+			mat := Matrix{
+				{
+					Points: []Point{{T: 6000, V: 1}, {T: 12000, V: 3}, {T: 15000, V: 4}},
+				},
+				{
+					Points: []Point{{T: 7000, V: 1}},
+				},
+			}
+			res := ShiftMatrixTime(mat, shiftedQuery, query)
+			So(len(res), ShouldEqual, 1)
+			So(res[0].Points, ShouldResemble, []Point{{T: 9000, V: 1}, {T: 15000, V: 3}, {T: 18000, V: 4}})
+		})
+
+		Convey("instant query", func() {
+			query := interfaces.Query{Start: 10000, End: 10000, IsInstantQuery: true}
+			shiftedQuery := interfaces.Query{Start: 4000, End: 4000, IsInstantQuery: true}
+			mat := Matrix{{Points: []Point{{T: 4000, V: 1}}}}
+			res := ShiftMatrixTime(mat, shiftedQuery, query)
+			So(res[0].Points, ShouldResemble, []Point{{T: 10000, V: 1}})
+		})
+	})
+}
@@ -586,6 +586,61 @@ func (f funcAggOverTime) Call(vals []parser.Value, args parser.Expressions, enh
 	return Vector{}
 }
 
+// === idelta(node parser.ValueTypeMatrix) Vector ===
+type funcIdelta struct{}
+
+func (f funcIdelta) New(args parser.Expressions) FunctionCall {
+	return &funcIdelta{}
+}
+func (f funcIdelta) Call(vals []parser.Value, args parser.Expressions, enh *EvalNodeHelper) Vector {
+	// 返回空，idelta 与 irate 共用叶子节点的采样聚合，在合并样本点时就已经完成计算，无需再回调。
+	return Vector{}
+}
+
+// === resets(node parser.ValueTypeMatrix) Vector ===
+type funcResets struct{}
+
+func (f funcResets) New(args parser.Expressions) FunctionCall {
+	return &funcResets{}
+}
+func (f funcResets) Call(vals []parser.Value, args parser.Expressions, enh *EvalNodeHelper) Vector {
+	// 返回空，resets 在叶子节点合并原始样本点时就已经完成计算，无需再回调。
+	return Vector{}
+}
+
+// === deriv(node parser.ValueTypeMatrix), predict_linear(node parser.ValueTypeMatrix, k parser.ValueTypeScalar) Vector ===
+type funcLinearRegression struct{}
+
+func (f funcLinearRegression) New(args parser.Expressions) FunctionCall {
+	return &funcLinearRegression{}
+}
+func (f funcLinearRegression) Call(vals []parser.Value, args parser.Expressions, enh *EvalNodeHelper) Vector {
+	// 返回空，deriv 和 predict_linear 在叶子节点合并回归统计量时就已经完成计算，无需再回调。
+	return Vector{}
+}
+
+// === holt_winters(node parser.ValueTypeMatrix, sf, tf parser.ValueTypeScalar) Vector ===
+type funcHoltWinters struct{}
+
+func (f funcHoltWinters) New(args parser.Expressions) FunctionCall {
+	return &funcHoltWinters{}
+}
+func (f funcHoltWinters) Call(vals []parser.Value, args parser.Expressions, enh *EvalNodeHelper) Vector {
+	// 返回空，holt_winters 在叶子节点合并原始样本点时就已经完成计算，无需再回调。
+	return Vector{}
+}
+
+// === quantile_over_time(q parser.ValueTypeScalar, node parser.ValueTypeMatrix) Vector ===
+type funcQuantileOverTime struct{}
+
+func (f funcQuantileOverTime) New(args parser.Expressions) FunctionCall {
+	return &funcQuantileOverTime{}
+}
+func (f funcQuantileOverTime) Call(vals []parser.Value, args parser.Expressions, enh *EvalNodeHelper) Vector {
+	// 返回空，quantile_over_time 在叶子节点合并原始样本点时就已经完成计算，无需再回调。
+	return Vector{}
+}
+
 // TODO后续把Call方法中需要的参数提到结构体中
 type funcPercentRank struct{}
 
@@ -1271,6 +1326,15 @@ var FunctionCalls = map[string]FunctionCall{
 	"count_over_time":              &funcAggOverTime{},
 	"max_over_time":                &funcAggOverTime{},
 	"min_over_time":                &funcAggOverTime{},
+	"stddev_over_time":             &funcAggOverTime{},
+	"stdvar_over_time":             &funcAggOverTime{},
+	"last_over_time":               &funcAggOverTime{},
+	"quantile_over_time":           &funcQuantileOverTime{},
+	"idelta":                       &funcIdelta{},
+	"resets":                       &funcResets{},
+	"deriv":                        &funcLinearRegression{},
+	"predict_linear":               &funcLinearRegression{},
+	"holt_winters":                 &funcHoltWinters{},
 	"delta":                        &funcDelta{},
 	"percent_rank":                 &funcPercentRank{},
 	"rank":                         &funcRank{},
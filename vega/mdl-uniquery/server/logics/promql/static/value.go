@@ -16,16 +16,17 @@ import (
 	"uniquery/logics/promql/parser"
 )
 
-func (Matrix) Type() parser.ValueType       { return parser.ValueTypeMatrix }
-func (Vector) Type() parser.ValueType       { return parser.ValueTypeVector }
-func (Scalar) Type() parser.ValueType       { return parser.ValueTypeScalar }
-func (String) Type() parser.ValueType       { return parser.ValueTypeString }
-func (IrateMatrix) Type() parser.ValueType  { return parser.ValueTypeMatrix }
-func (IrateSeries) Type() parser.ValueType  { return parser.ValueTypeMatrix }
-func (RatePoint) Type() parser.ValueType    { return parser.ValueTypeMatrix }
-func (ChangesPoint) Type() parser.ValueType { return parser.ValueTypeMatrix }
-func (DeltaPoint) Type() parser.ValueType   { return parser.ValueTypeMatrix }
-func (PageMatrix) Type() parser.ValueType   { return parser.ValueTypeMatrix }
+func (Matrix) Type() parser.ValueType          { return parser.ValueTypeMatrix }
+func (Vector) Type() parser.ValueType          { return parser.ValueTypeVector }
+func (Scalar) Type() parser.ValueType          { return parser.ValueTypeScalar }
+func (String) Type() parser.ValueType          { return parser.ValueTypeString }
+func (IrateMatrix) Type() parser.ValueType     { return parser.ValueTypeMatrix }
+func (IrateSeries) Type() parser.ValueType     { return parser.ValueTypeMatrix }
+func (RatePoint) Type() parser.ValueType       { return parser.ValueTypeMatrix }
+func (ChangesPoint) Type() parser.ValueType    { return parser.ValueTypeMatrix }
+func (DeltaPoint) Type() parser.ValueType      { return parser.ValueTypeMatrix }
+func (RegressionPoint) Type() parser.ValueType { return parser.ValueTypeMatrix }
+func (PageMatrix) Type() parser.ValueType      { return parser.ValueTypeMatrix }
 
 // String represents a string value.
 type String struct {
@@ -227,8 +228,10 @@ func (p ChangesPoint) String() string {
 }
 
 type AGGPoint struct {
-	Value float64
-	Count int64
+	Value        float64
+	Count        int64
+	SumOfSquares float64 // stddev_over_time, stdvar_over_time 使用
+	Timestamp    int64   // last_over_time 使用，记录样本点的时间戳
 }
 
 func (p AGGPoint) String() string {
@@ -236,6 +239,21 @@ func (p AGGPoint) String() string {
 	return fmt.Sprintf("Value:%v,Count:%d", lastV, p.Count)
 }
 
+// 线性回归的中间结果，时间以秒为单位，且是相对于查询的基准时间的偏移量
+type RegressionPoint struct {
+	Count int64
+	SumT  float64
+	SumV  float64
+	SumTT float64
+	SumTV float64
+}
+
+func (p RegressionPoint) String() string {
+	return fmt.Sprintf("Count:%d,SumT:%v,SumV:%v,SumTT:%v,SumTV:%v", p.Count,
+		strconv.FormatFloat(p.SumT, 'f', -1, 64), strconv.FormatFloat(p.SumV, 'f', -1, 64),
+		strconv.FormatFloat(p.SumTT, 'f', -1, 64), strconv.FormatFloat(p.SumTV, 'f', -1, 64))
+}
+
 type DeltaPoint struct {
 	FirstTimestamp int64
 	FirstValue     float64
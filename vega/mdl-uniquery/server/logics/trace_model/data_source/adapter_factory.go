@@ -16,6 +16,7 @@ import (
 	uerrors "uniquery/errors"
 	"uniquery/interfaces"
 	data_view "uniquery/logics/trace_model/data_source/data_view"
+	otel "uniquery/logics/trace_model/data_source/otel"
 	tingyun "uniquery/logics/trace_model/data_source/tingyun"
 )
 
@@ -23,6 +24,8 @@ func NewTraceModelAdapter(ctx context.Context, dataSourceType string, appSetting
 	switch dataSourceType {
 	case interfaces.SOURCE_TYPE_DATA_VIEW:
 		return data_view.NewDataViewAdapter(appSetting), nil
+	case interfaces.SOURCE_TYPE_OTEL:
+		return otel.NewOTelAdapter(appSetting), nil
 	case interfaces.SOURCE_TYPE_TINGYUN:
 		return tingyun.NewTingYunAdapter(appSetting), nil
 	default:
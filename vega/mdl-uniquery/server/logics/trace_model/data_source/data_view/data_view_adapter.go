@@ -440,6 +440,66 @@ func (dvAdapter *dataViewAdapter) GetRelatedLogCountMap(ctx context.Context, mod
 	return stats, nil
 }
 
+func (dvAdapter *dataViewAdapter) GetSpansByTimeRange(ctx context.Context, model interfaces.TraceModel, params interfaces.ServiceDependencyQueryParams) (spans []interfaces.AbstractSpan, truncated bool, err error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic层: 通过dataViewAdapter获取时间范围内的span")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		span.End()
+	}()
+
+	spanConf, _ := model.SpanConfig.(interfaces.SpanConfigWithDataView)
+
+	// 1. 生成query, 时间范围交由数据视图处理
+	query := &interfaces.DataViewQueryV1{
+		GlobalFilters: params.Condition,
+		Scroll:        interfaces.DEFAULT_SEARCH_SCROLL_STR,
+		ViewQueryCommonParams: interfaces.ViewQueryCommonParams{
+			Start:  params.Start,
+			End:    params.End,
+			Limit:  interfaces.MAX_SEARCH_SIZE,
+			Format: interfaces.Format_Original,
+		},
+	}
+
+	scrollIDs := make([]string, 0)
+	defer func() {
+		// 删除本次分页查询使用的scrollID
+		go dvAdapter.clearScrollIDs(context.Background(), scrollIDs)
+	}()
+
+	// 2. 分批拉取span, 超出MAX_ANALYTICS_SPAN_SIZE后截断
+	spans = make([]interfaces.AbstractSpan, 0)
+	for {
+		viewInternalResp, err := dvAdapter.dvService.RetrieveSingleViewData(ctx, spanConf.DataView.ID, query)
+		if err != nil {
+			return spans, false, err
+		}
+
+		scrollID := viewInternalResp.ScrollId
+		scrollIDs = append(scrollIDs, scrollID)
+
+		astNodes := viewInternalResp.Datas
+		for _, astNode := range astNodes {
+			if len(spans) >= interfaces.MAX_ANALYTICS_SPAN_SIZE {
+				return spans, true, nil
+			}
+			spans = append(spans, dvAdapter.extractRawSpan(spanConf, astNode, false))
+		}
+
+		if len(astNodes) < interfaces.MAX_SEARCH_SIZE {
+			break
+		}
+
+		query.ScrollId = scrollID
+	}
+
+	return spans, false, nil
+}
+
 /*
 	私有方法
 */
@@ -365,6 +365,62 @@ func TestGetSpanMap2(t *testing.T) {
 	})
 }
 
+func TestGetSpansByTimeRange2(t *testing.T) {
+	Convey("Test GetSpansByTimeRange", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockDVService := umock.NewMockDataViewService(mockCtrl)
+		mockDSLAccess := umock.NewMockDslService(mockCtrl)
+		mockDSLAccess.EXPECT().DeleteScroll(gomock.Any(), gomock.Any()).AnyTimes()
+
+		dvAdapter := dataViewAdapter{
+			dvService:  mockDVService,
+			dslService: mockDSLAccess,
+		}
+
+		model := interfaces.TraceModel{
+			SpanSourceType: interfaces.SOURCE_TYPE_DATA_VIEW,
+			SpanConfig: interfaces.SpanConfigWithDataView{
+				TraceID:     interfaces.TraceIDConfig{FieldName: "TraceId"},
+				SpanID:      interfaces.SpanIDConfig{FieldNames: []string{"SpanId"}},
+				ServiceName: interfaces.ServiceNameConfig{FieldName: "Resource.service.name"},
+				StartTime:   interfaces.StartTimeConfig{FieldName: "StartTime"},
+				EndTime:     interfaces.EndTimeConfig{FieldName: "EndTime"},
+			},
+		}
+		params := interfaces.ServiceDependencyQueryParams{Start: 1718700000000, End: 1718703600000}
+
+		Convey("Get failed, caused by the error from method 'RetrieveSingleViewData'", func() {
+			expectedErr := errors.New("some errors")
+			mockDVService.EXPECT().RetrieveSingleViewData(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&interfaces.ViewInternalResponse{}, expectedErr)
+
+			_, _, err := dvAdapter.GetSpansByTimeRange(testCtx, model, params)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Get succeed", func() {
+			astNode, _ := sonic.GetFromString(`{"TraceId": "t1", "SpanId": "s1", "StartTime": 10, "EndTime": 30, "Resource": {"service": {"name": "svc"}}}`)
+			mockDVService.EXPECT().RetrieveSingleViewData(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, viewID string, query *interfaces.DataViewQueryV1) (*interfaces.ViewInternalResponse, error) {
+					So(query.Start, ShouldEqual, params.Start)
+					So(query.End, ShouldEqual, params.End)
+					return &interfaces.ViewInternalResponse{ScrollId: "scroll", Datas: []*ast.Node{&astNode}}, nil
+				})
+
+			spans, truncated, err := dvAdapter.GetSpansByTimeRange(testCtx, model, params)
+			So(err, ShouldBeNil)
+			So(truncated, ShouldBeFalse)
+			So(len(spans), ShouldEqual, 1)
+			So(spans[0].TraceID, ShouldEqual, "t1")
+			So(spans[0].SpanID, ShouldEqual, "s1")
+			So(spans[0].ServiceName, ShouldEqual, "svc")
+			So(spans[0].Duration, ShouldEqual, 20)
+		})
+	})
+}
+
 func TestGetRelatedLogCountMap2(t *testing.T) {
 	Convey("Test GetRelatedLogCountMap", t, func() {
 		mockCtrl := gomock.NewController(t)
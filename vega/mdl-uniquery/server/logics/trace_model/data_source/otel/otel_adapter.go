@@ -0,0 +1,600 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package data_source
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic/ast"
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/sync/errgroup"
+
+	"uniquery/common"
+	cond "uniquery/common/condition"
+	vopt "uniquery/common/value_opt"
+	uerrors "uniquery/errors"
+	"uniquery/interfaces"
+	"uniquery/logics/data_view"
+	"uniquery/logics/dsl"
+)
+
+// OTel span索引(Data Prepper otel-v1-apm-span)中的字段
+const (
+	OTEL_FIELD_TRACE_ID            = "traceId"
+	OTEL_FIELD_SPAN_ID             = "spanId"
+	OTEL_FIELD_PARENT_SPAN_ID      = "parentSpanId"
+	OTEL_FIELD_NAME                = "name"
+	OTEL_FIELD_KIND                = "kind"
+	OTEL_FIELD_START_TIME          = "startTime"
+	OTEL_FIELD_END_TIME            = "endTime"
+	OTEL_FIELD_DURATION            = "durationInNanos"
+	OTEL_FIELD_SERVICE_NAME        = "serviceName"
+	OTEL_FIELD_STATUS_CODE         = "status.code"
+	OTEL_FIELD_STATUS_MESSAGE      = "status.message"
+	OTEL_FIELD_EVENTS              = "events"
+	OTEL_FIELD_LINKS               = "links"
+	OTEL_FIELD_RESOURCE_SERVICE    = "resource.attributes.service@name"
+	OTEL_PREFIX_RESOURCE_ATTRIBUTE = "resource.attributes."
+	OTEL_PREFIX_SPAN_ATTRIBUTE     = "span.attributes."
+	OTEL_PREFIX_SPAN_KIND          = "span_kind_"
+	OTEL_PREFIX_STATUS_CODE        = "status_code_"
+)
+
+var (
+	otelaOnce sync.Once
+	otela     interfaces.TraceModelAdapter
+
+	// OTel status code: 0-unset, 1-ok, 2-error
+	OTEL_STATUS_CODE_MAP = map[string]string{
+		"0": interfaces.SPAN_STATUS_UNSET,
+		"1": interfaces.SPAN_STATUS_OK,
+		"2": interfaces.SPAN_STATUS_ERROR,
+	}
+)
+
+type otelAdapter struct {
+	dvService  interfaces.DataViewService
+	dslService interfaces.DslService
+}
+
+func NewOTelAdapter(appSetting *common.AppSetting) interfaces.TraceModelAdapter {
+	otelaOnce.Do(func() {
+		otela = &otelAdapter{
+			dvService:  data_view.NewDataViewService(appSetting),
+			dslService: dsl.NewDslService(appSetting),
+		}
+	})
+	return otela
+}
+
+func (otelAdapter *otelAdapter) GetSpanList(ctx context.Context, model interfaces.TraceModel, params interfaces.SpanListQueryParams) (entries []interfaces.SpanListEntry, total int64, err error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic层: 通过otelAdapter获取span列表")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		span.End()
+	}()
+
+	spanConf, _ := model.SpanConfig.(interfaces.SpanConfigWithOTel)
+
+	// 1. 生成query, 供dataView查询使用
+	if params.TraceID != "_all" {
+		params.Condition = &cond.CondCfg{
+			Operation: cond.OperationAnd,
+			SubConds: []*cond.CondCfg{
+				otelAdapter.genEqCondition(OTEL_FIELD_TRACE_ID, params.TraceID),
+				params.Condition,
+			},
+		}
+	}
+
+	// OTel span索引没有@timestamp字段, 默认按span开始时间排序
+	sort := params.Sort
+	if sort == interfaces.DEFAULT_SORT {
+		sort = OTEL_FIELD_START_TIME
+	}
+
+	query := &interfaces.DataViewQueryV1{
+		GlobalFilters: params.Condition,
+		SortParamsV1: interfaces.SortParamsV1{
+			Sort:      sort,
+			Direction: params.Direction,
+		},
+		ViewQueryCommonParams: interfaces.ViewQueryCommonParams{
+			Offset:    params.Offset,
+			Limit:     params.Limit,
+			NeedTotal: true,
+			Format:    interfaces.Format_Original,
+		},
+	}
+
+	// 2. 基于数据视图查询Span数据
+	viewInternalResp, err := otelAdapter.dvService.RetrieveSingleViewData(ctx, spanConf.DataView.ID, query)
+	if err != nil {
+		return []interfaces.SpanListEntry{}, 0, err
+	}
+
+	if len(viewInternalResp.Datas) == 0 {
+		return []interfaces.SpanListEntry{}, 0, nil
+	}
+
+	// 3. 将viewInternalResp.Datas转换为spanList
+	total = viewInternalResp.Total
+	astNodes := viewInternalResp.Datas
+	spanList := make([]interfaces.SpanListEntry, len(astNodes))
+
+	g, _ := errgroup.WithContext(ctx)
+	g.SetLimit(40)
+	for i, astNode := range astNodes {
+		i, astNode := i, astNode
+		g.Go(
+			func() error {
+				rawSpan, _ := astNode.MapUseNumber()
+				abstractSpan := otelAdapter.extractRawSpan(astNode, false)
+				spanList[i] = otelAdapter.genSpanDetail(rawSpan, abstractSpan)
+				return nil
+			},
+		)
+	}
+
+	_ = g.Wait()
+	return spanList, total, nil
+}
+
+func (otelAdapter *otelAdapter) GetSpan(ctx context.Context, model interfaces.TraceModel, params interfaces.SpanQueryParams) (spanDetail interfaces.SpanDetail, err error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic层: 通过otelAdapter获取span详情")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		span.End()
+	}()
+
+	spanConf, _ := model.SpanConfig.(interfaces.SpanConfigWithOTel)
+
+	// 1. 根据传入的traceID与spanID构建filters
+	subConditions := []*cond.CondCfg{otelAdapter.genEqCondition(OTEL_FIELD_SPAN_ID, params.SpanID)}
+	if params.TraceID != "_all" {
+		subConditions = append(subConditions, otelAdapter.genEqCondition(OTEL_FIELD_TRACE_ID, params.TraceID))
+	}
+
+	// 2. 构建数据视图查询所需的query
+	query := &interfaces.DataViewQueryV1{
+		GlobalFilters: &cond.CondCfg{
+			Operation: cond.OperationAnd,
+			SubConds:  subConditions,
+		},
+		ViewQueryCommonParams: interfaces.ViewQueryCommonParams{
+			Limit:  1,
+			Format: interfaces.Format_Original,
+		},
+	}
+
+	// 3. 基于数据视图查询Span数据
+	viewInternalResp, err := otelAdapter.dvService.RetrieveSingleViewData(ctx, spanConf.DataView.ID, query)
+	if err != nil {
+		return interfaces.SpanDetail{}, err
+	}
+
+	if len(viewInternalResp.Datas) == 0 {
+		errDetails := fmt.Sprintf("The span whose spanID equals %s was not found!", params.SpanID)
+		logger.Error(errDetails)
+		o11y.Error(ctx, errDetails)
+		return interfaces.SpanDetail{}, rest.NewHTTPError(ctx, http.StatusNotFound,
+			uerrors.Uniquery_TraceModel_SpanNotFound).WithErrorDetails(errDetails)
+	}
+
+	// 4. 将viewInternalResp.Datas[0]转换为spanDetail
+	astNode := viewInternalResp.Datas[0]
+	rawSpan, _ := astNode.MapUseNumber()
+	abstractSpan := otelAdapter.extractRawSpan(astNode, false)
+	spanDetail = otelAdapter.genSpanDetail(rawSpan, abstractSpan)
+	return spanDetail, nil
+}
+
+func (otelAdapter *otelAdapter) GetSpanMap(ctx context.Context, model interfaces.TraceModel, params interfaces.TraceQueryParams) (briefSpanMap map[string]*interfaces.BriefSpan_, detailSpanMap map[string]interfaces.SpanDetail, err error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic层: 通过otelAdapter获取span map")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		span.End()
+	}()
+
+	spanConf, _ := model.SpanConfig.(interfaces.SpanConfigWithOTel)
+
+	// 1. 生成query, 供dataView查询使用
+	query := &interfaces.DataViewQueryV1{
+		GlobalFilters: otelAdapter.genEqCondition(OTEL_FIELD_TRACE_ID, params.TraceID),
+		Scroll:        interfaces.DEFAULT_SEARCH_SCROLL_STR,
+		ViewQueryCommonParams: interfaces.ViewQueryCommonParams{
+			Limit:  interfaces.MAX_SEARCH_SIZE,
+			Format: interfaces.Format_Original,
+		},
+	}
+
+	scrollIDs := make([]string, 0)
+	defer func() {
+		// 删除本次分页查询使用的scrollID
+		go otelAdapter.clearScrollIDs(context.Background(), scrollIDs)
+	}()
+
+	spanMap := make(map[string]*interfaces.BriefSpan_, 0)
+
+	// 2. 基于数据视图分页查询Span数据
+	for {
+		viewInternalResp, err := otelAdapter.dvService.RetrieveSingleViewData(ctx, spanConf.DataView.ID, query)
+		if err != nil {
+			return spanMap, make(map[string]interfaces.SpanDetail), err
+		}
+
+		scrollID := viewInternalResp.ScrollId
+		scrollIDs = append(scrollIDs, scrollID)
+
+		if len(viewInternalResp.Datas) == 0 {
+			break
+		}
+
+		for _, astNode := range viewInternalResp.Datas {
+			abstractSpan := otelAdapter.extractRawSpan(astNode, true)
+			spanMap[abstractSpan.SpanID] = &interfaces.BriefSpan_{
+				Key:          abstractSpan.SpanID,
+				Name:         abstractSpan.Name,
+				SpanID:       abstractSpan.SpanID,
+				ParentSpanID: abstractSpan.ParentSpanID,
+				StartTime:    abstractSpan.StartTime,
+				EndTime:      abstractSpan.EndTime,
+				Duration:     abstractSpan.Duration,
+				Kind:         abstractSpan.Kind,
+				Status:       abstractSpan.Status,
+				ServiceName:  abstractSpan.ServiceName,
+				Children:     make([]*interfaces.BriefSpan_, 0),
+			}
+		}
+
+		if len(viewInternalResp.Datas) < interfaces.MAX_SEARCH_SIZE {
+			break
+		}
+
+		query.ScrollId = scrollID
+	}
+
+	return spanMap, make(map[string]interfaces.SpanDetail), nil
+}
+
+func (otelAdapter *otelAdapter) GetSpansByTimeRange(ctx context.Context, model interfaces.TraceModel, params interfaces.ServiceDependencyQueryParams) (spans []interfaces.AbstractSpan, truncated bool, err error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic层: 通过otelAdapter获取时间范围内的span")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		span.End()
+	}()
+
+	spanConf, _ := model.SpanConfig.(interfaces.SpanConfigWithOTel)
+
+	query := &interfaces.DataViewQueryV1{
+		GlobalFilters: params.Condition,
+		Scroll:        interfaces.DEFAULT_SEARCH_SCROLL_STR,
+		ViewQueryCommonParams: interfaces.ViewQueryCommonParams{
+			Start:  params.Start,
+			End:    params.End,
+			Limit:  interfaces.MAX_SEARCH_SIZE,
+			Format: interfaces.Format_Original,
+		},
+	}
+
+	scrollIDs := make([]string, 0)
+	defer func() {
+		go otelAdapter.clearScrollIDs(context.Background(), scrollIDs)
+	}()
+
+	spans = make([]interfaces.AbstractSpan, 0)
+	for {
+		viewInternalResp, err := otelAdapter.dvService.RetrieveSingleViewData(ctx, spanConf.DataView.ID, query)
+		if err != nil {
+			return spans, false, err
+		}
+
+		scrollID := viewInternalResp.ScrollId
+		scrollIDs = append(scrollIDs, scrollID)
+
+		for _, astNode := range viewInternalResp.Datas {
+			if len(spans) >= interfaces.MAX_ANALYTICS_SPAN_SIZE {
+				return spans, true, nil
+			}
+			spans = append(spans, otelAdapter.extractRawSpan(astNode, false))
+		}
+
+		if len(viewInternalResp.Datas) < interfaces.MAX_SEARCH_SIZE {
+			break
+		}
+
+		query.ScrollId = scrollID
+	}
+
+	return spans, false, nil
+}
+
+// 关联日志由related_log_source_type对应的adapter查询, otelAdapter只负责span
+func (otelAdapter *otelAdapter) GetRelatedLogCountMap(ctx context.Context, model interfaces.TraceModel, params interfaces.TraceQueryParams) (countMap map[string]int64, err error) {
+	_, span := ar_trace.Tracer.Start(ctx, "logic层: 通过otelAdapter获取关联日志的统计信息")
+	defer func() {
+		span.SetStatus(codes.Ok, "")
+		span.End()
+	}()
+
+	return map[string]int64{}, nil
+}
+
+func (otelAdapter *otelAdapter) GetSpanRelatedLogList(ctx context.Context, model interfaces.TraceModel, params interfaces.RelatedLogListQueryParams) (entries []interfaces.RelatedLogListEntry, total int64, err error) {
+	_, span := ar_trace.Tracer.Start(ctx, "logic层: 通过otelAdapter获取span关联日志列表")
+	defer func() {
+		span.SetStatus(codes.Ok, "")
+		span.End()
+	}()
+
+	return []interfaces.RelatedLogListEntry{}, 0, nil
+}
+
+/*
+	私有方法
+*/
+
+func (otelAdapter *otelAdapter) genEqCondition(fieldName string, value string) *cond.CondCfg {
+	return &cond.CondCfg{
+		Operation: cond.OperationEq,
+		Name:      fieldName,
+		ValueOptCfg: vopt.ValueOptCfg{
+			ValueFrom: vopt.ValueFrom_Const,
+			Value:     value,
+		},
+	}
+}
+
+// Data Prepper写入的文档中, status.code等字段以带点的key平铺存储, 也兼容嵌套存储的文档
+func (otelAdapter *otelAdapter) getNode(astNode *ast.Node, fieldName string) *ast.Node {
+	if node := astNode.Get(fieldName); node.Exists() {
+		return node
+	}
+
+	paths := common.SplitString2InterfaceArray(fieldName, ".")
+	return astNode.GetByPath(paths...)
+}
+
+func (otelAdapter *otelAdapter) getString(astNode *ast.Node, fieldName string) string {
+	val, err := otelAdapter.getNode(astNode, fieldName).Interface()
+	if err != nil || val == nil {
+		return ""
+	}
+	return common.Any2String(val)
+}
+
+func (otelAdapter *otelAdapter) extractRawSpan(astNode *ast.Node, simpleInfo bool) interfaces.AbstractSpan {
+	abstractSpan := interfaces.AbstractSpan{
+		Name:         otelAdapter.getString(astNode, OTEL_FIELD_NAME),
+		SpanID:       otelAdapter.getString(astNode, OTEL_FIELD_SPAN_ID),
+		ParentSpanID: otelAdapter.getString(astNode, OTEL_FIELD_PARENT_SPAN_ID),
+	}
+
+	// 1. 提取StartTime, EndTime和Duration, 统一转换为微秒
+	abstractSpan.StartTime, abstractSpan.EndTime, abstractSpan.Duration = otelAdapter.parseTimeAndDuration(astNode)
+
+	// 2. 提取Kind, OTel的kind形如SPAN_KIND_SERVER
+	kind := strings.TrimPrefix(strings.ToLower(otelAdapter.getString(astNode, OTEL_FIELD_KIND)), OTEL_PREFIX_SPAN_KIND)
+	if val, ok := interfaces.SPAN_KIND_MAP[kind]; ok {
+		abstractSpan.Kind = val
+	} else {
+		abstractSpan.Kind = interfaces.SPAN_KIND_UNSPECIFIED
+	}
+
+	// 3. 提取Status, 兼容数值和STATUS_CODE_ERROR形式的status code
+	statusCode := otelAdapter.getString(astNode, OTEL_FIELD_STATUS_CODE)
+	if val, ok := OTEL_STATUS_CODE_MAP[statusCode]; ok {
+		abstractSpan.Status = val
+	} else if val, ok := interfaces.SPAN_STATUS_MAP[strings.TrimPrefix(strings.ToLower(statusCode), OTEL_PREFIX_STATUS_CODE)]; ok {
+		abstractSpan.Status = val
+	} else {
+		abstractSpan.Status = interfaces.SPAN_STATUS_UNSET
+	}
+
+	// 4. 提取ServiceName, serviceName为空时取资源属性service.name
+	abstractSpan.ServiceName = otelAdapter.getString(astNode, OTEL_FIELD_SERVICE_NAME)
+	if abstractSpan.ServiceName == "" {
+		abstractSpan.ServiceName = otelAdapter.getString(astNode, OTEL_FIELD_RESOURCE_SERVICE)
+	}
+
+	if !simpleInfo {
+		// 5. 提取TraceID
+		abstractSpan.TraceID = otelAdapter.getString(astNode, OTEL_FIELD_TRACE_ID)
+	}
+
+	return abstractSpan
+}
+
+func (otelAdapter *otelAdapter) parseTimeAndDuration(astNode *ast.Node) (int64, int64, int64) {
+	startTime := otelAdapter.parseTime(otelAdapter.getString(astNode, OTEL_FIELD_START_TIME))
+	endTime := otelAdapter.parseTime(otelAdapter.getString(astNode, OTEL_FIELD_END_TIME))
+
+	valInf, _ := otelAdapter.getNode(astNode, OTEL_FIELD_DURATION).Number()
+	durationInNanos, err := valInf.Int64()
+	if err != nil {
+		// 没有durationInNanos时根据开始和结束时间计算
+		return startTime, endTime, endTime - startTime
+	}
+
+	duration := durationInNanos / 1000
+	if endTime == 0 {
+		endTime = startTime + duration
+	}
+	return startTime, endTime, duration
+}
+
+// OTel的时间为RFC3339格式, 精确到纳秒
+func (otelAdapter *otelAdapter) parseTime(timeStr string) int64 {
+	if timeStr == "" {
+		return 0
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, timeStr)
+	if err != nil {
+		logger.Errorf("Parse otel span time %s err: %v", timeStr, err)
+		return 0
+	}
+	return t.UnixMicro()
+}
+
+// 根据abstractSpan补充rawSpan, 并将OTel的属性、事件、链接整理成统一的结构
+func (otelAdapter *otelAdapter) genSpanDetail(rawSpan map[string]any, abstractSpan interfaces.AbstractSpan) map[string]any {
+	if rawSpan == nil {
+		rawSpan = make(map[string]any)
+	}
+
+	rawSpan["__trace_id"] = abstractSpan.TraceID
+	rawSpan["__span_id"] = abstractSpan.SpanID
+	rawSpan["__parent_span_id"] = abstractSpan.ParentSpanID
+	rawSpan["__name"] = abstractSpan.Name
+	rawSpan["__start_time"] = abstractSpan.StartTime
+	rawSpan["__end_time"] = abstractSpan.EndTime
+	rawSpan["__duration"] = abstractSpan.Duration
+	rawSpan["__kind"] = abstractSpan.Kind
+	rawSpan["__status"] = abstractSpan.Status
+	rawSpan["__service_name"] = abstractSpan.ServiceName
+
+	rawSpan["__status_message"] = otelAdapter.getRawString(rawSpan, OTEL_FIELD_STATUS_MESSAGE)
+	rawSpan["__resource_attributes"] = otelAdapter.collectAttributes(rawSpan, OTEL_PREFIX_RESOURCE_ATTRIBUTE)
+	rawSpan["__attributes"] = otelAdapter.collectAttributes(rawSpan, OTEL_PREFIX_SPAN_ATTRIBUTE)
+	rawSpan["__events"] = otelAdapter.genEvents(rawSpan[OTEL_FIELD_EVENTS])
+	rawSpan["__links"] = otelAdapter.genLinks(rawSpan[OTEL_FIELD_LINKS])
+
+	return rawSpan
+}
+
+func (otelAdapter *otelAdapter) getRawString(rawSpan map[string]any, fieldName string) string {
+	if val, ok := rawSpan[fieldName]; ok && val != nil {
+		return common.Any2String(val)
+	}
+
+	var cur any = rawSpan
+	for _, path := range strings.Split(fieldName, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return ""
+		}
+		cur = m[path]
+	}
+
+	if cur == nil {
+		return ""
+	}
+	return common.Any2String(cur)
+}
+
+// 收集平铺存储的属性, Data Prepper将属性名中的"."替换成了"@", 这里还原成OTel的属性名
+func (otelAdapter *otelAdapter) collectAttributes(rawSpan map[string]any, prefix string) map[string]any {
+	attrs := make(map[string]any)
+	for k, v := range rawSpan {
+		if strings.HasPrefix(k, prefix) {
+			attrs[strings.ReplaceAll(strings.TrimPrefix(k, prefix), "@", ".")] = v
+		}
+	}
+
+	// 嵌套存储的属性
+	var cur any = rawSpan
+	for _, path := range strings.Split(strings.TrimSuffix(prefix, "."), ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return attrs
+		}
+		cur = m[path]
+	}
+
+	if m, ok := cur.(map[string]any); ok {
+		for k, v := range m {
+			attrs[strings.ReplaceAll(k, "@", ".")] = v
+		}
+	}
+	return attrs
+}
+
+func (otelAdapter *otelAdapter) genEvents(rawEvents any) []map[string]any {
+	events := make([]map[string]any, 0)
+	arr, _ := rawEvents.([]any)
+	for _, item := range arr {
+		rawEvent, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		timeStr, _ := rawEvent["time"].(string)
+		events = append(events, map[string]any{
+			"name":       rawEvent["name"],
+			"time":       otelAdapter.parseTime(timeStr),
+			"attributes": otelAdapter.normalizeAttributes(rawEvent["attributes"]),
+		})
+	}
+	return events
+}
+
+func (otelAdapter *otelAdapter) genLinks(rawLinks any) []map[string]any {
+	links := make([]map[string]any, 0)
+	arr, _ := rawLinks.([]any)
+	for _, item := range arr {
+		rawLink, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		links = append(links, map[string]any{
+			"trace_id":    rawLink["traceId"],
+			"span_id":     rawLink["spanId"],
+			"trace_state": rawLink["traceState"],
+			"attributes":  otelAdapter.normalizeAttributes(rawLink["attributes"]),
+		})
+	}
+	return links
+}
+
+func (otelAdapter *otelAdapter) normalizeAttributes(rawAttrs any) map[string]any {
+	attrs := make(map[string]any)
+	m, _ := rawAttrs.(map[string]any)
+	for k, v := range m {
+		attrs[strings.ReplaceAll(k, "@", ".")] = v
+	}
+	return attrs
+}
+
+// 批量清理scrollID
+func (otelAdapter *otelAdapter) clearScrollIDs(ctx context.Context, scrollIDs []string) {
+	if len(scrollIDs) == 0 {
+		return
+	}
+
+	para := interfaces.DeleteScroll{
+		ScrollId: scrollIDs,
+	}
+
+	// 返回值不接, golangci-lint报错
+	_, _, _ = otelAdapter.dslService.DeleteScroll(ctx, para)
+}
@@ -0,0 +1,275 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package data_source
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/bytedance/sonic/ast"
+	"github.com/golang/mock/gomock"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	. "github.com/smartystreets/goconvey/convey"
+
+	uerrors "uniquery/errors"
+	"uniquery/interfaces"
+	umock "uniquery/interfaces/mock"
+)
+
+var (
+	testCtx = context.WithValue(context.Background(), rest.XLangKey, rest.DefaultLanguage)
+
+	testOTelSpan = `{
+		"traceId": "t1",
+		"spanId": "s2",
+		"parentSpanId": "s1",
+		"name": "GET /api",
+		"kind": "SPAN_KIND_SERVER",
+		"startTime": "2024-06-18T08:50:49.000001Z",
+		"endTime": "2024-06-18T08:50:49.000501Z",
+		"durationInNanos": 500000,
+		"serviceName": "svc-a",
+		"status.code": 2,
+		"status.message": "timeout",
+		"resource.attributes.service@name": "svc-a",
+		"resource.attributes.host@name": "node-1",
+		"span.attributes.http@method": "GET",
+		"events": [{"name": "exception", "time": "2024-06-18T08:50:49.000101Z", "attributes": {"exception@type": "Timeout"}}],
+		"links": [{"traceId": "t0", "spanId": "s0", "traceState": "", "attributes": {}}]
+	}`
+)
+
+func TestOTelGetSpanList(t *testing.T) {
+	Convey("Test GetSpanList", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockDVService := umock.NewMockDataViewService(mockCtrl)
+		adapter := &otelAdapter{dvService: mockDVService}
+
+		model := interfaces.TraceModel{
+			SpanSourceType: interfaces.SOURCE_TYPE_OTEL,
+			SpanConfig: interfaces.SpanConfigWithOTel{
+				DataView: interfaces.DataViewConfig{ID: "v1"},
+			},
+		}
+
+		Convey("Get failed, caused by the error from method 'RetrieveSingleViewData'", func() {
+			expectedErr := errors.New("some errors")
+			mockDVService.EXPECT().RetrieveSingleViewData(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&interfaces.ViewInternalResponse{}, expectedErr)
+
+			_, _, err := adapter.GetSpanList(testCtx, model, interfaces.SpanListQueryParams{})
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Get succeed, sort by start time", func() {
+			astNode, _ := sonic.GetFromString(testOTelSpan)
+			mockDVService.EXPECT().RetrieveSingleViewData(gomock.Any(), "v1", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, viewID string, query *interfaces.DataViewQueryV1) (*interfaces.ViewInternalResponse, error) {
+					So(query.Sort, ShouldEqual, OTEL_FIELD_START_TIME)
+					So(query.GlobalFilters.SubConds[0].Name, ShouldEqual, OTEL_FIELD_TRACE_ID)
+					return &interfaces.ViewInternalResponse{Total: 1, Datas: []*ast.Node{&astNode}}, nil
+				})
+
+			entries, total, err := adapter.GetSpanList(testCtx, model, interfaces.SpanListQueryParams{
+				TraceID: "t1",
+				PaginationQueryParams: interfaces.PaginationQueryParams{
+					Sort: interfaces.DEFAULT_SORT,
+				},
+			})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 1)
+			So(entries[0]["__span_id"], ShouldEqual, "s2")
+			So(entries[0]["__status"], ShouldEqual, interfaces.SPAN_STATUS_ERROR)
+		})
+	})
+}
+
+func TestOTelGetSpan(t *testing.T) {
+	Convey("Test GetSpan", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockDVService := umock.NewMockDataViewService(mockCtrl)
+		adapter := &otelAdapter{dvService: mockDVService}
+
+		model := interfaces.TraceModel{
+			SpanSourceType: interfaces.SOURCE_TYPE_OTEL,
+			SpanConfig:     interfaces.SpanConfigWithOTel{},
+		}
+
+		Convey("Get failed, caused by span not found", func() {
+			mockDVService.EXPECT().RetrieveSingleViewData(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&interfaces.ViewInternalResponse{}, nil)
+
+			_, err := adapter.GetSpan(testCtx, model, interfaces.SpanQueryParams{TraceID: "t1", SpanID: "s2"})
+			So(err.(*rest.HTTPError).HTTPCode, ShouldEqual, http.StatusNotFound)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_TraceModel_SpanNotFound)
+		})
+
+		Convey("Get succeed", func() {
+			astNode, _ := sonic.GetFromString(testOTelSpan)
+			mockDVService.EXPECT().RetrieveSingleViewData(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&interfaces.ViewInternalResponse{Datas: []*ast.Node{&astNode}}, nil)
+
+			spanDetail, err := adapter.GetSpan(testCtx, model, interfaces.SpanQueryParams{TraceID: "t1", SpanID: "s2"})
+			So(err, ShouldBeNil)
+			So(spanDetail["__trace_id"], ShouldEqual, "t1")
+			So(spanDetail["__status_message"], ShouldEqual, "timeout")
+			So(spanDetail["__resource_attributes"], ShouldResemble, map[string]any{
+				"service.name": "svc-a",
+				"host.name":    "node-1",
+			})
+			So(spanDetail["__attributes"], ShouldResemble, map[string]any{"http.method": "GET"})
+
+			events := spanDetail["__events"].([]map[string]any)
+			So(len(events), ShouldEqual, 1)
+			So(events[0]["time"], ShouldEqual, int64(1718700649000101))
+			So(events[0]["attributes"], ShouldResemble, map[string]any{"exception.type": "Timeout"})
+
+			links := spanDetail["__links"].([]map[string]any)
+			So(len(links), ShouldEqual, 1)
+			So(links[0]["span_id"], ShouldEqual, "s0")
+		})
+	})
+}
+
+func TestOTelGetSpanMap(t *testing.T) {
+	Convey("Test GetSpanMap", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockDVService := umock.NewMockDataViewService(mockCtrl)
+		mockDSLService := umock.NewMockDslService(mockCtrl)
+		adapter := &otelAdapter{dvService: mockDVService, dslService: mockDSLService}
+		mockDSLService.EXPECT().DeleteScroll(gomock.Any(), gomock.Any()).AnyTimes()
+
+		model := interfaces.TraceModel{
+			SpanSourceType: interfaces.SOURCE_TYPE_OTEL,
+			SpanConfig:     interfaces.SpanConfigWithOTel{},
+		}
+
+		Convey("Get failed, caused by the error from method 'RetrieveSingleViewData'", func() {
+			expectedErr := errors.New("some errors")
+			mockDVService.EXPECT().RetrieveSingleViewData(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&interfaces.ViewInternalResponse{}, expectedErr)
+
+			_, _, err := adapter.GetSpanMap(testCtx, model, interfaces.TraceQueryParams{TraceID: "t1"})
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Get succeed", func() {
+			astNode, _ := sonic.GetFromString(testOTelSpan)
+			mockDVService.EXPECT().RetrieveSingleViewData(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&interfaces.ViewInternalResponse{ScrollId: "scroll", Datas: []*ast.Node{&astNode}}, nil)
+
+			briefSpanMap, detailSpanMap, err := adapter.GetSpanMap(testCtx, model, interfaces.TraceQueryParams{TraceID: "t1"})
+			So(err, ShouldBeNil)
+			So(len(detailSpanMap), ShouldEqual, 0)
+			So(briefSpanMap["s2"].ParentSpanID, ShouldEqual, "s1")
+			So(briefSpanMap["s2"].Kind, ShouldEqual, interfaces.SPAN_KIND_SERVER)
+		})
+	})
+}
+
+func TestOTelGetSpansByTimeRange(t *testing.T) {
+	Convey("Test GetSpansByTimeRange", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockDVService := umock.NewMockDataViewService(mockCtrl)
+		mockDSLService := umock.NewMockDslService(mockCtrl)
+		adapter := &otelAdapter{dvService: mockDVService, dslService: mockDSLService}
+		mockDSLService.EXPECT().DeleteScroll(gomock.Any(), gomock.Any()).AnyTimes()
+
+		model := interfaces.TraceModel{
+			SpanSourceType: interfaces.SOURCE_TYPE_OTEL,
+			SpanConfig:     interfaces.SpanConfigWithOTel{},
+		}
+		params := interfaces.ServiceDependencyQueryParams{Start: 1718700000000, End: 1718703600000}
+
+		Convey("Get failed, caused by the error from method 'RetrieveSingleViewData'", func() {
+			expectedErr := errors.New("some errors")
+			mockDVService.EXPECT().RetrieveSingleViewData(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&interfaces.ViewInternalResponse{}, expectedErr)
+
+			_, _, err := adapter.GetSpansByTimeRange(testCtx, model, params)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Get succeed", func() {
+			astNode, _ := sonic.GetFromString(testOTelSpan)
+			mockDVService.EXPECT().RetrieveSingleViewData(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, viewID string, query *interfaces.DataViewQueryV1) (*interfaces.ViewInternalResponse, error) {
+					So(query.Start, ShouldEqual, params.Start)
+					So(query.End, ShouldEqual, params.End)
+					return &interfaces.ViewInternalResponse{ScrollId: "scroll", Datas: []*ast.Node{&astNode}}, nil
+				})
+
+			spans, truncated, err := adapter.GetSpansByTimeRange(testCtx, model, params)
+			So(err, ShouldBeNil)
+			So(truncated, ShouldBeFalse)
+			So(len(spans), ShouldEqual, 1)
+			So(spans[0].TraceID, ShouldEqual, "t1")
+			So(spans[0].ParentSpanID, ShouldEqual, "s1")
+			So(spans[0].ServiceName, ShouldEqual, "svc-a")
+		})
+	})
+}
+
+func TestOTelExtractRawSpan(t *testing.T) {
+	Convey("Test extractRawSpan", t, func() {
+		adapter := &otelAdapter{}
+
+		Convey("Flattened otel span", func() {
+			astNode, _ := sonic.GetFromString(testOTelSpan)
+			abstractSpan := adapter.extractRawSpan(&astNode, false)
+			So(abstractSpan, ShouldResemble, interfaces.AbstractSpan{
+				TraceID:      "t1",
+				SpanID:       "s2",
+				ParentSpanID: "s1",
+				Name:         "GET /api",
+				StartTime:    1718700649000001,
+				EndTime:      1718700649000501,
+				Duration:     500,
+				Kind:         interfaces.SPAN_KIND_SERVER,
+				Status:       interfaces.SPAN_STATUS_ERROR,
+				ServiceName:  "svc-a",
+			})
+		})
+
+		Convey("Nested otel span without duration and service name", func() {
+			astNode, _ := sonic.GetFromString(`{
+				"traceId": "t1",
+				"spanId": "s1",
+				"parentSpanId": "",
+				"kind": "SPAN_KIND_CLIENT",
+				"startTime": "2024-06-18T08:50:49Z",
+				"endTime": "2024-06-18T08:50:50Z",
+				"status": {"code": "STATUS_CODE_OK"},
+				"resource": {"attributes": {"service@name": "svc-b"}}
+			}`)
+			abstractSpan := adapter.extractRawSpan(&astNode, true)
+			So(abstractSpan.TraceID, ShouldEqual, "")
+			So(abstractSpan.ParentSpanID, ShouldEqual, "")
+			So(abstractSpan.Duration, ShouldEqual, 1000000)
+			So(abstractSpan.Kind, ShouldEqual, interfaces.SPAN_KIND_CLIENT)
+			So(abstractSpan.Status, ShouldEqual, interfaces.SPAN_STATUS_OK)
+			So(abstractSpan.ServiceName, ShouldEqual, "svc-b")
+		})
+
+		Convey("Unknown kind and status", func() {
+			astNode, _ := sonic.GetFromString(`{"kind": "foo", "status.code": 9}`)
+			abstractSpan := adapter.extractRawSpan(&astNode, true)
+			So(abstractSpan.Kind, ShouldEqual, interfaces.SPAN_KIND_UNSPECIFIED)
+			So(abstractSpan.Status, ShouldEqual, interfaces.SPAN_STATUS_UNSET)
+		})
+	})
+}
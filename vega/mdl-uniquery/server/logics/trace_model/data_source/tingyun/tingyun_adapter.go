@@ -276,6 +276,25 @@ func (tyAdapter *tingYunwAdapter) GetSpanRelatedLogList(ctx context.Context, mod
 	return []interfaces.RelatedLogListEntry{}, 0, nil
 }
 
+// 听云API只支持按trace查询, 暂不支持拉取时间范围内的全量span
+func (tyAdapter *tingYunwAdapter) GetSpansByTimeRange(ctx context.Context, model interfaces.TraceModel, params interfaces.ServiceDependencyQueryParams) (spans []interfaces.AbstractSpan, truncated bool, err error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic层: 通过tingYunwAdapter获取时间范围内的span")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		span.End()
+	}()
+
+	errDetails := "Querying spans by time range is not supported when the span source is a tingyun data connection"
+	logger.Error(errDetails)
+	o11y.Error(ctx, errDetails)
+	return []interfaces.AbstractSpan{}, false, rest.NewHTTPError(ctx, http.StatusBadRequest, uerrors.Uniquery_TraceModel_UnsupportedSourceType).
+		WithErrorDetails(errDetails)
+}
+
 /*
 	私有方法
 */
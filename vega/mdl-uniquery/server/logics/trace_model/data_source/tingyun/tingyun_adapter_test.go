@@ -504,6 +504,21 @@ func TestGetSpanRelatedLogList(t *testing.T) {
 	})
 }
 
+func TestGetSpansByTimeRange(t *testing.T) {
+	Convey("Test GetSpansByTimeRange", t, func() {
+		tyAdapter := tingYunwAdapter{}
+
+		Convey("Get failed, caused by unsupported source type", func() {
+			model := interfaces.TraceModel{}
+			params := interfaces.ServiceDependencyQueryParams{}
+
+			_, _, err := tyAdapter.GetSpansByTimeRange(testCtx, model, params)
+			So(err.(*rest.HTTPError).HTTPCode, ShouldEqual, http.StatusBadRequest)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_TraceModel_UnsupportedSourceType)
+		})
+	})
+}
+
 /*
 	私有方法
 */
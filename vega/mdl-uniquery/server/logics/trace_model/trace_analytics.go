@@ -0,0 +1,417 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package trace_model
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"go.opentelemetry.io/otel/codes"
+
+	uerrors "uniquery/errors"
+	"uniquery/interfaces"
+	"uniquery/logics/trace_model/data_source"
+)
+
+// 服务依赖统计的累加器
+type callAccumulator struct {
+	count      int64
+	errorCount int64
+	durations  []int64
+}
+
+func (acc *callAccumulator) add(span *interfaces.AbstractSpan, withLatency bool) {
+	acc.count++
+	if span.Status == interfaces.SPAN_STATUS_ERROR {
+		acc.errorCount++
+	}
+	if withLatency {
+		acc.durations = append(acc.durations, span.Duration)
+	}
+}
+
+func (acc *callAccumulator) errorRate() float64 {
+	if acc.count == 0 {
+		return 0
+	}
+	return float64(acc.errorCount) / float64(acc.count)
+}
+
+type spanKey struct {
+	traceID string
+	spanID  string
+}
+
+type edgeKey struct {
+	source string
+	target string
+}
+
+func (tms *traceModelService) GetServiceDependencies(ctx context.Context, model interfaces.TraceModel, params interfaces.ServiceDependencyQueryParams) (graph interfaces.ServiceDependencyGraph, err error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic层: 获取服务依赖图")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		span.End()
+	}()
+
+	// 决策当前模型id的数据查询权限
+	err = tms.ps.CheckPermission(ctx, interfaces.Resource{
+		ID:   model.ID,
+		Type: interfaces.RESOURCE_TYPE_TRACE_MODEL,
+	}, []string{interfaces.OPERATION_TYPE_DATA_QUERY})
+	if err != nil {
+		return interfaces.ServiceDependencyGraph{}, err
+	}
+
+	sourceType, err := tms.getUnderlyingDataSouceType(ctx, interfaces.QUERY_CATEGORY_SPAN, model)
+	if err != nil {
+		return interfaces.ServiceDependencyGraph{}, err
+	}
+
+	adapter, err := data_source.NewTraceModelAdapter(ctx, sourceType, tms.appSetting)
+	if err != nil {
+		o11y.Error(ctx, err.Error())
+		return interfaces.ServiceDependencyGraph{}, err
+	}
+
+	spans, truncated, err := adapter.GetSpansByTimeRange(ctx, model, params)
+	if err != nil {
+		return interfaces.ServiceDependencyGraph{}, err
+	}
+
+	graph = tms.buildServiceDependencyGraph(ctx, spans)
+	graph.Start = params.Start
+	graph.End = params.End
+	graph.Truncated = truncated
+	return graph, nil
+}
+
+func (tms *traceModelService) GetCriticalPath(ctx context.Context, model interfaces.TraceModel, params interfaces.TraceQueryParams) (criticalPath interfaces.CriticalPath, err error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "logic层: 获取trace关键路径")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		span.End()
+	}()
+
+	// 决策当前模型id的数据查询权限
+	err = tms.ps.CheckPermission(ctx, interfaces.Resource{
+		ID:   model.ID,
+		Type: interfaces.RESOURCE_TYPE_TRACE_MODEL,
+	}, []string{interfaces.OPERATION_TYPE_DATA_QUERY})
+	if err != nil {
+		return interfaces.CriticalPath{}, err
+	}
+
+	sourceType, err := tms.getUnderlyingDataSouceType(ctx, interfaces.QUERY_CATEGORY_SPAN, model)
+	if err != nil {
+		return interfaces.CriticalPath{}, err
+	}
+
+	adapter, err := data_source.NewTraceModelAdapter(ctx, sourceType, tms.appSetting)
+	if err != nil {
+		o11y.Error(ctx, err.Error())
+		return interfaces.CriticalPath{}, err
+	}
+
+	briefSpanMap, _, err := adapter.GetSpanMap(ctx, model, params)
+	if err != nil {
+		return interfaces.CriticalPath{}, err
+	}
+
+	if len(briefSpanMap) == 0 {
+		errDetails := fmt.Sprintf("The trace whose traceID equals %s was not found!", params.TraceID)
+		logger.Error(errDetails)
+		o11y.Error(ctx, errDetails)
+		return interfaces.CriticalPath{}, rest.NewHTTPError(ctx, http.StatusNotFound, uerrors.Uniquery_TraceModel_TraceNotFound).
+			WithErrorDetails(errDetails)
+	}
+
+	rootSpan := tms.buildTree(ctx, briefSpanMap)
+	criticalPath = tms.computeCriticalPath(ctx, rootSpan)
+	criticalPath.TraceID = params.TraceID
+	return criticalPath, nil
+}
+
+/*
+	私有方法
+*/
+
+// 基于时间范围内的span构建服务依赖图
+// 父子span属于不同服务时, 记为父span所在服务对子span所在服务的一次调用
+func (tms *traceModelService) buildServiceDependencyGraph(ctx context.Context, spans []interfaces.AbstractSpan) interfaces.ServiceDependencyGraph {
+	_, span := ar_trace.Tracer.Start(ctx, "logic层: 构建服务依赖图")
+	defer func() {
+		span.SetStatus(codes.Ok, "")
+		span.End()
+	}()
+
+	spanIndex := make(map[spanKey]*interfaces.AbstractSpan, len(spans))
+	traceSet := make(map[string]struct{})
+	for i := range spans {
+		spanIndex[spanKey{traceID: spans[i].TraceID, spanID: spans[i].SpanID}] = &spans[i]
+		traceSet[spans[i].TraceID] = struct{}{}
+	}
+
+	nodeAccs := make(map[string]*callAccumulator)
+	edgeAccs := make(map[edgeKey]*callAccumulator)
+	for i := range spans {
+		s := &spans[i]
+
+		var parent *interfaces.AbstractSpan
+		if s.ParentSpanID != "" {
+			parent = spanIndex[spanKey{traceID: s.TraceID, spanID: s.ParentSpanID}]
+		}
+		isEntry := parent == nil || parent.ServiceName != s.ServiceName
+
+		nodeAcc, ok := nodeAccs[s.ServiceName]
+		if !ok {
+			nodeAcc = &callAccumulator{}
+			nodeAccs[s.ServiceName] = nodeAcc
+		}
+		nodeAcc.add(s, isEntry)
+
+		if parent != nil && isEntry {
+			key := edgeKey{source: parent.ServiceName, target: s.ServiceName}
+			edgeAcc, ok := edgeAccs[key]
+			if !ok {
+				edgeAcc = &callAccumulator{}
+				edgeAccs[key] = edgeAcc
+			}
+			edgeAcc.add(s, true)
+		}
+	}
+
+	graph := interfaces.ServiceDependencyGraph{
+		TraceCount: int64(len(traceSet)),
+		SpanCount:  int64(len(spans)),
+		Nodes:      make([]interfaces.ServiceNodeStats, 0, len(nodeAccs)),
+		Edges:      make([]interfaces.ServiceEdgeStats, 0, len(edgeAccs)),
+	}
+
+	for serviceName, acc := range nodeAccs {
+		graph.Nodes = append(graph.Nodes, interfaces.ServiceNodeStats{
+			ServiceName: serviceName,
+			SpanCount:   acc.count,
+			ErrorCount:  acc.errorCount,
+			ErrorRate:   acc.errorRate(),
+			Latency:     calcLatencyStats(acc.durations),
+		})
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].ServiceName < graph.Nodes[j].ServiceName
+	})
+
+	for key, acc := range edgeAccs {
+		graph.Edges = append(graph.Edges, interfaces.ServiceEdgeStats{
+			Source:     key.source,
+			Target:     key.target,
+			CallCount:  acc.count,
+			ErrorCount: acc.errorCount,
+			ErrorRate:  acc.errorRate(),
+			Latency:    calcLatencyStats(acc.durations),
+		})
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].Source != graph.Edges[j].Source {
+			return graph.Edges[i].Source < graph.Edges[j].Source
+		}
+		return graph.Edges[i].Target < graph.Edges[j].Target
+	})
+
+	return graph
+}
+
+// 计算延迟的最值、均值和分位数, 分位数采用nearest-rank方法
+func calcLatencyStats(durations []int64) interfaces.LatencyStats {
+	if len(durations) == 0 {
+		return interfaces.LatencyStats{}
+	}
+
+	sorted := append([]int64{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum int64
+	for _, d := range sorted {
+		sum += d
+	}
+
+	percentile := func(p float64) int64 {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1]
+	}
+
+	return interfaces.LatencyStats{
+		Min: sorted[0],
+		Max: sorted[len(sorted)-1],
+		Avg: sum / int64(len(sorted)),
+		P50: percentile(50),
+		P90: percentile(90),
+		P95: percentile(95),
+		P99: percentile(99),
+	}
+}
+
+// 计算trace的关键路径
+// 从根span的结束时间往前回溯, 每次选取在当前时间点之前最晚结束的子span进入其内部继续回溯,
+// 未被子span覆盖的时间段记为当前span自身在关键路径上的耗时
+func (tms *traceModelService) computeCriticalPath(ctx context.Context, rootSpan *interfaces.BriefSpan_) interfaces.CriticalPath {
+	_, span := ar_trace.Tracer.Start(ctx, "logic层: 计算trace关键路径")
+	defer func() {
+		span.SetStatus(codes.Ok, "")
+		span.End()
+	}()
+
+	criticalPath := interfaces.CriticalPath{
+		Segments: make([]interfaces.CriticalPathSegment, 0),
+		Spans:    make([]interfaces.CriticalPathSpan, 0),
+	}
+	if rootSpan == nil {
+		return criticalPath
+	}
+
+	criticalPath.StartTime = rootSpan.StartTime
+	criticalPath.EndTime = rootSpan.EndTime
+	criticalPath.Duration = rootSpan.EndTime - rootSpan.StartTime
+
+	// 1. 回溯得到的片段为倒序, 需翻转
+	segments := make([]interfaces.CriticalPathSegment, 0)
+	walkCriticalPath(rootSpan, rootSpan.EndTime, &segments)
+	for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+		segments[i], segments[j] = segments[j], segments[i]
+	}
+	criticalPath.Segments = segments
+
+	// 2. 汇总关键路径上每个span的关键耗时与自身耗时
+	spanMap := make(map[string]*interfaces.BriefSpan_)
+	queue := []*interfaces.BriefSpan_{rootSpan}
+	for len(queue) > 0 {
+		s := queue[0]
+		spanMap[s.SpanID] = s
+		queue = append(queue[1:], s.Children...)
+	}
+
+	indexes := make(map[string]int)
+	for _, segment := range segments {
+		idx, ok := indexes[segment.SpanID]
+		if !ok {
+			s := spanMap[segment.SpanID]
+			criticalPath.Spans = append(criticalPath.Spans, interfaces.CriticalPathSpan{
+				SpanID:      s.SpanID,
+				Name:        s.Name,
+				ServiceName: s.ServiceName,
+				Kind:        s.Kind,
+				Status:      s.Status,
+				StartTime:   s.StartTime,
+				EndTime:     s.EndTime,
+				Duration:    s.Duration,
+				SelfTime:    calcSelfTime(s),
+			})
+			idx = len(criticalPath.Spans) - 1
+			indexes[segment.SpanID] = idx
+		}
+		criticalPath.Spans[idx].CriticalTime += segment.Duration
+	}
+
+	sort.SliceStable(criticalPath.Spans, func(i, j int) bool {
+		return criticalPath.Spans[i].CriticalTime > criticalPath.Spans[j].CriticalTime
+	})
+
+	return criticalPath
+}
+
+func walkCriticalPath(s *interfaces.BriefSpan_, end int64, segments *[]interfaces.CriticalPathSegment) {
+	cursor := min(end, s.EndTime)
+
+	children := append([]*interfaces.BriefSpan_{}, s.Children...)
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].EndTime > children[j].EndTime
+	})
+
+	for _, child := range children {
+		if cursor <= s.StartTime {
+			break
+		}
+		// 子span在当前时间点之后才开始, 不在关键路径上
+		if child.StartTime >= cursor {
+			continue
+		}
+
+		childEnd := min(child.EndTime, cursor)
+		if childEnd < cursor {
+			appendSegment(segments, s, childEnd, cursor)
+		}
+		walkCriticalPath(child, childEnd, segments)
+		cursor = max(child.StartTime, s.StartTime)
+	}
+
+	if cursor > s.StartTime {
+		appendSegment(segments, s, s.StartTime, cursor)
+	}
+}
+
+func appendSegment(segments *[]interfaces.CriticalPathSegment, s *interfaces.BriefSpan_, start, end int64) {
+	*segments = append(*segments, interfaces.CriticalPathSegment{
+		SpanID:      s.SpanID,
+		Name:        s.Name,
+		ServiceName: s.ServiceName,
+		StartTime:   start,
+		EndTime:     end,
+		Duration:    end - start,
+	})
+}
+
+// 计算span的自身耗时, 即span耗时减去子span时间区间的并集(裁剪到span自身范围内)
+func calcSelfTime(s *interfaces.BriefSpan_) int64 {
+	type interval struct {
+		start int64
+		end   int64
+	}
+
+	intervals := make([]interval, 0, len(s.Children))
+	for _, child := range s.Children {
+		start, end := max(child.StartTime, s.StartTime), min(child.EndTime, s.EndTime)
+		if start < end {
+			intervals = append(intervals, interval{start: start, end: end})
+		}
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start < intervals[j].start })
+
+	var covered int64
+	curStart, curEnd := int64(0), int64(-1)
+	for i, iv := range intervals {
+		if i == 0 || iv.start > curEnd {
+			if i > 0 {
+				covered += curEnd - curStart
+			}
+			curStart, curEnd = iv.start, iv.end
+		} else {
+			curEnd = max(curEnd, iv.end)
+		}
+	}
+	if len(intervals) > 0 {
+		covered += curEnd - curStart
+	}
+
+	return s.EndTime - s.StartTime - covered
+}
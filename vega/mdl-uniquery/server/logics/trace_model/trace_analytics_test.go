@@ -0,0 +1,314 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package trace_model
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	. "github.com/agiledragon/gomonkey/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"uniquery/common"
+	uerrors "uniquery/errors"
+	"uniquery/interfaces"
+	umock "uniquery/interfaces/mock"
+	"uniquery/logics/trace_model/data_source"
+)
+
+func TestGetServiceDependencies(t *testing.T) {
+	Convey("Test GetServiceDependencies", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		mockTMAccess := umock.NewMockTraceModelAccess(mockCtrl)
+		mockDCAccess := umock.NewMockDataConnectionAccess(mockCtrl)
+		mockTMAdapter := umock.NewMockTraceModelAdapter(mockCtrl)
+		psMock := umock.NewMockPermissionService(mockCtrl)
+
+		mockTMService := MockNewTraceModelService(appSetting, mockTMAccess, mockDCAccess, psMock)
+
+		patch1 := ApplyPrivateMethod(reflect.TypeOf(&traceModelService{}), "getUnderlyingDataSouceType",
+			func(tmService *traceModelService, ctx context.Context, queryCategory string, model interfaces.TraceModel) (string, error) {
+				return interfaces.SOURCE_TYPE_DATA_VIEW, nil
+			})
+		defer patch1.Reset()
+
+		patch2 := ApplyFuncReturn(data_source.NewTraceModelAdapter, mockTMAdapter, nil)
+		defer patch2.Reset()
+
+		params := interfaces.ServiceDependencyQueryParams{Start: 1, End: 2}
+
+		Convey("Get failed, caused by the error from method 'CheckPermission'", func() {
+			expectedErr := rest.NewHTTPError(testCtx, http.StatusForbidden, rest.PublicError_Forbidden)
+			psMock.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedErr)
+
+			_, err := mockTMService.GetServiceDependencies(testCtx, interfaces.TraceModel{}, params)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Get failed, caused by the error from method 'GetSpansByTimeRange'", func() {
+			expectedErr := errors.New("some errors")
+			psMock.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mockTMAdapter.EXPECT().GetSpansByTimeRange(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, false, expectedErr)
+
+			_, err := mockTMService.GetServiceDependencies(testCtx, interfaces.TraceModel{}, params)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Get succeed", func() {
+			psMock.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mockTMAdapter.EXPECT().GetSpansByTimeRange(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return([]interfaces.AbstractSpan{
+					{TraceID: "t1", SpanID: "1", ServiceName: "a", Duration: 10},
+					{TraceID: "t1", SpanID: "2", ParentSpanID: "1", ServiceName: "b", Duration: 5},
+				}, true, nil)
+
+			graph, err := mockTMService.GetServiceDependencies(testCtx, interfaces.TraceModel{}, params)
+			So(err, ShouldBeNil)
+			So(graph.Start, ShouldEqual, 1)
+			So(graph.End, ShouldEqual, 2)
+			So(graph.Truncated, ShouldBeTrue)
+			So(len(graph.Nodes), ShouldEqual, 2)
+			So(len(graph.Edges), ShouldEqual, 1)
+		})
+	})
+}
+
+func TestGetCriticalPath(t *testing.T) {
+	Convey("Test GetCriticalPath", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		appSetting := &common.AppSetting{}
+		mockTMAccess := umock.NewMockTraceModelAccess(mockCtrl)
+		mockDCAccess := umock.NewMockDataConnectionAccess(mockCtrl)
+		mockTMAdapter := umock.NewMockTraceModelAdapter(mockCtrl)
+		psMock := umock.NewMockPermissionService(mockCtrl)
+
+		mockTMService := MockNewTraceModelService(appSetting, mockTMAccess, mockDCAccess, psMock)
+
+		patch1 := ApplyPrivateMethod(reflect.TypeOf(&traceModelService{}), "getUnderlyingDataSouceType",
+			func(tmService *traceModelService, ctx context.Context, queryCategory string, model interfaces.TraceModel) (string, error) {
+				return interfaces.SOURCE_TYPE_DATA_VIEW, nil
+			})
+		defer patch1.Reset()
+
+		patch2 := ApplyFuncReturn(data_source.NewTraceModelAdapter, mockTMAdapter, nil)
+		defer patch2.Reset()
+
+		params := interfaces.TraceQueryParams{TraceID: "t1"}
+
+		Convey("Get failed, caused by the error from method 'GetSpanMap'", func() {
+			expectedErr := errors.New("some errors")
+			psMock.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mockTMAdapter.EXPECT().GetSpanMap(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, nil, expectedErr)
+
+			_, err := mockTMService.GetCriticalPath(testCtx, interfaces.TraceModel{}, params)
+			So(err, ShouldResemble, expectedErr)
+		})
+
+		Convey("Get failed, caused by trace not found", func() {
+			psMock.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mockTMAdapter.EXPECT().GetSpanMap(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(map[string]*interfaces.BriefSpan_{}, map[string]interfaces.SpanDetail{}, nil)
+
+			_, err := mockTMService.GetCriticalPath(testCtx, interfaces.TraceModel{}, params)
+			So(err.(*rest.HTTPError).BaseError.ErrorCode, ShouldEqual, uerrors.Uniquery_TraceModel_TraceNotFound)
+		})
+
+		Convey("Get succeed", func() {
+			psMock.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mockTMAdapter.EXPECT().GetSpanMap(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(map[string]*interfaces.BriefSpan_{
+					"1": {SpanID: "1", StartTime: 0, EndTime: 100, Duration: 100},
+					"2": {SpanID: "2", ParentSpanID: "1", StartTime: 10, EndTime: 60, Duration: 50},
+				}, map[string]interfaces.SpanDetail{}, nil)
+
+			criticalPath, err := mockTMService.GetCriticalPath(testCtx, interfaces.TraceModel{}, params)
+			So(err, ShouldBeNil)
+			So(criticalPath.TraceID, ShouldEqual, "t1")
+			So(criticalPath.Duration, ShouldEqual, 100)
+			So(len(criticalPath.Segments), ShouldEqual, 3)
+		})
+	})
+}
+
+func TestBuildServiceDependencyGraph(t *testing.T) {
+	Convey("Test buildServiceDependencyGraph", t, func() {
+		tms := &traceModelService{}
+
+		Convey("Empty spans", func() {
+			graph := tms.buildServiceDependencyGraph(testCtx, []interfaces.AbstractSpan{})
+			So(graph.SpanCount, ShouldEqual, 0)
+			So(graph.Nodes, ShouldBeEmpty)
+			So(graph.Edges, ShouldBeEmpty)
+		})
+
+		Convey("Spans of two traces", func() {
+			spans := []interfaces.AbstractSpan{
+				// trace1: gateway -> order -> order(internal) -> db
+				{TraceID: "t1", SpanID: "1", ServiceName: "gateway", Duration: 100, Status: interfaces.SPAN_STATUS_OK},
+				{TraceID: "t1", SpanID: "2", ParentSpanID: "1", ServiceName: "order", Duration: 80, Status: interfaces.SPAN_STATUS_OK},
+				{TraceID: "t1", SpanID: "3", ParentSpanID: "2", ServiceName: "order", Duration: 30, Status: interfaces.SPAN_STATUS_OK},
+				{TraceID: "t1", SpanID: "4", ParentSpanID: "3", ServiceName: "db", Duration: 20, Status: interfaces.SPAN_STATUS_ERROR},
+				// trace2: gateway -> order, 父span不在时间范围内的db span不产生边
+				{TraceID: "t2", SpanID: "1", ServiceName: "gateway", Duration: 50, Status: interfaces.SPAN_STATUS_OK},
+				{TraceID: "t2", SpanID: "2", ParentSpanID: "1", ServiceName: "order", Duration: 40, Status: interfaces.SPAN_STATUS_ERROR},
+				{TraceID: "t2", SpanID: "5", ParentSpanID: "9", ServiceName: "db", Duration: 10, Status: interfaces.SPAN_STATUS_OK},
+			}
+
+			graph := tms.buildServiceDependencyGraph(testCtx, spans)
+			So(graph.TraceCount, ShouldEqual, 2)
+			So(graph.SpanCount, ShouldEqual, 7)
+
+			So(graph.Nodes, ShouldResemble, []interfaces.ServiceNodeStats{
+				{
+					ServiceName: "db",
+					SpanCount:   2,
+					ErrorCount:  1,
+					ErrorRate:   0.5,
+					Latency:     interfaces.LatencyStats{Min: 10, Max: 20, Avg: 15, P50: 10, P90: 20, P95: 20, P99: 20},
+				},
+				{
+					ServiceName: "gateway",
+					SpanCount:   2,
+					ErrorCount:  0,
+					ErrorRate:   0,
+					Latency:     interfaces.LatencyStats{Min: 50, Max: 100, Avg: 75, P50: 50, P90: 100, P95: 100, P99: 100},
+				},
+				{
+					ServiceName: "order",
+					SpanCount:   3,
+					ErrorCount:  1,
+					ErrorRate:   float64(1) / 3,
+					Latency:     interfaces.LatencyStats{Min: 40, Max: 80, Avg: 60, P50: 40, P90: 80, P95: 80, P99: 80},
+				},
+			})
+
+			So(graph.Edges, ShouldResemble, []interfaces.ServiceEdgeStats{
+				{
+					Source:     "gateway",
+					Target:     "order",
+					CallCount:  2,
+					ErrorCount: 1,
+					ErrorRate:  0.5,
+					Latency:    interfaces.LatencyStats{Min: 40, Max: 80, Avg: 60, P50: 40, P90: 80, P95: 80, P99: 80},
+				},
+				{
+					Source:     "order",
+					Target:     "db",
+					CallCount:  1,
+					ErrorCount: 1,
+					ErrorRate:  1,
+					Latency:    interfaces.LatencyStats{Min: 20, Max: 20, Avg: 20, P50: 20, P90: 20, P95: 20, P99: 20},
+				},
+			})
+		})
+	})
+}
+
+func TestCalcLatencyStats(t *testing.T) {
+	Convey("Test calcLatencyStats", t, func() {
+		Convey("Empty durations", func() {
+			So(calcLatencyStats(nil), ShouldResemble, interfaces.LatencyStats{})
+		})
+
+		Convey("Durations from 1 to 100", func() {
+			durations := make([]int64, 0, 100)
+			for i := int64(100); i >= 1; i-- {
+				durations = append(durations, i)
+			}
+
+			stats := calcLatencyStats(durations)
+			So(stats, ShouldResemble, interfaces.LatencyStats{
+				Min: 1, Max: 100, Avg: 50, P50: 50, P90: 90, P95: 95, P99: 99,
+			})
+			// 入参不应被排序修改
+			So(durations[0], ShouldEqual, 100)
+		})
+	})
+}
+
+func TestComputeCriticalPath(t *testing.T) {
+	Convey("Test computeCriticalPath", t, func() {
+		tms := &traceModelService{}
+
+		Convey("Nil root span", func() {
+			criticalPath := tms.computeCriticalPath(testCtx, nil)
+			So(criticalPath.Segments, ShouldBeEmpty)
+			So(criticalPath.Spans, ShouldBeEmpty)
+		})
+
+		Convey("Sequential and parallel children", func() {
+			// root [0, 100]
+			//   a [10, 40]
+			//     a1 [15, 35]
+			//   b [30, 90]  与a重叠, 结束更晚
+			//   c [50, 70]  完全被b覆盖, 不在关键路径上
+			a1 := &interfaces.BriefSpan_{SpanID: "a1", ServiceName: "s3", StartTime: 15, EndTime: 35, Duration: 20}
+			a := &interfaces.BriefSpan_{SpanID: "a", ServiceName: "s2", StartTime: 10, EndTime: 40, Duration: 30,
+				Children: []*interfaces.BriefSpan_{a1}}
+			b := &interfaces.BriefSpan_{SpanID: "b", ServiceName: "s2", StartTime: 30, EndTime: 90, Duration: 60}
+			c := &interfaces.BriefSpan_{SpanID: "c", ServiceName: "s4", StartTime: 50, EndTime: 70, Duration: 20}
+			root := &interfaces.BriefSpan_{SpanID: "root", ServiceName: "s1", StartTime: 0, EndTime: 100, Duration: 100,
+				Children: []*interfaces.BriefSpan_{a, b, c}}
+
+			criticalPath := tms.computeCriticalPath(testCtx, root)
+			So(criticalPath.StartTime, ShouldEqual, 0)
+			So(criticalPath.EndTime, ShouldEqual, 100)
+			So(criticalPath.Duration, ShouldEqual, 100)
+
+			segments := make([][3]any, 0, len(criticalPath.Segments))
+			var total int64
+			for _, segment := range criticalPath.Segments {
+				segments = append(segments, [3]any{segment.SpanID, segment.StartTime, segment.EndTime})
+				total += segment.Duration
+			}
+			So(segments, ShouldResemble, [][3]any{
+				{"root", int64(0), int64(10)},
+				{"a", int64(10), int64(15)},
+				{"a1", int64(15), int64(30)},
+				{"b", int64(30), int64(90)},
+				{"root", int64(90), int64(100)},
+			})
+			So(total, ShouldEqual, criticalPath.Duration)
+
+			So(criticalPath.Spans, ShouldResemble, []interfaces.CriticalPathSpan{
+				{SpanID: "b", ServiceName: "s2", StartTime: 30, EndTime: 90, Duration: 60, SelfTime: 60, CriticalTime: 60},
+				{SpanID: "root", ServiceName: "s1", StartTime: 0, EndTime: 100, Duration: 100, SelfTime: 20, CriticalTime: 20},
+				{SpanID: "a1", ServiceName: "s3", StartTime: 15, EndTime: 35, Duration: 20, SelfTime: 20, CriticalTime: 15},
+				{SpanID: "a", ServiceName: "s2", StartTime: 10, EndTime: 40, Duration: 30, SelfTime: 10, CriticalTime: 5},
+			})
+		})
+	})
+}
+
+func TestCalcSelfTime(t *testing.T) {
+	Convey("Test calcSelfTime", t, func() {
+		Convey("No children", func() {
+			s := &interfaces.BriefSpan_{StartTime: 0, EndTime: 10}
+			So(calcSelfTime(s), ShouldEqual, 10)
+		})
+
+		Convey("Overlapping children and child exceeding parent", func() {
+			s := &interfaces.BriefSpan_{StartTime: 0, EndTime: 100, Children: []*interfaces.BriefSpan_{
+				{StartTime: 10, EndTime: 30},
+				{StartTime: 20, EndTime: 40},
+				{StartTime: 60, EndTime: 120},
+			}}
+			So(calcSelfTime(s), ShouldEqual, 100-30-40)
+		})
+	})
+}
@@ -413,6 +413,8 @@ func (tms *traceModelService) getUnderlyingDataSouceType(ctx context.Context, qu
 
 	if queryCategory == interfaces.QUERY_CATEGORY_SPAN && model.SpanSourceType == interfaces.SOURCE_TYPE_DATA_VIEW {
 		return interfaces.SOURCE_TYPE_DATA_VIEW, nil
+	} else if queryCategory == interfaces.QUERY_CATEGORY_SPAN && model.SpanSourceType == interfaces.SOURCE_TYPE_OTEL {
+		return interfaces.SOURCE_TYPE_OTEL, nil
 	} else if queryCategory == interfaces.QUERY_CATEGORY_RELATED_LOG && model.RelatedLogSourceType == interfaces.SOURCE_TYPE_DATA_VIEW {
 		return interfaces.SOURCE_TYPE_DATA_VIEW, nil
 	} else { // queryCategory == interfaces.QUERY_CATEGORY_SPAN && model.SpanSourceType == interfaces.SOURCE_TYPE_DATA_CONNECTION
@@ -586,6 +586,15 @@ func TestGetUnderlyingDataSouceType(t *testing.T) {
 			So(sourceType, ShouldEqual, interfaces.SOURCE_TYPE_DATA_VIEW)
 		})
 
+		Convey("queryCategory is interfaces.QUERY_CATEGORY_SPAN and model.SpanSourceType is interfaces.SOURCE_TYPE_OTEL", func() {
+			sourceType, err := mockTMService.getUnderlyingDataSouceType(testCtx, interfaces.QUERY_CATEGORY_SPAN, interfaces.TraceModel{
+				SpanSourceType:       interfaces.SOURCE_TYPE_OTEL,
+				RelatedLogSourceType: interfaces.SOURCE_TYPE_DATA_VIEW,
+			})
+			So(err, ShouldBeNil)
+			So(sourceType, ShouldEqual, interfaces.SOURCE_TYPE_OTEL)
+		})
+
 		Convey("queryCategory is interfaces.QUERY_CATEGORY_RELATED_LOG and model.RelatedLogSourceType is interfaces.SOURCE_TYPE_DATA_VIEW", func() {
 			sourceType, err := mockTMService.getUnderlyingDataSouceType(testCtx, interfaces.QUERY_CATEGORY_RELATED_LOG, interfaces.TraceModel{
 				RelatedLogSourceType: interfaces.SOURCE_TYPE_DATA_VIEW,
@@ -0,0 +1,18 @@
+-- Copyright The kweaver.ai Authors.
+--
+-- Licensed under the Apache License, Version 2.0.
+-- See the LICENSE file in the project root for details.
+
+-- ==========================================
+-- t_resource_profile 数据画像缓存表
+-- ==========================================
+CREATE TABLE IF NOT EXISTS t_resource_profile (
+    f_resource_id             VARCHAR(40) NOT NULL DEFAULT '' COMMENT '所属resource ID',
+    f_sample_size             INT NOT NULL DEFAULT 0 COMMENT '请求的采样行数',
+    f_row_count               BIGINT(20) NOT NULL DEFAULT 0 COMMENT '实际参与统计的行数',
+    f_columns                 MEDIUMTEXT NOT NULL COMMENT '列统计信息（JSON数组格式）',
+    f_profile_time            BIGINT(20) NOT NULL DEFAULT 0 COMMENT '画像计算时间',
+
+    -- 索引
+    PRIMARY KEY (f_resource_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin COMMENT='数据资源画像缓存表，schema变更后失效';
@@ -0,0 +1,79 @@
+-- Copyright The kweaver.ai Authors.
+--
+-- Licensed under the Apache License, Version 2.0.
+-- See the LICENSE file in the project root for details.
+
+-- ==========================================
+-- t_connector_type 0.3.1 新增的 Connector 类型
+-- ==========================================
+
+INSERT INTO t_connector_type (f_type, f_name, f_description, f_mode, f_category, f_field_config, f_enabled)
+SELECT 'postgresql', 'postgresql', 'PostgreSQL 关系型数据库连接器', 'local', 'table',
+    '{
+        "host":     {"name":"主机地址","type":"string","description":"PostgreSQL 服务器主机地址","required":true,"encrypted":false},
+        "port":     {"name":"端口号","type":"integer","description":"PostgreSQL 服务器端口","required":true,"encrypted":false},
+        "username": {"name":"用户名","type":"string","description":"数据库用户名","required":true,"encrypted":false},
+        "password": {"name":"密码","type":"string","description":"数据库密码","required":true,"encrypted":true},
+        "database": {"name":"数据库","type":"string","description":"连接的数据库名称（可选，默认 postgres）","required":false,"encrypted":false},
+        "schemas":  {"name":"Schema 列表","type":"array","description":"Schema 名称列表（可选，为空则发现所有用户 schema）","required":false,"encrypted":false},
+        "options":  {"name":"连接参数","type":"object","description":"连接参数（如 sslmode, connect_timeout 等）","required":false,"encrypted":false}
+    }',
+    TRUE
+FROM DUAL WHERE NOT EXISTS ( SELECT f_type FROM t_connector_type WHERE f_type = 'postgresql' );
+
+INSERT INTO t_connector_type (f_type, f_name, f_description, f_mode, f_category, f_field_config, f_enabled)
+SELECT 'kafka', 'kafka', 'Kafka 消息队列连接器', 'local', 'topic',
+    '{
+        "brokers":        {"name":"Broker 列表","type":"array","description":"Kafka broker 地址列表（host:port）","required":true,"encrypted":false},
+        "username":       {"name":"用户名","type":"string","description":"SASL 认证用户名（可选）","required":false,"encrypted":false},
+        "password":       {"name":"密码","type":"string","description":"SASL 认证密码（可选）","required":false,"encrypted":true},
+        "sasl_mechanism": {"name":"SASL 机制","type":"string","description":"SASL 认证机制：PLAIN、SCRAM-SHA-256、SCRAM-SHA-512（默认 PLAIN）","required":false,"encrypted":false},
+        "tls":            {"name":"启用 TLS","type":"boolean","description":"是否使用 TLS 连接","required":false,"encrypted":false},
+        "topic_pattern":  {"name":"Topic 模式","type":"string","description":"Topic 匹配模式（可选，如 log-*）","required":false,"encrypted":false},
+        "sample_size":    {"name":"采样条数","type":"integer","description":"每个 topic 采样用于推断 schema 的最近消息条数（默认 100）","required":false,"encrypted":false}
+    }',
+    TRUE
+FROM DUAL WHERE NOT EXISTS ( SELECT f_type FROM t_connector_type WHERE f_type = 'kafka' );
+
+INSERT INTO t_connector_type (f_type, f_name, f_description, f_mode, f_category, f_field_config, f_enabled)
+SELECT 'prometheus', 'prometheus', 'Prometheus 兼容时序数据库连接器', 'local', 'metric',
+    '{
+        "url":             {"name":"服务地址","type":"string","description":"Prometheus 兼容 API 地址（如 http://localhost:9090）","required":true,"encrypted":false},
+        "username":        {"name":"用户名","type":"string","description":"Basic 认证用户名（可选）","required":false,"encrypted":false},
+        "password":        {"name":"密码","type":"string","description":"Basic 认证密码（可选）","required":false,"encrypted":true},
+        "token":           {"name":"访问令牌","type":"string","description":"Bearer Token（可选，与 Basic 认证二选一）","required":false,"encrypted":true},
+        "tls_skip_verify": {"name":"跳过证书校验","type":"boolean","description":"HTTPS 连接时是否跳过服务端证书校验","required":false,"encrypted":false},
+        "metric_pattern":  {"name":"指标模式","type":"string","description":"指标名称匹配模式（可选，如 node_*）","required":false,"encrypted":false},
+        "series_limit":    {"name":"序列采样数","type":"integer","description":"每个指标用于发现标签集合的最大序列数（默认 1000）","required":false,"encrypted":false},
+        "lookback":        {"name":"回溯时长","type":"string","description":"发现标签集合时回溯的时间范围（默认 1h）","required":false,"encrypted":false}
+    }',
+    TRUE
+FROM DUAL WHERE NOT EXISTS ( SELECT f_type FROM t_connector_type WHERE f_type = 'prometheus' );
+
+INSERT INTO t_connector_type (f_type, f_name, f_description, f_mode, f_category, f_field_config, f_enabled)
+SELECT 'localfs', 'localfs', '本地文件系统连接器', 'local', 'fileset',
+    '{
+        "root":            {"name":"根目录","type":"string","description":"服务所在主机上的目录绝对路径","required":true,"encrypted":false},
+        "follow_symlinks": {"name":"跟随软链接","type":"boolean","description":"是否跟随指向根目录之外的软链接（默认否）","required":false,"encrypted":false},
+        "file_pattern":    {"name":"文件模式","type":"string","description":"文件名匹配模式（可选，如 *.csv）","required":false,"encrypted":false},
+        "sample_size":     {"name":"采样行数","type":"integer","description":"每个文件用于推断 schema 的行数（默认 100）","required":false,"encrypted":false}
+    }',
+    TRUE
+FROM DUAL WHERE NOT EXISTS ( SELECT f_type FROM t_connector_type WHERE f_type = 'localfs' );
+
+INSERT INTO t_connector_type (f_type, f_name, f_description, f_mode, f_category, f_field_config, f_enabled)
+SELECT 's3', 's3', 'S3 兼容对象存储连接器', 'local', 'fileset',
+    '{
+        "endpoint":          {"name":"服务地址","type":"string","description":"S3 兼容服务地址（host:port，如 s3.amazonaws.com）","required":true,"encrypted":false},
+        "access_key_id":     {"name":"Access Key","type":"string","description":"访问密钥 ID","required":true,"encrypted":false},
+        "secret_access_key": {"name":"Secret Key","type":"string","description":"访问密钥","required":true,"encrypted":true},
+        "region":            {"name":"区域","type":"string","description":"存储桶所在区域（可选）","required":false,"encrypted":false},
+        "bucket":            {"name":"存储桶","type":"string","description":"存储桶名称","required":true,"encrypted":false},
+        "prefix":            {"name":"路径前缀","type":"string","description":"只发现该前缀下的对象（可选，如 warehouse/）","required":false,"encrypted":false},
+        "use_ssl":           {"name":"启用 HTTPS","type":"boolean","description":"是否使用 HTTPS 连接","required":false,"encrypted":false},
+        "path_style":        {"name":"路径风格访问","type":"boolean","description":"是否使用 path-style 地址（MinIO 等自建服务通常需要开启）","required":false,"encrypted":false},
+        "file_pattern":      {"name":"文件模式","type":"string","description":"文件名匹配模式（可选，如 *.parquet）","required":false,"encrypted":false},
+        "sample_size":       {"name":"采样行数","type":"integer","description":"每个文件用于推断 schema 的行数（默认 100）","required":false,"encrypted":false}
+    }',
+    TRUE
+FROM DUAL WHERE NOT EXISTS ( SELECT f_type FROM t_connector_type WHERE f_type = 's3' );
@@ -0,0 +1,56 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// reference-connector 以进程外方式运行内存参考连接器，用于联调 Remote Connector Protocol。
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+
+	"vega-backend/logics/connectors/remote/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":13020", "listen address")
+	flag.Parse()
+
+	gin.SetMode(gin.ReleaseMode)
+	srv := server.NewServer(server.NewMemoryConnector(nil), "1.0.0")
+
+	s := &http.Server{
+		Addr:              *addr,
+		Handler:           srv.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		err := s.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			logger.Fatalf("s.ListenAndServe err:%v", err)
+		}
+	}()
+	logger.Infof("Reference connector started on %s", *addr)
+
+	<-ctx.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		logger.Errorf("Server Shutdown:%v", err)
+	}
+	srv.Close(ctx)
+	logger.Info("Reference connector exited")
+}
@@ -41,6 +41,16 @@ type CryptoSetting struct {
 	PublicKeyPath  string `mapstructure:"publicKeyPath"`  // RSA 公钥文件路径
 }
 
+// ConnectorSetting 内置 connector 配置项
+type ConnectorSetting struct {
+	LocalFS LocalFSSetting `mapstructure:"localfs"`
+}
+
+// LocalFSSetting 本地文件系统 connector 配置项
+type LocalFSSetting struct {
+	AllowedRoots []string `mapstructure:"allowedRoots"` // 允许作为根目录的目录，未配置时不允许创建本地文件系统数据源
+}
+
 // RedisSetting Redis 配置项
 type RedisSetting struct {
 	Host     string
@@ -55,6 +65,7 @@ type AppSetting struct {
 	LogSetting           logger.LogSetting         `mapstructure:"log"`
 	ObservabilitySetting o11y.ObservabilitySetting `mapstructure:"observability"`
 	CryptoSetting        CryptoSetting             `mapstructure:"crypto"`
+	ConnectorSetting     ConnectorSetting          `mapstructure:"connector"`
 	DepServices          map[string]map[string]any `mapstructure:"depServices"`
 
 	DBSetting         libdb.DBSetting
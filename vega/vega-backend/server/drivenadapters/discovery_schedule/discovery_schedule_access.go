@@ -0,0 +1,338 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package discovery_schedule provides DiscoverySchedule data access operations.
+package discovery_schedule
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	libdb "github.com/kweaver-ai/kweaver-go-lib/db"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	attr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"vega-backend/common"
+	"vega-backend/interfaces"
+)
+
+const (
+	// 定时发现计划保存在目录的发现策略中
+	DISCOVERY_SCHEDULE_TABLE_NAME = "t_catalog_discovery_policy"
+)
+
+var (
+	dsAccessOnce sync.Once
+	dsAccess     interfaces.DiscoveryScheduleAccess
+)
+
+type discoveryScheduleAccess struct {
+	appSetting *common.AppSetting
+	db         *sql.DB
+}
+
+// NewDiscoveryScheduleAccess creates a new DiscoveryScheduleAccess.
+func NewDiscoveryScheduleAccess(appSetting *common.AppSetting) interfaces.DiscoveryScheduleAccess {
+	dsAccessOnce.Do(func() {
+		dsAccess = &discoveryScheduleAccess{
+			appSetting: appSetting,
+			db:         libdb.NewDB(&appSetting.DBSetting),
+		}
+	})
+	return dsAccess
+}
+
+func selectColumns() []string {
+	return []string{
+		"f_id",
+		"f_discovery_cron",
+		"f_enabled",
+		"f_last_run_time",
+		"f_last_task_id",
+		"f_next_run_time",
+		"f_creator",
+		"f_creator_type",
+		"f_create_time",
+		"f_updater",
+		"f_updater_type",
+		"f_update_time",
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSchedule(row rowScanner) (*interfaces.DiscoverySchedule, error) {
+	schedule := &interfaces.DiscoverySchedule{}
+	err := row.Scan(
+		&schedule.CatalogID,
+		&schedule.CronExpr,
+		&schedule.Enabled,
+		&schedule.LastRunTime,
+		&schedule.LastTaskID,
+		&schedule.NextRunTime,
+		&schedule.Creator.ID,
+		&schedule.Creator.Type,
+		&schedule.CreateTime,
+		&schedule.Updater.ID,
+		&schedule.Updater.Type,
+		&schedule.UpdateTime,
+	)
+	return schedule, err
+}
+
+// Create creates a new DiscoverySchedule.
+func (da *discoveryScheduleAccess) Create(ctx context.Context, schedule *interfaces.DiscoverySchedule) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Insert into discovery_schedule",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(
+		attr.Key("db_url").String(libdb.GetDBUrl()),
+		attr.Key("db_type").String(libdb.GetDBType()))
+
+	sqlStr, vals, err := sq.Insert(DISCOVERY_SCHEDULE_TABLE_NAME).
+		Columns(append(selectColumns(), "f_discovery_mode", "f_discovery_config", "f_change_policy_config")...).
+		Values(
+			schedule.CatalogID,
+			schedule.CronExpr,
+			schedule.Enabled,
+			schedule.LastRunTime,
+			schedule.LastTaskID,
+			schedule.NextRunTime,
+			schedule.Creator.ID,
+			schedule.Creator.Type,
+			schedule.CreateTime,
+			schedule.Updater.ID,
+			schedule.Updater.Type,
+			schedule.UpdateTime,
+			interfaces.DiscoveryModeScheduled,
+			"",
+			"",
+		).ToSql()
+	if err != nil {
+		logger.Errorf("Failed to build insert discovery_schedule sql: %v", err)
+		o11y.Error(ctx, fmt.Sprintf("Failed to build insert discovery_schedule sql: %v", err))
+		span.SetStatus(codes.Error, "Build sql failed")
+		return err
+	}
+
+	o11y.Info(ctx, fmt.Sprintf("Insert discovery_schedule SQL: %s", sqlStr))
+
+	_, err = da.db.ExecContext(ctx, sqlStr, vals...)
+	if err != nil {
+		logger.Errorf("Insert discovery_schedule failed: %v", err)
+		o11y.Error(ctx, fmt.Sprintf("Insert discovery_schedule failed: %v", err))
+		span.SetStatus(codes.Error, "Insert failed")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetByCatalogID retrieves the DiscoverySchedule of a Catalog.
+func (da *discoveryScheduleAccess) GetByCatalogID(ctx context.Context, catalogID string) (*interfaces.DiscoverySchedule, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Query discovery_schedule by catalog ID",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(attr.Key("catalog_id").String(catalogID))
+
+	sqlStr, vals, err := sq.Select(selectColumns()...).
+		From(DISCOVERY_SCHEDULE_TABLE_NAME).
+		Where(sq.Eq{"f_id": catalogID}).
+		ToSql()
+	if err != nil {
+		logger.Errorf("Failed to build select discovery_schedule sql: %v", err)
+		span.SetStatus(codes.Error, "Build sql failed")
+		return nil, err
+	}
+
+	schedule, err := scanSchedule(da.db.QueryRowContext(ctx, sqlStr, vals...))
+	if err == sql.ErrNoRows {
+		span.SetStatus(codes.Ok, "")
+		return nil, nil
+	}
+	if err != nil {
+		logger.Errorf("Scan discovery_schedule failed: %v", err)
+		span.SetStatus(codes.Error, "Scan failed")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return schedule, nil
+}
+
+// ListDue lists enabled DiscoverySchedules whose next run time is not after now.
+func (da *discoveryScheduleAccess) ListDue(ctx context.Context, now int64) ([]*interfaces.DiscoverySchedule, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "List due discovery_schedules",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	sqlStr, vals, err := sq.Select(selectColumns()...).
+		From(DISCOVERY_SCHEDULE_TABLE_NAME).
+		Where(sq.Eq{"f_discovery_mode": interfaces.DiscoveryModeScheduled}).
+		Where(sq.Eq{"f_enabled": true}).
+		Where(sq.LtOrEq{"f_next_run_time": now}).
+		OrderBy("f_next_run_time ASC").
+		ToSql()
+	if err != nil {
+		span.SetStatus(codes.Error, "Build sql failed")
+		return nil, err
+	}
+
+	rows, err := da.db.QueryContext(ctx, sqlStr, vals...)
+	if err != nil {
+		span.SetStatus(codes.Error, "Query failed")
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := make([]*interfaces.DiscoverySchedule, 0)
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, "Scan row failed")
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return schedules, nil
+}
+
+// Update updates a DiscoverySchedule's cron expression, enabled status and next run time.
+func (da *discoveryScheduleAccess) Update(ctx context.Context, schedule *interfaces.DiscoverySchedule) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Update discovery_schedule",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(attr.Key("catalog_id").String(schedule.CatalogID))
+
+	sqlStr, vals, err := sq.Update(DISCOVERY_SCHEDULE_TABLE_NAME).
+		Set("f_discovery_mode", interfaces.DiscoveryModeScheduled).
+		Set("f_discovery_cron", schedule.CronExpr).
+		Set("f_enabled", schedule.Enabled).
+		Set("f_next_run_time", schedule.NextRunTime).
+		Set("f_updater", schedule.Updater.ID).
+		Set("f_updater_type", schedule.Updater.Type).
+		Set("f_update_time", schedule.UpdateTime).
+		Where(sq.Eq{"f_id": schedule.CatalogID}).
+		ToSql()
+	if err != nil {
+		span.SetStatus(codes.Error, "Build sql failed")
+		return err
+	}
+
+	_, err = da.db.ExecContext(ctx, sqlStr, vals...)
+	if err != nil {
+		span.SetStatus(codes.Error, "Update failed")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// Claim moves a due DiscoverySchedule to its next run time, returns false if it was claimed by others.
+// 以原下次触发时间作为乐观锁条件，多实例部署时同一时刻只有一个实例能触发
+func (da *discoveryScheduleAccess) Claim(ctx context.Context, catalogID string,
+	prevNextRunTime int64, runTime int64, nextRunTime int64) (bool, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Claim discovery_schedule",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(attr.Key("catalog_id").String(catalogID))
+
+	sqlStr, vals, err := sq.Update(DISCOVERY_SCHEDULE_TABLE_NAME).
+		Set("f_last_run_time", runTime).
+		Set("f_next_run_time", nextRunTime).
+		Where(sq.Eq{"f_id": catalogID}).
+		Where(sq.Eq{"f_next_run_time": prevNextRunTime}).
+		ToSql()
+	if err != nil {
+		span.SetStatus(codes.Error, "Build sql failed")
+		return false, err
+	}
+
+	result, err := da.db.ExecContext(ctx, sqlStr, vals...)
+	if err != nil {
+		span.SetStatus(codes.Error, "Update failed")
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		span.SetStatus(codes.Error, "Get affected rows failed")
+		return false, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return affected == 1, nil
+}
+
+// UpdateLastTask records the discovery task triggered by a DiscoverySchedule.
+func (da *discoveryScheduleAccess) UpdateLastTask(ctx context.Context, catalogID string, taskID string) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Update discovery_schedule last task",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	sqlStr, vals, err := sq.Update(DISCOVERY_SCHEDULE_TABLE_NAME).
+		Set("f_last_task_id", taskID).
+		Where(sq.Eq{"f_id": catalogID}).
+		ToSql()
+	if err != nil {
+		span.SetStatus(codes.Error, "Build sql failed")
+		return err
+	}
+
+	_, err = da.db.ExecContext(ctx, sqlStr, vals...)
+	if err != nil {
+		span.SetStatus(codes.Error, "Update failed")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeleteByCatalogIDs deletes DiscoverySchedules by catalog IDs.
+func (da *discoveryScheduleAccess) DeleteByCatalogIDs(ctx context.Context, catalogIDs []string) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Delete discovery_schedules",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	if len(catalogIDs) == 0 {
+		span.SetStatus(codes.Ok, "")
+		return nil
+	}
+
+	sqlStr, vals, err := sq.Delete(DISCOVERY_SCHEDULE_TABLE_NAME).
+		Where(sq.Eq{"f_id": catalogIDs}).
+		ToSql()
+	if err != nil {
+		span.SetStatus(codes.Error, "Build sql failed")
+		return err
+	}
+
+	_, err = da.db.ExecContext(ctx, sqlStr, vals...)
+	if err != nil {
+		span.SetStatus(codes.Error, "Delete failed")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
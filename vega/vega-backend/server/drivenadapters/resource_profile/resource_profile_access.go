@@ -0,0 +1,200 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package resource_profile provides ResourceProfile data access operations.
+package resource_profile
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	libdb "github.com/kweaver-ai/kweaver-go-lib/db"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	attr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"vega-backend/common"
+	"vega-backend/interfaces"
+)
+
+const (
+	RESOURCE_PROFILE_TABLE_NAME = "t_resource_profile"
+)
+
+var (
+	rpAccessOnce sync.Once
+	rpAccess     interfaces.ResourceProfileAccess
+)
+
+type resourceProfileAccess struct {
+	appSetting *common.AppSetting
+	db         *sql.DB
+}
+
+// NewResourceProfileAccess creates a new ResourceProfileAccess.
+func NewResourceProfileAccess(appSetting *common.AppSetting) interfaces.ResourceProfileAccess {
+	rpAccessOnce.Do(func() {
+		rpAccess = &resourceProfileAccess{
+			appSetting: appSetting,
+			db:         libdb.NewDB(&appSetting.DBSetting),
+		}
+	})
+	return rpAccess
+}
+
+// GetByResourceID retrieves the cached ResourceProfile of a Resource.
+func (ra *resourceProfileAccess) GetByResourceID(ctx context.Context, resourceID string) (*interfaces.ResourceProfile, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Query resource_profile",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(attr.Key("resource_id").String(resourceID))
+
+	sqlStr, vals, err := sq.Select(
+		"f_resource_id",
+		"f_sample_size",
+		"f_row_count",
+		"f_columns",
+		"f_profile_time",
+	).From(RESOURCE_PROFILE_TABLE_NAME).
+		Where(sq.Eq{"f_resource_id": resourceID}).
+		ToSql()
+	if err != nil {
+		span.SetStatus(codes.Error, "Build sql failed")
+		return nil, err
+	}
+
+	profile := &interfaces.ResourceProfile{}
+	var columns string
+	err = ra.db.QueryRowContext(ctx, sqlStr, vals...).Scan(
+		&profile.ResourceID,
+		&profile.SampleSize,
+		&profile.RowCount,
+		&columns,
+		&profile.ProfileTime,
+	)
+	if err == sql.ErrNoRows {
+		span.SetStatus(codes.Ok, "")
+		return nil, nil
+	}
+	if err != nil {
+		logger.Errorf("Scan resource_profile failed: %v", err)
+		span.SetStatus(codes.Error, "Scan failed")
+		return nil, err
+	}
+
+	profile.Columns = []*interfaces.ColumnProfile{}
+	if columns != "" {
+		_ = json.Unmarshal([]byte(columns), &profile.Columns)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return profile, nil
+}
+
+// Upsert saves the ResourceProfile of a Resource, replacing the cached one.
+func (ra *resourceProfileAccess) Upsert(ctx context.Context, profile *interfaces.ResourceProfile) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Upsert resource_profile",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(
+		attr.Key("db_url").String(libdb.GetDBUrl()),
+		attr.Key("db_type").String(libdb.GetDBType()))
+
+	columnsBytes, _ := json.Marshal(profile.Columns)
+	if profile.Columns == nil {
+		columnsBytes = []byte("[]")
+	}
+
+	delSql, delVals, err := sq.Delete(RESOURCE_PROFILE_TABLE_NAME).
+		Where(sq.Eq{"f_resource_id": profile.ResourceID}).
+		ToSql()
+	if err != nil {
+		span.SetStatus(codes.Error, "Build sql failed")
+		return err
+	}
+	sqlStr, vals, err := sq.Insert(RESOURCE_PROFILE_TABLE_NAME).
+		Columns(
+			"f_resource_id",
+			"f_sample_size",
+			"f_row_count",
+			"f_columns",
+			"f_profile_time",
+		).
+		Values(
+			profile.ResourceID,
+			profile.SampleSize,
+			profile.RowCount,
+			string(columnsBytes),
+			profile.ProfileTime,
+		).ToSql()
+	if err != nil {
+		logger.Errorf("Failed to build insert resource_profile sql: %v", err)
+		o11y.Error(ctx, fmt.Sprintf("Failed to build insert resource_profile sql: %v", err))
+		span.SetStatus(codes.Error, "Build sql failed")
+		return err
+	}
+
+	// 先删除旧画像再写入，两步在同一事务中完成
+	tx, err := ra.db.BeginTx(ctx, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "Begin transaction failed")
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err = tx.ExecContext(ctx, delSql, delVals...); err == nil {
+		_, err = tx.ExecContext(ctx, sqlStr, vals...)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		logger.Errorf("Upsert resource_profile failed: %v", err)
+		o11y.Error(ctx, fmt.Sprintf("Upsert resource_profile failed: %v", err))
+		span.SetStatus(codes.Error, "Upsert failed")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeleteByResourceIDs deletes ResourceProfiles by resource IDs.
+func (ra *resourceProfileAccess) DeleteByResourceIDs(ctx context.Context, resourceIDs []string) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Delete resource_profile",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	if len(resourceIDs) == 0 {
+		span.SetStatus(codes.Ok, "")
+		return nil
+	}
+
+	sqlStr, vals, err := sq.Delete(RESOURCE_PROFILE_TABLE_NAME).
+		Where(sq.Eq{"f_resource_id": resourceIDs}).
+		ToSql()
+	if err != nil {
+		span.SetStatus(codes.Error, "Build sql failed")
+		return err
+	}
+
+	_, err = ra.db.ExecContext(ctx, sqlStr, vals...)
+	if err != nil {
+		span.SetStatus(codes.Error, "Delete failed")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
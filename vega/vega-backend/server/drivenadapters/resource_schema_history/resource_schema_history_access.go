@@ -0,0 +1,293 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package resource_schema_history provides ResourceSchemaHistory data access operations.
+package resource_schema_history
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	libdb "github.com/kweaver-ai/kweaver-go-lib/db"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	attr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"vega-backend/common"
+	"vega-backend/interfaces"
+)
+
+const (
+	RESOURCE_SCHEMA_HISTORY_TABLE_NAME = "t_resource_schema_history"
+)
+
+var (
+	rshAccessOnce sync.Once
+	rshAccess     interfaces.ResourceSchemaHistoryAccess
+)
+
+type resourceSchemaHistoryAccess struct {
+	appSetting *common.AppSetting
+	db         *sql.DB
+}
+
+// NewResourceSchemaHistoryAccess creates a new ResourceSchemaHistoryAccess.
+func NewResourceSchemaHistoryAccess(appSetting *common.AppSetting) interfaces.ResourceSchemaHistoryAccess {
+	rshAccessOnce.Do(func() {
+		rshAccess = &resourceSchemaHistoryAccess{
+			appSetting: appSetting,
+			db:         libdb.NewDB(&appSetting.DBSetting),
+		}
+	})
+	return rshAccess
+}
+
+func selectColumns() []string {
+	return []string{
+		"f_id",
+		"f_resource_id",
+		"f_schema_version",
+		"f_schema_definition",
+		"f_change_type",
+		"f_change_summary",
+		"f_changes",
+		"f_breaking",
+		"f_schema_inferred",
+		"f_change_time",
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanHistory(row rowScanner) (*interfaces.ResourceSchemaHistory, error) {
+	history := &interfaces.ResourceSchemaHistory{}
+	var schemaDefinition, changes string
+	err := row.Scan(
+		&history.ID,
+		&history.ResourceID,
+		&history.SchemaVersion,
+		&schemaDefinition,
+		&history.ChangeType,
+		&history.ChangeSummary,
+		&changes,
+		&history.Breaking,
+		&history.SchemaInferred,
+		&history.ChangeTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	history.SchemaDefinition = []interfaces.Property{}
+	if schemaDefinition != "" {
+		_ = json.Unmarshal([]byte(schemaDefinition), &history.SchemaDefinition)
+	}
+	history.Changes = []*interfaces.SchemaFieldChange{}
+	if changes != "" {
+		_ = json.Unmarshal([]byte(changes), &history.Changes)
+	}
+	return history, nil
+}
+
+// Create creates a ResourceSchemaHistory.
+func (ra *resourceSchemaHistoryAccess) Create(ctx context.Context, history *interfaces.ResourceSchemaHistory) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Insert into resource_schema_history",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(
+		attr.Key("db_url").String(libdb.GetDBUrl()),
+		attr.Key("db_type").String(libdb.GetDBType()))
+
+	// 序列化 schema 快照和字段变更明细
+	schemaDefinitionBytes, _ := json.Marshal(history.SchemaDefinition)
+	if history.SchemaDefinition == nil {
+		schemaDefinitionBytes = []byte("[]")
+	}
+	changesBytes, _ := json.Marshal(history.Changes)
+	if history.Changes == nil {
+		changesBytes = []byte("[]")
+	}
+
+	sqlStr, vals, err := sq.Insert(RESOURCE_SCHEMA_HISTORY_TABLE_NAME).
+		Columns(selectColumns()...).
+		Values(
+			history.ID,
+			history.ResourceID,
+			history.SchemaVersion,
+			string(schemaDefinitionBytes),
+			history.ChangeType,
+			history.ChangeSummary,
+			string(changesBytes),
+			history.Breaking,
+			history.SchemaInferred,
+			history.ChangeTime,
+		).ToSql()
+	if err != nil {
+		logger.Errorf("Failed to build insert resource_schema_history sql: %v", err)
+		o11y.Error(ctx, fmt.Sprintf("Failed to build insert resource_schema_history sql: %v", err))
+		span.SetStatus(codes.Error, "Build sql failed")
+		return err
+	}
+
+	_, err = ra.db.ExecContext(ctx, sqlStr, vals...)
+	if err != nil {
+		logger.Errorf("Insert resource_schema_history failed: %v", err)
+		o11y.Error(ctx, fmt.Sprintf("Insert resource_schema_history failed: %v", err))
+		span.SetStatus(codes.Error, "Insert failed")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetLatestByResourceID retrieves the latest ResourceSchemaHistory of a Resource.
+func (ra *resourceSchemaHistoryAccess) GetLatestByResourceID(ctx context.Context,
+	resourceID string) (*interfaces.ResourceSchemaHistory, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Query latest resource_schema_history",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(attr.Key("resource_id").String(resourceID))
+
+	sqlStr, vals, err := sq.Select(selectColumns()...).
+		From(RESOURCE_SCHEMA_HISTORY_TABLE_NAME).
+		Where(sq.Eq{"f_resource_id": resourceID}).
+		OrderBy("f_change_time DESC", "f_id DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		span.SetStatus(codes.Error, "Build sql failed")
+		return nil, err
+	}
+
+	history, err := scanHistory(ra.db.QueryRowContext(ctx, sqlStr, vals...))
+	if err == sql.ErrNoRows {
+		span.SetStatus(codes.Ok, "")
+		return nil, nil
+	}
+	if err != nil {
+		logger.Errorf("Scan resource_schema_history failed: %v", err)
+		span.SetStatus(codes.Error, "Scan failed")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return history, nil
+}
+
+// List lists ResourceSchemaHistories with filters.
+func (ra *resourceSchemaHistoryAccess) List(ctx context.Context,
+	params interfaces.ResourceSchemaHistoryQueryParams) ([]*interfaces.ResourceSchemaHistory, int64, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "List resource_schema_history",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	builder := sq.Select(selectColumns()...).From(RESOURCE_SCHEMA_HISTORY_TABLE_NAME)
+	countBuilder := sq.Select("COUNT(*)").From(RESOURCE_SCHEMA_HISTORY_TABLE_NAME)
+
+	if params.ResourceID != "" {
+		builder = builder.Where(sq.Eq{"f_resource_id": params.ResourceID})
+		countBuilder = countBuilder.Where(sq.Eq{"f_resource_id": params.ResourceID})
+	}
+	if params.ChangeType != "" {
+		builder = builder.Where(sq.Eq{"f_change_type": params.ChangeType})
+		countBuilder = countBuilder.Where(sq.Eq{"f_change_type": params.ChangeType})
+	}
+	if params.BreakingOnly {
+		builder = builder.Where(sq.Eq{"f_breaking": true})
+		countBuilder = countBuilder.Where(sq.Eq{"f_breaking": true})
+	}
+	if params.StartTime > 0 {
+		builder = builder.Where(sq.GtOrEq{"f_change_time": params.StartTime})
+		countBuilder = countBuilder.Where(sq.GtOrEq{"f_change_time": params.StartTime})
+	}
+	if params.EndTime > 0 {
+		builder = builder.Where(sq.LtOrEq{"f_change_time": params.EndTime})
+		countBuilder = countBuilder.Where(sq.LtOrEq{"f_change_time": params.EndTime})
+	}
+
+	countSql, countVals, _ := countBuilder.ToSql()
+	var total int64
+	err := ra.db.QueryRowContext(ctx, countSql, countVals...).Scan(&total)
+	if err != nil {
+		logger.Errorf("Failed to count resource_schema_history: %v", err)
+		span.SetStatus(codes.Error, "Count failed")
+		return nil, 0, err
+	}
+
+	// Pagination
+	if params.Limit > 0 {
+		builder = builder.Limit(uint64(params.Limit)).Offset(uint64(params.Offset))
+	}
+	builder = builder.OrderBy("f_change_time DESC", "f_id DESC")
+
+	sqlStr, vals, err := builder.ToSql()
+	if err != nil {
+		span.SetStatus(codes.Error, "Build sql failed")
+		return nil, 0, err
+	}
+
+	rows, err := ra.db.QueryContext(ctx, sqlStr, vals...)
+	if err != nil {
+		span.SetStatus(codes.Error, "Query failed")
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	histories := make([]*interfaces.ResourceSchemaHistory, 0)
+	for rows.Next() {
+		history, err := scanHistory(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, "Scan row failed")
+			return nil, 0, err
+		}
+		histories = append(histories, history)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return histories, total, nil
+}
+
+// DeleteByResourceIDs deletes ResourceSchemaHistories by resource IDs.
+func (ra *resourceSchemaHistoryAccess) DeleteByResourceIDs(ctx context.Context, resourceIDs []string) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Delete resource_schema_history",
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	if len(resourceIDs) == 0 {
+		span.SetStatus(codes.Ok, "")
+		return nil
+	}
+
+	sqlStr, vals, err := sq.Delete(RESOURCE_SCHEMA_HISTORY_TABLE_NAME).
+		Where(sq.Eq{"f_resource_id": resourceIDs}).
+		ToSql()
+	if err != nil {
+		span.SetStatus(codes.Error, "Build sql failed")
+		return err
+	}
+
+	_, err = ra.db.ExecContext(ctx, sqlStr, vals...)
+	if err != nil {
+		span.SetStatus(codes.Error, "Delete failed")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
@@ -360,7 +360,7 @@ func (r *restHandler) DiscoverCatalogResources(c *gin.Context) {
 	}
 
 	// Create discovery task (async)
-	taskID, err := r.dts.Create(ctx, catalog.ID)
+	taskID, err := r.dts.Create(ctx, catalog.ID, interfaces.DiscoveryTaskTriggerManual)
 	if err != nil {
 		httpErr := rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Catalog_InternalError).
 			WithErrorDetails(err.Error())
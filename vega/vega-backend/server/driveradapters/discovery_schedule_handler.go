@@ -0,0 +1,141 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package driveradapters provides HTTP handlers.
+package driveradapters
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"go.opentelemetry.io/otel/trace"
+
+	oerrors "vega-backend/errors"
+	"vega-backend/interfaces"
+)
+
+// GetDiscoverySchedule handles GET /api/vega-backend/v1/catalogs/:id/discovery-schedule
+func (r *restHandler) GetDiscoverySchedule(c *gin.Context) {
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"GetDiscoverySchedule", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := r.generateAccountInfo(c)
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	catalogID := c.Param("ids")
+
+	// Verify catalog exists
+	if _, err := r.cs.GetByID(ctx, catalogID, false); err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	schedule, err := r.dss.GetByCatalogID(ctx, catalogID)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	logger.Debug("Handler GetDiscoverySchedule Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, schedule)
+}
+
+// SetDiscoverySchedule handles PUT /api/vega-backend/v1/catalogs/:id/discovery-schedule
+func (r *restHandler) SetDiscoverySchedule(c *gin.Context) {
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"SetDiscoverySchedule", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := r.generateAccountInfo(c)
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	catalogID := c.Param("id")
+
+	var req interfaces.DiscoveryScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_InvalidParameter_RequestBody).
+			WithErrorDetails(err.Error())
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	// Verify catalog exists
+	catalog, err := r.cs.GetByID(ctx, catalogID, false)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+	// 只有物理目录支持资源发现
+	if catalog.Type != interfaces.CatalogTypePhysical {
+		httpErr := rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Catalog_InvalidParameter_Type).
+			WithErrorDetails("discovery schedule is only supported for physical catalogs")
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	schedule, err := r.dss.Set(ctx, catalogID, &req)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	logger.Debug("Handler SetDiscoverySchedule Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, schedule)
+}
+
+// DeleteDiscoverySchedule handles DELETE /api/vega-backend/v1/catalogs/:id/discovery-schedule
+func (r *restHandler) DeleteDiscoverySchedule(c *gin.Context) {
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"DeleteDiscoverySchedule", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := r.generateAccountInfo(c)
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	catalogID := c.Param("ids")
+
+	// Verify schedule exists
+	if _, err := r.dss.GetByCatalogID(ctx, catalogID); err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	if err := r.dss.Delete(ctx, catalogID); err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	logger.Debug("Handler DeleteDiscoverySchedule Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusNoContent)
+	rest.ReplyOK(c, http.StatusNoContent, nil)
+}
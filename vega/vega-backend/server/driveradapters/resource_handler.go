@@ -260,3 +260,185 @@ func (r *restHandler) DeleteResources(c *gin.Context) {
 	o11y.AddHttpAttrs4Ok(span, http.StatusNoContent)
 	rest.ReplyOK(c, http.StatusNoContent, nil)
 }
+
+// ListResourceSchemaHistory handles GET /api/vega-backend/v1/resources/:id/schema-history
+func (r *restHandler) ListResourceSchemaHistory(c *gin.Context) {
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"ListResourceSchemaHistory", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := r.generateAccountInfo(c)
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	id := c.Param("ids")
+
+	// Check if id exists
+	exists, err := r.rs.CheckExistByID(ctx, id)
+	if err != nil {
+		httpErr := rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.VegaManager_Resource_InternalError).WithErrorDetails(err.Error())
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+	if !exists {
+		httpErr := rest.NewHTTPError(ctx, http.StatusNotFound,
+			oerrors.VegaManager_Resource_NotFound).WithErrorDetails(fmt.Sprintf("id %s not found", id))
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	params, err := parseSchemaHistoryQueryParams(ctx, c, id)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	entries, total, err := r.rs.ListSchemaHistory(ctx, params)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	result := map[string]any{
+		"entries":     entries,
+		"total_count": total,
+	}
+
+	logger.Debug("Handler ListResourceSchemaHistory Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, result)
+}
+
+// PreviewResource handles GET /api/vega-backend/v1/resources/:id/preview
+func (r *restHandler) PreviewResource(c *gin.Context) {
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"PreviewResource", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := r.generateAccountInfo(c)
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	id := c.Param("ids")
+
+	limit, err := parsePreviewLimit(ctx, c)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	resource, err := r.rs.GetByID(ctx, id)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	preview, err := r.rs.Preview(ctx, resource, limit)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	logger.Debug("Handler PreviewResource Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, preview)
+}
+
+// GetResourceProfile handles GET /api/vega-backend/v1/resources/:id/profile
+func (r *restHandler) GetResourceProfile(c *gin.Context) {
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"GetResourceProfile", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := r.generateAccountInfo(c)
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	id := c.Param("ids")
+
+	params, err := parseProfileParams(ctx, c)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	resource, err := r.rs.GetByID(ctx, id)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	profile, err := r.rs.GetProfile(ctx, resource, params)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	logger.Debug("Handler GetResourceProfile Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, profile)
+}
+
+// QueryResourceMetric handles GET /api/vega-backend/v1/resources/:id/metric-data
+func (r *restHandler) QueryResourceMetric(c *gin.Context) {
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c),
+		"QueryResourceMetric", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	accountInfo := r.generateAccountInfo(c)
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, accountInfo)
+
+	o11y.AddHttpAttrs4API(span, o11y.GetAttrsByGinCtx(c))
+
+	id := c.Param("ids")
+
+	params, err := parseMetricQueryParams(ctx, c)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	resource, err := r.rs.GetByID(ctx, id)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	data, err := r.rs.QueryMetric(ctx, resource, params)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, httpErr)
+		return
+	}
+
+	logger.Debug("Handler QueryResourceMetric Success")
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, data)
+}
@@ -20,6 +20,7 @@ import (
 	"vega-backend/interfaces"
 	"vega-backend/logics/catalog"
 	connectortype "vega-backend/logics/connector_type"
+	discoveryschedule "vega-backend/logics/discovery_schedule"
 	discoverytask "vega-backend/logics/discovery_task"
 	"vega-backend/logics/resource"
 	"vega-backend/version"
@@ -36,6 +37,7 @@ type restHandler struct {
 	rs         interfaces.ResourceService
 	cts        interfaces.ConnectorTypeService
 	dts        interfaces.DiscoveryTaskService // 任务服务
+	dss        interfaces.DiscoveryScheduleService
 }
 
 // NewRestHandler creates a new RestHandler.
@@ -48,6 +50,7 @@ func NewRestHandler(appSetting *common.AppSetting) RestHandler {
 		rs:         rs,
 		cts:        connectortype.NewConnectorTypeService(appSetting),
 		dts:        discoverytask.NewDiscoveryTaskService(appSetting),
+		dss:        discoveryschedule.NewDiscoveryScheduleService(appSetting),
 	}
 }
 
@@ -72,6 +75,9 @@ func (r *restHandler) RegisterPublic(engine *gin.Engine) {
 			catalogs.POST("/:id/test-connection", r.TestConnection)
 			catalogs.POST("/:id/discover", r.DiscoverCatalogResources)
 			catalogs.GET("/:ids/resources", r.ListCatalogResources)
+			catalogs.GET("/:ids/discovery-schedule", r.GetDiscoverySchedule)
+			catalogs.PUT("/:id/discovery-schedule", r.verifyJsonContentType(), r.SetDiscoverySchedule)
+			catalogs.DELETE("/:ids/discovery-schedule", r.DeleteDiscoverySchedule)
 		}
 
 		// Resource APIs
@@ -82,6 +88,10 @@ func (r *restHandler) RegisterPublic(engine *gin.Engine) {
 			resources.GET("/:ids", r.GetResources)
 			resources.PUT("/:id", r.verifyJsonContentType(), r.UpdateResource)
 			resources.DELETE("/:ids", r.DeleteResources)
+			resources.GET("/:ids/schema-history", r.ListResourceSchemaHistory)
+			resources.GET("/:ids/preview", r.PreviewResource)
+			resources.GET("/:ids/profile", r.GetResourceProfile)
+			resources.GET("/:ids/metric-data", r.QueryResourceMetric)
 		}
 
 		// ConnectorType APIs
@@ -7,7 +7,15 @@ package driveradapters
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+
+	oerrors "vega-backend/errors"
 	"vega-backend/interfaces"
 )
 
@@ -23,3 +31,150 @@ func ValidateResourceRequest(ctx context.Context, req *interfaces.ResourceReques
 	}
 	return nil
 }
+
+// 解析并校验 schema 变更历史的查询参数
+func parseSchemaHistoryQueryParams(ctx context.Context, c *gin.Context,
+	resourceID string) (interfaces.ResourceSchemaHistoryQueryParams, error) {
+
+	params := interfaces.ResourceSchemaHistoryQueryParams{
+		PaginationParams: interfaces.PaginationParams{
+			Offset: getIntQuery(c, "offset", interfaces.DefaultOffset),
+			Limit:  getIntQuery(c, "limit", interfaces.DefaultLimit),
+		},
+		ResourceID: resourceID,
+		ChangeType: c.Query("change_type"),
+	}
+
+	switch params.ChangeType {
+	case "", interfaces.SchemaChangeCreated, interfaces.SchemaChangeFieldAdded, interfaces.SchemaChangeFieldRemoved,
+		interfaces.SchemaChangeFieldModified, interfaces.SchemaChangeTypeChanged:
+	default:
+		return params, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+			WithErrorDetails(fmt.Sprintf("invalid change_type: %s", params.ChangeType))
+	}
+
+	if v := c.Query("breaking_only"); v != "" {
+		breakingOnly, err := strconv.ParseBool(v)
+		if err != nil {
+			return params, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+				WithErrorDetails(fmt.Sprintf("invalid breaking_only: %s", v))
+		}
+		params.BreakingOnly = breakingOnly
+	}
+
+	for key, target := range map[string]*int64{"start_time": &params.StartTime, "end_time": &params.EndTime} {
+		v := c.Query(key)
+		if v == "" {
+			continue
+		}
+		t, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || t < 0 {
+			return params, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+				WithErrorDetails(fmt.Sprintf("invalid %s: %s", key, v))
+		}
+		*target = t
+	}
+	if params.StartTime > 0 && params.EndTime > 0 && params.StartTime > params.EndTime {
+		return params, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+			WithErrorDetails("start_time must not be later than end_time")
+	}
+
+	return params, nil
+}
+
+// 解析并校验数据预览的行数
+func parsePreviewLimit(ctx context.Context, c *gin.Context) (int, error) {
+	limit := interfaces.DEFAULT_PREVIEW_LIMIT
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > interfaces.MAX_PREVIEW_LIMIT {
+			return 0, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+				WithErrorDetails(fmt.Sprintf("limit must be an integer between 1 and %d", interfaces.MAX_PREVIEW_LIMIT))
+		}
+		limit = l
+	}
+	return limit, nil
+}
+
+// 解析并校验指标查询参数。time 为即时查询时间，start、end、step 同时给出时为区间查询，时间均为毫秒时间戳
+func parseMetricQueryParams(ctx context.Context, c *gin.Context) (interfaces.ResourceMetricQueryParams, error) {
+	params := interfaces.ResourceMetricQueryParams{}
+
+	parseTime := func(name string) (int64, error) {
+		v := c.Query(name)
+		if v == "" {
+			return 0, nil
+		}
+		t, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || t <= 0 {
+			return 0, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+				WithErrorDetails(fmt.Sprintf("%s must be a positive millisecond timestamp", name))
+		}
+		return t, nil
+	}
+
+	var err error
+	if params.Time, err = parseTime("time"); err != nil {
+		return params, err
+	}
+	if params.Start, err = parseTime("start"); err != nil {
+		return params, err
+	}
+	if params.End, err = parseTime("end"); err != nil {
+		return params, err
+	}
+
+	step := c.Query("step")
+	if params.Start == 0 && params.End == 0 && step == "" {
+		return params, nil
+	}
+	if params.Time > 0 {
+		return params, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+			WithErrorDetails("time can not be used together with start, end and step")
+	}
+	if params.Start == 0 || params.End == 0 || step == "" {
+		return params, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+			WithErrorDetails("start, end and step are all required for a range query")
+	}
+	if params.End < params.Start {
+		return params, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+			WithErrorDetails("end must not be before start")
+	}
+	if params.Step, err = time.ParseDuration(step); err != nil || params.Step <= 0 {
+		return params, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+			WithErrorDetails(fmt.Sprintf("step must be a positive duration such as 30s or 1m, got %s", step))
+	}
+	points := time.Duration(params.End-params.Start)*time.Millisecond/params.Step + 1
+	if points > interfaces.MAX_METRIC_QUERY_POINTS {
+		return params, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+			WithErrorDetails(fmt.Sprintf("range query can not exceed %d points per series, increase step", interfaces.MAX_METRIC_QUERY_POINTS))
+	}
+	return params, nil
+}
+
+// 解析并校验数据画像的查询参数
+func parseProfileParams(ctx context.Context, c *gin.Context) (interfaces.ResourceProfileParams, error) {
+	params := interfaces.ResourceProfileParams{
+		SampleSize: interfaces.DEFAULT_PROFILE_SAMPLE_SIZE,
+	}
+
+	if v := c.Query("sample_size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 1 || size > interfaces.MAX_PROFILE_SAMPLE_SIZE {
+			return params, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+				WithErrorDetails(fmt.Sprintf("sample_size must be an integer between 1 and %d", interfaces.MAX_PROFILE_SAMPLE_SIZE))
+		}
+		params.SampleSize = size
+	}
+
+	if v := c.Query("refresh"); v != "" {
+		refresh, err := strconv.ParseBool(v)
+		if err != nil {
+			return params, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_InvalidParameter).
+				WithErrorDetails(fmt.Sprintf("invalid refresh: %s", v))
+		}
+		params.Refresh = refresh
+	}
+
+	return params, nil
+}
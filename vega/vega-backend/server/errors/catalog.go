@@ -14,6 +14,7 @@ const (
 	VegaManager_Catalog_InvalidParameter_Name            = "VegaManager.Catalog.InvalidParameter.Name"
 	VegaManager_Catalog_InvalidParameter_ConnectorType   = "VegaManager.Catalog.InvalidParameter.ConnectorType"
 	VegaManager_Catalog_InvalidParameter_ConnectorConfig = "VegaManager.Catalog.InvalidParameter.ConnectorConfig"
+	VegaManager_Catalog_InvalidParameter_CronExpr        = "VegaManager.Catalog.InvalidParameter.CronExpr"
 	VegaManager_Catalog_LengthExceeded_Name              = "VegaManager.Catalog.LengthExceeded.Name"
 	VegaManager_Catalog_LengthExceeded_Description       = "VegaManager.Catalog.LengthExceeded.Description"
 
@@ -23,6 +24,9 @@ const (
 	VegaManager_Catalog_HasAssets  = "VegaManager.Catalog.HasAssets"
 	VegaManager_Catalog_IsDisabled = "VegaManager.Catalog.IsDisabled"
 
+	// 404 Not Found
+	VegaManager_Catalog_DiscoveryScheduleNotFound = "VegaManager.Catalog.DiscoveryScheduleNotFound"
+
 	// 500 Internal Server Error
 	VegaManager_Catalog_InternalError                      = "VegaManager.Catalog.InternalError"
 	VegaManager_Catalog_InternalError_CreateFailed         = "VegaManager.Catalog.InternalError.CreateFailed"
@@ -41,12 +45,14 @@ var CatalogErrCodeList = []string{
 	VegaManager_Catalog_InvalidParameter_Name,
 	VegaManager_Catalog_InvalidParameter_ConnectorType,
 	VegaManager_Catalog_InvalidParameter_ConnectorConfig,
+	VegaManager_Catalog_InvalidParameter_CronExpr,
 	VegaManager_Catalog_LengthExceeded_Name,
 	VegaManager_Catalog_LengthExceeded_Description,
 	VegaManager_Catalog_NotFound,
 	VegaManager_Catalog_NameExists,
 	VegaManager_Catalog_HasAssets,
 	VegaManager_Catalog_IsDisabled,
+	VegaManager_Catalog_DiscoveryScheduleNotFound,
 	VegaManager_Catalog_InternalError,
 	VegaManager_Catalog_InternalError_CreateFailed,
 	VegaManager_Catalog_InternalError_GetFailed,
@@ -15,6 +15,8 @@ const (
 	VegaManager_Resource_InvalidParameter_CatalogID = "VegaManager.Resource.InvalidParameter.CatalogID"
 	VegaManager_Resource_LengthExceeded_Name        = "VegaManager.Resource.LengthExceeded.Name"
 	VegaManager_Resource_LengthExceeded_Description = "VegaManager.Resource.LengthExceeded.Description"
+	VegaManager_Resource_PreviewNotSupported        = "VegaManager.Resource.PreviewNotSupported"
+	VegaManager_Resource_MetricQueryNotSupported    = "VegaManager.Resource.MetricQueryNotSupported"
 
 	// 403 Forbidden
 	VegaManager_Resource_NotFound        = "VegaManager.Resource.NotFound"
@@ -31,6 +33,7 @@ const (
 	VegaManager_Resource_InternalError_UpdateFailed = "VegaManager.Resource.InternalError.UpdateFailed"
 	VegaManager_Resource_InternalError_DeleteFailed = "VegaManager.Resource.InternalError.DeleteFailed"
 	VegaManager_Resource_InternalError_SyncFailed   = "VegaManager.Resource.InternalError.SyncFailed"
+	VegaManager_Resource_InternalError_ReadFailed   = "VegaManager.Resource.InternalError.ReadFailed"
 )
 
 var ResourceErrCodeList = []string{
@@ -40,6 +43,8 @@ var ResourceErrCodeList = []string{
 	VegaManager_Resource_InvalidParameter_CatalogID,
 	VegaManager_Resource_LengthExceeded_Name,
 	VegaManager_Resource_LengthExceeded_Description,
+	VegaManager_Resource_PreviewNotSupported,
+	VegaManager_Resource_MetricQueryNotSupported,
 	VegaManager_Resource_NotFound,
 	VegaManager_Resource_NameExists,
 	VegaManager_Resource_CatalogNotFound,
@@ -52,4 +57,5 @@ var ResourceErrCodeList = []string{
 	VegaManager_Resource_InternalError_UpdateFailed,
 	VegaManager_Resource_InternalError_DeleteFailed,
 	VegaManager_Resource_InternalError_SyncFailed,
+	VegaManager_Resource_InternalError_ReadFailed,
 }
@@ -68,18 +68,77 @@ type QueryResult struct {
 
 // FileMeta represents file metadata.
 type FileMeta struct {
-	Path         string `json:"path"`
-	Name         string `json:"name"`
-	Size         int64  `json:"size"`
-	LastModified int64  `json:"last_modified"`
-	ContentType  string `json:"content_type"`
+	Path         string         `json:"path"`
+	Name         string         `json:"name"`
+	Size         int64          `json:"size"`
+	LastModified int64          `json:"last_modified"`
+	ContentType  string         `json:"content_type"`
+	Format       string         `json:"format"`     // csv | jsonl | parquet | excel，无法识别时为空
+	Properties   map[string]any `json:"properties"` // 扩展属性：row_count, delimiter, sheet 等
+	Columns      []ColumnMeta   `json:"columns"`    // 采样推断或文件自带的 schema
+}
+
+// FilesetMeta represents a group of files sharing one schema, e.g. a Hive-style partitioned directory.
+type FilesetMeta struct {
+	Path          string         `json:"path"` // 文件集根目录
+	Name          string         `json:"name"`
+	Format        string         `json:"format"`
+	PartitionKeys []string       `json:"partition_keys"` // 目录中 key=value 形式的分区键，按层级排列
+	FileCount     int            `json:"file_count"`
+	TotalSize     int64          `json:"total_size"`
+	LastModified  int64          `json:"last_modified"`
+	Properties    map[string]any `json:"properties"` // 扩展属性：partition_count, sample_file 等
+	Columns       []ColumnMeta   `json:"columns"`    // 文件列在前，分区列在后
 }
 
 // TopicMeta represents message topic metadata.
 type TopicMeta struct {
-	Name       string `json:"name"`
-	Partitions int    `json:"partitions"`
-	Replicas   int    `json:"replicas"`
+	Name           string             `json:"name"`
+	Partitions     int                `json:"partitions"`
+	Replicas       int                `json:"replicas"`
+	Properties     map[string]any     `json:"properties"`      // 扩展属性：message_count, retention_ms, cleanup_policy 等
+	Fields         []TopicField       `json:"fields"`          // 采样消息推断出的 schema
+	ConsumerGroups []ConsumerGroupLag `json:"consumer_groups"` // 订阅该 topic 的消费组及其积压
+}
+
+// TopicField represents a message field inferred from sampled messages.
+type TopicField struct {
+	Name     string `json:"name"`      // 字段路径，嵌套对象以 . 连接
+	Type     string `json:"type"`      // VEGA 类型
+	OrigType string `json:"orig_type"` // 采样到的 JSON 类型，多种类型以 | 连接
+	Nullable bool   `json:"nullable"`  // 采样中是否出现缺失或 null
+}
+
+// ConsumerGroupLag represents consumer lag of a consumer group on a topic.
+type ConsumerGroupLag struct {
+	GroupID    string         `json:"group_id"`
+	Lag        int64          `json:"lag"`
+	Partitions []PartitionLag `json:"partitions"`
+}
+
+// PartitionLag represents consumer lag on a single partition.
+type PartitionLag struct {
+	Partition       int   `json:"partition"`
+	CommittedOffset int64 `json:"committed_offset"`
+	EndOffset       int64 `json:"end_offset"`
+	Lag             int64 `json:"lag"`
+}
+
+// MetricMeta represents time-series metric metadata.
+type MetricMeta struct {
+	Name        string        `json:"name"`
+	Type        string        `json:"type"` // counter, gauge, histogram, summary, unknown 等
+	Help        string        `json:"help"`
+	Unit        string        `json:"unit"`
+	SeriesCount int           `json:"series_count"` // 采样到的序列数，受 series_limit 限制
+	Labels      []MetricLabel `json:"labels"`
+}
+
+// MetricLabel represents a label of a metric and its sampled values.
+type MetricLabel struct {
+	Name        string   `json:"name"`
+	Values      []string `json:"values"`      // 采样到的取值，最多保留若干个
+	Cardinality int      `json:"cardinality"` // 采样序列中的不同取值个数
 }
 
 // MetricResult represents time-series query result.
@@ -0,0 +1,34 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package interfaces defines entities, DTOs, and service interfaces.
+package interfaces
+
+const (
+	// DiscoveryModeScheduled is the catalog discovery mode driven by cron schedule.
+	DiscoveryModeScheduled string = "scheduled"
+)
+
+// DiscoverySchedule represents a per-catalog scheduled discovery entity.
+type DiscoverySchedule struct {
+	CatalogID string `json:"catalog_id"`
+	CronExpr  string `json:"cron_expr"` // 标准 5 段 cron 表达式，如 "0 2 * * *"
+	Enabled   bool   `json:"enabled"`
+
+	LastRunTime int64  `json:"last_run_time"` // 上次触发时间
+	LastTaskID  string `json:"last_task_id"`  // 上次触发的发现任务ID
+	NextRunTime int64  `json:"next_run_time"` // 下次触发时间
+
+	Creator    AccountInfo `json:"creator"`
+	CreateTime int64       `json:"create_time"`
+	Updater    AccountInfo `json:"updater"`
+	UpdateTime int64       `json:"update_time"`
+}
+
+// DiscoveryScheduleRequest represents set discovery schedule request.
+type DiscoveryScheduleRequest struct {
+	CronExpr string `json:"cron_expr"`
+	Enabled  bool   `json:"enabled"`
+}
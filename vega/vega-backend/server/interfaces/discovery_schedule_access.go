@@ -0,0 +1,27 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package interfaces defines entities, DTOs, and service interfaces.
+package interfaces
+
+import "context"
+
+// DiscoveryScheduleAccess defines discovery schedule data access interface.
+type DiscoveryScheduleAccess interface {
+	// Create creates a new DiscoverySchedule.
+	Create(ctx context.Context, schedule *DiscoverySchedule) error
+	// GetByCatalogID retrieves the DiscoverySchedule of a Catalog.
+	GetByCatalogID(ctx context.Context, catalogID string) (*DiscoverySchedule, error)
+	// ListDue lists enabled DiscoverySchedules whose next run time is not after now.
+	ListDue(ctx context.Context, now int64) ([]*DiscoverySchedule, error)
+	// Update updates a DiscoverySchedule's cron expression, enabled status and next run time.
+	Update(ctx context.Context, schedule *DiscoverySchedule) error
+	// Claim moves a due DiscoverySchedule to its next run time, returns false if it was claimed by others.
+	Claim(ctx context.Context, catalogID string, prevNextRunTime int64, runTime int64, nextRunTime int64) (bool, error)
+	// UpdateLastTask records the discovery task triggered by a DiscoverySchedule.
+	UpdateLastTask(ctx context.Context, catalogID string, taskID string) error
+	// DeleteByCatalogIDs deletes DiscoverySchedules by catalog IDs.
+	DeleteByCatalogIDs(ctx context.Context, catalogIDs []string) error
+}
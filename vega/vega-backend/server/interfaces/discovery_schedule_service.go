@@ -0,0 +1,24 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package interfaces defines entities, DTOs, and service interfaces.
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// DiscoveryScheduleService defines discovery schedule business logic interface.
+type DiscoveryScheduleService interface {
+	// GetByCatalogID retrieves the DiscoverySchedule of a Catalog.
+	GetByCatalogID(ctx context.Context, catalogID string) (*DiscoverySchedule, error)
+	// Set creates or updates the DiscoverySchedule of a Catalog.
+	Set(ctx context.Context, catalogID string, req *DiscoveryScheduleRequest) (*DiscoverySchedule, error)
+	// Delete deletes the DiscoverySchedule of a Catalog.
+	Delete(ctx context.Context, catalogID string) error
+	// TriggerDue creates scheduled discovery tasks for all due DiscoverySchedules.
+	TriggerDue(ctx context.Context, now time.Time) (int, error)
+}
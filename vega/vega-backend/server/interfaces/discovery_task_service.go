@@ -10,8 +10,8 @@ import "context"
 
 // DiscoveryTaskService defines discovery task business logic interface.
 type DiscoveryTaskService interface {
-	// Create creates a new DiscoveryTask and sends message to Kafka.
-	Create(ctx context.Context, catalogID string) (string, error)
+	// Create creates a new DiscoveryTask and enqueues it to the task queue.
+	Create(ctx context.Context, catalogID string, triggerType string) (string, error)
 	// GetByID retrieves a DiscoveryTask by ID.
 	GetByID(ctx context.Context, id string) (*DiscoveryTask, error)
 	// List lists DiscoveryTasks for a catalog.
@@ -0,0 +1,87 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package interfaces defines entities, DTOs, and service interfaces.
+package interfaces
+
+import "time"
+
+const (
+	// Resource preview and profile limits.
+	DEFAULT_PREVIEW_LIMIT       = 10
+	MAX_PREVIEW_LIMIT           = 1000
+	DEFAULT_PROFILE_SAMPLE_SIZE = 1000
+	MAX_PROFILE_SAMPLE_SIZE     = 10000
+	PROFILE_TOP_VALUES_NUMBER   = 10
+	PROFILE_HISTOGRAM_BUCKETS   = 10
+
+	// Metric resource query limits, the same as Prometheus.
+	MAX_METRIC_QUERY_POINTS = 11000
+)
+
+// ResourcePreview represents the first rows of a Resource.
+type ResourcePreview struct {
+	ResourceID string           `json:"resource_id"`
+	Columns    []string         `json:"columns"`
+	Rows       []map[string]any `json:"rows"`
+	Total      int64            `json:"total"` // 数据源返回的总数，无法获取时等于返回行数
+}
+
+// ValueCount represents a value and its occurrence count in the sample.
+type ValueCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// HistogramBucket represents an equal width bucket [Lower, Upper) of a numeric column, the last bucket includes Upper.
+type HistogramBucket struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int64   `json:"count"`
+}
+
+// ColumnProfile represents statistics of a column computed on the sample.
+type ColumnProfile struct {
+	Name          string             `json:"name"`
+	Type          string             `json:"type"`
+	NullCount     int64              `json:"null_count"`
+	NullRatio     float64            `json:"null_ratio"`
+	DistinctCount int64              `json:"distinct_count"`
+	Min           any                `json:"min,omitempty"`
+	Max           any                `json:"max,omitempty"`
+	TopValues     []*ValueCount      `json:"top_values"`
+	Histogram     []*HistogramBucket `json:"histogram,omitempty"` // 仅数值类型
+}
+
+// ResourceProfile represents the data profile of a Resource.
+type ResourceProfile struct {
+	ResourceID  string           `json:"resource_id"`
+	SampleSize  int              `json:"sample_size"` // 请求的采样行数
+	RowCount    int64            `json:"row_count"`   // 实际参与统计的行数
+	Columns     []*ColumnProfile `json:"columns"`
+	ProfileTime int64            `json:"profile_time"`
+	Cached      bool             `json:"cached"`
+}
+
+// ResourceProfileParams represents parameters for profiling a Resource.
+type ResourceProfileParams struct {
+	SampleSize int
+	Refresh    bool // 忽略缓存重新计算
+}
+
+// ResourceMetricQueryParams represents parameters for querying a metric Resource.
+// Start and End are both zero for an instant query at Time, times are in milliseconds.
+type ResourceMetricQueryParams struct {
+	Time  int64 // 即时查询的时间，0 表示数据源当前时间
+	Start int64
+	End   int64
+	Step  time.Duration
+}
+
+// ResourceMetricData represents the series of a metric Resource.
+type ResourceMetricData struct {
+	ResourceID string          `json:"resource_id"`
+	Results    []*MetricResult `json:"results"`
+}
@@ -0,0 +1,19 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package interfaces defines entities, DTOs, and service interfaces.
+package interfaces
+
+import "context"
+
+// ResourceProfileAccess defines resource profile data access interface.
+type ResourceProfileAccess interface {
+	// GetByResourceID retrieves the cached ResourceProfile of a Resource.
+	GetByResourceID(ctx context.Context, resourceID string) (*ResourceProfile, error)
+	// Upsert saves the ResourceProfile of a Resource, replacing the cached one.
+	Upsert(ctx context.Context, profile *ResourceProfile) error
+	// DeleteByResourceIDs deletes ResourceProfiles by resource IDs.
+	DeleteByResourceIDs(ctx context.Context, resourceIDs []string) error
+}
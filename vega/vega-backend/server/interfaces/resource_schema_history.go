@@ -0,0 +1,65 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package interfaces defines entities, DTOs, and service interfaces.
+package interfaces
+
+const (
+	// SchemaChange type constants.
+	SchemaChangeCreated       string = "created"
+	SchemaChangeFieldAdded    string = "field_added"
+	SchemaChangeFieldRemoved  string = "field_removed"
+	SchemaChangeFieldModified string = "field_modified" // 同一版本包含多种字段变更
+	SchemaChangeTypeChanged   string = "type_changed"
+
+	// ResourceSchemaChangeTopic is the topic for breaking schema change events.
+	ResourceSchemaChangeTopic = "adp-vega-resource-schema-change"
+)
+
+// SchemaFieldChange represents a field level schema change.
+type SchemaFieldChange struct {
+	ChangeType string `json:"change_type"` // field_added/field_removed/type_changed
+	FieldName  string `json:"field_name"`
+	OldType    string `json:"old_type,omitempty"`
+	NewType    string `json:"new_type,omitempty"`
+	Breaking   bool   `json:"breaking"` // 删除字段、修改类型为破坏性变更
+}
+
+// ResourceSchemaHistory represents a schema snapshot of a Resource and its changes against the previous one.
+type ResourceSchemaHistory struct {
+	ID               string     `json:"id"`
+	ResourceID       string     `json:"resource_id"`
+	SchemaVersion    string     `json:"schema_version"`
+	SchemaDefinition []Property `json:"schema_definition"`
+
+	ChangeType     string               `json:"change_type"`
+	ChangeSummary  string               `json:"change_summary"`
+	Changes        []*SchemaFieldChange `json:"changes"`
+	Breaking       bool                 `json:"breaking"`
+	SchemaInferred bool                 `json:"schema_inferred"`
+	ChangeTime     int64                `json:"change_time"`
+}
+
+// ResourceSchemaHistoryQueryParams represents query parameters for listing schema history.
+type ResourceSchemaHistoryQueryParams struct {
+	PaginationParams
+	ResourceID   string
+	ChangeType   string
+	BreakingOnly bool
+	StartTime    int64
+	EndTime      int64
+}
+
+// ResourceSchemaChangeMessage represents the kafka message of breaking schema changes.
+type ResourceSchemaChangeMessage struct {
+	ResourceID       string               `json:"resource_id"`
+	ResourceName     string               `json:"resource_name"`
+	CatalogID        string               `json:"catalog_id"`
+	Category         string               `json:"category"`
+	SourceIdentifier string               `json:"source_identifier"`
+	SchemaVersion    string               `json:"schema_version"`
+	Changes          []*SchemaFieldChange `json:"changes"`
+	ChangeTime       int64                `json:"change_time"`
+}
@@ -0,0 +1,21 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package interfaces defines entities, DTOs, and service interfaces.
+package interfaces
+
+import "context"
+
+// ResourceSchemaHistoryAccess defines resource schema history data access interface.
+type ResourceSchemaHistoryAccess interface {
+	// Create creates a ResourceSchemaHistory.
+	Create(ctx context.Context, history *ResourceSchemaHistory) error
+	// GetLatestByResourceID retrieves the latest ResourceSchemaHistory of a Resource.
+	GetLatestByResourceID(ctx context.Context, resourceID string) (*ResourceSchemaHistory, error)
+	// List lists ResourceSchemaHistories with filters.
+	List(ctx context.Context, params ResourceSchemaHistoryQueryParams) ([]*ResourceSchemaHistory, int64, error)
+	// DeleteByResourceIDs deletes ResourceSchemaHistories by resource IDs.
+	DeleteByResourceIDs(ctx context.Context, resourceIDs []string) error
+}
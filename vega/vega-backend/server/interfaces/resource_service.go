@@ -33,6 +33,15 @@ type ResourceService interface {
 	// CheckExistByName checks if a Resource exists by name.
 	CheckExistByName(ctx context.Context, catalogID string, name string) (bool, error)
 
-	// UpdateResource updates a Resource directly.
+	// UpdateResource updates a Resource directly, and records schema changes against the stored schema.
 	UpdateResource(ctx context.Context, resource *Resource) error
+	// ListSchemaHistory lists schema history of a Resource.
+	ListSchemaHistory(ctx context.Context, params ResourceSchemaHistoryQueryParams) ([]*ResourceSchemaHistory, int64, error)
+
+	// Preview reads the first rows of a Resource from its data source.
+	Preview(ctx context.Context, resource *Resource, limit int) (*ResourcePreview, error)
+	// GetProfile returns the cached data profile of a Resource, or computes it on a sample of rows.
+	GetProfile(ctx context.Context, resource *Resource, params ResourceProfileParams) (*ResourceProfile, error)
+	// QueryMetric runs an instant or range query on a metric Resource.
+	QueryMetric(ctx context.Context, resource *Resource, params ResourceMetricQueryParams) (*ResourceMetricData, error)
 }
@@ -25,6 +25,7 @@ import (
 
 	"vega-backend/common"
 	catalogAccess "vega-backend/drivenadapters/catalog"
+	discoveryScheduleAccess "vega-backend/drivenadapters/discovery_schedule"
 	oerrors "vega-backend/errors"
 	"vega-backend/interfaces"
 	"vega-backend/logics/connectors/factory"
@@ -43,6 +44,7 @@ var (
 type catalogService struct {
 	appSetting *common.AppSetting
 	ca         interfaces.CatalogAccess
+	dsa        interfaces.DiscoveryScheduleAccess
 	cipher     kwcrypto.Cipher
 }
 
@@ -60,6 +62,7 @@ func NewCatalogService(appSetting *common.AppSetting) interfaces.CatalogService
 		cService = &catalogService{
 			appSetting: appSetting,
 			ca:         catalogAccess.NewCatalogAccess(appSetting),
+			dsa:        discoveryScheduleAccess.NewDiscoveryScheduleAccess(appSetting),
 			cipher:     cipher,
 		}
 	})
@@ -330,6 +333,9 @@ func (cs *catalogService) DeleteByIDs(ctx context.Context, ids []string) error {
 		return rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Catalog_InternalError_DeleteFailed).
 			WithErrorDetails(err.Error())
 	}
+	if err := cs.dsa.DeleteByCatalogIDs(ctx, ids); err != nil {
+		logger.Errorf("Delete discovery schedules of catalogs %v failed: %v", ids, err)
+	}
 
 	span.SetStatus(codes.Ok, "")
 	return nil
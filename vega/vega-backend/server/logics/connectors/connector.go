@@ -8,6 +8,7 @@ package connectors
 
 import (
 	"context"
+	"time"
 
 	"vega-backend/interfaces"
 )
@@ -57,24 +58,50 @@ type TableConnector interface {
 // Implementations: s3, hdfs, minio, feishu, notion, etc.
 type FileConnector interface {
 	Connector
+
+	ListFiles(ctx context.Context) ([]*interfaces.FileMeta, error)
+	// GetFileMeta 识别文件格式并推断 schema
+	GetFileMeta(ctx context.Context, file *interfaces.FileMeta) error
+	// ReadFile 读取文件数据，结果结构与 TableConnector.ExecuteQuery 一致，limit <= 0 时读取全部
+	ReadFile(ctx context.Context, path string, limit int) (*interfaces.QueryResult, error)
 }
 
 // FilesetConnector defines the interface for file/document storage connectors.
 // Implementations: s3, hdfs, minio, feishu, notion, etc.
 type FilesetConnector interface {
-	Connector
+	FileConnector
+
+	// ListFilesets 列出分区目录组成的文件集，其中的文件不再出现在 ListFiles 结果中
+	ListFilesets(ctx context.Context) ([]*interfaces.FilesetMeta, error)
+	GetFilesetMeta(ctx context.Context, fileset *interfaces.FilesetMeta) error
+	// ReadFileset 依次读取文件集中的文件，并附加分区列
+	ReadFileset(ctx context.Context, path string, limit int) (*interfaces.QueryResult, error)
 }
 
 // TopicConnector defines the interface for message queue connectors.
 // Implementations: kafka, pulsar, etc.
 type TopicConnector interface {
 	Connector
+
+	ListTopics(ctx context.Context) ([]*interfaces.TopicMeta, error)
+	// GetTopicMeta 补齐分区偏移、消费积压，并采样消息推断 schema
+	GetTopicMeta(ctx context.Context, topic *interfaces.TopicMeta) error
 }
 
 // MetricConnector defines the interface for time-series database connectors.
 // Implementations: prometheus, influxdb, etc.
 type MetricConnector interface {
 	Connector
+
+	ListMetrics(ctx context.Context) ([]*interfaces.MetricMeta, error)
+	// GetMetricMeta 补齐指标的标签集合与序列数
+	GetMetricMeta(ctx context.Context, metric *interfaces.MetricMeta) error
+
+	// QueryInstant 执行即时查询，ts 为零值时使用服务端当前时间
+	QueryInstant(ctx context.Context, query string, ts time.Time) ([]*interfaces.MetricResult, error)
+	// QueryRange 执行区间查询，返回 [start, end] 内按 step 对齐的数据点
+	QueryRange(ctx context.Context, query string, start, end time.Time,
+		step time.Duration) ([]*interfaces.MetricResult, error)
 }
 
 // IndexConnector defines the interface for search engine connectors.
@@ -84,6 +111,8 @@ type IndexConnector interface {
 
 	ListIndexes(ctx context.Context) ([]*interfaces.IndexMeta, error)
 	GetIndexMeta(ctx context.Context, index *interfaces.IndexMeta) error
+	// SearchIndex 检索索引中的前 limit 条文档，文档顶层字段作为列，Total 为索引命中的文档总数
+	SearchIndex(ctx context.Context, index string, limit int) (*interfaces.QueryResult, error)
 }
 
 // APIConnector defines the interface for REST/GraphQL API connectors.
@@ -6,12 +6,22 @@
 package factory
 
 import (
+	"vega-backend/logics/connectors/local/fileset/localfs"
+	"vega-backend/logics/connectors/local/fileset/s3"
 	"vega-backend/logics/connectors/local/index/opensearch"
+	"vega-backend/logics/connectors/local/metric/prometheus"
 	"vega-backend/logics/connectors/local/table/mysql"
+	"vega-backend/logics/connectors/local/table/postgresql"
+	"vega-backend/logics/connectors/local/topic/kafka"
 )
 
 // InitLocalConnectors 初始化本地 connector
 func (cf *ConnectorFactory) InitLocalConnectors() {
 	cf.connectors["mysql"] = mysql.NewMySQLConnector()
+	cf.connectors["postgresql"] = postgresql.NewPostgreSQLConnector()
 	cf.connectors["opensearch"] = opensearch.NewOpenSearchConnector()
+	cf.connectors["kafka"] = kafka.NewKafkaConnector()
+	cf.connectors["prometheus"] = prometheus.NewPrometheusConnector()
+	cf.connectors["localfs"] = localfs.NewLocalFSConnector(cf.appSetting.ConnectorSetting.LocalFS.AllowedRoots)
+	cf.connectors["s3"] = s3.NewS3Connector()
 }
@@ -0,0 +1,501 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package fileset provides base implementation for file and fileset connectors.
+package fileset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"vega-backend/interfaces"
+)
+
+const (
+	defaultSampleSize = 100
+	maxSampleSize     = 1000
+	// 文件集根目录位于存储根目录时的路径
+	rootPath = "."
+)
+
+// Object 存储中的一个文件，Path 为相对存储根目录、以 / 分隔的路径
+type Object struct {
+	Path         string
+	Size         int64
+	LastModified time.Time
+}
+
+// Store 文件存储抽象，由本地文件系统、S3 等实现
+type Store interface {
+	// Walk 递归遍历存储根目录下的所有文件
+	Walk(ctx context.Context, fn func(obj Object) error) error
+	Open(ctx context.Context, path string) (File, error)
+}
+
+// Options 文件类 connector 的通用配置
+type Options struct {
+	FilePattern string `mapstructure:"file_pattern"`
+	SampleSize  int    `mapstructure:"sample_size"`
+}
+
+// Validate 校验配置并补齐默认值
+func (o *Options) Validate() error {
+	if o.FilePattern != "" {
+		if _, err := path.Match(o.FilePattern, ""); err != nil {
+			return fmt.Errorf("invalid file_pattern %q: %w", o.FilePattern, err)
+		}
+	}
+	if o.SampleSize <= 0 {
+		o.SampleSize = defaultSampleSize
+	}
+	if o.SampleSize > maxSampleSize {
+		o.SampleSize = maxSampleSize
+	}
+	return nil
+}
+
+// Base 基于 Store 实现文件、文件集的发现、schema 推断和读取，供具体 connector 复用
+type Base struct {
+	store   Store
+	options Options
+	name    string // 存储根目录名称，用作根目录文件集的名称
+
+	// 最近一次遍历的结果，ListFiles 与 ListFilesets 共用，Reset 后重新遍历
+	objects []Object
+}
+
+// NewBase 创建 Base
+func NewBase(store Store, options Options, name string) *Base {
+	return &Base{
+		store:   store,
+		options: options,
+		name:    name,
+	}
+}
+
+// Reset 清除遍历缓存
+func (b *Base) Reset() {
+	b.objects = nil
+}
+
+func (b *Base) listObjects(ctx context.Context) ([]Object, error) {
+	if b.objects != nil {
+		return b.objects, nil
+	}
+
+	objects := []Object{}
+	err := b.store.Walk(ctx, func(obj Object) error {
+		if isHidden(obj.Path) || !b.matchFile(path.Base(obj.Path)) {
+			return nil
+		}
+		objects = append(objects, obj)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Path < objects[j].Path })
+	b.objects = objects
+	return objects, nil
+}
+
+// ListFiles lists files that do not belong to any partitioned fileset.
+func (b *Base) ListFiles(ctx context.Context) ([]*interfaces.FileMeta, error) {
+	objects, err := b.listObjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	files := []*interfaces.FileMeta{}
+	for _, obj := range objects {
+		if _, ok := splitPartition(obj.Path); ok {
+			continue
+		}
+		format := formatByExt(obj.Path)
+		files = append(files, &interfaces.FileMeta{
+			Path:         obj.Path,
+			Name:         path.Base(obj.Path),
+			Size:         obj.Size,
+			LastModified: obj.LastModified.UnixMilli(),
+			ContentType:  formatContentTypes[format],
+			Format:       format,
+		})
+	}
+	return files, nil
+}
+
+// GetFileMeta detects the file format and infers its schema from sampled rows.
+func (b *Base) GetFileMeta(ctx context.Context, file *interfaces.FileMeta) error {
+	f, format, err := b.open(ctx, file.Path, file.Format)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	file.Format = format
+	if format == "" {
+		return nil
+	}
+	file.ContentType = formatContentTypes[format]
+
+	data, err := readFile(ctx, format, f, b.options.SampleSize, 0, false)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", file.Path, err)
+	}
+	file.Columns = data.columns
+	file.Properties = data.properties
+	return nil
+}
+
+// ReadFile reads rows of a file in the same shape as TableConnector.ExecuteQuery.
+func (b *Base) ReadFile(ctx context.Context, filePath string, limit int) (*interfaces.QueryResult, error) {
+	f, format, err := b.open(ctx, filePath, formatByExt(filePath))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if format == "" {
+		return nil, fmt.Errorf("unrecognized format of file %s", filePath)
+	}
+
+	data, err := readFile(ctx, format, f, b.options.SampleSize, limit, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	result := &interfaces.QueryResult{
+		Columns: columnNames(data.columns),
+		Rows:    data.rows,
+	}
+	if result.Rows == nil {
+		result.Rows = make([]map[string]any, 0)
+	}
+	result.Total = int64(len(result.Rows))
+	return result, nil
+}
+
+// ListFilesets groups Hive-style partitioned directories (key=value) into filesets.
+func (b *Base) ListFilesets(ctx context.Context) ([]*interfaces.FilesetMeta, error) {
+	objects, err := b.listObjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list filesets: %w", err)
+	}
+
+	filesets := make(map[string]*interfaces.FilesetMeta)
+	partitions := make(map[string]map[string]struct{})
+	formats := make(map[string]map[string]int)
+	for _, obj := range objects {
+		part, ok := splitPartition(obj.Path)
+		if !ok {
+			continue
+		}
+
+		fs, ok := filesets[part.root]
+		if !ok {
+			fs = &interfaces.FilesetMeta{
+				Path: part.root,
+				Name: b.filesetName(part.root),
+			}
+			filesets[part.root] = fs
+			partitions[part.root] = make(map[string]struct{})
+			formats[part.root] = make(map[string]int)
+		}
+
+		fs.PartitionKeys = mergeKeys(fs.PartitionKeys, part.keys)
+		fs.FileCount++
+		fs.TotalSize += obj.Size
+		fs.LastModified = max(fs.LastModified, obj.LastModified.UnixMilli())
+		partitions[part.root][part.dir] = struct{}{}
+		formats[part.root][formatByExt(obj.Path)]++
+	}
+
+	result := make([]*interfaces.FilesetMeta, 0, len(filesets))
+	for root, fs := range filesets {
+		fs.Format = majorFormat(formats[root])
+		fs.Properties = map[string]any{
+			"partition_count": len(partitions[root]),
+		}
+		result = append(result, fs)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result, nil
+}
+
+// GetFilesetMeta infers the fileset schema from its most recently modified file.
+func (b *Base) GetFilesetMeta(ctx context.Context, fileset *interfaces.FilesetMeta) error {
+	objects, err := b.filesetObjects(ctx, fileset.Path)
+	if err != nil {
+		return err
+	}
+	if len(objects) == 0 {
+		return fmt.Errorf("fileset %s not found", fileset.Path)
+	}
+
+	// 选取最近修改的、格式与文件集一致的文件采样
+	var sample *Object
+	for i := range objects {
+		obj := &objects[i]
+		if fileset.Format != "" && formatByExt(obj.Path) != fileset.Format {
+			continue
+		}
+		if sample == nil || obj.LastModified.After(sample.LastModified) {
+			sample = obj
+		}
+	}
+	if sample == nil {
+		sample = &objects[len(objects)-1]
+	}
+
+	file := &interfaces.FileMeta{Path: sample.Path, Format: formatByExt(sample.Path)}
+	if err := b.GetFileMeta(ctx, file); err != nil {
+		return err
+	}
+
+	fileset.Format = file.Format
+	if fileset.Properties == nil {
+		fileset.Properties = make(map[string]any)
+	}
+	for k, v := range file.Properties {
+		// 单个文件的行数不代表整个文件集
+		if k == "row_count" {
+			continue
+		}
+		fileset.Properties[k] = v
+	}
+	fileset.Properties["sample_file"] = sample.Path
+
+	fileset.Columns = file.Columns
+	for _, key := range fileset.PartitionKeys {
+		if containsColumn(fileset.Columns, key) {
+			continue
+		}
+		fileset.Columns = append(fileset.Columns, interfaces.ColumnMeta{
+			Name:            key,
+			Type:            kindString,
+			OrigType:        "partition",
+			OrdinalPosition: len(fileset.Columns) + 1,
+		})
+	}
+	return nil
+}
+
+// ReadFileset reads rows of all files in a fileset, appending partition columns.
+func (b *Base) ReadFileset(ctx context.Context, filesetPath string, limit int) (*interfaces.QueryResult, error) {
+	objects, err := b.filesetObjects(ctx, filesetPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("fileset %s not found", filesetPath)
+	}
+
+	result := &interfaces.QueryResult{
+		Columns: []string{},
+		Rows:    make([]map[string]any, 0),
+	}
+	var partitionKeys []string
+	for _, obj := range objects {
+		remaining := 0
+		if limit > 0 {
+			remaining = limit - len(result.Rows)
+			if remaining <= 0 {
+				break
+			}
+		}
+
+		data, err := b.ReadFile(ctx, obj.Path, remaining)
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range data.Columns {
+			if !containsString(result.Columns, col) {
+				result.Columns = append(result.Columns, col)
+			}
+		}
+
+		part, _ := splitPartition(obj.Path)
+		partitionKeys = mergeKeys(partitionKeys, part.keys)
+		for _, row := range data.Rows {
+			for i, key := range part.keys {
+				if _, ok := row[key]; !ok {
+					row[key] = part.values[i]
+				}
+			}
+		}
+		result.Rows = append(result.Rows, data.Rows...)
+	}
+
+	for _, key := range partitionKeys {
+		if !containsString(result.Columns, key) {
+			result.Columns = append(result.Columns, key)
+		}
+	}
+	result.Total = int64(len(result.Rows))
+	return result, nil
+}
+
+// filesetObjects 返回属于指定文件集的文件
+func (b *Base) filesetObjects(ctx context.Context, filesetPath string) ([]Object, error) {
+	objects, err := b.listObjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of fileset %s: %w", filesetPath, err)
+	}
+
+	var result []Object
+	for _, obj := range objects {
+		if part, ok := splitPartition(obj.Path); ok && part.root == filesetPath {
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+// open 打开文件，format 为空时根据文件头识别
+func (b *Base) open(ctx context.Context, filePath string, format string) (File, string, error) {
+	f, err := b.store.Open(ctx, filePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	if format != "" {
+		return f, format, nil
+	}
+
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		f.Close()
+		return nil, "", fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, "", fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+	return f, sniffFormat(head[:n]), nil
+}
+
+func (b *Base) matchFile(name string) bool {
+	if b.options.FilePattern == "" {
+		return true
+	}
+	matched, _ := path.Match(b.options.FilePattern, name)
+	return matched
+}
+
+func (b *Base) filesetName(root string) string {
+	if root == rootPath {
+		return b.name
+	}
+	return path.Base(root)
+}
+
+// partition 文件路径中的分区信息
+type partition struct {
+	root   string   // 文件集根目录
+	dir    string   // 分区目录，如 dt=2024-01-01/region=cn
+	keys   []string // 分区键
+	values []string // 分区值
+}
+
+// splitPartition 从文件路径中找出第一段连续的 key=value 目录，不存在时返回 false
+func splitPartition(p string) (partition, bool) {
+	dir := path.Dir(p)
+	if dir == "." {
+		return partition{}, false
+	}
+
+	segments := strings.Split(dir, "/")
+	start := -1
+	var part partition
+	for i, seg := range segments {
+		key, value, ok := strings.Cut(seg, "=")
+		if !ok || key == "" {
+			if start >= 0 {
+				break
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		part.keys = append(part.keys, key)
+		part.values = append(part.values, value)
+	}
+	if start < 0 {
+		return partition{}, false
+	}
+
+	part.root = rootPath
+	if start > 0 {
+		part.root = strings.Join(segments[:start], "/")
+	}
+	part.dir = strings.Join(segments[start:start+len(part.keys)], "/")
+	return part, true
+}
+
+// isHidden 过滤以 . 或 _ 开头的文件和目录，如 _SUCCESS、.crc、_delta_log
+func isHidden(p string) bool {
+	for _, seg := range strings.Split(p, "/") {
+		if strings.HasPrefix(seg, ".") || strings.HasPrefix(seg, "_") {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeKeys(keys []string, more []string) []string {
+	for _, k := range more {
+		if !containsString(keys, k) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// majorFormat 返回出现次数最多的可识别格式
+func majorFormat(counts map[string]int) string {
+	best, bestCount := "", 0
+	for format, count := range counts {
+		if format == "" {
+			continue
+		}
+		if count > bestCount || (count == bestCount && format < best) {
+			best, bestCount = format, count
+		}
+	}
+	return best
+}
+
+func columnNames(columns []interfaces.ColumnMeta) []string {
+	names := make([]string, 0, len(columns))
+	for _, col := range columns {
+		names = append(names, col.Name)
+	}
+	return names
+}
+
+func containsColumn(columns []interfaces.ColumnMeta, name string) bool {
+	for _, col := range columns {
+		if col.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,512 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package fileset
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/xuri/excelize/v2"
+
+	"vega-backend/interfaces"
+)
+
+// 支持推断 schema 的文件格式
+const (
+	FormatCSV     = "csv"
+	FormatJSONL   = "jsonl"
+	FormatParquet = "parquet"
+	FormatExcel   = "excel"
+)
+
+var extFormats = map[string]string{
+	".csv":     FormatCSV,
+	".tsv":     FormatCSV,
+	".jsonl":   FormatJSONL,
+	".ndjson":  FormatJSONL,
+	".json":    FormatJSONL,
+	".parquet": FormatParquet,
+	".xlsx":    FormatExcel,
+	".xlsm":    FormatExcel,
+}
+
+var formatContentTypes = map[string]string{
+	FormatCSV:     "text/csv",
+	FormatJSONL:   "application/x-ndjson",
+	FormatParquet: "application/vnd.apache.parquet",
+	FormatExcel:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+const (
+	sniffSize      = 8 << 10
+	parquetBatch   = 1024
+	valueFieldName = "value" // 非对象 JSON 行统一归入该列
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// formatByExt 根据扩展名识别格式
+func formatByExt(name string) string {
+	return extFormats[strings.ToLower(path.Ext(name))]
+}
+
+// sniffFormat 根据文件头识别格式，无法识别时返回空
+func sniffFormat(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte("PAR1")):
+		return FormatParquet
+	case bytes.HasPrefix(head, []byte("PK\x03\x04")):
+		// xlsx 为 zip 包，其他 zip 文件会在读取时报错
+		return FormatExcel
+	}
+
+	text := bytes.TrimLeft(bytes.TrimPrefix(head, utf8BOM), " \t\r\n")
+	if len(text) == 0 || !utf8.Valid(trimPartialRune(text)) {
+		return ""
+	}
+	if text[0] == '{' || text[0] == '[' {
+		return FormatJSONL
+	}
+	if sniffDelimiter(text) != 0 {
+		return FormatCSV
+	}
+	return ""
+}
+
+// trimPartialRune 去掉因截断产生的不完整 UTF-8 字符
+func trimPartialRune(b []byte) []byte {
+	for i := 0; i < utf8.UTFMax && len(b) > 0; i++ {
+		r, size := utf8.DecodeLastRune(b)
+		if r != utf8.RuneError || size != 1 {
+			break
+		}
+		b = b[:len(b)-1]
+	}
+	return b
+}
+
+// sniffDelimiter 在前几行中选出出现次数一致且最多的分隔符
+func sniffDelimiter(text []byte) rune {
+	lines := bytes.Split(text, []byte("\n"))
+	if len(lines) > 1 {
+		// 最后一行可能被截断
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > 5 {
+		lines = lines[:5]
+	}
+
+	var best rune
+	bestCount := 0
+	for _, delim := range []rune{',', '\t', ';', '|'} {
+		count := -1
+		for _, line := range lines {
+			n := bytes.Count(line, []byte(string(delim)))
+			if count == -1 {
+				count = n
+			} else if n != count {
+				count = 0
+				break
+			}
+		}
+		if count > bestCount {
+			best, bestCount = delim, count
+		}
+	}
+	return best
+}
+
+// File 可随机读取的文件，Parquet 与 Excel 需要 ReaderAt
+type File interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+}
+
+// visitFunc 逐行回调，columns 为该行的列顺序，返回 false 时停止读取
+type visitFunc func(columns []string, row map[string]any) bool
+
+// scanInfo 扫描结果中与行无关的信息
+type scanInfo struct {
+	declared   []interfaces.ColumnMeta // 文件自带的 schema（Parquet），为空时按采样推断
+	text       bool                    // 值均为字符串，需按推断类型转换
+	properties map[string]any
+}
+
+type scanner func(ctx context.Context, f File, visit visitFunc) (*scanInfo, error)
+
+var scanners = map[string]scanner{
+	FormatCSV:     scanCSV,
+	FormatJSONL:   scanJSONL,
+	FormatParquet: scanParquet,
+	FormatExcel:   scanExcel,
+}
+
+// fileData 文件读取结果
+type fileData struct {
+	columns    []interfaces.ColumnMeta
+	rows       []map[string]any
+	properties map[string]any
+}
+
+// readFile 读取文件，前 sampleSize 行用于推断 schema。
+// keepRows 为 false 时只采样推断 schema；为 true 时返回至多 limit 行数据，limit <= 0 表示全部。
+func readFile(ctx context.Context, format string, f File, sampleSize int, limit int,
+	keepRows bool) (*fileData, error) {
+
+	scan, ok := scanners[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported file format: %q", format)
+	}
+
+	inferrer := newSchemaInferrer()
+	var rows []map[string]any
+	n := 0
+	info, err := scan(ctx, f, func(columns []string, row map[string]any) bool {
+		if n < sampleSize {
+			inferrer.AddRow(columns, row)
+		}
+		if keepRows {
+			rows = append(rows, row)
+		}
+		n++
+		if keepRows {
+			return limit <= 0 || n < limit
+		}
+		return n < sampleSize
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	columns := info.declared
+	if columns == nil {
+		columns = inferrer.Columns()
+	}
+
+	for _, row := range rows {
+		for _, col := range columns {
+			v, ok := row[col.Name]
+			if !ok {
+				continue
+			}
+			if info.text {
+				if s, ok := v.(string); ok {
+					row[col.Name] = convertText(s, col.Type)
+				}
+			} else if format == FormatJSONL {
+				row[col.Name] = convertJSON(v)
+			}
+		}
+	}
+
+	if info.properties == nil {
+		info.properties = make(map[string]any)
+	}
+	info.properties["sample_rows"] = min(n, sampleSize)
+
+	return &fileData{
+		columns:    columns,
+		rows:       rows,
+		properties: info.properties,
+	}, nil
+}
+
+func scanCSV(ctx context.Context, f File, visit visitFunc) (*scanInfo, error) {
+	br := bufio.NewReader(f)
+	head, _ := br.Peek(sniffSize)
+	if bytes.HasPrefix(head, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+		head = head[len(utf8BOM):]
+	}
+	delim := sniffDelimiter(head)
+	if delim == 0 {
+		delim = ','
+	}
+
+	reader := csv.NewReader(br)
+	reader.Comma = delim
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	info := &scanInfo{
+		text:       true,
+		properties: map[string]any{"delimiter": string(delim)},
+	}
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return info, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+	columns := normalizeHeader(header)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv record: %w", err)
+		}
+		if !visit(columns, recordToRow(columns, record)) {
+			break
+		}
+	}
+	return info, nil
+}
+
+func scanJSONL(ctx context.Context, f File, visit visitFunc) (*scanInfo, error) {
+	br := bufio.NewReader(f)
+	head, _ := br.Peek(sniffSize)
+	if bytes.HasPrefix(head, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+		head = head[len(utf8BOM):]
+	}
+
+	decoder := json.NewDecoder(br)
+	decoder.UseNumber()
+
+	// 兼容整个文件为一个 JSON 数组的情况
+	if trimmed := bytes.TrimLeft(head, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("failed to read json array: %w", err)
+		}
+	}
+
+	for decoder.More() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var v any
+		if err := decoder.Decode(&v); err != nil {
+			return nil, fmt.Errorf("failed to decode json line: %w", err)
+		}
+
+		row, ok := v.(map[string]any)
+		if !ok {
+			row = map[string]any{valueFieldName: v}
+		}
+		columns := make([]string, 0, len(row))
+		for k := range row {
+			columns = append(columns, k)
+		}
+		sort.Strings(columns)
+
+		if !visit(columns, row) {
+			break
+		}
+	}
+	return &scanInfo{}, nil
+}
+
+func scanParquet(ctx context.Context, f File, visit visitFunc) (*scanInfo, error) {
+	pf, err := file.NewParquetReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open parquet file: %w", err)
+	}
+	defer pf.Close()
+
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: parquetBatch}, memory.DefaultAllocator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open parquet file: %w", err)
+	}
+	schema, err := fr.Schema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parquet schema: %w", err)
+	}
+
+	columns := make([]string, 0, schema.NumFields())
+	declared := make([]interfaces.ColumnMeta, 0, schema.NumFields())
+	for i, field := range schema.Fields() {
+		columns = append(columns, field.Name)
+		declared = append(declared, interfaces.ColumnMeta{
+			Name:            field.Name,
+			Type:            arrowType(field.Type),
+			OrigType:        field.Type.String(),
+			Nullable:        field.Nullable,
+			OrdinalPosition: i + 1,
+		})
+	}
+
+	info := &scanInfo{
+		declared: declared,
+		properties: map[string]any{
+			"row_count":  pf.NumRows(),
+			"row_groups": pf.NumRowGroups(),
+			"created_by": pf.MetaData().GetCreatedBy(),
+		},
+	}
+
+	rr, err := fr.GetRecordReader(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parquet records: %w", err)
+	}
+	defer rr.Release()
+
+	for rr.Next() {
+		rec := rr.Record()
+		for i := 0; i < int(rec.NumRows()); i++ {
+			row := make(map[string]any, len(columns))
+			for j, col := range columns {
+				arr := rec.Column(j)
+				if arr.IsNull(i) {
+					row[col] = nil
+				} else {
+					row[col] = arr.GetOneForMarshal(i)
+				}
+			}
+			if !visit(columns, row) {
+				return info, nil
+			}
+		}
+	}
+	if err := rr.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read parquet records: %w", err)
+	}
+	return info, nil
+}
+
+// arrowType 将 Parquet 列对应的 Arrow 类型映射为 VEGA 类型
+func arrowType(t arrow.DataType) string {
+	switch t.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		return "integer"
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return "unsigned_integer"
+	case arrow.FLOAT16, arrow.FLOAT32, arrow.FLOAT64:
+		return "float"
+	case arrow.DECIMAL128, arrow.DECIMAL256:
+		return "decimal"
+	case arrow.BOOL:
+		return "boolean"
+	case arrow.STRING, arrow.LARGE_STRING:
+		return "string"
+	case arrow.BINARY, arrow.LARGE_BINARY, arrow.FIXED_SIZE_BINARY:
+		return "binary"
+	case arrow.DATE32, arrow.DATE64:
+		return "date"
+	case arrow.TIMESTAMP:
+		return "datetime"
+	case arrow.TIME32, arrow.TIME64:
+		return "time"
+	case arrow.LIST, arrow.LARGE_LIST, arrow.FIXED_SIZE_LIST, arrow.STRUCT, arrow.MAP:
+		return "json"
+	default:
+		return "string"
+	}
+}
+
+func scanExcel(ctx context.Context, f File, visit visitFunc) (*scanInfo, error) {
+	xf, err := excelize.OpenReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open excel file: %w", err)
+	}
+	defer xf.Close()
+
+	sheets := xf.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("excel file has no sheet")
+	}
+	// 只读取第一个工作表
+	sheet := sheets[0]
+	info := &scanInfo{
+		text: true,
+		properties: map[string]any{
+			"sheet":       sheet,
+			"sheet_count": len(sheets),
+		},
+	}
+
+	rows, err := xf.Rows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sheet %s: %w", sheet, err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		record, err := rows.Columns()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet %s: %w", sheet, err)
+		}
+		// 第一个非空行作为表头
+		if columns == nil {
+			if isEmptyRecord(record) {
+				continue
+			}
+			columns = normalizeHeader(record)
+			continue
+		}
+		if isEmptyRecord(record) {
+			continue
+		}
+		if !visit(columns, recordToRow(columns, record)) {
+			break
+		}
+	}
+	return info, rows.Error()
+}
+
+// normalizeHeader 处理空列名和重复列名
+func normalizeHeader(header []string) []string {
+	columns := make([]string, len(header))
+	seen := make(map[string]int)
+	for i, h := range header {
+		name := strings.TrimSpace(h)
+		if name == "" {
+			name = fmt.Sprintf("column_%d", i+1)
+		}
+		seen[name]++
+		if seen[name] > 1 {
+			name = fmt.Sprintf("%s_%d", name, seen[name])
+		}
+		columns[i] = name
+	}
+	return columns
+}
+
+func recordToRow(columns []string, record []string) map[string]any {
+	row := make(map[string]any, len(columns))
+	for i, col := range columns {
+		if i < len(record) {
+			row[col] = record[i]
+		}
+	}
+	return row
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
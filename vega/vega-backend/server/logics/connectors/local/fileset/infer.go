@@ -0,0 +1,249 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package fileset
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"vega-backend/interfaces"
+)
+
+// 采样值的类型
+const (
+	kindNull     = "null"
+	kindInteger  = "integer"
+	kindFloat    = "float"
+	kindBoolean  = "boolean"
+	kindDate     = "date"
+	kindDatetime = "datetime"
+	kindString   = "string"
+	kindJSON     = "json"
+)
+
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+}
+
+var datetimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05.000",
+	"2006/01/02 15:04:05",
+}
+
+type columnStats struct {
+	present int
+	kinds   map[string]int
+}
+
+// schemaInferrer 根据采样行推断列类型，列顺序为首次出现的顺序
+type schemaInferrer struct {
+	rows    int
+	columns []string
+	stats   map[string]*columnStats
+}
+
+func newSchemaInferrer() *schemaInferrer {
+	return &schemaInferrer{stats: make(map[string]*columnStats)}
+}
+
+// AddRow 加入一行采样数据
+func (s *schemaInferrer) AddRow(columns []string, row map[string]any) {
+	s.rows++
+	for _, col := range columns {
+		cs, ok := s.stats[col]
+		if !ok {
+			cs = &columnStats{kinds: make(map[string]int)}
+			s.stats[col] = cs
+			s.columns = append(s.columns, col)
+		}
+		v, ok := row[col]
+		if !ok {
+			continue
+		}
+		cs.present++
+		cs.kinds[valueKind(v)]++
+	}
+}
+
+// Columns 返回推断出的列
+func (s *schemaInferrer) Columns() []interfaces.ColumnMeta {
+	columns := make([]interfaces.ColumnMeta, 0, len(s.columns))
+	for i, name := range s.columns {
+		cs := s.stats[name]
+		kinds := make([]string, 0, len(cs.kinds))
+		for kind := range cs.kinds {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+
+		columns = append(columns, interfaces.ColumnMeta{
+			Name:            name,
+			Type:            mergeKinds(cs.kinds),
+			OrigType:        strings.Join(kinds, "|"),
+			Nullable:        cs.present < s.rows || cs.kinds[kindNull] > 0,
+			OrdinalPosition: i + 1,
+		})
+	}
+	return columns
+}
+
+// valueKind 返回采样值的类型，文本格式中的字符串会尝试识别数字、布尔和时间
+func valueKind(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return kindNull
+	case bool:
+		return kindBoolean
+	case int, int32, int64:
+		return kindInteger
+	case float32, float64:
+		return kindFloat
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			return kindInteger
+		}
+		return kindFloat
+	case string:
+		return stringKind(val)
+	case []any, map[string]any:
+		return kindJSON
+	default:
+		return kindString
+	}
+}
+
+func stringKind(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return kindNull
+	}
+	// 以 0 开头的多位数字（如邮编、编号）保留为字符串
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if len(s) > 1 && (s[0] == '0' || strings.HasPrefix(s, "-0")) {
+			return kindString
+		}
+		return kindInteger
+	}
+	if isDecimal(s) {
+		if _, err := strconv.ParseFloat(s, 64); err == nil {
+			return kindFloat
+		}
+	}
+	switch strings.ToLower(s) {
+	case "true", "false":
+		return kindBoolean
+	}
+	if parseLayouts(s, dateLayouts) {
+		return kindDate
+	}
+	if parseLayouts(s, datetimeLayouts) {
+		return kindDatetime
+	}
+	return kindString
+}
+
+// isDecimal 排除 ParseFloat 可接受的 inf、nan、十六进制等写法
+func isDecimal(s string) bool {
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9', r == '.', r == '-', r == '+', r == 'e', r == 'E':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func parseLayouts(s string, layouts []string) bool {
+	for _, layout := range layouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeKinds 将采样到的类型合并为 VEGA 类型
+func mergeKinds(kinds map[string]int) string {
+	nonNull := make(map[string]bool)
+	for kind := range kinds {
+		if kind != kindNull {
+			nonNull[kind] = true
+		}
+	}
+
+	switch {
+	case len(nonNull) == 0:
+		return kindString
+	case len(nonNull) == 1:
+		for kind := range nonNull {
+			return kind
+		}
+	case len(nonNull) == 2 && nonNull[kindInteger] && nonNull[kindFloat]:
+		return kindFloat
+	case len(nonNull) == 2 && nonNull[kindDate] && nonNull[kindDatetime]:
+		return kindDatetime
+	case nonNull[kindJSON]:
+		return kindJSON
+	}
+	return kindString
+}
+
+// convertText 按推断类型转换文本格式中的字符串值，无法转换时保留原值
+func convertText(s string, typ string) any {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return nil
+	}
+	switch typ {
+	case kindInteger:
+		if n, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+			return n
+		}
+	case kindFloat:
+		if f, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return f
+		}
+	case kindBoolean:
+		if b, err := strconv.ParseBool(strings.ToLower(trimmed)); err == nil {
+			return b
+		}
+	}
+	return s
+}
+
+// convertJSON 将 json.Number 转换为 int64 或 float64
+func convertJSON(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		if n, err := val.Int64(); err == nil {
+			return n
+		}
+		if f, err := val.Float64(); err == nil {
+			return f
+		}
+		return val.String()
+	case map[string]any:
+		for k, item := range val {
+			val[k] = convertJSON(item)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = convertJSON(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
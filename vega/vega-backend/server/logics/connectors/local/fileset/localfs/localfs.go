@@ -0,0 +1,355 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package localfs provides local filesystem file/fileset connector implementation.
+package localfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors"
+	"vega-backend/logics/connectors/local/fileset"
+)
+
+type localfsConfig struct {
+	Root            string `mapstructure:"root"`
+	FollowSymlinks  bool   `mapstructure:"follow_symlinks"`
+	fileset.Options `mapstructure:",squash"`
+}
+
+// LocalFSConnector implements FilesetConnector for a directory on the local filesystem.
+type LocalFSConnector struct {
+	enabled      bool
+	allowedRoots []string // 服务配置中允许作为根目录的目录
+	config       *localfsConfig
+	base         *fileset.Base
+}
+
+// NewLocalFSConnector 创建本地文件系统 connector 构建器，根目录必须位于 allowedRoots 之一下
+func NewLocalFSConnector(allowedRoots []string) connectors.FilesetConnector {
+	return &LocalFSConnector{allowedRoots: allowedRoots}
+}
+
+// GetType returns the data source type.
+func (c *LocalFSConnector) GetType() string {
+	return "localfs"
+}
+
+// GetName returns the data source name.
+func (c *LocalFSConnector) GetName() string {
+	return "localfs"
+}
+
+// GetMode returns the connector mode.
+func (c *LocalFSConnector) GetMode() string {
+	return interfaces.ConnectorModeLocal
+}
+
+// GetCategory returns the connector category.
+func (c *LocalFSConnector) GetCategory() string {
+	return interfaces.ConnectorCategoryFileset
+}
+
+// GetEnabled returns the enabled status.
+func (c *LocalFSConnector) GetEnabled() bool {
+	return c.enabled
+}
+
+// SetEnabled sets the enabled status.
+func (c *LocalFSConnector) SetEnabled(enabled bool) {
+	c.enabled = enabled
+}
+
+// GetSensitiveFields returns the sensitive fields for local filesystem connector.
+func (c *LocalFSConnector) GetSensitiveFields() []string {
+	return []string{}
+}
+
+// GetFieldConfig returns the field configuration for local filesystem connector.
+func (c *LocalFSConnector) GetFieldConfig() map[string]interfaces.ConnectorFieldConfig {
+	return map[string]interfaces.ConnectorFieldConfig{
+		"root":            {Name: "根目录", Type: "string", Description: "服务所在主机上的目录绝对路径，须位于服务配置允许的目录下", Required: true, Encrypted: false},
+		"follow_symlinks": {Name: "跟随软链接", Type: "boolean", Description: "是否跟随指向根目录之外的软链接（默认否）", Required: false, Encrypted: false},
+		"file_pattern":    {Name: "文件模式", Type: "string", Description: "文件名匹配模式（可选，如 *.csv）", Required: false, Encrypted: false},
+		"sample_size":     {Name: "采样行数", Type: "integer", Description: "每个文件用于推断 schema 的行数（默认 100）", Required: false, Encrypted: false},
+	}
+}
+
+// New creates a new local filesystem connector.
+func (c *LocalFSConnector) New(cfg interfaces.ConnectorConfig) (connectors.Connector, error) {
+	var lCfg localfsConfig
+	if err := mapstructure.WeakDecode(cfg, &lCfg); err != nil {
+		return nil, fmt.Errorf("failed to decode localfs config: %w", err)
+	}
+
+	if lCfg.Root == "" {
+		return nil, fmt.Errorf("localfs config missing required field: root")
+	}
+	if !filepath.IsAbs(lCfg.Root) {
+		return nil, fmt.Errorf("localfs root must be an absolute path: %s", lCfg.Root)
+	}
+	lCfg.Root = filepath.Clean(lCfg.Root)
+	if err := checkAllowedRoot(lCfg.Root, c.allowedRoots); err != nil {
+		return nil, err
+	}
+	if err := lCfg.Options.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &LocalFSConnector{
+		enabled:      c.enabled,
+		allowedRoots: c.allowedRoots,
+		config:       &lCfg,
+	}, nil
+}
+
+// Connect checks the root directory.
+func (c *LocalFSConnector) Connect(ctx context.Context) error {
+	if c.base != nil {
+		return nil
+	}
+
+	if err := c.checkRoot(); err != nil {
+		return err
+	}
+
+	store := &localStore{root: c.config.Root, followSymlinks: c.config.FollowSymlinks}
+	c.base = fileset.NewBase(store, c.config.Options, filepath.Base(c.config.Root))
+	return nil
+}
+
+func (c *LocalFSConnector) checkRoot() error {
+	info, err := os.Stat(c.config.Root)
+	if err != nil {
+		return fmt.Errorf("failed to access root %s: %w", c.config.Root, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("root %s is not a directory", c.config.Root)
+	}
+
+	// 根目录经软链接解析后仍须位于允许的目录下
+	real, err := filepath.EvalSymlinks(c.config.Root)
+	if err != nil {
+		return fmt.Errorf("failed to resolve root %s: %w", c.config.Root, err)
+	}
+	allowed := make([]string, 0, len(c.allowedRoots))
+	for _, dir := range c.allowedRoots {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			dir = resolved
+		}
+		allowed = append(allowed, dir)
+	}
+	return checkAllowedRoot(real, allowed)
+}
+
+// checkAllowedRoot 要求 root 为允许的目录之一或位于其下
+func checkAllowedRoot(root string, allowedRoots []string) error {
+	if len(allowedRoots) == 0 {
+		return fmt.Errorf("localfs is disabled: no allowed root directories configured on the server")
+	}
+	for _, dir := range allowedRoots {
+		if !filepath.IsAbs(dir) {
+			continue
+		}
+		dir = filepath.Clean(dir)
+		if root == dir || strings.HasPrefix(root, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("localfs root %s is not under any allowed root directory", root)
+}
+
+// Close releases the connector.
+func (c *LocalFSConnector) Close(ctx context.Context) error {
+	c.base = nil
+	return nil
+}
+
+// Ping checks the root directory.
+func (c *LocalFSConnector) Ping(ctx context.Context) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	return c.checkRoot()
+}
+
+// TestConnection tests access to the root directory.
+func (c *LocalFSConnector) TestConnection(ctx context.Context) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	f, err := os.Open(c.config.Root)
+	if err != nil {
+		return fmt.Errorf("failed to open root %s: %w", c.config.Root, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Readdirnames(1); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read root %s: %w", c.config.Root, err)
+	}
+	return nil
+}
+
+// GetMetadata returns the metadata for the catalog.
+func (c *LocalFSConnector) GetMetadata(ctx context.Context) (map[string]any, error) {
+	if c.base == nil {
+		return nil, fmt.Errorf("connector not connected")
+	}
+
+	return map[string]any{
+		"root": c.config.Root,
+	}, nil
+}
+
+// ListFiles lists files under root that are not part of a partitioned fileset.
+func (c *LocalFSConnector) ListFiles(ctx context.Context) ([]*interfaces.FileMeta, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.base.ListFiles(ctx)
+}
+
+// GetFileMeta fills the file with detected format and inferred schema.
+func (c *LocalFSConnector) GetFileMeta(ctx context.Context, file *interfaces.FileMeta) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	return c.base.GetFileMeta(ctx, file)
+}
+
+// ReadFile reads rows of a file.
+func (c *LocalFSConnector) ReadFile(ctx context.Context, path string, limit int) (*interfaces.QueryResult, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.base.ReadFile(ctx, path, limit)
+}
+
+// ListFilesets lists partitioned directories under root.
+func (c *LocalFSConnector) ListFilesets(ctx context.Context) ([]*interfaces.FilesetMeta, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.base.ListFilesets(ctx)
+}
+
+// GetFilesetMeta fills the fileset with inferred schema.
+func (c *LocalFSConnector) GetFilesetMeta(ctx context.Context, fs *interfaces.FilesetMeta) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	return c.base.GetFilesetMeta(ctx, fs)
+}
+
+// ReadFileset reads rows of a fileset.
+func (c *LocalFSConnector) ReadFileset(ctx context.Context, path string, limit int) (*interfaces.QueryResult, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.base.ReadFileset(ctx, path, limit)
+}
+
+// localStore 以本地目录为根的文件存储
+type localStore struct {
+	root           string
+	followSymlinks bool
+}
+
+// Walk implements fileset.Store.
+func (s *localStore) Walk(ctx context.Context, fn func(obj fileset.Object) error) error {
+	return filepath.WalkDir(s.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		// 软链接默认不跟随，避免读取根目录之外的文件
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if !s.followSymlinks {
+				return nil
+			}
+			if info, err = os.Stat(p); err != nil || info.IsDir() {
+				return nil
+			}
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		return fn(fileset.Object{
+			Path:         filepath.ToSlash(rel),
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+	})
+}
+
+// Open implements fileset.Store.
+func (s *localStore) Open(ctx context.Context, path string) (fileset.File, error) {
+	p, err := s.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(p)
+}
+
+// resolve 将相对路径解析为根目录下的绝对路径，拒绝越界访问
+func (s *localStore) resolve(path string) (string, error) {
+	rel := filepath.FromSlash(path)
+	if filepath.IsAbs(rel) {
+		return "", fmt.Errorf("path must be relative to root: %s", path)
+	}
+	p := filepath.Join(s.root, rel)
+	if p != s.root && !strings.HasPrefix(p, s.root+string(filepath.Separator)) {
+		return "", fmt.Errorf("path escapes root: %s", path)
+	}
+
+	// 不跟随软链接时，要求解析后的真实路径仍位于根目录下
+	if !s.followSymlinks {
+		root, err := filepath.EvalSymlinks(s.root)
+		if err != nil {
+			return "", err
+		}
+		real, err := filepath.EvalSymlinks(p)
+		if err != nil {
+			return "", err
+		}
+		if !strings.HasPrefix(real, root+string(filepath.Separator)) {
+			return "", fmt.Errorf("path escapes root via symlink: %s", path)
+		}
+	}
+	return p, nil
+}
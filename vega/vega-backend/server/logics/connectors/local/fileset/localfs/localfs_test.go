@@ -0,0 +1,76 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package localfs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"vega-backend/interfaces"
+)
+
+// TestNewRejectsRootOutsideAllowedRoots 根目录不在服务配置允许的目录下时拒绝创建
+func TestNewRejectsRootOutsideAllowedRoots(t *testing.T) {
+	allowed := t.TempDir()
+	builder := NewLocalFSConnector([]string{allowed})
+
+	for _, root := range []string{"/", "/etc", filepath.Dir(allowed), allowed + "-other", filepath.Join(allowed, "..", "etc")} {
+		if _, err := builder.New(interfaces.ConnectorConfig{"root": root}); err == nil {
+			t.Errorf("root %s outside allowed roots should be rejected", root)
+		}
+	}
+}
+
+// TestNewRejectsWhenNoAllowedRoots 未配置允许的目录时不允许使用本地文件系统
+func TestNewRejectsWhenNoAllowedRoots(t *testing.T) {
+	for _, root := range []string{"/", "/etc", t.TempDir()} {
+		if _, err := NewLocalFSConnector(nil).New(interfaces.ConnectorConfig{"root": root}); err == nil {
+			t.Errorf("root %s should be rejected when no allowed roots are configured", root)
+		}
+	}
+}
+
+// TestNewAcceptsRootUnderAllowedRoots 允许的目录本身及其子目录可以作为根目录
+func TestNewAcceptsRootUnderAllowedRoots(t *testing.T) {
+	allowed := t.TempDir()
+	sub := filepath.Join(allowed, "sales")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, root := range []string{allowed, sub} {
+		conn, err := NewLocalFSConnector([]string{"/nonexistent", allowed}).New(interfaces.ConnectorConfig{"root": root})
+		if err != nil {
+			t.Fatalf("root %s under allowed roots should be accepted: %v", root, err)
+		}
+		if err := conn.Connect(context.Background()); err != nil {
+			t.Errorf("connect root %s: %v", root, err)
+		}
+	}
+
+	if _, err := NewLocalFSConnector([]string{"/"}).New(interfaces.ConnectorConfig{"root": "/etc"}); err != nil {
+		t.Errorf("root /etc should be accepted when / is allowed: %v", err)
+	}
+}
+
+// TestConnectRejectsSymlinkOutsideAllowedRoots 指向允许目录之外的软链接不能作为根目录
+func TestConnectRejectsSymlinkOutsideAllowedRoots(t *testing.T) {
+	allowed := t.TempDir()
+	link := filepath.Join(allowed, "etc")
+	if err := os.Symlink("/etc", link); err != nil {
+		t.Skipf("symlink not supported: %v", err)
+	}
+
+	conn, err := NewLocalFSConnector([]string{allowed}).New(interfaces.ConnectorConfig{"root": link})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	if err := conn.Connect(context.Background()); err == nil {
+		t.Error("root symlinked outside allowed roots should be rejected on connect")
+	}
+}
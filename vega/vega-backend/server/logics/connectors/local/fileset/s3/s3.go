@@ -0,0 +1,334 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package s3 provides S3-compatible object storage file/fileset connector implementation.
+package s3
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/mitchellh/mapstructure"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors"
+	"vega-backend/logics/connectors/local/fileset"
+)
+
+type s3Config struct {
+	Endpoint        string `mapstructure:"endpoint"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	Region          string `mapstructure:"region"`
+	Bucket          string `mapstructure:"bucket"`
+	Prefix          string `mapstructure:"prefix"`
+	UseSSL          bool   `mapstructure:"use_ssl"`
+	PathStyle       bool   `mapstructure:"path_style"`
+	fileset.Options `mapstructure:",squash"`
+}
+
+// S3Connector implements FilesetConnector for S3-compatible object storage (AWS S3, MinIO, OSS, COS etc.).
+type S3Connector struct {
+	enabled bool
+	config  *s3Config
+	client  *minio.Client
+	base    *fileset.Base
+}
+
+// NewS3Connector 创建 S3 connector 构建器
+func NewS3Connector() connectors.FilesetConnector {
+	return &S3Connector{}
+}
+
+// GetType returns the data source type.
+func (c *S3Connector) GetType() string {
+	return "s3"
+}
+
+// GetName returns the data source name.
+func (c *S3Connector) GetName() string {
+	return "s3"
+}
+
+// GetMode returns the connector mode.
+func (c *S3Connector) GetMode() string {
+	return interfaces.ConnectorModeLocal
+}
+
+// GetCategory returns the connector category.
+func (c *S3Connector) GetCategory() string {
+	return interfaces.ConnectorCategoryFileset
+}
+
+// GetEnabled returns the enabled status.
+func (c *S3Connector) GetEnabled() bool {
+	return c.enabled
+}
+
+// SetEnabled sets the enabled status.
+func (c *S3Connector) SetEnabled(enabled bool) {
+	c.enabled = enabled
+}
+
+// GetSensitiveFields returns the sensitive fields for S3 connector.
+func (c *S3Connector) GetSensitiveFields() []string {
+	return []string{"secret_access_key"}
+}
+
+// GetFieldConfig returns the field configuration for S3 connector.
+func (c *S3Connector) GetFieldConfig() map[string]interfaces.ConnectorFieldConfig {
+	return map[string]interfaces.ConnectorFieldConfig{
+		"endpoint":          {Name: "服务地址", Type: "string", Description: "S3 兼容服务地址（host:port，如 s3.amazonaws.com）", Required: true, Encrypted: false},
+		"access_key_id":     {Name: "Access Key", Type: "string", Description: "访问密钥 ID", Required: true, Encrypted: false},
+		"secret_access_key": {Name: "Secret Key", Type: "string", Description: "访问密钥", Required: true, Encrypted: true},
+		"region":            {Name: "区域", Type: "string", Description: "存储桶所在区域（可选）", Required: false, Encrypted: false},
+		"bucket":            {Name: "存储桶", Type: "string", Description: "存储桶名称", Required: true, Encrypted: false},
+		"prefix":            {Name: "路径前缀", Type: "string", Description: "只发现该前缀下的对象（可选，如 warehouse/）", Required: false, Encrypted: false},
+		"use_ssl":           {Name: "启用 HTTPS", Type: "boolean", Description: "是否使用 HTTPS 连接", Required: false, Encrypted: false},
+		"path_style":        {Name: "路径风格访问", Type: "boolean", Description: "是否使用 path-style 地址（MinIO 等自建服务通常需要开启）", Required: false, Encrypted: false},
+		"file_pattern":      {Name: "文件模式", Type: "string", Description: "文件名匹配模式（可选，如 *.parquet）", Required: false, Encrypted: false},
+		"sample_size":       {Name: "采样行数", Type: "integer", Description: "每个文件用于推断 schema 的行数（默认 100）", Required: false, Encrypted: false},
+	}
+}
+
+// New creates a new S3 connector.
+func (c *S3Connector) New(cfg interfaces.ConnectorConfig) (connectors.Connector, error) {
+	var sCfg s3Config
+	if err := mapstructure.WeakDecode(cfg, &sCfg); err != nil {
+		return nil, fmt.Errorf("failed to decode s3 config: %w", err)
+	}
+
+	if sCfg.Endpoint == "" {
+		return nil, fmt.Errorf("s3 config missing required field: endpoint")
+	}
+	if strings.Contains(sCfg.Endpoint, "://") || strings.Contains(sCfg.Endpoint, "/") {
+		return nil, fmt.Errorf("invalid s3 endpoint %q: must be host[:port] without scheme or path", sCfg.Endpoint)
+	}
+	if _, _, err := net.SplitHostPort(sCfg.Endpoint); err != nil && strings.Contains(sCfg.Endpoint, ":") {
+		return nil, fmt.Errorf("invalid s3 endpoint %q: %w", sCfg.Endpoint, err)
+	}
+	if sCfg.Bucket == "" {
+		return nil, fmt.Errorf("s3 config missing required field: bucket")
+	}
+	if sCfg.AccessKeyID == "" || sCfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("s3 config missing required field: access_key_id or secret_access_key")
+	}
+	sCfg.Prefix = strings.TrimLeft(sCfg.Prefix, "/")
+	if sCfg.Prefix != "" && !strings.HasSuffix(sCfg.Prefix, "/") {
+		sCfg.Prefix += "/"
+	}
+	if err := sCfg.Options.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &S3Connector{
+		enabled: c.enabled,
+		config:  &sCfg,
+	}, nil
+}
+
+// Connect establishes connection to the object storage and checks the bucket.
+func (c *S3Connector) Connect(ctx context.Context) error {
+	if c.client != nil {
+		return nil
+	}
+
+	lookup := minio.BucketLookupAuto
+	if c.config.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+	client, err := minio.New(c.config.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(c.config.AccessKeyID, c.config.SecretAccessKey, ""),
+		Secure:       c.config.UseSSL,
+		Region:       c.config.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create s3 client: %w", err)
+	}
+
+	// 客户端为惰性连接，这里检查一次存储桶以验证地址和凭证
+	exists, err := client.BucketExists(ctx, c.config.Bucket)
+	if err != nil {
+		return fmt.Errorf("failed to connect to s3: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", c.config.Bucket)
+	}
+
+	c.client = client
+	store := &s3Store{client: client, bucket: c.config.Bucket, prefix: c.config.Prefix}
+	c.base = fileset.NewBase(store, c.config.Options, c.rootName())
+	return nil
+}
+
+func (c *S3Connector) rootName() string {
+	if c.config.Prefix == "" {
+		return c.config.Bucket
+	}
+	return path.Base(strings.TrimSuffix(c.config.Prefix, "/"))
+}
+
+// Close closes the connection.
+func (c *S3Connector) Close(ctx context.Context) error {
+	c.client = nil
+	c.base = nil
+	return nil
+}
+
+// Ping checks the connection.
+func (c *S3Connector) Ping(ctx context.Context) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	_, err := c.client.BucketExists(ctx, c.config.Bucket)
+	return err
+}
+
+// TestConnection tests the connection to the object storage.
+func (c *S3Connector) TestConnection(ctx context.Context) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	// 列举一个对象以验证读取权限
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for obj := range c.client.ListObjects(ctx, c.config.Bucket, minio.ListObjectsOptions{
+		Prefix:  c.config.Prefix,
+		MaxKeys: 1,
+	}) {
+		if obj.Err != nil {
+			return fmt.Errorf("failed to list objects: %w", obj.Err)
+		}
+		break
+	}
+	return nil
+}
+
+// GetMetadata returns the metadata for the catalog.
+func (c *S3Connector) GetMetadata(ctx context.Context) (map[string]any, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("connector not connected")
+	}
+
+	meta := map[string]any{
+		"endpoint": c.config.Endpoint,
+		"bucket":   c.config.Bucket,
+		"prefix":   c.config.Prefix,
+	}
+	if location, err := c.client.GetBucketLocation(ctx, c.config.Bucket); err == nil {
+		meta["region"] = location
+	}
+	return meta, nil
+}
+
+// ListFiles lists objects that are not part of a partitioned fileset.
+func (c *S3Connector) ListFiles(ctx context.Context) ([]*interfaces.FileMeta, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.base.ListFiles(ctx)
+}
+
+// GetFileMeta fills the file with detected format and inferred schema.
+func (c *S3Connector) GetFileMeta(ctx context.Context, file *interfaces.FileMeta) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	return c.base.GetFileMeta(ctx, file)
+}
+
+// ReadFile reads rows of an object.
+func (c *S3Connector) ReadFile(ctx context.Context, path string, limit int) (*interfaces.QueryResult, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.base.ReadFile(ctx, path, limit)
+}
+
+// ListFilesets lists partitioned prefixes.
+func (c *S3Connector) ListFilesets(ctx context.Context) ([]*interfaces.FilesetMeta, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.base.ListFilesets(ctx)
+}
+
+// GetFilesetMeta fills the fileset with inferred schema.
+func (c *S3Connector) GetFilesetMeta(ctx context.Context, fs *interfaces.FilesetMeta) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	return c.base.GetFilesetMeta(ctx, fs)
+}
+
+// ReadFileset reads rows of a fileset.
+func (c *S3Connector) ReadFileset(ctx context.Context, path string, limit int) (*interfaces.QueryResult, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.base.ReadFileset(ctx, path, limit)
+}
+
+// s3Store 以存储桶前缀为根的文件存储
+type s3Store struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// Walk implements fileset.Store.
+func (s *s3Store) Walk(ctx context.Context, fn func(obj fileset.Object) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    s.prefix,
+		Recursive: true,
+	}) {
+		if obj.Err != nil {
+			return fmt.Errorf("failed to list objects: %w", obj.Err)
+		}
+		// 跳过目录占位对象
+		if strings.HasSuffix(obj.Key, "/") {
+			continue
+		}
+		if err := fn(fileset.Object{
+			Path:         strings.TrimPrefix(obj.Key, s.prefix),
+			Size:         obj.Size,
+			LastModified: obj.LastModified,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Open implements fileset.Store.
+func (s *s3Store) Open(ctx context.Context, path string) (fileset.File, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, s.prefix+path, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject 为惰性请求，Stat 确认对象存在
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, err
+	}
+	return obj, nil
+}
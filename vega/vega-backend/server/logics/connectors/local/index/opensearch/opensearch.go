@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
@@ -322,3 +323,65 @@ func (c *OpenSearchConnector) fetchSettings(ctx context.Context, index *interfac
 	}
 	return nil
 }
+
+// SearchIndex retrieves the first documents of an index.
+func (c *OpenSearchConnector) SearchIndex(ctx context.Context, index string, limit int) (*interfaces.QueryResult, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	req := opensearchapi.SearchRequest{
+		Index:          []string{index},
+		Body:           strings.NewReader(`{"query":{"match_all":{}}}`),
+		Size:           &limit,
+		TrackTotalHits: true,
+	}
+	resp, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to search index %s: %s", index, resp.String())
+	}
+
+	var searchResp struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Source map[string]any `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	decoder := json.NewDecoder(resp.Body)
+	// 保留大整数精度
+	decoder.UseNumber()
+	if err := decoder.Decode(&searchResp); err != nil {
+		return nil, err
+	}
+
+	result := &interfaces.QueryResult{
+		Columns: []string{},
+		Rows:    make([]map[string]any, 0, len(searchResp.Hits.Hits)),
+		Total:   searchResp.Hits.Total.Value,
+	}
+	seen := make(map[string]bool)
+	for _, hit := range searchResp.Hits.Hits {
+		row := hit.Source
+		if row == nil {
+			row = map[string]any{}
+		}
+		for k := range row {
+			if !seen[k] {
+				seen[k] = true
+				result.Columns = append(result.Columns, k)
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	sort.Strings(result.Columns)
+	return result, nil
+}
@@ -0,0 +1,256 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+
+	"vega-backend/interfaces"
+)
+
+// Prometheus HTTP API 路径，见 https://prometheus.io/docs/prometheus/latest/querying/api/
+const (
+	pathQuery       = "/api/v1/query"
+	pathQueryRange  = "/api/v1/query_range"
+	pathSeries      = "/api/v1/series"
+	pathMetricNames = "/api/v1/label/__name__/values"
+	pathMetadata    = "/api/v1/metadata"
+	pathBuildInfo   = "/api/v1/status/buildinfo"
+)
+
+const (
+	labelMetricName = "__name__"
+
+	statusSuccess = "success"
+
+	resultTypeVector = "vector"
+	resultTypeMatrix = "matrix"
+	resultTypeScalar = "scalar"
+)
+
+// apiResponse Prometheus API 通用响应结构
+type apiResponse struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+	Warnings  []string        `json:"warnings"`
+}
+
+type metricMetadata struct {
+	Type string `json:"type"`
+	Help string `json:"help"`
+	Unit string `json:"unit"`
+}
+
+type buildInfo struct {
+	Version   string `json:"version"`
+	Revision  string `json:"revision"`
+	GoVersion string `json:"goVersion"`
+}
+
+// do 调用 Prometheus API 并将 data 字段解码到 out。
+// GET 请求参数放在 URL 中，POST 请求以表单提交，避免长查询超出 URL 长度限制。
+func (c *PrometheusConnector) do(ctx context.Context, method string, apiPath string,
+	params url.Values, out any) error {
+
+	u := *c.baseURL
+	u.Path = strings.TrimRight(u.Path, "/") + apiPath
+
+	var body io.Reader
+	if method == http.MethodGet {
+		u.RawQuery = params.Encode()
+	} else {
+		body = strings.NewReader(params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case c.config.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	case c.config.Username != "":
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var apiResp apiResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		// 非 API 响应（如认证网关返回的页面），直接返回状态码和内容
+		if resp.StatusCode/100 != 2 {
+			return fmt.Errorf("request %s failed with status %d: %s", apiPath, resp.StatusCode, truncate(respBody))
+		}
+		return fmt.Errorf("failed to decode response of %s: %w", apiPath, err)
+	}
+	if apiResp.Status != statusSuccess {
+		return fmt.Errorf("request %s failed with status %d: %s: %s", apiPath, resp.StatusCode,
+			apiResp.ErrorType, apiResp.Error)
+	}
+	for _, w := range apiResp.Warnings {
+		logger.Warnf("Prometheus %s warning: %s", apiPath, w)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(apiResp.Data, out); err != nil {
+		return fmt.Errorf("failed to decode data of %s: %w", apiPath, err)
+	}
+	return nil
+}
+
+// queryData query / query_range 接口的 data 字段
+type queryData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+type vectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  samplePair        `json:"value"`
+}
+
+type matrixSeries struct {
+	Metric map[string]string `json:"metric"`
+	Values []samplePair      `json:"values"`
+}
+
+// samplePair 形如 [<unix_seconds>, "<value>"]
+type samplePair interfaces.MetricValue
+
+func (p *samplePair) UnmarshalJSON(b []byte) error {
+	var raw [2]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	var ts float64
+	if err := json.Unmarshal(raw[0], &ts); err != nil {
+		return fmt.Errorf("invalid sample timestamp: %w", err)
+	}
+	var s string
+	if err := json.Unmarshal(raw[1], &s); err != nil {
+		return fmt.Errorf("invalid sample value: %w", err)
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid sample value %q: %w", s, err)
+	}
+
+	p.Timestamp = int64(math.Round(ts * 1000))
+	p.Value = v
+	return nil
+}
+
+// toMetricResults 将查询结果转换为 MetricResult，时间戳单位为毫秒
+func (d *queryData) toMetricResults() ([]*interfaces.MetricResult, error) {
+	switch d.ResultType {
+	case resultTypeVector:
+		var samples []vectorSample
+		if err := json.Unmarshal(d.Result, &samples); err != nil {
+			return nil, fmt.Errorf("failed to decode vector result: %w", err)
+		}
+		results := make([]*interfaces.MetricResult, 0, len(samples))
+		for _, s := range samples {
+			results = append(results, newMetricResult(s.Metric, finiteValues(s.Value)))
+		}
+		return results, nil
+
+	case resultTypeMatrix:
+		var series []matrixSeries
+		if err := json.Unmarshal(d.Result, &series); err != nil {
+			return nil, fmt.Errorf("failed to decode matrix result: %w", err)
+		}
+		results := make([]*interfaces.MetricResult, 0, len(series))
+		for _, s := range series {
+			results = append(results, newMetricResult(s.Metric, finiteValues(s.Values...)))
+		}
+		return results, nil
+
+	case resultTypeScalar:
+		var sample samplePair
+		if err := json.Unmarshal(d.Result, &sample); err != nil {
+			return nil, fmt.Errorf("failed to decode scalar result: %w", err)
+		}
+		return []*interfaces.MetricResult{
+			newMetricResult(nil, finiteValues(sample)),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported query result type: %s", d.ResultType)
+	}
+}
+
+// finiteValues 过滤 NaN、Inf 数据点，这些值无法以 JSON 数字表示，按缺失点处理
+func finiteValues(pairs ...samplePair) []interfaces.MetricValue {
+	values := make([]interfaces.MetricValue, 0, len(pairs))
+	for _, p := range pairs {
+		if math.IsNaN(p.Value) || math.IsInf(p.Value, 0) {
+			continue
+		}
+		values = append(values, interfaces.MetricValue(p))
+	}
+	return values
+}
+
+func newMetricResult(metric map[string]string, values []interfaces.MetricValue) *interfaces.MetricResult {
+	labels := make(map[string]string, len(metric))
+	for k, v := range metric {
+		if k != labelMetricName {
+			labels[k] = v
+		}
+	}
+	return &interfaces.MetricResult{
+		Metric: metric[labelMetricName],
+		Values: values,
+		Labels: labels,
+	}
+}
+
+// formatTime 格式化为带毫秒精度的 unix 秒
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixMilli())/1000, 'f', -1, 64)
+}
+
+// formatDuration 格式化为秒数
+func formatDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+func truncate(b []byte) string {
+	const maxLen = 256
+	if len(b) > maxLen {
+		return string(b[:maxLen]) + "..."
+	}
+	return string(b)
+}
@@ -0,0 +1,397 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package prometheus provides Prometheus-API-compatible metric connector implementation.
+package prometheus
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors"
+)
+
+const (
+	defaultSeriesLimit = 1000
+	maxSeriesLimit     = 10000
+	defaultLookback    = time.Hour
+	requestTimeout     = 30 * time.Second
+	// 每个标签最多保留的采样取值个数
+	maxLabelValues = 20
+)
+
+type prometheusConfig struct {
+	URL           string `mapstructure:"url"`
+	Username      string `mapstructure:"username"`
+	Password      string `mapstructure:"password"`
+	Token         string `mapstructure:"token"`
+	TLSSkipVerify bool   `mapstructure:"tls_skip_verify"`
+	MetricPattern string `mapstructure:"metric_pattern"`
+	SeriesLimit   int    `mapstructure:"series_limit"`
+	Lookback      string `mapstructure:"lookback"`
+}
+
+// PrometheusConnector implements MetricConnector for Prometheus-API-compatible backends
+// (Prometheus, Thanos, VictoriaMetrics, Mimir etc.).
+type PrometheusConnector struct {
+	enabled  bool
+	config   *prometheusConfig
+	baseURL  *url.URL
+	lookback time.Duration
+	client   *http.Client
+
+	// metric -> 元数据，ListMetrics 时填充
+	metadata map[string]metricMetadata
+}
+
+// NewPrometheusConnector 创建 Prometheus connector 构建器
+func NewPrometheusConnector() connectors.MetricConnector {
+	return &PrometheusConnector{}
+}
+
+// GetType returns the data source type.
+func (c *PrometheusConnector) GetType() string {
+	return "prometheus"
+}
+
+// GetName returns the data source name.
+func (c *PrometheusConnector) GetName() string {
+	return "prometheus"
+}
+
+// GetMode returns the connector mode.
+func (c *PrometheusConnector) GetMode() string {
+	return interfaces.ConnectorModeLocal
+}
+
+// GetCategory returns the connector category.
+func (c *PrometheusConnector) GetCategory() string {
+	return interfaces.ConnectorCategoryMetric
+}
+
+// GetEnabled returns the enabled status.
+func (c *PrometheusConnector) GetEnabled() bool {
+	return c.enabled
+}
+
+// SetEnabled sets the enabled status.
+func (c *PrometheusConnector) SetEnabled(enabled bool) {
+	c.enabled = enabled
+}
+
+// GetSensitiveFields returns the sensitive fields for Prometheus connector.
+func (c *PrometheusConnector) GetSensitiveFields() []string {
+	return []string{"password", "token"}
+}
+
+// GetFieldConfig returns the field configuration for Prometheus connector.
+func (c *PrometheusConnector) GetFieldConfig() map[string]interfaces.ConnectorFieldConfig {
+	return map[string]interfaces.ConnectorFieldConfig{
+		"url":             {Name: "服务地址", Type: "string", Description: "Prometheus 兼容 API 地址（如 http://localhost:9090）", Required: true, Encrypted: false},
+		"username":        {Name: "用户名", Type: "string", Description: "Basic 认证用户名（可选）", Required: false, Encrypted: false},
+		"password":        {Name: "密码", Type: "string", Description: "Basic 认证密码（可选）", Required: false, Encrypted: true},
+		"token":           {Name: "访问令牌", Type: "string", Description: "Bearer Token（可选，与 Basic 认证二选一）", Required: false, Encrypted: true},
+		"tls_skip_verify": {Name: "跳过证书校验", Type: "boolean", Description: "HTTPS 连接时是否跳过服务端证书校验", Required: false, Encrypted: false},
+		"metric_pattern":  {Name: "指标模式", Type: "string", Description: "指标名称匹配模式（可选，如 node_*）", Required: false, Encrypted: false},
+		"series_limit":    {Name: "序列采样数", Type: "integer", Description: "每个指标用于发现标签集合的最大序列数（默认 1000）", Required: false, Encrypted: false},
+		"lookback":        {Name: "回溯时长", Type: "string", Description: "发现标签集合时回溯的时间范围（默认 1h）", Required: false, Encrypted: false},
+	}
+}
+
+// New creates a new Prometheus connector.
+func (c *PrometheusConnector) New(cfg interfaces.ConnectorConfig) (connectors.Connector, error) {
+	var pCfg prometheusConfig
+	if err := mapstructure.WeakDecode(cfg, &pCfg); err != nil {
+		return nil, fmt.Errorf("failed to decode prometheus config: %w", err)
+	}
+
+	if pCfg.URL == "" {
+		return nil, fmt.Errorf("prometheus config missing required field: url")
+	}
+	baseURL, err := url.Parse(strings.TrimRight(pCfg.URL, "/"))
+	if err != nil || (baseURL.Scheme != "http" && baseURL.Scheme != "https") || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid prometheus url %q: must be http(s)://host[:port][/path]", pCfg.URL)
+	}
+	if pCfg.Token != "" && pCfg.Username != "" {
+		return nil, fmt.Errorf("prometheus config: token and username are mutually exclusive")
+	}
+	if pCfg.MetricPattern != "" {
+		if _, err := path.Match(pCfg.MetricPattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid metric_pattern %q: %w", pCfg.MetricPattern, err)
+		}
+	}
+	if pCfg.SeriesLimit <= 0 {
+		pCfg.SeriesLimit = defaultSeriesLimit
+	}
+	if pCfg.SeriesLimit > maxSeriesLimit {
+		pCfg.SeriesLimit = maxSeriesLimit
+	}
+	lookback := defaultLookback
+	if pCfg.Lookback != "" {
+		lookback, err = time.ParseDuration(pCfg.Lookback)
+		if err != nil || lookback <= 0 {
+			return nil, fmt.Errorf("invalid lookback %q: must be a positive duration such as 1h", pCfg.Lookback)
+		}
+	}
+
+	return &PrometheusConnector{
+		enabled:  c.enabled,
+		config:   &pCfg,
+		baseURL:  baseURL,
+		lookback: lookback,
+	}, nil
+}
+
+// Connect establishes connection to Prometheus.
+func (c *PrometheusConnector) Connect(ctx context.Context) error {
+	if c.client != nil {
+		return nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.config.TLSSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	client := &http.Client{
+		Timeout:   requestTimeout,
+		Transport: transport,
+	}
+
+	// HTTP 为无状态连接，这里执行一次最简查询以验证地址和认证
+	c.client = client
+	if err := c.ping(ctx); err != nil {
+		c.client = nil
+		transport.CloseIdleConnections()
+		return fmt.Errorf("failed to connect to prometheus: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection.
+func (c *PrometheusConnector) Close(ctx context.Context) error {
+	if c.client != nil {
+		c.client.CloseIdleConnections()
+		c.client = nil
+	}
+	c.metadata = nil
+	return nil
+}
+
+// Ping checks the connection.
+func (c *PrometheusConnector) Ping(ctx context.Context) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	return c.ping(ctx)
+}
+
+func (c *PrometheusConnector) ping(ctx context.Context) error {
+	params := url.Values{"query": {"1"}}
+	var data queryData
+	return c.do(ctx, http.MethodGet, pathQuery, params, &data)
+}
+
+// TestConnection tests the connection to Prometheus.
+func (c *PrometheusConnector) TestConnection(ctx context.Context) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	return c.Ping(ctx)
+}
+
+// GetMetadata returns the metadata for the catalog.
+func (c *PrometheusConnector) GetMetadata(ctx context.Context) (map[string]any, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("connector not connected")
+	}
+
+	meta := map[string]any{
+		"url": c.baseURL.String(),
+	}
+
+	// buildinfo 并非所有兼容实现都提供，失败时仅返回地址
+	var info buildInfo
+	if err := c.do(ctx, http.MethodGet, pathBuildInfo, nil, &info); err == nil {
+		meta["version"] = info.Version
+		meta["revision"] = info.Revision
+		meta["go_version"] = info.GoVersion
+	}
+
+	return meta, nil
+}
+
+// ListMetrics lists all metric names matching metric_pattern.
+func (c *PrometheusConnector) ListMetrics(ctx context.Context) ([]*interfaces.MetricMeta, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if err := c.do(ctx, http.MethodGet, pathMetricNames, nil, &names); err != nil {
+		return nil, fmt.Errorf("failed to list metric names: %w", err)
+	}
+
+	// 指标类型、说明来自 metadata 接口，部分兼容实现不提供，失败时忽略
+	c.metadata = make(map[string]metricMetadata)
+	var metadata map[string][]metricMetadata
+	if err := c.do(ctx, http.MethodGet, pathMetadata, nil, &metadata); err == nil {
+		for name, entries := range metadata {
+			if len(entries) > 0 {
+				c.metadata[name] = entries[0]
+			}
+		}
+	}
+
+	metrics := make([]*interfaces.MetricMeta, 0, len(names))
+	for _, name := range names {
+		if !c.matchMetric(name) {
+			continue
+		}
+		metric := &interfaces.MetricMeta{Name: name, Type: "unknown"}
+		if md, ok := c.metadata[name]; ok {
+			metric.Type = md.Type
+			metric.Help = md.Help
+			metric.Unit = md.Unit
+		}
+		metrics = append(metrics, metric)
+	}
+
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics, nil
+}
+
+// GetMetricMeta fills the metric with label sets sampled from its series.
+func (c *PrometheusConnector) GetMetricMeta(ctx context.Context, metric *interfaces.MetricMeta) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	end := time.Now()
+	params := url.Values{
+		"match[]": {fmt.Sprintf("{__name__=%q}", metric.Name)},
+		"start":   {formatTime(end.Add(-c.lookback))},
+		"end":     {formatTime(end)},
+		"limit":   {fmt.Sprint(c.config.SeriesLimit)},
+	}
+	var series []map[string]string
+	if err := c.do(ctx, http.MethodPost, pathSeries, params, &series); err != nil {
+		return fmt.Errorf("failed to get series for metric %s: %w", metric.Name, err)
+	}
+	// 旧版本 Prometheus 不支持 limit 参数，这里再截断一次
+	if len(series) > c.config.SeriesLimit {
+		series = series[:c.config.SeriesLimit]
+	}
+
+	metric.SeriesCount = len(series)
+	metric.Labels = labelSets(series)
+
+	if metric.Type == "" {
+		metric.Type = "unknown"
+		if md, ok := c.metadata[metric.Name]; ok {
+			metric.Type = md.Type
+			metric.Help = md.Help
+			metric.Unit = md.Unit
+		}
+	}
+	return nil
+}
+
+// QueryInstant evaluates an instant query at ts.
+func (c *PrometheusConnector) QueryInstant(ctx context.Context, query string,
+	ts time.Time) ([]*interfaces.MetricResult, error) {
+
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"query": {query}}
+	if !ts.IsZero() {
+		params.Set("time", formatTime(ts))
+	}
+	var data queryData
+	if err := c.do(ctx, http.MethodPost, pathQuery, params, &data); err != nil {
+		return nil, fmt.Errorf("failed to execute instant query: %w", err)
+	}
+	return data.toMetricResults()
+}
+
+// QueryRange evaluates a range query over [start, end] with the given step.
+func (c *PrometheusConnector) QueryRange(ctx context.Context, query string, start, end time.Time,
+	step time.Duration) ([]*interfaces.MetricResult, error) {
+
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("range query step must be positive")
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("range query end must not be before start")
+	}
+
+	params := url.Values{
+		"query": {query},
+		"start": {formatTime(start)},
+		"end":   {formatTime(end)},
+		"step":  {formatDuration(step)},
+	}
+	var data queryData
+	if err := c.do(ctx, http.MethodPost, pathQueryRange, params, &data); err != nil {
+		return nil, fmt.Errorf("failed to execute range query: %w", err)
+	}
+	return data.toMetricResults()
+}
+
+func (c *PrometheusConnector) matchMetric(name string) bool {
+	if c.config.MetricPattern == "" {
+		return true
+	}
+	matched, _ := path.Match(c.config.MetricPattern, name)
+	return matched
+}
+
+// labelSets 汇总序列中的标签及其取值
+func labelSets(series []map[string]string) []interfaces.MetricLabel {
+	values := make(map[string]map[string]struct{})
+	for _, s := range series {
+		for name, value := range s {
+			if name == labelMetricName {
+				continue
+			}
+			if values[name] == nil {
+				values[name] = make(map[string]struct{})
+			}
+			values[name][value] = struct{}{}
+		}
+	}
+
+	labels := make([]interfaces.MetricLabel, 0, len(values))
+	for name, set := range values {
+		vs := make([]string, 0, len(set))
+		for v := range set {
+			vs = append(vs, v)
+		}
+		sort.Strings(vs)
+		label := interfaces.MetricLabel{Name: name, Cardinality: len(vs), Values: vs}
+		if len(vs) > maxLabelValues {
+			label.Values = vs[:maxLabelValues]
+		}
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return labels
+}
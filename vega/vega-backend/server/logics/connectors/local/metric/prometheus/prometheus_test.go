@@ -0,0 +1,125 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"vega-backend/interfaces"
+)
+
+// newStandIn 启动模拟 Prometheus API 的服务，记录最近一次查询的表单参数
+func newStandIn(t *testing.T, form *map[string]string) *PrometheusConnector {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	record := func(r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		*form = map[string]string{}
+		for k := range r.Form {
+			(*form)[k] = r.Form.Get(k)
+		}
+	}
+	mux.HandleFunc(pathQuery, func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		if r.Form.Get("query") == "1" {
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1700000000,"1"]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"__name__":"up","job":"prometheus","instance":"localhost:9090"},"value":[1700000000.5,"1"]},
+			{"metric":{"__name__":"up","job":"node","instance":"localhost:9100"},"value":[1700000000.5,"NaN"]}]}}`))
+	})
+	mux.HandleFunc(pathQueryRange, func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"__name__":"up","job":"prometheus"},"values":[[1700000000,"1"],[1700000060,"0"]]}]}}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	conn, err := NewPrometheusConnector().New(interfaces.ConnectorConfig{"url": server.URL})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	if err := conn.Connect(context.Background()); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	return conn.(*PrometheusConnector)
+}
+
+// TestQueryInstant 即时查询返回向量结果，过滤非有限值并去掉 __name__ 标签
+func TestQueryInstant(t *testing.T) {
+	var form map[string]string
+	conn := newStandIn(t, &form)
+
+	results, err := conn.QueryInstant(context.Background(), "up", time.UnixMilli(1700000000500))
+	if err != nil {
+		t.Fatalf("query instant: %v", err)
+	}
+	if form["query"] != "up" || form["time"] != "1700000000.5" {
+		t.Errorf("unexpected request form %v", form)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 series, got %d", len(results))
+	}
+
+	first := results[0]
+	if first.Metric != "up" || first.Labels["job"] != "prometheus" {
+		t.Errorf("unexpected series %+v", first)
+	}
+	if _, ok := first.Labels[labelMetricName]; ok {
+		t.Errorf("labels should not contain %s", labelMetricName)
+	}
+	if len(first.Values) != 1 || first.Values[0] != (interfaces.MetricValue{Timestamp: 1700000000500, Value: 1}) {
+		t.Errorf("unexpected values %+v", first.Values)
+	}
+	if len(results[1].Values) != 0 {
+		t.Errorf("NaN sample should be dropped, got %+v", results[1].Values)
+	}
+
+	// 未指定时间时使用数据源当前时间
+	if _, err := conn.QueryInstant(context.Background(), "up", time.Time{}); err != nil {
+		t.Fatalf("query instant: %v", err)
+	}
+	if _, ok := form["time"]; ok {
+		t.Errorf("time should not be sent for a zero timestamp, got %v", form)
+	}
+}
+
+// TestQueryRange 区间查询返回矩阵结果，时间参数以秒提交
+func TestQueryRange(t *testing.T) {
+	var form map[string]string
+	conn := newStandIn(t, &form)
+
+	start := time.Unix(1700000000, 0)
+	results, err := conn.QueryRange(context.Background(), "up", start, start.Add(time.Minute), 30*time.Second)
+	if err != nil {
+		t.Fatalf("query range: %v", err)
+	}
+	if form["start"] != "1700000000" || form["end"] != "1700000060" || form["step"] != "30" {
+		t.Errorf("unexpected request form %v", form)
+	}
+	if len(results) != 1 || len(results[0].Values) != 2 {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if results[0].Values[1] != (interfaces.MetricValue{Timestamp: 1700000060000, Value: 0}) {
+		t.Errorf("unexpected values %+v", results[0].Values)
+	}
+
+	if _, err := conn.QueryRange(context.Background(), "up", start, start.Add(time.Minute), 0); err == nil {
+		t.Error("non-positive step should be rejected")
+	}
+	if _, err := conn.QueryRange(context.Background(), "up", start, start.Add(-time.Minute), time.Second); err == nil {
+		t.Error("end before start should be rejected")
+	}
+}
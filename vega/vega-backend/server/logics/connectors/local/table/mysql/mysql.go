@@ -19,6 +19,7 @@ import (
 
 	"vega-backend/interfaces"
 	"vega-backend/logics/connectors"
+	"vega-backend/logics/connectors/local/table"
 )
 
 type mysqlConfig struct {
@@ -705,8 +706,32 @@ func (c *MySQLConnector) fetchForeignKeys(ctx context.Context, table *interfaces
 	return nil
 }
 
+// ExecuteQuery 在连接的数据库上执行查询，参数占位符为 ?
 func (c *MySQLConnector) ExecuteQuery(ctx context.Context, query string, args ...any) (*interfaces.QueryResult, error) {
-	return nil, nil
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	result, err := table.ScanRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan query result: %w", err)
+	}
+
+	// 驱动以 []byte 返回字符、decimal 等类型的文本表示，转为字符串便于序列化
+	for _, row := range result.Rows {
+		for k, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[k] = string(b)
+			}
+		}
+	}
+	return result, nil
 }
 
 // GetMetadata returns the metadata for the catalog.
@@ -0,0 +1,845 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package postgresql provides PostgreSQL database connector implementation.
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	_ "github.com/lib/pq"
+	"github.com/mitchellh/mapstructure"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors"
+	"vega-backend/logics/connectors/local/table"
+)
+
+type postgresqlConfig struct {
+	Host     string         `mapstructure:"host"`
+	Port     int            `mapstructure:"port"`
+	Username string         `mapstructure:"username"`
+	Password string         `mapstructure:"password"`
+	Database string         `mapstructure:"database"`
+	Schemas  []string       `mapstructure:"schemas"`
+	Options  map[string]any `mapstructure:"options"`
+}
+
+var (
+	// SYSTEM_SCHEMAS 系统 schema，pg_toast_*、pg_temp_* 等按前缀排除
+	SYSTEM_SCHEMAS = []string{
+		"information_schema",
+		"pg_catalog",
+	}
+
+	// relkind 到 TableMeta.SubType 的映射。分区表的子分区不单独列出。
+	RELKIND_SUB_TYPES = map[string]string{
+		"r": "table",
+		"p": "table",
+		"f": "table",
+		"v": "view",
+		"m": "materialized_view",
+	}
+
+	// pg_constraint 中外键动作的编码
+	FK_ACTIONS = map[string]string{
+		"a": "NO ACTION",
+		"r": "RESTRICT",
+		"c": "CASCADE",
+		"n": "SET NULL",
+		"d": "SET DEFAULT",
+	}
+
+	psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+)
+
+const (
+	// DEFAULT_DATABASE 未指定数据库时连接的默认库
+	DEFAULT_DATABASE = "postgres"
+	// IDENTIFIER_MAX_LENGTH PostgreSQL 标识符最大长度（NAMEDATALEN - 1）
+	IDENTIFIER_MAX_LENGTH = 63
+	// PORT_MIN 有效端口最小值
+	PORT_MIN = 1
+	// PORT_MAX 有效端口最大值
+	PORT_MAX = 65535
+)
+
+// PostgreSQLConnector implements TableConnector for PostgreSQL.
+// PostgreSQL 的连接固定在一个数据库上，表归属于 schema，因此 TableMeta.Database 填写 schema 名，
+// 资源标识为 schema.table，可直接用于该数据库上的查询。
+type PostgreSQLConnector struct {
+	enabled bool
+
+	config *postgresqlConfig
+
+	connected bool
+	db        *sql.DB
+}
+
+// NewPostgreSQLConnector 创建 PostgreSQL connector 构建器
+func NewPostgreSQLConnector() connectors.TableConnector {
+	return &PostgreSQLConnector{}
+}
+
+// GetType returns the data source type.
+func (c *PostgreSQLConnector) GetType() string {
+	return "postgresql"
+}
+
+// GetName returns the connector name.
+func (c *PostgreSQLConnector) GetName() string {
+	return "postgresql"
+}
+
+// GetMode returns the connector mode.
+func (c *PostgreSQLConnector) GetMode() string {
+	return interfaces.ConnectorModeLocal
+}
+
+// GetCategory returns the connector category.
+func (c *PostgreSQLConnector) GetCategory() string {
+	return interfaces.ConnectorCategoryTable
+}
+
+// GetEnabled returns the enabled status.
+func (c *PostgreSQLConnector) GetEnabled() bool {
+	return c.enabled
+}
+
+// SetEnabled sets the enabled status.
+func (c *PostgreSQLConnector) SetEnabled(enabled bool) {
+	c.enabled = enabled
+}
+
+// GetSensitiveFields returns the sensitive fields for PostgreSQL connector.
+func (c *PostgreSQLConnector) GetSensitiveFields() []string {
+	return []string{"password"}
+}
+
+// GetFieldConfig returns the field configuration for PostgreSQL connector.
+func (c *PostgreSQLConnector) GetFieldConfig() map[string]interfaces.ConnectorFieldConfig {
+	return map[string]interfaces.ConnectorFieldConfig{
+		"host":     {Name: "主机地址", Type: "string", Description: "PostgreSQL 服务器主机地址", Required: true, Encrypted: false},
+		"port":     {Name: "端口号", Type: "integer", Description: "PostgreSQL 服务器端口", Required: true, Encrypted: false},
+		"username": {Name: "用户名", Type: "string", Description: "数据库用户名", Required: true, Encrypted: false},
+		"password": {Name: "密码", Type: "string", Description: "数据库密码", Required: true, Encrypted: true},
+		"database": {Name: "数据库", Type: "string", Description: "连接的数据库名称（可选，默认 postgres）", Required: false, Encrypted: false},
+		"schemas":  {Name: "Schema 列表", Type: "array", Description: "Schema 名称列表（可选，为空则发现所有用户 schema）", Required: false, Encrypted: false},
+		"options":  {Name: "连接参数", Type: "object", Description: "连接参数（如 sslmode, connect_timeout 等）", Required: false, Encrypted: false},
+	}
+}
+
+// New creates a new PostgreSQL connector.
+// Schemas 为可选字段，不指定时发现数据库下所有用户 schema。
+func (c *PostgreSQLConnector) New(cfg interfaces.ConnectorConfig) (connectors.Connector, error) {
+	var pCfg postgresqlConfig
+	if err := mapstructure.Decode(cfg, &pCfg); err != nil {
+		return nil, fmt.Errorf("failed to decode postgresql config: %w", err)
+	}
+
+	if pCfg.Host == "" || pCfg.Port == 0 || pCfg.Username == "" || pCfg.Password == "" {
+		return nil, fmt.Errorf("postgresql connector config is incomplete")
+	}
+
+	// 验证端口号范围
+	if pCfg.Port < PORT_MIN || pCfg.Port > PORT_MAX {
+		return nil, fmt.Errorf("port %d is out of valid range (%d-%d)", pCfg.Port, PORT_MIN, PORT_MAX)
+	}
+
+	if pCfg.Database == "" {
+		pCfg.Database = DEFAULT_DATABASE
+	}
+
+	// 验证数据库和 schema 名称长度
+	if len(pCfg.Database) > IDENTIFIER_MAX_LENGTH {
+		return nil, fmt.Errorf("database name '%s' exceeds maximum length of %d characters", pCfg.Database, IDENTIFIER_MAX_LENGTH)
+	}
+	for _, schema := range pCfg.Schemas {
+		if len(schema) > IDENTIFIER_MAX_LENGTH {
+			return nil, fmt.Errorf("schema name '%s' exceeds maximum length of %d characters", schema, IDENTIFIER_MAX_LENGTH)
+		}
+	}
+
+	return &PostgreSQLConnector{
+		config: &pCfg,
+	}, nil
+}
+
+// Connect establishes connection to PostgreSQL database.
+func (c *PostgreSQLConnector) Connect(ctx context.Context) error {
+	if c.connected {
+		return nil
+	}
+
+	// Build DSN
+	values := url.Values{}
+	values.Set("sslmode", "disable")
+
+	// Apply options
+	for k, v := range c.config.Options {
+		values.Set(k, fmt.Sprintf("%v", v))
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.config.Username, c.config.Password),
+		Host:     net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port)),
+		Path:     "/" + c.config.Database,
+		RawQuery: values.Encode(),
+	}
+
+	db, err := sql.Open("postgres", dsn.String())
+	if err != nil {
+		return err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return err
+	}
+
+	c.db = db
+	c.connected = true
+
+	return nil
+}
+
+// Close closes the database connection.
+func (c *PostgreSQLConnector) Close(ctx context.Context) error {
+	if c.db != nil {
+		err := c.db.Close()
+		c.connected = false
+		c.db = nil
+		return err
+	}
+	return nil
+}
+
+// Ping checks the database connection.
+func (c *PostgreSQLConnector) Ping(ctx context.Context) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	return c.db.PingContext(ctx)
+}
+
+// TestConnection tests the connection to PostgreSQL database.
+func (c *PostgreSQLConnector) TestConnection(ctx context.Context) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	// 如果配置了 schemas 列表，验证这些 schema 是否存在
+	if len(c.config.Schemas) > 0 {
+		if err := c.validateSchemas(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateSchemas 验证配置的 schema 是否存在
+func (c *PostgreSQLConnector) validateSchemas(ctx context.Context) error {
+	query, args, err := psql.Select("nspname").
+		From("pg_catalog.pg_namespace").
+		Where(sq.Eq{"nspname": c.config.Schemas}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to list schemas: %w", err)
+	}
+	defer rows.Close()
+
+	existingSchemas := make(map[string]bool)
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return fmt.Errorf("failed to scan schema name: %w", err)
+		}
+		existingSchemas[schema] = true
+	}
+
+	// 检查配置的 schema 是否都存在
+	var notFoundSchemas []string
+	for _, schema := range c.config.Schemas {
+		if !existingSchemas[schema] {
+			notFoundSchemas = append(notFoundSchemas, schema)
+		}
+	}
+
+	if len(notFoundSchemas) > 0 {
+		return fmt.Errorf("schemas not found: %v", notFoundSchemas)
+	}
+
+	return nil
+}
+
+// ListDatabases 列出实例下所有可连接的数据库（排除模板库）。
+func (c *PostgreSQLConnector) ListDatabases(ctx context.Context) ([]string, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Select("datname").
+		From("pg_catalog.pg_database").
+		Where(sq.Eq{"datistemplate": false}).
+		Where(sq.Eq{"datallowconn": true}).
+		OrderBy("datname").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list databases query: %w", err)
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %w", err)
+	}
+	defer rows.Close()
+
+	var databases []string
+	for rows.Next() {
+		var db string
+		if err := rows.Scan(&db); err != nil {
+			return nil, fmt.Errorf("failed to scan database name: %w", err)
+		}
+		databases = append(databases, db)
+	}
+	return databases, rows.Err()
+}
+
+// relationBuilder 构造查询表、视图、物化视图基本信息的语句
+func (c *PostgreSQLConnector) relationBuilder() sq.SelectBuilder {
+	return psql.Select(
+		"n.nspname",
+		"c.relname",
+		"c.relkind",
+		"obj_description(c.oid, 'pg_class')",
+		"pg_catalog.pg_get_userbyid(c.relowner)",
+		"c.reltuples::bigint",
+		"pg_catalog.pg_relation_size(c.oid)",
+		"pg_catalog.pg_indexes_size(c.oid)",
+		"pg_catalog.pg_total_relation_size(c.oid)",
+	).From("pg_catalog.pg_class c").
+		Join("pg_catalog.pg_namespace n ON n.oid = c.relnamespace").
+		Where(sq.Eq{"c.relkind": []string{"r", "p", "f", "v", "m"}})
+}
+
+// scanRelation 把 relationBuilder 的一行结果填充到 TableMeta
+func scanRelation(rows sq.RowScanner, meta *interfaces.TableMeta) error {
+	var schema, name, relkind string
+	var description, owner sql.NullString
+	var tableRows, dataLength, indexLength, totalLength sql.NullInt64
+
+	if err := rows.Scan(
+		&schema,
+		&name,
+		&relkind,
+		&description,
+		&owner,
+		&tableRows,
+		&dataLength,
+		&indexLength,
+		&totalLength,
+	); err != nil {
+		return err
+	}
+
+	meta.Name = name
+	meta.Database = schema
+	meta.Description = description.String
+	if meta.SubType == "" {
+		meta.SubType = RELKIND_SUB_TYPES[relkind]
+	}
+
+	if meta.Properties == nil {
+		meta.Properties = make(map[string]any)
+	}
+	meta.Properties["schema"] = schema
+	meta.Properties["owner"] = owner.String
+	// 从未 analyze 过的表 reltuples 为 -1
+	meta.Properties["row_count"] = max(tableRows.Int64, 0)
+	meta.Properties["data_length"] = dataLength.Int64
+	meta.Properties["index_length"] = indexLength.Int64
+	meta.Properties["total_length"] = totalLength.Int64
+	switch relkind {
+	case "p":
+		meta.Properties["partitioned"] = true
+	case "f":
+		meta.Properties["foreign"] = true
+	}
+	return nil
+}
+
+// ListTables 返回数据库中的表、分区表、外部表、视图和物化视图。
+// 如果配置了 Schemas，只列出这些 schema 下的表；否则遍历所有用户 schema。
+// 返回的 TableMeta.Database 字段为所属 schema。
+func (c *PostgreSQLConnector) ListTables(ctx context.Context) ([]*interfaces.TableMeta, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	// 分区表的子分区通过父表访问，不单独列出
+	builder := c.relationBuilder().
+		Where("NOT c.relispartition")
+
+	// Filter schemas
+	if len(c.config.Schemas) > 0 {
+		builder = builder.Where(sq.Eq{"n.nspname": c.config.Schemas})
+	} else {
+		builder = builder.Where(sq.NotEq{"n.nspname": SYSTEM_SCHEMAS}).
+			Where(sq.NotLike{"n.nspname": "pg_toast%"}).
+			Where(sq.NotLike{"n.nspname": "pg_temp_%"})
+	}
+
+	query, args, err := builder.OrderBy("n.nspname", "c.relname").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list tables query: %w", err)
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []*interfaces.TableMeta
+	for rows.Next() {
+		meta := &interfaces.TableMeta{}
+		if err := scanRelation(rows, meta); err != nil {
+			return nil, fmt.Errorf("failed to scan table info: %w", err)
+		}
+		meta.Properties["database"] = c.config.Database
+		tables = append(tables, meta)
+	}
+
+	return tables, rows.Err()
+}
+
+// GetTableMeta returns metadata for a specific table.
+// table.Database 为 schema 名，table.Name 为表名
+func (c *PostgreSQLConnector) GetTableMeta(ctx context.Context, table *interfaces.TableMeta) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	// 1. 获取表基本信息（类型、属主、行数、大小、注释）
+	if err := c.fetchTableStatus(ctx, table); err != nil {
+		return fmt.Errorf("failed to fetch table status: %w", err)
+	}
+
+	// 2. 获取字段信息
+	if err := c.fetchColumns(ctx, table); err != nil {
+		return fmt.Errorf("failed to fetch columns: %w", err)
+	}
+
+	// 3. 获取索引信息，同时确定主键
+	if err := c.fetchIndexes(ctx, table); err != nil {
+		return fmt.Errorf("failed to fetch indexes: %w", err)
+	}
+
+	// 4. 获取外键信息
+	if err := c.fetchForeignKeys(ctx, table); err != nil {
+		return fmt.Errorf("failed to fetch foreign keys: %w", err)
+	}
+
+	return nil
+}
+
+// fetchTableStatus retrieves table status from pg_class.
+func (c *PostgreSQLConnector) fetchTableStatus(ctx context.Context, table *interfaces.TableMeta) error {
+	query, args, err := c.relationBuilder().
+		Where(sq.Eq{"n.nspname": table.Database}).
+		Where(sq.Eq{"c.relname": table.Name}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	row := c.db.QueryRowContext(ctx, query, args...)
+	if err := scanRelation(row, table); err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+	table.Properties["database"] = c.config.Database
+	return nil
+}
+
+// fetchColumns retrieves column metadata from pg_attribute.
+// 不使用 information_schema.columns，因为它不包含物化视图的字段。
+func (c *PostgreSQLConnector) fetchColumns(ctx context.Context, table *interfaces.TableMeta) error {
+	query, args, err := psql.Select(
+		"a.attname",
+		"t.typname",
+		"pg_catalog.format_type(a.atttypid, a.atttypmod)",
+		"a.attnotnull",
+		"pg_catalog.pg_get_expr(d.adbin, d.adrelid)",
+		"pg_catalog.col_description(a.attrelid, a.attnum)",
+		"a.atttypmod",
+		"co.collname",
+		"a.attnum",
+	).From("pg_catalog.pg_attribute a").
+		Join("pg_catalog.pg_class c ON c.oid = a.attrelid").
+		Join("pg_catalog.pg_namespace n ON n.oid = c.relnamespace").
+		Join("pg_catalog.pg_type t ON t.oid = a.atttypid").
+		LeftJoin("pg_catalog.pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum").
+		LeftJoin("pg_catalog.pg_collation co ON co.oid = a.attcollation AND a.attcollation <> t.typcollation").
+		Where(sq.Eq{"n.nspname": table.Database}).
+		Where(sq.Eq{"c.relname": table.Name}).
+		Where(sq.Gt{"a.attnum": 0}).
+		Where(sq.Eq{"a.attisdropped": false}).
+		OrderBy("a.attnum").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var columns []interfaces.ColumnMeta
+	for rows.Next() {
+		var name, typeName, columnType sql.NullString
+		var columnDefault, description, collation sql.NullString
+		var notNull bool
+		var typmod, position sql.NullInt64
+
+		if err := rows.Scan(
+			&name,
+			&typeName,
+			&columnType,
+			&notNull,
+			&columnDefault,
+			&description,
+			&typmod,
+			&collation,
+			&position,
+		); err != nil {
+			return err
+		}
+
+		col := interfaces.ColumnMeta{
+			Name:            name.String,
+			Type:            MapType(typeName.String),
+			OrigType:        columnType.String,
+			Nullable:        !notNull,
+			DefaultValue:    columnDefault.String,
+			Description:     description.String,
+			Collation:       collation.String,
+			OrdinalPosition: int(position.Int64),
+		}
+		applyTypeModifier(&col, typeName.String, int(typmod.Int64))
+		columns = append(columns, col)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	table.Columns = columns
+	return nil
+}
+
+// applyTypeModifier 从 atttypmod 中解析长度、精度和小数位，与 information_schema.columns 的计算方式一致
+func applyTypeModifier(col *interfaces.ColumnMeta, typeName string, typmod int) {
+	switch typeName {
+	case "int2":
+		col.NumPrecision = 16
+	case "int4":
+		col.NumPrecision = 32
+	case "int8":
+		col.NumPrecision = 64
+	case "float4":
+		col.NumPrecision = 24
+	case "float8":
+		col.NumPrecision = 53
+	case "numeric":
+		// typmod = ((precision << 16) | scale) + 4，未指定精度时为 -1
+		if typmod >= 4 {
+			col.NumPrecision = ((typmod - 4) >> 16) & 0xffff
+			col.NumScale = (typmod - 4) & 0xffff
+		}
+	case "bpchar", "varchar":
+		// typmod = 长度 + 4
+		if typmod >= 4 {
+			col.CharMaxLen = typmod - 4
+		}
+	case "bit", "varbit":
+		if typmod > 0 {
+			col.CharMaxLen = typmod
+		}
+	case "timestamp", "timestamptz", "time", "timetz":
+		// 未指定精度时默认为 6 位
+		col.DatetimePrecision = 6
+		if typmod >= 0 {
+			col.DatetimePrecision = typmod
+		}
+	}
+}
+
+// fetchIndexes retrieves index metadata from pg_index, and fills primary keys.
+func (c *PostgreSQLConnector) fetchIndexes(ctx context.Context, table *interfaces.TableMeta) error {
+	query, args, err := psql.Select(
+		"i.relname",
+		"pg_catalog.pg_get_indexdef(ix.indexrelid, k.ord::int, true)",
+		"ix.indisunique",
+		"ix.indisprimary",
+	).From("pg_catalog.pg_index ix").
+		Join("pg_catalog.pg_class t ON t.oid = ix.indrelid").
+		Join("pg_catalog.pg_namespace n ON n.oid = t.relnamespace").
+		Join("pg_catalog.pg_class i ON i.oid = ix.indexrelid").
+		JoinClause("CROSS JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord)").
+		Where(sq.Eq{"n.nspname": table.Database}).
+		Where(sq.Eq{"t.relname": table.Name}).
+		OrderBy("i.relname", "k.ord").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var indexes []interfaces.IndexInfo
+	indexPos := make(map[string]int)
+
+	for rows.Next() {
+		var indexName, columnName sql.NullString
+		var unique, primary bool
+
+		if err := rows.Scan(
+			&indexName,
+			&columnName,
+			&unique,
+			&primary,
+		); err != nil {
+			return err
+		}
+
+		// 表达式索引的列为表达式文本
+		name := indexName.String
+		if pos, ok := indexPos[name]; ok {
+			indexes[pos].Columns = append(indexes[pos].Columns, columnName.String)
+		} else {
+			indexPos[name] = len(indexes)
+			indexes = append(indexes, interfaces.IndexInfo{
+				Name:    name,
+				Columns: []string{columnName.String},
+				Unique:  unique,
+				Primary: primary,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var pkColumns []string
+	for _, idx := range indexes {
+		if idx.Primary {
+			pkColumns = idx.Columns
+			break
+		}
+	}
+
+	// 与 mysql 一致，主键列的 ColumnKey 标记为 PRI
+	pkSet := make(map[string]bool, len(pkColumns))
+	for _, col := range pkColumns {
+		pkSet[col] = true
+	}
+	for i := range table.Columns {
+		if pkSet[table.Columns[i].Name] {
+			table.Columns[i].ColumnKey = "PRI"
+		}
+	}
+
+	table.Indexes = indexes
+	table.PKs = pkColumns
+	return nil
+}
+
+// fetchForeignKeys retrieves foreign key metadata from pg_constraint.
+// 被引用表与当前表在同一 schema 时 RefTable 为表名，否则为 schema.table。
+func (c *PostgreSQLConnector) fetchForeignKeys(ctx context.Context, table *interfaces.TableMeta) error {
+	query, args, err := psql.Select(
+		"con.conname",
+		"a.attname",
+		"rn.nspname",
+		"rc.relname",
+		"ra.attname",
+		"con.confdeltype",
+		"con.confupdtype",
+	).From("pg_catalog.pg_constraint con").
+		Join("pg_catalog.pg_class c ON c.oid = con.conrelid").
+		Join("pg_catalog.pg_namespace n ON n.oid = c.relnamespace").
+		Join("pg_catalog.pg_class rc ON rc.oid = con.confrelid").
+		Join("pg_catalog.pg_namespace rn ON rn.oid = rc.relnamespace").
+		JoinClause("CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, refattnum, ord)").
+		Join("pg_catalog.pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum").
+		Join("pg_catalog.pg_attribute ra ON ra.attrelid = con.confrelid AND ra.attnum = k.refattnum").
+		Where(sq.Eq{"con.contype": "f"}).
+		Where(sq.Eq{"n.nspname": table.Database}).
+		Where(sq.Eq{"c.relname": table.Name}).
+		OrderBy("con.conname", "k.ord").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var fks []interfaces.ForeignKeyInfo
+	fkPos := make(map[string]int)
+
+	for rows.Next() {
+		var constraintName, columnName, refSchema, refTableName, refColumnName sql.NullString
+		var onDelete, onUpdate sql.NullString
+
+		if err := rows.Scan(
+			&constraintName,
+			&columnName,
+			&refSchema,
+			&refTableName,
+			&refColumnName,
+			&onDelete,
+			&onUpdate,
+		); err != nil {
+			return err
+		}
+
+		name := constraintName.String
+		if pos, ok := fkPos[name]; ok {
+			fks[pos].Columns = append(fks[pos].Columns, columnName.String)
+			fks[pos].RefColumns = append(fks[pos].RefColumns, refColumnName.String)
+		} else {
+			refTable := refTableName.String
+			if refSchema.String != table.Database {
+				refTable = refSchema.String + "." + refTable
+			}
+			fkPos[name] = len(fks)
+			fks = append(fks, interfaces.ForeignKeyInfo{
+				Name:       name,
+				Columns:    []string{columnName.String},
+				RefTable:   refTable,
+				RefColumns: []string{refColumnName.String},
+				OnDelete:   FK_ACTIONS[onDelete.String],
+				OnUpdate:   FK_ACTIONS[onUpdate.String],
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	table.ForeignKeys = fks
+	return nil
+}
+
+// ExecuteQuery 在连接的数据库上执行查询，参数占位符为 $1, $2 ...
+func (c *PostgreSQLConnector) ExecuteQuery(ctx context.Context, query string, args ...any) (*interfaces.QueryResult, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	result, err := table.ScanRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan query result: %w", err)
+	}
+
+	// lib/pq 以 []byte 返回 numeric、数组等类型的文本表示，转为字符串便于序列化
+	for _, row := range result.Rows {
+		for k, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[k] = string(b)
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetMetadata returns the metadata for the catalog.
+func (c *PostgreSQLConnector) GetMetadata(ctx context.Context) (map[string]any, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	// 包含版本、字符集、时区、只读以及复制相关的配置
+	targetSettings := []string{
+		"server_version",
+		"server_encoding",
+		"lc_collate",
+		"lc_ctype",
+		"TimeZone",
+		"max_connections",
+		"default_transaction_read_only",
+		"wal_level",
+	}
+
+	query, args, err := psql.Select("name", "setting").
+		From("pg_catalog.pg_settings").
+		Where(sq.Eq{"name": targetSettings}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	metadata := make(map[string]any)
+	for rows.Next() {
+		var name, setting string
+		if err := rows.Scan(&name, &setting); err == nil {
+			metadata[strings.ToLower(name)] = setting
+		}
+	}
+	metadata["database"] = c.config.Database
+
+	// 推断集群模式：处于恢复状态为备库，存在复制连接为主库
+	metadata["cluster_mode"] = "standalone" // Default
+	var inRecovery bool
+	if err := c.db.QueryRowContext(ctx, "SELECT pg_catalog.pg_is_in_recovery()").Scan(&inRecovery); err == nil && inRecovery {
+		metadata["cluster_mode"] = "standby"
+	} else {
+		var replicas int64
+		if err := c.db.QueryRowContext(ctx, "SELECT count(*) FROM pg_catalog.pg_stat_replication").Scan(&replicas); err == nil && replicas > 0 {
+			metadata["cluster_mode"] = "primary"
+		}
+	}
+
+	return metadata, nil
+}
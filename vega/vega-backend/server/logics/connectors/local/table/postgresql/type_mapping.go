@@ -0,0 +1,96 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package postgresql provides PostgreSQL database connector implementation.
+package postgresql
+
+import "strings"
+
+// TypeMapping maps PostgreSQL native types to VEGA types.
+// 同时包含 pg_type 中的内部类型名（如 int4、bpchar）和 SQL 标准类型名（如 integer、character varying）。
+var TypeMapping = map[string]string{
+	// Integer types
+	"int2":        "integer",
+	"int4":        "integer",
+	"int8":        "integer",
+	"smallint":    "integer",
+	"integer":     "integer",
+	"int":         "integer",
+	"bigint":      "integer",
+	"smallserial": "integer",
+	"serial":      "integer",
+	"bigserial":   "integer",
+	"oid":         "unsigned_integer",
+
+	// Float types
+	"float4":           "float",
+	"float8":           "float",
+	"real":             "float",
+	"double precision": "float",
+
+	// Decimal types
+	"numeric": "decimal",
+	"decimal": "decimal",
+	"money":   "decimal",
+
+	// String types
+	"bpchar":            "string",
+	"char":              "string",
+	"character":         "string",
+	"varchar":           "string",
+	"character varying": "string",
+	"name":              "string",
+	"uuid":              "string",
+	"inet":              "string",
+	"cidr":              "string",
+	"macaddr":           "string",
+	"macaddr8":          "string",
+	"citext":            "string",
+
+	// Text types
+	"text":     "text",
+	"xml":      "text",
+	"tsvector": "text",
+	"tsquery":  "text",
+
+	// Date/Time types
+	"date":                        "date",
+	"timestamp":                   "datetime",
+	"timestamptz":                 "datetime",
+	"timestamp without time zone": "datetime",
+	"timestamp with time zone":    "datetime",
+	"time":                        "time",
+	"timetz":                      "time",
+	"time without time zone":      "time",
+	"time with time zone":         "time",
+	"interval":                    "string",
+
+	// Boolean
+	"bool":    "boolean",
+	"boolean": "boolean",
+
+	// Binary types
+	"bytea":       "binary",
+	"bit":         "binary",
+	"varbit":      "binary",
+	"bit varying": "binary",
+
+	// JSON
+	"json":  "json",
+	"jsonb": "json",
+}
+
+// MapType returns VEGA type for PostgreSQL native type.
+// 数组类型（pg_type 中以 _ 开头，或 format_type 输出以 [] 结尾）按文本处理，取值为 PostgreSQL 数组字面量，如 {1,2,3}。
+func MapType(nativeType string) string {
+	nativeType = strings.ToLower(strings.TrimSpace(nativeType))
+	if strings.HasPrefix(nativeType, "_") || strings.HasSuffix(nativeType, "[]") {
+		return "text"
+	}
+	if vegaType, ok := TypeMapping[nativeType]; ok {
+		return vegaType
+	}
+	return "unsupported" // default
+}
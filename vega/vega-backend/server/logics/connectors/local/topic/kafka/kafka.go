@@ -0,0 +1,648 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package kafka provides Kafka topic connector implementation.
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"github.com/mitchellh/mapstructure"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors"
+)
+
+const (
+	defaultSampleSize = 100
+	maxSampleSize     = 1000
+	dialTimeout       = 10 * time.Second
+	fetchMaxBytes     = 1 << 20
+	fetchMaxWait      = 500 * time.Millisecond
+)
+
+const (
+	saslPlain       = "PLAIN"
+	saslScramSHA256 = "SCRAM-SHA-256"
+	saslScramSHA512 = "SCRAM-SHA-512"
+)
+
+type kafkaConfig struct {
+	Brokers       []string `mapstructure:"brokers"`
+	Username      string   `mapstructure:"username"`
+	Password      string   `mapstructure:"password"`
+	SASLMechanism string   `mapstructure:"sasl_mechanism"`
+	TLS           bool     `mapstructure:"tls"`
+	TopicPattern  string   `mapstructure:"topic_pattern"`
+	SampleSize    int      `mapstructure:"sample_size"`
+}
+
+// KafkaConnector implements TopicConnector for Kafka.
+type KafkaConnector struct {
+	enabled bool
+	config  *kafkaConfig
+	client  *kafka.Client
+
+	// topic -> partition ids，ListTopics 时填充，用于批量获取消费组位点
+	topicPartitions map[string][]int
+	// group -> topic -> committed offsets，首次计算积压时加载
+	groupOffsets map[string]map[string][]kafka.OffsetFetchPartition
+}
+
+// NewKafkaConnector 创建 Kafka connector 构建器
+func NewKafkaConnector() connectors.TopicConnector {
+	return &KafkaConnector{}
+}
+
+// GetType returns the data source type.
+func (c *KafkaConnector) GetType() string {
+	return "kafka"
+}
+
+// GetName returns the data source name.
+func (c *KafkaConnector) GetName() string {
+	return "kafka"
+}
+
+// GetMode returns the connector mode.
+func (c *KafkaConnector) GetMode() string {
+	return interfaces.ConnectorModeLocal
+}
+
+// GetCategory returns the connector category.
+func (c *KafkaConnector) GetCategory() string {
+	return interfaces.ConnectorCategoryTopic
+}
+
+// GetEnabled returns the enabled status.
+func (c *KafkaConnector) GetEnabled() bool {
+	return c.enabled
+}
+
+// SetEnabled sets the enabled status.
+func (c *KafkaConnector) SetEnabled(enabled bool) {
+	c.enabled = enabled
+}
+
+// GetSensitiveFields returns the sensitive fields for Kafka connector.
+func (c *KafkaConnector) GetSensitiveFields() []string {
+	return []string{"password"}
+}
+
+// GetFieldConfig returns the field configuration for Kafka connector.
+func (c *KafkaConnector) GetFieldConfig() map[string]interfaces.ConnectorFieldConfig {
+	return map[string]interfaces.ConnectorFieldConfig{
+		"brokers":        {Name: "Broker 列表", Type: "array", Description: "Kafka broker 地址列表（host:port）", Required: true, Encrypted: false},
+		"username":       {Name: "用户名", Type: "string", Description: "SASL 认证用户名（可选）", Required: false, Encrypted: false},
+		"password":       {Name: "密码", Type: "string", Description: "SASL 认证密码（可选）", Required: false, Encrypted: true},
+		"sasl_mechanism": {Name: "SASL 机制", Type: "string", Description: "SASL 认证机制：PLAIN、SCRAM-SHA-256、SCRAM-SHA-512（默认 PLAIN）", Required: false, Encrypted: false},
+		"tls":            {Name: "启用 TLS", Type: "boolean", Description: "是否使用 TLS 连接", Required: false, Encrypted: false},
+		"topic_pattern":  {Name: "Topic 模式", Type: "string", Description: "Topic 匹配模式（可选，如 log-*）", Required: false, Encrypted: false},
+		"sample_size":    {Name: "采样条数", Type: "integer", Description: "每个 topic 采样用于推断 schema 的最近消息条数（默认 100）", Required: false, Encrypted: false},
+	}
+}
+
+// New creates a new Kafka connector.
+func (c *KafkaConnector) New(cfg interfaces.ConnectorConfig) (connectors.Connector, error) {
+	var kCfg kafkaConfig
+	if err := mapstructure.WeakDecode(cfg, &kCfg); err != nil {
+		return nil, fmt.Errorf("failed to decode kafka config: %w", err)
+	}
+
+	if len(kCfg.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka config missing required field: brokers")
+	}
+	for _, broker := range kCfg.Brokers {
+		if _, _, err := splitHostPort(broker); err != nil {
+			return nil, fmt.Errorf("invalid kafka broker %q: %w", broker, err)
+		}
+	}
+	if kCfg.TopicPattern != "" {
+		if _, err := path.Match(kCfg.TopicPattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid topic_pattern %q: %w", kCfg.TopicPattern, err)
+		}
+	}
+	if kCfg.SampleSize <= 0 {
+		kCfg.SampleSize = defaultSampleSize
+	}
+	if kCfg.SampleSize > maxSampleSize {
+		kCfg.SampleSize = maxSampleSize
+	}
+
+	return &KafkaConnector{
+		enabled: c.enabled,
+		config:  &kCfg,
+	}, nil
+}
+
+// Connect establishes connection to Kafka.
+func (c *KafkaConnector) Connect(ctx context.Context) error {
+	if c.client != nil {
+		return nil
+	}
+
+	mechanism, err := c.saslMechanism()
+	if err != nil {
+		return err
+	}
+
+	transport := &kafka.Transport{
+		DialTimeout: dialTimeout,
+		ClientID:    "vega-backend",
+		SASL:        mechanism,
+	}
+	if c.config.TLS {
+		transport.TLS = &tls.Config{}
+	}
+
+	client := &kafka.Client{
+		Addr:      kafka.TCP(c.config.Brokers...),
+		Timeout:   dialTimeout,
+		Transport: transport,
+	}
+
+	// kafka.Client 为惰性连接，这里拉取一次集群元数据以验证连通性和认证
+	if _, err := client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{}}); err != nil {
+		transport.CloseIdleConnections()
+		return fmt.Errorf("failed to connect to kafka: %w", err)
+	}
+
+	c.client = client
+	return nil
+}
+
+// saslMechanism 根据配置创建 SASL 认证机制，未配置用户名时不认证
+func (c *KafkaConnector) saslMechanism() (sasl.Mechanism, error) {
+	if c.config.Username == "" {
+		return nil, nil
+	}
+
+	switch strings.ToUpper(c.config.SASLMechanism) {
+	case "", saslPlain:
+		return plain.Mechanism{Username: c.config.Username, Password: c.config.Password}, nil
+	case saslScramSHA256:
+		return scram.Mechanism(scram.SHA256, c.config.Username, c.config.Password)
+	case saslScramSHA512:
+		return scram.Mechanism(scram.SHA512, c.config.Username, c.config.Password)
+	default:
+		return nil, fmt.Errorf("unsupported sasl_mechanism: %s", c.config.SASLMechanism)
+	}
+}
+
+// Close closes the connection.
+func (c *KafkaConnector) Close(ctx context.Context) error {
+	if c.client != nil {
+		if transport, ok := c.client.Transport.(*kafka.Transport); ok {
+			transport.CloseIdleConnections()
+		}
+		c.client = nil
+	}
+	c.topicPartitions = nil
+	c.groupOffsets = nil
+	return nil
+}
+
+// Ping checks the connection.
+func (c *KafkaConnector) Ping(ctx context.Context) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	_, err := c.client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{}})
+	return err
+}
+
+// TestConnection tests the connection to Kafka.
+func (c *KafkaConnector) TestConnection(ctx context.Context) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	return c.Ping(ctx)
+}
+
+// GetMetadata returns the metadata for the catalog.
+func (c *KafkaConnector) GetMetadata(ctx context.Context) (map[string]any, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("connector not connected")
+	}
+
+	resp, err := c.client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster metadata: %w", err)
+	}
+
+	brokers := make([]string, 0, len(resp.Brokers))
+	for _, b := range resp.Brokers {
+		brokers = append(brokers, fmt.Sprintf("%s:%d", b.Host, b.Port))
+	}
+	sort.Strings(brokers)
+
+	return map[string]any{
+		"cluster_id":   resp.ClusterID,
+		"controller":   resp.Controller.ID,
+		"broker_count": len(resp.Brokers),
+		"brokers":      brokers,
+	}, nil
+}
+
+// ListTopics lists all non-internal topics matching topic_pattern.
+func (c *KafkaConnector) ListTopics(ctx context.Context) ([]*interfaces.TopicMeta, error) {
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Metadata(ctx, &kafka.MetadataRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topics: %w", err)
+	}
+
+	c.topicPartitions = make(map[string][]int)
+	topics := make([]*interfaces.TopicMeta, 0, len(resp.Topics))
+	for _, t := range resp.Topics {
+		if t.Internal || t.Error != nil || !c.matchTopic(t.Name) {
+			continue
+		}
+		c.topicPartitions[t.Name] = partitionIDs(t.Partitions)
+		topics = append(topics, &interfaces.TopicMeta{
+			Name:       t.Name,
+			Partitions: len(t.Partitions),
+			Replicas:   replicationFactor(t.Partitions),
+		})
+	}
+
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Name < topics[j].Name })
+	return topics, nil
+}
+
+// GetTopicMeta fills the topic with offsets, configs, consumer lag and sampled schema.
+func (c *KafkaConnector) GetTopicMeta(ctx context.Context, topic *interfaces.TopicMeta) error {
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+
+	// 1. 分区与副本
+	resp, err := c.client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{topic.Name}})
+	if err != nil {
+		return fmt.Errorf("failed to get metadata for topic %s: %w", topic.Name, err)
+	}
+	if len(resp.Topics) == 0 {
+		return fmt.Errorf("topic %s not found", topic.Name)
+	}
+	t := resp.Topics[0]
+	if t.Error != nil {
+		return fmt.Errorf("failed to get metadata for topic %s: %w", topic.Name, t.Error)
+	}
+	partitions := partitionIDs(t.Partitions)
+	topic.Partitions = len(partitions)
+	topic.Replicas = replicationFactor(t.Partitions)
+
+	// 2. 分区位点
+	offsets, err := c.partitionOffsets(ctx, topic.Name, partitions)
+	if err != nil {
+		return err
+	}
+
+	var messageCount int64
+	partitionProps := make([]map[string]any, 0, len(offsets))
+	for _, po := range offsets {
+		messageCount += po.LastOffset - po.FirstOffset
+		partitionProps = append(partitionProps, map[string]any{
+			"partition":    po.Partition,
+			"first_offset": po.FirstOffset,
+			"last_offset":  po.LastOffset,
+		})
+	}
+
+	properties := map[string]any{
+		"message_count":     messageCount,
+		"partition_offsets": partitionProps,
+	}
+	for k, v := range c.topicConfigs(ctx, topic.Name) {
+		properties[k] = v
+	}
+
+	// 3. 消费积压
+	lags, err := c.consumerLags(ctx, topic.Name, partitions, offsets)
+	if err != nil {
+		logger.Warnf("Failed to get consumer lag for topic %s: %v", topic.Name, err)
+	}
+
+	// 4. 采样推断 schema
+	inferrer := newSchemaInferrer()
+	sampled := c.sampleMessages(ctx, topic.Name, offsets, inferrer)
+	properties["sample_count"] = sampled
+
+	topic.Properties = properties
+	topic.Fields = inferrer.Fields()
+	topic.ConsumerGroups = lags
+	return nil
+}
+
+// partitionOffsets 获取各分区的起止位点
+func (c *KafkaConnector) partitionOffsets(ctx context.Context, topic string, partitions []int) ([]kafka.PartitionOffsets, error) {
+	requests := make([]kafka.OffsetRequest, 0, len(partitions)*2)
+	for _, p := range partitions {
+		requests = append(requests, kafka.FirstOffsetOf(p), kafka.LastOffsetOf(p))
+	}
+
+	resp, err := c.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{topic: requests},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list offsets for topic %s: %w", topic, err)
+	}
+
+	offsets := make([]kafka.PartitionOffsets, 0, len(partitions))
+	for _, po := range resp.Topics[topic] {
+		if po.Error != nil {
+			return nil, fmt.Errorf("failed to list offsets for topic %s partition %d: %w", topic, po.Partition, po.Error)
+		}
+		offsets = append(offsets, po)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i].Partition < offsets[j].Partition })
+	return offsets, nil
+}
+
+// topicConfigs 获取 topic 的保留策略等配置，失败时仅记录日志
+func (c *KafkaConnector) topicConfigs(ctx context.Context, topic string) map[string]any {
+	resp, err := c.client.DescribeConfigs(ctx, &kafka.DescribeConfigsRequest{
+		Resources: []kafka.DescribeConfigRequestResource{{
+			ResourceType: kafka.ResourceTypeTopic,
+			ResourceName: topic,
+			ConfigNames:  []string{"retention.ms", "retention.bytes", "cleanup.policy"},
+		}},
+	})
+	if err != nil {
+		logger.Warnf("Failed to describe configs for topic %s: %v", topic, err)
+		return nil
+	}
+
+	configs := make(map[string]any)
+	for _, res := range resp.Resources {
+		if res.Error != nil {
+			logger.Warnf("Failed to describe configs for topic %s: %v", topic, res.Error)
+			continue
+		}
+		for _, entry := range res.ConfigEntries {
+			key := strings.ReplaceAll(entry.ConfigName, ".", "_")
+			if n, err := strconv.ParseInt(entry.ConfigValue, 10, 64); err == nil {
+				configs[key] = n
+			} else {
+				configs[key] = entry.ConfigValue
+			}
+		}
+	}
+	return configs
+}
+
+// consumerLags 计算各消费组在该 topic 上的积压
+func (c *KafkaConnector) consumerLags(ctx context.Context, topic string, partitions []int,
+	offsets []kafka.PartitionOffsets) ([]interfaces.ConsumerGroupLag, error) {
+
+	if err := c.loadGroupOffsets(ctx, topic, partitions); err != nil {
+		return nil, err
+	}
+
+	endOffsets := make(map[int]int64, len(offsets))
+	for _, po := range offsets {
+		endOffsets[po.Partition] = po.LastOffset
+	}
+
+	groupIDs := make([]string, 0, len(c.groupOffsets))
+	for groupID := range c.groupOffsets {
+		groupIDs = append(groupIDs, groupID)
+	}
+	sort.Strings(groupIDs)
+
+	lags := []interfaces.ConsumerGroupLag{}
+	for _, groupID := range groupIDs {
+		committed := c.groupOffsets[groupID][topic]
+		group := interfaces.ConsumerGroupLag{GroupID: groupID}
+		for _, p := range committed {
+			// 未提交过位点的分区返回 -1
+			if p.Error != nil || p.CommittedOffset < 0 {
+				continue
+			}
+			end := endOffsets[p.Partition]
+			lag := max(end-p.CommittedOffset, 0)
+			group.Lag += lag
+			group.Partitions = append(group.Partitions, interfaces.PartitionLag{
+				Partition:       p.Partition,
+				CommittedOffset: p.CommittedOffset,
+				EndOffset:       end,
+				Lag:             lag,
+			})
+		}
+		if len(group.Partitions) > 0 {
+			sort.Slice(group.Partitions, func(i, j int) bool {
+				return group.Partitions[i].Partition < group.Partitions[j].Partition
+			})
+			lags = append(lags, group)
+		}
+	}
+	return lags, nil
+}
+
+// loadGroupOffsets 加载消费组位点。
+// 首次调用时按 ListTopics 得到的全部 topic 一次性拉取，避免 topic 数 × 消费组数 次请求；
+// 对未包含在内的 topic 再单独补拉。
+func (c *KafkaConnector) loadGroupOffsets(ctx context.Context, topic string, partitions []int) error {
+	if c.groupOffsets == nil {
+		resp, err := c.client.ListGroups(ctx, &kafka.ListGroupsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list consumer groups: %w", err)
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("failed to list consumer groups: %w", resp.Error)
+		}
+
+		topics := make(map[string][]int, len(c.topicPartitions)+1)
+		for name, ps := range c.topicPartitions {
+			topics[name] = ps
+		}
+		topics[topic] = partitions
+
+		c.groupOffsets = make(map[string]map[string][]kafka.OffsetFetchPartition)
+		for _, g := range resp.Groups {
+			if g.ProtocolType != "" && g.ProtocolType != "consumer" {
+				continue
+			}
+			c.groupOffsets[g.GroupID] = c.fetchGroupOffsets(ctx, g.GroupID, topics)
+		}
+		return nil
+	}
+
+	for groupID, byTopic := range c.groupOffsets {
+		if _, ok := byTopic[topic]; ok {
+			continue
+		}
+		for name, ps := range c.fetchGroupOffsets(ctx, groupID, map[string][]int{topic: partitions}) {
+			byTopic[name] = ps
+		}
+		if _, ok := byTopic[topic]; !ok {
+			// 标记为已加载，避免重复请求
+			byTopic[topic] = nil
+		}
+	}
+	return nil
+}
+
+func (c *KafkaConnector) fetchGroupOffsets(ctx context.Context, groupID string,
+	topics map[string][]int) map[string][]kafka.OffsetFetchPartition {
+
+	resp, err := c.client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{
+		GroupID: groupID,
+		Topics:  topics,
+	})
+	if err != nil {
+		logger.Warnf("Failed to fetch offsets for consumer group %s: %v", groupID, err)
+		return map[string][]kafka.OffsetFetchPartition{}
+	}
+	if resp.Error != nil {
+		logger.Warnf("Failed to fetch offsets for consumer group %s: %v", groupID, resp.Error)
+		return map[string][]kafka.OffsetFetchPartition{}
+	}
+	return resp.Topics
+}
+
+// sampleMessages 从各分区尾部采样最近的消息送入 inferrer，返回采样条数
+func (c *KafkaConnector) sampleMessages(ctx context.Context, topic string,
+	offsets []kafka.PartitionOffsets, inferrer *schemaInferrer) int {
+
+	nonEmpty := 0
+	for _, po := range offsets {
+		if po.LastOffset > po.FirstOffset {
+			nonEmpty++
+		}
+	}
+	if nonEmpty == 0 {
+		return 0
+	}
+	perPartition := int64((c.config.SampleSize + nonEmpty - 1) / nonEmpty)
+
+	sampled := 0
+	for _, po := range offsets {
+		if po.LastOffset <= po.FirstOffset {
+			continue
+		}
+		start := max(po.LastOffset-perPartition, po.FirstOffset)
+		n, err := c.samplePartition(ctx, topic, po.Partition, start, po.LastOffset, inferrer)
+		if err != nil {
+			logger.Warnf("Failed to sample topic %s partition %d: %v", topic, po.Partition, err)
+		}
+		sampled += n
+	}
+	return sampled
+}
+
+// samplePartition 读取 [start, end) 区间内的消息
+func (c *KafkaConnector) samplePartition(ctx context.Context, topic string, partition int,
+	start, end int64, inferrer *schemaInferrer) (int, error) {
+
+	count := 0
+	offset := start
+	for offset < end {
+		resp, err := c.client.Fetch(ctx, &kafka.FetchRequest{
+			Topic:     topic,
+			Partition: partition,
+			Offset:    offset,
+			MinBytes:  1,
+			MaxBytes:  fetchMaxBytes,
+			MaxWait:   fetchMaxWait,
+		})
+		if err != nil {
+			return count, err
+		}
+		if resp.Error != nil {
+			return count, resp.Error
+		}
+		if resp.Records == nil {
+			break
+		}
+
+		next := offset
+		for {
+			record, err := resp.Records.ReadRecord()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return count, err
+			}
+			// 压缩批次可能包含起始位点之前的消息
+			if record.Offset < offset || record.Offset >= end {
+				continue
+			}
+			value, err := kafka.ReadAll(record.Value)
+			if err != nil {
+				return count, err
+			}
+			inferrer.Add(value)
+			count++
+			next = record.Offset + 1
+		}
+
+		// 没有前进（事务标记、已被清理的位点等）时停止，避免死循环
+		if next == offset {
+			break
+		}
+		offset = next
+	}
+	return count, nil
+}
+
+// matchTopic 判断 topic 是否匹配 topic_pattern
+func (c *KafkaConnector) matchTopic(name string) bool {
+	if c.config.TopicPattern == "" {
+		return true
+	}
+	matched, _ := path.Match(c.config.TopicPattern, name)
+	return matched
+}
+
+func partitionIDs(partitions []kafka.Partition) []int {
+	ids := make([]int, 0, len(partitions))
+	for _, p := range partitions {
+		ids = append(ids, p.ID)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// replicationFactor 取各分区副本数的最大值
+func replicationFactor(partitions []kafka.Partition) int {
+	replicas := 0
+	for _, p := range partitions {
+		replicas = max(replicas, len(p.Replicas))
+	}
+	return replicas
+}
+
+func splitHostPort(broker string) (string, int, error) {
+	i := strings.LastIndex(broker, ":")
+	if i <= 0 || i == len(broker)-1 {
+		return "", 0, fmt.Errorf("expected host:port")
+	}
+	port, err := strconv.Atoi(broker[i+1:])
+	if err != nil || port <= 0 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port")
+	}
+	return broker[:i], port, nil
+}
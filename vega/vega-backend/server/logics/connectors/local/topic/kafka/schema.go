@@ -0,0 +1,225 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"vega-backend/interfaces"
+)
+
+const (
+	// 嵌套对象展开的最大深度，超出部分按 json 处理
+	maxFlattenDepth = 5
+	// 非 JSON 对象消息统一归入该字段
+	valueFieldName = "value"
+)
+
+// JSON 原始类型
+const (
+	jsonString   = "string"
+	jsonInteger  = "integer"
+	jsonNumber   = "number"
+	jsonBoolean  = "boolean"
+	jsonObject   = "object"
+	jsonArray    = "array"
+	jsonNull     = "null"
+	jsonDatetime = "datetime" // 可解析为时间的字符串
+	rawBytes     = "bytes"    // 非 JSON 消息
+)
+
+type fieldStats struct {
+	order   int
+	present int
+	kinds   map[string]int
+}
+
+// schemaInferrer 根据采样消息推断 schema
+type schemaInferrer struct {
+	samples int
+	fields  map[string]*fieldStats
+}
+
+func newSchemaInferrer() *schemaInferrer {
+	return &schemaInferrer{fields: make(map[string]*fieldStats)}
+}
+
+// Add 加入一条消息
+func (s *schemaInferrer) Add(value []byte) {
+	if len(bytes.TrimSpace(value)) == 0 {
+		return
+	}
+	s.samples++
+
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		if utf8.Valid(value) {
+			s.observe(valueFieldName, jsonString)
+		} else {
+			s.observe(valueFieldName, rawBytes)
+		}
+		return
+	}
+
+	if obj, ok := v.(map[string]any); ok {
+		s.walk("", obj, 1)
+		return
+	}
+	s.observe(valueFieldName, jsonKind(v))
+}
+
+func (s *schemaInferrer) walk(prefix string, obj map[string]any, depth int) {
+	// 按 key 排序保证字段顺序稳定
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		name := k
+		if prefix != "" {
+			name = prefix + "." + k
+		}
+		if child, ok := obj[k].(map[string]any); ok && depth < maxFlattenDepth && len(child) > 0 {
+			s.walk(name, child, depth+1)
+			continue
+		}
+		s.observe(name, jsonKind(obj[k]))
+	}
+}
+
+func (s *schemaInferrer) observe(name string, kind string) {
+	fs, ok := s.fields[name]
+	if !ok {
+		fs = &fieldStats{order: len(s.fields), kinds: make(map[string]int)}
+		s.fields[name] = fs
+	}
+	fs.present++
+	fs.kinds[kind]++
+}
+
+// Fields 返回推断出的字段列表，按首次出现顺序排列
+func (s *schemaInferrer) Fields() []interfaces.TopicField {
+	names := make([]string, 0, len(s.fields))
+	for name := range s.fields {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return s.fields[names[i]].order < s.fields[names[j]].order
+	})
+
+	fields := make([]interfaces.TopicField, 0, len(names))
+	for _, name := range names {
+		fs := s.fields[name]
+		kinds := make([]string, 0, len(fs.kinds))
+		for kind := range fs.kinds {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+
+		fields = append(fields, interfaces.TopicField{
+			Name:     name,
+			Type:     vegaType(fs.kinds),
+			OrigType: strings.Join(kinds, "|"),
+			Nullable: fs.present < s.samples || fs.kinds[jsonNull] > 0,
+		})
+	}
+	return fields
+}
+
+// jsonKind 返回 JSON 值的类型
+func jsonKind(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return jsonNull
+	case bool:
+		return jsonBoolean
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			return jsonInteger
+		}
+		return jsonNumber
+	case string:
+		if isDatetime(val) {
+			return jsonDatetime
+		}
+		return jsonString
+	case []any:
+		return jsonArray
+	case map[string]any:
+		return jsonObject
+	default:
+		return jsonString
+	}
+}
+
+var datetimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05.000",
+}
+
+func isDatetime(s string) bool {
+	if len(s) < len("2006-01-02 15:04:05") || len(s) > len(time.RFC3339Nano)+4 {
+		return false
+	}
+	for _, layout := range datetimeLayouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// vegaType 将采样到的 JSON 类型合并为 VEGA 类型
+func vegaType(kinds map[string]int) string {
+	nonNull := make(map[string]bool)
+	for kind := range kinds {
+		if kind != jsonNull {
+			nonNull[kind] = true
+		}
+	}
+
+	switch {
+	case len(nonNull) == 0:
+		return "string"
+	case len(nonNull) == 1:
+		for kind := range nonNull {
+			switch kind {
+			case jsonInteger:
+				return "integer"
+			case jsonNumber:
+				return "float"
+			case jsonBoolean:
+				return "boolean"
+			case jsonDatetime:
+				return "datetime"
+			case jsonObject, jsonArray:
+				return "json"
+			case rawBytes:
+				return "binary"
+			default:
+				return "string"
+			}
+		}
+	case len(nonNull) == 2 && nonNull[jsonInteger] && nonNull[jsonNumber]:
+		return "float"
+	case len(nonNull) == 2 && nonNull[jsonString] && nonNull[jsonDatetime]:
+		return "string"
+	case nonNull[jsonObject] || nonNull[jsonArray]:
+		return "json"
+	}
+	return "string"
+}
@@ -0,0 +1,355 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package conformance provides a conformance test suite for Remote Connector Protocol servers.
+// 第三方 connector 在自己的测试中调用 Run 即可验证其实现是否符合协议：
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, conformance.Options{
+//			Endpoint: "http://127.0.0.1:8080",
+//			Type:     "my-connector",
+//			Config:   interfaces.ConnectorConfig{"host": "..."},
+//		})
+//	}
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/bytedance/sonic"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors/remote"
+)
+
+// Options 一致性测试参数
+type Options struct {
+	// Endpoint 被测 connector 服务地址，如 http://127.0.0.1:8080
+	Endpoint string
+	// Type 注册时使用的 connector 类型
+	Type string
+	// Config 可成功建立连接的 connector_config
+	Config interfaces.ConnectorConfig
+	// InvalidConfig 应当建立连接失败的 connector_config，为 nil 时跳过该用例
+	InvalidConfig interfaces.ConnectorConfig
+	// Query 用于验证 query 能力的查询语句，为空时跳过该用例
+	Query string
+}
+
+var validCategories = []string{
+	interfaces.ConnectorCategoryTable,
+	interfaces.ConnectorCategoryIndex,
+	interfaces.ConnectorCategoryTopic,
+	interfaces.ConnectorCategoryFile,
+	interfaces.ConnectorCategoryFileset,
+	interfaces.ConnectorCategoryMetric,
+	interfaces.ConnectorCategoryAPI,
+}
+
+// capabilityEndpoints 各能力对应的接口，用于验证未声明能力的拒绝行为
+var capabilityEndpoints = map[string]struct {
+	method string
+	path   string
+	body   any
+}{
+	remote.CapabilityListDatabases: {http.MethodGet, remote.PathDatabases, nil},
+	remote.CapabilityListTables:    {http.MethodGet, remote.PathTables, nil},
+	remote.CapabilityTableMeta:     {http.MethodPost, remote.PathTableMeta, remote.TableMetaRequest{Table: &interfaces.TableMeta{Name: "t"}}},
+	remote.CapabilityListIndexes:   {http.MethodGet, remote.PathIndexes, nil},
+	remote.CapabilityIndexMeta:     {http.MethodPost, remote.PathIndexMeta, remote.IndexMetaRequest{Index: &interfaces.IndexMeta{Name: "i"}}},
+	remote.CapabilityIndexSearch:   {http.MethodPost, remote.PathIndexSearch, remote.IndexSearchRequest{Index: "i", Limit: 1}},
+	remote.CapabilityQuery:         {http.MethodPost, remote.PathQuery, remote.QueryRequest{Query: "SELECT 1"}},
+}
+
+// Run 对 opts.Endpoint 上的 connector 服务执行协议一致性测试
+func Run(t *testing.T, opts Options) {
+	t.Helper()
+
+	ctx := context.Background()
+	client := remote.NewClient()
+
+	var info remote.InfoResponse
+	if err := client.Do(ctx, http.MethodGet, url(opts.Endpoint, remote.PathInfo), nil, &info); err != nil {
+		t.Fatalf("GET %s failed: %v", remote.PathInfo, err)
+	}
+
+	t.Run("Info", func(t *testing.T) {
+		if info.Name == "" {
+			t.Error("info.name is empty")
+		}
+		if !info.SupportsProtocol(remote.ProtocolVersion) {
+			t.Errorf("info.protocol_versions %v does not contain %s", info.ProtocolVersions, remote.ProtocolVersion)
+		}
+		if !slices.Contains(validCategories, info.Category) {
+			t.Errorf("info.category %q is not a valid category", info.Category)
+		}
+		for _, capability := range info.Capabilities {
+			if !slices.Contains(remote.AllCapabilities, capability) {
+				t.Errorf("unknown capability %q", capability)
+			}
+		}
+	})
+
+	t.Run("RejectUnsupportedProtocol", func(t *testing.T) {
+		body, _ := sonic.Marshal(remote.CreateConnectionRequest{Type: opts.Type, Config: opts.Config})
+		for _, version := range []string{"", "v0"} {
+			status, errResp := rawPost(t, url(opts.Endpoint, remote.PathConnections), version, body)
+			if status != http.StatusBadRequest || errResp.Code != remote.ErrCodeUnsupportedProtocol {
+				t.Errorf("protocol %q: expected 400 %s, got %d %s", version, remote.ErrCodeUnsupportedProtocol, status, errResp.Code)
+			}
+		}
+	})
+
+	t.Run("UnknownConnection", func(t *testing.T) {
+		_, err := client.Request(ctx, http.MethodPost, url(opts.Endpoint, remote.ConnectionPath("conformance-unknown", remote.PathPing)), nil)
+		expectProtocolError(t, err, http.StatusNotFound, remote.ErrCodeConnectionNotFound)
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		if opts.InvalidConfig == nil {
+			t.Skip("InvalidConfig not provided")
+		}
+		req := remote.CreateConnectionRequest{Type: opts.Type, Config: opts.InvalidConfig}
+		_, err := client.Request(ctx, http.MethodPost, url(opts.Endpoint, remote.PathConnections), req)
+		var perr *remote.ProtocolError
+		if !errors.As(err, &perr) {
+			t.Fatalf("expected protocol error, got %v", err)
+		}
+		if perr.StatusCode != http.StatusBadRequest ||
+			(perr.Code != remote.ErrCodeInvalidRequest && perr.Code != remote.ErrCodeConnectFailed) {
+			t.Errorf("expected 400 %s or %s, got %d %s", remote.ErrCodeInvalidRequest, remote.ErrCodeConnectFailed, perr.StatusCode, perr.Code)
+		}
+	})
+
+	t.Run("ConnectionLifecycle", func(t *testing.T) {
+		var resp remote.CreateConnectionResponse
+		req := remote.CreateConnectionRequest{Type: opts.Type, Config: opts.Config}
+		if err := client.Do(ctx, http.MethodPost, url(opts.Endpoint, remote.PathConnections), req, &resp); err != nil {
+			t.Fatalf("create connection failed: %v", err)
+		}
+		if resp.ConnectionID == "" {
+			t.Fatal("connection_id is empty")
+		}
+
+		pingURL := url(opts.Endpoint, remote.ConnectionPath(resp.ConnectionID, remote.PathPing))
+		if _, err := client.Post(ctx, pingURL, nil); err != nil {
+			t.Fatalf("ping failed: %v", err)
+		}
+		if _, err := client.Delete(ctx, url(opts.Endpoint, remote.ConnectionPath(resp.ConnectionID, ""))); err != nil {
+			t.Fatalf("close connection failed: %v", err)
+		}
+		_, err := client.Post(ctx, pingURL, nil)
+		expectProtocolError(t, err, http.StatusNotFound, remote.ErrCodeConnectionNotFound)
+	})
+
+	t.Run("UndeclaredCapabilities", func(t *testing.T) {
+		var resp remote.CreateConnectionResponse
+		req := remote.CreateConnectionRequest{Type: opts.Type, Config: opts.Config}
+		if err := client.Do(ctx, http.MethodPost, url(opts.Endpoint, remote.PathConnections), req, &resp); err != nil {
+			t.Fatalf("create connection failed: %v", err)
+		}
+		defer client.Delete(ctx, url(opts.Endpoint, remote.ConnectionPath(resp.ConnectionID, "")))
+
+		for capability, ep := range capabilityEndpoints {
+			if info.HasCapability(capability) {
+				continue
+			}
+			_, err := client.Request(ctx, ep.method, url(opts.Endpoint, remote.ConnectionPath(resp.ConnectionID, ep.path)), ep.body)
+			expectProtocolError(t, err, http.StatusNotImplemented, remote.ErrCodeUnsupportedCapability)
+		}
+	})
+
+	t.Run("RemoteConnector", func(t *testing.T) {
+		runRemoteConnector(t, ctx, &info, opts)
+	})
+}
+
+// runRemoteConnector 通过 RemoteConnector 调用已声明的全部能力，验证 VEGA Manager 侧可以正常使用该服务
+func runRemoteConnector(t *testing.T, ctx context.Context, info *remote.InfoResponse, opts Options) {
+	proto := remote.NewRemoteConnector(&interfaces.ConnectorType{
+		Type:     opts.Type,
+		Name:     info.Name,
+		Mode:     interfaces.ConnectorModeRemote,
+		Category: info.Category,
+		Endpoint: opts.Endpoint,
+		Enabled:  true,
+	})
+	conn, err := proto.New(opts.Config)
+	if err != nil {
+		t.Fatalf("new remote connector failed: %v", err)
+	}
+	rc := conn.(*remote.RemoteConnector)
+	defer rc.Close(ctx)
+
+	if err := rc.TestConnection(ctx); err != nil {
+		t.Fatalf("test connection failed: %v", err)
+	}
+	if err := rc.Ping(ctx); err != nil {
+		t.Errorf("ping failed: %v", err)
+	}
+	if _, err := rc.GetMetadata(ctx); err != nil {
+		t.Errorf("get metadata failed: %v", err)
+	}
+
+	if info.HasCapability(remote.CapabilityListDatabases) {
+		t.Run("ListDatabases", func(t *testing.T) {
+			databases, err := rc.ListDatabases(ctx)
+			if err != nil {
+				t.Fatalf("list databases failed: %v", err)
+			}
+			if databases == nil {
+				t.Error("databases should be an empty array rather than null")
+			}
+		})
+	}
+
+	if info.HasCapability(remote.CapabilityListTables) {
+		t.Run("Tables", func(t *testing.T) {
+			tables, err := rc.ListTables(ctx)
+			if err != nil {
+				t.Fatalf("list tables failed: %v", err)
+			}
+			if tables == nil {
+				t.Fatal("tables should be an empty array rather than null")
+			}
+			for _, table := range tables {
+				if table.Name == "" {
+					t.Error("table name is empty")
+				}
+			}
+			if len(tables) == 0 || !info.HasCapability(remote.CapabilityTableMeta) {
+				return
+			}
+
+			table := &interfaces.TableMeta{Name: tables[0].Name, Database: tables[0].Database}
+			if err := rc.GetTableMeta(ctx, table); err != nil {
+				t.Fatalf("get table meta failed: %v", err)
+			}
+			if table.Name != tables[0].Name {
+				t.Errorf("table meta name %q, expected %q", table.Name, tables[0].Name)
+			}
+			if len(table.Columns) == 0 {
+				t.Error("table meta has no columns")
+			}
+			for _, col := range table.Columns {
+				if col.Name == "" || col.Type == "" || col.OrigType == "" {
+					t.Errorf("column %+v should have name, type and orig_type", col)
+				}
+			}
+
+			missing := &interfaces.TableMeta{Name: "conformance_missing_table", Database: tables[0].Database}
+			expectProtocolError(t, rc.GetTableMeta(ctx, missing), http.StatusNotFound, remote.ErrCodeNotFound)
+		})
+	}
+
+	if info.HasCapability(remote.CapabilityQuery) {
+		t.Run("Query", func(t *testing.T) {
+			if opts.Query == "" {
+				t.Skip("Query not provided")
+			}
+			result, err := rc.ExecuteQuery(ctx, opts.Query)
+			if err != nil {
+				t.Fatalf("execute query failed: %v", err)
+			}
+			if len(result.Columns) == 0 {
+				t.Error("query result has no columns")
+			}
+			if result.Total < int64(len(result.Rows)) {
+				t.Errorf("query result total %d is less than rows %d", result.Total, len(result.Rows))
+			}
+		})
+	}
+
+	if info.HasCapability(remote.CapabilityListIndexes) {
+		t.Run("Indexes", func(t *testing.T) {
+			indexes, err := rc.ListIndexes(ctx)
+			if err != nil {
+				t.Fatalf("list indexes failed: %v", err)
+			}
+			if indexes == nil {
+				t.Fatal("indexes should be an empty array rather than null")
+			}
+			if len(indexes) == 0 || !info.HasCapability(remote.CapabilityIndexMeta) {
+				return
+			}
+
+			index := &interfaces.IndexMeta{Name: indexes[0].Name}
+			if err := rc.GetIndexMeta(ctx, index); err != nil {
+				t.Fatalf("get index meta failed: %v", err)
+			}
+			if index.Name != indexes[0].Name {
+				t.Errorf("index meta name %q, expected %q", index.Name, indexes[0].Name)
+			}
+		})
+	}
+
+	if info.HasCapability(remote.CapabilityIndexSearch) && info.HasCapability(remote.CapabilityListIndexes) {
+		t.Run("IndexSearch", func(t *testing.T) {
+			indexes, err := rc.ListIndexes(ctx)
+			if err != nil {
+				t.Fatalf("list indexes failed: %v", err)
+			}
+			if len(indexes) == 0 {
+				t.Skip("no index to search")
+			}
+
+			result, err := rc.SearchIndex(ctx, indexes[0].Name, 1)
+			if err != nil {
+				t.Fatalf("search index failed: %v", err)
+			}
+			if len(result.Rows) > 1 {
+				t.Errorf("search result has %d rows, expected at most 1", len(result.Rows))
+			}
+			if result.Total < int64(len(result.Rows)) {
+				t.Errorf("search result total %d is less than rows %d", result.Total, len(result.Rows))
+			}
+		})
+	}
+}
+
+func url(endpoint string, path string) string {
+	return endpoint + remote.BasePath + path
+}
+
+// rawPost 以指定协议版本头发送请求，version 为空时不带协议头
+func rawPost(t *testing.T, target string, version string, body []byte) (int, remote.ErrorResponse) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("create request failed: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if version != "" {
+		req.Header.Set(remote.ProtocolHeader, version)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var errResp remote.ErrorResponse
+	_ = sonic.ConfigDefault.NewDecoder(resp.Body).Decode(&errResp)
+	return resp.StatusCode, errResp
+}
+
+func expectProtocolError(t *testing.T, err error, status int, code string) {
+	t.Helper()
+
+	var perr *remote.ProtocolError
+	if !errors.As(err, &perr) {
+		t.Errorf("expected protocol error %d %s, got %v", status, code, err)
+		return
+	}
+	if perr.StatusCode != status || perr.Code != code {
+		t.Errorf("expected protocol error %d %s, got %d %s", status, code, perr.StatusCode, perr.Code)
+	}
+}
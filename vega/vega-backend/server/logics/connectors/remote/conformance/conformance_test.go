@@ -0,0 +1,35 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package conformance
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors/remote/server"
+)
+
+// TestReferenceServer 参考服务端须通过全部一致性用例
+func TestReferenceServer(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	srv := server.NewServer(server.NewMemoryConnector(nil), "1.0.0")
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+	defer srv.Close(context.Background())
+
+	Run(t, Options{
+		Endpoint:      ts.URL,
+		Type:          "memory",
+		Config:        interfaces.ConnectorConfig{"databases": []string{"shop"}},
+		InvalidConfig: interfaces.ConnectorConfig{"databases": []string{"nonexistent"}},
+		Query:         "SELECT * FROM shop.orders LIMIT 2",
+	})
+}
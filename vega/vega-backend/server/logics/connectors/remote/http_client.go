@@ -66,6 +66,7 @@ func (c *Client) Request(ctx context.Context, method, url string, body any) ([]b
 		req.Header.Set("Content-Type", "application/json")
 	}
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set(ProtocolHeader, ProtocolVersion)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -80,6 +81,11 @@ func (c *Client) Request(ctx context.Context, method, url string, body any) ([]b
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		logger.Errorf("Remote connector request failed: status=%d, body=%s", resp.StatusCode, string(respBody))
+		// 按协议约定解析错误响应，解析失败时保留原始响应体
+		var errResp ErrorResponse
+		if err := sonic.Unmarshal(respBody, &errResp); err == nil && errResp.Code != "" {
+			return nil, &ProtocolError{StatusCode: resp.StatusCode, Code: errResp.Code, Message: errResp.Message}
+		}
 		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
 	}
 
@@ -101,98 +107,17 @@ func (c *Client) Delete(ctx context.Context, url string) ([]byte, error) {
 	return c.Request(ctx, http.MethodDelete, url, nil)
 }
 
-// ============================================
-// Remote Connector API 请求/响应结构
-// ============================================
-
-// CreateConnectionRequest 创建连接请求
-type CreateConnectionRequest struct {
-	Type     string         `json:"type"`
-	Host     string         `json:"host"`
-	Port     int            `json:"port"`
-	Database string         `json:"database"`
-	Username string         `json:"username"`
-	Password string         `json:"password"`
-	Options  map[string]any `json:"options,omitempty"`
-}
-
-// CreateConnectionResponse 创建连接响应
-type CreateConnectionResponse struct {
-	ConnectionID string `json:"connection_id"`
-	Success      bool   `json:"success"`
-	Message      string `json:"message,omitempty"`
-}
-
-// TableMetaResponse 表元数据响应
-type TableMetaResponse struct {
-	Name        string       `json:"name"`
-	Schema      string       `json:"schema"`
-	SubType     string       `json:"sub_type"`
-	Columns     []ColumnMeta `json:"columns"`
-	PrimaryKey  []string     `json:"primary_key"`
-	Description string       `json:"description"`
-}
-
-// ColumnMeta 列元数据
-type ColumnMeta struct {
-	Name        string `json:"name"`
-	NativeType  string `json:"native_type"`
-	VegaType    string `json:"vega_type"`
-	Nullable    bool   `json:"nullable"`
-	Description string `json:"description"`
-}
-
-// QueryRequest 查询请求
-type QueryRequest struct {
-	Query string `json:"query"`
-	Args  []any  `json:"args,omitempty"`
-}
-
-// QueryResponse 查询响应
-type QueryResponse struct {
-	Columns []string         `json:"columns"`
-	Rows    []map[string]any `json:"rows"`
-	Total   int64            `json:"total"`
-}
-
-// IndexMetaResponse 索引元数据响应
-type IndexMetaResponse struct {
-	Name      string               `json:"name"`
-	DocCount  int64                `json:"doc_count"`
-	StoreSize string               `json:"store_size"`
-	Mapping   map[string]FieldMeta `json:"mapping"`
-	Settings  map[string]any       `json:"settings"`
-}
-
-// FieldMeta 字段元数据
-type FieldMeta struct {
-	Type       string `json:"type"`
-	Analyzer   string `json:"analyzer,omitempty"`
-	Searchable bool   `json:"searchable"`
-}
-
-// SearchRequest 搜索请求
-type SearchRequest struct {
-	Query map[string]any `json:"query"`
-	From  int            `json:"from"`
-	Size  int            `json:"size"`
-}
-
-// SearchResponse 搜索响应
-type SearchResponse struct {
-	Hits  []map[string]any `json:"hits"`
-	Total int64            `json:"total"`
-}
-
-// HealthResponse 健康检查响应
-type HealthResponse struct {
-	Status  string `json:"status"`
-	Message string `json:"message,omitempty"`
-}
-
-// ConnectorInfoResponse connector 信息响应
-type ConnectorInfoResponse struct {
-	Name         string   `json:"name"`
-	Version      string   `json:"version"`
-	Capabilities []string `json:"capabilities"`
+// Do 发送请求并将响应体解析到 out，out 为 nil 时忽略响应体
+func (c *Client) Do(ctx context.Context, method, url string, body any, out any) error {
+	respBody, err := c.Request(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := sonic.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+	return nil
 }
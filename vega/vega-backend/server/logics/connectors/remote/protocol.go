@@ -0,0 +1,219 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package remote provides HTTP-based remote connector implementations.
+package remote
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"vega-backend/interfaces"
+)
+
+// ============================================
+// Remote Connector Protocol v1
+// ============================================
+//
+// 进程外 connector 通过 HTTP/JSON 与 VEGA Manager 通信，所有接口挂在
+// {endpoint}/api/vega-connector/v1 之下：
+//
+//	GET    /info                                 能力协商（名称、版本、支持的协议版本、类别、能力列表）
+//	POST   /connections                          按 connector_config 建立会话，返回 connection_id
+//	DELETE /connections/{id}                     关闭会话
+//	POST   /connections/{id}/ping                存活检测
+//	POST   /connections/{id}/test                连接测试                [test_connection]
+//	GET    /connections/{id}/metadata            实例级元数据            [metadata]
+//	GET    /connections/{id}/databases           列出数据库              [list_databases]
+//	GET    /connections/{id}/tables              列出表                  [list_tables]
+//	POST   /connections/{id}/tables/meta         获取表元数据            [table_meta]
+//	GET    /connections/{id}/indexes             列出索引                [list_indexes]
+//	POST   /connections/{id}/indexes/meta        获取索引元数据          [index_meta]
+//	POST   /connections/{id}/indexes/search      检索索引文档            [index_search]
+//	POST   /connections/{id}/query               执行查询                [query]
+//
+// 请求须携带 X-Vega-Connector-Protocol 头声明协议版本；服务端不支持时返回 400 + unsupported_protocol。
+// 调用未声明的能力返回 501 + unsupported_capability，会话不存在返回 404 + connection_not_found。
+// 非 2xx 响应统一使用 ErrorResponse 作为响应体。表、列、索引等结构复用 interfaces 包中的 JSON 定义。
+
+const (
+	// ProtocolVersion 当前协议版本
+	ProtocolVersion = "v1"
+	// ProtocolHeader 协议版本请求头
+	ProtocolHeader = "X-Vega-Connector-Protocol"
+	// BasePath 协议接口前缀
+	BasePath = "/api/vega-connector/" + ProtocolVersion
+)
+
+// 协议接口路径（相对 BasePath）
+const (
+	PathInfo        = "/info"
+	PathConnections = "/connections"
+	PathPing        = "/ping"
+	PathTest        = "/test"
+	PathMetadata    = "/metadata"
+	PathDatabases   = "/databases"
+	PathTables      = "/tables"
+	PathTableMeta   = "/tables/meta"
+	PathIndexes     = "/indexes"
+	PathIndexMeta   = "/indexes/meta"
+	PathIndexSearch = "/indexes/search"
+	PathQuery       = "/query"
+)
+
+// 能力标识，由 /info 返回，VEGA Manager 仅调用声明过的能力
+const (
+	CapabilityTestConnection = "test_connection"
+	CapabilityMetadata       = "metadata"
+	CapabilityListDatabases  = "list_databases"
+	CapabilityListTables     = "list_tables"
+	CapabilityTableMeta      = "table_meta"
+	CapabilityListIndexes    = "list_indexes"
+	CapabilityIndexMeta      = "index_meta"
+	CapabilityIndexSearch    = "index_search"
+	CapabilityQuery          = "query"
+)
+
+// AllCapabilities 协议 v1 定义的全部能力
+var AllCapabilities = []string{
+	CapabilityTestConnection,
+	CapabilityMetadata,
+	CapabilityListDatabases,
+	CapabilityListTables,
+	CapabilityTableMeta,
+	CapabilityListIndexes,
+	CapabilityIndexMeta,
+	CapabilityIndexSearch,
+	CapabilityQuery,
+}
+
+// 协议错误码
+const (
+	ErrCodeUnsupportedProtocol   = "unsupported_protocol"
+	ErrCodeUnsupportedCapability = "unsupported_capability"
+	ErrCodeInvalidRequest        = "invalid_request"
+	ErrCodeConnectionNotFound    = "connection_not_found"
+	ErrCodeConnectFailed         = "connect_failed"
+	ErrCodeNotFound              = "not_found"
+	ErrCodeInternal              = "internal_error"
+)
+
+// InfoResponse connector 信息及能力声明
+type InfoResponse struct {
+	Name             string   `json:"name"`
+	Version          string   `json:"version"`
+	ProtocolVersions []string `json:"protocol_versions"`
+	Category         string   `json:"category"`
+	Capabilities     []string `json:"capabilities"`
+	// FieldConfig connector_config 字段定义，可用于注册 connector 类型时填充 field_config
+	FieldConfig map[string]interfaces.ConnectorFieldConfig `json:"field_config,omitempty"`
+}
+
+// SupportsProtocol 是否支持指定协议版本
+func (info *InfoResponse) SupportsProtocol(version string) bool {
+	return slices.Contains(info.ProtocolVersions, version)
+}
+
+// HasCapability 是否声明了指定能力
+func (info *InfoResponse) HasCapability(capability string) bool {
+	return slices.Contains(info.Capabilities, capability)
+}
+
+// CreateConnectionRequest 创建连接请求
+type CreateConnectionRequest struct {
+	Type   string                     `json:"type"`
+	Config interfaces.ConnectorConfig `json:"config"`
+}
+
+// CreateConnectionResponse 创建连接响应
+type CreateConnectionResponse struct {
+	ConnectionID string `json:"connection_id"`
+}
+
+// MetadataResponse 实例级元数据响应
+type MetadataResponse struct {
+	Metadata map[string]any `json:"metadata"`
+}
+
+// DatabasesResponse 数据库列表响应
+type DatabasesResponse struct {
+	Databases []string `json:"databases"`
+}
+
+// TablesResponse 表列表响应
+type TablesResponse struct {
+	Tables []*interfaces.TableMeta `json:"tables"`
+}
+
+// TableMetaRequest 表元数据请求，table 中至少包含 name（实例级连接时还需 database）
+type TableMetaRequest struct {
+	Table *interfaces.TableMeta `json:"table"`
+}
+
+// TableMetaResponse 表元数据响应
+type TableMetaResponse struct {
+	Table *interfaces.TableMeta `json:"table"`
+}
+
+// IndexesResponse 索引列表响应
+type IndexesResponse struct {
+	Indexes []*interfaces.IndexMeta `json:"indexes"`
+}
+
+// IndexMetaRequest 索引元数据请求，index 中至少包含 name
+type IndexMetaRequest struct {
+	Index *interfaces.IndexMeta `json:"index"`
+}
+
+// IndexMetaResponse 索引元数据响应
+type IndexMetaResponse struct {
+	Index *interfaces.IndexMeta `json:"index"`
+}
+
+// IndexSearchRequest 索引检索请求，返回前 limit 条文档
+type IndexSearchRequest struct {
+	Index string `json:"index"`
+	Limit int    `json:"limit"`
+}
+
+// QueryRequest 查询请求
+type QueryRequest struct {
+	Query string `json:"query"`
+	Args  []any  `json:"args,omitempty"`
+}
+
+// QueryResponse 查询响应，同时用于索引检索
+type QueryResponse struct {
+	Result *interfaces.QueryResult `json:"result"`
+}
+
+// ErrorResponse 错误响应
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ProtocolError 远程 connector 返回的协议错误
+type ProtocolError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *ProtocolError) Error() string {
+	return fmt.Sprintf("remote connector error (status %d, code %s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// ConnectionPath 返回指定连接下的接口路径
+func ConnectionPath(connectionID string, subPath string) string {
+	return PathConnections + "/" + url.PathEscape(connectionID) + subPath
+}
+
+// buildURL 拼接 endpoint 与协议路径
+func buildURL(endpoint string, path string) string {
+	return strings.TrimRight(endpoint, "/") + BasePath + path
+}
@@ -8,20 +8,41 @@ package remote
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"sync"
 
 	"vega-backend/interfaces"
 	"vega-backend/logics/connectors"
 )
 
+var (
+	clientOnce    sync.Once
+	defaultClient *Client
+)
+
+// getClient 返回共享的 HTTP 客户端，所有远程连接器复用同一连接池
+func getClient() *Client {
+	clientOnce.Do(func() {
+		defaultClient = NewClient()
+	})
+	return defaultClient
+}
+
 // ============================================
 // RemoteConnector 基础远程连接器
 // ============================================
 
-// RemoteConnector 实现基础的远程连接器代理
+// RemoteConnector 实现基础的远程连接器代理，按 Remote Connector Protocol v1 调用进程外 connector。
+// 同一实例既实现 TableConnector 也实现 IndexConnector，实际可用的操作由 /info 声明的能力决定。
 type RemoteConnector struct {
 	enabled  bool
 	connType *interfaces.ConnectorType
 	config   interfaces.ConnectorConfig
+
+	client       *Client
+	info         *InfoResponse
+	connectionID string
 }
 
 // NewRemoteConnector 创建基础远程连接器
@@ -82,23 +103,200 @@ func (rc *RemoteConnector) New(cfg interfaces.ConnectorConfig) (connectors.Conne
 	}, nil
 }
 
+// Negotiate 获取远程 connector 信息并校验协议版本与类别
+func (rc *RemoteConnector) Negotiate(ctx context.Context) (*InfoResponse, error) {
+	if rc.info != nil {
+		return rc.info, nil
+	}
+	if rc.connType.Endpoint == "" {
+		return nil, fmt.Errorf("remote connector %s has no endpoint", rc.connType.Type)
+	}
+
+	var info InfoResponse
+	if err := rc.getClient().Do(ctx, http.MethodGet, buildURL(rc.connType.Endpoint, PathInfo), nil, &info); err != nil {
+		return nil, fmt.Errorf("failed to get remote connector info: %w", err)
+	}
+	if !info.SupportsProtocol(ProtocolVersion) {
+		return nil, fmt.Errorf("remote connector %s does not support protocol %s, supported: %v",
+			rc.connType.Type, ProtocolVersion, info.ProtocolVersions)
+	}
+	if info.Category != "" && rc.connType.Category != "" && info.Category != rc.connType.Category {
+		return nil, fmt.Errorf("remote connector category mismatch: registered %s, remote %s",
+			rc.connType.Category, info.Category)
+	}
+
+	rc.info = &info
+	return rc.info, nil
+}
+
+// Connect 协商能力并在远程 connector 上建立会话
 func (rc *RemoteConnector) Connect(ctx context.Context) error {
+	if rc.connectionID != "" {
+		return nil
+	}
+	if _, err := rc.Negotiate(ctx); err != nil {
+		return err
+	}
+
+	req := CreateConnectionRequest{
+		Type:   rc.connType.Type,
+		Config: rc.config,
+	}
+	var resp CreateConnectionResponse
+	if err := rc.getClient().Do(ctx, http.MethodPost, buildURL(rc.connType.Endpoint, PathConnections), req, &resp); err != nil {
+		return fmt.Errorf("failed to create remote connection: %w", err)
+	}
+	if resp.ConnectionID == "" {
+		return fmt.Errorf("remote connector returned empty connection_id")
+	}
+
+	rc.connectionID = resp.ConnectionID
 	return nil
 }
 
+// Close 关闭远程会话
 func (rc *RemoteConnector) Close(ctx context.Context) error {
+	if rc.connectionID == "" {
+		return nil
+	}
+	url := buildURL(rc.connType.Endpoint, ConnectionPath(rc.connectionID, ""))
+	rc.connectionID = ""
+	if _, err := rc.getClient().Delete(ctx, url); err != nil {
+		return fmt.Errorf("failed to close remote connection: %w", err)
+	}
 	return nil
 }
 
+// Ping 检查远程会话是否可用
 func (rc *RemoteConnector) Ping(ctx context.Context) error {
-	return nil
+	return rc.call(ctx, http.MethodPost, PathPing, "", nil, nil)
 }
 
+// TestConnection 建立会话并由远程 connector 执行连接测试
 func (rc *RemoteConnector) TestConnection(ctx context.Context) error {
-	return nil
+	if err := rc.Connect(ctx); err != nil {
+		return err
+	}
+	if !rc.info.HasCapability(CapabilityTestConnection) {
+		// 未声明连接测试能力时，会话建立成功即视为连通
+		return nil
+	}
+	return rc.call(ctx, http.MethodPost, PathTest, CapabilityTestConnection, nil, nil)
 }
 
-// GetMetadata returns the metadata for the catalog (stub).
+// GetMetadata returns the metadata for the catalog.
 func (rc *RemoteConnector) GetMetadata(ctx context.Context) (map[string]any, error) {
-	return nil, nil
+	if err := rc.Connect(ctx); err != nil {
+		return nil, err
+	}
+	if !rc.info.HasCapability(CapabilityMetadata) {
+		return map[string]any{}, nil
+	}
+
+	var resp MetadataResponse
+	if err := rc.call(ctx, http.MethodGet, PathMetadata, CapabilityMetadata, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Metadata == nil {
+		resp.Metadata = map[string]any{}
+	}
+	return resp.Metadata, nil
+}
+
+// ListDatabases 列出远程实例下的数据库
+func (rc *RemoteConnector) ListDatabases(ctx context.Context) ([]string, error) {
+	var resp DatabasesResponse
+	if err := rc.call(ctx, http.MethodGet, PathDatabases, CapabilityListDatabases, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Databases, nil
+}
+
+// ListTables 列出远程实例下的表
+func (rc *RemoteConnector) ListTables(ctx context.Context) ([]*interfaces.TableMeta, error) {
+	var resp TablesResponse
+	if err := rc.call(ctx, http.MethodGet, PathTables, CapabilityListTables, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Tables, nil
+}
+
+// GetTableMeta 获取表的完整元数据，结果回填到 table 中
+func (rc *RemoteConnector) GetTableMeta(ctx context.Context, table *interfaces.TableMeta) error {
+	var resp TableMetaResponse
+	if err := rc.call(ctx, http.MethodPost, PathTableMeta, CapabilityTableMeta, TableMetaRequest{Table: table}, &resp); err != nil {
+		return err
+	}
+	if resp.Table == nil {
+		return fmt.Errorf("remote connector returned empty table meta for %s", table.Name)
+	}
+	*table = *resp.Table
+	return nil
+}
+
+// ExecuteQuery 在远程 connector 上执行查询
+func (rc *RemoteConnector) ExecuteQuery(ctx context.Context, query string, args ...any) (*interfaces.QueryResult, error) {
+	var resp QueryResponse
+	if err := rc.call(ctx, http.MethodPost, PathQuery, CapabilityQuery, QueryRequest{Query: query, Args: args}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Result == nil {
+		return &interfaces.QueryResult{}, nil
+	}
+	return resp.Result, nil
+}
+
+// ListIndexes 列出远程实例下的索引
+func (rc *RemoteConnector) ListIndexes(ctx context.Context) ([]*interfaces.IndexMeta, error) {
+	var resp IndexesResponse
+	if err := rc.call(ctx, http.MethodGet, PathIndexes, CapabilityListIndexes, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Indexes, nil
+}
+
+// GetIndexMeta 获取索引的完整元数据，结果回填到 index 中
+func (rc *RemoteConnector) GetIndexMeta(ctx context.Context, index *interfaces.IndexMeta) error {
+	var resp IndexMetaResponse
+	if err := rc.call(ctx, http.MethodPost, PathIndexMeta, CapabilityIndexMeta, IndexMetaRequest{Index: index}, &resp); err != nil {
+		return err
+	}
+	if resp.Index == nil {
+		return fmt.Errorf("remote connector returned empty index meta for %s", index.Name)
+	}
+	*index = *resp.Index
+	return nil
+}
+
+// SearchIndex 检索远程索引中的前 limit 条文档
+func (rc *RemoteConnector) SearchIndex(ctx context.Context, index string, limit int) (*interfaces.QueryResult, error) {
+	var resp QueryResponse
+	if err := rc.call(ctx, http.MethodPost, PathIndexSearch, CapabilityIndexSearch,
+		IndexSearchRequest{Index: index, Limit: limit}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Result == nil {
+		return &interfaces.QueryResult{}, nil
+	}
+	return resp.Result, nil
+}
+
+// call 在当前会话上调用协议接口，capability 非空时先校验远程 connector 是否声明了该能力
+func (rc *RemoteConnector) call(ctx context.Context, method, subPath, capability string, body any, out any) error {
+	if err := rc.Connect(ctx); err != nil {
+		return err
+	}
+	if capability != "" && !rc.info.HasCapability(capability) {
+		return fmt.Errorf("remote connector %s does not support capability %s", rc.connType.Type, capability)
+	}
+
+	url := buildURL(rc.connType.Endpoint, ConnectionPath(rc.connectionID, subPath))
+	return rc.getClient().Do(ctx, method, url, body, out)
+}
+
+func (rc *RemoteConnector) getClient() *Client {
+	if rc.client == nil {
+		rc.client = getClient()
+	}
+	return rc.client
 }
@@ -0,0 +1,330 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors"
+)
+
+// ============================================
+// MemoryConnector 参考用内存表连接器
+// ============================================
+
+// MemoryTable 内存表，Meta.Database 为所属数据库
+type MemoryTable struct {
+	Meta interfaces.TableMeta
+	Rows []map[string]any
+}
+
+// memoryConfig 内存连接器配置
+type memoryConfig struct {
+	Databases []string `mapstructure:"databases"`
+}
+
+// 仅支持 SELECT * FROM [db.]table [LIMIT n]
+var memoryQueryPattern = regexp.MustCompile(`(?i)^\s*select\s+\*\s+from\s+([\w.]+)(?:\s+limit\s+(\d+))?\s*;?\s*$`)
+
+// MemoryConnector 基于内存数据集的 TableConnector，用于演示协议及运行一致性测试
+type MemoryConnector struct {
+	enabled bool
+	config  *memoryConfig
+	tables  []*MemoryTable
+
+	connected bool
+}
+
+// NewMemoryConnector 创建内存连接器，tables 为空时使用 SampleTables
+func NewMemoryConnector(tables []*MemoryTable) connectors.TableConnector {
+	if len(tables) == 0 {
+		tables = SampleTables()
+	}
+	return &MemoryConnector{tables: tables}
+}
+
+// GetType returns the data source type.
+func (c *MemoryConnector) GetType() string {
+	return "memory"
+}
+
+// GetName returns the connector name.
+func (c *MemoryConnector) GetName() string {
+	return "memory"
+}
+
+// GetMode returns the connector mode.
+func (c *MemoryConnector) GetMode() string {
+	return interfaces.ConnectorModeRemote
+}
+
+// GetCategory returns the connector category.
+func (c *MemoryConnector) GetCategory() string {
+	return interfaces.ConnectorCategoryTable
+}
+
+// GetEnabled returns the enabled status.
+func (c *MemoryConnector) GetEnabled() bool {
+	return c.enabled
+}
+
+// SetEnabled sets the enabled status.
+func (c *MemoryConnector) SetEnabled(enabled bool) {
+	c.enabled = enabled
+}
+
+// GetSensitiveFields returns the sensitive fields for memory connector.
+func (c *MemoryConnector) GetSensitiveFields() []string {
+	return []string{}
+}
+
+// GetFieldConfig returns the field configuration for memory connector.
+func (c *MemoryConnector) GetFieldConfig() map[string]interfaces.ConnectorFieldConfig {
+	return map[string]interfaces.ConnectorFieldConfig{
+		"databases": {Name: "数据库列表", Type: "array", Description: "数据库名称列表（可选，为空则返回全部数据库）", Required: false, Encrypted: false},
+	}
+}
+
+// New creates a new memory connector sharing the same dataset.
+func (c *MemoryConnector) New(cfg interfaces.ConnectorConfig) (connectors.Connector, error) {
+	var mCfg memoryConfig
+	if err := mapstructure.Decode(cfg, &mCfg); err != nil {
+		return nil, fmt.Errorf("failed to decode memory config: %w", err)
+	}
+	return &MemoryConnector{
+		enabled: c.enabled,
+		config:  &mCfg,
+		tables:  c.tables,
+	}, nil
+}
+
+// Connect 校验配置的数据库是否存在
+func (c *MemoryConnector) Connect(ctx context.Context) error {
+	if c.connected {
+		return nil
+	}
+	existing := make(map[string]bool)
+	for _, t := range c.tables {
+		existing[t.Meta.Database] = true
+	}
+	if c.config != nil {
+		for _, db := range c.config.Databases {
+			if !existing[db] {
+				return fmt.Errorf("database %s does not exist", db)
+			}
+		}
+	}
+	c.connected = true
+	return nil
+}
+
+// Close closes the connection.
+func (c *MemoryConnector) Close(ctx context.Context) error {
+	c.connected = false
+	return nil
+}
+
+// Ping checks the connection.
+func (c *MemoryConnector) Ping(ctx context.Context) error {
+	if !c.connected {
+		return fmt.Errorf("not connected")
+	}
+	return nil
+}
+
+// TestConnection tests the connection.
+func (c *MemoryConnector) TestConnection(ctx context.Context) error {
+	return c.Connect(ctx)
+}
+
+// GetMetadata returns the metadata for the catalog.
+func (c *MemoryConnector) GetMetadata(ctx context.Context) (map[string]any, error) {
+	return map[string]any{
+		"product":     "memory",
+		"table_count": len(c.visibleTables()),
+	}, nil
+}
+
+// ListDatabases returns all databases.
+func (c *MemoryConnector) ListDatabases(ctx context.Context) ([]string, error) {
+	seen := make(map[string]bool)
+	databases := []string{}
+	for _, t := range c.visibleTables() {
+		if !seen[t.Meta.Database] {
+			seen[t.Meta.Database] = true
+			databases = append(databases, t.Meta.Database)
+		}
+	}
+	sort.Strings(databases)
+	return databases, nil
+}
+
+// ListTables returns all tables without columns.
+func (c *MemoryConnector) ListTables(ctx context.Context) ([]*interfaces.TableMeta, error) {
+	tables := make([]*interfaces.TableMeta, 0, len(c.tables))
+	for _, t := range c.visibleTables() {
+		tables = append(tables, &interfaces.TableMeta{
+			Name:        t.Meta.Name,
+			Description: t.Meta.Description,
+			Database:    t.Meta.Database,
+			SubType:     t.Meta.SubType,
+			Properties: map[string]any{
+				"row_count": len(t.Rows),
+			},
+		})
+	}
+	return tables, nil
+}
+
+// GetTableMeta fills the table with its full metadata.
+func (c *MemoryConnector) GetTableMeta(ctx context.Context, table *interfaces.TableMeta) error {
+	t := c.findTable(table.Database, table.Name)
+	if t == nil {
+		return fmt.Errorf("table %s.%s: %w", table.Database, table.Name, ErrNotFound)
+	}
+
+	meta := t.Meta
+	meta.Properties = map[string]any{"row_count": len(t.Rows)}
+	*table = meta
+	return nil
+}
+
+// ExecuteQuery executes a simple full table scan with optional limit.
+func (c *MemoryConnector) ExecuteQuery(ctx context.Context, query string, args ...any) (*interfaces.QueryResult, error) {
+	m := memoryQueryPattern.FindStringSubmatch(query)
+	if m == nil {
+		return nil, fmt.Errorf("unsupported query: %s", query)
+	}
+
+	database, name := "", m[1]
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		database, name = name[:i], name[i+1:]
+	}
+	t := c.findTable(database, name)
+	if t == nil {
+		return nil, fmt.Errorf("table %s: %w", m[1], ErrNotFound)
+	}
+
+	rows := t.Rows
+	if m[2] != "" {
+		limit, _ := strconv.Atoi(m[2])
+		if limit < len(rows) {
+			rows = rows[:limit]
+		}
+	}
+
+	columns := make([]string, 0, len(t.Meta.Columns))
+	for _, col := range t.Meta.Columns {
+		columns = append(columns, col.Name)
+	}
+	return &interfaces.QueryResult{
+		Columns: columns,
+		Rows:    rows,
+		Total:   int64(len(rows)),
+	}, nil
+}
+
+// visibleTables 返回配置的数据库范围内的表
+func (c *MemoryConnector) visibleTables() []*MemoryTable {
+	if c.config == nil || len(c.config.Databases) == 0 {
+		return c.tables
+	}
+	tables := make([]*MemoryTable, 0, len(c.tables))
+	for _, t := range c.tables {
+		for _, db := range c.config.Databases {
+			if t.Meta.Database == db {
+				tables = append(tables, t)
+				break
+			}
+		}
+	}
+	return tables
+}
+
+// findTable 按数据库与表名查找，database 为空时匹配第一个同名表
+func (c *MemoryConnector) findTable(database, name string) *MemoryTable {
+	for _, t := range c.visibleTables() {
+		if t.Meta.Name == name && (database == "" || t.Meta.Database == database) {
+			return t
+		}
+	}
+	return nil
+}
+
+// SampleTables 返回参考连接器默认的演示数据集
+func SampleTables() []*MemoryTable {
+	return []*MemoryTable{
+		{
+			Meta: interfaces.TableMeta{
+				Name:        "customers",
+				Description: "客户信息",
+				Database:    "shop",
+				SubType:     "table",
+				Columns: []interfaces.ColumnMeta{
+					{Name: "id", Type: "integer", OrigType: "int64", OrdinalPosition: 1, ColumnKey: "PRI"},
+					{Name: "name", Type: "string", OrigType: "string", OrdinalPosition: 2, CharMaxLen: 64},
+					{Name: "email", Type: "string", OrigType: "string", Nullable: true, OrdinalPosition: 3, CharMaxLen: 128},
+				},
+				PKs: []string{"id"},
+				Indexes: []interfaces.IndexInfo{
+					{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Primary: true},
+				},
+			},
+			Rows: []map[string]any{
+				{"id": 1, "name": "alice", "email": "alice@example.com"},
+				{"id": 2, "name": "bob", "email": nil},
+			},
+		},
+		{
+			Meta: interfaces.TableMeta{
+				Name:        "orders",
+				Description: "订单",
+				Database:    "shop",
+				SubType:     "table",
+				Columns: []interfaces.ColumnMeta{
+					{Name: "id", Type: "integer", OrigType: "int64", OrdinalPosition: 1, ColumnKey: "PRI"},
+					{Name: "customer_id", Type: "integer", OrigType: "int64", OrdinalPosition: 2, ColumnKey: "MUL"},
+					{Name: "amount", Type: "decimal", OrigType: "decimal(10,2)", OrdinalPosition: 3, NumPrecision: 10, NumScale: 2},
+					{Name: "created_at", Type: "datetime", OrigType: "timestamp", OrdinalPosition: 4},
+				},
+				PKs: []string{"id"},
+				Indexes: []interfaces.IndexInfo{
+					{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Primary: true},
+					{Name: "idx_customer", Columns: []string{"customer_id"}},
+				},
+				ForeignKeys: []interfaces.ForeignKeyInfo{
+					{Name: "fk_orders_customer", Columns: []string{"customer_id"}, RefTable: "customers", RefColumns: []string{"id"}},
+				},
+			},
+			Rows: []map[string]any{
+				{"id": 1, "customer_id": 1, "amount": "19.90", "created_at": "2024-01-01 10:00:00"},
+				{"id": 2, "customer_id": 1, "amount": "5.00", "created_at": "2024-01-02 11:30:00"},
+				{"id": 3, "customer_id": 2, "amount": "42.00", "created_at": "2024-01-03 09:15:00"},
+			},
+		},
+		{
+			Meta: interfaces.TableMeta{
+				Name:        "events",
+				Description: "访问日志",
+				Database:    "analytics",
+				SubType:     "table",
+				Columns: []interfaces.ColumnMeta{
+					{Name: "ts", Type: "datetime", OrigType: "timestamp", OrdinalPosition: 1},
+					{Name: "payload", Type: "json", OrigType: "json", Nullable: true, OrdinalPosition: 2},
+				},
+			},
+			Rows: []map[string]any{},
+		},
+	}
+}
@@ -0,0 +1,346 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package server provides a reference implementation of the Remote Connector Protocol.
+// 任意 connectors.Connector 实现都可以通过 Server 以进程外 connector 的形式对外提供服务，
+// 能力列表根据其实现的接口（TableConnector / IndexConnector）自动推导。
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"github.com/rs/xid"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors"
+	"vega-backend/logics/connectors/remote"
+)
+
+// ErrNotFound connector 找不到请求的表或索引时返回（可包装），服务端映射为 404 + not_found
+var ErrNotFound = errors.New("not found")
+
+// Server 远程 connector 参考服务端
+type Server struct {
+	prototype connectors.Connector
+	info      remote.InfoResponse
+
+	mu       sync.Mutex
+	sessions map[string]connectors.Connector
+}
+
+// NewServer 基于 connector 原型创建服务端，每个会话通过 prototype.New 创建独立实例
+func NewServer(prototype connectors.Connector, version string) *Server {
+	return &Server{
+		prototype: prototype,
+		info: remote.InfoResponse{
+			Name:             prototype.GetName(),
+			Version:          version,
+			ProtocolVersions: []string{remote.ProtocolVersion},
+			Category:         prototype.GetCategory(),
+			Capabilities:     capabilitiesOf(prototype),
+			FieldConfig:      prototype.GetFieldConfig(),
+		},
+		sessions: make(map[string]connectors.Connector),
+	}
+}
+
+// capabilitiesOf 根据 connector 实现的接口推导能力列表
+func capabilitiesOf(c connectors.Connector) []string {
+	capabilities := []string{remote.CapabilityTestConnection, remote.CapabilityMetadata}
+	if _, ok := c.(connectors.TableConnector); ok {
+		capabilities = append(capabilities,
+			remote.CapabilityListDatabases,
+			remote.CapabilityListTables,
+			remote.CapabilityTableMeta,
+			remote.CapabilityQuery)
+	}
+	if _, ok := c.(connectors.IndexConnector); ok {
+		capabilities = append(capabilities,
+			remote.CapabilityListIndexes,
+			remote.CapabilityIndexMeta,
+			remote.CapabilityIndexSearch)
+	}
+	return capabilities
+}
+
+// Info 返回服务端声明的 connector 信息
+func (s *Server) Info() remote.InfoResponse {
+	return s.info
+}
+
+// Handler 返回注册了全部协议接口的 http.Handler
+func (s *Server) Handler() http.Handler {
+	engine := gin.New()
+	engine.Use(gin.Recovery())
+	s.RegisterRoutes(engine)
+	return engine
+}
+
+// RegisterRoutes 在 engine 上注册协议接口
+func (s *Server) RegisterRoutes(engine *gin.Engine) {
+	engine.GET(remote.BasePath+remote.PathInfo, s.getInfo)
+
+	group := engine.Group(remote.BasePath+remote.PathConnections, s.checkProtocol)
+	{
+		group.POST("", s.createConnection)
+		group.DELETE("/:id", s.closeConnection)
+		group.POST("/:id"+remote.PathPing, s.withSession(s.ping))
+		group.POST("/:id"+remote.PathTest, s.withSession(s.testConnection))
+		group.GET("/:id"+remote.PathMetadata, s.withSession(s.getMetadata))
+		group.GET("/:id"+remote.PathDatabases, s.withTableSession(s.listDatabases))
+		group.GET("/:id"+remote.PathTables, s.withTableSession(s.listTables))
+		group.POST("/:id"+remote.PathTableMeta, s.withTableSession(s.getTableMeta))
+		group.POST("/:id"+remote.PathQuery, s.withTableSession(s.executeQuery))
+		group.GET("/:id"+remote.PathIndexes, s.withIndexSession(s.listIndexes))
+		group.POST("/:id"+remote.PathIndexMeta, s.withIndexSession(s.getIndexMeta))
+		group.POST("/:id"+remote.PathIndexSearch, s.withIndexSession(s.searchIndex))
+	}
+}
+
+// Close 关闭所有会话
+func (s *Server) Close(ctx context.Context) {
+	s.mu.Lock()
+	sessions := s.sessions
+	s.sessions = make(map[string]connectors.Connector)
+	s.mu.Unlock()
+
+	for id, c := range sessions {
+		if err := c.Close(ctx); err != nil {
+			logger.Warnf("close remote connector session %s failed: %v", id, err)
+		}
+	}
+}
+
+// checkProtocol 校验请求声明的协议版本
+func (s *Server) checkProtocol(c *gin.Context) {
+	version := c.GetHeader(remote.ProtocolHeader)
+	if version != remote.ProtocolVersion {
+		abortWithError(c, http.StatusBadRequest, remote.ErrCodeUnsupportedProtocol,
+			"unsupported protocol version: "+version)
+		return
+	}
+	c.Next()
+}
+
+func (s *Server) getInfo(c *gin.Context) {
+	c.JSON(http.StatusOK, s.info)
+}
+
+func (s *Server) createConnection(c *gin.Context) {
+	var req remote.CreateConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, remote.ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	conn, err := s.prototype.New(req.Config)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, remote.ErrCodeInvalidRequest, err.Error())
+		return
+	}
+	if err := conn.Connect(c.Request.Context()); err != nil {
+		abortWithError(c, http.StatusBadRequest, remote.ErrCodeConnectFailed, err.Error())
+		return
+	}
+
+	id := xid.New().String()
+	s.mu.Lock()
+	s.sessions[id] = conn
+	s.mu.Unlock()
+
+	c.JSON(http.StatusCreated, remote.CreateConnectionResponse{ConnectionID: id})
+}
+
+func (s *Server) closeConnection(c *gin.Context) {
+	id := c.Param("id")
+	s.mu.Lock()
+	conn, ok := s.sessions[id]
+	delete(s.sessions, id)
+	s.mu.Unlock()
+
+	if !ok {
+		abortWithError(c, http.StatusNotFound, remote.ErrCodeConnectionNotFound, "connection not found: "+id)
+		return
+	}
+	if err := conn.Close(c.Request.Context()); err != nil {
+		logger.Warnf("close remote connector session %s failed: %v", id, err)
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// withSession 查找会话并调用 handler
+func (s *Server) withSession(handler func(*gin.Context, connectors.Connector)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		s.mu.Lock()
+		conn, ok := s.sessions[id]
+		s.mu.Unlock()
+
+		if !ok {
+			abortWithError(c, http.StatusNotFound, remote.ErrCodeConnectionNotFound, "connection not found: "+id)
+			return
+		}
+		handler(c, conn)
+	}
+}
+
+// withTableSession 查找会话并要求其实现 TableConnector
+func (s *Server) withTableSession(handler func(*gin.Context, connectors.TableConnector)) gin.HandlerFunc {
+	return s.withSession(func(c *gin.Context, conn connectors.Connector) {
+		tc, ok := conn.(connectors.TableConnector)
+		if !ok {
+			abortWithError(c, http.StatusNotImplemented, remote.ErrCodeUnsupportedCapability,
+				"connector does not support table operations")
+			return
+		}
+		handler(c, tc)
+	})
+}
+
+// withIndexSession 查找会话并要求其实现 IndexConnector
+func (s *Server) withIndexSession(handler func(*gin.Context, connectors.IndexConnector)) gin.HandlerFunc {
+	return s.withSession(func(c *gin.Context, conn connectors.Connector) {
+		ic, ok := conn.(connectors.IndexConnector)
+		if !ok {
+			abortWithError(c, http.StatusNotImplemented, remote.ErrCodeUnsupportedCapability,
+				"connector does not support index operations")
+			return
+		}
+		handler(c, ic)
+	})
+}
+
+func (s *Server) ping(c *gin.Context, conn connectors.Connector) {
+	if err := conn.Ping(c.Request.Context()); err != nil {
+		abortWithConnectorError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) testConnection(c *gin.Context, conn connectors.Connector) {
+	if err := conn.TestConnection(c.Request.Context()); err != nil {
+		abortWithError(c, http.StatusBadRequest, remote.ErrCodeConnectFailed, err.Error())
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) getMetadata(c *gin.Context, conn connectors.Connector) {
+	metadata, err := conn.GetMetadata(c.Request.Context())
+	if err != nil {
+		abortWithConnectorError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, remote.MetadataResponse{Metadata: metadata})
+}
+
+func (s *Server) listDatabases(c *gin.Context, conn connectors.TableConnector) {
+	databases, err := conn.ListDatabases(c.Request.Context())
+	if err != nil {
+		abortWithConnectorError(c, err)
+		return
+	}
+	if databases == nil {
+		databases = []string{}
+	}
+	c.JSON(http.StatusOK, remote.DatabasesResponse{Databases: databases})
+}
+
+func (s *Server) listTables(c *gin.Context, conn connectors.TableConnector) {
+	tables, err := conn.ListTables(c.Request.Context())
+	if err != nil {
+		abortWithConnectorError(c, err)
+		return
+	}
+	if tables == nil {
+		tables = []*interfaces.TableMeta{}
+	}
+	c.JSON(http.StatusOK, remote.TablesResponse{Tables: tables})
+}
+
+func (s *Server) getTableMeta(c *gin.Context, conn connectors.TableConnector) {
+	var req remote.TableMetaRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Table == nil || req.Table.Name == "" {
+		abortWithError(c, http.StatusBadRequest, remote.ErrCodeInvalidRequest, "table.name is required")
+		return
+	}
+	if err := conn.GetTableMeta(c.Request.Context(), req.Table); err != nil {
+		abortWithConnectorError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, remote.TableMetaResponse{Table: req.Table})
+}
+
+func (s *Server) executeQuery(c *gin.Context, conn connectors.TableConnector) {
+	var req remote.QueryRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Query == "" {
+		abortWithError(c, http.StatusBadRequest, remote.ErrCodeInvalidRequest, "query is required")
+		return
+	}
+	result, err := conn.ExecuteQuery(c.Request.Context(), req.Query, req.Args...)
+	if err != nil {
+		abortWithConnectorError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, remote.QueryResponse{Result: result})
+}
+
+func (s *Server) listIndexes(c *gin.Context, conn connectors.IndexConnector) {
+	indexes, err := conn.ListIndexes(c.Request.Context())
+	if err != nil {
+		abortWithConnectorError(c, err)
+		return
+	}
+	if indexes == nil {
+		indexes = []*interfaces.IndexMeta{}
+	}
+	c.JSON(http.StatusOK, remote.IndexesResponse{Indexes: indexes})
+}
+
+func (s *Server) getIndexMeta(c *gin.Context, conn connectors.IndexConnector) {
+	var req remote.IndexMetaRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Index == nil || req.Index.Name == "" {
+		abortWithError(c, http.StatusBadRequest, remote.ErrCodeInvalidRequest, "index.name is required")
+		return
+	}
+	if err := conn.GetIndexMeta(c.Request.Context(), req.Index); err != nil {
+		abortWithConnectorError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, remote.IndexMetaResponse{Index: req.Index})
+}
+
+func (s *Server) searchIndex(c *gin.Context, conn connectors.IndexConnector) {
+	var req remote.IndexSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Index == "" || req.Limit <= 0 {
+		abortWithError(c, http.StatusBadRequest, remote.ErrCodeInvalidRequest, "index and a positive limit are required")
+		return
+	}
+	result, err := conn.SearchIndex(c.Request.Context(), req.Index, req.Limit)
+	if err != nil {
+		abortWithConnectorError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, remote.QueryResponse{Result: result})
+}
+
+// abortWithConnectorError 将 connector 返回的错误映射为协议错误
+func abortWithConnectorError(c *gin.Context, err error) {
+	if errors.Is(err, ErrNotFound) {
+		abortWithError(c, http.StatusNotFound, remote.ErrCodeNotFound, err.Error())
+		return
+	}
+	abortWithError(c, http.StatusInternalServerError, remote.ErrCodeInternal, err.Error())
+}
+
+func abortWithError(c *gin.Context, status int, code string, message string) {
+	c.AbortWithStatusJSON(status, remote.ErrorResponse{Code: code, Message: message})
+}
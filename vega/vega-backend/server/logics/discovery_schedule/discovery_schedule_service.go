@@ -0,0 +1,256 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+// Package discovery_schedule provides DiscoverySchedule business logic.
+package discovery_schedule
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	o11y "github.com/kweaver-ai/kweaver-go-lib/observability"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/codes"
+
+	"vega-backend/common"
+	discoveryScheduleAccess "vega-backend/drivenadapters/discovery_schedule"
+	oerrors "vega-backend/errors"
+	"vega-backend/interfaces"
+	"vega-backend/logics/catalog"
+	"vega-backend/logics/discovery_task"
+)
+
+const (
+	// 定时发现的最小触发间隔，避免频繁扫描数据源
+	minScheduleInterval = 5 * time.Minute
+)
+
+var (
+	dssOnce    sync.Once
+	dssService interfaces.DiscoveryScheduleService
+)
+
+type discoveryScheduleService struct {
+	appSetting *common.AppSetting
+	dsa        interfaces.DiscoveryScheduleAccess
+	cs         interfaces.CatalogService
+	dts        interfaces.DiscoveryTaskService
+}
+
+// NewDiscoveryScheduleService creates or returns the singleton DiscoveryScheduleService.
+func NewDiscoveryScheduleService(appSetting *common.AppSetting) interfaces.DiscoveryScheduleService {
+	dssOnce.Do(func() {
+		dssService = &discoveryScheduleService{
+			appSetting: appSetting,
+			dsa:        discoveryScheduleAccess.NewDiscoveryScheduleAccess(appSetting),
+			cs:         catalog.NewCatalogService(appSetting),
+			dts:        discovery_task.NewDiscoveryTaskService(appSetting),
+		}
+	})
+	return dssService
+}
+
+// parseCronExpr 解析标准 5 段 cron 表达式（支持 @daily 等描述符），并校验触发间隔
+func parseCronExpr(expr string) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	// 抽查后续若干次触发时间的间隔
+	prev := schedule.Next(time.Now())
+	for i := 0; i < 10; i++ {
+		next := schedule.Next(prev)
+		if next.Sub(prev) < minScheduleInterval {
+			return nil, fmt.Errorf("interval between runs must be at least %s", minScheduleInterval)
+		}
+		prev = next
+	}
+	return schedule, nil
+}
+
+// GetByCatalogID retrieves the DiscoverySchedule of a Catalog.
+func (dss *discoveryScheduleService) GetByCatalogID(ctx context.Context, catalogID string) (*interfaces.DiscoverySchedule, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Get discovery schedule")
+	defer span.End()
+
+	schedule, err := dss.dsa.GetByCatalogID(ctx, catalogID)
+	if err != nil {
+		span.SetStatus(codes.Error, "Get discovery schedule failed")
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Catalog_InternalError_GetFailed).
+			WithErrorDetails(err.Error())
+	}
+	if schedule == nil {
+		span.SetStatus(codes.Error, "Discovery schedule not found")
+		return nil, rest.NewHTTPError(ctx, http.StatusNotFound, oerrors.VegaManager_Catalog_DiscoveryScheduleNotFound)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return schedule, nil
+}
+
+// Set creates or updates the DiscoverySchedule of a Catalog.
+func (dss *discoveryScheduleService) Set(ctx context.Context, catalogID string,
+	req *interfaces.DiscoveryScheduleRequest) (*interfaces.DiscoverySchedule, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Set discovery schedule")
+	defer span.End()
+
+	cronSchedule, err := parseCronExpr(req.CronExpr)
+	if err != nil {
+		span.SetStatus(codes.Error, "Invalid cron expression")
+		return nil, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Catalog_InvalidParameter_CronExpr).
+			WithErrorDetails(err.Error())
+	}
+
+	accountInfo := interfaces.AccountInfo{}
+	if v := ctx.Value(interfaces.ACCOUNT_INFO_KEY); v != nil {
+		accountInfo = v.(interfaces.AccountInfo)
+	}
+
+	schedule, err := dss.dsa.GetByCatalogID(ctx, catalogID)
+	if err != nil {
+		span.SetStatus(codes.Error, "Get discovery schedule failed")
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Catalog_InternalError_GetFailed).
+			WithErrorDetails(err.Error())
+	}
+
+	now := time.Now()
+	if schedule == nil {
+		schedule = &interfaces.DiscoverySchedule{
+			CatalogID:   catalogID,
+			CronExpr:    req.CronExpr,
+			Enabled:     req.Enabled,
+			NextRunTime: cronSchedule.Next(now).UnixMilli(),
+			Creator:     accountInfo,
+			CreateTime:  now.UnixMilli(),
+			Updater:     accountInfo,
+			UpdateTime:  now.UnixMilli(),
+		}
+		if err := dss.dsa.Create(ctx, schedule); err != nil {
+			logger.Errorf("Create discovery schedule failed: %v", err)
+			o11y.Error(ctx, fmt.Sprintf("Create discovery schedule failed: %v", err))
+			span.SetStatus(codes.Error, "Create discovery schedule failed")
+			return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Catalog_InternalError_UpdateFailed).
+				WithErrorDetails(err.Error())
+		}
+	} else {
+		schedule.CronExpr = req.CronExpr
+		schedule.Enabled = req.Enabled
+		schedule.NextRunTime = cronSchedule.Next(now).UnixMilli()
+		schedule.Updater = accountInfo
+		schedule.UpdateTime = now.UnixMilli()
+		if err := dss.dsa.Update(ctx, schedule); err != nil {
+			logger.Errorf("Update discovery schedule failed: %v", err)
+			o11y.Error(ctx, fmt.Sprintf("Update discovery schedule failed: %v", err))
+			span.SetStatus(codes.Error, "Update discovery schedule failed")
+			return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Catalog_InternalError_UpdateFailed).
+				WithErrorDetails(err.Error())
+		}
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return schedule, nil
+}
+
+// Delete deletes the DiscoverySchedule of a Catalog.
+func (dss *discoveryScheduleService) Delete(ctx context.Context, catalogID string) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Delete discovery schedule")
+	defer span.End()
+
+	if err := dss.dsa.DeleteByCatalogIDs(ctx, []string{catalogID}); err != nil {
+		span.SetStatus(codes.Error, "Delete discovery schedule failed")
+		return rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Catalog_InternalError_DeleteFailed).
+			WithErrorDetails(err.Error())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// TriggerDue creates scheduled discovery tasks for all due DiscoverySchedules.
+func (dss *discoveryScheduleService) TriggerDue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "Trigger due discovery schedules")
+	defer span.End()
+
+	schedules, err := dss.dsa.ListDue(ctx, now.UnixMilli())
+	if err != nil {
+		span.SetStatus(codes.Error, "List due discovery schedules failed")
+		return 0, err
+	}
+
+	triggered := 0
+	for _, schedule := range schedules {
+		cronSchedule, err := cron.ParseStandard(schedule.CronExpr)
+		if err != nil {
+			logger.Errorf("Invalid cron expression %q of catalog %s: %v", schedule.CronExpr, schedule.CatalogID, err)
+			continue
+		}
+
+		// 错过的触发点不补跑，下次触发时间从当前时间开始计算
+		claimed, err := dss.dsa.Claim(ctx, schedule.CatalogID, schedule.NextRunTime,
+			now.UnixMilli(), cronSchedule.Next(now).UnixMilli())
+		if err != nil {
+			logger.Errorf("Failed to claim discovery schedule of catalog %s: %v", schedule.CatalogID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		taskID, err := dss.trigger(ctx, schedule)
+		if err != nil {
+			logger.Errorf("Failed to trigger scheduled discovery for catalog %s: %v", schedule.CatalogID, err)
+			continue
+		}
+		if taskID == "" {
+			continue
+		}
+		if err := dss.dsa.UpdateLastTask(ctx, schedule.CatalogID, taskID); err != nil {
+			logger.Errorf("Failed to record last task of discovery schedule %s: %v", schedule.CatalogID, err)
+		}
+		triggered++
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return triggered, nil
+}
+
+// trigger 为一个到期的发现计划创建发现任务，目录不可用或已有未结束任务时跳过本次触发
+func (dss *discoveryScheduleService) trigger(ctx context.Context, schedule *interfaces.DiscoverySchedule) (string, error) {
+	catalog, err := dss.cs.GetByID(ctx, schedule.CatalogID, false)
+	if err != nil {
+		return "", err
+	}
+	if !catalog.Enabled || catalog.Type != interfaces.CatalogTypePhysical {
+		logger.Warnf("Skip scheduled discovery for catalog %s: catalog is disabled or not physical", schedule.CatalogID)
+		return "", nil
+	}
+
+	for _, status := range []string{interfaces.DiscoveryTaskStatusPending, interfaces.DiscoveryTaskStatusRunning} {
+		_, total, err := dss.dts.List(ctx, interfaces.DiscoveryTaskQueryParams{
+			PaginationParams: interfaces.PaginationParams{Limit: 1},
+			CatalogID:        schedule.CatalogID,
+			Status:           status,
+		})
+		if err != nil {
+			return "", err
+		}
+		if total > 0 {
+			logger.Infof("Skip scheduled discovery for catalog %s: a %s task exists", schedule.CatalogID, status)
+			return "", nil
+		}
+	}
+
+	// 定时任务以计划的最后修改人作为创建者
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, schedule.Updater)
+	return dss.dts.Create(ctx, schedule.CatalogID, interfaces.DiscoveryTaskTriggerScheduled)
+}
@@ -50,7 +50,7 @@ func NewDiscoveryTaskService(appSetting *common.AppSetting) interfaces.Discovery
 }
 
 // Create creates a new DiscoveryTask and enqueues it to the task queue.
-func (dts *discoveryTaskService) Create(ctx context.Context, catalogID string) (string, error) {
+func (dts *discoveryTaskService) Create(ctx context.Context, catalogID string, triggerType string) (string, error) {
 	ctx, span := ar_trace.Tracer.Start(ctx, "DiscoveryTaskService.Create",
 		trace.WithSpanKind(trace.SpanKindServer))
 	defer span.End()
@@ -65,7 +65,7 @@ func (dts *discoveryTaskService) Create(ctx context.Context, catalogID string) (
 	task := &interfaces.DiscoveryTask{
 		ID:          xid.New().String(),
 		CatalogID:   catalogID,
-		TriggerType: interfaces.DiscoveryTaskTriggerManual,
+		TriggerType: triggerType,
 		Status:      interfaces.DiscoveryTaskStatusPending,
 		Progress:    0,
 		Message:     "",
@@ -0,0 +1,220 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"vega-backend/interfaces"
+)
+
+// isNumericType 数值类型的列计算直方图
+func isNumericType(tp string) bool {
+	switch tp {
+	case "integer", "unsigned_integer", "float", "decimal":
+		return true
+	default:
+		return false
+	}
+}
+
+// profileColumns 在采样数据上逐列计算统计信息，列类型取自资源 schema
+func profileColumns(schema []interfaces.Property, result *interfaces.QueryResult) []*interfaces.ColumnProfile {
+	types := make(map[string]string, len(schema))
+	for _, p := range schema {
+		types[p.Name] = p.Type
+	}
+
+	columns := make([]*interfaces.ColumnProfile, 0, len(result.Columns))
+	for _, name := range result.Columns {
+		values := make([]any, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			// 文档类数据源中缺失的字段视为空值
+			values = append(values, row[name])
+		}
+		columns = append(columns, profileColumn(name, types[name], values))
+	}
+	return columns
+}
+
+// profileColumn 计算单列的空值比例、去重数、最值、高频值和直方图
+func profileColumn(name, tp string, values []any) *interfaces.ColumnProfile {
+	column := &interfaces.ColumnProfile{
+		Name:      name,
+		Type:      tp,
+		TopValues: []*interfaces.ValueCount{},
+	}
+
+	counts := make(map[string]int64)
+	numbers := make([]float64, 0, len(values))
+	numeric := isNumericType(tp)
+	var (
+		minValue, maxValue string
+		minTime, maxTime   time.Time
+		stringCount        int
+	)
+	for _, v := range values {
+		if v == nil {
+			column.NullCount++
+			continue
+		}
+		key := valueKey(v)
+		counts[key]++
+
+		if numeric {
+			if f, ok := toFloat(v); ok {
+				numbers = append(numbers, f)
+			}
+			continue
+		}
+		switch tv := v.(type) {
+		case time.Time:
+			if minTime.IsZero() || tv.Before(minTime) {
+				minTime = tv
+			}
+			if maxTime.IsZero() || tv.After(maxTime) {
+				maxTime = tv
+			}
+		case string:
+			stringCount++
+			if stringCount == 1 || key < minValue {
+				minValue = key
+			}
+			if stringCount == 1 || key > maxValue {
+				maxValue = key
+			}
+		}
+	}
+
+	if len(values) > 0 {
+		column.NullRatio = float64(column.NullCount) / float64(len(values))
+	}
+	column.DistinctCount = int64(len(counts))
+	column.TopValues = topValues(counts, interfaces.PROFILE_TOP_VALUES_NUMBER)
+
+	switch {
+	case len(numbers) > 0:
+		lower, upper := numbers[0], numbers[0]
+		for _, f := range numbers {
+			lower = math.Min(lower, f)
+			upper = math.Max(upper, f)
+		}
+		column.Min, column.Max = lower, upper
+		column.Histogram = histogram(numbers, lower, upper, interfaces.PROFILE_HISTOGRAM_BUCKETS)
+	case !minTime.IsZero():
+		column.Min, column.Max = minTime.Format(time.RFC3339Nano), maxTime.Format(time.RFC3339Nano)
+	case stringCount > 0:
+		column.Min, column.Max = minValue, maxValue
+	}
+	return column
+}
+
+// valueKey 将值转为用于计数的字符串，复合类型使用 JSON 表示
+func valueKey(v any) string {
+	switch tv := v.(type) {
+	case string:
+		return tv
+	case []byte:
+		return string(tv)
+	case time.Time:
+		return tv.Format(time.RFC3339Nano)
+	case map[string]any, []any:
+		b, _ := json.Marshal(tv)
+		return string(b)
+	default:
+		return fmt.Sprint(tv)
+	}
+}
+
+// toFloat 将数值或数值字符串（如 decimal 的文本表示）转为 float64
+func toFloat(v any) (float64, bool) {
+	switch tv := v.(type) {
+	case float64:
+		return tv, true
+	case float32:
+		return float64(tv), true
+	case int:
+		return float64(tv), true
+	case int8:
+		return float64(tv), true
+	case int16:
+		return float64(tv), true
+	case int32:
+		return float64(tv), true
+	case int64:
+		return float64(tv), true
+	case uint:
+		return float64(tv), true
+	case uint8:
+		return float64(tv), true
+	case uint16:
+		return float64(tv), true
+	case uint32:
+		return float64(tv), true
+	case uint64:
+		return float64(tv), true
+	case json.Number:
+		f, err := tv.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(tv, 64)
+		return f, err == nil
+	case []byte:
+		f, err := strconv.ParseFloat(string(tv), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// topValues 返回出现次数最多的 n 个值，次数相同时按值排序
+func topValues(counts map[string]int64, n int) []*interfaces.ValueCount {
+	values := make([]*interfaces.ValueCount, 0, len(counts))
+	for value, count := range counts {
+		values = append(values, &interfaces.ValueCount{Value: value, Count: count})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return values[i].Value < values[j].Value
+	})
+	if len(values) > n {
+		values = values[:n]
+	}
+	return values
+}
+
+// histogram 在 [lower, upper] 上计算等宽直方图，所有值相同时只有一个桶
+func histogram(numbers []float64, lower, upper float64, buckets int) []*interfaces.HistogramBucket {
+	if lower == upper {
+		return []*interfaces.HistogramBucket{{Lower: lower, Upper: upper, Count: int64(len(numbers))}}
+	}
+
+	width := (upper - lower) / float64(buckets)
+	result := make([]*interfaces.HistogramBucket, buckets)
+	for i := range result {
+		result[i] = &interfaces.HistogramBucket{
+			Lower: lower + width*float64(i),
+			Upper: lower + width*float64(i+1),
+		}
+	}
+	result[buckets-1].Upper = upper
+
+	for _, f := range numbers {
+		i := int((f - lower) / width)
+		if i >= buckets {
+			i = buckets - 1
+		}
+		result[i].Count++
+	}
+	return result
+}
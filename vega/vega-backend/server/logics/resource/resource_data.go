@@ -0,0 +1,320 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	attr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	oerrors "vega-backend/errors"
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors"
+	"vega-backend/logics/connectors/factory"
+)
+
+// Preview reads the first rows of a Resource from its data source.
+func (rs *resourceService) Preview(ctx context.Context, resource *interfaces.Resource,
+	limit int) (*interfaces.ResourcePreview, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Preview resource")
+	defer span.End()
+
+	span.SetAttributes(
+		attr.Key("resource_id").String(resource.ID),
+		attr.Key("limit").Int(limit))
+
+	result, err := rs.readRows(ctx, resource, limit)
+	if err != nil {
+		span.SetStatus(codes.Error, "Read resource data failed")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return &interfaces.ResourcePreview{
+		ResourceID: resource.ID,
+		Columns:    result.Columns,
+		Rows:       result.Rows,
+		Total:      result.Total,
+	}, nil
+}
+
+// GetProfile returns the cached data profile of a Resource, or computes it on a sample of rows.
+func (rs *resourceService) GetProfile(ctx context.Context, resource *interfaces.Resource,
+	params interfaces.ResourceProfileParams) (*interfaces.ResourceProfile, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Get resource profile")
+	defer span.End()
+
+	span.SetAttributes(
+		attr.Key("resource_id").String(resource.ID),
+		attr.Key("sample_size").Int(params.SampleSize),
+		attr.Key("refresh").Bool(params.Refresh))
+
+	// 采样行数相同的缓存可直接返回
+	if !params.Refresh {
+		cached, err := rs.rpa.GetByResourceID(ctx, resource.ID)
+		if err != nil {
+			span.SetStatus(codes.Error, "Get resource profile failed")
+			return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Resource_InternalError_GetFailed).
+				WithErrorDetails(err.Error())
+		}
+		if cached != nil && cached.SampleSize == params.SampleSize {
+			cached.Cached = true
+			span.SetStatus(codes.Ok, "")
+			return cached, nil
+		}
+	}
+
+	result, err := rs.readRows(ctx, resource, params.SampleSize)
+	if err != nil {
+		span.SetStatus(codes.Error, "Read resource data failed")
+		return nil, err
+	}
+
+	profile := &interfaces.ResourceProfile{
+		ResourceID:  resource.ID,
+		SampleSize:  params.SampleSize,
+		RowCount:    int64(len(result.Rows)),
+		Columns:     profileColumns(resource.SchemaDefinition, result),
+		ProfileTime: time.Now().UnixMilli(),
+	}
+
+	// 缓存失败不影响本次返回
+	if err := rs.rpa.Upsert(ctx, profile); err != nil {
+		logger.Warnf("Failed to cache profile of resource %s: %v", resource.ID, err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return profile, nil
+}
+
+// QueryMetric runs an instant query, or a range query when start and end are given, on a metric Resource.
+func (rs *resourceService) QueryMetric(ctx context.Context, resource *interfaces.Resource,
+	params interfaces.ResourceMetricQueryParams) (*interfaces.ResourceMetricData, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "Query resource metric")
+	defer span.End()
+
+	span.SetAttributes(
+		attr.Key("resource_id").String(resource.ID),
+		attr.Key("time").Int64(params.Time),
+		attr.Key("start").Int64(params.Start),
+		attr.Key("end").Int64(params.End),
+		attr.Key("step").String(params.Step.String()))
+
+	if resource.Category != interfaces.ResourceCategoryMetric {
+		span.SetStatus(codes.Error, "Resource is not a metric")
+		return nil, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_MetricQueryNotSupported).
+			WithErrorDetails(fmt.Sprintf("resource category %s does not support metric query", resource.Category))
+	}
+
+	connector, catalog, err := rs.openConnector(ctx, resource, oerrors.VegaManager_Resource_MetricQueryNotSupported)
+	if err != nil {
+		span.SetStatus(codes.Error, "Open connector failed")
+		return nil, err
+	}
+	defer connector.Close(ctx)
+
+	mc, ok := connector.(connectors.MetricConnector)
+	if !ok {
+		span.SetStatus(codes.Error, "Connector is not a metric connector")
+		return nil, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_MetricQueryNotSupported).
+			WithErrorDetails(fmt.Sprintf("connector %s can not query metrics", catalog.ConnectorType))
+	}
+
+	var results []*interfaces.MetricResult
+	if params.Start > 0 && params.End > 0 {
+		results, err = mc.QueryRange(ctx, resource.SourceIdentifier,
+			time.UnixMilli(params.Start), time.UnixMilli(params.End), params.Step)
+	} else {
+		var ts time.Time
+		if params.Time > 0 {
+			ts = time.UnixMilli(params.Time)
+		}
+		results, err = mc.QueryInstant(ctx, resource.SourceIdentifier, ts)
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, "Query metric failed")
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Resource_InternalError_ReadFailed).
+			WithErrorDetails(err.Error())
+	}
+	if results == nil {
+		results = []*interfaces.MetricResult{}
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return &interfaces.ResourceMetricData{
+		ResourceID: resource.ID,
+		Results:    results,
+	}, nil
+}
+
+// openConnector 创建并连接资源所属物理 catalog 的 connector，调用方负责关闭
+func (rs *resourceService) openConnector(ctx context.Context, resource *interfaces.Resource,
+	notSupportedCode string) (connectors.Connector, *interfaces.Catalog, error) {
+
+	catalog, err := rs.cs.GetByID(ctx, resource.CatalogID, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if catalog.Type != interfaces.CatalogTypePhysical {
+		return nil, nil, rest.NewHTTPError(ctx, http.StatusBadRequest, notSupportedCode).
+			WithErrorDetails("only resources of physical catalogs support reading data")
+	}
+
+	connector, err := factory.GetFactory().CreateConnectorInstance(ctx, catalog.ConnectorType,
+		interfaces.ConnectorConfig(catalog.ConnectorConfig))
+	if err != nil {
+		return nil, nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Resource_InternalError_ReadFailed).
+			WithErrorDetails(fmt.Sprintf("failed to create connector: %v", err))
+	}
+	if err := connector.Connect(ctx); err != nil {
+		return nil, nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Resource_InternalError_ReadFailed).
+			WithErrorDetails(fmt.Sprintf("failed to connect: %v", err))
+	}
+	return connector, catalog, nil
+}
+
+// readRows 通过资源所属 catalog 的 connector 读取前 limit 行数据，指标资源读取当前时刻的数据点
+func (rs *resourceService) readRows(ctx context.Context, resource *interfaces.Resource,
+	limit int) (*interfaces.QueryResult, error) {
+
+	switch resource.Category {
+	case interfaces.ResourceCategoryTable, interfaces.ResourceCategoryFile,
+		interfaces.ResourceCategoryFileset, interfaces.ResourceCategoryIndex,
+		interfaces.ResourceCategoryMetric:
+	default:
+		return nil, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_PreviewNotSupported).
+			WithErrorDetails(fmt.Sprintf("resource category %s does not support preview", resource.Category))
+	}
+
+	connector, catalog, err := rs.openConnector(ctx, resource, oerrors.VegaManager_Resource_PreviewNotSupported)
+	if err != nil {
+		return nil, err
+	}
+	defer connector.Close(ctx)
+
+	var (
+		result    *interfaces.QueryResult
+		supported = true
+	)
+	switch resource.Category {
+	case interfaces.ResourceCategoryTable:
+		tc, ok := connector.(connectors.TableConnector)
+		if supported = ok; ok {
+			result, err = tc.ExecuteQuery(ctx, previewQuery(catalog.ConnectorType, resource, limit))
+		}
+	case interfaces.ResourceCategoryFile:
+		fc, ok := connector.(connectors.FileConnector)
+		if supported = ok; ok {
+			result, err = fc.ReadFile(ctx, resource.SourceIdentifier, limit)
+		}
+	case interfaces.ResourceCategoryFileset:
+		fc, ok := connector.(connectors.FilesetConnector)
+		if supported = ok; ok {
+			result, err = fc.ReadFileset(ctx, resource.SourceIdentifier, limit)
+		}
+	case interfaces.ResourceCategoryIndex:
+		ic, ok := connector.(connectors.IndexConnector)
+		if supported = ok; ok {
+			result, err = ic.SearchIndex(ctx, resource.SourceIdentifier, limit)
+		}
+	case interfaces.ResourceCategoryMetric:
+		mc, ok := connector.(connectors.MetricConnector)
+		if supported = ok; ok {
+			var series []*interfaces.MetricResult
+			if series, err = mc.QueryInstant(ctx, resource.SourceIdentifier, time.Time{}); err == nil {
+				result = metricRows(series, limit)
+			}
+		}
+	}
+	if !supported {
+		return nil, rest.NewHTTPError(ctx, http.StatusBadRequest, oerrors.VegaManager_Resource_PreviewNotSupported).
+			WithErrorDetails(fmt.Sprintf("connector %s can not read %s resources", catalog.ConnectorType, resource.Category))
+	}
+	if err != nil {
+		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Resource_InternalError_ReadFailed).
+			WithErrorDetails(err.Error())
+	}
+
+	if result == nil {
+		result = &interfaces.QueryResult{}
+	}
+	if result.Columns == nil {
+		result.Columns = []string{}
+	}
+	if result.Rows == nil {
+		result.Rows = []map[string]any{}
+	}
+	// 部分数据源只能读取到数据行，总数不小于返回行数
+	if result.Total < int64(len(result.Rows)) {
+		result.Total = int64(len(result.Rows))
+	}
+	return result, nil
+}
+
+// metricRows 将指标序列展开为 timestamp、value 及标签列的数据行，每个数据点一行
+func metricRows(series []*interfaces.MetricResult, limit int) *interfaces.QueryResult {
+	labelSet := map[string]bool{}
+	total := 0
+	for _, s := range series {
+		for name := range s.Labels {
+			labelSet[name] = true
+		}
+		total += len(s.Values)
+	}
+	labels := make([]string, 0, len(labelSet))
+	for name := range labelSet {
+		labels = append(labels, name)
+	}
+	sort.Strings(labels)
+
+	result := &interfaces.QueryResult{
+		Columns: append([]string{"timestamp", "value"}, labels...),
+		Rows:    []map[string]any{},
+		Total:   int64(total),
+	}
+	for _, s := range series {
+		for _, v := range s.Values {
+			if len(result.Rows) >= limit {
+				return result
+			}
+			row := map[string]any{"timestamp": v.Timestamp, "value": v.Value}
+			for name, value := range s.Labels {
+				row[name] = value
+			}
+			result.Rows = append(result.Rows, row)
+		}
+	}
+	return result
+}
+
+// previewQuery 构建读取表前 limit 行的 SQL，MySQL 使用反引号引用标识符，其他数据源使用双引号
+func previewQuery(connectorType string, resource *interfaces.Resource, limit int) string {
+	parts := []string{resource.SourceIdentifier}
+	if resource.Database != "" && strings.HasPrefix(resource.SourceIdentifier, resource.Database+".") {
+		parts = []string{resource.Database, strings.TrimPrefix(resource.SourceIdentifier, resource.Database+".")}
+	}
+
+	quote := `"`
+	if connectorType == "mysql" {
+		quote = "`"
+	}
+	for i, part := range parts {
+		parts[i] = quote + strings.ReplaceAll(part, quote, quote+quote) + quote
+	}
+	return fmt.Sprintf("SELECT * FROM %s LIMIT %d", strings.Join(parts, "."), limit)
+}
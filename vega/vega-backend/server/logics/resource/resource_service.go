@@ -21,9 +21,13 @@ import (
 	"go.opentelemetry.io/otel/codes"
 
 	"vega-backend/common"
+	kafkaAccess "vega-backend/drivenadapters/kafka"
 	resourceAccess "vega-backend/drivenadapters/resource"
+	profileAccess "vega-backend/drivenadapters/resource_profile"
+	schemaHistoryAccess "vega-backend/drivenadapters/resource_schema_history"
 	oerrors "vega-backend/errors"
 	"vega-backend/interfaces"
+	"vega-backend/logics/catalog"
 )
 
 var (
@@ -34,6 +38,12 @@ var (
 type resourceService struct {
 	appSetting *common.AppSetting
 	ra         interfaces.ResourceAccess
+	rsha       interfaces.ResourceSchemaHistoryAccess
+	rpa        interfaces.ResourceProfileAccess
+	ka         interfaces.KafkaAccess
+	cs         interfaces.CatalogService
+
+	topicOnce sync.Once
 }
 
 // NewResourceService creates a new ResourceService.
@@ -42,6 +52,10 @@ func NewResourceService(appSetting *common.AppSetting) interfaces.ResourceServic
 		rService = &resourceService{
 			appSetting: appSetting,
 			ra:         resourceAccess.NewResourceAccess(appSetting),
+			rsha:       schemaHistoryAccess.NewResourceSchemaHistoryAccess(appSetting),
+			rpa:        profileAccess.NewResourceProfileAccess(appSetting),
+			ka:         kafkaAccess.NewKafkaAccess(appSetting),
+			cs:         catalog.NewCatalogService(appSetting),
 		}
 	})
 	return rService
@@ -256,6 +270,12 @@ func (rs *resourceService) DeleteByIDs(ctx context.Context, ids []string) error
 		return rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Resource_InternalError_DeleteFailed).
 			WithErrorDetails(err.Error())
 	}
+	if err := rs.rsha.DeleteByResourceIDs(ctx, ids); err != nil {
+		logger.Errorf("Delete schema history of resources %v failed: %v", ids, err)
+	}
+	if err := rs.rpa.DeleteByResourceIDs(ctx, ids); err != nil {
+		logger.Errorf("Delete profile of resources %v failed: %v", ids, err)
+	}
 
 	span.SetStatus(codes.Ok, "")
 	return nil
@@ -293,17 +313,32 @@ func (rs *resourceService) CheckExistByName(ctx context.Context, catalogID strin
 	return resource != nil, nil
 }
 
-// UpdateResource updates a Resource directly.
+// UpdateResource updates a Resource directly, and records schema changes against the stored schema.
 func (rs *resourceService) UpdateResource(ctx context.Context, resource *interfaces.Resource) error {
 	ctx, span := ar_trace.Tracer.Start(ctx, "Update resource")
 	defer span.End()
 
+	// 更新前读取库中的 schema 作为上次快照
+	previous, err := rs.ra.GetByID(ctx, resource.ID)
+	if err != nil {
+		span.SetStatus(codes.Error, "Get resource failed")
+		return rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Resource_InternalError_GetFailed).
+			WithErrorDetails(err.Error())
+	}
+
 	if err := rs.ra.Update(ctx, resource); err != nil {
 		span.SetStatus(codes.Error, "Update resource failed")
 		return rest.NewHTTPError(ctx, http.StatusInternalServerError, oerrors.VegaManager_Resource_InternalError_UpdateFailed).
 			WithErrorDetails(err.Error())
 	}
 
+	// schema 变更记录失败不影响资源更新
+	if previous != nil {
+		if err := rs.recordSchemaChanges(ctx, previous, resource); err != nil {
+			logger.Errorf("Failed to record schema changes for resource %s: %v", resource.ID, err)
+		}
+	}
+
 	span.SetStatus(codes.Ok, "")
 	return nil
 }
@@ -0,0 +1,244 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"github.com/rs/xid"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
+
+	oerrors "vega-backend/errors"
+	"vega-backend/interfaces"
+)
+
+const (
+	// 变更摘要的最大长度，与表字段长度一致
+	maxChangeSummaryLength = 1000
+)
+
+// diffSchema 按字段名比较新旧 schema，返回新增、删除和类型变更的字段
+func diffSchema(previous, current []interfaces.Property) []*interfaces.SchemaFieldChange {
+	currentTypes := make(map[string]string, len(current))
+	for _, p := range current {
+		currentTypes[p.Name] = p.Type
+	}
+	previousTypes := make(map[string]string, len(previous))
+	for _, p := range previous {
+		previousTypes[p.Name] = p.Type
+	}
+
+	changes := []*interfaces.SchemaFieldChange{}
+	for _, p := range previous {
+		newType, ok := currentTypes[p.Name]
+		switch {
+		case !ok:
+			changes = append(changes, &interfaces.SchemaFieldChange{
+				ChangeType: interfaces.SchemaChangeFieldRemoved,
+				FieldName:  p.Name,
+				OldType:    p.Type,
+				Breaking:   true,
+			})
+		case newType != p.Type:
+			changes = append(changes, &interfaces.SchemaFieldChange{
+				ChangeType: interfaces.SchemaChangeTypeChanged,
+				FieldName:  p.Name,
+				OldType:    p.Type,
+				NewType:    newType,
+				Breaking:   true,
+			})
+		}
+	}
+	for _, p := range current {
+		if _, ok := previousTypes[p.Name]; !ok {
+			changes = append(changes, &interfaces.SchemaFieldChange{
+				ChangeType: interfaces.SchemaChangeFieldAdded,
+				FieldName:  p.Name,
+				NewType:    p.Type,
+			})
+		}
+	}
+	return changes
+}
+
+// summarizeChanges 返回一个版本的变更类型和变更摘要
+func summarizeChanges(changes []*interfaces.SchemaFieldChange) (string, string) {
+	changeType := changes[0].ChangeType
+	var added, removed, typeChanged []string
+	for _, change := range changes {
+		if change.ChangeType != changeType {
+			changeType = interfaces.SchemaChangeFieldModified
+		}
+		switch change.ChangeType {
+		case interfaces.SchemaChangeFieldAdded:
+			added = append(added, change.FieldName)
+		case interfaces.SchemaChangeFieldRemoved:
+			removed = append(removed, change.FieldName)
+		case interfaces.SchemaChangeTypeChanged:
+			typeChanged = append(typeChanged, fmt.Sprintf("%s(%s -> %s)", change.FieldName, change.OldType, change.NewType))
+		}
+	}
+
+	parts := []string{}
+	if len(added) > 0 {
+		parts = append(parts, "added: "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		parts = append(parts, "removed: "+strings.Join(removed, ", "))
+	}
+	if len(typeChanged) > 0 {
+		parts = append(parts, "type changed: "+strings.Join(typeChanged, ", "))
+	}
+	summary := []rune(strings.Join(parts, "; "))
+	if len(summary) > maxChangeSummaryLength {
+		summary = append(summary[:maxChangeSummaryLength-3], []rune("...")...)
+	}
+	return changeType, string(summary)
+}
+
+// isSchemaInferred 文件、文件集和消息主题的 schema 由采样数据推断
+func isSchemaInferred(category string) bool {
+	switch category {
+	case interfaces.ResourceCategoryFile, interfaces.ResourceCategoryFileset, interfaces.ResourceCategoryTopic:
+		return true
+	default:
+		return false
+	}
+}
+
+// recordSchemaChanges 将资源的新 schema 与上次快照比较，生成新的 schema 版本，并在出现破坏性变更时发送事件
+func (rs *resourceService) recordSchemaChanges(ctx context.Context, previous, resource *interfaces.Resource) error {
+	// 未获取到 schema 时不生成快照
+	if len(resource.SchemaDefinition) == 0 {
+		return nil
+	}
+
+	latest, err := rs.rsha.GetLatestByResourceID(ctx, resource.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get latest schema history: %w", err)
+	}
+
+	// 以最近一次快照为基准，尚无快照时以更新前的 schema 为基准
+	baseline := previous.SchemaDefinition
+	version := 1
+	if latest != nil {
+		baseline = latest.SchemaDefinition
+		if v, err := strconv.Atoi(latest.SchemaVersion); err == nil {
+			version = v + 1
+		}
+	}
+
+	history := &interfaces.ResourceSchemaHistory{
+		ID:               xid.New().String(),
+		ResourceID:       resource.ID,
+		SchemaVersion:    strconv.Itoa(version),
+		SchemaDefinition: resource.SchemaDefinition,
+		Changes:          []*interfaces.SchemaFieldChange{},
+		SchemaInferred:   isSchemaInferred(resource.Category),
+		ChangeTime:       time.Now().UnixMilli(),
+	}
+
+	changes := diffSchema(baseline, resource.SchemaDefinition)
+	switch {
+	case len(baseline) == 0 || (latest == nil && len(changes) == 0):
+		// 首个快照
+		history.ChangeType = interfaces.SchemaChangeCreated
+		history.ChangeSummary = fmt.Sprintf("initial schema with %d fields", len(resource.SchemaDefinition))
+	case len(changes) == 0:
+		return nil
+	default:
+		history.Changes = changes
+		history.ChangeType, history.ChangeSummary = summarizeChanges(changes)
+	}
+
+	breaking := []*interfaces.SchemaFieldChange{}
+	for _, change := range history.Changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+	history.Breaking = len(breaking) > 0
+
+	if err := rs.rsha.Create(ctx, history); err != nil {
+		return fmt.Errorf("failed to save schema history: %w", err)
+	}
+	logger.Infof("Recorded schema version %s (%s) for resource %s: %s",
+		history.SchemaVersion, history.ChangeType, resource.ID, history.ChangeSummary)
+
+	// schema 变化后缓存的数据画像不再可信
+	if err := rs.rpa.DeleteByResourceIDs(ctx, []string{resource.ID}); err != nil {
+		logger.Warnf("Failed to invalidate profile of resource %s: %v", resource.ID, err)
+	}
+
+	if !history.Breaking {
+		return nil
+	}
+	return rs.publishSchemaChanges(ctx, resource, history, breaking)
+}
+
+// publishSchemaChanges 发送破坏性 schema 变更事件
+func (rs *resourceService) publishSchemaChanges(ctx context.Context, resource *interfaces.Resource,
+	history *interfaces.ResourceSchemaHistory, changes []*interfaces.SchemaFieldChange) error {
+
+	rs.topicOnce.Do(func() {
+		if err := rs.ka.CreateTopic(ctx, interfaces.ResourceSchemaChangeTopic); err != nil {
+			logger.Warnf("Failed to create topic %s: %v", interfaces.ResourceSchemaChangeTopic, err)
+		}
+	})
+
+	value, err := sonic.Marshal(&interfaces.ResourceSchemaChangeMessage{
+		ResourceID:       resource.ID,
+		ResourceName:     resource.Name,
+		CatalogID:        resource.CatalogID,
+		Category:         resource.Category,
+		SourceIdentifier: resource.SourceIdentifier,
+		SchemaVersion:    history.SchemaVersion,
+		Changes:          changes,
+		ChangeTime:       history.ChangeTime,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema change message: %w", err)
+	}
+
+	w, err := rs.ka.NewWriter(ctx, interfaces.ResourceSchemaChangeTopic)
+	if err != nil {
+		return fmt.Errorf("failed to create kafka writer: %w", err)
+	}
+	defer rs.ka.CloseWriter(w)
+
+	return rs.ka.WriteMessages(ctx, w, kafka.Message{
+		Key:   []byte(resource.ID),
+		Value: value,
+	})
+}
+
+// ListSchemaHistory lists schema history of a Resource.
+func (rs *resourceService) ListSchemaHistory(ctx context.Context,
+	params interfaces.ResourceSchemaHistoryQueryParams) ([]*interfaces.ResourceSchemaHistory, int64, error) {
+
+	ctx, span := ar_trace.Tracer.Start(ctx, "List resource schema history")
+	defer span.End()
+
+	histories, total, err := rs.rsha.List(ctx, params)
+	if err != nil {
+		span.SetStatus(codes.Error, "List resource schema history failed")
+		return []*interfaces.ResourceSchemaHistory{}, 0, rest.NewHTTPError(ctx, http.StatusInternalServerError,
+			oerrors.VegaManager_Resource_InternalError_GetFailed).WithErrorDetails(err.Error())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return histories, total, nil
+}
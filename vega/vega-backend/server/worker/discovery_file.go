@@ -0,0 +1,258 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors"
+)
+
+// fileSource 待发现的文件或文件集，二者只有一个非空
+type fileSource struct {
+	file    *interfaces.FileMeta
+	fileset *interfaces.FilesetMeta
+}
+
+func (s fileSource) identifier() string {
+	if s.fileset != nil {
+		return s.fileset.Path
+	}
+	return s.file.Path
+}
+
+type fileDiscoveryItem struct {
+	resource *interfaces.Resource
+	source   fileSource
+}
+
+// discoverFileResources discovers file and fileset resources from a file connector.
+func (dw *discoveryWorker) discoverFileResources(ctx context.Context,
+	catalog *interfaces.Catalog, connector connectors.Connector) (*interfaces.DiscoveryResult, error) {
+
+	fileConnector, ok := connector.(connectors.FileConnector)
+	if !ok {
+		return nil, fmt.Errorf("connector does not support file discovery")
+	}
+
+	// Step 1: List Files & Filesets
+	files, err := fileConnector.ListFiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	sources := make([]fileSource, 0, len(files))
+	for _, f := range files {
+		sources = append(sources, fileSource{file: f})
+	}
+
+	filesetCount := 0
+	if filesetConnector, ok := connector.(connectors.FilesetConnector); ok {
+		filesets, err := filesetConnector.ListFilesets(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list filesets: %w", err)
+		}
+		for _, fs := range filesets {
+			sources = append(sources, fileSource{fileset: fs})
+		}
+		filesetCount = len(filesets)
+	}
+	logger.Infof("Discovered %d files and %d filesets from source", len(files), filesetCount)
+
+	// Step 2: Get Existing Resources
+	existingResources, err := dw.rs.GetByCatalogID(ctx, catalog.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing resources: %w", err)
+	}
+
+	// Step 3: Reconcile
+	result, items, err := dw.reconcileFileResources(ctx, catalog, sources, existingResources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile resources: %w", err)
+	}
+
+	// Step 4: Enrich（识别格式并推断 schema）
+	if err := dw.enrichFileMetadata(ctx, fileConnector, items); err != nil {
+		return nil, fmt.Errorf("failed to enrich file metadata: %w", err)
+	}
+
+	logger.Infof("Discovery completed for catalog %s: new=%d, stale=%d, unchanged=%d",
+		catalog.ID, result.NewCount, result.StaleCount, result.UnchangedCount)
+
+	return result, nil
+}
+
+// reconcileFileResources reconciles source files and filesets with existing resources.
+func (dw *discoveryWorker) reconcileFileResources(ctx context.Context,
+	catalog *interfaces.Catalog, sources []fileSource,
+	existingResources []*interfaces.Resource) (*interfaces.DiscoveryResult, []fileDiscoveryItem, error) {
+
+	result := &interfaces.DiscoveryResult{
+		CatalogID: catalog.ID,
+	}
+
+	var items []fileDiscoveryItem
+
+	existingMap := make(map[string]*interfaces.Resource)
+	for _, r := range existingResources {
+		existingMap[r.SourceIdentifier] = r
+	}
+
+	sourceMap := make(map[string]fileSource)
+	for _, s := range sources {
+		sourceMap[s.identifier()] = s
+	}
+
+	// Handle new and existing
+	for _, source := range sources {
+		sourceIdentifier := source.identifier()
+
+		if resource, ok := existingMap[sourceIdentifier]; ok {
+			if resource.Status == interfaces.ResourceStatusStale {
+				if err := dw.rs.UpdateStatus(ctx, resource.ID, interfaces.ResourceStatusActive, ""); err != nil {
+					logger.Errorf("Failed to reactivate resource %s: %v", resource.ID, err)
+				}
+			}
+			result.UnchangedCount++
+			items = append(items, fileDiscoveryItem{
+				resource: resource,
+				source:   source,
+			})
+		} else {
+			resource, err := dw.createFileResource(ctx, catalog, source)
+			if err != nil {
+				logger.Errorf("Failed to create resource %s: %v", sourceIdentifier, err)
+			} else {
+				result.NewCount++
+				items = append(items, fileDiscoveryItem{
+					resource: resource,
+					source:   source,
+				})
+			}
+		}
+	}
+
+	// Handle stale
+	for sourceIdentifier, existing := range existingMap {
+		if _, ok := sourceMap[sourceIdentifier]; !ok {
+			if existing.Status != interfaces.ResourceStatusStale {
+				if err := dw.rs.UpdateStatus(ctx, existing.ID, interfaces.ResourceStatusStale, ""); err != nil {
+					logger.Errorf("Failed to mark resource %s as stale: %v", existing.ID, err)
+				} else {
+					result.StaleCount++
+				}
+			}
+		}
+	}
+
+	result.Message = fmt.Sprintf("Discovery completed: %d new, %d stale, %d unchanged",
+		result.NewCount, result.StaleCount, result.UnchangedCount)
+
+	return result, items, nil
+}
+
+// createFileResource creates a new resource for a file or fileset.
+func (dw *discoveryWorker) createFileResource(ctx context.Context, catalog *interfaces.Catalog,
+	source fileSource) (*interfaces.Resource, error) {
+
+	req := &interfaces.ResourceRequest{
+		CatalogID:        catalog.ID,
+		Name:             source.identifier(),
+		Category:         interfaces.ResourceCategoryFile,
+		Status:           interfaces.ResourceStatusActive,
+		SourceIdentifier: source.identifier(),
+	}
+	if source.fileset != nil {
+		req.Category = interfaces.ResourceCategoryFileset
+		// 位于根目录的文件集使用根目录名称
+		if source.fileset.Path == "." {
+			req.Name = source.fileset.Name
+		}
+	}
+	id, err := dw.rs.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return dw.rs.GetByID(ctx, id)
+}
+
+// enrichFileMetadata enriches file and fileset resources with format and inferred schema.
+func (dw *discoveryWorker) enrichFileMetadata(ctx context.Context,
+	fileConnector connectors.FileConnector, items []fileDiscoveryItem) error {
+
+	for _, item := range items {
+		resource := item.resource
+		sourceIdentifier := item.source.identifier()
+
+		var (
+			columns        []interfaces.ColumnMeta
+			sourceMetadata = make(map[string]any)
+		)
+		if resource.SourceMetadata != nil {
+			sourceMetadata = resource.SourceMetadata
+		}
+
+		if fs := item.source.fileset; fs != nil {
+			filesetConnector := fileConnector.(connectors.FilesetConnector)
+			// 单个文件集解析失败不影响其他资源
+			if err := filesetConnector.GetFilesetMeta(ctx, fs); err != nil {
+				logger.Warnf("Failed to get metadata for fileset %s: %v", fs.Path, err)
+				continue
+			}
+			columns = fs.Columns
+			sourceMetadata["format"] = fs.Format
+			sourceMetadata["partition_keys"] = fs.PartitionKeys
+			sourceMetadata["file_count"] = fs.FileCount
+			sourceMetadata["total_size"] = fs.TotalSize
+			sourceMetadata["last_modified"] = fs.LastModified
+			if len(fs.Properties) > 0 {
+				sourceMetadata["properties"] = fs.Properties
+			}
+		} else {
+			file := item.source.file
+			if err := fileConnector.GetFileMeta(ctx, file); err != nil {
+				logger.Warnf("Failed to get metadata for file %s: %v", file.Path, err)
+				continue
+			}
+			columns = file.Columns
+			sourceMetadata["format"] = file.Format
+			sourceMetadata["content_type"] = file.ContentType
+			sourceMetadata["size"] = file.Size
+			sourceMetadata["last_modified"] = file.LastModified
+			if len(file.Properties) > 0 {
+				sourceMetadata["properties"] = file.Properties
+			}
+		}
+
+		resource.SchemaDefinition = []interfaces.Property{}
+		for _, column := range columns {
+			resource.SchemaDefinition = append(resource.SchemaDefinition, interfaces.Property{
+				Name:         column.Name,
+				Type:         column.Type,
+				DisplayName:  column.Name,
+				OriginalName: column.Name,
+				Description:  column.Description,
+			})
+		}
+		if len(columns) > 0 {
+			sourceMetadata["columns"] = columns
+		}
+		resource.SourceMetadata = sourceMetadata
+
+		if err := dw.rs.UpdateResource(ctx, resource); err != nil {
+			logger.Errorf("Failed to update metadata for %s: %v", sourceIdentifier, err)
+			return err
+		}
+
+		logger.Infof("Enriched %s %s: format=%v, columns=%d",
+			resource.Category, sourceIdentifier, sourceMetadata["format"], len(columns))
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors"
+)
+
+// 指标资源的固定字段，标签字段追加在其后
+const (
+	metricFieldTimestamp = "timestamp"
+	metricFieldValue     = "value"
+)
+
+type metricDiscoveryItem struct {
+	resource   *interfaces.Resource
+	metricMeta *interfaces.MetricMeta
+}
+
+// discoverMetricResources discovers metric resources from a metric connector.
+func (dw *discoveryWorker) discoverMetricResources(ctx context.Context,
+	catalog *interfaces.Catalog, connector connectors.Connector) (*interfaces.DiscoveryResult, error) {
+
+	metricConnector, ok := connector.(connectors.MetricConnector)
+	if !ok {
+		return nil, fmt.Errorf("connector does not support metric discovery")
+	}
+
+	// Step 1: List Metrics
+	sourceMetrics, err := metricConnector.ListMetrics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metrics: %w", err)
+	}
+	logger.Infof("Discovered %d metrics from source", len(sourceMetrics))
+
+	// Step 2: Get Existing Resources
+	existingResources, err := dw.rs.GetByCatalogID(ctx, catalog.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing resources: %w", err)
+	}
+
+	// Step 3: Reconcile
+	result, items, err := dw.reconcileMetricResources(ctx, catalog, sourceMetrics, existingResources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile resources: %w", err)
+	}
+
+	// Step 4: Enrich（采样序列获取标签集合）
+	if err := dw.enrichMetricMetadata(ctx, metricConnector, items); err != nil {
+		return nil, fmt.Errorf("failed to enrich metric metadata: %w", err)
+	}
+
+	logger.Infof("Discovery completed for catalog %s: new=%d, stale=%d, unchanged=%d",
+		catalog.ID, result.NewCount, result.StaleCount, result.UnchangedCount)
+
+	return result, nil
+}
+
+// reconcileMetricResources reconciles source metrics with existing resources.
+func (dw *discoveryWorker) reconcileMetricResources(ctx context.Context,
+	catalog *interfaces.Catalog, sourceMetrics []*interfaces.MetricMeta,
+	existingResources []*interfaces.Resource) (*interfaces.DiscoveryResult, []metricDiscoveryItem, error) {
+
+	result := &interfaces.DiscoveryResult{
+		CatalogID: catalog.ID,
+	}
+
+	var items []metricDiscoveryItem
+
+	existingMap := make(map[string]*interfaces.Resource)
+	for _, r := range existingResources {
+		existingMap[r.SourceIdentifier] = r
+	}
+
+	sourceMap := make(map[string]*interfaces.MetricMeta)
+	for _, m := range sourceMetrics {
+		sourceMap[m.Name] = m
+	}
+
+	// Handle new and existing
+	for _, metric := range sourceMetrics {
+		sourceIdentifier := metric.Name
+
+		if resource, ok := existingMap[sourceIdentifier]; ok {
+			if resource.Status == interfaces.ResourceStatusStale {
+				if err := dw.rs.UpdateStatus(ctx, resource.ID, interfaces.ResourceStatusActive, ""); err != nil {
+					logger.Errorf("Failed to reactivate resource %s: %v", resource.ID, err)
+				}
+			}
+			result.UnchangedCount++
+			items = append(items, metricDiscoveryItem{
+				resource:   resource,
+				metricMeta: metric,
+			})
+		} else {
+			resource, err := dw.createMetricResource(ctx, catalog, metric)
+			if err != nil {
+				logger.Errorf("Failed to create resource %s: %v", sourceIdentifier, err)
+			} else {
+				result.NewCount++
+				items = append(items, metricDiscoveryItem{
+					resource:   resource,
+					metricMeta: metric,
+				})
+			}
+		}
+	}
+
+	// Handle stale
+	for sourceIdentifier, existing := range existingMap {
+		if _, ok := sourceMap[sourceIdentifier]; !ok {
+			if existing.Status != interfaces.ResourceStatusStale {
+				if err := dw.rs.UpdateStatus(ctx, existing.ID, interfaces.ResourceStatusStale, ""); err != nil {
+					logger.Errorf("Failed to mark resource %s as stale: %v", existing.ID, err)
+				} else {
+					result.StaleCount++
+				}
+			}
+		}
+	}
+
+	result.Message = fmt.Sprintf("Discovery completed: %d new, %d stale, %d unchanged",
+		result.NewCount, result.StaleCount, result.UnchangedCount)
+
+	return result, items, nil
+}
+
+// createMetricResource creates a new resource for a metric.
+func (dw *discoveryWorker) createMetricResource(ctx context.Context, catalog *interfaces.Catalog,
+	metric *interfaces.MetricMeta) (*interfaces.Resource, error) {
+
+	req := &interfaces.ResourceRequest{
+		CatalogID:        catalog.ID,
+		Name:             metric.Name,
+		Description:      metric.Help,
+		Category:         interfaces.ResourceCategoryMetric,
+		Status:           interfaces.ResourceStatusActive,
+		SourceIdentifier: metric.Name,
+	}
+	id, err := dw.rs.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return dw.rs.GetByID(ctx, id)
+}
+
+// enrichMetricMetadata enriches metric resources with label sets and series count.
+func (dw *discoveryWorker) enrichMetricMetadata(ctx context.Context,
+	metricConnector connectors.MetricConnector, items []metricDiscoveryItem) error {
+
+	for _, item := range items {
+		metric := item.metricMeta
+		resource := item.resource
+
+		if err := metricConnector.GetMetricMeta(ctx, metric); err != nil {
+			logger.Warnf("Failed to get metadata for metric %s: %v", metric.Name, err)
+			return err
+		}
+
+		// 时序数据统一建模为 timestamp + value，标签作为字符串维度
+		resource.SchemaDefinition = []interfaces.Property{
+			{Name: metricFieldTimestamp, Type: "datetime", DisplayName: metricFieldTimestamp, OriginalName: metricFieldTimestamp},
+			{Name: metricFieldValue, Type: "float", DisplayName: metricFieldValue, OriginalName: metricFieldValue},
+		}
+		for _, label := range metric.Labels {
+			if label.Name == metricFieldTimestamp || label.Name == metricFieldValue {
+				logger.Warnf("Label %s of metric %s conflicts with a built-in field, skipped", label.Name, metric.Name)
+				continue
+			}
+			resource.SchemaDefinition = append(resource.SchemaDefinition, interfaces.Property{
+				Name:         label.Name,
+				Type:         "string",
+				DisplayName:  label.Name,
+				OriginalName: label.Name,
+				Description:  "",
+			})
+		}
+
+		sourceMetadata := make(map[string]any)
+		if resource.SourceMetadata != nil {
+			sourceMetadata = resource.SourceMetadata
+		}
+		sourceMetadata["metric_type"] = metric.Type
+		sourceMetadata["help"] = metric.Help
+		sourceMetadata["unit"] = metric.Unit
+		sourceMetadata["series_count"] = metric.SeriesCount
+		sourceMetadata["labels"] = metric.Labels
+		resource.SourceMetadata = sourceMetadata
+
+		if err := dw.rs.UpdateResource(ctx, resource); err != nil {
+			logger.Errorf("Failed to update metadata for metric %s: %v", metric.Name, err)
+			return err
+		}
+
+		logger.Infof("Enriched metric %s: type=%s, series=%d, labels=%d",
+			metric.Name, metric.Type, metric.SeriesCount, len(metric.Labels))
+	}
+	return nil
+}
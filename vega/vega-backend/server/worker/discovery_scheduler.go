@@ -0,0 +1,48 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+)
+
+const (
+	// 定时发现计划的检查周期
+	discoveryScheduleCheckInterval = 30 * time.Second
+)
+
+// startScheduler periodically creates discovery tasks for due discovery schedules.
+func (dw *discoveryWorker) startScheduler() {
+	go func() {
+		ticker := time.NewTicker(discoveryScheduleCheckInterval)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			dw.triggerSchedules(context.Background(), now)
+		}
+	}()
+}
+
+// triggerSchedules triggers due discovery schedules once.
+func (dw *discoveryWorker) triggerSchedules(ctx context.Context, now time.Time) {
+	defer func() {
+		if err := recover(); err != nil {
+			logger.Errorf("Discovery scheduler failed: %v", err)
+		}
+	}()
+
+	count, err := dw.dss.TriggerDue(ctx, now)
+	if err != nil {
+		logger.Errorf("Failed to trigger discovery schedules: %v", err)
+		return
+	}
+	if count > 0 {
+		logger.Infof("Triggered %d scheduled discovery tasks", count)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+
+	"vega-backend/interfaces"
+	"vega-backend/logics/connectors"
+)
+
+type topicDiscoveryItem struct {
+	resource  *interfaces.Resource
+	topicMeta *interfaces.TopicMeta
+}
+
+// discoverTopicResources discovers topic resources from a topic connector.
+func (dw *discoveryWorker) discoverTopicResources(ctx context.Context,
+	catalog *interfaces.Catalog, connector connectors.Connector) (*interfaces.DiscoveryResult, error) {
+
+	topicConnector, ok := connector.(connectors.TopicConnector)
+	if !ok {
+		return nil, fmt.Errorf("connector does not support topic discovery")
+	}
+
+	// Step 1: List Topics
+	sourceTopics, err := topicConnector.ListTopics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topics: %w", err)
+	}
+	logger.Infof("Discovered %d topics from source", len(sourceTopics))
+
+	// Step 2: Get Existing Resources
+	existingResources, err := dw.rs.GetByCatalogID(ctx, catalog.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing resources: %w", err)
+	}
+
+	// Step 3: Reconcile
+	result, items, err := dw.reconcileTopicResources(ctx, catalog, sourceTopics, existingResources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile resources: %w", err)
+	}
+
+	// Step 4: Enrich（采样推断 schema、统计积压）
+	if err := dw.enrichTopicMetadata(ctx, topicConnector, items); err != nil {
+		return nil, fmt.Errorf("failed to enrich topic metadata: %w", err)
+	}
+
+	logger.Infof("Discovery completed for catalog %s: new=%d, stale=%d, unchanged=%d",
+		catalog.ID, result.NewCount, result.StaleCount, result.UnchangedCount)
+
+	return result, nil
+}
+
+// reconcileTopicResources reconciles source topics with existing resources.
+func (dw *discoveryWorker) reconcileTopicResources(ctx context.Context,
+	catalog *interfaces.Catalog, sourceTopics []*interfaces.TopicMeta,
+	existingResources []*interfaces.Resource) (*interfaces.DiscoveryResult, []topicDiscoveryItem, error) {
+
+	result := &interfaces.DiscoveryResult{
+		CatalogID: catalog.ID,
+	}
+
+	var items []topicDiscoveryItem
+
+	existingMap := make(map[string]*interfaces.Resource)
+	for _, r := range existingResources {
+		existingMap[r.SourceIdentifier] = r
+	}
+
+	sourceMap := make(map[string]*interfaces.TopicMeta)
+	for _, t := range sourceTopics {
+		sourceMap[t.Name] = t
+	}
+
+	// Handle new and existing
+	for _, topic := range sourceTopics {
+		sourceIdentifier := topic.Name
+
+		if resource, ok := existingMap[sourceIdentifier]; ok {
+			if resource.Status == interfaces.ResourceStatusStale {
+				if err := dw.rs.UpdateStatus(ctx, resource.ID, interfaces.ResourceStatusActive, ""); err != nil {
+					logger.Errorf("Failed to reactivate resource %s: %v", resource.ID, err)
+				}
+			}
+			result.UnchangedCount++
+			items = append(items, topicDiscoveryItem{
+				resource:  resource,
+				topicMeta: topic,
+			})
+		} else {
+			resource, err := dw.createTopicResource(ctx, catalog, topic)
+			if err != nil {
+				logger.Errorf("Failed to create resource %s: %v", sourceIdentifier, err)
+			} else {
+				result.NewCount++
+				items = append(items, topicDiscoveryItem{
+					resource:  resource,
+					topicMeta: topic,
+				})
+			}
+		}
+	}
+
+	// Handle stale
+	for sourceIdentifier, existing := range existingMap {
+		if _, ok := sourceMap[sourceIdentifier]; !ok {
+			if existing.Status != interfaces.ResourceStatusStale {
+				if err := dw.rs.UpdateStatus(ctx, existing.ID, interfaces.ResourceStatusStale, ""); err != nil {
+					logger.Errorf("Failed to mark resource %s as stale: %v", existing.ID, err)
+				} else {
+					result.StaleCount++
+				}
+			}
+		}
+	}
+
+	result.Message = fmt.Sprintf("Discovery completed: %d new, %d stale, %d unchanged",
+		result.NewCount, result.StaleCount, result.UnchangedCount)
+
+	return result, items, nil
+}
+
+// createTopicResource creates a new resource for a topic.
+func (dw *discoveryWorker) createTopicResource(ctx context.Context, catalog *interfaces.Catalog,
+	topic *interfaces.TopicMeta) (*interfaces.Resource, error) {
+
+	req := &interfaces.ResourceRequest{
+		CatalogID:        catalog.ID,
+		Name:             topic.Name,
+		Category:         interfaces.ResourceCategoryTopic,
+		Status:           interfaces.ResourceStatusActive,
+		SourceIdentifier: topic.Name,
+	}
+	id, err := dw.rs.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return dw.rs.GetByID(ctx, id)
+}
+
+// enrichTopicMetadata enriches topic resources with sampled schema and consumer lag.
+func (dw *discoveryWorker) enrichTopicMetadata(ctx context.Context,
+	topicConnector connectors.TopicConnector, items []topicDiscoveryItem) error {
+
+	for _, item := range items {
+		topic := item.topicMeta
+		resource := item.resource
+
+		if err := topicConnector.GetTopicMeta(ctx, topic); err != nil {
+			logger.Warnf("Failed to get metadata for topic %s: %v", topic.Name, err)
+			return err
+		}
+
+		// 采样未取到消息时保留上一次推断的 schema，避免空 topic 覆盖已有定义
+		if len(topic.Fields) > 0 {
+			resource.SchemaDefinition = []interfaces.Property{}
+			for _, field := range topic.Fields {
+				resource.SchemaDefinition = append(resource.SchemaDefinition, interfaces.Property{
+					Name:         field.Name,
+					Type:         field.Type,
+					DisplayName:  field.Name,
+					OriginalName: field.Name,
+					Description:  "",
+				})
+			}
+		}
+
+		sourceMetadata := make(map[string]any)
+		if resource.SourceMetadata != nil {
+			sourceMetadata = resource.SourceMetadata
+		}
+		sourceMetadata["partitions"] = topic.Partitions
+		sourceMetadata["replicas"] = topic.Replicas
+		if len(topic.Properties) > 0 {
+			sourceMetadata["properties"] = topic.Properties
+		}
+		if len(topic.Fields) > 0 {
+			sourceMetadata["fields"] = topic.Fields
+		}
+		sourceMetadata["consumer_groups"] = topic.ConsumerGroups
+		resource.SourceMetadata = sourceMetadata
+
+		if err := dw.rs.UpdateResource(ctx, resource); err != nil {
+			logger.Errorf("Failed to update metadata for topic %s: %v", topic.Name, err)
+			return err
+		}
+
+		logger.Infof("Enriched topic %s: partitions=%d, fields=%d, consumer_groups=%d",
+			topic.Name, topic.Partitions, len(topic.Fields), len(topic.ConsumerGroups))
+	}
+	return nil
+}
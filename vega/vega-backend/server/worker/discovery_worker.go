@@ -22,6 +22,7 @@ import (
 	logicsCatalog "vega-backend/logics/catalog"
 	"vega-backend/logics/connectors"
 	"vega-backend/logics/connectors/factory"
+	"vega-backend/logics/discovery_schedule"
 	"vega-backend/logics/discovery_task"
 	"vega-backend/logics/resource"
 )
@@ -38,6 +39,7 @@ type discoveryWorker struct {
 	rs         interfaces.ResourceService
 	cs         interfaces.CatalogService
 	dts        interfaces.DiscoveryTaskService
+	dss        interfaces.DiscoveryScheduleService
 }
 
 // NewDiscoveryWorker creates or returns the singleton DiscoveryWorker.
@@ -49,6 +51,7 @@ func NewDiscoveryWorker(appSetting *common.AppSetting) interfaces.DiscoveryWorke
 			rs:         resource.NewResourceService(appSetting),
 			cs:         logicsCatalog.NewCatalogService(appSetting),
 			dts:        discovery_task.NewDiscoveryTaskService(appSetting),
+			dss:        discovery_schedule.NewDiscoveryScheduleService(appSetting),
 		}
 	})
 	return dWorker
@@ -64,6 +67,9 @@ func (dw *discoveryWorker) Start() {
 			time.Sleep(1 * time.Second)
 		}
 	}()
+
+	// Start discovery scheduler
+	dw.startScheduler()
 }
 
 func (dw *discoveryWorker) Run(ctx context.Context) error {
@@ -169,6 +175,10 @@ func (dw *discoveryWorker) discoverCatalog(ctx context.Context,
 		return dw.discoverTableResources(ctx, catalog, connector)
 	case interfaces.ConnectorCategoryIndex:
 		return dw.discoverIndexResources(ctx, catalog, connector)
+	case interfaces.ConnectorCategoryTopic:
+		return dw.discoverTopicResources(ctx, catalog, connector)
+	case interfaces.ConnectorCategoryMetric:
+		return dw.discoverMetricResources(ctx, catalog, connector)
 	case interfaces.ConnectorCategoryFile, interfaces.ConnectorCategoryFileset:
 		return dw.discoverFileResources(ctx, catalog, connector)
 	default:
@@ -176,13 +186,6 @@ func (dw *discoveryWorker) discoverCatalog(ctx context.Context,
 	}
 }
 
-// discoverFileResources discovers file resources from a file connector.
-func (dw *discoveryWorker) discoverFileResources(ctx context.Context,
-	catalog *interfaces.Catalog, connector connectors.Connector) (*interfaces.DiscoveryResult, error) {
-	// TODO: 实现文件资源发现逻辑
-	return nil, fmt.Errorf("file resource discovery not implemented yet")
-}
-
 // createAndConnectConnector creates and connects a connector for the catalog.
 func (dw *discoveryWorker) createAndConnectConnector(ctx context.Context,
 	catalog *interfaces.Catalog) (connectors.Connector, error) {
@@ -0,0 +1,87 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package kafka
+
+import (
+	"vega-backend-tests/at/catalog/helpers"
+	"vega-backend-tests/at/setup"
+)
+
+// KafkaPayloadBuilder Kafka catalog payload构建器
+type KafkaPayloadBuilder struct {
+	config     setup.KafkaConfig
+	testConfig *setup.TestConfig
+}
+
+// NewKafkaPayloadBuilder 创建Kafka payload构建器
+func NewKafkaPayloadBuilder(config setup.KafkaConfig) *KafkaPayloadBuilder {
+	return &KafkaPayloadBuilder{config: config}
+}
+
+// SetTestConfig 设置测试配置（包含加密器）
+func (b *KafkaPayloadBuilder) SetTestConfig(tc *setup.TestConfig) {
+	b.testConfig = tc
+}
+
+// encryptPassword 加密密码
+func (b *KafkaPayloadBuilder) encryptPassword(password string) string {
+	if b.testConfig != nil {
+		return b.testConfig.EncryptString(password)
+	}
+	return password
+}
+
+// GetConnectorType 返回connector类型
+func (b *KafkaPayloadBuilder) GetConnectorType() string {
+	return "kafka"
+}
+
+// BuildCreatePayload 构建基本的Kafka catalog创建payload
+func (b *KafkaPayloadBuilder) BuildCreatePayload() map[string]any {
+	connectorConfig := map[string]any{
+		"brokers": b.config.Brokers,
+	}
+	if b.config.Username != "" {
+		connectorConfig["username"] = b.config.Username
+		connectorConfig["password"] = b.encryptPassword(b.config.Password)
+		connectorConfig["sasl_mechanism"] = b.config.SASLMechanism
+	}
+
+	return map[string]any{
+		"name":             helpers.GenerateUniqueName("test-kafka-catalog"),
+		"connector_type":   "kafka",
+		"connector_config": connectorConfig,
+	}
+}
+
+// BuildCreatePayloadWithTopicPattern 构建指定topic_pattern的Kafka catalog payload
+func (b *KafkaPayloadBuilder) BuildCreatePayloadWithTopicPattern(pattern string) map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	connectorConfig["topic_pattern"] = pattern
+	return payload
+}
+
+// BuildCreatePayloadWithSampleSize 构建指定采样条数的Kafka catalog payload
+func (b *KafkaPayloadBuilder) BuildCreatePayloadWithSampleSize(sampleSize int) map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	connectorConfig["sample_size"] = sampleSize
+	return payload
+}
+
+// BuildCreatePayloadWithBrokers 构建指定broker列表的Kafka catalog payload
+func (b *KafkaPayloadBuilder) BuildCreatePayloadWithBrokers(brokers []string) map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	connectorConfig["brokers"] = brokers
+	return payload
+}
+
+// GetConfig 返回Kafka配置（供测试中直接使用）
+func (b *KafkaPayloadBuilder) GetConfig() setup.KafkaConfig {
+	return b.config
+}
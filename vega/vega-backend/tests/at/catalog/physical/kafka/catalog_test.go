@@ -0,0 +1,203 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package kafka
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	cataloghelpers "vega-backend-tests/at/catalog/helpers"
+	"vega-backend-tests/at/setup"
+	"vega-backend-tests/testutil"
+)
+
+// TestKafkaCatalogCreate Kafka Catalog创建AT测试
+// 编号规则：KF1xx
+func TestKafkaCatalogCreate(t *testing.T) {
+	var (
+		config  *setup.TestConfig
+		client  *testutil.HTTPClient
+		builder *KafkaPayloadBuilder
+	)
+
+	Convey("Kafka Catalog创建AT测试 - 初始化", t, func() {
+		var err error
+		config, err = setup.LoadTestConfig()
+		So(err, ShouldBeNil)
+		So(config, ShouldNotBeNil)
+		So(config.TargetKafka.Brokers, ShouldNotBeEmpty)
+
+		client = testutil.NewHTTPClient(config.VegaManager.BaseURL)
+		err = client.CheckHealth()
+		So(err, ShouldBeNil)
+		t.Logf("✓ AT测试环境就绪，VEGA Manager: %s", config.VegaManager.BaseURL)
+
+		builder = NewKafkaPayloadBuilder(config.TargetKafka)
+		builder.SetTestConfig(config)
+
+		cataloghelpers.CleanupCatalogs(client, t)
+
+		// ========== 正向测试（KF101-KF107） ==========
+
+		Convey("KF101: 创建Kafka catalog - 基本场景", func() {
+			payload := builder.BuildCreatePayload()
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+			So(resp.Body["id"], ShouldNotBeEmpty)
+		})
+
+		Convey("KF102: 创建后验证connector_type为kafka", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			getResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID)
+			catalog := cataloghelpers.ExtractFromEntriesResponse(getResp)
+			So(catalog["connector_type"], ShouldEqual, "kafka")
+			So(catalog["type"], ShouldEqual, cataloghelpers.CatalogTypePhysical)
+		})
+
+		Convey("KF103: 创建指定topic_pattern的Kafka catalog", func() {
+			payload := builder.BuildCreatePayloadWithTopicPattern(builder.GetConfig().Topic + "*")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("KF104: 创建指定sample_size的Kafka catalog", func() {
+			payload := builder.BuildCreatePayloadWithSampleSize(20)
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("KF105: Kafka连接测试成功", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			testResp := client.POST("/api/vega-backend/v1/catalogs/"+catalogID+"/test-connection", nil)
+			So(testResp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("KF106: 获取Kafka catalog健康状态", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			statusResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/health-status")
+			So(statusResp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("KF107: 验证connector_config.password不返回", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			getResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID)
+			So(getResp.StatusCode, ShouldEqual, http.StatusOK)
+
+			catalog := cataloghelpers.ExtractFromEntriesResponse(getResp)
+			if connCfg, ok := catalog["connector_config"].(map[string]any); ok {
+				_, hasPassword := connCfg["password"]
+				So(hasPassword, ShouldBeFalse)
+			}
+		})
+
+		// ========== connector_config负向测试（KF121-KF125） ==========
+
+		Convey("KF121: 缺少brokers字段", func() {
+			payload := builder.BuildCreatePayload()
+			delete(payload["connector_config"].(map[string]any), "brokers")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("KF122: broker地址缺少端口", func() {
+			payload := builder.BuildCreatePayloadWithBrokers([]string{"localhost"})
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("KF123: broker端口超出范围（65536）", func() {
+			payload := builder.BuildCreatePayloadWithBrokers([]string{"localhost:65536"})
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("KF124: 不可达的broker", func() {
+			payload := builder.BuildCreatePayloadWithBrokers([]string{"127.0.0.1:1"})
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("KF125: 不支持的sasl_mechanism", func() {
+			payload := builder.BuildCreatePayload()
+			connectorConfig := payload["connector_config"].(map[string]any)
+			connectorConfig["username"] = "user"
+			connectorConfig["password"] = builder.encryptPassword("password")
+			connectorConfig["sasl_mechanism"] = "GSSAPI"
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+// TestKafkaCatalogDiscover Kafka Catalog资源发现AT测试
+// 编号规则：KF2xx
+func TestKafkaCatalogDiscover(t *testing.T) {
+	Convey("Kafka Catalog资源发现AT测试 - 初始化", t, func() {
+		config, err := setup.LoadTestConfig()
+		So(err, ShouldBeNil)
+		So(config.TargetKafka.Topic, ShouldNotBeEmpty)
+
+		client := testutil.NewHTTPClient(config.VegaManager.BaseURL)
+		So(client.CheckHealth(), ShouldBeNil)
+
+		builder := NewKafkaPayloadBuilder(config.TargetKafka)
+		builder.SetTestConfig(config)
+
+		cataloghelpers.CleanupCatalogs(client, t)
+
+		Convey("KF201: 发现topic并采样推断schema", func() {
+			payload := builder.BuildCreatePayloadWithTopicPattern(builder.GetConfig().Topic)
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+			catalogID := createResp.Body["id"].(string)
+
+			discoverResp := client.POST("/api/vega-backend/v1/catalogs/"+catalogID+"/discover", nil)
+			So(discoverResp.StatusCode, ShouldEqual, http.StatusOK)
+			taskID := discoverResp.Body["id"].(string)
+
+			// 等待发现任务结束
+			status := ""
+			cataloghelpers.WaitForCondition(func() bool {
+				taskResp := client.GET("/api/vega-backend/v1/discovery-tasks/" + taskID)
+				status, _ = taskResp.Body["status"].(string)
+				return status == "completed" || status == "failed"
+			}, 60*time.Second, time.Second)
+			So(status, ShouldEqual, "completed")
+
+			resourcesResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/resources")
+			So(resourcesResp.StatusCode, ShouldEqual, http.StatusOK)
+			resource := cataloghelpers.ExtractFromEntriesResponse(resourcesResp)
+			So(resource, ShouldNotBeNil)
+			So(resource["category"], ShouldEqual, "topic")
+			So(resource["source_identifier"], ShouldEqual, builder.GetConfig().Topic)
+			So(resource["schema_definition"], ShouldNotBeEmpty)
+
+			sourceMetadata, ok := resource["source_metadata"].(map[string]any)
+			So(ok, ShouldBeTrue)
+			So(sourceMetadata["partitions"], ShouldBeGreaterThan, 0)
+			So(sourceMetadata, ShouldContainKey, "consumer_groups")
+		})
+	})
+}
@@ -794,3 +794,153 @@ func TestMySQLSpecificOptions(t *testing.T) {
 
 	_ = ctx
 }
+
+// TestMySQLDiscoverySchedule MySQL Catalog定时发现AT测试
+// 编号规则：MY6xx
+func TestMySQLDiscoverySchedule(t *testing.T) {
+	var (
+		ctx     context.Context
+		config  *setup.TestConfig
+		client  *testutil.HTTPClient
+		builder *MySQLPayloadBuilder
+	)
+
+	Convey("MySQL Catalog定时发现AT测试 - 初始化", t, func() {
+		ctx = context.Background()
+
+		var err error
+		config, err = setup.LoadTestConfig()
+		So(err, ShouldBeNil)
+
+		client = testutil.NewHTTPClient(config.VegaManager.BaseURL)
+		err = client.CheckHealth()
+		So(err, ShouldBeNil)
+
+		builder = NewMySQLPayloadBuilder(config.TargetMySQL)
+		builder.SetTestConfig(config)
+
+		cataloghelpers.CleanupCatalogs(client, t)
+
+		createCatalog := func() string {
+			createResp := client.POST("/api/vega-backend/v1/catalogs", builder.BuildCreatePayload())
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+			return createResp.Body["id"].(string)
+		}
+
+		// ========== 定时发现测试（MY601-MY609） ==========
+
+		Convey("MY601: 设置定时发现计划", func() {
+			catalogID := createCatalog()
+
+			resp := client.PUT("/api/vega-backend/v1/catalogs/"+catalogID+"/discovery-schedule", map[string]any{
+				"cron_expr": "0 2 * * *",
+				"enabled":   true,
+			})
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(resp.Body["catalog_id"], ShouldEqual, catalogID)
+			So(resp.Body["cron_expr"], ShouldEqual, "0 2 * * *")
+			So(resp.Body["next_run_time"], ShouldBeGreaterThan, 0)
+		})
+
+		Convey("MY602: 查询定时发现计划", func() {
+			catalogID := createCatalog()
+			setResp := client.PUT("/api/vega-backend/v1/catalogs/"+catalogID+"/discovery-schedule", map[string]any{
+				"cron_expr": "@daily",
+				"enabled":   true,
+			})
+			So(setResp.StatusCode, ShouldEqual, http.StatusOK)
+
+			resp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/discovery-schedule")
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(resp.Body["cron_expr"], ShouldEqual, "@daily")
+			So(resp.Body["enabled"], ShouldBeTrue)
+		})
+
+		Convey("MY603: 更新定时发现计划为停用", func() {
+			catalogID := createCatalog()
+			setResp := client.PUT("/api/vega-backend/v1/catalogs/"+catalogID+"/discovery-schedule", map[string]any{
+				"cron_expr": "0 2 * * *",
+				"enabled":   true,
+			})
+			So(setResp.StatusCode, ShouldEqual, http.StatusOK)
+
+			resp := client.PUT("/api/vega-backend/v1/catalogs/"+catalogID+"/discovery-schedule", map[string]any{
+				"cron_expr": "30 3 * * 1",
+				"enabled":   false,
+			})
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+
+			getResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/discovery-schedule")
+			So(getResp.StatusCode, ShouldEqual, http.StatusOK)
+			So(getResp.Body["cron_expr"], ShouldEqual, "30 3 * * 1")
+			So(getResp.Body["enabled"], ShouldBeFalse)
+		})
+
+		Convey("MY604: 无效的cron表达式", func() {
+			catalogID := createCatalog()
+
+			resp := client.PUT("/api/vega-backend/v1/catalogs/"+catalogID+"/discovery-schedule", map[string]any{
+				"cron_expr": "invalid",
+				"enabled":   true,
+			})
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("MY605: 触发间隔小于5分钟", func() {
+			catalogID := createCatalog()
+
+			resp := client.PUT("/api/vega-backend/v1/catalogs/"+catalogID+"/discovery-schedule", map[string]any{
+				"cron_expr": "* * * * *",
+				"enabled":   true,
+			})
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("MY606: 未设置定时发现计划时查询", func() {
+			catalogID := createCatalog()
+
+			resp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/discovery-schedule")
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("MY607: 删除定时发现计划", func() {
+			catalogID := createCatalog()
+			setResp := client.PUT("/api/vega-backend/v1/catalogs/"+catalogID+"/discovery-schedule", map[string]any{
+				"cron_expr": "0 2 * * *",
+				"enabled":   true,
+			})
+			So(setResp.StatusCode, ShouldEqual, http.StatusOK)
+
+			resp := client.DELETE("/api/vega-backend/v1/catalogs/" + catalogID + "/discovery-schedule")
+			So(resp.StatusCode, ShouldEqual, http.StatusNoContent)
+
+			getResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/discovery-schedule")
+			So(getResp.StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("MY608: 不存在的catalog设置定时发现计划", func() {
+			resp := client.PUT("/api/vega-backend/v1/catalogs/nonexistent-catalog/discovery-schedule", map[string]any{
+				"cron_expr": "0 2 * * *",
+				"enabled":   true,
+			})
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("MY609: 删除catalog后定时发现计划不可查", func() {
+			catalogID := createCatalog()
+			setResp := client.PUT("/api/vega-backend/v1/catalogs/"+catalogID+"/discovery-schedule", map[string]any{
+				"cron_expr": "0 2 * * *",
+				"enabled":   true,
+			})
+			So(setResp.StatusCode, ShouldEqual, http.StatusOK)
+
+			deleteResp := client.DELETE("/api/vega-backend/v1/catalogs/" + catalogID)
+			So(deleteResp.StatusCode, ShouldEqual, http.StatusNoContent)
+
+			resp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/discovery-schedule")
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+	})
+
+	_ = ctx
+}
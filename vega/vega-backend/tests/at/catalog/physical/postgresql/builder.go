@@ -0,0 +1,108 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package postgresql
+
+import (
+	"fmt"
+	"time"
+
+	"vega-backend-tests/at/catalog/helpers"
+	"vega-backend-tests/at/setup"
+)
+
+// PostgreSQLPayloadBuilder PostgreSQL catalog payload构建器
+type PostgreSQLPayloadBuilder struct {
+	config     setup.PostgreSQLConfig
+	testConfig *setup.TestConfig
+}
+
+// NewPostgreSQLPayloadBuilder 创建PostgreSQL payload构建器
+func NewPostgreSQLPayloadBuilder(config setup.PostgreSQLConfig) *PostgreSQLPayloadBuilder {
+	return &PostgreSQLPayloadBuilder{config: config}
+}
+
+// SetTestConfig 设置测试配置（包含加密器）
+func (b *PostgreSQLPayloadBuilder) SetTestConfig(tc *setup.TestConfig) {
+	b.testConfig = tc
+}
+
+// encryptPassword 加密密码
+func (b *PostgreSQLPayloadBuilder) encryptPassword(password string) string {
+	if b.testConfig != nil {
+		return b.testConfig.EncryptString(password)
+	}
+	return password
+}
+
+// GetConnectorType 返回connector类型
+func (b *PostgreSQLPayloadBuilder) GetConnectorType() string {
+	return "postgresql"
+}
+
+// BuildCreatePayload 构建基本的PostgreSQL catalog创建payload
+func (b *PostgreSQLPayloadBuilder) BuildCreatePayload() map[string]any {
+	return map[string]any{
+		"name":           helpers.GenerateUniqueName("test-postgresql-catalog"),
+		"connector_type": "postgresql",
+		"connector_config": map[string]any{
+			"host":     b.config.Host,
+			"port":     b.config.Port,
+			"database": b.config.Database,
+			"username": b.config.Username,
+			"password": b.encryptPassword(b.config.Password),
+		},
+	}
+}
+
+// BuildCreatePayloadWithSchemas 构建指定schema列表的PostgreSQL catalog payload
+func (b *PostgreSQLPayloadBuilder) BuildCreatePayloadWithSchemas(schemas []string) map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	connectorConfig["schemas"] = schemas
+	return payload
+}
+
+// BuildCreatePayloadWithOptions 构建包含options的PostgreSQL catalog payload
+func (b *PostgreSQLPayloadBuilder) BuildCreatePayloadWithOptions(options map[string]any) map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	connectorConfig["options"] = options
+	return payload
+}
+
+// BuildCreatePayloadWithoutDatabase 构建不含database的PostgreSQL catalog payload（连接默认库postgres）
+func (b *PostgreSQLPayloadBuilder) BuildCreatePayloadWithoutDatabase() map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	delete(connectorConfig, "database")
+	return payload
+}
+
+// BuildCreatePayloadWithWrongCredentials 构建错误凭证的PostgreSQL catalog payload
+func (b *PostgreSQLPayloadBuilder) BuildCreatePayloadWithWrongCredentials() map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	connectorConfig["password"] = b.encryptPassword("wrong_password_123")
+	return payload
+}
+
+// BuildCreatePayloadWithNonExistentDB 构建不存在数据库的PostgreSQL payload
+func (b *PostgreSQLPayloadBuilder) BuildCreatePayloadWithNonExistentDB() map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	connectorConfig["database"] = "nonexistent_db_" + fmt.Sprintf("%d", time.Now().UnixNano())
+	return payload
+}
+
+// BuildCreatePayloadWithNonExistentSchema 构建不存在schema的PostgreSQL payload
+func (b *PostgreSQLPayloadBuilder) BuildCreatePayloadWithNonExistentSchema() map[string]any {
+	return b.BuildCreatePayloadWithSchemas([]string{"nonexistent_schema_" + fmt.Sprintf("%d", time.Now().UnixNano())})
+}
+
+// GetConfig 返回PostgreSQL配置（供测试中直接使用）
+func (b *PostgreSQLPayloadBuilder) GetConfig() setup.PostgreSQLConfig {
+	return b.config
+}
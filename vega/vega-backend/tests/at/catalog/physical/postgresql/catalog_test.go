@@ -0,0 +1,172 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package postgresql
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	cataloghelpers "vega-backend-tests/at/catalog/helpers"
+	"vega-backend-tests/at/setup"
+	"vega-backend-tests/testutil"
+)
+
+// TestPostgreSQLCatalogCreate PostgreSQL Catalog创建AT测试
+// 编号规则：PG1xx
+func TestPostgreSQLCatalogCreate(t *testing.T) {
+	var (
+		config  *setup.TestConfig
+		client  *testutil.HTTPClient
+		builder *PostgreSQLPayloadBuilder
+	)
+
+	Convey("PostgreSQL Catalog创建AT测试 - 初始化", t, func() {
+		var err error
+		config, err = setup.LoadTestConfig()
+		So(err, ShouldBeNil)
+		So(config, ShouldNotBeNil)
+		So(config.TargetPostgreSQL.Host, ShouldNotBeEmpty)
+
+		client = testutil.NewHTTPClient(config.VegaManager.BaseURL)
+		err = client.CheckHealth()
+		So(err, ShouldBeNil)
+		t.Logf("✓ AT测试环境就绪，VEGA Manager: %s", config.VegaManager.BaseURL)
+
+		builder = NewPostgreSQLPayloadBuilder(config.TargetPostgreSQL)
+		builder.SetTestConfig(config)
+
+		cataloghelpers.CleanupCatalogs(client, t)
+
+		// ========== 正向测试（PG101-PG108） ==========
+
+		Convey("PG101: 创建PostgreSQL catalog - 基本场景", func() {
+			payload := builder.BuildCreatePayload()
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+			So(resp.Body["id"], ShouldNotBeEmpty)
+		})
+
+		Convey("PG102: 创建后验证connector_type为postgresql", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			getResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID)
+			catalog := cataloghelpers.ExtractFromEntriesResponse(getResp)
+			So(catalog["connector_type"], ShouldEqual, "postgresql")
+			So(catalog["type"], ShouldEqual, cataloghelpers.CatalogTypePhysical)
+		})
+
+		Convey("PG103: 创建指定schemas的PostgreSQL catalog", func() {
+			payload := builder.BuildCreatePayloadWithSchemas([]string{builder.GetConfig().Schema})
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("PG104: 创建带PostgreSQL特定options（sslmode/connect_timeout）", func() {
+			options := map[string]any{
+				"sslmode":         "disable",
+				"connect_timeout": 10,
+			}
+			payload := builder.BuildCreatePayloadWithOptions(options)
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("PG105: 不指定database（连接默认库postgres）", func() {
+			payload := builder.BuildCreatePayloadWithoutDatabase()
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("PG106: PostgreSQL连接测试成功", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			testResp := client.POST("/api/vega-backend/v1/catalogs/"+catalogID+"/test-connection", nil)
+			So(testResp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("PG107: 获取PostgreSQL catalog健康状态", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			statusResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/health-status")
+			So(statusResp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("PG108: 验证connector_config.password不返回", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			getResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID)
+			So(getResp.StatusCode, ShouldEqual, http.StatusOK)
+
+			catalog := cataloghelpers.ExtractFromEntriesResponse(getResp)
+			if connCfg, ok := catalog["connector_config"].(map[string]any); ok {
+				_, hasPassword := connCfg["password"]
+				So(hasPassword, ShouldBeFalse)
+			}
+		})
+
+		// ========== connector_config负向测试（PG121-PG127） ==========
+
+		Convey("PG121: 缺少host字段", func() {
+			payload := builder.BuildCreatePayload()
+			delete(payload["connector_config"].(map[string]any), "host")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("PG122: 错误密码", func() {
+			payload := builder.BuildCreatePayloadWithWrongCredentials()
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("PG123: 不存在的数据库", func() {
+			payload := builder.BuildCreatePayloadWithNonExistentDB()
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("PG124: 不存在的schema", func() {
+			payload := builder.BuildCreatePayloadWithNonExistentSchema()
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("PG125: 超出范围端口（65536）", func() {
+			payload := builder.BuildCreatePayload()
+			payload["connector_config"].(map[string]any)["port"] = 65536
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("PG126: database名称超过最大长度（63字符）", func() {
+			payload := builder.BuildCreatePayload()
+			payload["connector_config"].(map[string]any)["database"] = strings.Repeat("a", 64)
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("PG127: schema名称超过最大长度（63字符）", func() {
+			payload := builder.BuildCreatePayloadWithSchemas([]string{strings.Repeat("a", 64)})
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package prometheus
+
+import (
+	"vega-backend-tests/at/catalog/helpers"
+	"vega-backend-tests/at/setup"
+)
+
+// PrometheusPayloadBuilder Prometheus catalog payload构建器
+type PrometheusPayloadBuilder struct {
+	config     setup.PrometheusConfig
+	testConfig *setup.TestConfig
+}
+
+// NewPrometheusPayloadBuilder 创建Prometheus payload构建器
+func NewPrometheusPayloadBuilder(config setup.PrometheusConfig) *PrometheusPayloadBuilder {
+	return &PrometheusPayloadBuilder{config: config}
+}
+
+// SetTestConfig 设置测试配置（包含加密器）
+func (b *PrometheusPayloadBuilder) SetTestConfig(tc *setup.TestConfig) {
+	b.testConfig = tc
+}
+
+// encryptPassword 加密密码
+func (b *PrometheusPayloadBuilder) encryptPassword(password string) string {
+	if b.testConfig != nil {
+		return b.testConfig.EncryptString(password)
+	}
+	return password
+}
+
+// GetConnectorType 返回connector类型
+func (b *PrometheusPayloadBuilder) GetConnectorType() string {
+	return "prometheus"
+}
+
+// BuildCreatePayload 构建基本的Prometheus catalog创建payload
+func (b *PrometheusPayloadBuilder) BuildCreatePayload() map[string]any {
+	connectorConfig := map[string]any{
+		"url": b.config.URL,
+	}
+	if b.config.Username != "" {
+		connectorConfig["username"] = b.config.Username
+		connectorConfig["password"] = b.encryptPassword(b.config.Password)
+	}
+
+	return map[string]any{
+		"name":             helpers.GenerateUniqueName("test-prometheus-catalog"),
+		"connector_type":   "prometheus",
+		"connector_config": connectorConfig,
+	}
+}
+
+// BuildCreatePayloadWithMetricPattern 构建指定metric_pattern的Prometheus catalog payload
+func (b *PrometheusPayloadBuilder) BuildCreatePayloadWithMetricPattern(pattern string) map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	connectorConfig["metric_pattern"] = pattern
+	return payload
+}
+
+// BuildCreatePayloadWithURL 构建指定服务地址的Prometheus catalog payload
+func (b *PrometheusPayloadBuilder) BuildCreatePayloadWithURL(url string) map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	connectorConfig["url"] = url
+	return payload
+}
+
+// GetConfig 返回Prometheus配置（供测试中直接使用）
+func (b *PrometheusPayloadBuilder) GetConfig() setup.PrometheusConfig {
+	return b.config
+}
@@ -0,0 +1,269 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	cataloghelpers "vega-backend-tests/at/catalog/helpers"
+	"vega-backend-tests/at/setup"
+	"vega-backend-tests/testutil"
+)
+
+// TestPrometheusCatalogCreate Prometheus Catalog创建AT测试
+// 编号规则：PM1xx
+func TestPrometheusCatalogCreate(t *testing.T) {
+	var (
+		config  *setup.TestConfig
+		client  *testutil.HTTPClient
+		builder *PrometheusPayloadBuilder
+	)
+
+	Convey("Prometheus Catalog创建AT测试 - 初始化", t, func() {
+		var err error
+		config, err = setup.LoadTestConfig()
+		So(err, ShouldBeNil)
+		So(config, ShouldNotBeNil)
+		So(config.TargetPrometheus.URL, ShouldNotBeEmpty)
+
+		client = testutil.NewHTTPClient(config.VegaManager.BaseURL)
+		err = client.CheckHealth()
+		So(err, ShouldBeNil)
+		t.Logf("✓ AT测试环境就绪，VEGA Manager: %s", config.VegaManager.BaseURL)
+
+		builder = NewPrometheusPayloadBuilder(config.TargetPrometheus)
+		builder.SetTestConfig(config)
+
+		cataloghelpers.CleanupCatalogs(client, t)
+
+		// ========== 正向测试（PM101-PM107） ==========
+
+		Convey("PM101: 创建Prometheus catalog - 基本场景", func() {
+			payload := builder.BuildCreatePayload()
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+			So(resp.Body["id"], ShouldNotBeEmpty)
+		})
+
+		Convey("PM102: 创建后验证connector_type为prometheus", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			getResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID)
+			catalog := cataloghelpers.ExtractFromEntriesResponse(getResp)
+			So(catalog["connector_type"], ShouldEqual, "prometheus")
+			So(catalog["type"], ShouldEqual, cataloghelpers.CatalogTypePhysical)
+		})
+
+		Convey("PM103: 创建指定metric_pattern的Prometheus catalog", func() {
+			payload := builder.BuildCreatePayloadWithMetricPattern("prometheus_*")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("PM104: 创建指定series_limit和lookback的Prometheus catalog", func() {
+			payload := builder.BuildCreatePayload()
+			connectorConfig := payload["connector_config"].(map[string]any)
+			connectorConfig["series_limit"] = 100
+			connectorConfig["lookback"] = "30m"
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("PM105: Prometheus连接测试成功", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			testResp := client.POST("/api/vega-backend/v1/catalogs/"+catalogID+"/test-connection", nil)
+			So(testResp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("PM106: 获取Prometheus catalog健康状态", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			statusResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/health-status")
+			So(statusResp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("PM107: 验证connector_config.password不返回", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			getResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID)
+			So(getResp.StatusCode, ShouldEqual, http.StatusOK)
+
+			catalog := cataloghelpers.ExtractFromEntriesResponse(getResp)
+			if connCfg, ok := catalog["connector_config"].(map[string]any); ok {
+				_, hasPassword := connCfg["password"]
+				So(hasPassword, ShouldBeFalse)
+			}
+		})
+
+		// ========== connector_config负向测试（PM121-PM125） ==========
+
+		Convey("PM121: 缺少url字段", func() {
+			payload := builder.BuildCreatePayload()
+			delete(payload["connector_config"].(map[string]any), "url")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("PM122: url缺少协议", func() {
+			payload := builder.BuildCreatePayloadWithURL("localhost:9090")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("PM123: 不可达的服务地址", func() {
+			payload := builder.BuildCreatePayloadWithURL("http://127.0.0.1:1")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("PM124: 非法的lookback", func() {
+			payload := builder.BuildCreatePayload()
+			payload["connector_config"].(map[string]any)["lookback"] = "one hour"
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("PM125: 非法的metric_pattern", func() {
+			payload := builder.BuildCreatePayloadWithMetricPattern("node_[")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+// TestPrometheusCatalogDiscover Prometheus Catalog资源发现AT测试
+// 编号规则：PM2xx
+func TestPrometheusCatalogDiscover(t *testing.T) {
+	Convey("Prometheus Catalog资源发现AT测试 - 初始化", t, func() {
+		config, err := setup.LoadTestConfig()
+		So(err, ShouldBeNil)
+		So(config.TargetPrometheus.Metric, ShouldNotBeEmpty)
+
+		client := testutil.NewHTTPClient(config.VegaManager.BaseURL)
+		So(client.CheckHealth(), ShouldBeNil)
+
+		builder := NewPrometheusPayloadBuilder(config.TargetPrometheus)
+		builder.SetTestConfig(config)
+
+		cataloghelpers.CleanupCatalogs(client, t)
+
+		Convey("PM201: 发现指标及其标签集合", func() {
+			resource := discoverMetricResource(client, builder)
+			So(resource["category"], ShouldEqual, "metric")
+			So(resource["source_identifier"], ShouldEqual, builder.GetConfig().Metric)
+
+			// timestamp、value 之后至少有一个标签字段
+			schema, ok := resource["schema_definition"].([]any)
+			So(ok, ShouldBeTrue)
+			So(len(schema), ShouldBeGreaterThan, 2)
+
+			sourceMetadata, ok := resource["source_metadata"].(map[string]any)
+			So(ok, ShouldBeTrue)
+			So(sourceMetadata["series_count"], ShouldBeGreaterThan, 0)
+			So(sourceMetadata["labels"], ShouldNotBeEmpty)
+		})
+
+		Convey("PM202: 预览指标资源返回当前数据点", func() {
+			resource := discoverMetricResource(client, builder)
+			resourceID := resource["id"].(string)
+
+			resp := client.GET("/api/vega-backend/v1/resources/" + resourceID + "/preview?limit=5")
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			columns, ok := resp.Body["columns"].([]any)
+			So(ok, ShouldBeTrue)
+			So(len(columns), ShouldBeGreaterThan, 2)
+			So(columns[0], ShouldEqual, "timestamp")
+			So(columns[1], ShouldEqual, "value")
+			rows, ok := resp.Body["rows"].([]any)
+			So(ok, ShouldBeTrue)
+			So(len(rows), ShouldBeBetweenOrEqual, 1, 5)
+		})
+
+		Convey("PM203: 指标资源即时查询与区间查询", func() {
+			resource := discoverMetricResource(client, builder)
+			resourceID := resource["id"].(string)
+
+			instantResp := client.GET("/api/vega-backend/v1/resources/" + resourceID + "/metric-data")
+			So(instantResp.StatusCode, ShouldEqual, http.StatusOK)
+			results, ok := instantResp.Body["results"].([]any)
+			So(ok, ShouldBeTrue)
+			So(results, ShouldNotBeEmpty)
+			series := results[0].(map[string]any)
+			So(series["metric"], ShouldEqual, builder.GetConfig().Metric)
+			So(series["values"], ShouldHaveLength, 1)
+
+			end := time.Now().UnixMilli()
+			start := end - 5*time.Minute.Milliseconds()
+			rangeResp := client.GET(fmt.Sprintf("/api/vega-backend/v1/resources/%s/metric-data?start=%d&end=%d&step=1m",
+				resourceID, start, end))
+			So(rangeResp.StatusCode, ShouldEqual, http.StatusOK)
+			results, ok = rangeResp.Body["results"].([]any)
+			So(ok, ShouldBeTrue)
+			So(results, ShouldNotBeEmpty)
+		})
+
+		Convey("PM204: 指标查询参数非法", func() {
+			resource := discoverMetricResource(client, builder)
+			resourceID := resource["id"].(string)
+
+			for _, query := range []string{
+				"start=1700000000000&end=1700000060000",
+				"start=1700000060000&end=1700000000000&step=1m",
+				"start=1700000000000&end=1800000000000&step=1s",
+				"time=1700000000000&start=1700000000000&end=1700000060000&step=1m",
+				"step=0s&start=1700000000000&end=1700000060000",
+			} {
+				resp := client.GET("/api/vega-backend/v1/resources/" + resourceID + "/metric-data?" + query)
+				So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+			}
+		})
+	})
+}
+
+// discoverMetricResource 创建只包含配置指标的 catalog 并发现资源，返回发现的指标资源
+func discoverMetricResource(client *testutil.HTTPClient, builder *PrometheusPayloadBuilder) map[string]any {
+	payload := builder.BuildCreatePayloadWithMetricPattern(builder.GetConfig().Metric)
+	createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+	So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+	catalogID := createResp.Body["id"].(string)
+
+	discoverResp := client.POST("/api/vega-backend/v1/catalogs/"+catalogID+"/discover", nil)
+	So(discoverResp.StatusCode, ShouldEqual, http.StatusOK)
+	taskID := discoverResp.Body["id"].(string)
+
+	// 等待发现任务结束
+	status := ""
+	cataloghelpers.WaitForCondition(func() bool {
+		taskResp := client.GET("/api/vega-backend/v1/discovery-tasks/" + taskID)
+		status, _ = taskResp.Body["status"].(string)
+		return status == "completed" || status == "failed"
+	}, 60*time.Second, time.Second)
+	So(status, ShouldEqual, "completed")
+
+	resourcesResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/resources")
+	So(resourcesResp.StatusCode, ShouldEqual, http.StatusOK)
+	resource := cataloghelpers.ExtractFromEntriesResponse(resourcesResp)
+	So(resource, ShouldNotBeNil)
+	return resource
+}
@@ -0,0 +1,78 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package s3
+
+import (
+	"vega-backend-tests/at/catalog/helpers"
+	"vega-backend-tests/at/setup"
+)
+
+// S3PayloadBuilder S3 catalog payload构建器
+type S3PayloadBuilder struct {
+	config     setup.S3Config
+	testConfig *setup.TestConfig
+}
+
+// NewS3PayloadBuilder 创建S3 payload构建器
+func NewS3PayloadBuilder(config setup.S3Config) *S3PayloadBuilder {
+	return &S3PayloadBuilder{config: config}
+}
+
+// SetTestConfig 设置测试配置（包含加密器）
+func (b *S3PayloadBuilder) SetTestConfig(tc *setup.TestConfig) {
+	b.testConfig = tc
+}
+
+// encryptSecret 加密访问密钥
+func (b *S3PayloadBuilder) encryptSecret(secret string) string {
+	if b.testConfig != nil {
+		return b.testConfig.EncryptString(secret)
+	}
+	return secret
+}
+
+// GetConnectorType 返回connector类型
+func (b *S3PayloadBuilder) GetConnectorType() string {
+	return "s3"
+}
+
+// BuildCreatePayload 构建基本的S3 catalog创建payload
+func (b *S3PayloadBuilder) BuildCreatePayload() map[string]any {
+	return map[string]any{
+		"name":           helpers.GenerateUniqueName("test-s3-catalog"),
+		"connector_type": "s3",
+		"connector_config": map[string]any{
+			"endpoint":          b.config.Endpoint,
+			"access_key_id":     b.config.AccessKeyID,
+			"secret_access_key": b.encryptSecret(b.config.SecretAccessKey),
+			"bucket":            b.config.Bucket,
+			"prefix":            b.config.Prefix,
+			"use_ssl":           b.config.UseSSL,
+			"path_style":        true,
+		},
+	}
+}
+
+// BuildCreatePayloadWithFilePattern 构建指定file_pattern的S3 catalog payload
+func (b *S3PayloadBuilder) BuildCreatePayloadWithFilePattern(pattern string) map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	connectorConfig["file_pattern"] = pattern
+	return payload
+}
+
+// BuildCreatePayloadWithBucket 构建指定存储桶的S3 catalog payload
+func (b *S3PayloadBuilder) BuildCreatePayloadWithBucket(bucket string) map[string]any {
+	payload := b.BuildCreatePayload()
+	connectorConfig := payload["connector_config"].(map[string]any)
+	connectorConfig["bucket"] = bucket
+	return payload
+}
+
+// GetConfig 返回S3配置（供测试中直接使用）
+func (b *S3PayloadBuilder) GetConfig() setup.S3Config {
+	return b.config
+}
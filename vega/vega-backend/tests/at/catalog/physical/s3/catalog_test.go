@@ -0,0 +1,201 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package s3
+
+import (
+	"net/http"
+	"path"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	cataloghelpers "vega-backend-tests/at/catalog/helpers"
+	"vega-backend-tests/at/setup"
+	"vega-backend-tests/testutil"
+)
+
+// TestS3CatalogCreate S3 Catalog创建AT测试
+// 编号规则：S31xx
+func TestS3CatalogCreate(t *testing.T) {
+	var (
+		config  *setup.TestConfig
+		client  *testutil.HTTPClient
+		builder *S3PayloadBuilder
+	)
+
+	Convey("S3 Catalog创建AT测试 - 初始化", t, func() {
+		var err error
+		config, err = setup.LoadTestConfig()
+		So(err, ShouldBeNil)
+		So(config, ShouldNotBeNil)
+		So(config.TargetS3.Endpoint, ShouldNotBeEmpty)
+		So(config.TargetS3.Bucket, ShouldNotBeEmpty)
+
+		client = testutil.NewHTTPClient(config.VegaManager.BaseURL)
+		err = client.CheckHealth()
+		So(err, ShouldBeNil)
+		t.Logf("✓ AT测试环境就绪，VEGA Manager: %s", config.VegaManager.BaseURL)
+
+		builder = NewS3PayloadBuilder(config.TargetS3)
+		builder.SetTestConfig(config)
+
+		cataloghelpers.CleanupCatalogs(client, t)
+
+		// ========== 正向测试（S3101-S3106） ==========
+
+		Convey("S3101: 创建S3 catalog - 基本场景", func() {
+			payload := builder.BuildCreatePayload()
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+			So(resp.Body["id"], ShouldNotBeEmpty)
+		})
+
+		Convey("S3102: 创建后验证connector_type为s3", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			getResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID)
+			catalog := cataloghelpers.ExtractFromEntriesResponse(getResp)
+			So(catalog["connector_type"], ShouldEqual, "s3")
+			So(catalog["type"], ShouldEqual, cataloghelpers.CatalogTypePhysical)
+		})
+
+		Convey("S3103: 创建指定file_pattern和sample_size的S3 catalog", func() {
+			payload := builder.BuildCreatePayloadWithFilePattern("*.csv")
+			payload["connector_config"].(map[string]any)["sample_size"] = 50
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("S3104: S3连接测试成功", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			testResp := client.POST("/api/vega-backend/v1/catalogs/"+catalogID+"/test-connection", nil)
+			So(testResp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("S3105: 获取S3 catalog健康状态", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			statusResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/health-status")
+			So(statusResp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("S3106: 验证connector_config.secret_access_key不返回", func() {
+			payload := builder.BuildCreatePayload()
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+
+			catalogID := createResp.Body["id"].(string)
+			getResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID)
+			So(getResp.StatusCode, ShouldEqual, http.StatusOK)
+
+			catalog := cataloghelpers.ExtractFromEntriesResponse(getResp)
+			if connCfg, ok := catalog["connector_config"].(map[string]any); ok {
+				_, hasSecret := connCfg["secret_access_key"]
+				So(hasSecret, ShouldBeFalse)
+			}
+		})
+
+		// ========== connector_config负向测试（S3121-S3125） ==========
+
+		Convey("S3121: 缺少endpoint字段", func() {
+			payload := builder.BuildCreatePayload()
+			delete(payload["connector_config"].(map[string]any), "endpoint")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("S3122: endpoint包含协议", func() {
+			payload := builder.BuildCreatePayload()
+			payload["connector_config"].(map[string]any)["endpoint"] = "http://" + builder.GetConfig().Endpoint
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("S3123: 缺少bucket字段", func() {
+			payload := builder.BuildCreatePayload()
+			delete(payload["connector_config"].(map[string]any), "bucket")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("S3124: 不存在的存储桶", func() {
+			payload := builder.BuildCreatePayloadWithBucket("vega-at-not-exists")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("S3125: 非法的file_pattern", func() {
+			payload := builder.BuildCreatePayloadWithFilePattern("*.[csv")
+			resp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+// TestS3CatalogDiscover S3 Catalog资源发现AT测试
+// 编号规则：S32xx
+func TestS3CatalogDiscover(t *testing.T) {
+	Convey("S3 Catalog资源发现AT测试 - 初始化", t, func() {
+		config, err := setup.LoadTestConfig()
+		So(err, ShouldBeNil)
+		So(config.TargetS3.Object, ShouldNotBeEmpty)
+
+		client := testutil.NewHTTPClient(config.VegaManager.BaseURL)
+		So(client.CheckHealth(), ShouldBeNil)
+
+		builder := NewS3PayloadBuilder(config.TargetS3)
+		builder.SetTestConfig(config)
+
+		cataloghelpers.CleanupCatalogs(client, t)
+
+		Convey("S3201: 发现CSV文件并推断schema", func() {
+			payload := builder.BuildCreatePayloadWithFilePattern(path.Base(builder.GetConfig().Object))
+			createResp := client.POST("/api/vega-backend/v1/catalogs", payload)
+			So(createResp.StatusCode, ShouldEqual, http.StatusCreated)
+			catalogID := createResp.Body["id"].(string)
+
+			discoverResp := client.POST("/api/vega-backend/v1/catalogs/"+catalogID+"/discover", nil)
+			So(discoverResp.StatusCode, ShouldEqual, http.StatusOK)
+			taskID := discoverResp.Body["id"].(string)
+
+			// 等待发现任务结束
+			status := ""
+			cataloghelpers.WaitForCondition(func() bool {
+				taskResp := client.GET("/api/vega-backend/v1/discovery-tasks/" + taskID)
+				status, _ = taskResp.Body["status"].(string)
+				return status == "completed" || status == "failed"
+			}, 60*time.Second, time.Second)
+			So(status, ShouldEqual, "completed")
+
+			resourcesResp := client.GET("/api/vega-backend/v1/catalogs/" + catalogID + "/resources")
+			So(resourcesResp.StatusCode, ShouldEqual, http.StatusOK)
+			resource := cataloghelpers.ExtractFromEntriesResponse(resourcesResp)
+			So(resource, ShouldNotBeNil)
+			So(resource["category"], ShouldEqual, "file")
+			So(resource["source_identifier"], ShouldEqual, builder.GetConfig().Object)
+
+			schema, ok := resource["schema_definition"].([]any)
+			So(ok, ShouldBeTrue)
+			So(schema, ShouldNotBeEmpty)
+
+			sourceMetadata, ok := resource["source_metadata"].(map[string]any)
+			So(ok, ShouldBeTrue)
+			So(sourceMetadata["format"], ShouldEqual, "csv")
+			So(sourceMetadata["size"], ShouldBeGreaterThan, 0)
+		})
+	})
+}
@@ -708,6 +708,53 @@ func RunCommonNameUniquenessTests(suite *TestSuite) {
 	})
 }
 
+// RunCommonDataTests 运行通用Resource数据预览与画像参数测试
+// 测试编号前缀: RM6xx
+func RunCommonDataTests(suite *TestSuite) {
+	catalogID := suite.CatalogID
+
+	Convey("RM601: 预览不存在的resource", func() {
+		resp := suite.Client.GET("/api/vega-backend/v1/resources/non-existent-id-12345/preview")
+		So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+	})
+
+	Convey("RM602: 画像不存在的resource", func() {
+		resp := suite.Client.GET("/api/vega-backend/v1/resources/non-existent-id-12345/profile")
+		So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+	})
+
+	Convey("RM603: 预览行数非法", func() {
+		resourceID, _ := suite.CreateResource(BuildPayloadWithCategory(catalogID, "table"))
+		So(resourceID, ShouldNotBeEmpty)
+
+		for _, limit := range []string{"0", "-1", "1001", "abc"} {
+			resp := suite.Client.GET("/api/vega-backend/v1/resources/" + resourceID + "/preview?limit=" + limit)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		}
+	})
+
+	Convey("RM604: 画像采样行数或refresh非法", func() {
+		resourceID, _ := suite.CreateResource(BuildPayloadWithCategory(catalogID, "table"))
+		So(resourceID, ShouldNotBeEmpty)
+
+		for _, query := range []string{"sample_size=0", "sample_size=10001", "refresh=maybe"} {
+			resp := suite.Client.GET("/api/vega-backend/v1/resources/" + resourceID + "/profile?" + query)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		}
+	})
+
+	Convey("RM605: 不支持预览的资源类别", func() {
+		resourceID, _ := suite.CreateResource(BuildPayloadWithCategory(catalogID, "metric"))
+		So(resourceID, ShouldNotBeEmpty)
+
+		resp := suite.Client.GET("/api/vega-backend/v1/resources/" + resourceID + "/preview")
+		So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+
+		resp = suite.Client.GET("/api/vega-backend/v1/resources/" + resourceID + "/profile")
+		So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+	})
+}
+
 // RunAllCommonTests 运行所有通用Resource测试
 func RunAllCommonTests(suite *TestSuite) {
 	RunCommonCreateTests(suite)
@@ -718,4 +765,5 @@ func RunAllCommonTests(suite *TestSuite) {
 	RunCommonUpdateTests(suite)
 	RunCommonDeleteTests(suite)
 	RunCommonNameUniquenessTests(suite)
+	RunCommonDataTests(suite)
 }
@@ -6,6 +6,7 @@
 package mysql
 
 import (
+	"net/http"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -106,3 +107,63 @@ func TestMySQLResourceDelete(t *testing.T) {
 		})
 	})
 }
+
+// TestMySQLResourceData MySQL Resource数据预览与画像AT测试
+func TestMySQLResourceData(t *testing.T) {
+	Convey("MySQL Resource数据预览与画像AT测试 - 初始化", t, func() {
+		suite, err := internal.NewTestSuite(t, "mysql")
+		So(err, ShouldBeNil)
+
+		err = suite.Setup()
+		So(err, ShouldBeNil)
+		defer suite.Cleanup()
+
+		// ========== 参数测试（RM601-605） ==========
+		Convey("参数测试（RM601-605）", func() {
+			internal.RunCommonDataTests(suite)
+		})
+
+		// ========== 数据测试（RM611-613） ==========
+		Convey("数据测试（RM611-613）", func() {
+			// 使用任何 MySQL 实例都存在的系统表作为数据源
+			payload := internal.BuildPayloadWithCategory(suite.CatalogID, "table")
+			payload["database"] = "information_schema"
+			payload["source_identifier"] = "information_schema.CHARACTER_SETS"
+			resourceID, _ := suite.CreateResource(payload)
+			So(resourceID, ShouldNotBeEmpty)
+
+			Convey("RM611: 预览前N行数据", func() {
+				resp := suite.Client.GET("/api/vega-backend/v1/resources/" + resourceID + "/preview?limit=3")
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(resp.Body["columns"], ShouldContain, "CHARACTER_SET_NAME")
+				So(len(resp.Body["rows"].([]any)), ShouldEqual, 3)
+			})
+
+			Convey("RM612: 计算画像后命中缓存", func() {
+				resp := suite.Client.GET("/api/vega-backend/v1/resources/" + resourceID + "/profile?sample_size=20")
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(resp.Body["cached"], ShouldBeFalse)
+				So(resp.Body["row_count"], ShouldEqual, float64(20))
+
+				columns := resp.Body["columns"].([]any)
+				So(len(columns), ShouldBeGreaterThan, 0)
+				column := columns[0].(map[string]any)
+				So(column["null_ratio"], ShouldEqual, float64(0))
+				So(column["distinct_count"], ShouldEqual, float64(20))
+
+				resp = suite.Client.GET("/api/vega-backend/v1/resources/" + resourceID + "/profile?sample_size=20")
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(resp.Body["cached"], ShouldBeTrue)
+			})
+
+			Convey("RM613: refresh忽略缓存重新计算", func() {
+				resp := suite.Client.GET("/api/vega-backend/v1/resources/" + resourceID + "/profile?sample_size=20")
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+
+				resp = suite.Client.GET("/api/vega-backend/v1/resources/" + resourceID + "/profile?sample_size=20&refresh=true")
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(resp.Body["cached"], ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -17,7 +17,11 @@ import (
 type TestConfig struct {
 	VegaManager      VegaManagerConfig `mapstructure:"vega_manager"`
 	TargetMySQL      MySQLConfig       `mapstructure:"target_mysql"`
+	TargetPostgreSQL PostgreSQLConfig  `mapstructure:"target_postgresql"`
 	TargetOpenSearch OpenSearchConfig  `mapstructure:"target_opensearch"`
+	TargetKafka      KafkaConfig       `mapstructure:"target_kafka"`
+	TargetPrometheus PrometheusConfig  `mapstructure:"target_prometheus"`
+	TargetS3         S3Config          `mapstructure:"target_s3"`
 	Crypto           CryptoConfig      `mapstructure:"crypto"`
 
 	// Cipher 运行时初始化的加密器（非配置文件字段）
@@ -38,6 +42,44 @@ type MySQLConfig struct {
 	Password string `mapstructure:"password"`
 }
 
+// PostgreSQLConfig 测试目标PostgreSQL配置
+type PostgreSQLConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Database string `mapstructure:"database"`
+	Schema   string `mapstructure:"schema"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// KafkaConfig 测试目标Kafka配置
+type KafkaConfig struct {
+	Brokers       []string `mapstructure:"brokers"`
+	Username      string   `mapstructure:"username"`
+	Password      string   `mapstructure:"password"`
+	SASLMechanism string   `mapstructure:"sasl_mechanism"`
+	Topic         string   `mapstructure:"topic"` // 已存在且包含JSON消息的topic，用于发现与schema采样验证
+}
+
+// PrometheusConfig 测试目标Prometheus配置
+type PrometheusConfig struct {
+	URL      string `mapstructure:"url"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	Metric   string `mapstructure:"metric"` // 已存在的指标名称，用于发现与查询验证
+}
+
+// S3Config 测试目标S3兼容对象存储配置
+type S3Config struct {
+	Endpoint        string `mapstructure:"endpoint"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	Bucket          string `mapstructure:"bucket"`
+	Prefix          string `mapstructure:"prefix"`
+	UseSSL          bool   `mapstructure:"use_ssl"`
+	Object          string `mapstructure:"object"` // prefix下已存在的CSV对象（相对prefix的路径），用于发现与schema推断验证
+}
+
 // OpenSearchConfig 测试目标OpenSearch配置
 type OpenSearchConfig struct {
 	Host     string `mapstructure:"host"`
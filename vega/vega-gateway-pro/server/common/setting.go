@@ -33,10 +33,21 @@ type PoolSetting struct {
 
 // 查询配置项
 type QuerySetting struct {
-	CleanIntervalTime time.Duration `mapstructure:"cleanIntervalTime"`
-	MaxIntervalTime   time.Duration `mapstructure:"maxIntervalTime"`
-	DataQuerySize     int           `mapstructure:"dataQuerySize"`
-	DataCacheSize     int           `mapstructure:"dataCacheSize"`
+	CleanIntervalTime  time.Duration      `mapstructure:"cleanIntervalTime"`
+	MaxIntervalTime    time.Duration      `mapstructure:"maxIntervalTime"`
+	DataQuerySize      int                `mapstructure:"dataQuerySize"`
+	DataCacheSize      int                `mapstructure:"dataCacheSize"`
+	DirectQueryTimeout time.Duration      `mapstructure:"directQueryTimeout"` // 直连查询执行超时时间，0表示不限制
+	ResultCache        ResultCacheSetting `mapstructure:"resultCache"`
+}
+
+// 查询结果缓存配置项
+type ResultCacheSetting struct {
+	Enabled       bool                     `mapstructure:"enabled"`
+	DefaultTTL    time.Duration            `mapstructure:"defaultTTL"`
+	DataSourceTTL map[string]time.Duration `mapstructure:"dataSourceTTL"` // 按数据源ID或数据源类型配置的有效期，0表示不缓存
+	MaxEntries    int                      `mapstructure:"maxEntries"`
+	MaxRows       int                      `mapstructure:"maxRows"` // 单条缓存的最大行数，结果超出时不缓存
 }
 
 type KafkaSetting struct {
@@ -81,6 +81,37 @@ func (vca *vegaCalculateAccess) NextUriQuery(ctx context.Context, nextUri string
 	return vca.handleResponse(ctx, span, respCode, result, err)
 }
 
+// CancelQuery 取消查询，对查询的任一 nextUri 发送 DELETE 请求即可终止整个查询
+func (vca *vegaCalculateAccess) CancelQuery(ctx context.Context, nextUri string) error {
+	ctx, span := ar_trace.Tracer.Start(ctx, "取消查询", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	vegaCalculateHeaders := map[string]string{
+		"X-Presto-User": "admin",
+	}
+
+	respCode, _, err := vca.httpClient.DeleteNoUnmarshal(ctx, nextUri, vegaCalculateHeaders)
+	logger.Debugf("delete [%s] is finished, response code [%d], error is [%v]", nextUri, respCode, err)
+
+	if err != nil {
+		logger.Errorf("cancel vega calculate query failed: %v", err)
+		o11y.AddHttpAttrs4Error(span, respCode, "InternalError", "Http Delete Failed")
+		o11y.Error(ctx, fmt.Sprintf("cancel vega calculate query failed: %v", err))
+		return fmt.Errorf("cancel vega calculate query failed: %v", err)
+	}
+
+	// 查询已结束时返回 404 或 410，同样视为取消成功
+	if respCode != http.StatusNoContent && respCode != http.StatusOK &&
+		respCode != http.StatusNotFound && respCode != http.StatusGone {
+		logger.Errorf("cancel vega calculate query failed, response code is [%d]", respCode)
+		o11y.AddHttpAttrs4Error(span, respCode, "InternalError", "Http status is not 204")
+		return fmt.Errorf("cancel vega calculate query failed, response code is [%d]", respCode)
+	}
+
+	o11y.AddHttpAttrs4Ok(span, respCode)
+	return nil
+}
+
 func (vca *vegaCalculateAccess) handleResponse(ctx context.Context, span trace.Span, respCode int, result []byte, err error) (*interfaces.VegaCalculateData, error) {
 	if err != nil {
 		logger.Errorf("fetch data from vega calculate failed: %v", err)
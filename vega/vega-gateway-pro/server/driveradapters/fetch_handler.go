@@ -6,6 +6,7 @@
 package driveradapters
 
 import (
+	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -36,6 +37,9 @@ func (r *restHandler) FetchQuery(c *gin.Context) {
 	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c), "driver layer: FetchQuery",
 		trace.WithSpanKind(trace.SpanKindServer))
 	defer span.End()
+
+	// 账户信息决定查询结果缓存的可见范围
+	ctx = context.WithValue(ctx, interfaces.ACCOUNT_INFO_KEY, r.generateAccountInfo(c))
 	query := interfaces.FetchQueryReq{}
 	err := c.ShouldBindJSON(&query)
 	if err != nil {
@@ -145,3 +149,79 @@ func (r *restHandler) NextQuery(c *gin.Context) {
 	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
 	rest.ReplyOK(c, http.StatusOK, res)
 }
+
+// CancelQuery @Summary 取消查询
+// @Description 终止数据源上正在执行的查询并释放查询缓存
+// @Tags fetch
+// @Produce json
+// @Param query_id path string true "查询ID"
+// @Success 204 "取消成功"
+// @Failure 404 {object} rest.HTTPError "查询不存在"
+// @Failure 500 {object} rest.HTTPError "内部服务器错误"
+// @Router /fetch/{query_id} [delete]
+func (r *restHandler) CancelQuery(c *gin.Context) {
+	logger.Debug("Handler CancelQuery Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c), "driver layer: CancelQuery",
+		trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	err := r.fetchService.CancelQuery(ctx, c.Param("query_id"))
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, err)
+		return
+	}
+	o11y.AddHttpAttrs4Ok(span, http.StatusNoContent)
+	rest.ReplyOK(c, http.StatusNoContent, nil)
+}
+
+// GetQueryStatus @Summary 获取查询状态
+// @Description 获取查询状态、已读取行数、已执行时长和查询来源
+// @Tags fetch
+// @Produce json
+// @Param query_id path string true "查询ID"
+// @Success 200 {object} interfaces.QueryStatus "查询状态"
+// @Failure 404 {object} rest.HTTPError "查询不存在"
+// @Failure 500 {object} rest.HTTPError "内部服务器错误"
+// @Router /fetch/{query_id}/status [get]
+func (r *restHandler) GetQueryStatus(c *gin.Context) {
+	logger.Debug("Handler GetQueryStatus Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c), "driver layer: GetQueryStatus",
+		trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	res, err := r.fetchService.GetQueryStatus(ctx, c.Param("query_id"))
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, err)
+		return
+	}
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, res)
+}
+
+// GetResultCacheStats @Summary 获取查询结果缓存统计
+// @Description 获取查询结果缓存的条目数、命中次数和命中率
+// @Tags fetch
+// @Produce json
+// @Success 200 {object} interfaces.ResultCacheStats "查询结果缓存统计"
+// @Failure 500 {object} rest.HTTPError "内部服务器错误"
+// @Router /result-cache/stats [get]
+func (r *restHandler) GetResultCacheStats(c *gin.Context) {
+	logger.Debug("Handler GetResultCacheStats Start")
+	ctx, span := ar_trace.Tracer.Start(rest.GetLanguageCtx(c), "driver layer: GetResultCacheStats",
+		trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	res, err := r.fetchService.GetResultCacheStats(ctx)
+	if err != nil {
+		httpErr := err.(*rest.HTTPError)
+		o11y.AddHttpAttrs4HttpError(span, httpErr)
+		rest.ReplyError(c, err)
+		return
+	}
+	o11y.AddHttpAttrs4Ok(span, http.StatusOK)
+	rest.ReplyOK(c, http.StatusOK, res)
+}
@@ -46,6 +46,12 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		exApiV1.POST("/fetch", r.FetchQuery)
 		// 批次查询接口
 		exApiV1.GET("/fetch/:query_id/:slug/:token", r.NextQuery)
+		// 取消查询接口
+		exApiV1.DELETE("/fetch/:query_id", r.CancelQuery)
+		// 查询状态接口
+		exApiV1.GET("/fetch/:query_id/status", r.GetQueryStatus)
+		// 查询结果缓存统计接口
+		exApiV1.GET("/result-cache/stats", r.GetResultCacheStats)
 
 	}
 
@@ -55,6 +61,12 @@ func (r *restHandler) RegisterPublic(c *gin.Engine) {
 		inApiV1.POST("/fetch", r.FetchQuery)
 		// 批次查询接口
 		inApiV1.GET("/fetch/:query_id/:slug/:token", r.NextQuery)
+		// 取消查询接口
+		inApiV1.DELETE("/fetch/:query_id", r.CancelQuery)
+		// 查询状态接口
+		inApiV1.GET("/fetch/:query_id/status", r.GetQueryStatus)
+		// 查询结果缓存统计接口
+		inApiV1.GET("/result-cache/stats", r.GetResultCacheStats)
 
 	}
 
@@ -78,7 +90,7 @@ func (r *restHandler) HealthCheck(c *gin.Context) {
 func (r *restHandler) verifyOAuthMiddleWare() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := rest.GetLanguageCtx(c)
-		_, err := r.hydra.VerifyToken(ctx, c)
+		visitor, err := r.hydra.VerifyToken(ctx, c)
 		if err != nil {
 			httpError := rest.NewHTTPError(ctx, http.StatusUnauthorized, rest.PublicError_Unauthorized).
 				WithErrorDetails(err.Error())
@@ -87,7 +99,19 @@ func (r *restHandler) verifyOAuthMiddleWare() gin.HandlerFunc {
 			return
 		}
 
+		// 以令牌中的访问者身份为准，覆盖请求头中的账户信息
+		c.Request.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_ID, visitor.ID)
+		c.Request.Header.Set(interfaces.HTTP_HEADER_ACCOUNT_TYPE, string(visitor.Type))
+
 		//执行后续操作
 		c.Next()
 	}
 }
+
+// 从请求头中获取账户信息
+func (r *restHandler) generateAccountInfo(c *gin.Context) interfaces.AccountInfo {
+	return interfaces.AccountInfo{
+		ID:   c.GetHeader(interfaces.HTTP_HEADER_ACCOUNT_ID),
+		Type: c.GetHeader(interfaces.HTTP_HEADER_ACCOUNT_TYPE),
+	}
+}
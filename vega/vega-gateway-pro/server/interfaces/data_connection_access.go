@@ -35,6 +35,9 @@ type BinData struct {
 	StorageProtocol string `json:"storage_protocol"`
 	StorageBase     string `json:"storage_base"`
 	ReplicaSet      string `json:"replica_set"`
+
+	// 连接参数（如 sslmode, connect_timeout 等），直连查询时拼接到连接串中
+	Options map[string]any `json:"options,omitempty"`
 }
 
 //go:generate mockgen -source ../interfaces/data_connection_access.go -destination ../interfaces/mock/mock_data_connection_access.go
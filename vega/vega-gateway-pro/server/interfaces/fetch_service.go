@@ -7,13 +7,32 @@ package interfaces
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// 查询状态
+const (
+	QUERY_STATE_RUNNING  = "running"  // 正在从数据源读取数据
+	QUERY_STATE_FINISHED = "finished" // 数据源已读完，缓存中可能仍有未取走的数据
+	QUERY_STATE_FAILED   = "failed"
+	QUERY_STATE_CANCELED = "canceled"
+)
+
+// 查询来源，直连查询的来源为数据源类型
+const (
+	QUERY_SOURCE_ETRINO = "etrino" // 转发给Etrino执行
+	QUERY_SOURCE_CACHE  = "cache"  // 命中查询结果缓存
+)
+
 //go:generate mockgen -source ../interfaces/fetch_service.go -destination ../interfaces/mock/mock_fetch_service.go
 type FetchService interface {
 	FetchQuery(ctx context.Context, query *FetchQueryReq) (*FetchResp, error)
 	NextQuery(ctx context.Context, query *NextQueryReq) (*FetchResp, error)
+	CancelQuery(ctx context.Context, queryId string) error
+	GetQueryStatus(ctx context.Context, queryId string) (*QueryStatus, error)
+	GetResultCacheStats(ctx context.Context) (*ResultCacheStats, error)
 }
 
 type FetchQueryReq struct {
@@ -35,6 +54,24 @@ type NextQueryReq struct {
 	BatchSize int    `form:"batch_size" binding:"omitempty,min=1,max=10000" example:"100"` // 批次大小
 }
 
+type QueryStatus struct {
+	QueryId      string `json:"query_id"`
+	State        string `json:"state"`         // 查询状态
+	Source       string `json:"source"`        // 查询来源，直连查询为数据源类型，命中缓存为 cache，否则为 etrino
+	RowsProduced int64  `json:"rows_produced"` // 已从数据源读取的行数
+	ElapsedTime  int64  `json:"elapsed_time"`  // 已执行时长（毫秒）
+	Error        string `json:"error,omitempty"`
+}
+
+type ResultCacheStats struct {
+	Enabled bool    `json:"enabled"`  // 是否开启查询结果缓存
+	Entries int     `json:"entries"`  // 缓存条目数
+	Rows    int64   `json:"rows"`     // 缓存的总行数
+	Hits    int64   `json:"hits"`     // 命中次数
+	Misses  int64   `json:"misses"`   // 未命中次数
+	HitRate float64 `json:"hit_rate"` // 命中率
+}
+
 type FetchResp struct {
 	NextUri    string    `json:"next_uri,omitempty"`
 	Columns    []*Column `json:"columns,omitempty"`
@@ -57,7 +94,59 @@ type ResultCache struct {
 	ResultSet     any         // 存储结果集，具体类型根据连接器实现而定
 	Token         int         // 查询下标
 	Columns       []*Column   // 列信息
-	ResultChan    chan *[]any // 查询结果通道
-	Error         error       // 存储查询错误
+	ResultChan    chan *[]any // 查询结果通道，只由取数协程关闭
 	MaxExceedTime time.Time   // 查询最大超时时间
+
+	State        atomic.Value  // 查询状态，取数协程与取消请求并发读写，存储 string
+	RowsProduced atomic.Int64  // 已从数据源读取的行数
+	StartTime    time.Time     // 查询开始时间
+	Done         chan struct{} // 查询被清理时关闭，通知取数协程停止写入结果通道
+
+	// 查询放入缓存后才设置，取数协程与状态、取消请求并发读写，由 mu 保护
+	mu     sync.Mutex
+	err    error  // 查询错误
+	source string // 查询来源
+	cancel func() // 取消数据源上正在执行的查询，未开始读取数据时为 nil
+}
+
+// SetErr 记录查询错误
+func (c *ResultCache) SetErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// Err 返回查询错误
+func (c *ResultCache) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// SetSource 记录查询来源
+func (c *ResultCache) SetSource(source string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.source = source
+}
+
+// Source 返回查询来源
+func (c *ResultCache) Source() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.source
+}
+
+// SetCancel 设置取消数据源上查询的函数
+func (c *ResultCache) SetCancel(cancel func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cancel = cancel
+}
+
+// CancelFunc 返回取消数据源上查询的函数，未设置时为 nil
+func (c *ResultCache) CancelFunc() func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cancel
 }
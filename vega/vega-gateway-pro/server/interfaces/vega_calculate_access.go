@@ -13,6 +13,7 @@ import (
 type VegaCalculateAccess interface {
 	StatementQuery(ctx context.Context, sql string) (*VegaCalculateData, error)
 	NextUriQuery(ctx context.Context, nextUri string) (*VegaCalculateData, error)
+	CancelQuery(ctx context.Context, nextUri string) error
 }
 
 type VegaCalculateData struct {
@@ -0,0 +1,116 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package sql_connectors
+
+import (
+	"database/sql"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"vega-gateway-pro/interfaces"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+// ClickHouseConnector ClickHouse连接器，查询取消时驱动会向服务端发送 Cancel 请求
+type ClickHouseConnector struct {
+	dbConnector
+	ConnInfo *interfaces.DataSource
+}
+
+// NewClickHouseConnector 创建ClickHouse连接器，connect_protocol 为 http 时使用HTTP协议，否则使用Native协议
+func NewClickHouseConnector(connInfo *interfaces.DataSource, timeout time.Duration) (*ClickHouseConnector, error) {
+	newClickHouseConnector := &ClickHouseConnector{
+		dbConnector: dbConnector{
+			name:    "clickhouse",
+			timeout: timeout,
+			mapType: clickhouseColumnType,
+		},
+		ConnInfo: connInfo,
+	}
+
+	protocol := clickhouse.Native
+	if strings.EqualFold(connInfo.BinData.ConnectProtocol, "http") {
+		protocol = clickhouse.HTTP
+	}
+
+	db := clickhouse.OpenDB(&clickhouse.Options{
+		Protocol: protocol,
+		Addr:     []string{net.JoinHostPort(connInfo.BinData.Host, strconv.Itoa(connInfo.BinData.Port))},
+		Auth: clickhouse.Auth{
+			Database: connInfo.BinData.DataBaseName,
+			Username: connInfo.BinData.Account,
+			Password: connInfo.BinData.Password,
+		},
+	})
+
+	if err := newClickHouseConnector.open(db); err != nil {
+		return nil, err
+	}
+
+	return newClickHouseConnector, nil
+}
+
+// ClickHouse类型到Etrino类型的映射，无符号整数按 Trino ClickHouse 连接器的约定放宽一级
+var clickhouseTypes = map[string]string{
+	"Bool":        "boolean",
+	"Int8":        "tinyint",
+	"Int16":       "smallint",
+	"Int32":       "integer",
+	"Int64":       "bigint",
+	"UInt8":       "smallint",
+	"UInt16":      "integer",
+	"UInt32":      "bigint",
+	"UInt64":      "decimal(20,0)",
+	"Float32":     "real",
+	"Float64":     "double",
+	"String":      "varchar",
+	"Date":        "date",
+	"Date32":      "date",
+	"UUID":        "uuid",
+	"IPv4":        "ipaddress",
+	"IPv6":        "ipaddress",
+	"JSON":        "json",
+	"FixedString": "varchar",
+}
+
+// clickhouseColumnType 将ClickHouse列类型映射为Etrino类型，Nullable 和 LowCardinality 包装被去除
+func clickhouseColumnType(ct *sql.ColumnType) string {
+	return mapClickHouseType(ct.DatabaseTypeName())
+}
+
+func mapClickHouseType(name string) string {
+	for _, wrapper := range []string{"Nullable(", "LowCardinality("} {
+		if inner, ok := strings.CutPrefix(name, wrapper); ok {
+			return mapClickHouseType(strings.TrimSuffix(inner, ")"))
+		}
+	}
+
+	base, params, hasParams := strings.Cut(name, "(")
+	params = strings.TrimSuffix(params, ")")
+	switch base {
+	case "Decimal":
+		// 服务端返回的列类型总是 Decimal(P, S) 形式
+		if hasParams {
+			return "decimal(" + strings.ReplaceAll(params, " ", "") + ")"
+		}
+		return "decimal"
+	case "DateTime64":
+		// DateTime64(3, 'Asia/Shanghai') 只保留精度
+		precision, _, _ := strings.Cut(params, ",")
+		return "timestamp(" + strings.TrimSpace(precision) + ")"
+	case "DateTime":
+		return "timestamp"
+	case "Array":
+		return "array(" + mapClickHouseType(params) + ")"
+	}
+
+	if tp, ok := clickhouseTypes[base]; ok {
+		return tp
+	}
+	return strings.ToLower(name)
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	_ "github.com/go-sql-driver/mysql" // MySQL驱动
 	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"time"
 	"vega-gateway-pro/interfaces"
 )
 
@@ -20,13 +21,18 @@ type ConnectorHandler interface {
 	Close() error
 }
 
-// NewConnectorHandler returns a new ConnectorHandler based on the given DataSource
-func NewConnectorHandler(dataSource *interfaces.DataSource) (ConnectorHandler, error) {
+// NewConnectorHandler returns a new ConnectorHandler based on the given DataSource,
+// timeout limits the query execution time of the connector, 0 means no limit
+func NewConnectorHandler(dataSource *interfaces.DataSource, timeout time.Duration) (ConnectorHandler, error) {
 	switch dataSource.Type {
 	case "mysql":
-		return NewMySQLConnector(dataSource)
+		return NewMySQLConnector(dataSource, timeout)
 	case "maria":
-		return NewMySQLConnector(dataSource)
+		return NewMySQLConnector(dataSource, timeout)
+	case "postgresql":
+		return NewPostgreSQLConnector(dataSource, timeout)
+	case "clickhouse":
+		return NewClickHouseConnector(dataSource, timeout)
 	default:
 		logger.Errorf("unsupported data source type: %s", dataSource.Type)
 		return nil, fmt.Errorf("unsupported data source type: %s", dataSource.Type)
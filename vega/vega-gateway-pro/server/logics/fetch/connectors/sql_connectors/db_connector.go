@@ -0,0 +1,151 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package sql_connectors
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"vega-gateway-pro/interfaces"
+
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+)
+
+// dbConnector 基于 database/sql 的通用连接器，结果集以游标方式按批次读取。
+// 查询上下文的生命周期与连接器一致，Close 时取消上下文，由驱动通知服务端终止查询。
+type dbConnector struct {
+	name    string // 数据源类型名称，用于日志
+	db      *sql.DB
+	timeout time.Duration
+	ctx     context.Context
+	cancel  context.CancelFunc
+	rows    *sql.Rows
+
+	// mapType 将驱动返回的列类型映射为与 Etrino 一致的类型名
+	mapType func(ct *sql.ColumnType) string
+}
+
+// open 验证连接并创建查询上下文，timeout 为 0 时不限制查询时长
+func (h *dbConnector) open(db *sql.DB) error {
+	h.db = db
+
+	pingCtx := context.Background()
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		pingCtx, cancel = context.WithTimeout(pingCtx, h.timeout)
+		defer cancel()
+	}
+	if err := h.db.PingContext(pingCtx); err != nil {
+		h.db.Close()
+		logger.Errorf("connect %s failed: %v", h.name, err)
+		return fmt.Errorf("connect %s failed: %w", h.name, err)
+	}
+
+	if h.timeout > 0 {
+		h.ctx, h.cancel = context.WithTimeout(context.Background(), h.timeout)
+	} else {
+		h.ctx, h.cancel = context.WithCancel(context.Background())
+	}
+	return nil
+}
+
+// GetResultSet 获取查询结果集
+func (h *dbConnector) GetResultSet(query string) (any, error) {
+	rows, err := h.db.QueryContext(h.ctx, query)
+	if err != nil {
+		h.Close()
+		logger.Errorf("query failed: %v", err)
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+
+	h.rows = rows
+	return rows, nil
+}
+
+// GetColumns 获取结果集中的列信息
+func (h *dbConnector) GetColumns(resultSet any) ([]*interfaces.Column, error) {
+	rows, ok := resultSet.(*sql.Rows)
+	if !ok {
+		logger.Errorf("resultSet type error, expect *sql.Rows")
+		return nil, fmt.Errorf("resultSet type error, expect *sql.Rows")
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		h.Close()
+		logger.Errorf("get column types failed: %v", err)
+		return nil, fmt.Errorf("get column types failed: %w", err)
+	}
+
+	columns := make([]*interfaces.Column, 0, len(columnTypes))
+	for _, ct := range columnTypes {
+		columns = append(columns, &interfaces.Column{
+			Name: ct.Name(),
+			Type: h.mapType(ct),
+		})
+	}
+
+	return columns, nil
+}
+
+// GetData 从结果集中读取至多 batchSize 行，读完时返回的结果集为 nil
+func (h *dbConnector) GetData(resultSet any, columnSize int, batchSize int) (any, []*[]any, error) {
+	rows, ok := resultSet.(*sql.Rows)
+	if !ok {
+		logger.Errorf("resultSet type error, expect *sql.Rows")
+		return nil, nil, fmt.Errorf("resultSet type error, expect *sql.Rows")
+	}
+
+	var result []*[]any
+	values := make([]any, columnSize)
+	valuePtrs := make([]any, columnSize)
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+
+	for len(result) < batchSize && rows.Next() {
+		if err := rows.Scan(valuePtrs...); err != nil {
+			h.Close()
+			logger.Errorf("scan row data failed: %v", err)
+			return nil, nil, fmt.Errorf("scan row data failed: %w", err)
+		}
+
+		row := make([]any, columnSize)
+		for i, val := range values {
+			if b, ok := val.([]byte); ok {
+				row[i] = string(b)
+			} else {
+				row[i] = val
+			}
+		}
+		result = append(result, &row)
+	}
+
+	if err := rows.Err(); err != nil {
+		h.Close()
+		logger.Errorf("scan rows failed: %v", err)
+		return nil, nil, fmt.Errorf("scan rows failed: %w", err)
+	}
+
+	if len(result) < batchSize {
+		// 结果数量小于批次大小，说明没有更多数据了
+		h.Close()
+		return nil, result, nil
+	}
+	return rows, result, nil
+}
+
+// Close 取消查询并释放连接。先取消上下文再关闭结果集，避免关闭时读完剩余数据
+func (h *dbConnector) Close() error {
+	if h.cancel != nil {
+		h.cancel()
+	}
+	if h.rows != nil {
+		h.rows.Close()
+	}
+	return h.db.Close()
+}
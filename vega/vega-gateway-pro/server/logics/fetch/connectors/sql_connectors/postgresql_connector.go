@@ -0,0 +1,137 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package sql_connectors
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"vega-gateway-pro/interfaces"
+
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"github.com/lib/pq" // PostgreSQL驱动
+)
+
+// lib/pq 不支持 prefer，由连接器在服务端未开启 SSL 时降级为 disable
+const postgresqlSSLModePrefer = "prefer"
+
+// PostgreSQLConnector PostgreSQL连接器，查询取消时驱动会向服务端发送 CancelRequest
+type PostgreSQLConnector struct {
+	dbConnector
+	ConnInfo *interfaces.DataSource
+}
+
+// NewPostgreSQLConnector 创建PostgreSQL连接器
+func NewPostgreSQLConnector(connInfo *interfaces.DataSource, timeout time.Duration) (*PostgreSQLConnector, error) {
+	newPostgreSQLConnector := &PostgreSQLConnector{
+		dbConnector: dbConnector{
+			name:    "postgresql",
+			timeout: timeout,
+			mapType: postgresqlColumnType,
+		},
+		ConnInfo: connInfo,
+	}
+
+	// 未指定 sslmode 时按 prefer 处理：优先使用 TLS，服务端未开启 SSL 时再使用明文连接
+	sslMode := postgresqlSSLModePrefer
+	if v, ok := connInfo.BinData.Options["sslmode"]; ok {
+		sslMode = fmt.Sprintf("%v", v)
+	}
+	connectMode := sslMode
+	if sslMode == postgresqlSSLModePrefer {
+		connectMode = "require"
+	}
+
+	err := newPostgreSQLConnector.connect(connInfo, connectMode)
+	if sslMode == postgresqlSSLModePrefer && errors.Is(err, pq.ErrSSLNotSupported) {
+		logger.Warnf("postgresql %s:%d does not support SSL, fall back to plaintext connection",
+			connInfo.BinData.Host, connInfo.BinData.Port)
+		err = newPostgreSQLConnector.connect(connInfo, "disable")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newPostgreSQLConnector, nil
+}
+
+// connect 按指定的 sslmode 打开并验证连接
+func (c *PostgreSQLConnector) connect(connInfo *interfaces.DataSource, sslMode string) error {
+	db, err := sql.Open("postgres", postgresqlDSN(connInfo, sslMode))
+	if err != nil {
+		logger.Errorf("connect postgresql failed: %v", err)
+		return fmt.Errorf("connect postgresql failed: %w", err)
+	}
+	return c.open(db)
+}
+
+// postgresqlDSN 构建DSN连接字符串，连接参数中的 sslmode 由调用方决定，指定schema时设置search_path
+func postgresqlDSN(connInfo *interfaces.DataSource, sslMode string) string {
+	query := url.Values{}
+	for k, v := range connInfo.BinData.Options {
+		query.Set(k, fmt.Sprintf("%v", v))
+	}
+	query.Set("sslmode", sslMode)
+	if connInfo.BinData.Schema != "" {
+		query.Set("search_path", connInfo.BinData.Schema)
+	}
+	dsn := &url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(connInfo.BinData.Account, connInfo.BinData.Password),
+		Host:     net.JoinHostPort(connInfo.BinData.Host, strconv.Itoa(connInfo.BinData.Port)),
+		Path:     "/" + connInfo.BinData.DataBaseName,
+		RawQuery: query.Encode(),
+	}
+	return dsn.String()
+}
+
+// PostgreSQL类型到Etrino类型的映射
+var postgresqlTypes = map[string]string{
+	"BOOL":        "boolean",
+	"INT2":        "smallint",
+	"INT4":        "integer",
+	"INT8":        "bigint",
+	"FLOAT4":      "real",
+	"FLOAT8":      "double",
+	"VARCHAR":     "varchar",
+	"TEXT":        "varchar",
+	"NAME":        "varchar",
+	"BPCHAR":      "char",
+	"DATE":        "date",
+	"TIME":        "time",
+	"TIMETZ":      "time with time zone",
+	"TIMESTAMP":   "timestamp",
+	"TIMESTAMPTZ": "timestamp with time zone",
+	"JSON":        "json",
+	"JSONB":       "json",
+	"UUID":        "uuid",
+	"BYTEA":       "varbinary",
+}
+
+// postgresqlColumnType 将PostgreSQL列类型映射为Etrino类型，数组类型以 _ 开头
+func postgresqlColumnType(ct *sql.ColumnType) string {
+	name := ct.DatabaseTypeName()
+	if name == "NUMERIC" {
+		if precision, scale, ok := ct.DecimalSize(); ok {
+			return fmt.Sprintf("decimal(%d,%d)", precision, scale)
+		}
+		return "decimal"
+	}
+	if elem, ok := strings.CutPrefix(name, "_"); ok {
+		if tp, ok := postgresqlTypes[elem]; ok {
+			return "array(" + tp + ")"
+		}
+	}
+	if tp, ok := postgresqlTypes[name]; ok {
+		return tp
+	}
+	return strings.ToLower(name)
+}
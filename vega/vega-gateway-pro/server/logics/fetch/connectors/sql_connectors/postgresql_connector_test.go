@@ -0,0 +1,106 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package sql_connectors
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"vega-gateway-pro/interfaces"
+)
+
+// postgres 协议中 SSLRequest 的请求码
+const sslRequestCode = 80877103
+
+func TestPostgresqlDSN(t *testing.T) {
+	connInfo := &interfaces.DataSource{BinData: interfaces.BinData{
+		Host:         "pg.local",
+		Port:         5432,
+		Account:      "user",
+		Password:     "p@ss",
+		DataBaseName: "db",
+		Schema:       "sales",
+		Options:      map[string]any{"sslmode": "verify-full", "connect_timeout": 10},
+	}}
+
+	dsn, err := url.Parse(postgresqlDSN(connInfo, "require"))
+	if err != nil {
+		t.Fatalf("parse dsn failed: %v", err)
+	}
+	query := dsn.Query()
+	if query.Get("sslmode") != "require" || query.Get("connect_timeout") != "10" || query.Get("search_path") != "sales" {
+		t.Errorf("unexpected query %v", query)
+	}
+	if password, _ := dsn.User.Password(); dsn.Host != "pg.local:5432" || dsn.Path != "/db" || password != "p@ss" {
+		t.Errorf("unexpected dsn %s", dsn)
+	}
+}
+
+// TestSSLModePreferFallback 服务端拒绝 SSL 时 prefer 模式改用明文连接重试，显式 require 时不重试
+func TestSSLModePreferFallback(t *testing.T) {
+	for _, tt := range []struct {
+		options  map[string]any
+		requests []bool // 每次连接的首个请求是否为 SSLRequest
+	}{
+		{nil, []bool{true, false}},
+		{map[string]any{"sslmode": "require"}, []bool{true}},
+		{map[string]any{"sslmode": "disable"}, []bool{false}},
+	} {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listen failed: %v", err)
+		}
+
+		requests := make(chan bool, 4)
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				// 首个请求为 长度(4字节) + 请求码(4字节)，SSLRequest 回复 N 表示不支持，其余请求直接断开
+				header := make([]byte, 8)
+				if _, err := io.ReadFull(conn, header); err == nil {
+					isSSL := binary.BigEndian.Uint32(header[4:]) == sslRequestCode
+					requests <- isSSL
+					if isSSL {
+						_, _ = conn.Write([]byte("N"))
+					}
+				}
+				conn.Close()
+			}
+		}()
+
+		host, port, _ := net.SplitHostPort(listener.Addr().String())
+		portNum, _ := strconv.Atoi(port)
+		connInfo := &interfaces.DataSource{BinData: interfaces.BinData{
+			Host: host, Port: portNum, Account: "user", DataBaseName: "db", Options: tt.options,
+		}}
+		if _, err := NewPostgreSQLConnector(connInfo, time.Second); err == nil {
+			t.Errorf("%v: expected connect error", tt.options)
+		}
+		listener.Close()
+		close(requests)
+
+		var got []bool
+		for isSSL := range requests {
+			got = append(got, isSSL)
+		}
+		if len(got) != len(tt.requests) {
+			t.Fatalf("%v: expected requests %v, got %v", tt.options, tt.requests, got)
+		}
+		for i := range got {
+			if got[i] != tt.requests[i] {
+				t.Errorf("%v: expected requests %v, got %v", tt.options, tt.requests, got)
+			}
+		}
+	}
+}
@@ -46,6 +46,8 @@ type Service struct {
 	queryLocks           sync.Map   // 用于管理每个查询的锁
 	QuerySize            int        // 当前查询的数量
 	querySizeLock        sync.Mutex // 用于保护QuerySize的锁
+	resultSetCache       *resultSetCache
+	resultSetFills       sync.Map // 等待写入结果缓存的查询
 }
 
 func NewFetchService(appSetting *common.AppSetting) interfaces.FetchService {
@@ -55,6 +57,7 @@ func NewFetchService(appSetting *common.AppSetting) interfaces.FetchService {
 			dataConnectionAccess: logics.DataConnectionAccess,
 			vegaCalculateAccess:  logics.VegaCalculateAccess,
 			coordinatorId:        generateRandomCoordId(),
+			resultSetCache:       newResultSetCache(appSetting),
 		}
 		fetchService = service
 
@@ -88,6 +91,7 @@ func (fs *Service) startCacheCleaner(interval time.Duration) {
 			}
 			return true
 		})
+		fs.resultSetCache.removeExpired()
 		logger.Debugf("Clean query cache done, query size: %d", fs.QuerySize)
 	}
 }
@@ -95,13 +99,18 @@ func (fs *Service) startCacheCleaner(interval time.Duration) {
 // cleanQuery 清理查询
 func (fs *Service) cleanQuery(key any, resultCache *interfaces.ResultCache) {
 
-	// 关闭查询结果通道
-	if resultCache != nil && resultCache.ResultChan != nil {
-		close(resultCache.ResultChan)
+	// 取消查询、缓存清理器和取数请求可能同时清理同一个查询，只有删除缓存成功的一方继续清理
+	if _, loaded := fs.queryCache.LoadAndDelete(key); !loaded {
+		return
 	}
 
-	fs.queryCache.Delete(key) // 删除缓存
-	fs.queryLocks.Delete(key) // 删除查询锁
+	// 通知取数协程停止写入，结果通道由取数协程退出时关闭
+	if resultCache != nil && resultCache.Done != nil {
+		close(resultCache.Done)
+	}
+
+	fs.queryLocks.Delete(key)             // 删除查询锁
+	fs.resultSetFills.Delete(resultCache) // 未读完的查询不写入结果缓存
 
 	// 减少查询数量
 	fs.querySizeLock.Lock()
@@ -179,9 +188,11 @@ func (fs *Service) fetchSqlQuery(ctx context.Context, query *interfaces.FetchQue
 		Token:         0,
 		Columns:       make([]*interfaces.Column, 0),
 		ResultChan:    resultChan,
-		Error:         nil,
 		MaxExceedTime: time.Now().Add(fs.appSetting.QuerySetting.MaxIntervalTime),
+		StartTime:     time.Now(),
+		Done:          make(chan struct{}),
 	}
+	resultCache.State.Store(interfaces.QUERY_STATE_RUNNING)
 	fs.queryCache.Store(queryCacheKey, resultCache)
 
 	// 记录开始时间
@@ -211,12 +222,37 @@ func (fs *Service) fetchSqlQuery(ctx context.Context, query *interfaces.FetchQue
 
 	logger.Infof("Original SQL: %s", query.Sql)
 
+	// 开启查询结果缓存时，先查缓存
+	var dataSource *interfaces.DataSource
+	if fs.appSetting.QuerySetting.ResultCache.Enabled {
+		dataSource, err = fs.dataConnectionAccess.GetDataSourceById(ctx, query.DataSourceId)
+		if err != nil {
+			logger.Errorf("Get data source failed: %s", err.Error())
+			return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
+				WithErrorDetails(err.Error())
+		}
+
+		hit, err := fs.lookupResultSetCache(ctx, query.Sql, dataSource, queryId, slug, resultCache)
+		if err != nil {
+			return nil, err
+		}
+		if hit {
+			return fs.handleQueryResult(ctx, query.Type, *query.Timeout, *query.BatchSize, queryId, slug, 0)
+		}
+	}
+
 	//提取表名
 	tablesResult, err := sqlglot.ExtractTables(query.Sql, "trino")
 	if err != nil {
 		logger.Errorf("Extract tables failed: %s", err.Error())
-		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
-			WithErrorDetails(err.Error())
+
+		// 超出解析器支持范围的语法，查询退化，转发给Etrino
+		logger.Infof("Degradation to Etrino query, SQL: %s", query.Sql)
+		err := fs.getDataFromEtrino(ctx, query.Sql, queryId, slug)
+		if err != nil {
+			return nil, err
+		}
+		return fs.handleQueryResult(ctx, query.Type, *query.Timeout, *query.BatchSize, queryId, slug, 0)
 	}
 	if len(tablesResult.Tables) == 0 {
 		logger.Errorf("Extract tables failed: sql not contain table")
@@ -237,12 +273,14 @@ func (fs *Service) fetchSqlQuery(ctx context.Context, query *interfaces.FetchQue
 
 	if catalog != "" { //单源查询
 		logger.Infof("Single-source query, SQL: %s", query.Sql)
-		//获取数据源id对应的数据源信息
-		dataSource, err := fs.dataConnectionAccess.GetDataSourceById(ctx, query.DataSourceId)
-		if err != nil {
-			logger.Errorf("Get data source failed: %s", err.Error())
-			return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
-				WithErrorDetails(err.Error())
+		//获取数据源id对应的数据源信息，查询结果缓存已获取时直接使用
+		if dataSource == nil {
+			dataSource, err = fs.dataConnectionAccess.GetDataSourceById(ctx, query.DataSourceId)
+			if err != nil {
+				logger.Errorf("Get data source failed: %s", err.Error())
+				return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
+					WithErrorDetails(err.Error())
+			}
 		}
 
 		//校验data_source_id和catalog是否匹配
@@ -253,7 +291,7 @@ func (fs *Service) fetchSqlQuery(ctx context.Context, query *interfaces.FetchQue
 		}
 
 		//根据数据源类型创建连接器
-		connector, err := sql_connectors.NewConnectorHandler(dataSource)
+		connector, err := sql_connectors.NewConnectorHandler(dataSource, fs.appSetting.QuerySetting.DirectQueryTimeout)
 		if err != nil {
 			logger.Errorf("New connector handler failed: %s", err.Error())
 
@@ -291,7 +329,7 @@ func (fs *Service) fetchSqlQuery(ctx context.Context, query *interfaces.FetchQue
 				} else {
 					// 直连查询获取结果集
 					logger.Infof("Transpiled SQL query, SQL: %s", sqlParseResult.SQL)
-					connector, err = sql_connectors.NewConnectorHandler(dataSource)
+					connector, err = sql_connectors.NewConnectorHandler(dataSource, fs.appSetting.QuerySetting.DirectQueryTimeout)
 					if err != nil {
 						logger.Errorf("New connector handler failed: %s", err.Error())
 
@@ -314,7 +352,7 @@ func (fs *Service) fetchSqlQuery(ctx context.Context, query *interfaces.FetchQue
 							}
 						} else {
 							// 直连数据查询
-							err = fs.getDataFromQueryResult(ctx, connector, resultSet, queryId, slug)
+							err = fs.getDataFromQueryResult(ctx, connector, resultSet, dataSource.Type, queryId, slug)
 							if err != nil {
 								return nil, err
 							}
@@ -323,7 +361,7 @@ func (fs *Service) fetchSqlQuery(ctx context.Context, query *interfaces.FetchQue
 				}
 			} else {
 				// 直连数据查询
-				err = fs.getDataFromQueryResult(ctx, connector, resultSet, queryId, slug)
+				err = fs.getDataFromQueryResult(ctx, connector, resultSet, dataSource.Type, queryId, slug)
 				if err != nil {
 					return nil, err
 				}
@@ -343,7 +381,7 @@ func (fs *Service) fetchSqlQuery(ctx context.Context, query *interfaces.FetchQue
 }
 
 // getDataFromQueryResult 从查询结果集中获取数据
-func (fs *Service) getDataFromQueryResult(ctx context.Context, connector sql_connectors.ConnectorHandler, resultSet any, queryId string, slug string) error {
+func (fs *Service) getDataFromQueryResult(ctx context.Context, connector sql_connectors.ConnectorHandler, resultSet any, source string, queryId string, slug string) error {
 
 	queryCacheKey := fmt.Sprintf("%s_%s", queryId, slug)
 
@@ -356,8 +394,15 @@ func (fs *Service) getDataFromQueryResult(ctx context.Context, connector sql_con
 	resultCache, _ := existingCache.(*interfaces.ResultCache)
 
 	resultCache.ResultSet = resultSet
+	resultCache.SetSource(source)
+	resultCache.SetCancel(func() {
+		// 取消查询上下文，由驱动终止数据源上的查询
+		connector.Close()
+	})
 
 	err := fs.queryPool.Submit(func() {
+		// 结果通道只由取数协程关闭，取消查询时通过 Done 通知取数协程退出
+		defer close(resultCache.ResultChan)
 
 		defer func() {
 			if r := recover(); r != nil {
@@ -415,32 +460,59 @@ func (fs *Service) getDataFromQueryResult(ctx context.Context, connector sql_con
 // storeToCache 存储查询到缓存中
 func (fs *Service) storeToCache(oldResultCache *interfaces.ResultCache, resultSet any, columns []*interfaces.Column, resData []*[]any, err error) {
 	if err != nil {
-		oldResultCache.Error = err
+		oldResultCache.SetErr(err)
+		oldResultCache.State.CompareAndSwap(interfaces.QUERY_STATE_RUNNING, interfaces.QUERY_STATE_FAILED)
+		fs.resultSetFills.Delete(oldResultCache)
 	} else {
 		oldResultCache.Columns = columns
 		for _, data := range resData {
-			oldResultCache.ResultChan <- data
+			select {
+			case <-oldResultCache.Done:
+				// 查询已取消或已清理，丢弃剩余数据
+				return
+			case oldResultCache.ResultChan <- data:
+			}
+		}
+		oldResultCache.RowsProduced.Add(int64(len(resData)))
+		fs.fillResultSetCache(oldResultCache, resultSet, columns, resData)
+		if resultSet == nil {
+			oldResultCache.State.CompareAndSwap(interfaces.QUERY_STATE_RUNNING, interfaces.QUERY_STATE_FINISHED)
 		}
 	}
 	oldResultCache.ResultSet = resultSet
 }
 
-// checkCachePeriodically 定期检查缓存通道是否有数据
+// checkCachePeriodically 定期检查缓存通道是否有数据，查询被清理时立即返回
 func (fs *Service) checkCachePeriodically(cache *interfaces.ResultCache) {
 	ticker := time.NewTicker(1 * time.Millisecond)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		// 定期检查是否有数据
-		if len(cache.ResultChan) < fs.appSetting.QuerySetting.DataQuerySize {
+	for {
+		select {
+		case <-cache.Done:
 			return
+		case <-ticker.C:
+			// 定期检查是否有数据
+			if len(cache.ResultChan) < fs.appSetting.QuerySetting.DataQuerySize {
+				return
+			}
 		}
 	}
 }
 
+// isQueryCanceled 判断查询是否已被取消
+func isQueryCanceled(resultCache *interfaces.ResultCache) bool {
+	return resultCache.State.Load() == interfaces.QUERY_STATE_CANCELED
+}
+
 // getDataFromEtrino 从Etrino获取数据
 func (fs *Service) getDataFromEtrino(ctx context.Context, sql string, queryId string, slug string) error {
 
+	queryCacheKey := fmt.Sprintf("%s_%s", queryId, slug)
+	if existingCache, ok := fs.queryCache.Load(queryCacheKey); ok {
+		existingCache.(*interfaces.ResultCache).SetSource(interfaces.QUERY_SOURCE_ETRINO)
+	}
+
 	statementData, err := fs.vegaCalculateAccess.StatementQuery(ctx, sql)
 	if err != nil {
 		logger.Errorf("Etrino statement query failed, %s", err.Error())
@@ -457,6 +529,16 @@ func (fs *Service) getDataFromEtrino(ctx context.Context, sql string, queryId st
 
 	// 第一阶段：处理queued阶段
 	for nextUri != "" && strings.Contains(nextUri, "queued") {
+		// 排队期间查询被取消，通知Etrino终止查询
+		if _, ok := fs.queryCache.Load(queryCacheKey); !ok {
+			logger.Infof("QueryId: %s, slug: %s, query canceled while queued", queryId, slug)
+			if err := fs.vegaCalculateAccess.CancelQuery(ctx, nextUri); err != nil {
+				logger.Errorf("Etrino cancel query failed, %s", err.Error())
+			}
+			return rest.NewHTTPError(ctx, http.StatusNotFound, rest.PublicError_NotFound).
+				WithErrorDetails("Query canceled")
+		}
+
 		nextData, err := fs.vegaCalculateAccess.NextUriQuery(ctx, nextUri)
 		if err != nil {
 			logger.Errorf("Etrino queued query failed, %s", err.Error())
@@ -490,10 +572,21 @@ func (fs *Service) getDataFromEtrinoExecutingNextUri(ctx context.Context, nextUr
 
 	resultCache.ResultSet = nextUri
 
+	queryCtx, cancel := context.WithCancel(context.Background())
+	resultCache.SetCancel(func() {
+		// 中断正在进行的nextUri请求，并通知Etrino终止查询
+		cancel()
+		if uri, ok := resultCache.ResultSet.(string); ok && uri != "" {
+			if err := fs.vegaCalculateAccess.CancelQuery(context.Background(), uri); err != nil {
+				logger.Errorf("Etrino cancel query failed, %s", err.Error())
+			}
+		}
+	})
+
 	// 提交查询任务到查询池
 	err := fs.queryPool.Submit(func() {
-
-		queryCtx, cancel := context.WithCancel(context.Background())
+		// 取数协程退出时关闭结果通道
+		defer close(resultCache.ResultChan)
 
 		defer func() {
 			if r := recover(); r != nil {
@@ -562,6 +655,7 @@ func (fs *Service) getDataFromEtrinoExecutingNextUri(ctx context.Context, nextUr
 	})
 
 	if err != nil {
+		cancel()
 		logger.Errorf("Get data from Etrino executing nextUri failed, %s", err.Error())
 		return rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
 			WithErrorDetails(err.Error())
@@ -598,7 +692,8 @@ func (fs *Service) handleQueryResult(ctx context.Context, queryType int, timeout
 			select {
 			case <-ticker.C:
 				// 定期检查缓存， 查询异常、数据足够、查询完成退出检查
-				if resultCache.Error != nil ||
+				if resultCache.Err() != nil ||
+					isQueryCanceled(resultCache) ||
 					len(resultCache.ResultChan) >= batchSize ||
 					(len(resultCache.Columns) > 0 && resultCache.ResultSet == nil) {
 					break
@@ -606,7 +701,8 @@ func (fs *Service) handleQueryResult(ctx context.Context, queryType int, timeout
 				continue
 			case <-timeoutChan:
 				// 超时后检查缓存， 查询异常、数据足够、查询完成退出检查
-				if resultCache.Error != nil ||
+				if resultCache.Err() != nil ||
+					isQueryCanceled(resultCache) ||
 					len(resultCache.ResultChan) >= batchSize ||
 					(len(resultCache.Columns) > 0 && resultCache.ResultSet == nil) {
 					break
@@ -647,15 +743,22 @@ func (fs *Service) handleQueryResult(ctx context.Context, queryType int, timeout
 		}
 
 		if totalCount == batchSize ||
+			isQueryCanceled(resultCache) ||
 			(resultCache.ResultSet == nil && len(resultCache.ResultChan) == 0) {
 			break
 		}
 	}
 
-	if resultCache.Error != nil {
-		logger.Errorf("Query failed, %s", resultCache.Error.Error())
+	if isQueryCanceled(resultCache) {
+		logger.Infof("QueryId: %s, slug: %s, token: %d, query canceled", queryId, slug, token)
+		return nil, rest.NewHTTPError(ctx, http.StatusNotFound, rest.PublicError_NotFound).
+			WithErrorDetails("Query canceled")
+	}
+
+	if err := resultCache.Err(); err != nil {
+		logger.Errorf("Query failed, %s", err.Error())
 		return nil, rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
-			WithErrorDetails(resultCache.Error.Error())
+			WithErrorDetails(err.Error())
 	}
 
 	// 构建最终结果
@@ -740,6 +843,63 @@ func (fs *Service) NextQuery(ctx context.Context, req *interfaces.NextQueryReq)
 
 }
 
+// CancelQuery 取消查询，终止数据源上正在执行的查询并释放查询缓存
+func (fs *Service) CancelQuery(ctx context.Context, queryId string) error {
+	queryCacheKey, resultCache := fs.loadQuery(queryId)
+	if resultCache == nil {
+		return rest.NewHTTPError(ctx, http.StatusNotFound, rest.PublicError_NotFound).
+			WithErrorDetails("Query does not exist")
+	}
+
+	logger.Infof("Cancel query, queryId: %s, source: %s, rows produced: %d",
+		queryId, resultCache.Source(), resultCache.RowsProduced.Load())
+
+	// 先标记取消，使读取结果的请求和取数协程尽快退出，再终止数据源上的查询
+	resultCache.State.Store(interfaces.QUERY_STATE_CANCELED)
+	if cancel := resultCache.CancelFunc(); cancel != nil {
+		cancel()
+	}
+	fs.cleanQuery(queryCacheKey, resultCache)
+
+	return nil
+}
+
+// GetQueryStatus 获取查询状态，查询结束且数据被取完后缓存即被清理，此时返回查询不存在
+func (fs *Service) GetQueryStatus(ctx context.Context, queryId string) (*interfaces.QueryStatus, error) {
+	_, resultCache := fs.loadQuery(queryId)
+	if resultCache == nil {
+		return nil, rest.NewHTTPError(ctx, http.StatusNotFound, rest.PublicError_NotFound).
+			WithErrorDetails("Query does not exist")
+	}
+
+	status := &interfaces.QueryStatus{
+		QueryId:      queryId,
+		State:        resultCache.State.Load().(string),
+		Source:       resultCache.Source(),
+		RowsProduced: resultCache.RowsProduced.Load(),
+		ElapsedTime:  time.Since(resultCache.StartTime).Milliseconds(),
+	}
+	if err := resultCache.Err(); err != nil {
+		status.Error = err.Error()
+	}
+
+	return status, nil
+}
+
+// loadQuery 根据查询ID查找查询缓存，缓存键格式为 queryId_slug，slug 中不含下划线
+func (fs *Service) loadQuery(queryId string) (queryCacheKey any, resultCache *interfaces.ResultCache) {
+	fs.queryCache.Range(func(key, value any) bool {
+		slug, ok := strings.CutPrefix(key.(string), queryId+"_")
+		if !ok || strings.Contains(slug, "_") {
+			return true
+		}
+		queryCacheKey = key
+		resultCache, _ = value.(*interfaces.ResultCache)
+		return false
+	})
+	return queryCacheKey, resultCache
+}
+
 // generateQueryId 生成格式为 YYYMMdd_HHmmss_index_coordId 的查询ID
 func (fs *Service) generateQueryId() string {
 	now := time.Now()
@@ -0,0 +1,191 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"vega-gateway-pro/common"
+	"vega-gateway-pro/interfaces"
+)
+
+// streamingConnector 持续返回数据、永不结束的连接器，用于模拟正在流式读取的查询
+type streamingConnector struct {
+	closed atomic.Bool
+}
+
+func (c *streamingConnector) GetResultSet(sql string) (any, error) {
+	return "rows", nil
+}
+
+func (c *streamingConnector) GetColumns(resultSet any) ([]*interfaces.Column, error) {
+	return []*interfaces.Column{{Name: "id", Type: "bigint"}}, nil
+}
+
+func (c *streamingConnector) GetData(resultSet any, columnSize int, batchSize int) (any, []*[]any, error) {
+	if c.closed.Load() {
+		return nil, nil, fmt.Errorf("connector closed")
+	}
+	data := make([]*[]any, 0, batchSize)
+	for i := 0; i < batchSize; i++ {
+		data = append(data, &[]any{i})
+	}
+	return resultSet, data, nil
+}
+
+func (c *streamingConnector) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	fs := &Service{
+		appSetting: &common.AppSetting{
+			QuerySetting: common.QuerySetting{
+				MaxIntervalTime: time.Minute,
+				DataQuerySize:   8,
+				DataCacheSize:   16,
+			},
+		},
+	}
+	fs.resultSetCache = newResultSetCache(fs.appSetting)
+	fs.InitQueryPool(common.PoolSetting{QueryPoolSize: 4})
+	t.Cleanup(fs.queryPool.Release)
+	return fs
+}
+
+// TestCancelQueryWhileStreaming 取消正在流式写入结果通道的查询，取数协程应停止写入并关闭结果通道，
+// 需配合 -race 运行
+func TestCancelQueryWhileStreaming(t *testing.T) {
+	fs := newTestService(t)
+
+	for i := 0; i < 50; i++ {
+		queryId := fmt.Sprintf("20261017_000000_%05d_test", i)
+		slug := "slug"
+		resultCache := &interfaces.ResultCache{
+			Columns:       make([]*interfaces.Column, 0),
+			ResultChan:    make(chan *[]any, fs.appSetting.QuerySetting.DataCacheSize),
+			MaxExceedTime: time.Now().Add(time.Minute),
+			StartTime:     time.Now(),
+			Done:          make(chan struct{}),
+		}
+		resultCache.State.Store(interfaces.QUERY_STATE_RUNNING)
+		fs.queryCache.Store(fmt.Sprintf("%s_%s", queryId, slug), resultCache)
+		fs.QuerySize++
+
+		connector := &streamingConnector{}
+		if err := fs.getDataFromQueryResult(context.Background(), connector, "rows", "mysql", queryId, slug); err != nil {
+			t.Fatalf("start streaming query failed: %v", err)
+		}
+
+		// 消费方持续读取结果，直到取数协程关闭结果通道
+		drained := make(chan int)
+		go func() {
+			n := 0
+			for range resultCache.ResultChan {
+				n++
+			}
+			drained <- n
+		}()
+
+		for resultCache.RowsProduced.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		if err := fs.CancelQuery(context.Background(), queryId); err != nil {
+			t.Fatalf("cancel query failed: %v", err)
+		}
+
+		select {
+		case <-drained:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("result channel not closed after cancel")
+		}
+
+		if state := resultCache.State.Load(); state != interfaces.QUERY_STATE_CANCELED {
+			t.Fatalf("expected state %s, got %v", interfaces.QUERY_STATE_CANCELED, state)
+		}
+		if !connector.closed.Load() {
+			t.Fatalf("connector not closed after cancel")
+		}
+		if _, ok := fs.queryCache.Load(fmt.Sprintf("%s_%s", queryId, slug)); ok {
+			t.Fatalf("query cache not cleaned after cancel")
+		}
+	}
+}
+
+// failingConnector 返回若干批数据后读取失败的连接器
+type failingConnector struct {
+	streamingConnector
+	batches atomic.Int32
+}
+
+func (c *failingConnector) GetData(resultSet any, columnSize int, batchSize int) (any, []*[]any, error) {
+	if c.batches.Add(1) > 3 {
+		return nil, nil, fmt.Errorf("connection reset")
+	}
+	return c.streamingConnector.GetData(resultSet, columnSize, batchSize)
+}
+
+// TestGetQueryStatusWhileFailing 取数协程写入查询错误的同时轮询查询状态，需配合 -race 运行
+func TestGetQueryStatusWhileFailing(t *testing.T) {
+	fs := newTestService(t)
+
+	queryId := "20261017_000000_00000_status"
+	slug := "slug"
+	resultCache := &interfaces.ResultCache{
+		Columns:       make([]*interfaces.Column, 0),
+		ResultChan:    make(chan *[]any, fs.appSetting.QuerySetting.DataCacheSize),
+		MaxExceedTime: time.Now().Add(time.Minute),
+		StartTime:     time.Now(),
+		Done:          make(chan struct{}),
+	}
+	resultCache.State.Store(interfaces.QUERY_STATE_RUNNING)
+	fs.queryCache.Store(fmt.Sprintf("%s_%s", queryId, slug), resultCache)
+	fs.QuerySize++
+
+	// 状态轮询与取数协程并发执行
+	polled := make(chan *interfaces.QueryStatus)
+	go func() {
+		for {
+			status, err := fs.GetQueryStatus(context.Background(), queryId)
+			if err != nil {
+				t.Errorf("get query status failed: %v", err)
+				close(polled)
+				return
+			}
+			if status.State == interfaces.QUERY_STATE_FAILED {
+				polled <- status
+				return
+			}
+		}
+	}()
+
+	if err := fs.getDataFromQueryResult(context.Background(), &failingConnector{}, "rows", "mysql", queryId, slug); err != nil {
+		t.Fatalf("start query failed: %v", err)
+	}
+	go func() {
+		for range resultCache.ResultChan {
+		}
+	}()
+
+	select {
+	case status, ok := <-polled:
+		if !ok {
+			return
+		}
+		if status.Source != "mysql" || status.Error != "connection reset" {
+			t.Fatalf("unexpected status %+v", status)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("query not failed")
+	}
+}
@@ -0,0 +1,350 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package fetch
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"vega-gateway-pro/common"
+	"vega-gateway-pro/interfaces"
+	"vega-gateway-pro/logics/fetch/sqlglot"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_metric"
+	"github.com/kweaver-ai/kweaver-go-lib/logger"
+	"github.com/kweaver-ai/kweaver-go-lib/rest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// 可观测性指标
+const (
+	RESULT_CACHE_HIT_NAME         = "vega_gateway_result_cache_hit_count"
+	RESULT_CACHE_MISS_NAME        = "vega_gateway_result_cache_miss_count"
+	RESULT_CACHE_HIT_DESCRIPTION  = "result cache hit count"
+	RESULT_CACHE_MISS_DESCRIPTION = "result cache miss count"
+	COUNT_UNIT                    = "count"
+)
+
+// resultSetEntry 一条查询结果缓存，只缓存完整读完的结果
+type resultSetEntry struct {
+	key          string
+	dataSourceId string
+	fingerprint  string // 写入时数据源连接配置的指纹
+	columns      []*interfaces.Column
+	rows         []*[]any
+	expireTime   time.Time
+}
+
+// resultSetFill 未命中缓存的查询，读取数据的同时收集结果，读完后写入缓存
+type resultSetFill struct {
+	entry *resultSetEntry
+	ttl   time.Duration
+}
+
+// resultSetCache 查询结果缓存，键由规范化后的SQL、数据源和调用者身份共同决定，
+// 条目数超出上限时淘汰最久未使用的条目
+type resultSetCache struct {
+	appSetting *common.AppSetting
+
+	lock         sync.Mutex
+	entries      map[string]*list.Element
+	lru          *list.List
+	fingerprints map[string]string // 数据源ID -> 连接配置指纹
+
+	hits        atomic.Int64
+	misses      atomic.Int64
+	hitCounter  metric.Int64Counter
+	missCounter metric.Int64Counter
+}
+
+func newResultSetCache(appSetting *common.AppSetting) *resultSetCache {
+	hitCounter, _ := ar_metric.Meter.Int64Counter(
+		RESULT_CACHE_HIT_NAME,
+		metric.WithUnit(COUNT_UNIT),
+		metric.WithDescription(RESULT_CACHE_HIT_DESCRIPTION),
+	)
+	missCounter, _ := ar_metric.Meter.Int64Counter(
+		RESULT_CACHE_MISS_NAME,
+		metric.WithUnit(COUNT_UNIT),
+		metric.WithDescription(RESULT_CACHE_MISS_DESCRIPTION),
+	)
+
+	return &resultSetCache{
+		appSetting:   appSetting,
+		entries:      make(map[string]*list.Element),
+		lru:          list.New(),
+		fingerprints: make(map[string]string),
+		hitCounter:   hitCounter,
+		missCounter:  missCounter,
+	}
+}
+
+// ttl 获取数据源的缓存有效期，优先按数据源ID，其次按数据源类型，返回 0 表示不缓存。
+// 配置文件中的键会被转为小写
+func (c *resultSetCache) ttl(dataSource *interfaces.DataSource) time.Duration {
+	setting := c.appSetting.QuerySetting.ResultCache
+	if !setting.Enabled {
+		return 0
+	}
+	if ttl, ok := setting.DataSourceTTL[strings.ToLower(dataSource.ID)]; ok {
+		return ttl
+	}
+	if ttl, ok := setting.DataSourceTTL[strings.ToLower(dataSource.Type)]; ok {
+		return ttl
+	}
+	return setting.DefaultTTL
+}
+
+// cacheKey 生成缓存键，SQL无法解析时仅折叠空白字符
+func (c *resultSetCache) cacheKey(sql string, dataSourceId string, accountInfo interfaces.AccountInfo) string {
+	normalized, err := sqlglot.NormalizeSQL(sql, "trino")
+	if err != nil {
+		normalized = strings.Join(strings.Fields(sql), " ")
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		normalized, dataSourceId, accountInfo.Type, accountInfo.ID,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkDataSource 检查数据源连接配置是否变化，变化时清除该数据源的全部缓存，返回当前配置的指纹
+func (c *resultSetCache) checkDataSource(dataSource *interfaces.DataSource) string {
+	data, _ := json.Marshal(dataSource.BinData)
+	sum := sha256.Sum256(append([]byte(dataSource.Type+"\x00"), data...))
+	fingerprint := hex.EncodeToString(sum[:])
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if old, ok := c.fingerprints[dataSource.ID]; ok && old != fingerprint {
+		removed := 0
+		for key, elem := range c.entries {
+			if elem.Value.(*resultSetEntry).dataSourceId == dataSource.ID {
+				c.removeLocked(key, elem)
+				removed++
+			}
+		}
+		logger.Infof("Data source %s connection config changed, %d result cache entries invalidated", dataSource.ID, removed)
+	}
+	c.fingerprints[dataSource.ID] = fingerprint
+
+	return fingerprint
+}
+
+// get 读取未过期的缓存并记录命中情况
+func (c *resultSetCache) get(ctx context.Context, key string, dataSourceType string) (*resultSetEntry, bool) {
+	c.lock.Lock()
+	var entry *resultSetEntry
+	if elem, ok := c.entries[key]; ok {
+		if time.Now().Before(elem.Value.(*resultSetEntry).expireTime) {
+			entry = elem.Value.(*resultSetEntry)
+			c.lru.MoveToFront(elem)
+		} else {
+			c.removeLocked(key, elem)
+		}
+	}
+	c.lock.Unlock()
+
+	attrs := metric.WithAttributes(attribute.String("data_source_type", dataSourceType))
+	if entry == nil {
+		c.misses.Add(1)
+		c.missCounter.Add(ctx, 1, attrs)
+		return nil, false
+	}
+	c.hits.Add(1)
+	c.hitCounter.Add(ctx, 1, attrs)
+	return entry, true
+}
+
+// put 写入缓存，写入前数据源配置已变化的结果直接丢弃
+func (c *resultSetCache) put(entry *resultSetEntry) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.fingerprints[entry.dataSourceId] != entry.fingerprint {
+		return
+	}
+
+	if elem, ok := c.entries[entry.key]; ok {
+		c.removeLocked(entry.key, elem)
+	}
+	c.entries[entry.key] = c.lru.PushFront(entry)
+
+	maxEntries := c.appSetting.QuerySetting.ResultCache.MaxEntries
+	for maxEntries > 0 && c.lru.Len() > maxEntries {
+		oldest := c.lru.Back()
+		c.removeLocked(oldest.Value.(*resultSetEntry).key, oldest)
+	}
+}
+
+// removeExpired 清除过期的缓存，关闭缓存后清除全部缓存
+func (c *resultSetCache) removeExpired() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	enabled := c.appSetting.QuerySetting.ResultCache.Enabled
+	now := time.Now()
+	for key, elem := range c.entries {
+		if !enabled || now.After(elem.Value.(*resultSetEntry).expireTime) {
+			c.removeLocked(key, elem)
+		}
+	}
+}
+
+func (c *resultSetCache) removeLocked(key string, elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, key)
+}
+
+func (c *resultSetCache) stats() *interfaces.ResultCacheStats {
+	c.lock.Lock()
+	stats := &interfaces.ResultCacheStats{
+		Enabled: c.appSetting.QuerySetting.ResultCache.Enabled,
+		Entries: len(c.entries),
+	}
+	for _, elem := range c.entries {
+		stats.Rows += int64(len(elem.Value.(*resultSetEntry).rows))
+	}
+	c.lock.Unlock()
+
+	stats.Hits = c.hits.Load()
+	stats.Misses = c.misses.Load()
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// lookupResultSetCache 查询结果缓存，命中时从缓存中读取数据；
+// 未命中且数据源允许缓存时，登记查询以便读完后写入缓存
+func (fs *Service) lookupResultSetCache(ctx context.Context, sql string, dataSource *interfaces.DataSource,
+	queryId string, slug string, resultCache *interfaces.ResultCache) (bool, error) {
+
+	ttl := fs.resultSetCache.ttl(dataSource)
+	if ttl <= 0 {
+		return false, nil
+	}
+
+	var accountInfo interfaces.AccountInfo
+	if v, ok := ctx.Value(interfaces.ACCOUNT_INFO_KEY).(interfaces.AccountInfo); ok {
+		accountInfo = v
+	}
+
+	fingerprint := fs.resultSetCache.checkDataSource(dataSource)
+	key := fs.resultSetCache.cacheKey(sql, dataSource.ID, accountInfo)
+
+	if entry, ok := fs.resultSetCache.get(ctx, key, dataSource.Type); ok {
+		logger.Infof("Result cache hit, queryId: %s, slug: %s, rows: %d", queryId, slug, len(entry.rows))
+		return true, fs.getDataFromResultSetCache(ctx, entry, queryId, slug)
+	}
+
+	fs.resultSetFills.Store(resultCache, &resultSetFill{
+		entry: &resultSetEntry{
+			key:          key,
+			dataSourceId: dataSource.ID,
+			fingerprint:  fingerprint,
+		},
+		ttl: ttl,
+	})
+	return false, nil
+}
+
+// getDataFromResultSetCache 按批次将缓存的结果写入查询结果通道，与读取数据源的方式保持一致
+func (fs *Service) getDataFromResultSetCache(ctx context.Context, entry *resultSetEntry, queryId string, slug string) error {
+
+	queryCacheKey := fmt.Sprintf("%s_%s", queryId, slug)
+
+	existingCache, ok := fs.queryCache.Load(queryCacheKey)
+	if !ok {
+		logger.Errorf("Query does not exist, queryId: %s, slug: %s", queryId, slug)
+		return rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
+			WithErrorDetails("Query does not exist")
+	}
+	resultCache, _ := existingCache.(*interfaces.ResultCache)
+
+	rows := entry.rows
+	resultCache.ResultSet = rows
+	resultCache.SetSource(interfaces.QUERY_SOURCE_CACHE)
+
+	err := fs.queryPool.Submit(func() {
+		// 取数协程退出时关闭结果通道
+		defer close(resultCache.ResultChan)
+
+		defer func() {
+			if r := recover(); r != nil {
+				err := fmt.Errorf("QueryId: %s, slug: %s, result cache query panic in goroutine: %v", queryId, slug, r)
+				logger.Error(err)
+				fs.storeToCache(resultCache, nil, nil, nil, err)
+			}
+			logger.Debugf("QueryId: %s, slug: %s, result cache query goroutine exit", queryId, slug)
+		}()
+
+		for {
+			// 如果缓存不存在, 则退出循环
+			if _, ok := fs.queryCache.Load(queryCacheKey); !ok {
+				break
+			}
+
+			batch := rows[:min(len(rows), fs.appSetting.QuerySetting.DataQuerySize)]
+			rows = rows[len(batch):]
+
+			// 数据已全部写入，结果集置为 nil 表示查询完成
+			if len(rows) == 0 {
+				fs.storeToCache(resultCache, nil, entry.columns, batch, nil)
+				break
+			}
+			fs.storeToCache(resultCache, rows, entry.columns, batch, nil)
+
+			// 定期检查缓存中是否有数据
+			fs.checkCachePeriodically(resultCache)
+		}
+	})
+
+	if err != nil {
+		logger.Errorf("Get data from result cache failed, %s", err.Error())
+		return rest.NewHTTPError(ctx, http.StatusInternalServerError, rest.PublicError_InternalServerError).
+			WithErrorDetails(err.Error())
+	}
+
+	return nil
+}
+
+// fillResultSetCache 收集未命中缓存的查询读取到的数据，查询读完后写入缓存，超过行数上限时放弃缓存
+func (fs *Service) fillResultSetCache(resultCache *interfaces.ResultCache, resultSet any, columns []*interfaces.Column, resData []*[]any) {
+	v, ok := fs.resultSetFills.Load(resultCache)
+	if !ok {
+		return
+	}
+	fill := v.(*resultSetFill)
+
+	if len(fill.entry.rows)+len(resData) > fs.appSetting.QuerySetting.ResultCache.MaxRows {
+		fs.resultSetFills.Delete(resultCache)
+		return
+	}
+	fill.entry.rows = append(fill.entry.rows, resData...)
+
+	if resultSet == nil {
+		fs.resultSetFills.Delete(resultCache)
+		fill.entry.columns = columns
+		fill.entry.expireTime = time.Now().Add(fill.ttl)
+		fs.resultSetCache.put(fill.entry)
+	}
+}
+
+// GetResultCacheStats 获取查询结果缓存的统计信息
+func (fs *Service) GetResultCacheStats(ctx context.Context) (*interfaces.ResultCacheStats, error) {
+	return fs.resultSetCache.stats(), nil
+}
@@ -0,0 +1,143 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package sqlglot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 方言对 NULL 排序位置的默认约定，与 sqlglot 的 NULL_ORDERING 一致
+const (
+	nullsAreSmall = "nulls_are_small" // NULL 视为最小值
+	nullsAreLarge = "nulls_are_large" // NULL 视为最大值
+	nullsAreLast  = "nulls_are_last"  // 无论升降序 NULL 都排在最后
+)
+
+// NULL 排序的显式写法支持情况
+const (
+	nullOrderingNative  = iota // 支持 NULLS FIRST/LAST
+	nullOrderingEmulate        // 不支持，使用 CASE WHEN x IS NULL 排序项模拟
+)
+
+// Dialect SQL 方言，描述解析与生成时的差异
+type Dialect struct {
+	Name string
+
+	identQuote     rune
+	nullOrdering   string
+	nullSupport    int
+	castTypes      map[string]string // 规范类型名到方言类型名的映射，未列出的保持不变
+	dropTypeParams map[string]bool   // 映射后不再保留参数的类型
+
+	concatAsFunc           bool   // || 生成为 CONCAT 函数
+	ifAsCase               bool   // IF 生成为 CASE WHEN
+	substringFromFor       bool   // SUBSTRING 生成为 SUBSTRING(s FROM i FOR n)
+	lengthFunc             string // LENGTH 的函数名
+	currentTimestampParens bool   // CURRENT_TIMESTAMP 生成时带括号
+	fetchAsLimit           bool   // FETCH FIRST n ROWS ONLY 生成为 LIMIT n
+	backslashEscapes       bool   // 字符串中的反斜杠为转义符，生成时需要转义
+}
+
+var (
+	// Trino 作为读取方言
+	Trino = &Dialect{
+		Name:         "trino",
+		identQuote:   '"',
+		nullOrdering: nullsAreLast,
+		nullSupport:  nullOrderingNative,
+		lengthFunc:   "LENGTH",
+	}
+
+	MySQL = &Dialect{
+		Name:         "mysql",
+		identQuote:   '`',
+		nullOrdering: nullsAreSmall,
+		nullSupport:  nullOrderingEmulate,
+		// MySQL 的 CAST 只接受有限的目标类型
+		castTypes: map[string]string{
+			"BIGINT":    "SIGNED",
+			"INT":       "SIGNED",
+			"SMALLINT":  "SIGNED",
+			"TINYINT":   "SIGNED",
+			"BOOLEAN":   "SIGNED",
+			"VARCHAR":   "CHAR",
+			"TEXT":      "CHAR",
+			"REAL":      "FLOAT",
+			"TIMESTAMP": "DATETIME",
+			"VARBINARY": "BINARY",
+		},
+		dropTypeParams: map[string]bool{
+			"SIGNED": true,
+		},
+		concatAsFunc:           true,
+		lengthFunc:             "CHAR_LENGTH",
+		currentTimestampParens: true,
+		fetchAsLimit:           true,
+		backslashEscapes:       true,
+	}
+
+	Postgres = &Dialect{
+		Name:         "postgres",
+		identQuote:   '"',
+		nullOrdering: nullsAreLarge,
+		nullSupport:  nullOrderingNative,
+		castTypes: map[string]string{
+			"DOUBLE":    "DOUBLE PRECISION",
+			"TINYINT":   "SMALLINT",
+			"VARBINARY": "BYTEA",
+		},
+		ifAsCase:         true,
+		substringFromFor: true,
+		lengthFunc:       "LENGTH",
+	}
+
+	// OpenSearch SQL 语法接近 MySQL，类型系统使用 OpenSearch 的类型名
+	OpenSearch = &Dialect{
+		Name:         "opensearch",
+		identQuote:   '`',
+		nullOrdering: nullsAreSmall,
+		nullSupport:  nullOrderingNative,
+		castTypes: map[string]string{
+			"VARCHAR":  "STRING",
+			"CHAR":     "STRING",
+			"TEXT":     "STRING",
+			"BIGINT":   "LONG",
+			"SMALLINT": "SHORT",
+			"TINYINT":  "BYTE",
+			"DECIMAL":  "DOUBLE",
+		},
+		dropTypeParams: map[string]bool{
+			"STRING": true,
+			"DOUBLE": true,
+		},
+		concatAsFunc: true,
+		lengthFunc:   "LENGTH",
+		fetchAsLimit: true,
+	}
+)
+
+var dialects = map[string]*Dialect{
+	Trino.Name:      Trino,
+	MySQL.Name:      MySQL,
+	Postgres.Name:   Postgres,
+	OpenSearch.Name: OpenSearch,
+}
+
+// GetDialect 按名称获取方言
+func GetDialect(name string) (*Dialect, error) {
+	d, ok := dialects[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dialect: %s", name)
+	}
+	return d, nil
+}
+
+// quoteIdentifier 使用方言的引号引用标识符
+func (d *Dialect) quoteIdentifier(name string) string {
+	q := string(d.identQuote)
+	return q + strings.ReplaceAll(name, q, q+q) + q
+}
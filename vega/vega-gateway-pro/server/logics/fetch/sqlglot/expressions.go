@@ -0,0 +1,339 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package sqlglot
+
+// Expression 语法树节点
+type Expression interface {
+	// children 返回直接子节点，用于广度优先遍历
+	children() []Expression
+}
+
+// Identifier 标识符，Quoted 表示原 SQL 中带引号
+type Identifier struct {
+	Name   string
+	Quoted bool
+}
+
+// TableAlias 表或子查询的别名，可带列别名
+type TableAlias struct {
+	Name    *Identifier
+	Columns []*Identifier
+}
+
+// CTE WITH 子句中的公用表表达式
+type CTE struct {
+	Alias *TableAlias
+	Query Expression
+}
+
+// Select 查询语句
+type Select struct {
+	With        []*CTE
+	Distinct    bool
+	Expressions []Expression
+	From        Expression // 逗号分隔的其余数据源作为 Comma JOIN 存放在 Joins 中
+	Joins       []*Join
+	Where       Expression
+	GroupBy     []Expression
+	Having      Expression
+	Modifiers
+}
+
+// SetOperation UNION/INTERSECT/EXCEPT
+type SetOperation struct {
+	With     []*CTE
+	Op       string
+	Distinct bool // 显式写出 DISTINCT
+	All      bool
+	Left     Expression
+	Right    Expression
+	Modifiers
+}
+
+// Modifiers 查询的排序和分页子句
+type Modifiers struct {
+	OrderBy []*Ordered
+	Limit   Expression
+	Offset  Expression
+	// Fetch 表示分页以 FETCH {FIRST|NEXT} n ROWS ONLY 写出，FetchDirection 为 FIRST 或 NEXT
+	Fetch          bool
+	FetchDirection string
+}
+
+// TableRef 表引用
+type TableRef struct {
+	Catalog *Identifier
+	Schema  *Identifier
+	Name    *Identifier
+	Alias   *TableAlias
+}
+
+// Subquery 括号中的子查询，出现在 FROM 中时可带别名
+type Subquery struct {
+	Query Expression
+	Alias *TableAlias
+}
+
+// Join 连接，Comma 表示以逗号分隔的数据源
+type Join struct {
+	Side   string // LEFT/RIGHT/FULL
+	Kind   string // INNER/OUTER/CROSS
+	Comma  bool
+	Source Expression
+	On     Expression
+	Using  []*Identifier
+}
+
+// Column 列引用，Parts 依次为限定名和列名
+type Column struct {
+	Parts []*Identifier
+}
+
+// Star 星号，可带表限定
+type Star struct {
+	Qualifier []*Identifier
+}
+
+// Literal 数值或字符串常量
+type Literal struct {
+	Value    string
+	IsString bool
+}
+
+// Keyword NULL/TRUE/FALSE 等常量关键字
+type Keyword struct {
+	Name string
+}
+
+// Placeholder 参数占位符
+type Placeholder struct{}
+
+// Alias 带别名的投影
+type Alias struct {
+	This Expression
+	Name *Identifier
+}
+
+// Binary 二元运算，Op 为规范化后的运算符（如 <>、AND、||）
+type Binary struct {
+	Op    string
+	Left  Expression
+	Right Expression
+}
+
+// Not 逻辑非
+type Not struct {
+	This Expression
+}
+
+// Neg 负号
+type Neg struct {
+	This Expression
+}
+
+// Paren 原 SQL 中的括号
+type Paren struct {
+	This Expression
+}
+
+// In IN 列表或 IN 子查询
+type In struct {
+	This   Expression
+	Values []Expression
+	Query  Expression
+}
+
+// Between BETWEEN ... AND ...
+type Between struct {
+	This Expression
+	Low  Expression
+	High Expression
+}
+
+// Like LIKE 模式匹配
+type Like struct {
+	This    Expression
+	Pattern Expression
+	Escape  Expression
+}
+
+// Is IS NULL/TRUE/FALSE
+type Is struct {
+	This  Expression
+	Right Expression
+}
+
+// Exists EXISTS 子查询
+type Exists struct {
+	Query Expression
+}
+
+// When CASE 中的 WHEN 分支
+type When struct {
+	Condition Expression
+	Result    Expression
+}
+
+// Case CASE 表达式，Operand 非空时为简单 CASE
+type Case struct {
+	Operand Expression
+	Whens   []*When
+	Else    Expression
+}
+
+// DataType 数据类型，Name 为规范化的类型名
+type DataType struct {
+	Name   string
+	Params []string
+}
+
+// Cast CAST/TRY_CAST，类型常量（如 DATE '2020-01-01'）也解析为 Cast
+type Cast struct {
+	This Expression
+	To   *DataType
+	Try  bool
+}
+
+// Func 函数调用，Known 表示为已知函数，名称已规范化为大写
+type Func struct {
+	Name     string
+	Known    bool
+	Args     []Expression
+	Distinct bool
+	Over     *Window
+	NoParens bool // 以关键字形式出现的函数，如 CURRENT_DATE
+}
+
+// Window 窗口定义
+type Window struct {
+	PartitionBy []Expression
+	OrderBy     []*Ordered
+	Frame       *WindowFrame
+}
+
+// WindowFrame 窗口框架，Kind 为 ROWS 或 RANGE，只写起始边界时 End 为 nil
+type WindowFrame struct {
+	Kind  string
+	Start *FrameBound
+	End   *FrameBound
+}
+
+// FrameBound 框架边界，Value 为 UNBOUNDED、CURRENT ROW 时为 nil，Side 为 PRECEDING/FOLLOWING
+type FrameBound struct {
+	Value     Expression
+	Unbounded bool
+	Side      string
+}
+
+// Ordered ORDER BY 中的排序项，Desc 为 nil 表示未写排序方向
+type Ordered struct {
+	This       Expression
+	Desc       *bool
+	NullsFirst bool
+}
+
+func (e *Identifier) children() []Expression  { return nil }
+func (e *Literal) children() []Expression     { return nil }
+func (e *Keyword) children() []Expression     { return nil }
+func (e *Placeholder) children() []Expression { return nil }
+func (e *Column) children() []Expression      { return nil }
+func (e *Star) children() []Expression        { return nil }
+func (e *TableRef) children() []Expression    { return nil }
+func (e *DataType) children() []Expression    { return nil }
+
+func (e *Select) children() []Expression {
+	var c []Expression
+	for _, cte := range e.With {
+		c = append(c, cte.Query)
+	}
+	c = append(c, e.Expressions...)
+	c = append(c, e.From)
+	for _, j := range e.Joins {
+		c = append(c, j)
+	}
+	c = append(c, e.Where)
+	c = append(c, e.GroupBy...)
+	c = append(c, e.Having)
+	return append(c, e.Modifiers.children()...)
+}
+
+func (e *SetOperation) children() []Expression {
+	var c []Expression
+	for _, cte := range e.With {
+		c = append(c, cte.Query)
+	}
+	c = append(c, e.Left, e.Right)
+	return append(c, e.Modifiers.children()...)
+}
+
+func (m *Modifiers) children() []Expression {
+	var c []Expression
+	for _, o := range m.OrderBy {
+		c = append(c, o)
+	}
+	return append(c, m.Limit, m.Offset)
+}
+
+func (e *Subquery) children() []Expression { return []Expression{e.Query} }
+func (e *Join) children() []Expression     { return []Expression{e.Source, e.On} }
+func (e *Alias) children() []Expression    { return []Expression{e.This} }
+func (e *Binary) children() []Expression   { return []Expression{e.Left, e.Right} }
+func (e *Not) children() []Expression      { return []Expression{e.This} }
+func (e *Neg) children() []Expression      { return []Expression{e.This} }
+func (e *Paren) children() []Expression    { return []Expression{e.This} }
+func (e *Between) children() []Expression  { return []Expression{e.This, e.Low, e.High} }
+func (e *Like) children() []Expression     { return []Expression{e.This, e.Pattern, e.Escape} }
+func (e *Is) children() []Expression       { return []Expression{e.This, e.Right} }
+func (e *Exists) children() []Expression   { return []Expression{e.Query} }
+func (e *Cast) children() []Expression     { return []Expression{e.This} }
+func (e *Ordered) children() []Expression  { return []Expression{e.This} }
+
+func (e *In) children() []Expression {
+	return append([]Expression{e.This, e.Query}, e.Values...)
+}
+
+func (e *Case) children() []Expression {
+	c := []Expression{e.Operand}
+	for _, w := range e.Whens {
+		c = append(c, w.Condition, w.Result)
+	}
+	return append(c, e.Else)
+}
+
+func (e *Func) children() []Expression {
+	c := append([]Expression{}, e.Args...)
+	if e.Over != nil {
+		c = append(c, e.Over.PartitionBy...)
+		for _, o := range e.Over.OrderBy {
+			c = append(c, o)
+		}
+		if f := e.Over.Frame; f != nil {
+			c = append(c, f.Start.Value)
+			if f.End != nil {
+				c = append(c, f.End.Value)
+			}
+		}
+	}
+	return c
+}
+
+// findAll 按广度优先顺序返回所有满足条件的节点
+func findAll(root Expression, match func(Expression) bool) []Expression {
+	var result []Expression
+	queue := []Expression{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == nil {
+			continue
+		}
+		if match(node) {
+			result = append(result, node)
+		}
+		queue = append(queue, node.children()...)
+	}
+	return result
+}
@@ -0,0 +1,433 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package sqlglot
+
+import (
+	"fmt"
+	"strings"
+)
+
+type generator struct {
+	dialect *Dialect
+}
+
+// generate 按目标方言将语法树生成为 SQL，输出格式与 sqlglot 的默认格式一致
+func generate(e Expression, dialect *Dialect) string {
+	g := &generator{dialect: dialect}
+	return g.sql(e)
+}
+
+func (g *generator) sql(e Expression) string {
+	switch n := e.(type) {
+	case nil:
+		return ""
+	case *Select:
+		return g.selectSQL(n)
+	case *SetOperation:
+		return g.setOperationSQL(n)
+	case *Subquery:
+		s := "(" + g.sql(n.Query) + ")"
+		if n.Alias != nil {
+			s += " AS " + g.tableAliasSQL(n.Alias)
+		}
+		return s
+	case *TableRef:
+		return g.tableSQL(n)
+	case *Identifier:
+		return g.identifierSQL(n)
+	case *Column:
+		return g.identifiersSQL(n.Parts)
+	case *Star:
+		if len(n.Qualifier) > 0 {
+			return g.identifiersSQL(n.Qualifier) + ".*"
+		}
+		return "*"
+	case *Literal:
+		if n.IsString {
+			return g.stringSQL(n.Value)
+		}
+		return n.Value
+	case *Keyword:
+		return n.Name
+	case *Placeholder:
+		return "?"
+	case *Alias:
+		return g.sql(n.This) + " AS " + g.identifierSQL(n.Name)
+	case *Binary:
+		return g.binarySQL(n)
+	case *Not:
+		return "NOT " + g.sql(n.This)
+	case *Neg:
+		return "-" + g.sql(n.This)
+	case *Paren:
+		return "(" + g.sql(n.This) + ")"
+	case *In:
+		if n.Query != nil {
+			return g.sql(n.This) + " IN (" + g.sql(n.Query) + ")"
+		}
+		return g.sql(n.This) + " IN (" + g.csv(n.Values) + ")"
+	case *Between:
+		return g.sql(n.This) + " BETWEEN " + g.sql(n.Low) + " AND " + g.sql(n.High)
+	case *Like:
+		s := g.sql(n.This) + " LIKE " + g.sql(n.Pattern)
+		if n.Escape != nil {
+			s += " ESCAPE " + g.sql(n.Escape)
+		}
+		return s
+	case *Is:
+		return g.sql(n.This) + " IS " + g.sql(n.Right)
+	case *Exists:
+		return "EXISTS(" + g.sql(n.Query) + ")"
+	case *Case:
+		return g.caseSQL(n)
+	case *Cast:
+		return "CAST(" + g.sql(n.This) + " AS " + g.dataTypeSQL(n.To) + ")"
+	case *Func:
+		return g.funcSQL(n)
+	case *Ordered:
+		return g.orderedSQL(n, true)
+	default:
+		panic(fmt.Sprintf("sqlglot: unsupported expression %T", e))
+	}
+}
+
+func (g *generator) csv(list []Expression) string {
+	parts := make([]string, 0, len(list))
+	for _, e := range list {
+		parts = append(parts, g.sql(e))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (g *generator) identifierSQL(ident *Identifier) string {
+	if ident.Quoted {
+		return g.dialect.quoteIdentifier(ident.Name)
+	}
+	return ident.Name
+}
+
+// identifiersSQL 生成以点号连接的限定名
+func (g *generator) identifiersSQL(parts []*Identifier) string {
+	names := make([]string, 0, len(parts))
+	for _, p := range parts {
+		names = append(names, g.identifierSQL(p))
+	}
+	return strings.Join(names, ".")
+}
+
+// identifierListSQL 生成逗号分隔的标识符列表，如列别名和 USING 中的列
+func (g *generator) identifierListSQL(list []*Identifier) string {
+	names := make([]string, 0, len(list))
+	for _, ident := range list {
+		names = append(names, g.identifierSQL(ident))
+	}
+	return strings.Join(names, ", ")
+}
+
+func (g *generator) stringSQL(value string) string {
+	if g.dialect.backslashEscapes {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func (g *generator) tableAliasSQL(alias *TableAlias) string {
+	s := g.identifierSQL(alias.Name)
+	if len(alias.Columns) > 0 {
+		s += "(" + g.identifierListSQL(alias.Columns) + ")"
+	}
+	return s
+}
+
+func (g *generator) tableSQL(t *TableRef) string {
+	var parts []*Identifier
+	for _, p := range []*Identifier{t.Catalog, t.Schema, t.Name} {
+		if p != nil {
+			parts = append(parts, p)
+		}
+	}
+	s := g.identifiersSQL(parts)
+	if t.Alias != nil {
+		s += " AS " + g.tableAliasSQL(t.Alias)
+	}
+	return s
+}
+
+func (g *generator) withSQL(ctes []*CTE) string {
+	if len(ctes) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(ctes))
+	for _, cte := range ctes {
+		parts = append(parts, g.tableAliasSQL(cte.Alias)+" AS ("+g.sql(cte.Query)+")")
+	}
+	return "WITH " + strings.Join(parts, ", ") + " "
+}
+
+func (g *generator) selectSQL(s *Select) string {
+	var sb strings.Builder
+	sb.WriteString(g.withSQL(s.With))
+	sb.WriteString("SELECT ")
+	if s.Distinct {
+		sb.WriteString("DISTINCT ")
+	}
+	sb.WriteString(g.csv(s.Expressions))
+
+	if s.From != nil {
+		sb.WriteString(" FROM " + g.sql(s.From))
+	}
+	for _, j := range s.Joins {
+		sb.WriteString(g.joinSQL(j))
+	}
+	if s.Where != nil {
+		sb.WriteString(" WHERE " + g.sql(s.Where))
+	}
+	if len(s.GroupBy) > 0 {
+		sb.WriteString(" GROUP BY " + g.csv(s.GroupBy))
+	}
+	if s.Having != nil {
+		sb.WriteString(" HAVING " + g.sql(s.Having))
+	}
+	sb.WriteString(g.modifiersSQL(&s.Modifiers))
+	return sb.String()
+}
+
+func (g *generator) setOperationSQL(s *SetOperation) string {
+	op := s.Op
+	if s.All {
+		op += " ALL"
+	} else if s.Distinct {
+		op += " DISTINCT"
+	}
+	return g.withSQL(s.With) + g.sql(s.Left) + " " + op + " " + g.sql(s.Right) + g.modifiersSQL(&s.Modifiers)
+}
+
+func (g *generator) joinSQL(j *Join) string {
+	source := g.sql(j.Source)
+	if j.Comma {
+		return ", " + source
+	}
+
+	var op []string
+	if j.Side != "" {
+		op = append(op, j.Side)
+	}
+	if j.Kind != "" {
+		op = append(op, j.Kind)
+	}
+	op = append(op, "JOIN")
+
+	s := " " + strings.Join(op, " ") + " " + source
+	if j.On != nil {
+		s += " ON " + g.sql(j.On)
+	} else if len(j.Using) > 0 {
+		s += " USING (" + g.identifierListSQL(j.Using) + ")"
+	}
+	return s
+}
+
+// modifiersSQL 生成排序和分页子句，LIMIT 总是写在 OFFSET 之前，FETCH 写在 OFFSET 之后
+func (g *generator) modifiersSQL(m *Modifiers) string {
+	var sb strings.Builder
+	if len(m.OrderBy) > 0 {
+		parts := make([]string, 0, len(m.OrderBy))
+		for _, o := range m.OrderBy {
+			parts = append(parts, g.orderedSQL(o, true))
+		}
+		sb.WriteString(" ORDER BY " + strings.Join(parts, ", "))
+	}
+
+	if m.Fetch && !g.dialect.fetchAsLimit {
+		if m.Offset != nil {
+			sb.WriteString(" OFFSET " + g.sql(m.Offset))
+		}
+		sb.WriteString(" FETCH " + m.FetchDirection + " " + g.sql(m.Limit) + " ROWS ONLY")
+		return sb.String()
+	}
+
+	if m.Limit != nil {
+		sb.WriteString(" LIMIT " + g.sql(m.Limit))
+	}
+	if m.Offset != nil {
+		sb.WriteString(" OFFSET " + g.sql(m.Offset))
+	}
+	return sb.String()
+}
+
+// orderedSQL 按目标方言的 NULL 排序约定决定是否显式写出 NULLS FIRST/LAST，
+// 不支持该语法的方言追加 CASE WHEN x IS NULL 排序项，emulate 为 false 时不模拟，直接忽略 NULL 排序
+func (g *generator) orderedSQL(o *Ordered, emulate bool) string {
+	this := g.sql(o.This)
+	desc := o.Desc != nil && *o.Desc
+	asc := !desc
+	nullsFirst := o.NullsFirst
+	nullsLast := !nullsFirst
+
+	large := g.dialect.nullOrdering == nullsAreLarge
+	small := g.dialect.nullOrdering == nullsAreSmall
+	last := g.dialect.nullOrdering == nullsAreLast
+
+	sortOrder := ""
+	if desc {
+		sortOrder = " DESC"
+	} else if o.Desc != nil {
+		sortOrder = " ASC"
+	}
+
+	nullsSortChange := ""
+	if nullsFirst && ((asc && large) || (desc && small) || last) {
+		nullsSortChange = " NULLS FIRST"
+	} else if nullsLast && ((asc && small) || (desc && large)) && !last {
+		nullsSortChange = " NULLS LAST"
+	}
+
+	if nullsSortChange != "" && g.dialect.nullSupport == nullOrderingEmulate {
+		// 按序号排序时无法模拟，忽略 NULL 排序
+		if emulate && !isInt(o.This) {
+			nullSortOrder := ""
+			if nullsSortChange == " NULLS FIRST" {
+				nullSortOrder = " DESC"
+			}
+			this = fmt.Sprintf("CASE WHEN %s IS NULL THEN 1 ELSE 0 END%s, %s", this, nullSortOrder, this)
+		}
+		nullsSortChange = ""
+	}
+	return this + sortOrder + nullsSortChange
+}
+
+// isInt 判断是否为整数常量，如 ORDER BY 1 中的列序号
+func isInt(e Expression) bool {
+	literal, ok := e.(*Literal)
+	return ok && !literal.IsString && !strings.ContainsAny(literal.Value, ".eE")
+}
+
+func (g *generator) binarySQL(b *Binary) string {
+	if b.Op == "||" && g.dialect.concatAsFunc {
+		return "CONCAT(" + g.csv(flattenConcat(b)) + ")"
+	}
+	return g.sql(b.Left) + " " + b.Op + " " + g.sql(b.Right)
+}
+
+// flattenConcat 将连续的 || 展开为 CONCAT 的参数列表
+func flattenConcat(e Expression) []Expression {
+	if b, ok := e.(*Binary); ok && b.Op == "||" {
+		return append(flattenConcat(b.Left), flattenConcat(b.Right)...)
+	}
+	return []Expression{e}
+}
+
+func (g *generator) caseSQL(c *Case) string {
+	var sb strings.Builder
+	sb.WriteString("CASE")
+	if c.Operand != nil {
+		sb.WriteString(" " + g.sql(c.Operand))
+	}
+	for _, w := range c.Whens {
+		sb.WriteString(" WHEN " + g.sql(w.Condition) + " THEN " + g.sql(w.Result))
+	}
+	if c.Else != nil {
+		sb.WriteString(" ELSE " + g.sql(c.Else))
+	}
+	sb.WriteString(" END")
+	return sb.String()
+}
+
+func (g *generator) dataTypeSQL(t *DataType) string {
+	name := t.Name
+	if mapped, ok := g.dialect.castTypes[name]; ok {
+		name = mapped
+	}
+	if len(t.Params) == 0 || g.dialect.dropTypeParams[name] {
+		return name
+	}
+	return name + "(" + strings.Join(t.Params, ", ") + ")"
+}
+
+func (g *generator) funcSQL(f *Func) string {
+	if !f.Known {
+		return g.callSQL(f.Name, f)
+	}
+
+	switch f.Name {
+	case "CURRENT_TIMESTAMP":
+		if g.dialect.currentTimestampParens {
+			return "CURRENT_TIMESTAMP()"
+		}
+		return "CURRENT_TIMESTAMP"
+	case "CURRENT_DATE":
+		return "CURRENT_DATE"
+	case "LENGTH":
+		return g.callSQL(g.dialect.lengthFunc, f)
+	case "IF":
+		if g.dialect.ifAsCase && len(f.Args) >= 2 {
+			c := &Case{Whens: []*When{{Condition: f.Args[0], Result: f.Args[1]}}}
+			if len(f.Args) > 2 {
+				c.Else = f.Args[2]
+			}
+			return g.caseSQL(c)
+		}
+	case "SUBSTRING":
+		if g.dialect.substringFromFor && len(f.Args) >= 2 {
+			s := "SUBSTRING(" + g.sql(f.Args[0]) + " FROM " + g.sql(f.Args[1])
+			if len(f.Args) > 2 {
+				s += " FOR " + g.sql(f.Args[2])
+			}
+			return s + ")"
+		}
+	}
+	return g.callSQL(f.Name, f)
+}
+
+func (g *generator) callSQL(name string, f *Func) string {
+	if f.NoParens {
+		return name
+	}
+	s := name + "("
+	if f.Distinct {
+		s += "DISTINCT "
+	}
+	s += g.csv(f.Args) + ")"
+
+	if f.Over != nil {
+		var spec []string
+		if len(f.Over.PartitionBy) > 0 {
+			spec = append(spec, "PARTITION BY "+g.csv(f.Over.PartitionBy))
+		}
+		if len(f.Over.OrderBy) > 0 {
+			parts := make([]string, 0, len(f.Over.OrderBy))
+			// 与 sqlglot 一致，带框架的窗口中追加排序项会改变 RANGE 偏移的含义，不模拟 NULL 排序
+			for _, o := range f.Over.OrderBy {
+				parts = append(parts, g.orderedSQL(o, f.Over.Frame == nil))
+			}
+			spec = append(spec, "ORDER BY "+strings.Join(parts, ", "))
+		}
+		if f.Over.Frame != nil {
+			spec = append(spec, g.windowFrameSQL(f.Over.Frame))
+		}
+		s += " OVER (" + strings.Join(spec, " ") + ")"
+	}
+	return s
+}
+
+// windowFrameSQL 与 sqlglot 一致总是以 BETWEEN 形式写出，省略的结束边界为 CURRENT ROW
+func (g *generator) windowFrameSQL(f *WindowFrame) string {
+	end := "CURRENT ROW"
+	if f.End != nil {
+		end = g.frameBoundSQL(f.End)
+	}
+	return f.Kind + " BETWEEN " + g.frameBoundSQL(f.Start) + " AND " + end
+}
+
+func (g *generator) frameBoundSQL(b *FrameBound) string {
+	switch {
+	case b.Unbounded:
+		return "UNBOUNDED " + b.Side
+	case b.Value != nil:
+		return g.sql(b.Value) + " " + b.Side
+	default:
+		return "CURRENT ROW"
+	}
+}
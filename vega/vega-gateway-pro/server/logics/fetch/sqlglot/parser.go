@@ -0,0 +1,1116 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package sqlglot
+
+import (
+	"fmt"
+)
+
+// 不能作为隐式别名的关键字
+var reservedKeywords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "GROUP": true, "BY": true, "HAVING": true,
+	"ORDER": true, "LIMIT": true, "OFFSET": true, "FETCH": true, "UNION": true, "INTERSECT": true,
+	"EXCEPT": true, "ALL": true, "DISTINCT": true, "AS": true, "ON": true, "USING": true,
+	"JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true, "FULL": true, "OUTER": true,
+	"CROSS": true, "AND": true, "OR": true, "NOT": true, "IN": true, "IS": true, "NULL": true,
+	"LIKE": true, "BETWEEN": true, "EXISTS": true, "CASE": true, "WHEN": true, "THEN": true,
+	"ELSE": true, "END": true, "WITH": true, "ASC": true, "DESC": true, "NULLS": true,
+	"OVER": true, "PARTITION": true, "ESCAPE": true, "TRUE": true, "FALSE": true,
+}
+
+// 数据类型名到规范类型名的映射
+var typeAliases = map[string]string{
+	"INTEGER":   "INT",
+	"INT":       "INT",
+	"BIGINT":    "BIGINT",
+	"SMALLINT":  "SMALLINT",
+	"TINYINT":   "TINYINT",
+	"DOUBLE":    "DOUBLE",
+	"REAL":      "REAL",
+	"FLOAT":     "FLOAT",
+	"DECIMAL":   "DECIMAL",
+	"NUMERIC":   "DECIMAL",
+	"VARCHAR":   "VARCHAR",
+	"CHAR":      "CHAR",
+	"TEXT":      "TEXT",
+	"BOOLEAN":   "BOOLEAN",
+	"DATE":      "DATE",
+	"TIME":      "TIME",
+	"TIMESTAMP": "TIMESTAMP",
+	"VARBINARY": "VARBINARY",
+	"JSON":      "JSON",
+}
+
+type parser struct {
+	dialect *Dialect
+	tokens  []token
+	pos     int
+}
+
+// parse 将单条 SQL 解析为语法树，末尾的分号会被忽略
+func parse(sql string, dialect *Dialect) (Expression, error) {
+	tokens, err := tokenize(sql, dialect.identQuote)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{dialect: dialect, tokens: tokens}
+	stmt, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	for p.matchOp(";") {
+	}
+	if p.cur().typ != tokenEOF {
+		return nil, p.unexpected()
+	}
+	return stmt, nil
+}
+
+func (p *parser) cur() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peek(offset int) token {
+	if p.pos+offset < len(p.tokens) {
+		return p.tokens[p.pos+offset]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isKeyword(kw string) bool {
+	return p.cur().upper() == kw
+}
+
+func (p *parser) matchKeyword(kws ...string) bool {
+	for i, kw := range kws {
+		if p.peek(i).upper() != kw {
+			return false
+		}
+	}
+	p.pos += len(kws)
+	return true
+}
+
+func (p *parser) expectKeyword(kw string) error {
+	if !p.matchKeyword(kw) {
+		return fmt.Errorf("expected %s but got %s at line %d, col %d", kw, p.cur(), p.cur().line, p.cur().col)
+	}
+	return nil
+}
+
+func (p *parser) isOp(op string) bool {
+	t := p.cur()
+	return t.typ == tokenOperator && t.text == op
+}
+
+func (p *parser) matchOp(op string) bool {
+	if p.isOp(op) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectOp(op string) error {
+	if !p.matchOp(op) {
+		return fmt.Errorf("expected '%s' but got %s at line %d, col %d", op, p.cur(), p.cur().line, p.cur().col)
+	}
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	t := p.cur()
+	return fmt.Errorf("unexpected token %s at line %d, col %d", t, t.line, t.col)
+}
+
+// parseQuery 解析可带 WITH 的查询及集合运算
+func (p *parser) parseQuery() (Expression, error) {
+	var ctes []*CTE
+	if p.matchKeyword("WITH") {
+		for {
+			alias, err := p.parseTableAliasDef()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectKeyword("AS"); err != nil {
+				return nil, err
+			}
+			if err := p.expectOp("("); err != nil {
+				return nil, err
+			}
+			query, err := p.parseQuery()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			ctes = append(ctes, &CTE{Alias: alias, Query: query})
+			if !p.matchOp(",") {
+				break
+			}
+		}
+	}
+
+	query, err := p.parseSetOperation()
+	if err != nil {
+		return nil, err
+	}
+	if len(ctes) > 0 {
+		switch q := query.(type) {
+		case *Select:
+			q.With = ctes
+		case *SetOperation:
+			q.With = ctes
+		}
+	}
+	return query, nil
+}
+
+// parseSetOperation 解析左结合的集合运算，ORDER BY 和分页子句作用于整个集合运算
+func (p *parser) parseSetOperation() (Expression, error) {
+	left, err := p.parseQueryTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.cur().upper()
+		if op != "UNION" && op != "INTERSECT" && op != "EXCEPT" {
+			break
+		}
+		p.next()
+		setOp := &SetOperation{Op: op, Left: left}
+		if p.matchKeyword("ALL") {
+			setOp.All = true
+		} else if p.matchKeyword("DISTINCT") {
+			setOp.Distinct = true
+		}
+		right, err := p.parseQueryTerm()
+		if err != nil {
+			return nil, err
+		}
+		setOp.Right = right
+		left = setOp
+	}
+
+	// 集合运算的排序和分页子句在最后一个查询中解析，这里将其上移
+	if setOp, ok := left.(*SetOperation); ok {
+		if last, ok := setOp.Right.(*Select); ok {
+			setOp.Modifiers, last.Modifiers = last.Modifiers, Modifiers{}
+		}
+	}
+	return left, nil
+}
+
+// parseQueryTerm 解析 SELECT 或括号中的查询
+func (p *parser) parseQueryTerm() (Expression, error) {
+	if p.isOp("(") {
+		p.next()
+		query, err := p.parseQuery()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+		return &Subquery{Query: query}, nil
+	}
+	return p.parseSelect()
+}
+
+func (p *parser) parseSelect() (*Select, error) {
+	if err := p.expectKeyword("SELECT"); err != nil {
+		return nil, err
+	}
+
+	sel := &Select{}
+	if p.matchKeyword("DISTINCT") {
+		sel.Distinct = true
+	} else {
+		p.matchKeyword("ALL")
+	}
+
+	for {
+		expr, err := p.parseProjection()
+		if err != nil {
+			return nil, err
+		}
+		sel.Expressions = append(sel.Expressions, expr)
+		if !p.matchOp(",") {
+			break
+		}
+	}
+
+	if p.matchKeyword("FROM") {
+		source, err := p.parseTableSource()
+		if err != nil {
+			return nil, err
+		}
+		sel.From = source
+		if err := p.parseJoins(sel); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.matchKeyword("WHERE") {
+		where, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		sel.Where = where
+	}
+
+	if p.matchKeyword("GROUP", "BY") {
+		for {
+			expr, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			sel.GroupBy = append(sel.GroupBy, expr)
+			if !p.matchOp(",") {
+				break
+			}
+		}
+	}
+
+	if p.matchKeyword("HAVING") {
+		having, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		sel.Having = having
+	}
+
+	if err := p.parseModifiers(&sel.Modifiers); err != nil {
+		return nil, err
+	}
+	return sel, nil
+}
+
+// parseModifiers 解析 ORDER BY、LIMIT、OFFSET 和 FETCH，分页子句顺序不限
+func (p *parser) parseModifiers(m *Modifiers) error {
+	if p.matchKeyword("ORDER", "BY") {
+		ordered, err := p.parseOrderedList()
+		if err != nil {
+			return err
+		}
+		m.OrderBy = ordered
+	}
+
+	for {
+		switch {
+		case m.Limit == nil && p.matchKeyword("LIMIT"):
+			limit, err := p.parsePrimary()
+			if err != nil {
+				return err
+			}
+			m.Limit = limit
+		case m.Offset == nil && p.matchKeyword("OFFSET"):
+			offset, err := p.parsePrimary()
+			if err != nil {
+				return err
+			}
+			m.Offset = offset
+			if !p.matchKeyword("ROWS") {
+				p.matchKeyword("ROW")
+			}
+		case m.Limit == nil && p.matchKeyword("FETCH"):
+			direction := p.cur().upper()
+			if direction != "FIRST" && direction != "NEXT" {
+				return p.unexpected()
+			}
+			p.next()
+			count, err := p.parsePrimary()
+			if err != nil {
+				return err
+			}
+			if !p.matchKeyword("ROWS") && !p.matchKeyword("ROW") {
+				return p.unexpected()
+			}
+			if err := p.expectKeyword("ONLY"); err != nil {
+				return err
+			}
+			m.Limit, m.Fetch, m.FetchDirection = count, true, direction
+		default:
+			return nil
+		}
+	}
+}
+
+func (p *parser) parseOrderedList() ([]*Ordered, error) {
+	var list []*Ordered
+	for {
+		expr, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		ordered := &Ordered{This: expr}
+		if p.matchKeyword("DESC") {
+			desc := true
+			ordered.Desc = &desc
+		} else if p.matchKeyword("ASC") {
+			desc := false
+			ordered.Desc = &desc
+		}
+
+		explicit := false
+		if p.matchKeyword("NULLS", "FIRST") {
+			ordered.NullsFirst, explicit = true, true
+		} else if p.matchKeyword("NULLS", "LAST") {
+			explicit = true
+		}
+		// 未显式指定时按读取方言的默认约定补齐，与 sqlglot 一致
+		if !explicit && p.dialect.nullOrdering != nullsAreLast {
+			desc := ordered.Desc != nil && *ordered.Desc
+			if (!desc && p.dialect.nullOrdering == nullsAreSmall) || (desc && p.dialect.nullOrdering != nullsAreSmall) {
+				ordered.NullsFirst = true
+			}
+		}
+
+		list = append(list, ordered)
+		if !p.matchOp(",") {
+			return list, nil
+		}
+	}
+}
+
+func (p *parser) parseProjection() (Expression, error) {
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	alias, err := p.parseAlias()
+	if err != nil {
+		return nil, err
+	}
+	if alias != nil {
+		return &Alias{This: expr, Name: alias}, nil
+	}
+	return expr, nil
+}
+
+// parseAlias 解析可选的 [AS] alias
+func (p *parser) parseAlias() (*Identifier, error) {
+	if p.matchKeyword("AS") {
+		ident, ok := p.parseIdentifier()
+		if !ok {
+			return nil, p.unexpected()
+		}
+		return ident, nil
+	}
+	t := p.cur()
+	if t.typ == tokenQuotedIdent || (t.typ == tokenIdent && !reservedKeywords[t.upper()]) {
+		ident, _ := p.parseIdentifier()
+		return ident, nil
+	}
+	return nil, nil
+}
+
+func (p *parser) parseIdentifier() (*Identifier, bool) {
+	t := p.cur()
+	switch t.typ {
+	case tokenIdent:
+		p.next()
+		return &Identifier{Name: t.text}, true
+	case tokenQuotedIdent:
+		p.next()
+		return &Identifier{Name: t.text, Quoted: true}, true
+	default:
+		return nil, false
+	}
+}
+
+// parseTableAliasDef 解析 CTE 名称或表别名及可选的列别名列表
+func (p *parser) parseTableAliasDef() (*TableAlias, error) {
+	name, ok := p.parseIdentifier()
+	if !ok {
+		return nil, p.unexpected()
+	}
+	alias := &TableAlias{Name: name}
+	if p.isOp("(") && (p.peek(1).typ == tokenIdent || p.peek(1).typ == tokenQuotedIdent) {
+		p.next()
+		for {
+			col, ok := p.parseIdentifier()
+			if !ok {
+				return nil, p.unexpected()
+			}
+			alias.Columns = append(alias.Columns, col)
+			if !p.matchOp(",") {
+				break
+			}
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+	}
+	return alias, nil
+}
+
+func (p *parser) parseTableAlias() (*TableAlias, error) {
+	hasAs := p.matchKeyword("AS")
+	t := p.cur()
+	if !hasAs && !(t.typ == tokenQuotedIdent || (t.typ == tokenIdent && !reservedKeywords[t.upper()])) {
+		return nil, nil
+	}
+	return p.parseTableAliasDef()
+}
+
+// parseTableSource 解析表名或带别名的子查询
+func (p *parser) parseTableSource() (Expression, error) {
+	if p.isOp("(") {
+		p.next()
+		if !p.isKeyword("SELECT") && !p.isKeyword("WITH") && !p.isOp("(") {
+			return nil, p.unexpected()
+		}
+		query, err := p.parseQuery()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+		alias, err := p.parseTableAlias()
+		if err != nil {
+			return nil, err
+		}
+		return &Subquery{Query: query, Alias: alias}, nil
+	}
+
+	var parts []*Identifier
+	for {
+		ident, ok := p.parseIdentifier()
+		if !ok {
+			return nil, p.unexpected()
+		}
+		parts = append(parts, ident)
+		if !p.matchOp(".") {
+			break
+		}
+	}
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("table name has too many parts: %d", len(parts))
+	}
+
+	table := &TableRef{Name: parts[len(parts)-1]}
+	if len(parts) >= 2 {
+		table.Schema = parts[len(parts)-2]
+	}
+	if len(parts) == 3 {
+		table.Catalog = parts[0]
+	}
+
+	alias, err := p.parseTableAlias()
+	if err != nil {
+		return nil, err
+	}
+	table.Alias = alias
+	return table, nil
+}
+
+func (p *parser) parseJoins(sel *Select) error {
+	for {
+		join := &Join{}
+		switch {
+		case p.matchOp(","):
+			join.Comma = true
+		case p.matchKeyword("JOIN"):
+		case p.matchKeyword("INNER", "JOIN"):
+			join.Kind = "INNER"
+		case p.matchKeyword("CROSS", "JOIN"):
+			join.Kind = "CROSS"
+		case p.isKeyword("LEFT") || p.isKeyword("RIGHT") || p.isKeyword("FULL"):
+			join.Side = p.next().upper()
+			if p.matchKeyword("OUTER") {
+				join.Kind = "OUTER"
+			}
+			if err := p.expectKeyword("JOIN"); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+
+		source, err := p.parseTableSource()
+		if err != nil {
+			return err
+		}
+		join.Source = source
+
+		if !join.Comma && join.Kind != "CROSS" {
+			if p.matchKeyword("ON") {
+				on, err := p.parseExpression()
+				if err != nil {
+					return err
+				}
+				join.On = on
+			} else if p.matchKeyword("USING") {
+				if err := p.expectOp("("); err != nil {
+					return err
+				}
+				for {
+					col, ok := p.parseIdentifier()
+					if !ok {
+						return p.unexpected()
+					}
+					join.Using = append(join.Using, col)
+					if !p.matchOp(",") {
+						break
+					}
+				}
+				if err := p.expectOp(")"); err != nil {
+					return err
+				}
+			}
+		}
+		sel.Joins = append(sel.Joins, join)
+	}
+}
+
+// 以下按 sqlglot 的优先级自低向高解析表达式：
+// OR < AND < 相等 < 比较 < 范围(IN/LIKE/BETWEEN/IS) < || < 加减 < 乘除 < 一元
+
+func (p *parser) parseExpression() (Expression, error) {
+	return p.parseBinaryLevel(0)
+}
+
+var binaryLevels = [][]string{
+	{"OR"},
+	{"AND"},
+	{"=", "<>", "!="},
+	{"<", "<=", ">", ">="},
+	nil, // 范围运算单独处理
+	{"||"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// rangeLevel 范围运算在 binaryLevels 中的层级
+const rangeLevel = 4
+
+func (p *parser) parseBinaryLevel(level int) (Expression, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+	if binaryLevels[level] == nil {
+		return p.parseRange(level)
+	}
+
+	left, err := p.parseBinaryLevel(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.matchBinaryOp(binaryLevels[level])
+		if op == "" {
+			return left, nil
+		}
+		right, err := p.parseBinaryLevel(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		if op == "!=" {
+			op = "<>"
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+}
+
+func (p *parser) matchBinaryOp(ops []string) string {
+	t := p.cur()
+	for _, op := range ops {
+		if (t.typ == tokenOperator && t.text == op) || (t.typ == tokenIdent && t.upper() == op) {
+			p.next()
+			return op
+		}
+	}
+	return ""
+}
+
+// parseRange 解析 [NOT] IN/LIKE/BETWEEN 和 IS [NOT]，否定形式生成为 Not 节点
+func (p *parser) parseRange(level int) (Expression, error) {
+	this, err := p.parseBinaryLevel(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		negate := false
+		if p.isKeyword("NOT") && (p.peek(1).upper() == "IN" || p.peek(1).upper() == "LIKE" || p.peek(1).upper() == "BETWEEN") {
+			p.next()
+			negate = true
+		}
+
+		var expr Expression
+		switch {
+		case p.matchKeyword("IN"):
+			in := &In{This: this}
+			if err := p.expectOp("("); err != nil {
+				return nil, err
+			}
+			if p.isKeyword("SELECT") || p.isKeyword("WITH") {
+				query, err := p.parseQuery()
+				if err != nil {
+					return nil, err
+				}
+				in.Query = query
+			} else {
+				for {
+					v, err := p.parseExpression()
+					if err != nil {
+						return nil, err
+					}
+					in.Values = append(in.Values, v)
+					if !p.matchOp(",") {
+						break
+					}
+				}
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			expr = in
+		case p.matchKeyword("LIKE"):
+			pattern, err := p.parseBinaryLevel(level + 1)
+			if err != nil {
+				return nil, err
+			}
+			like := &Like{This: this, Pattern: pattern}
+			if p.matchKeyword("ESCAPE") {
+				escape, err := p.parsePrimary()
+				if err != nil {
+					return nil, err
+				}
+				like.Escape = escape
+			}
+			expr = like
+		case p.matchKeyword("BETWEEN"):
+			low, err := p.parseBinaryLevel(level + 1)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectKeyword("AND"); err != nil {
+				return nil, err
+			}
+			high, err := p.parseBinaryLevel(level + 1)
+			if err != nil {
+				return nil, err
+			}
+			expr = &Between{This: this, Low: low, High: high}
+		case p.matchKeyword("IS"):
+			if p.matchKeyword("NOT") {
+				negate = true
+			}
+			right := p.cur().upper()
+			if right != "NULL" && right != "TRUE" && right != "FALSE" {
+				return nil, p.unexpected()
+			}
+			p.next()
+			expr = &Is{This: this, Right: &Keyword{Name: right}}
+		default:
+			return this, nil
+		}
+
+		if negate {
+			expr = &Not{This: expr}
+		}
+		this = expr
+	}
+}
+
+func (p *parser) parseUnary() (Expression, error) {
+	switch {
+	case p.matchKeyword("NOT"):
+		// 与 sqlglot 一致，NOT 的操作数为相等运算级别
+		this, err := p.parseBinaryLevel(2)
+		if err != nil {
+			return nil, err
+		}
+		return &Not{This: this}, nil
+	case p.matchOp("-"):
+		this, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Neg{This: this}, nil
+	case p.matchOp("+"):
+		return p.parseUnary()
+	default:
+		return p.parsePrimary()
+	}
+}
+
+func (p *parser) parsePrimary() (Expression, error) {
+	t := p.cur()
+	switch t.typ {
+	case tokenNumber:
+		p.next()
+		return &Literal{Value: t.text}, nil
+	case tokenString:
+		p.next()
+		return &Literal{Value: t.text, IsString: true}, nil
+	case tokenParam:
+		p.next()
+		return &Placeholder{}, nil
+	case tokenQuotedIdent:
+		return p.parseColumn()
+	case tokenOperator:
+		if t.text == "*" {
+			p.next()
+			return &Star{}, nil
+		}
+		if t.text == "(" {
+			p.next()
+			if p.isKeyword("SELECT") || p.isKeyword("WITH") {
+				query, err := p.parseQuery()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expectOp(")"); err != nil {
+					return nil, err
+				}
+				return &Subquery{Query: query}, nil
+			}
+			inner, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return &Paren{This: inner}, nil
+		}
+		return nil, p.unexpected()
+	case tokenIdent:
+		return p.parseIdentExpression()
+	default:
+		return nil, p.unexpected()
+	}
+}
+
+// parseIdentExpression 解析以标识符开头的表达式：关键字常量、CASE、CAST、类型常量、函数调用或列
+func (p *parser) parseIdentExpression() (Expression, error) {
+	t := p.cur()
+	upper := t.upper()
+
+	switch upper {
+	case "NULL", "TRUE", "FALSE":
+		p.next()
+		return &Keyword{Name: upper}, nil
+	case "CASE":
+		return p.parseCase()
+	case "CAST", "TRY_CAST":
+		if p.peek(1).text == "(" {
+			return p.parseCast()
+		}
+	case "EXISTS":
+		if p.peek(1).text == "(" {
+			p.next()
+			p.next()
+			query, err := p.parseQuery()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return &Exists{Query: query}, nil
+		}
+	case "CURRENT_DATE", "CURRENT_TIMESTAMP":
+		if p.peek(1).text != "(" {
+			p.next()
+			return &Func{Name: upper, Known: true, NoParens: true}, nil
+		}
+	case "DATE", "TIMESTAMP", "TIME":
+		// 类型常量 DATE '2020-01-01'
+		if p.peek(1).typ == tokenString {
+			p.next()
+			value := p.next()
+			return &Cast{This: &Literal{Value: value.text, IsString: true}, To: &DataType{Name: upper}}, nil
+		}
+	}
+
+	if p.peek(1).typ == tokenOperator && p.peek(1).text == "(" {
+		return p.parseFunction()
+	}
+	if reservedKeywords[upper] {
+		return nil, p.unexpected()
+	}
+	return p.parseColumn()
+}
+
+// parseColumn 解析 a.b.c 形式的列引用或 t.* 形式的星号
+func (p *parser) parseColumn() (Expression, error) {
+	var parts []*Identifier
+	for {
+		ident, ok := p.parseIdentifier()
+		if !ok {
+			return nil, p.unexpected()
+		}
+		parts = append(parts, ident)
+		if !p.isOp(".") {
+			break
+		}
+		p.next()
+		if p.matchOp("*") {
+			return &Star{Qualifier: parts}, nil
+		}
+	}
+	return &Column{Parts: parts}, nil
+}
+
+func (p *parser) parseCase() (Expression, error) {
+	p.next()
+	c := &Case{}
+	if !p.isKeyword("WHEN") {
+		operand, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		c.Operand = operand
+	}
+	for p.matchKeyword("WHEN") {
+		cond, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("THEN"); err != nil {
+			return nil, err
+		}
+		result, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		c.Whens = append(c.Whens, &When{Condition: cond, Result: result})
+	}
+	if len(c.Whens) == 0 {
+		return nil, p.unexpected()
+	}
+	if p.matchKeyword("ELSE") {
+		e, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		c.Else = e
+	}
+	if err := p.expectKeyword("END"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (p *parser) parseCast() (Expression, error) {
+	try := p.next().upper() == "TRY_CAST"
+	p.next()
+	this, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("AS"); err != nil {
+		return nil, err
+	}
+	dataType, err := p.parseDataType()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectOp(")"); err != nil {
+		return nil, err
+	}
+	return &Cast{This: this, To: dataType, Try: try}, nil
+}
+
+func (p *parser) parseDataType() (*DataType, error) {
+	t := p.cur()
+	if t.typ != tokenIdent {
+		return nil, p.unexpected()
+	}
+	p.next()
+
+	name := t.upper()
+	if name == "DOUBLE" {
+		p.matchKeyword("PRECISION")
+	}
+	if canonical, ok := typeAliases[name]; ok {
+		name = canonical
+	}
+
+	dataType := &DataType{Name: name}
+	if p.matchOp("(") {
+		for {
+			param := p.next()
+			if param.typ != tokenNumber {
+				return nil, fmt.Errorf("invalid type parameter %s at line %d, col %d", param, param.line, param.col)
+			}
+			dataType.Params = append(dataType.Params, param.text)
+			if !p.matchOp(",") {
+				break
+			}
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+	}
+	if name == "TIMESTAMP" && p.isKeyword("WITH") {
+		return nil, fmt.Errorf("unsupported data type: TIMESTAMP WITH TIME ZONE")
+	}
+	return dataType, nil
+}
+
+// 已知函数名到规范名称的映射
+var knownFunctions = map[string]string{
+	"COUNT": "COUNT", "SUM": "SUM", "AVG": "AVG", "MIN": "MIN", "MAX": "MAX",
+	"ABS": "ABS", "CEIL": "CEIL", "CEILING": "CEIL", "FLOOR": "FLOOR", "ROUND": "ROUND",
+	"SQRT": "SQRT", "POWER": "POWER", "POW": "POWER", "EXP": "EXP", "LN": "LN",
+	"LOWER": "LOWER", "UPPER": "UPPER", "TRIM": "TRIM", "LENGTH": "LENGTH",
+	"SUBSTR": "SUBSTRING", "SUBSTRING": "SUBSTRING", "CONCAT": "CONCAT",
+	"COALESCE": "COALESCE", "NULLIF": "NULLIF", "GREATEST": "GREATEST", "LEAST": "LEAST",
+	"IF": "IF", "MOD": "MOD",
+	"NOW": "CURRENT_TIMESTAMP", "CURRENT_TIMESTAMP": "CURRENT_TIMESTAMP", "CURRENT_DATE": "CURRENT_DATE",
+	"ROW_NUMBER": "ROW_NUMBER", "RANK": "RANK", "DENSE_RANK": "DENSE_RANK",
+	"LAG": "LAG", "LEAD": "LEAD", "FIRST_VALUE": "FIRST_VALUE", "LAST_VALUE": "LAST_VALUE",
+}
+
+func (p *parser) parseFunction() (Expression, error) {
+	nameToken := p.next()
+	p.next()
+
+	fn := &Func{Name: nameToken.text}
+	if canonical, ok := knownFunctions[nameToken.upper()]; ok {
+		fn.Name, fn.Known = canonical, true
+	}
+
+	if !p.isOp(")") {
+		if p.matchKeyword("DISTINCT") {
+			fn.Distinct = true
+		}
+		for {
+			arg, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			fn.Args = append(fn.Args, arg)
+			if !p.matchOp(",") {
+				break
+			}
+		}
+	}
+	if err := p.expectOp(")"); err != nil {
+		return nil, err
+	}
+
+	if p.matchKeyword("OVER") {
+		window, err := p.parseWindow()
+		if err != nil {
+			return nil, err
+		}
+		fn.Over = window
+	}
+
+	// MOD(a, b) 与 sqlglot 一致解析为取模运算，复合操作数加括号
+	if fn.Known && fn.Name == "MOD" && len(fn.Args) == 2 && fn.Over == nil {
+		wrap := func(e Expression) Expression {
+			if _, ok := e.(*Binary); ok {
+				return &Paren{This: e}
+			}
+			return e
+		}
+		return &Binary{Op: "%", Left: wrap(fn.Args[0]), Right: wrap(fn.Args[1])}, nil
+	}
+	return fn, nil
+}
+
+func (p *parser) parseWindow() (*Window, error) {
+	if err := p.expectOp("("); err != nil {
+		return nil, err
+	}
+	window := &Window{}
+	if p.matchKeyword("PARTITION", "BY") {
+		for {
+			expr, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			window.PartitionBy = append(window.PartitionBy, expr)
+			if !p.matchOp(",") {
+				break
+			}
+		}
+	}
+	if p.matchKeyword("ORDER", "BY") {
+		ordered, err := p.parseOrderedList()
+		if err != nil {
+			return nil, err
+		}
+		window.OrderBy = ordered
+	}
+	if kw := p.cur().upper(); kw == "ROWS" || kw == "RANGE" {
+		p.next()
+		frame, err := p.parseWindowFrame(kw)
+		if err != nil {
+			return nil, err
+		}
+		window.Frame = frame
+	}
+	if err := p.expectOp(")"); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+// parseWindowFrame 解析 ROWS/RANGE 之后的框架边界，支持 BETWEEN ... AND ... 与单边界写法
+func (p *parser) parseWindowFrame(kind string) (*WindowFrame, error) {
+	frame := &WindowFrame{Kind: kind}
+	between := p.matchKeyword("BETWEEN")
+	start, err := p.parseFrameBound()
+	if err != nil {
+		return nil, err
+	}
+	frame.Start = start
+	if between {
+		if err := p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		end, err := p.parseFrameBound()
+		if err != nil {
+			return nil, err
+		}
+		frame.End = end
+	}
+	return frame, nil
+}
+
+func (p *parser) parseFrameBound() (*FrameBound, error) {
+	if p.matchKeyword("CURRENT", "ROW") {
+		return &FrameBound{}, nil
+	}
+
+	bound := &FrameBound{}
+	if p.matchKeyword("UNBOUNDED") {
+		bound.Unbounded = true
+	} else {
+		// 与 BETWEEN 的上下界相同，从范围运算的下一级开始解析，避免吞掉 AND
+		value, err := p.parseBinaryLevel(rangeLevel + 1)
+		if err != nil {
+			return nil, err
+		}
+		bound.Value = value
+	}
+	if kw := p.cur().upper(); kw == "PRECEDING" || kw == "FOLLOWING" {
+		p.next()
+		bound.Side = kw
+		return bound, nil
+	}
+	return nil, p.unexpected()
+}
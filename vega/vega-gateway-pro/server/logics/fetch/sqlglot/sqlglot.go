@@ -6,12 +6,8 @@
 package sqlglot
 
 import (
-	"bytes"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/kweaver-ai/kweaver-go-lib/logger"
-	"os/exec"
 	"strings"
 )
 
@@ -37,57 +33,41 @@ type SQLParseResult struct {
 	Error   string      `json:"error"`
 }
 
-// ExtractTables 从SQL中提取所有表名
+// ExtractTables 从SQL中提取所有表名，包括 CTE 的引用
 func ExtractTables(sql string, dialect string) (*ExtractTablesResult, error) {
-	cmd := exec.Command("python3", "-c", `
-import sys
-import json
-import sqlglot
-from sqlglot.expressions import Table
-
-try:
-    sql = sys.argv[1]
-    dialect = sys.argv[2]
-    tables = [{
-        "catalog": t.catalog,
-        "schema": t.db,
-        "name": t.name
-    } for t in sqlglot.parse_one(sql, dialect=dialect).find_all(Table)]
-    print(json.dumps({
-        "tables": tables,
-        "sql": sql,
-        "dialect": dialect,
-        "error": None
-    }))
-except Exception as e:
-    print(json.dumps({
-        "tables": [],
-        "sql": sql,
-        "dialect": dialect,
-        "error": str(e)
-    }))
-`, sql, dialect)
-
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
+	d, err := GetDialect(dialect)
 	if err != nil {
 		logger.Errorf("ExtractTables failed, %s", err.Error())
 		return nil, err
 	}
 
-	var result ExtractTablesResult
-	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+	stmt, err := parse(sql, d)
+	if err != nil {
 		logger.Errorf("ExtractTables failed, %s", err.Error())
 		return nil, err
 	}
 
-	if result.Error != "" {
-		logger.Errorf("ExtractTables failed, %s", result.Error)
-		return nil, errors.New(result.Error)
+	result := &ExtractTablesResult{
+		Tables:  []*Table{},
+		SQL:     sql,
+		Dialect: dialect,
+	}
+	for _, node := range findAll(stmt, func(e Expression) bool {
+		_, ok := e.(*TableRef)
+		return ok
+	}) {
+		ref := node.(*TableRef)
+		table := &Table{Name: ref.Name.Name}
+		if ref.Schema != nil {
+			table.Schema = ref.Schema.Name
+		}
+		if ref.Catalog != nil {
+			table.Catalog = ref.Catalog.Name
+		}
+		result.Tables = append(result.Tables, table)
 	}
 
-	return &result, nil
+	return result, nil
 }
 
 // MapDataSourceTypeToDialect 将数据源类型映射到sqlglot方言
@@ -97,6 +77,10 @@ func MapDataSourceTypeToDialect(dataSourceType string) (string, error) {
 		return "mysql", nil
 	case "maria":
 		return "mysql", nil // MariaDB使用mysql方言
+	case "postgresql":
+		return "postgres", nil
+	case "opensearch":
+		return "opensearch", nil
 	default:
 		logger.Errorf("unsupported dataSourceType: %s", dataSourceType)
 		return "", fmt.Errorf("unsupported dataSourceType: %s", dataSourceType)
@@ -113,49 +97,41 @@ func TranspileSQL(sql string, fromDialect string, dataSourceType string) (*SQLPa
 		return nil, err
 	}
 
-	cmd := exec.Command("python3", "-c", `
-import sys
-import json
-import sqlglot
-
-try:
-    sql = sys.argv[1]
-    from_dialect = sys.argv[2]
-    to_dialect = sys.argv[3]
-    transpiled = sqlglot.transpile(sql, read=from_dialect, write=to_dialect)[0]
-    print(json.dumps({
-        "ast": None,
-        "sql": transpiled,
-        "dialect": to_dialect,
-        "error": None
-    }))
-except Exception as e:
-    print(json.dumps({
-        "ast": None,
-        "sql": sql,
-        "dialect": from_dialect,
-        "error": str(e)
-    }))
-`, sql, fromDialect, toDialect)
-
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err = cmd.Run()
+	from, err := GetDialect(fromDialect)
+	if err != nil {
+		logger.Errorf("TranspileSQL failed, %s", err.Error())
+		return nil, err
+	}
+	to, err := GetDialect(toDialect)
 	if err != nil {
 		logger.Errorf("TranspileSQL failed, %s", err.Error())
 		return nil, err
 	}
 
-	var result SQLParseResult
-	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+	stmt, err := parse(sql, from)
+	if err != nil {
 		logger.Errorf("TranspileSQL failed, %s", err.Error())
 		return nil, err
 	}
 
-	if result.Error != "" {
-		logger.Errorf("TranspileSQL failed, %s", result.Error)
-		return nil, errors.New(result.Error)
+	return &SQLParseResult{
+		SQL:     generate(stmt, to),
+		Dialect: toDialect,
+	}, nil
+}
+
+// NormalizeSQL 解析后按同一方言重新生成SQL，消除空白、关键字大小写等差异，
+// 语义相同但书写不同的语句得到相同的结果
+func NormalizeSQL(sql string, dialect string) (string, error) {
+	d, err := GetDialect(dialect)
+	if err != nil {
+		return "", err
+	}
+
+	stmt, err := parse(sql, d)
+	if err != nil {
+		return "", err
 	}
 
-	return &result, nil
+	return generate(stmt, d), nil
 }
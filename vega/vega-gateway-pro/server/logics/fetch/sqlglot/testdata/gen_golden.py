@@ -0,0 +1,56 @@
+# Copyright The kweaver.ai Authors.
+#
+# Licensed under the Apache License, Version 2.0.
+# See the LICENSE file in the project root for details.
+
+"""用 sqlglot 重新生成黄金用例中的期望输出，调用方式与替换前的 sqlglot 子进程一致。
+
+只改写 mysql、postgres 的转换结果和表提取结果；sqlglot 没有 OpenSearch 方言，opensearch 字段仍手工维护。
+新增用例时先在 json 中写好 name 和 sql，再在 testdata 目录下执行:
+
+    pip3 install sqlglot && python3 gen_golden.py
+
+生成所用的 sqlglot 版本写入 sqlglot_version.txt，与 json 一起提交。
+"""
+
+import json
+import os
+
+import sqlglot
+from sqlglot.expressions import Table
+
+HERE = os.path.dirname(os.path.abspath(__file__))
+
+
+def load(name):
+    with open(os.path.join(HERE, name), encoding="utf-8") as f:
+        return json.load(f)
+
+
+def dump(name, cases):
+    with open(os.path.join(HERE, name), "w", encoding="utf-8") as f:
+        json.dump(cases, f, ensure_ascii=False, indent=2)
+        f.write("\n")
+
+
+def main():
+    transpile_cases = load("transpile.json")
+    for case in transpile_cases:
+        for dialect in ("mysql", "postgres"):
+            case[dialect] = sqlglot.transpile(case["sql"], read="trino", write=dialect)[0]
+    dump("transpile.json", transpile_cases)
+
+    tables_cases = load("tables.json")
+    for case in tables_cases:
+        case["tables"] = [
+            {"catalog": t.catalog, "schema": t.db, "name": t.name}
+            for t in sqlglot.parse_one(case["sql"], dialect="trino").find_all(Table)
+        ]
+    dump("tables.json", tables_cases)
+
+    with open(os.path.join(HERE, "sqlglot_version.txt"), "w", encoding="utf-8") as f:
+        f.write(sqlglot.__version__ + "\n")
+
+
+if __name__ == "__main__":
+    main()
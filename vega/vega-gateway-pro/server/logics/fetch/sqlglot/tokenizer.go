@@ -0,0 +1,185 @@
+// Copyright The kweaver.ai Authors.
+//
+// Licensed under the Apache License, Version 2.0.
+// See the LICENSE file in the project root for details.
+
+package sqlglot
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenParam
+)
+
+// token 词法单元，关键字以 tokenIdent 表示，由解析器按上下文识别
+type token struct {
+	typ  tokenType
+	text string // 引号标识符与字符串为去除引号和转义后的内容
+	line int
+	col  int
+}
+
+// upper 返回标识符的大写形式，用于关键字比较
+func (t token) upper() string {
+	if t.typ != tokenIdent {
+		return ""
+	}
+	return strings.ToUpper(t.text)
+}
+
+func (t token) String() string {
+	switch t.typ {
+	case tokenEOF:
+		return "end of input"
+	case tokenString:
+		return "'" + t.text + "'"
+	case tokenQuotedIdent:
+		return `"` + t.text + `"`
+	default:
+		return t.text
+	}
+}
+
+// 多字符运算符，需先于单字符匹配
+var multiCharOperators = []string{"<>", "!=", "<=", ">=", "||"}
+
+// tokenize 将 SQL 切分为词法单元，注释被丢弃
+func tokenize(sql string, identQuote rune) ([]token, error) {
+	var (
+		tokens    []token
+		runes     = []rune(sql)
+		line, col = 1, 1
+		i         int
+	)
+
+	advance := func(n int) {
+		for k := 0; k < n && i < len(runes); k++ {
+			if runes[i] == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+			i++
+		}
+	}
+	peek := func(offset int) rune {
+		if i+offset < len(runes) {
+			return runes[i+offset]
+		}
+		return 0
+	}
+
+	for i < len(runes) {
+		r := runes[i]
+		startLine, startCol := line, col
+
+		switch {
+		case unicode.IsSpace(r):
+			advance(1)
+
+		case r == '-' && peek(1) == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				advance(1)
+			}
+
+		case r == '/' && peek(1) == '*':
+			advance(2)
+			for i < len(runes) && !(runes[i] == '*' && peek(1) == '/') {
+				advance(1)
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated comment at line %d, col %d", startLine, startCol)
+			}
+			advance(2)
+
+		case r == '\'' || r == identQuote:
+			// 引号内连续两个引号表示引号本身
+			var sb strings.Builder
+			advance(1)
+			closed := false
+			for i < len(runes) {
+				if runes[i] == r {
+					if peek(1) == r {
+						sb.WriteRune(r)
+						advance(2)
+						continue
+					}
+					advance(1)
+					closed = true
+					break
+				}
+				sb.WriteRune(runes[i])
+				advance(1)
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated quote at line %d, col %d", startLine, startCol)
+			}
+			typ := tokenString
+			if r == identQuote {
+				typ = tokenQuotedIdent
+			}
+			tokens = append(tokens, token{typ: typ, text: sb.String(), line: startLine, col: startCol})
+
+		case unicode.IsDigit(r) || (r == '.' && unicode.IsDigit(peek(1))):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				advance(1)
+			}
+			// 科学计数法
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				next := peek(1)
+				if unicode.IsDigit(next) || ((next == '+' || next == '-') && unicode.IsDigit(peek(2))) {
+					advance(2)
+					for i < len(runes) && unicode.IsDigit(runes[i]) {
+						advance(1)
+					}
+				}
+			}
+			tokens = append(tokens, token{typ: tokenNumber, text: string(runes[start:i]), line: startLine, col: startCol})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '$') {
+				advance(1)
+			}
+			tokens = append(tokens, token{typ: tokenIdent, text: string(runes[start:i]), line: startLine, col: startCol})
+
+		case r == '?':
+			advance(1)
+			tokens = append(tokens, token{typ: tokenParam, text: "?", line: startLine, col: startCol})
+
+		default:
+			matched := ""
+			for _, op := range multiCharOperators {
+				if strings.HasPrefix(string(runes[i:min(i+len(op), len(runes))]), op) {
+					matched = op
+					break
+				}
+			}
+			if matched == "" {
+				if !strings.ContainsRune("=<>+-*/%(),.;", r) {
+					return nil, fmt.Errorf("unexpected character %q at line %d, col %d", r, startLine, startCol)
+				}
+				matched = string(r)
+			}
+			advance(len([]rune(matched)))
+			tokens = append(tokens, token{typ: tokenOperator, text: matched, line: startLine, col: startCol})
+		}
+	}
+
+	tokens = append(tokens, token{typ: tokenEOF, line: line, col: col})
+	return tokens, nil
+}